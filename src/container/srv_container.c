@@ -1332,6 +1332,46 @@ get_cont_prop_access_info(daos_prop_t *prop, struct d_ownership *owner,
 	*acl = acl_entry->dpe_val_ptr;
 }
 
+/*
+ * Check that the credential of the pool handle may be used with the container,
+ * whose label is in prop. The pool label is only fetched if the credential is
+ * restricted to containers it does not name by pool UUID.
+ */
+static int
+check_cont_cred_scope(struct ds_pool_hdl *pool_hdl, struct cont *cont, daos_prop_t *prop)
+{
+	struct daos_prop_entry	*cont_label_entry;
+	struct daos_prop_entry	*pool_label_entry;
+	daos_prop_t		*pool_prop = NULL;
+	const char		*cont_label = NULL;
+	int			 rc;
+
+	cont_label_entry = daos_prop_entry_get(prop, DAOS_PROP_CO_LABEL);
+	if (cont_label_entry != NULL && cont_label_entry->dpe_str != NULL &&
+	    strcmp(cont_label_entry->dpe_str, DAOS_PROP_CO_LABEL_DEFAULT) != 0)
+		cont_label = cont_label_entry->dpe_str;
+
+	rc = ds_sec_cont_check_scope(&pool_hdl->sph_cred, cont->c_svc->cs_pool_uuid, NULL,
+				     cont->c_uuid, cont_label);
+	if (rc != -DER_NO_PERM)
+		return rc;
+
+	rc = ds_pool_prop_fetch(pool_hdl->sph_pool, DAOS_PO_QUERY_PROP_LABEL, &pool_prop);
+	if (rc != 0)
+		return rc;
+
+	pool_label_entry = daos_prop_entry_get(pool_prop, DAOS_PROP_PO_LABEL);
+	if (pool_label_entry == NULL || pool_label_entry->dpe_str == NULL ||
+	    strcmp(pool_label_entry->dpe_str, DAOS_PROP_PO_LABEL_DEFAULT) == 0)
+		D_GOTO(out, rc = -DER_NO_PERM);
+
+	rc = ds_sec_cont_check_scope(&pool_hdl->sph_cred, cont->c_svc->cs_pool_uuid,
+				     pool_label_entry->dpe_str, cont->c_uuid, cont_label);
+out:
+	daos_prop_free(pool_prop);
+	return rc;
+}
+
 struct recs_buf {
 	struct cont_tgt_close_rec      *rb_recs;
 	size_t				rb_recs_size;
@@ -1559,6 +1599,13 @@ cont_destroy(struct rdb_tx *tx, struct ds_pool_hdl *pool_hdl, struct cont *cont,
 
 	get_cont_prop_access_info(prop, &owner, &acl);
 
+	rc = check_cont_cred_scope(pool_hdl, cont, prop);
+	if (rc != 0) {
+		DL_ERROR(rc, DF_CONT ": credential may not be used with the container",
+			 DP_CONT(pool_hdl->sph_pool->sp_uuid, cont->c_uuid));
+		D_GOTO(out_prop, rc);
+	}
+
 	/*
 	 * Two groups of users can delete a container:
 	 * - Users who can delete any container in the pool
@@ -2344,6 +2391,14 @@ cont_open(struct rdb_tx *tx, struct ds_pool_hdl *pool_hdl, struct cont *cont, cr
 
 	get_cont_prop_access_info(prop, &owner, &acl);
 
+	rc = check_cont_cred_scope(pool_hdl, cont, prop);
+	if (rc != 0) {
+		DL_ERROR(rc, DF_CONT ": credential may not be used with the container",
+			 DP_CONT(cont->c_svc->cs_pool_uuid, cont->c_uuid));
+		daos_prop_free(prop);
+		D_GOTO(out, rc);
+	}
+
 	rc = ds_sec_cont_get_capabilities(flags, &pool_hdl->sph_cred, &owner, acl, &sec_capas);
 	if (rc != 0) {
 		D_ERROR(DF_CONT ": refusing attempt to open with flags " DF_X64 " error: " DF_RC
@@ -146,8 +146,13 @@ func (cmd *containerUpdateACLCmd) Execute(args []string) error {
 	case cmd.Entry != "" && cmd.File != "":
 		return errors.New("only one of --entry or --acl-file may be supplied")
 	case cmd.Entry != "":
+		var entry string
+		entry, err = control.EncodeACE(cmd.Entry)
+		if err != nil {
+			return err
+		}
 		acl := &control.AccessControlList{
-			Entries: []string{cmd.Entry},
+			Entries: []string{entry},
 		}
 		cACL, cleanupACL, err = aclToC(acl)
 	case cmd.File != "":
@@ -207,7 +212,11 @@ func (cmd *containerDeleteACLCmd) Execute(args []string) error {
 	}
 	defer cleanup()
 
-	cPrincipal := C.CString(cmd.Principal)
+	principal, err := control.EncodePrincipal(cmd.Principal)
+	if err != nil {
+		return err
+	}
+	cPrincipal := C.CString(principal)
 	defer C.free(unsafe.Pointer(cPrincipal))
 	var cType C.enum_daos_acl_principal_type
 	var cName *C.char
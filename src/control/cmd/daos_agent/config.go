@@ -120,23 +120,26 @@ func (tc *TelemetryConfig) Validate() error {
 
 // Config defines the agent configuration.
 type Config struct {
-	SystemName          string                     `yaml:"name"`
-	AccessPoints        []string                   `yaml:"access_points"`
-	ControlPort         int                        `yaml:"port"`
-	RuntimeDir          string                     `yaml:"runtime_dir"`
-	LogFile             string                     `yaml:"log_file"`
-	LogLevel            common.ControlLogLevel     `yaml:"control_log_mask,omitempty"`
-	CredentialConfig    *security.CredentialConfig `yaml:"credential_config"`
-	TransportConfig     *security.TransportConfig  `yaml:"transport_config"`
-	DisableCache        bool                       `yaml:"disable_caching,omitempty"`
-	CacheExpiration     refreshMinutes             `yaml:"cache_expiration,omitempty"`
-	DisableAutoEvict    bool                       `yaml:"disable_auto_evict,omitempty"`
-	EvictOnStart        bool                       `yaml:"enable_evict_on_start,omitempty"`
-	ExcludeFabricIfaces common.StringSet           `yaml:"exclude_fabric_ifaces,omitempty"`
-	IncludeFabricIfaces common.StringSet           `yaml:"include_fabric_ifaces,omitempty"`
-	FabricInterfaces    []*NUMAFabricConfig        `yaml:"fabric_ifaces,omitempty"`
-	ProviderIdx         uint                       // TODO SRS-31: Enable with multiprovider functionality
-	Telemetry           TelemetryConfig            `yaml:",inline"`
+	SystemName          string                       `yaml:"name"`
+	AccessPoints        []string                     `yaml:"access_points"`
+	ControlPort         int                          `yaml:"port"`
+	RuntimeDir          string                       `yaml:"runtime_dir"`
+	LogFile             string                       `yaml:"log_file"`
+	LogLevel            common.ControlLogLevel       `yaml:"control_log_mask,omitempty"`
+	CredentialConfig    *security.CredentialConfig   `yaml:"credential_config"`
+	TransportConfig     *security.TransportConfig    `yaml:"transport_config"`
+	SigningKeys         []*security.SigningKeyConfig `yaml:"signing_keys,omitempty"`
+	CredentialKey       *security.SigningKeyConfig   `yaml:"credential_signing_key,omitempty"`
+	CertRenewal         *security.CertRenewalConfig  `yaml:"cert_renewal,omitempty"`
+	DisableCache        bool                         `yaml:"disable_caching,omitempty"`
+	CacheExpiration     refreshMinutes               `yaml:"cache_expiration,omitempty"`
+	DisableAutoEvict    bool                         `yaml:"disable_auto_evict,omitempty"`
+	EvictOnStart        bool                         `yaml:"enable_evict_on_start,omitempty"`
+	ExcludeFabricIfaces common.StringSet             `yaml:"exclude_fabric_ifaces,omitempty"`
+	IncludeFabricIfaces common.StringSet             `yaml:"include_fabric_ifaces,omitempty"`
+	FabricInterfaces    []*NUMAFabricConfig          `yaml:"fabric_ifaces,omitempty"`
+	ProviderIdx         uint                         // TODO SRS-31: Enable with multiprovider functionality
+	Telemetry           TelemetryConfig              `yaml:",inline"`
 }
 
 // Validate performs basic validation of the configuration.
@@ -157,6 +160,135 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if c.CredentialConfig != nil {
+		if err := c.CredentialConfig.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.TransportConfig != nil {
+		if err := c.TransportConfig.WeakKeyPolicy.Validate(); err != nil {
+			return errors.Wrap(err, "transport_config.weak_key_policy")
+		}
+	}
+
+	if err := c.validateSigningKeys(); err != nil {
+		return errors.Wrap(err, "signing_keys")
+	}
+
+	if c.CredentialKey != nil {
+		if c.TransportConfig == nil || c.TransportConfig.AllowInsecure {
+			return errors.New("credential_signing_key can't be used with allow_insecure")
+		}
+		if err := c.CredentialKey.ValidateDefault(); err != nil {
+			return errors.Wrap(err, "credential_signing_key")
+		}
+	}
+
+	if err := c.validateCertRenewal(); err != nil {
+		return errors.Wrap(err, "cert_renewal")
+	}
+
+	if err := c.validateTokenSigner(); err != nil {
+		return errors.Wrap(err, "token_signer")
+	}
+
+	// Credentials are not signed if the transport is insecure, so there is
+	// nothing to co-sign.
+	if c.CredentialConfig.CosigningConfig.IsSet() && (c.TransportConfig == nil || c.TransportConfig.AllowInsecure) {
+		return errors.New("cosigning_config can't be used with allow_insecure")
+	}
+	if c.CredentialConfig.CredentialNonces && (c.TransportConfig == nil || c.TransportConfig.AllowInsecure) {
+		return errors.New("credential_nonces can't be used with allow_insecure")
+	}
+
+	return nil
+}
+
+// validateCertRenewal checks that the agent's certificate can be renewed. The
+// renewed key replaces the key file in place, so keys held elsewhere can't be
+// renewed.
+func (c *Config) validateCertRenewal() error {
+	if c.CertRenewal == nil {
+		return nil
+	}
+	if c.TransportConfig == nil || c.TransportConfig.AllowInsecure {
+		return errors.New("certificate renewal can't be used with allow_insecure")
+	}
+	tc := c.TransportConfig
+	if tc.PrivateKeyPath == "" || tc.PKCS11Key != nil || tc.KMSKey != nil || tc.TPMKey != nil {
+		return errors.New("certificate renewal requires the transport key to be held in a key file")
+	}
+	if tc.KeyPassphrase != nil {
+		return errors.New("certificate renewal can't be used with key_passphrase, as the renewed key is written unencrypted")
+	}
+	return c.CertRenewal.Validate()
+}
+
+// validateSigningKeys checks that each additional signing key is valid, and
+// that its system is the agent's. Several keys may be selected by the same
+// system and tenant, if they use different signature algorithms, which is
+// checked once they are loaded. Credentials are not signed if the transport is
+// insecure, so neither are keys allowed.
+func (c *Config) validateSigningKeys() error {
+	if len(c.SigningKeys) > 0 && c.TransportConfig != nil && c.TransportConfig.AllowInsecure {
+		return errors.New("signing keys can't be used with allow_insecure")
+	}
+
+	for _, key := range c.SigningKeys {
+		if err := key.Validate(); err != nil {
+			return err
+		}
+		if key.System != "" && key.System != c.SystemName {
+			return errors.Errorf("signing key %s is not for the agent's system %q", key, c.SystemName)
+		}
+	}
+	return nil
+}
+
+// validateTokenSigner checks that the keys with which credentials are signed
+// are held where the token signer requires, so that a misconfigured key fails
+// at startup rather than at the first credential request. The null signer
+// doesn't sign credentials, so it may only be used if the transport is
+// insecure.
+func (c *Config) validateTokenSigner() error {
+	if c.CredentialConfig == nil {
+		return nil
+	}
+
+	var held func(*security.CertificateConfig) bool
+	switch c.CredentialConfig.TokenSigner {
+	case security.TokenSignerPKCS11:
+		held = func(cfg *security.CertificateConfig) bool { return cfg.PKCS11Key != nil }
+	case security.TokenSignerKMS:
+		held = func(cfg *security.CertificateConfig) bool { return cfg.KMSKey != nil }
+	case security.TokenSignerNull:
+		if c.TransportConfig == nil || !c.TransportConfig.AllowInsecure {
+			return errors.New("the null token signer can only be used with allow_insecure")
+		}
+		return nil
+	default:
+		return nil
+	}
+
+	signer := c.CredentialConfig.TokenSigner
+	if c.TransportConfig == nil || c.TransportConfig.AllowInsecure {
+		return errors.Errorf("the %s token signer can't be used with allow_insecure", signer)
+	}
+	// Credentials are signed with the credential signing key, if set, rather
+	// than the transport key.
+	if c.CredentialKey != nil {
+		if !held(&c.CredentialKey.CertificateConfig) {
+			return errors.Errorf("the %s token signer requires credential_signing_key %s_key", signer, signer)
+		}
+	} else if !held(&c.TransportConfig.CertificateConfig) {
+		return errors.Errorf("the %s token signer requires transport_config %s_key", signer, signer)
+	}
+	for _, key := range c.SigningKeys {
+		if !held(&key.CertificateConfig) {
+			return errors.Errorf("the %s token signer requires %s_key for signing key %s", signer, signer, key)
+		}
+	}
 	return nil
 }
 
@@ -330,6 +330,373 @@ telemetry_enabled_procs: ^foo$
 				return cfg
 			}),
 		},
+		"signing keys": {
+			input: `
+signing_keys:
+- tenant: tenant-a
+  allowed_uids: [1000]
+  ca_cert: /etc/daos/certs/daosCA.crt
+  cert: /etc/daos/certs/tenant-a.crt
+  key: /etc/daos/certs/tenant-a.key
+- system: daos_server
+  tenant: tenant-b
+  allowed_gids: [2000]
+  ca_cert: /etc/daos/certs/daosCA.crt
+  cert: /etc/daos/certs/tenant-b.crt
+  tpm_key:
+    handle: "0x81010003"
+`,
+			expCfg: cfgWith(DefaultConfig(), func(cfg *Config) *Config {
+				cfg.SigningKeys = []*security.SigningKeyConfig{
+					{
+						Tenant:      "tenant-a",
+						AllowedUIDs: []uint32{1000},
+						CertificateConfig: security.CertificateConfig{
+							CARootPath:      "/etc/daos/certs/daosCA.crt",
+							CertificatePath: "/etc/daos/certs/tenant-a.crt",
+							PrivateKeyPath:  "/etc/daos/certs/tenant-a.key",
+						},
+					},
+					{
+						System:      "daos_server",
+						Tenant:      "tenant-b",
+						AllowedGIDs: []uint32{2000},
+						CertificateConfig: security.CertificateConfig{
+							CARootPath:      "/etc/daos/certs/daosCA.crt",
+							CertificatePath: "/etc/daos/certs/tenant-b.crt",
+							TPMKey:          &security.TPMKeyConfig{Handle: "0x81010003"},
+						},
+					},
+				}
+				return cfg
+			}),
+		},
+		"signing key without system or tenant": {
+			input: `
+signing_keys:
+- ca_cert: /etc/daos/certs/daosCA.crt
+  cert: /etc/daos/certs/tenant-a.crt
+  key: /etc/daos/certs/tenant-a.key
+`,
+			expErr: errors.New("one or both of system and tenant must be set"),
+		},
+		"tenant signing key not bound to clients": {
+			input: `
+signing_keys:
+- tenant: tenant-a
+  ca_cert: /etc/daos/certs/daosCA.crt
+  cert: /etc/daos/certs/tenant-a.crt
+  key: /etc/daos/certs/tenant-a.key
+`,
+			expErr: errors.New("allowed_uids or allowed_gids must be set"),
+		},
+		"signing key for another system": {
+			input: `
+signing_keys:
+- system: other
+  ca_cert: /etc/daos/certs/daosCA.crt
+  cert: /etc/daos/certs/other.crt
+  key: /etc/daos/certs/other.key
+`,
+			expErr: errors.New("not for the agent's system"),
+		},
+		"several signing keys for a tenant": {
+			input: `
+signing_keys:
+- tenant: tenant-a
+  allowed_uids: [1000]
+  ca_cert: /etc/daos/certs/daosCA.crt
+  cert: /etc/daos/certs/tenant-a-ed25519.crt
+  key: /etc/daos/certs/tenant-a-ed25519.key
+- tenant: tenant-a
+  allowed_uids: [1000]
+  ca_cert: /etc/daos/certs/daosCA.crt
+  cert: /etc/daos/certs/tenant-a-rsa.crt
+  key: /etc/daos/certs/tenant-a-rsa.key
+`,
+			expCfg: cfgWith(DefaultConfig(), func(cfg *Config) *Config {
+				cfg.SigningKeys = []*security.SigningKeyConfig{
+					{
+						Tenant:      "tenant-a",
+						AllowedUIDs: []uint32{1000},
+						CertificateConfig: security.CertificateConfig{
+							CARootPath:      "/etc/daos/certs/daosCA.crt",
+							CertificatePath: "/etc/daos/certs/tenant-a-ed25519.crt",
+							PrivateKeyPath:  "/etc/daos/certs/tenant-a-ed25519.key",
+						},
+					},
+					{
+						Tenant:      "tenant-a",
+						AllowedUIDs: []uint32{1000},
+						CertificateConfig: security.CertificateConfig{
+							CARootPath:      "/etc/daos/certs/daosCA.crt",
+							CertificatePath: "/etc/daos/certs/tenant-a-rsa.crt",
+							PrivateKeyPath:  "/etc/daos/certs/tenant-a-rsa.key",
+						},
+					},
+				}
+				return cfg
+			}),
+		},
+		"signing keys with insecure transport": {
+			input: `
+transport_config:
+  allow_insecure: true
+signing_keys:
+- tenant: tenant-a
+  allowed_uids: [1000]
+  ca_cert: /etc/daos/certs/daosCA.crt
+  cert: /etc/daos/certs/tenant-a.crt
+  key: /etc/daos/certs/tenant-a.key
+`,
+			expErr: errors.New("can't be used with allow_insecure"),
+		},
+		"credential signing key": {
+			input: `
+credential_signing_key:
+  ca_cert: /etc/daos/certs/daosCA.crt
+  cert: /etc/daos/certs/credentials.crt
+  key: /etc/daos/certs/credentials.key
+`,
+			expCfg: cfgWith(DefaultConfig(), func(cfg *Config) *Config {
+				cfg.CredentialKey = &security.SigningKeyConfig{
+					CertificateConfig: security.CertificateConfig{
+						CARootPath:      "/etc/daos/certs/daosCA.crt",
+						CertificatePath: "/etc/daos/certs/credentials.crt",
+						PrivateKeyPath:  "/etc/daos/certs/credentials.key",
+					},
+				}
+				return cfg
+			}),
+		},
+		"credential signing key for a tenant": {
+			input: `
+credential_signing_key:
+  tenant: tenant-a
+  ca_cert: /etc/daos/certs/daosCA.crt
+  cert: /etc/daos/certs/credentials.crt
+  key: /etc/daos/certs/credentials.key
+`,
+			expErr: errors.New("system and tenant can't be set on the default signing key"),
+		},
+		"credential signing key with insecure transport": {
+			input: `
+transport_config:
+  allow_insecure: true
+credential_signing_key:
+  ca_cert: /etc/daos/certs/daosCA.crt
+  cert: /etc/daos/certs/credentials.crt
+  key: /etc/daos/certs/credentials.key
+`,
+			expErr: errors.New("credential_signing_key can't be used with allow_insecure"),
+		},
+		"cert renewal with acme": {
+			input: `
+cert_renewal:
+  method: acme
+  renew_before: 168h
+  check_interval: 30m
+  acme:
+    directory_url: https://acme.example.com/directory
+    email: admin@example.com
+    account_key: /etc/daos/certs/acme-account.key
+    domains: [client-1.example.com]
+    http01_listen: ":8080"
+`,
+			expCfg: cfgWith(DefaultConfig(), func(cfg *Config) *Config {
+				cfg.CertRenewal = &security.CertRenewalConfig{
+					Method:        security.CertRenewalACME,
+					RenewBefore:   168 * time.Hour,
+					CheckInterval: 30 * time.Minute,
+					ACME: &security.ACMEConfig{
+						DirectoryURL: "https://acme.example.com/directory",
+						Email:        "admin@example.com",
+						AccountKey:   "/etc/daos/certs/acme-account.key",
+						Domains:      []string{"client-1.example.com"},
+						HTTP01Listen: ":8080",
+					},
+				}
+				return cfg
+			}),
+		},
+		"cert renewal with bad method": {
+			input: `
+cert_renewal:
+  method: magic
+`,
+			expErr: errors.New(`cert_renewal: invalid method "magic"`),
+		},
+		"cert renewal with insecure transport": {
+			input: `
+transport_config:
+  allow_insecure: true
+cert_renewal:
+  method: daos_ca
+`,
+			expErr: errors.New("certificate renewal can't be used with allow_insecure"),
+		},
+		"cert renewal with tpm key": {
+			input: `
+transport_config:
+  tpm_key:
+    handle: "0x81010002"
+cert_renewal:
+  method: daos_ca
+`,
+			expErr: errors.New("requires the transport key to be held in a key file"),
+		},
+		"cert renewal with encrypted key": {
+			input: `
+transport_config:
+  key_passphrase:
+    credential: daos_agent.key
+cert_renewal:
+  method: daos_ca
+`,
+			expErr: errors.New("can't be used with key_passphrase"),
+		},
+		"weak key policy": {
+			input: `
+transport_config:
+  weak_key_policy: fail
+`,
+			expCfg: cfgWith(DefaultConfig(), func(cfg *Config) *Config {
+				cfg.TransportConfig.WeakKeyPolicy = security.WeakKeyFail
+				return cfg
+			}),
+		},
+		"pkcs11 token signer": {
+			input: `
+credential_config:
+  token_signer: pkcs11
+transport_config:
+  pkcs11_key:
+    module: /usr/lib64/pkcs11/libsofthsm2.so
+    token_label: daos
+    key_label: agent
+`,
+			expCfg: cfgWith(DefaultConfig(), func(cfg *Config) *Config {
+				cfg.CredentialConfig.TokenSigner = security.TokenSignerPKCS11
+				cfg.TransportConfig.PKCS11Key = &security.PKCS11KeyConfig{
+					Module:     "/usr/lib64/pkcs11/libsofthsm2.so",
+					TokenLabel: "daos",
+					KeyLabel:   "agent",
+				}
+				return cfg
+			}),
+		},
+		"pkcs11 token signer with key file": {
+			input: `
+credential_config:
+  token_signer: pkcs11
+`,
+			expErr: errors.New("token_signer: the pkcs11 token signer requires transport_config pkcs11_key"),
+		},
+		"kms token signer with signing key file": {
+			input: `
+credential_config:
+  token_signer: kms
+transport_config:
+  kms_key:
+    backend: vault-transit
+    address: https://vault.example.com:8200
+    key_name: daos-agent
+signing_keys:
+- tenant: tenant-a
+  allowed_uids: [1000]
+  ca_cert: /etc/daos/certs/daosCA.crt
+  cert: /etc/daos/certs/tenant-a.crt
+  key: /etc/daos/certs/tenant-a.key
+`,
+			expErr: errors.New("the kms token signer requires kms_key for signing key"),
+		},
+		"null token signer with secure transport": {
+			input: `
+credential_config:
+  token_signer: "null"
+`,
+			expErr: errors.New("the null token signer can only be used with allow_insecure"),
+		},
+		"unknown token signer": {
+			input: `
+credential_config:
+  token_signer: magic
+`,
+			expErr: errors.New(`token_signer: unknown token signer "magic"`),
+		},
+		"cosigning config": {
+			input: `
+credential_config:
+  cosigning_config:
+    url: https://cosign.example.com/cosign
+    ca_cert: /etc/daos/certs/cosignCA.crt
+    cert: /etc/daos/certs/agent.crt
+    key: /etc/daos/certs/agent.key
+    users: [root]
+    machine: true
+`,
+			expCfg: cfgWith(DefaultConfig(), func(cfg *Config) *Config {
+				cfg.CredentialConfig.CosigningConfig = security.CosigningConfig{
+					URL:    "https://cosign.example.com/cosign",
+					CACert: "/etc/daos/certs/cosignCA.crt",
+					Cert:   "/etc/daos/certs/agent.crt",
+					Key:    "/etc/daos/certs/agent.key",
+					CosignedIdentities: security.CosignedIdentities{
+						Users:   []string{"root"},
+						Machine: true,
+					},
+				}
+				return cfg
+			}),
+		},
+		"cosigning config with insecure transport": {
+			input: `
+transport_config:
+  allow_insecure: true
+credential_config:
+  cosigning_config:
+    url: https://cosign.example.com/cosign
+    ca_cert: /etc/daos/certs/cosignCA.crt
+    cert: /etc/daos/certs/agent.crt
+    key: /etc/daos/certs/agent.key
+    groups: [admins]
+`,
+			expErr: errors.New("cosigning_config can't be used with allow_insecure"),
+		},
+		"credential nonces": {
+			input: `
+credential_config:
+  credential_nonces: true
+`,
+			expCfg: cfgWith(DefaultConfig(), func(cfg *Config) *Config {
+				cfg.CredentialConfig.CredentialNonces = true
+				return cfg
+			}),
+		},
+		"credential nonces with insecure transport": {
+			input: `
+transport_config:
+  allow_insecure: true
+credential_config:
+  credential_nonces: true
+`,
+			expErr: errors.New("credential_nonces can't be used with allow_insecure"),
+		},
+		"cosigning config without url": {
+			input: `
+credential_config:
+  cosigning_config:
+    users: [root]
+`,
+			expErr: errors.New("cosigning_config: url must be an https URL"),
+		},
+		"unknown weak key policy": {
+			input: `
+transport_config:
+  weak_key_policy: ignore
+`,
+			expErr: errors.New(`transport_config.weak_key_policy: unknown weak key policy "ignore"`),
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			gotCfg, gotErr := ReadConfig(strings.NewReader(tc.input))
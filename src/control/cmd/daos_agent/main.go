@@ -22,6 +22,7 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/daos"
 	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
 )
 
 type cliOptions struct {
@@ -40,6 +41,13 @@ type cliOptions struct {
 	DumpTopo      cmdutil.DumpTopologyCmd `command:"dump-topology" description:"Dump system topology"`
 	NetScan       netScanCmd              `command:"net-scan" description:"Perform local network fabric scan"`
 	Support       supportCmd              `command:"support" description:"Perform debug tasks to help support team"`
+	RevokeKey     revokeIssuerKeyCmd      `command:"revoke-issuer-key" description:"Revoke credentials signed by an issuer key"`
+	ListCache     listCredCacheCmd        `command:"list-credential-cache" description:"List the credentials cached by daos_agent"`
+	SnapshotCache snapshotCredCacheCmd    `command:"snapshot-credential-cache" description:"Write an encrypted snapshot of the credentials cached by daos_agent"`
+	RestoreCache  restoreCredCacheCmd     `command:"restore-credential-cache" description:"Restore a snapshot of cached credentials into daos_agent"`
+	WatchCache    watchCredCacheCmd       `command:"watch-credential-cache" description:"Print the lifecycle events of credentials cached by daos_agent"`
+	GenTPMKey     generateTPMKeyCmd       `command:"generate-tpm-key" description:"Generate a credential signing key in the TPM"`
+	Presign       presignCredentialsCmd   `command:"presign-credentials" description:"Pre-sign credentials for a fixed set of identities, for use without a running agent"`
 }
 
 type (
@@ -56,6 +64,17 @@ func (cmd *ctlInvokerCmd) setInvoker(ctlInvoker control.Invoker) {
 	cmd.ctlInvoker = ctlInvoker
 }
 
+// newControlConfig generates a control config based on the loaded agent config,
+// with the transport config holding the agent's current certificate.
+func newControlConfig(cfg *Config, tc *security.TransportConfig) *control.Config {
+	ctlCfg := control.DefaultConfig()
+	ctlCfg.TransportConfig = tc
+	ctlCfg.HostList = cfg.AccessPoints
+	ctlCfg.SystemName = cfg.SystemName
+	ctlCfg.ControlPort = cfg.ControlPort
+	return ctlCfg
+}
+
 type (
 	configSetter interface {
 		setConfig(*Config)
@@ -177,7 +196,7 @@ func parseOpts(args []string, opts *cliOptions, invoker control.Invoker, log *lo
 		}
 
 		switch cmd.(type) {
-		case *versionCmd, *netScanCmd, *cmdutil.DumpTopologyCmd:
+		case *versionCmd, *netScanCmd, *cmdutil.DumpTopologyCmd, *generateTPMKeyCmd:
 			// these commands don't need the rest of the setup
 			return cmd.Execute(args)
 		}
@@ -204,14 +223,7 @@ func parseOpts(args []string, opts *cliOptions, invoker control.Invoker, log *lo
 		}
 
 		if ctlCmd, ok := cmd.(ctlInvoker); ok {
-			// Generate a control config based on the loaded agent config.
-			ctlCfg := control.DefaultConfig()
-			ctlCfg.TransportConfig = cfg.TransportConfig
-			ctlCfg.HostList = cfg.AccessPoints
-			ctlCfg.SystemName = cfg.SystemName
-			ctlCfg.ControlPort = cfg.ControlPort
-
-			invoker.SetConfig(ctlCfg)
+			invoker.SetConfig(newControlConfig(cfg, cfg.TransportConfig))
 			ctlCmd.setInvoker(invoker)
 		}
 
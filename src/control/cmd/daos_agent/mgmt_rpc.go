@@ -49,6 +49,7 @@ type mgmtModule struct {
 
 	numaGetter  hardware.ProcessNUMAProvider
 	providerIdx uint
+	credCache   *credentialCache
 }
 
 func (mod *mgmtModule) HandleCall(ctx context.Context, session *drpc.Session, method drpc.Method, req []byte) ([]byte, error) {
@@ -88,6 +89,14 @@ func (mod *mgmtModule) HandleCall(ctx context.Context, session *drpc.Session, me
 		// call the disconnect handler and return success.
 		mod.handleNotifyExit(ctx, cred.Pid)
 		return nil, nil
+	case daos.MethodListCredentialCache:
+		return mod.handleListCredentialCache(cred)
+	case daos.MethodSnapshotCredentialCache:
+		return mod.handleSnapshotCredentialCache(cred)
+	case daos.MethodRestoreCredentialCache:
+		return mod.handleRestoreCredentialCache(cred, req)
+	case daos.MethodCredentialCacheEvents:
+		return mod.handleCredentialCacheEvents(cred, req)
 	}
 
 	return nil, drpc.UnknownMethodFailure()
@@ -100,7 +109,11 @@ func (mod *mgmtModule) GetMethod(id int32) (drpc.Method, error) {
 		daos.MethodSetupClientTelemetry.ID(),
 		daos.MethodNotifyPoolConnect.ID(),
 		daos.MethodNotifyPoolDisconnect.ID(),
-		daos.MethodNotifyExit.ID():
+		daos.MethodNotifyExit.ID(),
+		daos.MethodListCredentialCache.ID(),
+		daos.MethodSnapshotCredentialCache.ID(),
+		daos.MethodRestoreCredentialCache.ID(),
+		daos.MethodCredentialCacheEvents.ID():
 		return daos.MgmtMethod(id), nil
 	}
 
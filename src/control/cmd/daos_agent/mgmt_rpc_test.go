@@ -736,6 +736,22 @@ func TestAgent_mgmtModule_GetMethod(t *testing.T) {
 			methodID:  daos.MethodSetupClientTelemetry.ID(),
 			expMethod: daos.MethodSetupClientTelemetry,
 		},
+		"list-credential-cache": {
+			methodID:  daos.MethodListCredentialCache.ID(),
+			expMethod: daos.MethodListCredentialCache,
+		},
+		"snapshot-credential-cache": {
+			methodID:  daos.MethodSnapshotCredentialCache.ID(),
+			expMethod: daos.MethodSnapshotCredentialCache,
+		},
+		"restore-credential-cache": {
+			methodID:  daos.MethodRestoreCredentialCache.ID(),
+			expMethod: daos.MethodRestoreCredentialCache,
+		},
+		"credential-cache-events": {
+			methodID:  daos.MethodCredentialCacheEvents.ID(),
+			expMethod: daos.MethodCredentialCacheEvents,
+		},
 		"unknown": {
 			methodID: -1,
 			expErr:   errors.New("method ID -1"),
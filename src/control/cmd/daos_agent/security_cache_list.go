@@ -0,0 +1,128 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// cacheKeyHash returns a digest of the cache key, which identifies a cached
+// credential without revealing any token the key may contain.
+func cacheKeyHash(key string) string {
+	digest := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(digest[:])
+}
+
+// entries describes the unexpired cached credentials, oldest first, without
+// the credentials themselves.
+func (cc *credentialCache) entries() []*auth.CredCacheEntry {
+	if cc == nil {
+		return nil
+	}
+
+	var entries []*auth.CredCacheEntry
+	for _, key := range cc.cache.Keys() {
+		// Expired credentials are removed when they are looked up.
+		item, release, err := cc.cache.Get(context.Background(), key)
+		if err != nil {
+			continue
+		}
+		if cred, ok := item.(*cachedCredential); ok {
+			entries = append(entries, &auth.CredCacheEntry{
+				Flavor:    cred.flavor,
+				KeyHash:   cacheKeyHash(cred.key),
+				CreatedAt: cred.createdAt.Unix(),
+				ExpiresAt: cred.expiredAt.Unix(),
+				Hits:      cred.hits,
+			})
+		}
+		release()
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].CreatedAt != entries[j].CreatedAt {
+			return entries[i].CreatedAt < entries[j].CreatedAt
+		}
+		return entries[i].KeyHash < entries[j].KeyHash
+	})
+	return entries
+}
+
+// handleListCredentialCache lists the cached credentials. The cache reveals who
+// has been using the agent, so only root and the agent's own user may list it.
+func (mod *mgmtModule) handleListCredentialCache(cred *unix.Ucred) ([]byte, error) {
+	if !privilegedUid(cred.Uid) {
+		mod.log.Noticef("audit: denied request from uid %d to list the credential cache", cred.Uid)
+		return drpc.Marshal(&auth.ListCredCacheResp{Status: int32(daos.NoPermission)})
+	}
+
+	return drpc.Marshal(&auth.ListCredCacheResp{Entries: mod.credCache.entries()})
+}
+
+type listCredCacheCmd struct {
+	configCmd
+	cmdutil.LogCmd
+	cmdutil.JSONOutputCmd
+}
+
+func (cmd *listCredCacheCmd) Execute(_ []string) error {
+	sockPath := filepath.Join(cmd.cfg.RuntimeDir, agentSockName)
+	resp, err := auth.ListCredentialCache(cmd.MustLogCtx(), sockPath, daos.MethodListCredentialCache)
+	if err != nil {
+		return err
+	}
+	if resp.Status != 0 {
+		return errors.Wrap(daos.Status(resp.Status), "listing credential cache")
+	}
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp.Entries, nil)
+	}
+
+	if len(resp.Entries) == 0 {
+		_, err = fmt.Println("No cached credentials.")
+		return err
+	}
+
+	flavorTitle := "Flavor"
+	keyTitle := "Key Hash"
+	createdTitle := "Created"
+	expiresTitle := "Expires"
+	hitsTitle := "Hits"
+	table := []txtfmt.TableRow{}
+	for _, entry := range resp.Entries {
+		table = append(table, txtfmt.TableRow{
+			flavorTitle:  entry.Flavor.String(),
+			keyTitle:     entry.KeyHash,
+			createdTitle: time.Unix(entry.CreatedAt, 0).Format(time.RFC3339),
+			expiresTitle: time.Unix(entry.ExpiresAt, 0).Format(time.RFC3339),
+			hitsTitle:    strconv.FormatUint(entry.Hits, 10),
+		})
+	}
+
+	tf := txtfmt.NewTableFormatter(flavorTitle, keyTitle, createdTitle, expiresTitle, hitsTitle)
+	tf.InitWriter(os.Stdout)
+	tf.Format(table)
+	return nil
+}
@@ -0,0 +1,119 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/cache"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func TestAgent_credentialCache_entries(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	var nilCache *credentialCache
+	test.AssertEqual(t, 0, len(nilCache.entries()), "nil cache should have no entries")
+
+	cc := &credentialCache{
+		log:          log,
+		cache:        cache.NewShardedItemCache(log, credCacheShards),
+		credLifetime: time.Minute,
+		cacheMissFn: func(_ context.Context, _ logging.Logger, req auth.CredentialRequest) (*auth.Credential, error) {
+			return &auth.Credential{
+				Token:  &auth.Token{Flavor: auth.Flavor_AUTH_SYS, Data: []byte("secret")},
+				Origin: req.GetKey(),
+			}, nil
+		},
+	}
+	request := func(uid uint32) string {
+		t.Helper()
+		req := &auth.AuthSysCredentialRequest{
+			DomainInfo: security.InitDomainInfo(&syscall.Ucred{Uid: uid, Gid: uid}, ""),
+		}
+		if _, err := cc.getSignedCredential(test.Context(t), log, req); err != nil {
+			t.Fatal(err)
+		}
+		return req.GetKey()
+	}
+
+	key1 := request(1)
+	request(1)
+	request(1)
+	key2 := request(2)
+	expired, err := newCachedCredential("expired", &auth.Credential{}, -time.Minute, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cc.cache.Set(expired); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := cc.entries()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	hits := map[string]uint64{
+		cacheKeyHash(key1): 2,
+		cacheKeyHash(key2): 0,
+	}
+	for _, entry := range entries {
+		expHits, found := hits[entry.KeyHash]
+		if !found {
+			t.Fatalf("unexpected entry %q", entry.KeyHash)
+		}
+		test.AssertEqual(t, expHits, entry.Hits, "unexpected hits")
+		test.AssertEqual(t, auth.Flavor_AUTH_SYS, entry.Flavor, "unexpected flavor")
+		test.AssertTrue(t, entry.ExpiresAt > entry.CreatedAt, "credential expires before it was created")
+		test.AssertFalse(t, strings.Contains(entry.String(), "secret"), "credential exposed in entry")
+	}
+}
+
+func TestAgent_mgmtModule_handleListCredentialCache(t *testing.T) {
+	for name, tc := range map[string]struct {
+		uid       uint32
+		expStatus daos.Status
+	}{
+		"agent user": {
+			uid: uint32(os.Geteuid()),
+		},
+		"other user": {
+			uid:       uint32(os.Geteuid()) + 1,
+			expStatus: daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := &mgmtModule{log: log}
+			respBytes, err := mod.handleListCredentialCache(&unix.Ucred{Uid: tc.uid})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp := new(auth.ListCredCacheResp)
+			if err := proto.Unmarshal(respBytes, resp); err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, int32(tc.expStatus), resp.Status, "unexpected status")
+		})
+	}
+}
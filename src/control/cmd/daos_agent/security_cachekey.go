@@ -0,0 +1,63 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// cacheKeySecretContext distinguishes the secret keying the credential cache
+// keys from other uses of the secret it is derived from.
+const cacheKeySecretContext = "daos_agent credential cache keys\x00"
+
+// cacheKeyer derives the keys under which credentials are cached, and under
+// which requests for them are deduplicated, from the keys of the credential
+// requests. The keys are an HMAC of the flavor and request key with a secret
+// known only to the agent, so that even if a flavor derives guessable keys
+// from what its clients send, a client cannot craft a request whose key
+// collides with that of another identity's credential.
+type cacheKeyer struct {
+	secret []byte
+}
+
+// newCacheKeyer returns a keyer with a secret derived from that of the sealer,
+// so that the keys of credentials restored from the spool are unchanged, or a
+// random secret if cached credentials are not encrypted.
+func newCacheKeyer(sealer *credentialSealer) (*cacheKeyer, error) {
+	if sealer != nil {
+		return &cacheKeyer{secret: sealer.keySecret}, nil
+	}
+
+	secret := make([]byte, sha256.Size)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errors.Wrap(err, "generating credential cache key secret")
+	}
+	return &cacheKeyer{secret: secret}, nil
+}
+
+// key returns the key for the credential request. Without a keyer, the key of
+// the request is returned unchanged.
+func (k *cacheKeyer) key(req auth.CredentialRequest) string {
+	if k == nil {
+		return req.GetKey()
+	}
+
+	mac := hmac.New(sha256.New, k.secret)
+	var flavor [4]byte
+	binary.BigEndian.PutUint32(flavor[:], uint32(req.GetAuthFlavor()))
+	mac.Write(flavor[:])
+	mac.Write([]byte(req.GetKey()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,87 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func TestAgent_cacheKeyer(t *testing.T) {
+	sysReq := func(uid uint32) *auth.AuthSysCredentialRequest {
+		return &auth.AuthSysCredentialRequest{
+			DomainInfo: security.InitDomainInfo(&syscall.Ucred{Uid: uid, Gid: uid}, ""),
+		}
+	}
+
+	var nilKeyer *cacheKeyer
+	test.AssertEqual(t, sysReq(1).GetKey(), nilKeyer.key(sysReq(1)), "nil keyer should not change the key")
+
+	keyer, err := newCacheKeyer(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := keyer.key(sysReq(1))
+	test.AssertEqual(t, key, keyer.key(sysReq(1)), "key not stable")
+	test.AssertTrue(t, key != sysReq(1).GetKey(), "request key not keyed")
+	test.AssertTrue(t, key != keyer.key(sysReq(2)), "keys of different requests collide")
+
+	other, err := newCacheKeyer(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, key != other.key(sysReq(1)), "keys not keyed by a random secret")
+
+	// Keys derived from the sealer's secret survive a restart of the agent,
+	// so that the credentials restored from the spool are found.
+	sealer1, err := newCredentialSealer([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealer2, err := newCredentialSealer([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyer1, err := newCacheKeyer(sealer1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyer2, err := newCacheKeyer(sealer2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, keyer1.key(sysReq(1)), keyer2.key(sysReq(1)), "sealed keys not stable")
+	test.AssertTrue(t, keyer1.key(sysReq(1)) != key, "sealed keys not keyed by the sealer secret")
+}
+
+func TestAgent_cacheKeyer_flavor(t *testing.T) {
+	keyer, err := newCacheKeyer(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Requests of different flavors with the same key are kept apart.
+	sysReq := &auth.AuthSysCredentialRequest{
+		DomainInfo: security.InitDomainInfo(&syscall.Ucred{}, ""),
+	}
+	noneReq := &flavoredRequest{CredentialRequest: sysReq, flavor: auth.Flavor_AUTH_NONE}
+	test.AssertEqual(t, sysReq.GetKey(), noneReq.GetKey(), "request keys should match")
+	test.AssertTrue(t, keyer.key(sysReq) != keyer.key(noneReq), "keys of different flavors collide")
+}
+
+type flavoredRequest struct {
+	auth.CredentialRequest
+	flavor auth.Flavor
+}
+
+func (r *flavoredRequest) GetAuthFlavor() auth.Flavor {
+	return r.flavor
+}
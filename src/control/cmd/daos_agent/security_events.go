@@ -0,0 +1,238 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// credentialEventType is an event in the lifecycle of a cached credential.
+type credentialEventType string
+
+const (
+	credCreated     credentialEventType = "created"
+	credRefreshed   credentialEventType = "refreshed"
+	credExpired     credentialEventType = "expired"
+	credEvicted     credentialEventType = "evicted"
+	credInvalidated credentialEventType = "invalidated"
+
+	// credEventBufferSize is the number of events buffered for subscribers
+	// which poll for them.
+	credEventBufferSize = 1024
+)
+
+// credentialEventStream publishes the lifecycle events of cached credentials,
+// so that they can be correlated with authentication errors seen by
+// applications. The latest events are buffered, and subscribers poll for the
+// events which followed the last they saw. If logEvents is set, each event is
+// also logged.
+type credentialEventStream struct {
+	sync.Mutex
+	log       logging.Logger
+	logEvents bool
+	events    []*auth.CredCacheEvent
+	latest    uint64
+}
+
+func newCredentialEventStream(log logging.Logger, logEvents bool) *credentialEventStream {
+	return &credentialEventStream{
+		log:       log,
+		logEvents: logEvents,
+	}
+}
+
+// publish records an event for the credential cached under the key. The owner
+// is the UID it was cached for, if known.
+func (s *credentialEventStream) publish(evtType credentialEventType, key string, flavor auth.Flavor, owner *uint32) {
+	if s == nil {
+		return
+	}
+
+	evt := &auth.CredCacheEvent{
+		Time:    time.Now().UnixMilli(),
+		Type:    string(evtType),
+		Flavor:  flavor,
+		KeyHash: cacheKeyHash(key),
+		Owner:   -1,
+	}
+	if owner != nil {
+		evt.Owner = int64(*owner)
+	}
+
+	s.Lock()
+	s.latest++
+	evt.Seq = s.latest
+	if len(s.events) == credEventBufferSize {
+		copy(s.events, s.events[1:])
+		s.events[len(s.events)-1] = evt
+	} else {
+		s.events = append(s.events, evt)
+	}
+	s.Unlock()
+
+	if s.logEvents {
+		s.log.Infof("credential cache event: seq=%d type=%s flavor=%s key_hash=%s owner=%d",
+			evt.Seq, evt.Type, evt.Flavor, evt.KeyHash, evt.Owner)
+	}
+}
+
+// since returns the buffered events which followed the event with the sequence
+// number, the number of such events which are no longer buffered, and the
+// sequence number of the latest event.
+func (s *credentialEventStream) since(after uint64) ([]*auth.CredCacheEvent, uint64, uint64) {
+	if s == nil {
+		return nil, 0, 0
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if len(s.events) == 0 || after >= s.latest {
+		return nil, 0, s.latest
+	}
+
+	var missed uint64
+	first := s.events[0].Seq
+	if after+1 < first {
+		missed = first - after - 1
+		after = first - 1
+	}
+	events := make([]*auth.CredCacheEvent, 0, s.latest-after)
+	for _, evt := range s.events[after+1-first:] {
+		events = append(events, proto.Clone(evt).(*auth.CredCacheEvent))
+	}
+	return events, missed, s.latest
+}
+
+// describe returns the flavor and owner of the credential cached under the key,
+// if it is cached and unexpired.
+func (cc *credentialCache) describe(key string) (auth.Flavor, *uint32, bool) {
+	item, release, err := cc.cache.Get(context.Background(), key)
+	if err != nil {
+		return 0, nil, false
+	}
+	defer release()
+
+	cred, ok := item.(*cachedCredential)
+	if !ok {
+		return 0, nil, false
+	}
+	return cred.flavor, cred.owner, true
+}
+
+// handleCredentialCacheEvents returns the credential cache events which
+// followed the last event seen by the caller. Like the cache itself, the
+// events reveal who has been using the agent, so only root and the agent's own
+// user may fetch them.
+func (mod *mgmtModule) handleCredentialCacheEvents(cred *unix.Ucred, reqb []byte) ([]byte, error) {
+	if !privilegedUid(cred.Uid) {
+		mod.log.Noticef("audit: denied request from uid %d for credential cache events", cred.Uid)
+		return drpc.Marshal(&auth.CredCacheEventsResp{Status: int32(daos.NoPermission)})
+	}
+
+	req := new(auth.CredCacheEventsReq)
+	if err := proto.Unmarshal(reqb, req); err != nil {
+		return nil, drpc.UnmarshalingPayloadFailure()
+	}
+
+	var stream *credentialEventStream
+	if mod.credCache != nil {
+		stream = mod.credCache.events
+	}
+	events, missed, latest := stream.since(req.After)
+	return drpc.Marshal(&auth.CredCacheEventsResp{
+		Events: events,
+		Missed: missed,
+		Latest: latest,
+	})
+}
+
+type watchCredCacheCmd struct {
+	configCmd
+	cmdutil.LogCmd
+	cmdutil.JSONOutputCmd
+	Interval time.Duration `long:"interval" default:"1s" description:"Interval at which to poll for events"`
+	History  bool          `long:"history" description:"Also print the events buffered before the command started"`
+}
+
+func (cmd *watchCredCacheCmd) Execute(_ []string) error {
+	if cmd.Interval <= 0 {
+		return errors.New("--interval must be positive")
+	}
+
+	ctx := cmd.MustLogCtx()
+	sockPath := filepath.Join(cmd.cfg.RuntimeDir, agentSockName)
+	var after uint64
+	for first := true; ; first = false {
+		resp, err := auth.CredentialCacheEvents(ctx, sockPath, daos.MethodCredentialCacheEvents,
+			&auth.CredCacheEventsReq{After: after})
+		if err != nil {
+			return err
+		}
+		if resp.Status != 0 {
+			return errors.Wrap(daos.Status(resp.Status), "fetching credential cache events")
+		}
+		// The agent was restarted, so all of its events are new.
+		if resp.Latest < after {
+			after = 0
+			continue
+		}
+
+		if !first || cmd.History {
+			if resp.Missed > 0 {
+				fmt.Fprintf(os.Stderr, "missed %d credential cache events\n", resp.Missed)
+			}
+			if err := cmd.printEvents(resp.Events); err != nil {
+				return err
+			}
+		}
+		after = resp.Latest
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(cmd.Interval):
+		}
+	}
+}
+
+func (cmd *watchCredCacheCmd) printEvents(events []*auth.CredCacheEvent) error {
+	for _, evt := range events {
+		if cmd.JSONOutputEnabled() {
+			out, err := json.Marshal(evt)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			continue
+		}
+
+		owner := "-"
+		if evt.Owner >= 0 {
+			owner = fmt.Sprintf("%d", evt.Owner)
+		}
+		fmt.Printf("%s %-11s %-12s owner=%s key_hash=%s\n", time.UnixMilli(evt.Time).Format(time.RFC3339Nano),
+			evt.Type, evt.Flavor, owner, evt.KeyHash)
+	}
+	return nil
+}
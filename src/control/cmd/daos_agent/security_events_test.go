@@ -0,0 +1,216 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/cache"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func eventTypes(events []*auth.CredCacheEvent) []string {
+	types := []string{}
+	for _, evt := range events {
+		types = append(types, evt.Type)
+	}
+	return types
+}
+
+func TestAgent_credentialEventStream(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	var nilStream *credentialEventStream
+	nilStream.publish(credCreated, "key", auth.Flavor_AUTH_SYS, nil)
+	events, missed, latest := nilStream.since(0)
+	test.AssertEqual(t, 0, len(events), "nil stream has no events")
+	test.AssertEqual(t, uint64(0), missed+latest, "nil stream has no events")
+
+	s := newCredentialEventStream(log, true)
+	owner := uint32(1001)
+	s.publish(credCreated, "key", auth.Flavor_AUTH_SYS, &owner)
+	s.publish(credExpired, "key", auth.Flavor_AUTH_SYS, nil)
+
+	events, missed, latest = s.since(0)
+	test.CmpAny(t, "events", []string{"created", "expired"}, eventTypes(events))
+	test.AssertEqual(t, uint64(0), missed, "unexpected missed events")
+	test.AssertEqual(t, uint64(2), latest, "unexpected latest event")
+	test.AssertEqual(t, int64(1001), events[0].Owner, "unexpected owner")
+	test.AssertEqual(t, int64(-1), events[1].Owner, "unknown owner not reported")
+	test.AssertEqual(t, cacheKeyHash("key"), events[0].KeyHash, "unexpected key hash")
+	test.AssertTrue(t, strings.Contains(buf.String(), "credential cache event: seq=1 type=created"), "event not logged")
+
+	// Subscribers see only the events which followed the last they saw.
+	events, _, _ = s.since(1)
+	test.CmpAny(t, "events", []string{"expired"}, eventTypes(events))
+	events, _, latest = s.since(2)
+	test.AssertEqual(t, 0, len(events), "unexpected events")
+	test.AssertEqual(t, uint64(2), latest, "unexpected latest event")
+
+	// Only the latest events are buffered.
+	for i := 0; i < credEventBufferSize; i++ {
+		s.publish(credRefreshed, fmt.Sprintf("key-%d", i), auth.Flavor_AUTH_SYS, nil)
+	}
+	events, missed, latest = s.since(1)
+	test.AssertEqual(t, credEventBufferSize, len(events), "unexpected number of events")
+	test.AssertEqual(t, uint64(1), missed, "unexpected missed events")
+	test.AssertEqual(t, uint64(credEventBufferSize+2), latest, "unexpected latest event")
+	test.AssertEqual(t, uint64(3), events[0].Seq, "unexpected first event")
+
+	// Events can't be modified by subscribers.
+	events[0].Type = "modified"
+	events, _, _ = s.since(2)
+	test.AssertEqual(t, "refreshed", events[0].Type, "buffered event modified")
+}
+
+func TestAgent_credentialCache_events(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	stream := newCredentialEventStream(log, false)
+	cc := &credentialCache{
+		log:          log,
+		cache:        cache.NewShardedItemCache(log, credCacheShards),
+		credLifetime: time.Minute,
+		lru:          newCredentialLRU(2, 0, 0, nil),
+		events:       stream,
+		cacheMissFn: func(_ context.Context, _ logging.Logger, req auth.CredentialRequest) (*auth.Credential, error) {
+			return &auth.Credential{
+				Token:  &auth.Token{Flavor: auth.Flavor_AUTH_SYS},
+				Origin: req.GetKey(),
+			}, nil
+		},
+	}
+	cc.lru.events = stream
+	request := func(uid uint32) string {
+		t.Helper()
+		req := &auth.AuthSysCredentialRequest{
+			DomainInfo: security.InitDomainInfo(&syscall.Ucred{Uid: uid, Gid: uid}, ""),
+		}
+		owner := uid
+		ctx := context.WithValue(test.Context(t), credentialOwnerKey, &owner)
+		if _, err := cc.getSignedCredential(ctx, log, req); err != nil {
+			t.Fatal(err)
+		}
+		return req.GetKey()
+	}
+
+	key1 := request(1)
+	request(1)
+	request(2)
+	request(3)
+	events, _, _ := stream.since(0)
+	test.CmpAny(t, "events", []string{"created", "created", "created", "evicted"}, eventTypes(events))
+	test.AssertEqual(t, cacheKeyHash(key1), events[3].KeyHash, "least recently used not evicted")
+	test.AssertEqual(t, int64(1), events[3].Owner, "unexpected owner")
+
+	cc.invalidate(request(2))
+	events, _, latest := stream.since(4)
+	test.CmpAny(t, "events", []string{"invalidated"}, eventTypes(events))
+	test.AssertEqual(t, int64(2), events[0].Owner, "unexpected owner")
+
+	// An expired credential is reported when it is next requested, or when
+	// it is reaped.
+	for _, key := range cc.cache.Keys() {
+		item, release, err := cc.cache.Get(test.Context(t), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		item.(*cachedCredential).expiredAt = time.Now().Add(-time.Second)
+		release()
+	}
+	request(3)
+	events, _, latest = stream.since(latest)
+	test.CmpAny(t, "events", []string{"expired", "created"}, eventTypes(events))
+
+	item, release, err := cc.cache.Get(test.Context(t), cc.keyer.key(&auth.AuthSysCredentialRequest{
+		DomainInfo: security.InitDomainInfo(&syscall.Ucred{Uid: 3, Gid: 3}, ""),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	item.(*cachedCredential).expiredAt = time.Now().Add(-time.Second)
+	release()
+	cc.reapExpired()
+	events, _, _ = stream.since(latest)
+	test.CmpAny(t, "events", []string{"expired"}, eventTypes(events))
+}
+
+func TestAgent_mgmtModule_handleCredentialCacheEvents(t *testing.T) {
+	for name, tc := range map[string]struct {
+		uid       uint32
+		noCache   bool
+		after     uint64
+		expStatus daos.Status
+		expEvents []string
+		expLatest uint64
+	}{
+		"agent user": {
+			uid:       uint32(os.Geteuid()),
+			expEvents: []string{"created", "expired"},
+			expLatest: 2,
+		},
+		"after last seen": {
+			uid:       uint32(os.Geteuid()),
+			after:     1,
+			expEvents: []string{"expired"},
+			expLatest: 2,
+		},
+		"cache disabled": {
+			uid:       uint32(os.Geteuid()),
+			noCache:   true,
+			expEvents: []string{},
+		},
+		"other user": {
+			uid:       uint32(os.Geteuid()) + 1,
+			expStatus: daos.NoPermission,
+			expEvents: []string{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := &mgmtModule{log: log}
+			if !tc.noCache {
+				mod.credCache = &credentialCache{events: newCredentialEventStream(log, false)}
+				mod.credCache.events.publish(credCreated, "key", auth.Flavor_AUTH_SYS, nil)
+				mod.credCache.events.publish(credExpired, "key", auth.Flavor_AUTH_SYS, nil)
+			}
+
+			reqBytes, err := proto.Marshal(&auth.CredCacheEventsReq{After: tc.after})
+			if err != nil {
+				t.Fatal(err)
+			}
+			respBytes, err := mod.handleCredentialCacheEvents(&unix.Ucred{Uid: tc.uid}, reqBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp := new(auth.CredCacheEventsResp)
+			if err := proto.Unmarshal(respBytes, resp); err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, int32(tc.expStatus), resp.Status, "unexpected status")
+			test.CmpAny(t, "events", tc.expEvents, eventTypes(resp.Events))
+			test.AssertEqual(t, tc.expLatest, resp.Latest, "unexpected latest event")
+		})
+	}
+}
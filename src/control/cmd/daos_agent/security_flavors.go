@@ -0,0 +1,129 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+type (
+	// fetchFlavorsFn fetches the authentication flavors allowed by the
+	// named system.
+	fetchFlavorsFn func(ctx context.Context, sys string) ([]auth.Flavor, error)
+
+	// systemFlavors are the flavors last fetched for a system.
+	systemFlavors struct {
+		flavors   []auth.Flavor
+		fetchedAt time.Time
+	}
+
+	// validFlavorCache caches the authentication flavors allowed by each
+	// system for the TTL, so that changes to the servers' configuration
+	// reach the agent without restarting it. The flavors are refreshed in
+	// the background, and if they can't be fetched once the TTL has passed,
+	// the last flavors fetched continue to be served. The flavors of each
+	// system are cached separately, so that a flavor allowed by one system
+	// is never treated as allowed by another.
+	validFlavorCache struct {
+		sync.Mutex
+		log     logging.Logger
+		ttl     time.Duration
+		fetch   fetchFlavorsFn
+		systems map[string]*systemFlavors
+	}
+)
+
+func newValidFlavorCache(log logging.Logger, ttl time.Duration, fetch fetchFlavorsFn) *validFlavorCache {
+	return &validFlavorCache{
+		log:     log,
+		ttl:     ttl,
+		fetch:   fetch,
+		systems: make(map[string]*systemFlavors),
+	}
+}
+
+// get returns the valid flavors of the system, fetching them if they have not
+// been fetched within the TTL.
+func (c *validFlavorCache) get(ctx context.Context, sys string) ([]auth.Flavor, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	cached, found := c.systems[sys]
+	if found && time.Since(cached.fetchedAt) < c.ttl {
+		return slices.Clone(cached.flavors), nil
+	}
+
+	flavors, err := c.fetch(ctx, sys)
+	if err != nil {
+		if !found {
+			return nil, err
+		}
+		c.log.Errorf("unable to refresh valid authentication flavors of system %q, using those fetched at %s: %s",
+			sys, cached.fetchedAt.Format(time.RFC3339), err)
+		return slices.Clone(cached.flavors), nil
+	}
+	return slices.Clone(c.update(sys, flavors)), nil
+}
+
+// refresh fetches the valid flavors of each system already fetched, keeping
+// those already fetched for a system if it fails.
+func (c *validFlavorCache) refresh(ctx context.Context) {
+	c.Lock()
+	systems := make([]string, 0, len(c.systems))
+	for sys := range c.systems {
+		systems = append(systems, sys)
+	}
+	c.Unlock()
+
+	for _, sys := range systems {
+		flavors, err := c.fetch(ctx, sys)
+		if err != nil {
+			c.log.Errorf("unable to refresh valid authentication flavors of system %q: %s", sys, err)
+			continue
+		}
+
+		c.Lock()
+		c.update(sys, flavors)
+		c.Unlock()
+	}
+}
+
+// update records the flavors fetched for the system, and returns them. The
+// caller must hold the lock.
+func (c *validFlavorCache) update(sys string, flavors []auth.Flavor) []auth.Flavor {
+	cached, found := c.systems[sys]
+	if !found {
+		cached = &systemFlavors{}
+		c.systems[sys] = cached
+	} else if !slices.Equal(cached.flavors, flavors) {
+		c.log.Noticef("valid authentication flavors of system %q changed from %v to %v", sys, cached.flavors, flavors)
+	}
+	cached.flavors = flavors
+	cached.fetchedAt = time.Now()
+	return cached.flavors
+}
+
+// run refreshes the valid flavors every TTL until the context is canceled.
+func (c *validFlavorCache) run(ctx context.Context) {
+	ticker := time.NewTicker(c.ttl)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.refresh(ctx)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+const testFlavorSys = "daos_server"
+
+type mockFlavorFetcher struct {
+	sync.Mutex
+	flavors   []auth.Flavor
+	sysFlavor map[string][]auth.Flavor
+	err       error
+	calls     int
+}
+
+func (m *mockFlavorFetcher) fetch(_ context.Context, sys string) ([]auth.Flavor, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	if flavors, found := m.sysFlavor[sys]; found {
+		return flavors, nil
+	}
+	return m.flavors, nil
+}
+
+func (m *mockFlavorFetcher) set(flavors []auth.Flavor, err error) {
+	m.Lock()
+	defer m.Unlock()
+	m.flavors = flavors
+	m.err = err
+}
+
+func (m *mockFlavorFetcher) numCalls() int {
+	m.Lock()
+	defer m.Unlock()
+	return m.calls
+}
+
+func TestAgent_validFlavorCache_get(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	sysOnly := []auth.Flavor{auth.Flavor_AUTH_SYS}
+	sysAndMachine := []auth.Flavor{auth.Flavor_AUTH_SYS, auth.Flavor_AUTH_MACHINE}
+	fetcher := &mockFlavorFetcher{err: errors.New("server unavailable")}
+	c := newValidFlavorCache(log, time.Hour, fetcher.fetch)
+
+	// Nothing can be served until the flavors have been fetched.
+	_, err := c.get(test.Context(t), testFlavorSys)
+	test.CmpErr(t, errors.New("server unavailable"), err)
+
+	fetcher.set(sysOnly, nil)
+	flavors, err := c.get(test.Context(t), testFlavorSys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.CmpAny(t, "flavors", sysOnly, flavors)
+
+	// Fresh flavors are served without fetching them again.
+	fetcher.set(sysAndMachine, nil)
+	flavors, _ = c.get(test.Context(t), testFlavorSys)
+	test.CmpAny(t, "flavors", sysOnly, flavors)
+	test.AssertEqual(t, 2, fetcher.numCalls(), "unexpected number of fetches")
+
+	// Once the TTL has passed, the flavors are fetched again.
+	c.systems[testFlavorSys].fetchedAt = time.Now().Add(-2 * time.Hour)
+	flavors, _ = c.get(test.Context(t), testFlavorSys)
+	test.CmpAny(t, "flavors", sysAndMachine, flavors)
+
+	// If they can't be fetched, the stale flavors are served.
+	c.systems[testFlavorSys].fetchedAt = time.Now().Add(-2 * time.Hour)
+	fetcher.set(nil, errors.New("server unavailable"))
+	flavors, err = c.get(test.Context(t), testFlavorSys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.CmpAny(t, "flavors", sysAndMachine, flavors)
+
+	// The cached flavors can't be modified by callers.
+	flavors[0] = auth.Flavor_AUTH_NONE
+	flavors, _ = c.get(test.Context(t), testFlavorSys)
+	test.CmpAny(t, "flavors", sysAndMachine, flavors)
+}
+
+func TestAgent_validFlavorCache_run(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	fetcher := &mockFlavorFetcher{flavors: []auth.Flavor{auth.Flavor_AUTH_SYS}}
+	c := newValidFlavorCache(log, 10*time.Millisecond, fetcher.fetch)
+	if _, err := c.get(test.Context(t), testFlavorSys); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(test.Context(t))
+	done := make(chan struct{})
+	go func() {
+		c.run(ctx)
+		close(done)
+	}()
+
+	// A failed refresh keeps the flavors already fetched.
+	fetcher.set(nil, errors.New("server unavailable"))
+	for calls := fetcher.numCalls(); fetcher.numCalls() < calls+2; {
+		time.Sleep(time.Millisecond)
+	}
+	c.Lock()
+	test.CmpAny(t, "flavors", []auth.Flavor{auth.Flavor_AUTH_SYS}, c.systems[testFlavorSys].flavors)
+	c.Unlock()
+
+	// The flavors are refreshed in the background.
+	expFlavors := []auth.Flavor{auth.Flavor_AUTH_SYS, auth.Flavor_AUTH_MACHINE}
+	fetcher.set(expFlavors, nil)
+	var flavors []auth.Flavor
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		c.Lock()
+		flavors = c.systems[testFlavorSys].flavors
+		c.Unlock()
+		if len(flavors) == len(expFlavors) {
+			break
+		}
+	}
+	test.CmpAny(t, "flavors", expFlavors, flavors)
+
+	cancel()
+	<-done
+}
+
+func TestAgent_validFlavorCache_perSystem(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	fetcher := &mockFlavorFetcher{
+		sysFlavor: map[string][]auth.Flavor{
+			"sys-a": {auth.Flavor_AUTH_SYS, auth.Flavor_AUTH_MACHINE},
+			"sys-b": {auth.Flavor_AUTH_SYS},
+		},
+	}
+	c := newValidFlavorCache(log, time.Hour, fetcher.fetch)
+
+	flavors, err := c.get(test.Context(t), "sys-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.CmpAny(t, "sys-a flavors", fetcher.sysFlavor["sys-a"], flavors)
+
+	// A flavor allowed by one system isn't served for another.
+	flavors, err = c.get(test.Context(t), "sys-b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.CmpAny(t, "sys-b flavors", fetcher.sysFlavor["sys-b"], flavors)
+	test.AssertEqual(t, 2, fetcher.numCalls(), "unexpected number of fetches")
+
+	// Each system fetched is refreshed.
+	fetcher.sysFlavor["sys-b"] = []auth.Flavor{auth.Flavor_AUTH_MACHINE}
+	c.refresh(test.Context(t))
+	test.AssertEqual(t, 4, fetcher.numCalls(), "unexpected number of fetches")
+	flavors, _ = c.get(test.Context(t), "sys-b")
+	test.CmpAny(t, "sys-b flavors", []auth.Flavor{auth.Flavor_AUTH_MACHINE}, flavors)
+	flavors, _ = c.get(test.Context(t), "sys-a")
+	test.CmpAny(t, "sys-a flavors", fetcher.sysFlavor["sys-a"], flavors)
+}
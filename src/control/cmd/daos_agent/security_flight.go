@@ -0,0 +1,107 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"sync"
+
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+type (
+	// signedCredential is the result of a credential request.
+	signedCredential struct {
+		cred         *auth.Credential
+		renewalToken string
+	}
+
+	// signFn signs a credential.
+	signFn func(context.Context) (*signedCredential, error)
+
+	// credentialFlights deduplicates concurrent requests for the same
+	// credential, such as those from the processes of a job starting at
+	// once, so that only one of them reaches the signer and its backends,
+	// and the others wait for its result.
+	credentialFlights struct {
+		sync.Mutex
+		calls map[string]*credentialFlight
+	}
+
+	// credentialFlight is a credential request in flight. The request is
+	// canceled if every caller waiting for it gives up.
+	credentialFlight struct {
+		done    chan struct{}
+		cancel  context.CancelFunc
+		waiters int
+		result  *signedCredential
+		err     error
+	}
+)
+
+func newCredentialFlights() *credentialFlights {
+	return &credentialFlights{
+		calls: make(map[string]*credentialFlight),
+	}
+}
+
+// do signs the credential for the key, or waits for the result of a request
+// for the same key which is already in flight. The request runs until it
+// completes, or until every caller waiting for it has given up.
+func (f *credentialFlights) do(ctx context.Context, key string, sign signFn) (*signedCredential, error) {
+	if f == nil {
+		return sign(ctx)
+	}
+
+	f.Lock()
+	call, found := f.calls[key]
+	if !found {
+		// The request must not fail just because the caller which
+		// started it has given up, while others still wait for it.
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &credentialFlight{
+			done:   make(chan struct{}),
+			cancel: cancel,
+		}
+		f.calls[key] = call
+		go f.run(callCtx, key, call, sign)
+	}
+	call.waiters++
+	f.Unlock()
+
+	select {
+	case <-call.done:
+		return call.result, call.err
+	case <-ctx.Done():
+		f.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			f.forget(key, call)
+		}
+		f.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (f *credentialFlights) run(ctx context.Context, key string, call *credentialFlight, sign signFn) {
+	defer call.cancel()
+	call.result, call.err = sign(ctx)
+
+	f.Lock()
+	f.forget(key, call)
+	f.Unlock()
+	close(call.done)
+}
+
+// forget removes the call, if it is still the one in flight for the key. The
+// caller must hold the lock.
+func (f *credentialFlights) forget(key string, call *credentialFlight) {
+	if f.calls[key] == call {
+		delete(f.calls, key)
+	}
+}
@@ -0,0 +1,123 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func TestAgent_credentialFlights(t *testing.T) {
+	for name, tc := range map[string]struct {
+		signErr error
+		expErr  error
+	}{
+		"success": {},
+		"failure shared": {
+			signErr: errors.New("backend unavailable"),
+			expErr:  errors.New("backend unavailable"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			const callers = 10
+			flights := newCredentialFlights()
+			release := make(chan struct{})
+			var calls atomic.Int32
+			sign := func(context.Context) (*signedCredential, error) {
+				calls.Add(1)
+				<-release
+				if tc.signErr != nil {
+					return nil, tc.signErr
+				}
+				return &signedCredential{cred: &auth.Credential{Origin: "test"}}, nil
+			}
+
+			var wg sync.WaitGroup
+			errs := make([]error, callers)
+			results := make([]*signedCredential, callers)
+			for i := 0; i < callers; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					results[i], errs[i] = flights.do(test.Context(t), "key", sign)
+				}(i)
+			}
+			// Wait for all callers to join the flight before it completes.
+			for {
+				flights.Lock()
+				call := flights.calls["key"]
+				joined := call != nil && call.waiters == callers
+				flights.Unlock()
+				if joined {
+					break
+				}
+				time.Sleep(time.Millisecond)
+			}
+			close(release)
+			wg.Wait()
+
+			test.AssertEqual(t, int32(1), calls.Load(), "unexpected number of sign calls")
+			for i := 0; i < callers; i++ {
+				test.CmpErr(t, tc.expErr, errs[i])
+				test.AssertEqual(t, tc.expErr == nil, results[i] != nil, "unexpected result")
+			}
+			test.AssertEqual(t, 0, len(flights.calls), "flight not forgotten")
+		})
+	}
+}
+
+func TestAgent_credentialFlights_canceled(t *testing.T) {
+	flights := newCredentialFlights()
+	started := make(chan struct{})
+	var signCtx context.Context
+	sign := func(ctx context.Context) (*signedCredential, error) {
+		signCtx = ctx
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(test.Context(t))
+	errCh := make(chan error)
+	go func() {
+		_, err := flights.do(ctx, "key", sign)
+		errCh <- err
+	}()
+	<-started
+	cancel()
+
+	test.CmpErr(t, context.Canceled, <-errCh)
+	select {
+	case <-signCtx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("abandoned request was not canceled")
+	}
+
+	// A new request is not joined to the abandoned one.
+	result, err := flights.do(test.Context(t), "key", func(context.Context) (*signedCredential, error) {
+		return &signedCredential{}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, result != nil, "expected result")
+
+	var nilFlights *credentialFlights
+	if _, err := nilFlights.do(test.Context(t), "key", func(context.Context) (*signedCredential, error) {
+		return nil, errors.New("direct")
+	}); err == nil {
+		t.Fatal("expected error from direct call")
+	}
+}
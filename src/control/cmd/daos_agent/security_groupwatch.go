@@ -0,0 +1,277 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// lookupUserUid resolves a user name to its UID.
+var lookupUserUid = func(name string) (uint32, error) {
+	u, err := user.Lookup(name)
+	if err != nil {
+		return 0, err
+	}
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid uid for user %q", name)
+	}
+	return uint32(uid), nil
+}
+
+type (
+	// groupMembership maps each user to the groups they are a member of.
+	groupMembership map[string]map[string]struct{}
+
+	// watchedGroupFile is the last seen state of a watched group database.
+	// The membership is nil if the file is not in the format of /etc/group.
+	watchedGroupFile struct {
+		exists     bool
+		modTime    time.Time
+		size       int64
+		membership groupMembership
+	}
+
+	// groupWatcher polls group databases for changes, and invalidates the
+	// cached AUTH_SYS credentials of users whose group membership changed,
+	// so that new group grants take effect without waiting for expiry.
+	groupWatcher struct {
+		log      logging.Logger
+		cache    *credentialCache
+		paths    []string
+		interval time.Duration
+		files    map[string]*watchedGroupFile
+	}
+)
+
+func newGroupWatcher(log logging.Logger, cfg security.GroupWatchConfig, cc *credentialCache) *groupWatcher {
+	interval := cfg.PollInterval
+	if interval == 0 {
+		interval = security.DefaultGroupWatchPollInterval
+	}
+	return &groupWatcher{
+		log:      log,
+		cache:    cc,
+		paths:    cfg.Paths,
+		interval: interval,
+		files:    make(map[string]*watchedGroupFile),
+	}
+}
+
+// parseGroupFile parses group entries in the format of /etc/group. Groups are
+// identified by name and GID, so that a change to either is noticed.
+func parseGroupFile(data []byte) (groupMembership, error) {
+	membership := make(groupMembership)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, ":")
+		if len(fields) != 4 {
+			return nil, errors.Errorf("line %d: expected 4 fields, got %d", lineNum, len(fields))
+		}
+		if _, err := strconv.ParseUint(fields[2], 10, 32); err != nil {
+			return nil, errors.Errorf("line %d: invalid gid %q", lineNum, fields[2])
+		}
+
+		group := fields[0] + ":" + fields[2]
+		for _, name := range strings.Split(fields[3], ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if membership[name] == nil {
+				membership[name] = make(map[string]struct{})
+			}
+			membership[name][group] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+// changedUsers returns the users whose group membership differs between the
+// two.
+func changedUsers(old, cur groupMembership) []string {
+	var users []string
+	for name, groups := range cur {
+		oldGroups := old[name]
+		if len(oldGroups) != len(groups) {
+			users = append(users, name)
+			continue
+		}
+		for group := range groups {
+			if _, found := oldGroups[group]; !found {
+				users = append(users, name)
+				break
+			}
+		}
+	}
+	for name := range old {
+		if _, found := cur[name]; !found {
+			users = append(users, name)
+		}
+	}
+	return users
+}
+
+// load reads the current state of the watched group database.
+func (gw *groupWatcher) load(path string) (*watchedGroupFile, error) {
+	prev, seen := gw.files[path]
+	fi, err := os.Stat(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		if seen && !prev.exists {
+			return prev, nil
+		}
+		return &watchedGroupFile{}, nil
+	}
+
+	file := &watchedGroupFile{
+		exists:  true,
+		modTime: fi.ModTime(),
+		size:    fi.Size(),
+	}
+	if seen && prev.exists && prev.modTime.Equal(file.modTime) && prev.size == file.size {
+		return prev, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if file.membership, err = parseGroupFile(data); err != nil {
+		gw.log.Tracef("%s is not a group file (%s); any change invalidates all credentials", path, err)
+	}
+	return file, nil
+}
+
+// poll checks each watched group database for changes, and invalidates the
+// cached credentials affected by them. The first poll only records the state
+// of each database.
+func (gw *groupWatcher) poll() {
+	for _, path := range gw.paths {
+		prev, seen := gw.files[path]
+		cur, err := gw.load(path)
+		if err != nil {
+			gw.log.Errorf("unable to check group database %s: %s", path, err)
+			continue
+		}
+		gw.files[path] = cur
+		if !seen || cur == prev {
+			continue
+		}
+
+		if prev.membership == nil || cur.membership == nil {
+			gw.log.Debugf("group database %s changed", path)
+			gw.invalidateAll()
+			continue
+		}
+		users := changedUsers(prev.membership, cur.membership)
+		if len(users) == 0 {
+			continue
+		}
+		gw.log.Debugf("group membership changed in %s for %s", path, strings.Join(users, ", "))
+		gw.invalidateUsers(users)
+	}
+}
+
+func (gw *groupWatcher) invalidateAll() {
+	n := gw.cache.invalidateWhere(func(cred *cachedCredential) bool {
+		return cred.flavor == auth.Flavor_AUTH_SYS
+	})
+	if n > 0 {
+		gw.log.Infof("invalidated %d cached AUTH_SYS credentials after a group change", n)
+	}
+}
+
+func (gw *groupWatcher) invalidateUsers(users []string) {
+	uids := make(map[uint32]struct{})
+	for _, name := range users {
+		uid, err := lookupUserUid(name)
+		if err != nil {
+			// Without the UID, the user's credentials can't be found.
+			gw.log.Noticef("unable to look up user %q whose groups changed: %s", name, err)
+			gw.invalidateAll()
+			return
+		}
+		uids[uid] = struct{}{}
+	}
+
+	n := gw.cache.invalidateWhere(func(cred *cachedCredential) bool {
+		if cred.flavor != auth.Flavor_AUTH_SYS || cred.owner == nil {
+			return false
+		}
+		_, found := uids[*cred.owner]
+		return found
+	})
+	if n > 0 {
+		gw.log.Infof("invalidated %d cached AUTH_SYS credentials after a group change", n)
+	}
+}
+
+// run polls the watched group databases until the context is canceled.
+func (gw *groupWatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(gw.interval)
+	defer ticker.Stop()
+
+	for {
+		gw.poll()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// invalidateWhere removes the cached credentials matching the predicate,
+// returning the number removed.
+func (cc *credentialCache) invalidateWhere(match func(*cachedCredential) bool) int {
+	if cc == nil {
+		return 0
+	}
+
+	var n int
+	for _, key := range cc.cache.Keys() {
+		item, release, err := cc.cache.Get(context.Background(), key)
+		if err != nil {
+			continue
+		}
+		cred, ok := item.(*cachedCredential)
+		matched := ok && match(cred)
+		release()
+		if matched && cc.invalidate(key) {
+			n++
+		}
+	}
+	if n > 0 {
+		cc.notifyFlushed()
+	}
+	return n
+}
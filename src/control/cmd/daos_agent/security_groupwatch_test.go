@@ -0,0 +1,185 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/cache"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func TestAgent_parseGroupFile(t *testing.T) {
+	for name, tc := range map[string]struct {
+		data   string
+		expMem groupMembership
+		expErr error
+	}{
+		"empty": {
+			expMem: groupMembership{},
+		},
+		"groups": {
+			data: "# comment\n\nroot:x:0:\nwheel:x:10:alice, bob\nusers:x:100:bob\n",
+			expMem: groupMembership{
+				"alice": {"wheel:10": {}},
+				"bob":   {"wheel:10": {}, "users:100": {}},
+			},
+		},
+		"wrong number of fields": {
+			data:   "root:x:0\n",
+			expErr: errors.New("line 1: expected 4 fields"),
+		},
+		"invalid gid": {
+			data:   "root:x:0:\nwheel:x:ten:alice\n",
+			expErr: errors.New(`line 2: invalid gid "ten"`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mem, err := parseGroupFile([]byte(tc.data))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.CmpAny(t, "membership", tc.expMem, mem)
+		})
+	}
+}
+
+func TestAgent_changedUsers(t *testing.T) {
+	old := groupMembership{
+		"alice": {"wheel:10": {}},
+		"bob":   {"users:100": {}},
+		"carol": {"users:100": {}},
+		"dave":  {"users:100": {}},
+	}
+	cur := groupMembership{
+		"alice": {"wheel:10": {}},
+		"bob":   {"users:100": {}, "wheel:10": {}},
+		"carol": {"users:101": {}},
+		"erin":  {"users:100": {}},
+	}
+
+	users := changedUsers(old, cur)
+	sort.Strings(users)
+	test.CmpAny(t, "changed users", []string{"bob", "carol", "dave", "erin"}, users)
+}
+
+func TestAgent_groupWatcher_poll(t *testing.T) {
+	for name, tc := range map[string]struct {
+		initial   string
+		updated   string
+		lookupErr error
+		expKeys   []string
+	}{
+		"unchanged": {
+			initial: "wheel:x:10:alice\n",
+			updated: "wheel:x:10:alice\n",
+			expKeys: []string{"alice", "bob", "machine", "unowned"},
+		},
+		"membership changed": {
+			initial: "wheel:x:10:alice\n",
+			updated: "wheel:x:10:alice,bob\n",
+			expKeys: []string{"alice", "machine", "unowned"},
+		},
+		"unrelated change": {
+			initial: "wheel:x:10:alice\n",
+			updated: "wheel:x:10:alice\nusers:x:100:\n",
+			expKeys: []string{"alice", "bob", "machine", "unowned"},
+		},
+		"user lookup fails": {
+			initial:   "wheel:x:10:alice\n",
+			updated:   "wheel:x:10:alice,bob\n",
+			lookupErr: errors.New("no such user"),
+			expKeys:   []string{"machine"},
+		},
+		"not a group file": {
+			initial: "opaque cache\n",
+			updated: "opaque cache, changed\n",
+			expKeys: []string{"machine"},
+		},
+		"file removed": {
+			initial: "wheel:x:10:alice\n",
+			expKeys: []string{"machine"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			uids := map[string]uint32{"alice": 1, "bob": 2}
+			defer func(orig func(string) (uint32, error)) { lookupUserUid = orig }(lookupUserUid)
+			lookupUserUid = func(name string) (uint32, error) {
+				if tc.lookupErr != nil {
+					return 0, tc.lookupErr
+				}
+				return uids[name], nil
+			}
+
+			cc := &credentialCache{
+				log:   log,
+				cache: cache.NewShardedItemCache(log, credCacheShards),
+			}
+			addCred := func(key string, flavor auth.Flavor, owner *uint32) {
+				cred := &auth.Credential{Token: &auth.Token{Flavor: flavor}}
+				item, err := newCachedCredential(key, cred, time.Minute, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				item.owner = owner
+				if err := cc.cache.Set(item); err != nil {
+					t.Fatal(err)
+				}
+			}
+			aliceUid, bobUid := uids["alice"], uids["bob"]
+			addCred("alice", auth.Flavor_AUTH_SYS, &aliceUid)
+			addCred("bob", auth.Flavor_AUTH_SYS, &bobUid)
+			addCred("unowned", auth.Flavor_AUTH_SYS, nil)
+			addCred("machine", auth.Flavor_AUTH_NONE, &bobUid)
+
+			path := filepath.Join(t.TempDir(), "group")
+			if err := os.WriteFile(path, []byte(tc.initial), 0644); err != nil {
+				t.Fatal(err)
+			}
+			gw := newGroupWatcher(log, security.GroupWatchConfig{Paths: []string{path}}, cc)
+			test.AssertEqual(t, security.DefaultGroupWatchPollInterval, gw.interval, "unexpected interval")
+
+			// The initial state does not invalidate anything.
+			gw.poll()
+			test.AssertEqual(t, 4, cc.cache.Len(), "unexpected invalidation")
+
+			if tc.updated != "" {
+				if err := os.WriteFile(path, []byte(tc.updated), 0644); err != nil {
+					t.Fatal(err)
+				}
+				// Ensure the change is noticed, however coarse the mtime.
+				later := time.Now().Add(time.Second)
+				if err := os.Chtimes(path, later, later); err != nil {
+					t.Fatal(err)
+				}
+			} else if err := os.Remove(path); err != nil {
+				t.Fatal(err)
+			}
+			gw.poll()
+			test.CmpAny(t, "cached keys", tc.expKeys, cc.cache.Keys())
+
+			// Polling again without a change invalidates nothing more.
+			addCred("bob", auth.Flavor_AUTH_SYS, &bobUid)
+			expLen := cc.cache.Len()
+			gw.poll()
+			test.AssertEqual(t, expLen, cc.cache.Len(), "unexpected invalidation")
+		})
+	}
+}
@@ -0,0 +1,99 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// invalidate removes the cached credential for the key, returning true if one
+// was cached.
+func (cc *credentialCache) invalidate(key string) bool {
+	if cc == nil {
+		return false
+	}
+
+	cc.purgeMutex.Lock()
+	defer cc.purgeMutex.Unlock()
+	// A refresh already in progress must not restore the credential.
+	cc.generation++
+
+	found := cc.cache.Has(key)
+	if flavor, owner, ok := cc.describe(key); ok {
+		cc.events.publish(credInvalidated, key, flavor, owner)
+	}
+	cc.cache.Delete(key)
+	cc.lru.remove(key)
+	if err := cc.spool.remove(key); err != nil {
+		cc.log.Errorf("invalidating cached credential: %s", err)
+	}
+	return found
+}
+
+// invalidateCredential removes the caller's cached credential, and any cached
+// failure, so that its next request obtains a new credential rather than
+// waiting for the cached one to expire. The cache key is derived from the
+// caller's session in the same way as for a credential request, so a client
+// can only invalidate its own credential, and only that cached for the system
+// named in the request.
+func (m *SecurityModule) invalidateCredential(ctx context.Context, session *drpc.Session, body []byte) ([]byte, error) {
+	req := &auth.InvalidateCredReq{}
+	if err := proto.Unmarshal(body, req); err != nil {
+		return nil, drpc.UnmarshalingPayloadFailure()
+	}
+	if len(body) == 0 {
+		req.Flavor = auth.AuthSysCredentialFactory{}.GetAuthFlavor()
+	}
+
+	sys, err := m.credentialSystem(req.Sys)
+	if err != nil {
+		m.log.Errorf("cannot invalidate credential: %s", err)
+		return drpc.Marshal(&auth.InvalidateCredResp{Status: int32(daos.InvalidInput)})
+	}
+
+	factory, found := auth.FlavorToFactory[req.Flavor]
+	if !found {
+		m.log.Errorf("cannot invalidate credential of unknown flavor %s", req.Flavor)
+		return drpc.Marshal(&auth.InvalidateCredResp{Status: int32(daos.InvalidInput)})
+	}
+
+	signingTransport, err := m.signingTransport(ctx, sys, req.Tenant, m.sessionPeer(session, req.Tenant))
+	if err != nil {
+		m.log.Errorf("cannot invalidate credential: %s", err)
+		return drpc.Marshal(&auth.InvalidateCredResp{Status: int32(daos.InvalidInput)})
+	}
+	signingKey, err := signingTransport.PrivateKey()
+	if err != nil {
+		m.log.Errorf("failed to get signing key: %s", err)
+		return drpc.Marshal(&auth.InvalidateCredResp{Status: int32(daos.BadCert)})
+	}
+
+	credReq, err := factory.Init(m.log, m.config.credentials, session, req.Data, signingKey)
+	if err != nil {
+		if errors.Is(err, daos.MiscError) {
+			return drpc.Marshal(&auth.InvalidateCredResp{Status: int32(err.(daos.Status))})
+		}
+		m.log.Errorf("Unable to get credentials for client socket: %s", err)
+		return nil, err
+	}
+
+	key := m.keyer.key(newSystemCredentialRequest(sys, newTenantCredentialRequest(req.Tenant, credReq)))
+	m.negCache.forget(negativeCacheKey(req.Flavor, key))
+	resp := &auth.InvalidateCredResp{Invalidated: m.credCache.invalidate(key)}
+	if resp.Invalidated {
+		m.log.Debugf("invalidated cached %s credential at the request of the client", req.Flavor)
+	}
+
+	return drpc.Marshal(resp)
+}
@@ -0,0 +1,206 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// transport returns the transport config holding the agent's current
+// certificate and signing key.
+func (m *SecurityModule) transport() *security.TransportConfig {
+	if tc := m.reloadedTransport.Load(); tc != nil {
+		return tc
+	}
+	return m.config.transport
+}
+
+// credentialTransport returns the transport config holding the key with which
+// credentials are signed by default: the credential signing key, if one is
+// configured, or else the agent's transport key.
+func (m *SecurityModule) credentialTransport() *security.TransportConfig {
+	if m.credentialKey != nil {
+		return m.credentialKey.transport()
+	}
+	return m.transport()
+}
+
+// reloadSigningKey reloads the agent's certificates and signing keys, so that
+// they can be rotated without restarting the agent. The new certificates and
+// keys are checked as they are at startup, and if they can't be loaded, or fail
+// the checks, the old ones continue to be used. If a signing key changed, the
+// credentials signed with the old keys are flushed from the cache, so that they
+// are signed again with the new keys when next requested, and any warm-up
+// credentials are signed again immediately. If the agent's own certificate and
+// key changed, the control plane client is switched to them. It returns true if
+// a signing key changed.
+func (m *SecurityModule) reloadSigningKey() (bool, error) {
+	m.reloadMutex.Lock()
+	defer m.reloadMutex.Unlock()
+
+	changed, err := m.reloadTransportKey("credential signing key", m.transport(), m.reloadedTransport.Store)
+	if changed && m.transportReloaded != nil {
+		m.transportReloaded(m.transport())
+	}
+	keys := m.signingKeys
+	if m.credentialKey != nil {
+		keys = append([]*signingKey{m.credentialKey}, keys...)
+	}
+	for _, key := range keys {
+		keyChanged, keyErr := m.reloadTransportKey("credential signing key "+key.String(), key.transport(),
+			key.reloaded.Store)
+		if keyErr != nil && err == nil {
+			err = errors.Wrapf(keyErr, "signing key %s", key)
+		}
+		changed = changed || keyChanged
+	}
+
+	// Credentials signed with any changed key are flushed, even if another
+	// key failed to reload.
+	if changed {
+		if purged := m.credCache.purgeCredentials(); purged > 0 {
+			m.log.Noticef("flushed %d credentials cached with the old signing key", purged)
+		}
+	}
+	return changed, err
+}
+
+// reloadTransportKey reloads the certificate and signing key of the transport
+// config, storing the reloaded config if they pass the checks. It returns true
+// if the signing key changed.
+func (m *SecurityModule) reloadTransportKey(desc string, tc *security.TransportConfig, store func(*security.TransportConfig)) (bool, error) {
+	oldID, err := transportKeyID(tc)
+	if err != nil {
+		return false, err
+	}
+
+	reloaded, err := tc.Reload()
+	if err != nil {
+		return false, errors.Wrap(err, "reloading certificate and signing key")
+	}
+	cert, err := reloaded.Certificate()
+	if err != nil {
+		return false, errors.Wrap(err, "loading certificate")
+	}
+	key, err := reloaded.PrivateKey()
+	if err != nil {
+		return false, errors.Wrap(err, "loading signing key")
+	}
+	if err := checkClock(time.Now(), cert); err != nil {
+		return false, err
+	}
+	if err := checkKeyPair(key, cert); err != nil {
+		return false, err
+	}
+
+	store(reloaded)
+	newID, err := transportKeyID(reloaded)
+	if err != nil {
+		return false, err
+	}
+	if newID == oldID {
+		m.log.Debugf("%s unchanged after reload", desc)
+		return false, nil
+	}
+
+	m.log.Noticef("%s changed from %q to %q", desc, oldID, newID)
+	return true, nil
+}
+
+// signingKeyFiles is the last seen state of the agent's certificate and key
+// files.
+type signingKeyFiles map[string]struct {
+	modTime time.Time
+	size    int64
+}
+
+// statSigningKeyFiles returns the state of the certificate and key files of the
+// transport configs.
+func statSigningKeyFiles(tcs ...*security.TransportConfig) signingKeyFiles {
+	files := make(signingKeyFiles)
+	for _, tc := range tcs {
+		for _, path := range []string{tc.CARootPath, tc.CertificatePath, tc.PrivateKeyPath} {
+			if path == "" {
+				continue
+			}
+			entry := files[path]
+			if fi, err := os.Stat(path); err == nil {
+				entry.modTime = fi.ModTime()
+				entry.size = fi.Size()
+			}
+			files[path] = entry
+		}
+	}
+	return files
+}
+
+func (files signingKeyFiles) equal(other signingKeyFiles) bool {
+	if len(files) != len(other) {
+		return false
+	}
+	for path, entry := range files {
+		if otherEntry, found := other[path]; !found || !entry.modTime.Equal(otherEntry.modTime) ||
+			entry.size != otherEntry.size {
+			return false
+		}
+	}
+	return true
+}
+
+// signingKeyWatcher reloads the agent's certificates and signing keys whenever
+// their files change, so that rotating them doesn't need a signal to be sent
+// to the agent.
+type signingKeyWatcher struct {
+	mod      *SecurityModule
+	interval time.Duration
+	seen     signingKeyFiles
+}
+
+func newSigningKeyWatcher(mod *SecurityModule, interval time.Duration) *signingKeyWatcher {
+	return &signingKeyWatcher{
+		mod:      mod,
+		interval: interval,
+		seen:     statSigningKeyFiles(mod.signingTransports()...),
+	}
+}
+
+// run checks the files at the interval until the context is canceled. Changes
+// are retried until they can be loaded, so that a key and certificate which
+// are not replaced at the same instant are picked up once both have been
+// replaced.
+func (w *signingKeyWatcher) run(ctx context.Context) {
+	if w.mod.transport().AllowInsecure {
+		return
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current := statSigningKeyFiles(w.mod.signingTransports()...)
+		if current.equal(w.seen) {
+			continue
+		}
+		if _, err := w.mod.reloadSigningKey(); err != nil {
+			w.mod.log.Errorf("certificate or signing key changed, but could not be reloaded: %s", err)
+			continue
+		}
+		w.seen = current
+	}
+}
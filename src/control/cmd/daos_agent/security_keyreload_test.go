@@ -0,0 +1,202 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/cache"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// writeFileAtomic replaces the file, as a certificate rotation tool would.
+func writeFileAtomic(t *testing.T, path string, data []byte, perm os.FileMode) {
+	t.Helper()
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeTestKeyPair writes a new self-signed certificate and signing key to the
+// paths of the transport config, returning the ID of the key.
+func writeTestKeyPair(t *testing.T, tc *security.TransportConfig, writeCert bool) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if writeCert {
+		certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+		writeFileAtomic(t, tc.CARootPath, certPEM, 0644)
+		writeFileAtomic(t, tc.CertificatePath, certPEM, 0644)
+	}
+	writeFileAtomic(t, tc.PrivateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		security.MaxUserOnlyKeyPerm)
+
+	keyID, err := security.PrivateKeyID(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyID
+}
+
+func testKeyReloadModule(t *testing.T, log logging.Logger) (*SecurityModule, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	tc := security.DefaultAgentTransportConfig()
+	tc.AllowInsecure = false
+	tc.CARootPath = filepath.Join(dir, "daosCA.crt")
+	tc.CertificatePath = filepath.Join(dir, "agent.crt")
+	tc.PrivateKeyPath = filepath.Join(dir, "agent.key")
+	keyID := writeTestKeyPair(t, tc, true)
+
+	cc := &credentialCache{
+		log:          log,
+		cache:        cache.NewShardedItemCache(log, credCacheShards),
+		credLifetime: time.Minute,
+		lru:          newCredentialLRU(0, 0, 0, nil),
+		cacheMissFn: func(_ context.Context, _ logging.Logger, req auth.CredentialRequest) (*auth.Credential, error) {
+			return &auth.Credential{Origin: req.GetKey()}, nil
+		},
+	}
+	mod := &SecurityModule{
+		log:       log,
+		credCache: cc,
+		config: &securityConfig{
+			transport:   tc,
+			credentials: &security.CredentialConfig{},
+		},
+	}
+	// The key is loaded when the agent starts.
+	if _, err := mod.signingKeyID(); err != nil {
+		t.Fatal(err)
+	}
+	return mod, keyID
+}
+
+func cacheTestCredential(t *testing.T, mod *SecurityModule) {
+	t.Helper()
+
+	req := &auth.AuthSysCredentialRequest{
+		DomainInfo: security.InitDomainInfo(&syscall.Ucred{Uid: 1, Gid: 1}, ""),
+	}
+	if _, err := mod.credCache.getSignedCredential(test.Context(t), mod.log, req); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAgent_SecurityModule_reloadSigningKey(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	mod, keyID := testKeyReloadModule(t, log)
+	cacheTestCredential(t, mod)
+
+	// Credentials are kept if the signing key is unchanged.
+	changed, err := mod.reloadSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertFalse(t, changed, "signing key should be unchanged")
+	test.AssertEqual(t, 1, mod.credCache.cache.Len(), "credential flushed")
+
+	// A key which doesn't match the certificate isn't used.
+	writeTestKeyPair(t, mod.config.transport, false)
+	if _, err := mod.reloadSigningKey(); err == nil {
+		t.Fatal("expected reload of mismatched key to fail")
+	}
+	gotID, err := mod.signingKeyID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, keyID, gotID, "old signing key not kept")
+	test.AssertEqual(t, 1, mod.credCache.cache.Len(), "credential flushed")
+
+	// Credentials signed with the old key are flushed once it is rotated.
+	newID := writeTestKeyPair(t, mod.config.transport, true)
+	changed, err = mod.reloadSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, changed, "signing key should have changed")
+	gotID, err = mod.signingKeyID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, newID, gotID, "new signing key not used")
+	test.AssertEqual(t, 0, mod.credCache.cache.Len(), "credential signed with old key not flushed")
+}
+
+func TestAgent_signingKeyWatcher(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	mod, keyID := testKeyReloadModule(t, log)
+	cacheTestCredential(t, mod)
+
+	ctx, cancel := context.WithCancel(test.Context(t))
+	done := make(chan struct{})
+	watcher := newSigningKeyWatcher(mod, 10*time.Millisecond)
+	go func() {
+		watcher.run(ctx)
+		close(done)
+	}()
+
+	newID := writeTestKeyPair(t, mod.config.transport, true)
+	// The cache is flushed once the new key is in use.
+	for deadline := time.Now().Add(5 * time.Second); mod.credCache.cache.Len() > 0 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	test.AssertEqual(t, 0, mod.credCache.cache.Len(), "credential signed with old key not flushed")
+	gotID, err := mod.signingKeyID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, gotID != keyID, "old signing key still used")
+	test.AssertEqual(t, newID, gotID, "rotated signing key not reloaded")
+
+	cancel()
+	<-done
+}
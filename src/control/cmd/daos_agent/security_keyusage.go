@@ -0,0 +1,174 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+const (
+	// keyUsageWarmupIntervals is the number of intervals in which a key
+	// must have been used before changes in its usage are reported, so that
+	// its average usage is established.
+	keyUsageWarmupIntervals = 3
+	// keyUsageAverageWeight is the weight of the latest interval in the
+	// moving average of the usage of a key.
+	keyUsageAverageWeight = 0.25
+)
+
+type (
+	// keyUsageStats is the usage of a signing key.
+	keyUsageStats struct {
+		// flavors counts the signatures made in the current interval, by
+		// flavor.
+		flavors   map[auth.Flavor]uint64
+		average   float64
+		intervals int
+	}
+
+	// keyUsage counts the signatures made with each signing key, by flavor,
+	// and exports them as metrics. If a log interval is configured, the
+	// signatures made with each key are logged at that interval, and an
+	// interval in which a key made many more or fewer signatures than its
+	// recent average is reported, as it may be the sign of a compromised
+	// agent. A key which made no signatures in an interval, e.g. because it
+	// was rotated, is forgotten.
+	keyUsage struct {
+		sync.Mutex
+		log        logging.Logger
+		cfg        *security.KeyUsageConfig
+		keys       map[string]*keyUsageStats
+		signatures *prometheus.CounterVec
+	}
+)
+
+var _ prometheus.Collector = (*keyUsage)(nil)
+
+func newKeyUsage(log logging.Logger, cfg *security.KeyUsageConfig) *keyUsage {
+	return &keyUsage{
+		log:  log,
+		cfg:  cfg,
+		keys: make(map[string]*keyUsageStats),
+		signatures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "agent_credential_signatures",
+			Help: "Credentials signed with each signing key",
+		}, []string{"key_id", "flavor"}),
+	}
+}
+
+// record counts a signature made with the key for a credential of the flavor.
+func (u *keyUsage) record(keyID string, flavor auth.Flavor) {
+	u.signatures.WithLabelValues(keyID, flavor.String()).Inc()
+
+	u.Lock()
+	defer u.Unlock()
+
+	stats, found := u.keys[keyID]
+	if !found {
+		stats = &keyUsageStats{flavors: make(map[auth.Flavor]uint64)}
+		u.keys[keyID] = stats
+	}
+	stats.flavors[flavor]++
+}
+
+// report logs the signatures made with each key in the interval which has just
+// ended, reports abrupt changes in their usage, and starts a new interval.
+func (u *keyUsage) report(interval time.Duration) {
+	u.Lock()
+	defer u.Unlock()
+
+	keyIDs := make([]string, 0, len(u.keys))
+	for keyID := range u.keys {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+
+	factor := u.cfg.GetChangeFactor()
+	minSignatures := float64(u.cfg.GetMinSignatures())
+	for _, keyID := range keyIDs {
+		stats := u.keys[keyID]
+
+		var count uint64
+		flavors := make([]string, 0, len(stats.flavors))
+		for flavor, n := range stats.flavors {
+			count += n
+			flavors = append(flavors, fmt.Sprintf("%s: %d", flavor, n))
+		}
+		if count == 0 {
+			u.log.Debugf("signing key %s made no signatures in the last %s", keyID, interval)
+			delete(u.keys, keyID)
+			continue
+		}
+		sort.Strings(flavors)
+		u.log.Noticef("audit: signing key %s made %d signatures in the last %s (%s)", keyID, count, interval,
+			strings.Join(flavors, ", "))
+
+		current := float64(count)
+		if stats.intervals >= keyUsageWarmupIntervals && (current >= minSignatures || stats.average >= minSignatures) &&
+			(current >= stats.average*factor || current*factor <= stats.average) {
+			u.log.Errorf("usage of signing key %s changed abruptly: %d signatures in the last %s, against an average of %.0f",
+				keyID, count, interval, stats.average)
+		}
+
+		if stats.intervals == 0 {
+			stats.average = current
+		} else {
+			stats.average = keyUsageAverageWeight*current + (1-keyUsageAverageWeight)*stats.average
+		}
+		stats.intervals++
+		stats.flavors = make(map[auth.Flavor]uint64)
+	}
+}
+
+// run reports the usage of the keys at the configured interval, until the
+// context is canceled.
+func (u *keyUsage) run(ctx context.Context) {
+	if u.cfg.LogInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(u.cfg.LogInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			u.report(u.cfg.LogInterval)
+		}
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (u *keyUsage) Describe(ch chan<- *prometheus.Desc) {
+	if u == nil {
+		return
+	}
+
+	u.signatures.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (u *keyUsage) Collect(ch chan<- prometheus.Metric) {
+	if u == nil {
+		return
+	}
+
+	u.signatures.Collect(ch)
+}
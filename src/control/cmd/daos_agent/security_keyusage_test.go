@@ -0,0 +1,99 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func TestAgent_keyUsage_report(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg        security.KeyUsageConfig
+		intervals  []uint64 // signatures made in each interval
+		expChanged bool
+	}{
+		"steady": {
+			intervals: []uint64{200, 220, 180, 210, 190},
+		},
+		"spike": {
+			intervals:  []uint64{200, 220, 180, 210, 5000},
+			expChanged: true,
+		},
+		"drop": {
+			intervals:  []uint64{200, 220, 180, 210, 5},
+			expChanged: true,
+		},
+		"spike before average established": {
+			intervals: []uint64{200, 220, 5000},
+		},
+		"spike below minimum": {
+			intervals: []uint64{2, 3, 2, 2, 50},
+		},
+		"spike with lower minimum": {
+			cfg:        security.KeyUsageConfig{MinSignatures: 10},
+			intervals:  []uint64{2, 3, 2, 2, 50},
+			expChanged: true,
+		},
+		"spike below change factor": {
+			cfg:       security.KeyUsageConfig{ChangeFactor: 50},
+			intervals: []uint64{200, 220, 180, 210, 5000},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			usage := newKeyUsage(log, &tc.cfg)
+			for _, count := range tc.intervals {
+				for i := uint64(0); i < count; i++ {
+					usage.record("key-a", auth.Flavor_AUTH_SYS)
+				}
+				usage.report(time.Minute)
+			}
+
+			test.AssertEqual(t, tc.expChanged, strings.Contains(buf.String(), "changed abruptly"),
+				"unexpected report of changed usage")
+		})
+	}
+}
+
+func TestAgent_keyUsage_flavors(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	usage := newKeyUsage(log, &security.KeyUsageConfig{})
+	usage.record("key-a", auth.Flavor_AUTH_SYS)
+	usage.record("key-a", auth.Flavor_AUTH_SYS)
+	usage.record("key-a", auth.Flavor_AUTH_MACHINE)
+	usage.record("key-b", auth.Flavor_AUTH_SYS)
+
+	test.AssertEqual(t, 2.0, counterValue(t, usage.signatures, "key-a", "AUTH_SYS"),
+		"unexpected signature count")
+	test.AssertEqual(t, 1.0, counterValue(t, usage.signatures, "key-a", "AUTH_MACHINE"),
+		"unexpected signature count")
+
+	usage.report(time.Minute)
+	test.AssertTrue(t, strings.Contains(buf.String(),
+		"signing key key-a made 3 signatures in the last 1m0s (AUTH_MACHINE: 1, AUTH_SYS: 2)"),
+		"usage of key-a not logged")
+	test.AssertTrue(t, strings.Contains(buf.String(), "signing key key-b made 1 signatures"),
+		"usage of key-b not logged")
+
+	// A key which is no longer used is forgotten.
+	usage.record("key-a", auth.Flavor_AUTH_SYS)
+	usage.report(time.Minute)
+	test.AssertEqual(t, 1, len(usage.keys), "unused key not forgotten")
+	_, found := usage.keys["key-a"]
+	test.AssertTrue(t, found, "used key forgotten")
+}
@@ -0,0 +1,274 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+type (
+	// credentialLRU tracks the order in which cached credentials were last
+	// used, and the approximate memory they hold, so that the least recently
+	// used can be evicted once the cache is full. The credentials of each
+	// owner are also tracked in the order they were cached, so that the
+	// oldest can be evicted once the owner holds maxPerOwner, rather than
+	// one owner evicting everyone else's. A limit of zero is unlimited.
+	// Credentials which expired are only forgotten once they are evicted,
+	// or removed by the reaper.
+	credentialLRU struct {
+		sync.Mutex
+		maxEntries  int
+		maxPerOwner int
+		maxMem      uint64
+		used        uint64
+		order       *list.List
+		elems       map[string]*list.Element
+		owners      map[uint32]*list.List
+		metrics     *credentialCacheMetrics
+		events      *credentialEventStream
+	}
+
+	lruEntry struct {
+		key       string
+		flavor    auth.Flavor
+		owner     *uint32
+		ownerElem *list.Element
+		size      uint64
+	}
+
+	// cacheEvent is an event counted by flavor in the credential cache
+	// metrics.
+	cacheEvent string
+
+	// credentialCacheMetrics exports the usage and effectiveness of the
+	// credential cache.
+	credentialCacheMetrics struct {
+		entries prometheus.Gauge
+		bytes   prometheus.Gauge
+		events  map[cacheEvent]*prometheus.CounterVec
+	}
+)
+
+const (
+	cacheHit      cacheEvent = "hits"
+	cacheMiss     cacheEvent = "misses"
+	cacheEviction cacheEvent = "evictions"
+	cacheExpiry   cacheEvent = "expirations"
+	cacheRefresh  cacheEvent = "refreshes"
+	cacheError    cacheEvent = "errors"
+)
+
+var cacheEventHelp = map[cacheEvent]string{
+	cacheHit:      "Credential requests served from the credential cache",
+	cacheMiss:     "Credential requests not served from the credential cache",
+	cacheEviction: "Credentials evicted from the full credential cache",
+	cacheExpiry:   "Expired credentials removed from the credential cache by the reaper",
+	cacheRefresh:  "Cached credentials refreshed in the background",
+	cacheError:    "Credentials which could not be obtained for the credential cache",
+}
+
+var _ prometheus.Collector = (*credentialCacheMetrics)(nil)
+
+func newCredentialLRU(maxEntries, maxPerOwner int, maxMem uint64, metrics *credentialCacheMetrics) *credentialLRU {
+	return &credentialLRU{
+		maxEntries:  maxEntries,
+		maxPerOwner: maxPerOwner,
+		maxMem:      maxMem,
+		order:       list.New(),
+		elems:       make(map[string]*list.Element),
+		owners:      make(map[uint32]*list.List),
+		metrics:     metrics,
+	}
+}
+
+func (l *credentialLRU) full() bool {
+	return (l.maxEntries > 0 && l.order.Len() > l.maxEntries) || (l.maxMem > 0 && l.used > l.maxMem)
+}
+
+// touch marks the key as the most recently used, recording the flavor, owner
+// and size of its credential, and returns the keys which must be evicted to
+// keep the cache within its limits. The owner may be nil if it is not known.
+// The key itself is evicted if its credential alone exceeds the memory limit.
+func (l *credentialLRU) touch(key string, flavor auth.Flavor, owner *uint32, size uint64) []string {
+	if l == nil {
+		return nil
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	var evicted []string
+	if elem, found := l.elems[key]; found {
+		entry := elem.Value.(*lruEntry)
+		l.used = l.used - entry.size + size
+		entry.size = size
+		l.order.MoveToFront(elem)
+	} else {
+		entry := &lruEntry{key: key, flavor: flavor, owner: owner, size: size}
+		l.elems[key] = l.order.PushFront(entry)
+		l.used += size
+		evicted = l.addToOwner(entry)
+	}
+
+	for l.order.Len() > 0 && l.full() {
+		oldest := l.order.Back().Value.(*lruEntry)
+		l.evict(oldest)
+		evicted = append(evicted, oldest.key)
+	}
+	l.metrics.update(l.order.Len(), l.used)
+
+	return evicted
+}
+
+// addToOwner records the new entry as the newest of its owner, and returns the
+// keys of the owner's oldest entries which must be evicted to keep the owner
+// within its limit.
+func (l *credentialLRU) addToOwner(entry *lruEntry) []string {
+	if entry.owner == nil || l.maxPerOwner <= 0 {
+		return nil
+	}
+
+	owned, found := l.owners[*entry.owner]
+	if !found {
+		owned = list.New()
+		l.owners[*entry.owner] = owned
+	}
+	entry.ownerElem = owned.PushBack(entry)
+
+	var evicted []string
+	for owned.Len() > l.maxPerOwner {
+		oldest := owned.Front().Value.(*lruEntry)
+		l.evict(oldest)
+		evicted = append(evicted, oldest.key)
+	}
+	return evicted
+}
+
+// evict forgets the entry, counting and publishing its eviction.
+func (l *credentialLRU) evict(entry *lruEntry) {
+	l.forget(entry)
+	l.metrics.count(cacheEviction, entry.flavor)
+	l.events.publish(credEvicted, entry.key, entry.flavor, entry.owner)
+}
+
+func (l *credentialLRU) forget(entry *lruEntry) {
+	l.order.Remove(l.elems[entry.key])
+	delete(l.elems, entry.key)
+	l.used -= entry.size
+
+	if entry.ownerElem == nil {
+		return
+	}
+	owned := l.owners[*entry.owner]
+	owned.Remove(entry.ownerElem)
+	if owned.Len() == 0 {
+		delete(l.owners, *entry.owner)
+	}
+}
+
+// remove forgets the key.
+func (l *credentialLRU) remove(key string) {
+	if l == nil {
+		return
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	elem, found := l.elems[key]
+	if !found {
+		return
+	}
+	l.forget(elem.Value.(*lruEntry))
+	l.metrics.update(l.order.Len(), l.used)
+}
+
+// reset forgets all keys.
+func (l *credentialLRU) reset() {
+	if l == nil {
+		return
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	l.order.Init()
+	clear(l.elems)
+	clear(l.owners)
+	l.used = 0
+	l.metrics.update(0, 0)
+}
+
+func newCredentialCacheMetrics() *credentialCacheMetrics {
+	m := &credentialCacheMetrics{
+		entries: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "agent_credential_cache_entries",
+			Help: "Number of credentials held in the credential cache",
+		}),
+		bytes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "agent_credential_cache_bytes",
+			Help: "Approximate memory held by credentials in the credential cache",
+		}),
+		events: make(map[cacheEvent]*prometheus.CounterVec),
+	}
+	for event, help := range cacheEventHelp {
+		m.events[event] = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "agent_credential_cache_" + string(event),
+			Help: help,
+		}, []string{"flavor"})
+	}
+	return m
+}
+
+// count records a cache event for a credential of the flavor.
+func (m *credentialCacheMetrics) count(event cacheEvent, flavor auth.Flavor) {
+	if m == nil {
+		return
+	}
+
+	m.events[event].WithLabelValues(flavor.String()).Inc()
+}
+
+func (m *credentialCacheMetrics) update(entries int, bytes uint64) {
+	if m == nil {
+		return
+	}
+
+	m.entries.Set(float64(entries))
+	m.bytes.Set(float64(bytes))
+}
+
+// Describe implements the prometheus.Collector interface.
+func (m *credentialCacheMetrics) Describe(ch chan<- *prometheus.Desc) {
+	if m == nil {
+		return
+	}
+
+	m.entries.Describe(ch)
+	m.bytes.Describe(ch)
+	for _, events := range m.events {
+		events.Describe(ch)
+	}
+}
+
+// Collect implements the prometheus.Collector interface.
+func (m *credentialCacheMetrics) Collect(ch chan<- prometheus.Metric) {
+	if m == nil {
+		return
+	}
+
+	m.entries.Collect(ch)
+	m.bytes.Collect(ch)
+	for _, events := range m.events {
+		events.Collect(ch)
+	}
+}
@@ -0,0 +1,181 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func gaugeValue(t *testing.T, gauge prometheus.Gauge) float64 {
+	t.Helper()
+	var m dto.Metric
+	if err := gauge.Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetGauge().GetValue()
+}
+
+func counterValue(t *testing.T, counters *prometheus.CounterVec, labels ...string) float64 {
+	t.Helper()
+	counter, err := counters.GetMetricWithLabelValues(labels...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m dto.Metric
+	if err := counter.Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetCounter().GetValue()
+}
+
+type lruTouch struct {
+	key  string
+	size uint64
+}
+
+func TestAgent_credentialLRU(t *testing.T) {
+	for name, tc := range map[string]struct {
+		maxEntries int
+		maxMem     uint64
+		touched    []lruTouch
+		expEvicted []string
+		expOrder   []string
+		expUsed    uint64
+	}{
+		"unlimited": {
+			touched:  []lruTouch{{"a", 10}, {"b", 20}, {"c", 30}},
+			expOrder: []string{"c", "b", "a"},
+			expUsed:  60,
+		},
+		"oldest evicted": {
+			maxEntries: 2,
+			touched:    []lruTouch{{"a", 10}, {"b", 20}, {"c", 30}},
+			expEvicted: []string{"a"},
+			expOrder:   []string{"c", "b"},
+			expUsed:    50,
+		},
+		"recently used kept": {
+			maxEntries: 2,
+			touched:    []lruTouch{{"a", 10}, {"b", 20}, {"a", 10}, {"c", 30}},
+			expEvicted: []string{"b"},
+			expOrder:   []string{"c", "a"},
+			expUsed:    40,
+		},
+		"repeated key": {
+			maxEntries: 1,
+			touched:    []lruTouch{{"a", 10}, {"a", 10}, {"a", 10}},
+			expOrder:   []string{"a"},
+			expUsed:    10,
+		},
+		"memory limit": {
+			maxMem:     50,
+			touched:    []lruTouch{{"a", 10}, {"b", 20}, {"c", 30}},
+			expEvicted: []string{"a"},
+			expOrder:   []string{"c", "b"},
+			expUsed:    50,
+		},
+		"resized": {
+			maxMem:     50,
+			touched:    []lruTouch{{"a", 10}, {"b", 20}, {"a", 40}},
+			expEvicted: []string{"b"},
+			expOrder:   []string{"a"},
+			expUsed:    40,
+		},
+		"too large to cache": {
+			maxMem:     50,
+			touched:    []lruTouch{{"a", 10}, {"b", 60}},
+			expEvicted: []string{"a", "b"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			metrics := newCredentialCacheMetrics()
+			lru := newCredentialLRU(tc.maxEntries, 0, tc.maxMem, metrics)
+
+			var evicted []string
+			for _, touch := range tc.touched {
+				evicted = append(evicted, lru.touch(touch.key, auth.Flavor_AUTH_SYS, nil, touch.size)...)
+			}
+
+			var order []string
+			for elem := lru.order.Front(); elem != nil; elem = elem.Next() {
+				order = append(order, elem.Value.(*lruEntry).key)
+			}
+			test.CmpAny(t, "evicted", tc.expEvicted, evicted)
+			test.CmpAny(t, "order", tc.expOrder, order)
+			test.AssertEqual(t, len(tc.expOrder), len(lru.elems), "unexpected number of keys")
+			test.AssertEqual(t, tc.expUsed, lru.used, "unexpected memory used")
+			test.AssertEqual(t, float64(len(tc.expOrder)), gaugeValue(t, metrics.entries), "unexpected entries metric")
+			test.AssertEqual(t, float64(tc.expUsed), gaugeValue(t, metrics.bytes), "unexpected bytes metric")
+			test.AssertEqual(t, float64(len(tc.expEvicted)),
+				counterValue(t, metrics.events[cacheEviction], auth.Flavor_AUTH_SYS.String()), "unexpected evictions metric")
+		})
+	}
+
+	var nilLRU *credentialLRU
+	test.AssertEqual(t, 0, len(nilLRU.touch("a", auth.Flavor_AUTH_SYS, nil, 10)), "nil LRU should evict nothing")
+	nilLRU.remove("a")
+	nilLRU.reset()
+
+	metrics := newCredentialCacheMetrics()
+	lru := newCredentialLRU(2, 0, 0, metrics)
+	lru.touch("a", auth.Flavor_AUTH_SYS, nil, 10)
+	lru.touch("b", auth.Flavor_AUTH_SYS, nil, 20)
+	lru.remove("a")
+	lru.remove("c")
+	test.AssertEqual(t, 20.0, gaugeValue(t, metrics.bytes), "removed key still accounted for")
+	test.AssertEqual(t, 0, len(lru.touch("c", auth.Flavor_AUTH_SYS, nil, 10)), "removed key should make room")
+
+	metrics = newCredentialCacheMetrics()
+	lru = newCredentialLRU(1, 0, 0, metrics)
+	lru.touch("a", auth.Flavor_AUTH_SYS, nil, 10)
+	lru.reset()
+	test.AssertEqual(t, 0.0, gaugeValue(t, metrics.bytes), "reset LRU should hold nothing")
+	test.AssertEqual(t, 0, len(lru.touch("b", auth.Flavor_AUTH_SYS, nil, 10)), "reset LRU should evict nothing")
+}
+
+func TestAgent_credentialLRU_maxPerOwner(t *testing.T) {
+	alice, bob := uint32(1000), uint32(1001)
+	metrics := newCredentialCacheMetrics()
+	lru := newCredentialLRU(0, 2, 0, metrics)
+
+	var evicted []string
+	for _, touch := range []struct {
+		key   string
+		owner *uint32
+	}{
+		{"a1", &alice},
+		{"b1", &bob},
+		{"a2", &alice},
+		{"a1", &alice},
+		{"unowned", nil},
+		{"a3", &alice},
+		{"a4", &alice},
+	} {
+		evicted = append(evicted, lru.touch(touch.key, auth.Flavor_AUTH_SYS, touch.owner, 10)...)
+	}
+
+	// The oldest of alice's credentials are evicted, even though a1 was
+	// used more recently, and bob's are kept.
+	test.CmpAny(t, "evicted", []string{"a1", "a2"}, evicted)
+	test.AssertEqual(t, 4, len(lru.elems), "unexpected number of keys")
+	test.AssertEqual(t, 2, lru.owners[alice].Len(), "unexpected number of alice's keys")
+	test.AssertEqual(t, 1, lru.owners[bob].Len(), "unexpected number of bob's keys")
+	test.AssertEqual(t, 2.0, counterValue(t, metrics.events[cacheEviction], auth.Flavor_AUTH_SYS.String()), "unexpected evictions metric")
+
+	lru.remove("b1")
+	_, found := lru.owners[bob]
+	test.AssertFalse(t, found, "owner without keys not forgotten")
+
+	lru.reset()
+	test.AssertEqual(t, 0, len(lru.owners), "owners not reset")
+}
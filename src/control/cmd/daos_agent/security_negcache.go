@@ -0,0 +1,162 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/cache"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+type (
+	// failureClassifierFn returns the status of a failed credential request.
+	failureClassifierFn func(error) string
+
+	// negativeCache caches failed credential requests, so that a client
+	// repeating a bad request is refused without the request reaching
+	// expensive backends, such as the access manager, each time.
+	negativeCache struct {
+		log        logging.Logger
+		cfg        *security.NegativeCacheConfig
+		cache      *cache.ItemCache
+		maxEntries int
+		classify   failureClassifierFn
+	}
+
+	// cachedFailure is a failed credential request, which implements the
+	// cache.ExpirableItem interface.
+	cachedFailure struct {
+		cacheItem
+		key       string
+		status    string
+		err       error
+		expiredAt time.Time
+	}
+)
+
+var _ cache.ExpirableItem = (*cachedFailure)(nil)
+
+func newNegativeCache(log logging.Logger, cfg *security.NegativeCacheConfig) *negativeCache {
+	if cfg == nil || !cfg.Enabled() {
+		return nil
+	}
+
+	maxEntries := cfg.MaxEntries
+	if maxEntries == 0 {
+		maxEntries = security.DefaultNegativeCacheMaxEntries
+	}
+	return &negativeCache{
+		log:        log,
+		cfg:        cfg,
+		cache:      cache.NewItemCache(log),
+		maxEntries: maxEntries,
+		classify:   auth.ClassifyFailure,
+	}
+}
+
+// negativeCacheKey derives the key of a credential request in the negative
+// cache, without retaining any token the request key may contain.
+func negativeCacheKey(flavor auth.Flavor, reqKey string) string {
+	sum := sha256.Sum256([]byte(reqKey))
+	return flavor.String() + ":" + hex.EncodeToString(sum[:])
+}
+
+// Key returns the key for the cached failure.
+func (f *cachedFailure) Key() string {
+	if f == nil {
+		return ""
+	}
+
+	return f.key
+}
+
+// IsExpired returns true if the cached failure is expired.
+func (f *cachedFailure) IsExpired() bool {
+	if f == nil || f.expiredAt.IsZero() {
+		return true
+	}
+
+	return time.Now().After(f.expiredAt)
+}
+
+// check returns the cached failure of the request with the key, if any.
+func (nc *negativeCache) check(key string) error {
+	if nc == nil {
+		return nil
+	}
+
+	item, release, err := nc.cache.Get(context.Background(), key)
+	if err != nil {
+		return nil
+	}
+	defer release()
+
+	failure, ok := item.(*cachedFailure)
+	if !ok {
+		return nil
+	}
+	return errors.Wrapf(failure.err, "cached %s failure", failure.status)
+}
+
+// record caches the failure of the request with the key, for the TTL of its
+// status. If the cache is full, expired failures are removed to make room, and
+// the failure is not cached if there is still none.
+func (nc *negativeCache) record(key string, err error) {
+	if nc == nil || err == nil {
+		return
+	}
+
+	status := nc.classify(err)
+	ttl := nc.cfg.StatusTTL(status)
+	if ttl <= 0 {
+		return
+	}
+
+	if nc.cache.Len() >= nc.maxEntries {
+		nc.prune()
+		if nc.cache.Len() >= nc.maxEntries {
+			nc.log.Debugf("negative cache is full; not caching %s failure", status)
+			return
+		}
+	}
+
+	if err := nc.cache.Set(&cachedFailure{
+		key:       key,
+		status:    status,
+		err:       err,
+		expiredAt: time.Now().Add(ttl),
+	}); err != nil {
+		nc.log.Errorf("caching failed credential request: %s", err)
+	}
+}
+
+// forget removes the cached failure of the request with the key, if any.
+func (nc *negativeCache) forget(key string) {
+	if nc == nil {
+		return
+	}
+
+	nc.cache.Delete(key)
+}
+
+// prune removes the expired failures.
+func (nc *negativeCache) prune() {
+	for _, key := range nc.cache.Keys() {
+		// Expired items are removed when they are looked up.
+		if _, release, err := nc.cache.Get(context.Background(), key); err == nil {
+			release()
+		}
+	}
+}
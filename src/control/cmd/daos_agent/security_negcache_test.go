@@ -0,0 +1,126 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func TestAgent_negativeCache(t *testing.T) {
+	rejected := errors.New("invalid token")
+	unavailable := errors.New("backend unreachable")
+	classify := func(err error) string {
+		if err == unavailable {
+			return security.CredentialFailureUnavailable
+		}
+		return security.CredentialFailureRejected
+	}
+
+	for name, tc := range map[string]struct {
+		cfg       security.NegativeCacheConfig
+		failure   error
+		wait      time.Duration
+		expCached error
+	}{
+		"disabled": {
+			failure: rejected,
+		},
+		"rejection cached": {
+			cfg:       security.NegativeCacheConfig{TTL: time.Minute},
+			failure:   rejected,
+			expCached: errors.New("cached rejected failure: invalid token"),
+		},
+		"rejection expired": {
+			cfg:     security.NegativeCacheConfig{TTL: time.Millisecond},
+			failure: rejected,
+			wait:    5 * time.Millisecond,
+		},
+		"status not cached": {
+			cfg: security.NegativeCacheConfig{
+				TTL: time.Minute,
+				StatusTTLs: map[string]time.Duration{
+					security.CredentialFailureUnavailable: 0,
+				},
+			},
+			failure: unavailable,
+		},
+		"status ttl": {
+			cfg: security.NegativeCacheConfig{
+				StatusTTLs: map[string]time.Duration{
+					security.CredentialFailureUnavailable: time.Minute,
+				},
+			},
+			failure:   unavailable,
+			expCached: errors.New("cached unavailable failure"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			nc := newNegativeCache(log, &tc.cfg)
+			if nc != nil {
+				nc.classify = classify
+			}
+			key := negativeCacheKey(auth.Flavor_AUTH_SYS, "token")
+			nc.record(key, tc.failure)
+			time.Sleep(tc.wait)
+
+			err := nc.check(key)
+			test.CmpErr(t, tc.expCached, err)
+			if tc.expCached != nil {
+				test.AssertTrue(t, errors.Is(err, tc.failure), "cached failure does not wrap the original")
+			}
+			test.AssertEqual(t, nil, nc.check(negativeCacheKey(auth.Flavor_AUTH_SYS, "other")), "unexpected cached failure")
+
+			nc.forget(key)
+			test.AssertEqual(t, nil, nc.check(key), "failure cached after it was forgotten")
+		})
+	}
+}
+
+func TestAgent_negativeCache_full(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	nc := newNegativeCache(log, &security.NegativeCacheConfig{
+		TTL: time.Minute,
+		StatusTTLs: map[string]time.Duration{
+			security.CredentialFailureTimeout: time.Millisecond,
+		},
+		MaxEntries: 2,
+	})
+	nc.classify = func(err error) string { return err.Error() }
+
+	nc.record("a", errors.New(security.CredentialFailureTimeout))
+	nc.record("b", errors.New(security.CredentialFailureRejected))
+	nc.record("c", errors.New(security.CredentialFailureRejected))
+	test.AssertTrue(t, nc.check("c") == nil, "failure cached in a full cache")
+
+	// Once a failure has expired, it makes room for another.
+	time.Sleep(5 * time.Millisecond)
+	nc.record("c", errors.New(security.CredentialFailureRejected))
+	test.AssertTrue(t, nc.check("c") != nil, "failure not cached after pruning")
+	test.AssertEqual(t, 2, nc.cache.Len(), "unexpected number of cached failures")
+}
+
+func TestAgent_negativeCacheKey(t *testing.T) {
+	key := negativeCacheKey(auth.Flavor_AUTH_ACCMAN, "secret-token")
+
+	test.AssertFalse(t, strings.Contains(key, "secret-token"), "token retained in key")
+	test.AssertTrue(t, strings.HasPrefix(key, auth.Flavor_AUTH_ACCMAN.String()+":"), "key not scoped to flavor")
+	test.AssertTrue(t, key != negativeCacheKey(auth.Flavor_AUTH_SYS, "secret-token"), "keys of flavors collide")
+}
@@ -0,0 +1,139 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const (
+	// nonceRetryInterval is how long to wait before retrying to fetch
+	// nonces after failing to.
+	nonceRetryInterval = 10 * time.Second
+	// nonceFetchTimeout bounds each attempt to fetch nonces.
+	nonceFetchTimeout = time.Minute
+	// nonceBatchSize is how many nonces are fetched at a time.
+	nonceBatchSize = 128
+)
+
+// nonceSupply holds the nonces issued by the servers which the agent records
+// in the credentials it hands out, one in each, as the servers accept each
+// nonce only once. Nonces are fetched in batches, and the supply is refilled
+// in the background once it runs low or half of the lifetime of the nonces
+// has passed, so that a credential is rarely held up waiting for one.
+type nonceSupply struct {
+	sync.Mutex
+	log     logging.Logger
+	invoker control.UnaryInvoker
+	fetch   func(context.Context, control.UnaryInvoker, *control.GetCredentialNonceReq) (*control.GetCredentialNonceResp, error)
+	now     func() time.Time
+	batch   int
+	nonces  [][]byte
+	expires time.Time
+	low     chan struct{}
+}
+
+func newNonceSupply(log logging.Logger, invoker control.UnaryInvoker) *nonceSupply {
+	return &nonceSupply{
+		log:     log,
+		invoker: invoker,
+		fetch:   control.GetCredentialNonce,
+		now:     time.Now,
+		batch:   nonceBatchSize,
+		low:     make(chan struct{}, 1),
+	}
+}
+
+// refill fetches a new batch of nonces in place of those remaining, and
+// returns how long to wait before the next refill.
+func (ns *nonceSupply) refill(ctx context.Context) time.Duration {
+	fetchCtx, cancel := context.WithTimeout(ctx, nonceFetchTimeout)
+	defer cancel()
+
+	resp, err := ns.fetch(fetchCtx, ns.invoker, &control.GetCredentialNonceReq{Count: ns.batch})
+	now := ns.now()
+	if err == nil && !now.Before(resp.Expires) {
+		err = errors.Errorf("servers issued nonces which expired at %s", resp.Expires.Format(time.RFC3339))
+	}
+	if err == nil && len(resp.Nonces) == 0 {
+		err = errors.New("servers issued no nonces")
+	}
+	if err != nil {
+		ns.log.Errorf("failed to fetch credential nonces: %s", err)
+		return nonceRetryInterval
+	}
+
+	ns.Lock()
+	ns.nonces = resp.Nonces
+	ns.expires = resp.Expires
+	ns.Unlock()
+
+	ns.log.Debugf("fetched %d credential nonces expiring at %s", len(resp.Nonces),
+		resp.Expires.Format(time.RFC3339))
+	return resp.Expires.Sub(now) / 2
+}
+
+// pop removes a nonce from the supply, if it holds one which has not expired.
+func (ns *nonceSupply) pop() []byte {
+	ns.Lock()
+	defer ns.Unlock()
+
+	if !ns.now().Before(ns.expires) {
+		ns.nonces = nil
+	}
+	if len(ns.nonces) == 0 {
+		return nil
+	}
+
+	nonce := ns.nonces[0]
+	ns.nonces = ns.nonces[1:]
+	if len(ns.nonces) < ns.batch/2 {
+		select {
+		case ns.low <- struct{}{}:
+		default:
+		}
+	}
+	return nonce
+}
+
+// take returns a nonce to record in a credential, which is not handed out
+// again. If the supply has run out, a batch is fetched first. If none can be
+// fetched, nil is returned, and the credential is signed without a nonce.
+func (ns *nonceSupply) take(ctx context.Context) []byte {
+	if nonce := ns.pop(); nonce != nil {
+		return nonce
+	}
+
+	ns.refill(ctx)
+	nonce := ns.pop()
+	if nonce == nil {
+		ns.log.Error("no credential nonce available; signing credential without one")
+	}
+	return nonce
+}
+
+// run keeps the supply filled until the context is canceled.
+func (ns *nonceSupply) run(ctx context.Context) {
+	for {
+		timer := time.NewTimer(ns.refill(ctx))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-ns.low:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,135 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestAgent_nonceSupply_refill(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	for name, tc := range map[string]struct {
+		cur       [][]byte
+		resp      *control.GetCredentialNonceResp
+		err       error
+		expWait   time.Duration
+		expNonces [][]byte
+	}{
+		"fetched": {
+			resp: &control.GetCredentialNonceResp{
+				Nonces:  [][]byte{[]byte("a"), []byte("b")},
+				Expires: now.Add(10 * time.Minute),
+			},
+			expWait:   5 * time.Minute,
+			expNonces: [][]byte{[]byte("a"), []byte("b")},
+		},
+		"replaced": {
+			cur: [][]byte{[]byte("old")},
+			resp: &control.GetCredentialNonceResp{
+				Nonces:  [][]byte{[]byte("new")},
+				Expires: now.Add(time.Hour),
+			},
+			expWait:   30 * time.Minute,
+			expNonces: [][]byte{[]byte("new")},
+		},
+		"failed; current nonces kept": {
+			cur:       [][]byte{[]byte("old")},
+			err:       errors.New("no servers"),
+			expWait:   nonceRetryInterval,
+			expNonces: [][]byte{[]byte("old")},
+		},
+		"expired nonces issued": {
+			cur: [][]byte{[]byte("old")},
+			resp: &control.GetCredentialNonceResp{
+				Nonces:  [][]byte{[]byte("new")},
+				Expires: now,
+			},
+			expWait:   nonceRetryInterval,
+			expNonces: [][]byte{[]byte("old")},
+		},
+		"no nonces issued": {
+			resp: &control.GetCredentialNonceResp{
+				Expires: now.Add(time.Hour),
+			},
+			expWait: nonceRetryInterval,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			ns := newNonceSupply(log, nil)
+			ns.now = func() time.Time { return now }
+			ns.nonces = tc.cur
+			ns.fetch = func(_ context.Context, _ control.UnaryInvoker, req *control.GetCredentialNonceReq) (*control.GetCredentialNonceResp, error) {
+				test.AssertEqual(t, nonceBatchSize, req.Count, "unexpected batch size")
+				return tc.resp, tc.err
+			}
+
+			test.AssertEqual(t, tc.expWait, ns.refill(test.Context(t)), "unexpected wait")
+			test.AssertEqual(t, tc.expNonces, ns.nonces, "unexpected nonces")
+		})
+	}
+}
+
+func TestAgent_nonceSupply_take(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	var fetches int
+	ns := newNonceSupply(log, nil)
+	ns.now = func() time.Time { return now }
+	ns.batch = 4
+	ns.fetch = func(context.Context, control.UnaryInvoker, *control.GetCredentialNonceReq) (*control.GetCredentialNonceResp, error) {
+		fetches++
+		return &control.GetCredentialNonceResp{
+			Nonces:  [][]byte{{byte(fetches), 1}, {byte(fetches), 2}, {byte(fetches), 3}, {byte(fetches), 4}},
+			Expires: now.Add(time.Hour),
+		}, nil
+	}
+
+	// An empty supply is filled before a nonce is handed out, and each
+	// nonce is handed out only once.
+	seen := make(map[string]bool)
+	for i := 0; i < 6; i++ {
+		nonce := ns.take(test.Context(t))
+		if nonce == nil {
+			t.Fatal("no nonce taken")
+		}
+		if seen[string(nonce)] {
+			t.Fatalf("nonce %x handed out twice", nonce)
+		}
+		seen[string(nonce)] = true
+	}
+	test.AssertEqual(t, 2, fetches, "unexpected number of fetches")
+
+	// Running low asks for the supply to be refilled in the background.
+	select {
+	case <-ns.low:
+	default:
+		t.Fatal("supply running low not signaled")
+	}
+
+	// Expired nonces are not handed out.
+	now = now.Add(2 * time.Hour)
+	ns.fetch = func(context.Context, control.UnaryInvoker, *control.GetCredentialNonceReq) (*control.GetCredentialNonceResp, error) {
+		return nil, errors.New("no servers")
+	}
+	if nonce := ns.take(test.Context(t)); nonce != nil {
+		t.Fatalf("expired nonce %x taken", nonce)
+	}
+}
@@ -0,0 +1,99 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// minPinnedRefreshInterval bounds how often pinned credentials are checked.
+const minPinnedRefreshInterval = time.Second
+
+// resolvePinnedUsers resolves the pinned users, given by name or UID, to UIDs.
+// Users which can't be resolved are skipped, so that a user missing on one
+// node does not prevent the agent from starting.
+func resolvePinnedUsers(log logging.Logger, users []string) map[uint32]struct{} {
+	if len(users) == 0 {
+		return nil
+	}
+
+	pinned := make(map[uint32]struct{})
+	for _, name := range users {
+		name = strings.TrimSpace(name)
+		if uid, err := strconv.ParseUint(name, 10, 32); err == nil {
+			pinned[uint32(uid)] = struct{}{}
+			continue
+		}
+		uid, err := lookupUserUid(name)
+		if err != nil {
+			log.Errorf("unable to pin cached credentials of user %q: %s", name, err)
+			continue
+		}
+		pinned[uid] = struct{}{}
+	}
+	return pinned
+}
+
+// isPinned returns true if the credentials of the owner are pinned.
+func (cc *credentialCache) isPinned(owner *uint32) bool {
+	if owner == nil || len(cc.pinned) == 0 {
+		return false
+	}
+	_, found := cc.pinned[*owner]
+	return found
+}
+
+// pinnedRefreshAhead returns how long before they expire pinned credentials
+// are refreshed. Unless refreshAhead is set, they are refreshed once three
+// quarters of their lifetime has passed.
+func (cc *credentialCache) pinnedRefreshAhead() time.Duration {
+	if cc.refreshAhead > 0 {
+		return cc.refreshAhead
+	}
+	return cc.credLifetime / 4
+}
+
+// refreshPinned refreshes the pinned credentials which are nearing expiry. The
+// credentials restored from the spool are refreshed once they have been
+// requested again, as the request they were cached for is not persisted.
+func (cc *credentialCache) refreshPinned(ctx context.Context, log logging.Logger) {
+	refreshAhead := cc.pinnedRefreshAhead()
+	for _, key := range cc.cache.Keys() {
+		item, release, err := cc.cache.Get(ctx, key)
+		if err != nil {
+			continue
+		}
+		if cred, ok := item.(*cachedCredential); ok && cred.pinned && cred.req != nil &&
+			cred.needsRefresh(refreshAhead) {
+			cred.refreshing = true
+			// The refreshed credential is pinned for the same owner.
+			go cc.refresh(context.WithValue(ctx, credentialOwnerKey, cred.owner), log, cred.req, cred)
+		}
+		release()
+	}
+}
+
+// keepPinnedFresh refreshes the pinned credentials before they expire, until
+// the context is canceled, so that they are always served from the cache.
+func (cc *credentialCache) keepPinnedFresh(ctx context.Context, log logging.Logger) {
+	ticker := time.NewTicker(max(cc.pinnedRefreshAhead()/2, minPinnedRefreshInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cc.refreshPinned(ctx, log)
+		}
+	}
+}
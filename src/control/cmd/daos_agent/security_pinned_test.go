@@ -0,0 +1,119 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/cache"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func TestAgent_resolvePinnedUsers(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	defer func(orig func(string) (uint32, error)) { lookupUserUid = orig }(lookupUserUid)
+	lookupUserUid = func(name string) (uint32, error) {
+		if name == "daos_mover" {
+			return 1001, nil
+		}
+		return 0, errors.Errorf("unknown user %q", name)
+	}
+
+	test.AssertEqual(t, 0, len(resolvePinnedUsers(log, nil)), "no users should be pinned")
+	test.CmpAny(t, "pinned", map[uint32]struct{}{1001: {}, 2002: {}},
+		resolvePinnedUsers(log, []string{"daos_mover", " 2002", "nobody"}))
+}
+
+func TestAgent_credentialCache_pinned(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	var mutex sync.Mutex
+	misses := make(map[string]int)
+	cc := &credentialCache{
+		log:          log,
+		cache:        cache.NewShardedItemCache(log, credCacheShards),
+		credLifetime: time.Minute,
+		lru:          newCredentialLRU(1, 0, 0, nil),
+		pinned:       map[uint32]struct{}{1: {}},
+		cacheMissFn: func(_ context.Context, _ logging.Logger, req auth.CredentialRequest) (*auth.Credential, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			misses[req.GetKey()]++
+			return &auth.Credential{Origin: fmt.Sprintf("%s-%d", req.GetKey(), misses[req.GetKey()])}, nil
+		},
+	}
+	newReq := func(uid uint32) *auth.AuthSysCredentialRequest {
+		return &auth.AuthSysCredentialRequest{
+			DomainInfo: security.InitDomainInfo(&syscall.Ucred{Uid: uid, Gid: uid}, ""),
+		}
+	}
+	request := func(req *auth.AuthSysCredentialRequest) {
+		t.Helper()
+		uid := req.DomainInfo.Uid()
+		ctx := context.WithValue(test.Context(t), credentialOwnerKey, &uid)
+		if _, err := cc.getSignedCredential(ctx, log, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	getCached := func(key string) (string, bool) {
+		t.Helper()
+		item, release, err := cc.cache.Get(test.Context(t), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer release()
+		cred := item.(*cachedCredential)
+		return cred.cred.Origin, cred.pinned
+	}
+
+	pinnedReq, req2, req3 := newReq(1), newReq(2), newReq(3)
+	request(pinnedReq)
+	request(req2)
+	request(req3)
+
+	// The pinned credential is not evicted when the cache is full.
+	test.AssertTrue(t, cc.cache.Has(pinnedReq.GetKey()), "pinned credential was evicted")
+	test.AssertFalse(t, cc.cache.Has(req2.GetKey()), "least recently used credential was not evicted")
+	_, pinned := getCached(pinnedReq.GetKey())
+	test.AssertTrue(t, pinned, "credential not marked as pinned")
+	_, pinned = getCached(req3.GetKey())
+	test.AssertFalse(t, pinned, "credential unexpectedly pinned")
+
+	// Pinned credentials are refreshed without being requested, once they
+	// near expiry.
+	cc.refreshPinned(test.Context(t), log)
+	mutex.Lock()
+	test.AssertEqual(t, 1, misses[pinnedReq.GetKey()], "fresh pinned credential was refreshed")
+	mutex.Unlock()
+
+	cc.refreshAhead = 2 * cc.credLifetime
+	cc.refreshPinned(test.Context(t), log)
+	origin, pinned := getCached(pinnedReq.GetKey())
+	for deadline := time.Now().Add(5 * time.Second); origin == pinnedReq.GetKey()+"-1" && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		origin, pinned = getCached(pinnedReq.GetKey())
+	}
+	test.AssertEqual(t, pinnedReq.GetKey()+"-2", origin, "pinned credential not refreshed")
+	test.AssertTrue(t, pinned, "refreshed credential not pinned")
+
+	mutex.Lock()
+	test.AssertEqual(t, 1, misses[req3.GetKey()], "unpinned credential was refreshed")
+	mutex.Unlock()
+}
@@ -0,0 +1,40 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"crypto"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/pkcs11"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func init() {
+	security.RegisterPKCS11KeyOpener(openPKCS11Key)
+}
+
+// openPKCS11Key opens the agent's signing key on a PKCS#11 token. The key
+// reopens its session with the token as needed, so signing recovers once a
+// removed token is back.
+func openPKCS11Key(cfg *security.PKCS11KeyConfig, pin string, pub crypto.PublicKey) (crypto.Signer, error) {
+	keyID, err := hex.DecodeString(cfg.KeyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "key_id")
+	}
+
+	return pkcs11.OpenKey(pkcs11.KeyConfig{
+		Module:     cfg.Module,
+		TokenLabel: cfg.TokenLabel,
+		Slot:       cfg.Slot,
+		KeyLabel:   cfg.KeyLabel,
+		KeyID:      keyID,
+		PIN:        pin,
+	}, pub)
+}
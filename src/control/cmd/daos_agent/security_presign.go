@@ -0,0 +1,165 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// lookupUserName resolves a UID to the name of its user.
+var lookupUserName = func(uid uint32) (string, error) {
+	u, err := user.LookupId(strconv.FormatUint(uint64(uid), 10))
+	if err != nil {
+		return "", err
+	}
+	return u.Username, nil
+}
+
+type presignCredentialsCmd struct {
+	configCmd
+	cmdutil.LogCmd
+	cmdutil.JSONOutputCmd
+	Identities string `long:"identities" required:"1" description:"Path to a YAML list of the identities (user, group, groups, machine_name) to pre-sign credentials for"`
+	Output     string `long:"output" required:"1" description:"Path to which to write the pre-signed credentials"`
+}
+
+type presignCredentialsResp struct {
+	System string `json:"system"`
+	KeyID  string `json:"key_id"`
+	Users  int    `json:"users"`
+	Output string `json:"output"`
+}
+
+// Execute signs a credential for each of the identities with the agent's
+// credential signing key, or its transport key if it has none, without the
+// agent running or any server being reachable. The resulting bundle is
+// installed on the nodes of an air-gapped or bootstrapping cluster as
+// presigned_credentials, from which their agents serve the credentials of the
+// identities.
+func (cmd *presignCredentialsCmd) Execute(_ []string) error {
+	if cmd.cfg.TransportConfig.AllowInsecure {
+		return errors.New("credentials can't be pre-signed with allow_insecure, as they would not be signed")
+	}
+
+	data, err := os.ReadFile(cmd.Identities)
+	if err != nil {
+		return errors.Wrap(err, "reading identities")
+	}
+	var ids []*auth.PresignIdentity
+	if err := yaml.UnmarshalStrict(data, &ids); err != nil {
+		return errors.Wrapf(err, "parsing identities %s", cmd.Identities)
+	}
+	if len(ids) == 0 {
+		return errors.Errorf("no identities listed in %s", cmd.Identities)
+	}
+
+	signingTransport := cmd.cfg.TransportConfig
+	if cmd.cfg.CredentialKey != nil {
+		signingTransport = cmd.cfg.CredentialKey.TransportConfig()
+	}
+	key, err := signingTransport.PrivateKey()
+	if err != nil {
+		return errors.Wrap(err, "loading signing key")
+	}
+	bundle, err := auth.PresignCredentials(key, cmd.cfg.SystemName, ids)
+	if err != nil {
+		return err
+	}
+	if err := bundle.Write(cmd.Output); err != nil {
+		return err
+	}
+	cmd.Noticef("audit: pre-signed credentials of %d users for system %s with key %s, written to %s",
+		bundle.Users(), bundle.System, bundle.KeyID, cmd.Output)
+
+	resp := &presignCredentialsResp{
+		System: bundle.System,
+		KeyID:  bundle.KeyID,
+		Users:  bundle.Users(),
+		Output: cmd.Output,
+	}
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, nil)
+	}
+
+	_, err = fmt.Printf("Pre-signed credentials of %d users with key %s\nWritten to %s\n",
+		resp.Users, resp.KeyID, resp.Output)
+	return err
+}
+
+// loadPresignedCredentials loads the bundle of pre-signed credentials, which
+// must have been signed for the agent's system.
+func (m *SecurityModule) loadPresignedCredentials(path string) error {
+	bundle, err := auth.LoadPresignedCredentials(path)
+	if err != nil {
+		return err
+	}
+	if bundle.System != m.config.sys {
+		return errors.Errorf("pre-signed credentials %s are for system %q (agent serves %q)",
+			path, bundle.System, m.config.sys)
+	}
+	m.presigned = bundle
+	m.log.Noticef("audit: serving pre-signed credentials of %d users, signed at %s by key %s",
+		bundle.Users(), bundle.SignedAt, bundle.KeyID)
+	return nil
+}
+
+// presignedCredential returns the pre-signed credential of the client, if it
+// requested an AUTH_SYS credential which may be served from the pre-signed
+// credentials. Credentials for other systems or tenants, and one-time
+// credentials, are always signed by the agent, as are those of users without a
+// pre-signed credential.
+func (m *SecurityModule) presignedCredential(session *drpc.Session, credReq *auth.GetCredReq) *auth.Credential {
+	if m.presigned == nil || credReq.Flavor != auth.Flavor_AUTH_SYS || credReq.Tenant != "" ||
+		credReq.Scope == auth.Scope_SCOPE_ONE_TIME || credReq.RenewalToken != "" {
+		return nil
+	}
+	if _, err := m.credentialSystem(credReq.Sys); err != nil {
+		return nil
+	}
+	if m.revoked.Len() != 0 && m.revoked.IsRevoked(m.presigned.KeyID) {
+		return nil
+	}
+
+	uc, ok := session.Conn.(*net.UnixConn)
+	if !ok {
+		return nil
+	}
+	info, err := security.DomainInfoFromUnixConn(m.log, uc)
+	if err != nil {
+		m.log.Errorf("unable to get credentials for client socket: %s", err)
+		return nil
+	}
+	return m.presignedUserCredential(info.Uid())
+}
+
+// presignedUserCredential returns the pre-signed credential of the user, or
+// nil if there is none.
+func (m *SecurityModule) presignedUserCredential(uid uint32) *auth.Credential {
+	name, err := lookupUserName(uid)
+	if err != nil {
+		m.log.Debugf("unable to look up uid %d: %s", uid, err)
+		return nil
+	}
+	cred, err := m.presigned.Lookup(name)
+	if err != nil {
+		m.log.Errorf("%s", err)
+		return nil
+	}
+	return cred
+}
@@ -0,0 +1,121 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAgent_presignCredentialsCmd(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expKeyID, err := security.PrivateKeyID(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	origLookup := lookupUserName
+	lookupUserName = func(uid uint32) (string, error) {
+		switch uid {
+		case 1000:
+			return "alice", nil
+		case 1001:
+			return "bob", nil
+		}
+		return "", errors.Errorf("unknown uid %d", uid)
+	}
+	defer func() { lookupUserName = origLookup }()
+
+	for name, tc := range map[string]struct {
+		identities string
+		insecure   bool
+		agentSys   string
+		expErr     error
+		expLoadErr error
+	}{
+		"success": {
+			identities: "- user: alice\n  group: users\n  machine_name: mgmt\n",
+			agentSys:   "daos_server",
+		},
+		"insecure": {
+			identities: "- user: alice\n",
+			insecure:   true,
+			expErr:     errors.New("can't be pre-signed with allow_insecure"),
+		},
+		"no identities": {
+			identities: "[]\n",
+			expErr:     errors.New("no identities listed"),
+		},
+		"unknown field": {
+			identities: "- user: alice\n  uid: 1000\n",
+			expErr:     errors.New("parsing identities"),
+		},
+		"other system": {
+			identities: "- user: alice\n",
+			agentSys:   "other",
+			expLoadErr: errors.New(`are for system "daos_server" (agent serves "other")`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			dir := t.TempDir()
+			idsPath := filepath.Join(dir, "identities.yml")
+			if err := os.WriteFile(idsPath, []byte(tc.identities), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := DefaultConfig()
+			cfg.SystemName = "daos_server"
+			cfg.TransportConfig = testSigningTransport(t, "agent", key)
+			cfg.TransportConfig.AllowInsecure = tc.insecure
+			cmd := &presignCredentialsCmd{
+				Identities: idsPath,
+				Output:     filepath.Join(dir, "presigned.json"),
+			}
+			cmd.setConfig(cfg)
+			cmd.SetLog(log)
+
+			err := cmd.Execute(nil)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			secCfg := defaultTestSecurityConfig(t, log, testInfoCacheParams{})
+			secCfg.sys = tc.agentSys
+			mod := NewSecurityModule(log, secCfg)
+			err = mod.loadPresignedCredentials(cmd.Output)
+			test.CmpErr(t, tc.expLoadErr, err)
+			if tc.expLoadErr != nil {
+				return
+			}
+
+			cred := mod.presignedUserCredential(1000)
+			if cred == nil {
+				t.Fatal("no pre-signed credential for alice")
+			}
+			test.AssertEqual(t, expKeyID, cred.KeyId, "unexpected key ID")
+			test.AssertTrue(t, mod.presignedUserCredential(1001) == nil, "unexpected credential for bob")
+			test.AssertTrue(t, mod.presignedUserCredential(1002) == nil, "unexpected credential for unknown uid")
+		})
+	}
+}
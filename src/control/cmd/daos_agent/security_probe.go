@@ -0,0 +1,151 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// backendProbeTimeout bounds a single probe of a flavor backend.
+const backendProbeTimeout = 30 * time.Second
+
+type (
+	backendProbeFn func(context.Context, auth.Flavor, *security.CredentialConfig) error
+
+	// backendProbes checks that the backends of the enabled flavors, such as
+	// the access manager, are reachable, so that a misconfigured backend is
+	// found before a client needs it. Their status is exported as metrics.
+	backendProbes struct {
+		sync.Mutex
+		log     logging.Logger
+		cfg     *security.CredentialConfig
+		flavors []auth.Flavor
+		status  map[auth.Flavor]error
+		probe   backendProbeFn
+		up      *prometheus.GaugeVec
+	}
+)
+
+var _ prometheus.Collector = (*backendProbes)(nil)
+
+func newBackendProbes(log logging.Logger, cfg *security.CredentialConfig) *backendProbes {
+	if cfg == nil {
+		return nil
+	}
+
+	return &backendProbes{
+		log:    log,
+		cfg:    cfg,
+		status: make(map[auth.Flavor]error),
+		probe:  auth.ProbeFlavor,
+		up: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "agent_credential_backend_up",
+			Help: "Whether the backend of the credential flavor was reachable when last probed",
+		}, []string{"flavor"}),
+	}
+}
+
+// probeAll probes the backend of each flavor, logging changes in their status.
+func (p *backendProbes) probeAll(ctx context.Context) {
+	for _, flavor := range p.flavors {
+		probeCtx, cancel := context.WithTimeout(ctx, backendProbeTimeout)
+		err := p.probe(probeCtx, flavor, p.cfg)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		p.update(flavor, err)
+	}
+}
+
+func (p *backendProbes) update(flavor auth.Flavor, err error) {
+	p.Lock()
+	defer p.Unlock()
+
+	prev, probed := p.status[flavor]
+	switch {
+	case err != nil && (!probed || prev == nil):
+		p.log.Errorf("backend for %s is unreachable: %s", flavor, err)
+	case err != nil:
+		p.log.Debugf("backend for %s is still unreachable: %s", flavor, err)
+	case !probed:
+		p.log.Debugf("backend for %s is reachable", flavor)
+	case prev != nil:
+		p.log.Noticef("backend for %s has recovered", flavor)
+	}
+	p.status[flavor] = err
+
+	upVal := 1.0
+	if err != nil {
+		upVal = 0
+	}
+	p.up.WithLabelValues(flavor.String()).Set(upVal)
+}
+
+// start probes the backends once before returning, so that problems are
+// reported at startup, and then at the configured interval until the context
+// is canceled. Unreachable backends do not prevent the agent from starting,
+// as they may recover. It must be called once any flavor plugins are loaded.
+func (p *backendProbes) start(ctx context.Context) {
+	if p == nil {
+		return
+	}
+
+	for _, flavor := range enabledFlavors(p.cfg) {
+		if auth.HasBackend(flavor) {
+			p.flavors = append(p.flavors, flavor)
+		}
+	}
+	if len(p.flavors) == 0 {
+		return
+	}
+	p.probeAll(ctx)
+
+	interval := p.cfg.BackendProbeInterval
+	if interval == 0 {
+		interval = security.DefaultBackendProbeInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.probeAll(ctx)
+			}
+		}
+	}()
+}
+
+// Describe implements the prometheus.Collector interface.
+func (p *backendProbes) Describe(ch chan<- *prometheus.Desc) {
+	if p == nil {
+		return
+	}
+
+	p.up.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (p *backendProbes) Collect(ch chan<- prometheus.Metric) {
+	if p == nil {
+		return
+	}
+
+	p.up.Collect(ch)
+}
@@ -0,0 +1,72 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func TestAgent_backendProbes(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	cfg := &security.CredentialConfig{
+		AMConfig: security.AccessManagerConfig{BaseURL: "https://am1.example.com"},
+	}
+	probes := newBackendProbes(log, cfg)
+
+	results := []error{errors.New("connection refused"), errors.New("connection refused"), nil}
+	var probed []auth.Flavor
+	probes.probe = func(_ context.Context, flavor auth.Flavor, _ *security.CredentialConfig) error {
+		probed = append(probed, flavor)
+		err := results[0]
+		results = results[1:]
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(test.Context(t))
+	defer cancel()
+	probes.start(ctx)
+	test.CmpAny(t, "probed flavors", []auth.Flavor{auth.Flavor_AUTH_ACCMAN}, probed)
+	test.CmpErr(t, errors.New("connection refused"), probes.status[auth.Flavor_AUTH_ACCMAN])
+	test.AssertTrue(t, strings.Contains(buf.String(), "backend for AUTH_ACCMAN is unreachable"),
+		"startup failure not reported")
+
+	// A failure is only reported once, until the backend recovers.
+	buf.Reset()
+	probes.probeAll(ctx)
+	test.AssertFalse(t, strings.Contains(buf.String(), "is unreachable: "), "repeated failure reported")
+	probes.probeAll(ctx)
+	test.CmpErr(t, nil, probes.status[auth.Flavor_AUTH_ACCMAN])
+	test.AssertTrue(t, strings.Contains(buf.String(), "backend for AUTH_ACCMAN has recovered"),
+		"recovery not reported")
+}
+
+func TestAgent_backendProbes_NoBackends(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	probes := newBackendProbes(log, &security.CredentialConfig{})
+	probes.probe = func(context.Context, auth.Flavor, *security.CredentialConfig) error {
+		t.Fatal("unexpected probe")
+		return nil
+	}
+	probes.start(test.Context(t))
+
+	// Should not panic.
+	var nilProbes *backendProbes
+	nilProbes.start(test.Context(t))
+	test.AssertTrue(t, newBackendProbes(log, nil) == nil, "expected nil probes without configuration")
+}
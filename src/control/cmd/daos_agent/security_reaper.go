@@ -0,0 +1,57 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/lib/cache"
+)
+
+// reapExpired removes the expired cached credentials, and their spooled
+// copies, returning the number removed. Otherwise, an expired credential is
+// only removed once it is requested again, so the credentials of clients which
+// do not return are held until they are evicted.
+func (cc *credentialCache) reapExpired() int {
+	if cc == nil {
+		return 0
+	}
+
+	return cc.cache.DeleteExpired(func(item cache.Item) {
+		cred, ok := item.(*cachedCredential)
+		if !ok {
+			return
+		}
+		// The cache remains locked, so that a credential cached for
+		// the same key in the meantime is not forgotten instead.
+		cc.lru.remove(cred.key)
+		if err := cc.spool.remove(cred.key); err != nil {
+			cc.log.Errorf("removing expired credential: %s", err)
+		}
+		cc.metrics.count(cacheExpiry, cred.flavor)
+		cc.events.publish(credExpired, cred.key, cred.flavor, cred.owner)
+	})
+}
+
+// reapExpiredEvery removes the expired cached credentials at the interval
+// until the context is canceled.
+func (cc *credentialCache) reapExpiredEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if reaped := cc.reapExpired(); reaped > 0 {
+				cc.log.Debugf("removed %d expired cached credentials", reaped)
+			}
+		}
+	}
+}
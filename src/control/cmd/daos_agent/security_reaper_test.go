@@ -0,0 +1,99 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/cache"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func testReaperCache(t *testing.T, log logging.Logger) *credentialCache {
+	t.Helper()
+
+	sealer := testSealer(t)
+	spool, err := newCredentialSpool(log, t.TempDir(), sealer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metrics := newCredentialCacheMetrics()
+	return &credentialCache{
+		log:          log,
+		cache:        cache.NewShardedItemCache(log, credCacheShards),
+		credLifetime: time.Minute,
+		lru:          newCredentialLRU(0, 0, 0, metrics),
+		metrics:      metrics,
+		sealer:       sealer,
+		spool:        spool,
+	}
+}
+
+func addReaperCred(t *testing.T, cc *credentialCache, key string, lifetime time.Duration) {
+	t.Helper()
+
+	item := testSpooledCred(t, cc.sealer, key, lifetime)
+	if err := cc.cache.Set(item); err != nil {
+		t.Fatal(err)
+	}
+	if err := cc.spool.store(item); err != nil {
+		t.Fatal(err)
+	}
+	cc.touch(item.key, item.flavor, item.owner, item.size())
+}
+
+func TestAgent_credentialCache_reapExpired(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	var nilCache *credentialCache
+	test.AssertEqual(t, 0, nilCache.reapExpired(), "nil cache has nothing to reap")
+
+	cc := testReaperCache(t, log)
+	addReaperCred(t, cc, "uid:1", -time.Minute)
+	addReaperCred(t, cc, "uid:2", -time.Minute)
+	addReaperCred(t, cc, "uid:3", time.Minute)
+
+	test.AssertEqual(t, 2, cc.reapExpired(), "unexpected number reaped")
+	test.CmpAny(t, "cached keys", []string{"uid:3"}, cc.cache.Keys())
+	test.AssertEqual(t, 1, len(cc.lru.elems), "expired credentials still accounted for")
+	test.AssertEqual(t, 1, len(spoolFiles(t, cc.spool.dir)), "expired credentials still spooled")
+	test.AssertEqual(t, 2.0, counterValue(t, cc.metrics.events[cacheExpiry], auth.Flavor_AUTH_SYS.String()),
+		"unexpected expirations metric")
+
+	test.AssertEqual(t, 0, cc.reapExpired(), "nothing left to reap")
+}
+
+func TestAgent_credentialCache_reapExpiredEvery(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	cc := testReaperCache(t, log)
+	addReaperCred(t, cc, "uid:1", time.Minute)
+
+	ctx, cancel := context.WithCancel(test.Context(t))
+	done := make(chan struct{})
+	go func() {
+		cc.reapExpiredEvery(ctx, 10*time.Millisecond)
+		close(done)
+	}()
+
+	// A credential which expires after it was cached is reaped without
+	// being requested again.
+	addReaperCred(t, cc, "uid:2", 20*time.Millisecond)
+	for deadline := time.Now().Add(5 * time.Second); cc.cache.Len() > 1 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	test.CmpAny(t, "cached keys", []string{"uid:1"}, cc.cache.Keys())
+
+	cancel()
+	<-done
+}
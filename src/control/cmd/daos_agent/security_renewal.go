@@ -0,0 +1,351 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/acme"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// renewalTimeout bounds each attempt to renew the agent certificate.
+const renewalTimeout = 10 * time.Minute
+
+// certIssuer issues a certificate for the key of a DER-encoded CSR, renewing
+// the agent's current certificate. It returns the certificate chain, leaf
+// first.
+type certIssuer interface {
+	issue(ctx context.Context, csr []byte, current *x509.Certificate) ([]*x509.Certificate, error)
+}
+
+// daosCAIssuer has agent certificates renewed by the control plane CA.
+type daosCAIssuer struct {
+	invoker control.UnaryInvoker
+}
+
+func (i *daosCAIssuer) issue(ctx context.Context, csr []byte, _ *x509.Certificate) ([]*x509.Certificate, error) {
+	resp, err := control.SignAgentCert(ctx, i.invoker, &control.SignAgentCertReq{CSR: csr})
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(resp.Cert)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing renewed certificate")
+	}
+	return []*x509.Certificate{cert}, nil
+}
+
+// acmeIssuer has agent certificates renewed by an ACME server, answering its
+// http-01 challenges for the duration of the renewal.
+type acmeIssuer struct {
+	cfg    *security.ACMEConfig
+	client *acme.Client
+}
+
+func newACMEIssuer(log logging.Logger, cfg *security.ACMEConfig) (*acmeIssuer, error) {
+	key, err := loadACMEAccountKey(log, cfg.AccountKey)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{Timeout: time.Minute}
+	if cfg.CACert != "" {
+		caPEM, err := security.LoadPEMData(cfg.CACert, security.MaxCertPerm)
+		if err != nil {
+			return nil, errors.Wrap(err, "loading ACME server CA certificate")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, errors.Errorf("no certificates in %s", cfg.CACert)
+		}
+		httpClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+		}
+	}
+
+	return &acmeIssuer{
+		cfg: cfg,
+		client: &acme.Client{
+			DirectoryURL: cfg.DirectoryURL,
+			Key:          key,
+			Email:        cfg.Email,
+			HTTPClient:   httpClient,
+			Responder:    acme.NewHTTP01Responder(),
+		},
+	}, nil
+}
+
+// loadACMEAccountKey loads the ACME account key, generating it if it doesn't
+// exist yet.
+func loadACMEAccountKey(log logging.Logger, path string) (*ecdsa.PrivateKey, error) {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, errors.Wrap(err, "generating ACME account key")
+		}
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}),
+			security.MaxUserOnlyKeyPerm); err != nil {
+			return nil, errors.Wrap(err, "writing ACME account key")
+		}
+		log.Noticef("generated ACME account key %s", path)
+		return key, nil
+	}
+
+	key, err := security.LoadPrivateKey(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading ACME account key")
+	}
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok || ecKey.Curve != elliptic.P256() {
+		return nil, errors.Errorf("ACME account key %s is not an ECDSA P-256 key", path)
+	}
+	return ecKey, nil
+}
+
+func (i *acmeIssuer) issue(ctx context.Context, csr []byte, _ *x509.Certificate) ([]*x509.Certificate, error) {
+	listen := i.cfg.HTTP01Listen
+	if listen == "" {
+		listen = security.DefaultACMEHTTP01Listen
+	}
+	lis, err := net.Listen("tcp", listen)
+	if err != nil {
+		return nil, errors.Wrap(err, "listening for ACME http-01 challenges")
+	}
+	srv := &http.Server{Handler: i.client.Responder, ReadHeaderTimeout: 10 * time.Second}
+	go srv.Serve(lis)
+	defer srv.Close()
+
+	return i.client.ObtainCertificate(ctx, i.cfg.Domains, csr)
+}
+
+// certRenewer renews the agent's certificate before it expires. The renewed
+// certificate is for a new key, which replaces the key file in place, and is
+// loaded as if rotated by an administrator, so that credentials are signed
+// with it and the control plane client presents it.
+type certRenewer struct {
+	log    logging.Logger
+	cfg    *security.CertRenewalConfig
+	mod    *SecurityModule
+	issuer certIssuer
+}
+
+func newCertRenewer(log logging.Logger, cfg *security.CertRenewalConfig, mod *SecurityModule, invoker control.UnaryInvoker) (*certRenewer, error) {
+	r := &certRenewer{
+		log: log,
+		cfg: cfg,
+		mod: mod,
+	}
+	switch cfg.Method {
+	case security.CertRenewalDAOSCA:
+		r.issuer = &daosCAIssuer{invoker: invoker}
+	case security.CertRenewalACME:
+		issuer, err := newACMEIssuer(log, cfg.ACME)
+		if err != nil {
+			return nil, err
+		}
+		r.issuer = issuer
+	default:
+		return nil, errors.Errorf("invalid certificate renewal method %q", cfg.Method)
+	}
+	return r, nil
+}
+
+// run checks whether the certificate is due for renewal at the configured
+// interval, until the context is canceled. A failed renewal is retried at the
+// next check.
+func (r *certRenewer) run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.GetCheckInterval())
+	defer ticker.Stop()
+
+	for {
+		if err := r.check(ctx, time.Now()); err != nil {
+			r.log.Errorf("agent certificate renewal failed: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check renews the certificate if it is due for renewal.
+func (r *certRenewer) check(ctx context.Context, now time.Time) error {
+	tc := r.mod.transport()
+	cert, err := tc.Certificate()
+	if err != nil {
+		return errors.Wrap(err, "loading agent certificate")
+	}
+
+	renewAt := r.cfg.RenewalTime(cert)
+	if now.Before(renewAt) {
+		r.log.Debugf("agent certificate expires at %s; renewal due at %s", cert.NotAfter.Format(time.RFC3339),
+			renewAt.Format(time.RFC3339))
+		return nil
+	}
+	if now.After(cert.NotAfter) {
+		r.log.Errorf("agent certificate expired at %s; attempting renewal", cert.NotAfter.Format(time.RFC3339))
+	} else {
+		r.log.Noticef("agent certificate expires at %s; renewing", cert.NotAfter.Format(time.RFC3339))
+	}
+
+	renewCtx, cancel := context.WithTimeout(ctx, renewalTimeout)
+	defer cancel()
+	return r.renew(renewCtx, tc, cert)
+}
+
+// renew obtains a certificate for a new key, checks that it can be used in
+// place of the current one, and installs it.
+func (r *certRenewer) renew(ctx context.Context, tc *security.TransportConfig, current *x509.Certificate) error {
+	oldKey, err := tc.PrivateKey()
+	if err != nil {
+		return errors.Wrap(err, "loading agent key")
+	}
+	newKey, err := generateKeyLike(oldKey)
+	if err != nil {
+		return err
+	}
+
+	template := &x509.CertificateRequest{
+		Subject:     current.Subject,
+		DNSNames:    current.DNSNames,
+		IPAddresses: current.IPAddresses,
+	}
+	if r.cfg.ACME != nil {
+		template.DNSNames = r.cfg.ACME.Domains
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, template, newKey)
+	if err != nil {
+		return errors.Wrap(err, "creating certificate signing request")
+	}
+
+	chain, err := r.issuer.issue(ctx, csr, current)
+	if err != nil {
+		return errors.Wrap(err, "obtaining renewed certificate")
+	}
+	if len(chain) == 0 {
+		return errors.New("no renewed certificate issued")
+	}
+	leaf := chain[0]
+	if !publicKeysEqual(newKey.Public(), leaf.PublicKey) {
+		return errors.New("renewed certificate is not for the new key")
+	}
+	if leaf.Subject.CommonName != current.Subject.CommonName {
+		return errors.Errorf("renewed certificate is for %q, not %q", leaf.Subject.CommonName,
+			current.Subject.CommonName)
+	}
+
+	if err := installKeyPair(tc, newKey, chain); err != nil {
+		return err
+	}
+	if _, err := r.mod.reloadSigningKey(); err != nil {
+		return errors.Wrap(err, "loading renewed certificate")
+	}
+
+	keyID, err := security.PublicKeyID(leaf.PublicKey)
+	if err != nil {
+		return err
+	}
+	r.log.Noticef("audit: renewed agent certificate with key %s, expiring at %s", keyID,
+		leaf.NotAfter.Format(time.RFC3339))
+	return nil
+}
+
+// generateKeyLike generates a new key of the same type and size as the key.
+func generateKeyLike(key crypto.PrivateKey) (crypto.Signer, error) {
+	var newKey crypto.Signer
+	var err error
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		newKey, err = rsa.GenerateKey(rand.Reader, k.N.BitLen())
+	case *ecdsa.PrivateKey:
+		newKey, err = ecdsa.GenerateKey(k.Curve, rand.Reader)
+	case ed25519.PrivateKey:
+		_, newKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, errors.Errorf("can't renew a certificate for a %T key", key)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "generating new key")
+	}
+	return newKey, nil
+}
+
+func publicKeysEqual(a, b crypto.PublicKey) bool {
+	ak, ok := a.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && ak.Equal(b)
+}
+
+// installKeyPair replaces the key and certificate files of the transport
+// config with the new key and certificate chain. The new files are checked by
+// loading them as the transport config would, against its CA certificate,
+// before replacing the old ones.
+func installKeyPair(tc *security.TransportConfig, key crypto.Signer, chain []*x509.Certificate) error {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return errors.Wrap(err, "encoding new key")
+	}
+	var certPEM []byte
+	for _, cert := range chain {
+		certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+
+	newKeyPath := tc.PrivateKeyPath + ".renewed"
+	newCertPath := tc.CertificatePath + ".renewed"
+	defer os.Remove(newKeyPath)
+	defer os.Remove(newCertPath)
+
+	os.Remove(newKeyPath)
+	if err := os.WriteFile(newKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+		security.MaxUserOnlyKeyPerm); err != nil {
+		return errors.Wrap(err, "writing new key")
+	}
+	if err := os.WriteFile(newCertPath, certPEM, security.MaxCertPerm); err != nil {
+		return errors.Wrap(err, "writing renewed certificate")
+	}
+
+	candidate := *tc
+	candidate.CertificatePath = newCertPath
+	candidate.PrivateKeyPath = newKeyPath
+	if _, err := candidate.Reload(); err != nil {
+		return errors.Wrap(err, "checking renewed certificate")
+	}
+
+	// The key is replaced first, so that a reload between the two renames
+	// fails on the mismatched pair and keeps the old key until the
+	// certificate has been replaced too.
+	if err := os.Rename(newKeyPath, tc.PrivateKeyPath); err != nil {
+		return errors.Wrap(err, "replacing key")
+	}
+	if err := os.Rename(newCertPath, tc.CertificatePath); err != nil {
+		return errors.Wrap(err, "replacing certificate")
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// testIssuer issues renewed certificates signed by the CA key, or for the
+// wrong key if badKey is set.
+type testIssuer struct {
+	ca     *x509.Certificate
+	caKey  crypto.Signer
+	badKey bool
+	issued int
+}
+
+func (i *testIssuer) issue(_ context.Context, csrDER []byte, current *x509.Certificate) ([]*x509.Certificate, error) {
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		return nil, err
+	}
+	pub := csr.PublicKey
+	if i.badKey {
+		other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		pub = other.Public()
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      current.Subject,
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, i.ca, pub, i.caKey)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	i.issued++
+	return []*x509.Certificate{cert}, nil
+}
+
+func TestAgent_certRenewer_check(t *testing.T) {
+	for name, tc := range map[string]struct {
+		after      time.Duration // time after now at which to check
+		badKey     bool
+		expRenewed bool
+		expErr     error
+	}{
+		"not due": {
+			after: 10 * time.Minute,
+		},
+		"due": {
+			after:      30 * time.Minute,
+			expRenewed: true,
+		},
+		"expired": {
+			after:      2 * time.Hour,
+			expRenewed: true,
+		},
+		"issued for wrong key": {
+			after:  30 * time.Minute,
+			badKey: true,
+			expErr: errors.New("not for the new key"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod, oldID := testKeyReloadModule(t, log)
+			cacheTestCredential(t, mod)
+			var reloaded *security.TransportConfig
+			mod.transportReloaded = func(tc *security.TransportConfig) {
+				reloaded = tc
+			}
+
+			// The test certificate is self-signed, so renewals are
+			// signed with the current key.
+			ca, err := mod.transport().Certificate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			caKey, err := mod.transport().PrivateKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			issuer := &testIssuer{ca: ca, caKey: caKey.(crypto.Signer), badKey: tc.badKey}
+			renewer := &certRenewer{
+				log:    log,
+				cfg:    &security.CertRenewalConfig{Method: security.CertRenewalDAOSCA},
+				mod:    mod,
+				issuer: issuer,
+			}
+			tcfg := mod.config.transport
+			oldCert, err := os.ReadFile(tcfg.CertificatePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = renewer.check(test.Context(t), time.Now().Add(tc.after))
+			test.CmpErr(t, tc.expErr, err)
+
+			for _, path := range []string{tcfg.CertificatePath + ".renewed", tcfg.PrivateKeyPath + ".renewed"} {
+				if _, err := os.Stat(path); !os.IsNotExist(err) {
+					t.Fatalf("temporary file %s not removed", path)
+				}
+			}
+			gotID, err := mod.signingKeyID()
+			if err != nil {
+				t.Fatal(err)
+			}
+			newCert, err := os.ReadFile(tcfg.CertificatePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !tc.expRenewed {
+				test.AssertEqual(t, oldID, gotID, "signing key changed")
+				test.AssertEqual(t, string(oldCert), string(newCert), "certificate replaced")
+				test.AssertEqual(t, 1, mod.credCache.cache.Len(), "credential flushed")
+				test.AssertTrue(t, reloaded == nil, "control client config updated")
+				return
+			}
+
+			test.AssertEqual(t, 1, issuer.issued, "unexpected number of certificates issued")
+			test.AssertTrue(t, gotID != oldID, "signing key not renewed")
+			test.AssertTrue(t, string(oldCert) != string(newCert), "certificate not replaced")
+			test.AssertEqual(t, 0, mod.credCache.cache.Len(), "credential signed with old key not flushed")
+			test.AssertTrue(t, reloaded == mod.transport(), "control client config not updated")
+		})
+	}
+}
+
+func TestAgent_generateKeyLike(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		key    crypto.PrivateKey
+		check  func(crypto.Signer) bool
+		expErr error
+	}{
+		"rsa": {
+			key: rsaKey,
+			check: func(k crypto.Signer) bool {
+				newKey, ok := k.(*rsa.PrivateKey)
+				return ok && newKey.N.BitLen() == 2048
+			},
+		},
+		"ecdsa": {
+			key: ecKey,
+			check: func(k crypto.Signer) bool {
+				newKey, ok := k.(*ecdsa.PrivateKey)
+				return ok && newKey.Curve == elliptic.P384()
+			},
+		},
+		"ed25519": {
+			key: edKey,
+			check: func(k crypto.Signer) bool {
+				_, ok := k.(ed25519.PrivateKey)
+				return ok
+			},
+		},
+		"unsupported": {
+			key:    "not a key",
+			expErr: errors.New("can't renew a certificate"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := generateKeyLike(tc.key)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertTrue(t, tc.check(got), "new key differs in type or size")
+		})
+	}
+}
@@ -0,0 +1,171 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// revokeCallerAllowed returns true if the peer on the other end of the session
+// is permitted to revoke issuer keys. The agent socket is world-writable, so
+// only root and the agent's own user may do so.
+func revokeCallerAllowed(log logging.Logger, session *drpc.Session) (bool, uint32) {
+	uc, ok := session.Conn.(*net.UnixConn)
+	if !ok {
+		return false, 0
+	}
+
+	info, err := security.DomainInfoFromUnixConn(log, uc)
+	if err != nil {
+		log.Errorf("unable to get credentials for client socket: %s", err)
+		return false, 0
+	}
+
+	uid := info.Uid()
+	return privilegedUid(uid), uid
+}
+
+// privilegedUid returns true if the user is root or the agent's own user.
+func privilegedUid(uid uint32) bool {
+	return uid == 0 || uid == uint32(os.Geteuid())
+}
+
+// purgeCredentials removes all cached credentials, returning the number removed.
+func (cc *credentialCache) purgeCredentials() uint32 {
+	if cc == nil {
+		return 0
+	}
+
+	cc.purgeMutex.Lock()
+	defer cc.purgeMutex.Unlock()
+	cc.generation++
+
+	var purged uint32
+	for _, key := range cc.cache.Keys() {
+		if flavor, owner, ok := cc.describe(key); ok {
+			cc.events.publish(credInvalidated, key, flavor, owner)
+		}
+		cc.cache.Delete(key)
+		purged++
+	}
+	cc.lru.reset()
+	cc.spool.clear()
+	cc.notifyFlushed()
+	return purged
+}
+
+// signingKeyID returns the ID of the key used to sign credentials.
+func (m *SecurityModule) signingKeyID() (string, error) {
+	return transportKeyID(m.credentialTransport())
+}
+
+// transportKeyID returns the ID of the signing key of the transport config.
+func transportKeyID(tc *security.TransportConfig) (string, error) {
+	signingKey, err := tc.PrivateKey()
+	if err != nil {
+		return "", err
+	}
+	if signingKey == nil {
+		return "", nil
+	}
+	return security.PrivateKeyID(signingKey)
+}
+
+// signingKeyRevoked returns true if the agent's signing key has been revoked.
+func (m *SecurityModule) signingKeyRevoked() bool {
+	return m.transportKeyRevoked(m.credentialTransport())
+}
+
+// transportKeyRevoked returns true if the signing key of the transport config
+// has been revoked.
+func (m *SecurityModule) transportKeyRevoked(tc *security.TransportConfig) bool {
+	if m.revoked.Len() == 0 {
+		return false
+	}
+
+	keyID, err := transportKeyID(tc)
+	if err != nil || keyID == "" {
+		return false
+	}
+	return m.revoked.IsRevoked(keyID)
+}
+
+func (m *SecurityModule) revokeIssuerKey(session *drpc.Session, body []byte) ([]byte, error) {
+	req := &auth.RevokeIssuerKeyReq{}
+	if err := proto.Unmarshal(body, req); err != nil {
+		return nil, drpc.UnmarshalingPayloadFailure()
+	}
+
+	allowed, uid := revokeCallerAllowed(m.log, session)
+	if !allowed {
+		m.log.Noticef("audit: denied request from uid %d to revoke issuer key %s", uid, req.KeyId)
+		return drpc.Marshal(&auth.RevokeIssuerKeyResp{Status: int32(daos.NoPermission)})
+	}
+
+	added, err := m.revoked.Revoke(req.KeyId, req.Reason)
+	if err != nil {
+		m.log.Errorf("failed to revoke issuer key: %v", err)
+		return drpc.Marshal(&auth.RevokeIssuerKeyResp{Status: int32(daos.InvalidInput)})
+	}
+
+	resp := &auth.RevokeIssuerKeyResp{}
+	if added {
+		m.log.Noticef("audit: uid %d revoked issuer key %s (reason: %q)", uid, req.KeyId, req.Reason)
+	}
+	if slices.ContainsFunc(m.signingTransports(), m.transportKeyRevoked) {
+		resp.Purged = m.credCache.purgeCredentials()
+		m.log.Noticef("audit: agent signing key %s is revoked; purged %d cached credentials", req.KeyId, resp.Purged)
+	}
+
+	return drpc.Marshal(resp)
+}
+
+type revokeIssuerKeyCmd struct {
+	configCmd
+	cmdutil.LogCmd
+	cmdutil.JSONOutputCmd
+	KeyID  string `long:"key-id" required:"1" description:"ID of the issuer key to revoke"`
+	Reason string `long:"reason" description:"Reason for the revocation, recorded in the audit log"`
+}
+
+func (cmd *revokeIssuerKeyCmd) Execute(_ []string) error {
+	keyID, err := auth.ParseKeyID(cmd.KeyID)
+	if err != nil {
+		return err
+	}
+
+	sockPath := filepath.Join(cmd.cfg.RuntimeDir, agentSockName)
+	resp, err := auth.RevokeIssuerKey(cmd.MustLogCtx(), sockPath, daos.MethodAgentRevokeIssuerKey,
+		&auth.RevokeIssuerKeyReq{KeyId: keyID, Reason: cmd.Reason})
+	if err != nil {
+		return err
+	}
+	if resp.Status != 0 {
+		return errors.Wrap(daos.Status(resp.Status), "revoking issuer key")
+	}
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, nil)
+	}
+
+	_, err = fmt.Printf("Revoked issuer key %s (%d cached credentials purged)\n", keyID, resp.Purged)
+	return err
+}
@@ -10,14 +10,20 @@ package main
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"net"
 	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unsafe"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/daos-stack/daos/src/control/drpc"
 	"github.com/daos-stack/daos/src/control/lib/cache"
+	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/daos"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/security"
@@ -28,28 +34,84 @@ type (
 	// credSignerFn defines the function signature for signing credentials.
 	credSignerFn func(context.Context, logging.Logger, auth.CredentialRequest) (*auth.Credential, error)
 
-	// credentialCache implements a cache for signed credentials.
+	// credentialCache implements a cache for signed credentials, keyed by
+	// the keyer. Its optional behaviors, such as encryption, persistence
+	// and refreshing ahead of expiry, are enabled by setting their fields.
 	credentialCache struct {
 		log          logging.Logger
-		cache        *cache.ItemCache
+		cache        *cache.ShardedItemCache
 		credLifetime time.Duration
+		// jitter, if set, expires each credential up to that much
+		// before credLifetime, so that credentials cached together do
+		// not all expire together.
+		jitter time.Duration
+		// refreshAhead, if set, is how long before they expire that
+		// credentials are refreshed in the background, while they
+		// continue to be served.
+		refreshAhead time.Duration
 		cacheMissFn  credSignerFn
+		// lru accounts for the size of the cached credentials, so that
+		// the least recently used can be evicted once the cache is full.
+		lru *credentialLRU
+		// metrics counts the cache events, such as hits, misses and
+		// evictions.
+		metrics *credentialCacheMetrics
+		// sealer, if set, encrypts the cached credentials, and spool,
+		// if set, persists them across agent restarts.
+		sealer *credentialSealer
+		spool  *credentialSpool
+		// pinned are UIDs whose credentials are never evicted, and are
+		// kept fresh.
+		pinned map[uint32]struct{}
+		// flushed, if set, is signaled whenever cached credentials are
+		// flushed.
+		flushed chan struct{}
+		keyer   *cacheKeyer
+		// events receives the lifecycle events of cached credentials.
+		events *credentialEventStream
+		// purgeMutex orders background refreshes with purges and
+		// invalidations, so that a refresh started before either does not
+		// repopulate the cache.
+		purgeMutex sync.RWMutex
+		generation uint64
 	}
 
-	// cachedCredential wraps a cached credential and implements the cache.ExpirableItem interface.
+	// cachedCredential wraps a cached credential and implements the
+	// cache.ExpirableItem and cache.EvictableItem interfaces. If the cache is
+	// encrypted, the credential is held in sealed instead of cred. The owner
+	// is the UID of the client it was cached for, if known.
 	cachedCredential struct {
 		cacheItem
 		key       string
+		flavor    auth.Flavor
+		owner     *uint32
+		createdAt time.Time
 		expiredAt time.Time
+		hits      uint64
 		cred      *auth.Credential
+		sealed    []byte
+		sealer    *credentialSealer
+		// refreshing is set while the credential is refreshed in the
+		// background.
+		refreshing bool
+		// pinned is set if the credential is kept fresh, using the
+		// request it was cached for.
+		pinned bool
+		req    auth.CredentialRequest
 	}
 
 	// securityConfig defines configuration parameters for SecurityModule.
 	securityConfig struct {
 		credentials *security.CredentialConfig
 		transport   *security.TransportConfig
-		infoCache   *InfoCache
-		sys         string
+		signingKeys []*security.SigningKeyConfig
+		// credentialKey, if set, signs credentials in place of the
+		// transport key.
+		credentialKey *security.SigningKeyConfig
+		infoCache     *InfoCache
+		sys           string
+		slos          *issuanceSLOs
+		cacheStats    *credentialCacheMetrics
 	}
 
 	// SecurityModule is the security drpc module struct
@@ -57,12 +119,60 @@ type (
 		log            logging.Logger
 		signCredential credSignerFn
 		credCache      *credentialCache
+		negCache       *negativeCache
+		flights        *credentialFlights
 		config         *securityConfig
 		infoCache      *InfoCache
+		slos           *issuanceSLOs
+		revoked        *auth.IssuerKeyRevocations
+		keyer          *cacheKeyer
+		// signingKeys are used in place of the transport key for the
+		// credentials of their systems or tenants.
+		signingKeys []*signingKey
+		// credentialKey, if set, is used in place of the transport key
+		// for the credentials of any system or tenant without a
+		// signing key of its own.
+		credentialKey *signingKey
+		// validFlavors caches the flavors allowed by the server, if they
+		// are refreshed rather than taken from the cached attach info.
+		validFlavors *validFlavorCache
+		// disabledFlavors are flavors which failed the startup self-test.
+		disabledFlavors map[auth.Flavor]error
+		// reloadedTransport replaces config.transport once the
+		// certificate and signing key have been reloaded.
+		reloadedTransport atomic.Pointer[security.TransportConfig]
+		reloadMutex       sync.Mutex
+		// transportReloaded is called with the reloaded transport
+		// config once the agent's certificate and key changed, so that
+		// the control plane client presents the new certificate.
+		transportReloaded func(*security.TransportConfig)
+		// presigned are credentials signed ahead of time, served in
+		// place of those the agent would sign for their users.
+		presigned *auth.PresignedCredentials
+		// cosigner has privileged credentials co-signed by a
+		// security officer's key before they are returned.
+		cosigner *auth.Cosigner
+		// nonces, if set, supplies the nonce issued by the servers
+		// which is recorded in each credential.
+		nonces *nonceSupply
 	}
 )
 
-var _ cache.ExpirableItem = (*cachedCredential)(nil)
+const (
+	// credRefreshTimeout bounds a background refresh of a cached credential.
+	credRefreshTimeout = 30 * time.Second
+	// credCacheShards is the number of independently locked shards of the
+	// credential cache, so that concurrent requests for different
+	// credentials do not contend for a single lock.
+	credCacheShards = 16
+
+	credentialOwnerKey ctxKey = "credential_owner"
+)
+
+var (
+	_ cache.ExpirableItem = (*cachedCredential)(nil)
+	_ cache.EvictableItem = (*cachedCredential)(nil)
+)
 
 func getCredReq(reqb []byte) (*auth.GetCredReq, error) {
 	credReq := new(auth.GetCredReq)
@@ -91,21 +201,76 @@ func NewSecurityModule(log logging.Logger, cfg *securityConfig) *SecurityModule
 	if cfg.credentials.CacheExpiration > 0 {
 		credCache = &credentialCache{
 			log:          log,
-			cache:        cache.NewItemCache(log),
+			cache:        cache.NewShardedItemCache(log, credCacheShards),
 			credLifetime: cfg.credentials.CacheExpiration,
+			jitter:       cfg.credentials.CacheJitter,
+			refreshAhead: cfg.credentials.CacheRefreshAhead,
 			cacheMissFn:  credentialRequestGetSigned,
+			metrics:      cfg.cacheStats,
+			pinned:       resolvePinnedUsers(log, cfg.credentials.CachePinnedUsers),
+			events:       newCredentialEventStream(log, cfg.credentials.CacheEventLog),
 		}
+		if len(cfg.credentials.CacheWarmup) > 0 {
+			credCache.flushed = make(chan struct{}, 1)
+		}
+		credCache.lru = newCredentialLRU(cfg.credentials.CacheMaxEntries, cfg.credentials.CacheMaxPerUser,
+			uint64(cfg.credentials.CacheMaxMem), cfg.cacheStats)
+		credCache.lru.events = credCache.events
+		if cfg.credentials.EncryptCache() {
+			sealer, err := loadCredentialSealer(&cfg.credentials.CacheEncryption, cfg.transport)
+			if err != nil {
+				// Cached credentials are bearer-equivalent, so they are not
+				// cached at all rather than cached in the clear.
+				log.Errorf("credential cache disabled: %s", err)
+				credCache = nil
+			} else {
+				credCache.sealer = sealer
+			}
+		}
+	}
+	var sealer *credentialSealer
+	if credCache != nil {
+		sealer = credCache.sealer
+	}
+	keyer, err := newCacheKeyer(sealer)
+	if err != nil && credCache != nil {
+		log.Errorf("credential cache disabled: %s", err)
+		credCache = nil
+	}
+	if credCache != nil {
+		credCache.keyer = keyer
 		credSigner = credCache.getSignedCredential
-		log.Noticef("credential cache enabled (entry lifetime: %s)", cfg.credentials.CacheExpiration)
+		log.Noticef("credential cache enabled (entry lifetime: %s, max entries: %d, max mem: %d bytes, encrypted: %t)",
+			cfg.credentials.CacheExpiration, cfg.credentials.CacheMaxEntries,
+			cfg.credentials.CacheMaxMem, credCache.sealer != nil)
+
+		if cfg.credentials.CacheSpoolDir != "" {
+			if err := credCache.enableSpool(cfg.credentials.CacheSpoolDir); err != nil {
+				log.Errorf("credential cache will not be persisted: %s", err)
+			}
+		}
 	}
 
-	return &SecurityModule{
+	mod := &SecurityModule{
 		log:            log,
 		signCredential: credSigner,
 		credCache:      credCache,
+		negCache:       newNegativeCache(log, &cfg.credentials.NegativeCache),
+		flights:        newCredentialFlights(),
 		config:         cfg,
 		infoCache:      cfg.infoCache,
+		slos:           cfg.slos,
+		revoked:        auth.NewIssuerKeyRevocations(),
+		keyer:          keyer,
+		signingKeys:    newSigningKeys(cfg.signingKeys),
+	}
+	if cfg.credentialKey != nil {
+		mod.credentialKey = newSigningKeys([]*security.SigningKeyConfig{cfg.credentialKey})[0]
+	}
+	if cfg.credentials.ValidFlavorsTTL > 0 {
+		mod.validFlavors = newValidFlavorCache(log, cfg.credentials.ValidFlavorsTTL, mod.fetchValidAuthFlavors)
 	}
+	return mod
 }
 
 // Key returns the key for the cached credential.
@@ -117,9 +282,18 @@ func (cred *cachedCredential) Key() string {
 	return cred.key
 }
 
+// size returns the approximate memory held by the cached credential.
+func (cred *cachedCredential) size() uint64 {
+	size := uint64(unsafe.Sizeof(*cred)) + uint64(len(cred.key)) + uint64(len(cred.sealed))
+	if cred.cred != nil {
+		size += uint64(proto.Size(cred.cred))
+	}
+	return size
+}
+
 // IsExpired returns true if the cached credential is expired.
 func (cred *cachedCredential) IsExpired() bool {
-	if cred == nil || cred.cred == nil || cred.expiredAt.IsZero() {
+	if cred == nil || (cred.cred == nil && cred.sealed == nil) || cred.expiredAt.IsZero() {
 		return true
 	}
 
@@ -127,54 +301,211 @@ func (cred *cachedCredential) IsExpired() bool {
 }
 
 func (cc *credentialCache) getSignedCredential(ctx context.Context, log logging.Logger, req auth.CredentialRequest) (*auth.Credential, error) {
-	key := req.GetKey()
+	key := cc.keyer.key(req)
+	// A credential cached under the key which is not found has expired.
+	wasCached := cc.cache.Has(key)
 
+	var created bool
 	createItem := func() (cache.Item, error) {
 		cc.log.Tracef("cache miss for %s", key)
-		cred, err := cc.cacheMissFn(ctx, log, req)
+		cc.metrics.count(cacheMiss, req.GetAuthFlavor())
+		if wasCached {
+			cc.events.publish(credExpired, key, req.GetAuthFlavor(), credentialOwner(ctx))
+		}
+		created = true
+		item, err := cc.newItem(ctx, log, req)
 		if err != nil {
 			return nil, err
 		}
-		cc.log.Tracef("getting credential for %s", key)
-		return newCachedCredential(key, cred, cc.credLifetime)
+		cc.events.publish(credCreated, key, item.flavor, item.owner)
+		if err := cc.spool.store(item); err != nil {
+			cc.log.Errorf("persisting cached credential: %s", err)
+		}
+		return item, nil
 	}
 
 	item, release, err := cc.cache.GetOrCreate(ctx, key, createItem)
 	if err != nil {
 		return nil, errors.Wrap(err, "getting cached credential from cache")
 	}
-	defer release()
-
 	cachedCred, ok := item.(*cachedCredential)
 	if !ok {
+		release()
 		return nil, errors.New("invalid cached credential")
 	}
+	// Deferred first so that it runs once the item has been released, as
+	// evicting other items locks the cache.
+	defer cc.touch(key, cachedCred.flavor, cachedCred.owner, cachedCred.size())
+	defer release()
+
+	if !created {
+		cachedCred.hits++
+		cc.metrics.count(cacheHit, req.GetAuthFlavor())
+	}
+	if cachedCred.needsRefresh(cc.refreshAhead) {
+		cachedCred.refreshing = true
+		go cc.refresh(ctx, log, req, cachedCred)
+	}
+
+	return cachedCred.credential()
+}
 
-	return cachedCred.cred, nil
+// newItem requests a new credential, to be cached under the key of the request.
+func (cc *credentialCache) newItem(ctx context.Context, log logging.Logger, req auth.CredentialRequest) (*cachedCredential, error) {
+	cred, err := cc.cacheMissFn(ctx, log, req)
+	if err != nil {
+		cc.metrics.count(cacheError, req.GetAuthFlavor())
+		return nil, err
+	}
+	cc.log.Tracef("getting credential for %s", req.GetKey())
+	item, err := newCachedCredential(cc.keyer.key(req), cred, cc.lifetime(), cc.sealer)
+	if err != nil {
+		return nil, err
+	}
+	item.owner = credentialOwner(ctx)
+	if cc.isPinned(item.owner) {
+		item.pinned = true
+		item.req = req
+	}
+	return item, nil
 }
 
-func newCachedCredential(key string, cred *auth.Credential, lifetime time.Duration) (*cachedCredential, error) {
+// lifetime returns the lifetime of a newly cached credential, reduced by a
+// random jitter so that credentials cached in a burst, such as at the start of
+// a job, are not all requested again at the same time.
+func (cc *credentialCache) lifetime() time.Duration {
+	if cc.jitter <= 0 {
+		return cc.credLifetime
+	}
+	return cc.credLifetime - time.Duration(rand.Int63n(int64(cc.jitter)+1))
+}
+
+// needsRefresh returns true if the credential expires within refreshAhead, and
+// is not already being refreshed.
+func (cred *cachedCredential) needsRefresh(refreshAhead time.Duration) bool {
+	return refreshAhead > 0 && !cred.refreshing && time.Until(cred.expiredAt) < refreshAhead
+}
+
+// refresh replaces the cached credential, which is nearing expiry, with a new
+// one. The current credential continues to be served until it is replaced, or
+// expires if the refresh fails.
+func (cc *credentialCache) refresh(ctx context.Context, log logging.Logger, req auth.CredentialRequest, old *cachedCredential) {
+	// The request which triggered the refresh may complete first.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), credRefreshTimeout)
+	defer cancel()
+
+	cc.purgeMutex.RLock()
+	generation := cc.generation
+	cc.purgeMutex.RUnlock()
+
+	item, err := cc.newItem(ctx, log, req)
+	if err != nil {
+		cc.log.Debugf("background refresh of credential for %s failed: %s", old.key, err)
+		old.Lock()
+		old.refreshing = false
+		old.Unlock()
+		return
+	}
+
+	cc.purgeMutex.RLock()
+	defer cc.purgeMutex.RUnlock()
+	if cc.generation != generation {
+		cc.log.Debugf("discarding credential for %s refreshed during a purge", old.key)
+		item.Evict()
+		// The credential may have been kept, if only another was
+		// invalidated, so allow it to be refreshed again.
+		old.Lock()
+		old.refreshing = false
+		old.Unlock()
+		return
+	}
+	if err := cc.cache.Set(item); err != nil {
+		cc.log.Errorf("caching refreshed credential: %s", err)
+		return
+	}
+	if err := cc.spool.store(item); err != nil {
+		cc.log.Errorf("persisting cached credential: %s", err)
+	}
+	cc.touch(item.key, item.flavor, item.owner, item.size())
+	cc.metrics.count(cacheRefresh, req.GetAuthFlavor())
+	cc.events.publish(credRefreshed, item.key, item.flavor, item.owner)
+	cc.log.Tracef("refreshed credential for %s", item.key)
+}
+
+// touch marks the cached credential as the most recently used, evicting the
+// least recently used credentials if the cache is full. Pinned credentials are
+// not accounted for, so they are never evicted.
+func (cc *credentialCache) touch(key string, flavor auth.Flavor, owner *uint32, size uint64) {
+	if cc.isPinned(owner) {
+		return
+	}
+	for _, evicted := range cc.lru.touch(key, flavor, owner, size) {
+		cc.log.Tracef("evicting least recently used credential for %s", evicted)
+		cc.cache.Delete(evicted)
+	}
+}
+
+// enableSpool persists the cached credentials to the spool directory, and
+// restores the unexpired credentials persisted before the agent restarted.
+func (cc *credentialCache) enableSpool(dir string) error {
+	spool, err := newCredentialSpool(cc.log, dir, cc.sealer)
+	if err != nil {
+		return err
+	}
+	cc.spool = spool
+
+	var restored int
+	for _, item := range spool.load() {
+		if err := cc.cache.Set(item); err != nil {
+			cc.log.Errorf("restoring cached credential: %s", err)
+			continue
+		}
+		cc.touch(item.key, item.flavor, item.owner, item.size())
+		restored++
+	}
+	cc.log.Noticef("credential cache persisted to %s (restored %d credentials)", dir, restored)
+
+	return nil
+}
+
+// newCachedCredential returns a cached credential, encrypted if the sealer is
+// set.
+func newCachedCredential(key string, cred *auth.Credential, lifetime time.Duration, sealer *credentialSealer) (*cachedCredential, error) {
 	if cred == nil {
 		return nil, errors.New("credential is nil")
 	}
 
-	return &cachedCredential{
+	now := time.Now()
+	item := &cachedCredential{
 		key:       key,
+		flavor:    cred.GetToken().GetFlavor(),
+		createdAt: now,
+		expiredAt: now.Add(lifetime),
 		cred:      cred,
-		expiredAt: time.Now().Add(lifetime),
-	}, nil
+	}
+	if err := item.sealCredential(sealer); err != nil {
+		return nil, errors.Wrap(err, "encrypting cached credential")
+	}
+	return item, nil
 }
 
 // HandleCall is the handler for calls to the SecurityModule
 func (m *SecurityModule) HandleCall(ctx context.Context, session *drpc.Session, method drpc.Method, reqb []byte) ([]byte, error) {
-	credReq, err := getCredReq(reqb)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to parse request body")
-	}
-
 	switch method {
 	case daos.MethodRequestCredentials:
-		validAuthFlavors, err := m.retrieveAuthFromServer(ctx)
+		credReq, err := getCredReq(reqb)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse request body")
+		}
+
+		sys, err := m.credentialSystem(credReq.Sys)
+		if err != nil {
+			m.log.Errorf("refusing credential request: %s", err)
+			return m.credRespWithStatus(daos.InvalidInput)
+		}
+		credReq.Sys = sys
+
+		validAuthFlavors, err := m.retrieveAuthFromServer(ctx, sys)
 		if err != nil || len(validAuthFlavors) == 0 {
 			return nil, errors.Wrap(err, "error in retrieving auth flavors from server")
 		}
@@ -182,20 +513,46 @@ func (m *SecurityModule) HandleCall(ctx context.Context, session *drpc.Session,
 		if !slices.Contains(validAuthFlavors, credReq.Flavor) {
 			return nil, errors.Errorf("invalid authentication method: the method requested is not allowed by the server configuration.")
 		}
+		if err, disabled := m.disabledFlavors[credReq.Flavor]; disabled {
+			return nil, errors.Wrapf(err, "%s is disabled after a failed self-test", credReq.Flavor)
+		}
 		return m.getCredential(ctx, session, credReq)
 	case daos.MethodRequestValidFlavors:
-		return m.getValidAuthFlavors(ctx)
+		return m.getValidAuthFlavors(ctx, reqb)
+	case daos.MethodAgentRevokeIssuerKey:
+		return m.revokeIssuerKey(session, reqb)
+	case daos.MethodRequestChallenge:
+		return m.getChallenge(session)
+	case daos.MethodInvalidateCredential:
+		return m.invalidateCredential(ctx, session, reqb)
 	}
 
 	return nil, drpc.UnknownMethodFailure()
 }
 
-func (m *SecurityModule) retrieveAuthFromServer(ctx context.Context) ([]auth.Flavor, error) {
-	resp, err := m.infoCache.GetAttachInfo(ctx, m.config.sys)
+func (m *SecurityModule) retrieveAuthFromServer(ctx context.Context, sys string) ([]auth.Flavor, error) {
+	if m.validFlavors != nil {
+		return m.validFlavors.get(ctx, sys)
+	}
+
+	resp, err := m.infoCache.GetAttachInfo(ctx, sys)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get attach info")
+	}
+	return validAuthFlavorsFromAttachInfo(resp)
+}
+
+// fetchValidAuthFlavors fetches the flavors allowed by the system, bypassing
+// the attach info cache.
+func (m *SecurityModule) fetchValidAuthFlavors(ctx context.Context, sys string) ([]auth.Flavor, error) {
+	resp, err := m.infoCache.getAttachInfoRemote(ctx, sys)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get attach info")
 	}
+	return validAuthFlavorsFromAttachInfo(resp)
+}
 
+func validAuthFlavorsFromAttachInfo(resp *control.GetAttachInfoResp) ([]auth.Flavor, error) {
 	validAuthFlavors := make([]auth.Flavor, len(resp.ValidAuthFlavors))
 	for i := 0; i < len(resp.ValidAuthFlavors); i++ {
 		validAuthFlavors[i] = auth.Flavor(resp.ValidAuthFlavors[i])
@@ -208,14 +565,75 @@ func (m *SecurityModule) retrieveAuthFromServer(ctx context.Context) ([]auth.Fla
 	return validAuthFlavors, nil
 }
 
+// withCredentialOwner returns a context recording the UID of the client on the
+// other end of the session, if credentials are cached per user, invalidated on
+// group changes or pinned.
+func (m *SecurityModule) withCredentialOwner(ctx context.Context, session *drpc.Session) context.Context {
+	creds := m.config.credentials
+	if m.credCache == nil ||
+		(creds.CacheMaxPerUser <= 0 && !creds.GroupWatch.Enabled() && len(m.credCache.pinned) == 0) {
+		return ctx
+	}
+
+	uc, ok := session.Conn.(*net.UnixConn)
+	if !ok {
+		return ctx
+	}
+	info, err := security.DomainInfoFromUnixConn(m.log, uc)
+	if err != nil {
+		m.log.Errorf("unable to get credentials for client socket: %s", err)
+		return ctx
+	}
+	uid := info.Uid()
+	return context.WithValue(ctx, credentialOwnerKey, &uid)
+}
+
+// credentialOwner returns the UID of the client requesting a credential, if
+// known.
+func credentialOwner(ctx context.Context) *uint32 {
+	uid, _ := ctx.Value(credentialOwnerKey).(*uint32)
+	return uid
+}
+
+// checkProcessPeer checks that a credential bound to a process, which may have
+// been served from the cache or restored from a snapshot of it, is only handed
+// to that process, the peer of the session.
+func (m *SecurityModule) checkProcessPeer(session *drpc.Session, cred *auth.Credential) error {
+	var pid int32
+	if uc, ok := session.Conn.(*net.UnixConn); ok {
+		if info, err := security.DomainInfoFromUnixConn(m.log, uc); err == nil {
+			pid = info.Pid()
+		}
+	}
+	return auth.ValidateProcessPeer(cred.GetToken(), pid)
+}
+
 // getCredentials generates a signed user credential based on the authentication method requested.
 func (m *SecurityModule) getCredential(ctx context.Context, session *drpc.Session, credReq *auth.GetCredReq) ([]byte, error) {
-	signingKey, err := m.config.transport.PrivateKey()
+	issueStart := time.Now()
+	ctx = m.withCredentialOwner(ctx, session)
+	if cred := m.presignedCredential(session, credReq); cred != nil {
+		m.slos.record(time.Since(issueStart), nil)
+		return drpc.Marshal(&auth.GetCredResp{Cred: cred})
+	}
+	signingTransport, err := m.signingTransport(ctx, credReq.Sys, credReq.Tenant, m.sessionPeer(session, credReq.Tenant))
 	if err != nil {
+		m.slos.record(time.Since(issueStart), err)
+		m.log.Errorf("refusing credential request: %s", err)
+		return m.credRespWithStatus(daos.InvalidInput)
+	}
+	signingKey, err := signingTransport.PrivateKey()
+	if err != nil {
+		m.slos.record(time.Since(issueStart), err)
 		m.log.Errorf("failed to get signing key: %s", err)
 		// something is wrong with the cert config
 		return m.credRespWithStatus(daos.BadCert)
 	}
+	if m.transportKeyRevoked(signingTransport) {
+		m.slos.record(time.Since(issueStart), daos.FailedSign)
+		m.log.Error("refusing to sign credential with revoked signing key")
+		return m.credRespWithStatus(daos.FailedSign)
+	}
 
 	req, err := auth.FlavorToFactory[credReq.Flavor].Init(m.log, m.config.credentials, session, credReq.Data, signingKey)
 	if err != nil {
@@ -225,15 +643,94 @@ func (m *SecurityModule) getCredential(ctx context.Context, session *drpc.Sessio
 		m.log.Errorf("Unable to get credentials for client socket: %s", err)
 		return nil, err
 	}
-
-	cred, err := m.signCredential(ctx, m.log, req)
+	renewable, _ := req.(auth.RenewableCredentialRequest)
+	if credReq.RenewalToken != "" {
+		if renewable == nil {
+			m.log.Errorf("%s credentials cannot be renewed", credReq.Flavor)
+			return m.credRespWithStatus(daos.InvalidInput)
+		}
+		renewable.UseRenewalToken(credReq.RenewalToken)
+	}
+	// Credentials are cached per system and tenant, so that one cached for
+	// a system or tenant can't satisfy a request for another.
+	req = newSystemCredentialRequest(credReq.Sys, newTenantCredentialRequest(credReq.Tenant, req))
+
+	signCredential := m.signCredential
+	switch credReq.Flavor {
+	case auth.Flavor_AUTH_DELEGATION:
+		// Delegation credentials carry their own expiry, so are never cached.
+		signCredential = credentialRequestGetSigned
+	case auth.Flavor_AUTH_ACCMAN:
+		// Access manager responses are cached by the flavor itself, so
+		// that they can be invalidated when access is revoked.
+		signCredential = credentialRequestGetSigned
+	}
+	key := m.keyer.key(req)
+	negKey := negativeCacheKey(credReq.Flavor, key)
+	if err := m.negCache.check(negKey); err != nil {
+		m.slos.record(time.Since(issueStart), err)
+		m.log.Debugf("failed to get user credential: %s", err)
+		return m.credRespWithStatus(daos.FailedSign)
+	}
+	// Concurrent requests for the same credential are signed only once.
+	signed, err := m.flights.do(ctx, credReq.Flavor.String()+":"+key,
+		func(ctx context.Context) (*signedCredential, error) {
+			cred, err := signCredential(ctx, m.log, req)
+			if err != nil {
+				m.negCache.record(negKey, err)
+				return nil, err
+			}
+			result := &signedCredential{cred: cred}
+			if renewable != nil {
+				result.renewalToken = renewable.RenewalToken()
+			}
+			return result, nil
+		})
+	var cred *auth.Credential
+	if err == nil {
+		cred = signed.cred
+	}
+	// Guest credentials are always issued as one-time credentials, so that
+	// they are short-lived.
+	if err == nil && (credReq.Scope == auth.Scope_SCOPE_ONE_TIME || credReq.Flavor == auth.Flavor_AUTH_ANON) {
+		// One-time credentials are never cached, so derive a fresh one from
+		// the (possibly cached) credential for each request.
+		cred, err = auth.NewOneTimeCredential(cred, signingKey)
+	}
+	if err == nil {
+		err = m.checkProcessPeer(session, cred)
+	}
+	// The servers accept each nonce only once, so one is recorded in each
+	// credential handed out, rather than in the cached credential.
+	if err == nil && m.nonces != nil {
+		if nonce := m.nonces.take(ctx); nonce != nil {
+			cred, err = auth.NewNonceCredential(cred, signingKey, nonce)
+		}
+	}
+	if err == nil {
+		cred, err = m.cosign(ctx, cred)
+	}
+	m.slos.record(time.Since(issueStart), err)
 	if err != nil {
 		m.log.Errorf("failed to get user credential: %s", err)
 		return m.credRespWithStatus(daos.FailedSign)
 	}
 
-	resp := &auth.GetCredResp{Cred: cred}
-	return drpc.Marshal(resp)
+	return drpc.Marshal(&auth.GetCredResp{Cred: cred, RenewalToken: signed.renewalToken})
+}
+
+// cosign returns a copy of the credential co-signed by the co-signing service,
+// if it is privileged. As the credential may be cached, it is co-signed for
+// each request, so that the co-signing service approves each use of it.
+func (m *SecurityModule) cosign(ctx context.Context, cred *auth.Credential) (*auth.Credential, error) {
+	if m.cosigner == nil {
+		return cred, nil
+	}
+	cosigned := proto.Clone(cred).(*auth.Credential)
+	if err := m.cosigner.Cosign(ctx, cosigned); err != nil {
+		return nil, errors.Wrap(err, "co-signing credential")
+	}
+	return cosigned, nil
 }
 
 func (m *SecurityModule) credRespWithStatus(status daos.Status) ([]byte, error) {
@@ -241,8 +738,27 @@ func (m *SecurityModule) credRespWithStatus(status daos.Status) ([]byte, error)
 	return drpc.Marshal(resp)
 }
 
-func (m *SecurityModule) getValidAuthFlavors(ctx context.Context) ([]byte, error) {
-	validAuthFlavors, err := m.retrieveAuthFromServer(ctx)
+func (m *SecurityModule) getChallenge(session *drpc.Session) ([]byte, error) {
+	challenge, err := auth.IssueChallenge(m.log, session)
+	if err != nil {
+		m.log.Errorf("unable to issue challenge: %s", err)
+		return drpc.Marshal(&auth.ChallengeResp{Status: int32(daos.MiscError)})
+	}
+
+	return drpc.Marshal(&auth.ChallengeResp{Challenge: challenge})
+}
+
+func (m *SecurityModule) getValidAuthFlavors(ctx context.Context, reqb []byte) ([]byte, error) {
+	req := new(auth.GetValidFlavorsReq)
+	if err := proto.Unmarshal(reqb, req); err != nil {
+		return nil, drpc.UnmarshalingPayloadFailure()
+	}
+	sys, err := m.credentialSystem(req.Sys)
+	if err != nil {
+		return nil, err
+	}
+
+	validAuthFlavors, err := m.retrieveAuthFromServer(ctx, sys)
 	if err != nil {
 		return nil, errors.Wrap(err, "error in retrieving auth flavors from server")
 	}
@@ -257,6 +773,12 @@ func (m *SecurityModule) GetMethod(id int32) (drpc.Method, error) {
 		return daos.MethodRequestCredentials, nil
 	} else if id == daos.MethodRequestValidFlavors.ID() {
 		return daos.MethodRequestValidFlavors, nil
+	} else if id == daos.MethodAgentRevokeIssuerKey.ID() {
+		return daos.MethodAgentRevokeIssuerKey, nil
+	} else if id == daos.MethodRequestChallenge.ID() {
+		return daos.MethodRequestChallenge, nil
+	} else if id == daos.MethodInvalidateCredential.ID() {
+		return daos.MethodInvalidateCredential, nil
 	}
 
 	return nil, fmt.Errorf("invalid method ID %d for module %s", id, m.String())
@@ -8,10 +8,16 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"net"
+	"os"
 	"os/user"
+	"strconv"
+	"strings"
+	"sync"
 	"syscall"
 	"testing"
 	"time"
@@ -50,6 +56,18 @@ func TestAgent_SecurityModule_GetMethod(t *testing.T) {
 			methodID:  daos.MethodRequestCredentials.ID(),
 			expMethod: daos.MethodRequestCredentials,
 		},
+		"revoke-issuer-key": {
+			methodID:  daos.MethodAgentRevokeIssuerKey.ID(),
+			expMethod: daos.MethodAgentRevokeIssuerKey,
+		},
+		"request-challenge": {
+			methodID:  daos.MethodRequestChallenge.ID(),
+			expMethod: daos.MethodRequestChallenge,
+		},
+		"invalidate-credential": {
+			methodID:  daos.MethodInvalidateCredential.ID(),
+			expMethod: daos.MethodInvalidateCredential,
+		},
 		"unknown": {
 			methodID: -1,
 			expErr:   errors.New("method ID -1"),
@@ -163,6 +181,95 @@ func TestAgentSecurityModule_RequestCreds_OK(t *testing.T) {
 	expectCredResp(t, respBytes, 0, true)
 }
 
+func TestAgentSecurityModule_RequestCreds_RenewalNotSupported(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	// Set up a real unix socket so we can make a real connection
+	conn, cleanup := setupTestUnixConn(t)
+	defer cleanup()
+
+	reqBytes, err := proto.Marshal(&auth.GetCredReq{
+		Flavor:       auth.Flavor_AUTH_SYS,
+		RenewalToken: "renew-jdoe",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod := NewSecurityModule(log, defaultTestSecurityConfig(t, log, testInfoCacheParams{}))
+	respBytes, err := mod.HandleCall(test.Context(t), newTestSession(t, log, conn),
+		daos.MethodRequestCredentials, reqBytes)
+	if err != nil {
+		t.Fatalf("Expected no error, got %+v", err)
+	}
+
+	expectCredResp(t, respBytes, int32(daos.InvalidInput), false)
+}
+
+func TestAgentSecurityModule_RequestCreds_OneTime(t *testing.T) {
+	for name, tc := range map[string]struct {
+		scope      auth.Scope
+		expOneTime bool
+	}{
+		"default scope": {
+			scope: auth.Scope_SCOPE_DEFAULT,
+		},
+		"one-time scope": {
+			scope:      auth.Scope_SCOPE_ONE_TIME,
+			expOneTime: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			// Set up a real unix socket so we can make a real connection
+			conn, cleanup := setupTestUnixConn(t)
+			defer cleanup()
+
+			reqBytes, err := proto.Marshal(&auth.GetCredReq{
+				Flavor: auth.Flavor_AUTH_SYS,
+				Scope:  tc.scope,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			mod := NewSecurityModule(log, defaultTestSecurityConfig(t, log, testInfoCacheParams{}))
+			var tokens []*auth.Sys
+			for i := 0; i < 2; i++ {
+				respBytes, err := mod.HandleCall(test.Context(t), newTestSession(t, log, conn),
+					daos.MethodRequestCredentials, reqBytes)
+				if err != nil {
+					t.Fatalf("Expected no error, got %+v", err)
+				}
+				expectCredResp(t, respBytes, 0, true)
+
+				resp := &auth.GetCredResp{}
+				if err := proto.Unmarshal(respBytes, resp); err != nil {
+					t.Fatal(err)
+				}
+				sys := &auth.Sys{}
+				if err := proto.Unmarshal(resp.Cred.Token.Data, sys); err != nil {
+					t.Fatal(err)
+				}
+				tokens = append(tokens, sys)
+			}
+
+			for _, sys := range tokens {
+				test.AssertEqual(t, tc.expOneTime, sys.Scope == auth.Scope_SCOPE_ONE_TIME, "unexpected scope")
+				test.AssertEqual(t, tc.expOneTime, len(sys.Nonce) > 0, "unexpected nonce")
+			}
+			// Each one-time credential is derived afresh, rather than
+			// served from the cache.
+			if tc.expOneTime && bytes.Equal(tokens[0].Nonce, tokens[1].Nonce) {
+				t.Fatal("one-time credential handed out twice")
+			}
+		})
+	}
+}
+
 func TestAgentSecurityModule_RequestCreds_NotUnixConn(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer test.ShowBufferOnFailure(t, buf)
@@ -341,7 +448,7 @@ func TestAgent_SecurityRPC_getCredential(t *testing.T) {
 			defer cleanup()
 
 			CredentialRequestGetSignedTesting := func(ctx context.Context, log logging.Logger, req auth.CredentialRequest) (*auth.Credential, error) {
-				r, ok := req.(*auth.AuthSysCredentialRequest)
+				r, ok := unwrapCredentialRequest(req).(*auth.AuthSysCredentialRequest)
 				if !ok {
 					return nil, errors.New("Testing fn used with non-unix credential request")
 				}
@@ -376,6 +483,68 @@ func TestAgent_SecurityRPC_getCredential(t *testing.T) {
 	}
 }
 
+func TestAgent_SecurityModule_checkProcessPeer(t *testing.T) {
+	// The test process is the peer of both ends of the test connection.
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", os.Getpid()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+	start, err := strconv.ParseUint(fields[19], 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	boundCred := func(pid int, start uint64) *auth.Credential {
+		data, err := proto.Marshal(&auth.Sys{User: "user@", Pid: uint32(pid), ProcessStart: start})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &auth.Credential{Token: &auth.Token{Flavor: auth.Flavor_AUTH_SYS, Data: data}}
+	}
+
+	for name, tc := range map[string]struct {
+		notUnix bool
+		cred    *auth.Credential
+		expErr  error
+	}{
+		"not bound": {
+			cred: boundCred(0, 0),
+		},
+		"bound to peer": {
+			cred: boundCred(os.Getpid(), start),
+		},
+		"bound to another process": {
+			cred:   boundCred(os.Getpid()+1, start),
+			expErr: errors.New("presented by pid"),
+		},
+		"bound to peer's pid before it was reused": {
+			cred:   boundCred(os.Getpid(), start+1),
+			expErr: errors.New("presented by another process"),
+		},
+		"peer unknown": {
+			notUnix: true,
+			cred:    boundCred(os.Getpid(), start),
+			expErr:  errors.New("presented by pid 0"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var conn net.Conn = &net.TCPConn{}
+			if !tc.notUnix {
+				unixConn, cleanup := setupTestUnixConn(t)
+				defer cleanup()
+				conn = unixConn
+			}
+
+			mod := NewSecurityModule(log, defaultTestSecurityConfig(t, log, testInfoCacheParams{}))
+			test.CmpErr(t, tc.expErr, mod.checkProcessPeer(newTestSession(t, log, conn), tc.cred))
+		})
+	}
+}
+
 func TestAgent_SecurityCachedCredentials(t *testing.T) {
 	cred0 := &auth.Credential{
 		Token:  &auth.Token{Flavor: auth.Flavor_AUTH_SYS, Data: []byte("user,group1,group2")},
@@ -466,3 +635,303 @@ func TestAgent_SecurityCachedCredentials(t *testing.T) {
 		})
 	}
 }
+
+func TestAgent_SecurityModule_RevokeIssuerKey(t *testing.T) {
+	validID := strings.Repeat("ab", 32)
+
+	for name, tc := range map[string]struct {
+		notUnixConn bool
+		keyID       string
+		expStatus   daos.Status
+		expRevoked  bool
+	}{
+		"not unix conn": {
+			notUnixConn: true,
+			keyID:       validID,
+			expStatus:   daos.NoPermission,
+		},
+		"invalid key ID": {
+			keyID:     "bad",
+			expStatus: daos.InvalidInput,
+		},
+		"success": {
+			keyID:      validID,
+			expRevoked: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var conn net.Conn = &net.TCPConn{}
+			if !tc.notUnixConn {
+				uc, cleanup := setupTestUnixConn(t)
+				defer cleanup()
+				conn = uc
+			}
+
+			mod := NewSecurityModule(log, defaultTestSecurityConfig(t, log, testInfoCacheParams{}))
+			reqBytes, err := proto.Marshal(&auth.RevokeIssuerKeyReq{KeyId: tc.keyID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			respBytes, err := mod.HandleCall(test.Context(t), newTestSession(t, log, conn),
+				daos.MethodAgentRevokeIssuerKey, reqBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp := new(auth.RevokeIssuerKeyResp)
+			if err := proto.Unmarshal(respBytes, resp); err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, int32(tc.expStatus), resp.Status, "unexpected status")
+			test.AssertEqual(t, tc.expRevoked, mod.revoked.IsRevoked(validID), "unexpected revocation state")
+		})
+	}
+}
+
+func TestAgent_SecurityModule_InvalidateCredential(t *testing.T) {
+	invalidate := func(t *testing.T, mod *SecurityModule, session *drpc.Session, req *auth.InvalidateCredReq) *auth.InvalidateCredResp {
+		t.Helper()
+		reqBytes, err := proto.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		respBytes, err := mod.HandleCall(test.Context(t), session, daos.MethodInvalidateCredential, reqBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp := new(auth.InvalidateCredResp)
+		if err := proto.Unmarshal(respBytes, resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	conn, cleanup := setupTestUnixConn(t)
+	defer cleanup()
+	session := newTestSession(t, log, conn)
+
+	cfg := defaultTestSecurityConfig(t, log, testInfoCacheParams{})
+	cfg.credentials.CacheExpiration = time.Minute
+	mod := NewSecurityModule(log, cfg)
+
+	respBytes, err := mod.HandleCall(test.Context(t), session, daos.MethodRequestCredentials, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectCredResp(t, respBytes, 0, true)
+	test.AssertEqual(t, 1, mod.credCache.cache.Len(), "credential not cached")
+
+	resp := invalidate(t, mod, session, &auth.InvalidateCredReq{Flavor: auth.Flavor_AUTH_SYS})
+	test.AssertEqual(t, int32(0), resp.Status, "unexpected status")
+	test.AssertTrue(t, resp.Invalidated, "cached credential not invalidated")
+	test.AssertEqual(t, 0, mod.credCache.cache.Len(), "credential still cached")
+
+	resp = invalidate(t, mod, session, &auth.InvalidateCredReq{Flavor: auth.Flavor_AUTH_SYS})
+	test.AssertFalse(t, resp.Invalidated, "nothing should be invalidated")
+
+	resp = invalidate(t, mod, session, &auth.InvalidateCredReq{Flavor: auth.Flavor(-1)})
+	test.AssertEqual(t, int32(daos.InvalidInput), resp.Status, "unexpected status for unknown flavor")
+}
+
+func TestAgent_credentialCache_purgeCredentials(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	var nilCache *credentialCache
+	test.AssertEqual(t, uint32(0), nilCache.purgeCredentials(), "nil cache should purge nothing")
+
+	cc := &credentialCache{log: log, cache: cache.NewShardedItemCache(log, credCacheShards)}
+	for _, key := range []string{"one", "two"} {
+		item, err := newCachedCredential(key, &auth.Credential{}, time.Minute, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cc.cache.Set(item); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	test.AssertEqual(t, uint32(2), cc.purgeCredentials(), "unexpected purge count")
+	test.AssertEqual(t, 0, len(cc.cache.Keys()), "expected empty cache")
+}
+
+func TestAgent_credentialCache_maxEntries(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	var misses int
+	cc := &credentialCache{
+		log:          log,
+		cache:        cache.NewShardedItemCache(log, credCacheShards),
+		credLifetime: time.Minute,
+		lru:          newCredentialLRU(2, 0, 0, nil),
+		cacheMissFn: func(_ context.Context, _ logging.Logger, req auth.CredentialRequest) (*auth.Credential, error) {
+			misses++
+			return &auth.Credential{Origin: req.GetKey()}, nil
+		},
+	}
+	request := func(uid uint32) {
+		t.Helper()
+		req := &auth.AuthSysCredentialRequest{
+			DomainInfo: security.InitDomainInfo(&syscall.Ucred{Uid: uid, Gid: uid}, ""),
+		}
+		if _, err := cc.getSignedCredential(test.Context(t), log, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	request(1)
+	request(2)
+	request(1)
+	request(3)
+	test.AssertEqual(t, 3, misses, "unexpected cache misses")
+	test.AssertEqual(t, 2, len(cc.cache.Keys()), "cache exceeded its maximum size")
+
+	// The least recently used credential was evicted.
+	request(1)
+	test.AssertEqual(t, 3, misses, "recently used credential was evicted")
+	request(2)
+	test.AssertEqual(t, 4, misses, "least recently used credential was not evicted")
+}
+
+func TestAgent_credentialCache_metrics(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	metrics := newCredentialCacheMetrics()
+	cc := &credentialCache{
+		log:          log,
+		cache:        cache.NewShardedItemCache(log, credCacheShards),
+		credLifetime: time.Minute,
+		lru:          newCredentialLRU(1, 0, 0, metrics),
+		metrics:      metrics,
+		cacheMissFn: func(_ context.Context, _ logging.Logger, req auth.CredentialRequest) (*auth.Credential, error) {
+			if strings.HasPrefix(req.GetKey(), "3:") {
+				return nil, errors.New("mock error")
+			}
+			return &auth.Credential{
+				Token:  &auth.Token{Flavor: auth.Flavor_AUTH_SYS},
+				Origin: req.GetKey(),
+			}, nil
+		},
+	}
+	request := func(uid uint32) {
+		req := &auth.AuthSysCredentialRequest{
+			DomainInfo: security.InitDomainInfo(&syscall.Ucred{Uid: uid, Gid: uid}, ""),
+		}
+		cc.getSignedCredential(test.Context(t), log, req)
+	}
+
+	request(1)
+	request(1)
+	request(1)
+	request(2)
+	request(3)
+
+	flavor := auth.Flavor_AUTH_SYS.String()
+	for event, exp := range map[cacheEvent]float64{
+		cacheHit:      2,
+		cacheMiss:     3,
+		cacheEviction: 1,
+		cacheRefresh:  0,
+		cacheError:    1,
+	} {
+		test.AssertEqual(t, exp, counterValue(t, metrics.events[event], flavor), "unexpected "+string(event))
+	}
+}
+
+func TestAgent_credentialCache_lifetime(t *testing.T) {
+	for name, tc := range map[string]struct {
+		jitter time.Duration
+	}{
+		"no jitter": {},
+		"jitter": {
+			jitter: 10 * time.Second,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cc := &credentialCache{credLifetime: time.Minute, jitter: tc.jitter}
+
+			seen := make(map[time.Duration]struct{})
+			for i := 0; i < 100; i++ {
+				lifetime := cc.lifetime()
+				test.AssertTrue(t, lifetime <= time.Minute, "lifetime exceeds cache_expiration")
+				test.AssertTrue(t, lifetime >= time.Minute-tc.jitter, "lifetime reduced by more than the jitter")
+				seen[lifetime] = struct{}{}
+			}
+			test.AssertEqual(t, tc.jitter > 0, len(seen) > 1, "unexpected variation in lifetimes")
+		})
+	}
+}
+
+func TestAgent_cachedCredential_size(t *testing.T) {
+	small, err := newCachedCredential("uid:1", &auth.Credential{
+		Token: &auth.Token{Flavor: auth.Flavor_AUTH_SYS, Data: make([]byte, 16)},
+	}, time.Minute, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	large, err := newCachedCredential("uid:1", &auth.Credential{
+		Token: &auth.Token{Flavor: auth.Flavor_AUTH_SYS, Data: make([]byte, 4096)},
+	}, time.Minute, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test.AssertTrue(t, large.size()-small.size() >= 4096-16, "size does not account for the token")
+}
+
+func TestAgent_credentialCache_refreshAhead(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	var mutex sync.Mutex
+	var misses int
+	cc := &credentialCache{
+		log:          log,
+		cache:        cache.NewShardedItemCache(log, credCacheShards),
+		credLifetime: time.Second,
+		refreshAhead: 500 * time.Millisecond,
+		cacheMissFn: func(_ context.Context, _ logging.Logger, req auth.CredentialRequest) (*auth.Credential, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			misses++
+			return &auth.Credential{Origin: fmt.Sprintf("cred%d", misses)}, nil
+		},
+	}
+	req := &auth.AuthSysCredentialRequest{
+		DomainInfo: security.InitDomainInfo(&syscall.Ucred{Uid: 1234, Gid: 5678}, ""),
+	}
+	getOrigin := func() string {
+		t.Helper()
+		cred, err := cc.getSignedCredential(test.Context(t), log, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cred.Origin
+	}
+
+	test.AssertEqual(t, "cred1", getOrigin(), "unexpected credential")
+	test.AssertEqual(t, "cred1", getOrigin(), "fresh credential not served from cache")
+
+	// Once the credential nears expiry it is still served, while a new one
+	// is requested in the background.
+	time.Sleep(600 * time.Millisecond)
+	test.AssertEqual(t, "cred1", getOrigin(), "expiring credential not served from cache")
+	origin := "cred1"
+	for deadline := time.Now().Add(time.Second); origin == "cred1" && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		origin = getOrigin()
+	}
+	test.AssertEqual(t, "cred2", origin, "credential not refreshed")
+	mutex.Lock()
+	test.AssertEqual(t, 2, misses, "unexpected number of requests")
+	mutex.Unlock()
+}
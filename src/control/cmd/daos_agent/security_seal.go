@@ -0,0 +1,204 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"os/exec"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+const (
+	// cacheKeyContext distinguishes the credential cache encryption key from
+	// other uses of the secret it is derived from.
+	cacheKeyContext  = "daos_agent credential cache\x00"
+	tpmUnsealCmd     = "tpm2_unseal"
+	tpmUnsealTimeout = 10 * time.Second
+)
+
+// credentialSealer encrypts cached credentials, which are bearer-equivalent,
+// so that they cannot be recovered from the agent's memory, such as from a
+// core dump, or from the credential spool without the key. The keySecret is
+// derived from the same secret, to key the credential cache keys.
+type credentialSealer struct {
+	aead      cipher.AEAD
+	keySecret []byte
+}
+
+// tpmUnseal returns the secret sealed by the TPM object with the handle.
+var tpmUnseal = func(ctx context.Context, handle string) ([]byte, error) {
+	secret, err := exec.CommandContext(ctx, tpmUnsealCmd, "-c", handle).Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s -c %s", tpmUnsealCmd, handle)
+	}
+	return secret, nil
+}
+
+// zeroize overwrites the buffer, so that the secret it held does not linger
+// in memory once released.
+func zeroize(buf []byte) {
+	clear(buf)
+}
+
+// loadCredentialSealer returns a sealer with a key from the configured source,
+// either derived from the agent's signing key or unsealed from a TPM.
+func loadCredentialSealer(cfg *security.CacheEncryptionConfig, transport *security.TransportConfig) (*credentialSealer, error) {
+	var secret []byte
+	switch cfg.KeySource {
+	case security.CacheKeyTPM:
+		ctx, cancel := context.WithTimeout(context.Background(), tpmUnsealTimeout)
+		defer cancel()
+
+		var err error
+		if secret, err = tpmUnseal(ctx, cfg.TPMHandle); err != nil {
+			return nil, errors.Wrap(err, "unsealing credential cache key")
+		}
+	default:
+		signingKey, err := transport.PrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		if secret, err = signingKeySecret(signingKey); err != nil {
+			return nil, err
+		}
+	}
+	defer zeroize(secret)
+
+	return newCredentialSealer(secret)
+}
+
+func signingKeySecret(signingKey crypto.PrivateKey) ([]byte, error) {
+	if signingKey == nil {
+		return nil, errors.New("a signing key is required to encrypt cached credentials")
+	}
+	// The software keys of the crypto packages all have Equal methods, unlike
+	// keys held on a token, by a key management service or in the TPM,
+	// which can't be exported.
+	if _, isSoftware := signingKey.(interface{ Equal(crypto.PrivateKey) bool }); !isSoftware {
+		return nil, errors.Errorf("the signing key can't be used to encrypt cached credentials; use key_source %q", security.CacheKeyTPM)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(signingKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "deriving credential cache key")
+	}
+	return der, nil
+}
+
+// newCredentialSealer returns a sealer with a key derived from the secret.
+func newCredentialSealer(secret []byte) (*credentialSealer, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("credential cache key secret is empty")
+	}
+
+	material := append([]byte(cacheKeyContext), secret...)
+	defer zeroize(material)
+	key := sha256.Sum256(material)
+	defer zeroize(key[:])
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	keySecret := sha256.Sum256(append([]byte(cacheKeySecretContext), secret...))
+	return &credentialSealer{aead: aead, keySecret: keySecret[:]}, nil
+}
+
+// seal encrypts the plaintext, authenticating the additional data with it.
+func (s *credentialSealer) seal(plaintext, data []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize(), s.aead.NonceSize()+len(plaintext)+s.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return s.aead.Seal(nonce, nonce, plaintext, data), nil
+}
+
+// open decrypts the sealed data, which must have been sealed with the same
+// additional data.
+func (s *credentialSealer) open(sealed, data []byte) ([]byte, error) {
+	if len(sealed) < s.aead.NonceSize() {
+		return nil, errors.New("truncated data")
+	}
+	nonce, sealed := sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():]
+	plaintext, err := s.aead.Open(nil, nonce, sealed, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypting")
+	}
+	return plaintext, nil
+}
+
+// sealCredential encrypts the credential in place if the sealer is set.
+func (cred *cachedCredential) sealCredential(sealer *credentialSealer) error {
+	if sealer == nil {
+		return nil
+	}
+
+	plaintext, err := proto.Marshal(cred.cred)
+	if err != nil {
+		return err
+	}
+	defer zeroize(plaintext)
+
+	if cred.sealed, err = sealer.seal(plaintext, []byte(cred.key)); err != nil {
+		return err
+	}
+	cred.sealer = sealer
+	cred.cred = nil
+
+	return nil
+}
+
+// marshal returns the serialized credential, decrypting it if it is sealed.
+// The caller should zeroize the result once it is no longer needed.
+func (cred *cachedCredential) marshal() ([]byte, error) {
+	if cred.sealed == nil {
+		return proto.Marshal(cred.cred)
+	}
+	return cred.sealer.open(cred.sealed, []byte(cred.key))
+}
+
+// credential returns the cached credential, decrypting it if it is sealed.
+func (cred *cachedCredential) credential() (*auth.Credential, error) {
+	if cred.sealed == nil {
+		return cred.cred, nil
+	}
+
+	plaintext, err := cred.marshal()
+	if err != nil {
+		return nil, errors.Wrap(err, "unsealing cached credential")
+	}
+	defer zeroize(plaintext)
+
+	out := new(auth.Credential)
+	if err := proto.Unmarshal(plaintext, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Evict scrubs the credential once it has been removed from the cache.
+func (cred *cachedCredential) Evict() {
+	zeroize(cred.sealed)
+	cred.sealed = nil
+	cred.cred = nil
+}
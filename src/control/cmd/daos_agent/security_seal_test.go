@@ -0,0 +1,190 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/cache"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAgent_loadCredentialSealer(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg       security.CacheEncryptionConfig
+		tpmSecret []byte
+		tpmErr    error
+		expHandle string
+		expErr    error
+	}{
+		"signing key required": {
+			expErr: errors.New("a signing key is required"),
+		},
+		"tpm": {
+			cfg: security.CacheEncryptionConfig{
+				KeySource: security.CacheKeyTPM,
+				TPMHandle: "0x81000001",
+			},
+			tpmSecret: []byte("sealed secret"),
+			expHandle: "0x81000001",
+		},
+		"tpm unseal failed": {
+			cfg: security.CacheEncryptionConfig{
+				KeySource: security.CacheKeyTPM,
+				TPMHandle: "0x81000001",
+			},
+			tpmErr:    errors.New("no such handle"),
+			expHandle: "0x81000001",
+			expErr:    errors.New("unsealing credential cache key: no such handle"),
+		},
+		"tpm secret empty": {
+			cfg: security.CacheEncryptionConfig{
+				KeySource: security.CacheKeyTPM,
+				TPMHandle: "0x81000001",
+			},
+			expHandle: "0x81000001",
+			expErr:    errors.New("secret is empty"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var gotHandle string
+			var secret []byte
+			orig := tpmUnseal
+			tpmUnseal = func(_ context.Context, handle string) ([]byte, error) {
+				gotHandle = handle
+				secret = append([]byte(nil), tc.tpmSecret...)
+				return secret, tc.tpmErr
+			}
+			defer func() { tpmUnseal = orig }()
+
+			sealer, err := loadCredentialSealer(&tc.cfg, &security.TransportConfig{AllowInsecure: true})
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expHandle, gotHandle, "unexpected TPM handle")
+			test.AssertTrue(t, bytes.Count(secret, []byte{0}) == len(secret), "TPM secret not zeroized")
+			if tc.expErr != nil {
+				return
+			}
+
+			// The key depends only on the secret.
+			same, err := newCredentialSealer(tc.tpmSecret)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sealed, err := sealer.seal([]byte("plaintext"), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			plaintext, err := same.open(sealed, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, "plaintext", string(plaintext), "unexpected plaintext")
+		})
+	}
+}
+
+// tokenKey stands in for a key held on a PKCS#11 token, which can sign but
+// can't be exported.
+type tokenKey struct {
+	crypto.Signer
+}
+
+func TestAgent_signingKeySecret(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		key    crypto.PrivateKey
+		expErr error
+	}{
+		"no key": {
+			expErr: errors.New("a signing key is required"),
+		},
+		"software key": {
+			key: key,
+		},
+		"token key": {
+			key:    tokenKey{key},
+			expErr: errors.New(`use key_source "tpm"`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			secret, err := signingKeySecret(tc.key)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertTrue(t, len(secret) > 0, "expected a secret")
+		})
+	}
+}
+
+func TestAgent_cachedCredential_sealed(t *testing.T) {
+	sealer := testSealer(t)
+
+	item, err := newCachedCredential("uid:1", testCred("secret-token"), time.Minute, sealer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, item.cred == nil, "credential held in the clear")
+	test.AssertFalse(t, bytes.Contains(item.sealed, []byte("secret-token")), "credential not encrypted")
+	test.AssertFalse(t, item.IsExpired(), "sealed credential should not be expired")
+
+	cred, err := item.credential()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(testCred("secret-token"), cred, protocmp.Transform()); diff != "" {
+		t.Fatalf("unexpected credential (-want +got):\n%s", diff)
+	}
+
+	// A credential sealed for one key cannot be passed off as another's.
+	other := &cachedCredential{key: "uid:2", sealed: item.sealed, sealer: sealer}
+	_, err = other.credential()
+	test.CmpErr(t, errors.New("unsealing cached credential"), err)
+
+	// A credential sealed with another key cannot be decrypted.
+	item.sealer = testSealer(t)
+	_, err = item.credential()
+	test.CmpErr(t, errors.New("unsealing cached credential"), err)
+}
+
+func TestAgent_cachedCredential_Evict(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	ic := cache.NewItemCache(log)
+	item, err := newCachedCredential("uid:1", testCred("uid:1"), time.Minute, testSealer(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ic.Set(item); err != nil {
+		t.Fatal(err)
+	}
+	sealed := item.sealed
+
+	ic.Delete(item.Key())
+
+	test.AssertTrue(t, bytes.Count(sealed, []byte{0}) == len(sealed), "evicted credential not zeroized")
+	test.AssertTrue(t, item.sealed == nil, "evicted credential still held")
+	test.AssertTrue(t, item.IsExpired(), "evicted credential should be expired")
+}
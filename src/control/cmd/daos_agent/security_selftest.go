@@ -0,0 +1,249 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"crypto"
+	"crypto/x509"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// enabledFlavors returns the flavors the agent has been configured to issue.
+// AUTH_SYS needs no configuration and is always enabled, as are any flavors
+// loaded from plugins.
+func enabledFlavors(cfg *security.CredentialConfig) []auth.Flavor {
+	flavors := []auth.Flavor{auth.Flavor_AUTH_SYS}
+	if cfg == nil {
+		return flavors
+	}
+
+	if cfg.AMConfig.BaseURL != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_ACCMAN)
+	}
+	if cfg.AzureConfig.TenantID != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_AZURE)
+	}
+	if len(cfg.GCPConfig.Audiences) > 0 {
+		flavors = append(flavors, auth.Flavor_AUTH_GCP)
+	}
+	if cfg.VaultConfig.Address != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_VAULT)
+	}
+	if cfg.OAuth2Config.TokenURL != "" || cfg.OAuth2Config.IntrospectionURL != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_OAUTH2)
+	}
+	if cfg.SSHConfig.AuthorizedKeysFile != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_SSH)
+	}
+	if cfg.FIDO2Config.CredentialsFile != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_FIDO2)
+	}
+	if len(cfg.SciTokensConfig.Issuers) > 0 {
+		flavors = append(flavors, auth.Flavor_AUTH_SCITOKENS)
+	}
+	if cfg.MacaroonConfig.RootKeyFile != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_MACAROON)
+	}
+	if cfg.BiscuitConfig.RootPublicKey != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_BISCUIT)
+	}
+	if cfg.KeystoneConfig.AuthURL != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_KEYSTONE)
+	}
+	if cfg.PKCS11Config.CACertDir != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_PKCS11)
+	}
+	if cfg.AnonConfig.User != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_ANON)
+	}
+	if cfg.ExecConfig.Command != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_EXEC)
+	}
+	if cfg.ProviderConfig.Address != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_PROVIDER)
+	}
+	if cfg.MachineConfig.Enabled {
+		flavors = append(flavors, auth.Flavor_AUTH_MACHINE)
+	}
+	if cfg.SlurmConfig.JWTKeyFile != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_SLURM)
+	}
+	if cfg.WLMConfig.PublicKey != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_WLM)
+	}
+	if cfg.ADConfig.Enabled {
+		flavors = append(flavors, auth.Flavor_AUTH_AD)
+	}
+	if cfg.TOTPConfig.SecretsFile != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_TOTP)
+	}
+	if cfg.DelegationConfig.Enabled {
+		flavors = append(flavors, auth.Flavor_AUTH_DELEGATION)
+	}
+	if len(cfg.ProxyConfig.AllowedUIDs) > 0 {
+		flavors = append(flavors, auth.Flavor_AUTH_PROXY)
+	}
+	if cfg.WebhookConfig.URL != "" {
+		flavors = append(flavors, auth.Flavor_AUTH_WEBHOOK)
+	}
+	flavors = append(flavors, auth.PluginFlavors()...)
+
+	return flavors
+}
+
+// checkClock verifies that the system clock is plausible, as credentials are
+// stamped with the time of issue and certificates are only valid for a period.
+func checkClock(now time.Time, cert *x509.Certificate) error {
+	if buildTime, err := time.Parse(time.RFC3339, build.BuildTime); err == nil && now.Before(buildTime) {
+		return errors.Errorf("system clock (%s) is earlier than the agent build time (%s)", now, buildTime)
+	}
+
+	if cert == nil {
+		return nil
+	}
+	if now.Before(cert.NotBefore) {
+		return errors.Errorf("system clock (%s) is earlier than the start of certificate validity (%s)", now, cert.NotBefore)
+	}
+	if now.After(cert.NotAfter) {
+		return errors.Errorf("certificate expired at %s", cert.NotAfter)
+	}
+
+	return nil
+}
+
+// checkKeyPair verifies that the signing key is the private half of the key in
+// the agent certificate, so that the server will be able to verify credentials.
+func checkKeyPair(key crypto.PrivateKey, cert *x509.Certificate) error {
+	if key == nil || cert == nil {
+		return nil
+	}
+
+	keyID, err := security.PrivateKeyID(key)
+	if err != nil {
+		return errors.Wrap(err, "identifying signing key")
+	}
+	certKeyID, err := security.PublicKeyID(cert.PublicKey)
+	if err != nil {
+		return errors.Wrap(err, "identifying certificate key")
+	}
+	if keyID != certKeyID {
+		return errors.Errorf("signing key %s does not match certificate key %s", keyID, certKeyID)
+	}
+
+	return nil
+}
+
+// checkSigningKey loads the certificate and signing key of the transport config,
+// and checks them as the agent's own are checked.
+func checkSigningKey(now time.Time, tc *security.TransportConfig) error {
+	cert, err := tc.Certificate()
+	if err != nil {
+		return errors.Wrap(err, "loading certificate")
+	}
+	key, err := tc.PrivateKey()
+	if err != nil {
+		return errors.Wrap(err, "loading signing key")
+	}
+	if err := checkClock(now, cert); err != nil {
+		return err
+	}
+	return checkKeyPair(key, cert)
+}
+
+// selfTest checks that the agent is able to issue credentials which the server
+// will accept. Failures specific to a flavor are returned in the map, and
+// failures which affect every flavor are returned as an error.
+func (m *SecurityModule) selfTest(now time.Time) (map[auth.Flavor]error, error) {
+	cert, err := m.transport().Certificate()
+	if err != nil {
+		return nil, errors.Wrap(err, "loading certificate")
+	}
+	key, err := m.transport().PrivateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "loading signing key")
+	}
+
+	if err := checkClock(now, cert); err != nil {
+		return nil, err
+	}
+	if err := checkKeyPair(key, cert); err != nil {
+		return nil, err
+	}
+	for _, signingKey := range m.signingKeys {
+		if err := checkSigningKey(now, signingKey.transport()); err != nil {
+			return nil, errors.Wrapf(err, "signing key %s", signingKey)
+		}
+	}
+	// The flavors are tested with the key which signs credentials by
+	// default.
+	if m.credentialKey != nil {
+		tc := m.credentialKey.transport()
+		if err := checkSigningKey(now, tc); err != nil {
+			return nil, errors.Wrap(err, "credential signing key")
+		}
+		if cert, err = tc.Certificate(); err != nil {
+			return nil, errors.Wrap(err, "loading credential signing certificate")
+		}
+		if key, err = tc.PrivateKey(); err != nil {
+			return nil, errors.Wrap(err, "loading credential signing key")
+		}
+	}
+
+	var pub crypto.PublicKey
+	if cert != nil {
+		pub = cert.PublicKey
+	}
+
+	flavorErrs := make(map[auth.Flavor]error)
+	for _, flavor := range enabledFlavors(m.config.credentials) {
+		if err := auth.SelfTestFlavor(flavor, key, pub); err != nil {
+			flavorErrs[flavor] = err
+		}
+	}
+
+	return flavorErrs, nil
+}
+
+// RunSelfTest runs the security self-test and applies the configured policy
+// to any failures. An error is returned if the agent should not start.
+func (m *SecurityModule) RunSelfTest() error {
+	policy := m.config.credentials.SelfTestPolicy
+	if policy == security.SelfTestSkip {
+		m.log.Notice("security self-test skipped")
+		return nil
+	}
+
+	flavorErrs, err := m.selfTest(time.Now())
+	if err == nil && len(flavorErrs) == 0 {
+		m.log.Debug("security self-test passed")
+		return nil
+	}
+
+	if err != nil {
+		m.log.Errorf("security self-test failed: %s", err)
+	}
+	for flavor, flavorErr := range flavorErrs {
+		m.log.Errorf("security self-test failed for %s: %s", flavor, flavorErr)
+	}
+
+	if policy != security.SelfTestDegrade {
+		if err == nil {
+			err = errors.Errorf("%d flavor(s) failed", len(flavorErrs))
+		}
+		return errors.Wrap(err, "security self-test failed")
+	}
+
+	m.disabledFlavors = flavorErrs
+	m.log.Noticef("security module running in degraded mode after failed self-test")
+	return nil
+}
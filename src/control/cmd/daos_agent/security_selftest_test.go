@@ -0,0 +1,120 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func TestAgent_enabledFlavors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg        *security.CredentialConfig
+		expFlavors []auth.Flavor
+	}{
+		"nil config": {
+			expFlavors: []auth.Flavor{auth.Flavor_AUTH_SYS},
+		},
+		"default config": {
+			cfg:        &security.CredentialConfig{},
+			expFlavors: []auth.Flavor{auth.Flavor_AUTH_SYS},
+		},
+		"external flavors": {
+			cfg: &security.CredentialConfig{
+				GCPConfig:   security.GCPConfig{Audiences: []string{"daos"}},
+				VaultConfig: security.VaultConfig{Address: "https://vault.example.com"},
+			},
+			expFlavors: []auth.Flavor{auth.Flavor_AUTH_SYS, auth.Flavor_AUTH_GCP, auth.Flavor_AUTH_VAULT},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpAny(t, "flavors", tc.expFlavors, enabledFlavors(tc.cfg))
+		})
+	}
+}
+
+func TestAgent_checkClock(t *testing.T) {
+	now := time.Now()
+	cert := &x509.Certificate{
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(time.Hour),
+	}
+
+	for name, tc := range map[string]struct {
+		now    time.Time
+		cert   *x509.Certificate
+		expErr error
+	}{
+		"no certificate": {
+			now: now,
+		},
+		"within validity": {
+			now:  now,
+			cert: cert,
+		},
+		"clock behind": {
+			now:    now.Add(-2 * time.Hour),
+			cert:   cert,
+			expErr: errors.New("earlier than the start of certificate validity"),
+		},
+		"certificate expired": {
+			now:    now.Add(2 * time.Hour),
+			cert:   cert,
+			expErr: errors.New("certificate expired"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, checkClock(tc.now, tc.cert))
+		})
+	}
+}
+
+func TestAgent_checkKeyPair(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test.CmpErr(t, nil, checkKeyPair(nil, nil))
+	test.CmpErr(t, nil, checkKeyPair(key, &x509.Certificate{PublicKey: &key.PublicKey}))
+	test.CmpErr(t, errors.New("does not match"), checkKeyPair(key, &x509.Certificate{PublicKey: &otherKey.PublicKey}))
+}
+
+func TestAgent_SecurityModule_RunSelfTest(t *testing.T) {
+	for name, policy := range map[string]security.SelfTestPolicy{
+		"default":  "",
+		"enforce":  security.SelfTestEnforce,
+		"degraded": security.SelfTestDegrade,
+		"skip":     security.SelfTestSkip,
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			cfg := defaultTestSecurityConfig(t, log, testInfoCacheParams{})
+			cfg.credentials.SelfTestPolicy = policy
+			mod := NewSecurityModule(log, cfg)
+
+			test.CmpErr(t, nil, mod.RunSelfTest())
+			test.AssertEqual(t, 0, len(mod.disabledFlavors), "no flavors should be disabled")
+		})
+	}
+}
@@ -0,0 +1,262 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// signingKey is an additional credential signing key of the agent, used in
+// place of the transport key for credentials requested for its system or
+// tenant. A tenant key is only used for the clients it is bound to by binding.
+// reloaded replaces config once the certificate and key have been reloaded.
+type signingKey struct {
+	sys      string
+	tenant   string
+	desc     string
+	binding  *security.SigningKeyConfig
+	config   *security.TransportConfig
+	reloaded atomic.Pointer[security.TransportConfig]
+}
+
+func newSigningKeys(cfgs []*security.SigningKeyConfig) []*signingKey {
+	keys := make([]*signingKey, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		keys = append(keys, &signingKey{
+			sys:     cfg.System,
+			tenant:  cfg.Tenant,
+			desc:    cfg.String(),
+			binding: cfg,
+			config:  cfg.TransportConfig(),
+		})
+	}
+	return keys
+}
+
+func (k *signingKey) String() string {
+	return k.desc
+}
+
+// allows returns true if the peer may request credentials signed with the key.
+// The tenant label is chosen by the client, so a tenant key is never used for
+// a peer which isn't known.
+func (k *signingKey) allows(peer *security.DomainInfo) bool {
+	if k.tenant == "" {
+		return true
+	}
+	return peer != nil && k.binding.Allows(peer.Uid(), peer.Gid())
+}
+
+// transport returns the transport config holding the key's current
+// certificate and key.
+func (k *signingKey) transport() *security.TransportConfig {
+	if tc := k.reloaded.Load(); tc != nil {
+		return tc
+	}
+	return k.config
+}
+
+// signingCandidates returns the transport configs holding the keys with which
+// credentials requested for the system and tenant may be signed, in order of
+// preference. Keys for both the tenant and the system are preferred to keys for
+// the tenant alone, and the agent's credential signing key, or its transport
+// key if it has none, is only a candidate for requests without a tenant, after
+// any keys for the system. Keys which are
+// equally specific are preferred in the order they are configured. Tenant keys
+// are only candidates for the peers they are bound to. Credentials requested
+// for a tenant without a key the peer may use are refused, rather than signed
+// with a key which is trusted for other tenants.
+func (m *SecurityModule) signingCandidates(sys, tenant string, peer *security.DomainInfo) ([]*security.TransportConfig, error) {
+	var candidates, tenantKeys []*security.TransportConfig
+	var denied bool
+	for _, key := range m.signingKeys {
+		var keys *[]*security.TransportConfig
+		switch {
+		case key.tenant == tenant && key.sys == sys:
+			keys = &candidates
+		case tenant != "" && key.tenant == tenant && key.sys == "":
+			keys = &tenantKeys
+		default:
+			continue
+		}
+		if !key.allows(peer) {
+			denied = true
+			continue
+		}
+		*keys = append(*keys, key.transport())
+	}
+	candidates = append(candidates, tenantKeys...)
+
+	if tenant == "" {
+		return append(candidates, m.credentialTransport()), nil
+	}
+	if len(candidates) == 0 {
+		if denied {
+			return nil, errors.Errorf("client is not permitted to use the signing keys for tenant %q", tenant)
+		}
+		return nil, errors.Errorf("no signing key for tenant %q", tenant)
+	}
+	return candidates, nil
+}
+
+// sessionPeer returns the domain info of the client on the other end of the
+// session, which selects the tenant keys it may use, if the request is for a
+// tenant.
+func (m *SecurityModule) sessionPeer(session *drpc.Session, tenant string) *security.DomainInfo {
+	if tenant == "" || session == nil {
+		return nil
+	}
+	uc, ok := session.Conn.(*net.UnixConn)
+	if !ok {
+		return nil
+	}
+	info, err := security.DomainInfoFromUnixConn(m.log, uc)
+	if err != nil {
+		m.log.Errorf("unable to get credentials for client socket: %s", err)
+		return nil
+	}
+	return info
+}
+
+// signingTransport returns the transport config holding the key with which to
+// sign credentials requested for the system and tenant: the preferred
+// candidate whose signature algorithm the system accepts, so that an agent
+// holding keys of several types may be used with servers which accept only
+// some of them, e.g. while migrating from RSA to Ed25519 keys. The algorithms
+// accepted by the system are only fetched if there is a choice of keys.
+func (m *SecurityModule) signingTransport(ctx context.Context, sys, tenant string, peer *security.DomainInfo) (*security.TransportConfig, error) {
+	candidates, err := m.signingCandidates(sys, tenant, peer)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+
+	resp, err := m.infoCache.GetAttachInfo(ctx, sys)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get attach info")
+	}
+	tc, err := selectSigningTransport(candidates, acceptedSignatureAlgorithms(resp))
+	if err != nil {
+		return nil, errors.Wrapf(err, "system %q", sys)
+	}
+	return tc, nil
+}
+
+// acceptedSignatureAlgorithms returns the signature algorithms which the system
+// advertises that it accepts, ignoring any unknown to the agent. A system which
+// doesn't advertise them predates any algorithm but the legacy ones.
+func acceptedSignatureAlgorithms(resp *control.GetAttachInfoResp) []security.SignatureAlgorithm {
+	if len(resp.SignatureAlgorithms) == 0 {
+		return security.LegacySignatureAlgorithms
+	}
+
+	var accepted []security.SignatureAlgorithm
+	for _, name := range resp.SignatureAlgorithms {
+		if algs, err := security.ParseSignatureAlgorithms([]string{name}); err == nil {
+			accepted = append(accepted, algs...)
+		}
+	}
+	return accepted
+}
+
+// selectSigningTransport returns the first of the candidates whose key uses one
+// of the accepted signature algorithms.
+func selectSigningTransport(candidates []*security.TransportConfig, accepted []security.SignatureAlgorithm) (*security.TransportConfig, error) {
+	algs := make([]string, 0, len(candidates))
+	for _, tc := range candidates {
+		alg, err := tc.SignatureAlgorithm()
+		if err != nil {
+			return nil, errors.Wrap(err, "determining signature algorithm of signing key")
+		}
+		// Nothing is signed with an insecure transport.
+		if alg == "" || slices.Contains(accepted, alg) {
+			return tc, nil
+		}
+		algs = append(algs, string(alg))
+	}
+
+	acceptedNames := make([]string, 0, len(accepted))
+	for _, alg := range accepted {
+		acceptedNames = append(acceptedNames, string(alg))
+	}
+	return nil, errors.Errorf("no signing key uses an accepted signature algorithm (keys: %s; accepted: %s)",
+		strings.Join(algs, ", "), strings.Join(acceptedNames, ", "))
+}
+
+// checkSigningKeyAlgorithms checks that no two signing keys for the same
+// system and tenant use the same signature algorithm, as only the first of
+// them would ever be used.
+func (m *SecurityModule) checkSigningKeyAlgorithms() error {
+	seen := make(map[string]struct{})
+	for _, key := range m.signingKeys {
+		alg, err := key.transport().SignatureAlgorithm()
+		if err != nil {
+			return errors.Wrapf(err, "signing key %s", key)
+		}
+		selector := key.String() + "/" + string(alg)
+		if _, found := seen[selector]; found {
+			return errors.Errorf("more than one signing key %s uses signature algorithm %s", key, alg)
+		}
+		seen[selector] = struct{}{}
+	}
+	return nil
+}
+
+// signingTransports returns the transport configs holding all of the agent's
+// keys, starting with its transport key and any credential signing key.
+func (m *SecurityModule) signingTransports() []*security.TransportConfig {
+	tcs := []*security.TransportConfig{m.transport()}
+	if m.credentialKey != nil {
+		tcs = append(tcs, m.credentialKey.transport())
+	}
+	for _, key := range m.signingKeys {
+		tcs = append(tcs, key.transport())
+	}
+	return tcs
+}
+
+// tenantCredentialRequest scopes a credential request to a tenant. Its key is
+// namespaced by the tenant label, so that a credential cached for, and signed
+// with the key of, one tenant can never satisfy a request for another.
+type tenantCredentialRequest struct {
+	auth.CredentialRequest
+	tenant string
+}
+
+// newTenantCredentialRequest scopes the request to the tenant, if any.
+func newTenantCredentialRequest(tenant string, req auth.CredentialRequest) auth.CredentialRequest {
+	if tenant == "" {
+		return req
+	}
+	return &tenantCredentialRequest{
+		CredentialRequest: req,
+		tenant:            tenant,
+	}
+}
+
+// Unwrap returns the request scoped to the tenant.
+func (r *tenantCredentialRequest) Unwrap() auth.CredentialRequest {
+	return r.CredentialRequest
+}
+
+// GetKey returns the key of the request, namespaced by the tenant label.
+func (r *tenantCredentialRequest) GetKey() string {
+	return "tenant:" + r.tenant + "/" + r.CredentialRequest.GetKey()
+}
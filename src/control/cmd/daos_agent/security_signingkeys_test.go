@@ -0,0 +1,393 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func TestAgent_SecurityModule_signingCandidates(t *testing.T) {
+	keyCfg := func(sys, tenant string) *security.SigningKeyConfig {
+		cfg := &security.SigningKeyConfig{System: sys, Tenant: tenant}
+		if tenant != "" {
+			cfg.AllowedUIDs = []uint32{1000}
+		}
+		return cfg
+	}
+	peer := func(uid, gid uint32) *security.DomainInfo {
+		return security.InitDomainInfo(&syscall.Ucred{Uid: uid, Gid: gid}, "")
+	}
+
+	for name, tc := range map[string]struct {
+		keys          []*security.SigningKeyConfig
+		credentialKey bool
+		sys           string
+		tenant        string
+		peer          *security.DomainInfo
+		expKeys       []int // indices of the expected keys, or -1 for the default key
+		expErr        error
+	}{
+		"no keys": {
+			sys:     "daos_server",
+			expKeys: []int{-1},
+		},
+		"credential signing key": {
+			credentialKey: true,
+			sys:           "daos_server",
+			expKeys:       []int{-1},
+		},
+		"system key before credential signing key": {
+			keys:          []*security.SigningKeyConfig{keyCfg("daos_server", "")},
+			credentialKey: true,
+			sys:           "daos_server",
+			expKeys:       []int{0, -1},
+		},
+		"credential signing key not used for tenant": {
+			credentialKey: true,
+			sys:           "daos_server",
+			tenant:        "tenant-a",
+			expErr:        errors.New(`no signing key for tenant "tenant-a"`),
+		},
+		"no tenant": {
+			keys:    []*security.SigningKeyConfig{keyCfg("", "tenant-a")},
+			sys:     "daos_server",
+			expKeys: []int{-1},
+		},
+		"system key": {
+			keys:    []*security.SigningKeyConfig{keyCfg("", "tenant-a"), keyCfg("daos_server", "")},
+			sys:     "daos_server",
+			expKeys: []int{1, -1},
+		},
+		"tenant key": {
+			keys:    []*security.SigningKeyConfig{keyCfg("", "tenant-a"), keyCfg("", "tenant-b")},
+			sys:     "daos_server",
+			tenant:  "tenant-b",
+			peer:    peer(1000, 1),
+			expKeys: []int{1},
+		},
+		"tenant key on system preferred": {
+			keys: []*security.SigningKeyConfig{
+				keyCfg("", "tenant-a"), keyCfg("daos_server", "tenant-a"), keyCfg("daos_server", ""),
+			},
+			sys:     "daos_server",
+			tenant:  "tenant-a",
+			peer:    peer(1000, 1),
+			expKeys: []int{1, 0},
+		},
+		"several keys for a tenant": {
+			keys: []*security.SigningKeyConfig{
+				keyCfg("", "tenant-a"), keyCfg("", "tenant-b"), keyCfg("", "tenant-a"),
+			},
+			sys:     "daos_server",
+			tenant:  "tenant-a",
+			peer:    peer(1000, 1),
+			expKeys: []int{0, 2},
+		},
+		"tenant key bound to group": {
+			keys: []*security.SigningKeyConfig{
+				{Tenant: "tenant-a", AllowedGIDs: []uint32{2000}},
+			},
+			sys:     "daos_server",
+			tenant:  "tenant-a",
+			peer:    peer(1, 2000),
+			expKeys: []int{0},
+		},
+		"only tenant keys bound to peer": {
+			keys: []*security.SigningKeyConfig{
+				{Tenant: "tenant-a", AllowedUIDs: []uint32{2000}}, keyCfg("", "tenant-a"),
+			},
+			sys:     "daos_server",
+			tenant:  "tenant-a",
+			peer:    peer(1000, 1),
+			expKeys: []int{1},
+		},
+		"peer not bound to tenant key": {
+			keys:   []*security.SigningKeyConfig{keyCfg("", "tenant-a")},
+			sys:    "daos_server",
+			tenant: "tenant-a",
+			peer:   peer(2000, 2000),
+			expErr: errors.New(`client is not permitted to use the signing keys for tenant "tenant-a"`),
+		},
+		"unknown peer": {
+			keys:   []*security.SigningKeyConfig{keyCfg("", "tenant-a")},
+			sys:    "daos_server",
+			tenant: "tenant-a",
+			expErr: errors.New(`client is not permitted to use the signing keys for tenant "tenant-a"`),
+		},
+		"unknown tenant": {
+			keys:   []*security.SigningKeyConfig{keyCfg("", "tenant-a"), keyCfg("daos_server", "")},
+			sys:    "daos_server",
+			tenant: "tenant-b",
+			expErr: errors.New(`no signing key for tenant "tenant-b"`),
+		},
+		"tenant without keys": {
+			sys:    "daos_server",
+			tenant: "tenant-a",
+			expErr: errors.New(`no signing key for tenant "tenant-a"`),
+		},
+		"tenant key for another system": {
+			keys:   []*security.SigningKeyConfig{keyCfg("other", "tenant-a")},
+			sys:    "daos_server",
+			tenant: "tenant-a",
+			expErr: errors.New(`no signing key for tenant "tenant-a"`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mod := &SecurityModule{
+				config: &securityConfig{
+					transport: &security.TransportConfig{AllowInsecure: true},
+				},
+				signingKeys: newSigningKeys(tc.keys),
+			}
+			if tc.credentialKey {
+				mod.credentialKey = newSigningKeys([]*security.SigningKeyConfig{{}})[0]
+			}
+
+			got, err := mod.signingCandidates(tc.sys, tc.tenant, tc.peer)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, len(tc.expKeys), len(got), "unexpected number of candidates")
+			for i, idx := range tc.expKeys {
+				exp := mod.config.transport
+				if mod.credentialKey != nil {
+					exp = mod.credentialKey.config
+				}
+				if idx >= 0 {
+					exp = mod.signingKeys[idx].config
+				}
+				test.AssertTrue(t, exp == got[i], fmt.Sprintf("unexpected candidate %d", i))
+			}
+		})
+	}
+}
+
+// testSigningTransport writes a self-signed certificate for the key, and
+// returns a transport config holding them.
+func testSigningTransport(t *testing.T, name string, key crypto.Signer) *security.TransportConfig {
+	t.Helper()
+
+	dir := t.TempDir()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &security.SigningKeyConfig{
+		Tenant: "tenant-a",
+		CertificateConfig: security.CertificateConfig{
+			CARootPath:      filepath.Join(dir, "daosCA.crt"),
+			CertificatePath: filepath.Join(dir, name+".crt"),
+			PrivateKeyPath:  filepath.Join(dir, name+".key"),
+		},
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	writeFileAtomic(t, cfg.CARootPath, certPEM, 0644)
+	writeFileAtomic(t, cfg.CertificatePath, certPEM, 0644)
+	writeFileAtomic(t, cfg.PrivateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+		security.MaxUserOnlyKeyPerm)
+	return cfg.TransportConfig()
+}
+
+func TestAgent_selectSigningTransport(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edTransport := testSigningTransport(t, "ed25519", edKey)
+	ecTransport := testSigningTransport(t, "ecdsa", ecKey)
+	insecure := &security.TransportConfig{AllowInsecure: true}
+
+	for name, tc := range map[string]struct {
+		candidates []*security.TransportConfig
+		resp       *control.GetAttachInfoResp
+		exp        *security.TransportConfig
+		expErr     error
+	}{
+		"preferred key accepted": {
+			candidates: []*security.TransportConfig{edTransport, ecTransport},
+			resp:       &control.GetAttachInfoResp{SignatureAlgorithms: []string{"rsa-pss", "ecdsa-p256", "ed25519"}},
+			exp:        edTransport,
+		},
+		"fallback key accepted": {
+			candidates: []*security.TransportConfig{edTransport, ecTransport},
+			resp:       &control.GetAttachInfoResp{SignatureAlgorithms: []string{"ecdsa-p256"}},
+			exp:        ecTransport,
+		},
+		"unknown algorithms ignored": {
+			candidates: []*security.TransportConfig{edTransport, ecTransport},
+			resp:       &control.GetAttachInfoResp{SignatureAlgorithms: []string{"ml-dsa-65", "ecdsa-p256"}},
+			exp:        ecTransport,
+		},
+		"server without negotiation": {
+			candidates: []*security.TransportConfig{edTransport, ecTransport},
+			resp:       &control.GetAttachInfoResp{},
+			expErr:     errors.New("no signing key uses an accepted signature algorithm (keys: ed25519, ecdsa-p256; accepted: rsa-pss)"),
+		},
+		"insecure": {
+			candidates: []*security.TransportConfig{insecure},
+			resp:       &control.GetAttachInfoResp{},
+			exp:        insecure,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := selectSigningTransport(tc.candidates, acceptedSignatureAlgorithms(tc.resp))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertTrue(t, tc.exp == got, "unexpected signing key selected")
+		})
+	}
+}
+
+func TestAgent_SecurityModule_checkSigningKeyAlgorithms(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenantKey := func(tc *security.TransportConfig) *signingKey {
+		return &signingKey{tenant: "tenant-a", desc: "for tenant tenant-a", config: tc}
+	}
+
+	mod := &SecurityModule{
+		signingKeys: []*signingKey{
+			tenantKey(testSigningTransport(t, "ed25519", edKey)),
+			tenantKey(testSigningTransport(t, "ecdsa", ecKey)),
+		},
+	}
+	test.CmpErr(t, nil, mod.checkSigningKeyAlgorithms())
+
+	mod.signingKeys = append(mod.signingKeys, tenantKey(testSigningTransport(t, "ed25519-2", edKey)))
+	test.CmpErr(t, errors.New("more than one signing key for tenant tenant-a uses signature algorithm ed25519"),
+		mod.checkSigningKeyAlgorithms())
+}
+
+func TestAgent_tenantCredentialRequest(t *testing.T) {
+	req := &auth.AuthSysCredentialRequest{
+		DomainInfo: security.InitDomainInfo(&syscall.Ucred{Uid: 1, Gid: 1}, ""),
+	}
+
+	test.AssertTrue(t, newTenantCredentialRequest("", req) == auth.CredentialRequest(req),
+		"request without a tenant should be unchanged")
+
+	tenantReq := newTenantCredentialRequest("tenant-a", req)
+	test.AssertTrue(t, unwrapCredentialRequest(tenantReq) == auth.CredentialRequest(req),
+		"tenant request should unwrap to the request")
+	sysReq := newSystemCredentialRequest("daos_server", tenantReq)
+	test.AssertTrue(t, unwrapCredentialRequest(sysReq) == auth.CredentialRequest(req),
+		"system request should unwrap to the request")
+
+	keyA := tenantReq.GetKey()
+	keyB := newTenantCredentialRequest("tenant-b", req).GetKey()
+	test.AssertTrue(t, keyA != req.GetKey(), "tenant request should have its own key")
+	test.AssertTrue(t, keyA != keyB, "requests of different tenants should have different keys")
+}
+
+func TestAgent_SecurityModule_reloadSigningKey_tenant(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	mod, _ := testKeyReloadModule(t, log)
+	dir := t.TempDir()
+	keyCfg := &security.SigningKeyConfig{
+		Tenant: "tenant-a",
+		CertificateConfig: security.CertificateConfig{
+			CARootPath:      filepath.Join(dir, "daosCA.crt"),
+			CertificatePath: filepath.Join(dir, "tenant-a.crt"),
+			PrivateKeyPath:  filepath.Join(dir, "tenant-a.key"),
+		},
+	}
+	mod.signingKeys = newSigningKeys([]*security.SigningKeyConfig{keyCfg})
+	tenantKey := mod.signingKeys[0]
+	keyID := writeTestKeyPair(t, tenantKey.config, true)
+	cacheTestCredential(t, mod)
+
+	gotID, err := transportKeyID(tenantKey.transport())
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, keyID, gotID, "unexpected tenant signing key")
+
+	// Credentials are kept if no signing key changed.
+	changed, err := mod.reloadSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertFalse(t, changed, "signing keys should be unchanged")
+	test.AssertEqual(t, 1, mod.credCache.cache.Len(), "credential flushed")
+
+	// Credentials are flushed once the tenant's key is rotated.
+	newID := writeTestKeyPair(t, tenantKey.config, true)
+	changed, err = mod.reloadSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, changed, "tenant signing key should have changed")
+	gotID, err = transportKeyID(tenantKey.transport())
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, newID, gotID, "new tenant signing key not used")
+	test.AssertEqual(t, 0, mod.credCache.cache.Len(), "credential signed with old key not flushed")
+
+	// A tenant key which fails to reload is reported, and the old one kept.
+	writeTestKeyPair(t, tenantKey.config, false)
+	if _, err := mod.reloadSigningKey(); err == nil {
+		t.Fatal("expected reload of mismatched key to fail")
+	}
+	gotID, err = transportKeyID(tenantKey.transport())
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, newID, gotID, "old tenant signing key not kept")
+}
@@ -0,0 +1,253 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	// sloBucketCount is the number of buckets the compliance window is divided into.
+	sloBucketCount = 60
+	// sloShortWindowDivisor determines the short alerting window as a fraction
+	// of the compliance window. An alert is only raised when both the long and
+	// short windows are burning, which avoids alerting on a problem that has
+	// already recovered.
+	sloShortWindowDivisor = 12
+	// sloMinAlertEvents is the minimum number of issuances in the short window
+	// before an alert may be raised.
+	sloMinAlertEvents = 10
+)
+
+type (
+	sloBucket struct {
+		start time.Time
+		good  uint64
+		bad   uint64
+	}
+
+	// sloTracker tracks compliance with a single credential issuance SLO over
+	// a sliding window.
+	sloTracker struct {
+		sync.Mutex
+		log         logging.Logger
+		slo         *security.CredentialSLO
+		bucketWidth time.Duration
+		buckets     []sloBucket
+		alerting    bool
+		now         func() time.Time
+	}
+
+	// issuanceSLOs tracks all configured credential issuance SLOs and exports
+	// their status as metrics.
+	issuanceSLOs struct {
+		trackers []*sloTracker
+		events   *prometheus.CounterVec
+		burnRate *prometheus.GaugeVec
+		alerting *prometheus.GaugeVec
+	}
+)
+
+var _ prometheus.Collector = (*issuanceSLOs)(nil)
+
+func newSLOTracker(log logging.Logger, slo *security.CredentialSLO) *sloTracker {
+	width := slo.Window / sloBucketCount
+	if width <= 0 {
+		width = time.Nanosecond
+	}
+
+	return &sloTracker{
+		log:         log,
+		slo:         slo,
+		bucketWidth: width,
+		buckets:     make([]sloBucket, sloBucketCount),
+		now:         time.Now,
+	}
+}
+
+func (t *sloTracker) isGood(latency time.Duration, err error) bool {
+	if err != nil {
+		return false
+	}
+	return t.slo.LatencyTarget == 0 || latency <= t.slo.LatencyTarget
+}
+
+func (t *sloTracker) bucketFor(now time.Time) *sloBucket {
+	start := now.Truncate(t.bucketWidth)
+	b := &t.buckets[(start.UnixNano()/int64(t.bucketWidth))%int64(len(t.buckets))]
+	if !b.start.Equal(start) {
+		*b = sloBucket{start: start}
+	}
+	return b
+}
+
+// record adds the result of a credential issuance to the tracker and returns
+// whether the issuance was considered good.
+func (t *sloTracker) record(latency time.Duration, err error) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	good := t.isGood(latency, err)
+	b := t.bucketFor(t.now())
+	if good {
+		b.good++
+	} else {
+		b.bad++
+	}
+
+	return good
+}
+
+// counts returns the number of good and bad events within the given span.
+func (t *sloTracker) counts(now time.Time, span time.Duration) (good, bad uint64) {
+	oldest := now.Truncate(t.bucketWidth).Add(-span)
+	for _, b := range t.buckets {
+		if b.start.IsZero() || !b.start.After(oldest) || b.start.After(now) {
+			continue
+		}
+		good += b.good
+		bad += b.bad
+	}
+	return
+}
+
+func (t *sloTracker) burnRateLocked(now time.Time, span time.Duration) (float64, uint64) {
+	good, bad := t.counts(now, span)
+	total := good + bad
+	if total == 0 {
+		return 0, 0
+	}
+	return (float64(bad) / float64(total)) / t.slo.ErrorBudget(), total
+}
+
+func (t *sloTracker) shortWindow() time.Duration {
+	span := t.slo.Window / sloShortWindowDivisor
+	if span < t.bucketWidth {
+		return t.bucketWidth
+	}
+	return span
+}
+
+// burnRates returns the current long- and short-window burn rates.
+func (t *sloTracker) burnRates() (long, short float64) {
+	t.Lock()
+	defer t.Unlock()
+
+	now := t.now()
+	long, _ = t.burnRateLocked(now, t.slo.Window)
+	short, _ = t.burnRateLocked(now, t.shortWindow())
+	return
+}
+
+// evaluate checks the burn rates against the alert threshold and logs
+// transitions into and out of the alerting state.
+func (t *sloTracker) evaluate() bool {
+	t.Lock()
+	defer t.Unlock()
+
+	now := t.now()
+	long, _ := t.burnRateLocked(now, t.slo.Window)
+	short, shortEvents := t.burnRateLocked(now, t.shortWindow())
+
+	burning := shortEvents >= sloMinAlertEvents &&
+		long >= t.slo.BurnRateAlert && short >= t.slo.BurnRateAlert
+	switch {
+	case burning && !t.alerting:
+		t.log.Noticef("credential issuance SLO %q is burning error budget: burn rate %.2f (%s), %.2f (%s), threshold %.2f",
+			t.slo.Name, long, t.slo.Window, short, t.shortWindow(), t.slo.BurnRateAlert)
+	case !burning && t.alerting:
+		t.log.Noticef("credential issuance SLO %q has recovered: burn rate %.2f (%s), %.2f (%s)",
+			t.slo.Name, long, t.slo.Window, short, t.shortWindow())
+	}
+	t.alerting = burning
+
+	return t.alerting
+}
+
+func newIssuanceSLOs(log logging.Logger, cfg *security.CredentialConfig) *issuanceSLOs {
+	if cfg == nil || len(cfg.IssuanceSLOs) == 0 {
+		return nil
+	}
+
+	s := &issuanceSLOs{
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "agent_credential_slo_events",
+			Help: "Credential issuances counted against an SLO",
+		}, []string{"slo", "result"}),
+		burnRate: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "agent_credential_slo_burn_rate",
+			Help: "Rate at which the SLO error budget is being consumed",
+		}, []string{"slo", "window"}),
+		alerting: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "agent_credential_slo_alerting",
+			Help: "Whether the SLO burn rate is above the alert threshold",
+		}, []string{"slo"}),
+	}
+
+	for _, slo := range cfg.IssuanceSLOs {
+		s.trackers = append(s.trackers, newSLOTracker(log, slo))
+		log.Debugf("tracking credential issuance SLO %q (objective: %g, latency target: %s, window: %s)",
+			slo.Name, slo.Objective, slo.LatencyTarget, slo.Window)
+	}
+
+	return s
+}
+
+// record adds the result of a credential issuance to all tracked SLOs.
+func (s *issuanceSLOs) record(latency time.Duration, err error) {
+	if s == nil {
+		return
+	}
+
+	for _, t := range s.trackers {
+		result := "good"
+		if !t.record(latency, err) {
+			result = "bad"
+		}
+		s.events.WithLabelValues(t.slo.Name, result).Inc()
+
+		alertVal := 0.0
+		if t.evaluate() {
+			alertVal = 1
+		}
+		s.alerting.WithLabelValues(t.slo.Name).Set(alertVal)
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (s *issuanceSLOs) Describe(ch chan<- *prometheus.Desc) {
+	if s == nil {
+		return
+	}
+
+	s.events.Describe(ch)
+	s.burnRate.Describe(ch)
+	s.alerting.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (s *issuanceSLOs) Collect(ch chan<- prometheus.Metric) {
+	if s == nil {
+		return
+	}
+
+	for _, t := range s.trackers {
+		long, short := t.burnRates()
+		s.burnRate.WithLabelValues(t.slo.Name, "long").Set(long)
+		s.burnRate.WithLabelValues(t.slo.Name, "short").Set(short)
+	}
+
+	s.events.Collect(ch)
+	s.burnRate.Collect(ch)
+	s.alerting.Collect(ch)
+}
@@ -0,0 +1,127 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAgent_sloTracker_record(t *testing.T) {
+	for name, tc := range map[string]struct {
+		latencyTarget time.Duration
+		latency       time.Duration
+		err           error
+		expGood       bool
+	}{
+		"error-rate only; success": {
+			latency: time.Hour,
+			expGood: true,
+		},
+		"error-rate only; failure": {
+			err: errors.New("failed"),
+		},
+		"latency; under target": {
+			latencyTarget: 200 * time.Millisecond,
+			latency:       100 * time.Millisecond,
+			expGood:       true,
+		},
+		"latency; over target": {
+			latencyTarget: 200 * time.Millisecond,
+			latency:       300 * time.Millisecond,
+		},
+		"latency; under target with failure": {
+			latencyTarget: 200 * time.Millisecond,
+			latency:       100 * time.Millisecond,
+			err:           errors.New("failed"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			slo := &security.CredentialSLO{
+				Name:          "test",
+				Objective:     0.99,
+				LatencyTarget: tc.latencyTarget,
+			}
+			if err := slo.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			tracker := newSLOTracker(log, slo)
+			test.AssertEqual(t, tc.expGood, tracker.record(tc.latency, tc.err), "")
+		})
+	}
+}
+
+func TestAgent_sloTracker_burnRates(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	slo := &security.CredentialSLO{
+		Name:      "test",
+		Objective: 0.75,
+		Window:    time.Hour,
+	}
+	if err := slo.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	tracker := newSLOTracker(log, slo)
+	tracker.now = func() time.Time { return now }
+
+	// Old failures are within the long window, but not the short one.
+	for i := 0; i < 10; i++ {
+		tracker.record(0, errors.New("failed"))
+	}
+	now = now.Add(30 * time.Minute)
+	for i := 0; i < 10; i++ {
+		tracker.record(0, nil)
+	}
+
+	long, short := tracker.burnRates()
+	// 10 bad out of 20 with a 25% budget
+	test.AssertEqual(t, 2.0, long, "unexpected long burn rate")
+	test.AssertEqual(t, 0.0, short, "unexpected short burn rate")
+	test.AssertFalse(t, tracker.evaluate(), "expected no alert")
+
+	// Once the failures age out of the long window, they no longer count.
+	now = now.Add(time.Hour)
+	long, _ = tracker.burnRates()
+	test.AssertEqual(t, 0.0, long, "unexpected long burn rate")
+
+	for i := 0; i < 10; i++ {
+		tracker.record(0, errors.New("failed"))
+	}
+	test.AssertTrue(t, tracker.evaluate(), "expected alert")
+
+	now = now.Add(10 * time.Minute)
+	for i := 0; i < 100; i++ {
+		tracker.record(0, nil)
+	}
+	test.AssertFalse(t, tracker.evaluate(), "expected alert to clear")
+}
+
+func TestAgent_issuanceSLOs_nil(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	slos := newIssuanceSLOs(log, &security.CredentialConfig{})
+	if slos != nil {
+		t.Fatal("expected nil SLOs without configuration")
+	}
+
+	// Should not panic.
+	slos.record(time.Second, nil)
+}
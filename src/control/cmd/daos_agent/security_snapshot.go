@@ -0,0 +1,245 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+const (
+	// snapshotContext authenticates a snapshot as such, so that it cannot be
+	// confused with other data encrypted with the credential cache key.
+	snapshotContext = "daos_agent credential cache snapshot\x00"
+	snapshotVersion = 1
+)
+
+var errCacheNotEncrypted = errors.New("the credential cache must be enabled and encrypted to be snapshotted")
+
+// credentialSnapshot is the content of a snapshot before encryption.
+type credentialSnapshot struct {
+	Version int                  `json:"version"`
+	TakenAt time.Time            `json:"taken_at"`
+	Creds   []*spooledCredential `json:"creds"`
+}
+
+// snapshot returns the unexpired cached credentials, encrypted with the
+// credential cache key, and the number of credentials in the snapshot. The
+// snapshot can be restored into any agent using the same key, such as a new
+// agent process replacing this one, so that its clients are not forced to
+// authenticate again.
+func (cc *credentialCache) snapshot() ([]byte, int, error) {
+	if cc == nil || cc.sealer == nil {
+		return nil, 0, errCacheNotEncrypted
+	}
+
+	snap := &credentialSnapshot{
+		Version: snapshotVersion,
+		TakenAt: time.Now(),
+	}
+	defer func() {
+		for _, spooled := range snap.Creds {
+			zeroize(spooled.Cred)
+		}
+	}()
+	for _, key := range cc.cache.Keys() {
+		// Expired credentials are removed when they are looked up.
+		item, release, err := cc.cache.Get(context.Background(), key)
+		if err != nil {
+			continue
+		}
+		if cred, ok := item.(*cachedCredential); ok {
+			spooled, err := newSpooledCredential(cred)
+			if err != nil {
+				cc.log.Errorf("unable to snapshot cached credential: %s", err)
+			} else {
+				snap.Creds = append(snap.Creds, spooled)
+			}
+		}
+		release()
+	}
+
+	plaintext, err := json.Marshal(snap)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer zeroize(plaintext)
+
+	sealed, err := cc.sealer.seal(plaintext, []byte(snapshotContext))
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "encrypting credential cache snapshot")
+	}
+	return sealed, len(snap.Creds), nil
+}
+
+// restore adds the unexpired credentials in the snapshot to the cache,
+// returning the number restored. Credentials which are already cached are kept
+// rather than replaced by those in the snapshot.
+func (cc *credentialCache) restore(sealed []byte) (int, error) {
+	if cc == nil || cc.sealer == nil {
+		return 0, errCacheNotEncrypted
+	}
+
+	plaintext, err := cc.sealer.open(sealed, []byte(snapshotContext))
+	if err != nil {
+		return 0, errors.Wrap(err, "decrypting credential cache snapshot")
+	}
+	defer zeroize(plaintext)
+
+	var snap credentialSnapshot
+	if err := json.Unmarshal(plaintext, &snap); err != nil {
+		return 0, errors.Wrap(err, "parsing credential cache snapshot")
+	}
+	defer func() {
+		for _, spooled := range snap.Creds {
+			zeroize(spooled.Cred)
+		}
+	}()
+	if snap.Version != snapshotVersion {
+		return 0, errors.Errorf("unsupported credential cache snapshot version %d", snap.Version)
+	}
+
+	// Credentials purged while the snapshot is restored are not restored.
+	cc.purgeMutex.RLock()
+	defer cc.purgeMutex.RUnlock()
+
+	var restored int
+	for _, spooled := range snap.Creds {
+		item, err := spooled.cachedCredential(cc.sealer)
+		if err != nil {
+			cc.log.Errorf("unable to restore cached credential: %s", err)
+			continue
+		}
+		if item.IsExpired() || cc.cache.Has(item.key) {
+			item.Evict()
+			continue
+		}
+
+		if err := cc.cache.Set(item); err != nil {
+			cc.log.Errorf("restoring cached credential: %s", err)
+			continue
+		}
+		if err := cc.spool.store(item); err != nil {
+			cc.log.Errorf("persisting cached credential: %s", err)
+		}
+		cc.touch(item.key, item.flavor, item.owner, item.size())
+		restored++
+	}
+	cc.log.Noticef("restored %d of %d credentials from a snapshot taken at %s", restored, len(snap.Creds),
+		snap.TakenAt.Format(time.RFC3339))
+
+	return restored, nil
+}
+
+// handleSnapshotCredentialCache returns an encrypted snapshot of the credential
+// cache. Only root and the agent's own user may take it, as the credentials it
+// holds are bearer-equivalent.
+func (mod *mgmtModule) handleSnapshotCredentialCache(cred *unix.Ucred) ([]byte, error) {
+	if !privilegedUid(cred.Uid) {
+		mod.log.Noticef("audit: denied request from uid %d to snapshot the credential cache", cred.Uid)
+		return drpc.Marshal(&auth.SnapshotCredCacheResp{Status: int32(daos.NoPermission)})
+	}
+
+	snapshot, count, err := mod.credCache.snapshot()
+	if err != nil {
+		mod.log.Errorf("unable to snapshot the credential cache: %s", err)
+		return drpc.Marshal(&auth.SnapshotCredCacheResp{Status: int32(daos.NotInit)})
+	}
+	mod.log.Noticef("audit: uid %d took a snapshot of %d cached credentials", cred.Uid, count)
+
+	return drpc.Marshal(&auth.SnapshotCredCacheResp{Snapshot: snapshot, Count: uint32(count)})
+}
+
+// handleRestoreCredentialCache restores a snapshot of a credential cache. Only
+// root and the agent's own user may restore it.
+func (mod *mgmtModule) handleRestoreCredentialCache(cred *unix.Ucred, reqb []byte) ([]byte, error) {
+	if !privilegedUid(cred.Uid) {
+		mod.log.Noticef("audit: denied request from uid %d to restore the credential cache", cred.Uid)
+		return drpc.Marshal(&auth.RestoreCredCacheResp{Status: int32(daos.NoPermission)})
+	}
+
+	req := new(auth.RestoreCredCacheReq)
+	if err := proto.Unmarshal(reqb, req); err != nil {
+		return nil, drpc.UnmarshalingPayloadFailure()
+	}
+
+	if mod.credCache == nil || mod.credCache.sealer == nil {
+		mod.log.Errorf("unable to restore the credential cache: %s", errCacheNotEncrypted)
+		return drpc.Marshal(&auth.RestoreCredCacheResp{Status: int32(daos.NotInit)})
+	}
+	restored, err := mod.credCache.restore(req.Snapshot)
+	if err != nil {
+		mod.log.Errorf("unable to restore the credential cache: %s", err)
+		return drpc.Marshal(&auth.RestoreCredCacheResp{Status: int32(daos.InvalidInput)})
+	}
+	mod.log.Noticef("audit: uid %d restored %d cached credentials from a snapshot", cred.Uid, restored)
+
+	return drpc.Marshal(&auth.RestoreCredCacheResp{Restored: uint32(restored)})
+}
+
+type snapshotCredCacheCmd struct {
+	configCmd
+	cmdutil.LogCmd
+	Output string `long:"output" required:"1" description:"Path of the file to write the encrypted snapshot to"`
+}
+
+func (cmd *snapshotCredCacheCmd) Execute(_ []string) error {
+	sockPath := filepath.Join(cmd.cfg.RuntimeDir, agentSockName)
+	resp, err := auth.SnapshotCredentialCache(cmd.MustLogCtx(), sockPath, daos.MethodSnapshotCredentialCache)
+	if err != nil {
+		return err
+	}
+	if resp.Status != 0 {
+		return errors.Wrap(daos.Status(resp.Status), "snapshotting credential cache")
+	}
+
+	if err := os.WriteFile(cmd.Output, resp.Snapshot, 0600); err != nil {
+		return errors.Wrap(err, "writing credential cache snapshot")
+	}
+
+	_, err = fmt.Printf("Wrote a snapshot of %d cached credentials to %s\n", resp.Count, cmd.Output)
+	return err
+}
+
+type restoreCredCacheCmd struct {
+	configCmd
+	cmdutil.LogCmd
+	Input string `long:"input" required:"1" description:"Path of the encrypted snapshot to restore"`
+}
+
+func (cmd *restoreCredCacheCmd) Execute(_ []string) error {
+	snapshot, err := os.ReadFile(cmd.Input)
+	if err != nil {
+		return errors.Wrap(err, "reading credential cache snapshot")
+	}
+
+	sockPath := filepath.Join(cmd.cfg.RuntimeDir, agentSockName)
+	resp, err := auth.RestoreCredentialCache(cmd.MustLogCtx(), sockPath, daos.MethodRestoreCredentialCache,
+		&auth.RestoreCredCacheReq{Snapshot: snapshot})
+	if err != nil {
+		return err
+	}
+	if resp.Status != 0 {
+		return errors.Wrap(daos.Status(resp.Status), "restoring credential cache")
+	}
+
+	_, err = fmt.Printf("Restored %d cached credentials\n", resp.Restored)
+	return err
+}
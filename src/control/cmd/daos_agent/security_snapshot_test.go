@@ -0,0 +1,255 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/cache"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func testSnapshotCache(t *testing.T, log logging.Logger, sealer *credentialSealer) *credentialCache {
+	t.Helper()
+	return &credentialCache{
+		log:          log,
+		cache:        cache.NewShardedItemCache(log, credCacheShards),
+		credLifetime: time.Minute,
+		sealer:       sealer,
+	}
+}
+
+func setSnapshotCred(t *testing.T, cc *credentialCache, key string, lifetime time.Duration) {
+	t.Helper()
+	if err := cc.cache.Set(testSpooledCred(t, cc.sealer, key, lifetime)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAgent_credentialCache_snapshot(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	// Agents using the same signing key derive the same cache key.
+	newSealer := func() *credentialSealer {
+		sealer, err := newCredentialSealer([]byte("secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sealer
+	}
+
+	var nilCache *credentialCache
+	if _, _, err := nilCache.snapshot(); err == nil {
+		t.Fatal("expected snapshot of a nil cache to fail")
+	}
+	_, _, err := testSnapshotCache(t, log, nil).snapshot()
+	test.CmpErr(t, errCacheNotEncrypted, err)
+
+	old := testSnapshotCache(t, log, newSealer())
+	setSnapshotCred(t, old, "uid:1", time.Minute)
+	setSnapshotCred(t, old, "uid:2", time.Minute)
+	setSnapshotCred(t, old, "expired", -time.Minute)
+	owner := uint32(1)
+	item, release, err := old.cache.Get(test.Context(t), "uid:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	item.(*cachedCredential).owner = &owner
+	release()
+
+	snapshot, count, err := old.snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 2, count, "unexpected number of credentials in snapshot")
+	test.AssertFalse(t, bytes.Contains(snapshot, []byte("uid:1")), "snapshot not encrypted")
+
+	// A snapshot can't be restored with a different key.
+	_, err = testSnapshotCache(t, log, testSealer(t)).restore(snapshot)
+	test.CmpErr(t, errors.New("decrypting credential cache snapshot"), err)
+	_, err = testSnapshotCache(t, log, nil).restore(snapshot)
+	test.CmpErr(t, errCacheNotEncrypted, err)
+
+	// Credentials already cached by the new agent are kept.
+	restoring := testSnapshotCache(t, log, newSealer())
+	restoring.credLifetime = time.Hour
+	setSnapshotCred(t, restoring, "uid:2", time.Hour)
+
+	restored, err := restoring.restore(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 1, restored, "unexpected number of credentials restored")
+
+	for key, expLifetime := range map[string]time.Duration{
+		"uid:1": time.Minute,
+		"uid:2": time.Hour,
+	} {
+		item, release, err := restoring.cache.Get(test.Context(t), key)
+		if err != nil {
+			t.Fatalf("%s not cached: %s", key, err)
+		}
+		cred := item.(*cachedCredential)
+		got, err := cred.credential()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(testCred(key), got, protocmp.Transform()); diff != "" {
+			t.Fatalf("unexpected credential for %s (-want, +got):\n%s\n", key, diff)
+		}
+		lifetime := cred.expiredAt.Sub(cred.createdAt)
+		test.AssertTrue(t, lifetime > expLifetime-time.Second && lifetime <= expLifetime,
+			"unexpected lifetime for "+key)
+		if key == "uid:1" {
+			test.AssertTrue(t, cred.owner != nil && *cred.owner == owner, "owner not restored")
+		}
+		release()
+	}
+	test.AssertFalse(t, restoring.cache.Has("expired"), "expired credential restored")
+
+	// Restoring the snapshot again restores nothing new.
+	restored, err = restoring.restore(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 0, restored, "credentials restored twice")
+}
+
+func TestAgent_mgmtModule_handleSnapshotCredentialCache(t *testing.T) {
+	for name, tc := range map[string]struct {
+		uid       uint32
+		encrypted bool
+		expStatus daos.Status
+		expCount  uint32
+	}{
+		"agent user": {
+			uid:       uint32(os.Geteuid()),
+			encrypted: true,
+			expCount:  1,
+		},
+		"other user": {
+			uid:       uint32(os.Geteuid()) + 1,
+			encrypted: true,
+			expStatus: daos.NoPermission,
+		},
+		"not encrypted": {
+			uid:       uint32(os.Geteuid()),
+			expStatus: daos.NotInit,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var sealer *credentialSealer
+			if tc.encrypted {
+				sealer = testSealer(t)
+			}
+			cc := testSnapshotCache(t, log, sealer)
+			setSnapshotCred(t, cc, "uid:1", time.Minute)
+			mod := &mgmtModule{log: log, credCache: cc}
+
+			respBytes, err := mod.handleSnapshotCredentialCache(&unix.Ucred{Uid: tc.uid})
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp := new(auth.SnapshotCredCacheResp)
+			if err := proto.Unmarshal(respBytes, resp); err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, int32(tc.expStatus), resp.Status, "unexpected status")
+			test.AssertEqual(t, tc.expCount, resp.Count, "unexpected count")
+			if tc.expStatus != 0 {
+				test.AssertEqual(t, 0, len(resp.Snapshot), "snapshot returned on failure")
+			}
+		})
+	}
+}
+
+func TestAgent_mgmtModule_handleRestoreCredentialCache(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	sealer := testSealer(t)
+	old := testSnapshotCache(t, log, sealer)
+	setSnapshotCred(t, old, "uid:1", time.Minute)
+	snapshot, _, err := old.snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		uid         uint32
+		encrypted   bool
+		snapshot    []byte
+		expStatus   daos.Status
+		expRestored uint32
+	}{
+		"agent user": {
+			uid:         uint32(os.Geteuid()),
+			encrypted:   true,
+			snapshot:    snapshot,
+			expRestored: 1,
+		},
+		"other user": {
+			uid:       uint32(os.Geteuid()) + 1,
+			encrypted: true,
+			snapshot:  snapshot,
+			expStatus: daos.NoPermission,
+		},
+		"not encrypted": {
+			uid:       uint32(os.Geteuid()),
+			snapshot:  snapshot,
+			expStatus: daos.NotInit,
+		},
+		"corrupt snapshot": {
+			uid:       uint32(os.Geteuid()),
+			encrypted: true,
+			snapshot:  []byte("garbage"),
+			expStatus: daos.InvalidInput,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var cc *credentialCache
+			if tc.encrypted {
+				cc = testSnapshotCache(t, log, sealer)
+			}
+			mod := &mgmtModule{log: log, credCache: cc}
+
+			reqBytes, err := proto.Marshal(&auth.RestoreCredCacheReq{Snapshot: tc.snapshot})
+			if err != nil {
+				t.Fatal(err)
+			}
+			respBytes, err := mod.handleRestoreCredentialCache(&unix.Ucred{Uid: tc.uid}, reqBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp := new(auth.RestoreCredCacheResp)
+			if err := proto.Unmarshal(respBytes, resp); err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, int32(tc.expStatus), resp.Status, "unexpected status")
+			test.AssertEqual(t, tc.expRestored, resp.Restored, "unexpected number restored")
+			if tc.expRestored > 0 {
+				test.AssertTrue(t, cc.cache.Has("uid:1"), "credential not restored")
+			}
+		})
+	}
+}
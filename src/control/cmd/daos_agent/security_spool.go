@@ -0,0 +1,247 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+const (
+	spoolFileExt    = ".cred"
+	spoolTempPrefix = ".tmp-"
+)
+
+type (
+	// credentialSpool persists cached credentials to a directory, so that
+	// they survive a restart of the agent. Each credential is stored in its
+	// own file, named by a digest of its cache key, and encrypted with the
+	// credential cache key. The spool is useless without the key, and is
+	// discarded if the key changes.
+	credentialSpool struct {
+		log    logging.Logger
+		dir    string
+		sealer *credentialSealer
+	}
+
+	// spooledCredential is the content of a spool file before encryption.
+	spooledCredential struct {
+		Key       string    `json:"key"`
+		Owner     *uint32   `json:"owner,omitempty"`
+		CreatedAt time.Time `json:"created_at"`
+		ExpiredAt time.Time `json:"expired_at"`
+		Cred      []byte    `json:"cred"`
+	}
+)
+
+// newCredentialSpool returns a spool in the directory, which is created if it
+// does not exist, encrypted with the sealer.
+func newCredentialSpool(log logging.Logger, dir string, sealer *credentialSealer) (*credentialSpool, error) {
+	if sealer == nil {
+		return nil, errors.New("cached credentials must be encrypted to be persisted")
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "creating credential spool")
+	}
+
+	return &credentialSpool{
+		log:    log,
+		dir:    dir,
+		sealer: sealer,
+	}, nil
+}
+
+func (s *credentialSpool) path(key string) string {
+	return filepath.Join(s.dir, cacheKeyHash(key)+spoolFileExt)
+}
+
+// newSpooledCredential returns the cached credential in the form in which it
+// is persisted. The caller should zeroize the Cred once it is no longer needed.
+func newSpooledCredential(item *cachedCredential) (*spooledCredential, error) {
+	credBytes, err := item.marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &spooledCredential{
+		Key:       item.key,
+		Owner:     item.owner,
+		CreatedAt: item.createdAt,
+		ExpiredAt: item.expiredAt,
+		Cred:      credBytes,
+	}, nil
+}
+
+// cachedCredential returns the persisted credential as a cached credential,
+// encrypted if the sealer is set.
+func (spooled *spooledCredential) cachedCredential(sealer *credentialSealer) (*cachedCredential, error) {
+	cred := new(auth.Credential)
+	if err := proto.Unmarshal(spooled.Cred, cred); err != nil {
+		return nil, err
+	}
+
+	item, err := newCachedCredential(spooled.Key, cred, 0, sealer)
+	if err != nil {
+		return nil, err
+	}
+	item.owner = spooled.Owner
+	item.expiredAt = spooled.ExpiredAt
+	if !spooled.CreatedAt.IsZero() {
+		item.createdAt = spooled.CreatedAt
+	}
+	return item, nil
+}
+
+// store writes the cached credential to the spool, replacing any credential
+// previously stored for its key.
+func (s *credentialSpool) store(item *cachedCredential) error {
+	if s == nil {
+		return nil
+	}
+
+	spooled, err := newSpooledCredential(item)
+	if err != nil {
+		return err
+	}
+	defer zeroize(spooled.Cred)
+	plaintext, err := json.Marshal(spooled)
+	if err != nil {
+		return err
+	}
+	defer zeroize(plaintext)
+
+	path := s.path(item.key)
+	// The file name is authenticated, so that files cannot be swapped.
+	sealed, err := s.sealer.seal(plaintext, []byte(filepath.Base(path)))
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, spoolTempPrefix)
+	if err != nil {
+		return errors.Wrap(err, "writing credential spool")
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(sealed); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "writing credential spool")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "writing credential spool")
+	}
+
+	return errors.Wrap(os.Rename(tmp.Name(), path), "writing credential spool")
+}
+
+// open decrypts the spool file.
+func (s *credentialSpool) open(path string) (*cachedCredential, error) {
+	sealed, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := s.sealer.open(sealed, []byte(filepath.Base(path)))
+	if err != nil {
+		return nil, err
+	}
+	defer zeroize(plaintext)
+
+	var spooled spooledCredential
+	if err := json.Unmarshal(plaintext, &spooled); err != nil {
+		return nil, err
+	}
+	defer zeroize(spooled.Cred)
+	if s.path(spooled.Key) != path {
+		return nil, errors.New("credential stored under the wrong key")
+	}
+	return spooled.cachedCredential(s.sealer)
+}
+
+// load returns the unexpired credentials in the spool. Files which are expired,
+// or cannot be decrypted, such as those encrypted with a previous signing key,
+// are removed.
+func (s *credentialSpool) load() []*cachedCredential {
+	if s == nil {
+		return nil
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		s.log.Errorf("reading credential spool: %s", err)
+		return nil
+	}
+
+	var creds []*cachedCredential
+	for _, entry := range entries {
+		path := filepath.Join(s.dir, entry.Name())
+		switch {
+		case strings.HasPrefix(entry.Name(), spoolTempPrefix):
+			// Left behind by an interrupted store.
+			os.Remove(path)
+			continue
+		case entry.IsDir() || filepath.Ext(entry.Name()) != spoolFileExt:
+			continue
+		}
+
+		cred, err := s.open(path)
+		switch {
+		case err != nil:
+			s.log.Debugf("discarding spooled credential %s: %s", entry.Name(), err)
+		case cred.IsExpired():
+			s.log.Tracef("discarding expired spooled credential %s", entry.Name())
+		default:
+			creds = append(creds, cred)
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			s.log.Errorf("removing spooled credential: %s", err)
+		}
+	}
+
+	return creds
+}
+
+// remove removes the credential stored for the key, if any.
+func (s *credentialSpool) remove(key string) error {
+	if s == nil {
+		return nil
+	}
+
+	if err := os.Remove(s.path(key)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "removing spooled credential")
+	}
+	return nil
+}
+
+// clear removes all credentials from the spool, returning the number removed.
+func (s *credentialSpool) clear() int {
+	if s == nil {
+		return 0
+	}
+
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*"+spoolFileExt))
+	if err != nil {
+		return 0
+	}
+	var removed int
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil {
+			s.log.Errorf("removing spooled credential: %s", err)
+			continue
+		}
+		removed++
+	}
+	return removed
+}
@@ -0,0 +1,239 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/cache"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func testSealer(t *testing.T) *credentialSealer {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, err := signingKeySecret(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealer, err := newCredentialSealer(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sealer
+}
+
+func testCred(key string) *auth.Credential {
+	return &auth.Credential{
+		Token:  &auth.Token{Flavor: auth.Flavor_AUTH_SYS, Data: []byte(key)},
+		Origin: "test-origin",
+	}
+}
+
+func testSpooledCred(t *testing.T, sealer *credentialSealer, key string, lifetime time.Duration) *cachedCredential {
+	t.Helper()
+	item, err := newCachedCredential(key, testCred(key), lifetime, sealer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return item
+}
+
+func spoolFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestAgent_credentialSpool(t *testing.T) {
+	sealer := testSealer(t)
+
+	for name, tc := range map[string]struct {
+		stored     []*cachedCredential
+		loadSealer *credentialSealer
+		tamper     func(t *testing.T, spool *credentialSpool)
+		expLoaded  []string
+		expRemains int
+	}{
+		"empty": {},
+		"restored": {
+			stored: []*cachedCredential{
+				testSpooledCred(t, sealer, "uid:1", time.Minute),
+				testSpooledCred(t, sealer, "uid:2", time.Minute),
+			},
+			expLoaded:  []string{"uid:1", "uid:2"},
+			expRemains: 2,
+		},
+		"expired discarded": {
+			stored: []*cachedCredential{
+				testSpooledCred(t, sealer, "uid:1", time.Minute),
+				testSpooledCred(t, sealer, "uid:2", -time.Minute),
+			},
+			expLoaded:  []string{"uid:1"},
+			expRemains: 1,
+		},
+		"signing key changed": {
+			stored: []*cachedCredential{
+				testSpooledCred(t, sealer, "uid:1", time.Minute),
+			},
+			loadSealer: testSealer(t),
+		},
+		"file swapped": {
+			stored: []*cachedCredential{
+				testSpooledCred(t, sealer, "uid:1", time.Minute),
+				testSpooledCred(t, sealer, "uid:2", time.Minute),
+			},
+			tamper: func(t *testing.T, spool *credentialSpool) {
+				if err := os.Rename(spool.path("uid:1"), spool.path("uid:2")); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		"file truncated": {
+			stored: []*cachedCredential{
+				testSpooledCred(t, sealer, "uid:1", time.Minute),
+			},
+			tamper: func(t *testing.T, spool *credentialSpool) {
+				if err := os.Truncate(spool.path("uid:1"), 4); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		"interrupted store": {
+			tamper: func(t *testing.T, spool *credentialSpool) {
+				if err := os.WriteFile(filepath.Join(spool.dir, spoolTempPrefix+"1"), nil, 0600); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			dir := filepath.Join(t.TempDir(), "spool")
+			spool, err := newCredentialSpool(log, dir, sealer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, item := range tc.stored {
+				if err := spool.store(item); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.tamper != nil {
+				tc.tamper(t, spool)
+			}
+
+			loadSealer := sealer
+			if tc.loadSealer != nil {
+				loadSealer = tc.loadSealer
+			}
+			reloaded, err := newCredentialSpool(log, dir, loadSealer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			loaded := reloaded.load()
+
+			var loadedKeys []string
+			for _, item := range loaded {
+				loadedKeys = append(loadedKeys, item.key)
+				test.AssertTrue(t, item.cred == nil, "restored credential not sealed")
+				cred, err := item.credential()
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, stored := range tc.stored {
+					if stored.key != item.key {
+						continue
+					}
+					if diff := cmp.Diff(testCred(stored.key), cred, protocmp.Transform()); diff != "" {
+						t.Fatalf("unexpected credential (-want +got):\n%s", diff)
+					}
+					test.AssertTrue(t, stored.expiredAt.Equal(item.expiredAt), "unexpected expiry")
+				}
+			}
+			sort.Strings(loadedKeys)
+			test.CmpAny(t, "loaded credentials", tc.expLoaded, loadedKeys)
+			test.AssertEqual(t, tc.expRemains, len(spoolFiles(t, dir)), "unexpected spool files")
+		})
+	}
+}
+
+func TestAgent_credentialSpool_permissions(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	dir := filepath.Join(t.TempDir(), "spool")
+	sealer := testSealer(t)
+	spool, err := newCredentialSpool(log, dir, sealer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := spool.store(testSpooledCred(t, sealer, "uid:1", time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	for path, expPerm := range map[string]os.FileMode{
+		dir:                 0700,
+		spool.path("uid:1"): 0600,
+	} {
+		fi, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		test.AssertEqual(t, expPerm, fi.Mode().Perm(), "unexpected permissions for "+path)
+	}
+
+	if err := spool.store(testSpooledCred(t, sealer, "uid:2", time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := spool.remove("uid:2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := spool.remove("uid:2"); err != nil {
+		t.Fatalf("removing a missing credential: %s", err)
+	}
+
+	test.AssertEqual(t, 1, spool.clear(), "unexpected number cleared")
+	test.AssertEqual(t, 0, len(spoolFiles(t, dir)), "spool not cleared")
+
+	_, err = newCredentialSpool(log, dir, nil)
+	test.CmpErr(t, errors.New("must be encrypted to be persisted"), err)
+}
+
+func TestAgent_credentialCache_enableSpool(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	cc := &credentialCache{
+		log:          log,
+		cache:        cache.NewShardedItemCache(log, credCacheShards),
+		credLifetime: time.Minute,
+	}
+	err := cc.enableSpool(t.TempDir())
+	test.CmpErr(t, errors.New("must be encrypted to be persisted"), err)
+	test.AssertTrue(t, cc.spool == nil, "spool enabled without encryption")
+}
@@ -0,0 +1,60 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// systemCredentialRequest scopes a credential request to a DAOS system. Its
+// key is namespaced by the system name, so that a credential cached for one
+// system can never satisfy a request for another, even if the requests are
+// otherwise identical.
+type systemCredentialRequest struct {
+	auth.CredentialRequest
+	sys string
+}
+
+func newSystemCredentialRequest(sys string, req auth.CredentialRequest) *systemCredentialRequest {
+	return &systemCredentialRequest{
+		CredentialRequest: req,
+		sys:               sys,
+	}
+}
+
+// GetKey returns the key of the request, namespaced by the system name.
+func (r *systemCredentialRequest) GetKey() string {
+	return r.sys + "/" + r.CredentialRequest.GetKey()
+}
+
+// Unwrap returns the request scoped to the system.
+func (r *systemCredentialRequest) Unwrap() auth.CredentialRequest {
+	return r.CredentialRequest
+}
+
+// unwrapCredentialRequest returns the request of the flavor, from within any
+// requests scoping it.
+func unwrapCredentialRequest(req auth.CredentialRequest) auth.CredentialRequest {
+	for {
+		wrapper, ok := req.(interface{ Unwrap() auth.CredentialRequest })
+		if !ok {
+			return req
+		}
+		req = wrapper.Unwrap()
+	}
+}
+
+// credentialSystem resolves the name of the system a client requested a
+// credential or flavors for. An empty name refers to the agent's own system.
+func (m *SecurityModule) credentialSystem(sys string) (string, error) {
+	if sys == "" || sys == m.config.sys {
+		return m.config.sys, nil
+	}
+	return "", errors.Errorf("unknown system name %q (agent serves %q)", sys, m.config.sys)
+}
@@ -0,0 +1,124 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func TestAgent_systemCredentialRequest(t *testing.T) {
+	req := &auth.AuthSysCredentialRequest{
+		DomainInfo: security.InitDomainInfo(&syscall.Ucred{Uid: 1, Gid: 1}, ""),
+	}
+	sysA := newSystemCredentialRequest("sys-a", req)
+	sysB := newSystemCredentialRequest("sys-b", req)
+
+	test.AssertEqual(t, "sys-a/"+req.GetKey(), sysA.GetKey(), "key not namespaced by system")
+	test.AssertTrue(t, sysA.GetKey() != sysB.GetKey(), "keys of different systems collide")
+	test.AssertEqual(t, req.GetAuthFlavor(), sysA.GetAuthFlavor(), "flavor not preserved")
+	test.AssertTrue(t, unwrapCredentialRequest(sysA) == auth.CredentialRequest(req), "request not unwrapped")
+	test.AssertTrue(t, unwrapCredentialRequest(req) == auth.CredentialRequest(req), "unwrapped request not returned")
+
+	keyer, err := newCacheKeyer(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, keyer.key(sysA) != keyer.key(sysB), "cache keys of different systems collide")
+}
+
+func TestAgent_SecurityModule_credentialSystem(t *testing.T) {
+	for name, tc := range map[string]struct {
+		sys    string
+		expSys string
+		expErr error
+	}{
+		"default": {
+			expSys: "daos_server",
+		},
+		"agent's system": {
+			sys:    "daos_server",
+			expSys: "daos_server",
+		},
+		"other system": {
+			sys:    "other",
+			expErr: errors.New("unknown system name"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			cfg := defaultTestSecurityConfig(t, log, testInfoCacheParams{})
+			cfg.sys = "daos_server"
+			mod := NewSecurityModule(log, cfg)
+
+			sys, err := mod.credentialSystem(tc.sys)
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expSys, sys, "unexpected system")
+		})
+	}
+}
+
+func TestAgent_SecurityModule_RequestCreds_System(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	conn, cleanup := setupTestUnixConn(t)
+	defer cleanup()
+	session := newTestSession(t, log, conn)
+
+	cfg := defaultTestSecurityConfig(t, log, testInfoCacheParams{})
+	cfg.sys = "daos_server"
+	cfg.credentials.CacheExpiration = time.Minute
+	mod := NewSecurityModule(log, cfg)
+
+	request := func(sys string) []byte {
+		t.Helper()
+		reqBytes, err := proto.Marshal(&auth.GetCredReq{Flavor: auth.Flavor_AUTH_SYS, Sys: sys})
+		if err != nil {
+			t.Fatal(err)
+		}
+		respBytes, err := mod.HandleCall(test.Context(t), session, daos.MethodRequestCredentials, reqBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return respBytes
+	}
+
+	expectCredResp(t, request("daos_server"), 0, true)
+	expectCredResp(t, request(""), 0, true)
+	test.AssertEqual(t, 1, mod.credCache.cache.Len(), "default system not resolved to the agent's")
+
+	expectCredResp(t, request("other"), int32(daos.InvalidInput), false)
+	test.AssertEqual(t, 1, mod.credCache.cache.Len(), "credential cached for unknown system")
+
+	reqBytes, err := proto.Marshal(&auth.InvalidateCredReq{Flavor: auth.Flavor_AUTH_SYS, Sys: "other"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	respBytes, err := mod.HandleCall(test.Context(t), session, daos.MethodInvalidateCredential, reqBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := new(auth.InvalidateCredResp)
+	if err := proto.Unmarshal(respBytes, resp); err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, int32(daos.InvalidInput), resp.Status, "unexpected status")
+	test.AssertEqual(t, 1, mod.credCache.cache.Len(), "credential of agent's system invalidated")
+}
@@ -0,0 +1,101 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/lib/tpm"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// tpmKeyGenerateTimeout allows for TPMs, which can be slow, to generate the
+// primary and signing keys.
+const tpmKeyGenerateTimeout = 2 * time.Minute
+
+// The TPM operations are swappable for testing.
+var (
+	tpmGenerateKey = tpm.GenerateKey
+	tpmOpenKey     = func(handle string, pub crypto.PublicKey) (crypto.Signer, error) {
+		return tpm.OpenKey(handle, pub, 0)
+	}
+)
+
+type generateTPMKeyCmd struct {
+	cmdutil.LogCmd
+	cmdutil.JSONOutputCmd
+	Handle     string `long:"handle" required:"1" description:"Persistent TPM handle at which to keep the key, e.g. 0x81010002"`
+	Algorithm  string `long:"algorithm" default:"ecc256" choice:"ecc256" choice:"ecc384" choice:"rsa2048" choice:"rsa3072" description:"Algorithm of the key"`
+	CSR        string `long:"csr" required:"1" description:"Path to which to write the certificate signing request for the key"`
+	CommonName string `long:"common-name" default:"agent" description:"Common name of the certificate"`
+}
+
+type generateTPMKeyResp struct {
+	Handle string `json:"handle"`
+	KeyID  string `json:"key_id"`
+	CSR    string `json:"csr"`
+}
+
+// Execute generates a signing key in the TPM, and a certificate signing request
+// for it. Once the request has been signed by the DAOS CA, the certificate is
+// installed as the agent's certificate, with tpm_key set to the handle, and in
+// the client certificate directory of the servers, which then accept
+// credentials signed by the key.
+func (cmd *generateTPMKeyCmd) Execute(_ []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), tpmKeyGenerateTimeout)
+	defer cancel()
+
+	pub, err := tpmGenerateKey(ctx, cmd.Handle, cmd.Algorithm)
+	if err != nil {
+		return err
+	}
+	key, err := tpmOpenKey(cmd.Handle, pub)
+	if err != nil {
+		return err
+	}
+	keyID, err := security.PublicKeyID(pub)
+	if err != nil {
+		return err
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{
+			Organization: []string{"DAOS"},
+			CommonName:   cmd.CommonName,
+		},
+	}, key)
+	if err != nil {
+		return errors.Wrap(err, "creating certificate signing request")
+	}
+	if err := os.WriteFile(cmd.CSR, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr}), 0644); err != nil {
+		return errors.Wrap(err, "writing certificate signing request")
+	}
+
+	resp := &generateTPMKeyResp{
+		Handle: cmd.Handle,
+		KeyID:  keyID,
+		CSR:    cmd.CSR,
+	}
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, nil)
+	}
+
+	_, err = fmt.Printf("Generated TPM key %s at handle %s\nCertificate signing request written to %s\n",
+		resp.KeyID, resp.Handle, resp.CSR)
+	return err
+}
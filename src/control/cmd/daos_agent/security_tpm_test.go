@@ -0,0 +1,93 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestAgent_generateTPMKeyCmd(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		genErr error
+		expErr error
+	}{
+		"success": {},
+		"generate failed": {
+			genErr: errors.New("handle in use"),
+			expErr: errors.New("handle in use"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir, tmpCleanup := test.CreateTestDir(t)
+			defer tmpCleanup()
+
+			var gotHandle, gotAlg string
+			origGen, origOpen := tpmGenerateKey, tpmOpenKey
+			tpmGenerateKey = func(_ context.Context, handle, alg string) (crypto.PublicKey, error) {
+				gotHandle, gotAlg = handle, alg
+				if tc.genErr != nil {
+					return nil, tc.genErr
+				}
+				return key.Public(), nil
+			}
+			tpmOpenKey = func(_ string, _ crypto.PublicKey) (crypto.Signer, error) {
+				return key, nil
+			}
+			defer func() { tpmGenerateKey, tpmOpenKey = origGen, origOpen }()
+
+			cmd := &generateTPMKeyCmd{
+				Handle:     "0x81010002",
+				Algorithm:  "ecc256",
+				CSR:        filepath.Join(tmpDir, "agent.csr"),
+				CommonName: "agent",
+			}
+			err := cmd.Execute(nil)
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, "0x81010002", gotHandle, "unexpected handle")
+			test.AssertEqual(t, "ecc256", gotAlg, "unexpected algorithm")
+			if tc.expErr != nil {
+				return
+			}
+
+			data, err := os.ReadFile(cmd.CSR)
+			if err != nil {
+				t.Fatal(err)
+			}
+			block, _ := pem.Decode(data)
+			if block == nil {
+				t.Fatal("no PEM block in CSR")
+			}
+			csr, err := x509.ParseCertificateRequest(block.Bytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := csr.CheckSignature(); err != nil {
+				t.Fatalf("invalid CSR signature: %s", err)
+			}
+			test.AssertTrue(t, key.PublicKey.Equal(csr.PublicKey), "CSR is not for the TPM key")
+			test.AssertEqual(t, "agent", csr.Subject.CommonName, "unexpected common name")
+		})
+	}
+}
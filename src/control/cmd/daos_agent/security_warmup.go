@@ -0,0 +1,153 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"crypto"
+	"os/user"
+	"strconv"
+	"syscall"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// lookupUserIDs resolves a user, given by name or UID, to its UID and primary
+// GID.
+var lookupUserIDs = func(name string) (uint32, uint32, error) {
+	var u *user.User
+	var err error
+	if _, numErr := strconv.ParseUint(name, 10, 32); numErr == nil {
+		u, err = user.LookupId(name)
+	} else {
+		u, err = user.Lookup(name)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid uid for user %q", name)
+	}
+	gid, err := strconv.ParseUint(u.Gid, 10, 32)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid gid for user %q", name)
+	}
+	return uint32(uid), uint32(gid), nil
+}
+
+// notifyFlushed signals that cached credentials were flushed, so that the
+// cache is warmed up again.
+func (cc *credentialCache) notifyFlushed() {
+	if cc == nil || cc.flushed == nil {
+		return
+	}
+
+	select {
+	case cc.flushed <- struct{}{}:
+	default:
+		// A warm-up is already pending.
+	}
+}
+
+// warmupRequest initializes the request for the credential of the warm-up
+// entry, as if it had been requested by one of the user's processes.
+func (m *SecurityModule) warmupRequest(entry security.CacheWarmupEntry, key crypto.PrivateKey) (auth.CredentialRequest, uint32, error) {
+	flavorName := entry.Flavor
+	if flavorName == "" {
+		flavorName = auth.Flavor_AUTH_SYS.String()
+	}
+	flavors, err := auth.ParseValidAuthFlavors([]string{flavorName})
+	if err != nil {
+		return nil, 0, err
+	}
+	flavor := flavors[0]
+	if err, disabled := m.disabledFlavors[flavor]; disabled {
+		return nil, 0, errors.Wrapf(err, "%s is disabled after a failed self-test", flavor)
+	}
+
+	factory, found := auth.FlavorToFactory[flavor]
+	if !found {
+		return nil, 0, errors.Errorf("unknown flavor %s", flavor)
+	}
+	userFactory, ok := factory.(auth.UserCredentialRequestFactory)
+	if !ok {
+		return nil, 0, errors.Errorf("%s credentials can't be requested on behalf of a user", flavor)
+	}
+
+	uid, gid, err := lookupUserIDs(entry.User)
+	if err != nil {
+		return nil, 0, err
+	}
+	info := security.InitDomainInfo(&syscall.Ucred{Uid: uid, Gid: gid}, "")
+	req, err := userFactory.InitForUser(m.log, m.config.credentials, info, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	return newSystemCredentialRequest(m.config.sys, req), uid, nil
+}
+
+// warmCredentialCache requests the configured warm-up credentials which are not
+// already cached, returning the number cached.
+func (m *SecurityModule) warmCredentialCache(ctx context.Context) int {
+	if m.credCache == nil || len(m.config.credentials.CacheWarmup) == 0 {
+		return 0
+	}
+	if m.signingKeyRevoked() {
+		m.log.Notice("not warming up the credential cache with a revoked signing key")
+		return 0
+	}
+	signingKey, err := m.credentialTransport().PrivateKey()
+	if err != nil {
+		m.log.Errorf("unable to warm up the credential cache: %s", err)
+		return 0
+	}
+
+	var warmed int
+	for _, entry := range m.config.credentials.CacheWarmup {
+		req, uid, err := m.warmupRequest(entry, signingKey)
+		if err != nil {
+			m.log.Errorf("unable to warm up credential of user %q: %s", entry.User, err)
+			continue
+		}
+		if m.credCache.cache.Has(m.credCache.keyer.key(req)) {
+			continue
+		}
+
+		ownerCtx := context.WithValue(ctx, credentialOwnerKey, &uid)
+		if _, err := m.credCache.getSignedCredential(ownerCtx, m.log, req); err != nil {
+			m.log.Errorf("unable to warm up %s credential of user %q: %s", req.GetAuthFlavor(), entry.User, err)
+			continue
+		}
+		warmed++
+	}
+	return warmed
+}
+
+// keepCacheWarm warms up the credential cache, and warms it up again whenever
+// it is flushed, until the context is canceled.
+func (m *SecurityModule) keepCacheWarm(ctx context.Context) {
+	if m.credCache == nil || len(m.config.credentials.CacheWarmup) == 0 {
+		return
+	}
+
+	for {
+		if warmed := m.warmCredentialCache(ctx); warmed > 0 {
+			m.log.Infof("warmed up the credential cache with %d credentials", warmed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.credCache.flushed:
+		}
+	}
+}
@@ -0,0 +1,192 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/cache"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func TestAgent_SecurityModule_warmCredentialCache(t *testing.T) {
+	defer func(orig func(string) (uint32, uint32, error)) { lookupUserIDs = orig }(lookupUserIDs)
+	lookupUserIDs = func(name string) (uint32, uint32, error) {
+		switch name {
+		case "daos_mover", "1001":
+			return 1001, 2001, nil
+		case "root":
+			return 0, 0, nil
+		}
+		return 0, 0, errors.Errorf("unknown user %q", name)
+	}
+
+	for name, tc := range map[string]struct {
+		entries     []security.CacheWarmupEntry
+		machine     bool
+		disabled    map[auth.Flavor]error
+		signErr     error
+		expWarmed   int
+		expRequests []auth.Flavor
+	}{
+		"none": {},
+		"default flavor": {
+			entries:     []security.CacheWarmupEntry{{User: "daos_mover"}},
+			expWarmed:   1,
+			expRequests: []auth.Flavor{auth.Flavor_AUTH_SYS},
+		},
+		"same credential twice": {
+			entries:     []security.CacheWarmupEntry{{User: "daos_mover"}, {User: "1001", Flavor: "sys"}},
+			expWarmed:   1,
+			expRequests: []auth.Flavor{auth.Flavor_AUTH_SYS},
+		},
+		"machine": {
+			entries:     []security.CacheWarmupEntry{{User: "root", Flavor: "AUTH_MACHINE"}},
+			machine:     true,
+			expWarmed:   1,
+			expRequests: []auth.Flavor{auth.Flavor_AUTH_MACHINE},
+		},
+		"bad entries skipped": {
+			entries: []security.CacheWarmupEntry{
+				{User: "nobody"},
+				{User: "daos_mover", Flavor: "bogus"},
+				{User: "daos_mover", Flavor: "oauth2"},
+				{User: "daos_mover", Flavor: "machine"},
+				{User: "daos_mover"},
+			},
+			expWarmed:   1,
+			expRequests: []auth.Flavor{auth.Flavor_AUTH_SYS},
+		},
+		"disabled flavor": {
+			entries:  []security.CacheWarmupEntry{{User: "daos_mover"}},
+			disabled: map[auth.Flavor]error{auth.Flavor_AUTH_SYS: errors.New("self-test failed")},
+		},
+		"signing fails": {
+			entries:     []security.CacheWarmupEntry{{User: "daos_mover"}},
+			signErr:     errors.New("signing failed"),
+			expRequests: []auth.Flavor{auth.Flavor_AUTH_SYS},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var requests []auth.Flavor
+			cc := &credentialCache{
+				log:          log,
+				cache:        cache.NewShardedItemCache(log, credCacheShards),
+				credLifetime: time.Minute,
+				cacheMissFn: func(ctx context.Context, _ logging.Logger, req auth.CredentialRequest) (*auth.Credential, error) {
+					requests = append(requests, req.GetAuthFlavor())
+					if owner := credentialOwner(ctx); owner == nil {
+						return nil, errors.New("credential owner not set")
+					}
+					if tc.signErr != nil {
+						return nil, tc.signErr
+					}
+					return &auth.Credential{Token: &auth.Token{Flavor: req.GetAuthFlavor()}}, nil
+				},
+			}
+			creds := &security.CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheWarmup:     tc.entries,
+				MachineConfig:   security.MachineConfig{Enabled: tc.machine},
+			}
+			mod := &SecurityModule{
+				log:       log,
+				credCache: cc,
+				config: &securityConfig{
+					transport:   &security.TransportConfig{AllowInsecure: true},
+					credentials: creds,
+				},
+				disabledFlavors: tc.disabled,
+			}
+
+			test.AssertEqual(t, tc.expWarmed, mod.warmCredentialCache(test.Context(t)), "unexpected number warmed")
+			test.CmpAny(t, "requests", tc.expRequests, requests)
+			test.AssertEqual(t, tc.expWarmed, cc.cache.Len(), "unexpected number cached")
+
+			// Cached credentials are not requested again.
+			requests = nil
+			mod.warmCredentialCache(test.Context(t))
+			if tc.signErr == nil {
+				test.AssertEqual(t, 0, len(requests), "cached credentials requested again")
+			}
+		})
+	}
+}
+
+func TestAgent_SecurityModule_keepCacheWarm(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	defer func(orig func(string) (uint32, uint32, error)) { lookupUserIDs = orig }(lookupUserIDs)
+	lookupUserIDs = func(string) (uint32, uint32, error) {
+		return 1001, 2001, nil
+	}
+
+	var mutex sync.Mutex
+	var requests int
+	cc := &credentialCache{
+		log:          log,
+		cache:        cache.NewShardedItemCache(log, credCacheShards),
+		credLifetime: time.Minute,
+		flushed:      make(chan struct{}, 1),
+		cacheMissFn: func(context.Context, logging.Logger, auth.CredentialRequest) (*auth.Credential, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			requests++
+			return &auth.Credential{Token: &auth.Token{Flavor: auth.Flavor_AUTH_SYS}}, nil
+		},
+	}
+	mod := &SecurityModule{
+		log:       log,
+		credCache: cc,
+		config: &securityConfig{
+			transport: &security.TransportConfig{AllowInsecure: true},
+			credentials: &security.CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheWarmup:     []security.CacheWarmupEntry{{User: "daos_mover"}},
+			},
+		},
+	}
+	waitForRequests := func(expRequests int) {
+		t.Helper()
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			mutex.Lock()
+			done := requests >= expRequests
+			mutex.Unlock()
+			if done && cc.cache.Len() == 1 {
+				return
+			}
+		}
+		t.Fatalf("credential cache not warmed up after %d requests", expRequests)
+	}
+
+	ctx, cancel := context.WithCancel(test.Context(t))
+	done := make(chan struct{})
+	go func() {
+		mod.keepCacheWarm(ctx)
+		close(done)
+	}()
+
+	// The cache is warmed up at startup, and again once it is flushed.
+	waitForRequests(1)
+	test.AssertEqual(t, uint32(1), cc.purgeCredentials(), "unexpected purge count")
+	waitForRequests(2)
+
+	cancel()
+	<-done
+}
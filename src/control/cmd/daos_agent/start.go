@@ -25,6 +25,8 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/hardware/hwloc"
 	"github.com/daos-stack/daos/src/control/lib/systemd"
 	"github.com/daos-stack/daos/src/control/lib/telemetry/promexp"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
 )
 
 type ctxKey string
@@ -92,13 +94,19 @@ func (cmd *startCmd) Execute(_ []string) error {
 	procmon.startMonitoring(ctx, cmd.cfg.EvictOnStart)
 	cmd.Debugf("started process monitor: %s", time.Since(procmonStart))
 
+	slos := newIssuanceSLOs(cmd.Logger, cmd.cfg.CredentialConfig)
+	probes := newBackendProbes(cmd.Logger, cmd.cfg.CredentialConfig)
+	cacheStats := newCredentialCacheMetrics()
+	keyUsage := newKeyUsage(cmd.Logger, &cmd.cfg.CredentialConfig.KeyUsage)
+
 	var clientMetricSource *promexp.ClientSource
 	if cmd.cfg.TelemetryExportEnabled() {
 		if ctx, clientMetricSource, err = promexp.NewClientSource(ctx); err != nil {
 			return errors.Wrap(err, "unable to create client metrics source")
 		}
 		telemetryStart := time.Now()
-		shutdown, err := startPrometheusExporter(ctx, cmd, clientMetricSource, cmd.cfg)
+		shutdown, err := startPrometheusExporter(ctx, cmd, clientMetricSource, cmd.cfg, slos, probes, cacheStats,
+			keyUsage)
 		if err != nil {
 			return errors.Wrap(err, "unable to start prometheus exporter")
 		}
@@ -107,13 +115,114 @@ func (cmd *startCmd) Execute(_ []string) error {
 	}
 
 	drpcRegStart := time.Now()
+	if err := auth.LoadPlugins(cmd.Logger, cmd.cfg.CredentialConfig.FlavorPlugins); err != nil {
+		return err
+	}
+	verifierHash, err := security.ParseVerifierHash(cmd.cfg.CredentialConfig.VerifierHash)
+	if err != nil {
+		return errors.Wrap(err, "verifier_hash")
+	}
+	if err := auth.SetVerifierHash(verifierHash); err != nil {
+		return err
+	}
+	if err := auth.SetTokenSignerBackend(cmd.cfg.CredentialConfig.TokenSigner); err != nil {
+		return err
+	}
+	if err := auth.SetCredentialLifetimes(cmd.cfg.CredentialConfig.Lifetime, cmd.cfg.CredentialConfig.FlavorLifetimes); err != nil {
+		return err
+	}
+	// Credentials are bound to this agent's system, so that servers of other systems reject them.
+	auth.SetCredentialSystem(cmd.cfg.SystemName)
+	signingPool := security.NewSigningPool(cmd.cfg.CredentialConfig.SigningWorkers)
+	defer signingPool.Close()
+	auth.SetSigningPool(signingPool)
+	cmd.Debugf("credentials are signed by up to %d workers at once", signingPool.Workers())
+	auth.SetSignatureObserver(keyUsage.record)
+	go keyUsage.run(ctx)
+	// Macaroons and biscuits restricted to a system are checked against this agent's.
+	cmd.cfg.CredentialConfig.MacaroonConfig.SystemName = cmd.cfg.SystemName
+	cmd.cfg.CredentialConfig.BiscuitConfig.SystemName = cmd.cfg.SystemName
+	// The credential provider and webhook are told which system credentials are requested for.
+	cmd.cfg.CredentialConfig.ProviderConfig.SystemName = cmd.cfg.SystemName
+	cmd.cfg.CredentialConfig.WebhookConfig.SystemName = cmd.cfg.SystemName
 	secCfg := &securityConfig{
-		transport:   cmd.cfg.TransportConfig,
-		credentials: cmd.cfg.CredentialConfig,
-		infoCache:   cache,
-		sys:         cmd.cfg.SystemName,
+		transport:     cmd.cfg.TransportConfig,
+		signingKeys:   cmd.cfg.SigningKeys,
+		credentialKey: cmd.cfg.CredentialKey,
+		credentials:   cmd.cfg.CredentialConfig,
+		infoCache:     cache,
+		sys:           cmd.cfg.SystemName,
+		slos:          slos,
+		cacheStats:    cacheStats,
 	}
 	module := NewSecurityModule(cmd.Logger, secCfg)
+	if err := module.RunSelfTest(); err != nil {
+		return err
+	}
+	if path := cmd.cfg.CredentialConfig.PresignedCredentials; path != "" {
+		if err := module.loadPresignedCredentials(path); err != nil {
+			return errors.Wrap(err, "presigned_credentials")
+		}
+	}
+	if module.cosigner, err = auth.NewCosigner(cmd.cfg.CredentialConfig.CosigningConfig, cmd.cfg.SystemName); err != nil {
+		return errors.Wrap(err, "cosigning_config")
+	}
+	module.transportReloaded = func(tc *security.TransportConfig) {
+		cmd.ctlInvoker.SetConfig(newControlConfig(cmd.cfg, tc))
+	}
+	probes.start(ctx)
+	if keyID, err := module.signingKeyID(); err != nil {
+		cmd.Errorf("unable to identify credential signing key: %v", err)
+	} else if keyID != "" {
+		cmd.Infof("credential signing key ID: %s", keyID)
+	}
+	for _, key := range module.signingKeys {
+		if keyID, err := transportKeyID(key.transport()); err != nil {
+			cmd.Errorf("unable to identify credential signing key %s: %v", key, err)
+		} else {
+			cmd.Infof("credential signing key ID %s: %s", key, keyID)
+		}
+	}
+	if err := module.checkSigningKeyAlgorithms(); err != nil {
+		return err
+	}
+	if err := cmd.cfg.TransportConfig.WeakKeyPolicy.Check(cmd.Logger, module.signingTransports()...); err != nil {
+		return err
+	}
+
+	if cmd.cfg.CredentialConfig.AMConfig.RevocationPollInterval > 0 {
+		go auth.PollAMRevocations(ctx, cmd.Logger, cmd.cfg.CredentialConfig)
+	}
+
+	if module.validFlavors != nil {
+		go module.validFlavors.run(ctx)
+	}
+	if cmd.cfg.CredentialConfig.SigningKeyReloadInterval > 0 {
+		go newSigningKeyWatcher(module, cmd.cfg.CredentialConfig.SigningKeyReloadInterval).run(ctx)
+	}
+	if cmd.cfg.CredentialConfig.CredentialNonces {
+		module.nonces = newNonceSupply(cmd.Logger, cmd.ctlInvoker)
+		go module.nonces.run(ctx)
+	}
+	if cmd.cfg.CertRenewal != nil {
+		renewer, err := newCertRenewer(cmd.Logger, cmd.cfg.CertRenewal, module, cmd.ctlInvoker)
+		if err != nil {
+			return errors.Wrap(err, "cert_renewal")
+		}
+		go renewer.run(ctx)
+	}
+	if len(cmd.cfg.CredentialConfig.CacheWarmup) > 0 {
+		go module.keepCacheWarm(ctx)
+	}
+	if module.credCache != nil && cmd.cfg.CredentialConfig.CacheReapInterval > 0 {
+		go module.credCache.reapExpiredEvery(ctx, cmd.cfg.CredentialConfig.CacheReapInterval)
+	}
+	if module.credCache != nil && len(module.credCache.pinned) > 0 {
+		go module.credCache.keepPinnedFresh(ctx, cmd.Logger)
+	}
+	if module.credCache != nil && cmd.cfg.CredentialConfig.GroupWatch.Enabled() {
+		go newGroupWatcher(cmd.Logger, cmd.cfg.CredentialConfig.GroupWatch, module.credCache).run(ctx)
+	}
 
 	drpcServer.RegisterRPCModule(module)
 	mgmtMod := &mgmtModule{
@@ -126,6 +235,7 @@ func (cmd *startCmd) Execute(_ []string) error {
 		providerIdx:   cmd.cfg.ProviderIdx,
 		cliMetricsSrc: clientMetricSource,
 		tmCfg:         &cmd.cfg.Telemetry,
+		credCache:     module.credCache,
 	}
 	drpcServer.RegisterRPCModule(mgmtMod)
 	cmd.Debugf("registered dRPC modules: %s", time.Since(drpcRegStart))
@@ -157,7 +267,7 @@ func (cmd *startCmd) Execute(_ []string) error {
 	signals := make(chan os.Signal)
 	finish := make(chan struct{})
 
-	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGPIPE, syscall.SIGUSR1, syscall.SIGUSR2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGPIPE, syscall.SIGUSR1, syscall.SIGUSR2, syscall.SIGHUP)
 	// Anonymous goroutine to wait on the signals channel and tell the
 	// program to finish when it receives a signal. Since we notify on
 	// SIGINT and SIGTERM we should only catch these on a kill or ctrl+c
@@ -176,6 +286,11 @@ func (cmd *startCmd) Execute(_ []string) error {
 			case syscall.SIGUSR2:
 				cmd.Infof("Signal received. Caught %s; refreshing caches", sig)
 				mgmtMod.RefreshCache(ctx)
+			case syscall.SIGHUP:
+				cmd.Infof("Signal received. Caught %s; reloading certificate and signing key", sig)
+				if _, err := module.reloadSigningKey(); err != nil {
+					cmd.Errorf("unable to reload certificate and signing key: %s", err)
+				}
 			default:
 				shutdownRcvd = time.Now()
 				cmd.Infof("Signal received.  Caught %s; shutting down", sig)
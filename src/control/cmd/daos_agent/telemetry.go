@@ -12,11 +12,12 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/lib/telemetry/promexp"
 	"github.com/daos-stack/daos/src/control/logging"
 )
 
-func startPrometheusExporter(ctx context.Context, log logging.Logger, cs *promexp.ClientSource, cfg *Config) (func(), error) {
+func startPrometheusExporter(ctx context.Context, log logging.Logger, cs *promexp.ClientSource, cfg *Config, agentCollectors ...prometheus.Collector) (func(), error) {
 	expCfg := &promexp.ExporterConfig{
 		Port:  cfg.Telemetry.Port,
 		Title: "DAOS Client Telemetry",
@@ -29,6 +30,13 @@ func startPrometheusExporter(ctx context.Context, log logging.Logger, cs *promex
 			}
 			prometheus.MustRegister(c)
 
+			for _, ac := range agentCollectors {
+				if common.InterfaceIsNil(ac) {
+					continue
+				}
+				prometheus.MustRegister(ac)
+			}
+
 			return nil
 		},
 	}
@@ -206,6 +206,18 @@ func (bci *bridgeConnInvoker) InvokeUnaryRPC(ctx context.Context, uReq control.U
 		resp = control.MockMSResponse("", nil, &mgmtpb.SystemGetPropResp{})
 	case *control.GetAttachInfoReq:
 		resp = control.MockMSResponse("", nil, &mgmtpb.GetAttachInfoResp{})
+	case *control.SystemRevokeCredentialsReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemRevokeCredentialsResp{})
+	case *control.GetCredentialRevocationsReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.GetCredentialRevocationsResp{})
+	case *control.SystemEnrollAgentReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemEnrollAgentResp{})
+	case *control.GetAgentEnrollmentsReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.GetAgentEnrollmentsResp{})
+	case *control.SystemUpdateAuthFlavorsReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemUpdateAuthFlavorsResp{})
+	case *control.GetAuthFlavorsReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.GetAuthFlavorsResp{})
 	case *control.NetworkScanReq:
 		resp = &control.UnaryResponse{
 			Responses: []*control.HostResponse{
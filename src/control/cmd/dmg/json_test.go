@@ -112,6 +112,12 @@ func TestDmg_JsonOutput(t *testing.T) {
 				testArgs = append(testArgs, "foo:bar")
 			case "system del-attr":
 				testArgs = append(testArgs, "foo")
+			case "system revoke-credentials", "system unenroll-agent":
+				testArgs = append(testArgs, "--agent", "foo.com")
+			case "system enroll-agent":
+				testArgs = append(testArgs, "--agent", "foo.com", "--key-id", "key")
+			case "system update-auth-flavors":
+				testArgs = append(testArgs, "--add", "sys")
 			case "system exclude", "system clear-exclude", "system drain",
 				"system reintegrate":
 				testArgs = append(testArgs, "--ranks", "0")
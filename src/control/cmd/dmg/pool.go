@@ -999,8 +999,12 @@ func (cmd *poolUpdateACLCmd) Execute(args []string) error {
 		}
 		acl = aclFileResult
 	} else {
+		entry, err := control.EncodeACE(cmd.Entry)
+		if err != nil {
+			return err
+		}
 		acl = &control.AccessControlList{
-			Entries: []string{cmd.Entry},
+			Entries: []string{entry},
 		}
 	}
 
@@ -1034,9 +1038,14 @@ type poolDeleteACLCmd struct {
 
 // Execute is run when the PoolDeleteACLCmd subcommand is activated
 func (cmd *poolDeleteACLCmd) Execute(args []string) error {
+	principal, err := control.EncodePrincipal(cmd.Principal)
+	if err != nil {
+		return err
+	}
+
 	req := &control.PoolDeleteACLReq{
 		ID:        cmd.PoolID().String(),
-		Principal: cmd.Principal,
+		Principal: principal,
 	}
 
 	resp, err := control.PoolDeleteACL(cmd.MustLogCtx(), cmd.ctlInvoker, req)
@@ -10,6 +10,7 @@ package main
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/jessevdk/go-flags"
@@ -22,28 +23,37 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
 	"github.com/daos-stack/daos/src/control/lib/txtfmt"
 	"github.com/daos-stack/daos/src/control/lib/ui"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
 )
 
 var errNoRanks = errors.New("no ranks or hosts specified")
 
 // SystemCmd is the struct representing the top-level system subcommand.
 type SystemCmd struct {
-	LeaderQuery  leaderQueryCmd        `command:"leader-query" description:"Query for current Management Service leader"`
-	Query        systemQueryCmd        `command:"query" description:"Query DAOS system status"`
-	Stop         systemStopCmd         `command:"stop" description:"Perform controlled shutdown of DAOS system"`
-	Start        systemStartCmd        `command:"start" description:"Perform start of stopped DAOS system"`
-	Exclude      systemExcludeCmd      `command:"exclude" description:"Exclude ranks from DAOS system"`
-	ClearExclude systemClearExcludeCmd `command:"clear-exclude" description:"Clear excluded state for ranks"`
-	Drain        systemDrainCmd        `command:"drain" description:"Drain ranks or hosts from all relevant pools in DAOS system"`
-	Reintegrate  systemReintegrateCmd  `command:"reintegrate" alias:"reint" description:"Reintegrate ranks or hosts into all relevant pools in DAOS system"`
-	Erase        systemEraseCmd        `command:"erase" description:"Erase system metadata prior to reformat"`
-	ListPools    poolListCmd           `command:"list-pools" description:"List all pools in the DAOS system"`
-	Cleanup      systemCleanupCmd      `command:"cleanup" description:"Clean up all resources associated with the specified machine"`
-	SetAttr      systemSetAttrCmd      `command:"set-attr" description:"Set system attributes"`
-	GetAttr      systemGetAttrCmd      `command:"get-attr" description:"Get system attributes"`
-	DelAttr      systemDelAttrCmd      `command:"del-attr" description:"Delete system attributes"`
-	SetProp      systemSetPropCmd      `command:"set-prop" description:"Set system properties"`
-	GetProp      systemGetPropCmd      `command:"get-prop" description:"Get system properties"`
+	LeaderQuery   leaderQueryCmd             `command:"leader-query" description:"Query for current Management Service leader"`
+	Query         systemQueryCmd             `command:"query" description:"Query DAOS system status"`
+	Stop          systemStopCmd              `command:"stop" description:"Perform controlled shutdown of DAOS system"`
+	Start         systemStartCmd             `command:"start" description:"Perform start of stopped DAOS system"`
+	Exclude       systemExcludeCmd           `command:"exclude" description:"Exclude ranks from DAOS system"`
+	ClearExclude  systemClearExcludeCmd      `command:"clear-exclude" description:"Clear excluded state for ranks"`
+	Drain         systemDrainCmd             `command:"drain" description:"Drain ranks or hosts from all relevant pools in DAOS system"`
+	Reintegrate   systemReintegrateCmd       `command:"reintegrate" alias:"reint" description:"Reintegrate ranks or hosts into all relevant pools in DAOS system"`
+	Erase         systemEraseCmd             `command:"erase" description:"Erase system metadata prior to reformat"`
+	ListPools     poolListCmd                `command:"list-pools" description:"List all pools in the DAOS system"`
+	Cleanup       systemCleanupCmd           `command:"cleanup" description:"Clean up all resources associated with the specified machine"`
+	SetAttr       systemSetAttrCmd           `command:"set-attr" description:"Set system attributes"`
+	GetAttr       systemGetAttrCmd           `command:"get-attr" description:"Get system attributes"`
+	DelAttr       systemDelAttrCmd           `command:"del-attr" description:"Delete system attributes"`
+	SetProp       systemSetPropCmd           `command:"set-prop" description:"Set system properties"`
+	GetProp       systemGetPropCmd           `command:"get-prop" description:"Get system properties"`
+	RevokeCreds   systemRevokeCredsCmd       `command:"revoke-credentials" description:"Revoke credentials throughout the system"`
+	ListRevoked   systemListRevocationsCmd   `command:"list-revocations" description:"List credentials revoked throughout the system"`
+	EnrollAgent   systemEnrollAgentCmd       `command:"enroll-agent" description:"Enroll an agent host with the key it signs credentials with"`
+	Unenroll      systemUnenrollAgentCmd     `command:"unenroll-agent" description:"Unenroll an agent host"`
+	ListEnrolled  systemListEnrolledCmd      `command:"list-enrolled-agents" description:"List the agent hosts enrolled throughout the system"`
+	UpdateFlavors systemUpdateAuthFlavorsCmd `command:"update-auth-flavors" description:"Change the auth flavors allowed throughout the system"`
+	ListFlavors   systemListAuthFlavorsCmd   `command:"list-auth-flavors" description:"List the auth flavors allowed throughout the system"`
 }
 
 type baseCtlCmd struct {
@@ -692,3 +702,297 @@ func (cmd *systemGetPropCmd) Execute(_ []string) error {
 
 	return nil
 }
+
+// systemRevokeCredsCmd represents the command to revoke credentials
+// throughout the system.
+type systemRevokeCredsCmd struct {
+	baseCtlCmd
+	KeyIDs     []string `long:"key-id" short:"k" description:"ID of an agent signing key whose credentials to revoke (may be repeated)"`
+	CredHashes []string `long:"cred-hash" short:"c" description:"Hash of a credential to revoke, as logged by the server which accepted it (may be repeated)"`
+	Agents     []string `long:"agent" short:"a" description:"Host of an agent whose credentials to revoke (may be repeated)"`
+	Reason     string   `long:"reason" short:"r" description:"Reason for the revocation, recorded in the audit log"`
+}
+
+// Execute is run when systemRevokeCredsCmd subcommand is activated.
+func (cmd *systemRevokeCredsCmd) Execute(_ []string) error {
+	req := &control.SystemRevokeCredentialsReq{
+		KeyIDs:     cmd.KeyIDs,
+		CredHashes: cmd.CredHashes,
+		AgentHosts: cmd.Agents,
+		Reason:     cmd.Reason,
+	}
+
+	resp, err := control.SystemRevokeCredentials(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "system revoke-credentials failed")
+	}
+	cmd.Infof("system revoke-credentials succeeded: %d revocations added, revocation list is at version %d",
+		resp.Added, resp.Version)
+
+	return nil
+}
+
+// systemListRevocationsCmd represents the command to list the credentials
+// revoked throughout the system.
+type systemListRevocationsCmd struct {
+	baseCtlCmd
+}
+
+func prettyPrintRevocations(out io.Writer, resp *control.GetCredentialRevocationsResp) {
+	if len(resp.KeyIDs)+len(resp.CredHashes)+len(resp.AgentHosts) == 0 {
+		fmt.Fprintln(out, "No credentials revoked.")
+		return
+	}
+
+	fmt.Fprintf(out, "Revocation list version %d\n", resp.Version)
+	typeTitle := "Revoked"
+	valueTitle := "Value"
+	table := []txtfmt.TableRow{}
+	for _, set := range []struct {
+		name   string
+		values []string
+	}{
+		{"key", resp.KeyIDs},
+		{"credential", resp.CredHashes},
+		{"agent", resp.AgentHosts},
+	} {
+		for _, value := range set.values {
+			table = append(table, txtfmt.TableRow{
+				typeTitle:  set.name,
+				valueTitle: value,
+			})
+		}
+	}
+
+	tf := txtfmt.NewTableFormatter(typeTitle, valueTitle)
+	tf.InitWriter(out)
+	tf.Format(table)
+}
+
+// Execute is run when systemListRevocationsCmd subcommand is activated.
+func (cmd *systemListRevocationsCmd) Execute(_ []string) error {
+	req := new(control.GetCredentialRevocationsReq)
+
+	resp, err := control.GetCredentialRevocations(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "system list-revocations failed")
+	}
+
+	var bld strings.Builder
+	prettyPrintRevocations(&bld, resp)
+	cmd.Infof("%s", bld.String())
+
+	return nil
+}
+
+// systemEnrollAgentCmd represents the command to enroll an agent host with
+// the key it signs credentials with.
+type systemEnrollAgentCmd struct {
+	baseCtlCmd
+	Agent    string `long:"agent" short:"a" description:"Host of the agent, as recorded in the credentials it signs"`
+	KeyID    string `long:"key-id" short:"k" description:"ID of the agent's signing key"`
+	CertPath string `long:"cert" short:"c" description:"Certificate of the agent's signing key, instead of its ID"`
+}
+
+// Execute is run when systemEnrollAgentCmd subcommand is activated.
+func (cmd *systemEnrollAgentCmd) Execute(_ []string) error {
+	req := &control.SystemEnrollAgentReq{
+		AgentHost: cmd.Agent,
+		KeyID:     cmd.KeyID,
+	}
+	if cmd.CertPath != "" {
+		if cmd.KeyID != "" {
+			return errors.New("--key-id and --cert may not be used together")
+		}
+		cert, err := security.LoadCertificate(cmd.CertPath)
+		if err != nil {
+			return errors.Wrapf(err, "loading agent certificate %s", cmd.CertPath)
+		}
+		if req.KeyID, err = security.PublicKeyID(cert.PublicKey); err != nil {
+			return err
+		}
+	}
+
+	resp, err := control.SystemEnrollAgent(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "system enroll-agent failed")
+	}
+	if !resp.Changed {
+		cmd.Infof("agent host %s is already enrolled with key %s", req.AgentHost, req.KeyID)
+		return nil
+	}
+	cmd.Infof("system enroll-agent succeeded: agent host %s enrolled with key %s, enrollments are at version %d",
+		req.AgentHost, req.KeyID, resp.Version)
+
+	return nil
+}
+
+// systemUnenrollAgentCmd represents the command to unenroll an agent host.
+type systemUnenrollAgentCmd struct {
+	baseCtlCmd
+	Agent string `long:"agent" short:"a" description:"Host of the agent to unenroll"`
+}
+
+// Execute is run when systemUnenrollAgentCmd subcommand is activated.
+func (cmd *systemUnenrollAgentCmd) Execute(_ []string) error {
+	req := &control.SystemEnrollAgentReq{
+		AgentHost: cmd.Agent,
+		Unenroll:  true,
+	}
+
+	resp, err := control.SystemEnrollAgent(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "system unenroll-agent failed")
+	}
+	if !resp.Changed {
+		cmd.Infof("agent host %s is not enrolled", req.AgentHost)
+		return nil
+	}
+	cmd.Infof("system unenroll-agent succeeded: agent host %s unenrolled, enrollments are at version %d",
+		req.AgentHost, resp.Version)
+
+	return nil
+}
+
+// systemListEnrolledCmd represents the command to list the agent hosts
+// enrolled throughout the system.
+type systemListEnrolledCmd struct {
+	baseCtlCmd
+}
+
+func prettyPrintEnrollments(out io.Writer, resp *control.GetAgentEnrollmentsResp) {
+	if len(resp.KeyIDs) == 0 {
+		fmt.Fprintln(out, "No agent hosts enrolled.")
+		return
+	}
+
+	fmt.Fprintf(out, "Agent enrollments version %d\n", resp.Version)
+	hostTitle := "Agent Host"
+	keyTitle := "Key ID"
+	hosts := make([]string, 0, len(resp.KeyIDs))
+	for host := range resp.KeyIDs {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	table := []txtfmt.TableRow{}
+	for _, host := range hosts {
+		table = append(table, txtfmt.TableRow{
+			hostTitle: host,
+			keyTitle:  resp.KeyIDs[host],
+		})
+	}
+
+	tf := txtfmt.NewTableFormatter(hostTitle, keyTitle)
+	tf.InitWriter(out)
+	tf.Format(table)
+}
+
+// Execute is run when systemListEnrolledCmd subcommand is activated.
+func (cmd *systemListEnrolledCmd) Execute(_ []string) error {
+	req := new(control.GetAgentEnrollmentsReq)
+
+	resp, err := control.GetAgentEnrollments(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "system list-enrolled-agents failed")
+	}
+
+	var bld strings.Builder
+	prettyPrintEnrollments(&bld, resp)
+	cmd.Infof("%s", bld.String())
+
+	return nil
+}
+
+// systemUpdateAuthFlavorsCmd represents the command to change the auth
+// flavors allowed throughout the system.
+type systemUpdateAuthFlavorsCmd struct {
+	baseCtlCmd
+	Add    []string `long:"add" short:"a" description:"Auth flavor to allow, by name or plugin flavor number (may be repeated)"`
+	Remove []string `long:"remove" short:"r" description:"Auth flavor to no longer allow (may be repeated)"`
+}
+
+func formatFlavors(flavors []auth.Flavor) string {
+	names := make([]string, len(flavors))
+	for i, flavor := range flavors {
+		names[i] = flavor.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// Execute is run when systemUpdateAuthFlavorsCmd subcommand is activated.
+func (cmd *systemUpdateAuthFlavorsCmd) Execute(_ []string) error {
+	req := new(control.SystemUpdateAuthFlavorsReq)
+	var err error
+	if req.Add, err = auth.ParseValidAuthFlavors(cmd.Add); err != nil {
+		return err
+	}
+	if req.Remove, err = auth.ParseValidAuthFlavors(cmd.Remove); err != nil {
+		return err
+	}
+
+	resp, err := control.SystemUpdateAuthFlavors(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "system update-auth-flavors failed")
+	}
+	if !resp.Changed {
+		cmd.Infof("auth flavors unchanged: %s", formatFlavors(resp.ValidAuthFlavors))
+		return nil
+	}
+	cmd.Infof("system update-auth-flavors succeeded: auth flavors %s are allowed at version %d",
+		formatFlavors(resp.ValidAuthFlavors), resp.Version)
+
+	return nil
+}
+
+// systemListAuthFlavorsCmd represents the command to list the auth flavors
+// allowed throughout the system.
+type systemListAuthFlavorsCmd struct {
+	baseCtlCmd
+}
+
+// Execute is run when systemListAuthFlavorsCmd subcommand is activated.
+func (cmd *systemListAuthFlavorsCmd) Execute(_ []string) error {
+	req := new(control.GetAuthFlavorsReq)
+
+	resp, err := control.GetAuthFlavors(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "system list-auth-flavors failed")
+	}
+
+	if resp.Version == 0 {
+		cmd.Infof("Auth flavors (as configured): %s", formatFlavors(resp.ValidAuthFlavors))
+		return nil
+	}
+	cmd.Infof("Auth flavors version %d: %s", resp.Version, formatFlavors(resp.ValidAuthFlavors))
+
+	return nil
+}
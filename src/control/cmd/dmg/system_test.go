@@ -22,6 +22,7 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/hostlist"
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
 	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
 	"github.com/daos-stack/daos/src/control/system"
 )
 
@@ -453,6 +454,112 @@ func TestDmg_SystemCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"system revoke-credentials",
+			"system revoke-credentials --key-id key1 -k key2 --cred-hash hash --agent host1 --reason leaked",
+			strings.Join([]string{
+				printRequest(t, &control.SystemRevokeCredentialsReq{
+					KeyIDs:     []string{"key1", "key2"},
+					CredHashes: []string{"hash"},
+					AgentHosts: []string{"host1"},
+					Reason:     "leaked",
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system revoke-credentials nothing to revoke",
+			"system revoke-credentials --reason leaked",
+			"",
+			errors.New("no credentials to revoke"),
+		},
+		{
+			"system list-revocations",
+			"system list-revocations",
+			strings.Join([]string{
+				printRequest(t, &control.GetCredentialRevocationsReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system enroll-agent",
+			"system enroll-agent --agent host1 --key-id key1",
+			strings.Join([]string{
+				printRequest(t, &control.SystemEnrollAgentReq{
+					AgentHost: "host1",
+					KeyID:     "key1",
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system enroll-agent no key",
+			"system enroll-agent --agent host1",
+			"",
+			errors.New("no agent key specified"),
+		},
+		{
+			"system enroll-agent key and cert",
+			"system enroll-agent --agent host1 --key-id key1 --cert agent.crt",
+			"",
+			errors.New("may not be used together"),
+		},
+		{
+			"system unenroll-agent",
+			"system unenroll-agent --agent host1",
+			strings.Join([]string{
+				printRequest(t, &control.SystemEnrollAgentReq{
+					AgentHost: "host1",
+					Unenroll:  true,
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system unenroll-agent no host",
+			"system unenroll-agent",
+			"",
+			errors.New("no agent host specified"),
+		},
+		{
+			"system list-enrolled-agents",
+			"system list-enrolled-agents",
+			strings.Join([]string{
+				printRequest(t, &control.GetAgentEnrollmentsReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system update-auth-flavors",
+			"system update-auth-flavors --add totp -a 1024 --remove AUTH_SYS",
+			strings.Join([]string{
+				printRequest(t, &control.SystemUpdateAuthFlavorsReq{
+					Add:    []auth.Flavor{auth.Flavor_AUTH_TOTP, auth.MinPluginFlavor},
+					Remove: []auth.Flavor{auth.Flavor_AUTH_SYS},
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system update-auth-flavors unknown flavor",
+			"system update-auth-flavors --add quack",
+			"",
+			errors.New("not recognized"),
+		},
+		{
+			"system update-auth-flavors nothing to update",
+			"system update-auth-flavors",
+			"",
+			errors.New("no auth flavors to add or remove"),
+		},
+		{
+			"system list-auth-flavors",
+			"system list-auth-flavors",
+			strings.Join([]string{
+				printRequest(t, &control.GetAuthFlavorsReq{}),
+			}, " "),
+			nil,
+		},
 		{
 			"Non-existent subcommand",
 			"system quack",
@@ -42,7 +42,7 @@ var file_mgmt_mgmt_proto_rawDesc = []byte{
 	0x11, 0x6d, 0x67, 0x6d, 0x74, 0x2f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x1a, 0x0d, 0x63, 0x68, 0x6b, 0x2f, 0x63, 0x68, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x1a, 0x10, 0x63, 0x68, 0x6b, 0x2f, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x32, 0xc2, 0x15, 0x0a, 0x07, 0x4d, 0x67, 0x6d, 0x74, 0x53, 0x76, 0x63, 0x12,
+	0x6f, 0x74, 0x6f, 0x32, 0xef, 0x1a, 0x0a, 0x07, 0x4d, 0x67, 0x6d, 0x74, 0x53, 0x76, 0x63, 0x12,
 	0x27, 0x0a, 0x04, 0x4a, 0x6f, 0x69, 0x6e, 0x12, 0x0d, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x4a,
 	0x6f, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x4a, 0x6f,
 	0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0c, 0x43, 0x6c, 0x75, 0x73,
@@ -204,101 +204,160 @@ var file_mgmt_mgmt_proto_rawDesc = []byte{
 	0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f,
 	0x70, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74,
 	0x65, 0x6d, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12,
-	0x37, 0x0a, 0x11, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65,
-	0x70, 0x6f, 0x72, 0x74, 0x12, 0x10, 0x2e, 0x63, 0x68, 0x6b, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61,
-	0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x14, 0x46, 0x61, 0x75, 0x6c,
-	0x74, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x46, 0x61, 0x75, 0x6c, 0x74,
-	0x12, 0x0a, 0x2e, 0x63, 0x68, 0x6b, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x1a, 0x0e, 0x2e, 0x6d,
-	0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x38,
-	0x0a, 0x18, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x4d, 0x67, 0x6d,
-	0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x0a, 0x2e, 0x63, 0x68, 0x6b,
-	0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61,
-	0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63,
-	0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72,
-	0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
-	0x6d, 0x67, 0x6d, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x42, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x65, 0x72, 0x74,
+	0x12, 0x16, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x41, 0x67, 0x65, 0x6e,
+	0x74, 0x43, 0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e,
+	0x53, 0x69, 0x67, 0x6e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x65, 0x72, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1b, 0x2e, 0x6d, 0x67, 0x6d, 0x74,
+	0x2e, 0x47, 0x65, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x4e, 0x6f,
+	0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x1c, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x4e, 0x6f, 0x6e, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x17, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x73, 0x12, 0x20, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52,
+	0x65, 0x76, 0x6f, 0x6b, 0x65, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73,
+	0x52, 0x65, 0x71, 0x1a, 0x21, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x43,
+	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x21, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x43,
+	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x22, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x47,
+	0x65, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x76, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x4e, 0x0a,
+	0x11, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x41, 0x67, 0x65,
+	0x6e, 0x74, 0x12, 0x1a, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x1b,
+	0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x6e, 0x72, 0x6f,
+	0x6c, 0x6c, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x54, 0x0a,
+	0x13, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x41,
+	0x67, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x1a, 0x1d, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65,
+	0x6e, 0x74, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x17, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x73, 0x12, 0x20,
+	0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x1a, 0x21, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x68,
+	0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x73, 0x12, 0x17, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x47,
+	0x65, 0x74, 0x41, 0x75, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x1a, 0x18, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x68, 0x46,
+	0x6c, 0x61, 0x76, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x11,
+	0x46, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x12, 0x10, 0x2e, 0x63, 0x68, 0x6b, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x14, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e,
+	0x6a, 0x65, 0x63, 0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x0a, 0x2e,
+	0x63, 0x68, 0x6b, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74,
+	0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x18, 0x46,
+	0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x4d, 0x67, 0x6d, 0x74, 0x50, 0x6f,
+	0x6f, 0x6c, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x0a, 0x2e, 0x63, 0x68, 0x6b, 0x2e, 0x46, 0x61,
+	0x75, 0x6c, 0x74, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x00, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64,
+	0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x67, 0x6d,
+	0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_mgmt_mgmt_proto_goTypes = []interface{}{
-	(*JoinReq)(nil),                 // 0: mgmt.JoinReq
-	(*shared.ClusterEventReq)(nil),  // 1: shared.ClusterEventReq
-	(*LeaderQueryReq)(nil),          // 2: mgmt.LeaderQueryReq
-	(*PoolCreateReq)(nil),           // 3: mgmt.PoolCreateReq
-	(*PoolDestroyReq)(nil),          // 4: mgmt.PoolDestroyReq
-	(*PoolEvictReq)(nil),            // 5: mgmt.PoolEvictReq
-	(*PoolExcludeReq)(nil),          // 6: mgmt.PoolExcludeReq
-	(*PoolDrainReq)(nil),            // 7: mgmt.PoolDrainReq
-	(*PoolExtendReq)(nil),           // 8: mgmt.PoolExtendReq
-	(*PoolReintReq)(nil),            // 9: mgmt.PoolReintReq
-	(*PoolQueryReq)(nil),            // 10: mgmt.PoolQueryReq
-	(*PoolQueryTargetReq)(nil),      // 11: mgmt.PoolQueryTargetReq
-	(*PoolSetPropReq)(nil),          // 12: mgmt.PoolSetPropReq
-	(*PoolGetPropReq)(nil),          // 13: mgmt.PoolGetPropReq
-	(*GetACLReq)(nil),               // 14: mgmt.GetACLReq
-	(*ModifyACLReq)(nil),            // 15: mgmt.ModifyACLReq
-	(*DeleteACLReq)(nil),            // 16: mgmt.DeleteACLReq
-	(*GetAttachInfoReq)(nil),        // 17: mgmt.GetAttachInfoReq
-	(*ListPoolsReq)(nil),            // 18: mgmt.ListPoolsReq
-	(*ListContReq)(nil),             // 19: mgmt.ListContReq
-	(*ContSetOwnerReq)(nil),         // 20: mgmt.ContSetOwnerReq
-	(*SystemQueryReq)(nil),          // 21: mgmt.SystemQueryReq
-	(*SystemStopReq)(nil),           // 22: mgmt.SystemStopReq
-	(*SystemStartReq)(nil),          // 23: mgmt.SystemStartReq
-	(*SystemExcludeReq)(nil),        // 24: mgmt.SystemExcludeReq
-	(*SystemDrainReq)(nil),          // 25: mgmt.SystemDrainReq
-	(*SystemEraseReq)(nil),          // 26: mgmt.SystemEraseReq
-	(*SystemCleanupReq)(nil),        // 27: mgmt.SystemCleanupReq
-	(*CheckEnableReq)(nil),          // 28: mgmt.CheckEnableReq
-	(*CheckDisableReq)(nil),         // 29: mgmt.CheckDisableReq
-	(*CheckStartReq)(nil),           // 30: mgmt.CheckStartReq
-	(*CheckStopReq)(nil),            // 31: mgmt.CheckStopReq
-	(*CheckQueryReq)(nil),           // 32: mgmt.CheckQueryReq
-	(*CheckSetPolicyReq)(nil),       // 33: mgmt.CheckSetPolicyReq
-	(*CheckGetPolicyReq)(nil),       // 34: mgmt.CheckGetPolicyReq
-	(*CheckActReq)(nil),             // 35: mgmt.CheckActReq
-	(*PoolUpgradeReq)(nil),          // 36: mgmt.PoolUpgradeReq
-	(*SystemSetAttrReq)(nil),        // 37: mgmt.SystemSetAttrReq
-	(*SystemGetAttrReq)(nil),        // 38: mgmt.SystemGetAttrReq
-	(*SystemSetPropReq)(nil),        // 39: mgmt.SystemSetPropReq
-	(*SystemGetPropReq)(nil),        // 40: mgmt.SystemGetPropReq
-	(*chk.CheckReport)(nil),         // 41: chk.CheckReport
-	(*chk.Fault)(nil),               // 42: chk.Fault
-	(*JoinResp)(nil),                // 43: mgmt.JoinResp
-	(*shared.ClusterEventResp)(nil), // 44: shared.ClusterEventResp
-	(*LeaderQueryResp)(nil),         // 45: mgmt.LeaderQueryResp
-	(*PoolCreateResp)(nil),          // 46: mgmt.PoolCreateResp
-	(*PoolDestroyResp)(nil),         // 47: mgmt.PoolDestroyResp
-	(*PoolEvictResp)(nil),           // 48: mgmt.PoolEvictResp
-	(*PoolExcludeResp)(nil),         // 49: mgmt.PoolExcludeResp
-	(*PoolDrainResp)(nil),           // 50: mgmt.PoolDrainResp
-	(*PoolExtendResp)(nil),          // 51: mgmt.PoolExtendResp
-	(*PoolReintResp)(nil),           // 52: mgmt.PoolReintResp
-	(*PoolQueryResp)(nil),           // 53: mgmt.PoolQueryResp
-	(*PoolQueryTargetResp)(nil),     // 54: mgmt.PoolQueryTargetResp
-	(*PoolSetPropResp)(nil),         // 55: mgmt.PoolSetPropResp
-	(*PoolGetPropResp)(nil),         // 56: mgmt.PoolGetPropResp
-	(*ACLResp)(nil),                 // 57: mgmt.ACLResp
-	(*GetAttachInfoResp)(nil),       // 58: mgmt.GetAttachInfoResp
-	(*ListPoolsResp)(nil),           // 59: mgmt.ListPoolsResp
-	(*ListContResp)(nil),            // 60: mgmt.ListContResp
-	(*DaosResp)(nil),                // 61: mgmt.DaosResp
-	(*SystemQueryResp)(nil),         // 62: mgmt.SystemQueryResp
-	(*SystemStopResp)(nil),          // 63: mgmt.SystemStopResp
-	(*SystemStartResp)(nil),         // 64: mgmt.SystemStartResp
-	(*SystemExcludeResp)(nil),       // 65: mgmt.SystemExcludeResp
-	(*SystemDrainResp)(nil),         // 66: mgmt.SystemDrainResp
-	(*SystemEraseResp)(nil),         // 67: mgmt.SystemEraseResp
-	(*SystemCleanupResp)(nil),       // 68: mgmt.SystemCleanupResp
-	(*CheckStartResp)(nil),          // 69: mgmt.CheckStartResp
-	(*CheckStopResp)(nil),           // 70: mgmt.CheckStopResp
-	(*CheckQueryResp)(nil),          // 71: mgmt.CheckQueryResp
-	(*CheckGetPolicyResp)(nil),      // 72: mgmt.CheckGetPolicyResp
-	(*CheckActResp)(nil),            // 73: mgmt.CheckActResp
-	(*PoolUpgradeResp)(nil),         // 74: mgmt.PoolUpgradeResp
-	(*SystemGetAttrResp)(nil),       // 75: mgmt.SystemGetAttrResp
-	(*SystemGetPropResp)(nil),       // 76: mgmt.SystemGetPropResp
+	(*JoinReq)(nil),                      // 0: mgmt.JoinReq
+	(*shared.ClusterEventReq)(nil),       // 1: shared.ClusterEventReq
+	(*LeaderQueryReq)(nil),               // 2: mgmt.LeaderQueryReq
+	(*PoolCreateReq)(nil),                // 3: mgmt.PoolCreateReq
+	(*PoolDestroyReq)(nil),               // 4: mgmt.PoolDestroyReq
+	(*PoolEvictReq)(nil),                 // 5: mgmt.PoolEvictReq
+	(*PoolExcludeReq)(nil),               // 6: mgmt.PoolExcludeReq
+	(*PoolDrainReq)(nil),                 // 7: mgmt.PoolDrainReq
+	(*PoolExtendReq)(nil),                // 8: mgmt.PoolExtendReq
+	(*PoolReintReq)(nil),                 // 9: mgmt.PoolReintReq
+	(*PoolQueryReq)(nil),                 // 10: mgmt.PoolQueryReq
+	(*PoolQueryTargetReq)(nil),           // 11: mgmt.PoolQueryTargetReq
+	(*PoolSetPropReq)(nil),               // 12: mgmt.PoolSetPropReq
+	(*PoolGetPropReq)(nil),               // 13: mgmt.PoolGetPropReq
+	(*GetACLReq)(nil),                    // 14: mgmt.GetACLReq
+	(*ModifyACLReq)(nil),                 // 15: mgmt.ModifyACLReq
+	(*DeleteACLReq)(nil),                 // 16: mgmt.DeleteACLReq
+	(*GetAttachInfoReq)(nil),             // 17: mgmt.GetAttachInfoReq
+	(*ListPoolsReq)(nil),                 // 18: mgmt.ListPoolsReq
+	(*ListContReq)(nil),                  // 19: mgmt.ListContReq
+	(*ContSetOwnerReq)(nil),              // 20: mgmt.ContSetOwnerReq
+	(*SystemQueryReq)(nil),               // 21: mgmt.SystemQueryReq
+	(*SystemStopReq)(nil),                // 22: mgmt.SystemStopReq
+	(*SystemStartReq)(nil),               // 23: mgmt.SystemStartReq
+	(*SystemExcludeReq)(nil),             // 24: mgmt.SystemExcludeReq
+	(*SystemDrainReq)(nil),               // 25: mgmt.SystemDrainReq
+	(*SystemEraseReq)(nil),               // 26: mgmt.SystemEraseReq
+	(*SystemCleanupReq)(nil),             // 27: mgmt.SystemCleanupReq
+	(*CheckEnableReq)(nil),               // 28: mgmt.CheckEnableReq
+	(*CheckDisableReq)(nil),              // 29: mgmt.CheckDisableReq
+	(*CheckStartReq)(nil),                // 30: mgmt.CheckStartReq
+	(*CheckStopReq)(nil),                 // 31: mgmt.CheckStopReq
+	(*CheckQueryReq)(nil),                // 32: mgmt.CheckQueryReq
+	(*CheckSetPolicyReq)(nil),            // 33: mgmt.CheckSetPolicyReq
+	(*CheckGetPolicyReq)(nil),            // 34: mgmt.CheckGetPolicyReq
+	(*CheckActReq)(nil),                  // 35: mgmt.CheckActReq
+	(*PoolUpgradeReq)(nil),               // 36: mgmt.PoolUpgradeReq
+	(*SystemSetAttrReq)(nil),             // 37: mgmt.SystemSetAttrReq
+	(*SystemGetAttrReq)(nil),             // 38: mgmt.SystemGetAttrReq
+	(*SystemSetPropReq)(nil),             // 39: mgmt.SystemSetPropReq
+	(*SystemGetPropReq)(nil),             // 40: mgmt.SystemGetPropReq
+	(*SignAgentCertReq)(nil),             // 41: mgmt.SignAgentCertReq
+	(*GetCredentialNonceReq)(nil),        // 42: mgmt.GetCredentialNonceReq
+	(*SystemRevokeCredentialsReq)(nil),   // 43: mgmt.SystemRevokeCredentialsReq
+	(*GetCredentialRevocationsReq)(nil),  // 44: mgmt.GetCredentialRevocationsReq
+	(*SystemEnrollAgentReq)(nil),         // 45: mgmt.SystemEnrollAgentReq
+	(*GetAgentEnrollmentsReq)(nil),       // 46: mgmt.GetAgentEnrollmentsReq
+	(*SystemUpdateAuthFlavorsReq)(nil),   // 47: mgmt.SystemUpdateAuthFlavorsReq
+	(*GetAuthFlavorsReq)(nil),            // 48: mgmt.GetAuthFlavorsReq
+	(*chk.CheckReport)(nil),              // 49: chk.CheckReport
+	(*chk.Fault)(nil),                    // 50: chk.Fault
+	(*JoinResp)(nil),                     // 51: mgmt.JoinResp
+	(*shared.ClusterEventResp)(nil),      // 52: shared.ClusterEventResp
+	(*LeaderQueryResp)(nil),              // 53: mgmt.LeaderQueryResp
+	(*PoolCreateResp)(nil),               // 54: mgmt.PoolCreateResp
+	(*PoolDestroyResp)(nil),              // 55: mgmt.PoolDestroyResp
+	(*PoolEvictResp)(nil),                // 56: mgmt.PoolEvictResp
+	(*PoolExcludeResp)(nil),              // 57: mgmt.PoolExcludeResp
+	(*PoolDrainResp)(nil),                // 58: mgmt.PoolDrainResp
+	(*PoolExtendResp)(nil),               // 59: mgmt.PoolExtendResp
+	(*PoolReintResp)(nil),                // 60: mgmt.PoolReintResp
+	(*PoolQueryResp)(nil),                // 61: mgmt.PoolQueryResp
+	(*PoolQueryTargetResp)(nil),          // 62: mgmt.PoolQueryTargetResp
+	(*PoolSetPropResp)(nil),              // 63: mgmt.PoolSetPropResp
+	(*PoolGetPropResp)(nil),              // 64: mgmt.PoolGetPropResp
+	(*ACLResp)(nil),                      // 65: mgmt.ACLResp
+	(*GetAttachInfoResp)(nil),            // 66: mgmt.GetAttachInfoResp
+	(*ListPoolsResp)(nil),                // 67: mgmt.ListPoolsResp
+	(*ListContResp)(nil),                 // 68: mgmt.ListContResp
+	(*DaosResp)(nil),                     // 69: mgmt.DaosResp
+	(*SystemQueryResp)(nil),              // 70: mgmt.SystemQueryResp
+	(*SystemStopResp)(nil),               // 71: mgmt.SystemStopResp
+	(*SystemStartResp)(nil),              // 72: mgmt.SystemStartResp
+	(*SystemExcludeResp)(nil),            // 73: mgmt.SystemExcludeResp
+	(*SystemDrainResp)(nil),              // 74: mgmt.SystemDrainResp
+	(*SystemEraseResp)(nil),              // 75: mgmt.SystemEraseResp
+	(*SystemCleanupResp)(nil),            // 76: mgmt.SystemCleanupResp
+	(*CheckStartResp)(nil),               // 77: mgmt.CheckStartResp
+	(*CheckStopResp)(nil),                // 78: mgmt.CheckStopResp
+	(*CheckQueryResp)(nil),               // 79: mgmt.CheckQueryResp
+	(*CheckGetPolicyResp)(nil),           // 80: mgmt.CheckGetPolicyResp
+	(*CheckActResp)(nil),                 // 81: mgmt.CheckActResp
+	(*PoolUpgradeResp)(nil),              // 82: mgmt.PoolUpgradeResp
+	(*SystemGetAttrResp)(nil),            // 83: mgmt.SystemGetAttrResp
+	(*SystemGetPropResp)(nil),            // 84: mgmt.SystemGetPropResp
+	(*SignAgentCertResp)(nil),            // 85: mgmt.SignAgentCertResp
+	(*GetCredentialNonceResp)(nil),       // 86: mgmt.GetCredentialNonceResp
+	(*SystemRevokeCredentialsResp)(nil),  // 87: mgmt.SystemRevokeCredentialsResp
+	(*GetCredentialRevocationsResp)(nil), // 88: mgmt.GetCredentialRevocationsResp
+	(*SystemEnrollAgentResp)(nil),        // 89: mgmt.SystemEnrollAgentResp
+	(*GetAgentEnrollmentsResp)(nil),      // 90: mgmt.GetAgentEnrollmentsResp
+	(*SystemUpdateAuthFlavorsResp)(nil),  // 91: mgmt.SystemUpdateAuthFlavorsResp
+	(*GetAuthFlavorsResp)(nil),           // 92: mgmt.GetAuthFlavorsResp
 }
 var file_mgmt_mgmt_proto_depIdxs = []int32{
 	0,  // 0: mgmt.MgmtSvc.Join:input_type -> mgmt.JoinReq
@@ -343,56 +402,72 @@ var file_mgmt_mgmt_proto_depIdxs = []int32{
 	38, // 39: mgmt.MgmtSvc.SystemGetAttr:input_type -> mgmt.SystemGetAttrReq
 	39, // 40: mgmt.MgmtSvc.SystemSetProp:input_type -> mgmt.SystemSetPropReq
 	40, // 41: mgmt.MgmtSvc.SystemGetProp:input_type -> mgmt.SystemGetPropReq
-	41, // 42: mgmt.MgmtSvc.FaultInjectReport:input_type -> chk.CheckReport
-	42, // 43: mgmt.MgmtSvc.FaultInjectPoolFault:input_type -> chk.Fault
-	42, // 44: mgmt.MgmtSvc.FaultInjectMgmtPoolFault:input_type -> chk.Fault
-	43, // 45: mgmt.MgmtSvc.Join:output_type -> mgmt.JoinResp
-	44, // 46: mgmt.MgmtSvc.ClusterEvent:output_type -> shared.ClusterEventResp
-	45, // 47: mgmt.MgmtSvc.LeaderQuery:output_type -> mgmt.LeaderQueryResp
-	46, // 48: mgmt.MgmtSvc.PoolCreate:output_type -> mgmt.PoolCreateResp
-	47, // 49: mgmt.MgmtSvc.PoolDestroy:output_type -> mgmt.PoolDestroyResp
-	48, // 50: mgmt.MgmtSvc.PoolEvict:output_type -> mgmt.PoolEvictResp
-	49, // 51: mgmt.MgmtSvc.PoolExclude:output_type -> mgmt.PoolExcludeResp
-	50, // 52: mgmt.MgmtSvc.PoolDrain:output_type -> mgmt.PoolDrainResp
-	51, // 53: mgmt.MgmtSvc.PoolExtend:output_type -> mgmt.PoolExtendResp
-	52, // 54: mgmt.MgmtSvc.PoolReintegrate:output_type -> mgmt.PoolReintResp
-	53, // 55: mgmt.MgmtSvc.PoolQuery:output_type -> mgmt.PoolQueryResp
-	54, // 56: mgmt.MgmtSvc.PoolQueryTarget:output_type -> mgmt.PoolQueryTargetResp
-	55, // 57: mgmt.MgmtSvc.PoolSetProp:output_type -> mgmt.PoolSetPropResp
-	56, // 58: mgmt.MgmtSvc.PoolGetProp:output_type -> mgmt.PoolGetPropResp
-	57, // 59: mgmt.MgmtSvc.PoolGetACL:output_type -> mgmt.ACLResp
-	57, // 60: mgmt.MgmtSvc.PoolOverwriteACL:output_type -> mgmt.ACLResp
-	57, // 61: mgmt.MgmtSvc.PoolUpdateACL:output_type -> mgmt.ACLResp
-	57, // 62: mgmt.MgmtSvc.PoolDeleteACL:output_type -> mgmt.ACLResp
-	58, // 63: mgmt.MgmtSvc.GetAttachInfo:output_type -> mgmt.GetAttachInfoResp
-	59, // 64: mgmt.MgmtSvc.ListPools:output_type -> mgmt.ListPoolsResp
-	60, // 65: mgmt.MgmtSvc.ListContainers:output_type -> mgmt.ListContResp
-	61, // 66: mgmt.MgmtSvc.ContSetOwner:output_type -> mgmt.DaosResp
-	62, // 67: mgmt.MgmtSvc.SystemQuery:output_type -> mgmt.SystemQueryResp
-	63, // 68: mgmt.MgmtSvc.SystemStop:output_type -> mgmt.SystemStopResp
-	64, // 69: mgmt.MgmtSvc.SystemStart:output_type -> mgmt.SystemStartResp
-	65, // 70: mgmt.MgmtSvc.SystemExclude:output_type -> mgmt.SystemExcludeResp
-	66, // 71: mgmt.MgmtSvc.SystemDrain:output_type -> mgmt.SystemDrainResp
-	67, // 72: mgmt.MgmtSvc.SystemErase:output_type -> mgmt.SystemEraseResp
-	68, // 73: mgmt.MgmtSvc.SystemCleanup:output_type -> mgmt.SystemCleanupResp
-	61, // 74: mgmt.MgmtSvc.SystemCheckEnable:output_type -> mgmt.DaosResp
-	61, // 75: mgmt.MgmtSvc.SystemCheckDisable:output_type -> mgmt.DaosResp
-	69, // 76: mgmt.MgmtSvc.SystemCheckStart:output_type -> mgmt.CheckStartResp
-	70, // 77: mgmt.MgmtSvc.SystemCheckStop:output_type -> mgmt.CheckStopResp
-	71, // 78: mgmt.MgmtSvc.SystemCheckQuery:output_type -> mgmt.CheckQueryResp
-	61, // 79: mgmt.MgmtSvc.SystemCheckSetPolicy:output_type -> mgmt.DaosResp
-	72, // 80: mgmt.MgmtSvc.SystemCheckGetPolicy:output_type -> mgmt.CheckGetPolicyResp
-	73, // 81: mgmt.MgmtSvc.SystemCheckRepair:output_type -> mgmt.CheckActResp
-	74, // 82: mgmt.MgmtSvc.PoolUpgrade:output_type -> mgmt.PoolUpgradeResp
-	61, // 83: mgmt.MgmtSvc.SystemSetAttr:output_type -> mgmt.DaosResp
-	75, // 84: mgmt.MgmtSvc.SystemGetAttr:output_type -> mgmt.SystemGetAttrResp
-	61, // 85: mgmt.MgmtSvc.SystemSetProp:output_type -> mgmt.DaosResp
-	76, // 86: mgmt.MgmtSvc.SystemGetProp:output_type -> mgmt.SystemGetPropResp
-	61, // 87: mgmt.MgmtSvc.FaultInjectReport:output_type -> mgmt.DaosResp
-	61, // 88: mgmt.MgmtSvc.FaultInjectPoolFault:output_type -> mgmt.DaosResp
-	61, // 89: mgmt.MgmtSvc.FaultInjectMgmtPoolFault:output_type -> mgmt.DaosResp
-	45, // [45:90] is the sub-list for method output_type
-	0,  // [0:45] is the sub-list for method input_type
+	41, // 42: mgmt.MgmtSvc.SignAgentCert:input_type -> mgmt.SignAgentCertReq
+	42, // 43: mgmt.MgmtSvc.GetCredentialNonce:input_type -> mgmt.GetCredentialNonceReq
+	43, // 44: mgmt.MgmtSvc.SystemRevokeCredentials:input_type -> mgmt.SystemRevokeCredentialsReq
+	44, // 45: mgmt.MgmtSvc.GetCredentialRevocations:input_type -> mgmt.GetCredentialRevocationsReq
+	45, // 46: mgmt.MgmtSvc.SystemEnrollAgent:input_type -> mgmt.SystemEnrollAgentReq
+	46, // 47: mgmt.MgmtSvc.GetAgentEnrollments:input_type -> mgmt.GetAgentEnrollmentsReq
+	47, // 48: mgmt.MgmtSvc.SystemUpdateAuthFlavors:input_type -> mgmt.SystemUpdateAuthFlavorsReq
+	48, // 49: mgmt.MgmtSvc.GetAuthFlavors:input_type -> mgmt.GetAuthFlavorsReq
+	49, // 50: mgmt.MgmtSvc.FaultInjectReport:input_type -> chk.CheckReport
+	50, // 51: mgmt.MgmtSvc.FaultInjectPoolFault:input_type -> chk.Fault
+	50, // 52: mgmt.MgmtSvc.FaultInjectMgmtPoolFault:input_type -> chk.Fault
+	51, // 53: mgmt.MgmtSvc.Join:output_type -> mgmt.JoinResp
+	52, // 54: mgmt.MgmtSvc.ClusterEvent:output_type -> shared.ClusterEventResp
+	53, // 55: mgmt.MgmtSvc.LeaderQuery:output_type -> mgmt.LeaderQueryResp
+	54, // 56: mgmt.MgmtSvc.PoolCreate:output_type -> mgmt.PoolCreateResp
+	55, // 57: mgmt.MgmtSvc.PoolDestroy:output_type -> mgmt.PoolDestroyResp
+	56, // 58: mgmt.MgmtSvc.PoolEvict:output_type -> mgmt.PoolEvictResp
+	57, // 59: mgmt.MgmtSvc.PoolExclude:output_type -> mgmt.PoolExcludeResp
+	58, // 60: mgmt.MgmtSvc.PoolDrain:output_type -> mgmt.PoolDrainResp
+	59, // 61: mgmt.MgmtSvc.PoolExtend:output_type -> mgmt.PoolExtendResp
+	60, // 62: mgmt.MgmtSvc.PoolReintegrate:output_type -> mgmt.PoolReintResp
+	61, // 63: mgmt.MgmtSvc.PoolQuery:output_type -> mgmt.PoolQueryResp
+	62, // 64: mgmt.MgmtSvc.PoolQueryTarget:output_type -> mgmt.PoolQueryTargetResp
+	63, // 65: mgmt.MgmtSvc.PoolSetProp:output_type -> mgmt.PoolSetPropResp
+	64, // 66: mgmt.MgmtSvc.PoolGetProp:output_type -> mgmt.PoolGetPropResp
+	65, // 67: mgmt.MgmtSvc.PoolGetACL:output_type -> mgmt.ACLResp
+	65, // 68: mgmt.MgmtSvc.PoolOverwriteACL:output_type -> mgmt.ACLResp
+	65, // 69: mgmt.MgmtSvc.PoolUpdateACL:output_type -> mgmt.ACLResp
+	65, // 70: mgmt.MgmtSvc.PoolDeleteACL:output_type -> mgmt.ACLResp
+	66, // 71: mgmt.MgmtSvc.GetAttachInfo:output_type -> mgmt.GetAttachInfoResp
+	67, // 72: mgmt.MgmtSvc.ListPools:output_type -> mgmt.ListPoolsResp
+	68, // 73: mgmt.MgmtSvc.ListContainers:output_type -> mgmt.ListContResp
+	69, // 74: mgmt.MgmtSvc.ContSetOwner:output_type -> mgmt.DaosResp
+	70, // 75: mgmt.MgmtSvc.SystemQuery:output_type -> mgmt.SystemQueryResp
+	71, // 76: mgmt.MgmtSvc.SystemStop:output_type -> mgmt.SystemStopResp
+	72, // 77: mgmt.MgmtSvc.SystemStart:output_type -> mgmt.SystemStartResp
+	73, // 78: mgmt.MgmtSvc.SystemExclude:output_type -> mgmt.SystemExcludeResp
+	74, // 79: mgmt.MgmtSvc.SystemDrain:output_type -> mgmt.SystemDrainResp
+	75, // 80: mgmt.MgmtSvc.SystemErase:output_type -> mgmt.SystemEraseResp
+	76, // 81: mgmt.MgmtSvc.SystemCleanup:output_type -> mgmt.SystemCleanupResp
+	69, // 82: mgmt.MgmtSvc.SystemCheckEnable:output_type -> mgmt.DaosResp
+	69, // 83: mgmt.MgmtSvc.SystemCheckDisable:output_type -> mgmt.DaosResp
+	77, // 84: mgmt.MgmtSvc.SystemCheckStart:output_type -> mgmt.CheckStartResp
+	78, // 85: mgmt.MgmtSvc.SystemCheckStop:output_type -> mgmt.CheckStopResp
+	79, // 86: mgmt.MgmtSvc.SystemCheckQuery:output_type -> mgmt.CheckQueryResp
+	69, // 87: mgmt.MgmtSvc.SystemCheckSetPolicy:output_type -> mgmt.DaosResp
+	80, // 88: mgmt.MgmtSvc.SystemCheckGetPolicy:output_type -> mgmt.CheckGetPolicyResp
+	81, // 89: mgmt.MgmtSvc.SystemCheckRepair:output_type -> mgmt.CheckActResp
+	82, // 90: mgmt.MgmtSvc.PoolUpgrade:output_type -> mgmt.PoolUpgradeResp
+	69, // 91: mgmt.MgmtSvc.SystemSetAttr:output_type -> mgmt.DaosResp
+	83, // 92: mgmt.MgmtSvc.SystemGetAttr:output_type -> mgmt.SystemGetAttrResp
+	69, // 93: mgmt.MgmtSvc.SystemSetProp:output_type -> mgmt.DaosResp
+	84, // 94: mgmt.MgmtSvc.SystemGetProp:output_type -> mgmt.SystemGetPropResp
+	85, // 95: mgmt.MgmtSvc.SignAgentCert:output_type -> mgmt.SignAgentCertResp
+	86, // 96: mgmt.MgmtSvc.GetCredentialNonce:output_type -> mgmt.GetCredentialNonceResp
+	87, // 97: mgmt.MgmtSvc.SystemRevokeCredentials:output_type -> mgmt.SystemRevokeCredentialsResp
+	88, // 98: mgmt.MgmtSvc.GetCredentialRevocations:output_type -> mgmt.GetCredentialRevocationsResp
+	89, // 99: mgmt.MgmtSvc.SystemEnrollAgent:output_type -> mgmt.SystemEnrollAgentResp
+	90, // 100: mgmt.MgmtSvc.GetAgentEnrollments:output_type -> mgmt.GetAgentEnrollmentsResp
+	91, // 101: mgmt.MgmtSvc.SystemUpdateAuthFlavors:output_type -> mgmt.SystemUpdateAuthFlavorsResp
+	92, // 102: mgmt.MgmtSvc.GetAuthFlavors:output_type -> mgmt.GetAuthFlavorsResp
+	69, // 103: mgmt.MgmtSvc.FaultInjectReport:output_type -> mgmt.DaosResp
+	69, // 104: mgmt.MgmtSvc.FaultInjectPoolFault:output_type -> mgmt.DaosResp
+	69, // 105: mgmt.MgmtSvc.FaultInjectMgmtPoolFault:output_type -> mgmt.DaosResp
+	53, // [53:106] is the sub-list for method output_type
+	0,  // [0:53] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
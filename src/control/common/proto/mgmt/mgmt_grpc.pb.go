@@ -70,6 +70,14 @@ const (
 	MgmtSvc_SystemGetAttr_FullMethodName            = "/mgmt.MgmtSvc/SystemGetAttr"
 	MgmtSvc_SystemSetProp_FullMethodName            = "/mgmt.MgmtSvc/SystemSetProp"
 	MgmtSvc_SystemGetProp_FullMethodName            = "/mgmt.MgmtSvc/SystemGetProp"
+	MgmtSvc_SignAgentCert_FullMethodName            = "/mgmt.MgmtSvc/SignAgentCert"
+	MgmtSvc_GetCredentialNonce_FullMethodName       = "/mgmt.MgmtSvc/GetCredentialNonce"
+	MgmtSvc_SystemRevokeCredentials_FullMethodName  = "/mgmt.MgmtSvc/SystemRevokeCredentials"
+	MgmtSvc_GetCredentialRevocations_FullMethodName = "/mgmt.MgmtSvc/GetCredentialRevocations"
+	MgmtSvc_SystemEnrollAgent_FullMethodName        = "/mgmt.MgmtSvc/SystemEnrollAgent"
+	MgmtSvc_GetAgentEnrollments_FullMethodName      = "/mgmt.MgmtSvc/GetAgentEnrollments"
+	MgmtSvc_SystemUpdateAuthFlavors_FullMethodName  = "/mgmt.MgmtSvc/SystemUpdateAuthFlavors"
+	MgmtSvc_GetAuthFlavors_FullMethodName           = "/mgmt.MgmtSvc/GetAuthFlavors"
 	MgmtSvc_FaultInjectReport_FullMethodName        = "/mgmt.MgmtSvc/FaultInjectReport"
 	MgmtSvc_FaultInjectPoolFault_FullMethodName     = "/mgmt.MgmtSvc/FaultInjectPoolFault"
 	MgmtSvc_FaultInjectMgmtPoolFault_FullMethodName = "/mgmt.MgmtSvc/FaultInjectMgmtPoolFault"
@@ -171,6 +179,22 @@ type MgmtSvcClient interface {
 	SystemSetProp(ctx context.Context, in *SystemSetPropReq, opts ...grpc.CallOption) (*DaosResp, error)
 	// Get a system property or properties.
 	SystemGetProp(ctx context.Context, in *SystemGetPropReq, opts ...grpc.CallOption) (*SystemGetPropResp, error)
+	// Sign a renewal of the certificate of the calling agent.
+	SignAgentCert(ctx context.Context, in *SignAgentCertReq, opts ...grpc.CallOption) (*SignAgentCertResp, error)
+	// Issue a nonce for the calling agent to sign credentials with.
+	GetCredentialNonce(ctx context.Context, in *GetCredentialNonceReq, opts ...grpc.CallOption) (*GetCredentialNonceResp, error)
+	// Revoke credentials throughout the system.
+	SystemRevokeCredentials(ctx context.Context, in *SystemRevokeCredentialsReq, opts ...grpc.CallOption) (*SystemRevokeCredentialsResp, error)
+	// Get the credentials revoked throughout the system.
+	GetCredentialRevocations(ctx context.Context, in *GetCredentialRevocationsReq, opts ...grpc.CallOption) (*GetCredentialRevocationsResp, error)
+	// Enroll an agent host with the key it signs credentials with.
+	SystemEnrollAgent(ctx context.Context, in *SystemEnrollAgentReq, opts ...grpc.CallOption) (*SystemEnrollAgentResp, error)
+	// Get the agent hosts enrolled throughout the system.
+	GetAgentEnrollments(ctx context.Context, in *GetAgentEnrollmentsReq, opts ...grpc.CallOption) (*GetAgentEnrollmentsResp, error)
+	// Change the authentication flavors allowed throughout the system.
+	SystemUpdateAuthFlavors(ctx context.Context, in *SystemUpdateAuthFlavorsReq, opts ...grpc.CallOption) (*SystemUpdateAuthFlavorsResp, error)
+	// Get the authentication flavors allowed throughout the system.
+	GetAuthFlavors(ctx context.Context, in *GetAuthFlavorsReq, opts ...grpc.CallOption) (*GetAuthFlavorsResp, error)
 	// Fault injection handlers are only implemented in non-release builds.
 	// FaultInjectReport injects a checker report.
 	FaultInjectReport(ctx context.Context, in *chk.CheckReport, opts ...grpc.CallOption) (*DaosResp, error)
@@ -607,6 +631,86 @@ func (c *mgmtSvcClient) SystemGetProp(ctx context.Context, in *SystemGetPropReq,
 	return out, nil
 }
 
+func (c *mgmtSvcClient) SignAgentCert(ctx context.Context, in *SignAgentCertReq, opts ...grpc.CallOption) (*SignAgentCertResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignAgentCertResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_SignAgentCert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) GetCredentialNonce(ctx context.Context, in *GetCredentialNonceReq, opts ...grpc.CallOption) (*GetCredentialNonceResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCredentialNonceResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_GetCredentialNonce_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemRevokeCredentials(ctx context.Context, in *SystemRevokeCredentialsReq, opts ...grpc.CallOption) (*SystemRevokeCredentialsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SystemRevokeCredentialsResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_SystemRevokeCredentials_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) GetCredentialRevocations(ctx context.Context, in *GetCredentialRevocationsReq, opts ...grpc.CallOption) (*GetCredentialRevocationsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCredentialRevocationsResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_GetCredentialRevocations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemEnrollAgent(ctx context.Context, in *SystemEnrollAgentReq, opts ...grpc.CallOption) (*SystemEnrollAgentResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SystemEnrollAgentResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_SystemEnrollAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) GetAgentEnrollments(ctx context.Context, in *GetAgentEnrollmentsReq, opts ...grpc.CallOption) (*GetAgentEnrollmentsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAgentEnrollmentsResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_GetAgentEnrollments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemUpdateAuthFlavors(ctx context.Context, in *SystemUpdateAuthFlavorsReq, opts ...grpc.CallOption) (*SystemUpdateAuthFlavorsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SystemUpdateAuthFlavorsResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_SystemUpdateAuthFlavors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) GetAuthFlavors(ctx context.Context, in *GetAuthFlavorsReq, opts ...grpc.CallOption) (*GetAuthFlavorsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAuthFlavorsResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_GetAuthFlavors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mgmtSvcClient) FaultInjectReport(ctx context.Context, in *chk.CheckReport, opts ...grpc.CallOption) (*DaosResp, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DaosResp)
@@ -733,6 +837,22 @@ type MgmtSvcServer interface {
 	SystemSetProp(context.Context, *SystemSetPropReq) (*DaosResp, error)
 	// Get a system property or properties.
 	SystemGetProp(context.Context, *SystemGetPropReq) (*SystemGetPropResp, error)
+	// Sign a renewal of the certificate of the calling agent.
+	SignAgentCert(context.Context, *SignAgentCertReq) (*SignAgentCertResp, error)
+	// Issue a nonce for the calling agent to sign credentials with.
+	GetCredentialNonce(context.Context, *GetCredentialNonceReq) (*GetCredentialNonceResp, error)
+	// Revoke credentials throughout the system.
+	SystemRevokeCredentials(context.Context, *SystemRevokeCredentialsReq) (*SystemRevokeCredentialsResp, error)
+	// Get the credentials revoked throughout the system.
+	GetCredentialRevocations(context.Context, *GetCredentialRevocationsReq) (*GetCredentialRevocationsResp, error)
+	// Enroll an agent host with the key it signs credentials with.
+	SystemEnrollAgent(context.Context, *SystemEnrollAgentReq) (*SystemEnrollAgentResp, error)
+	// Get the agent hosts enrolled throughout the system.
+	GetAgentEnrollments(context.Context, *GetAgentEnrollmentsReq) (*GetAgentEnrollmentsResp, error)
+	// Change the authentication flavors allowed throughout the system.
+	SystemUpdateAuthFlavors(context.Context, *SystemUpdateAuthFlavorsReq) (*SystemUpdateAuthFlavorsResp, error)
+	// Get the authentication flavors allowed throughout the system.
+	GetAuthFlavors(context.Context, *GetAuthFlavorsReq) (*GetAuthFlavorsResp, error)
 	// Fault injection handlers are only implemented in non-release builds.
 	// FaultInjectReport injects a checker report.
 	FaultInjectReport(context.Context, *chk.CheckReport) (*DaosResp, error)
@@ -875,6 +995,30 @@ func (UnimplementedMgmtSvcServer) SystemSetProp(context.Context, *SystemSetPropR
 func (UnimplementedMgmtSvcServer) SystemGetProp(context.Context, *SystemGetPropReq) (*SystemGetPropResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SystemGetProp not implemented")
 }
+func (UnimplementedMgmtSvcServer) SignAgentCert(context.Context, *SignAgentCertReq) (*SignAgentCertResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignAgentCert not implemented")
+}
+func (UnimplementedMgmtSvcServer) GetCredentialNonce(context.Context, *GetCredentialNonceReq) (*GetCredentialNonceResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCredentialNonce not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemRevokeCredentials(context.Context, *SystemRevokeCredentialsReq) (*SystemRevokeCredentialsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemRevokeCredentials not implemented")
+}
+func (UnimplementedMgmtSvcServer) GetCredentialRevocations(context.Context, *GetCredentialRevocationsReq) (*GetCredentialRevocationsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCredentialRevocations not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemEnrollAgent(context.Context, *SystemEnrollAgentReq) (*SystemEnrollAgentResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemEnrollAgent not implemented")
+}
+func (UnimplementedMgmtSvcServer) GetAgentEnrollments(context.Context, *GetAgentEnrollmentsReq) (*GetAgentEnrollmentsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentEnrollments not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemUpdateAuthFlavors(context.Context, *SystemUpdateAuthFlavorsReq) (*SystemUpdateAuthFlavorsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemUpdateAuthFlavors not implemented")
+}
+func (UnimplementedMgmtSvcServer) GetAuthFlavors(context.Context, *GetAuthFlavorsReq) (*GetAuthFlavorsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuthFlavors not implemented")
+}
 func (UnimplementedMgmtSvcServer) FaultInjectReport(context.Context, *chk.CheckReport) (*DaosResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FaultInjectReport not implemented")
 }
@@ -1661,6 +1805,150 @@ func _MgmtSvc_SystemGetProp_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MgmtSvc_SignAgentCert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignAgentCertReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SignAgentCert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_SignAgentCert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SignAgentCert(ctx, req.(*SignAgentCertReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_GetCredentialNonce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCredentialNonceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).GetCredentialNonce(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_GetCredentialNonce_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).GetCredentialNonce(ctx, req.(*GetCredentialNonceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemRevokeCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemRevokeCredentialsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemRevokeCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_SystemRevokeCredentials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemRevokeCredentials(ctx, req.(*SystemRevokeCredentialsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_GetCredentialRevocations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCredentialRevocationsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).GetCredentialRevocations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_GetCredentialRevocations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).GetCredentialRevocations(ctx, req.(*GetCredentialRevocationsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemEnrollAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemEnrollAgentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemEnrollAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_SystemEnrollAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemEnrollAgent(ctx, req.(*SystemEnrollAgentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_GetAgentEnrollments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgentEnrollmentsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).GetAgentEnrollments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_GetAgentEnrollments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).GetAgentEnrollments(ctx, req.(*GetAgentEnrollmentsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemUpdateAuthFlavors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemUpdateAuthFlavorsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemUpdateAuthFlavors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_SystemUpdateAuthFlavors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemUpdateAuthFlavors(ctx, req.(*SystemUpdateAuthFlavorsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_GetAuthFlavors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuthFlavorsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).GetAuthFlavors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_GetAuthFlavors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).GetAuthFlavors(ctx, req.(*GetAuthFlavorsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MgmtSvc_FaultInjectReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(chk.CheckReport)
 	if err := dec(in); err != nil {
@@ -1890,6 +2178,38 @@ var MgmtSvc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SystemGetProp",
 			Handler:    _MgmtSvc_SystemGetProp_Handler,
 		},
+		{
+			MethodName: "SignAgentCert",
+			Handler:    _MgmtSvc_SignAgentCert_Handler,
+		},
+		{
+			MethodName: "GetCredentialNonce",
+			Handler:    _MgmtSvc_GetCredentialNonce_Handler,
+		},
+		{
+			MethodName: "SystemRevokeCredentials",
+			Handler:    _MgmtSvc_SystemRevokeCredentials_Handler,
+		},
+		{
+			MethodName: "GetCredentialRevocations",
+			Handler:    _MgmtSvc_GetCredentialRevocations_Handler,
+		},
+		{
+			MethodName: "SystemEnrollAgent",
+			Handler:    _MgmtSvc_SystemEnrollAgent_Handler,
+		},
+		{
+			MethodName: "GetAgentEnrollments",
+			Handler:    _MgmtSvc_GetAgentEnrollments_Handler,
+		},
+		{
+			MethodName: "SystemUpdateAuthFlavors",
+			Handler:    _MgmtSvc_SystemUpdateAuthFlavors_Handler,
+		},
+		{
+			MethodName: "GetAuthFlavors",
+			Handler:    _MgmtSvc_GetAuthFlavors_Handler,
+		},
 		{
 			MethodName: "FaultInjectReport",
 			Handler:    _MgmtSvc_FaultInjectReport_Handler,
@@ -8,7 +8,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.34.1
-// 	protoc        v3.14.0
+// 	protoc        v3.5.0
 // source: mgmt/system.proto
 
 package mgmt
@@ -1441,6 +1441,963 @@ func (x *SystemGetPropResp) GetProperties() map[string]string {
 	return nil
 }
 
+// SignAgentCertReq contains a request from an agent for the control plane CA to
+// sign a renewal of its certificate, for the key in the CSR.
+type SignAgentCertReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`
+	Csr []byte `protobuf:"bytes,2,opt,name=csr,proto3" json:"csr,omitempty"` // DER-encoded certificate signing request
+}
+
+func (x *SignAgentCertReq) Reset() {
+	*x = SignAgentCertReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignAgentCertReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignAgentCertReq) ProtoMessage() {}
+
+func (x *SignAgentCertReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignAgentCertReq.ProtoReflect.Descriptor instead.
+func (*SignAgentCertReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SignAgentCertReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *SignAgentCertReq) GetCsr() []byte {
+	if x != nil {
+		return x.Csr
+	}
+	return nil
+}
+
+// SignAgentCertResp contains the renewed agent certificate.
+type SignAgentCertResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cert   []byte `protobuf:"bytes,1,opt,name=cert,proto3" json:"cert,omitempty"`                   // DER-encoded certificate
+	CaCert []byte `protobuf:"bytes,2,opt,name=ca_cert,json=caCert,proto3" json:"ca_cert,omitempty"` // DER-encoded certificate of the issuing CA
+}
+
+func (x *SignAgentCertResp) Reset() {
+	*x = SignAgentCertResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignAgentCertResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignAgentCertResp) ProtoMessage() {}
+
+func (x *SignAgentCertResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignAgentCertResp.ProtoReflect.Descriptor instead.
+func (*SignAgentCertResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SignAgentCertResp) GetCert() []byte {
+	if x != nil {
+		return x.Cert
+	}
+	return nil
+}
+
+func (x *SignAgentCertResp) GetCaCert() []byte {
+	if x != nil {
+		return x.CaCert
+	}
+	return nil
+}
+
+// GetCredentialNonceReq contains a request from an agent for nonces to sign
+// credentials with.
+type GetCredentialNonceReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys   string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`
+	Count uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"` // number of nonces to issue, or 0 for one
+}
+
+func (x *GetCredentialNonceReq) Reset() {
+	*x = GetCredentialNonceReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCredentialNonceReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCredentialNonceReq) ProtoMessage() {}
+
+func (x *GetCredentialNonceReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCredentialNonceReq.ProtoReflect.Descriptor instead.
+func (*GetCredentialNonceReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetCredentialNonceReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *GetCredentialNonceReq) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// GetCredentialNonceResp contains nonces issued by the servers.
+type GetCredentialNonceResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nonces  [][]byte `protobuf:"bytes,1,rep,name=nonces,proto3" json:"nonces,omitempty"`    // nonces to record in credentials, each in only one
+	Expires uint64   `protobuf:"varint,2,opt,name=expires,proto3" json:"expires,omitempty"` // time after which servers no longer accept the nonces (Unix seconds)
+}
+
+func (x *GetCredentialNonceResp) Reset() {
+	*x = GetCredentialNonceResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCredentialNonceResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCredentialNonceResp) ProtoMessage() {}
+
+func (x *GetCredentialNonceResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCredentialNonceResp.ProtoReflect.Descriptor instead.
+func (*GetCredentialNonceResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetCredentialNonceResp) GetNonces() [][]byte {
+	if x != nil {
+		return x.Nonces
+	}
+	return nil
+}
+
+func (x *GetCredentialNonceResp) GetExpires() uint64 {
+	if x != nil {
+		return x.Expires
+	}
+	return 0
+}
+
+// SystemRevokeCredentialsReq contains a request to revoke credentials
+// throughout the system.
+type SystemRevokeCredentialsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys        string   `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`
+	KeyIds     []string `protobuf:"bytes,2,rep,name=key_ids,json=keyIds,proto3" json:"key_ids,omitempty"`             // IDs of agent signing keys to revoke
+	CredHashes []string `protobuf:"bytes,3,rep,name=cred_hashes,json=credHashes,proto3" json:"cred_hashes,omitempty"` // hashes of individual credentials to revoke
+	AgentHosts []string `protobuf:"bytes,4,rep,name=agent_hosts,json=agentHosts,proto3" json:"agent_hosts,omitempty"` // hosts of agents whose credentials to revoke
+	Reason     string   `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`                           // reason for revocation, for audit records
+}
+
+func (x *SystemRevokeCredentialsReq) Reset() {
+	*x = SystemRevokeCredentialsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemRevokeCredentialsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemRevokeCredentialsReq) ProtoMessage() {}
+
+func (x *SystemRevokeCredentialsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemRevokeCredentialsReq.ProtoReflect.Descriptor instead.
+func (*SystemRevokeCredentialsReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SystemRevokeCredentialsReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *SystemRevokeCredentialsReq) GetKeyIds() []string {
+	if x != nil {
+		return x.KeyIds
+	}
+	return nil
+}
+
+func (x *SystemRevokeCredentialsReq) GetCredHashes() []string {
+	if x != nil {
+		return x.CredHashes
+	}
+	return nil
+}
+
+func (x *SystemRevokeCredentialsReq) GetAgentHosts() []string {
+	if x != nil {
+		return x.AgentHosts
+	}
+	return nil
+}
+
+func (x *SystemRevokeCredentialsReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// SystemRevokeCredentialsResp contains the result of a request to revoke
+// credentials.
+type SystemRevokeCredentialsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version uint64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"` // version of the revocation list including the request
+	Added   uint32 `protobuf:"varint,2,opt,name=added,proto3" json:"added,omitempty"`     // number of revocations not already in the list
+}
+
+func (x *SystemRevokeCredentialsResp) Reset() {
+	*x = SystemRevokeCredentialsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemRevokeCredentialsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemRevokeCredentialsResp) ProtoMessage() {}
+
+func (x *SystemRevokeCredentialsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemRevokeCredentialsResp.ProtoReflect.Descriptor instead.
+func (*SystemRevokeCredentialsResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SystemRevokeCredentialsResp) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SystemRevokeCredentialsResp) GetAdded() uint32 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+// GetCredentialRevocationsReq contains a request for the credentials revoked
+// throughout the system.
+type GetCredentialRevocationsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`
+}
+
+func (x *GetCredentialRevocationsReq) Reset() {
+	*x = GetCredentialRevocationsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCredentialRevocationsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCredentialRevocationsReq) ProtoMessage() {}
+
+func (x *GetCredentialRevocationsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCredentialRevocationsReq.ProtoReflect.Descriptor instead.
+func (*GetCredentialRevocationsReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetCredentialRevocationsReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+// GetCredentialRevocationsResp contains the credentials revoked throughout the
+// system.
+type GetCredentialRevocationsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version    uint64   `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`                        // version of the revocation list
+	KeyIds     []string `protobuf:"bytes,2,rep,name=key_ids,json=keyIds,proto3" json:"key_ids,omitempty"`             // IDs of revoked agent signing keys
+	CredHashes []string `protobuf:"bytes,3,rep,name=cred_hashes,json=credHashes,proto3" json:"cred_hashes,omitempty"` // hashes of revoked credentials
+	AgentHosts []string `protobuf:"bytes,4,rep,name=agent_hosts,json=agentHosts,proto3" json:"agent_hosts,omitempty"` // hosts of agents whose credentials are revoked
+}
+
+func (x *GetCredentialRevocationsResp) Reset() {
+	*x = GetCredentialRevocationsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCredentialRevocationsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCredentialRevocationsResp) ProtoMessage() {}
+
+func (x *GetCredentialRevocationsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCredentialRevocationsResp.ProtoReflect.Descriptor instead.
+func (*GetCredentialRevocationsResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetCredentialRevocationsResp) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetCredentialRevocationsResp) GetKeyIds() []string {
+	if x != nil {
+		return x.KeyIds
+	}
+	return nil
+}
+
+func (x *GetCredentialRevocationsResp) GetCredHashes() []string {
+	if x != nil {
+		return x.CredHashes
+	}
+	return nil
+}
+
+func (x *GetCredentialRevocationsResp) GetAgentHosts() []string {
+	if x != nil {
+		return x.AgentHosts
+	}
+	return nil
+}
+
+// SystemEnrollAgentReq contains a request to enroll the host of an agent with
+// the key it signs credentials with, or to unenroll it.
+type SystemEnrollAgentReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys       string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`
+	AgentHost string `protobuf:"bytes,2,opt,name=agent_host,json=agentHost,proto3" json:"agent_host,omitempty"` // host of the agent
+	KeyId     string `protobuf:"bytes,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`             // ID of the agent's signing key
+	Unenroll  bool   `protobuf:"varint,4,opt,name=unenroll,proto3" json:"unenroll,omitempty"`                   // unenroll the host rather than enroll it
+}
+
+func (x *SystemEnrollAgentReq) Reset() {
+	*x = SystemEnrollAgentReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemEnrollAgentReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemEnrollAgentReq) ProtoMessage() {}
+
+func (x *SystemEnrollAgentReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemEnrollAgentReq.ProtoReflect.Descriptor instead.
+func (*SystemEnrollAgentReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SystemEnrollAgentReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *SystemEnrollAgentReq) GetAgentHost() string {
+	if x != nil {
+		return x.AgentHost
+	}
+	return ""
+}
+
+func (x *SystemEnrollAgentReq) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *SystemEnrollAgentReq) GetUnenroll() bool {
+	if x != nil {
+		return x.Unenroll
+	}
+	return false
+}
+
+// SystemEnrollAgentResp contains the result of a request to enroll an agent.
+type SystemEnrollAgentResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version uint64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"` // version of the enrollments including the request
+	Changed bool   `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"` // whether the enrollments were changed by the request
+}
+
+func (x *SystemEnrollAgentResp) Reset() {
+	*x = SystemEnrollAgentResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemEnrollAgentResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemEnrollAgentResp) ProtoMessage() {}
+
+func (x *SystemEnrollAgentResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemEnrollAgentResp.ProtoReflect.Descriptor instead.
+func (*SystemEnrollAgentResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SystemEnrollAgentResp) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SystemEnrollAgentResp) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+// GetAgentEnrollmentsReq contains a request for the agent hosts enrolled
+// throughout the system.
+type GetAgentEnrollmentsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`
+}
+
+func (x *GetAgentEnrollmentsReq) Reset() {
+	*x = GetAgentEnrollmentsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAgentEnrollmentsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentEnrollmentsReq) ProtoMessage() {}
+
+func (x *GetAgentEnrollmentsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentEnrollmentsReq.ProtoReflect.Descriptor instead.
+func (*GetAgentEnrollmentsReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetAgentEnrollmentsReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+// GetAgentEnrollmentsResp contains the agent hosts enrolled throughout the
+// system.
+type GetAgentEnrollmentsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version uint64            `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`                                                                                                    // version of the enrollments
+	KeyIds  map[string]string `protobuf:"bytes,2,rep,name=key_ids,json=keyIds,proto3" json:"key_ids,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // IDs of the signing keys of enrolled agents, by host
+}
+
+func (x *GetAgentEnrollmentsResp) Reset() {
+	*x = GetAgentEnrollmentsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAgentEnrollmentsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentEnrollmentsResp) ProtoMessage() {}
+
+func (x *GetAgentEnrollmentsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentEnrollmentsResp.ProtoReflect.Descriptor instead.
+func (*GetAgentEnrollmentsResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetAgentEnrollmentsResp) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetAgentEnrollmentsResp) GetKeyIds() map[string]string {
+	if x != nil {
+		return x.KeyIds
+	}
+	return nil
+}
+
+// SystemUpdateAuthFlavorsReq contains a request to change the authentication
+// flavors allowed throughout the system.
+type SystemUpdateAuthFlavorsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys    string   `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`
+	Add    []uint32 `protobuf:"varint,2,rep,packed,name=add,proto3" json:"add,omitempty"`       // flavors to allow
+	Remove []uint32 `protobuf:"varint,3,rep,packed,name=remove,proto3" json:"remove,omitempty"` // flavors to no longer allow
+}
+
+func (x *SystemUpdateAuthFlavorsReq) Reset() {
+	*x = SystemUpdateAuthFlavorsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemUpdateAuthFlavorsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemUpdateAuthFlavorsReq) ProtoMessage() {}
+
+func (x *SystemUpdateAuthFlavorsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemUpdateAuthFlavorsReq.ProtoReflect.Descriptor instead.
+func (*SystemUpdateAuthFlavorsReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SystemUpdateAuthFlavorsReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *SystemUpdateAuthFlavorsReq) GetAdd() []uint32 {
+	if x != nil {
+		return x.Add
+	}
+	return nil
+}
+
+func (x *SystemUpdateAuthFlavorsReq) GetRemove() []uint32 {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+// SystemUpdateAuthFlavorsResp contains the result of a request to change the
+// authentication flavors allowed.
+type SystemUpdateAuthFlavorsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version          uint64   `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`                                                    // version of the flavor list including the request
+	Changed          bool     `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"`                                                    // whether the flavors were changed by the request
+	ValidAuthFlavors []uint32 `protobuf:"varint,3,rep,packed,name=valid_auth_flavors,json=validAuthFlavors,proto3" json:"valid_auth_flavors,omitempty"` // flavors allowed
+}
+
+func (x *SystemUpdateAuthFlavorsResp) Reset() {
+	*x = SystemUpdateAuthFlavorsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemUpdateAuthFlavorsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemUpdateAuthFlavorsResp) ProtoMessage() {}
+
+func (x *SystemUpdateAuthFlavorsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemUpdateAuthFlavorsResp.ProtoReflect.Descriptor instead.
+func (*SystemUpdateAuthFlavorsResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SystemUpdateAuthFlavorsResp) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SystemUpdateAuthFlavorsResp) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+func (x *SystemUpdateAuthFlavorsResp) GetValidAuthFlavors() []uint32 {
+	if x != nil {
+		return x.ValidAuthFlavors
+	}
+	return nil
+}
+
+// GetAuthFlavorsReq contains a request for the authentication flavors allowed
+// throughout the system.
+type GetAuthFlavorsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`
+}
+
+func (x *GetAuthFlavorsReq) Reset() {
+	*x = GetAuthFlavorsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAuthFlavorsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuthFlavorsReq) ProtoMessage() {}
+
+func (x *GetAuthFlavorsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuthFlavorsReq.ProtoReflect.Descriptor instead.
+func (*GetAuthFlavorsReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetAuthFlavorsReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+// GetAuthFlavorsResp contains the authentication flavors allowed throughout
+// the system.
+type GetAuthFlavorsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version          uint64   `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`                                                    // version of the flavor list, zero if unchanged since startup
+	ValidAuthFlavors []uint32 `protobuf:"varint,2,rep,packed,name=valid_auth_flavors,json=validAuthFlavors,proto3" json:"valid_auth_flavors,omitempty"` // flavors allowed
+}
+
+func (x *GetAuthFlavorsResp) Reset() {
+	*x = GetAuthFlavorsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAuthFlavorsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuthFlavorsResp) ProtoMessage() {}
+
+func (x *GetAuthFlavorsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuthFlavorsResp.ProtoReflect.Descriptor instead.
+func (*GetAuthFlavorsResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetAuthFlavorsResp) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetAuthFlavorsResp) GetValidAuthFlavors() []uint32 {
+	if x != nil {
+		return x.ValidAuthFlavors
+	}
+	return nil
+}
+
 type SystemCleanupResp_CleanupResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1455,7 +2412,7 @@ type SystemCleanupResp_CleanupResult struct {
 func (x *SystemCleanupResp_CleanupResult) Reset() {
 	*x = SystemCleanupResp_CleanupResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_system_proto_msgTypes[22]
+		mi := &file_mgmt_system_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1468,7 +2425,7 @@ func (x *SystemCleanupResp_CleanupResult) String() string {
 func (*SystemCleanupResp_CleanupResult) ProtoMessage() {}
 
 func (x *SystemCleanupResp_CleanupResult) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_system_proto_msgTypes[22]
+	mi := &file_mgmt_system_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1696,11 +2653,104 @@ var file_mgmt_system_proto_rawDesc = []byte{
 	0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
 	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
 	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x3a, 0x5a, 0x38, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73,
-	0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f,
-	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2f, 0x6d, 0x67, 0x6d, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x36, 0x0a, 0x10, 0x53,
+	0x69, 0x67, 0x6e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x12,
+	0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79,
+	0x73, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x73, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
+	0x63, 0x73, 0x72, 0x22, 0x40, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x43, 0x65, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x65, 0x72, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x63, 0x61, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x63,
+	0x61, 0x43, 0x65, 0x72, 0x74, 0x22, 0x3f, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x43, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x12, 0x10,
+	0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x4a, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x72, 0x65,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x06, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x73, 0x22, 0xa1, 0x01, 0x0a, 0x1a, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x76,
+	0x6f, 0x6b, 0x65, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x65,
+	0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x73, 0x79, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x63, 0x72, 0x65, 0x64, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x1f, 0x0a,
+	0x0b, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0a, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x4d, 0x0a, 0x1b, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x61, 0x64, 0x64, 0x65, 0x64, 0x22, 0x2f, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x43, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x22, 0x93, 0x01, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x43, 0x72,
+	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x72,
+	0x65, 0x64, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0a, 0x63, 0x72, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0a, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x22, 0x7a, 0x0a, 0x14,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x41, 0x67, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x5f,
+	0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x75, 0x6e, 0x65, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x75, 0x6e, 0x65, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x22, 0x4b, 0x0a, 0x15, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x22, 0x2a, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e,
+	0x74, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x12,
+	0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79,
+	0x73, 0x22, 0xb2, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x45, 0x6e,
+	0x72, 0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x42, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e,
+	0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x2e, 0x4b, 0x65, 0x79, 0x49, 0x64, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x06, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4b,
+	0x65, 0x79, 0x49, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x58, 0x0a, 0x1a, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x64, 0x64, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0d, 0x52, 0x03, 0x61, 0x64, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x22, 0x7f, 0x0a, 0x1b, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x41, 0x75, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x61, 0x75, 0x74,
+	0x68, 0x5f, 0x66, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52,
+	0x10, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x75, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72,
+	0x73, 0x22, 0x25, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x76,
+	0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x22, 0x5c, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x41,
+	0x75, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x12, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x66, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0d, 0x52, 0x10, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x75, 0x74, 0x68, 0x46,
+	0x6c, 0x61, 0x76, 0x6f, 0x72, 0x73, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f,
+	0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x67,
+	0x6d, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1715,7 +2765,7 @@ func file_mgmt_system_proto_rawDescGZIP() []byte {
 	return file_mgmt_system_proto_rawDescData
 }
 
-var file_mgmt_system_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_mgmt_system_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
 var file_mgmt_system_proto_goTypes = []interface{}{
 	(*SystemMember)(nil),                    // 0: mgmt.SystemMember
 	(*SystemStopReq)(nil),                   // 1: mgmt.SystemStopReq
@@ -1739,31 +2789,49 @@ var file_mgmt_system_proto_goTypes = []interface{}{
 	(*SystemSetPropReq)(nil),                // 19: mgmt.SystemSetPropReq
 	(*SystemGetPropReq)(nil),                // 20: mgmt.SystemGetPropReq
 	(*SystemGetPropResp)(nil),               // 21: mgmt.SystemGetPropResp
-	(*SystemCleanupResp_CleanupResult)(nil), // 22: mgmt.SystemCleanupResp.CleanupResult
-	nil,                                     // 23: mgmt.SystemSetAttrReq.AttributesEntry
-	nil,                                     // 24: mgmt.SystemGetAttrResp.AttributesEntry
-	nil,                                     // 25: mgmt.SystemSetPropReq.PropertiesEntry
-	nil,                                     // 26: mgmt.SystemGetPropResp.PropertiesEntry
-	(*shared.RankResult)(nil),               // 27: shared.RankResult
+	(*SignAgentCertReq)(nil),                // 22: mgmt.SignAgentCertReq
+	(*SignAgentCertResp)(nil),               // 23: mgmt.SignAgentCertResp
+	(*GetCredentialNonceReq)(nil),           // 24: mgmt.GetCredentialNonceReq
+	(*GetCredentialNonceResp)(nil),          // 25: mgmt.GetCredentialNonceResp
+	(*SystemRevokeCredentialsReq)(nil),      // 26: mgmt.SystemRevokeCredentialsReq
+	(*SystemRevokeCredentialsResp)(nil),     // 27: mgmt.SystemRevokeCredentialsResp
+	(*GetCredentialRevocationsReq)(nil),     // 28: mgmt.GetCredentialRevocationsReq
+	(*GetCredentialRevocationsResp)(nil),    // 29: mgmt.GetCredentialRevocationsResp
+	(*SystemEnrollAgentReq)(nil),            // 30: mgmt.SystemEnrollAgentReq
+	(*SystemEnrollAgentResp)(nil),           // 31: mgmt.SystemEnrollAgentResp
+	(*GetAgentEnrollmentsReq)(nil),          // 32: mgmt.GetAgentEnrollmentsReq
+	(*GetAgentEnrollmentsResp)(nil),         // 33: mgmt.GetAgentEnrollmentsResp
+	(*SystemUpdateAuthFlavorsReq)(nil),      // 34: mgmt.SystemUpdateAuthFlavorsReq
+	(*SystemUpdateAuthFlavorsResp)(nil),     // 35: mgmt.SystemUpdateAuthFlavorsResp
+	(*GetAuthFlavorsReq)(nil),               // 36: mgmt.GetAuthFlavorsReq
+	(*GetAuthFlavorsResp)(nil),              // 37: mgmt.GetAuthFlavorsResp
+	(*SystemCleanupResp_CleanupResult)(nil), // 38: mgmt.SystemCleanupResp.CleanupResult
+	nil,                                     // 39: mgmt.SystemSetAttrReq.AttributesEntry
+	nil,                                     // 40: mgmt.SystemGetAttrResp.AttributesEntry
+	nil,                                     // 41: mgmt.SystemSetPropReq.PropertiesEntry
+	nil,                                     // 42: mgmt.SystemGetPropResp.PropertiesEntry
+	nil,                                     // 43: mgmt.GetAgentEnrollmentsResp.KeyIdsEntry
+	(*shared.RankResult)(nil),               // 44: shared.RankResult
 }
 var file_mgmt_system_proto_depIdxs = []int32{
-	27, // 0: mgmt.SystemStopResp.results:type_name -> shared.RankResult
-	27, // 1: mgmt.SystemStartResp.results:type_name -> shared.RankResult
-	27, // 2: mgmt.SystemExcludeResp.results:type_name -> shared.RankResult
-	27, // 3: mgmt.PoolRanksResp.results:type_name -> shared.RankResult
+	44, // 0: mgmt.SystemStopResp.results:type_name -> shared.RankResult
+	44, // 1: mgmt.SystemStartResp.results:type_name -> shared.RankResult
+	44, // 2: mgmt.SystemExcludeResp.results:type_name -> shared.RankResult
+	44, // 3: mgmt.PoolRanksResp.results:type_name -> shared.RankResult
 	8,  // 4: mgmt.SystemDrainResp.responses:type_name -> mgmt.PoolRanksResp
 	0,  // 5: mgmt.SystemQueryResp.members:type_name -> mgmt.SystemMember
-	27, // 6: mgmt.SystemEraseResp.results:type_name -> shared.RankResult
-	22, // 7: mgmt.SystemCleanupResp.results:type_name -> mgmt.SystemCleanupResp.CleanupResult
-	23, // 8: mgmt.SystemSetAttrReq.attributes:type_name -> mgmt.SystemSetAttrReq.AttributesEntry
-	24, // 9: mgmt.SystemGetAttrResp.attributes:type_name -> mgmt.SystemGetAttrResp.AttributesEntry
-	25, // 10: mgmt.SystemSetPropReq.properties:type_name -> mgmt.SystemSetPropReq.PropertiesEntry
-	26, // 11: mgmt.SystemGetPropResp.properties:type_name -> mgmt.SystemGetPropResp.PropertiesEntry
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	44, // 6: mgmt.SystemEraseResp.results:type_name -> shared.RankResult
+	38, // 7: mgmt.SystemCleanupResp.results:type_name -> mgmt.SystemCleanupResp.CleanupResult
+	39, // 8: mgmt.SystemSetAttrReq.attributes:type_name -> mgmt.SystemSetAttrReq.AttributesEntry
+	40, // 9: mgmt.SystemGetAttrResp.attributes:type_name -> mgmt.SystemGetAttrResp.AttributesEntry
+	41, // 10: mgmt.SystemSetPropReq.properties:type_name -> mgmt.SystemSetPropReq.PropertiesEntry
+	42, // 11: mgmt.SystemGetPropResp.properties:type_name -> mgmt.SystemGetPropResp.PropertiesEntry
+	43, // 12: mgmt.GetAgentEnrollmentsResp.key_ids:type_name -> mgmt.GetAgentEnrollmentsResp.KeyIdsEntry
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_mgmt_system_proto_init() }
@@ -2037,6 +3105,198 @@ func file_mgmt_system_proto_init() {
 			}
 		}
 		file_mgmt_system_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignAgentCertReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignAgentCertResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCredentialNonceReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCredentialNonceResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemRevokeCredentialsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemRevokeCredentialsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCredentialRevocationsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCredentialRevocationsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemEnrollAgentReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemEnrollAgentResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAgentEnrollmentsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAgentEnrollmentsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemUpdateAuthFlavorsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemUpdateAuthFlavorsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAuthFlavorsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAuthFlavorsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SystemCleanupResp_CleanupResult); i {
 			case 0:
 				return &v.state
@@ -2055,7 +3315,7 @@ func file_mgmt_system_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_mgmt_system_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   27,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
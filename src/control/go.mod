@@ -25,6 +25,7 @@ require (
 	go.etcd.io/bbolt v1.3.5
 	golang.org/x/net v0.38.0
 	golang.org/x/sys v0.31.0
+	golang.org/x/text v0.23.0
 	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1
 	google.golang.org/grpc v1.66.2
 	google.golang.org/protobuf v1.34.1
@@ -51,5 +52,4 @@ require (
 	github.com/mattn/go-isatty v0.0.14 // indirect
 	github.com/matttproud/golang_protobuf_extensions v1.0.1 // indirect
 	github.com/prometheus/procfs v0.7.3 // indirect
-	golang.org/x/text v0.23.0 // indirect
 )
@@ -0,0 +1,542 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Package acme implements the subset of the ACME protocol (RFC 8555) needed to
+// obtain a certificate from an ACME server, proving control of the requested
+// domains with the http-01 challenge.
+package acme
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultPollInterval is the interval at which pending authorizations
+	// and orders are polled, unless the server asks for another.
+	DefaultPollInterval = 2 * time.Second
+
+	// ChallengePath is the path under which http-01 challenge responses
+	// are served.
+	ChallengePath = "/.well-known/acme-challenge/"
+
+	contentType   = "application/jose+json"
+	maxRespLen    = 1 << 20
+	maxNonceTries = 3
+)
+
+// Problem is an error reported by the ACME server (RFC 7807).
+type Problem struct {
+	Type   string `json:"type"`
+	Detail string `json:"detail"`
+	Status int    `json:"status"`
+}
+
+func (p *Problem) Error() string {
+	return fmt.Sprintf("acme: %s: %s (status %d)", p.Type, p.Detail, p.Status)
+}
+
+func isBadNonce(err error) bool {
+	var p *Problem
+	return errors.As(err, &p) && p.Type == "urn:ietf:params:acme:error:badNonce"
+}
+
+type directory struct {
+	NewNonce   string `json:"newNonce"`
+	NewAccount string `json:"newAccount"`
+	NewOrder   string `json:"newOrder"`
+}
+
+type identifier struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type order struct {
+	Status         string   `json:"status"`
+	Authorizations []string `json:"authorizations"`
+	Finalize       string   `json:"finalize"`
+	Certificate    string   `json:"certificate"`
+	Error          *Problem `json:"error"`
+}
+
+type challenge struct {
+	Type   string   `json:"type"`
+	URL    string   `json:"url"`
+	Token  string   `json:"token"`
+	Status string   `json:"status"`
+	Error  *Problem `json:"error"`
+}
+
+type authorization struct {
+	Status     string      `json:"status"`
+	Identifier identifier  `json:"identifier"`
+	Challenges []challenge `json:"challenges"`
+}
+
+// HTTP01Responder serves the responses to pending http-01 challenges. It must
+// be reachable by the ACME server on port 80 of the requested domains.
+type HTTP01Responder struct {
+	mu     sync.RWMutex
+	tokens map[string]string
+}
+
+// NewHTTP01Responder returns a responder with no pending challenges.
+func NewHTTP01Responder() *HTTP01Responder {
+	return &HTTP01Responder{tokens: make(map[string]string)}
+}
+
+func (r *HTTP01Responder) set(token, keyAuth string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[token] = keyAuth
+}
+
+func (r *HTTP01Responder) remove(token string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tokens, token)
+}
+
+// ServeHTTP responds to a challenge with its key authorization.
+func (r *HTTP01Responder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	token, found := strings.CutPrefix(req.URL.Path, ChallengePath)
+	if !found || req.Method != http.MethodGet {
+		http.NotFound(w, req)
+		return
+	}
+
+	r.mu.RLock()
+	keyAuth, found := r.tokens[token]
+	r.mu.RUnlock()
+	if !found {
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	io.WriteString(w, keyAuth)
+}
+
+// Client obtains certificates from an ACME server, with an account for its
+// key, which is registered with the server if it isn't already.
+type Client struct {
+	// DirectoryURL is the URL of the server's directory.
+	DirectoryURL string
+	// Key is the account key.
+	Key *ecdsa.PrivateKey
+	// Email is the account's contact address, if any.
+	Email string
+	// HTTPClient is used for requests to the server.
+	HTTPClient *http.Client
+	// Responder serves the http-01 challenge responses.
+	Responder *HTTP01Responder
+	// PollInterval overrides DefaultPollInterval.
+	PollInterval time.Duration
+
+	mu    sync.Mutex
+	dir   *directory
+	kid   string
+	nonce string
+}
+
+// ObtainCertificate orders a certificate for the domains, with the key of the
+// DER-encoded CSR, and returns the issued certificate chain, leaf first.
+func (c *Client) ObtainCertificate(ctx context.Context, domains []string, csrDER []byte) ([]*x509.Certificate, error) {
+	if c.Key == nil || c.Key.Curve != elliptic.P256() {
+		return nil, errors.New("acme: account key must be an ECDSA P-256 key")
+	}
+	if c.Responder == nil {
+		return nil, errors.New("acme: no http-01 challenge responder")
+	}
+	if len(domains) == 0 {
+		return nil, errors.New("acme: no domains to order a certificate for")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.register(ctx); err != nil {
+		return nil, err
+	}
+
+	ids := make([]identifier, 0, len(domains))
+	for _, d := range domains {
+		ids = append(ids, identifier{Type: "dns", Value: d})
+	}
+	var o order
+	resp, err := c.post(ctx, c.dir.NewOrder, map[string]any{"identifiers": ids}, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "acme: new order")
+	}
+	orderURL := resp.Header.Get("Location")
+
+	for _, authzURL := range o.Authorizations {
+		if err := c.authorize(ctx, authzURL); err != nil {
+			return nil, err
+		}
+	}
+
+	csr := base64.RawURLEncoding.EncodeToString(csrDER)
+	if _, err := c.post(ctx, o.Finalize, map[string]string{"csr": csr}, &o); err != nil {
+		return nil, errors.Wrap(err, "acme: finalize order")
+	}
+	for o.Status != "valid" {
+		switch o.Status {
+		case "pending", "ready", "processing":
+		default:
+			return nil, errors.Errorf("acme: order is %s: %v", o.Status, o.Error)
+		}
+		if orderURL == "" {
+			return nil, errors.New("acme: no order URL to poll")
+		}
+		if err := c.sleep(ctx); err != nil {
+			return nil, err
+		}
+		if _, err := c.post(ctx, orderURL, nil, &o); err != nil {
+			return nil, errors.Wrap(err, "acme: poll order")
+		}
+	}
+
+	return c.fetchChain(ctx, o.Certificate)
+}
+
+// register fetches the directory and registers the account, if not already
+// done. An account already registered for the key is reused.
+func (c *Client) register(ctx context.Context) error {
+	if c.kid != "" {
+		return nil
+	}
+
+	if c.dir == nil {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.DirectoryURL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := c.httpClient().Do(req)
+		if err != nil {
+			return errors.Wrap(err, "acme: fetching directory")
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return errors.Errorf("acme: fetching directory: %s", resp.Status)
+		}
+		dir := new(directory)
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxRespLen)).Decode(dir); err != nil {
+			return errors.Wrap(err, "acme: decoding directory")
+		}
+		if dir.NewNonce == "" || dir.NewAccount == "" || dir.NewOrder == "" {
+			return errors.New("acme: incomplete directory")
+		}
+		c.dir = dir
+	}
+
+	payload := map[string]any{"termsOfServiceAgreed": true}
+	if c.Email != "" {
+		payload["contact"] = []string{"mailto:" + c.Email}
+	}
+	resp, err := c.post(ctx, c.dir.NewAccount, payload, nil)
+	if err != nil {
+		return errors.Wrap(err, "acme: registering account")
+	}
+	c.kid = resp.Header.Get("Location")
+	if c.kid == "" {
+		return errors.New("acme: no account URL in registration response")
+	}
+	return nil
+}
+
+// authorize completes the http-01 challenge of a pending authorization.
+func (c *Client) authorize(ctx context.Context, authzURL string) error {
+	var authz authorization
+	if _, err := c.post(ctx, authzURL, nil, &authz); err != nil {
+		return errors.Wrap(err, "acme: fetching authorization")
+	}
+	if authz.Status == "valid" {
+		return nil
+	}
+
+	var chal *challenge
+	for i := range authz.Challenges {
+		if authz.Challenges[i].Type == "http-01" {
+			chal = &authz.Challenges[i]
+			break
+		}
+	}
+	if chal == nil {
+		return errors.Errorf("acme: no http-01 challenge offered for %s", authz.Identifier.Value)
+	}
+
+	thumbprint, err := JWKThumbprint(&c.Key.PublicKey)
+	if err != nil {
+		return err
+	}
+	c.Responder.set(chal.Token, chal.Token+"."+thumbprint)
+	defer c.Responder.remove(chal.Token)
+
+	if _, err := c.post(ctx, chal.URL, struct{}{}, nil); err != nil {
+		return errors.Wrapf(err, "acme: accepting challenge for %s", authz.Identifier.Value)
+	}
+	for authz.Status != "valid" {
+		if authz.Status != "pending" {
+			for _, ch := range authz.Challenges {
+				if ch.Error != nil {
+					return errors.Wrapf(ch.Error, "acme: authorization for %s is %s",
+						authz.Identifier.Value, authz.Status)
+				}
+			}
+			return errors.Errorf("acme: authorization for %s is %s", authz.Identifier.Value, authz.Status)
+		}
+		if err := c.sleep(ctx); err != nil {
+			return err
+		}
+		if _, err := c.post(ctx, authzURL, nil, &authz); err != nil {
+			return errors.Wrap(err, "acme: polling authorization")
+		}
+	}
+	return nil
+}
+
+// fetchChain downloads the PEM certificate chain of a valid order.
+func (c *Client) fetchChain(ctx context.Context, certURL string) ([]*x509.Certificate, error) {
+	if certURL == "" {
+		return nil, errors.New("acme: no certificate URL in valid order")
+	}
+	resp, err := c.post(ctx, certURL, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "acme: downloading certificate")
+	}
+
+	var chain []*x509.Certificate
+	rest := resp.body
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "acme: parsing certificate")
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("acme: no certificates in downloaded chain")
+	}
+	return chain, nil
+}
+
+type response struct {
+	*http.Response
+	body []byte
+}
+
+// post sends a JWS-signed request with the payload, or a POST-as-GET request
+// if it is nil, decoding the response into out if it is non-nil. A request
+// refused for a bad nonce is retried with a fresh one.
+func (c *Client) post(ctx context.Context, url string, payload any, out any) (*response, error) {
+	var err error
+	for try := 0; try < maxNonceTries; try++ {
+		var resp *response
+		resp, err = c.postOnce(ctx, url, payload)
+		if err == nil {
+			if out != nil {
+				if err := json.Unmarshal(resp.body, out); err != nil {
+					return nil, errors.Wrap(err, "decoding response")
+				}
+			}
+			return resp, nil
+		}
+		if !isBadNonce(err) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+func (c *Client) postOnce(ctx context.Context, url string, payload any) (*response, error) {
+	nonce, err := c.getNonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.signJWS(url, nonce, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	httpResp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if n := httpResp.Header.Get("Replay-Nonce"); n != "" {
+		c.nonce = n
+	}
+	respBody, err := io.ReadAll(io.LimitReader(httpResp.Body, maxRespLen))
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode >= 400 {
+		p := &Problem{Status: httpResp.StatusCode}
+		if json.Unmarshal(respBody, p) != nil || p.Type == "" {
+			return nil, errors.Errorf("%s: %s", httpResp.Status, strings.TrimSpace(string(respBody)))
+		}
+		return nil, p
+	}
+	return &response{Response: httpResp, body: respBody}, nil
+}
+
+// getNonce returns the nonce from the last response, or a new one.
+func (c *Client) getNonce(ctx context.Context) (string, error) {
+	if c.nonce != "" {
+		n := c.nonce
+		c.nonce = ""
+		return n, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.dir.NewNonce, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "acme: fetching nonce")
+	}
+	resp.Body.Close()
+	n := resp.Header.Get("Replay-Nonce")
+	if n == "" {
+		return "", errors.New("acme: no nonce in response")
+	}
+	return n, nil
+}
+
+// signJWS returns the flattened JWS of the payload, signed with the account
+// key. The key is identified by its account URL once registered, and by its
+// JWK before.
+func (c *Client) signJWS(url, nonce string, payload any) ([]byte, error) {
+	protected := map[string]any{
+		"alg":   "ES256",
+		"nonce": nonce,
+		"url":   url,
+	}
+	if c.kid != "" {
+		protected["kid"] = c.kid
+	} else {
+		k, err := jwk(&c.Key.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		protected["jwk"] = k
+	}
+	hdr, err := json.Marshal(protected)
+	if err != nil {
+		return nil, err
+	}
+
+	var payloadB64 string
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		payloadB64 = base64.RawURLEncoding.EncodeToString(data)
+	}
+	hdrB64 := base64.RawURLEncoding.EncodeToString(hdr)
+
+	digest := sha256.Sum256([]byte(hdrB64 + "." + payloadB64))
+	r, s, err := ecdsa.Sign(rand.Reader, c.Key, digest[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "acme: signing request")
+	}
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+
+	return json.Marshal(map[string]string{
+		"protected": hdrB64,
+		"payload":   payloadB64,
+		"signature": base64.RawURLEncoding.EncodeToString(sig),
+	})
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (c *Client) sleep(ctx context.Context) error {
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(interval):
+		return nil
+	}
+}
+
+// jwk returns the members of the JWK of the P-256 key.
+func jwk(pub *ecdsa.PublicKey) (map[string]string, error) {
+	ecdhPub, err := pub.ECDH()
+	if err != nil {
+		return nil, errors.Wrap(err, "acme: invalid account key")
+	}
+	// The uncompressed point is 0x04 || X || Y.
+	point := ecdhPub.Bytes()
+	return map[string]string{
+		"crv": "P-256",
+		"kty": "EC",
+		"x":   base64.RawURLEncoding.EncodeToString(point[1:33]),
+		"y":   base64.RawURLEncoding.EncodeToString(point[33:]),
+	}, nil
+}
+
+// JWKThumbprint returns the RFC 7638 thumbprint of the account key, as used in
+// the key authorizations of challenges.
+func JWKThumbprint(pub crypto.PublicKey) (string, error) {
+	ecPub, ok := pub.(*ecdsa.PublicKey)
+	if !ok || ecPub.Curve != elliptic.P256() {
+		return "", errors.New("acme: account key must be an ECDSA P-256 key")
+	}
+	k, err := jwk(ecPub)
+	if err != nil {
+		return "", err
+	}
+	// The members are in lexicographic order, without whitespace.
+	data := fmt.Sprintf(`{"crv":"%s","kty":"%s","x":"%s","y":"%s"}`, k["crv"], k["kty"], k["x"], k["y"])
+	sum := sha256.Sum256([]byte(data))
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
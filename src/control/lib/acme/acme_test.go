@@ -0,0 +1,423 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package acme
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+// fakeACME is a minimal ACME server, which validates the http-01 challenge by
+// calling the client's responder directly.
+type fakeACME struct {
+	t         *testing.T
+	srv       *httptest.Server
+	responder *HTTP01Responder
+	caKey     *ecdsa.PrivateKey
+	ca        *x509.Certificate
+
+	mu          sync.Mutex
+	nonces      map[string]bool
+	nextNonce   int
+	accountKey  *ecdsa.PublicKey
+	token       string
+	authzStatus string
+	orderStatus string
+	certPEM     []byte
+	badNonces   int // number of requests to refuse with badNonce
+	failAuthz   bool
+}
+
+func newFakeACME(t *testing.T, responder *HTTP01Responder) *fakeACME {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test ACME CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := &fakeACME{
+		t:           t,
+		responder:   responder,
+		caKey:       caKey,
+		ca:          ca,
+		nonces:      make(map[string]bool),
+		token:       "token-1",
+		authzStatus: "pending",
+		orderStatus: "pending",
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/directory", f.directory)
+	mux.HandleFunc("/nonce", f.newNonce)
+	mux.HandleFunc("/", f.signed)
+	f.srv = httptest.NewServer(mux)
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+func (f *fakeACME) url(path string) string {
+	return f.srv.URL + path
+}
+
+func (f *fakeACME) nonce(w http.ResponseWriter) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := fmt.Sprintf("nonce-%d", f.nextNonce)
+	f.nextNonce++
+	f.nonces[n] = true
+	w.Header().Set("Replay-Nonce", n)
+}
+
+func (f *fakeACME) problem(w http.ResponseWriter, typ string, status int) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(&Problem{Type: typ, Detail: "refused by test", Status: status})
+}
+
+func (f *fakeACME) directory(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]string{
+		"newNonce":   f.url("/nonce"),
+		"newAccount": f.url("/account"),
+		"newOrder":   f.url("/order"),
+	})
+}
+
+func (f *fakeACME) newNonce(w http.ResponseWriter, r *http.Request) {
+	f.nonce(w)
+}
+
+// verify checks the JWS of the request, returning its payload.
+func (f *fakeACME) verify(r *http.Request) ([]byte, error) {
+	var jws struct {
+		Protected string `json:"protected"`
+		Payload   string `json:"payload"`
+		Signature string `json:"signature"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&jws); err != nil {
+		return nil, err
+	}
+	hdrJSON, err := base64.RawURLEncoding.DecodeString(jws.Protected)
+	if err != nil {
+		return nil, err
+	}
+	var hdr struct {
+		Alg   string            `json:"alg"`
+		Nonce string            `json:"nonce"`
+		URL   string            `json:"url"`
+		Kid   string            `json:"kid"`
+		JWK   map[string]string `json:"jwk"`
+	}
+	if err := json.Unmarshal(hdrJSON, &hdr); err != nil {
+		return nil, err
+	}
+	if hdr.Alg != "ES256" || hdr.URL != f.url(r.URL.Path) {
+		return nil, errors.Errorf("bad protected header %+v", hdr)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.nonces[hdr.Nonce] {
+		return nil, errors.Errorf("unknown nonce %q", hdr.Nonce)
+	}
+	delete(f.nonces, hdr.Nonce)
+
+	pub := f.accountKey
+	if hdr.Kid == "" {
+		x, _ := base64.RawURLEncoding.DecodeString(hdr.JWK["x"])
+		y, _ := base64.RawURLEncoding.DecodeString(hdr.JWK["y"])
+		pub = &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+		f.accountKey = pub
+	} else if hdr.Kid != f.url("/account/1") {
+		return nil, errors.Errorf("unknown account %q", hdr.Kid)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(jws.Signature)
+	if err != nil || len(sig) != 64 {
+		return nil, errors.New("bad signature encoding")
+	}
+	digest := sha256.Sum256([]byte(jws.Protected + "." + jws.Payload))
+	if !ecdsa.Verify(pub, digest[:], new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])) {
+		return nil, errors.New("bad signature")
+	}
+	return base64.RawURLEncoding.DecodeString(jws.Payload)
+}
+
+func (f *fakeACME) signed(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	refuse := f.badNonces > 0
+	if refuse {
+		f.badNonces--
+	}
+	f.mu.Unlock()
+	if refuse {
+		f.nonce(w)
+		f.problem(w, "urn:ietf:params:acme:error:badNonce", http.StatusBadRequest)
+		return
+	}
+
+	payload, err := f.verify(r)
+	f.nonce(w)
+	if err != nil {
+		f.t.Logf("refused request: %s", err)
+		f.problem(w, "urn:ietf:params:acme:error:malformed", http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.URL.Path {
+	case "/account":
+		w.Header().Set("Location", f.url("/account/1"))
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"status":"valid"}`)
+	case "/order":
+		w.Header().Set("Location", f.url("/order/1"))
+		w.WriteHeader(http.StatusCreated)
+		f.writeOrder(w)
+	case "/order/1":
+		f.writeOrder(w)
+	case "/authz/1":
+		json.NewEncoder(w).Encode(map[string]any{
+			"status":     f.authzStatus,
+			"identifier": map[string]string{"type": "dns", "value": "client-1.example.com"},
+			"challenges": []map[string]string{
+				{"type": "dns-01", "url": f.url("/chal/dns"), "token": "other"},
+				{"type": "http-01", "url": f.url("/chal/1"), "token": f.token},
+			},
+		})
+	case "/chal/1":
+		// Validate the challenge as the server would, by fetching it.
+		rec := httptest.NewRecorder()
+		f.responder.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ChallengePath+f.token, nil))
+		thumbprint, _ := JWKThumbprint(f.accountKey)
+		if f.failAuthz || rec.Body.String() != f.token+"."+thumbprint {
+			f.authzStatus = "invalid"
+		} else {
+			f.authzStatus = "valid"
+			f.orderStatus = "ready"
+		}
+		io.WriteString(w, `{"status":"processing"}`)
+	case "/finalize/1":
+		var req struct {
+			CSR string `json:"csr"`
+		}
+		if err := json.Unmarshal(payload, &req); err != nil || f.orderStatus != "ready" {
+			f.problem(w, "urn:ietf:params:acme:error:orderNotReady", http.StatusForbidden)
+			return
+		}
+		if err := f.issue(req.CSR); err != nil {
+			f.problem(w, "urn:ietf:params:acme:error:badCSR", http.StatusBadRequest)
+			return
+		}
+		f.orderStatus = "processing"
+		f.writeOrder(w)
+		f.orderStatus = "valid"
+	case "/cert/1":
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		w.Write(f.certPEM)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeACME) writeOrder(w http.ResponseWriter) {
+	o := map[string]any{
+		"status":         f.orderStatus,
+		"authorizations": []string{f.url("/authz/1")},
+		"finalize":       f.url("/finalize/1"),
+	}
+	if f.orderStatus == "valid" {
+		o["certificate"] = f.url("/cert/1")
+	}
+	json.NewEncoder(w).Encode(o)
+}
+
+func (f *fakeACME) issue(csrB64 string) error {
+	der, err := base64.RawURLEncoding.DecodeString(csrB64)
+	if err != nil {
+		return err
+	}
+	csr, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		return err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      csr.Subject,
+		DNSNames:     csr.DNSNames,
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, f.ca, csr.PublicKey, f.caKey)
+	if err != nil {
+		return err
+	}
+	f.certPEM = append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: f.ca.Raw})...)
+	return nil
+}
+
+func TestACME_Client_ObtainCertificate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		badNonces int
+		failAuthz bool
+		noKey     bool
+		expErr    error
+	}{
+		"success": {},
+		"bad nonce retried": {
+			badNonces: 1,
+		},
+		"bad nonce retries exhausted": {
+			badNonces: maxNonceTries,
+			expErr:    errors.New("badNonce"),
+		},
+		"challenge fails": {
+			failAuthz: true,
+			expErr:    errors.New("authorization for client-1.example.com is invalid"),
+		},
+		"no account key": {
+			noKey:  true,
+			expErr: errors.New("account key must be an ECDSA P-256 key"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			responder := NewHTTP01Responder()
+			srv := newFakeACME(t, responder)
+			srv.badNonces = tc.badNonces
+			srv.failAuthz = tc.failAuthz
+
+			accountKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.noKey {
+				accountKey = nil
+			}
+			certKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+				Subject:  pkix.Name{CommonName: "agent"},
+				DNSNames: []string{"client-1.example.com"},
+			}, certKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := &Client{
+				DirectoryURL: srv.url("/directory"),
+				Key:          accountKey,
+				Email:        "admin@example.com",
+				Responder:    responder,
+				PollInterval: time.Millisecond,
+			}
+			chain, err := client.ObtainCertificate(test.Context(t), []string{"client-1.example.com"}, csr)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, 2, len(chain), "unexpected chain length")
+			test.AssertTrue(t, certKey.PublicKey.Equal(chain[0].PublicKey), "certificate not issued for the CSR key")
+			test.AssertTrue(t, chain[1].Equal(srv.ca), "CA certificate not in chain")
+
+			// The challenge response is withdrawn once complete.
+			rec := httptest.NewRecorder()
+			responder.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ChallengePath+srv.token, nil))
+			test.AssertEqual(t, http.StatusNotFound, rec.Code, "challenge response still served")
+		})
+	}
+}
+
+func TestACME_HTTP01Responder(t *testing.T) {
+	r := NewHTTP01Responder()
+	r.set("tok", "tok.thumb")
+
+	for path, exp := range map[string]string{
+		ChallengePath + "tok":   "tok.thumb",
+		ChallengePath + "other": "",
+		"/tok":                  "",
+	} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if exp == "" {
+			test.AssertEqual(t, http.StatusNotFound, rec.Code, "unexpected status for "+path)
+			continue
+		}
+		test.AssertEqual(t, exp, strings.TrimSpace(rec.Body.String()), "unexpected response for "+path)
+	}
+}
+
+func TestACME_JWKThumbprint(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := JWKThumbprint(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k, err := jwk(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonical, err := json.Marshal(k) // maps are marshaled with sorted keys
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(canonical)
+	test.AssertEqual(t, base64.RawURLEncoding.EncodeToString(sum[:]), got, "unexpected thumbprint")
+
+	if _, err := JWKThumbprint("not a key"); err == nil {
+		t.Fatal("expected non-key to be refused")
+	}
+}
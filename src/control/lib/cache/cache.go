@@ -38,6 +38,13 @@ type (
 		RefreshIfNeeded(ctx context.Context) (bool, error)
 	}
 
+	// EvictableItem is an Item that is notified when it is removed from the
+	// cache, e.g. to release or scrub the data it holds.
+	EvictableItem interface {
+		Item
+		Evict()
+	}
+
 	// ItemCache is a mechanism for caching Items to keys.
 	ItemCache struct {
 		log   logging.Logger
@@ -73,9 +80,22 @@ func (ic *ItemCache) Set(item Item) error {
 }
 
 func (ic *ItemCache) set(item Item) {
+	if old, found := ic.items[item.Key()]; found && old != item {
+		evict(old)
+	}
 	ic.items[item.Key()] = item
 }
 
+// evict notifies the item that it has been removed from the cache, once any
+// caller holding the item has released it.
+func evict(item Item) {
+	if ei, ok := item.(EvictableItem); ok {
+		ei.Lock()
+		defer ei.Unlock()
+		ei.Evict()
+	}
+}
+
 // Delete fully deletes an Item from the cache.
 func (ic *ItemCache) Delete(key string) {
 	if ic == nil {
@@ -85,7 +105,37 @@ func (ic *ItemCache) Delete(key string) {
 	ic.mutex.Lock()
 	defer ic.mutex.Unlock()
 
-	delete(ic.items, key)
+	if item, found := ic.items[key]; found {
+		delete(ic.items, key)
+		evict(item)
+	}
+}
+
+// DeleteExpired deletes all expired items from the cache, returning the number
+// deleted, rather than waiting for them to be looked up. If set, onDelete is
+// called with each item deleted before it is evicted, while the cache is still
+// locked, so that no other item can be cached under its key in the meantime.
+func (ic *ItemCache) DeleteExpired(onDelete func(Item)) int {
+	if ic == nil {
+		return 0
+	}
+
+	ic.mutex.Lock()
+	defer ic.mutex.Unlock()
+
+	var deleted int
+	for key, item := range ic.items {
+		if ei, ok := item.(ExpirableItem); !ok || !ei.IsExpired() {
+			continue
+		}
+		delete(ic.items, key)
+		if onDelete != nil {
+			onDelete(item)
+		}
+		evict(item)
+		deleted++
+	}
+	return deleted
 }
 
 // Has checks whether any item is cached under the given key.
@@ -101,6 +151,19 @@ func (ic *ItemCache) Has(key string) bool {
 	return found
 }
 
+// Len returns the number of items in the cache, including any which have
+// expired but not yet been removed.
+func (ic *ItemCache) Len() int {
+	if ic == nil {
+		return 0
+	}
+
+	ic.mutex.RLock()
+	defer ic.mutex.RUnlock()
+
+	return len(ic.items)
+}
+
 // Keys returns a sorted list of all keys in the cache.
 func (ic *ItemCache) Keys() []string {
 	if ic == nil {
@@ -220,6 +283,7 @@ func (ic *ItemCache) get(key string) (Item, error) {
 	if ok {
 		if ei, ok := item.(ExpirableItem); ok && ei.IsExpired() {
 			delete(ic.items, key)
+			evict(item)
 		} else {
 			return item, nil
 		}
@@ -513,6 +513,7 @@ func TestCache_ItemCache_Keys(t *testing.T) {
 			if diff := cmp.Diff(tc.expKeys, keys); diff != "" {
 				t.Fatalf("-want, +got:\n%s", diff)
 			}
+			test.AssertEqual(t, len(tc.expKeys), ic.Len(), "unexpected length")
 		})
 	}
 }
@@ -571,3 +572,106 @@ func TestCache_ItemCache_Delete(t *testing.T) {
 		})
 	}
 }
+
+type mockEvictableItem struct {
+	mockItem
+	expired bool
+	evicted int
+}
+
+func (m *mockEvictableItem) IsExpired() bool {
+	return m.expired
+}
+
+func (m *mockEvictableItem) Evict() {
+	m.evicted++
+}
+
+func TestCache_ItemCache_Evict(t *testing.T) {
+	for name, tc := range map[string]struct {
+		item       *mockEvictableItem
+		action     func(*testing.T, *ItemCache, *mockEvictableItem)
+		expEvicted int
+	}{
+		"deleted": {
+			item: &mockEvictableItem{mockItem: mockItem{ItemKey: "one"}},
+			action: func(_ *testing.T, ic *ItemCache, _ *mockEvictableItem) {
+				ic.Delete("one")
+			},
+			expEvicted: 1,
+		},
+		"replaced": {
+			item: &mockEvictableItem{mockItem: mockItem{ItemKey: "one"}},
+			action: func(_ *testing.T, ic *ItemCache, _ *mockEvictableItem) {
+				ic.Set(&mockEvictableItem{mockItem: mockItem{ItemKey: "one"}})
+			},
+			expEvicted: 1,
+		},
+		"set again": {
+			item: &mockEvictableItem{mockItem: mockItem{ItemKey: "one"}},
+			action: func(_ *testing.T, ic *ItemCache, item *mockEvictableItem) {
+				ic.Set(item)
+			},
+		},
+		"expired": {
+			item: &mockEvictableItem{mockItem: mockItem{ItemKey: "one"}, expired: true},
+			action: func(t *testing.T, ic *ItemCache, _ *mockEvictableItem) {
+				ic.Get(test.Context(t), "one")
+			},
+			expEvicted: 1,
+		},
+		"unexpired": {
+			item: &mockEvictableItem{mockItem: mockItem{ItemKey: "one"}},
+			action: func(t *testing.T, ic *ItemCache, _ *mockEvictableItem) {
+				_, release, _ := ic.Get(test.Context(t), "one")
+				release()
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			ic := NewItemCache(log)
+			if err := ic.Set(tc.item); err != nil {
+				t.Fatal(err)
+			}
+
+			tc.action(t, ic, tc.item)
+
+			test.AssertEqual(t, tc.expEvicted, tc.item.evicted, "unexpected evictions")
+		})
+	}
+}
+
+func TestCache_ItemCache_DeleteExpired(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	var nilCache *ItemCache
+	test.AssertEqual(t, 0, nilCache.DeleteExpired(nil), "nil cache has no items")
+
+	ic := NewItemCache(log)
+	expired := &mockEvictableItem{mockItem: mockItem{ItemKey: "expired"}, expired: true}
+	unexpired := &mockEvictableItem{mockItem: mockItem{ItemKey: "unexpired"}}
+	for _, item := range []Item{expired, unexpired, &mockItem{ItemKey: "plain"}} {
+		if err := ic.Set(item); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var deletedKeys []string
+	deleted := ic.DeleteExpired(func(item Item) {
+		// The cache remains locked while the item is being deleted.
+		test.AssertFalse(t, ic.mutex.TryLock(), "cache not locked")
+		deletedKeys = append(deletedKeys, item.Key())
+	})
+
+	test.AssertEqual(t, 1, deleted, "unexpected number deleted")
+	test.CmpAny(t, "deleted keys", []string{"expired"}, deletedKeys)
+	test.AssertEqual(t, 1, expired.evicted, "expired item not evicted")
+	test.AssertEqual(t, 0, unexpired.evicted, "unexpired item evicted")
+	test.CmpAny(t, "keys", []string{"plain", "unexpired"}, ic.Keys())
+
+	test.AssertEqual(t, 0, ic.DeleteExpired(nil), "nothing left to delete")
+}
@@ -0,0 +1,137 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package cache
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// ShardedItemCache spreads Items across a number of ItemCaches by key, so that
+// operations on items in different shards do not contend for the same lock.
+type ShardedItemCache struct {
+	shards []*ItemCache
+}
+
+// NewShardedItemCache creates a new ShardedItemCache with the given number of
+// shards. At least one shard is always created.
+func NewShardedItemCache(log logging.Logger, numShards int) *ShardedItemCache {
+	sc := &ShardedItemCache{
+		shards: make([]*ItemCache, max(numShards, 1)),
+	}
+	for i := range sc.shards {
+		sc.shards[i] = NewItemCache(log)
+	}
+	return sc
+}
+
+func (sc *ShardedItemCache) shard(key string) *ItemCache {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return sc.shards[h.Sum32()%uint32(len(sc.shards))]
+}
+
+// Set caches an item under a given key.
+func (sc *ShardedItemCache) Set(item Item) error {
+	if sc == nil {
+		return errors.New("ShardedItemCache is nil")
+	}
+
+	if common.InterfaceIsNil(item) || item.Key() == "" {
+		return errors.New("invalid item")
+	}
+
+	return sc.shard(item.Key()).Set(item)
+}
+
+// Delete fully removes an item from the cache.
+func (sc *ShardedItemCache) Delete(key string) {
+	if sc == nil {
+		return
+	}
+
+	sc.shard(key).Delete(key)
+}
+
+// DeleteExpired deletes all expired items from the cache, returning the number
+// deleted. If set, onDelete is called with each item deleted, while its shard
+// is still locked.
+func (sc *ShardedItemCache) DeleteExpired(onDelete func(Item)) int {
+	if sc == nil {
+		return 0
+	}
+
+	var deleted int
+	for _, shard := range sc.shards {
+		deleted += shard.DeleteExpired(onDelete)
+	}
+	return deleted
+}
+
+// Has checks whether any item is cached under the given key.
+func (sc *ShardedItemCache) Has(key string) bool {
+	if sc == nil {
+		return false
+	}
+
+	return sc.shard(key).Has(key)
+}
+
+// Len returns the number of items in the cache, including any which have
+// expired but not yet been removed.
+func (sc *ShardedItemCache) Len() int {
+	if sc == nil {
+		return 0
+	}
+
+	var n int
+	for _, shard := range sc.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+// Keys returns a sorted list of all keys in the cache.
+func (sc *ShardedItemCache) Keys() []string {
+	if sc == nil {
+		return nil
+	}
+
+	keys := []string{}
+	for _, shard := range sc.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GetOrCreate returns an item from the cache if it exists, otherwise it creates
+// the item using the given function and caches it. The item must be released
+// by the caller when it is safe to be modified.
+func (sc *ShardedItemCache) GetOrCreate(ctx context.Context, key string, missFn ItemCreateFunc) (Item, func(), error) {
+	if sc == nil {
+		return nil, noopRelease, errors.New("nil ShardedItemCache")
+	}
+
+	return sc.shard(key).GetOrCreate(ctx, key, missFn)
+}
+
+// Get returns an item from the cache if it exists, otherwise it returns an
+// error. The item must be released by the caller when it is safe to be modified.
+func (sc *ShardedItemCache) Get(ctx context.Context, key string) (Item, func(), error) {
+	if sc == nil {
+		return nil, noopRelease, errors.New("nil ShardedItemCache")
+	}
+
+	return sc.shard(key).Get(ctx, key)
+}
@@ -0,0 +1,130 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestCache_NewShardedItemCache(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	for name, tc := range map[string]struct {
+		numShards int
+		expShards int
+	}{
+		"zero": {
+			expShards: 1,
+		},
+		"negative": {
+			numShards: -1,
+			expShards: 1,
+		},
+		"several": {
+			numShards: 8,
+			expShards: 8,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sc := NewShardedItemCache(log, tc.numShards)
+			test.AssertEqual(t, tc.expShards, len(sc.shards), "unexpected number of shards")
+		})
+	}
+}
+
+func TestCache_ShardedItemCache(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	sc := NewShardedItemCache(log, 4)
+	var expKeys []string
+	for i := 0; i < 20; i++ {
+		key := fmt.Sprintf("key-%02d", i)
+		if err := sc.Set(&mockItem{ItemKey: key}); err != nil {
+			t.Fatal(err)
+		}
+		expKeys = append(expKeys, key)
+	}
+	sort.Strings(expKeys)
+
+	var used int
+	for _, shard := range sc.shards {
+		if shard.Len() > 0 {
+			used++
+		}
+	}
+	test.AssertTrue(t, used > 1, "items not spread across shards")
+	test.AssertEqual(t, len(expKeys), sc.Len(), "unexpected number of items")
+	test.CmpAny(t, "keys", expKeys, sc.Keys())
+	test.AssertTrue(t, sc.Has("key-03"), "item not found")
+
+	item, release, err := sc.Get(test.Context(t), "key-03")
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	test.AssertEqual(t, "key-03", item.Key(), "unexpected item")
+
+	sc.Delete("key-03")
+	test.AssertFalse(t, sc.Has("key-03"), "item not deleted")
+	if _, _, err := sc.Get(test.Context(t), "key-03"); err == nil {
+		t.Fatal("expected error getting deleted item")
+	}
+
+	var created bool
+	item, release, err = sc.GetOrCreate(test.Context(t), "key-03", func() (Item, error) {
+		created = true
+		return &mockItem{ItemKey: "key-03"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	test.AssertTrue(t, created, "item not created")
+	test.AssertTrue(t, sc.Has(item.Key()), "created item not cached")
+
+	test.CmpErr(t, errors.New("invalid item"), sc.Set(nil))
+
+	for i := 0; i < 10; i++ {
+		key := fmt.Sprintf("expired-%02d", i)
+		if err := sc.Set(&mockEvictableItem{mockItem: mockItem{ItemKey: key}, expired: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var deleted int
+	test.AssertEqual(t, 10, sc.DeleteExpired(func(Item) { deleted++ }), "unexpected number deleted")
+	test.AssertEqual(t, 10, deleted, "unexpected number of deletion callbacks")
+	test.AssertEqual(t, len(expKeys), sc.Len(), "unexpired items deleted")
+}
+
+func TestCache_ShardedItemCache_Nil(t *testing.T) {
+	var sc *ShardedItemCache
+
+	test.CmpErr(t, errors.New("nil"), sc.Set(&mockItem{ItemKey: "key"}))
+	sc.Delete("key")
+	test.AssertFalse(t, sc.Has("key"), "nil cache has no items")
+	test.AssertEqual(t, 0, sc.Len(), "nil cache has no items")
+	test.AssertEqual(t, 0, len(sc.Keys()), "nil cache has no keys")
+	test.AssertEqual(t, 0, sc.DeleteExpired(nil), "nil cache has no items")
+	if _, _, err := sc.Get(context.Background(), "key"); err == nil {
+		t.Fatal("expected error from nil cache")
+	}
+	if _, _, err := sc.GetOrCreate(context.Background(), "key", func() (Item, error) {
+		return &mockItem{ItemKey: "key"}, nil
+	}); err == nil {
+		t.Fatal("expected error from nil cache")
+	}
+}
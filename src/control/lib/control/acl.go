@@ -14,6 +14,17 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+const (
+	// ACE field indices
+	aceType = iota
+	aceFlags
+	acePrincipal
+	acePerms
+	aceNumFields // Must be last
 )
 
 // AccessControlList is a structure for the access control list.
@@ -102,7 +113,11 @@ func ParseACL(reader io.Reader) (*AccessControlList, error) {
 
 		line := strings.TrimSpace(scanner.Text())
 		if line != "" && !isACLFileComment(line) {
-			aceList = append(aceList, line)
+			ace, err := EncodeACE(line)
+			if err != nil {
+				return nil, err
+			}
+			aceList = append(aceList, ace)
 		}
 	}
 
@@ -131,12 +146,69 @@ func FormatACL(acl *AccessControlList, verbose bool) string {
 		if verbose {
 			fmt.Fprintf(&builder, "# %s\n", getVerboseACE(ace))
 		}
-		fmt.Fprintf(&builder, "%s\n", ace)
+		fmt.Fprintf(&builder, "%s\n", DecodeACE(ace))
 	}
 
 	return builder.String()
 }
 
+// EncodeACE converts an ACE whose principal is a non-Unix identity, e.g.
+// "A::oidc:alice@idp.example:rw", to the ACE for the group standing for the
+// identity, which the server adds to the groups of users with the identity.
+// Other ACEs are returned unchanged.
+func EncodeACE(ace string) (string, error) {
+	fields := strings.Split(ace, ":")
+	if len(fields) <= aceNumFields {
+		return ace, nil
+	}
+
+	last := len(fields) - 1
+	principal := strings.Join(fields[acePrincipal:last], ":")
+	idType, name, _ := strings.Cut(principal, ":")
+	id, err := auth.NewIdentity(idType, name)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid ACE %q", ace)
+	}
+
+	flags := fields[aceFlags]
+	if !strings.ContainsAny(flags, "Gg") {
+		flags += "G"
+	}
+	return strings.Join([]string{fields[aceType], flags, auth.IdentityPrincipal(id), fields[last]}, ":"), nil
+}
+
+// EncodePrincipal converts a principal which is a non-Unix identity, e.g.
+// "oidc:alice@idp.example", to the group principal standing for the identity.
+// Other principals are returned unchanged.
+func EncodePrincipal(principal string) (string, error) {
+	idType, name, found := strings.Cut(strings.TrimPrefix(principal, "g:"), ":")
+	if !found || !auth.IsIdentityType(idType) {
+		return principal, nil
+	}
+
+	id, err := auth.NewIdentity(idType, name)
+	if err != nil {
+		return "", err
+	}
+	return "g:" + auth.IdentityPrincipal(id), nil
+}
+
+// DecodeACE converts an ACE for the group standing for a non-Unix identity
+// back to the form in which it was written. Other ACEs are returned unchanged.
+func DecodeACE(ace string) string {
+	fields := strings.Split(ace, ":")
+	if len(fields) != aceNumFields {
+		return ace
+	}
+
+	id, err := auth.ParseIdentityPrincipal(fields[acePrincipal])
+	if err != nil || id == nil {
+		return ace
+	}
+	fields[acePrincipal] = auth.IdentityString(id)
+	return strings.Join(fields, ":")
+}
+
 // FormatACLDefault formats the AccessControlList in non-verbose mode.
 func FormatACLDefault(acl *AccessControlList) string {
 	return FormatACL(acl, false)
@@ -147,32 +219,23 @@ func getVerboseACE(shortACE string) string {
 		return ""
 	}
 
-	const (
-		// Field indices
-		ACETypes = iota
-		ACEFlags
-		ACEIdentity
-		ACEPerms
-		ACENumFields // Must be last
-	)
-
 	fields := strings.Split(shortACE, ":")
-	if len(fields) != ACENumFields {
+	if len(fields) != aceNumFields {
 		return "invalid ACE"
 	}
 
 	var b strings.Builder
 
-	fmt.Fprintf(&b, "%s:", getVerboseType(fields[ACETypes]))
+	fmt.Fprintf(&b, "%s:", getVerboseType(fields[aceType]))
 
-	if fields[ACEFlags] != "" {
-		b.WriteString(getVerboseFlags(fields[ACEFlags]))
+	if fields[aceFlags] != "" {
+		b.WriteString(getVerboseFlags(fields[aceFlags]))
 	}
 	b.WriteString(":")
 
-	fmt.Fprintf(&b, "%s:", getVerboseIdentity(fields[ACEIdentity]))
+	fmt.Fprintf(&b, "%s:", getVerboseIdentity(fields[acePrincipal]))
 
-	b.WriteString(getVerbosePermissions(fields[ACEPerms]))
+	b.WriteString(getVerbosePermissions(fields[acePerms]))
 
 	return b.String()
 }
@@ -205,6 +268,8 @@ func getVerboseIdentity(field string) string {
 		identity = "None"
 	} else if v, ok := specialIDs[identity]; ok {
 		identity = v
+	} else if id, err := auth.ParseIdentityPrincipal(identity); err == nil && id != nil {
+		identity = auth.IdentityString(id)
 	}
 
 	return identity
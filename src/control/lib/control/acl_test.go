@@ -194,6 +194,113 @@ func TestControl_ParseACL_MultiValidACE(t *testing.T) {
 	}
 }
 
+func TestControl_ParseACL_Identities(t *testing.T) {
+	mockFile := &mockReader{
+		text: "A::OWNER@:rw\nA::oidc:alice@idp.example:rw\nA:G:krb5:bob@EXAMPLE.COM:r\n",
+	}
+
+	result, err := ParseACL(mockFile)
+	if err != nil {
+		t.Fatalf("Expected no error, got '%s'", err.Error())
+	}
+
+	expectedACL := &AccessControlList{
+		Entries: []string{
+			"A::OWNER@:rw",
+			"A:G:oidc%3Aalice%40idp.example@identity:rw",
+			"A:G:krb5%3Abob%40EXAMPLE.COM@identity:r",
+		},
+	}
+	if diff := cmp.Diff(expectedACL, result); diff != "" {
+		t.Errorf("Unexpected ACL: %v\n", diff)
+	}
+
+	_, err = ParseACL(&mockReader{text: "A::odic:alice@idp.example:rw\n"})
+	test.CmpErr(t, errors.New("unknown identity type"), err)
+}
+
+func TestControl_EncodeACE(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ace    string
+		expACE string
+		expErr error
+	}{
+		"user": {
+			ace:    "A::friendlyuser@:rw",
+			expACE: "A::friendlyuser@:rw",
+		},
+		"not all fields": {
+			ace:    "A::friendlyuser@",
+			expACE: "A::friendlyuser@",
+		},
+		"OIDC subject": {
+			ace:    "A::oidc:alice@idp.example:rw",
+			expACE: "A:G:oidc%3Aalice%40idp.example@identity:rw",
+		},
+		"SPIFFE ID with group flag": {
+			ace:    "A:G:spiffe:example.org/ns/prod/sa/app:r",
+			expACE: "A:G:spiffe%3Aexample.org/ns/prod/sa/app@identity:r",
+		},
+		"Keystone user with lowercase group flag": {
+			ace:    "A:g:keystone:alice@Default:r",
+			expACE: "A:g:keystone%3Aalice%40Default@identity:r",
+		},
+		"unknown identity type": {
+			ace:    "A::friendlyuser@:rw:xyz",
+			expErr: errors.New("unknown identity type"),
+		},
+		"no identity name": {
+			ace:    "A::oidc::rw",
+			expErr: errors.New("invalid oidc identity"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ace, err := EncodeACE(tc.ace)
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expACE, ace, "unexpected ACE")
+			if tc.expErr == nil && tc.ace != ace {
+				test.AssertEqual(t, strings.Replace(tc.ace, "A::", "A:G:", 1), DecodeACE(ace),
+					"ACE not decoded")
+			}
+		})
+	}
+}
+
+func TestControl_EncodePrincipal(t *testing.T) {
+	for name, tc := range map[string]struct {
+		principal    string
+		expPrincipal string
+		expErr       error
+	}{
+		"user": {
+			principal:    "u:friendlyuser@",
+			expPrincipal: "u:friendlyuser@",
+		},
+		"special": {
+			principal:    "OWNER@",
+			expPrincipal: "OWNER@",
+		},
+		"identity": {
+			principal:    "oidc:alice@idp.example",
+			expPrincipal: "g:oidc%3Aalice%40idp.example@identity",
+		},
+		"identity as group": {
+			principal:    "g:krb5:bob@EXAMPLE.COM",
+			expPrincipal: "g:krb5%3Abob%40EXAMPLE.COM@identity",
+		},
+		"invalid identity": {
+			principal: "oidc:",
+			expErr:    errors.New("invalid oidc identity"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			principal, err := EncodePrincipal(tc.principal)
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expPrincipal, principal, "unexpected principal")
+		})
+	}
+}
+
 func TestControl_ParseACL_ErrorReadingFile(t *testing.T) {
 	expectedError := "mockErrorReader error"
 	mockFile := &mockErrorReader{
@@ -313,6 +420,17 @@ func TestControl_FormatACL(t *testing.T) {
 				"A:G:readers@:r\n",
 			verbose: true,
 		},
+		"identity verbose": {
+			acl: &AccessControlList{
+				Entries: []string{
+					"A:G:oidc%3Aalice%40idp.example@identity:rw",
+				},
+			},
+			expStr: "# Entries:\n" +
+				"# Allow:Group:oidc:alice@idp.example:Read/Write\n" +
+				"A:G:oidc:alice@idp.example:rw\n",
+			verbose: true,
+		},
 		"with owner user": {
 			acl: &AccessControlList{
 				Entries: []string{
@@ -379,6 +497,10 @@ func TestControl_GetVerboseACE(t *testing.T) {
 			shortACE: "A:G:mynicegroup@:r",
 			expStr:   "Allow:Group:mynicegroup@:Read",
 		},
+		"identity": {
+			shortACE: "A:G:spiffe%3Aexample.org/app@identity:r",
+			expStr:   "Allow:Group:spiffe:example.org/app:Read",
+		},
 		"everyone": {
 			shortACE: "A::EVERYONE@:r",
 			expStr:   "Allow::Everyone:Read",
@@ -243,6 +243,7 @@ type (
 		AlternateClientNetHints []ClientNetworkHint   `json:"secondary_client_net_hints"`
 		BuildInfo               BuildInfo             `json:"build_info"`
 		ValidAuthFlavors        []auth.Flavor         `json:"valid_auth_flavors"`
+		SignatureAlgorithms     []string              `json:"signature_algorithms"`
 	}
 )
 
@@ -28,6 +28,7 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/daos"
 	"github.com/daos-stack/daos/src/control/lib/hostlist"
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/security/auth"
 	"github.com/daos-stack/daos/src/control/system"
 )
 
@@ -1246,3 +1247,400 @@ func SystemGetProp(ctx context.Context, rpcClient UnaryInvoker, req *SystemGetPr
 
 	return resp, nil
 }
+
+type (
+	// SignAgentCertReq contains the inputs for the sign agent certificate request.
+	SignAgentCertReq struct {
+		unaryRequest
+		msRequest
+
+		CSR []byte // DER-encoded certificate signing request
+	}
+
+	// SignAgentCertResp contains the renewed agent certificate and the
+	// certificate of the CA which signed it, both DER-encoded.
+	SignAgentCertResp struct {
+		Cert   []byte
+		CACert []byte
+	}
+)
+
+// SignAgentCert requests that the management service sign a renewal of the
+// agent certificate the request is made with, for the key of the CSR.
+func SignAgentCert(ctx context.Context, rpcClient UnaryInvoker, req *SignAgentCertReq) (*SignAgentCertResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+	if len(req.CSR) == 0 {
+		return nil, errors.New("empty certificate signing request")
+	}
+
+	pbReq := &mgmtpb.SignAgentCertReq{
+		Sys: req.getSystem(rpcClient),
+		Csr: req.CSR,
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SignAgentCert(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS SignAgentCert request: %d byte CSR", len(pbReq.Csr))
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := ur.getMSResponse()
+	if err != nil {
+		return nil, errors.Wrap(err, "sign agent certificate failed")
+	}
+
+	pbResp, ok := msg.(*mgmtpb.SignAgentCertResp)
+	if !ok {
+		return nil, errors.Errorf("unexpected response type: %T", msg)
+	}
+
+	return &SignAgentCertResp{
+		Cert:   pbResp.Cert,
+		CACert: pbResp.CaCert,
+	}, nil
+}
+
+type (
+	// GetCredentialNonceReq contains the inputs for the get credential nonce
+	// request.
+	GetCredentialNonceReq struct {
+		unaryRequest
+		msRequest
+		Count int // number of nonces to issue, or 0 for one
+	}
+
+	// GetCredentialNonceResp contains the nonces issued by the servers, and
+	// the time after which they no longer accept them.
+	GetCredentialNonceResp struct {
+		Nonces  [][]byte
+		Expires time.Time
+	}
+)
+
+// GetCredentialNonce requests that the management service issue nonces for
+// the agent to record in the credentials it signs, one in each.
+func GetCredentialNonce(ctx context.Context, rpcClient UnaryInvoker, req *GetCredentialNonceReq) (*GetCredentialNonceResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	if req.Count < 0 {
+		return nil, errors.Errorf("invalid nonce count %d", req.Count)
+	}
+
+	pbReq := &mgmtpb.GetCredentialNonceReq{
+		Sys:   req.getSystem(rpcClient),
+		Count: uint32(req.Count),
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).GetCredentialNonce(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS GetCredentialNonce request: %+v", pbReq)
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := ur.getMSResponse()
+	if err != nil {
+		return nil, errors.Wrap(err, "get credential nonce failed")
+	}
+
+	pbResp, ok := msg.(*mgmtpb.GetCredentialNonceResp)
+	if !ok {
+		return nil, errors.Errorf("unexpected response type: %T", msg)
+	}
+
+	return &GetCredentialNonceResp{
+		Nonces:  pbResp.Nonces,
+		Expires: time.Unix(int64(pbResp.Expires), 0),
+	}, nil
+}
+
+type (
+	// SystemRevokeCredentialsReq contains the inputs for the system revoke
+	// credentials request.
+	SystemRevokeCredentialsReq struct {
+		unaryRequest
+		msRequest
+		KeyIDs     []string
+		CredHashes []string
+		AgentHosts []string
+		Reason     string
+	}
+
+	// SystemRevokeCredentialsResp contains the results of a system revoke
+	// credentials request.
+	SystemRevokeCredentialsResp struct {
+		Version uint64 `json:"version"`
+		Added   uint32 `json:"added"`
+	}
+)
+
+// SystemRevokeCredentials requests that the management service add the
+// credentials to the list of those revoked throughout the system.
+func SystemRevokeCredentials(ctx context.Context, rpcClient UnaryInvoker, req *SystemRevokeCredentialsReq) (*SystemRevokeCredentialsResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+	if len(req.KeyIDs)+len(req.CredHashes)+len(req.AgentHosts) == 0 {
+		return nil, errors.New("no credentials to revoke")
+	}
+
+	pbReq := &mgmtpb.SystemRevokeCredentialsReq{
+		Sys:        req.getSystem(rpcClient),
+		KeyIds:     req.KeyIDs,
+		CredHashes: req.CredHashes,
+		AgentHosts: req.AgentHosts,
+		Reason:     req.Reason,
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemRevokeCredentials(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS SystemRevokeCredentials request: %+v", pbReq)
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(SystemRevokeCredentialsResp)
+	return resp, convertMSResponse(ur, resp)
+}
+
+type (
+	// GetCredentialRevocationsReq contains the inputs for the get credential
+	// revocations request.
+	GetCredentialRevocationsReq struct {
+		unaryRequest
+		msRequest
+	}
+
+	// GetCredentialRevocationsResp contains the credentials revoked
+	// throughout the system.
+	GetCredentialRevocationsResp struct {
+		Version    uint64   `json:"version"`
+		KeyIDs     []string `json:"key_ids"`
+		CredHashes []string `json:"cred_hashes"`
+		AgentHosts []string `json:"agent_hosts"`
+	}
+)
+
+// GetCredentialRevocations requests the list of credentials revoked
+// throughout the system from the management service.
+func GetCredentialRevocations(ctx context.Context, rpcClient UnaryInvoker, req *GetCredentialRevocationsReq) (*GetCredentialRevocationsResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &mgmtpb.GetCredentialRevocationsReq{
+		Sys: req.getSystem(rpcClient),
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).GetCredentialRevocations(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS GetCredentialRevocations request: %+v", pbReq)
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(GetCredentialRevocationsResp)
+	return resp, convertMSResponse(ur, resp)
+}
+
+type (
+	// SystemEnrollAgentReq contains the inputs for the system enroll agent
+	// request.
+	SystemEnrollAgentReq struct {
+		unaryRequest
+		msRequest
+		AgentHost string
+		KeyID     string
+		Unenroll  bool
+	}
+
+	// SystemEnrollAgentResp contains the results of a system enroll agent
+	// request.
+	SystemEnrollAgentResp struct {
+		Version uint64 `json:"version"`
+		Changed bool   `json:"changed"`
+	}
+)
+
+// SystemEnrollAgent requests that the management service enroll the agent host
+// with the key it signs credentials with, or unenroll it, throughout the
+// system.
+func SystemEnrollAgent(ctx context.Context, rpcClient UnaryInvoker, req *SystemEnrollAgentReq) (*SystemEnrollAgentResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+	if req.AgentHost == "" {
+		return nil, errors.New("no agent host specified")
+	}
+	if req.KeyID == "" && !req.Unenroll {
+		return nil, errors.New("no agent key specified")
+	}
+
+	pbReq := &mgmtpb.SystemEnrollAgentReq{
+		Sys:       req.getSystem(rpcClient),
+		AgentHost: req.AgentHost,
+		KeyId:     req.KeyID,
+		Unenroll:  req.Unenroll,
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemEnrollAgent(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS SystemEnrollAgent request: %+v", pbReq)
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(SystemEnrollAgentResp)
+	return resp, convertMSResponse(ur, resp)
+}
+
+type (
+	// GetAgentEnrollmentsReq contains the inputs for the get agent
+	// enrollments request.
+	GetAgentEnrollmentsReq struct {
+		unaryRequest
+		msRequest
+	}
+
+	// GetAgentEnrollmentsResp contains the agent hosts enrolled throughout
+	// the system, with the IDs of their keys.
+	GetAgentEnrollmentsResp struct {
+		Version uint64            `json:"version"`
+		KeyIDs  map[string]string `json:"key_ids"`
+	}
+)
+
+// GetAgentEnrollments requests the agent hosts enrolled throughout the system
+// from the management service.
+func GetAgentEnrollments(ctx context.Context, rpcClient UnaryInvoker, req *GetAgentEnrollmentsReq) (*GetAgentEnrollmentsResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &mgmtpb.GetAgentEnrollmentsReq{
+		Sys: req.getSystem(rpcClient),
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).GetAgentEnrollments(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS GetAgentEnrollments request: %+v", pbReq)
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(GetAgentEnrollmentsResp)
+	return resp, convertMSResponse(ur, resp)
+}
+
+type (
+	// SystemUpdateAuthFlavorsReq contains the inputs for the system update
+	// auth flavors request.
+	SystemUpdateAuthFlavorsReq struct {
+		unaryRequest
+		msRequest
+		Add    []auth.Flavor
+		Remove []auth.Flavor
+	}
+
+	// SystemUpdateAuthFlavorsResp contains the results of a system update
+	// auth flavors request.
+	SystemUpdateAuthFlavorsResp struct {
+		Version          uint64        `json:"version"`
+		Changed          bool          `json:"changed"`
+		ValidAuthFlavors []auth.Flavor `json:"valid_auth_flavors"`
+	}
+)
+
+func flavorsToUint32(flavors []auth.Flavor) []uint32 {
+	nums := make([]uint32, len(flavors))
+	for i, flavor := range flavors {
+		nums[i] = uint32(flavor)
+	}
+	return nums
+}
+
+// SystemUpdateAuthFlavors requests that the management service add and
+// remove auth flavors allowed throughout the system.
+func SystemUpdateAuthFlavors(ctx context.Context, rpcClient UnaryInvoker, req *SystemUpdateAuthFlavorsReq) (*SystemUpdateAuthFlavorsResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+	if len(req.Add)+len(req.Remove) == 0 {
+		return nil, errors.New("no auth flavors to add or remove")
+	}
+
+	pbReq := &mgmtpb.SystemUpdateAuthFlavorsReq{
+		Sys:    req.getSystem(rpcClient),
+		Add:    flavorsToUint32(req.Add),
+		Remove: flavorsToUint32(req.Remove),
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemUpdateAuthFlavors(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS SystemUpdateAuthFlavors request: %+v", pbReq)
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(SystemUpdateAuthFlavorsResp)
+	return resp, convertMSResponse(ur, resp)
+}
+
+type (
+	// GetAuthFlavorsReq contains the inputs for the get auth flavors
+	// request.
+	GetAuthFlavorsReq struct {
+		unaryRequest
+		msRequest
+	}
+
+	// GetAuthFlavorsResp contains the auth flavors allowed throughout the
+	// system.
+	GetAuthFlavorsResp struct {
+		Version          uint64        `json:"version"`
+		ValidAuthFlavors []auth.Flavor `json:"valid_auth_flavors"`
+	}
+)
+
+// GetAuthFlavors requests the auth flavors allowed throughout the system from
+// the management service.
+func GetAuthFlavors(ctx context.Context, rpcClient UnaryInvoker, req *GetAuthFlavorsReq) (*GetAuthFlavorsResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &mgmtpb.GetAuthFlavorsReq{
+		Sys: req.getSystem(rpcClient),
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).GetAuthFlavors(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS GetAuthFlavors request: %+v", pbReq)
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(GetAuthFlavorsResp)
+	return resp, convertMSResponse(ur, resp)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/hostlist"
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
 	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
 	"github.com/daos-stack/daos/src/control/system"
 )
 
@@ -1887,3 +1888,475 @@ func TestControl_SystemGetAttr(t *testing.T) {
 		})
 	}
 }
+
+func TestControl_SignAgentCert(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req     *SignAgentCertReq
+		mic     *MockInvokerConfig
+		expResp *SignAgentCertResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"empty csr": {
+			req:    &SignAgentCertReq{},
+			expErr: errors.New("empty certificate signing request"),
+		},
+		"req fails": {
+			req: &SignAgentCertReq{CSR: []byte("csr")},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("error"), nil),
+				},
+			},
+			expErr: errors.New("sign agent certificate failed: error"),
+		},
+		"success": {
+			req: &SignAgentCertReq{CSR: []byte("csr")},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.SignAgentCertResp{
+						Cert:   []byte("cert"),
+						CaCert: []byte("ca"),
+					}),
+				},
+			},
+			expResp: &SignAgentCertResp{
+				Cert:   []byte("cert"),
+				CACert: []byte("ca"),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotResp, gotErr := SignAgentCert(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_GetCredentialNonce(t *testing.T) {
+	expires := time.Unix(1700000000, 0)
+
+	for name, tc := range map[string]struct {
+		req     *GetCredentialNonceReq
+		mic     *MockInvokerConfig
+		expResp *GetCredentialNonceResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"req fails": {
+			req: &GetCredentialNonceReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("error"), nil),
+				},
+			},
+			expErr: errors.New("get credential nonce failed: error"),
+		},
+		"invalid count": {
+			req:    &GetCredentialNonceReq{Count: -1},
+			expErr: errors.New("invalid nonce count"),
+		},
+		"success": {
+			req: &GetCredentialNonceReq{Count: 2},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.GetCredentialNonceResp{
+						Nonces:  [][]byte{[]byte("nonce1"), []byte("nonce2")},
+						Expires: uint64(expires.Unix()),
+					}),
+				},
+			},
+			expResp: &GetCredentialNonceResp{
+				Nonces:  [][]byte{[]byte("nonce1"), []byte("nonce2")},
+				Expires: expires,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotResp, gotErr := GetCredentialNonce(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_SystemRevokeCredentials(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req     *SystemRevokeCredentialsReq
+		mic     *MockInvokerConfig
+		expResp *SystemRevokeCredentialsResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"nothing to revoke": {
+			req:    &SystemRevokeCredentialsReq{Reason: "reason"},
+			expErr: errors.New("no credentials to revoke"),
+		},
+		"req fails": {
+			req: &SystemRevokeCredentialsReq{AgentHosts: []string{"host1"}},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("error"), nil),
+				},
+			},
+			expErr: errors.New("error"),
+		},
+		"success": {
+			req: &SystemRevokeCredentialsReq{AgentHosts: []string{"host1"}},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.SystemRevokeCredentialsResp{
+						Version: 3,
+						Added:   1,
+					}),
+				},
+			},
+			expResp: &SystemRevokeCredentialsResp{
+				Version: 3,
+				Added:   1,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotResp, gotErr := SystemRevokeCredentials(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_GetCredentialRevocations(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req     *GetCredentialRevocationsReq
+		mic     *MockInvokerConfig
+		expResp *GetCredentialRevocationsResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"req fails": {
+			req: &GetCredentialRevocationsReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("error"), nil),
+				},
+			},
+			expErr: errors.New("error"),
+		},
+		"success": {
+			req: &GetCredentialRevocationsReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.GetCredentialRevocationsResp{
+						Version:    2,
+						KeyIds:     []string{"key"},
+						CredHashes: []string{"hash"},
+						AgentHosts: []string{"host1"},
+					}),
+				},
+			},
+			expResp: &GetCredentialRevocationsResp{
+				Version:    2,
+				KeyIDs:     []string{"key"},
+				CredHashes: []string{"hash"},
+				AgentHosts: []string{"host1"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotResp, gotErr := GetCredentialRevocations(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_SystemEnrollAgent(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req     *SystemEnrollAgentReq
+		mic     *MockInvokerConfig
+		expResp *SystemEnrollAgentResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"no host": {
+			req:    &SystemEnrollAgentReq{KeyID: "key"},
+			expErr: errors.New("no agent host specified"),
+		},
+		"no key": {
+			req:    &SystemEnrollAgentReq{AgentHost: "host1"},
+			expErr: errors.New("no agent key specified"),
+		},
+		"req fails": {
+			req: &SystemEnrollAgentReq{AgentHost: "host1", KeyID: "key"},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("error"), nil),
+				},
+			},
+			expErr: errors.New("error"),
+		},
+		"enroll": {
+			req: &SystemEnrollAgentReq{AgentHost: "host1", KeyID: "key"},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.SystemEnrollAgentResp{
+						Version: 3,
+						Changed: true,
+					}),
+				},
+			},
+			expResp: &SystemEnrollAgentResp{
+				Version: 3,
+				Changed: true,
+			},
+		},
+		"unenroll": {
+			req: &SystemEnrollAgentReq{AgentHost: "host1", Unenroll: true},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.SystemEnrollAgentResp{
+						Version: 2,
+					}),
+				},
+			},
+			expResp: &SystemEnrollAgentResp{
+				Version: 2,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotResp, gotErr := SystemEnrollAgent(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_GetAgentEnrollments(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req     *GetAgentEnrollmentsReq
+		mic     *MockInvokerConfig
+		expResp *GetAgentEnrollmentsResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"req fails": {
+			req: &GetAgentEnrollmentsReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("error"), nil),
+				},
+			},
+			expErr: errors.New("error"),
+		},
+		"success": {
+			req: &GetAgentEnrollmentsReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.GetAgentEnrollmentsResp{
+						Version: 2,
+						KeyIds:  map[string]string{"host1": "key"},
+					}),
+				},
+			},
+			expResp: &GetAgentEnrollmentsResp{
+				Version: 2,
+				KeyIDs:  map[string]string{"host1": "key"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotResp, gotErr := GetAgentEnrollments(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_SystemUpdateAuthFlavors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req     *SystemUpdateAuthFlavorsReq
+		mic     *MockInvokerConfig
+		expResp *SystemUpdateAuthFlavorsResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"nothing to update": {
+			req:    &SystemUpdateAuthFlavorsReq{},
+			expErr: errors.New("no auth flavors to add or remove"),
+		},
+		"req fails": {
+			req: &SystemUpdateAuthFlavorsReq{Add: []auth.Flavor{auth.Flavor_AUTH_TOTP}},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("error"), nil),
+				},
+			},
+			expErr: errors.New("error"),
+		},
+		"success": {
+			req: &SystemUpdateAuthFlavorsReq{
+				Add:    []auth.Flavor{auth.Flavor_AUTH_TOTP},
+				Remove: []auth.Flavor{auth.Flavor_AUTH_SYS},
+			},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.SystemUpdateAuthFlavorsResp{
+						Version:          2,
+						Changed:          true,
+						ValidAuthFlavors: []uint32{uint32(auth.Flavor_AUTH_TOTP)},
+					}),
+				},
+			},
+			expResp: &SystemUpdateAuthFlavorsResp{
+				Version:          2,
+				Changed:          true,
+				ValidAuthFlavors: []auth.Flavor{auth.Flavor_AUTH_TOTP},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotResp, gotErr := SystemUpdateAuthFlavors(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_GetAuthFlavors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req     *GetAuthFlavorsReq
+		mic     *MockInvokerConfig
+		expResp *GetAuthFlavorsResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"req fails": {
+			req: &GetAuthFlavorsReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("error"), nil),
+				},
+			},
+			expErr: errors.New("error"),
+		},
+		"success": {
+			req: &GetAuthFlavorsReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.GetAuthFlavorsResp{
+						Version:          1,
+						ValidAuthFlavors: []uint32{uint32(auth.Flavor_AUTH_SYS), uint32(auth.MinPluginFlavor)},
+					}),
+				},
+			},
+			expResp: &GetAuthFlavorsResp{
+				Version:          1,
+				ValidAuthFlavors: []auth.Flavor{auth.Flavor_AUTH_SYS, auth.MinPluginFlavor},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotResp, gotErr := GetAuthFlavors(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
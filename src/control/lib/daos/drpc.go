@@ -55,8 +55,11 @@ func (m securityAgentMethod) ID() int32 {
 
 func (m securityAgentMethod) String() string {
 	if s, ok := map[securityAgentMethod]string{
-		MethodRequestCredentials:  "request agent credentials",
-		MethodRequestValidFlavors: "request valid authentication flavors",
+		MethodRequestCredentials:   "request agent credentials",
+		MethodRequestValidFlavors:  "request valid authentication flavors",
+		MethodAgentRevokeIssuerKey: "revoke issuer key",
+		MethodRequestChallenge:     "request authentication challenge",
+		MethodInvalidateCredential: "invalidate cached credential",
 	}[m]; ok {
 		return s
 	}
@@ -69,6 +72,12 @@ const (
 	MethodRequestCredentials securityAgentMethod = C.DRPC_METHOD_SEC_AGENT_REQUEST_CREDS
 	// MethodRequestCredentials is a ModuleSecurityAgent method
 	MethodRequestValidFlavors securityAgentMethod = C.DRPC_METHOD_SEC_AGENT_REQUEST_AUTH_FLAVORS
+	// MethodAgentRevokeIssuerKey is a ModuleSecurityAgent method
+	MethodAgentRevokeIssuerKey securityAgentMethod = C.DRPC_METHOD_SEC_AGENT_REVOKE_ISSUER_KEY
+	// MethodRequestChallenge is a ModuleSecurityAgent method
+	MethodRequestChallenge securityAgentMethod = C.DRPC_METHOD_SEC_AGENT_REQUEST_CHALLENGE
+	// MethodInvalidateCredential is a ModuleSecurityAgent method
+	MethodInvalidateCredential securityAgentMethod = C.DRPC_METHOD_SEC_AGENT_INVALIDATE_CRED
 )
 
 type MgmtMethod int32
@@ -83,41 +92,45 @@ func (m MgmtMethod) ID() int32 {
 
 func (m MgmtMethod) String() string {
 	if s, ok := map[MgmtMethod]string{
-		MethodPrepShutdown:         "PrepShutdown",
-		MethodPingRank:             "PingRank",
-		MethodSetRank:              "SetRank",
-		MethodSetLogMasks:          "SetLogMasks",
-		MethodGetAttachInfo:        "GetAttachInfo",
-		MethodPoolCreate:           "PoolCreate",
-		MethodPoolDestroy:          "PoolDestroy",
-		MethodPoolEvict:            "PoolEvict",
-		MethodPoolExclude:          "PoolExclude",
-		MethodPoolDrain:            "PoolDrain",
-		MethodPoolExtend:           "PoolExtend",
-		MethodPoolReintegrate:      "PoolReintegrate",
-		MethodBioHealth:            "BioHealth",
-		MethodSetUp:                "SetUp",
-		MethodSmdDevs:              "SmdDevs",
-		MethodSmdPools:             "SmdPools",
-		MethodPoolGetACL:           "PoolGetACL",
-		MethodPoolOverwriteACL:     "PoolOverwriteACL",
-		MethodPoolUpdateACL:        "PoolUpdateACL",
-		MethodPoolDeleteACL:        "PoolDeleteACL",
-		MethodSetFaultyState:       "SetFaultyState",
-		MethodReplaceStorage:       "ReplaceStorage",
-		MethodListContainers:       "ListContainers",
-		MethodPoolQuery:            "PoolQuery",
-		MethodPoolQueryTarget:      "PoolQueryTarget",
-		MethodPoolSetProp:          "PoolSetProp",
-		MethodContSetOwner:         "ContSetOwner",
-		MethodGroupUpdate:          "GroupUpdate",
-		MethodNotifyPoolConnect:    "NotifyPoolConnect",
-		MethodNotifyPoolDisconnect: "NotifyPoolDisconnect",
-		MethodNotifyExit:           "NotifyExit",
-		MethodPoolGetProp:          "PoolGetProp",
-		MethodPoolUpgrade:          "PoolUpgrade",
-		MethodLedManage:            "LedManage",
-		MethodSetupClientTelemetry: "SetupClientTelemetry",
+		MethodPrepShutdown:            "PrepShutdown",
+		MethodPingRank:                "PingRank",
+		MethodSetRank:                 "SetRank",
+		MethodSetLogMasks:             "SetLogMasks",
+		MethodGetAttachInfo:           "GetAttachInfo",
+		MethodPoolCreate:              "PoolCreate",
+		MethodPoolDestroy:             "PoolDestroy",
+		MethodPoolEvict:               "PoolEvict",
+		MethodPoolExclude:             "PoolExclude",
+		MethodPoolDrain:               "PoolDrain",
+		MethodPoolExtend:              "PoolExtend",
+		MethodPoolReintegrate:         "PoolReintegrate",
+		MethodBioHealth:               "BioHealth",
+		MethodSetUp:                   "SetUp",
+		MethodSmdDevs:                 "SmdDevs",
+		MethodSmdPools:                "SmdPools",
+		MethodPoolGetACL:              "PoolGetACL",
+		MethodPoolOverwriteACL:        "PoolOverwriteACL",
+		MethodPoolUpdateACL:           "PoolUpdateACL",
+		MethodPoolDeleteACL:           "PoolDeleteACL",
+		MethodSetFaultyState:          "SetFaultyState",
+		MethodReplaceStorage:          "ReplaceStorage",
+		MethodListContainers:          "ListContainers",
+		MethodPoolQuery:               "PoolQuery",
+		MethodPoolQueryTarget:         "PoolQueryTarget",
+		MethodPoolSetProp:             "PoolSetProp",
+		MethodContSetOwner:            "ContSetOwner",
+		MethodGroupUpdate:             "GroupUpdate",
+		MethodNotifyPoolConnect:       "NotifyPoolConnect",
+		MethodNotifyPoolDisconnect:    "NotifyPoolDisconnect",
+		MethodNotifyExit:              "NotifyExit",
+		MethodPoolGetProp:             "PoolGetProp",
+		MethodPoolUpgrade:             "PoolUpgrade",
+		MethodLedManage:               "LedManage",
+		MethodSetupClientTelemetry:    "SetupClientTelemetry",
+		MethodListCredentialCache:     "ListCredentialCache",
+		MethodSnapshotCredentialCache: "SnapshotCredentialCache",
+		MethodRestoreCredentialCache:  "RestoreCredentialCache",
+		MethodCredentialCacheEvents:   "CredentialCacheEvents",
 	}[m]; ok {
 		return s
 	}
@@ -206,6 +219,14 @@ const (
 	MethodLedManage MgmtMethod = C.DRPC_METHOD_MGMT_LED_MANAGE
 	// MethodSetupClientTelemetry defines a method to setup client telemetry
 	MethodSetupClientTelemetry MgmtMethod = C.DRPC_METHOD_MGMT_SETUP_CLIENT_TELEM
+	// MethodListCredentialCache defines a method to list the agent's cached credentials
+	MethodListCredentialCache MgmtMethod = C.DRPC_METHOD_MGMT_LIST_CRED_CACHE
+	// MethodSnapshotCredentialCache defines a method to snapshot the agent's cached credentials
+	MethodSnapshotCredentialCache MgmtMethod = C.DRPC_METHOD_MGMT_SNAPSHOT_CRED_CACHE
+	// MethodRestoreCredentialCache defines a method to restore a snapshot of cached credentials into the agent
+	MethodRestoreCredentialCache MgmtMethod = C.DRPC_METHOD_MGMT_RESTORE_CRED_CACHE
+	// MethodCredentialCacheEvents defines a method to fetch the agent's credential cache events
+	MethodCredentialCacheEvents MgmtMethod = C.DRPC_METHOD_MGMT_CRED_CACHE_EVENTS
 )
 
 type SrvMethod int32
@@ -177,4 +177,14 @@ const (
 	RedundancyFactorExceeded Status = -C.DER_RF
 	// AgentCommFailed indicates that client/agent communication failed.
 	AgentCommFailed Status = -C.DER_AGENT_COMM
+	// CredentialExpired indicates that a credential is outside its validity period.
+	CredentialExpired Status = -C.DER_CRED_EXPIRED
+	// CredentialRevoked indicates that a credential, or the key which signed it, has been revoked.
+	CredentialRevoked Status = -C.DER_CRED_REVOKED
+	// BadAuthFlavor indicates that a credential's authentication flavor is not accepted.
+	BadAuthFlavor Status = -C.DER_BAD_AUTH_FLAVOR
+	// BadSignature indicates that a credential's signature failed to verify.
+	BadSignature Status = -C.DER_BAD_SIGNATURE
+	// UnknownKey indicates that a credential was signed by a key which is not trusted.
+	UnknownKey Status = -C.DER_UNKNOWN_KEY
 )
@@ -0,0 +1,264 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package kms
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	awsKMSService   = "kms"
+	awsKMSTarget    = "TrentService.Sign"
+	awsJSONType     = "application/x-amz-json-1.1"
+	awsSigV4Algo    = "AWS4-HMAC-SHA256"
+	awsSigV4DateFmt = "20060102T150405Z"
+)
+
+var awsHashNames = map[crypto.Hash]string{
+	crypto.SHA256: "SHA_256",
+	crypto.SHA384: "SHA_384",
+	crypto.SHA512: "SHA_512",
+}
+
+// AWSKMSConfig identifies an asymmetric signing key in AWS KMS. The AWS
+// credentials are taken from the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// (for temporary credentials) AWS_SESSION_TOKEN environment variables.
+type AWSKMSConfig struct {
+	// Region is the AWS region of the key.
+	Region string
+	// KeyID is the ID, ARN or alias of the key.
+	KeyID string
+	// Endpoint is the URL of the KMS service, if not the public endpoint of
+	// the region (e.g. a VPC endpoint).
+	Endpoint string
+	// CACert is the CA certificate of the endpoint, if not a system CA.
+	CACert string
+	// Timeout is the time allowed for each signature request.
+	Timeout time.Duration
+}
+
+type awsKMS struct {
+	cfg    AWSKMSConfig
+	url    string
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewAWSKMSSigner returns a signer using the AWS KMS key, whose public half is
+// pub.
+func NewAWSKMSSigner(cfg AWSKMSConfig, pub crypto.PublicKey) (*Signer, error) {
+	if cfg.Region == "" {
+		return nil, errors.New("AWS region is required")
+	}
+	if cfg.KeyID == "" {
+		return nil, errors.New("AWS KMS key ID is required")
+	}
+	if _, isEd25519 := pub.(ed25519.PublicKey); isEd25519 {
+		return nil, errors.New("ed25519 keys are not supported by AWS KMS")
+	}
+
+	client, err := newHTTPClient(cfg.CACert)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := strings.TrimSuffix(cfg.Endpoint, "/")
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com", awsKMSService, cfg.Region)
+	}
+
+	return newSigner(pub, cfg.Timeout, &awsKMS{
+		cfg:    cfg,
+		url:    endpoint + "/",
+		client: client,
+		now:    time.Now,
+	})
+}
+
+func (a *awsKMS) String() string {
+	return fmt.Sprintf("AWS KMS key %q in %s", a.cfg.KeyID, a.cfg.Region)
+}
+
+// awsSigningAlgorithm returns the KMS signing algorithm for the key and
+// options. KMS RSA-PSS signatures always have a salt as long as the hash.
+func awsSigningAlgorithm(pub crypto.PublicKey, opts crypto.SignerOpts) (string, error) {
+	hash, found := awsHashNames[opts.HashFunc()]
+	if !found {
+		return "", errors.Errorf("unsupported hash %s", opts.HashFunc())
+	}
+
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		pssOpts, isPSS := opts.(*rsa.PSSOptions)
+		if !isPSS {
+			return "RSASSA_PKCS1_V1_5_" + hash, nil
+		}
+		if pssOpts.SaltLength != rsa.PSSSaltLengthEqualsHash && pssOpts.SaltLength != opts.HashFunc().Size() {
+			return "", errors.Errorf("unsupported RSA-PSS salt length %d", pssOpts.SaltLength)
+		}
+		return "RSASSA_PSS_" + hash, nil
+	case *ecdsa.PublicKey:
+		return "ECDSA_" + hash, nil
+	default:
+		return "", errors.Errorf("%T keys are not supported by AWS KMS", pub)
+	}
+}
+
+func (a *awsKMS) sign(ctx context.Context, pub crypto.PublicKey, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	algorithm, err := awsSigningAlgorithm(pub, opts)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := awsCredentialsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(struct {
+		KeyID            string `json:"KeyId"`
+		Message          string `json:"Message"`
+		MessageType      string `json:"MessageType"`
+		SigningAlgorithm string `json:"SigningAlgorithm"`
+	}{
+		KeyID:            a.cfg.KeyID,
+		Message:          base64.StdEncoding.EncodeToString(digest),
+		MessageType:      "DIGEST",
+		SigningAlgorithm: algorithm,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := post(ctx, a.client, a.url, body, func(req *http.Request) {
+		req.Header.Set("Content-Type", awsJSONType)
+		req.Header.Set("X-Amz-Target", awsKMSTarget)
+		signV4(req, body, creds, a.cfg.Region, awsKMSService, a.now())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Signature string `json:"Signature"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, errors.Wrap(err, "parsing response")
+	}
+	sig, err := base64.StdEncoding.DecodeString(resp.Signature)
+	if err != nil || len(sig) == 0 {
+		return nil, errors.Errorf("invalid signature %q in response", resp.Signature)
+	}
+	return sig, nil
+}
+
+// awsCredentials are the credentials used to sign AWS requests.
+type awsCredentials struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+}
+
+func awsCredentialsFromEnv() (*awsCredentials, error) {
+	creds := &awsCredentials{
+		accessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		secretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		sessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.accessKeyID == "" || creds.secretAccessKey == "" {
+		return nil, errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set")
+	}
+	return creds, nil
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// signV4 adds an AWS Signature Version 4 authorization header to the request,
+// signing the host and all headers already set on it.
+func signV4(req *http.Request, body []byte, creds *awsCredentials, region, service string, now time.Time) {
+	amzDate := now.UTC().Format(awsSigV4DateFmt)
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.sessionToken)
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, headers[name])
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		sha256Hex(body),
+	}, "\n")
+
+	date := amzDate[:8]
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		awsSigV4Algo,
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+creds.secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		awsSigV4Algo, creds.accessKeyID, scope, signedHeaders, signature))
+}
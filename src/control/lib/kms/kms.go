@@ -0,0 +1,157 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Package kms provides signers whose private keys are held by an external key
+// management service, which performs the signature operation, so that the key
+// never exists on the local host.
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultTimeout is the default time allowed for a signature request.
+	DefaultTimeout = 10 * time.Second
+
+	maxResponseSize = 1 << 20
+)
+
+// backend requests signatures from a key management service.
+type backend interface {
+	// sign returns the signature of the digest, which is the unhashed
+	// message for Ed25519 keys.
+	sign(ctx context.Context, pub crypto.PublicKey, digest []byte, opts crypto.SignerOpts) ([]byte, error)
+	// String describes the key.
+	String() string
+}
+
+// Signer is a crypto.Signer whose private key is held by a key management
+// service.
+type Signer struct {
+	pub     crypto.PublicKey
+	timeout time.Duration
+	backend backend
+}
+
+func newSigner(pub crypto.PublicKey, timeout time.Duration, b backend) (*Signer, error) {
+	switch pub.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+	default:
+		return nil, errors.Errorf("unsupported public key type %T", pub)
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return &Signer{
+		pub:     pub,
+		timeout: timeout,
+		backend: b,
+	}, nil
+}
+
+// Public returns the public half of the key.
+func (s *Signer) Public() crypto.PublicKey {
+	return s.pub
+}
+
+// Sign asks the key management service to sign the digest.
+func (s *Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	hash := opts.HashFunc()
+	if hash != 0 && len(digest) != hash.Size() {
+		return nil, errors.Errorf("digest length %d does not match %s", len(digest), hash)
+	}
+	if _, isEd25519 := s.pub.(ed25519.PublicKey); isEd25519 && hash != 0 {
+		return nil, errors.New("ed25519 keys sign the unhashed message")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	sig, err := s.backend.sign(ctx, s.pub, digest, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "signing with %s", s.backend)
+	}
+	return sig, nil
+}
+
+// newHTTPClient returns an HTTP client trusting the CA certificate, or the
+// system CAs if none is given.
+func newHTTPClient(caCert string) (*http.Client, error) {
+	if caCert == "" {
+		return http.DefaultClient, nil
+	}
+
+	pemData, err := os.ReadFile(caCert)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading CA certificate %q", caCert)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemData) {
+		return nil, errors.Errorf("no certificates found in %q", caCert)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
+// post sends the request body to the URL, once the request has been prepared
+// (e.g. authenticated) by the backend, and returns the response body.
+func post(ctx context.Context, client *http.Client, url string, body []byte, prepare func(*http.Request)) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	prepare(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{Code: resp.StatusCode, Body: string(bytes.TrimSpace(respBody))}
+	}
+	return respBody, nil
+}
+
+// StatusError is returned when a key management service responds to a
+// signature request with an error.
+type StatusError struct {
+	Code int
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unexpected status code %d", e.Code)
+	}
+	return fmt.Sprintf("unexpected status code %d: %s", e.Code, e.Body)
+}
@@ -0,0 +1,419 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package kms
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+type testKeys struct {
+	rsa     *rsa.PrivateKey
+	ecdsa   *ecdsa.PrivateKey
+	ed25519 ed25519.PrivateKey
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testKeys{rsa: rsaKey, ecdsa: ecdsaKey, ed25519: ed25519Key}
+}
+
+// signTestDigest signs a digest of the message with the signer, as the token
+// signer does for each type of key, and verifies the signature.
+func signTestDigest(t *testing.T, signer crypto.Signer, message []byte) error {
+	t.Helper()
+
+	var digest []byte
+	var opts crypto.SignerOpts
+	switch signer.Public().(type) {
+	case *rsa.PublicKey:
+		sum := crypto.SHA512.New()
+		sum.Write(message)
+		digest = sum.Sum(nil)
+		opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA512}
+	case *ecdsa.PublicKey:
+		sum := crypto.SHA256.New()
+		sum.Write(message)
+		digest = sum.Sum(nil)
+		opts = crypto.SHA256
+	default:
+		digest = message
+		opts = crypto.Hash(0)
+	}
+
+	sig, err := signer.Sign(rand.Reader, digest, opts)
+	if err != nil {
+		return err
+	}
+
+	switch pub := signer.Public().(type) {
+	case *rsa.PublicKey:
+		err = rsa.VerifyPSS(pub, crypto.SHA512, digest, sig, opts.(*rsa.PSSOptions))
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(pub, digest, sig) {
+			err = errors.New("invalid ECDSA signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(pub, digest, sig) {
+			err = errors.New("invalid Ed25519 signature")
+		}
+	}
+	if err != nil {
+		t.Fatalf("signature did not verify: %s", err)
+	}
+	return nil
+}
+
+// fakeVault emulates the sign endpoint of the Vault transit engine.
+func fakeVault(t *testing.T, keys map[string]crypto.Signer, token string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != token {
+			http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+			return
+		}
+		key, found := keys[strings.TrimPrefix(r.URL.Path, "/v1/transit/sign/")]
+		if !found {
+			http.Error(w, `{"errors":["signing key not found"]}`, http.StatusBadRequest)
+			return
+		}
+
+		var req vaultSignRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		input, err := base64.StdEncoding.DecodeString(req.Input)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var opts crypto.SignerOpts = crypto.Hash(0)
+		for hash, name := range vaultHashes {
+			if name == req.HashAlgorithm {
+				opts = hash
+			}
+		}
+		if req.SignatureAlgorithm == "pss" {
+			if req.SaltLength != "hash" {
+				http.Error(w, "unexpected salt length", http.StatusBadRequest)
+				return
+			}
+			opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: opts.HashFunc()}
+		}
+		if (opts.HashFunc() != 0) != req.Prehashed {
+			http.Error(w, "unexpected prehashed", http.StatusBadRequest)
+			return
+		}
+
+		sig, err := key.Sign(rand.Reader, input, opts)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]string{
+				"signature": "vault:v1:" + base64.StdEncoding.EncodeToString(sig),
+			},
+		})
+	}))
+}
+
+func TestKMS_VaultTransitSigner(t *testing.T) {
+	keys := newTestKeys(t)
+	srv := fakeVault(t, map[string]crypto.Signer{
+		"rsa":     keys.rsa,
+		"ecdsa":   keys.ecdsa,
+		"ed25519": keys.ed25519,
+	}, "s.token")
+	defer srv.Close()
+
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+	tokenFile := filepath.Join(tmpDir, "token")
+	if err := os.WriteFile(tokenFile, []byte("s.token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	badTokenFile := filepath.Join(tmpDir, "bad-token")
+	if err := os.WriteFile(badTokenFile, []byte("s.bad"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		cfg       VaultTransitConfig
+		pub       crypto.PublicKey
+		expNewErr error
+		expErr    error
+	}{
+		"no address": {
+			cfg:       VaultTransitConfig{KeyName: "rsa"},
+			pub:       keys.rsa.Public(),
+			expNewErr: errors.New("address is required"),
+		},
+		"no key name": {
+			cfg:       VaultTransitConfig{Address: srv.URL},
+			pub:       keys.rsa.Public(),
+			expNewErr: errors.New("key name is required"),
+		},
+		"unsupported key": {
+			cfg:       VaultTransitConfig{Address: srv.URL, KeyName: "rsa"},
+			pub:       "not a key",
+			expNewErr: errors.New("unsupported public key type"),
+		},
+		"rsa": {
+			cfg: VaultTransitConfig{Address: srv.URL, KeyName: "rsa", TokenFile: tokenFile},
+			pub: keys.rsa.Public(),
+		},
+		"ecdsa": {
+			cfg: VaultTransitConfig{Address: srv.URL + "/", KeyName: "ecdsa", TokenFile: tokenFile},
+			pub: keys.ecdsa.Public(),
+		},
+		"ed25519": {
+			cfg: VaultTransitConfig{Address: srv.URL, KeyName: "ed25519", TokenFile: tokenFile},
+			pub: keys.ed25519.Public(),
+		},
+		"bad token": {
+			cfg:    VaultTransitConfig{Address: srv.URL, KeyName: "rsa", TokenFile: badTokenFile},
+			pub:    keys.rsa.Public(),
+			expErr: errors.New("unexpected status code 403"),
+		},
+		"missing token file": {
+			cfg:    VaultTransitConfig{Address: srv.URL, KeyName: "rsa", TokenFile: filepath.Join(tmpDir, "nope")},
+			pub:    keys.rsa.Public(),
+			expErr: errors.New("reading Vault token"),
+		},
+		"unknown key": {
+			cfg:    VaultTransitConfig{Address: srv.URL, KeyName: "other", TokenFile: tokenFile},
+			pub:    keys.rsa.Public(),
+			expErr: errors.New("signing key not found"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			signer, err := NewVaultTransitSigner(tc.cfg, tc.pub)
+			test.CmpErr(t, tc.expNewErr, err)
+			if tc.expNewErr != nil {
+				return
+			}
+
+			test.CmpErr(t, tc.expErr, signTestDigest(t, signer, []byte("message")))
+		})
+	}
+}
+
+func TestKMS_VaultTransitSigner_EnvToken(t *testing.T) {
+	keys := newTestKeys(t)
+	srv := fakeVault(t, map[string]crypto.Signer{"ecdsa": keys.ecdsa}, "s.env")
+	defer srv.Close()
+
+	signer, err := NewVaultTransitSigner(VaultTransitConfig{Address: srv.URL, KeyName: "ecdsa"}, keys.ecdsa.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("VAULT_TOKEN", "")
+	test.CmpErr(t, errors.New("VAULT_TOKEN is not set"), signTestDigest(t, signer, []byte("message")))
+
+	t.Setenv("VAULT_TOKEN", "s.env")
+	test.CmpErr(t, nil, signTestDigest(t, signer, []byte("message")))
+}
+
+func TestKMS_VaultTransitSigner_Timeout(t *testing.T) {
+	keys := newTestKeys(t)
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	t.Setenv("VAULT_TOKEN", "s.token")
+	signer, err := NewVaultTransitSigner(VaultTransitConfig{
+		Address: srv.URL,
+		KeyName: "ecdsa",
+		Timeout: 10 * time.Millisecond,
+	}, keys.ecdsa.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test.CmpErr(t, errors.New("context deadline exceeded"), signTestDigest(t, signer, []byte("message")))
+}
+
+func TestKMS_signV4(t *testing.T) {
+	// The get-vanilla example of the AWS Signature Version 4 test suite.
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now, err := time.Parse(awsSigV4DateFmt, "20150830T123600Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds := &awsCredentials{
+		accessKeyID:     "AKIDEXAMPLE",
+		secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+
+	signV4(req, nil, creds, "us-east-1", "service", now)
+
+	test.AssertEqual(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, "+
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"), "unexpected authorization")
+}
+
+// fakeAWSKMS emulates the Sign action of AWS KMS.
+func fakeAWSKMS(t *testing.T, keys map[string]crypto.Signer) *httptest.Server {
+	hashes := map[string]crypto.Hash{}
+	for hash, name := range awsHashNames {
+		hashes[name] = hash
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") ||
+			!strings.Contains(auth, "/us-east-1/kms/aws4_request") ||
+			r.Header.Get("X-Amz-Target") != awsKMSTarget {
+			http.Error(w, `{"__type":"UnrecognizedClientException"}`, http.StatusBadRequest)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var req struct {
+			KeyID            string `json:"KeyId"`
+			Message          []byte `json:"Message"`
+			MessageType      string `json:"MessageType"`
+			SigningAlgorithm string `json:"SigningAlgorithm"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil || req.MessageType != "DIGEST" {
+			http.Error(w, `{"__type":"ValidationException"}`, http.StatusBadRequest)
+			return
+		}
+		key, found := keys[req.KeyID]
+		if !found {
+			http.Error(w, `{"__type":"NotFoundException"}`, http.StatusBadRequest)
+			return
+		}
+
+		algo := req.SigningAlgorithm
+		hash := hashes[algo[len(algo)-7:]]
+		var opts crypto.SignerOpts = hash
+		if strings.HasPrefix(algo, "RSASSA_PSS_") {
+			opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: hash}
+		}
+		sig, err := key.Sign(rand.Reader, req.Message, opts)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"KeyId":            req.KeyID,
+			"Signature":        sig,
+			"SigningAlgorithm": algo,
+		})
+	}))
+}
+
+func TestKMS_AWSKMSSigner(t *testing.T) {
+	keys := newTestKeys(t)
+	srv := fakeAWSKMS(t, map[string]crypto.Signer{
+		"alias/rsa":   keys.rsa,
+		"alias/ecdsa": keys.ecdsa,
+	})
+	defer srv.Close()
+
+	for name, tc := range map[string]struct {
+		cfg       AWSKMSConfig
+		pub       crypto.PublicKey
+		noCreds   bool
+		expNewErr error
+		expErr    error
+	}{
+		"no region": {
+			cfg:       AWSKMSConfig{KeyID: "alias/rsa"},
+			pub:       keys.rsa.Public(),
+			expNewErr: errors.New("region is required"),
+		},
+		"no key ID": {
+			cfg:       AWSKMSConfig{Region: "us-east-1"},
+			pub:       keys.rsa.Public(),
+			expNewErr: errors.New("key ID is required"),
+		},
+		"rsa": {
+			cfg: AWSKMSConfig{Region: "us-east-1", KeyID: "alias/rsa", Endpoint: srv.URL},
+			pub: keys.rsa.Public(),
+		},
+		"ecdsa": {
+			cfg: AWSKMSConfig{Region: "us-east-1", KeyID: "alias/ecdsa", Endpoint: srv.URL},
+			pub: keys.ecdsa.Public(),
+		},
+		"ed25519": {
+			cfg:       AWSKMSConfig{Region: "us-east-1", KeyID: "alias/ed25519", Endpoint: srv.URL},
+			pub:       keys.ed25519.Public(),
+			expNewErr: errors.New("not supported by AWS KMS"),
+		},
+		"no credentials": {
+			cfg:     AWSKMSConfig{Region: "us-east-1", KeyID: "alias/rsa", Endpoint: srv.URL},
+			pub:     keys.rsa.Public(),
+			noCreds: true,
+			expErr:  errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set"),
+		},
+		"unknown key": {
+			cfg:    AWSKMSConfig{Region: "us-east-1", KeyID: "alias/other", Endpoint: srv.URL},
+			pub:    keys.rsa.Public(),
+			expErr: errors.New("NotFoundException"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if tc.noCreds {
+				t.Setenv("AWS_ACCESS_KEY_ID", "")
+			} else {
+				t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+			}
+			t.Setenv("AWS_SECRET_ACCESS_KEY", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY")
+
+			signer, err := NewAWSKMSSigner(tc.cfg, tc.pub)
+			test.CmpErr(t, tc.expNewErr, err)
+			if tc.expNewErr != nil {
+				return
+			}
+
+			test.CmpErr(t, tc.expErr, signTestDigest(t, signer, []byte("message")))
+		})
+	}
+}
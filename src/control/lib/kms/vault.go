@@ -0,0 +1,204 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package kms
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultVaultTransitMount is the default path of the Vault transit engine.
+const DefaultVaultTransitMount = "transit"
+
+var vaultHashes = map[crypto.Hash]string{
+	crypto.SHA256: "sha2-256",
+	crypto.SHA384: "sha2-384",
+	crypto.SHA512: "sha2-512",
+}
+
+// VaultTransitConfig identifies a key in the transit secrets engine of a
+// HashiCorp Vault server.
+type VaultTransitConfig struct {
+	// Address is the URL of the Vault server.
+	Address string
+	// Mount is the path of the transit engine (default "transit").
+	Mount string
+	// KeyName is the name of the transit key.
+	KeyName string
+	// Namespace is the Vault Enterprise namespace of the transit engine.
+	Namespace string
+	// TokenFile contains the Vault token, and is read for each request so
+	// that the token may be renewed by e.g. Vault Agent. If unset, the
+	// token is taken from the VAULT_TOKEN environment variable.
+	TokenFile string
+	// CACert is the CA certificate of the Vault server, if not a system CA.
+	CACert string
+	// Timeout is the time allowed for each signature request.
+	Timeout time.Duration
+}
+
+type vaultTransit struct {
+	cfg    VaultTransitConfig
+	url    string
+	client *http.Client
+}
+
+// NewVaultTransitSigner returns a signer using the Vault transit key, whose
+// public half is pub.
+func NewVaultTransitSigner(cfg VaultTransitConfig, pub crypto.PublicKey) (*Signer, error) {
+	if cfg.Address == "" {
+		return nil, errors.New("Vault address is required")
+	}
+	if cfg.KeyName == "" {
+		return nil, errors.New("Vault transit key name is required")
+	}
+	if cfg.Mount == "" {
+		cfg.Mount = DefaultVaultTransitMount
+	}
+
+	client, err := newHTTPClient(cfg.CACert)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSigner(pub, cfg.Timeout, &vaultTransit{
+		cfg: cfg,
+		url: fmt.Sprintf("%s/v1/%s/sign/%s", strings.TrimSuffix(cfg.Address, "/"),
+			strings.Trim(cfg.Mount, "/"), url.PathEscape(cfg.KeyName)),
+		client: client,
+	})
+}
+
+func (v *vaultTransit) String() string {
+	return fmt.Sprintf("Vault transit key %q at %s", v.cfg.KeyName, v.cfg.Address)
+}
+
+func (v *vaultTransit) token() (string, error) {
+	if v.cfg.TokenFile == "" {
+		if token := os.Getenv("VAULT_TOKEN"); token != "" {
+			return token, nil
+		}
+		return "", errors.New("no Vault token file is configured and VAULT_TOKEN is not set")
+	}
+
+	data, err := os.ReadFile(v.cfg.TokenFile)
+	if err != nil {
+		return "", errors.Wrap(err, "reading Vault token")
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", errors.Errorf("Vault token file %q is empty", v.cfg.TokenFile)
+	}
+	return token, nil
+}
+
+// vaultSignRequest is the body of a transit engine sign request.
+type vaultSignRequest struct {
+	Input              string `json:"input"`
+	Prehashed          bool   `json:"prehashed,omitempty"`
+	HashAlgorithm      string `json:"hash_algorithm,omitempty"`
+	SignatureAlgorithm string `json:"signature_algorithm,omitempty"`
+	SaltLength         string `json:"salt_length,omitempty"`
+	MarshalingAlgo     string `json:"marshaling_algorithm,omitempty"`
+}
+
+// vaultSignRequestFor returns the sign request for the digest.
+func vaultSignRequestFor(pub crypto.PublicKey, digest []byte, opts crypto.SignerOpts) (*vaultSignRequest, error) {
+	req := &vaultSignRequest{
+		Input: base64.StdEncoding.EncodeToString(digest),
+	}
+	if _, isEd25519 := pub.(ed25519.PublicKey); isEd25519 {
+		return req, nil
+	}
+
+	hash, found := vaultHashes[opts.HashFunc()]
+	if !found {
+		return nil, errors.Errorf("unsupported hash %s", opts.HashFunc())
+	}
+	req.Prehashed = true
+	req.HashAlgorithm = hash
+
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		pssOpts, isPSS := opts.(*rsa.PSSOptions)
+		if !isPSS {
+			req.SignatureAlgorithm = "pkcs1v15"
+			break
+		}
+		req.SignatureAlgorithm = "pss"
+		switch pssOpts.SaltLength {
+		case rsa.PSSSaltLengthEqualsHash:
+			req.SaltLength = "hash"
+		case rsa.PSSSaltLengthAuto:
+			req.SaltLength = "auto"
+		default:
+			req.SaltLength = strconv.Itoa(pssOpts.SaltLength)
+		}
+	default:
+		req.MarshalingAlgo = "asn1"
+	}
+	return req, nil
+}
+
+func (v *vaultTransit) sign(ctx context.Context, pub crypto.PublicKey, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	signReq, err := vaultSignRequestFor(pub, digest, opts)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(signReq)
+	if err != nil {
+		return nil, err
+	}
+	token, err := v.token()
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := post(ctx, v.client, v.url, body, func(req *http.Request) {
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Vault-Token", token)
+		if v.cfg.Namespace != "" {
+			req.Header.Set("X-Vault-Namespace", v.cfg.Namespace)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data struct {
+			Signature string `json:"signature"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, errors.Wrap(err, "parsing response")
+	}
+
+	// Transit signatures are of the form vault:v<key version>:<base64>.
+	parts := strings.Split(resp.Data.Signature, ":")
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, errors.Errorf("unexpected signature format %q", resp.Data.Signature)
+	}
+	sig, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding signature")
+	}
+	return sig, nil
+}
@@ -0,0 +1,205 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// PKCS#11 mechanism types and parameters used for signing.
+const (
+	ckmRSAPKCS    = 0x0001
+	ckmRSAPKCSPSS = 0x000d
+	ckmSHA256     = 0x0250
+	ckmSHA384     = 0x0260
+	ckmSHA512     = 0x0270
+	ckmECDSA      = 0x1041
+	ckmEDDSA      = 0x1057
+
+	ckgMGF1SHA256 = 0x2
+	ckgMGF1SHA384 = 0x3
+	ckgMGF1SHA512 = 0x4
+)
+
+// PKCS#11 return values which are handled specially.
+const (
+	ckrOK                         = 0x000
+	ckrDeviceError                = 0x030
+	ckrDeviceRemoved              = 0x032
+	ckrKeyHandleInvalid           = 0x060
+	ckrObjectHandleInvalid        = 0x082
+	ckrPINIncorrect               = 0x0a0
+	ckrSessionClosed              = 0x0b0
+	ckrSessionHandleInvalid       = 0x0b3
+	ckrTokenNotPresent            = 0x0e0
+	ckrTokenNotRecognized         = 0x0e1
+	ckrUserAlreadyLoggedIn        = 0x100
+	ckrUserNotLoggedIn            = 0x101
+	ckrCryptokiAlreadyInitialized = 0x191
+)
+
+// Error is a PKCS#11 return value other than CKR_OK.
+type Error uint64
+
+var errorNames = map[Error]string{
+	ckrDeviceError:                "CKR_DEVICE_ERROR",
+	ckrDeviceRemoved:              "CKR_DEVICE_REMOVED",
+	ckrKeyHandleInvalid:           "CKR_KEY_HANDLE_INVALID",
+	ckrObjectHandleInvalid:        "CKR_OBJECT_HANDLE_INVALID",
+	ckrPINIncorrect:               "CKR_PIN_INCORRECT",
+	ckrSessionClosed:              "CKR_SESSION_CLOSED",
+	ckrSessionHandleInvalid:       "CKR_SESSION_HANDLE_INVALID",
+	ckrTokenNotPresent:            "CKR_TOKEN_NOT_PRESENT",
+	ckrTokenNotRecognized:         "CKR_TOKEN_NOT_RECOGNIZED",
+	ckrUserNotLoggedIn:            "CKR_USER_NOT_LOGGED_IN",
+	ckrCryptokiAlreadyInitialized: "CKR_CRYPTOKI_ALREADY_INITIALIZED",
+}
+
+func (e Error) Error() string {
+	if name, found := errorNames[e]; found {
+		return fmt.Sprintf("pkcs11: %s (0x%x)", name, uint64(e))
+	}
+	return fmt.Sprintf("pkcs11: error 0x%x", uint64(e))
+}
+
+// TokenRemoved returns true if the error indicates that the token, or the
+// session with it, has gone away, e.g. because the token was removed or the
+// HSM was restarted. A new session may succeed once the token is back.
+func (e Error) TokenRemoved() bool {
+	switch e {
+	case ckrDeviceError, ckrDeviceRemoved, ckrKeyHandleInvalid, ckrObjectHandleInvalid,
+		ckrSessionClosed, ckrSessionHandleInvalid, ckrTokenNotPresent,
+		ckrTokenNotRecognized, ckrUserNotLoggedIn:
+		return true
+	default:
+		return false
+	}
+}
+
+// isTokenRemoved returns true if the error is a PKCS#11 error indicating that
+// the token has gone away.
+func isTokenRemoved(err error) bool {
+	var p11Err Error
+	return errors.As(err, &p11Err) && p11Err.TokenRemoved()
+}
+
+// mechanism is a PKCS#11 signing mechanism, with the parameters of an
+// RSA-PSS signature.
+type mechanism struct {
+	typ     uint64
+	pss     bool
+	hashAlg uint64
+	mgf     uint64
+	saltLen uint64
+}
+
+var (
+	pssHashes = map[crypto.Hash][2]uint64{
+		crypto.SHA256: {ckmSHA256, ckgMGF1SHA256},
+		crypto.SHA384: {ckmSHA384, ckgMGF1SHA384},
+		crypto.SHA512: {ckmSHA512, ckgMGF1SHA512},
+	}
+
+	// digestInfoPrefixes are the DER encodings of the DigestInfo of PKCS #1
+	// v1.5 signatures, preceding the digest itself.
+	digestInfoPrefixes = map[crypto.Hash][]byte{
+		crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+		crypto.SHA384: {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+		crypto.SHA512: {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+	}
+)
+
+// signMechanism returns the mechanism used to sign the digest with the private
+// half of the public key, and the data passed to the token to be signed.
+func signMechanism(pub crypto.PublicKey, digest []byte, opts crypto.SignerOpts) (*mechanism, []byte, error) {
+	hash := opts.HashFunc()
+	if hash != 0 && len(digest) != hash.Size() {
+		return nil, nil, errors.Errorf("digest length %d does not match %s", len(digest), hash)
+	}
+
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		pssOpts, isPSS := opts.(*rsa.PSSOptions)
+		if !isPSS {
+			prefix, found := digestInfoPrefixes[hash]
+			if !found {
+				return nil, nil, errors.Errorf("unsupported RSA PKCS #1 v1.5 hash %s", hash)
+			}
+			return &mechanism{typ: ckmRSAPKCS}, append(append([]byte{}, prefix...), digest...), nil
+		}
+
+		params, found := pssHashes[hash]
+		if !found {
+			return nil, nil, errors.Errorf("unsupported RSA-PSS hash %s", hash)
+		}
+		saltLen, err := pssSaltLength(pub, hash, pssOpts.SaltLength)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &mechanism{
+			typ:     ckmRSAPKCSPSS,
+			pss:     true,
+			hashAlg: params[0],
+			mgf:     params[1],
+			saltLen: saltLen,
+		}, digest, nil
+	case *ecdsa.PublicKey:
+		return &mechanism{typ: ckmECDSA}, digest, nil
+	case ed25519.PublicKey:
+		if hash != 0 {
+			return nil, nil, errors.New("ed25519 keys sign the unhashed message")
+		}
+		return &mechanism{typ: ckmEDDSA}, digest, nil
+	default:
+		return nil, nil, errors.Errorf("unsupported public key type %T", pub)
+	}
+}
+
+// pssSaltLength returns the salt length of an RSA-PSS signature, following the
+// conventions of the rsa package.
+func pssSaltLength(pub *rsa.PublicKey, hash crypto.Hash, saltLength int) (uint64, error) {
+	switch saltLength {
+	case rsa.PSSSaltLengthEqualsHash:
+		return uint64(hash.Size()), nil
+	case rsa.PSSSaltLengthAuto:
+		// The largest salt which fits the key.
+		emLen := (pub.N.BitLen() - 1 + 7) / 8
+		saltLen := emLen - hash.Size() - 2
+		if saltLen < 0 {
+			return 0, errors.New("RSA key is too small for the hash")
+		}
+		return uint64(saltLen), nil
+	default:
+		if saltLength < 0 {
+			return 0, errors.Errorf("invalid RSA-PSS salt length %d", saltLength)
+		}
+		return uint64(saltLength), nil
+	}
+}
+
+// ecdsaSignatureToASN1 converts the raw r || s signature returned by PKCS#11
+// tokens to the ASN.1 encoding used by the ecdsa package.
+func ecdsaSignatureToASN1(sig []byte) ([]byte, error) {
+	if len(sig) == 0 || len(sig)%2 != 0 {
+		return nil, errors.Errorf("invalid ECDSA signature length %d", len(sig))
+	}
+	half := len(sig) / 2
+	return asn1.Marshal(struct {
+		R, S *big.Int
+	}{
+		R: new(big.Int).SetBytes(sig[:half]),
+		S: new(big.Int).SetBytes(sig[half:]),
+	})
+}
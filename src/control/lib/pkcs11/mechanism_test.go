@@ -0,0 +1,227 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestPKCS11_signMechanism(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("data"))
+
+	for name, tc := range map[string]struct {
+		pub     crypto.PublicKey
+		digest  []byte
+		opts    crypto.SignerOpts
+		expMech *mechanism
+		expData []byte
+		expErr  error
+	}{
+		"rsa pss salt equals hash": {
+			pub:    &rsaKey.PublicKey,
+			digest: digest[:],
+			opts:   &rsa.PSSOptions{Hash: crypto.SHA256, SaltLength: rsa.PSSSaltLengthEqualsHash},
+			expMech: &mechanism{
+				typ:     ckmRSAPKCSPSS,
+				pss:     true,
+				hashAlg: ckmSHA256,
+				mgf:     ckgMGF1SHA256,
+				saltLen: 32,
+			},
+			expData: digest[:],
+		},
+		"rsa pss auto salt": {
+			pub:    &rsaKey.PublicKey,
+			digest: digest[:],
+			opts:   &rsa.PSSOptions{Hash: crypto.SHA256, SaltLength: rsa.PSSSaltLengthAuto},
+			expMech: &mechanism{
+				typ:     ckmRSAPKCSPSS,
+				pss:     true,
+				hashAlg: ckmSHA256,
+				mgf:     ckgMGF1SHA256,
+				saltLen: 256 - 32 - 2,
+			},
+			expData: digest[:],
+		},
+		"rsa pkcs1v15": {
+			pub:     &rsaKey.PublicKey,
+			digest:  digest[:],
+			opts:    crypto.SHA256,
+			expMech: &mechanism{typ: ckmRSAPKCS},
+			expData: append(append([]byte{}, digestInfoPrefixes[crypto.SHA256]...), digest[:]...),
+		},
+		"rsa unsupported hash": {
+			pub:    &rsaKey.PublicKey,
+			digest: make([]byte, crypto.SHA1.Size()),
+			opts:   crypto.SHA1,
+			expErr: errors.New("unsupported RSA PKCS #1 v1.5 hash"),
+		},
+		"digest length mismatch": {
+			pub:    &rsaKey.PublicKey,
+			digest: digest[:16],
+			opts:   crypto.SHA256,
+			expErr: errors.New("does not match"),
+		},
+		"ecdsa": {
+			pub:     &ecKey.PublicKey,
+			digest:  digest[:],
+			opts:    crypto.SHA256,
+			expMech: &mechanism{typ: ckmECDSA},
+			expData: digest[:],
+		},
+		"ed25519": {
+			pub:     edPub,
+			digest:  []byte("data"),
+			opts:    crypto.Hash(0),
+			expMech: &mechanism{typ: ckmEDDSA},
+			expData: []byte("data"),
+		},
+		"ed25519 prehashed": {
+			pub:    edPub,
+			digest: digest[:],
+			opts:   crypto.SHA256,
+			expErr: errors.New("unhashed message"),
+		},
+		"unsupported key": {
+			pub:    "key",
+			digest: digest[:],
+			opts:   crypto.SHA256,
+			expErr: errors.New("unsupported public key type"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mech, data, err := signMechanism(tc.pub, tc.digest, tc.opts)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.CmpAny(t, "mechanism", *tc.expMech, *mech, cmp.AllowUnexported(mechanism{}))
+			test.CmpAny(t, "data", tc.expData, data)
+		})
+	}
+}
+
+func TestPKCS11_digestInfoPrefixes(t *testing.T) {
+	oids := map[crypto.Hash]asn1.ObjectIdentifier{
+		crypto.SHA256: {2, 16, 840, 1, 101, 3, 4, 2, 1},
+		crypto.SHA384: {2, 16, 840, 1, 101, 3, 4, 2, 2},
+		crypto.SHA512: {2, 16, 840, 1, 101, 3, 4, 2, 3},
+	}
+
+	for hash, prefix := range digestInfoPrefixes {
+		t.Run(hash.String(), func(t *testing.T) {
+			digest := make([]byte, hash.Size())
+			var info struct {
+				Algorithm pkix.AlgorithmIdentifier
+				Digest    []byte
+			}
+			rest, err := asn1.Unmarshal(append(append([]byte{}, prefix...), digest...), &info)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, 0, len(rest), "trailing data")
+			test.AssertTrue(t, info.Algorithm.Algorithm.Equal(oids[hash]), "unexpected algorithm")
+			test.AssertEqual(t, hash.Size(), len(info.Digest), "unexpected digest length")
+		})
+	}
+}
+
+func TestPKCS11_ecdsaSignatureToASN1(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("data"))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Tokens return r and s padded to the size of the curve.
+	raw := make([]byte, 96)
+	r.FillBytes(raw[:48])
+	s.FillBytes(raw[48:])
+
+	sig, err := ecdsaSignatureToASN1(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], sig), "converted signature does not verify")
+
+	var parsed struct{ R, S *big.Int }
+	if _, err := asn1.Unmarshal(sig, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 0, r.Cmp(parsed.R), "unexpected r")
+
+	if _, err := ecdsaSignatureToASN1(raw[:95]); err == nil {
+		t.Fatal("expected error for odd-length signature")
+	}
+}
+
+func TestPKCS11_Error(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err        error
+		expRemoved bool
+		expMsg     string
+	}{
+		"device removed": {
+			err:        Error(ckrDeviceRemoved),
+			expRemoved: true,
+			expMsg:     "CKR_DEVICE_REMOVED (0x32)",
+		},
+		"wrapped session closed": {
+			err:        errors.Wrap(Error(ckrSessionHandleInvalid), "signing"),
+			expRemoved: true,
+			expMsg:     "signing: pkcs11: CKR_SESSION_HANDLE_INVALID",
+		},
+		"pin incorrect": {
+			err:    Error(ckrPINIncorrect),
+			expMsg: "CKR_PIN_INCORRECT",
+		},
+		"unknown": {
+			err:    Error(0x12345),
+			expMsg: "pkcs11: error 0x12345",
+		},
+		"other error": {
+			err:    errors.New("not pkcs11"),
+			expMsg: "not pkcs11",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.AssertEqual(t, tc.expRemoved, isTokenRemoved(tc.err), "unexpected removal")
+			test.CmpErr(t, errors.New(tc.expMsg), tc.err)
+		})
+	}
+}
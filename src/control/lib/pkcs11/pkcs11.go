@@ -0,0 +1,535 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Package pkcs11 provides signing with private keys held on PKCS#11 tokens,
+// such as HSMs, smart cards or softHSM. The PKCS#11 module is loaded with
+// dlopen, so no PKCS#11 library is needed to build the package.
+package pkcs11
+
+/*
+#include <stdlib.h>
+#include <string.h>
+
+typedef unsigned long ck_ulong;
+
+typedef struct {
+	void		*create_mutex;
+	void		*destroy_mutex;
+	void		*lock_mutex;
+	void		*unlock_mutex;
+	ck_ulong	 flags;
+	void		*reserved;
+} ck_c_initialize_args;
+
+typedef struct {
+	ck_ulong	 type;
+	void		*value;
+	ck_ulong	 value_len;
+} ck_attribute;
+
+typedef struct {
+	ck_ulong	 mechanism;
+	void		*parameter;
+	ck_ulong	 parameter_len;
+} ck_mechanism;
+
+typedef struct {
+	ck_ulong	hash_alg;
+	ck_ulong	mgf;
+	ck_ulong	salt_len;
+} ck_rsa_pkcs_pss_params;
+
+typedef struct {
+	unsigned char	major;
+	unsigned char	minor;
+} ck_version;
+
+typedef struct {
+	unsigned char	label[32];
+	unsigned char	manufacturer_id[32];
+	unsigned char	model[16];
+	unsigned char	serial_number[16];
+	ck_ulong	flags;
+	ck_ulong	max_session_count;
+	ck_ulong	session_count;
+	ck_ulong	max_rw_session_count;
+	ck_ulong	rw_session_count;
+	ck_ulong	max_pin_len;
+	ck_ulong	min_pin_len;
+	ck_ulong	total_public_memory;
+	ck_ulong	free_public_memory;
+	ck_ulong	total_private_memory;
+	ck_ulong	free_private_memory;
+	ck_version	hardware_version;
+	ck_version	firmware_version;
+	unsigned char	utc_time[16];
+} ck_token_info;
+
+#define CKF_OS_LOCKING_OK	0x2
+#define CKF_SERIAL_SESSION	0x4
+#define CKU_USER		0x1
+#define CKA_CLASS		0x0
+#define CKA_LABEL		0x3
+#define CKA_ID			0x102
+#define CKO_PRIVATE_KEY		0x3
+
+static ck_ulong
+p11_initialize(void *fn)
+{
+	ck_c_initialize_args args;
+
+	memset(&args, 0, sizeof(args));
+	args.flags = CKF_OS_LOCKING_OK;
+	return ((ck_ulong (*)(void *))fn)(&args);
+}
+
+static ck_ulong
+p11_get_slot_list(void *fn, ck_ulong *slots, ck_ulong *count)
+{
+	// Only slots with a token present are listed.
+	return ((ck_ulong (*)(unsigned char, ck_ulong *, ck_ulong *))fn)(1, slots, count);
+}
+
+static ck_ulong
+p11_get_token_label(void *fn, ck_ulong slot, unsigned char *label)
+{
+	ck_token_info	info;
+	ck_ulong	rc;
+
+	memset(&info, 0, sizeof(info));
+	rc = ((ck_ulong (*)(ck_ulong, ck_token_info *))fn)(slot, &info);
+	if (rc == 0)
+		memcpy(label, info.label, sizeof(info.label));
+	return rc;
+}
+
+static ck_ulong
+p11_open_session(void *fn, ck_ulong slot, ck_ulong *session)
+{
+	return ((ck_ulong (*)(ck_ulong, ck_ulong, void *, void *, ck_ulong *))fn)(
+		slot, CKF_SERIAL_SESSION, NULL, NULL, session);
+}
+
+static ck_ulong
+p11_close_session(void *fn, ck_ulong session)
+{
+	return ((ck_ulong (*)(ck_ulong))fn)(session);
+}
+
+static ck_ulong
+p11_login(void *fn, ck_ulong session, unsigned char *pin, ck_ulong pin_len)
+{
+	return ((ck_ulong (*)(ck_ulong, ck_ulong, unsigned char *, ck_ulong))fn)(
+		session, CKU_USER, pin, pin_len);
+}
+
+static ck_ulong
+p11_find_private_keys(void *init_fn, void *find_fn, void *final_fn, ck_ulong session,
+		      void *label, ck_ulong label_len, void *id, ck_ulong id_len,
+		      ck_ulong *keys, ck_ulong max_keys, ck_ulong *count)
+{
+	ck_ulong	key_class = CKO_PRIVATE_KEY;
+	ck_attribute	tmpl[3];
+	ck_ulong	n = 0;
+	ck_ulong	rc;
+
+	tmpl[n].type = CKA_CLASS;
+	tmpl[n].value = &key_class;
+	tmpl[n++].value_len = sizeof(key_class);
+	if (label_len > 0) {
+		tmpl[n].type = CKA_LABEL;
+		tmpl[n].value = label;
+		tmpl[n++].value_len = label_len;
+	}
+	if (id_len > 0) {
+		tmpl[n].type = CKA_ID;
+		tmpl[n].value = id;
+		tmpl[n++].value_len = id_len;
+	}
+
+	rc = ((ck_ulong (*)(ck_ulong, ck_attribute *, ck_ulong))init_fn)(session, tmpl, n);
+	if (rc != 0)
+		return rc;
+	rc = ((ck_ulong (*)(ck_ulong, ck_ulong *, ck_ulong, ck_ulong *))find_fn)(
+		session, keys, max_keys, count);
+	((ck_ulong (*)(ck_ulong))final_fn)(session);
+	return rc;
+}
+
+static ck_ulong
+p11_sign(void *init_fn, void *sign_fn, ck_ulong session, ck_ulong key, ck_ulong mech_type,
+	 int pss, ck_ulong hash_alg, ck_ulong mgf, ck_ulong salt_len,
+	 unsigned char *data, ck_ulong data_len, unsigned char *sig, ck_ulong *sig_len)
+{
+	ck_rsa_pkcs_pss_params	params;
+	ck_mechanism		mech;
+	ck_ulong		rc;
+
+	memset(&mech, 0, sizeof(mech));
+	mech.mechanism = mech_type;
+	if (pss) {
+		params.hash_alg = hash_alg;
+		params.mgf = mgf;
+		params.salt_len = salt_len;
+		mech.parameter = &params;
+		mech.parameter_len = sizeof(params);
+	}
+
+	rc = ((ck_ulong (*)(ck_ulong, ck_mechanism *, ck_ulong))init_fn)(session, &mech, key);
+	if (rc != 0)
+		return rc;
+	return ((ck_ulong (*)(ck_ulong, unsigned char *, ck_ulong, unsigned char *, ck_ulong *))sign_fn)(
+		session, data, data_len, sig, sig_len);
+}
+*/
+import "C"
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/dlopen"
+)
+
+const (
+	// maxSignatureLen is the size of the buffer signatures are written to,
+	// large enough for an RSA key of 8192 bits.
+	maxSignatureLen = 1024
+	maxSlots        = 64
+)
+
+// moduleFunctions are the PKCS#11 functions used, resolved from the module.
+var moduleFunctions = []string{
+	"C_Initialize",
+	"C_GetSlotList",
+	"C_GetTokenInfo",
+	"C_OpenSession",
+	"C_CloseSession",
+	"C_Login",
+	"C_FindObjectsInit",
+	"C_FindObjects",
+	"C_FindObjectsFinal",
+	"C_SignInit",
+	"C_Sign",
+}
+
+// module is a loaded and initialized PKCS#11 module. Modules are never
+// unloaded, as other keys may be opened with them later.
+type module struct {
+	path string
+	lib  *dlopen.LibHandle
+	fns  map[string]unsafe.Pointer
+}
+
+var (
+	modulesMutex sync.Mutex
+	modules      = make(map[string]*module)
+)
+
+// loadModule returns the initialized module at the path.
+func loadModule(path string) (*module, error) {
+	modulesMutex.Lock()
+	defer modulesMutex.Unlock()
+
+	if mod, found := modules[path]; found {
+		return mod, nil
+	}
+
+	lib, err := dlopen.GetHandle(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading PKCS#11 module %s", path)
+	}
+
+	mod := &module{
+		path: path,
+		lib:  lib,
+		fns:  make(map[string]unsafe.Pointer),
+	}
+	for _, name := range moduleFunctions {
+		fn, err := lib.GetSymbolPointer(name)
+		if err == nil && fn == nil {
+			err = errors.Errorf("symbol %q is nil", name)
+		}
+		if err != nil {
+			lib.Close()
+			return nil, errors.Wrapf(err, "PKCS#11 module %s", path)
+		}
+		mod.fns[name] = fn
+	}
+
+	if rc := C.p11_initialize(mod.fns["C_Initialize"]); rc != ckrOK && rc != ckrCryptokiAlreadyInitialized {
+		lib.Close()
+		return nil, errors.Wrapf(Error(rc), "initializing PKCS#11 module %s", path)
+	}
+
+	modules[path] = mod
+	return mod, nil
+}
+
+func checkRC(rc C.ck_ulong, op string) error {
+	if rc == ckrOK {
+		return nil
+	}
+	return errors.Wrap(Error(rc), op)
+}
+
+// findSlot returns the slot holding the configured token.
+func (mod *module) findSlot(cfg *KeyConfig) (C.ck_ulong, error) {
+	slots := make([]C.ck_ulong, maxSlots)
+	count := C.ck_ulong(len(slots))
+	if err := checkRC(C.p11_get_slot_list(mod.fns["C_GetSlotList"], &slots[0], &count), "listing slots"); err != nil {
+		return 0, err
+	}
+
+	for _, slot := range slots[:count] {
+		if cfg.Slot != nil {
+			if uint(slot) == *cfg.Slot {
+				return slot, nil
+			}
+			continue
+		}
+
+		label := make([]byte, 32)
+		if err := checkRC(C.p11_get_token_label(mod.fns["C_GetTokenInfo"], slot, (*C.uchar)(&label[0])), "getting token info"); err != nil {
+			return 0, err
+		}
+		if strings.TrimRight(string(label), " \x00") == cfg.TokenLabel {
+			return slot, nil
+		}
+	}
+
+	if cfg.Slot != nil {
+		return 0, errors.Wrapf(Error(ckrTokenNotPresent), "no token in slot %d", *cfg.Slot)
+	}
+	return 0, errors.Wrapf(Error(ckrTokenNotPresent), "no token labeled %q", cfg.TokenLabel)
+}
+
+// KeyConfig identifies a private key held on a PKCS#11 token.
+type KeyConfig struct {
+	// Module is the path of the PKCS#11 module's shared library.
+	Module string
+	// TokenLabel selects the token by its label, unless Slot is set.
+	TokenLabel string
+	// Slot selects the token by the ID of its slot.
+	Slot *uint
+	// KeyLabel and KeyID select the private key on the token. At least
+	// one must be set, and together they must match a single key.
+	KeyLabel string
+	KeyID    []byte
+	// PIN is the user PIN of the token.
+	PIN string
+}
+
+// keyDesc describes the key for error messages.
+func (cfg *KeyConfig) keyDesc() string {
+	var desc []string
+	if cfg.KeyLabel != "" {
+		desc = append(desc, fmt.Sprintf("labeled %q", cfg.KeyLabel))
+	}
+	if len(cfg.KeyID) > 0 {
+		desc = append(desc, fmt.Sprintf("with ID %x", cfg.KeyID))
+	}
+	return strings.Join(desc, " ")
+}
+
+// Key is a private key held on a PKCS#11 token. It implements crypto.Signer,
+// for RSA (PKCS #1 v1.5 and PSS), ECDSA and Ed25519 keys. If the token goes
+// away, e.g. because it was removed or the HSM was restarted, signing fails
+// until it is back, when a new session is opened with it.
+type Key struct {
+	sync.Mutex
+	cfg     KeyConfig
+	mod     *module
+	pub     crypto.PublicKey
+	session C.ck_ulong
+	handle  C.ck_ulong
+	open    bool
+}
+
+// OpenKey opens a session with the token holding the configured key, whose
+// public half is pub.
+func OpenKey(cfg KeyConfig, pub crypto.PublicKey) (*Key, error) {
+	if cfg.KeyLabel == "" && len(cfg.KeyID) == 0 {
+		return nil, errors.New("a key label or ID is required")
+	}
+	if cfg.TokenLabel == "" && cfg.Slot == nil {
+		return nil, errors.New("a token label or slot is required")
+	}
+
+	mod, err := loadModule(cfg.Module)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &Key{
+		cfg: cfg,
+		mod: mod,
+		pub: pub,
+	}
+	if err := key.openSession(); err != nil {
+		return nil, err
+	}
+	runtime.SetFinalizer(key, func(k *Key) { k.Close() })
+	return key, nil
+}
+
+// openSession opens a session with the token, logs in and finds the key.
+func (k *Key) openSession() error {
+	slot, err := k.mod.findSlot(&k.cfg)
+	if err != nil {
+		return err
+	}
+
+	var session C.ck_ulong
+	if err := checkRC(C.p11_open_session(k.mod.fns["C_OpenSession"], slot, &session), "opening session"); err != nil {
+		return err
+	}
+
+	if err := k.login(session); err != nil {
+		C.p11_close_session(k.mod.fns["C_CloseSession"], session)
+		return err
+	}
+
+	handle, err := k.findKey(session)
+	if err != nil {
+		C.p11_close_session(k.mod.fns["C_CloseSession"], session)
+		return err
+	}
+
+	k.session = session
+	k.handle = handle
+	k.open = true
+	return nil
+}
+
+func (k *Key) login(session C.ck_ulong) error {
+	if k.cfg.PIN == "" {
+		return nil
+	}
+
+	pin := C.CBytes([]byte(k.cfg.PIN))
+	defer C.free(pin)
+	rc := C.p11_login(k.mod.fns["C_Login"], session, (*C.uchar)(pin), C.ck_ulong(len(k.cfg.PIN)))
+	if rc == ckrUserAlreadyLoggedIn {
+		return nil
+	}
+	return checkRC(rc, "logging in to token")
+}
+
+func (k *Key) findKey(session C.ck_ulong) (C.ck_ulong, error) {
+	var label, id unsafe.Pointer
+	if k.cfg.KeyLabel != "" {
+		label = C.CBytes([]byte(k.cfg.KeyLabel))
+		defer C.free(label)
+	}
+	if len(k.cfg.KeyID) > 0 {
+		id = C.CBytes(k.cfg.KeyID)
+		defer C.free(id)
+	}
+
+	keys := make([]C.ck_ulong, 2)
+	var count C.ck_ulong
+	rc := C.p11_find_private_keys(k.mod.fns["C_FindObjectsInit"], k.mod.fns["C_FindObjects"],
+		k.mod.fns["C_FindObjectsFinal"], session, label, C.ck_ulong(len(k.cfg.KeyLabel)),
+		id, C.ck_ulong(len(k.cfg.KeyID)), &keys[0], C.ck_ulong(len(keys)), &count)
+	if err := checkRC(rc, "finding private key"); err != nil {
+		return 0, err
+	}
+
+	switch count {
+	case 0:
+		return 0, errors.Errorf("no private key %s on token", k.cfg.keyDesc())
+	case 1:
+		return keys[0], nil
+	default:
+		return 0, errors.Errorf("more than one private key %s on token", k.cfg.keyDesc())
+	}
+}
+
+func (k *Key) closeSession() {
+	if !k.open {
+		return
+	}
+	C.p11_close_session(k.mod.fns["C_CloseSession"], k.session)
+	k.open = false
+}
+
+// Close closes the session with the token.
+func (k *Key) Close() error {
+	k.Lock()
+	defer k.Unlock()
+
+	k.closeSession()
+	return nil
+}
+
+// Public returns the public half of the key.
+func (k *Key) Public() crypto.PublicKey {
+	return k.pub
+}
+
+func (k *Key) sign(mech *mechanism, data []byte) ([]byte, error) {
+	if !k.open {
+		if err := k.openSession(); err != nil {
+			return nil, err
+		}
+	}
+
+	sig := make([]byte, maxSignatureLen)
+	sigLen := C.ck_ulong(len(sig))
+	var pss C.int
+	if mech.pss {
+		pss = 1
+	}
+	cData := C.CBytes(data)
+	defer C.free(cData)
+
+	rc := C.p11_sign(k.mod.fns["C_SignInit"], k.mod.fns["C_Sign"], k.session, k.handle,
+		C.ck_ulong(mech.typ), pss, C.ck_ulong(mech.hashAlg), C.ck_ulong(mech.mgf),
+		C.ck_ulong(mech.saltLen), (*C.uchar)(cData), C.ck_ulong(len(data)),
+		(*C.uchar)(unsafe.Pointer(&sig[0])), &sigLen)
+	if err := checkRC(rc, "signing"); err != nil {
+		return nil, err
+	}
+	return sig[:sigLen], nil
+}
+
+// Sign signs the digest with the key on the token. If the token has gone away,
+// a new session is opened with it, so that signing succeeds once it is back.
+func (k *Key) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	mech, data, err := signMechanism(k.pub, digest, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	k.Lock()
+	defer k.Unlock()
+
+	sig, err := k.sign(mech, data)
+	if isTokenRemoved(err) {
+		k.closeSession()
+		sig, err = k.sign(mech, data)
+	}
+	if err != nil {
+		if isTokenRemoved(err) {
+			k.closeSession()
+		}
+		return nil, errors.Wrapf(err, "PKCS#11 module %s", k.mod.path)
+	}
+
+	if _, isECDSA := k.pub.(*ecdsa.PublicKey); isECDSA {
+		return ecdsaSignatureToASN1(sig)
+	}
+	return sig, nil
+}
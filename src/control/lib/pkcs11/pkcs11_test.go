@@ -0,0 +1,60 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pkcs11
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestPKCS11_OpenKey(t *testing.T) {
+	slot := uint(0)
+
+	for name, tc := range map[string]struct {
+		cfg    KeyConfig
+		expErr error
+	}{
+		"no key": {
+			cfg: KeyConfig{
+				Module:     "libsofthsm2.so",
+				TokenLabel: "daos",
+			},
+			expErr: errors.New("key label or ID is required"),
+		},
+		"no token": {
+			cfg: KeyConfig{
+				Module:   "libsofthsm2.so",
+				KeyLabel: "agent",
+			},
+			expErr: errors.New("token label or slot is required"),
+		},
+		"missing module": {
+			cfg: KeyConfig{
+				Module:   "/nonexistent/libpkcs11.so",
+				Slot:     &slot,
+				KeyLabel: "agent",
+			},
+			expErr: errors.New("loading PKCS#11 module"),
+		},
+		"not a module": {
+			cfg: KeyConfig{
+				Module:   "libc.so.6",
+				Slot:     &slot,
+				KeyLabel: "agent",
+			},
+			expErr: errors.New("C_Initialize"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := OpenKey(tc.cfg, nil)
+			test.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
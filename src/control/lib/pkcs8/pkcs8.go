@@ -0,0 +1,356 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Package pkcs8 encrypts and decrypts PKCS#8 private keys with a passphrase,
+// using the PBES2 scheme of RFC 8018 with the scrypt (RFC 7914) or PBKDF2 key
+// derivation functions and AES-CBC encryption. These are the encrypted keys
+// written by "openssl pkcs8 -topk8 -scrypt" and "openssl pkcs8 -topk8 -v2
+// aes-256-cbc". The legacy PBES1 schemes, and PEM files with a DEK-Info
+// header, are not supported.
+package pkcs8
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"hash"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// PEMType is the type of the PEM block holding an encrypted key.
+	PEMType = "ENCRYPTED PRIVATE KEY"
+
+	// maxScryptMemory limits the memory used to derive a key with scrypt,
+	// so that a crafted key file can't exhaust the memory of the process.
+	maxScryptMemory = 1 << 30
+	// maxPBKDF2Iterations limits the time spent deriving a key with PBKDF2.
+	maxPBKDF2Iterations = 1 << 24
+)
+
+// ErrIncorrectPassphrase is returned if the key can't be decrypted with the
+// passphrase.
+var ErrIncorrectPassphrase = errors.New("incorrect passphrase")
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidScrypt = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11591, 4, 11}
+
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA224 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 8}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	oidAES128CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+type (
+	encryptedPrivateKeyInfo struct {
+		Algorithm     pkix.AlgorithmIdentifier
+		EncryptedData []byte
+	}
+
+	pbes2Params struct {
+		KeyDerivationFunc pkix.AlgorithmIdentifier
+		EncryptionScheme  pkix.AlgorithmIdentifier
+	}
+
+	pbkdf2Params struct {
+		Salt           []byte
+		IterationCount int
+		KeyLength      int                      `asn1:"optional"`
+		PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+	}
+
+	scryptParams struct {
+		Salt                     []byte
+		CostParameter            int
+		BlockSize                int
+		ParallelizationParameter int
+		KeyLength                int `asn1:"optional"`
+	}
+
+	// privateKeyInfo is only parsed to check that a key was decrypted.
+	privateKeyInfo struct {
+		Version    int
+		Algorithm  pkix.AlgorithmIdentifier
+		PrivateKey []byte
+	}
+)
+
+// KDF is a function deriving the key which encrypts a private key from the
+// passphrase.
+type KDF int
+
+const (
+	// KDFScrypt derives the key with scrypt, which is memory-hard.
+	KDFScrypt KDF = iota
+	// KDFPBKDF2 derives the key with PBKDF2 and HMAC-SHA256.
+	KDFPBKDF2
+)
+
+const (
+	defaultScryptN          = 1 << 14
+	defaultScryptR          = 8
+	defaultScryptP          = 1
+	defaultPBKDF2Iterations = 600000
+	saltLen                 = 16
+)
+
+// DecryptPrivateKey decrypts the DER encoding of a PKCS#8
+// EncryptedPrivateKeyInfo with the passphrase, and returns the DER encoding of
+// the PKCS#8 PrivateKeyInfo, which may be parsed with
+// x509.ParsePKCS8PrivateKey.
+func DecryptPrivateKey(der, passphrase []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, errors.Wrap(err, "parsing encrypted private key")
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after encrypted private key")
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, errors.Errorf("unsupported key encryption scheme %s (only PBES2 is supported)",
+			info.Algorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, errors.Wrap(err, "parsing PBES2 parameters")
+	}
+
+	keyLen, err := aesKeyLen(params.EncryptionScheme.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, errors.Wrap(err, "parsing cipher parameters")
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, errors.Errorf("invalid AES-CBC IV length %d", len(iv))
+	}
+
+	key, err := deriveKey(params.KeyDerivationFunc, passphrase, keyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := decryptCBC(key, iv, info.EncryptedData)
+	if err != nil {
+		return nil, err
+	}
+	var pki privateKeyInfo
+	if rest, err := asn1.Unmarshal(plain, &pki); err != nil || len(rest) != 0 {
+		return nil, ErrIncorrectPassphrase
+	}
+	return plain, nil
+}
+
+// EncryptPrivateKey encrypts the DER encoding of a PKCS#8 PrivateKeyInfo with
+// AES-256-CBC, under a key derived from the passphrase with the KDF, and
+// returns the DER encoding of the PKCS#8 EncryptedPrivateKeyInfo.
+func EncryptPrivateKey(rand io.Reader, der, passphrase []byte, kdf KDF) ([]byte, error) {
+	salt := make([]byte, saltLen)
+	if _, err := io.ReadFull(rand, salt); err != nil {
+		return nil, err
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand, iv); err != nil {
+		return nil, err
+	}
+
+	var kdfAlg pkix.AlgorithmIdentifier
+	var key []byte
+	switch kdf {
+	case KDFScrypt:
+		params, err := asn1.Marshal(scryptParams{
+			Salt:                     salt,
+			CostParameter:            defaultScryptN,
+			BlockSize:                defaultScryptR,
+			ParallelizationParameter: defaultScryptP,
+		})
+		if err != nil {
+			return nil, err
+		}
+		kdfAlg = pkix.AlgorithmIdentifier{Algorithm: oidScrypt, Parameters: asn1.RawValue{FullBytes: params}}
+		key, err = scrypt(passphrase, salt, defaultScryptN, defaultScryptR, defaultScryptP, 32)
+		if err != nil {
+			return nil, err
+		}
+	case KDFPBKDF2:
+		params, err := asn1.Marshal(pbkdf2Params{
+			Salt:           salt,
+			IterationCount: defaultPBKDF2Iterations,
+			PRF:            pkix.AlgorithmIdentifier{Algorithm: oidHMACWithSHA256, Parameters: asn1.NullRawValue},
+		})
+		if err != nil {
+			return nil, err
+		}
+		kdfAlg = pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: params}}
+		key = pbkdf2(sha256.New, passphrase, salt, defaultPBKDF2Iterations, 32)
+	default:
+		return nil, errors.Errorf("unknown KDF %d", kdf)
+	}
+
+	ivParams, err := asn1.Marshal(iv)
+	if err != nil {
+		return nil, err
+	}
+	params, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: kdfAlg,
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidAES256CBC, Parameters: asn1.RawValue{FullBytes: ivParams}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	padLen := aes.BlockSize - len(der)%aes.BlockSize
+	data := append(append([]byte{}, der...), bytes.Repeat([]byte{byte(padLen)}, padLen)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(data, data)
+
+	return asn1.Marshal(encryptedPrivateKeyInfo{
+		Algorithm:     pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: params}},
+		EncryptedData: data,
+	})
+}
+
+func aesKeyLen(oid asn1.ObjectIdentifier) (int, error) {
+	switch {
+	case oid.Equal(oidAES128CBC):
+		return 16, nil
+	case oid.Equal(oidAES192CBC):
+		return 24, nil
+	case oid.Equal(oidAES256CBC):
+		return 32, nil
+	default:
+		return 0, errors.Errorf("unsupported key encryption cipher %s (only AES-CBC is supported)", oid)
+	}
+}
+
+func deriveKey(alg pkix.AlgorithmIdentifier, passphrase []byte, keyLen int) ([]byte, error) {
+	switch {
+	case alg.Algorithm.Equal(oidScrypt):
+		var params scryptParams
+		if _, err := asn1.Unmarshal(alg.Parameters.FullBytes, &params); err != nil {
+			return nil, errors.Wrap(err, "parsing scrypt parameters")
+		}
+		if params.KeyLength != 0 && params.KeyLength != keyLen {
+			return nil, errors.Errorf("scrypt key length %d doesn't match the cipher", params.KeyLength)
+		}
+		return scrypt(passphrase, params.Salt, params.CostParameter, params.BlockSize,
+			params.ParallelizationParameter, keyLen)
+	case alg.Algorithm.Equal(oidPBKDF2):
+		var params pbkdf2Params
+		if _, err := asn1.Unmarshal(alg.Parameters.FullBytes, &params); err != nil {
+			return nil, errors.Wrap(err, "parsing PBKDF2 parameters")
+		}
+		if params.KeyLength != 0 && params.KeyLength != keyLen {
+			return nil, errors.Errorf("PBKDF2 key length %d doesn't match the cipher", params.KeyLength)
+		}
+		if params.IterationCount < 1 || params.IterationCount > maxPBKDF2Iterations {
+			return nil, errors.Errorf("invalid PBKDF2 iteration count %d", params.IterationCount)
+		}
+		prf, err := pbkdf2PRF(params.PRF.Algorithm)
+		if err != nil {
+			return nil, err
+		}
+		return pbkdf2(prf, passphrase, params.Salt, params.IterationCount, keyLen), nil
+	default:
+		return nil, errors.Errorf("unsupported key derivation function %s (only scrypt and PBKDF2 are supported)",
+			alg.Algorithm)
+	}
+}
+
+func pbkdf2PRF(oid asn1.ObjectIdentifier) (func() hash.Hash, error) {
+	switch {
+	case len(oid) == 0, oid.Equal(oidHMACWithSHA1):
+		return sha1.New, nil
+	case oid.Equal(oidHMACWithSHA224):
+		return sha256.New224, nil
+	case oid.Equal(oidHMACWithSHA256):
+		return sha256.New, nil
+	case oid.Equal(oidHMACWithSHA384):
+		return sha512.New384, nil
+	case oid.Equal(oidHMACWithSHA512):
+		return sha512.New, nil
+	default:
+		return nil, errors.Errorf("unsupported PBKDF2 PRF %s", oid)
+	}
+}
+
+func decryptCBC(key, iv, data []byte) ([]byte, error) {
+	if len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return nil, errors.New("encrypted key is not a whole number of blocks")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, data)
+
+	// A wrong passphrase almost always leaves invalid padding.
+	padLen := int(plain[len(plain)-1])
+	if padLen == 0 || padLen > aes.BlockSize {
+		return nil, ErrIncorrectPassphrase
+	}
+	for _, b := range plain[len(plain)-padLen:] {
+		if int(b) != padLen {
+			return nil, ErrIncorrectPassphrase
+		}
+	}
+	return plain[:len(plain)-padLen], nil
+}
+
+// pbkdf2 derives a key as defined in RFC 8018, section 5.2.
+func pbkdf2(h func() hash.Hash, password, salt []byte, iter, keyLen int) []byte {
+	prf := hmac.New(h, password)
+	hashLen := prf.Size()
+	numBlocks := (keyLen + hashLen - 1) / hashLen
+
+	var counter [4]byte
+	dk := make([]byte, 0, numBlocks*hashLen)
+	u := make([]byte, hashLen)
+	for block := 1; block <= numBlocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		counter[0] = byte(block >> 24)
+		counter[1] = byte(block >> 16)
+		counter[2] = byte(block >> 8)
+		counter[3] = byte(block)
+		prf.Write(counter[:])
+		dk = prf.Sum(dk)
+		t := dk[len(dk)-hashLen:]
+		copy(u, t)
+
+		for n := 2; n <= iter; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for i := range u {
+				t[i] ^= u[i]
+			}
+		}
+	}
+	return dk[:keyLen]
+}
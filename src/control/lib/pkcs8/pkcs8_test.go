@@ -0,0 +1,182 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pkcs8
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func loadTestPEM(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("no PEM data in %s", name)
+	}
+	return block.Bytes
+}
+
+func TestPKCS8_DecryptPrivateKey(t *testing.T) {
+	plain := loadTestPEM(t, "key.pem")
+
+	for name, tc := range map[string]struct {
+		file       string
+		passphrase string
+		expErr     error
+	}{
+		"scrypt": {
+			file:       "key_scrypt.pem",
+			passphrase: "correct-horse",
+		},
+		"pbkdf2 with hmacWithSHA512 and aes-128-cbc": {
+			file:       "key_pbkdf2.pem",
+			passphrase: "correct-horse",
+		},
+		"pbkdf2 with hmacWithSHA256 and aes-256-cbc": {
+			file:       "key_pbkdf2_sha256.pem",
+			passphrase: "correct-horse",
+		},
+		"incorrect passphrase": {
+			file:       "key_scrypt.pem",
+			passphrase: "battery-staple",
+			expErr:     ErrIncorrectPassphrase,
+		},
+		"pbes1": {
+			file:       "key_pbes1.pem",
+			passphrase: "correct-horse",
+			expErr:     errors.New("only PBES2 is supported"),
+		},
+		"not encrypted": {
+			file:   "key.pem",
+			expErr: errors.New("parsing encrypted private key"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			der, err := DecryptPrivateKey(loadTestPEM(t, tc.file), []byte(tc.passphrase))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertTrue(t, bytes.Equal(plain, der), "decrypted key doesn't match")
+		})
+	}
+}
+
+func TestPKCS8_EncryptPrivateKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, kdf := range map[string]KDF{
+		"scrypt": KDFScrypt,
+		"pbkdf2": KDFPBKDF2,
+	} {
+		t.Run(name, func(t *testing.T) {
+			encrypted, err := EncryptPrivateKey(rand.Reader, plain, []byte("secret"), kdf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = DecryptPrivateKey(encrypted, []byte("not the secret"))
+			test.CmpErr(t, ErrIncorrectPassphrase, err)
+
+			der, err := DecryptPrivateKey(encrypted, []byte("secret"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertTrue(t, bytes.Equal(plain, der), "decrypted key doesn't match")
+		})
+	}
+}
+
+func TestPKCS8_scrypt(t *testing.T) {
+	// Test vectors from RFC 7914, section 12.
+	for name, tc := range map[string]struct {
+		password string
+		salt     string
+		n, r, p  int
+		expKey   string
+		expErr   error
+	}{
+		"empty": {
+			n: 16, r: 1, p: 1,
+			expKey: "77d6576238657b203b19ca42c18a0497f16b4844e3074ae8dfdffa3fede21442" +
+				"fcd0069ded0948f8326a753a0fc81f17e8d3e0fb2e0d3628cf35e20c38d18906",
+		},
+		"password": {
+			password: "password",
+			salt:     "NaCl",
+			n:        1024, r: 8, p: 16,
+			expKey: "fdbabe1c9d3472007856e7190d01e9fe7c6ad7cbc8237830e77376634b373162" +
+				"2eaf30d92e22a3886ff109279d9830dac727afb94a83ee6d8360cbdfa2cc0640",
+		},
+		"n not a power of two": {
+			n: 1000, r: 8, p: 1,
+			expErr: errors.New("must be a power of two"),
+		},
+		"too much memory": {
+			n: 1 << 24, r: 8, p: 1,
+			expErr: errors.New("bytes of memory"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			key, err := scrypt([]byte(tc.password), []byte(tc.salt), tc.n, tc.r, tc.p, 64)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.expKey, hex.EncodeToString(key), "unexpected key")
+		})
+	}
+}
+
+func TestPKCS8_pbkdf2(t *testing.T) {
+	// Test vectors from RFC 6070.
+	for name, tc := range map[string]struct {
+		iter   int
+		expKey string
+	}{
+		"one iteration": {
+			iter:   1,
+			expKey: "0c60c80f961f0e71f3a9b524af6012062fe037a6",
+		},
+		"4096 iterations": {
+			iter:   4096,
+			expKey: "4b007901b765489abead49d926f721d065a429c1",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			key := pbkdf2(sha1.New, []byte("password"), []byte("salt"), tc.iter, 20)
+			test.AssertEqual(t, tc.expKey, hex.EncodeToString(key), "unexpected key")
+		})
+	}
+}
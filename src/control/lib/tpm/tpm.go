@@ -0,0 +1,276 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Package tpm provides signing keys held in a TPM 2.0, using the tpm2-tools
+// commands. The private half of such a key never leaves the TPM in which it
+// was generated, so it can't be used on any other machine.
+package tpm
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultTimeout is the default time allowed for a TPM operation.
+	DefaultTimeout = 10 * time.Second
+
+	persistentHandleFirst = 0x81000000
+	persistentHandleLast  = 0x81ffffff
+
+	// keyAttributes make the key a signing key which can't be duplicated
+	// out of the TPM, and whose private half was generated by it.
+	keyAttributes = "fixedtpm|fixedparent|sensitivedataorigin|userwithauth|sign"
+)
+
+// Algorithms of keys which may be generated.
+const (
+	AlgECCP256 = "ecc256"
+	AlgECCP384 = "ecc384"
+	AlgRSA2048 = "rsa2048"
+	AlgRSA3072 = "rsa3072"
+)
+
+var tpmHashes = map[crypto.Hash]string{
+	crypto.SHA256: "sha256",
+	crypto.SHA384: "sha384",
+	crypto.SHA512: "sha512",
+}
+
+// runTool runs the tpm2-tools command, returning its output.
+var runTool = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.Wrapf(err, "%s: %s", name, msg)
+		}
+		return nil, errors.Wrap(err, name)
+	}
+	return out, nil
+}
+
+// ParseHandle parses the persistent handle of a TPM object, e.g. "0x81010002".
+func ParseHandle(handle string) (uint32, error) {
+	value, err := strconv.ParseUint(handle, 0, 32)
+	if err != nil {
+		return 0, errors.Errorf("invalid TPM handle %q", handle)
+	}
+	if value < persistentHandleFirst || value > persistentHandleLast {
+		return 0, errors.Errorf("TPM handle %q is not a persistent handle (0x%x-0x%x)",
+			handle, persistentHandleFirst, persistentHandleLast)
+	}
+	return uint32(value), nil
+}
+
+func formatHandle(handle uint32) string {
+	return "0x" + strconv.FormatUint(uint64(handle), 16)
+}
+
+// withTempDir calls the function with a directory for the files exchanged
+// with the tpm2-tools commands, which is removed afterwards.
+func withTempDir(fn func(dir string) error) error {
+	dir, err := os.MkdirTemp("", "daos-tpm")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	return fn(dir)
+}
+
+// Key is a crypto.Signer whose private key is held in a TPM.
+type Key struct {
+	handle  string
+	pub     crypto.PublicKey
+	timeout time.Duration
+}
+
+// OpenKey returns the key at the persistent handle, whose public half is pub.
+func OpenKey(handle string, pub crypto.PublicKey, timeout time.Duration) (*Key, error) {
+	value, err := ParseHandle(handle)
+	if err != nil {
+		return nil, err
+	}
+	switch pub.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+	default:
+		return nil, errors.Errorf("unsupported public key type %T", pub)
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return &Key{
+		handle:  formatHandle(value),
+		pub:     pub,
+		timeout: timeout,
+	}, nil
+}
+
+// Public returns the public half of the key.
+func (k *Key) Public() crypto.PublicKey {
+	return k.pub
+}
+
+// Sign asks the TPM to sign the digest.
+func (k *Key) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	hash, found := tpmHashes[opts.HashFunc()]
+	if !found {
+		return nil, errors.Errorf("unsupported hash %s", opts.HashFunc())
+	}
+	if len(digest) != opts.HashFunc().Size() {
+		return nil, errors.Errorf("digest length %d does not match %s", len(digest), opts.HashFunc())
+	}
+
+	var scheme string
+	switch k.pub.(type) {
+	case *rsa.PublicKey:
+		scheme = "rsassa"
+		if _, isPSS := opts.(*rsa.PSSOptions); isPSS {
+			// The TPM chooses the salt length, which the verifier
+			// detects.
+			scheme = "rsapss"
+		}
+	default:
+		scheme = "ecdsa"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), k.timeout)
+	defer cancel()
+
+	var sig []byte
+	err := withTempDir(func(dir string) error {
+		digestPath := filepath.Join(dir, "digest")
+		sigPath := filepath.Join(dir, "sig")
+		if err := os.WriteFile(digestPath, digest, 0600); err != nil {
+			return err
+		}
+		if _, err := runTool(ctx, "tpm2_sign", "-c", k.handle, "-g", hash, "-s", scheme,
+			"-d", "-f", "plain", "-o", sigPath, digestPath); err != nil {
+			return err
+		}
+
+		var err error
+		sig, err = os.ReadFile(sigPath)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "signing with TPM key %s", k.handle)
+	}
+
+	if _, isECDSA := k.pub.(*ecdsa.PublicKey); isECDSA {
+		return ecdsaSignatureToASN1(sig)
+	}
+	return sig, nil
+}
+
+// ecdsaSignatureToASN1 returns the ASN.1 encoding of the ECDSA signature, which
+// older tpm2-tools write as the raw r || s.
+func ecdsaSignatureToASN1(sig []byte) ([]byte, error) {
+	var parsed struct {
+		R, S *big.Int
+	}
+	if rest, err := asn1.Unmarshal(sig, &parsed); err == nil && len(rest) == 0 {
+		return sig, nil
+	}
+
+	if len(sig) == 0 || len(sig)%2 != 0 {
+		return nil, errors.Errorf("invalid ECDSA signature length %d", len(sig))
+	}
+	half := len(sig) / 2
+	parsed.R = new(big.Int).SetBytes(sig[:half])
+	parsed.S = new(big.Int).SetBytes(sig[half:])
+	return asn1.Marshal(parsed)
+}
+
+// ReadPublic returns the public half of the key at the persistent handle.
+func ReadPublic(ctx context.Context, handle string) (crypto.PublicKey, error) {
+	value, err := ParseHandle(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	var pub crypto.PublicKey
+	err = withTempDir(func(dir string) error {
+		pemPath := filepath.Join(dir, "key.pem")
+		if _, err := runTool(ctx, "tpm2_readpublic", "-c", formatHandle(value), "-f", "pem", "-o", pemPath); err != nil {
+			return err
+		}
+		data, err := os.ReadFile(pemPath)
+		if err != nil {
+			return err
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return errors.New("no public key in tpm2_readpublic output")
+		}
+		pub, err = x509.ParsePKIXPublicKey(block.Bytes)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading TPM key %s", handle)
+	}
+	return pub, nil
+}
+
+// GenerateKey generates a signing key with the algorithm in the TPM, under its
+// owner hierarchy, and makes it persistent at the handle. The key can't be
+// exported from the TPM.
+func GenerateKey(ctx context.Context, handle, alg string) (crypto.PublicKey, error) {
+	value, err := ParseHandle(handle)
+	if err != nil {
+		return nil, err
+	}
+	switch alg {
+	case AlgECCP256, AlgECCP384, AlgRSA2048, AlgRSA3072:
+	default:
+		return nil, errors.Errorf("unsupported key algorithm %q (must be %s, %s, %s or %s)",
+			alg, AlgECCP256, AlgECCP384, AlgRSA2048, AlgRSA3072)
+	}
+
+	err = withTempDir(func(dir string) error {
+		primary := filepath.Join(dir, "primary.ctx")
+		pubPath := filepath.Join(dir, "key.pub")
+		privPath := filepath.Join(dir, "key.priv")
+		keyCtx := filepath.Join(dir, "key.ctx")
+
+		for _, cmd := range [][]string{
+			{"tpm2_createprimary", "-C", "o", "-g", "sha256", "-G", "ecc", "-c", primary},
+			{"tpm2_create", "-C", primary, "-g", "sha256", "-G", alg, "-a", keyAttributes, "-u", pubPath, "-r", privPath},
+			{"tpm2_load", "-C", primary, "-u", pubPath, "-r", privPath, "-c", keyCtx},
+			{"tpm2_evictcontrol", "-C", "o", "-c", keyCtx, formatHandle(value)},
+		} {
+			if _, err := runTool(ctx, cmd[0], cmd[1:]...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "generating TPM key at %s", handle)
+	}
+
+	return ReadPublic(ctx, handle)
+}
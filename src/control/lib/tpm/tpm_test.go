@@ -0,0 +1,282 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package tpm
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+// fakeTPM emulates the tpm2-tools commands used, with software keys.
+type fakeTPM struct {
+	created    crypto.Signer
+	persistent map[string]crypto.Signer
+	rawECDSA   bool
+}
+
+func (f *fakeTPM) run(_ context.Context, name string, args ...string) ([]byte, error) {
+	arg := func(flag string) string {
+		for i := range args[:len(args)-1] {
+			if args[i] == flag {
+				return args[i+1]
+			}
+		}
+		return ""
+	}
+
+	switch name {
+	case "tpm2_createprimary", "tpm2_load":
+		return nil, os.WriteFile(arg("-c"), nil, 0600)
+	case "tpm2_create":
+		if arg("-a") != keyAttributes {
+			return nil, errors.New("unexpected attributes")
+		}
+		var err error
+		switch arg("-G") {
+		case AlgECCP256:
+			f.created, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		case AlgRSA2048:
+			f.created, err = rsa.GenerateKey(rand.Reader, 2048)
+		default:
+			err = errors.New("unsupported algorithm")
+		}
+		return nil, err
+	case "tpm2_evictcontrol":
+		handle := args[len(args)-1]
+		if _, found := f.persistent[handle]; found {
+			return nil, errors.New("handle in use")
+		}
+		f.persistent[handle] = f.created
+		return nil, nil
+	case "tpm2_readpublic":
+		key, found := f.persistent[arg("-c")]
+		if !found {
+			return nil, errors.New("handle not found")
+		}
+		der, err := x509.MarshalPKIXPublicKey(key.Public())
+		if err != nil {
+			return nil, err
+		}
+		return nil, os.WriteFile(arg("-o"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600)
+	case "tpm2_sign":
+		key, found := f.persistent[arg("-c")]
+		if !found {
+			return nil, errors.New("handle not found")
+		}
+		digest, err := os.ReadFile(args[len(args)-1])
+		if err != nil {
+			return nil, err
+		}
+		hashes := map[string]crypto.Hash{"sha256": crypto.SHA256, "sha384": crypto.SHA384, "sha512": crypto.SHA512}
+		var opts crypto.SignerOpts = hashes[arg("-g")]
+		if arg("-s") == "rsapss" {
+			opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto, Hash: opts.HashFunc()}
+		}
+
+		var sig []byte
+		if ecKey, isECDSA := key.(*ecdsa.PrivateKey); isECDSA && f.rawECDSA {
+			r, s, err := ecdsa.Sign(rand.Reader, ecKey, digest)
+			if err != nil {
+				return nil, err
+			}
+			size := (ecKey.Curve.Params().BitSize + 7) / 8
+			sig = append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...)
+		} else if sig, err = key.Sign(rand.Reader, digest, opts); err != nil {
+			return nil, err
+		}
+		return nil, os.WriteFile(arg("-o"), sig, 0600)
+	default:
+		return nil, errors.Errorf("unexpected command %s", name)
+	}
+}
+
+func setupFakeTPM(t *testing.T) *fakeTPM {
+	t.Helper()
+
+	fake := &fakeTPM{persistent: make(map[string]crypto.Signer)}
+	orig := runTool
+	runTool = fake.run
+	t.Cleanup(func() { runTool = orig })
+	return fake
+}
+
+func TestTPM_ParseHandle(t *testing.T) {
+	for name, tc := range map[string]struct {
+		handle    string
+		expHandle uint32
+		expErr    error
+	}{
+		"persistent": {
+			handle:    "0x81010002",
+			expHandle: 0x81010002,
+		},
+		"not a number": {
+			handle: "owner",
+			expErr: errors.New("invalid TPM handle"),
+		},
+		"transient": {
+			handle: "0x80000001",
+			expErr: errors.New("not a persistent handle"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			handle, err := ParseHandle(tc.handle)
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expHandle, handle, "unexpected handle")
+		})
+	}
+}
+
+func TestTPM_GenerateKey(t *testing.T) {
+	for name, tc := range map[string]struct {
+		alg      string
+		rawECDSA bool
+	}{
+		"ecdsa": {
+			alg: AlgECCP256,
+		},
+		"ecdsa raw signature": {
+			alg:      AlgECCP256,
+			rawECDSA: true,
+		},
+		"rsa": {
+			alg: AlgRSA2048,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fake := setupFakeTPM(t)
+			fake.rawECDSA = tc.rawECDSA
+
+			pub, err := GenerateKey(context.Background(), "0x81010002", tc.alg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, fake.created.Public(), pub, "unexpected public key")
+
+			key, err := OpenKey("0x81010002", pub, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			switch pub := pub.(type) {
+			case *ecdsa.PublicKey:
+				digest := sha256.Sum256([]byte("message"))
+				sig, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+				if err != nil {
+					t.Fatal(err)
+				}
+				test.AssertTrue(t, ecdsa.VerifyASN1(pub, digest[:], sig), "invalid ECDSA signature")
+			case *rsa.PublicKey:
+				digest := sha512.Sum512([]byte("message"))
+				opts := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA512}
+				sig, err := key.Sign(rand.Reader, digest[:], opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := rsa.VerifyPSS(pub, crypto.SHA512, digest[:], sig, nil); err != nil {
+					t.Fatalf("invalid RSA signature: %s", err)
+				}
+			}
+		})
+	}
+}
+
+func TestTPM_GenerateKey_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		handle string
+		alg    string
+		inUse  bool
+		expErr error
+	}{
+		"bad handle": {
+			handle: "0x1",
+			alg:    AlgECCP256,
+			expErr: errors.New("not a persistent handle"),
+		},
+		"bad algorithm": {
+			handle: "0x81010002",
+			alg:    "dsa",
+			expErr: errors.New("unsupported key algorithm"),
+		},
+		"handle in use": {
+			handle: "0x81010002",
+			alg:    AlgECCP256,
+			inUse:  true,
+			expErr: errors.New("generating TPM key at 0x81010002: handle in use"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fake := setupFakeTPM(t)
+			if tc.inUse {
+				fake.persistent["0x81010002"] = nil
+			}
+
+			_, err := GenerateKey(context.Background(), tc.handle, tc.alg)
+			test.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestTPM_Key_Sign_Errors(t *testing.T) {
+	setupFakeTPM(t)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenKey("0x81010002", "not a key", 0); err == nil {
+		t.Fatal("expected error for unsupported key")
+	}
+	key, err := OpenKey("0x81010002", ecKey.Public(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := sha256.Sum256([]byte("message"))
+	_, err = key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	test.CmpErr(t, errors.New("signing with TPM key 0x81010002: handle not found"), err)
+
+	_, err = key.Sign(rand.Reader, digest[:16], crypto.SHA256)
+	test.CmpErr(t, errors.New("digest length 16"), err)
+
+	_, err = key.Sign(rand.Reader, digest[:], crypto.MD5)
+	test.CmpErr(t, errors.New("unsupported hash"), err)
+}
+
+func TestTPM_ecdsaSignatureToASN1(t *testing.T) {
+	raw := append(big.NewInt(1).FillBytes(make([]byte, 32)), big.NewInt(2).FillBytes(make([]byte, 32))...)
+	der, err := ecdsaSignatureToASN1(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// DER signatures are returned unchanged.
+	same, err := ecdsaSignatureToASN1(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, der, same, "DER signature changed")
+
+	if _, err := ecdsaSignatureToASN1(raw[:63]); err == nil {
+		t.Fatal("expected error for odd length")
+	}
+}
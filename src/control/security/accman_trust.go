@@ -0,0 +1,38 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// AccessManagerTrustConfig configures the servers to verify the chain of trust
+// of the identities asserted by the access manager. AUTH_ACCMAN credentials
+// must carry the access manager's signed statement of their identity, made by
+// a key whose certificate chains to one of the CA certificates, so that an
+// agent cannot assert an identity the access manager did not.
+type AccessManagerTrustConfig struct {
+	CACerts []string `yaml:"ca_certs,omitempty"`
+}
+
+// Validate checks the access manager trust configuration.
+func (tc *AccessManagerTrustConfig) Validate() error {
+	if tc == nil {
+		return nil
+	}
+	if len(tc.CACerts) == 0 {
+		return errors.New("ca_certs must be set")
+	}
+	for _, path := range tc.CACerts {
+		if !filepath.IsAbs(path) {
+			return errors.Errorf("ca_certs: %q must be an absolute path", path)
+		}
+	}
+	return nil
+}
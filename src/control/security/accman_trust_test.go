@@ -0,0 +1,43 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestSecurity_AccessManagerTrustConfig_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg    *AccessManagerTrustConfig
+		expErr error
+	}{
+		"nil": {},
+		"valid": {
+			cfg: &AccessManagerTrustConfig{
+				CACerts: []string{"/etc/daos/certs/am_ca.crt"},
+			},
+		},
+		"no certs": {
+			cfg:    &AccessManagerTrustConfig{},
+			expErr: errors.New("ca_certs must be set"),
+		},
+		"relative cert": {
+			cfg: &AccessManagerTrustConfig{
+				CACerts: []string{"am_ca.crt"},
+			},
+			expErr: errors.New(`ca_certs: "am_ca.crt" must be an absolute path`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.cfg.Validate())
+		})
+	}
+}
@@ -0,0 +1,184 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultAgentCertLifetime is the lifetime of agent certificates signed by the
+// control plane CA, unless configured otherwise.
+const DefaultAgentCertLifetime = 30 * 24 * time.Hour
+
+// agentCertBackdate allows for clock skew between the server and the agent.
+const agentCertBackdate = 5 * time.Minute
+
+// AgentCAConfig configures a server to sign renewals of agent certificates
+// with the CA, so that agents renew their certificates before they expire.
+// If InstallDir is set, the renewed certificates are also written to it, so
+// that servers sharing it as their client_cert_dir trust the renewed keys.
+type AgentCAConfig struct {
+	CACert       string        `yaml:"ca_cert"`
+	CAKey        string        `yaml:"ca_key"`
+	CertLifetime time.Duration `yaml:"cert_lifetime,omitempty"`
+	InstallDir   string        `yaml:"install_dir,omitempty"`
+}
+
+// Validate checks the agent CA configuration.
+func (cfg *AgentCAConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.CACert == "" || cfg.CAKey == "" {
+		return errors.New("ca_cert and ca_key are required")
+	}
+	if cfg.CertLifetime < 0 {
+		return errors.New("cert_lifetime must not be negative")
+	}
+	if cfg.InstallDir != "" && !filepath.IsAbs(cfg.InstallDir) {
+		return errors.Errorf("install_dir %q must be an absolute path", cfg.InstallDir)
+	}
+	return nil
+}
+
+// AgentCA signs renewals of agent certificates.
+type AgentCA struct {
+	cert       *x509.Certificate
+	key        crypto.Signer
+	lifetime   time.Duration
+	installDir string
+}
+
+// LoadAgentCA loads the CA certificate and key of the configuration.
+func LoadAgentCA(cfg *AgentCAConfig) (*AgentCA, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Wrap(err, "agent_ca")
+	}
+
+	cert, err := LoadCertificate(cfg.CACert)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading agent CA certificate")
+	}
+	if !cert.IsCA {
+		return nil, errors.Errorf("%s is not a CA certificate", cfg.CACert)
+	}
+	key, err := LoadPrivateKey(cfg.CAKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading agent CA key")
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, &UnsupportedKeyError{}
+	}
+	if err := checkSignerMatches(signer, cert.PublicKey); err != nil {
+		return nil, err
+	}
+
+	lifetime := cfg.CertLifetime
+	if lifetime == 0 {
+		lifetime = DefaultAgentCertLifetime
+	}
+	return &AgentCA{
+		cert:       cert,
+		key:        signer,
+		lifetime:   lifetime,
+		installDir: cfg.InstallDir,
+	}, nil
+}
+
+// Certificate returns the CA certificate.
+func (ca *AgentCA) Certificate() *x509.Certificate {
+	return ca.cert
+}
+
+// Sign signs a certificate for the key in the DER-encoded CSR, renewing the
+// agent certificate the request was made with. The identity of the new
+// certificate is copied from the current one, rather than taken from the CSR,
+// so that an agent can only renew its own certificate.
+func (ca *AgentCA) Sign(csrDER []byte, current *x509.Certificate) (*x509.Certificate, error) {
+	if current == nil {
+		return nil, errors.New("no current agent certificate")
+	}
+	if current.Subject.CommonName != ComponentAgent.String() {
+		return nil, errors.Errorf("certificate %q is not an agent certificate", current.Subject.CommonName)
+	}
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing certificate signing request")
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, errors.Wrap(err, "checking certificate signing request signature")
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrap(err, "generating serial number")
+	}
+	now := time.Now()
+	if !now.Before(ca.cert.NotAfter) {
+		return nil, errors.New("agent CA certificate has expired")
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               current.Subject,
+		DNSNames:              current.DNSNames,
+		IPAddresses:           current.IPAddresses,
+		NotBefore:             now.Add(-agentCertBackdate),
+		NotAfter:              now.Add(ca.lifetime),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+	if template.NotAfter.After(ca.cert.NotAfter) {
+		template.NotAfter = ca.cert.NotAfter
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, ca.cert, csr.PublicKey, ca.key)
+	if err != nil {
+		return nil, errors.Wrap(err, "signing agent certificate")
+	}
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing agent certificate")
+	}
+	if err := ca.install(cert); err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
+
+// install writes the certificate to the install directory, if any, named for
+// the ID of its key.
+func (ca *AgentCA) install(cert *x509.Certificate) error {
+	if ca.installDir == "" {
+		return nil
+	}
+
+	keyID, err := PublicKeyID(cert.PublicKey)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(ca.installDir, "agent-"+keyID+".crt")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), MaxCertPerm); err != nil {
+		return errors.Wrap(err, "installing agent certificate")
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return errors.Wrap(err, "installing agent certificate")
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+// writeTestAgentCA writes a CA certificate and key, valid for the lifetime, to
+// the directory.
+func writeTestAgentCA(t *testing.T, dir string, lifetime time.Duration) *AgentCAConfig {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{Organization: []string{"DAOS"}, CommonName: "DAOS CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(lifetime),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &AgentCAConfig{
+		CACert: filepath.Join(dir, "daosCA.crt"),
+		CAKey:  filepath.Join(dir, "daosCA.key"),
+	}
+	if err := os.WriteFile(cfg.CACert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), MaxCertPerm); err != nil {
+		t.Fatal(err)
+	}
+	writeTestSecretFile(t, dir, "daosCA.key", string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})))
+	return cfg
+}
+
+// testAgentCSR returns a DER-encoded CSR for a new key, and its public key.
+func testAgentCSR(t *testing.T, cn string) ([]byte, crypto.PublicKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: cn},
+		DNSNames: []string{"other-host"},
+	}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return csr, key.Public()
+}
+
+func TestSecurity_AgentCAConfig_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg    *AgentCAConfig
+		expErr error
+	}{
+		"nil": {},
+		"valid": {
+			cfg: &AgentCAConfig{CACert: "/etc/daos/certs/daosCA.crt", CAKey: "/etc/daos/certs/daosCA.key"},
+		},
+		"no key": {
+			cfg:    &AgentCAConfig{CACert: "/etc/daos/certs/daosCA.crt"},
+			expErr: errors.New("ca_cert and ca_key are required"),
+		},
+		"negative lifetime": {
+			cfg: &AgentCAConfig{
+				CACert:       "/etc/daos/certs/daosCA.crt",
+				CAKey:        "/etc/daos/certs/daosCA.key",
+				CertLifetime: -time.Hour,
+			},
+			expErr: errors.New("cert_lifetime must not be negative"),
+		},
+		"relative install dir": {
+			cfg: &AgentCAConfig{
+				CACert:     "/etc/daos/certs/daosCA.crt",
+				CAKey:      "/etc/daos/certs/daosCA.key",
+				InstallDir: "clients",
+			},
+			expErr: errors.New(`install_dir "clients" must be an absolute path`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.cfg.Validate())
+		})
+	}
+}
+
+func TestSecurity_LoadAgentCA(t *testing.T) {
+	dir := t.TempDir()
+	cfg := writeTestAgentCA(t, dir, time.Hour)
+
+	if _, err := LoadAgentCA(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	// A certificate which isn't a CA is refused.
+	notCA := &AgentCAConfig{
+		CACert: copyTestCertFile(t, dir, "agent.crt", MaxCertPerm),
+		CAKey:  copyTestCertFile(t, dir, "agent.key", MaxUserOnlyKeyPerm),
+	}
+	_, err := LoadAgentCA(notCA)
+	test.CmpErr(t, errors.New("is not a CA certificate"), err)
+
+	// A key which doesn't match the certificate is refused.
+	mismatched := &AgentCAConfig{CACert: cfg.CACert, CAKey: notCA.CAKey}
+	if _, err := LoadAgentCA(mismatched); err == nil {
+		t.Fatal("expected mismatched CA key to be refused")
+	}
+}
+
+func TestSecurity_AgentCA_Sign(t *testing.T) {
+	dir := t.TempDir()
+	installDir := filepath.Join(dir, "clients")
+	if err := os.Mkdir(installDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := writeTestAgentCA(t, dir, 365*24*time.Hour)
+	cfg.CertLifetime = 24 * time.Hour
+	cfg.InstallDir = installDir
+	ca, err := LoadAgentCA(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current := &x509.Certificate{
+		Subject:  pkix.Name{Organization: []string{"DAOS"}, CommonName: "agent"},
+		DNSNames: []string{"client-1"},
+	}
+	csr, pub := testAgentCSR(t, "admin")
+
+	for name, tc := range map[string]struct {
+		csr     []byte
+		current *x509.Certificate
+		expErr  error
+	}{
+		"no current certificate": {
+			csr:    csr,
+			expErr: errors.New("no current agent certificate"),
+		},
+		"not an agent": {
+			csr:     csr,
+			current: &x509.Certificate{Subject: pkix.Name{CommonName: "admin"}},
+			expErr:  errors.New(`certificate "admin" is not an agent certificate`),
+		},
+		"bad csr": {
+			csr:     []byte("garbage"),
+			current: current,
+			expErr:  errors.New("parsing certificate signing request"),
+		},
+		"success": {
+			csr:     csr,
+			current: current,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cert, err := ca.Sign(tc.csr, tc.current)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			if err := cert.CheckSignatureFrom(ca.Certificate()); err != nil {
+				t.Fatal(err)
+			}
+			// The identity is that of the current certificate, not the CSR.
+			test.AssertEqual(t, "agent", cert.Subject.CommonName, "unexpected common name")
+			test.AssertEqual(t, []string{"client-1"}, cert.DNSNames, "unexpected DNS names")
+			test.AssertEqual(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage,
+				"unexpected extended key usage")
+			test.AssertTrue(t, cert.NotAfter.Before(time.Now().Add(25*time.Hour)), "lifetime not applied")
+
+			keyID, err := PublicKeyID(pub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			installed, err := LoadCertificate(filepath.Join(installDir, "agent-"+keyID+".crt"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertTrue(t, installed.Equal(cert), "installed certificate differs")
+		})
+	}
+}
+
+func TestSecurity_AgentCA_Sign_expired(t *testing.T) {
+	dir := t.TempDir()
+	ca, err := LoadAgentCA(writeTestAgentCA(t, dir, -time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, _ := testAgentCSR(t, "agent")
+
+	_, err = ca.Sign(csr, &x509.Certificate{Subject: pkix.Name{CommonName: "agent"}})
+	test.CmpErr(t, errors.New("agent CA certificate has expired"), err)
+}
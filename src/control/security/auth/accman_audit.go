@@ -0,0 +1,219 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// amCorrelationHeader carries the correlation ID of each request, so that the
+// audit records of the agent and the access manager can be matched.
+const amCorrelationHeader = "X-DAOS-Correlation-ID"
+
+// Outcomes of the access manager requests recorded in the audit log.
+const (
+	amAuditAllowed = "allowed"
+	amAuditDenied  = "denied"
+	amAuditError   = "error"
+)
+
+type (
+	// amAuditRecord records a single access manager request in the audit
+	// log. Credentials and renewal tokens are only recorded as fingerprints,
+	// and the subject and roles are redacted according to the configuration.
+	amAuditRecord struct {
+		Time            time.Time `json:"time"`
+		CorrelationID   string    `json:"correlation_id"`
+		CallerID        string    `json:"caller_id"`
+		Request         string    `json:"request"`
+		Endpoint        string    `json:"endpoint,omitempty"`
+		ProtocolVersion int       `json:"protocol_version,omitempty"`
+		Credential      string    `json:"credential,omitempty"`
+		Subject         string    `json:"subject,omitempty"`
+		Roles           []string  `json:"roles,omitempty"`
+		Cached          bool      `json:"cached,omitempty"`
+		LatencyMs       float64   `json:"latency_ms"`
+		Outcome         string    `json:"outcome"`
+		Error           string    `json:"error,omitempty"`
+	}
+
+	// amAuditor writes audit records to the audit log, one JSON object per
+	// line. A nil auditor discards the records.
+	amAuditor struct {
+		sync.Mutex
+		w         io.Writer
+		redaction string
+	}
+
+	// amDeniedError is returned when the access manager rejects a request,
+	// with the message from its response.
+	amDeniedError struct {
+		message string
+	}
+)
+
+var (
+	amAuditorsMutex sync.Mutex
+	amAuditors      = make(map[security.AccessManagerAuditConfig]*amAuditor)
+)
+
+func (e *amDeniedError) Error() string {
+	return e.message
+}
+
+// getAMAuditor returns the shared auditor writing to the configured audit log,
+// or nil if none is configured.
+func getAMAuditor(cfg *security.AccessManagerAuditConfig) (*amAuditor, error) {
+	if cfg.LogFile == "" {
+		return nil, nil
+	}
+
+	amAuditorsMutex.Lock()
+	defer amAuditorsMutex.Unlock()
+
+	if auditor, found := amAuditors[*cfg]; found {
+		return auditor, nil
+	}
+
+	f, err := os.OpenFile(cfg.LogFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening access manager audit log")
+	}
+	auditor := &amAuditor{w: f, redaction: cfg.Redaction}
+	amAuditors[*cfg] = auditor
+
+	return auditor, nil
+}
+
+// amFingerprint returns a short digest identifying a secret or identity
+// without revealing it.
+func amFingerprint(value string) string {
+	if value == "" {
+		return ""
+	}
+	digest := sha256.Sum256([]byte(value))
+	return "sha256:" + hex.EncodeToString(digest[:8])
+}
+
+// newAMCorrelationID returns a random ID for a request.
+func newAMCorrelationID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+// amAuditOutcome classifies the outcome of a request by its error.
+func amAuditOutcome(err error) string {
+	var denied *amDeniedError
+	var statusErr *amStatusError
+	switch {
+	case err == nil:
+		return amAuditAllowed
+	case errors.As(err, &denied):
+		return amAuditDenied
+	case errors.As(err, &statusErr) &&
+		(statusErr.code == http.StatusUnauthorized || statusErr.code == http.StatusForbidden):
+		return amAuditDenied
+	default:
+		return amAuditError
+	}
+}
+
+func (a *amAuditor) redact(identity string) string {
+	switch {
+	case identity == "":
+		return ""
+	case a.redaction == security.AMAuditRedactNone:
+		return identity
+	case a.redaction == security.AMAuditRedactFull:
+		return "[redacted]"
+	default:
+		return amFingerprint(identity)
+	}
+}
+
+// record writes the record to the audit log, with its identities redacted.
+func (a *amAuditor) record(ctx context.Context, rec *amAuditRecord) {
+	if a == nil {
+		return
+	}
+
+	rec.Subject = a.redact(rec.Subject)
+	roles := rec.Roles
+	rec.Roles = nil
+	for _, role := range roles {
+		rec.Roles = append(rec.Roles, a.redact(role))
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("encoding access manager audit record: %s", err)
+		return
+	}
+
+	a.Lock()
+	defer a.Unlock()
+	if _, err := a.w.Write(append(line, '\n')); err != nil {
+		logging.FromContext(ctx).Errorf("writing access manager audit log: %s", err)
+	}
+}
+
+// audit records the outcome of a request for the credential, which started at
+// the given time, in the audit log.
+func (r *AuthAccManCredentialRequest) audit(ctx context.Context, apiPath, credential string, start time.Time, info *accManInfo, cached bool, err error) {
+	if r.auditor == nil {
+		return
+	}
+
+	rec := &amAuditRecord{
+		Time:          start.UTC(),
+		CorrelationID: r.correlationID,
+		CallerID:      r.callerID,
+		Request:       apiPath,
+		Credential:    amFingerprint(credential),
+		Cached:        cached,
+		LatencyMs:     float64(time.Since(start).Microseconds()) / 1000,
+		Outcome:       amAuditOutcome(err),
+	}
+	if !cached {
+		rec.Endpoint = r.lastEndpoint
+		rec.ProtocolVersion = r.protocolVersion
+	}
+	if info != nil {
+		rec.Subject = info.Subject
+		if rec.Subject == "" {
+			rec.Subject = info.Identity
+		}
+		rec.Roles = info.Roles
+	}
+	if err != nil {
+		// Transport errors include the request URL, and so the credential.
+		rec.Error = err.Error()
+		if credential != "" {
+			rec.Error = strings.NewReplacer(url.QueryEscape(credential), rec.Credential,
+				credential, rec.Credential).Replace(rec.Error)
+		}
+	}
+	r.auditor.record(ctx, rec)
+}
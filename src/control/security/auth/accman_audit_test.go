@@ -0,0 +1,221 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func readAMAuditRecords(t *testing.T, buf *bytes.Buffer) []*amAuditRecord {
+	t.Helper()
+
+	var records []*amAuditRecord
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		rec := new(amAuditRecord)
+		if err := json.Unmarshal([]byte(line), rec); err != nil {
+			t.Fatalf("invalid audit record %q: %s", line, err)
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+func TestAuth_AuthAccManCredentialRequest_Audit(t *testing.T) {
+	for name, tc := range map[string]struct {
+		status       int
+		unreachable  bool
+		renewalToken string
+		redaction    string
+		expOutcome   string
+		expRequest   string
+		expSubject   string
+		expRoles     []string
+		expErr       error
+	}{
+		"allowed": {
+			status:     http.StatusOK,
+			expOutcome: amAuditAllowed,
+			expRequest: "/validate",
+			expSubject: amFingerprint("am://jdoe"),
+			expRoles:   []string{amFingerprint("am://admins")},
+		},
+		"allowed without redaction": {
+			status:     http.StatusOK,
+			redaction:  security.AMAuditRedactNone,
+			expOutcome: amAuditAllowed,
+			expRequest: "/validate",
+			expSubject: "am://jdoe",
+			expRoles:   []string{"am://admins"},
+		},
+		"allowed with full redaction": {
+			status:     http.StatusOK,
+			redaction:  security.AMAuditRedactFull,
+			expOutcome: amAuditAllowed,
+			expRequest: "/validate",
+			expSubject: "[redacted]",
+			expRoles:   []string{"[redacted]"},
+		},
+		"rejected by status": {
+			status:     http.StatusForbidden,
+			expOutcome: amAuditDenied,
+			expRequest: "/validate",
+			expErr:     errors.New(`unexpected status code "403"`),
+		},
+		"rejected renewal": {
+			status:       http.StatusOK,
+			renewalToken: "bad",
+			expOutcome:   amAuditDenied,
+			expRequest:   "/renew",
+			expErr:       errors.New("invalid renewal token"),
+		},
+		"unreachable": {
+			unreachable: true,
+			expOutcome:  amAuditError,
+			expRequest:  "/validate",
+			expErr:      errors.New("no access manager endpoint is available"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var endpoint string
+			var am *testAM
+			if tc.unreachable {
+				endpoint = unreachableAMURL(t)
+			} else {
+				am = startTestAM(t, tc.status)
+				endpoint = am.url
+			}
+
+			var buf bytes.Buffer
+			req := &AuthAccManCredentialRequest{
+				delegationCredential: "jdoe",
+				callerID:             "daos",
+				endpoints:            []string{endpoint},
+				health:               newAMHealth(),
+				versions:             newAMVersions(),
+				transport:            &amRESTTransport{client: http.DefaultClient},
+				auditor:              &amAuditor{w: &buf, redaction: tc.redaction},
+			}
+			credential := req.delegationCredential
+			if tc.renewalToken != "" {
+				req.UseRenewalToken(tc.renewalToken)
+				credential = tc.renewalToken
+			}
+
+			var err error
+			if tc.renewalToken != "" {
+				_, err = req.renewCredential(test.Context(t))
+			} else {
+				_, err = req.validateAndParseDelegationCredential(test.Context(t))
+			}
+			test.CmpErr(t, tc.expErr, err)
+
+			records := readAMAuditRecords(t, &buf)
+			if len(records) != 1 {
+				t.Fatalf("expected 1 audit record, got %d", len(records))
+			}
+			rec := records[0]
+			test.AssertEqual(t, tc.expOutcome, rec.Outcome, "unexpected outcome")
+			test.AssertEqual(t, tc.expRequest, rec.Request, "unexpected request")
+			test.AssertEqual(t, tc.expSubject, rec.Subject, "unexpected subject")
+			test.CmpAny(t, "roles", tc.expRoles, rec.Roles)
+			test.AssertEqual(t, amFingerprint(credential), rec.Credential, "unexpected credential fingerprint")
+			test.AssertEqual(t, "daos", rec.CallerID, "unexpected caller ID")
+			test.AssertTrue(t, rec.CorrelationID != "", "expected a correlation ID")
+			test.AssertTrue(t, rec.LatencyMs >= 0, "expected a latency")
+			test.AssertFalse(t, strings.Contains(buf.String(), "credential="+credential),
+				"credential recorded in the audit log")
+			if tc.expErr != nil {
+				test.AssertTrue(t, strings.Contains(rec.Error, tc.expErr.Error()), "unexpected error")
+			}
+			if tc.expOutcome == amAuditAllowed {
+				test.AssertEqual(t, am.url, rec.Endpoint, "unexpected endpoint")
+				test.AssertEqual(t, amMaxProtocolVersion, rec.ProtocolVersion, "unexpected protocol version")
+				test.AssertEqual(t, rec.CorrelationID, am.correlationID.Load(), "correlation ID not sent")
+			}
+		})
+	}
+}
+
+func TestAuth_AuthAccManCredentialRequest_AuditCached(t *testing.T) {
+	am := startTestAM(t, http.StatusOK)
+
+	var buf bytes.Buffer
+	req := &AuthAccManCredentialRequest{
+		delegationCredential: "jdoe",
+		callerID:             "daos",
+		endpoints:            []string{am.url},
+		health:               newAMHealth(),
+		versions:             newAMVersions(),
+		transport:            &amRESTTransport{client: http.DefaultClient},
+		cache:                newAMResponseCache(),
+		cacheLifetime:        time.Minute,
+		auditor:              &amAuditor{w: &buf},
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := req.validateAndParseDelegationCredential(test.Context(t)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records := readAMAuditRecords(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("expected 2 audit records, got %d", len(records))
+	}
+	test.AssertFalse(t, records[0].Cached, "first response should not be cached")
+	test.AssertTrue(t, records[1].Cached, "second response should be cached")
+	test.AssertEqual(t, "", records[1].Endpoint, "cached response has no endpoint")
+	test.AssertEqual(t, records[0].Subject, records[1].Subject, "subjects should match")
+	test.AssertTrue(t, records[0].CorrelationID != records[1].CorrelationID, "correlation IDs should differ")
+}
+
+func TestAuth_getAMAuditor(t *testing.T) {
+	auditor, err := getAMAuditor(&security.AccessManagerAuditConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, auditor == nil, "expected no auditor without a log file")
+
+	cfg := &security.AccessManagerAuditConfig{
+		LogFile: filepath.Join(t.TempDir(), "am_audit.log"),
+	}
+	auditor, err = getAMAuditor(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shared, err := getAMAuditor(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, auditor == shared, "expected auditor to be shared")
+
+	fi, err := os.Stat(cfg.LogFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, os.FileMode(0600), fi.Mode().Perm(), "unexpected audit log permissions")
+
+	_, err = getAMAuditor(&security.AccessManagerAuditConfig{
+		LogFile: filepath.Join(t.TempDir(), "missing", "am_audit.log"),
+	})
+	test.CmpErr(t, errors.New("opening access manager audit log"), err)
+}
@@ -0,0 +1,220 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+type (
+	// amResponseCache caches the responses of the access manager to
+	// validation requests, so that it is not consulted for each credential
+	// request. Entries are indexed by the opaque identifier of the subject
+	// they identify, so that all of them can be invalidated when the access
+	// manager revokes the subject's access.
+	amResponseCache struct {
+		sync.Mutex
+		entries  map[[sha256.Size]byte]*amCacheEntry
+		subjects map[string]map[[sha256.Size]byte]struct{}
+		// cursor is the position in the access manager's revocation feed
+		// up to which revocations have been applied.
+		cursor string
+		// generation is incremented for each revocation, so that responses
+		// requested before it are not cached after it.
+		generation uint64
+	}
+
+	amCacheEntry struct {
+		info    *accManInfo
+		subject string
+		expires time.Time
+	}
+
+	// amRevocations is the list of subjects whose access has been revoked
+	// since the cursor given in the request.
+	amRevocations struct {
+		Subjects []string `json:"subjects"`
+		Cursor   string   `json:"cursor"`
+	}
+)
+
+var defaultAMCache = newAMResponseCache()
+
+func newAMResponseCache() *amResponseCache {
+	return &amResponseCache{
+		entries:  make(map[[sha256.Size]byte]*amCacheEntry),
+		subjects: make(map[string]map[[sha256.Size]byte]struct{}),
+	}
+}
+
+// subject returns the identifier by which the access manager revokes access,
+// which is the identity if the access manager does not supply one.
+func (info *accManInfo) subject() string {
+	if info.Subject != "" {
+		return info.Subject
+	}
+	return info.Identity
+}
+
+// get returns the cached response for the credential, or nil if there is none
+// or it has expired.
+func (c *amResponseCache) get(credential string, now time.Time) *accManInfo {
+	c.Lock()
+	defer c.Unlock()
+
+	digest := sha256.Sum256([]byte(credential))
+	entry, found := c.entries[digest]
+	if !found {
+		return nil
+	}
+	if !now.Before(entry.expires) {
+		c.remove(digest, entry.subject)
+		return nil
+	}
+	return entry.info
+}
+
+// currentGeneration returns the generation to be passed to add for a response
+// which is about to be requested.
+func (c *amResponseCache) currentGeneration() uint64 {
+	c.Lock()
+	defer c.Unlock()
+	return c.generation
+}
+
+// add caches the response for the credential until it expires, unless a
+// revocation has been applied since the response was requested.
+func (c *amResponseCache) add(credential string, info *accManInfo, expires time.Time, generation uint64) {
+	c.Lock()
+	defer c.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	digest := sha256.Sum256([]byte(credential))
+	if old, found := c.entries[digest]; found {
+		c.remove(digest, old.subject)
+	}
+
+	subject := info.subject()
+	c.entries[digest] = &amCacheEntry{info: info, subject: subject, expires: expires}
+	if c.subjects[subject] == nil {
+		c.subjects[subject] = make(map[[sha256.Size]byte]struct{})
+	}
+	c.subjects[subject][digest] = struct{}{}
+}
+
+func (c *amResponseCache) remove(digest [sha256.Size]byte, subject string) {
+	delete(c.entries, digest)
+	delete(c.subjects[subject], digest)
+	if len(c.subjects[subject]) == 0 {
+		delete(c.subjects, subject)
+	}
+}
+
+// revoke invalidates the cached responses for the subjects, and returns the
+// number invalidated.
+func (c *amResponseCache) revoke(subjects ...string) int {
+	c.Lock()
+	defer c.Unlock()
+
+	if len(subjects) > 0 {
+		c.generation++
+	}
+	var count int
+	for _, subject := range subjects {
+		for digest := range c.subjects[subject] {
+			delete(c.entries, digest)
+			count++
+		}
+		delete(c.subjects, subject)
+	}
+	return count
+}
+
+// pollRevocations fetches the subjects whose access has been revoked since the
+// last poll from the access manager, and invalidates their cached responses.
+// Each poll is recorded in the audit log.
+func (c *amResponseCache) pollRevocations(ctx context.Context, log logging.Logger, req *AuthAccManCredentialRequest) (err error) {
+	c.Lock()
+	cursor := c.cursor
+	c.Unlock()
+
+	start := time.Now()
+	defer func() {
+		req.audit(ctx, "/revocations", "", start, nil, false, err)
+	}()
+
+	amResp, err := req.request_am(ctx, "/revocations", http.MethodGet, "since", cursor)
+	if err != nil {
+		return errors.Wrap(err, "polling access manager revocations")
+	}
+	if amResp.Error.ResponseCode != 0 {
+		return &amDeniedError{message: amResp.Error.Message}
+	}
+	var revocations amRevocations
+	if err := json.Unmarshal([]byte(amResp.Info), &revocations); err != nil {
+		return errors.Wrap(err, "parsing access manager revocations")
+	}
+
+	if count := c.revoke(revocations.Subjects...); count > 0 {
+		log.Noticef("audit: access manager revoked %d subject(s); invalidated %d cached response(s)",
+			len(revocations.Subjects), count)
+	}
+	c.Lock()
+	c.cursor = revocations.Cursor
+	c.Unlock()
+
+	return nil
+}
+
+// PollAMRevocations polls the access manager for revocations at the configured
+// interval until the context is canceled, and invalidates the cached responses
+// for the subjects whose access has been revoked.
+func PollAMRevocations(ctx context.Context, log logging.Logger, cfg *security.CredentialConfig) {
+	req, err := newAccManRequest(cfg)
+	if err != nil {
+		log.Errorf("unable to poll access manager revocations: %s", err)
+		return
+	}
+	ticker := time.NewTicker(cfg.AMConfig.RevocationPollInterval)
+	defer ticker.Stop()
+
+	var unsupported bool
+	for {
+		err := req.cache.pollRevocations(ctx, log, req)
+		switch {
+		case errors.Is(err, errAMUnsupported):
+			// Keep polling in case the access manager is upgraded, but
+			// only report it once.
+			if !unsupported {
+				log.Noticef("%s; cached responses are only invalidated when they expire", err)
+			}
+			unsupported = true
+		case err != nil && ctx.Err() == nil:
+			log.Errorf("%s", err)
+		default:
+			unsupported = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,169 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestAuth_amResponseCache(t *testing.T) {
+	now := time.Now()
+	cache := newAMResponseCache()
+	jdoe := &accManInfo{Identity: "am://jdoe", Subject: "sub-1"}
+	jroe := &accManInfo{Identity: "am://jroe"}
+
+	cache.add("cred-1", jdoe, now.Add(time.Minute), cache.currentGeneration())
+	cache.add("cred-2", jdoe, now.Add(time.Minute), cache.currentGeneration())
+	cache.add("cred-3", jroe, now.Add(time.Minute), cache.currentGeneration())
+
+	test.AssertTrue(t, cache.get("cred-1", now) == jdoe, "response not cached")
+	test.AssertTrue(t, cache.get("cred-4", now) == nil, "unexpected response")
+	test.AssertTrue(t, cache.get("cred-3", now.Add(time.Minute)) == nil, "expired response returned")
+	test.AssertEqual(t, 0, cache.revoke("am://jroe"), "expired response revoked")
+
+	test.AssertEqual(t, 0, cache.revoke("am://jdoe"), "revoked by identity instead of subject")
+	test.AssertEqual(t, 2, cache.revoke("sub-1"), "unexpected revoked responses")
+	test.AssertTrue(t, cache.get("cred-1", now) == nil, "revoked response returned")
+	test.AssertTrue(t, cache.get("cred-2", now) == nil, "revoked response returned")
+
+	// A response requested before a revocation must not be cached after it.
+	generation := cache.currentGeneration()
+	cache.revoke("sub-1")
+	cache.add("cred-1", jdoe, now.Add(time.Minute), generation)
+	test.AssertTrue(t, cache.get("cred-1", now) == nil, "stale response cached")
+}
+
+func TestAuth_amResponseCache_pollRevocations(t *testing.T) {
+	for name, tc := range map[string]struct {
+		versions  *amVersionRange
+		handler   http.HandlerFunc
+		expErr    error
+		expCached bool
+		expCursor string
+	}{
+		"subject revoked": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/revocations" || r.URL.Query().Get("since") != "c1" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				info, _ := json.Marshal(&amRevocations{Subjects: []string{"am://jdoe"}, Cursor: "c2"})
+				json.NewEncoder(w).Encode(&amResp{Info: string(info)})
+			},
+			expCursor: "c2",
+		},
+		"nothing revoked": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				info, _ := json.Marshal(&amRevocations{Cursor: "c1"})
+				json.NewEncoder(w).Encode(&amResp{Info: string(info)})
+			},
+			expCached: true,
+			expCursor: "c1",
+		},
+		"access manager error": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				resp := &amResp{}
+				resp.Error.ResponseCode = 1
+				resp.Error.Message = "unknown caller"
+				json.NewEncoder(w).Encode(resp)
+			},
+			expErr:    errors.New("unknown caller"),
+			expCached: true,
+			expCursor: "c1",
+		},
+		"revocations not supported": {
+			versions: &amVersionRange{Min: amProtocolV1, Max: amProtocolV1},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+			},
+			expErr:    errAMUnsupported,
+			expCached: true,
+			expCursor: "c1",
+		},
+		"access manager unavailable": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			expErr:    errors.New("polling access manager revocations"),
+			expCached: true,
+			expCursor: "c1",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			versions := tc.versions
+			if versions == nil {
+				versions = &amVersionRange{Min: amProtocolV1, Max: amProtocolV2}
+			}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/version" {
+					info, _ := json.Marshal(versions)
+					json.NewEncoder(w).Encode(&amResp{Info: string(info)})
+					return
+				}
+				tc.handler(w, r)
+			}))
+			defer srv.Close()
+
+			cache := newAMResponseCache()
+			cache.cursor = "c1"
+			cache.add("cred-1", &accManInfo{Identity: "am://jdoe"}, time.Now().Add(time.Minute), cache.currentGeneration())
+			req := &AuthAccManCredentialRequest{
+				callerID:  "daos",
+				endpoints: []string{srv.URL},
+				health:    newAMHealth(),
+				versions:  newAMVersions(),
+				transport: &amRESTTransport{client: http.DefaultClient},
+				cache:     cache,
+			}
+
+			err := cache.pollRevocations(test.Context(t), log, req)
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expCached, cache.get("cred-1", time.Now()) != nil, "unexpected cached response")
+			test.AssertEqual(t, tc.expCursor, cache.cursor, "unexpected cursor")
+		})
+	}
+}
+
+func TestAuth_AuthAccManCredentialRequest_Cached(t *testing.T) {
+	am := startTestAM(t, http.StatusOK)
+	req := &AuthAccManCredentialRequest{
+		delegationCredential: "jdoe",
+		callerID:             "daos",
+		endpoints:            []string{am.url},
+		health:               newAMHealth(),
+		versions:             newAMVersions(),
+		transport:            &amRESTTransport{client: http.DefaultClient},
+		cache:                newAMResponseCache(),
+		cacheLifetime:        time.Minute,
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := req.validateAndParseDelegationCredential(test.Context(t)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The first validation is preceded by the version handshake.
+	test.AssertEqual(t, int32(2), am.hits.Load(), "response not cached")
+
+	req.cache.revoke("am://jdoe")
+	if _, err := req.validateAndParseDelegationCredential(test.Context(t)); err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, int32(3), am.hits.Load(), "revoked response used")
+}
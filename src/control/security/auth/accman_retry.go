@@ -0,0 +1,124 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	// DefaultAMMaxAttempts is the number of times a request is attempted
+	// if no maximum is configured.
+	DefaultAMMaxAttempts = 3
+	// DefaultAMRetryBackoff is the backoff before the first retry if none
+	// is configured.
+	DefaultAMRetryBackoff = 100 * time.Millisecond
+	// DefaultAMRetryBackoffCap is the maximum backoff between retries if
+	// none is configured.
+	DefaultAMRetryBackoffCap = 2 * time.Second
+)
+
+type (
+	// amRetryPolicy determines how a failed access manager request is
+	// retried. A request with the zero policy is only attempted once.
+	amRetryPolicy struct {
+		maxAttempts int
+		backoff     time.Duration
+		backoffCap  time.Duration
+	}
+
+	// amUnavailableError is returned when no access manager endpoint could
+	// be reached, with the failure of each.
+	amUnavailableError struct {
+		failures []string
+	}
+)
+
+func (e *amUnavailableError) Error() string {
+	return "no access manager endpoint is available: " + strings.Join(e.failures, "; ")
+}
+
+// newAMRetryPolicy returns the configured retry policy, with defaults for the
+// settings which have not been configured.
+func newAMRetryPolicy(cfg *security.AccessManagerConfig) amRetryPolicy {
+	policy := amRetryPolicy{
+		maxAttempts: cfg.MaxAttempts,
+		backoff:     cfg.RetryBackoff,
+		backoffCap:  cfg.RetryBackoffCap,
+	}
+	if policy.maxAttempts == 0 {
+		policy.maxAttempts = DefaultAMMaxAttempts
+	}
+	if policy.backoff == 0 {
+		policy.backoff = DefaultAMRetryBackoff
+	}
+	if policy.backoffCap == 0 {
+		policy.backoffCap = max(DefaultAMRetryBackoffCap, policy.backoff)
+	}
+	return policy
+}
+
+// isRetryableAMError reports whether a request which failed with the error may
+// succeed if retried: when no endpoint was available, or the access manager
+// asked for the request to be retried later. Requests rejected by the access
+// manager are not retried.
+func isRetryableAMError(err error) bool {
+	var unavailable *amUnavailableError
+	if errors.As(err, &unavailable) {
+		return true
+	}
+	var statusErr *amStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.code == http.StatusTooManyRequests || statusErr.code == http.StatusRequestTimeout
+	}
+	return false
+}
+
+// delay returns the backoff before the given retry, counting from 1. It grows
+// exponentially up to the cap, and is jittered so that agents which failed at
+// the same time do not retry in lockstep.
+func (p amRetryPolicy) delay(retry int) time.Duration {
+	backoff := p.backoff
+	for i := 1; i < retry && backoff < p.backoffCap; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, p.backoffCap)
+	if backoff <= 0 {
+		return 0
+	}
+
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// withRetry calls the request function until it succeeds, fails with an error
+// which is not retryable, or the maximum number of attempts is reached.
+func (p amRetryPolicy) withRetry(ctx context.Context, request func() (*amResp, error)) (*amResp, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := request()
+		if err == nil || attempt >= p.maxAttempts || !isRetryableAMError(err) {
+			return resp, err
+		}
+
+		backoff := p.delay(attempt)
+		logging.FromContext(ctx).Debugf("retrying access manager request after %s (attempt %d of %d): %s",
+			backoff, attempt, p.maxAttempts, err)
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), err.Error())
+		case <-time.After(backoff):
+		}
+	}
+}
@@ -0,0 +1,160 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_newAMRetryPolicy(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg       security.AccessManagerConfig
+		expPolicy amRetryPolicy
+	}{
+		"defaults": {
+			expPolicy: amRetryPolicy{
+				maxAttempts: DefaultAMMaxAttempts,
+				backoff:     DefaultAMRetryBackoff,
+				backoffCap:  DefaultAMRetryBackoffCap,
+			},
+		},
+		"configured": {
+			cfg: security.AccessManagerConfig{
+				MaxAttempts:     5,
+				RetryBackoff:    time.Second,
+				RetryBackoffCap: time.Minute,
+			},
+			expPolicy: amRetryPolicy{
+				maxAttempts: 5,
+				backoff:     time.Second,
+				backoffCap:  time.Minute,
+			},
+		},
+		"backoff above default cap": {
+			cfg: security.AccessManagerConfig{
+				RetryBackoff: 5 * time.Second,
+			},
+			expPolicy: amRetryPolicy{
+				maxAttempts: DefaultAMMaxAttempts,
+				backoff:     5 * time.Second,
+				backoffCap:  5 * time.Second,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.AssertEqual(t, tc.expPolicy, newAMRetryPolicy(&tc.cfg), "unexpected policy")
+		})
+	}
+}
+
+func TestAuth_amRetryPolicy_delay(t *testing.T) {
+	policy := amRetryPolicy{backoff: 100 * time.Millisecond, backoffCap: time.Second}
+
+	for retry, expMax := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		for i := 0; i < 100; i++ {
+			delay := policy.delay(retry)
+			if delay < expMax/2 || delay > expMax {
+				t.Fatalf("retry %d: delay %s not in [%s, %s]", retry, delay, expMax/2, expMax)
+			}
+		}
+	}
+}
+
+func TestAuth_AuthAccManCredentialRequest_Retry(t *testing.T) {
+	for name, tc := range map[string]struct {
+		failures    int
+		failStatus  int
+		maxAttempts int
+		expErr      error
+		expHits     int32
+	}{
+		"single attempt": {
+			failures:   1,
+			failStatus: http.StatusServiceUnavailable,
+			expErr:     errors.New("no access manager endpoint is available"),
+			expHits:    1,
+		},
+		"recovers after unavailable": {
+			failures:    2,
+			failStatus:  http.StatusServiceUnavailable,
+			maxAttempts: 3,
+			expHits:     3,
+		},
+		"unavailable after max attempts": {
+			failures:    3,
+			failStatus:  http.StatusBadGateway,
+			maxAttempts: 2,
+			expErr:      errors.New("no access manager endpoint is available"),
+			expHits:     2,
+		},
+		"recovers after throttling": {
+			failures:    1,
+			failStatus:  http.StatusTooManyRequests,
+			maxAttempts: 3,
+			expHits:     2,
+		},
+		"rejection not retried": {
+			failures:    1,
+			failStatus:  http.StatusForbidden,
+			maxAttempts: 3,
+			expErr:      errors.New(`unexpected status code "403"`),
+			expHits:     1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var hits atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/version" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				if int(hits.Add(1)) <= tc.failures {
+					w.WriteHeader(tc.failStatus)
+					return
+				}
+				info, _ := json.Marshal(&accManInfo{Identity: "am://jdoe"})
+				json.NewEncoder(w).Encode(&amResp{Info: string(info)})
+			}))
+			defer srv.Close()
+
+			req := &AuthAccManCredentialRequest{
+				delegationCredential: "jdoe",
+				callerID:             "daos",
+				endpoints:            []string{srv.URL},
+				retry: amRetryPolicy{
+					maxAttempts: tc.maxAttempts,
+					backoff:     time.Millisecond,
+					backoffCap:  time.Millisecond,
+				},
+				health:    newAMHealth(),
+				versions:  newAMVersions(),
+				transport: &amRESTTransport{client: http.DefaultClient},
+			}
+
+			_, err := req.validateAndParseDelegationCredential(test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expHits, hits.Load(), "unexpected validation requests")
+		})
+	}
+}
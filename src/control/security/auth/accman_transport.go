@@ -0,0 +1,272 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth/accman"
+)
+
+type (
+	// amRequest is a request of the access manager protocol, independent of
+	// the transport used to send it.
+	amRequest struct {
+		path          string
+		method        string
+		params        url.Values
+		version       int
+		correlationID string
+	}
+
+	// amTransport sends requests to a single access manager endpoint. The
+	// endpoint is reported as unavailable if it could not be reached or
+	// failed, in which case the request may be sent to another. A request
+	// rejected by the access manager is reported with an amStatusError.
+	amTransport interface {
+		send(ctx context.Context, endpoint string, req *amRequest) (*amResp, bool, error)
+	}
+
+	// amRESTTransport sends requests to the REST API of the access manager
+	// over HTTP(S).
+	amRESTTransport struct {
+		client *http.Client
+	}
+
+	// amUnixTransport sends requests to the REST API of a local access
+	// manager over HTTP on a Unix socket, with a client for each socket.
+	amUnixTransport struct {
+		key amClientKey
+	}
+
+	// amGRPCTransport sends requests to the AccessManager gRPC service, with
+	// a connection to each endpoint which is shared between requests.
+	amGRPCTransport struct {
+		sync.Mutex
+		creds credentials.TransportCredentials
+		conns map[string]*grpc.ClientConn
+	}
+)
+
+var (
+	amGRPCTransportsMutex sync.Mutex
+	amGRPCTransports      = make(map[security.AccessManagerTLSConfig]*amGRPCTransport)
+
+	// amGRPCMethods is the AccessManager method for each request.
+	amGRPCMethods = map[string]string{
+		"/version":     accman.AccessManager_Version_FullMethodName,
+		"/validate":    accman.AccessManager_Validate_FullMethodName,
+		"/renew":       accman.AccessManager_Renew_FullMethodName,
+		"/revocations": accman.AccessManager_Revocations_FullMethodName,
+	}
+
+	// amGRPCStatusCodes maps the status of a request rejected by the gRPC
+	// service to the equivalent status of the REST API. Other failures are
+	// treated as the endpoint being unavailable.
+	amGRPCStatusCodes = map[codes.Code]int{
+		codes.InvalidArgument:   http.StatusBadRequest,
+		codes.Unauthenticated:   http.StatusUnauthorized,
+		codes.PermissionDenied:  http.StatusForbidden,
+		codes.NotFound:          http.StatusNotFound,
+		codes.Unimplemented:     http.StatusNotFound,
+		codes.ResourceExhausted: http.StatusTooManyRequests,
+	}
+)
+
+// getAMTransport returns the transport selected by the configuration.
+func getAMTransport(cfg *security.AccessManagerConfig) (amTransport, error) {
+	switch cfg.Transport {
+	case security.AMTransportGRPC:
+		return getAMGRPCTransport(&cfg.TLS)
+	case security.AMTransportUnix:
+		return &amUnixTransport{key: newAMClientKey(cfg)}, nil
+	default:
+		client, err := getAMClient(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return &amRESTTransport{client: client}, nil
+	}
+}
+
+func (t *amRESTTransport) send(ctx context.Context, endpoint string, req *amRequest) (*amResp, bool, error) {
+	u, err := url.ParseRequestURI(endpoint)
+	if err != nil {
+		return nil, false, fmt.Errorf("check agent config to ensure AM url is correct (can't happen: %w)", err)
+	}
+
+	return sendAMHTTP(ctx, t.client, u, endpoint, req)
+}
+
+func (t *amUnixTransport) send(ctx context.Context, endpoint string, req *amRequest) (*amResp, bool, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "unix" {
+		return nil, false, fmt.Errorf("check agent config to ensure AM socket %q is correct", endpoint)
+	}
+	key := t.key
+	key.socket = u.Path
+	client, err := getAMClientForKey(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The host is ignored, as the client always dials the socket.
+	return sendAMHTTP(ctx, client, &url.URL{Scheme: "http", Host: "localhost"}, endpoint, req)
+}
+
+// sendAMHTTP sends the request to the REST API of the access manager at the
+// base URL.
+func sendAMHTTP(ctx context.Context, client *http.Client, base *url.URL, endpoint string, req *amRequest) (*amResp, bool, error) {
+	u := *base
+	u.Path = req.path
+	u.RawQuery = req.params.Encode()
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		req.method,
+		u.String(),
+		http.NoBody,
+	)
+	if err != nil {
+		return nil, true, fmt.Errorf(`cannot create request for "%s": %w`, u.String(), err)
+	}
+	if req.version > 0 {
+		request.Header.Set(amVersionHeader, strconv.Itoa(req.version))
+	}
+	if req.correlationID != "" {
+		request.Header.Set(amCorrelationHeader, req.correlationID)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, false, fmt.Errorf(`cannot access "%s": %w`, u.String(), err)
+	}
+
+	//goland:noinspection GoUnhandledErrorResult
+	defer response.Body.Close()
+	// The connection is only returned to the pool once the body has been read.
+	defer io.Copy(io.Discard, io.LimitReader(response.Body, maxAMDrainSize))
+	if response.StatusCode >= http.StatusInternalServerError {
+		return nil, false, fmt.Errorf(`unexpected status code "%d" from "%s"`, response.StatusCode, endpoint)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, true, &amStatusError{code: response.StatusCode}
+	}
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf(`error reading response from %s: %w`, u.String(), err)
+	}
+
+	var resp amResp
+	if err := json.Unmarshal(responseBody, &resp); err != nil {
+		return nil, true, errors.Wrapf(err, "parsing response from %q", endpoint)
+	}
+	return &resp, true, nil
+}
+
+// getAMGRPCTransport returns the shared gRPC transport for the TLS
+// configuration, so that the certificates are only loaded once and the
+// connections are reused between requests. If no certificates are configured,
+// the access manager is verified with the system trust store.
+func getAMGRPCTransport(cfg *security.AccessManagerTLSConfig) (*amGRPCTransport, error) {
+	amGRPCTransportsMutex.Lock()
+	defer amGRPCTransportsMutex.Unlock()
+
+	if transport, found := amGRPCTransports[*cfg]; found {
+		return transport, nil
+	}
+
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.IsSet() {
+		var err error
+		if tlsCfg, err = cfg.TLSConfig(); err != nil {
+			return nil, errors.Wrap(err, "loading access manager certificates")
+		}
+	}
+	transport := newAMGRPCTransport(credentials.NewTLS(tlsCfg))
+	amGRPCTransports[*cfg] = transport
+
+	return transport, nil
+}
+
+func newAMGRPCTransport(creds credentials.TransportCredentials) *amGRPCTransport {
+	return &amGRPCTransport{
+		creds: creds,
+		conns: make(map[string]*grpc.ClientConn),
+	}
+}
+
+func (t *amGRPCTransport) conn(endpoint string) (*grpc.ClientConn, error) {
+	t.Lock()
+	defer t.Unlock()
+
+	if conn, found := t.conns[endpoint]; found {
+		return conn, nil
+	}
+
+	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(t.creds))
+	if err != nil {
+		return nil, errors.Wrapf(err, "access manager %q", endpoint)
+	}
+	t.conns[endpoint] = conn
+
+	return conn, nil
+}
+
+func (t *amGRPCTransport) send(ctx context.Context, endpoint string, req *amRequest) (*amResp, bool, error) {
+	method, found := amGRPCMethods[req.path]
+	if !found {
+		return nil, true, &amStatusError{code: http.StatusNotFound}
+	}
+	conn, err := t.conn(endpoint)
+	if err != nil {
+		return nil, false, err
+	}
+
+	md := metadata.MD{}
+	if req.version > 0 {
+		md.Set(amVersionHeader, strconv.Itoa(req.version))
+	}
+	if req.correlationID != "" {
+		md.Set(amCorrelationHeader, req.correlationID)
+	}
+	in := &accman.Request{Params: make(map[string]string, len(req.params))}
+	for key := range req.params {
+		in.Params[key] = req.params.Get(key)
+	}
+
+	out := new(accman.Response)
+	if err := conn.Invoke(metadata.NewOutgoingContext(ctx, md), method, in, out); err != nil {
+		if code, found := amGRPCStatusCodes[status.Code(err)]; found {
+			return nil, true, &amStatusError{code: code}
+		}
+		return nil, false, fmt.Errorf(`cannot access "%s": %w`, endpoint, err)
+	}
+
+	return &amResp{
+		Error:     amErr{ResponseCode: int(out.Error), Message: out.Message},
+		Info:      out.Info,
+		Signature: out.Signature,
+		CertChain: out.CertChain,
+	}, true, nil
+}
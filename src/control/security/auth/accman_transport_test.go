@@ -0,0 +1,244 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth/accman"
+)
+
+// testGRPCAM is a fake access manager serving the gRPC transport. If versions
+// is nil, it predates versioning.
+type testGRPCAM struct {
+	accman.UnimplementedAccessManagerServer
+	versions *amVersionRange
+	md       metadata.MD
+}
+
+func (am *testGRPCAM) Version(_ context.Context, req *accman.Request) (*accman.Response, error) {
+	if am.versions == nil {
+		return nil, status.Error(codes.Unimplemented, "unknown method")
+	}
+	info, _ := json.Marshal(am.versions)
+	return &accman.Response{Info: string(info)}, nil
+}
+
+func (am *testGRPCAM) Validate(ctx context.Context, req *accman.Request) (*accman.Response, error) {
+	am.md, _ = metadata.FromIncomingContext(ctx)
+	switch req.Params["credential"] {
+	case "denied":
+		return nil, status.Error(codes.PermissionDenied, "denied")
+	case "unknown":
+		return &accman.Response{Error: 1, Message: "unknown credential"}, nil
+	}
+	info, _ := json.Marshal(&accManInfo{
+		Identity: "am://" + req.Params["credential"],
+		Roles:    []string{"am://admins"},
+	})
+	return &accman.Response{Info: string(info)}, nil
+}
+
+func startTestGRPCAM(t *testing.T, am *testGRPCAM) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	accman.RegisterAccessManagerServer(srv, am)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestAuth_amGRPCTransport(t *testing.T) {
+	for name, tc := range map[string]struct {
+		versions    *amVersionRange
+		credential  string
+		unreachable bool
+		expIdentity string
+		expVersion  int
+		expErr      error
+	}{
+		"validated": {
+			versions:    &amVersionRange{Min: amMinProtocolVersion, Max: amMaxProtocolVersion},
+			credential:  "jdoe",
+			expIdentity: "am://jdoe",
+			expVersion:  amMaxProtocolVersion,
+		},
+		"access manager predates versioning": {
+			credential:  "jdoe",
+			expIdentity: "am://jdoe",
+			expVersion:  amProtocolV1,
+		},
+		"permission denied": {
+			versions:   &amVersionRange{Min: amMinProtocolVersion, Max: amMaxProtocolVersion},
+			credential: "denied",
+			expErr:     errors.New(`unexpected status code "403"`),
+		},
+		"rejected": {
+			versions:   &amVersionRange{Min: amMinProtocolVersion, Max: amMaxProtocolVersion},
+			credential: "unknown",
+			expErr:     errors.New("unknown credential"),
+		},
+		"unreachable": {
+			credential:  "jdoe",
+			unreachable: true,
+			expErr:      errors.New("no access manager endpoint is available"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			am := &testGRPCAM{versions: tc.versions}
+			endpoint := startTestGRPCAM(t, am)
+			if tc.unreachable {
+				lis, err := net.Listen("tcp", "127.0.0.1:0")
+				if err != nil {
+					t.Fatal(err)
+				}
+				endpoint = lis.Addr().String()
+				lis.Close()
+			}
+
+			req := &AuthAccManCredentialRequest{
+				delegationCredential: tc.credential,
+				callerID:             "daos",
+				endpoints:            []string{endpoint},
+				health:               newAMHealth(),
+				versions:             newAMVersions(),
+				transport:            newAMGRPCTransport(insecure.NewCredentials()),
+			}
+
+			info, err := req.validateAndParseDelegationCredential(test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.expIdentity, info.Identity, "unexpected identity")
+			test.AssertEqual(t, tc.expVersion, req.protocolVersion, "unexpected negotiated version")
+			test.CmpAny(t, "correlation ID", []string{req.correlationID}, am.md.Get(amCorrelationHeader))
+		})
+	}
+}
+
+func TestAuth_amUnixTransport(t *testing.T) {
+	dir, err := os.MkdirTemp("", "am")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "am.sock")
+
+	lis, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/validate" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		info, _ := json.Marshal(&accManInfo{Identity: "am://" + r.URL.Query().Get("credential")})
+		json.NewEncoder(w).Encode(&amResp{Info: string(info)})
+	}))
+	srv.Listener.Close()
+	srv.Listener = lis
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	cfg := &security.CredentialConfig{
+		AMConfig: security.AccessManagerConfig{
+			CallerID:  "daos",
+			Transport: security.AMTransportUnix,
+			BaseURL:   "unix://" + socket,
+		},
+	}
+	req, err := newAccManRequest(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.health = newAMHealth()
+	req.versions = newAMVersions()
+	req.delegationCredential = "jdoe"
+
+	info, err := req.validateAndParseDelegationCredential(test.Context(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, "am://jdoe", info.Identity, "unexpected identity")
+	test.AssertEqual(t, amProtocolV1, req.protocolVersion, "unexpected negotiated version")
+}
+
+func TestAuth_getAMTransport(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg     security.AccessManagerConfig
+		expType amTransport
+		expErr  error
+	}{
+		"default": {
+			expType: &amRESTTransport{},
+		},
+		"rest": {
+			cfg:     security.AccessManagerConfig{Transport: security.AMTransportREST},
+			expType: &amRESTTransport{},
+		},
+		"grpc": {
+			cfg:     security.AccessManagerConfig{Transport: security.AMTransportGRPC},
+			expType: &amGRPCTransport{},
+		},
+		"grpc missing certificates": {
+			cfg: security.AccessManagerConfig{
+				Transport: security.AMTransportGRPC,
+				TLS: security.AccessManagerTLSConfig{
+					CACert: filepath.Join(t.TempDir(), "missing.crt"),
+					Cert:   "agent.crt",
+					Key:    "agent.key",
+				},
+			},
+			expErr: errors.New("loading access manager certificates"),
+		},
+		"unix": {
+			cfg:     security.AccessManagerConfig{Transport: security.AMTransportUnix},
+			expType: &amUnixTransport{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			transport, err := getAMTransport(&tc.cfg)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, fmt.Sprintf("%T", tc.expType), fmt.Sprintf("%T", transport), "unexpected transport")
+		})
+	}
+
+	grpcCfg := &security.AccessManagerConfig{Transport: security.AMTransportGRPC}
+	transport, _ := getAMTransport(grpcCfg)
+	shared, _ := getAMTransport(grpcCfg)
+	test.AssertTrue(t, transport == shared, "gRPC transport not shared")
+}
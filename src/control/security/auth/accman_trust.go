@@ -0,0 +1,125 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// AMTrustVerifier verifies the chain of trust of the identities asserted by
+// the access manager: that the credential carries the access manager's signed
+// statement of its identity, made by a key certified by one of the trusted
+// CAs. An agent can sign a credential for any identity, but only the access
+// manager can sign the statement which attests to it.
+type AMTrustVerifier struct {
+	roots *x509.CertPool
+}
+
+// NewAMTrustVerifier loads the trusted CA certificates of the access manager.
+// If the chain of trust is not configured, nil is returned, which verifies
+// nothing.
+func NewAMTrustVerifier(cfg *security.AccessManagerTrustConfig) (*AMTrustVerifier, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	roots := x509.NewCertPool()
+	for _, path := range cfg.CACerts {
+		cert, err := security.LoadCertificate(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "loading access manager CA certificate %s", path)
+		}
+		roots.AddCert(cert)
+	}
+	return &AMTrustVerifier{roots: roots}, nil
+}
+
+// verifyChain checks that the leaf of the certificate chain was issued by one
+// of the trusted CAs, via the intermediates in the chain, and returns it.
+func (v *AMTrustVerifier) verifyChain(chain [][]byte, now time.Time) (*x509.Certificate, error) {
+	if len(chain) == 0 {
+		return nil, errors.New("no certificate chain")
+	}
+
+	certs := make([]*x509.Certificate, len(chain))
+	for i, der := range chain {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, errors.Wrapf(err, "certificate %d of chain", i)
+		}
+		certs[i] = cert
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, err
+	}
+	return certs[0], nil
+}
+
+// Verify checks the access manager's attestation of the identity asserted by
+// an AUTH_ACCMAN credential. The statement must be signed by a key whose
+// certificate chains to a trusted CA, must not have expired, and must assert
+// the identity and roles in the token. Credentials of other flavors are not
+// asserted by the access manager, and are not checked.
+func (v *AMTrustVerifier) Verify(cred *Credential, now time.Time) error {
+	if v == nil || cred.GetToken().GetFlavor() != Flavor_AUTH_ACCMAN {
+		return nil
+	}
+
+	att := cred.GetAttestation()
+	if att == nil {
+		return errors.New("credential has not been attested by the access manager")
+	}
+	leaf, err := v.verifyChain(att.GetCertChain(), now)
+	if err != nil {
+		return errors.Wrapf(daos.BadCert, "access manager attestation: untrusted certificate (%s)", err)
+	}
+	if err := security.DefaultTokenSigner().Verify(leaf.PublicKey, att.GetStatement(), att.GetSignature()); err != nil {
+		return errors.Wrapf(daos.BadSignature, "access manager attestation failed to verify (%s)", err)
+	}
+
+	info := &accManInfo{}
+	if err := json.Unmarshal(att.GetStatement(), info); err != nil {
+		return errors.Wrap(err, "malformed access manager attestation")
+	}
+	if info.Expires != 0 && now.After(time.Unix(info.Expires, 0)) {
+		return errors.Wrapf(daos.CredentialExpired, "access manager attestation expired at %s",
+			time.Unix(info.Expires, 0).UTC().Format(time.RFC3339))
+	}
+
+	attested, err := info.sys()
+	if err != nil {
+		return errors.Wrap(err, "access manager attestation")
+	}
+	sys, err := sysFromToken(cred.GetToken())
+	if err != nil {
+		return err
+	}
+	if sys.GetMachinename() != attested.GetMachinename() || sys.GetUser() != attested.GetUser() ||
+		!slices.Equal(sys.GetGroups(), attested.GetGroups()) {
+		return errors.Errorf("credential for %q does not match the identity %q attested by the access manager",
+			sys.GetUser(), attested.GetUser())
+	}
+	return nil
+}
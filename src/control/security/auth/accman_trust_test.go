@@ -0,0 +1,191 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_AMTrustVerifier_Verify(t *testing.T) {
+	agentKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	amKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca := newTestCA(t, "AM CA")
+	otherCA := newTestCA(t, "Other CA")
+	amChain := [][]byte{ca.issue(t, amKey.Public(), &x509.Certificate{})}
+	otherChain := [][]byte{otherCA.issue(t, amKey.Public(), &x509.Certificate{})}
+
+	verifier, err := NewAMTrustVerifier(&security.AccessManagerTrustConfig{
+		CACerts: []string{writeTestCACert(t, ca, t.TempDir())},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jdoe := &accManInfo{Identity: "am://jdoe", Roles: []string{"am://admins"}}
+	attest := func(info *accManInfo, chain [][]byte) *Attestation {
+		statement, err := json.Marshal(info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := security.DefaultTokenSigner().Sign(amKey, statement)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &Attestation{Statement: statement, Signature: sig, CertChain: chain}
+	}
+	newCred := func(flavor Flavor, info *accManInfo, att *Attestation) *Credential {
+		sys, err := info.sys()
+		if err != nil {
+			t.Fatal(err)
+		}
+		cred, err := newSignedCredential(flavor, agentKey, sys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cred.Attestation = att
+		return cred
+	}
+	forged := attest(jdoe, amChain)
+	forged.Statement = []byte(`{"id":"am://root","roles":["am://admins"]}`)
+
+	for name, tc := range map[string]struct {
+		verifier *AMTrustVerifier
+		cred     *Credential
+		expErr   error
+	}{
+		"not verified": {
+			cred: newCred(Flavor_AUTH_ACCMAN, jdoe, nil),
+		},
+		"attested": {
+			verifier: verifier,
+			cred:     newCred(Flavor_AUTH_ACCMAN, jdoe, attest(jdoe, amChain)),
+		},
+		"not asserted by the access manager": {
+			verifier: verifier,
+			cred:     newCred(Flavor_AUTH_SYS, jdoe, nil),
+		},
+		"not attested": {
+			verifier: verifier,
+			cred:     newCred(Flavor_AUTH_ACCMAN, jdoe, nil),
+			expErr:   errors.New("has not been attested"),
+		},
+		"no certificate chain": {
+			verifier: verifier,
+			cred:     newCred(Flavor_AUTH_ACCMAN, jdoe, attest(jdoe, nil)),
+			expErr:   errors.New("no certificate chain"),
+		},
+		"untrusted CA": {
+			verifier: verifier,
+			cred:     newCred(Flavor_AUTH_ACCMAN, jdoe, attest(jdoe, otherChain)),
+			expErr:   errors.New("untrusted certificate"),
+		},
+		"forged statement": {
+			verifier: verifier,
+			cred:     newCred(Flavor_AUTH_ACCMAN, jdoe, forged),
+			expErr:   errors.New("attestation failed to verify"),
+		},
+		"expired": {
+			verifier: verifier,
+			cred: newCred(Flavor_AUTH_ACCMAN, jdoe, attest(&accManInfo{
+				Identity: jdoe.Identity,
+				Roles:    jdoe.Roles,
+				Expires:  time.Now().Add(-time.Minute).Unix(),
+			}, amChain)),
+			expErr: errors.New("attestation expired"),
+		},
+		"different identity": {
+			verifier: verifier,
+			cred: newCred(Flavor_AUTH_ACCMAN, &accManInfo{Identity: "am://root", Roles: jdoe.Roles},
+				attest(jdoe, amChain)),
+			expErr: errors.New(`credential for "root@am" does not match the identity "jdoe@am"`),
+		},
+		"different roles": {
+			verifier: verifier,
+			cred: newCred(Flavor_AUTH_ACCMAN, &accManInfo{Identity: jdoe.Identity, Roles: []string{"am://root"}},
+				attest(jdoe, amChain)),
+			expErr: errors.New("does not match"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.verifier.Verify(tc.cred, time.Now()))
+		})
+	}
+}
+
+func TestAuth_AuthAccManCredentialRequest_Attestation(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	agentKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	amKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca := newTestCA(t, "AM CA")
+	amCert := ca.issue(t, amKey.Public(), &x509.Certificate{})
+
+	// The access manager predates versioning, and signs the info in its
+	// response.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/validate" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		info, _ := json.Marshal(&accManInfo{Identity: "am://jdoe", Roles: []string{"am://admins"}})
+		sig, _ := security.DefaultTokenSigner().Sign(amKey, info)
+		json.NewEncoder(w).Encode(&amResp{Info: string(info), Signature: sig, CertChain: [][]byte{amCert}})
+	}))
+	defer srv.Close()
+
+	req := &AuthAccManCredentialRequest{
+		delegationCredential: "jdoe",
+		signingKey:           agentKey,
+		callerID:             "daos",
+		endpoints:            []string{srv.URL},
+		health:               newAMHealth(),
+		versions:             newAMVersions(),
+		transport:            &amRESTTransport{client: http.DefaultClient},
+	}
+	cred, err := req.GetSignedCredential(log, test.Context(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cred.Attestation == nil {
+		t.Fatal("credential was not attested")
+	}
+
+	verifier, err := NewAMTrustVerifier(&security.AccessManagerTrustConfig{
+		CACerts: []string{writeTestCACert(t, ca, t.TempDir())},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.CmpErr(t, nil, verifier.Verify(cred, time.Now()))
+}
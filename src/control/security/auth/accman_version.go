@@ -0,0 +1,146 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Versions of the protocol spoken with the access manager. Each version adds
+// to the requests of the previous one.
+const (
+	// amProtocolV1 supports credential validation.
+	amProtocolV1 = 1
+	// amProtocolV2 adds revocation subjects and the revocation feed.
+	amProtocolV2 = 2
+	// amProtocolV3 adds renewal tokens and the renewal request.
+	amProtocolV3 = 3
+
+	amMinProtocolVersion = amProtocolV1
+	amMaxProtocolVersion = amProtocolV3
+
+	// amVersionHeader carries the negotiated version in each request.
+	amVersionHeader = "X-DAOS-AM-Version"
+)
+
+// amPathVersions is the protocol version needed for each request introduced
+// after the first.
+var amPathVersions = map[string]int{
+	"/revocations": amProtocolV2,
+	"/renew":       amProtocolV3,
+}
+
+// errAMUnsupported is returned for a request which the access manager does
+// not support, as its protocol version is too old.
+var errAMUnsupported = errors.New("not supported by the access manager")
+
+type (
+	// amVersions records the protocol version negotiated with each access
+	// manager endpoint, so that the handshake is only repeated once the
+	// endpoint has been unavailable.
+	amVersions struct {
+		sync.Mutex
+		negotiated map[string]int
+	}
+
+	// amVersionRange is the range of protocol versions supported by the
+	// access manager, returned by its /version request.
+	amVersionRange struct {
+		Min int `json:"min_version"`
+		Max int `json:"max_version"`
+	}
+
+	// amStatusError is returned for an unexpected status code from the
+	// access manager.
+	amStatusError struct {
+		code int
+	}
+)
+
+var defaultAMVersions = newAMVersions()
+
+func newAMVersions() *amVersions {
+	return &amVersions{negotiated: make(map[string]int)}
+}
+
+func (v *amVersions) get(endpoint string) (int, bool) {
+	v.Lock()
+	defer v.Unlock()
+	version, found := v.negotiated[endpoint]
+	return version, found
+}
+
+func (v *amVersions) set(endpoint string, version int) {
+	v.Lock()
+	defer v.Unlock()
+	v.negotiated[endpoint] = version
+}
+
+func (v *amVersions) forget(endpoint string) {
+	v.Lock()
+	defer v.Unlock()
+	delete(v.negotiated, endpoint)
+}
+
+func (e *amStatusError) Error() string {
+	return fmt.Sprintf(`unexpected status code "%d"`, e.code)
+}
+
+// negotiateVersion returns the highest protocol version supported by both
+// the agent and the access manager endpoint. An access manager without the
+// /version request predates versioning and only supports the first version.
+func (r *AuthAccManCredentialRequest) negotiateVersion(ctx context.Context, endpoint string) (int, bool, error) {
+	if version, found := r.versions.get(endpoint); found {
+		return version, true, nil
+	}
+
+	params := url.Values{}
+	params.Set("caller_id", r.callerID)
+	params.Set("min_version", strconv.Itoa(amMinProtocolVersion))
+	params.Set("max_version", strconv.Itoa(amMaxProtocolVersion))
+
+	resp, available, err := r.transport.send(ctx, endpoint, &amRequest{
+		path:          "/version",
+		method:        http.MethodGet,
+		params:        params,
+		correlationID: r.correlationID,
+	})
+	var statusErr *amStatusError
+	if errors.As(err, &statusErr) && statusErr.code == http.StatusNotFound {
+		r.versions.set(endpoint, amProtocolV1)
+		return amProtocolV1, true, nil
+	}
+	if err != nil {
+		return 0, available, err
+	}
+
+	var supported amVersionRange
+	if resp.Error.ResponseCode != 0 {
+		return 0, true, errors.New(resp.Error.Message)
+	}
+	if err := json.Unmarshal([]byte(resp.Info), &supported); err != nil {
+		return 0, true, errors.Wrap(err, "parsing access manager version")
+	}
+
+	version := min(supported.Max, amMaxProtocolVersion)
+	if version < max(supported.Min, amMinProtocolVersion) {
+		// Another endpoint may be running a compatible version.
+		return 0, false, errors.Errorf("%q supports access manager protocol versions %d-%d, agent supports %d-%d",
+			endpoint, supported.Min, supported.Max, amMinProtocolVersion, amMaxProtocolVersion)
+	}
+	r.versions.set(endpoint, version)
+
+	return version, true, nil
+}
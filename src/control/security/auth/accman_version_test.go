@@ -0,0 +1,134 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestAuth_AuthAccManCredentialRequest_negotiateVersion(t *testing.T) {
+	for name, tc := range map[string]struct {
+		versions   *amVersionRange
+		expVersion int
+		expErr     error
+	}{
+		"current access manager": {
+			versions:   &amVersionRange{Min: amProtocolV1, Max: amProtocolV3},
+			expVersion: amProtocolV3,
+		},
+		"access manager predates versioning": {
+			expVersion: amProtocolV1,
+		},
+		"newer access manager": {
+			versions:   &amVersionRange{Min: amProtocolV1, Max: 5},
+			expVersion: amProtocolV3,
+		},
+		"older access manager": {
+			versions:   &amVersionRange{Min: amProtocolV1, Max: amProtocolV1},
+			expVersion: amProtocolV1,
+		},
+		"no common version": {
+			versions: &amVersionRange{Min: 4, Max: 5},
+			expErr:   errors.New("supports access manager protocol versions 4-5, agent supports 1-3"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			am := startTestAMVersions(t, http.StatusOK, tc.versions)
+			req := &AuthAccManCredentialRequest{
+				delegationCredential: "jdoe",
+				callerID:             "daos",
+				endpoints:            []string{am.url},
+				health:               newAMHealth(),
+				versions:             newAMVersions(),
+				transport:            &amRESTTransport{client: http.DefaultClient},
+			}
+
+			for i := 0; i < 2; i++ {
+				_, err := req.validateAndParseDelegationCredential(test.Context(t))
+				test.CmpErr(t, tc.expErr, err)
+			}
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.expVersion, req.protocolVersion, "unexpected negotiated version")
+			test.AssertEqual(t, fmt.Sprint(tc.expVersion), am.version.Load(), "unexpected request version")
+			test.AssertEqual(t, int32(3), am.hits.Load(), "version negotiated more than once")
+		})
+	}
+}
+
+func TestAuth_AuthAccManCredentialRequest_Renewal(t *testing.T) {
+	for name, tc := range map[string]struct {
+		versions        *amVersionRange
+		renewalToken    string
+		expUser         string
+		expRenewalToken string
+		expErr          error
+	}{
+		"token issued on validation": {
+			versions:        &amVersionRange{Min: amProtocolV1, Max: amProtocolV3},
+			expUser:         "jdoe@am",
+			expRenewalToken: "renew-jdoe",
+		},
+		"renewed with token": {
+			versions:        &amVersionRange{Min: amProtocolV1, Max: amProtocolV3},
+			renewalToken:    "renew-jsmith",
+			expUser:         "jsmith@am",
+			expRenewalToken: "renew-jsmith+",
+		},
+		"invalid token": {
+			versions:     &amVersionRange{Min: amProtocolV1, Max: amProtocolV3},
+			renewalToken: "bad",
+			expErr:       errors.New("invalid renewal token"),
+		},
+		"renewal unsupported": {
+			versions:     &amVersionRange{Min: amProtocolV1, Max: amProtocolV2},
+			renewalToken: "renew-jsmith",
+			expErr:       errAMUnsupported,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			am := startTestAMVersions(t, http.StatusOK, tc.versions)
+			req := &AuthAccManCredentialRequest{
+				delegationCredential: "jdoe",
+				callerID:             "daos",
+				endpoints:            []string{am.url},
+				health:               newAMHealth(),
+				versions:             newAMVersions(),
+				transport:            &amRESTTransport{client: http.DefaultClient},
+			}
+			if tc.renewalToken != "" {
+				req.UseRenewalToken(tc.renewalToken)
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			sys := &Sys{}
+			if err := proto.Unmarshal(cred.Token.Data, sys); err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expRenewalToken, req.RenewalToken(), "unexpected renewal token")
+		})
+	}
+}
@@ -10,45 +10,182 @@ import (
 	"bytes"
 	"context"
 	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/daos"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/security"
 )
 
-// VerifierFromToken will return a SHA512 hash of the token data. If a signing key
-// is passed in it will additionally sign the hash of the token.
+// verifierHashes maps the hash algorithms which may be recorded in a verifier
+// to their implementations.
+var verifierHashes = map[HashAlgorithm]crypto.Hash{
+	HashAlgorithm_HASH_SHA256:   crypto.SHA256,
+	HashAlgorithm_HASH_SHA384:   crypto.SHA384,
+	HashAlgorithm_HASH_SHA512:   crypto.SHA512,
+	HashAlgorithm_HASH_SHA3_256: crypto.SHA3_256,
+	HashAlgorithm_HASH_SHA3_384: crypto.SHA3_384,
+	HashAlgorithm_HASH_SHA3_512: crypto.SHA3_512,
+}
+
+// verifierHash is the hash algorithm used to compute new verifiers.
+var verifierHash atomic.Int32
+
+// SetVerifierHash sets the hash algorithm used to compute new verifiers. The
+// algorithm is recorded in each verifier, so that credentials computed with
+// different algorithms can be verified side by side.
+func SetVerifierHash(hash crypto.Hash) error {
+	for alg, h := range verifierHashes {
+		if h != hash {
+			continue
+		}
+		if !hash.Available() {
+			return errors.Errorf("hash algorithm %s is not available", hash)
+		}
+		verifierHash.Store(int32(alg))
+		return nil
+	}
+	return errors.Errorf("hash algorithm %s may not be used for verifiers", hash)
+}
+
+// signingPool, if set, performs the signatures of new verifiers.
+var signingPool atomic.Pointer[security.SigningPool]
+
+// SetSigningPool has the signatures of new verifiers performed by the pool, so
+// that the number of signatures performed at once is bounded. If pool is nil,
+// they are performed by the caller.
+func SetSigningPool(pool *security.SigningPool) {
+	signingPool.Store(pool)
+}
+
+// signatureObserver, if set, is called for each credential signed.
+var signatureObserver atomic.Pointer[func(keyID string, flavor Flavor)]
+
+// SetSignatureObserver has observe called with the ID of the key and the
+// flavor of each credential signed, so that the usage of each key can be
+// audited. If observe is nil, no credentials are observed.
+func SetSignatureObserver(observe func(keyID string, flavor Flavor)) {
+	if observe == nil {
+		signatureObserver.Store(nil)
+		return
+	}
+	signatureObserver.Store(&observe)
+}
+
+// tokenSignerBackend, if set, is the backend of the TokenSigners with which
+// verifiers are signed and verified.
+var tokenSignerBackend atomic.Pointer[security.TokenSignerBackend]
+
+// SetTokenSignerBackend sets the backend of the TokenSigners with which
+// verifiers are signed and verified. The software signer is used until it is
+// set.
+func SetTokenSignerBackend(backend security.TokenSignerBackend) error {
+	if err := backend.Validate(); err != nil {
+		return err
+	}
+	tokenSignerBackend.Store(&backend)
+	return nil
+}
+
+// newTokenSigner returns a TokenSigner of the configured backend which uses
+// the hash algorithm.
+func newTokenSigner(alg HashAlgorithm) (security.TokenSigner, error) {
+	hash, found := verifierHashes[alg]
+	if !found {
+		return nil, errors.Errorf("unknown verifier hash algorithm %d", alg)
+	}
+
+	var backend security.TokenSignerBackend
+	if b := tokenSignerBackend.Load(); b != nil {
+		backend = *b
+	}
+	return security.NewBackendTokenSigner(backend, hash)
+}
+
+// VerifierFromToken will return a hash of the token data, computed with the
+// configured verifier hash algorithm (SHA-512 by default). If a signing key is
+// passed in it will additionally sign the hash of the token.
 func VerifierFromToken(key crypto.PublicKey, token *Token) ([]byte, error) {
+	return verifierFromToken(key, token, HashAlgorithm(verifierHash.Load()))
+}
+
+func verifierFromToken(key crypto.PublicKey, token *Token, alg HashAlgorithm) ([]byte, error) {
 	var sig []byte
 	tokenBytes, err := proto.Marshal(token)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to marshal Token")
 	}
 
-	signer := security.DefaultTokenSigner()
+	signer, err := newTokenSigner(alg)
+	if err != nil {
+		return nil, err
+	}
 
 	if key == nil {
 		return signer.Hash(tokenBytes)
 	}
-	sig, err = signer.Sign(key, tokenBytes)
+	if pool := signingPool.Load(); pool != nil {
+		sig, err = pool.Sign(func() ([]byte, error) {
+			return signer.Sign(key, tokenBytes)
+		})
+	} else {
+		sig, err = signer.Sign(key, tokenBytes)
+	}
 	return sig, errors.Wrap(err, "signing verifier failed")
 }
 
+// newVerifier returns the verifier for the token, recording the hash algorithm
+// it was computed with.
+func newVerifier(key crypto.PublicKey, token *Token) (*Token, error) {
+	alg := HashAlgorithm(verifierHash.Load())
+	verifier, err := verifierFromToken(key, token, alg)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Unable to generate verifier")
+	}
+
+	return &Token{
+		Flavor: token.GetFlavor(),
+		Data:   verifier,
+		Hash:   alg,
+	}, nil
+}
+
 // VerifyToken takes the auth token and the signature bytes in the verifier and
 // verifies it against the public key provided for the agent who claims to have
 // provided the token. It also confirms that the token is from an authentication
-// source supported by the server.
+// source supported by the server. The verifier must have been computed with
+// SHA-512; use VerifyCredential to verify a verifier computed with the hash
+// algorithm recorded in it.
 func VerifyToken(key crypto.PublicKey, token *Token, sig []byte) error {
+	return verifyToken(key, token, sig, HashAlgorithm_HASH_SHA512)
+}
+
+// VerifyCredential verifies the credential's token against its verifier, using
+// the hash algorithm recorded in the verifier.
+func VerifyCredential(key crypto.PublicKey, cred *Credential) error {
+	verifier := cred.GetVerifier()
+	return verifyToken(key, cred.GetToken(), verifier.GetData(), verifier.GetHash())
+}
+
+func verifyToken(key crypto.PublicKey, token *Token, sig []byte, alg HashAlgorithm) error {
 	tokenBytes, err := proto.Marshal(token)
 	if err != nil {
 		return errors.Wrap(err, "unable to marshal Token")
 	}
 
-	signer := security.DefaultTokenSigner()
+	signer, err := newTokenSigner(alg)
+	if err != nil {
+		return err
+	}
 
 	if key == nil {
 		digest, err := signer.Hash(tokenBytes)
@@ -58,11 +195,182 @@ func VerifyToken(key crypto.PublicKey, token *Token, sig []byte) error {
 		if bytes.Equal(digest, sig) {
 			return nil
 		}
-		return errors.Errorf("unsigned hash failed to verify.")
+		return errors.Wrap(daos.BadSignature, "unsigned hash failed to verify")
+	}
+
+	if err := signer.Verify(key, tokenBytes, sig); err != nil {
+		return errors.Wrapf(daos.BadSignature, "token verification Failed (%s)", err)
+	}
+	return nil
+}
+
+// newSignedCredential packs the Sys token into a Token of the given flavor and
+// signs it with the supplied key.
+func newSignedCredential(flavor Flavor, key crypto.PrivateKey, sys *Sys) (*Credential, error) {
+	tokenBytes, err := proto.Marshal(sys)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to marshal AuthSys token")
+	}
+
+	token := Token{
+		Flavor: flavor,
+		Data:   tokenBytes}
+
+	return newCredential(key, &token)
+}
+
+// newCredential returns a credential for the token, with its verifier signed by
+// the supplied key. The ID of the key is recorded in the credential, so that a
+// server which trusts several agent keys can select the one to verify it with.
+// The validity period of the credential, if its flavor has a lifetime, the
+// system it is for, if set, and the class of its identity are recorded in the
+// token before it is signed.
+func newCredential(key crypto.PrivateKey, token *Token) (*Credential, error) {
+	stampValidity(token, time.Now())
+	stampSystem(token)
+	stampClass(token)
+	verifier, err := newVerifier(key, token)
+	if err != nil {
+		return nil, err
 	}
 
-	err = signer.Verify(key, tokenBytes, sig)
-	return errors.Wrap(err, "token verification Failed")
+	cred := &Credential{
+		Token:    token,
+		Verifier: verifier,
+		Origin:   "agent"}
+	if key != nil {
+		keyID, err := security.PrivateKeyID(key)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to identify signing key")
+		}
+		cred.KeyId = keyID
+		if observe := signatureObserver.Load(); observe != nil {
+			(*observe)(keyID, token.GetFlavor())
+		}
+	}
+	return cred, nil
+}
+
+// tokenCacheKey derives a cache key for a bearer token without retaining the
+// token itself in the key.
+func tokenCacheKey(flavor Flavor, token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return flavor.String() + ":" + hex.EncodeToString(sum[:])
+}
+
+// externalIdentitySys builds a Sys token for an identity asserted by an
+// external identity provider. The identity, any aliases it may be mapped by,
+// and its groups are normalized before the identity map is consulted and before
+// they are embedded in the token. If the identity has been mapped to a local
+// user, the mapped names are used. Otherwise, the identity and its groups are
+// qualified with the provider's realm (e.g. "<id>@azure") unless they are
+// already domain-qualified.
+func externalIdentitySys(realm string, fold security.CaseFoldPolicy, idMap security.ExternalIdentityMap, id string, groups []string, aliases ...string) (*Sys, error) {
+	hostname, err := GetMachineName()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get hostname")
+	}
+
+	sys := &Sys{
+		Machinename: hostname,
+	}
+
+	lookupIDs := make([]string, 0, len(aliases)+1)
+	for _, rawID := range append([]string{id}, aliases...) {
+		if rawID == "" {
+			continue
+		}
+		normID, err := security.NormalizePrincipal(rawID, fold)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s identity", realm)
+		}
+		lookupIDs = append(lookupIDs, normID)
+	}
+
+	if mapped := idMap.Lookup(lookupIDs...); mapped != nil {
+		sys.User = sysNameToPrincipalName(mapped.User)
+		if mapped.Group != "" {
+			sys.Group = sysNameToPrincipalName(mapped.Group)
+		}
+		for _, g := range mapped.Groups {
+			sys.Groups = append(sys.Groups, sysNameToPrincipalName(g))
+		}
+		return sys, nil
+	}
+
+	if id == "" {
+		return nil, errors.Errorf("no %s identity supplied", realm)
+	}
+	sys.User = realmPrincipalName(lookupIDs[0], realm)
+	for _, g := range groups {
+		normGroup, err := security.NormalizePrincipal(g, fold)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s group", realm)
+		}
+		sys.Groups = append(sys.Groups, realmPrincipalName(normGroup, realm))
+	}
+
+	return sys, nil
+}
+
+// realmPrincipalName qualifies a name with the realm unless it is already
+// domain-qualified (e.g. an email address). A name with an empty domain
+// (e.g. "root@") is qualified too, so that it can never be taken for a local
+// principal.
+func realmPrincipalName(name, realm string) string {
+	user, domain, _ := strings.Cut(name, "@")
+	if domain != "" {
+		return name
+	}
+	return user + "@" + realm
+}
+
+// assertedPrincipalName returns the principal for a name asserted by a trusted
+// site component. Names without a domain are local names.
+func assertedPrincipalName(name string, fold security.CaseFoldPolicy) (string, error) {
+	principal, err := security.NormalizePrincipal(name, fold)
+	if err != nil {
+		return "", err
+	}
+	if !strings.Contains(principal, "@") {
+		principal = sysNameToPrincipalName(principal)
+	}
+	return principal, nil
+}
+
+// assertedIdentitySys builds a Sys token for an identity asserted by a trusted
+// site component, such as an exec helper or credential provider, which is
+// responsible for authenticating the client and mapping it to a DAOS identity.
+func assertedIdentitySys(fold security.CaseFoldPolicy, user, group string, groups []string) (*Sys, error) {
+	if user == "" {
+		return nil, errors.New("no user asserted")
+	}
+
+	hostname, err := GetMachineName()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get hostname")
+	}
+	sys := &Sys{
+		Machinename: hostname,
+	}
+
+	if sys.User, err = assertedPrincipalName(user, fold); err != nil {
+		return nil, errors.Wrap(err, "invalid user")
+	}
+	if group != "" {
+		if sys.Group, err = assertedPrincipalName(group, fold); err != nil {
+			return nil, errors.Wrap(err, "invalid group")
+		}
+	}
+	for _, g := range groups {
+		principal, err := assertedPrincipalName(g, fold)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid group")
+		}
+		sys.Groups = append(sys.Groups, principal)
+	}
+
+	return sys, nil
 }
 
 // AuthSysFromAuthToken takes an opaque AuthToken and turns it into a
@@ -88,6 +396,11 @@ func ParseValidAuthFlavors(authStrings []string) ([]Flavor, error) {
 	for i := 0; i < len(authStrings); i++ {
 		// Uppercase avoids case sensitivity, we trim the AUTH_ prefix to enable raw authentication names.
 		authString := strings.TrimPrefix(strings.ToUpper(authStrings[i]), "AUTH_")
+		// Flavors provided by agent plugins have no name, and are given by number.
+		if num, err := strconv.ParseInt(authString, 10, 32); err == nil && Flavor(num) >= MinPluginFlavor {
+			validAuthFlavors[i] = Flavor(num)
+			continue
+		}
 		flavor, ok := Flavor_value["AUTH_"+authString]
 		if !ok {
 			return nil, errors.Errorf("auth string %s is not recognized", authStrings[i])
@@ -126,18 +439,86 @@ type (
 		// Returns the auth flavor that refers to the CredentialRequest implementation.
 		GetAuthFlavor() Flavor
 	}
+
+	// UserCredentialRequestFactory is implemented by the factories of flavors
+	// whose credentials depend only on the identity of the client, so that
+	// they can be requested on behalf of a user before any of the user's
+	// processes asks for one, e.g. to warm up the credential cache.
+	UserCredentialRequestFactory interface {
+		CredentialRequestFactory
+		// Initialize a request for the credential of the identified user,
+		// as if it had been requested by one of the user's processes.
+		InitForUser(log logging.Logger, secCfg *security.CredentialConfig, info *security.DomainInfo, key crypto.PrivateKey) (CredentialRequest, error)
+	}
+
+	// RenewableCredentialRequest is implemented by the requests of flavors
+	// whose credentials can be renewed with a token issued alongside them,
+	// without authenticating the client again.
+	RenewableCredentialRequest interface {
+		CredentialRequest
+		// Renews the credential issued with the token, instead of
+		// authenticating the client from the request body.
+		UseRenewalToken(token string)
+		// Returns the token with which the signed credential can be
+		// renewed, if one was issued.
+		RenewalToken() string
+	}
 )
 
+// generateAuthMap returns a copy of the base map with the factories added. An
+// error is returned if more than one factory is provided for a flavor.
+func generateAuthMap(base AuthMap, factories ...CredentialRequestFactory) (AuthMap, error) {
+	authMap := make(AuthMap, len(base)+len(factories))
+	for flavor, factory := range base {
+		authMap[flavor] = factory
+	}
+	for _, factory := range factories {
+		if factory == nil {
+			return nil, errors.New("nil credential factory")
+		}
+		flavor := factory.GetAuthFlavor()
+		if existing, found := authMap[flavor]; found {
+			return nil, errors.Errorf("flavor %s of %T is already provided by %T", flavor, factory, existing)
+		}
+		authMap[flavor] = factory
+	}
+	return authMap, nil
+}
+
 // CredentialRequests is a list of authentication methods the agent can use.
 // To implement a new type of authentication: satisfy the CredentialRequest and
 // CredentialRequestFactory interfaces, add a new flavor in auth.proto, ensure
 // that your `GetAuthFlavor` method returns this new unique flavor and add your
 // interface to the `CredentialRequests` list below.
+// Flavors may also be provided by Go plugins loaded when the agent starts; see
+// LoadPlugins.
 // The server must be configured to allow an authentication method when it is initalized.
 // By default, only Unix authentication is enabled.
 
 // var CredentialRequests = []CredentialRequestFactory{&AuthSysCredentialFactory{}, &AuthAccManCredentialFactory{}}
 var FlavorToFactory = map[Flavor]CredentialRequestFactory{
-	AuthSysCredentialFactory{}.GetAuthFlavor():    &AuthSysCredentialFactory{},
-	AuthAccManCredentialFactory{}.GetAuthFlavor(): &AuthAccManCredentialFactory{},
+	AuthSysCredentialFactory{}.GetAuthFlavor():        &AuthSysCredentialFactory{},
+	AuthAccManCredentialFactory{}.GetAuthFlavor():     &AuthAccManCredentialFactory{},
+	AuthAzureCredentialFactory{}.GetAuthFlavor():      &AuthAzureCredentialFactory{},
+	AuthGCPCredentialFactory{}.GetAuthFlavor():        &AuthGCPCredentialFactory{},
+	AuthVaultCredentialFactory{}.GetAuthFlavor():      &AuthVaultCredentialFactory{},
+	AuthOAuth2CredentialFactory{}.GetAuthFlavor():     &AuthOAuth2CredentialFactory{},
+	AuthSSHCredentialFactory{}.GetAuthFlavor():        &AuthSSHCredentialFactory{},
+	AuthFIDO2CredentialFactory{}.GetAuthFlavor():      &AuthFIDO2CredentialFactory{},
+	AuthSciTokensCredentialFactory{}.GetAuthFlavor():  &AuthSciTokensCredentialFactory{},
+	AuthMacaroonCredentialFactory{}.GetAuthFlavor():   &AuthMacaroonCredentialFactory{},
+	AuthBiscuitCredentialFactory{}.GetAuthFlavor():    &AuthBiscuitCredentialFactory{},
+	AuthKeystoneCredentialFactory{}.GetAuthFlavor():   &AuthKeystoneCredentialFactory{},
+	AuthPKCS11CredentialFactory{}.GetAuthFlavor():     &AuthPKCS11CredentialFactory{},
+	AuthAnonCredentialFactory{}.GetAuthFlavor():       &AuthAnonCredentialFactory{},
+	AuthExecCredentialFactory{}.GetAuthFlavor():       &AuthExecCredentialFactory{},
+	AuthProviderCredentialFactory{}.GetAuthFlavor():   &AuthProviderCredentialFactory{},
+	AuthMachineCredentialFactory{}.GetAuthFlavor():    &AuthMachineCredentialFactory{},
+	AuthSlurmCredentialFactory{}.GetAuthFlavor():      &AuthSlurmCredentialFactory{},
+	AuthWLMCredentialFactory{}.GetAuthFlavor():        &AuthWLMCredentialFactory{},
+	AuthADCredentialFactory{}.GetAuthFlavor():         &AuthADCredentialFactory{},
+	AuthTOTPCredentialFactory{}.GetAuthFlavor():       &AuthTOTPCredentialFactory{},
+	AuthDelegationCredentialFactory{}.GetAuthFlavor(): &AuthDelegationCredentialFactory{},
+	AuthProxyCredentialFactory{}.GetAuthFlavor():      &AuthProxyCredentialFactory{},
+	AuthWebhookCredentialFactory{}.GetAuthFlavor():    &AuthWebhookCredentialFactory{},
 }
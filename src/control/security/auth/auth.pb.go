@@ -8,7 +8,7 @@
 // versions:
 // 	protoc-gen-go v1.34.1
 // 	protoc        v3.5.0
-// source: auth.proto
+// source: security/auth.proto
 
 package auth
 
@@ -30,19 +30,88 @@ const (
 type Flavor int32
 
 const (
-	Flavor_AUTH_NONE Flavor = 0
-	Flavor_AUTH_SYS  Flavor = 1
+	Flavor_AUTH_NONE       Flavor = 0  // No authentication.
+	Flavor_AUTH_SYS        Flavor = 1  // Traditional Unix identity based authentication.
+	Flavor_AUTH_ACCMAN     Flavor = 2  // Authentication provided by the Access Manager.
+	Flavor_AUTH_AZURE      Flavor = 3  // Azure AD (Entra ID) access token authentication.
+	Flavor_AUTH_GCP        Flavor = 4  // Google-signed ID token authentication.
+	Flavor_AUTH_VAULT      Flavor = 5  // HashiCorp Vault token authentication.
+	Flavor_AUTH_OAUTH2     Flavor = 6  // OAuth2 client credentials authentication.
+	Flavor_AUTH_SSH        Flavor = 7  // Signature by a key held in ssh-agent.
+	Flavor_AUTH_FIDO2      Flavor = 8  // WebAuthn assertion by a FIDO2 security key.
+	Flavor_AUTH_SCITOKENS  Flavor = 9  // SciTokens or WLCG token authentication.
+	Flavor_AUTH_MACAROON   Flavor = 10 // Macaroon minted by a site authority.
+	Flavor_AUTH_BISCUIT    Flavor = 11 // Biscuit token signed by a site authority.
+	Flavor_AUTH_KEYSTONE   Flavor = 12 // OpenStack Keystone token authentication.
+	Flavor_AUTH_PKCS11     Flavor = 13 // Signature by a smart card key via PKCS#11.
+	Flavor_AUTH_ANON       Flavor = 14 // Unauthenticated guest access.
+	Flavor_AUTH_EXEC       Flavor = 15 // Identity asserted by a site-provided helper executable.
+	Flavor_AUTH_PROVIDER   Flavor = 16 // Identity asserted by a site-operated credential provider service.
+	Flavor_AUTH_MACHINE    Flavor = 17 // Identity of the node itself, for node-local daemons.
+	Flavor_AUTH_SLURM      Flavor = 18 // Slurm JWT presented by a process of a Slurm job.
+	Flavor_AUTH_WLM        Flavor = 19 // Job token signed by a workload manager such as Flux.
+	Flavor_AUTH_AD         Flavor = 20 // Active Directory identity resolved through SSSD.
+	Flavor_AUTH_TOTP       Flavor = 21 // Unix identity confirmed with a TOTP one-time password.
+	Flavor_AUTH_DELEGATION Flavor = 22 // Restricted credential delegated by a user to a service.
+	Flavor_AUTH_PROXY      Flavor = 23 // Identity of a remote user asserted by a trusted gateway.
+	Flavor_AUTH_WEBHOOK    Flavor = 24 // Identity asserted by a site-operated HTTPS webhook.
 )
 
 // Enum value maps for Flavor.
 var (
 	Flavor_name = map[int32]string{
-		0: "AUTH_NONE",
-		1: "AUTH_SYS",
+		0:  "AUTH_NONE",
+		1:  "AUTH_SYS",
+		2:  "AUTH_ACCMAN",
+		3:  "AUTH_AZURE",
+		4:  "AUTH_GCP",
+		5:  "AUTH_VAULT",
+		6:  "AUTH_OAUTH2",
+		7:  "AUTH_SSH",
+		8:  "AUTH_FIDO2",
+		9:  "AUTH_SCITOKENS",
+		10: "AUTH_MACAROON",
+		11: "AUTH_BISCUIT",
+		12: "AUTH_KEYSTONE",
+		13: "AUTH_PKCS11",
+		14: "AUTH_ANON",
+		15: "AUTH_EXEC",
+		16: "AUTH_PROVIDER",
+		17: "AUTH_MACHINE",
+		18: "AUTH_SLURM",
+		19: "AUTH_WLM",
+		20: "AUTH_AD",
+		21: "AUTH_TOTP",
+		22: "AUTH_DELEGATION",
+		23: "AUTH_PROXY",
+		24: "AUTH_WEBHOOK",
 	}
 	Flavor_value = map[string]int32{
-		"AUTH_NONE": 0,
-		"AUTH_SYS":  1,
+		"AUTH_NONE":       0,
+		"AUTH_SYS":        1,
+		"AUTH_ACCMAN":     2,
+		"AUTH_AZURE":      3,
+		"AUTH_GCP":        4,
+		"AUTH_VAULT":      5,
+		"AUTH_OAUTH2":     6,
+		"AUTH_SSH":        7,
+		"AUTH_FIDO2":      8,
+		"AUTH_SCITOKENS":  9,
+		"AUTH_MACAROON":   10,
+		"AUTH_BISCUIT":    11,
+		"AUTH_KEYSTONE":   12,
+		"AUTH_PKCS11":     13,
+		"AUTH_ANON":       14,
+		"AUTH_EXEC":       15,
+		"AUTH_PROVIDER":   16,
+		"AUTH_MACHINE":    17,
+		"AUTH_SLURM":      18,
+		"AUTH_WLM":        19,
+		"AUTH_AD":         20,
+		"AUTH_TOTP":       21,
+		"AUTH_DELEGATION": 22,
+		"AUTH_PROXY":      23,
+		"AUTH_WEBHOOK":    24,
 	}
 )
 
@@ -57,11 +126,11 @@ func (x Flavor) String() string {
 }
 
 func (Flavor) Descriptor() protoreflect.EnumDescriptor {
-	return file_auth_proto_enumTypes[0].Descriptor()
+	return file_security_auth_proto_enumTypes[0].Descriptor()
 }
 
 func (Flavor) Type() protoreflect.EnumType {
-	return &file_auth_proto_enumTypes[0]
+	return &file_security_auth_proto_enumTypes[0]
 }
 
 func (x Flavor) Number() protoreflect.EnumNumber {
@@ -70,7 +139,166 @@ func (x Flavor) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Flavor.Descriptor instead.
 func (Flavor) EnumDescriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{0}
+	return file_security_auth_proto_rawDescGZIP(), []int{0}
+}
+
+// Class of the identity a credential was issued to
+type CredentialClass int32
+
+const (
+	CredentialClass_CLASS_UNSPECIFIED CredentialClass = 0 // Not recorded by the agent.
+	CredentialClass_CLASS_USER        CredentialClass = 1 // A user, interactive or in a job.
+	CredentialClass_CLASS_MACHINE     CredentialClass = 2 // A node, for its node-local services.
+	CredentialClass_CLASS_DELEGATED   CredentialClass = 3 // A service acting for a user who delegated to it.
+)
+
+// Enum value maps for CredentialClass.
+var (
+	CredentialClass_name = map[int32]string{
+		0: "CLASS_UNSPECIFIED",
+		1: "CLASS_USER",
+		2: "CLASS_MACHINE",
+		3: "CLASS_DELEGATED",
+	}
+	CredentialClass_value = map[string]int32{
+		"CLASS_UNSPECIFIED": 0,
+		"CLASS_USER":        1,
+		"CLASS_MACHINE":     2,
+		"CLASS_DELEGATED":   3,
+	}
+)
+
+func (x CredentialClass) Enum() *CredentialClass {
+	p := new(CredentialClass)
+	*p = x
+	return p
+}
+
+func (x CredentialClass) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CredentialClass) Descriptor() protoreflect.EnumDescriptor {
+	return file_security_auth_proto_enumTypes[1].Descriptor()
+}
+
+func (CredentialClass) Type() protoreflect.EnumType {
+	return &file_security_auth_proto_enumTypes[1]
+}
+
+func (x CredentialClass) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CredentialClass.Descriptor instead.
+func (CredentialClass) EnumDescriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{1}
+}
+
+// Scope of use permitted for a credential
+type Scope int32
+
+const (
+	Scope_SCOPE_DEFAULT  Scope = 0 // May be presented any number of times.
+	Scope_SCOPE_ONE_TIME Scope = 1 // May be presented once to each server, e.g. for destructive operations.
+)
+
+// Enum value maps for Scope.
+var (
+	Scope_name = map[int32]string{
+		0: "SCOPE_DEFAULT",
+		1: "SCOPE_ONE_TIME",
+	}
+	Scope_value = map[string]int32{
+		"SCOPE_DEFAULT":  0,
+		"SCOPE_ONE_TIME": 1,
+	}
+)
+
+func (x Scope) Enum() *Scope {
+	p := new(Scope)
+	*p = x
+	return p
+}
+
+func (x Scope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Scope) Descriptor() protoreflect.EnumDescriptor {
+	return file_security_auth_proto_enumTypes[2].Descriptor()
+}
+
+func (Scope) Type() protoreflect.EnumType {
+	return &file_security_auth_proto_enumTypes[2]
+}
+
+func (x Scope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Scope.Descriptor instead.
+func (Scope) EnumDescriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{2}
+}
+
+// Hash algorithm used to compute a verifier
+type HashAlgorithm int32
+
+const (
+	HashAlgorithm_HASH_SHA512   HashAlgorithm = 0 // Default, and the algorithm used before it was recorded.
+	HashAlgorithm_HASH_SHA256   HashAlgorithm = 1
+	HashAlgorithm_HASH_SHA384   HashAlgorithm = 2
+	HashAlgorithm_HASH_SHA3_256 HashAlgorithm = 3
+	HashAlgorithm_HASH_SHA3_384 HashAlgorithm = 4
+	HashAlgorithm_HASH_SHA3_512 HashAlgorithm = 5
+)
+
+// Enum value maps for HashAlgorithm.
+var (
+	HashAlgorithm_name = map[int32]string{
+		0: "HASH_SHA512",
+		1: "HASH_SHA256",
+		2: "HASH_SHA384",
+		3: "HASH_SHA3_256",
+		4: "HASH_SHA3_384",
+		5: "HASH_SHA3_512",
+	}
+	HashAlgorithm_value = map[string]int32{
+		"HASH_SHA512":   0,
+		"HASH_SHA256":   1,
+		"HASH_SHA384":   2,
+		"HASH_SHA3_256": 3,
+		"HASH_SHA3_384": 4,
+		"HASH_SHA3_512": 5,
+	}
+)
+
+func (x HashAlgorithm) Enum() *HashAlgorithm {
+	p := new(HashAlgorithm)
+	*p = x
+	return p
+}
+
+func (x HashAlgorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HashAlgorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_security_auth_proto_enumTypes[3].Descriptor()
+}
+
+func (HashAlgorithm) Type() protoreflect.EnumType {
+	return &file_security_auth_proto_enumTypes[3]
+}
+
+func (x HashAlgorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HashAlgorithm.Descriptor instead.
+func (HashAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{3}
 }
 
 type Token struct {
@@ -78,14 +306,20 @@ type Token struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Flavor Flavor `protobuf:"varint,1,opt,name=flavor,proto3,enum=auth.Flavor" json:"flavor,omitempty"` // flavor of this authentication token
-	Data   []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`                       // packed structure of the specified flavor
+	Flavor    Flavor          `protobuf:"varint,1,opt,name=flavor,proto3,enum=auth.Flavor" json:"flavor,omitempty"`        // flavor of this authentication token
+	Data      []byte          `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`                              // packed structure of the specified flavor
+	Hash      HashAlgorithm   `protobuf:"varint,3,opt,name=hash,proto3,enum=auth.HashAlgorithm" json:"hash,omitempty"`     // hash algorithm used to compute a verifier
+	NotBefore uint64          `protobuf:"varint,4,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`  // time before which the credential is invalid (Unix seconds), if set
+	Expires   uint64          `protobuf:"varint,5,opt,name=expires,proto3" json:"expires,omitempty"`                       // time after which the credential is invalid (Unix seconds), if set
+	Nonce     []byte          `protobuf:"bytes,6,opt,name=nonce,proto3" json:"nonce,omitempty"`                            // nonce issued by the servers, if the credential was signed with one
+	System    string          `protobuf:"bytes,7,opt,name=system,proto3" json:"system,omitempty"`                          // name of the system the credential was signed for, if set
+	Class     CredentialClass `protobuf:"varint,8,opt,name=class,proto3,enum=auth.CredentialClass" json:"class,omitempty"` // class of the identity, if recorded by the agent
 }
 
 func (x *Token) Reset() {
 	*x = Token{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_auth_proto_msgTypes[0]
+		mi := &file_security_auth_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -98,7 +332,7 @@ func (x *Token) String() string {
 func (*Token) ProtoMessage() {}
 
 func (x *Token) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[0]
+	mi := &file_security_auth_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -111,7 +345,7 @@ func (x *Token) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Token.ProtoReflect.Descriptor instead.
 func (*Token) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{0}
+	return file_security_auth_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *Token) GetFlavor() Flavor {
@@ -128,24 +362,83 @@ func (x *Token) GetData() []byte {
 	return nil
 }
 
+func (x *Token) GetHash() HashAlgorithm {
+	if x != nil {
+		return x.Hash
+	}
+	return HashAlgorithm_HASH_SHA512
+}
+
+func (x *Token) GetNotBefore() uint64 {
+	if x != nil {
+		return x.NotBefore
+	}
+	return 0
+}
+
+func (x *Token) GetExpires() uint64 {
+	if x != nil {
+		return x.Expires
+	}
+	return 0
+}
+
+func (x *Token) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+func (x *Token) GetSystem() string {
+	if x != nil {
+		return x.System
+	}
+	return ""
+}
+
+func (x *Token) GetClass() CredentialClass {
+	if x != nil {
+		return x.Class
+	}
+	return CredentialClass_CLASS_UNSPECIFIED
+}
+
 // Token structure for AUTH_SYS flavor cred
 type Sys struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Stamp       uint64   `protobuf:"varint,1,opt,name=stamp,proto3" json:"stamp,omitempty"`            // timestamp
-	Machinename string   `protobuf:"bytes,2,opt,name=machinename,proto3" json:"machinename,omitempty"` // machine name
-	User        string   `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`               // user name
-	Group       string   `protobuf:"bytes,4,opt,name=group,proto3" json:"group,omitempty"`             // primary group name
-	Groups      []string `protobuf:"bytes,5,rep,name=groups,proto3" json:"groups,omitempty"`           // secondary group names
-	Secctx      string   `protobuf:"bytes,6,opt,name=secctx,proto3" json:"secctx,omitempty"`           // Additional field for MAC label
+	Stamp         uint64      `protobuf:"varint,1,opt,name=stamp,proto3" json:"stamp,omitempty"`                                     // timestamp
+	Machinename   string      `protobuf:"bytes,2,opt,name=machinename,proto3" json:"machinename,omitempty"`                          // machine name
+	User          string      `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`                                        // user name
+	Group         string      `protobuf:"bytes,4,opt,name=group,proto3" json:"group,omitempty"`                                      // primary group name
+	Groups        []string    `protobuf:"bytes,5,rep,name=groups,proto3" json:"groups,omitempty"`                                    // secondary group names
+	Secctx        string      `protobuf:"bytes,6,opt,name=secctx,proto3" json:"secctx,omitempty"`                                    // Additional field for MAC label
+	Scope         Scope       `protobuf:"varint,7,opt,name=scope,proto3,enum=auth.Scope" json:"scope,omitempty"`                     // permitted scope of use
+	Nonce         []byte      `protobuf:"bytes,8,opt,name=nonce,proto3" json:"nonce,omitempty"`                                      // makes each one-time token unique
+	GrantedScopes []string    `protobuf:"bytes,9,rep,name=granted_scopes,json=grantedScopes,proto3" json:"granted_scopes,omitempty"` // authorization scopes granted by the token issuer
+	Pools         []string    `protobuf:"bytes,10,rep,name=pools,proto3" json:"pools,omitempty"`                                     // pools the credential may be used with, if restricted
+	Guest         bool        `protobuf:"varint,11,opt,name=guest,proto3" json:"guest,omitempty"`                                    // identity is an unauthenticated guest
+	Machine       bool        `protobuf:"varint,12,opt,name=machine,proto3" json:"machine,omitempty"`                                // identity is a node rather than a user
+	JobId         string      `protobuf:"bytes,13,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`                        // workload manager job the credential was issued to
+	Sid           string      `protobuf:"bytes,14,opt,name=sid,proto3" json:"sid,omitempty"`                                         // Windows security identifier of the user
+	SecondFactor  bool        `protobuf:"varint,15,opt,name=second_factor,json=secondFactor,proto3" json:"second_factor,omitempty"`  // identity was confirmed with a second factor
+	Delegation    *Delegation `protobuf:"bytes,16,opt,name=delegation,proto3" json:"delegation,omitempty"`                           // delegator of the credential, if delegated
+	Proxy         *Proxy      `protobuf:"bytes,17,opt,name=proxy,proto3" json:"proxy,omitempty"`                                     // gateway the credential was requested through, if proxied
+	Cgroup        string      `protobuf:"bytes,18,opt,name=cgroup,proto3" json:"cgroup,omitempty"`                                   // cgroup of the requesting process, if recorded
+	ContainerId   string      `protobuf:"bytes,19,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`      // container of the requesting process, if recorded
+	Claims        []*Claim    `protobuf:"bytes,20,rep,name=claims,proto3" json:"claims,omitempty"`                                   // additional claims about the identity, for access policies
+	Pid           uint32      `protobuf:"varint,21,opt,name=pid,proto3" json:"pid,omitempty"`                                        // PID of the requesting process, if the credential is bound to it
+	ProcessStart  uint64      `protobuf:"varint,22,opt,name=process_start,json=processStart,proto3" json:"process_start,omitempty"`  // start time of the requesting process (clock ticks after boot), if bound
+	Identities    []*Identity `protobuf:"bytes,23,rep,name=identities,proto3" json:"identities,omitempty"`                           // identities of the user other than its Unix identity, as verified by the flavor
 }
 
 func (x *Sys) Reset() {
 	*x = Sys{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_auth_proto_msgTypes[1]
+		mi := &file_security_auth_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -158,7 +451,7 @@ func (x *Sys) String() string {
 func (*Sys) ProtoMessage() {}
 
 func (x *Sys) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[1]
+	mi := &file_security_auth_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -171,7 +464,7 @@ func (x *Sys) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Sys.ProtoReflect.Descriptor instead.
 func (*Sys) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{1}
+	return file_security_auth_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Sys) GetStamp() uint64 {
@@ -216,98 +509,153 @@ func (x *Sys) GetSecctx() string {
 	return ""
 }
 
-// Token and verifier are expected to have the same flavor type.
-type Credential struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *Sys) GetScope() Scope {
+	if x != nil {
+		return x.Scope
+	}
+	return Scope_SCOPE_DEFAULT
+}
 
-	Token    *Token `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`       // authentication token
-	Verifier *Token `protobuf:"bytes,2,opt,name=verifier,proto3" json:"verifier,omitempty"` // to verify integrity of the token
-	Origin   string `protobuf:"bytes,3,opt,name=origin,proto3" json:"origin,omitempty"`     // the agent that created this credential
+func (x *Sys) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
 }
 
-func (x *Credential) Reset() {
-	*x = Credential{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_auth_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Sys) GetGrantedScopes() []string {
+	if x != nil {
+		return x.GrantedScopes
 	}
+	return nil
 }
 
-func (x *Credential) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Sys) GetPools() []string {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
 }
 
-func (*Credential) ProtoMessage() {}
+func (x *Sys) GetGuest() bool {
+	if x != nil {
+		return x.Guest
+	}
+	return false
+}
 
-func (x *Credential) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Sys) GetMachine() bool {
+	if x != nil {
+		return x.Machine
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use Credential.ProtoReflect.Descriptor instead.
-func (*Credential) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{2}
+func (x *Sys) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
 }
 
-func (x *Credential) GetToken() *Token {
+func (x *Sys) GetSid() string {
 	if x != nil {
-		return x.Token
+		return x.Sid
+	}
+	return ""
+}
+
+func (x *Sys) GetSecondFactor() bool {
+	if x != nil {
+		return x.SecondFactor
+	}
+	return false
+}
+
+func (x *Sys) GetDelegation() *Delegation {
+	if x != nil {
+		return x.Delegation
 	}
 	return nil
 }
 
-func (x *Credential) GetVerifier() *Token {
+func (x *Sys) GetProxy() *Proxy {
 	if x != nil {
-		return x.Verifier
+		return x.Proxy
 	}
 	return nil
 }
 
-func (x *Credential) GetOrigin() string {
+func (x *Sys) GetCgroup() string {
 	if x != nil {
-		return x.Origin
+		return x.Cgroup
 	}
 	return ""
 }
 
-// GetCredResp represents the result of a request to fetch authentication
-// credentials.
-type GetCredResp struct {
+func (x *Sys) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *Sys) GetClaims() []*Claim {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
+func (x *Sys) GetPid() uint32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *Sys) GetProcessStart() uint64 {
+	if x != nil {
+		return x.ProcessStart
+	}
+	return 0
+}
+
+func (x *Sys) GetIdentities() []*Identity {
+	if x != nil {
+		return x.Identities
+	}
+	return nil
+}
+
+// Identity is an identity of the user of a type other than Unix, such as an
+// OIDC subject or SPIFFE ID, against which ACL entries may be written.
+type Identity struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status int32       `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"` // Status of the request
-	Cred   *Credential `protobuf:"bytes,2,opt,name=cred,proto3" json:"cred,omitempty"`      // Caller's authentication credential
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // type of the identity, e.g. "oidc"
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // name of the identity, e.g. "alice@idp.example"
 }
 
-func (x *GetCredResp) Reset() {
-	*x = GetCredResp{}
+func (x *Identity) Reset() {
+	*x = Identity{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_auth_proto_msgTypes[3]
+		mi := &file_security_auth_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetCredResp) String() string {
+func (x *Identity) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCredResp) ProtoMessage() {}
+func (*Identity) ProtoMessage() {}
 
-func (x *GetCredResp) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[3]
+func (x *Identity) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -318,52 +666,54 @@ func (x *GetCredResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCredResp.ProtoReflect.Descriptor instead.
-func (*GetCredResp) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use Identity.ProtoReflect.Descriptor instead.
+func (*Identity) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetCredResp) GetStatus() int32 {
+func (x *Identity) GetType() string {
 	if x != nil {
-		return x.Status
+		return x.Type
 	}
-	return 0
+	return ""
 }
 
-func (x *GetCredResp) GetCred() *Credential {
+func (x *Identity) GetName() string {
 	if x != nil {
-		return x.Cred
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-// ValidateCredReq represents a request to verify a set of authentication
-// credentials.
-type ValidateCredReq struct {
+// Claim is an opaque attribute of the identity, such as the job or container
+// the credential was requested from, against which access policies may be
+// written.
+type Claim struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Cred *Credential `protobuf:"bytes,1,opt,name=cred,proto3" json:"cred,omitempty"` // Credential to be validated
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`     // name of the claim
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"` // value of the claim
 }
 
-func (x *ValidateCredReq) Reset() {
-	*x = ValidateCredReq{}
+func (x *Claim) Reset() {
+	*x = Claim{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_auth_proto_msgTypes[4]
+		mi := &file_security_auth_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ValidateCredReq) String() string {
+func (x *Claim) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidateCredReq) ProtoMessage() {}
+func (*Claim) ProtoMessage() {}
 
-func (x *ValidateCredReq) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[4]
+func (x *Claim) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -374,46 +724,57 @@ func (x *ValidateCredReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidateCredReq.ProtoReflect.Descriptor instead.
-func (*ValidateCredReq) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Claim.ProtoReflect.Descriptor instead.
+func (*Claim) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *ValidateCredReq) GetCred() *Credential {
+func (x *Claim) GetKey() string {
 	if x != nil {
-		return x.Cred
+		return x.Key
 	}
-	return nil
+	return ""
 }
 
-// ValidateCredResp represents the result of a request to validate
-// authentication credentials.
-type ValidateCredResp struct {
+func (x *Claim) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// Token and verifier are expected to have the same flavor type.
+type Credential struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status int32  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"` // Status of the request
-	Token  *Token `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`    // Validated authentication token from the credential
+	Token         *Token       `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                                        // authentication token
+	Verifier      *Token       `protobuf:"bytes,2,opt,name=verifier,proto3" json:"verifier,omitempty"`                                  // to verify integrity of the token
+	Origin        string       `protobuf:"bytes,3,opt,name=origin,proto3" json:"origin,omitempty"`                                      // the agent that created this credential
+	KeyId         string       `protobuf:"bytes,4,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`                           // ID of the agent key which signed the verifier
+	Cosignature   *Token       `protobuf:"bytes,5,opt,name=cosignature,proto3" json:"cosignature,omitempty"`                            // signature of the token by a co-signing key, for privileged credentials
+	CosignerKeyId string       `protobuf:"bytes,6,opt,name=cosigner_key_id,json=cosignerKeyId,proto3" json:"cosigner_key_id,omitempty"` // ID of the co-signing key
+	Attestation   *Attestation `protobuf:"bytes,7,opt,name=attestation,proto3" json:"attestation,omitempty"`                            // issuer's signed assertion of the identity, for flavors asserted by an external issuer
 }
 
-func (x *ValidateCredResp) Reset() {
-	*x = ValidateCredResp{}
+func (x *Credential) Reset() {
+	*x = Credential{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_auth_proto_msgTypes[5]
+		mi := &file_security_auth_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ValidateCredResp) String() string {
+func (x *Credential) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidateCredResp) ProtoMessage() {}
+func (*Credential) ProtoMessage() {}
 
-func (x *ValidateCredResp) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[5]
+func (x *Credential) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -424,117 +785,2235 @@ func (x *ValidateCredResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidateCredResp.ProtoReflect.Descriptor instead.
-func (*ValidateCredResp) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Credential.ProtoReflect.Descriptor instead.
+func (*Credential) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ValidateCredResp) GetStatus() int32 {
+func (x *Credential) GetToken() *Token {
 	if x != nil {
-		return x.Status
+		return x.Token
 	}
-	return 0
+	return nil
 }
 
-func (x *ValidateCredResp) GetToken() *Token {
+func (x *Credential) GetVerifier() *Token {
 	if x != nil {
-		return x.Token
+		return x.Verifier
 	}
 	return nil
 }
 
-var File_auth_proto protoreflect.FileDescriptor
-
-var file_auth_proto_rawDesc = []byte{
-	0x0a, 0x0a, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x61, 0x75,
-	0x74, 0x68, 0x22, 0x41, 0x0a, 0x05, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x24, 0x0a, 0x06, 0x66,
-	0x6c, 0x61, 0x76, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x61, 0x75,
-	0x74, 0x68, 0x2e, 0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x52, 0x06, 0x66, 0x6c, 0x61, 0x76, 0x6f,
-	0x72, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x97, 0x01, 0x0a, 0x03, 0x53, 0x79, 0x73, 0x12, 0x14, 0x0a,
-	0x05, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e,
-	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f,
-	0x75, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12,
-	0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x63, 0x74,
-	0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x63, 0x74, 0x78, 0x22,
-	0x70, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x21, 0x0a,
-	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61,
-	0x75, 0x74, 0x68, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x12, 0x27, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
-	0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x72, 0x69,
-	0x67, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69,
-	0x6e, 0x22, 0x4b, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x43, 0x72, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70,
-	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x24, 0x0a, 0x04, 0x63, 0x72, 0x65, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x43, 0x72,
-	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x04, 0x63, 0x72, 0x65, 0x64, 0x22, 0x37,
-	0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x43, 0x72, 0x65, 0x64, 0x52, 0x65,
-	0x71, 0x12, 0x24, 0x0a, 0x04, 0x63, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x10, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
-	0x6c, 0x52, 0x04, 0x63, 0x72, 0x65, 0x64, 0x22, 0x4d, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69, 0x64,
-	0x61, 0x74, 0x65, 0x43, 0x72, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
-	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2a, 0x25, 0x0a, 0x06, 0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72,
-	0x12, 0x0d, 0x0a, 0x09, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12,
-	0x0c, 0x0a, 0x08, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x53, 0x59, 0x53, 0x10, 0x01, 0x42, 0x3b, 0x5a,
-	0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73,
-	0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f,
-	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79,
-	0x2f, 0x61, 0x75, 0x74, 0x68, 0x3b, 0x61, 0x75, 0x74, 0x68, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
-}
-
-var (
-	file_auth_proto_rawDescOnce sync.Once
-	file_auth_proto_rawDescData = file_auth_proto_rawDesc
+func (x *Credential) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *Credential) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *Credential) GetCosignature() *Token {
+	if x != nil {
+		return x.Cosignature
+	}
+	return nil
+}
+
+func (x *Credential) GetCosignerKeyId() string {
+	if x != nil {
+		return x.CosignerKeyId
+	}
+	return ""
+}
+
+func (x *Credential) GetAttestation() *Attestation {
+	if x != nil {
+		return x.Attestation
+	}
+	return nil
+}
+
+// Attestation is a statement of the identity in a credential, signed by the
+// issuer which asserted it, such as the access manager for AUTH_ACCMAN.
+type Attestation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Statement []byte   `protobuf:"bytes,1,opt,name=statement,proto3" json:"statement,omitempty"`                  // statement of the identity, as returned by the issuer
+	Signature []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`                  // signature of the statement by the issuer
+	CertChain [][]byte `protobuf:"bytes,3,rep,name=cert_chain,json=certChain,proto3" json:"cert_chain,omitempty"` // DER certificates of the signing key, leaf first
+}
+
+func (x *Attestation) Reset() {
+	*x = Attestation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Attestation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attestation) ProtoMessage() {}
+
+func (x *Attestation) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attestation.ProtoReflect.Descriptor instead.
+func (*Attestation) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Attestation) GetStatement() []byte {
+	if x != nil {
+		return x.Statement
+	}
+	return nil
+}
+
+func (x *Attestation) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *Attestation) GetCertChain() [][]byte {
+	if x != nil {
+		return x.CertChain
+	}
+	return nil
+}
+
+// Delegation records the delegator of an AUTH_DELEGATION credential, and the
+// restrictions placed on its use beyond those of the Sys token.
+type Delegation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Parent     *Credential `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`         // credential of the delegator
+	Containers []string    `protobuf:"bytes,2,rep,name=containers,proto3" json:"containers,omitempty"` // containers ("<pool>/<container>") the delegate may use, if restricted
+	Expires    uint64      `protobuf:"varint,3,opt,name=expires,proto3" json:"expires,omitempty"`      // time after which the credential is invalid (Unix seconds)
+	Delegate   string      `protobuf:"bytes,4,opt,name=delegate,proto3" json:"delegate,omitempty"`     // service acting on behalf of the delegator
+}
+
+func (x *Delegation) Reset() {
+	*x = Delegation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Delegation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Delegation) ProtoMessage() {}
+
+func (x *Delegation) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Delegation.ProtoReflect.Descriptor instead.
+func (*Delegation) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Delegation) GetParent() *Credential {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
+func (x *Delegation) GetContainers() []string {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+func (x *Delegation) GetExpires() uint64 {
+	if x != nil {
+		return x.Expires
+	}
+	return 0
+}
+
+func (x *Delegation) GetDelegate() string {
+	if x != nil {
+		return x.Delegate
+	}
+	return ""
+}
+
+// Proxy records the gateway through which a remote user's AUTH_PROXY
+// credential was requested.
+type Proxy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host       string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`                               // gateway host that requested the credential
+	ClientHost string `protobuf:"bytes,2,opt,name=client_host,json=clientHost,proto3" json:"client_host,omitempty"` // host of the remote user, as reported by the gateway
+}
+
+func (x *Proxy) Reset() {
+	*x = Proxy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Proxy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Proxy) ProtoMessage() {}
+
+func (x *Proxy) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Proxy.ProtoReflect.Descriptor instead.
+func (*Proxy) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Proxy) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *Proxy) GetClientHost() string {
+	if x != nil {
+		return x.ClientHost
+	}
+	return ""
+}
+
+type GetCredReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Flavor       Flavor `protobuf:"varint,1,opt,name=flavor,proto3,enum=auth.Flavor" json:"flavor,omitempty"`               // flavor of this request
+	Data         []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`                                     // data for authentication
+	Scope        Scope  `protobuf:"varint,3,opt,name=scope,proto3,enum=auth.Scope" json:"scope,omitempty"`                  // requested scope of the credential
+	RenewalToken string `protobuf:"bytes,4,opt,name=renewal_token,json=renewalToken,proto3" json:"renewal_token,omitempty"` // renews a credential previously issued with this token, instead of data
+	Sys          string `protobuf:"bytes,5,opt,name=sys,proto3" json:"sys,omitempty"`                                       // name of the system the credential is for, or empty for the agent's system
+	Tenant       string `protobuf:"bytes,6,opt,name=tenant,proto3" json:"tenant,omitempty"`                                 // tenant label selecting the agent's signing key, or empty for the default key
+}
+
+func (x *GetCredReq) Reset() {
+	*x = GetCredReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCredReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCredReq) ProtoMessage() {}
+
+func (x *GetCredReq) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCredReq.ProtoReflect.Descriptor instead.
+func (*GetCredReq) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetCredReq) GetFlavor() Flavor {
+	if x != nil {
+		return x.Flavor
+	}
+	return Flavor_AUTH_NONE
+}
+
+func (x *GetCredReq) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GetCredReq) GetScope() Scope {
+	if x != nil {
+		return x.Scope
+	}
+	return Scope_SCOPE_DEFAULT
+}
+
+func (x *GetCredReq) GetRenewalToken() string {
+	if x != nil {
+		return x.RenewalToken
+	}
+	return ""
+}
+
+func (x *GetCredReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *GetCredReq) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+// GetCredResp represents the result of a request to fetch authentication
+// credentials.
+type GetCredResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status       int32       `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`                                // Status of the request
+	Cred         *Credential `protobuf:"bytes,2,opt,name=cred,proto3" json:"cred,omitempty"`                                     // Caller's authentication credential
+	RenewalToken string      `protobuf:"bytes,3,opt,name=renewal_token,json=renewalToken,proto3" json:"renewal_token,omitempty"` // token with which to renew the credential, if the flavor supports it
+}
+
+func (x *GetCredResp) Reset() {
+	*x = GetCredResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCredResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCredResp) ProtoMessage() {}
+
+func (x *GetCredResp) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCredResp.ProtoReflect.Descriptor instead.
+func (*GetCredResp) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetCredResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *GetCredResp) GetCred() *Credential {
+	if x != nil {
+		return x.Cred
+	}
+	return nil
+}
+
+func (x *GetCredResp) GetRenewalToken() string {
+	if x != nil {
+		return x.RenewalToken
+	}
+	return ""
+}
+
+// GetValidFlavorsReq represents a request for the authentication flavors
+// allowed by a system. The request body may be empty.
+type GetValidFlavorsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"` // name of the system, or empty for the agent's system
+}
+
+func (x *GetValidFlavorsReq) Reset() {
+	*x = GetValidFlavorsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetValidFlavorsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetValidFlavorsReq) ProtoMessage() {}
+
+func (x *GetValidFlavorsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetValidFlavorsReq.ProtoReflect.Descriptor instead.
+func (*GetValidFlavorsReq) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetValidFlavorsReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+// GetValidFlavorsResp represents the result of a request for the
+// authentication flavors allowed by the system.
+type GetValidFlavorsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status           int32    `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`                                             // Status of the request
+	ValidAuthFlavors []Flavor `protobuf:"varint,2,rep,packed,name=validAuthFlavors,proto3,enum=auth.Flavor" json:"validAuthFlavors,omitempty"` // Auth flavors accepted by agent/server
+}
+
+func (x *GetValidFlavorsResp) Reset() {
+	*x = GetValidFlavorsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetValidFlavorsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetValidFlavorsResp) ProtoMessage() {}
+
+func (x *GetValidFlavorsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetValidFlavorsResp.ProtoReflect.Descriptor instead.
+func (*GetValidFlavorsResp) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetValidFlavorsResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *GetValidFlavorsResp) GetValidAuthFlavors() []Flavor {
+	if x != nil {
+		return x.ValidAuthFlavors
+	}
+	return nil
+}
+
+// ValidateCredReq represents a request to verify a set of authentication
+// credentials.
+type ValidateCredReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cred      *Credential `protobuf:"bytes,1,opt,name=cred,proto3" json:"cred,omitempty"`                             // Credential to be validated
+	Pool      string      `protobuf:"bytes,2,opt,name=pool,proto3" json:"pool,omitempty"`                             // UUID of the pool the credential is presented to, if any
+	PoolLabel string      `protobuf:"bytes,3,opt,name=pool_label,json=poolLabel,proto3" json:"pool_label,omitempty"`  // label of the pool the credential is presented to, if any
+	CheckOnly bool        `protobuf:"varint,4,opt,name=check_only,json=checkOnly,proto3" json:"check_only,omitempty"` // check the credential without using up its nonce or one-time use
+}
+
+func (x *ValidateCredReq) Reset() {
+	*x = ValidateCredReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidateCredReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateCredReq) ProtoMessage() {}
+
+func (x *ValidateCredReq) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateCredReq.ProtoReflect.Descriptor instead.
+func (*ValidateCredReq) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ValidateCredReq) GetCred() *Credential {
+	if x != nil {
+		return x.Cred
+	}
+	return nil
+}
+
+func (x *ValidateCredReq) GetPool() string {
+	if x != nil {
+		return x.Pool
+	}
+	return ""
+}
+
+func (x *ValidateCredReq) GetPoolLabel() string {
+	if x != nil {
+		return x.PoolLabel
+	}
+	return ""
+}
+
+func (x *ValidateCredReq) GetCheckOnly() bool {
+	if x != nil {
+		return x.CheckOnly
+	}
+	return false
+}
+
+// ValidateCredResp represents the result of a request to validate
+// authentication credentials.
+type ValidateCredResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status int32  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"` // Status of the request
+	Token  *Token `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`    // Validated authentication token from the credential
+}
+
+func (x *ValidateCredResp) Reset() {
+	*x = ValidateCredResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidateCredResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateCredResp) ProtoMessage() {}
+
+func (x *ValidateCredResp) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateCredResp.ProtoReflect.Descriptor instead.
+func (*ValidateCredResp) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ValidateCredResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ValidateCredResp) GetToken() *Token {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+// RevokeIssuerKeyReq represents a request to revoke all credentials signed by
+// an agent key.
+type RevokeIssuerKeyReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KeyId  string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"` // ID of the revoked signing key
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`            // Reason for revocation, for audit records
+}
+
+func (x *RevokeIssuerKeyReq) Reset() {
+	*x = RevokeIssuerKeyReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokeIssuerKeyReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeIssuerKeyReq) ProtoMessage() {}
+
+func (x *RevokeIssuerKeyReq) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeIssuerKeyReq.ProtoReflect.Descriptor instead.
+func (*RevokeIssuerKeyReq) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RevokeIssuerKeyReq) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *RevokeIssuerKeyReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// RevokeIssuerKeyResp represents the result of a request to revoke an agent
+// key.
+type RevokeIssuerKeyResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status int32  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"` // Status of the request
+	Purged uint32 `protobuf:"varint,2,opt,name=purged,proto3" json:"purged,omitempty"` // Number of cached credentials purged
+}
+
+func (x *RevokeIssuerKeyResp) Reset() {
+	*x = RevokeIssuerKeyResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokeIssuerKeyResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeIssuerKeyResp) ProtoMessage() {}
+
+func (x *RevokeIssuerKeyResp) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeIssuerKeyResp.ProtoReflect.Descriptor instead.
+func (*RevokeIssuerKeyResp) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RevokeIssuerKeyResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *RevokeIssuerKeyResp) GetPurged() uint32 {
+	if x != nil {
+		return x.Purged
+	}
+	return 0
+}
+
+// InvalidateCredReq represents a request by a client to invalidate its own
+// cached credential. The fields are those of the GetCredReq which obtained the
+// credential.
+type InvalidateCredReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Flavor Flavor `protobuf:"varint,1,opt,name=flavor,proto3,enum=auth.Flavor" json:"flavor,omitempty"` // flavor of the cached credential
+	Data   []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`                       // data for authentication
+	Sys    string `protobuf:"bytes,3,opt,name=sys,proto3" json:"sys,omitempty"`                         // name of the system the credential is for, or empty for the agent's system
+	Tenant string `protobuf:"bytes,4,opt,name=tenant,proto3" json:"tenant,omitempty"`                   // tenant label the credential was requested for, if any
+}
+
+func (x *InvalidateCredReq) Reset() {
+	*x = InvalidateCredReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvalidateCredReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateCredReq) ProtoMessage() {}
+
+func (x *InvalidateCredReq) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateCredReq.ProtoReflect.Descriptor instead.
+func (*InvalidateCredReq) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *InvalidateCredReq) GetFlavor() Flavor {
+	if x != nil {
+		return x.Flavor
+	}
+	return Flavor_AUTH_NONE
+}
+
+func (x *InvalidateCredReq) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *InvalidateCredReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *InvalidateCredReq) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+// InvalidateCredResp represents the result of a request to invalidate a cached
+// credential.
+type InvalidateCredResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status      int32 `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`           // Status of the request
+	Invalidated bool  `protobuf:"varint,2,opt,name=invalidated,proto3" json:"invalidated,omitempty"` // True if a cached credential was invalidated
+}
+
+func (x *InvalidateCredResp) Reset() {
+	*x = InvalidateCredResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvalidateCredResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateCredResp) ProtoMessage() {}
+
+func (x *InvalidateCredResp) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateCredResp.ProtoReflect.Descriptor instead.
+func (*InvalidateCredResp) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *InvalidateCredResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *InvalidateCredResp) GetInvalidated() bool {
+	if x != nil {
+		return x.Invalidated
+	}
+	return false
+}
+
+// CredCacheEntry describes a credential cached by the agent, without the
+// credential itself.
+type CredCacheEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Flavor    Flavor `protobuf:"varint,1,opt,name=flavor,proto3,enum=auth.Flavor" json:"flavor,omitempty"`       // flavor of the cached credential
+	KeyHash   string `protobuf:"bytes,2,opt,name=key_hash,json=keyHash,proto3" json:"key_hash,omitempty"`        // SHA-256 digest of the cache key
+	CreatedAt int64  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix time at which the credential was cached
+	ExpiresAt int64  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix time at which the cached credential expires
+	Hits      uint64 `protobuf:"varint,5,opt,name=hits,proto3" json:"hits,omitempty"`                            // Number of requests served from the cache
+}
+
+func (x *CredCacheEntry) Reset() {
+	*x = CredCacheEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CredCacheEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredCacheEntry) ProtoMessage() {}
+
+func (x *CredCacheEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredCacheEntry.ProtoReflect.Descriptor instead.
+func (*CredCacheEntry) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CredCacheEntry) GetFlavor() Flavor {
+	if x != nil {
+		return x.Flavor
+	}
+	return Flavor_AUTH_NONE
+}
+
+func (x *CredCacheEntry) GetKeyHash() string {
+	if x != nil {
+		return x.KeyHash
+	}
+	return ""
+}
+
+func (x *CredCacheEntry) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *CredCacheEntry) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *CredCacheEntry) GetHits() uint64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+// ListCredCacheResp represents the result of a request to list the credentials
+// cached by the agent.
+type ListCredCacheResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status  int32             `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`  // Status of the request
+	Entries []*CredCacheEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"` // Cached credentials
+}
+
+func (x *ListCredCacheResp) Reset() {
+	*x = ListCredCacheResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCredCacheResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCredCacheResp) ProtoMessage() {}
+
+func (x *ListCredCacheResp) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCredCacheResp.ProtoReflect.Descriptor instead.
+func (*ListCredCacheResp) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListCredCacheResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ListCredCacheResp) GetEntries() []*CredCacheEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// CredCacheEvent describes an event in the lifecycle of a credential cached by
+// the agent.
+type CredCacheEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seq     uint64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`                        // Sequence number of the event
+	Time    int64  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`                      // Unix time of the event, in milliseconds
+	Type    string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`                       // created, refreshed, expired, evicted or invalidated
+	Flavor  Flavor `protobuf:"varint,4,opt,name=flavor,proto3,enum=auth.Flavor" json:"flavor,omitempty"` // flavor of the cached credential
+	KeyHash string `protobuf:"bytes,5,opt,name=key_hash,json=keyHash,proto3" json:"key_hash,omitempty"`  // SHA-256 digest of the cache key
+	Owner   int64  `protobuf:"varint,6,opt,name=owner,proto3" json:"owner,omitempty"`                    // UID the credential was cached for, or -1 if unknown
+}
+
+func (x *CredCacheEvent) Reset() {
+	*x = CredCacheEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CredCacheEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredCacheEvent) ProtoMessage() {}
+
+func (x *CredCacheEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredCacheEvent.ProtoReflect.Descriptor instead.
+func (*CredCacheEvent) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CredCacheEvent) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *CredCacheEvent) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *CredCacheEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CredCacheEvent) GetFlavor() Flavor {
+	if x != nil {
+		return x.Flavor
+	}
+	return Flavor_AUTH_NONE
+}
+
+func (x *CredCacheEvent) GetKeyHash() string {
+	if x != nil {
+		return x.KeyHash
+	}
+	return ""
+}
+
+func (x *CredCacheEvent) GetOwner() int64 {
+	if x != nil {
+		return x.Owner
+	}
+	return 0
+}
+
+// CredCacheEventsReq represents a request for the credential cache events
+// which followed an event already seen.
+type CredCacheEventsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	After uint64 `protobuf:"varint,1,opt,name=after,proto3" json:"after,omitempty"` // Sequence number of the last event seen, or 0
+}
+
+func (x *CredCacheEventsReq) Reset() {
+	*x = CredCacheEventsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CredCacheEventsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredCacheEventsReq) ProtoMessage() {}
+
+func (x *CredCacheEventsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredCacheEventsReq.ProtoReflect.Descriptor instead.
+func (*CredCacheEventsReq) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CredCacheEventsReq) GetAfter() uint64 {
+	if x != nil {
+		return x.After
+	}
+	return 0
+}
+
+// CredCacheEventsResp represents the result of a request for credential cache
+// events.
+type CredCacheEventsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status int32             `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"` // Status of the request
+	Events []*CredCacheEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`  // Events following the requested one
+	Missed uint64            `protobuf:"varint,3,opt,name=missed,proto3" json:"missed,omitempty"` // Events no longer buffered by the agent
+	Latest uint64            `protobuf:"varint,4,opt,name=latest,proto3" json:"latest,omitempty"` // Sequence number of the latest event
+}
+
+func (x *CredCacheEventsResp) Reset() {
+	*x = CredCacheEventsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CredCacheEventsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredCacheEventsResp) ProtoMessage() {}
+
+func (x *CredCacheEventsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredCacheEventsResp.ProtoReflect.Descriptor instead.
+func (*CredCacheEventsResp) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CredCacheEventsResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *CredCacheEventsResp) GetEvents() []*CredCacheEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *CredCacheEventsResp) GetMissed() uint64 {
+	if x != nil {
+		return x.Missed
+	}
+	return 0
+}
+
+func (x *CredCacheEventsResp) GetLatest() uint64 {
+	if x != nil {
+		return x.Latest
+	}
+	return 0
+}
+
+// SnapshotCredCacheResp carries a snapshot of the credentials cached by the
+// agent, encrypted with the credential cache key, which can be restored into
+// another agent process using the same key.
+type SnapshotCredCacheResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   int32  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`    // Status of the request
+	Snapshot []byte `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"` // Encrypted snapshot of the cached credentials
+	Count    uint32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`      // Number of credentials in the snapshot
+}
+
+func (x *SnapshotCredCacheResp) Reset() {
+	*x = SnapshotCredCacheResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotCredCacheResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotCredCacheResp) ProtoMessage() {}
+
+func (x *SnapshotCredCacheResp) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotCredCacheResp.ProtoReflect.Descriptor instead.
+func (*SnapshotCredCacheResp) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SnapshotCredCacheResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *SnapshotCredCacheResp) GetSnapshot() []byte {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *SnapshotCredCacheResp) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// RestoreCredCacheReq represents a request to restore a snapshot of a
+// credential cache into the agent.
+type RestoreCredCacheReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Snapshot []byte `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"` // Encrypted snapshot taken by SnapshotCredCacheResp
+}
+
+func (x *RestoreCredCacheReq) Reset() {
+	*x = RestoreCredCacheReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreCredCacheReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreCredCacheReq) ProtoMessage() {}
+
+func (x *RestoreCredCacheReq) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreCredCacheReq.ProtoReflect.Descriptor instead.
+func (*RestoreCredCacheReq) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RestoreCredCacheReq) GetSnapshot() []byte {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+// RestoreCredCacheResp represents the result of a request to restore a
+// snapshot of a credential cache.
+type RestoreCredCacheResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   int32  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`     // Status of the request
+	Restored uint32 `protobuf:"varint,2,opt,name=restored,proto3" json:"restored,omitempty"` // Number of credentials restored
+}
+
+func (x *RestoreCredCacheResp) Reset() {
+	*x = RestoreCredCacheResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreCredCacheResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreCredCacheResp) ProtoMessage() {}
+
+func (x *RestoreCredCacheResp) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreCredCacheResp.ProtoReflect.Descriptor instead.
+func (*RestoreCredCacheResp) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RestoreCredCacheResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *RestoreCredCacheResp) GetRestored() uint32 {
+	if x != nil {
+		return x.Restored
+	}
+	return 0
+}
+
+// ChallengeResp carries a challenge issued by the agent, which the client
+// must sign with its key to obtain a credential of a proof-of-possession
+// flavor such as AUTH_SSH or AUTH_FIDO2.
+type ChallengeResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status    int32  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`      // Status of the request
+	Challenge []byte `protobuf:"bytes,2,opt,name=challenge,proto3" json:"challenge,omitempty"` // Opaque challenge to be signed
+}
+
+func (x *ChallengeResp) Reset() {
+	*x = ChallengeResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChallengeResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChallengeResp) ProtoMessage() {}
+
+func (x *ChallengeResp) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChallengeResp.ProtoReflect.Descriptor instead.
+func (*ChallengeResp) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ChallengeResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ChallengeResp) GetChallenge() []byte {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+// SSHAuthReq is the body of an AUTH_SSH credential request.
+type SSHAuthReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PublicKey []byte `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"` // Public key in SSH wire format
+	Challenge []byte `protobuf:"bytes,2,opt,name=challenge,proto3" json:"challenge,omitempty"`                  // Challenge issued by the agent
+	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`                  // SSH signature of the challenge
+}
+
+func (x *SSHAuthReq) Reset() {
+	*x = SSHAuthReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SSHAuthReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SSHAuthReq) ProtoMessage() {}
+
+func (x *SSHAuthReq) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SSHAuthReq.ProtoReflect.Descriptor instead.
+func (*SSHAuthReq) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SSHAuthReq) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *SSHAuthReq) GetChallenge() []byte {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+func (x *SSHAuthReq) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// FIDO2AuthReq is the body of an AUTH_FIDO2 credential request, carrying a
+// WebAuthn assertion over a challenge issued by the agent.
+type FIDO2AuthReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CredentialId      []byte `protobuf:"bytes,1,opt,name=credential_id,json=credentialId,proto3" json:"credential_id,omitempty"`                // ID of the registered credential used
+	ClientDataJson    []byte `protobuf:"bytes,2,opt,name=client_data_json,json=clientDataJson,proto3" json:"client_data_json,omitempty"`        // CollectedClientData, including the challenge
+	AuthenticatorData []byte `protobuf:"bytes,3,opt,name=authenticator_data,json=authenticatorData,proto3" json:"authenticator_data,omitempty"` // Authenticator data from the assertion
+	Signature         []byte `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`                                          // Signature over authenticator data and client data hash
+}
+
+func (x *FIDO2AuthReq) Reset() {
+	*x = FIDO2AuthReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FIDO2AuthReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FIDO2AuthReq) ProtoMessage() {}
+
+func (x *FIDO2AuthReq) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FIDO2AuthReq.ProtoReflect.Descriptor instead.
+func (*FIDO2AuthReq) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *FIDO2AuthReq) GetCredentialId() []byte {
+	if x != nil {
+		return x.CredentialId
+	}
+	return nil
+}
+
+func (x *FIDO2AuthReq) GetClientDataJson() []byte {
+	if x != nil {
+		return x.ClientDataJson
+	}
+	return nil
+}
+
+func (x *FIDO2AuthReq) GetAuthenticatorData() []byte {
+	if x != nil {
+		return x.AuthenticatorData
+	}
+	return nil
+}
+
+func (x *FIDO2AuthReq) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// PKCS11AuthReq is the body of an AUTH_PKCS11 credential request, carrying a
+// signature made with a key held on a smart card (e.g. a PIV authentication
+// key) over a challenge issued by the agent.
+type PKCS11AuthReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Certificate []byte `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"` // DER X.509 certificate of the card key
+	Challenge   []byte `protobuf:"bytes,2,opt,name=challenge,proto3" json:"challenge,omitempty"`     // Challenge issued by the agent
+	Signature   []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`     // Signature of the SHA-256 digest of the signed challenge
+}
+
+func (x *PKCS11AuthReq) Reset() {
+	*x = PKCS11AuthReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PKCS11AuthReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PKCS11AuthReq) ProtoMessage() {}
+
+func (x *PKCS11AuthReq) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PKCS11AuthReq.ProtoReflect.Descriptor instead.
+func (*PKCS11AuthReq) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PKCS11AuthReq) GetCertificate() []byte {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+func (x *PKCS11AuthReq) GetChallenge() []byte {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+func (x *PKCS11AuthReq) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// DelegationReq is the body of an AUTH_DELEGATION credential request, in which
+// the holder of a credential asks for a restricted credential to pass to a
+// service acting on their behalf.
+type DelegationReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Parent     *Credential `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`         // credential of the delegator
+	Pools      []string    `protobuf:"bytes,2,rep,name=pools,proto3" json:"pools,omitempty"`           // pools the delegate may use
+	Containers []string    `protobuf:"bytes,3,rep,name=containers,proto3" json:"containers,omitempty"` // containers ("<pool>/<container>") the delegate may use, if restricted
+	Lifetime   uint32      `protobuf:"varint,4,opt,name=lifetime,proto3" json:"lifetime,omitempty"`    // requested lifetime in seconds, or 0 for the maximum
+	Delegate   string      `protobuf:"bytes,5,opt,name=delegate,proto3" json:"delegate,omitempty"`     // service acting on behalf of the delegator
+}
+
+func (x *DelegationReq) Reset() {
+	*x = DelegationReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DelegationReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DelegationReq) ProtoMessage() {}
+
+func (x *DelegationReq) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DelegationReq.ProtoReflect.Descriptor instead.
+func (*DelegationReq) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DelegationReq) GetParent() *Credential {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
+func (x *DelegationReq) GetPools() []string {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
+func (x *DelegationReq) GetContainers() []string {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+func (x *DelegationReq) GetLifetime() uint32 {
+	if x != nil {
+		return x.Lifetime
+	}
+	return 0
+}
+
+func (x *DelegationReq) GetDelegate() string {
+	if x != nil {
+		return x.Delegate
+	}
+	return ""
+}
+
+// ProxyReq is the body of an AUTH_PROXY credential request, in which a
+// gateway asserts the identity of a remote user it is serving.
+type ProxyReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User       string   `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`                               // user name of the remote user
+	Group      string   `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`                             // primary group name of the remote user
+	Groups     []string `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`                           // secondary group names of the remote user
+	ClientHost string   `protobuf:"bytes,4,opt,name=client_host,json=clientHost,proto3" json:"client_host,omitempty"` // host of the remote user
+}
+
+func (x *ProxyReq) Reset() {
+	*x = ProxyReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_security_auth_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProxyReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProxyReq) ProtoMessage() {}
+
+func (x *ProxyReq) ProtoReflect() protoreflect.Message {
+	mi := &file_security_auth_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProxyReq.ProtoReflect.Descriptor instead.
+func (*ProxyReq) Descriptor() ([]byte, []int) {
+	return file_security_auth_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ProxyReq) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *ProxyReq) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *ProxyReq) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *ProxyReq) GetClientHost() string {
+	if x != nil {
+		return x.ClientHost
+	}
+	return ""
+}
+
+var File_security_auth_proto protoreflect.FileDescriptor
+
+var file_security_auth_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x61, 0x75, 0x74, 0x68, 0x22, 0xfe, 0x01, 0x0a, 0x05,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x24, 0x0a, 0x06, 0x66, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x46, 0x6c, 0x61,
+	0x76, 0x6f, 0x72, 0x52, 0x06, 0x66, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x27, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e,
+	0x61, 0x75, 0x74, 0x68, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x6f, 0x74, 0x5f,
+	0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x6f,
+	0x74, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12,
+	0x2b, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15,
+	0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x22, 0xa7, 0x05, 0x0a,
+	0x03, 0x53, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61,
+	0x63, 0x68, 0x69, 0x6e, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72,
+	0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x65, 0x63, 0x63, 0x74, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x65, 0x63, 0x63, 0x74, 0x78, 0x12, 0x21, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x53, 0x63, 0x6f,
+	0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e,
+	0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12,
+	0x25, 0x0a, 0x0e, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x65, 0x64,
+	0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x18,
+	0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x67, 0x75, 0x65, 0x73, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x67, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x12, 0x15, 0x0a, 0x06,
+	0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f,
+	0x62, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x73, 0x69, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x5f,
+	0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x30, 0x0a, 0x0a, 0x64, 0x65,
+	0x6c, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x05,
+	0x70, 0x72, 0x6f, 0x78, 0x79, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x75,
+	0x74, 0x68, 0x2e, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x06, 0x63, 0x6c,
+	0x61, 0x69, 0x6d, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x75, 0x74,
+	0x68, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x52, 0x06, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x12,
+	0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x70, 0x69,
+	0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x18, 0x16, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2e, 0x0a, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x18, 0x17, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x75, 0x74,
+	0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x32, 0x0a, 0x08, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x2f, 0x0a, 0x05, 0x43, 0x6c,
+	0x61, 0x69, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x93, 0x02, 0x0a, 0x0a,
+	0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x21, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x75, 0x74, 0x68,
+	0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x27, 0x0a,
+	0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x08, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x15,
+	0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x0b, 0x63, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x75, 0x74,
+	0x68, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x63, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72,
+	0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63,
+	0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x33, 0x0a, 0x0b,
+	0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x68, 0x0a, 0x0b, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x65, 0x72, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x09, 0x63, 0x65, 0x72, 0x74, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x22, 0x8c, 0x01, 0x0a, 0x0a,
+	0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x06, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x75, 0x74,
+	0x68, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x06, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x22, 0x3c, 0x0a, 0x05, 0x50, 0x72,
+	0x6f, 0x78, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x22, 0xb8, 0x01, 0x0a, 0x0a, 0x47, 0x65, 0x74,
+	0x43, 0x72, 0x65, 0x64, 0x52, 0x65, 0x71, 0x12, 0x24, 0x0a, 0x06, 0x66, 0x6c, 0x61, 0x76, 0x6f,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x46,
+	0x6c, 0x61, 0x76, 0x6f, 0x72, 0x52, 0x06, 0x66, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x21, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0b, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73,
+	0x63, 0x6f, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x6e, 0x65, 0x77, 0x61, 0x6c, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6e,
+	0x65, 0x77, 0x61, 0x6c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x74,
+	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e,
+	0x61, 0x6e, 0x74, 0x22, 0x70, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x43, 0x72, 0x65, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x24, 0x0a, 0x04, 0x63, 0x72,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e,
+	0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x04, 0x63, 0x72, 0x65, 0x64,
+	0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x6e, 0x65, 0x77, 0x61, 0x6c, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6e, 0x65, 0x77, 0x61, 0x6c,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x26, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73,
+	0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x22, 0x67, 0x0a,
+	0x13, 0x47, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x38, 0x0a, 0x10,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x75, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x46, 0x6c,
+	0x61, 0x76, 0x6f, 0x72, 0x52, 0x10, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x75, 0x74, 0x68, 0x46,
+	0x6c, 0x61, 0x76, 0x6f, 0x72, 0x73, 0x22, 0x89, 0x01, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x43, 0x72, 0x65, 0x64, 0x52, 0x65, 0x71, 0x12, 0x24, 0x0a, 0x04, 0x63, 0x72,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e,
+	0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x04, 0x63, 0x72, 0x65, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x6f, 0x6f, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x6f, 0x6f, 0x6c, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x6f, 0x6e, 0x6c,
+	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x4f, 0x6e,
+	0x6c, 0x79, 0x22, 0x4d, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x43, 0x72,
+	0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21,
+	0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e,
+	0x61, 0x75, 0x74, 0x68, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0x43, 0x0a, 0x12, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x49, 0x73, 0x73, 0x75, 0x65,
+	0x72, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x45, 0x0a, 0x13, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65,
+	0x49, 0x73, 0x73, 0x75, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x72, 0x67, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x70, 0x75, 0x72, 0x67, 0x65, 0x64, 0x22, 0x77, 0x0a,
+	0x11, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x43, 0x72, 0x65, 0x64, 0x52,
+	0x65, 0x71, 0x12, 0x24, 0x0a, 0x06, 0x66, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72,
+	0x52, 0x06, 0x66, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03,
+	0x73, 0x79, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x22, 0x4e, 0x0a, 0x12, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x43, 0x72, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x6e, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x64, 0x22, 0xa3, 0x01, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x64, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x24, 0x0a, 0x06, 0x66, 0x6c, 0x61,
+	0x76, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x61, 0x75, 0x74, 0x68,
+	0x2e, 0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x52, 0x06, 0x66, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x12,
+	0x19, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69, 0x74, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x68, 0x69, 0x74, 0x73, 0x22, 0x5b, 0x0a, 0x11,
+	0x4c, 0x69, 0x73, 0x74, 0x43, 0x72, 0x65, 0x64, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x65, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x75, 0x74,
+	0x68, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x43, 0x61, 0x63, 0x68, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0xa1, 0x01, 0x0a, 0x0e, 0x43, 0x72,
+	0x65, 0x64, 0x43, 0x61, 0x63, 0x68, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x24, 0x0a, 0x06, 0x66, 0x6c, 0x61, 0x76, 0x6f, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x46, 0x6c,
+	0x61, 0x76, 0x6f, 0x72, 0x52, 0x06, 0x66, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x12, 0x19, 0x0a, 0x08,
+	0x6b, 0x65, 0x79, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6b, 0x65, 0x79, 0x48, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0x2a, 0x0a,
+	0x12, 0x43, 0x72, 0x65, 0x64, 0x43, 0x61, 0x63, 0x68, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x22, 0x8b, 0x01, 0x0a, 0x13, 0x43, 0x72,
+	0x65, 0x64, 0x43, 0x61, 0x63, 0x68, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x75, 0x74, 0x68,
+	0x2e, 0x43, 0x72, 0x65, 0x64, 0x43, 0x61, 0x63, 0x68, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52,
+	0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x69, 0x73, 0x73, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x22, 0x61, 0x0a, 0x15, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x43, 0x72, 0x65, 0x64, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x31, 0x0a, 0x13, 0x52, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x72, 0x65, 0x64, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65,
+	0x71, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x4a, 0x0a,
+	0x14, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x72, 0x65, 0x64, 0x43, 0x61, 0x63, 0x68,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x08, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x22, 0x45, 0x0a, 0x0d, 0x43, 0x68, 0x61,
+	0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65,
+	0x22, 0x67, 0x0a, 0x0a, 0x53, 0x53, 0x48, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x71, 0x12, 0x1d,
+	0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a,
+	0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xaa, 0x01, 0x0a, 0x0c, 0x46, 0x49,
+	0x44, 0x4f, 0x32, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x71, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x72,
+	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x64, 0x12,
+	0x28, 0x0a, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6a,
+	0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x44, 0x61, 0x74, 0x61, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x61, 0x75, 0x74,
+	0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63,
+	0x61, 0x74, 0x6f, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x6d, 0x0a, 0x0d, 0x50, 0x4b, 0x43, 0x53, 0x31, 0x31,
+	0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x71, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x63, 0x65,
+	0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61,
+	0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x68,
+	0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xa7, 0x01, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x28, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x43,
+	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x05, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x69, 0x66, 0x65, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6c, 0x69, 0x66, 0x65, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x22,
+	0x6d, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x75,
+	0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x1f, 0x0a,
+	0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x2a, 0xa4,
+	0x03, 0x0a, 0x06, 0x46, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x55, 0x54,
+	0x48, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x55, 0x54, 0x48,
+	0x5f, 0x53, 0x59, 0x53, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x41,
+	0x43, 0x43, 0x4d, 0x41, 0x4e, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x55, 0x54, 0x48, 0x5f,
+	0x41, 0x5a, 0x55, 0x52, 0x45, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x55, 0x54, 0x48, 0x5f,
+	0x47, 0x43, 0x50, 0x10, 0x04, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x56, 0x41,
+	0x55, 0x4c, 0x54, 0x10, 0x05, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4f, 0x41,
+	0x55, 0x54, 0x48, 0x32, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x53,
+	0x53, 0x48, 0x10, 0x07, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x46, 0x49, 0x44,
+	0x4f, 0x32, 0x10, 0x08, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x53, 0x43, 0x49,
+	0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x53, 0x10, 0x09, 0x12, 0x11, 0x0a, 0x0d, 0x41, 0x55, 0x54, 0x48,
+	0x5f, 0x4d, 0x41, 0x43, 0x41, 0x52, 0x4f, 0x4f, 0x4e, 0x10, 0x0a, 0x12, 0x10, 0x0a, 0x0c, 0x41,
+	0x55, 0x54, 0x48, 0x5f, 0x42, 0x49, 0x53, 0x43, 0x55, 0x49, 0x54, 0x10, 0x0b, 0x12, 0x11, 0x0a,
+	0x0d, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4b, 0x45, 0x59, 0x53, 0x54, 0x4f, 0x4e, 0x45, 0x10, 0x0c,
+	0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x50, 0x4b, 0x43, 0x53, 0x31, 0x31, 0x10,
+	0x0d, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x41, 0x4e, 0x4f, 0x4e, 0x10, 0x0e,
+	0x12, 0x0d, 0x0a, 0x09, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x10, 0x0f, 0x12,
+	0x11, 0x0a, 0x0d, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x50, 0x52, 0x4f, 0x56, 0x49, 0x44, 0x45, 0x52,
+	0x10, 0x10, 0x12, 0x10, 0x0a, 0x0c, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4d, 0x41, 0x43, 0x48, 0x49,
+	0x4e, 0x45, 0x10, 0x11, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x53, 0x4c, 0x55,
+	0x52, 0x4d, 0x10, 0x12, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x57, 0x4c, 0x4d,
+	0x10, 0x13, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x41, 0x44, 0x10, 0x14, 0x12,
+	0x0d, 0x0a, 0x09, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x54, 0x4f, 0x54, 0x50, 0x10, 0x15, 0x12, 0x13,
+	0x0a, 0x0f, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f,
+	0x4e, 0x10, 0x16, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x50, 0x52, 0x4f, 0x58,
+	0x59, 0x10, 0x17, 0x12, 0x10, 0x0a, 0x0c, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x57, 0x45, 0x42, 0x48,
+	0x4f, 0x4f, 0x4b, 0x10, 0x18, 0x2a, 0x60, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x4c, 0x41, 0x53,
+	0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x0e, 0x0a, 0x0a, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x10, 0x01, 0x12,
+	0x11, 0x0a, 0x0d, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x4d, 0x41, 0x43, 0x48, 0x49, 0x4e, 0x45,
+	0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x44, 0x45, 0x4c, 0x45,
+	0x47, 0x41, 0x54, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x2e, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x70, 0x65,
+	0x12, 0x11, 0x0a, 0x0d, 0x53, 0x43, 0x4f, 0x50, 0x45, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c,
+	0x54, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x43, 0x4f, 0x50, 0x45, 0x5f, 0x4f, 0x4e, 0x45,
+	0x5f, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x01, 0x2a, 0x7b, 0x0a, 0x0d, 0x48, 0x61, 0x73, 0x68, 0x41,
+	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x0f, 0x0a, 0x0b, 0x48, 0x41, 0x53, 0x48,
+	0x5f, 0x53, 0x48, 0x41, 0x35, 0x31, 0x32, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x48, 0x41, 0x53,
+	0x48, 0x5f, 0x53, 0x48, 0x41, 0x32, 0x35, 0x36, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x48, 0x41,
+	0x53, 0x48, 0x5f, 0x53, 0x48, 0x41, 0x33, 0x38, 0x34, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x48,
+	0x41, 0x53, 0x48, 0x5f, 0x53, 0x48, 0x41, 0x33, 0x5f, 0x32, 0x35, 0x36, 0x10, 0x03, 0x12, 0x11,
+	0x0a, 0x0d, 0x48, 0x41, 0x53, 0x48, 0x5f, 0x53, 0x48, 0x41, 0x33, 0x5f, 0x33, 0x38, 0x34, 0x10,
+	0x04, 0x12, 0x11, 0x0a, 0x0d, 0x48, 0x41, 0x53, 0x48, 0x5f, 0x53, 0x48, 0x41, 0x33, 0x5f, 0x35,
+	0x31, 0x32, 0x10, 0x05, 0x42, 0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61,
+	0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x73,
+	0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x3b, 0x61, 0x75, 0x74,
+	0x68, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_security_auth_proto_rawDescOnce sync.Once
+	file_security_auth_proto_rawDescData = file_security_auth_proto_rawDesc
 )
 
-func file_auth_proto_rawDescGZIP() []byte {
-	file_auth_proto_rawDescOnce.Do(func() {
-		file_auth_proto_rawDescData = protoimpl.X.CompressGZIP(file_auth_proto_rawDescData)
+func file_security_auth_proto_rawDescGZIP() []byte {
+	file_security_auth_proto_rawDescOnce.Do(func() {
+		file_security_auth_proto_rawDescData = protoimpl.X.CompressGZIP(file_security_auth_proto_rawDescData)
 	})
-	return file_auth_proto_rawDescData
-}
-
-var file_auth_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_auth_proto_goTypes = []interface{}{
-	(Flavor)(0),              // 0: auth.Flavor
-	(*Token)(nil),            // 1: auth.Token
-	(*Sys)(nil),              // 2: auth.Sys
-	(*Credential)(nil),       // 3: auth.Credential
-	(*GetCredResp)(nil),      // 4: auth.GetCredResp
-	(*ValidateCredReq)(nil),  // 5: auth.ValidateCredReq
-	(*ValidateCredResp)(nil), // 6: auth.ValidateCredResp
-}
-var file_auth_proto_depIdxs = []int32{
-	0, // 0: auth.Token.flavor:type_name -> auth.Flavor
-	1, // 1: auth.Credential.token:type_name -> auth.Token
-	1, // 2: auth.Credential.verifier:type_name -> auth.Token
-	3, // 3: auth.GetCredResp.cred:type_name -> auth.Credential
-	3, // 4: auth.ValidateCredReq.cred:type_name -> auth.Credential
-	1, // 5: auth.ValidateCredResp.token:type_name -> auth.Token
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
-}
-
-func init() { file_auth_proto_init() }
-func file_auth_proto_init() {
-	if File_auth_proto != nil {
+	return file_security_auth_proto_rawDescData
+}
+
+var file_security_auth_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_security_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_security_auth_proto_goTypes = []interface{}{
+	(Flavor)(0),                   // 0: auth.Flavor
+	(CredentialClass)(0),          // 1: auth.CredentialClass
+	(Scope)(0),                    // 2: auth.Scope
+	(HashAlgorithm)(0),            // 3: auth.HashAlgorithm
+	(*Token)(nil),                 // 4: auth.Token
+	(*Sys)(nil),                   // 5: auth.Sys
+	(*Identity)(nil),              // 6: auth.Identity
+	(*Claim)(nil),                 // 7: auth.Claim
+	(*Credential)(nil),            // 8: auth.Credential
+	(*Attestation)(nil),           // 9: auth.Attestation
+	(*Delegation)(nil),            // 10: auth.Delegation
+	(*Proxy)(nil),                 // 11: auth.Proxy
+	(*GetCredReq)(nil),            // 12: auth.GetCredReq
+	(*GetCredResp)(nil),           // 13: auth.GetCredResp
+	(*GetValidFlavorsReq)(nil),    // 14: auth.GetValidFlavorsReq
+	(*GetValidFlavorsResp)(nil),   // 15: auth.GetValidFlavorsResp
+	(*ValidateCredReq)(nil),       // 16: auth.ValidateCredReq
+	(*ValidateCredResp)(nil),      // 17: auth.ValidateCredResp
+	(*RevokeIssuerKeyReq)(nil),    // 18: auth.RevokeIssuerKeyReq
+	(*RevokeIssuerKeyResp)(nil),   // 19: auth.RevokeIssuerKeyResp
+	(*InvalidateCredReq)(nil),     // 20: auth.InvalidateCredReq
+	(*InvalidateCredResp)(nil),    // 21: auth.InvalidateCredResp
+	(*CredCacheEntry)(nil),        // 22: auth.CredCacheEntry
+	(*ListCredCacheResp)(nil),     // 23: auth.ListCredCacheResp
+	(*CredCacheEvent)(nil),        // 24: auth.CredCacheEvent
+	(*CredCacheEventsReq)(nil),    // 25: auth.CredCacheEventsReq
+	(*CredCacheEventsResp)(nil),   // 26: auth.CredCacheEventsResp
+	(*SnapshotCredCacheResp)(nil), // 27: auth.SnapshotCredCacheResp
+	(*RestoreCredCacheReq)(nil),   // 28: auth.RestoreCredCacheReq
+	(*RestoreCredCacheResp)(nil),  // 29: auth.RestoreCredCacheResp
+	(*ChallengeResp)(nil),         // 30: auth.ChallengeResp
+	(*SSHAuthReq)(nil),            // 31: auth.SSHAuthReq
+	(*FIDO2AuthReq)(nil),          // 32: auth.FIDO2AuthReq
+	(*PKCS11AuthReq)(nil),         // 33: auth.PKCS11AuthReq
+	(*DelegationReq)(nil),         // 34: auth.DelegationReq
+	(*ProxyReq)(nil),              // 35: auth.ProxyReq
+}
+var file_security_auth_proto_depIdxs = []int32{
+	0,  // 0: auth.Token.flavor:type_name -> auth.Flavor
+	3,  // 1: auth.Token.hash:type_name -> auth.HashAlgorithm
+	1,  // 2: auth.Token.class:type_name -> auth.CredentialClass
+	2,  // 3: auth.Sys.scope:type_name -> auth.Scope
+	10, // 4: auth.Sys.delegation:type_name -> auth.Delegation
+	11, // 5: auth.Sys.proxy:type_name -> auth.Proxy
+	7,  // 6: auth.Sys.claims:type_name -> auth.Claim
+	6,  // 7: auth.Sys.identities:type_name -> auth.Identity
+	4,  // 8: auth.Credential.token:type_name -> auth.Token
+	4,  // 9: auth.Credential.verifier:type_name -> auth.Token
+	4,  // 10: auth.Credential.cosignature:type_name -> auth.Token
+	9,  // 11: auth.Credential.attestation:type_name -> auth.Attestation
+	8,  // 12: auth.Delegation.parent:type_name -> auth.Credential
+	0,  // 13: auth.GetCredReq.flavor:type_name -> auth.Flavor
+	2,  // 14: auth.GetCredReq.scope:type_name -> auth.Scope
+	8,  // 15: auth.GetCredResp.cred:type_name -> auth.Credential
+	0,  // 16: auth.GetValidFlavorsResp.validAuthFlavors:type_name -> auth.Flavor
+	8,  // 17: auth.ValidateCredReq.cred:type_name -> auth.Credential
+	4,  // 18: auth.ValidateCredResp.token:type_name -> auth.Token
+	0,  // 19: auth.InvalidateCredReq.flavor:type_name -> auth.Flavor
+	0,  // 20: auth.CredCacheEntry.flavor:type_name -> auth.Flavor
+	22, // 21: auth.ListCredCacheResp.entries:type_name -> auth.CredCacheEntry
+	0,  // 22: auth.CredCacheEvent.flavor:type_name -> auth.Flavor
+	24, // 23: auth.CredCacheEventsResp.events:type_name -> auth.CredCacheEvent
+	8,  // 24: auth.DelegationReq.parent:type_name -> auth.Credential
+	25, // [25:25] is the sub-list for method output_type
+	25, // [25:25] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_security_auth_proto_init() }
+func file_security_auth_proto_init() {
+	if File_security_auth_proto != nil {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_auth_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_security_auth_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Token); i {
 			case 0:
 				return &v.state
@@ -546,8 +3025,32 @@ func file_auth_proto_init() {
 				return nil
 			}
 		}
-		file_auth_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Sys); i {
+		file_security_auth_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Sys); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Identity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Claim); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -558,7 +3061,7 @@ func file_auth_proto_init() {
 				return nil
 			}
 		}
-		file_auth_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_security_auth_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Credential); i {
 			case 0:
 				return &v.state
@@ -570,7 +3073,55 @@ func file_auth_proto_init() {
 				return nil
 			}
 		}
-		file_auth_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_security_auth_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Attestation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Delegation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Proxy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCredReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetCredResp); i {
 			case 0:
 				return &v.state
@@ -582,7 +3133,31 @@ func file_auth_proto_init() {
 				return nil
 			}
 		}
-		file_auth_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_security_auth_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetValidFlavorsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetValidFlavorsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ValidateCredReq); i {
 			case 0:
 				return &v.state
@@ -594,7 +3169,7 @@ func file_auth_proto_init() {
 				return nil
 			}
 		}
-		file_auth_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_security_auth_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ValidateCredResp); i {
 			case 0:
 				return &v.state
@@ -606,24 +3181,240 @@ func file_auth_proto_init() {
 				return nil
 			}
 		}
+		file_security_auth_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeIssuerKeyReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeIssuerKeyResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InvalidateCredReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InvalidateCredResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CredCacheEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCredCacheResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CredCacheEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CredCacheEventsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CredCacheEventsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotCredCacheResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreCredCacheReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreCredCacheResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChallengeResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SSHAuthReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FIDO2AuthReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PKCS11AuthReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DelegationReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_security_auth_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProxyReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_auth_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   6,
+			RawDescriptor: file_security_auth_proto_rawDesc,
+			NumEnums:      4,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
-		GoTypes:           file_auth_proto_goTypes,
-		DependencyIndexes: file_auth_proto_depIdxs,
-		EnumInfos:         file_auth_proto_enumTypes,
-		MessageInfos:      file_auth_proto_msgTypes,
+		GoTypes:           file_security_auth_proto_goTypes,
+		DependencyIndexes: file_security_auth_proto_depIdxs,
+		EnumInfos:         file_security_auth_proto_enumTypes,
+		MessageInfos:      file_security_auth_proto_msgTypes,
 	}.Build()
-	File_auth_proto = out.File
-	file_auth_proto_rawDesc = nil
-	file_auth_proto_goTypes = nil
-	file_auth_proto_depIdxs = nil
+	File_security_auth_proto = out.File
+	file_security_auth_proto_rawDesc = nil
+	file_security_auth_proto_goTypes = nil
+	file_security_auth_proto_depIdxs = nil
 }
@@ -11,13 +11,14 @@ import (
 	"crypto"
 	"encoding/json"
 	"fmt"
-	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
-	"google.golang.org/protobuf/proto"
 
 	"github.com/daos-stack/daos/src/control/drpc"
 	"github.com/daos-stack/daos/src/control/logging"
@@ -30,16 +31,54 @@ type (
 	}
 
 	// AuthAccManCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_ACCMAN flavor.
+	// lastEndpoint and protocolVersion are the endpoint which last answered
+	// the request and the protocol version negotiated with it. If
+	// renewalToken is set, the credential is renewed with it rather than by
+	// validating the delegation credential. Each request is recorded by the
+	// auditor under a new correlationID.
 	AuthAccManCredentialRequest struct {
 		delegationCredential string
+		renewalToken         string
+		issuedRenewalToken   string
 		signingKey           crypto.PrivateKey
 		callerID             string
-		baseURL              string
+		endpoints            []string
+		retryInterval        time.Duration
+		retry                amRetryPolicy
+		health               *amHealth
+		versions             *amVersions
+		lastEndpoint         string
+		protocolVersion      int
+		transport            amTransport
+		cache                *amResponseCache
+		cacheLifetime        time.Duration
+		auditor              *amAuditor
+		correlationID        string
+	}
+
+	// amHealth tracks the access manager endpoints found to be unavailable,
+	// so that requests go straight to the next endpoint until they are retried.
+	amHealth struct {
+		sync.Mutex
+		downUntil map[string]time.Time
 	}
 
 	accManInfo struct {
 		Identity string   `json:"id"`
 		Roles    []string `json:"roles"`
+		// Subject is the opaque identifier by which the access manager
+		// revokes access, if it differs from the identity.
+		Subject string `json:"subject,omitempty"`
+		// RenewalToken may be used to renew the credential issued for
+		// the identity, without validating the delegation credential.
+		RenewalToken string `json:"renewal_token,omitempty"`
+		// Expires is the time after which the access manager's signed
+		// statement of the identity is no longer accepted by the
+		// servers (Unix seconds), if set.
+		Expires int64 `json:"expires,omitempty"`
+		// attestation is the signed statement of the identity, if the
+		// access manager signs its responses.
+		attestation *Attestation
 	}
 
 	amErr struct {
@@ -47,18 +86,141 @@ type (
 		Message      string `json:"message"`
 	}
 
+	// amResp is the response of the access manager. If the access
+	// manager signs its responses, the signature of the info and the
+	// certificate chain of the signing key are included.
 	amResp struct {
-		Error amErr  `json:"error"`
-		Info  string `json:"info"`
+		Error     amErr    `json:"error"`
+		Info      string   `json:"info"`
+		Signature []byte   `json:"signature,omitempty"`
+		CertChain [][]byte `json:"cert_chain,omitempty"`
 	}
 )
 
-func (r *AuthAccManCredentialRequest) request_am(ctx context.Context, apiPath string, method string, kv ...string) ([]byte, error) {
-	u, err := url.ParseRequestURI(r.baseURL)
-	if err != nil {
-		return nil, fmt.Errorf("check agent config to ensure AM url is correct (can't happen: %w)", err)
+const (
+	// DefaultAMRetryInterval is the time for which an unavailable access
+	// manager endpoint is skipped if no retry interval is configured.
+	DefaultAMRetryInterval = 30 * time.Second
+	// DefaultAMMaxConnections is the maximum number of connections to each
+	// access manager endpoint if no maximum is configured.
+	DefaultAMMaxConnections = 16
+	// DefaultAMIdleTimeout is the time for which an idle connection to an
+	// access manager endpoint is kept open if no timeout is configured.
+	DefaultAMIdleTimeout = 90 * time.Second
+
+	amKeepAlive    = 30 * time.Second
+	maxAMDrainSize = 64 << 10
+)
+
+var (
+	defaultAMHealth = newAMHealth()
+
+	amClientsMutex sync.Mutex
+	amClients      = make(map[amClientKey]*http.Client)
+)
+
+// amClientKey identifies the connection settings of an access manager client.
+// If socket is set, the client connects to the Unix socket at that path.
+type amClientKey struct {
+	maxConns    int
+	idleTimeout time.Duration
+	tls         security.AccessManagerTLSConfig
+	socket      string
+}
+
+func newAMClientKey(cfg *security.AccessManagerConfig) amClientKey {
+	key := amClientKey{maxConns: cfg.MaxConnections, idleTimeout: cfg.IdleTimeout, tls: cfg.TLS}
+	if key.maxConns == 0 {
+		key.maxConns = DefaultAMMaxConnections
+	}
+	if key.idleTimeout == 0 {
+		key.idleTimeout = DefaultAMIdleTimeout
+	}
+	return key
+}
+
+// getAMClient returns the shared HTTP client for the access manager, which
+// keeps a pool of connections to each endpoint alive between requests rather
+// than dialing for each one. If TLS is configured, the access manager is
+// reached using its certificates rather than the system trust store.
+func getAMClient(cfg *security.AccessManagerConfig) (*http.Client, error) {
+	return getAMClientForKey(newAMClientKey(cfg))
+}
+
+func getAMClientForKey(key amClientKey) (*http.Client, error) {
+	amClientsMutex.Lock()
+	defer amClientsMutex.Unlock()
+
+	if client, found := amClients[key]; found {
+		return client, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if key.tls.IsSet() {
+		tlsCfg, err := key.tls.TLSConfig()
+		if err != nil {
+			return nil, errors.Wrap(err, "loading access manager certificates")
+		}
+		transport.TLSClientConfig = tlsCfg
+	}
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: amKeepAlive,
+	}
+	transport.DialContext = dialer.DialContext
+	if key.socket != "" {
+		transport.Proxy = nil
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", key.socket)
+		}
+	}
+	transport.MaxConnsPerHost = key.maxConns
+	transport.MaxIdleConnsPerHost = key.maxConns
+	transport.IdleConnTimeout = key.idleTimeout
+	client := &http.Client{Transport: transport}
+	amClients[key] = client
+
+	return client, nil
+}
+
+func newAMHealth() *amHealth {
+	return &amHealth{downUntil: make(map[string]time.Time)}
+}
+
+// order returns the endpoints to try, in order of priority, with those which
+// are marked as unavailable moved to the end as a last resort.
+func (h *amHealth) order(endpoints []string, now time.Time) []string {
+	h.Lock()
+	defer h.Unlock()
+
+	var up, down []string
+	for _, endpoint := range endpoints {
+		if now.Before(h.downUntil[endpoint]) {
+			down = append(down, endpoint)
+			continue
+		}
+		up = append(up, endpoint)
 	}
-	u.Path = apiPath
+	return append(up, down...)
+}
+
+func (h *amHealth) markDown(endpoint string, until time.Time) {
+	h.Lock()
+	defer h.Unlock()
+	h.downUntil[endpoint] = until
+}
+
+func (h *amHealth) markUp(endpoint string) {
+	h.Lock()
+	defer h.Unlock()
+	delete(h.downUntil, endpoint)
+}
+
+// request_am sends the request to the access manager endpoints in order of
+// priority, failing over to the next if one is unavailable, and retries it
+// according to the retry policy. The protocol version is negotiated with each
+// endpoint before its first request.
+func (r *AuthAccManCredentialRequest) request_am(ctx context.Context, apiPath string, method string, kv ...string) (*amResp, error) {
 	params := url.Values{}
 	if len(kv)%2 != 0 {
 		return nil, fmt.Errorf("must have an even number of key/value pairs")
@@ -66,77 +228,220 @@ func (r *AuthAccManCredentialRequest) request_am(ctx context.Context, apiPath st
 	for i := 0; i < len(kv); i += 2 {
 		params.Set(kv[i], kv[i+1])
 	}
-
 	params.Set("caller_id", r.callerID)
-	u.RawQuery = params.Encode()
-
-	request, err := http.NewRequestWithContext(
-		ctx,
-		method,
-		u.String(),
-		http.NoBody,
-	)
-	if err != nil {
-		return nil, fmt.Errorf(`cannot create request for "%s": %w`, u.String(), err)
+
+	if len(r.endpoints) == 0 {
+		return nil, errors.New("no access manager endpoint is configured")
 	}
+	r.correlationID = newAMCorrelationID()
 
-	response, err := http.DefaultClient.Do(request)
-	if err != nil {
-		return nil, fmt.Errorf(`cannot access "%s": %w`, u.String(), err)
+	return r.retry.withRetry(ctx, func() (*amResp, error) {
+		return r.requestFailover(ctx, apiPath, method, params)
+	})
+}
+
+// requestFailover makes a single attempt at the request, trying each endpoint
+// in order of priority until one is available.
+func (r *AuthAccManCredentialRequest) requestFailover(ctx context.Context, apiPath, method string, params url.Values) (*amResp, error) {
+	var failures []string
+	for _, endpoint := range r.health.order(r.endpoints, time.Now()) {
+		resp, available, err := r.requestVersioned(ctx, endpoint, apiPath, method, params)
+		if available {
+			r.health.markUp(endpoint)
+			return resp, err
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		r.markDown(endpoint)
+		failures = append(failures, err.Error())
+	}
+	return nil, &amUnavailableError{failures: failures}
+}
+
+// markDown skips the endpoint until the retry interval has passed.
+func (r *AuthAccManCredentialRequest) markDown(endpoint string) {
+	retryInterval := r.retryInterval
+	if retryInterval == 0 {
+		retryInterval = DefaultAMRetryInterval
+	}
+	r.health.markDown(endpoint, time.Now().Add(retryInterval))
+	// The endpoint may have been upgraded by the time it is retried.
+	r.versions.forget(endpoint)
+}
+
+// probe checks that each access manager endpoint is reachable and speaks a
+// compatible protocol version, updating their health accordingly.
+func (r *AuthAccManCredentialRequest) probe(ctx context.Context) error {
+	if len(r.endpoints) == 0 {
+		return errors.New("no access manager endpoint is configured")
 	}
 
-	//goland:noinspection GoUnhandledErrorResult
-	defer response.Body.Close()
-	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf(`unexpected status code "%d"`, response.StatusCode)
+	var failures []string
+	for _, endpoint := range r.endpoints {
+		r.versions.forget(endpoint)
+		_, available, err := r.negotiateVersion(ctx, endpoint)
+		switch {
+		case !available:
+			r.markDown(endpoint)
+		case err == nil:
+			r.health.markUp(endpoint)
+			continue
+		}
+		failures = append(failures, err.Error())
 	}
-	responseBody, err := io.ReadAll(response.Body)
+	if len(failures) > 0 {
+		return errors.Errorf("%d of %d access manager endpoint(s) failed: %s",
+			len(failures), len(r.endpoints), strings.Join(failures, "; "))
+	}
+
+	return nil
+}
+
+// requestVersioned negotiates the protocol version with the endpoint and sends
+// the request, unless the negotiated version does not support it.
+func (r *AuthAccManCredentialRequest) requestVersioned(ctx context.Context, endpoint, apiPath, method string, params url.Values) (*amResp, bool, error) {
+	version, available, err := r.negotiateVersion(ctx, endpoint)
 	if err != nil {
-		return nil, fmt.Errorf(`error reading response from %s: %w`, u.String(), err)
+		return nil, available, err
+	}
+	if version < amPathVersions[apiPath] {
+		return nil, true, errors.Wrapf(errAMUnsupported, "%s requires protocol version %d, %q negotiated %d",
+			apiPath, amPathVersions[apiPath], endpoint, version)
 	}
-	return responseBody, err
+	r.lastEndpoint = endpoint
+	r.protocolVersion = version
+
+	return r.transport.send(ctx, endpoint, &amRequest{
+		path:          apiPath,
+		method:        method,
+		params:        params,
+		version:       version,
+		correlationID: r.correlationID,
+	})
 }
 
-func (r *AuthAccManCredentialRequest) validateAndParseDelegationCredential() (*accManInfo, error) {
-	var amResp amResp
-	var authInfo accManInfo
+// validateAndParseDelegationCredential asks the access manager to validate the
+// delegation credential, unless a response for it has been cached.
+func (r *AuthAccManCredentialRequest) validateAndParseDelegationCredential(ctx context.Context) (*accManInfo, error) {
+	var generation uint64
+	if r.cacheLifetime > 0 {
+		start := time.Now()
+		if info := r.cache.get(r.delegationCredential, start); info != nil {
+			r.correlationID = newAMCorrelationID()
+			r.audit(ctx, "/validate", r.delegationCredential, start, info, true, nil)
+			return info, nil
+		}
+		generation = r.cache.currentGeneration()
+	}
 
-	resp, err := r.request_am(context.Background(), "/validate", http.MethodGet, "credential", r.delegationCredential)
+	authInfo, err := r.requestInfo(ctx, "/validate", "credential", r.delegationCredential)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to validate the provided credential - check AM server and agent configuration")
 	}
 
-	err = json.Unmarshal(resp, &amResp)
+	if r.cacheLifetime > 0 {
+		r.cache.add(r.delegationCredential, authInfo, time.Now().Add(r.cacheLifetime), generation)
+	}
+	return authInfo, nil
+}
+
+// renewCredential asks the access manager to renew the credential issued with
+// the renewal token. Renewals are not cached, as the access manager may issue
+// a new renewal token with each.
+func (r *AuthAccManCredentialRequest) renewCredential(ctx context.Context) (*accManInfo, error) {
+	authInfo, err := r.requestInfo(ctx, "/renew", "renewal_token", r.renewalToken)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, "failed to renew the credential")
 	}
 
+	return authInfo, nil
+}
+
+// requestInfo sends a request for the credential to the access manager and
+// parses the identity in its response. The outcome is recorded in the audit
+// log.
+func (r *AuthAccManCredentialRequest) requestInfo(ctx context.Context, apiPath, param, credential string) (authInfo *accManInfo, err error) {
+	start := time.Now()
+	defer func() {
+		r.audit(ctx, apiPath, credential, start, authInfo, false, err)
+	}()
+
+	amResp, err := r.request_am(ctx, apiPath, http.MethodGet, param, credential)
+	if err != nil {
+		return nil, err
+	}
 	if amResp.Error.ResponseCode != 0 {
-		return nil, errors.New(amResp.Error.Message)
+		return nil, &amDeniedError{message: amResp.Error.Message}
 	}
 
-	err = json.Unmarshal([]byte(amResp.Info), &authInfo)
-	if err != nil {
+	authInfo = new(accManInfo)
+	if err := json.Unmarshal([]byte(amResp.Info), authInfo); err != nil {
 		return nil, err
 	}
+	if len(amResp.Signature) > 0 {
+		authInfo.attestation = &Attestation{
+			Statement: []byte(amResp.Info),
+			Signature: amResp.Signature,
+			CertChain: amResp.CertChain,
+		}
+	}
 
-	return &authInfo, nil
+	return authInfo, nil
 }
 
 // func (req *AuthAccManCredentialRequest) AllocCredentialRequest() CredentialRequest {
 // 	return &AuthAccManCredentialRequest{}
 // }
 
+// newAccManRequest returns a request to the configured access manager.
+// Responses are cached for the credential cache lifetime.
+func newAccManRequest(secCfg *security.CredentialConfig) (*AuthAccManCredentialRequest, error) {
+	transport, err := getAMTransport(&secCfg.AMConfig)
+	if err != nil {
+		return nil, err
+	}
+	auditor, err := getAMAuditor(&secCfg.AMConfig.Audit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthAccManCredentialRequest{
+		callerID:      secCfg.AMConfig.CallerID,
+		endpoints:     secCfg.AMConfig.Endpoints(),
+		retryInterval: secCfg.AMConfig.RetryInterval,
+		retry:         newAMRetryPolicy(&secCfg.AMConfig),
+		health:        defaultAMHealth,
+		versions:      defaultAMVersions,
+		transport:     transport,
+		cache:         defaultAMCache,
+		cacheLifetime: secCfg.CacheExpiration,
+		auditor:       auditor,
+	}, nil
+}
+
 func (fac *AuthAccManCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
-	req := &AuthAccManCredentialRequest{}
+	req, err := newAccManRequest(secCfg)
+	if err != nil {
+		return &AuthAccManCredentialRequest{}, err
+	}
 	req.delegationCredential = string(reqBody)
 	req.signingKey = key
-	req.callerID = secCfg.AMConfig.CallerID
-	req.baseURL = secCfg.AMConfig.BaseURL
 
 	return req, nil
 }
 
+// ProbeBackend checks that each configured access manager endpoint is
+// reachable and speaks a compatible protocol version.
+func (fac *AuthAccManCredentialFactory) ProbeBackend(ctx context.Context, secCfg *security.CredentialConfig) error {
+	req, err := newAccManRequest(secCfg)
+	if err != nil {
+		return err
+	}
+
+	return req.probe(ctx)
+}
+
 func GetAccManFlavor() Flavor {
 	return Flavor_AUTH_ACCMAN
 }
@@ -149,79 +454,94 @@ func (req *AuthAccManCredentialRequest) GetAuthFlavor() Flavor {
 	return GetAccManFlavor()
 }
 
-// GetSignedCredential returns a credential based on the provided domain info and
-// signing key.
-func (req *AuthAccManCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
-	// DAOS is built with UNIX groups in mind. We cannot use the AM-style URLS as they contain colons, and do not end with an '@'.
-	// This function helps parse out the path.
-	seperate := func(url string) (string, string, error) {
-		var split_url = strings.Split(url, "://")
-		if len(split_url) != 2 {
-			return "", "", errors.New("Access manager url does not follow expected format 'foo://bar/...'")
-		}
-		split_url[0] += "://"
-		split_url[1] += "@am"
-		return split_url[0], split_url[1], nil
+// splitAMURL splits an access manager URL into its scheme and its path,
+// which is qualified as an access manager principal. DAOS is built with UNIX
+// groups in mind. We cannot use the AM-style URLS as they contain colons, and
+// do not end with an '@'.
+func splitAMURL(url string) (string, string, error) {
+	var split_url = strings.Split(url, "://")
+	if len(split_url) != 2 {
+		return "", "", errors.New("Access manager url does not follow expected format 'foo://bar/...'")
 	}
+	split_url[0] += "://"
+	split_url[1] += "@am"
+	return split_url[0], split_url[1], nil
+}
 
-	authInfo, err := req.validateAndParseDelegationCredential()
-	if err != nil {
-		return nil, err
-	}
-
-	machineName, identity, err := seperate(authInfo.Identity)
-
+// sys returns the Sys token for the identity.
+func (info *accManInfo) sys() (*Sys, error) {
+	machineName, identity, err := splitAMURL(info.Identity)
 	if err != nil {
 		return nil, err
 	}
 
-	groups := make([]string, len(authInfo.Roles))
+	groups := make([]string, len(info.Roles))
 	for i := range groups {
-		var _, role, err = seperate(authInfo.Roles[i])
+		var _, role, err = splitAMURL(info.Roles[i])
 		if err != nil {
 			return nil, err
 		}
 		groups[i] = role
 	}
 
-	// Craft AuthToken
-	sys := Sys{
+	return &Sys{
 		Stamp:       0,
 		Machinename: machineName,
 		User:        identity,
 		Group:       "",
 		Groups:      groups,
-		Secctx:      ""}
+		Secctx:      ""}, nil
+}
 
-	// Marshal our AuthSys token into a byte array
-	tokenBytes, err := proto.Marshal(&sys)
+// GetSignedCredential returns a credential based on the provided domain info and
+// signing key. If the access manager signed its statement of the identity, it
+// is attached to the credential so that the servers can verify it.
+func (req *AuthAccManCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	var authInfo *accManInfo
+	var err error
+	if req.renewalToken != "" {
+		authInfo, err = req.renewCredential(ctx)
+	} else {
+		authInfo, err = req.validateAndParseDelegationCredential(ctx)
+	}
 	if err != nil {
-		return nil, errors.Wrap(err, "Unable to marshal AuthSys token")
+		return nil, err
 	}
+	req.issuedRenewalToken = authInfo.RenewalToken
 
-	token := Token{
-		Flavor: req.GetAuthFlavor(),
-		Data:   tokenBytes}
-
-	verifier, err := VerifierFromToken(req.signingKey, &token)
+	// Craft AuthToken
+	sys, err := authInfo.sys()
 	if err != nil {
-		return nil, errors.WithMessage(err, "Unable to generate verifier")
+		return nil, err
 	}
 
-	verifierToken := Token{
-		Flavor: req.GetAuthFlavor(),
-		Data:   verifier}
-
-	credential := Credential{
-		Token:    &token,
-		Verifier: &verifierToken,
-		Origin:   "agent"}
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+	credential.Attestation = authInfo.attestation
 
-	logging.FromContext(ctx).Tracef("%s: successfully signed credential", authInfo)
+	logging.FromContext(ctx).Tracef("%s: successfully signed credential (access manager protocol version %d)",
+		authInfo, req.protocolVersion)
 
-	return &credential, nil
+	return credential, nil
 }
 
 func (req *AuthAccManCredentialRequest) GetKey() string {
+	if req.renewalToken != "" {
+		return "renewal:" + req.renewalToken
+	}
 	return req.delegationCredential
 }
+
+// UseRenewalToken renews the credential issued with the token instead of
+// validating the delegation credential.
+func (req *AuthAccManCredentialRequest) UseRenewalToken(token string) {
+	req.renewalToken = token
+}
+
+// RenewalToken returns the token issued by the access manager with which the
+// signed credential can be renewed, if it supports renewal.
+func (req *AuthAccManCredentialRequest) RenewalToken() string {
+	return req.issuedRenewalToken
+}
@@ -0,0 +1,393 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// testAM is a fake access manager which counts the requests and connections
+// it receives and responds with the configured status.
+type testAM struct {
+	url      string
+	status   int
+	versions *amVersionRange
+	hits     atomic.Int32
+	conns    atomic.Int32
+	// version and correlationID are the protocol version and correlation ID
+	// of the last validation request.
+	version       atomic.Value
+	correlationID atomic.Value
+}
+
+func startTestAM(t *testing.T, status int) *testAM {
+	t.Helper()
+	return startTestAMVersions(t, status, &amVersionRange{Min: amMinProtocolVersion, Max: amMaxProtocolVersion})
+}
+
+// startTestAMVersions starts a fake access manager supporting the range of
+// protocol versions, or predating versioning if it is nil.
+func startTestAMVersions(t *testing.T, status int, versions *amVersionRange) *testAM {
+	t.Helper()
+	am := &testAM{status: status, versions: versions}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		am.hits.Add(1)
+		if am.status != http.StatusOK {
+			w.WriteHeader(am.status)
+			return
+		}
+		if r.URL.Query().Get("caller_id") != "daos" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/version":
+			if am.versions == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			info, _ := json.Marshal(am.versions)
+			json.NewEncoder(w).Encode(&amResp{Info: string(info)})
+		case "/validate":
+			am.version.Store(r.Header.Get(amVersionHeader))
+			am.correlationID.Store(r.Header.Get(amCorrelationHeader))
+			info, _ := json.Marshal(&accManInfo{
+				Identity:     "am://" + r.URL.Query().Get("credential"),
+				Roles:        []string{"am://admins"},
+				RenewalToken: "renew-" + r.URL.Query().Get("credential"),
+			})
+			json.NewEncoder(w).Encode(&amResp{Info: string(info)})
+		case "/renew":
+			am.version.Store(r.Header.Get(amVersionHeader))
+			token := r.URL.Query().Get("renewal_token")
+			if !strings.HasPrefix(token, "renew-") {
+				json.NewEncoder(w).Encode(&amResp{Error: amErr{ResponseCode: 1, Message: "invalid renewal token"}})
+				return
+			}
+			info, _ := json.Marshal(&accManInfo{
+				Identity:     "am://" + strings.TrimPrefix(token, "renew-"),
+				Roles:        []string{"am://admins"},
+				RenewalToken: token + "+",
+			})
+			json.NewEncoder(w).Encode(&amResp{Info: string(info)})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			am.conns.Add(1)
+		}
+	}
+	srv.Start()
+	t.Cleanup(srv.Close)
+	am.url = srv.URL
+	return am
+}
+
+// unreachableAMURL returns the URL of a server which has been shut down.
+func unreachableAMURL(t *testing.T) string {
+	t.Helper()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	return srv.URL
+}
+
+func TestAuth_AuthAccManCredentialRequest_Failover(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		primaryStatus  int
+		unreachable    bool
+		expErr         error
+		expPrimaryHits int32
+		expSecondHits  int32
+		expPrimaryDown bool
+	}{
+		"primary available": {
+			primaryStatus:  http.StatusOK,
+			expPrimaryHits: 2,
+		},
+		"primary unreachable": {
+			unreachable:    true,
+			expSecondHits:  2,
+			expPrimaryDown: true,
+		},
+		"primary server error": {
+			primaryStatus:  http.StatusServiceUnavailable,
+			expPrimaryHits: 1,
+			expSecondHits:  2,
+			expPrimaryDown: true,
+		},
+		"primary client error": {
+			primaryStatus:  http.StatusForbidden,
+			expErr:         errors.New(`unexpected status code "403"`),
+			expPrimaryHits: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			primary := startTestAM(t, tc.primaryStatus)
+			primaryURL := primary.url
+			if tc.unreachable {
+				primaryURL = unreachableAMURL(t)
+			}
+			secondary := startTestAM(t, http.StatusOK)
+
+			req := &AuthAccManCredentialRequest{
+				delegationCredential: "jdoe",
+				signingKey:           agentKey,
+				callerID:             "daos",
+				endpoints:            []string{primaryURL, secondary.url},
+				health:               newAMHealth(),
+				versions:             newAMVersions(),
+				transport:            &amRESTTransport{client: http.DefaultClient},
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expPrimaryHits, primary.hits.Load(), "unexpected primary requests")
+			test.AssertEqual(t, tc.expSecondHits, secondary.hits.Load(), "unexpected secondary requests")
+			order := req.health.order(req.endpoints, time.Now())
+			test.AssertEqual(t, tc.expPrimaryDown, order[0] != primaryURL, "unexpected primary health")
+			if tc.expErr != nil {
+				return
+			}
+
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, "jdoe@am", sys.User, "unexpected user")
+		})
+	}
+}
+
+func TestAuth_AuthAccManCredentialRequest_AllUnavailable(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	failing := startTestAM(t, http.StatusBadGateway)
+	req := &AuthAccManCredentialRequest{
+		delegationCredential: "jdoe",
+		callerID:             "daos",
+		endpoints:            []string{unreachableAMURL(t), failing.url},
+		health:               newAMHealth(),
+		versions:             newAMVersions(),
+		transport:            &amRESTTransport{client: http.DefaultClient},
+	}
+
+	_, err := req.GetSignedCredential(log, test.Context(t))
+	test.CmpErr(t, errors.New("no access manager endpoint is available"), err)
+
+	// Endpoints marked as unavailable are still tried as a last resort.
+	_, err = req.GetSignedCredential(log, test.Context(t))
+	test.CmpErr(t, errors.New("no access manager endpoint is available"), err)
+	test.AssertEqual(t, int32(2), failing.hits.Load(), "unavailable endpoint not retried")
+}
+
+func TestAuth_amHealth(t *testing.T) {
+	health := newAMHealth()
+	endpoints := []string{"http://am1", "http://am2", "http://am3"}
+	now := time.Now()
+
+	test.CmpAny(t, "initial order", endpoints, health.order(endpoints, now))
+
+	health.markDown("http://am1", now.Add(time.Minute))
+	test.CmpAny(t, "primary down", []string{"http://am2", "http://am3", "http://am1"}, health.order(endpoints, now))
+	test.CmpAny(t, "primary retried", endpoints, health.order(endpoints, now.Add(2*time.Minute)))
+
+	health.markUp("http://am1")
+	test.CmpAny(t, "primary up", endpoints, health.order(endpoints, now))
+}
+
+func TestAuth_getAMClient(t *testing.T) {
+	cfg := &security.AccessManagerConfig{MaxConnections: 4}
+	client, err := getAMClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shared, _ := getAMClient(cfg)
+	test.AssertTrue(t, client == shared, "client not shared")
+	other, _ := getAMClient(&security.AccessManagerConfig{})
+	test.AssertTrue(t, client != other, "client shared between settings")
+
+	_, err = getAMClient(&security.AccessManagerConfig{
+		TLS: security.AccessManagerTLSConfig{
+			CACert: filepath.Join(t.TempDir(), "missing.crt"),
+			Cert:   "agent.crt",
+			Key:    "agent.key",
+		},
+	})
+	test.CmpErr(t, errors.New("loading access manager certificates"), err)
+
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	// Only the first request to an available endpoint negotiates the version.
+	for status, expHits := range map[int]int32{http.StatusOK: 4, http.StatusForbidden: 3} {
+		am := startTestAM(t, status)
+		req := &AuthAccManCredentialRequest{
+			delegationCredential: "jdoe",
+			callerID:             "daos",
+			endpoints:            []string{am.url},
+			health:               newAMHealth(),
+			versions:             newAMVersions(),
+			transport:            &amRESTTransport{client: client},
+		}
+		for i := 0; i < 3; i++ {
+			req.GetSignedCredential(log, test.Context(t))
+		}
+		test.AssertEqual(t, expHits, am.hits.Load(), "unexpected requests")
+		test.AssertEqual(t, int32(1), am.conns.Load(), "connection not reused")
+	}
+}
+
+func TestAuth_AuthAccManCredentialRequest_TLS(t *testing.T) {
+	siteCA := newTestCA(t, "site-ca")
+	otherCA := newTestCA(t, "other-ca")
+
+	dir := t.TempDir()
+	certPath, keyPath := writeTestKeyPair(t, siteCA, dir, "am", &x509.Certificate{
+		DNSNames:    []string{"am.example.com"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(siteCA.cert)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/validate" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		info, _ := json.Marshal(&accManInfo{Identity: "am://" + r.URL.Query().Get("credential")})
+		json.NewEncoder(w).Encode(&amResp{Info: string(info)})
+	}))
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MaxVersion:   tls.VersionTLS12,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	for name, tc := range map[string]struct {
+		clientCA   *testCA
+		serverName string
+		minVersion string
+		expErr     error
+	}{
+		"trusted": {
+			serverName: "am.example.com",
+		},
+		"server name mismatch": {
+			expErr: errors.New("no access manager endpoint is available"),
+		},
+		"agent certificate not trusted": {
+			clientCA:   otherCA,
+			serverName: "am.example.com",
+			expErr:     errors.New("no access manager endpoint is available"),
+		},
+		"minimum version not supported": {
+			serverName: "am.example.com",
+			minVersion: "1.3",
+			expErr:     errors.New("no access manager endpoint is available"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			clientCA := tc.clientCA
+			if clientCA == nil {
+				clientCA = siteCA
+			}
+			dir := t.TempDir()
+			certPath, keyPath := writeTestKeyPair(t, clientCA, dir, "agent", &x509.Certificate{
+				ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			})
+			client, err := getAMClient(&security.AccessManagerConfig{
+				TLS: security.AccessManagerTLSConfig{
+					CACert:     writeTestCACert(t, siteCA, dir),
+					Cert:       certPath,
+					Key:        keyPath,
+					ServerName: tc.serverName,
+					MinVersion: tc.minVersion,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := &AuthAccManCredentialRequest{
+				delegationCredential: "jdoe",
+				callerID:             "daos",
+				endpoints:            []string{srv.URL},
+				health:               newAMHealth(),
+				versions:             newAMVersions(),
+				transport:            &amRESTTransport{client: client},
+			}
+			info, err := req.validateAndParseDelegationCredential(test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertEqual(t, "am://jdoe", info.Identity, "unexpected identity")
+		})
+	}
+}
+
+func TestAuth_AuthAccManCredentialRequest_probe(t *testing.T) {
+	available := startTestAM(t, http.StatusOK)
+	unreachable := unreachableAMURL(t)
+	req := &AuthAccManCredentialRequest{
+		callerID:  "daos",
+		endpoints: []string{unreachable, available.url},
+		health:    newAMHealth(),
+		versions:  newAMVersions(),
+		transport: &amRESTTransport{client: http.DefaultClient},
+	}
+
+	err := req.probe(test.Context(t))
+	test.CmpErr(t, errors.New("1 of 2 access manager endpoint(s) failed"), err)
+	test.AssertEqual(t, int32(1), available.hits.Load(), "unexpected requests")
+	test.CmpAny(t, "endpoint order", []string{available.url, unreachable}, req.health.order(req.endpoints, time.Now()))
+	_, negotiated := req.versions.get(available.url)
+	test.AssertTrue(t, negotiated, "version not negotiated with available endpoint")
+
+	// Each probe repeats the handshake, in case the endpoint has changed.
+	req.endpoints = []string{available.url}
+	if err := req.probe(test.Context(t)); err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, int32(2), available.hits.Load(), "handshake not repeated")
+}
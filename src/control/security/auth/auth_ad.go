@@ -0,0 +1,280 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"encoding/binary"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// DefaultADTimeout is the time allowed for SSSD to resolve the client's
+// identity if no timeout is configured.
+const DefaultADTimeout = 10 * time.Second
+
+const (
+	sssdService       = "org.freedesktop.sssd.infopipe"
+	sssdPath          = "/org/freedesktop/sssd/infopipe"
+	sssdUsersPath     = sssdPath + "/Users"
+	sssdGroupsPath    = sssdPath + "/Groups"
+	sssdUsersIface    = sssdService + ".Users"
+	sssdUserIface     = sssdUsersIface + ".User"
+	sssdGroupsIface   = sssdService + ".Groups"
+	sssdGroupIface    = sssdGroupsIface + ".Group"
+	dbusPropertyIface = "org.freedesktop.DBus.Properties"
+
+	// sssdSIDAttr is the user attribute holding the SID in string form.
+	sssdSIDAttr = "objectSIDString"
+)
+
+type (
+	// AuthADCredentialFactory is a factory interface for AuthADCredentialRequests.
+	AuthADCredentialFactory struct {
+	}
+
+	// AuthADCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_AD flavor.
+	AuthADCredentialRequest struct {
+		uid        uint32
+		secctx     string
+		signingKey crypto.PrivateKey
+		busSocket  string
+		timeout    time.Duration
+		caseFold   security.CaseFoldPolicy
+		container  *processContainer
+		process    *processBinding
+	}
+
+	// sssdIdentity is a user as resolved by SSSD.
+	sssdIdentity struct {
+		User   string
+		Group  string
+		Groups []string
+		SID    string
+	}
+)
+
+func (fac *AuthADCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthADCredentialRequest{}
+
+	if secCfg == nil || !secCfg.ADConfig.Enabled {
+		return req, drpc.NewFailureWithMessage("agent is not configured for Active Directory authentication")
+	}
+
+	info, err := sessionDomainInfo(log, session)
+	if err != nil {
+		return req, err
+	}
+	container, err := clientContainer(&secCfg.ContainerConfig, info.Pid())
+	if err != nil {
+		return req, err
+	}
+	process, err := clientProcess(secCfg.ProcessBinding, info.Pid())
+	if err != nil {
+		return req, err
+	}
+
+	req.uid = info.Uid()
+	req.secctx = info.Ctx()
+	req.signingKey = key
+	req.busSocket = secCfg.ADConfig.BusSocket
+	req.timeout = secCfg.ADConfig.Timeout
+	req.caseFold = secCfg.PrincipalCaseFold
+	req.container = container
+	req.process = process
+
+	return req, nil
+}
+
+func GetADFlavor() Flavor {
+	return Flavor_AUTH_AD
+}
+
+func (fac AuthADCredentialFactory) GetAuthFlavor() Flavor {
+	return GetADFlavor()
+}
+
+func (req *AuthADCredentialRequest) GetAuthFlavor() Flavor {
+	return GetADFlavor()
+}
+
+// variantValue returns the value of a property or attribute, which must be of
+// the given type.
+func variantValue[T any](values map[interface{}]interface{}, name string) (T, error) {
+	var zero T
+	v, found := values[name]
+	if !found {
+		return zero, errors.Errorf("SSSD did not return %s", name)
+	}
+	if variant, ok := v.(dbusVariant); ok {
+		v = variant.Value
+	}
+	value, ok := v.(T)
+	if !ok {
+		return zero, errors.Errorf("SSSD returned %s of unexpected type %T", name, v)
+	}
+	return value, nil
+}
+
+// sssdCall calls an SSSD InfoPipe method, which returns a single value.
+func sssdCall(ctx context.Context, conn *dbusConn, path, iface, member, sig string, args ...interface{}) (interface{}, error) {
+	reply, err := conn.Call(ctx, sssdService, path, iface, member, sig, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) != 1 {
+		return nil, errors.Errorf("%s.%s returned %d values", iface, member, len(reply))
+	}
+	return reply[0], nil
+}
+
+// sssdLookup resolves the user with the given uid through the SSSD InfoPipe.
+func sssdLookup(ctx context.Context, conn *dbusConn, uid uint32) (*sssdIdentity, error) {
+	reply, err := sssdCall(ctx, conn, sssdUsersPath, sssdUsersIface, "FindByID", "u", uid)
+	if err != nil {
+		return nil, err
+	}
+	userPath, ok := reply.(string)
+	if !ok {
+		return nil, errors.New("SSSD returned an invalid user path")
+	}
+
+	reply, err = sssdCall(ctx, conn, userPath, dbusPropertyIface, "GetAll", "s", sssdUserIface)
+	if err != nil {
+		return nil, err
+	}
+	props, ok := reply.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.New("SSSD returned invalid user properties")
+	}
+	id := &sssdIdentity{}
+	if id.User, err = variantValue[string](props, "name"); err != nil {
+		return nil, err
+	}
+	gid, err := variantValue[uint32](props, "gidNumber")
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err = sssdCall(ctx, conn, sssdGroupsPath, sssdGroupsIface, "FindByID", "u", gid)
+	if err != nil {
+		return nil, err
+	}
+	groupPath, ok := reply.(string)
+	if !ok {
+		return nil, errors.New("SSSD returned an invalid group path")
+	}
+	reply, err = sssdCall(ctx, conn, groupPath, dbusPropertyIface, "Get", "ss", sssdGroupIface, "name")
+	if err != nil {
+		return nil, err
+	}
+	if name, ok := reply.(dbusVariant); ok {
+		id.Group, _ = name.Value.(string)
+	}
+	if id.Group == "" {
+		return nil, errors.Errorf("SSSD returned no name for group %d", gid)
+	}
+
+	reply, err = sssdCall(ctx, conn, sssdPath, sssdService, "GetUserGroups", "s", id.User)
+	if err != nil {
+		return nil, err
+	}
+	groups, ok := reply.([]interface{})
+	if !ok {
+		return nil, errors.New("SSSD returned invalid user groups")
+	}
+	for _, g := range groups {
+		// The primary group is already in the credential.
+		if name, ok := g.(string); ok && name != id.Group {
+			id.Groups = append(id.Groups, name)
+		}
+	}
+
+	reply, err = sssdCall(ctx, conn, sssdPath, sssdService, "GetUserAttr", "sas", id.User, []string{sssdSIDAttr})
+	if err != nil {
+		return nil, err
+	}
+	attrs, ok := reply.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.New("SSSD returned invalid user attributes")
+	}
+	if _, found := attrs[sssdSIDAttr]; !found {
+		return nil, errors.Errorf("user %q has no SID; not an Active Directory user", id.User)
+	}
+	sids, err := variantValue[[]interface{}](attrs, sssdSIDAttr)
+	if err != nil {
+		return nil, err
+	}
+	if len(sids) != 1 {
+		return nil, errors.Errorf("SSSD returned %d SIDs for user %q", len(sids), id.User)
+	}
+	if id.SID, ok = sids[0].(string); !ok || id.SID == "" {
+		return nil, errors.Errorf("SSSD returned an invalid SID for user %q", id.User)
+	}
+
+	return id, nil
+}
+
+// GetSignedCredential resolves the client's user and groups through SSSD and
+// returns a credential for them carrying the user's SID.
+func (req *AuthADCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	timeout := req.timeout
+	if timeout == 0 {
+		timeout = DefaultADTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	socket := req.busSocket
+	if socket == "" {
+		socket = DefaultSystemBusAddress
+	}
+	conn, err := dialDBus(ctx, socket)
+	if err != nil {
+		return nil, errors.Wrap(err, "connecting to SSSD")
+	}
+	defer conn.Close()
+
+	id, err := sssdLookup(ctx, conn, req.uid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolving uid %d through SSSD", req.uid)
+	}
+
+	sys, err := assertedIdentitySys(req.caseFold, id.User, id.Group, id.Groups)
+	if err != nil {
+		return nil, errors.Wrap(err, "SSSD")
+	}
+	sys.Sid = id.SID
+	sys.Secctx = req.secctx
+	req.container.apply(sys)
+	req.process.apply(sys)
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("uid %d: successfully signed credential for %s (%s)",
+		req.uid, sys.User, sys.Sid)
+	return credential, nil
+}
+
+// GetKey returns a cache key for the request, which is bound to the
+// requesting uid and MAC label, and its cgroup and process if recorded.
+func (req *AuthADCredentialRequest) GetKey() string {
+	data := binary.BigEndian.AppendUint32(nil, req.uid)
+	data = append(data, req.secctx...)
+	data = append(data, req.container.key()...)
+	data = append(data, req.process.key()...)
+	return tokenCacheKey(req.GetAuthFlavor(), string(data))
+}
@@ -0,0 +1,213 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+type fakeSSSDUser struct {
+	name   string
+	gid    uint32
+	groups []string
+	sids   []string
+}
+
+// fakeSSSD returns a fake bus handler implementing the parts of the SSSD
+// InfoPipe used by the AUTH_AD flavor.
+func fakeSSSD(users map[uint32]fakeSSSDUser, groups map[uint32]string) func(*dbusMessage) fakeDBusReply {
+	notFound := fakeDBusReply{
+		Sig:     "s",
+		Body:    []interface{}{"No such user or group"},
+		ErrName: "org.freedesktop.sssd.Error.NotFound",
+	}
+	byName := func(name string) (fakeSSSDUser, bool) {
+		for _, u := range users {
+			if u.name == name {
+				return u, true
+			}
+		}
+		return fakeSSSDUser{}, false
+	}
+	str := func(v string) dbusVariant { return dbusVariant{Sig: "s", Value: v} }
+
+	return func(call *dbusMessage) fakeDBusReply {
+		switch {
+		case call.Interface == sssdUsersIface && call.Member == "FindByID":
+			if _, found := users[call.Body[0].(uint32)]; !found {
+				return notFound
+			}
+			return fakeDBusReply{Sig: "o", Body: []interface{}{fmt.Sprintf("%s/%d", sssdUsersPath, call.Body[0])}}
+		case call.Interface == sssdGroupsIface && call.Member == "FindByID":
+			if _, found := groups[call.Body[0].(uint32)]; !found {
+				return notFound
+			}
+			return fakeDBusReply{Sig: "o", Body: []interface{}{fmt.Sprintf("%s/%d", sssdGroupsPath, call.Body[0])}}
+		case call.Member == "GetAll" && call.Body[0] == sssdUserIface:
+			var uid uint32
+			fmt.Sscanf(strings.TrimPrefix(call.Path, sssdUsersPath+"/"), "%d", &uid)
+			u := users[uid]
+			return fakeDBusReply{Sig: "a{sv}", Body: []interface{}{map[interface{}]interface{}{
+				"name":      str(u.name),
+				"uidNumber": dbusVariant{Sig: "u", Value: uid},
+				"gidNumber": dbusVariant{Sig: "u", Value: u.gid},
+			}}}
+		case call.Member == "Get" && call.Body[0] == sssdGroupIface:
+			var gid uint32
+			fmt.Sscanf(strings.TrimPrefix(call.Path, sssdGroupsPath+"/"), "%d", &gid)
+			return fakeDBusReply{Sig: "v", Body: []interface{}{str(groups[gid])}}
+		case call.Member == "GetUserGroups":
+			u, found := byName(call.Body[0].(string))
+			if !found {
+				return notFound
+			}
+			return fakeDBusReply{Sig: "as", Body: []interface{}{u.groups}}
+		case call.Member == "GetUserAttr":
+			u, found := byName(call.Body[0].(string))
+			if !found {
+				return notFound
+			}
+			attrs := map[interface{}]interface{}{}
+			if u.sids != nil {
+				attrs[sssdSIDAttr] = dbusVariant{Sig: "as", Value: u.sids}
+			}
+			return fakeDBusReply{Sig: "a{sv}", Body: []interface{}{attrs}}
+		}
+		return fakeDBusReply{
+			Sig:     "s",
+			Body:    []interface{}{call.Member},
+			ErrName: "org.freedesktop.DBus.Error.UnknownMethod",
+		}
+	}
+}
+
+func TestAuth_AuthADCredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bus := startFakeDBus(t, fakeSSSD(
+		map[uint32]fakeSSSDUser{
+			1000: {
+				name:   "jdoe@ad.example.com",
+				gid:    5000,
+				groups: []string{"domain users@ad.example.com", "hpc@ad.example.com"},
+				sids:   []string{"S-1-5-21-1004336348-1177238915-682003330-1104"},
+			},
+			1001: {
+				name:   "localuser",
+				gid:    1001,
+				groups: []string{"localuser"},
+			},
+			1002: {
+				name:   "broken@ad.example.com",
+				gid:    5000,
+				groups: []string{"domain users@ad.example.com"},
+				sids:   []string{},
+			},
+		},
+		map[uint32]string{
+			1001: "localuser",
+			5000: "domain users@ad.example.com",
+		},
+	))
+
+	for name, tc := range map[string]struct {
+		uid       uint32
+		busSocket string
+		expSys    *Sys
+		expErr    error
+	}{
+		"no bus": {
+			uid:       1000,
+			busSocket: filepath.Join(t.TempDir(), "missing"),
+			expErr:    errors.New("connecting to SSSD"),
+		},
+		"unknown uid": {
+			uid:    2000,
+			expErr: errors.New("sssd.Error.NotFound"),
+		},
+		"not an AD user": {
+			uid:    1001,
+			expErr: errors.New("not an Active Directory user"),
+		},
+		"no SID": {
+			uid:    1002,
+			expErr: errors.New("returned 0 SIDs"),
+		},
+		"success": {
+			uid: 1000,
+			expSys: &Sys{
+				User:   "jdoe@ad.example.com",
+				Group:  "domain users@ad.example.com",
+				Groups: []string{"hpc@ad.example.com"},
+				Sid:    "S-1-5-21-1004336348-1177238915-682003330-1104",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthADCredentialRequest{
+				uid:        tc.uid,
+				signingKey: agentKey,
+				busSocket:  bus,
+			}
+			if tc.busSocket != "" {
+				req.busSocket = tc.busSocket
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_AD, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expSys.User, sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expSys.Group, sys.Group, "unexpected group")
+			test.CmpAny(t, "groups", tc.expSys.Groups, sys.Groups)
+			test.AssertEqual(t, tc.expSys.Sid, sys.Sid, "unexpected SID")
+		})
+	}
+}
+
+func TestAuth_AuthADCredentialRequest_GetKey(t *testing.T) {
+	req := func(uid uint32) *AuthADCredentialRequest {
+		return &AuthADCredentialRequest{uid: uid}
+	}
+
+	test.AssertEqual(t, req(1000).GetKey(), req(1000).GetKey(), "same request, different keys")
+	test.AssertTrue(t, req(1000).GetKey() != req(1001).GetKey(), "key not bound to uid")
+
+	inContainer := req(1000)
+	inContainer.container = &processContainer{cgroup: "/system.slice/docker-0a1b.scope"}
+	test.AssertTrue(t, req(1000).GetKey() != inContainer.GetKey(), "key not bound to cgroup")
+
+	labelled := req(1000)
+	labelled.secctx = "staff_u:staff_r:staff_t:s3"
+	test.AssertTrue(t, req(1000).GetKey() != labelled.GetKey(), "key not bound to MAC label")
+}
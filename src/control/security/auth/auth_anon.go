@@ -0,0 +1,114 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+type (
+	// AuthAnonCredentialFactory is a factory interface for AuthAnonCredentialRequests.
+	AuthAnonCredentialFactory struct {
+	}
+
+	// AuthAnonCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_ANON flavor.
+	AuthAnonCredentialRequest struct {
+		signingKey crypto.PrivateKey
+		user       string
+		group      string
+	}
+)
+
+func (fac *AuthAnonCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthAnonCredentialRequest{}
+
+	if secCfg == nil || secCfg.AnonConfig.User == "" {
+		return req, drpc.NewFailureWithMessage("agent is not configured for anonymous access")
+	}
+
+	req.signingKey = key
+	req.user = secCfg.AnonConfig.User
+	req.group = secCfg.AnonConfig.Group
+
+	return req, nil
+}
+
+func GetAnonFlavor() Flavor {
+	return Flavor_AUTH_ANON
+}
+
+func (fac AuthAnonCredentialFactory) GetAuthFlavor() Flavor {
+	return GetAnonFlavor()
+}
+
+func (req *AuthAnonCredentialRequest) GetAuthFlavor() Flavor {
+	return GetAnonFlavor()
+}
+
+// GetSignedCredential returns a credential for the configured guest identity,
+// marked as a guest so that it can be distinguished from an authenticated
+// user of the same name. The caller is expected to issue it to the client as
+// a one-time credential, which limits its lifetime.
+func (req *AuthAnonCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	hostname, err := GetMachineName()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get hostname")
+	}
+
+	sys := &Sys{
+		Machinename: hostname,
+		User:        sysNameToPrincipalName(req.user),
+		Guest:       true,
+	}
+	if req.group != "" {
+		sys.Group = sysNameToPrincipalName(req.group)
+	}
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("successfully signed guest credential for %s", sys.User)
+	return credential, nil
+}
+
+// GetKey returns a cache key for the request. All guest credentials are for
+// the same identity.
+func (req *AuthAnonCredentialRequest) GetKey() string {
+	return tokenCacheKey(req.GetAuthFlavor(), "")
+}
+
+// ValidateAnonToken checks that a verified AUTH_ANON token is a one-time guest
+// credential, so that an anonymous identity cannot outlive the one-time
+// credential lifetime or be mistaken for an authenticated user. Tokens of other
+// flavors are ignored.
+func ValidateAnonToken(token *Token) error {
+	if token.GetFlavor() != Flavor_AUTH_ANON {
+		return nil
+	}
+
+	sys, err := sysFromToken(token)
+	if err != nil {
+		return err
+	}
+	if !sys.Guest {
+		return errors.New("anonymous credential is not marked as a guest")
+	}
+	if sys.Scope != Scope_SCOPE_ONE_TIME {
+		return errors.New("anonymous credential is not a one-time credential")
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_AuthAnonCredentialFactory_Init(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg    security.AnonConfig
+		expErr error
+	}{
+		"not configured": {
+			expErr: errors.New("not configured for anonymous access"),
+		},
+		"configured": {
+			cfg: security.AnonConfig{User: "nobody"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			secCfg := &security.CredentialConfig{AnonConfig: tc.cfg}
+			_, err := (&AuthAnonCredentialFactory{}).Init(log, secCfg, nil, nil, nil)
+			test.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestAuth_AuthAnonCredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		user     string
+		group    string
+		expGroup string
+	}{
+		"user only": {
+			user: "nobody",
+		},
+		"user and group": {
+			user:     "nobody",
+			group:    "nogroup",
+			expGroup: "nogroup@",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthAnonCredentialRequest{
+				signingKey: agentKey,
+				user:       tc.user,
+				group:      tc.group,
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_ANON, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.user+"@", sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, sys.Group, "unexpected group")
+			test.AssertTrue(t, sys.Guest, "credential not marked as guest")
+
+			// Only a one-time credential derived from it is accepted.
+			test.CmpErr(t, errors.New("not a one-time credential"), ValidateAnonToken(cred.Token))
+			oneTime, err := NewOneTimeCredential(cred, agentKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.CmpErr(t, nil, ValidateAnonToken(oneTime.Token))
+		})
+	}
+}
+
+func TestAuth_ValidateAnonToken(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		flavor Flavor
+		sys    *Sys
+		expErr error
+	}{
+		"other flavor ignored": {
+			flavor: Flavor_AUTH_SYS,
+			sys:    &Sys{User: "jdoe@"},
+		},
+		"not a guest": {
+			flavor: Flavor_AUTH_ANON,
+			sys:    &Sys{User: "jdoe@", Scope: Scope_SCOPE_ONE_TIME},
+			expErr: errors.New("not marked as a guest"),
+		},
+		"not one-time": {
+			flavor: Flavor_AUTH_ANON,
+			sys:    &Sys{User: "nobody@", Guest: true},
+			expErr: errors.New("not a one-time credential"),
+		},
+		"one-time guest": {
+			flavor: Flavor_AUTH_ANON,
+			sys:    &Sys{User: "nobody@", Guest: true, Scope: Scope_SCOPE_ONE_TIME},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cred, err := newSignedCredential(tc.flavor, agentKey, tc.sys)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			test.CmpErr(t, tc.expErr, ValidateAnonToken(cred.Token))
+		})
+	}
+}
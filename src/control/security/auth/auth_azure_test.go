@@ -0,0 +1,177 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_AuthAzureCredentialRequest_GetSignedCredential(t *testing.T) {
+	tokenKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tenantID := "00000000-0000-0000-0000-000000000000"
+	objectID := "11111111-1111-1111-1111-111111111111"
+	appID := "22222222-2222-2222-2222-222222222222"
+	azureCfg := &security.AzureConfig{TenantID: tenantID}
+
+	azureClaims := func(extra map[string]interface{}) map[string]interface{} {
+		claims := map[string]interface{}{
+			"iss": "https://login.microsoftonline.com/" + tenantID + "/v2.0",
+			"aud": "api://daos",
+			"tid": tenantID,
+			"oid": objectID,
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+		for k, v := range extra {
+			claims[k] = v
+		}
+		return claims
+	}
+	withoutClaim := func(name string, claims map[string]interface{}) map[string]interface{} {
+		delete(claims, name)
+		return claims
+	}
+
+	for name, tc := range map[string]struct {
+		token       string
+		identityMap security.ExternalIdentityMap
+		expUser     string
+		expGroup    string
+		expGroups   []string
+		expIdentity string
+		expErr      error
+	}{
+		"no token": {
+			expErr: errors.New("no Azure access token"),
+		},
+		"wrong issuer": {
+			token: testSignJWT(t, "RS256", "k", tokenKey, azureClaims(map[string]interface{}{
+				"iss": "https://login.microsoftonline.com/other/v2.0",
+			})),
+			expErr: errors.New("not trusted"),
+		},
+		"tenant mismatch": {
+			token: testSignJWT(t, "RS256", "k", tokenKey, azureClaims(map[string]interface{}{
+				"tid": "33333333-3333-3333-3333-333333333333",
+			})),
+			expErr: errors.New("issued for tenant"),
+		},
+		"object ID": {
+			token:       testSignJWT(t, "RS256", "k", tokenKey, azureClaims(nil)),
+			expUser:     objectID + "@azure",
+			expIdentity: objectID + "@login.microsoftonline.com",
+		},
+		"v1.0 token": {
+			token: testSignJWT(t, "RS256", "k", tokenKey, azureClaims(map[string]interface{}{
+				"iss": "https://sts.windows.net/" + tenantID + "/",
+			})),
+			expUser:     objectID + "@azure",
+			expIdentity: objectID + "@login.microsoftonline.com",
+		},
+		"appid without object ID": {
+			token: testSignJWT(t, "RS256", "k", tokenKey, withoutClaim("oid", azureClaims(map[string]interface{}{
+				"appid": appID,
+			}))),
+			expUser:     appID + "@azure",
+			expIdentity: appID + "@login.microsoftonline.com",
+		},
+		"azp without object ID": {
+			token: testSignJWT(t, "RS256", "k", tokenKey, withoutClaim("oid", azureClaims(map[string]interface{}{
+				"azp": appID,
+			}))),
+			expUser:     appID + "@azure",
+			expIdentity: appID + "@login.microsoftonline.com",
+		},
+		"mapped object ID": {
+			token: testSignJWT(t, "RS256", "k", tokenKey, azureClaims(map[string]interface{}{
+				"appid": appID,
+			})),
+			identityMap: security.ExternalIdentityMap{
+				objectID: {User: "svc", Group: "daos"},
+			},
+			expUser:     "svc@",
+			expGroup:    "daos@",
+			expIdentity: objectID + "@login.microsoftonline.com",
+		},
+		"mapped appid": {
+			token: testSignJWT(t, "RS256", "k", tokenKey, azureClaims(map[string]interface{}{
+				"appid": appID,
+			})),
+			identityMap: security.ExternalIdentityMap{
+				appID: {User: "svc"},
+			},
+			expUser:     "svc@",
+			expIdentity: objectID + "@login.microsoftonline.com",
+		},
+		"groups and roles": {
+			token: testSignJWT(t, "RS256", "k", tokenKey, azureClaims(map[string]interface{}{
+				"groups": []string{"hpc", "admins"},
+				"roles":  []string{"Data.Read"},
+			})),
+			expUser:     objectID + "@azure",
+			expGroups:   []string{"hpc@azure", "admins@azure", "Data.Read@azure"},
+			expIdentity: objectID + "@login.microsoftonline.com",
+		},
+		"no identity": {
+			token:  testSignJWT(t, "RS256", "k", tokenKey, withoutClaim("oid", azureClaims(nil))),
+			expErr: errors.New("no azure identity"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthAzureCredentialRequest{
+				accessToken: tc.token,
+				signingKey:  agentKey,
+				tenantID:    tenantID,
+				identityMap: tc.identityMap,
+				verifier: &jwtVerifier{
+					issuers:   azureCfg.Issuers(),
+					audiences: []string{"api://daos"},
+					keys:      staticJWKS{"k": &tokenKey.PublicKey},
+				},
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_AZURE, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, sys.Group, "unexpected group")
+			test.CmpAny(t, "groups", tc.expGroups, sys.Groups)
+			test.CmpAny(t, "identities", []*Identity{{Type: IdentityOIDC, Name: tc.expIdentity}},
+				sys.Identities, test.DefaultCmpOpts()...)
+		})
+	}
+}
@@ -0,0 +1,245 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth/biscuit"
+)
+
+// Facts from which the credential is built. They are only taken from the
+// authority block signed by the issuer, or derived by its rules; blocks added
+// by holders of the token can only restrict its use with checks.
+const (
+	biscuitFactUser   = "user"   // user($name): user the token authenticates
+	biscuitFactGroup  = "group"  // group($name): primary group of the user
+	biscuitFactMember = "member" // member($name): secondary group of the user
+	biscuitFactRight  = "right"  // right($scope): authorization scope granted
+	biscuitFactPool   = "pool"   // pool($label): pool the credential may be used with
+)
+
+// Facts supplied by the agent, against which the token's checks are evaluated.
+const (
+	biscuitFactTime     = "time"     // time($now): current time
+	biscuitFactSystem   = "system"   // system($name): DAOS system of the agent
+	biscuitFactResource = "resource" // resource($label): pool being authorized
+)
+
+type (
+	// AuthBiscuitCredentialFactory is a factory interface for AuthBiscuitCredentialRequests.
+	AuthBiscuitCredentialFactory struct {
+	}
+
+	// AuthBiscuitCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_BISCUIT flavor.
+	AuthBiscuitCredentialRequest struct {
+		token      []byte
+		signingKey crypto.PrivateKey
+		rootKey    ed25519.PublicKey
+		system     string
+		now        func() time.Time
+	}
+
+	// biscuitGrant is the identity and access granted by a Biscuit token.
+	biscuitGrant struct {
+		user   string
+		group  string
+		groups []string
+		rights []string
+		pools  []string // nil if the token is not restricted to any pools
+	}
+)
+
+func (fac *AuthBiscuitCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthBiscuitCredentialRequest{}
+
+	if secCfg == nil || secCfg.BiscuitConfig.RootPublicKey == "" {
+		return req, drpc.NewFailureWithMessage("agent is not configured for biscuit authentication")
+	}
+	rootKey, err := secCfg.BiscuitConfig.PublicKey()
+	if err != nil {
+		return req, err
+	}
+
+	req.token = bytes.TrimSpace(reqBody)
+	req.signingKey = key
+	req.rootKey = rootKey
+	req.system = secCfg.BiscuitConfig.SystemName
+	req.now = time.Now
+
+	return req, nil
+}
+
+func GetBiscuitFlavor() Flavor {
+	return Flavor_AUTH_BISCUIT
+}
+
+func (fac AuthBiscuitCredentialFactory) GetAuthFlavor() Flavor {
+	return GetBiscuitFlavor()
+}
+
+func (req *AuthBiscuitCredentialRequest) GetAuthFlavor() Flavor {
+	return GetBiscuitFlavor()
+}
+
+// biscuitStrings returns the distinct arguments of the trusted facts with the
+// given name, each of which must have a single string argument.
+func biscuitStrings(a *biscuit.Authorizer, name string) ([]string, error) {
+	results, err := a.Query(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	for _, terms := range results {
+		if len(terms) != 1 {
+			return nil, errors.Errorf("biscuit fact %s must have a single argument", name)
+		}
+		s, ok := terms[0].AsString()
+		if !ok {
+			return nil, errors.Errorf("biscuit fact %s(%s) must have a string argument", name, terms[0])
+		}
+		if !slices.Contains(values, s) {
+			values = append(values, s)
+		}
+	}
+	return values, nil
+}
+
+// authorizeBiscuit evaluates the token's facts and checks, and returns the
+// identity and access it grants. If the token names pools, its checks are
+// evaluated once per pool with the pool as the resource, and only the pools
+// for which all checks pass are granted.
+func authorizeBiscuit(tok *biscuit.Token, now time.Time, system string) (*biscuitGrant, error) {
+	newAuthorizer := func(pool string) *biscuit.Authorizer {
+		a := tok.Authorizer()
+		a.AddFact(biscuitFactTime, biscuit.DateTerm(now))
+		if system != "" {
+			a.AddFact(biscuitFactSystem, biscuit.StringTerm(system))
+		}
+		if pool != "" {
+			a.AddFact(biscuitFactResource, biscuit.StringTerm(pool))
+		}
+		return a
+	}
+
+	a := newAuthorizer("")
+	grant := &biscuitGrant{}
+	facts := map[string]*[]string{
+		biscuitFactMember: &grant.groups,
+		biscuitFactRight:  &grant.rights,
+		biscuitFactPool:   &grant.pools,
+	}
+	for name, values := range facts {
+		var err error
+		if *values, err = biscuitStrings(a, name); err != nil {
+			return nil, err
+		}
+	}
+
+	users, err := biscuitStrings(a, biscuitFactUser)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != 1 {
+		return nil, errors.Errorf("biscuit authority must state exactly one %s fact", biscuitFactUser)
+	}
+	grant.user = users[0]
+
+	groups, err := biscuitStrings(a, biscuitFactGroup)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) > 1 {
+		return nil, errors.Errorf("biscuit authority states more than one %s fact", biscuitFactGroup)
+	}
+	if len(groups) == 1 {
+		grant.group = groups[0]
+	}
+
+	if len(grant.pools) == 0 {
+		if err := a.Authorize(); err != nil {
+			return nil, err
+		}
+		return grant, nil
+	}
+
+	var allowed []string
+	var firstErr error
+	for _, pool := range grant.pools {
+		err := newAuthorizer(pool).Authorize()
+		if err == nil {
+			allowed = append(allowed, pool)
+		} else if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if len(allowed) == 0 {
+		return nil, errors.Wrap(firstErr, "biscuit permits no pools")
+	}
+	grant.pools = allowed
+
+	return grant, nil
+}
+
+// GetSignedCredential verifies the Biscuit token and its checks, and returns
+// a credential for the user it authenticates, restricted to the pools it
+// allows.
+func (req *AuthBiscuitCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	if len(req.token) == 0 {
+		return nil, errors.New("no biscuit supplied")
+	}
+
+	tok, err := biscuit.Parse(req.token, req.rootKey)
+	if err != nil {
+		return nil, err
+	}
+	grant, err := authorizeBiscuit(tok, req.now(), req.system)
+	if err != nil {
+		return nil, err
+	}
+
+	hostname, err := GetMachineName()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get hostname")
+	}
+	sys := &Sys{
+		Machinename:   hostname,
+		User:          sysNameToPrincipalName(grant.user),
+		GrantedScopes: grant.rights,
+		Pools:         grant.pools,
+	}
+	if grant.group != "" {
+		sys.Group = sysNameToPrincipalName(grant.group)
+	}
+	for _, g := range grant.groups {
+		sys.Groups = append(sys.Groups, sysNameToPrincipalName(g))
+	}
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("biscuit with %d blocks: successfully signed credential for %s (pools: %v)",
+		tok.Blocks(), sys.User, sys.Pools)
+	return credential, nil
+}
+
+func (req *AuthBiscuitCredentialRequest) GetKey() string {
+	return tokenCacheKey(req.GetAuthFlavor(), string(req.token))
+}
@@ -0,0 +1,300 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/binary"
+	"encoding/hex"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth/biscuit"
+)
+
+// testBiscuitMinter mints and attenuates Biscuit tokens, as a site authority
+// or token holder using a Biscuit library would. Every symbol used is defined
+// by the token.
+type testBiscuitMinter struct {
+	t     *testing.T
+	syms  []string
+	pb    *biscuit.Biscuit
+	key   ed25519.PrivateKey
+	block *biscuit.Block
+}
+
+func newTestBiscuitMinter(t *testing.T, root ed25519.PrivateKey) *testBiscuitMinter {
+	return &testBiscuitMinter{t: t, pb: &biscuit.Biscuit{}, key: root, block: &biscuit.Block{}}
+}
+
+func (m *testBiscuitMinter) sym(s string) uint64 {
+	i := slices.Index(m.syms, s)
+	if i < 0 {
+		m.syms = append(m.syms, s)
+		m.block.Symbols = append(m.block.Symbols, s)
+		i = len(m.syms) - 1
+	}
+	return 1024 + uint64(i)
+}
+
+func (m *testBiscuitMinter) str(s string) *biscuit.TermV2 {
+	return &biscuit.TermV2{Content: &biscuit.TermV2_String_{String_: m.sym(s)}}
+}
+
+func (m *testBiscuitMinter) variable(name string) *biscuit.TermV2 {
+	return &biscuit.TermV2{Content: &biscuit.TermV2_Variable{Variable: uint32(m.sym(name))}}
+}
+
+func (m *testBiscuitMinter) pred(name string, terms ...*biscuit.TermV2) *biscuit.PredicateV2 {
+	return &biscuit.PredicateV2{Name: m.sym(name), Terms: terms}
+}
+
+func (m *testBiscuitMinter) fact(name string, args ...string) *testBiscuitMinter {
+	var terms []*biscuit.TermV2
+	for _, a := range args {
+		terms = append(terms, m.str(a))
+	}
+	m.block.FactsV2 = append(m.block.FactsV2, &biscuit.FactV2{Predicate: m.pred(name, terms...)})
+	return m
+}
+
+// checkIf adds a check whose single query has the given body predicate and
+// expression operations.
+func (m *testBiscuitMinter) checkIf(body *biscuit.PredicateV2, ops ...*biscuit.Op) *testBiscuitMinter {
+	query := &biscuit.RuleV2{Head: m.pred("query"), Body: []*biscuit.PredicateV2{body}}
+	if len(ops) > 0 {
+		query.Expressions = []*biscuit.ExpressionV2{{Ops: ops}}
+	}
+	m.block.ChecksV2 = append(m.block.ChecksV2, &biscuit.CheckV2{Queries: []*biscuit.RuleV2{query}})
+	return m
+}
+
+// expires adds a check that the token is used before the given time.
+func (m *testBiscuitMinter) expires(at time.Time) *testBiscuitMinter {
+	return m.checkIf(m.pred("time", m.variable("t")),
+		&biscuit.Op{Content: &biscuit.Op_Value{Value: m.variable("t")}},
+		&biscuit.Op{Content: &biscuit.Op_Value{Value: &biscuit.TermV2{Content: &biscuit.TermV2_Date{Date: uint64(at.Unix())}}}},
+		&biscuit.Op{Content: &biscuit.Op_Binary{Binary: &biscuit.OpBinary{Kind: biscuit.OpBinary_LessThan}}})
+}
+
+// pools adds a check that the resource is one of the given pools.
+func (m *testBiscuitMinter) pools(pools ...string) *testBiscuitMinter {
+	set := &biscuit.TermSet{}
+	for _, p := range pools {
+		set.Set = append(set.Set, m.str(p))
+	}
+	return m.checkIf(m.pred("resource", m.variable("r")),
+		&biscuit.Op{Content: &biscuit.Op_Value{Value: &biscuit.TermV2{Content: &biscuit.TermV2_Set{Set: set}}}},
+		&biscuit.Op{Content: &biscuit.Op_Value{Value: m.variable("r")}},
+		&biscuit.Op{Content: &biscuit.Op_Binary{Binary: &biscuit.OpBinary{Kind: biscuit.OpBinary_Contains}}})
+}
+
+// system adds a check that the token is used with the given DAOS system.
+func (m *testBiscuitMinter) system(name string) *testBiscuitMinter {
+	return m.checkIf(m.pred("system", m.str(name)))
+}
+
+// next signs the current block and starts a new attenuation block.
+func (m *testBiscuitMinter) next() *testBiscuitMinter {
+	m.block.Version = proto.Uint32(3)
+	data, err := proto.Marshal(m.block)
+	if err != nil {
+		m.t.Fatal(err)
+	}
+	nextPub, nextPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		m.t.Fatal(err)
+	}
+
+	sb := &biscuit.SignedBlock{Block: data, NextKey: &biscuit.PublicKey{Key: nextPub}}
+	payload := binary.LittleEndian.AppendUint32(append([]byte{}, data...), uint32(biscuit.PublicKey_Ed25519))
+	sb.Signature = ed25519.Sign(m.key, append(payload, nextPub...))
+	if m.pb.Authority == nil {
+		m.pb.Authority = sb
+	} else {
+		m.pb.Blocks = append(m.pb.Blocks, sb)
+	}
+	m.pb.Proof = &biscuit.Proof{Content: &biscuit.Proof_NextSecret{NextSecret: nextPriv.Seed()}}
+
+	m.key = nextPriv
+	m.block = &biscuit.Block{}
+	return m
+}
+
+func (m *testBiscuitMinter) token() []byte {
+	m.next()
+	data, err := proto.Marshal(m.pb)
+	if err != nil {
+		m.t.Fatal(err)
+	}
+	return data
+}
+
+func TestAuth_AuthBiscuitCredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootPub, rootPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mint := func() *testBiscuitMinter {
+		return newTestBiscuitMinter(t, rootPriv).fact("user", "jdoe")
+	}
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	for name, tc := range map[string]struct {
+		token     []byte
+		expUser   string
+		expGroup  string
+		expGroups []string
+		expScopes []string
+		expPools  []string
+		expErr    error
+	}{
+		"no biscuit": {
+			expErr: errors.New("no biscuit"),
+		},
+		"minted by another authority": {
+			token:  newTestBiscuitMinter(t, otherPriv).fact("user", "jdoe").token(),
+			expErr: errors.New("invalid signature"),
+		},
+		"no user": {
+			token:  newTestBiscuitMinter(t, rootPriv).fact("group", "hpc").token(),
+			expErr: errors.New("exactly one user fact"),
+		},
+		"two users": {
+			token:  mint().fact("user", "root").token(),
+			expErr: errors.New("exactly one user fact"),
+		},
+		"success": {
+			token: mint().fact("group", "hpc").fact("member", "admins").fact("member", "users").
+				fact("right", "pool:read").expires(future).token(),
+			expUser:   "jdoe@",
+			expGroup:  "hpc@",
+			expGroups: []string{"admins@", "users@"},
+			expScopes: []string{"pool:read"},
+		},
+		"expired": {
+			token:  mint().expires(past).token(),
+			expErr: errors.New("checks failed"),
+		},
+		"attenuated expiry": {
+			token:  mint().expires(future).next().expires(past).token(),
+			expErr: errors.New("checks failed"),
+		},
+		"restricted to this system": {
+			token:   mint().next().system("daos_server").token(),
+			expUser: "jdoe@",
+		},
+		"restricted to another system": {
+			token:  mint().next().system("other").token(),
+			expErr: errors.New("checks failed"),
+		},
+		"user added by holder is ignored": {
+			token:   mint().next().fact("user", "root").fact("right", "admin").token(),
+			expUser: "jdoe@",
+		},
+		"pools narrowed by holder": {
+			token:    mint().fact("pool", "tank").fact("pool", "scratch").next().pools("scratch", "home").token(),
+			expUser:  "jdoe@",
+			expPools: []string{"scratch"},
+		},
+		"no pools permitted": {
+			token:  mint().fact("pool", "tank").next().pools("home").token(),
+			expErr: errors.New("biscuit permits no pools"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthBiscuitCredentialRequest{
+				token:      tc.token,
+				signingKey: agentKey,
+				rootKey:    rootPub,
+				system:     "daos_server",
+				now:        time.Now,
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_BISCUIT, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, sys.Group, "unexpected group")
+			test.CmpAny(t, "groups", tc.expGroups, sys.Groups)
+			test.CmpAny(t, "granted scopes", tc.expScopes, sys.GrantedScopes)
+			test.CmpAny(t, "pools", tc.expPools, sys.Pools)
+
+			// The server rejects the credential for pools the biscuit
+			// does not grant.
+			if len(tc.expPools) > 0 {
+				test.CmpErr(t, nil, ValidateTokenPool(cred.Token, "", tc.expPools[0]))
+				test.CmpErr(t, errors.New("may not be used with pool"), ValidateTokenPool(cred.Token, "", "home"))
+			}
+		})
+	}
+}
+
+func TestAuth_AuthBiscuitCredentialFactory_Init(t *testing.T) {
+	rootPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		cfg    security.BiscuitConfig
+		expErr error
+	}{
+		"not configured": {
+			expErr: errors.New("not configured for biscuit"),
+		},
+		"configured": {
+			cfg: security.BiscuitConfig{RootPublicKey: "ed25519/" + hex.EncodeToString(rootPub)},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			secCfg := &security.CredentialConfig{BiscuitConfig: tc.cfg}
+			req, err := (&AuthBiscuitCredentialFactory{}).Init(log, secCfg, nil, []byte(" token\n"), nil)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertEqual(t, "token", string(req.(*AuthBiscuitCredentialRequest).token), "token not trimmed")
+		})
+	}
+}
@@ -0,0 +1,260 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"encoding/binary"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// DefaultDelegationLifetime is the maximum lifetime of a delegation credential
+// if none is configured.
+const DefaultDelegationLifetime = time.Hour
+
+type (
+	// AuthDelegationCredentialFactory is a factory interface for AuthDelegationCredentialRequests.
+	AuthDelegationCredentialFactory struct {
+	}
+
+	// AuthDelegationCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_DELEGATION flavor.
+	AuthDelegationCredentialRequest struct {
+		req         *DelegationReq
+		uid         uint32
+		signingKey  crypto.PrivateKey
+		maxLifetime time.Duration
+		now         func() time.Time
+	}
+)
+
+func (fac *AuthDelegationCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthDelegationCredentialRequest{}
+
+	if secCfg == nil || !secCfg.DelegationConfig.Enabled {
+		return req, drpc.NewFailureWithMessage("agent is not configured to issue delegation credentials")
+	}
+
+	req.req = new(DelegationReq)
+	if err := proto.Unmarshal(reqBody, req.req); err != nil {
+		return req, drpc.UnmarshalingPayloadFailure()
+	}
+
+	uid, err := sessionUID(log, session)
+	if err != nil {
+		return req, err
+	}
+
+	req.uid = uid
+	req.signingKey = key
+	req.maxLifetime = secCfg.DelegationConfig.MaxLifetime
+	req.now = time.Now
+
+	return req, nil
+}
+
+func GetDelegationFlavor() Flavor {
+	return Flavor_AUTH_DELEGATION
+}
+
+func (fac AuthDelegationCredentialFactory) GetAuthFlavor() Flavor {
+	return GetDelegationFlavor()
+}
+
+func (req *AuthDelegationCredentialRequest) GetAuthFlavor() Flavor {
+	return GetDelegationFlavor()
+}
+
+// signingPublicKey returns the public key matching the agent's signing key,
+// or nil if credentials are not signed.
+func signingPublicKey(key crypto.PrivateKey) (crypto.PublicKey, error) {
+	if key == nil {
+		return nil, nil
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("signing key of type %T has no public key", key)
+	}
+	return signer.Public(), nil
+}
+
+// delegatorSys returns the Sys token of a credential that may be delegated.
+// Delegation credentials may not be delegated further, and guest, machine and
+// one-time credentials may not be delegated at all.
+func delegatorSys(parent *Credential) (*Sys, error) {
+	if parent.GetToken() == nil || parent.GetVerifier() == nil {
+		return nil, errors.New("no delegator credential")
+	}
+	if parent.GetToken().GetFlavor() == Flavor_AUTH_DELEGATION {
+		return nil, errors.New("delegation credentials cannot be delegated")
+	}
+
+	sys, err := sysFromToken(parent.GetToken())
+	if err != nil {
+		return nil, errors.Wrap(err, "delegator credential")
+	}
+	switch {
+	case sys.Guest:
+		return nil, errors.New("guest credentials cannot be delegated")
+	case sys.Machine:
+		return nil, errors.New("machine credentials cannot be delegated")
+	case sys.Scope == Scope_SCOPE_ONE_TIME:
+		return nil, errors.New("one-time credentials cannot be delegated")
+	}
+	return sys, nil
+}
+
+// checkDelegatedScope checks that the pools and containers are a subset of
+// those the delegator may use.
+func checkDelegatedScope(delegator *Sys, pools, containers []string) error {
+	if len(pools) == 0 {
+		return errors.New("delegation must be restricted to at least one pool")
+	}
+	for _, pool := range pools {
+		if pool == "" {
+			return errors.New("empty pool in delegation")
+		}
+		if len(delegator.Pools) > 0 && !slices.Contains(delegator.Pools, pool) {
+			return errors.Errorf("delegator may not use pool %q", pool)
+		}
+	}
+	for _, cont := range containers {
+		pool, name, found := strings.Cut(cont, "/")
+		if !found || name == "" {
+			return errors.Errorf("container %q is not of the form <pool>/<container>", cont)
+		}
+		if !slices.Contains(pools, pool) {
+			return errors.Errorf("container %q is not in a delegated pool", cont)
+		}
+	}
+	return nil
+}
+
+// delegatedSys derives the Sys token of a delegation credential from that of
+// the delegator. The delegate acts as the delegator, but is restricted to the
+// delegated pools, and has not confirmed a second factor.
+func delegatedSys(delegator *Sys, pools []string, delegation *Delegation) *Sys {
+	sys := proto.Clone(delegator).(*Sys)
+	sys.Pools = pools
+	sys.SecondFactor = false
+	sys.Delegation = delegation
+	return sys
+}
+
+// GetSignedCredential checks that the delegator's credential was issued by
+// this agent and returns a credential restricted to the requested pools,
+// containers and lifetime.
+func (req *AuthDelegationCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	parent := req.req.GetParent()
+	delegator, err := delegatorSys(parent)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := signingPublicKey(req.signingKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyCredential(pub, parent); err != nil {
+		return nil, errors.Wrap(err, "delegator credential was not issued by this agent")
+	}
+
+	if err := checkDelegatedScope(delegator, req.req.GetPools(), req.req.GetContainers()); err != nil {
+		return nil, err
+	}
+	maxLifetime := req.maxLifetime
+	if maxLifetime == 0 {
+		maxLifetime = DefaultDelegationLifetime
+	}
+	lifetime := time.Duration(req.req.GetLifetime()) * time.Second
+	if lifetime == 0 {
+		lifetime = maxLifetime
+	}
+	if lifetime > maxLifetime {
+		return nil, errors.Errorf("requested lifetime %s exceeds the maximum of %s", lifetime, maxLifetime)
+	}
+	expires := req.now().Add(lifetime)
+
+	sys := delegatedSys(delegator, req.req.GetPools(), &Delegation{
+		Parent:     parent,
+		Containers: req.req.GetContainers(),
+		Expires:    uint64(expires.Unix()),
+		Delegate:   req.req.GetDelegate(),
+	})
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Noticef("audit: uid %d: delegated credential for %s to %q for pools %v until %s",
+		req.uid, sys.User, sys.Delegation.Delegate, sys.Pools, expires.Format(time.RFC3339))
+	return credential, nil
+}
+
+// GetKey returns a cache key for the request. Delegation credentials are not
+// cached, as they carry their own expiry.
+func (req *AuthDelegationCredentialRequest) GetKey() string {
+	body, _ := proto.Marshal(req.req)
+	return tokenCacheKey(req.GetAuthFlavor(), string(append(binary.BigEndian.AppendUint32(nil, req.uid), body...)))
+}
+
+// ValidateDelegationToken checks that an AUTH_DELEGATION token has not expired,
+// that its delegator's credential was signed with the same key, and that the
+// delegated identity and scope are no more than those of the delegator. Other
+// flavors may not claim to be delegated.
+func ValidateDelegationToken(token *Token, key crypto.PublicKey, now time.Time) error {
+	sys, err := sysFromToken(token)
+	if err != nil {
+		return err
+	}
+	if token.GetFlavor() != Flavor_AUTH_DELEGATION {
+		if sys.Delegation != nil {
+			return errors.Errorf("%s credential claims to be delegated", token.GetFlavor())
+		}
+		return nil
+	}
+
+	delegation := sys.GetDelegation()
+	if delegation == nil {
+		return errors.New("delegation credential has no delegator")
+	}
+	if now.Unix() >= int64(delegation.GetExpires()) {
+		return errors.New("delegation credential has expired")
+	}
+
+	parent := delegation.GetParent()
+	delegator, err := delegatorSys(parent)
+	if err != nil {
+		return err
+	}
+	if err := VerifyCredential(key, parent); err != nil {
+		return errors.Wrap(err, "delegator credential")
+	}
+	if err := checkDelegatedScope(delegator, sys.Pools, delegation.GetContainers()); err != nil {
+		return err
+	}
+
+	// Delegation credentials may be derived as one-time credentials.
+	expected := delegatedSys(delegator, sys.Pools, delegation)
+	expected.Scope = sys.Scope
+	expected.Stamp = sys.Stamp
+	expected.Nonce = sys.Nonce
+	if !proto.Equal(expected, sys) {
+		return errors.New("delegation credential asserts more than its delegator")
+	}
+
+	return nil
+}
@@ -0,0 +1,254 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func testDelegatorCred(t *testing.T, key *rsa.PrivateKey, flavor Flavor, sys *Sys) *Credential {
+	t.Helper()
+	cred, err := newSignedCredential(flavor, key, sys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cred
+}
+
+func TestAuth_AuthDelegationCredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	delegator := &Sys{
+		User:   "jdoe@",
+		Group:  "users@",
+		Groups: []string{"hpc@"},
+	}
+	parent := testDelegatorCred(t, agentKey, Flavor_AUTH_SYS, delegator)
+	restricted := testDelegatorCred(t, agentKey, Flavor_AUTH_SYS, &Sys{User: "jdoe@", Pools: []string{"tank"}})
+	now := time.Unix(1700000000, 0)
+
+	for name, tc := range map[string]struct {
+		req        *DelegationReq
+		expErr     error
+		expExpires int64
+	}{
+		"no parent": {
+			req:    &DelegationReq{Pools: []string{"tank"}},
+			expErr: errors.New("no delegator credential"),
+		},
+		"parent from another agent": {
+			req: &DelegationReq{
+				Parent: testDelegatorCred(t, otherKey, Flavor_AUTH_SYS, delegator),
+				Pools:  []string{"tank"},
+			},
+			expErr: errors.New("not issued by this agent"),
+		},
+		"delegation of delegation": {
+			req: &DelegationReq{
+				Parent: testDelegatorCred(t, agentKey, Flavor_AUTH_DELEGATION, delegator),
+				Pools:  []string{"tank"},
+			},
+			expErr: errors.New("cannot be delegated"),
+		},
+		"guest parent": {
+			req: &DelegationReq{
+				Parent: testDelegatorCred(t, agentKey, Flavor_AUTH_ANON, &Sys{User: "guest@", Guest: true}),
+				Pools:  []string{"tank"},
+			},
+			expErr: errors.New("guest credentials cannot be delegated"),
+		},
+		"one-time parent": {
+			req: &DelegationReq{
+				Parent: testDelegatorCred(t, agentKey, Flavor_AUTH_SYS, &Sys{User: "jdoe@", Scope: Scope_SCOPE_ONE_TIME}),
+				Pools:  []string{"tank"},
+			},
+			expErr: errors.New("one-time credentials cannot be delegated"),
+		},
+		"no pools": {
+			req:    &DelegationReq{Parent: parent},
+			expErr: errors.New("at least one pool"),
+		},
+		"pool outside delegator's": {
+			req:    &DelegationReq{Parent: restricted, Pools: []string{"tank", "scratch"}},
+			expErr: errors.New(`may not use pool "scratch"`),
+		},
+		"malformed container": {
+			req:    &DelegationReq{Parent: parent, Pools: []string{"tank"}, Containers: []string{"results"}},
+			expErr: errors.New("not of the form"),
+		},
+		"container outside pools": {
+			req:    &DelegationReq{Parent: parent, Pools: []string{"tank"}, Containers: []string{"scratch/results"}},
+			expErr: errors.New("not in a delegated pool"),
+		},
+		"lifetime too long": {
+			req:    &DelegationReq{Parent: parent, Pools: []string{"tank"}, Lifetime: 7200},
+			expErr: errors.New("exceeds the maximum"),
+		},
+		"default lifetime": {
+			req:        &DelegationReq{Parent: parent, Pools: []string{"tank"}, Delegate: "gateway"},
+			expExpires: now.Add(DefaultDelegationLifetime).Unix(),
+		},
+		"restricted": {
+			req: &DelegationReq{
+				Parent:     restricted,
+				Pools:      []string{"tank"},
+				Containers: []string{"tank/results"},
+				Lifetime:   600,
+				Delegate:   "gateway",
+			},
+			expExpires: now.Add(10 * time.Minute).Unix(),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthDelegationCredentialRequest{
+				req:        tc.req,
+				uid:        1000,
+				signingKey: agentKey,
+				now:        func() time.Time { return now },
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_DELEGATION, cred.Token.Flavor, "unexpected flavor")
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, "jdoe@", sys.User, "unexpected user")
+			test.CmpAny(t, "pools", tc.req.Pools, sys.Pools)
+			test.CmpAny(t, "containers", tc.req.Containers, sys.Delegation.Containers)
+			test.AssertEqual(t, tc.req.Delegate, sys.Delegation.Delegate, "unexpected delegate")
+			test.AssertEqual(t, uint64(tc.expExpires), sys.Delegation.Expires, "unexpected expiry")
+
+			if err := ValidateDelegationToken(cred.Token, &agentKey.PublicKey, now); err != nil {
+				t.Fatalf("issued credential failed validation: %s", err)
+			}
+		})
+	}
+}
+
+func TestAuth_ValidateDelegationToken(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	delegator := &Sys{User: "jdoe@", Group: "users@", Pools: []string{"tank", "scratch"}}
+	parent := testDelegatorCred(t, agentKey, Flavor_AUTH_SYS, delegator)
+	delegation := func() *Delegation {
+		return &Delegation{Parent: parent, Expires: uint64(now.Add(time.Minute).Unix())}
+	}
+	token := func(flavor Flavor, sys *Sys) *Token {
+		data, err := proto.Marshal(sys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &Token{Flavor: flavor, Data: data}
+	}
+	delegated := func(modify func(*Sys)) *Token {
+		sys := delegatedSys(delegator, []string{"tank"}, delegation())
+		if modify != nil {
+			modify(sys)
+		}
+		return token(Flavor_AUTH_DELEGATION, sys)
+	}
+
+	oneTime, err := NewOneTimeCredential(testDelegatorCred(t, agentKey, Flavor_AUTH_DELEGATION,
+		delegatedSys(delegator, []string{"tank"}, delegation())), agentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		token  *Token
+		key    *rsa.PublicKey
+		expErr error
+	}{
+		"not delegated": {
+			token: token(Flavor_AUTH_SYS, &Sys{User: "jdoe@"}),
+		},
+		"sys claiming delegation": {
+			token:  token(Flavor_AUTH_SYS, &Sys{User: "jdoe@", Delegation: delegation()}),
+			expErr: errors.New("AUTH_SYS credential claims to be delegated"),
+		},
+		"no delegation": {
+			token:  token(Flavor_AUTH_DELEGATION, &Sys{User: "jdoe@"}),
+			expErr: errors.New("has no delegator"),
+		},
+		"expired": {
+			token: delegated(func(sys *Sys) {
+				sys.Delegation.Expires = uint64(now.Unix())
+			}),
+			expErr: errors.New("has expired"),
+		},
+		"delegator signed by another key": {
+			token:  delegated(nil),
+			key:    &otherKey.PublicKey,
+			expErr: errors.New("delegator credential"),
+		},
+		"pool widened": {
+			token: delegated(func(sys *Sys) {
+				sys.Pools = []string{"tank", "home"}
+			}),
+			expErr: errors.New(`may not use pool "home"`),
+		},
+		"identity changed": {
+			token: delegated(func(sys *Sys) {
+				sys.User = "root@"
+			}),
+			expErr: errors.New("asserts more than its delegator"),
+		},
+		"group added": {
+			token: delegated(func(sys *Sys) {
+				sys.Groups = append(sys.Groups, "admins@")
+			}),
+			expErr: errors.New("asserts more than its delegator"),
+		},
+		"valid": {
+			token: delegated(nil),
+		},
+		"one-time": {
+			token: oneTime.Token,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			key := &agentKey.PublicKey
+			if tc.key != nil {
+				key = tc.key
+			}
+			test.CmpErr(t, tc.expErr, ValidateDelegationToken(tc.token, key, now))
+		})
+	}
+}
@@ -0,0 +1,236 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	// DefaultExecTimeout is the time a helper is allowed to run if no timeout
+	// is configured.
+	DefaultExecTimeout = 10 * time.Second
+
+	maxExecOutputSize = 1 << 20
+	maxExecStderrSize = 4096
+
+	// execPath is the search path given to the helper, which otherwise runs
+	// with only the variables configured for it.
+	execPath = "PATH=/usr/sbin:/usr/bin:/sbin:/bin"
+)
+
+type (
+	// AuthExecCredentialFactory is a factory interface for AuthExecCredentialRequests.
+	AuthExecCredentialFactory struct {
+	}
+
+	// AuthExecCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_EXEC flavor.
+	AuthExecCredentialRequest struct {
+		body       []byte
+		uid        uint32
+		gid        uint32
+		pid        int32
+		signingKey crypto.PrivateKey
+		command    string
+		args       []string
+		env        []string
+		timeout    time.Duration
+		caseFold   security.CaseFoldPolicy
+	}
+
+	// execIdentity is the identity written as JSON to stdout by the helper,
+	// or returned by an identity webhook. Names without a domain are local
+	// user and group names. Claims are recorded in the token, for access
+	// policies written against them. Pools, if any, restrict the credential
+	// to those pools, named by label or UUID.
+	execIdentity struct {
+		User          string            `json:"user"`
+		Group         string            `json:"group,omitempty"`
+		Groups        []string          `json:"groups,omitempty"`
+		GrantedScopes []string          `json:"granted_scopes,omitempty"`
+		Pools         []string          `json:"pools,omitempty"`
+		Claims        map[string]string `json:"claims,omitempty"`
+	}
+)
+
+func (fac *AuthExecCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthExecCredentialRequest{}
+
+	if secCfg == nil || secCfg.ExecConfig.Command == "" {
+		return req, drpc.NewFailureWithMessage("agent is not configured with an exec helper")
+	}
+
+	info, err := sessionDomainInfo(log, session)
+	if err != nil {
+		return req, err
+	}
+
+	req.body = reqBody
+	req.uid = info.Uid()
+	req.gid = info.Gid()
+	req.pid = info.Pid()
+	req.signingKey = key
+	req.command = secCfg.ExecConfig.Command
+	req.args = secCfg.ExecConfig.Args
+	req.env = secCfg.ExecConfig.Env
+	req.timeout = secCfg.ExecConfig.Timeout
+	req.caseFold = secCfg.PrincipalCaseFold
+
+	return req, nil
+}
+
+func GetExecFlavor() Flavor {
+	return Flavor_AUTH_EXEC
+}
+
+func (fac AuthExecCredentialFactory) GetAuthFlavor() Flavor {
+	return GetExecFlavor()
+}
+
+func (req *AuthExecCredentialRequest) GetAuthFlavor() Flavor {
+	return GetExecFlavor()
+}
+
+// checkExecHelper ensures that the helper can only have been installed by a
+// privileged user, as whoever can replace it can assert any identity.
+func checkExecHelper(path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return errors.Wrap(err, "exec helper")
+	}
+	if !fi.Mode().IsRegular() {
+		return errors.Errorf("exec helper %q is not a regular file", path)
+	}
+	if fi.Mode().Perm()&0022 != 0 {
+		return errors.Errorf("exec helper %q is writable by group or others", path)
+	}
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok && st.Uid != 0 && int(st.Uid) != os.Geteuid() {
+		return errors.Errorf("exec helper %q is not owned by root or the agent user", path)
+	}
+	return nil
+}
+
+// limitedBuffer is an io.Writer that records whether more than its limit was
+// written to it.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit    int
+	overflow bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); len(p) > room {
+		b.overflow = true
+		p = p[:max(room, 0)]
+	}
+	b.Buffer.Write(p)
+	return len(p), nil
+}
+
+// runHelper runs the helper with the request body on stdin and returns the
+// identity it writes to stdout. The client's credentials are passed in the
+// environment.
+func (req *AuthExecCredentialRequest) runHelper(ctx context.Context) (*execIdentity, error) {
+	if err := checkExecHelper(req.command); err != nil {
+		return nil, err
+	}
+
+	timeout := req.timeout
+	if timeout == 0 {
+		timeout = DefaultExecTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stdout := &limitedBuffer{limit: maxExecOutputSize}
+	stderr := &limitedBuffer{limit: maxExecStderrSize}
+	cmd := exec.CommandContext(ctx, req.command, req.args...)
+	cmd.Stdin = bytes.NewReader(req.body)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// Don't wait indefinitely for output from any children the helper left
+	// running after it was killed.
+	cmd.WaitDelay = time.Second
+	cmd.Env = append([]string{
+		execPath,
+		fmt.Sprintf("DAOS_CLIENT_UID=%d", req.uid),
+		fmt.Sprintf("DAOS_CLIENT_GID=%d", req.gid),
+		fmt.Sprintf("DAOS_CLIENT_PID=%d", req.pid),
+	}, req.env...)
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, errors.Errorf("exec helper %q timed out after %s", req.command, timeout)
+		}
+		return nil, errors.Wrapf(err, "exec helper %q failed: %s", req.command, strings.TrimSpace(stderr.String()))
+	}
+	if stdout.overflow {
+		return nil, errors.Errorf("exec helper %q output exceeds %d bytes", req.command, maxExecOutputSize)
+	}
+
+	id := &execIdentity{}
+	if err := json.Unmarshal(stdout.Bytes(), id); err != nil {
+		return nil, errors.Wrapf(err, "parsing output of exec helper %q", req.command)
+	}
+	if id.User == "" {
+		return nil, errors.Errorf("exec helper %q returned no user", req.command)
+	}
+	return id, nil
+}
+
+// GetSignedCredential runs the helper and returns a credential for the
+// identity it asserts.
+func (req *AuthExecCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	id, err := req.runHelper(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sys, err := assertedIdentitySys(req.caseFold, id.User, id.Group, id.Groups)
+	if err != nil {
+		return nil, errors.Wrapf(err, "exec helper %q", req.command)
+	}
+	sys.GrantedScopes = id.GrantedScopes
+	sys.Pools = id.Pools
+	if sys.Claims, err = NewClaims(id.Claims); err != nil {
+		return nil, errors.Wrapf(err, "exec helper %q", req.command)
+	}
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("uid %d: successfully signed credential for %s asserted by exec helper",
+		req.uid, sys.User)
+	return credential, nil
+}
+
+// GetKey returns a cache key for the request. As the helper may identify the
+// client by its credentials, the key is bound to the requesting uid and gid.
+func (req *AuthExecCredentialRequest) GetKey() string {
+	data := binary.BigEndian.AppendUint32(nil, req.uid)
+	data = binary.BigEndian.AppendUint32(data, req.gid)
+	data = append(data, req.body...)
+	return tokenCacheKey(req.GetAuthFlavor(), string(data))
+}
@@ -0,0 +1,169 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// writeTestHelper writes a shell script to be run as the exec helper.
+func writeTestHelper(t *testing.T, script string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "helper")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	// Set explicitly, as the mode given to WriteFile is subject to umask.
+	if err := os.Chmod(path, mode); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAuth_AuthExecCredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		script    string
+		mode      os.FileMode
+		body      string
+		env       []string
+		timeout   time.Duration
+		expUser   string
+		expGroup  string
+		expGroups []string
+		expScopes []string
+		expPools  []string
+		expClaims []string
+		expErr    error
+	}{
+		"missing helper": {
+			expErr: errors.New("no such file"),
+		},
+		"writable by others": {
+			script: `echo '{"user": "root"}'`,
+			mode:   0777,
+			expErr: errors.New("writable by group or others"),
+		},
+		"helper fails": {
+			script: "echo 'unknown ticket' >&2; exit 1",
+			expErr: errors.New("unknown ticket"),
+		},
+		"helper times out": {
+			script:  "exec sleep 10",
+			timeout: 100 * time.Millisecond,
+			expErr:  errors.New("timed out"),
+		},
+		"invalid output": {
+			script: "echo 'user=jdoe'",
+			expErr: errors.New("parsing output"),
+		},
+		"no user": {
+			script: `echo '{"group": "hpc"}'`,
+			expErr: errors.New("returned no user"),
+		},
+		"identity from request body": {
+			script:    `read name; echo "{\"user\": \"$name\", \"group\": \"hpc\", \"groups\": [\"uid$DAOS_CLIENT_UID\"]}"`,
+			body:      "jdoe\n",
+			expUser:   "jdoe@",
+			expGroup:  "hpc@",
+			expGroups: []string{"uid1000@"},
+		},
+		"domain principals and grants": {
+			script:    `echo "{\"user\": \"jdoe@$REALM\", \"granted_scopes\": [\"pool:read\"], \"pools\": [\"tank\"]}"`,
+			env:       []string{"REALM=EXAMPLE.COM"},
+			expUser:   "jdoe@example.com",
+			expScopes: []string{"pool:read"},
+			expPools:  []string{"tank"},
+		},
+		"claims": {
+			script:    `echo '{"user": "jdoe", "claims": {"project": "climate", "job": "1234"}}'`,
+			expUser:   "jdoe@",
+			expClaims: []string{"job=1234@claim", "project=climate@claim"},
+		},
+		"invalid claim": {
+			script: `echo '{"user": "jdoe", "claims": {"project": "a:b"}}'`,
+			expErr: errors.New(`claim "project" has invalid value "a:b"`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			command := filepath.Join(t.TempDir(), "missing")
+			if tc.script != "" {
+				mode := tc.mode
+				if mode == 0 {
+					mode = 0700
+				}
+				command = writeTestHelper(t, tc.script, mode)
+			}
+
+			req := &AuthExecCredentialRequest{
+				body:       []byte(tc.body),
+				uid:        1000,
+				gid:        1000,
+				signingKey: agentKey,
+				command:    command,
+				env:        tc.env,
+				timeout:    tc.timeout,
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_EXEC, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, sys.Group, "unexpected group")
+			test.CmpAny(t, "groups", tc.expGroups, sys.Groups)
+			test.CmpAny(t, "granted scopes", tc.expScopes, sys.GrantedScopes)
+			test.CmpAny(t, "pools", tc.expPools, sys.Pools)
+			if len(tc.expPools) > 0 {
+				test.CmpErr(t, nil, ValidateTokenPool(cred.Token, "", tc.expPools[0]))
+				test.CmpErr(t, errors.New("may not be used with pool"), ValidateTokenPool(cred.Token, "", "home"))
+			}
+			var claims []string
+			for _, claim := range sys.Claims {
+				claims = append(claims, ClaimPrincipal(claim))
+			}
+			test.CmpAny(t, "claims", tc.expClaims, claims)
+		})
+	}
+}
+
+func TestAuth_AuthExecCredentialRequest_GetKey(t *testing.T) {
+	req := func(uid uint32, body string) *AuthExecCredentialRequest {
+		return &AuthExecCredentialRequest{uid: uid, gid: 100, body: []byte(body)}
+	}
+
+	test.AssertEqual(t, req(1000, "ticket").GetKey(), req(1000, "ticket").GetKey(), "same request, different keys")
+	test.AssertTrue(t, req(1000, "ticket").GetKey() != req(1001, "ticket").GetKey(), "key not bound to uid")
+	test.AssertTrue(t, req(1000, "ticket").GetKey() != req(1000, "other").GetKey(), "key not bound to body")
+}
@@ -0,0 +1,354 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	// fido2AssertionType is the type of client data for an assertion, as
+	// opposed to a registration.
+	fido2AssertionType = "webauthn.get"
+
+	// Authenticator data flags (WebAuthn Level 2, section 6.1).
+	fido2FlagUserPresent  = 0x01
+	fido2FlagUserVerified = 0x04
+
+	// Authenticator data is the RP ID hash, flags and signature counter,
+	// followed by any extensions.
+	fido2AuthDataMinLen = sha256.Size + 1 + 4
+)
+
+type (
+	// AuthFIDO2CredentialFactory is a factory interface for AuthFIDO2CredentialRequests.
+	AuthFIDO2CredentialFactory struct {
+	}
+
+	// AuthFIDO2CredentialRequest defines the request parameters for GetSignedCredential for the AUTH_FIDO2 flavor.
+	AuthFIDO2CredentialRequest struct {
+		req        *FIDO2AuthReq
+		uid        uint32
+		signingKey crypto.PrivateKey
+		config     *security.FIDO2Config
+		challenges *Challenges
+		signCounts *fido2SignCounts
+	}
+
+	// fido2ClientData is the subset of the WebAuthn CollectedClientData
+	// checked by the agent.
+	fido2ClientData struct {
+		Type        string `json:"type"`
+		Challenge   string `json:"challenge"`
+		Origin      string `json:"origin"`
+		CrossOrigin bool   `json:"crossOrigin"`
+	}
+
+	// fido2Credential is a registered credential, along with the DAOS
+	// identity it maps to.
+	fido2Credential struct {
+		id    []byte
+		key   crypto.PublicKey
+		user  string
+		group string
+	}
+
+	// fido2SignCounts records the highest signature counter seen for each
+	// credential, in order to detect cloned authenticators.
+	fido2SignCounts struct {
+		sync.Mutex
+		counts map[string]uint32
+	}
+)
+
+var defaultFIDO2SignCounts = newFIDO2SignCounts()
+
+func newFIDO2SignCounts() *fido2SignCounts {
+	return &fido2SignCounts{counts: make(map[string]uint32)}
+}
+
+// update records the counter for the credential, returning an error if it has
+// not increased since the last assertion. Authenticators which do not
+// implement a counter always report zero.
+func (sc *fido2SignCounts) update(id []byte, count uint32) error {
+	sc.Lock()
+	defer sc.Unlock()
+
+	last, found := sc.counts[string(id)]
+	if found && (count != 0 || last != 0) && count <= last {
+		return errors.Errorf("signature counter %d is not greater than %d; the authenticator may have been cloned", count, last)
+	}
+	sc.counts[string(id)] = count
+	return nil
+}
+
+func (fac *AuthFIDO2CredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthFIDO2CredentialRequest{}
+
+	if secCfg == nil || secCfg.FIDO2Config.CredentialsFile == "" {
+		return req, drpc.NewFailureWithMessage("agent is not configured for FIDO2 authentication")
+	}
+
+	req.req = new(FIDO2AuthReq)
+	if err := proto.Unmarshal(reqBody, req.req); err != nil {
+		return req, drpc.UnmarshalingPayloadFailure()
+	}
+
+	uid, err := sessionUID(log, session)
+	if err != nil {
+		return req, err
+	}
+
+	req.uid = uid
+	req.signingKey = key
+	req.config = &secCfg.FIDO2Config
+	req.challenges = defaultChallenges
+	req.signCounts = defaultFIDO2SignCounts
+
+	return req, nil
+}
+
+func GetFIDO2Flavor() Flavor {
+	return Flavor_AUTH_FIDO2
+}
+
+func (fac AuthFIDO2CredentialFactory) GetAuthFlavor() Flavor {
+	return GetFIDO2Flavor()
+}
+
+func (req *AuthFIDO2CredentialRequest) GetAuthFlavor() Flavor {
+	return GetFIDO2Flavor()
+}
+
+// parseFIDO2Credentials parses a file of registered credentials. Each line
+// holds the base64url-encoded credential ID, the base64-encoded DER
+// SubjectPublicKeyInfo of the credential's public key, and the user (and
+// optionally group) it authenticates.
+//
+//	Kp2bV0dqzyHkqA... MFkwEwYHKoZIzj0CAQYI... jdoe:admins
+func parseFIDO2Credentials(data []byte) ([]*fido2Credential, error) {
+	var creds []*fido2Credential
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, errors.Errorf("line %d: expected credential ID, public key and user", lineNum)
+		}
+
+		cred := &fido2Credential{}
+		var err error
+		if cred.id, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(fields[0], "=")); err != nil {
+			return nil, errors.Wrapf(err, "line %d: credential ID", lineNum)
+		}
+		der, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d: public key", lineNum)
+		}
+		if cred.key, err = x509.ParsePKIXPublicKey(der); err != nil {
+			return nil, errors.Wrapf(err, "line %d: public key", lineNum)
+		}
+		switch cred.key.(type) {
+		case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+		default:
+			return nil, errors.Errorf("line %d: unsupported public key type %T", lineNum, cred.key)
+		}
+		cred.user, cred.group, _ = strings.Cut(fields[2], ":")
+		if cred.user == "" {
+			return nil, errors.Errorf("line %d: no user", lineNum)
+		}
+
+		creds = append(creds, cred)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return creds, nil
+}
+
+// registeredCredential returns the registered credential with the ID used in
+// the request.
+func (req *AuthFIDO2CredentialRequest) registeredCredential() (*fido2Credential, error) {
+	data, err := os.ReadFile(req.config.CredentialsFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading FIDO2 credentials")
+	}
+	creds, err := parseFIDO2Credentials(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", req.config.CredentialsFile)
+	}
+
+	for _, cred := range creds {
+		if bytes.Equal(cred.id, req.req.GetCredentialId()) {
+			return cred, nil
+		}
+	}
+	return nil, errors.New("FIDO2 credential is not registered")
+}
+
+// fido2VerifySignature checks an assertion signature, which covers the
+// authenticator data followed by the hash of the client data.
+func fido2VerifySignature(key crypto.PublicKey, authData, clientDataHash, sig []byte) error {
+	signed := append(append([]byte{}, authData...), clientDataHash...)
+	digest := sha256.Sum256(signed)
+
+	switch pub := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(pub, digest[:], sig) {
+			return errors.New("invalid ECDSA signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(pub, signed, sig) {
+			return errors.New("invalid ed25519 signature")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+			return errors.Wrap(err, "invalid RSA signature")
+		}
+	default:
+		return errors.Errorf("unsupported public key type %T", key)
+	}
+	return nil
+}
+
+// checkClientData checks the client data of the assertion and returns the
+// challenge it was made over.
+func (req *AuthFIDO2CredentialRequest) checkClientData() ([]byte, error) {
+	var cd fido2ClientData
+	if err := json.Unmarshal(req.req.GetClientDataJson(), &cd); err != nil {
+		return nil, errors.Wrap(err, "malformed FIDO2 client data")
+	}
+	if cd.Type != fido2AssertionType {
+		return nil, errors.Errorf("FIDO2 client data type %q is not %q", cd.Type, fido2AssertionType)
+	}
+	if cd.Origin != req.config.Origin || cd.CrossOrigin {
+		return nil, errors.Errorf("FIDO2 assertion was made for origin %q", cd.Origin)
+	}
+
+	challenge, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(cd.Challenge, "="))
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed FIDO2 challenge")
+	}
+	return challenge, nil
+}
+
+// checkAuthenticatorData checks the authenticator data of the assertion and
+// returns the signature counter.
+func (req *AuthFIDO2CredentialRequest) checkAuthenticatorData() (uint32, error) {
+	authData := req.req.GetAuthenticatorData()
+	if len(authData) < fido2AuthDataMinLen {
+		return 0, errors.New("malformed FIDO2 authenticator data")
+	}
+
+	rpIDHash := sha256.Sum256([]byte(req.config.RPID))
+	if !bytes.Equal(authData[:sha256.Size], rpIDHash[:]) {
+		return 0, errors.New("FIDO2 assertion was made for another relying party")
+	}
+	flags := authData[sha256.Size]
+	if flags&fido2FlagUserPresent == 0 {
+		return 0, errors.New("FIDO2 assertion was made without user presence")
+	}
+	if req.config.RequireUserVerification && flags&fido2FlagUserVerified == 0 {
+		return 0, errors.New("FIDO2 assertion was made without user verification")
+	}
+
+	return binary.BigEndian.Uint32(authData[sha256.Size+1:]), nil
+}
+
+// GetSignedCredential checks the client's WebAuthn assertion over the
+// challenge and returns a credential for the user the FIDO2 credential is
+// registered to.
+func (req *AuthFIDO2CredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	if len(req.req.GetCredentialId()) == 0 || len(req.req.GetSignature()) == 0 {
+		return nil, errors.New("no FIDO2 credential ID or signature supplied")
+	}
+
+	cred, err := req.registeredCredential()
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := req.checkClientData()
+	if err != nil {
+		return nil, err
+	}
+	signCount, err := req.checkAuthenticatorData()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the signature before consuming the challenge, so that an
+	// invalid request cannot burn a legitimate client's challenge.
+	clientDataHash := sha256.Sum256(req.req.GetClientDataJson())
+	if err := fido2VerifySignature(cred.key, req.req.GetAuthenticatorData(), clientDataHash[:], req.req.GetSignature()); err != nil {
+		return nil, err
+	}
+	if err := req.challenges.Redeem(challenge, req.uid); err != nil {
+		return nil, err
+	}
+	if err := req.signCounts.update(cred.id, signCount); err != nil {
+		log.Noticef("audit: FIDO2 credential for %s rejected: %s", cred.user, err)
+		return nil, err
+	}
+
+	hostname, err := GetMachineName()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get hostname")
+	}
+	sys := &Sys{
+		Machinename: hostname,
+		User:        sysNameToPrincipalName(cred.user),
+	}
+	if cred.group != "" {
+		sys.Group = sysNameToPrincipalName(cred.group)
+	}
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("uid %d: successfully signed credential for %s with FIDO2 assertion", req.uid, sys.User)
+	return credential, nil
+}
+
+// GetKey returns a cache key for the request. As the cache is consulted before
+// the challenge is redeemed, the key is bound to the requesting uid.
+func (req *AuthFIDO2CredentialRequest) GetKey() string {
+	data := binary.BigEndian.AppendUint32(nil, req.uid)
+	data = append(data, req.req.GetClientDataJson()...)
+	data = append(data, req.req.GetSignature()...)
+	return tokenCacheKey(req.GetAuthFlavor(), string(data))
+}
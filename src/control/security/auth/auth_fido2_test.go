@@ -0,0 +1,428 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	testFIDO2RPID   = "example.com"
+	testFIDO2Origin = "https://daos.example.com"
+)
+
+// testFIDO2Assertion holds the parameters of an assertion made by an
+// authenticator in a test.
+type testFIDO2Assertion struct {
+	rpID        string
+	origin      string
+	typ         string
+	crossOrigin bool
+	flags       byte
+	signCount   uint32
+}
+
+func newTestFIDO2Assertion() *testFIDO2Assertion {
+	return &testFIDO2Assertion{
+		rpID:   testFIDO2RPID,
+		origin: testFIDO2Origin,
+		typ:    fido2AssertionType,
+		flags:  fido2FlagUserPresent | fido2FlagUserVerified,
+	}
+}
+
+// testFIDO2Sign produces a WebAuthn assertion over the challenge, as a
+// security key and browser would.
+func testFIDO2Sign(t *testing.T, key crypto.Signer, credID, challenge []byte, a *testFIDO2Assertion) *FIDO2AuthReq {
+	t.Helper()
+
+	clientData, err := json.Marshal(map[string]interface{}{
+		"type":        a.typ,
+		"challenge":   base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":      a.origin,
+		"crossOrigin": a.crossOrigin,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rpIDHash := sha256.Sum256([]byte(a.rpID))
+	authData := append(rpIDHash[:], a.flags)
+	authData = binary.BigEndian.AppendUint32(authData, a.signCount)
+
+	clientDataHash := sha256.Sum256(clientData)
+	signed := append(append([]byte{}, authData...), clientDataHash[:]...)
+	digest := sha256.Sum256(signed)
+
+	var sig []byte
+	switch priv := key.(type) {
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(priv, signed)
+	case *ecdsa.PrivateKey:
+		sig, err = ecdsa.SignASN1(rand.Reader, priv, digest[:])
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA256, digest[:])
+	default:
+		t.Fatalf("unsupported key type %T", key)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &FIDO2AuthReq{
+		CredentialId:      credID,
+		ClientDataJson:    clientData,
+		AuthenticatorData: authData,
+		Signature:         sig,
+	}
+}
+
+func testFIDO2CredentialLine(t *testing.T, credID []byte, key crypto.PublicKey, identity string) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fmt.Sprintf("%s %s %s\n", base64.RawURLEncoding.EncodeToString(credID),
+		base64.StdEncoding.EncodeToString(der), identity)
+}
+
+func TestAuth_fido2SignCounts_update(t *testing.T) {
+	for name, tc := range map[string]struct {
+		counts []uint32
+		expErr error
+	}{
+		"first use": {
+			counts: []uint32{5},
+		},
+		"increasing": {
+			counts: []uint32{1, 2, 10},
+		},
+		"no counter": {
+			counts: []uint32{0, 0, 0},
+		},
+		"repeated": {
+			counts: []uint32{3, 3},
+			expErr: errors.New("may have been cloned"),
+		},
+		"decreasing": {
+			counts: []uint32{3, 2},
+			expErr: errors.New("may have been cloned"),
+		},
+		"reset to zero": {
+			counts: []uint32{3, 0},
+			expErr: errors.New("may have been cloned"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sc := newFIDO2SignCounts()
+			var err error
+			for _, count := range tc.counts {
+				if err = sc.update([]byte("cred"), count); err != nil {
+					break
+				}
+			}
+			test.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestAuth_parseFIDO2Credentials(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey := base64.StdEncoding.EncodeToString(der)
+
+	for name, tc := range map[string]struct {
+		data     string
+		expUser  string
+		expGroup string
+		expErr   error
+	}{
+		"user and group": {
+			data:     fmt.Sprintf("# comment\n\nY3JlZA %s jdoe:admins\n", pubKey),
+			expUser:  "jdoe",
+			expGroup: "admins",
+		},
+		"padded credential ID": {
+			data:    fmt.Sprintf("Y3JlZA== %s jdoe", pubKey),
+			expUser: "jdoe",
+		},
+		"missing user": {
+			data:   fmt.Sprintf("Y3JlZA %s", pubKey),
+			expErr: errors.New("line 1: expected credential ID"),
+		},
+		"empty user": {
+			data:   fmt.Sprintf("Y3JlZA %s :admins", pubKey),
+			expErr: errors.New("no user"),
+		},
+		"bad public key": {
+			data:   "Y3JlZA AAAA jdoe",
+			expErr: errors.New("public key"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			creds, err := parseFIDO2Credentials([]byte(tc.data))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, 1, len(creds), "unexpected number of credentials")
+			test.AssertEqual(t, "cred", string(creds[0].id), "unexpected credential ID")
+			test.AssertEqual(t, tc.expUser, creds[0].user, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, creds[0].group, "unexpected group")
+		})
+	}
+}
+
+func TestAuth_AuthFIDO2CredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecID, edID := []byte("ec-credential"), []byte("ed-credential")
+
+	credFile := filepath.Join(t.TempDir(), "fido2_credentials")
+	credData := testFIDO2CredentialLine(t, ecID, ecKey.Public(), "jdoe:admins") +
+		testFIDO2CredentialLine(t, edID, edKey.Public(), "root")
+	if err := os.WriteFile(credFile, []byte(credData), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	const uid = 1000
+	challenges := NewChallenges(ChallengeLifetime)
+	newChallenge := func() []byte {
+		challenge, err := challenges.Issue(uid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return challenge
+	}
+
+	for name, tc := range map[string]struct {
+		req       func() *FIDO2AuthReq
+		requireUV bool
+		expUser   string
+		expGroup  string
+		expErr    error
+	}{
+		"no signature": {
+			req: func() *FIDO2AuthReq {
+				return &FIDO2AuthReq{CredentialId: ecID}
+			},
+			expErr: errors.New("no FIDO2 credential ID or signature"),
+		},
+		"unregistered credential": {
+			req: func() *FIDO2AuthReq {
+				return testFIDO2Sign(t, ecKey, []byte("other"), newChallenge(), newTestFIDO2Assertion())
+			},
+			expErr: errors.New("not registered"),
+		},
+		"signature by another key": {
+			req: func() *FIDO2AuthReq {
+				return testFIDO2Sign(t, edKey, ecID, newChallenge(), newTestFIDO2Assertion())
+			},
+			expErr: errors.New("invalid ECDSA signature"),
+		},
+		"registration rather than assertion": {
+			req: func() *FIDO2AuthReq {
+				a := newTestFIDO2Assertion()
+				a.typ = "webauthn.create"
+				return testFIDO2Sign(t, ecKey, ecID, newChallenge(), a)
+			},
+			expErr: errors.New("is not \"webauthn.get\""),
+		},
+		"wrong origin": {
+			req: func() *FIDO2AuthReq {
+				a := newTestFIDO2Assertion()
+				a.origin = "https://phish.example.net"
+				return testFIDO2Sign(t, ecKey, ecID, newChallenge(), a)
+			},
+			expErr: errors.New("made for origin"),
+		},
+		"cross origin": {
+			req: func() *FIDO2AuthReq {
+				a := newTestFIDO2Assertion()
+				a.crossOrigin = true
+				return testFIDO2Sign(t, ecKey, ecID, newChallenge(), a)
+			},
+			expErr: errors.New("made for origin"),
+		},
+		"wrong relying party": {
+			req: func() *FIDO2AuthReq {
+				a := newTestFIDO2Assertion()
+				a.rpID = "example.net"
+				return testFIDO2Sign(t, ecKey, ecID, newChallenge(), a)
+			},
+			expErr: errors.New("another relying party"),
+		},
+		"user not present": {
+			req: func() *FIDO2AuthReq {
+				a := newTestFIDO2Assertion()
+				a.flags = fido2FlagUserVerified
+				return testFIDO2Sign(t, ecKey, ecID, newChallenge(), a)
+			},
+			expErr: errors.New("without user presence"),
+		},
+		"user not verified": {
+			req: func() *FIDO2AuthReq {
+				a := newTestFIDO2Assertion()
+				a.flags = fido2FlagUserPresent
+				return testFIDO2Sign(t, ecKey, ecID, newChallenge(), a)
+			},
+			requireUV: true,
+			expErr:    errors.New("without user verification"),
+		},
+		"forged challenge": {
+			req: func() *FIDO2AuthReq {
+				return testFIDO2Sign(t, ecKey, ecID, make([]byte, challengeLen), newTestFIDO2Assertion())
+			},
+			expErr: errors.New("not issued by this agent"),
+		},
+		"success without user verification": {
+			req: func() *FIDO2AuthReq {
+				a := newTestFIDO2Assertion()
+				a.flags = fido2FlagUserPresent
+				return testFIDO2Sign(t, ecKey, ecID, newChallenge(), a)
+			},
+			expUser:  "jdoe@",
+			expGroup: "admins@",
+		},
+		"success ed25519": {
+			req: func() *FIDO2AuthReq {
+				return testFIDO2Sign(t, edKey, edID, newChallenge(), newTestFIDO2Assertion())
+			},
+			requireUV: true,
+			expUser:   "root@",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthFIDO2CredentialRequest{
+				req:        tc.req(),
+				uid:        uid,
+				signingKey: agentKey,
+				config: &security.FIDO2Config{
+					RPID:                    testFIDO2RPID,
+					Origin:                  testFIDO2Origin,
+					CredentialsFile:         credFile,
+					RequireUserVerification: tc.requireUV,
+				},
+				challenges: challenges,
+				signCounts: newFIDO2SignCounts(),
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_FIDO2, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, sys.Group, "unexpected group")
+
+			// The challenge cannot be used to obtain another credential.
+			_, err = req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, errors.New("already been used"), err)
+		})
+	}
+}
+
+func TestAuth_AuthFIDO2CredentialRequest_ClonedAuthenticator(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	credID := []byte("credential")
+
+	credFile := filepath.Join(t.TempDir(), "fido2_credentials")
+	if err := os.WriteFile(credFile, []byte(testFIDO2CredentialLine(t, credID, ecKey.Public(), "jdoe")), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	const uid = 1000
+	challenges := NewChallenges(ChallengeLifetime)
+	signCounts := newFIDO2SignCounts()
+	getCred := func(signCount uint32) error {
+		challenge, err := challenges.Issue(uid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a := newTestFIDO2Assertion()
+		a.signCount = signCount
+		req := &AuthFIDO2CredentialRequest{
+			req:        testFIDO2Sign(t, ecKey, credID, challenge, a),
+			uid:        uid,
+			signingKey: agentKey,
+			config: &security.FIDO2Config{
+				RPID:            testFIDO2RPID,
+				Origin:          testFIDO2Origin,
+				CredentialsFile: credFile,
+			},
+			challenges: challenges,
+			signCounts: signCounts,
+		}
+		_, err = req.GetSignedCredential(log, test.Context(t))
+		return err
+	}
+
+	test.CmpErr(t, nil, getCred(7))
+	test.CmpErr(t, nil, getCred(8))
+	test.CmpErr(t, errors.New("may have been cloned"), getCred(8))
+}
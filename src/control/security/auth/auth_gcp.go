@@ -0,0 +1,137 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const gcpRealm = "gcp"
+
+type (
+	// AuthGCPCredentialFactory is a factory interface for AuthGCPCredentialRequests.
+	AuthGCPCredentialFactory struct {
+	}
+
+	// AuthGCPCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_GCP flavor.
+	AuthGCPCredentialRequest struct {
+		idToken        string
+		signingKey     crypto.PrivateKey
+		allowedDomains []string
+		identityMap    security.ExternalIdentityMap
+		caseFold       security.CaseFoldPolicy
+		verifier       *jwtVerifier
+	}
+)
+
+func (fac *AuthGCPCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthGCPCredentialRequest{}
+
+	if secCfg == nil || len(secCfg.GCPConfig.Audiences) == 0 {
+		return req, drpc.NewFailureWithMessage("agent is not configured for GCP authentication")
+	}
+
+	gcpCfg := &secCfg.GCPConfig
+	req.idToken = strings.TrimSpace(string(reqBody))
+	req.signingKey = key
+	req.allowedDomains = gcpCfg.AllowedDomains
+	req.identityMap = gcpCfg.IdentityMap
+	req.caseFold = secCfg.PrincipalCaseFold
+	req.verifier = &jwtVerifier{
+		issuers:   gcpCfg.Issuers(),
+		audiences: gcpCfg.Audiences,
+		keys:      getRemoteJWKS(gcpCfg.KeySetURL()),
+	}
+
+	return req, nil
+}
+
+func GetGCPFlavor() Flavor {
+	return Flavor_AUTH_GCP
+}
+
+func (fac AuthGCPCredentialFactory) GetAuthFlavor() Flavor {
+	return GetGCPFlavor()
+}
+
+func (req *AuthGCPCredentialRequest) GetAuthFlavor() Flavor {
+	return GetGCPFlavor()
+}
+
+// verifiedEmail returns the email address asserted by the token if Google has
+// verified it and it belongs to an allowed domain.
+func (req *AuthGCPCredentialRequest) verifiedEmail(claims jwtClaims) (string, error) {
+	email := claims.stringClaim("email")
+	if email == "" || !claims.boolClaim("email_verified") {
+		return "", nil
+	}
+
+	if len(req.allowedDomains) > 0 {
+		domain := email[strings.LastIndex(email, "@")+1:]
+		if !slices.Contains(req.allowedDomains, domain) {
+			return "", errors.Errorf("GCP identity %q is not in an allowed domain", email)
+		}
+	}
+
+	return email, nil
+}
+
+// GetSignedCredential validates the Google-signed ID token and returns a
+// credential for the service account or instance identity it asserts.
+func (req *AuthGCPCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	if req.idToken == "" {
+		return nil, errors.New("no GCP ID token supplied")
+	}
+
+	claims, err := req.verifier.Verify(ctx, req.idToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid GCP ID token")
+	}
+
+	email, err := req.verifiedEmail(claims)
+	if err != nil {
+		return nil, err
+	}
+	if email == "" && len(req.allowedDomains) > 0 {
+		return nil, errors.New("GCP ID token has no verified email")
+	}
+
+	// Service account emails are already domain-qualified and are used as the
+	// principal name directly; otherwise fall back to the subject ID.
+	sub := claims.stringClaim("sub")
+	id := email
+	if id == "" {
+		id = sub
+	}
+
+	sys, err := externalIdentitySys(gcpRealm, req.caseFold, req.identityMap, id, nil, sub)
+	if err != nil {
+		return nil, err
+	}
+	addIdentity(sys, IdentityOIDC, sub+"@accounts.google.com")
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("%s: successfully signed credential for %s", claims, sys.User)
+	return credential, nil
+}
+
+func (req *AuthGCPCredentialRequest) GetKey() string {
+	return tokenCacheKey(req.GetAuthFlavor(), req.idToken)
+}
@@ -0,0 +1,142 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_AuthGCPCredentialRequest_GetSignedCredential(t *testing.T) {
+	tokenKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gcpClaims := func(extra map[string]interface{}) map[string]interface{} {
+		claims := map[string]interface{}{
+			"iss": "https://accounts.google.com",
+			"aud": "daos",
+			"sub": "1234567890",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+		for k, v := range extra {
+			claims[k] = v
+		}
+		return claims
+	}
+	svcAcct := map[string]interface{}{
+		"email":          "svc@project.iam.gserviceaccount.com",
+		"email_verified": true,
+	}
+
+	for name, tc := range map[string]struct {
+		token          string
+		allowedDomains []string
+		identityMap    security.ExternalIdentityMap
+		expUser        string
+		expGroup       string
+		expErr         error
+	}{
+		"no token": {
+			expErr: errors.New("no GCP ID token"),
+		},
+		"wrong issuer": {
+			token:  testSignJWT(t, "RS256", "k", tokenKey, gcpClaims(map[string]interface{}{"iss": "https://evil.example"})),
+			expErr: errors.New("not trusted"),
+		},
+		"service account email": {
+			token:   testSignJWT(t, "RS256", "k", tokenKey, gcpClaims(svcAcct)),
+			expUser: "svc@project.iam.gserviceaccount.com",
+		},
+		"unverified email falls back to subject": {
+			token: testSignJWT(t, "RS256", "k", tokenKey, gcpClaims(map[string]interface{}{
+				"email": "svc@project.iam.gserviceaccount.com",
+			})),
+			expUser: "1234567890@gcp",
+		},
+		"mapped identity": {
+			token: testSignJWT(t, "RS256", "k", tokenKey, gcpClaims(svcAcct)),
+			identityMap: security.ExternalIdentityMap{
+				"svc@project.iam.gserviceaccount.com": {User: "svc", Group: "daos"},
+			},
+			expUser:  "svc@",
+			expGroup: "daos@",
+		},
+		"unnormalized email": {
+			token: testSignJWT(t, "RS256", "k", tokenKey, gcpClaims(map[string]interface{}{
+				"email":          "\uff53vc@B\u00fccher.example", // fullwidth "s"
+				"email_verified": true,
+			})),
+			identityMap: security.ExternalIdentityMap{
+				"svc@xn--bcher-kva.example": {User: "svc"},
+			},
+			expUser: "svc@",
+		},
+		"allowed domain": {
+			token:          testSignJWT(t, "RS256", "k", tokenKey, gcpClaims(svcAcct)),
+			allowedDomains: []string{"project.iam.gserviceaccount.com"},
+			expUser:        "svc@project.iam.gserviceaccount.com",
+		},
+		"disallowed domain": {
+			token:          testSignJWT(t, "RS256", "k", tokenKey, gcpClaims(svcAcct)),
+			allowedDomains: []string{"example.com"},
+			expErr:         errors.New("not in an allowed domain"),
+		},
+		"domain restriction without email": {
+			token:          testSignJWT(t, "RS256", "k", tokenKey, gcpClaims(nil)),
+			allowedDomains: []string{"example.com"},
+			expErr:         errors.New("no verified email"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthGCPCredentialRequest{
+				idToken:        tc.token,
+				signingKey:     agentKey,
+				allowedDomains: tc.allowedDomains,
+				identityMap:    tc.identityMap,
+				verifier: &jwtVerifier{
+					issuers:   (&security.GCPConfig{}).Issuers(),
+					audiences: []string{"daos"},
+					keys:      staticJWKS{"k": &tokenKey.PublicKey},
+				},
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_GCP, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, sys.Group, "unexpected group")
+		})
+	}
+}
@@ -0,0 +1,225 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	keystoneRealm = "keystone"
+
+	// keystoneTokensPath is the Identity API v3 token validation endpoint. The
+	// service catalog is not needed, and may be large.
+	keystoneTokensPath      = "/v3/auth/tokens?nocatalog"
+	maxKeystoneResponseSize = 1 << 20
+)
+
+type (
+	// AuthKeystoneCredentialFactory is a factory interface for AuthKeystoneCredentialRequests.
+	AuthKeystoneCredentialFactory struct {
+	}
+
+	// AuthKeystoneCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_KEYSTONE flavor.
+	AuthKeystoneCredentialRequest struct {
+		keystoneToken string
+		signingKey    crypto.PrivateKey
+		authURL       string
+		requiredRoles []string
+		projectGroups map[string]string
+		identityMap   security.ExternalIdentityMap
+		caseFold      security.CaseFoldPolicy
+		client        *http.Client
+		now           func() time.Time
+	}
+
+	keystoneRef struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	keystoneScopedRef struct {
+		keystoneRef
+		Domain keystoneRef `json:"domain"`
+	}
+
+	// keystoneTokenInfo is the subset of a Keystone token validation response
+	// used to determine the identity of the token holder.
+	keystoneTokenInfo struct {
+		ExpiresAt time.Time          `json:"expires_at"`
+		User      keystoneScopedRef  `json:"user"`
+		Project   *keystoneScopedRef `json:"project"`
+		Roles     []keystoneRef      `json:"roles"`
+	}
+)
+
+func (fac *AuthKeystoneCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthKeystoneCredentialRequest{}
+
+	if secCfg == nil || secCfg.KeystoneConfig.AuthURL == "" {
+		return req, drpc.NewFailureWithMessage("agent is not configured for Keystone authentication")
+	}
+
+	ksCfg := &secCfg.KeystoneConfig
+	client, err := getCAClient(ksCfg.CACert)
+	if err != nil {
+		return req, err
+	}
+
+	req.keystoneToken = strings.TrimSpace(string(reqBody))
+	req.signingKey = key
+	req.authURL = strings.TrimSuffix(strings.TrimSuffix(ksCfg.AuthURL, "/"), "/v3")
+	req.requiredRoles = ksCfg.RequiredRoles
+	req.projectGroups = ksCfg.ProjectGroups
+	req.identityMap = ksCfg.IdentityMap
+	req.caseFold = secCfg.PrincipalCaseFold
+	req.client = client
+	req.now = time.Now
+
+	return req, nil
+}
+
+func GetKeystoneFlavor() Flavor {
+	return Flavor_AUTH_KEYSTONE
+}
+
+func (fac AuthKeystoneCredentialFactory) GetAuthFlavor() Flavor {
+	return GetKeystoneFlavor()
+}
+
+func (req *AuthKeystoneCredentialRequest) GetAuthFlavor() Flavor {
+	return GetKeystoneFlavor()
+}
+
+// qualifiedName returns the name qualified with its domain, as names are only
+// unique within a Keystone domain.
+func (r *keystoneScopedRef) qualifiedName() string {
+	domain := r.Domain.Name
+	if domain == "" {
+		domain = r.Domain.ID
+	}
+	return r.Name + "@" + domain
+}
+
+// validateToken asks Keystone to validate the client's token. The request is
+// authorized with the client's own token, which Keystone permits for the
+// token's subject, so the agent needs no Keystone credentials of its own.
+func (req *AuthKeystoneCredentialRequest) validateToken(ctx context.Context) (*keystoneTokenInfo, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, req.authURL+keystoneTokensPath, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Keystone token validation request")
+	}
+	httpReq.Header.Set("X-Auth-Token", req.keystoneToken)
+	httpReq.Header.Set("X-Subject-Token", req.keystoneToken)
+
+	resp, err := req.client.Do(httpReq)
+	if err != nil {
+		return nil, errors.Wrapf(err, "validating Keystone token at %q", req.authURL)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("validating Keystone token at %q: unexpected status code %d", req.authURL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxKeystoneResponseSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading Keystone token validation response")
+	}
+
+	var validation struct {
+		Token *keystoneTokenInfo `json:"token"`
+	}
+	if err := json.Unmarshal(body, &validation); err != nil {
+		return nil, errors.Wrap(err, "parsing Keystone token validation response")
+	}
+	if validation.Token == nil {
+		return nil, errors.New("Keystone token validation response contained no token")
+	}
+	if !validation.Token.ExpiresAt.After(req.now()) {
+		return nil, errors.Errorf("Keystone token expired at %s", validation.Token.ExpiresAt)
+	}
+
+	return validation.Token, nil
+}
+
+// roles returns the names of the roles granted to the token holder.
+func (info *keystoneTokenInfo) roles() []string {
+	var roles []string
+	for _, r := range info.Roles {
+		if r.Name != "" && !slices.Contains(roles, r.Name) {
+			roles = append(roles, r.Name)
+		}
+	}
+	return roles
+}
+
+// GetSignedCredential validates the Keystone token and returns a credential
+// for the user it belongs to. The token's project is included as a group,
+// or as the primary group it is configured to map to.
+func (req *AuthKeystoneCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	if req.keystoneToken == "" {
+		return nil, errors.New("no Keystone token supplied")
+	}
+
+	info, err := req.validateToken(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid Keystone token")
+	}
+
+	roles := info.roles()
+	if len(req.requiredRoles) > 0 && !slices.ContainsFunc(roles, func(r string) bool {
+		return slices.Contains(req.requiredRoles, r)
+	}) {
+		return nil, errors.Errorf("Keystone token has none of the required roles %v", req.requiredRoles)
+	}
+
+	var groups []string
+	if info.Project != nil {
+		groups = append(groups, info.Project.qualifiedName())
+	}
+
+	sys, err := externalIdentitySys(keystoneRealm, req.caseFold, req.identityMap, info.User.qualifiedName(), groups, info.User.ID)
+	if err != nil {
+		return nil, err
+	}
+	if info.Project != nil && sys.Group == "" {
+		for _, key := range []string{info.Project.qualifiedName(), info.Project.ID} {
+			if group, found := req.projectGroups[key]; found {
+				sys.Group = sysNameToPrincipalName(group)
+				break
+			}
+		}
+	}
+	sys.GrantedScopes = roles
+	addIdentity(sys, IdentityKeystone, info.User.qualifiedName())
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("Keystone user %q: successfully signed credential for %s", info.User.ID, sys.User)
+	return credential, nil
+}
+
+func (req *AuthKeystoneCredentialRequest) GetKey() string {
+	return tokenCacheKey(req.GetAuthFlavor(), req.keystoneToken)
+}
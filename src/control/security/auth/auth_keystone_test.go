@@ -0,0 +1,184 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_AuthKeystoneCredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	user := keystoneScopedRef{
+		keystoneRef: keystoneRef{ID: "u-1", Name: "jdoe"},
+		Domain:      keystoneRef{ID: "default", Name: "Default"},
+	}
+	project := &keystoneScopedRef{
+		keystoneRef: keystoneRef{ID: "p-1", Name: "hpc"},
+		Domain:      keystoneRef{ID: "d-1", Name: "research"},
+	}
+	tokens := map[string]*keystoneTokenInfo{
+		"project-scoped": {
+			ExpiresAt: now.Add(time.Hour),
+			User:      user,
+			Project:   project,
+			Roles:     []keystoneRef{{ID: "r-1", Name: "member"}, {ID: "r-2", Name: "reader"}},
+		},
+		"unscoped": {
+			ExpiresAt: now.Add(time.Hour),
+			User:      user,
+		},
+		"expired": {
+			ExpiresAt: now.Add(-time.Minute),
+			User:      user,
+		},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/auth/tokens" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("X-Auth-Token") != r.Header.Get("X-Subject-Token") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		info, found := tokens[r.Header.Get("X-Subject-Token")]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{"token": info}); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+
+	for name, tc := range map[string]struct {
+		token         string
+		requiredRoles []string
+		projectGroups map[string]string
+		identityMap   security.ExternalIdentityMap
+		expUser       string
+		expGroup      string
+		expGroups     []string
+		expScopes     []string
+		expErr        error
+	}{
+		"no token": {
+			expErr: errors.New("no Keystone token"),
+		},
+		"revoked token": {
+			token:  "revoked",
+			expErr: errors.New("status code 404"),
+		},
+		"expired token": {
+			token:  "expired",
+			expErr: errors.New("Keystone token expired"),
+		},
+		"project-scoped": {
+			token:     "project-scoped",
+			expUser:   "jdoe@default",
+			expGroups: []string{"hpc@research"},
+			expScopes: []string{"member", "reader"},
+		},
+		"unscoped": {
+			token:   "unscoped",
+			expUser: "jdoe@default",
+		},
+		"project mapped to group": {
+			token:         "project-scoped",
+			projectGroups: map[string]string{"hpc@research": "hpc"},
+			expUser:       "jdoe@default",
+			expGroup:      "hpc@",
+			expGroups:     []string{"hpc@research"},
+			expScopes:     []string{"member", "reader"},
+		},
+		"project ID mapped to group": {
+			token:         "project-scoped",
+			projectGroups: map[string]string{"p-1": "hpc"},
+			expUser:       "jdoe@default",
+			expGroup:      "hpc@",
+			expGroups:     []string{"hpc@research"},
+			expScopes:     []string{"member", "reader"},
+		},
+		"mapped user": {
+			token: "project-scoped",
+			identityMap: security.ExternalIdentityMap{
+				"u-1": {User: "jdoe", Group: "staff"},
+			},
+			projectGroups: map[string]string{"p-1": "hpc"},
+			expUser:       "jdoe@",
+			expGroup:      "staff@",
+			expScopes:     []string{"member", "reader"},
+		},
+		"required role held": {
+			token:         "project-scoped",
+			requiredRoles: []string{"admin", "member"},
+			expUser:       "jdoe@default",
+			expGroups:     []string{"hpc@research"},
+			expScopes:     []string{"member", "reader"},
+		},
+		"required role not held": {
+			token:         "unscoped",
+			requiredRoles: []string{"member"},
+			expErr:        errors.New("none of the required roles"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthKeystoneCredentialRequest{
+				keystoneToken: tc.token,
+				signingKey:    agentKey,
+				authURL:       srv.URL,
+				requiredRoles: tc.requiredRoles,
+				projectGroups: tc.projectGroups,
+				identityMap:   tc.identityMap,
+				client:        srv.Client(),
+				now:           time.Now,
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_KEYSTONE, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, sys.Group, "unexpected group")
+			test.CmpAny(t, "groups", tc.expGroups, sys.Groups)
+			test.CmpAny(t, "granted scopes", tc.expScopes, sys.GrantedScopes)
+			test.CmpAny(t, "identities", []*Identity{{Type: IdentityKeystone, Name: "jdoe@Default"}},
+				sys.Identities, test.DefaultCmpOpts()...)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// First-party caveat conditions understood by the agent. Each caveat is of
+// the form "<condition> <argument>".
+const (
+	macaroonCaveatTimeBefore = "time-before" // RFC 3339 time at which the macaroon expires
+	macaroonCaveatSystem     = "daos-system" // DAOS system the macaroon may be used with
+	macaroonCaveatUser       = "daos-user"   // user the macaroon authenticates
+	macaroonCaveatGroup      = "daos-group"  // primary group of the user
+	macaroonCaveatPools      = "daos-pools"  // comma-separated pools the credential may be used with
+
+	// macaroonMinRootKeyLen is the shortest root key accepted.
+	macaroonMinRootKeyLen = 16
+)
+
+type (
+	// AuthMacaroonCredentialFactory is a factory interface for AuthMacaroonCredentialRequests.
+	AuthMacaroonCredentialFactory struct {
+	}
+
+	// AuthMacaroonCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_MACAROON flavor.
+	AuthMacaroonCredentialRequest struct {
+		macaroon    []byte
+		signingKey  crypto.PrivateKey
+		rootKeyFile string
+		system      string
+		now         func() time.Time
+	}
+
+	// macaroonGrant is the access granted by the caveats of a macaroon.
+	macaroonGrant struct {
+		user    string
+		group   string
+		pools   []string // nil if the macaroon is not restricted to any pools
+		expires time.Time
+	}
+)
+
+func (fac *AuthMacaroonCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthMacaroonCredentialRequest{}
+
+	if secCfg == nil || secCfg.MacaroonConfig.RootKeyFile == "" {
+		return req, drpc.NewFailureWithMessage("agent is not configured for macaroon authentication")
+	}
+
+	req.macaroon = bytes.TrimSpace(reqBody)
+	req.signingKey = key
+	req.rootKeyFile = secCfg.MacaroonConfig.RootKeyFile
+	req.system = secCfg.MacaroonConfig.SystemName
+	req.now = time.Now
+
+	return req, nil
+}
+
+func GetMacaroonFlavor() Flavor {
+	return Flavor_AUTH_MACAROON
+}
+
+func (fac AuthMacaroonCredentialFactory) GetAuthFlavor() Flavor {
+	return GetMacaroonFlavor()
+}
+
+func (req *AuthMacaroonCredentialRequest) GetAuthFlavor() Flavor {
+	return GetMacaroonFlavor()
+}
+
+func (req *AuthMacaroonCredentialRequest) rootKey() ([]byte, error) {
+	data, err := os.ReadFile(req.rootKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading macaroon root key")
+	}
+	key := bytes.TrimSpace(data)
+	if len(key) < macaroonMinRootKeyLen {
+		return nil, errors.Errorf("macaroon root key must be at least %d bytes", macaroonMinRootKeyLen)
+	}
+	return key, nil
+}
+
+// checkMacaroonCaveats checks that all of the caveats are satisfied, and
+// returns the access they grant. As caveats can only be added to a macaroon,
+// repeated caveats may only narrow the access granted.
+func checkMacaroonCaveats(caveats []macaroonCaveat, now time.Time, system string) (*macaroonGrant, error) {
+	grant := &macaroonGrant{}
+
+	for _, c := range caveats {
+		cond, arg, _ := strings.Cut(string(c.id), " ")
+		arg = strings.TrimSpace(arg)
+		if arg == "" {
+			return nil, errors.Errorf("macaroon caveat %q has no argument", c.id)
+		}
+
+		switch cond {
+		case macaroonCaveatTimeBefore:
+			expires, err := time.Parse(time.RFC3339, arg)
+			if err != nil {
+				return nil, errors.Wrapf(err, "macaroon caveat %q", c.id)
+			}
+			if !now.Before(expires) {
+				return nil, errors.Errorf("macaroon expired at %s", expires)
+			}
+			if grant.expires.IsZero() || expires.Before(grant.expires) {
+				grant.expires = expires
+			}
+		case macaroonCaveatSystem:
+			if arg != system {
+				return nil, errors.Errorf("macaroon is restricted to system %q", arg)
+			}
+		case macaroonCaveatUser:
+			if grant.user != "" && grant.user != arg {
+				return nil, errors.Errorf("conflicting %s caveats", cond)
+			}
+			grant.user = arg
+		case macaroonCaveatGroup:
+			if grant.group != "" && grant.group != arg {
+				return nil, errors.Errorf("conflicting %s caveats", cond)
+			}
+			grant.group = arg
+		case macaroonCaveatPools:
+			pools := strings.Split(arg, ",")
+			if grant.pools != nil {
+				pools = slices.DeleteFunc(pools, func(p string) bool {
+					return !slices.Contains(grant.pools, p)
+				})
+				if len(pools) == 0 {
+					return nil, errors.New("macaroon caveats permit no pools")
+				}
+			}
+			grant.pools = pools
+		default:
+			return nil, errors.Errorf("unsatisfied macaroon caveat %q", c.id)
+		}
+	}
+
+	if grant.expires.IsZero() {
+		return nil, errors.Errorf("macaroon has no %s caveat", macaroonCaveatTimeBefore)
+	}
+	if grant.user == "" {
+		return nil, errors.Errorf("macaroon has no %s caveat", macaroonCaveatUser)
+	}
+
+	return grant, nil
+}
+
+// GetSignedCredential verifies the macaroon and its caveats, and returns a
+// credential for the user it authenticates, restricted to the pools it allows.
+func (req *AuthMacaroonCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	if len(req.macaroon) == 0 {
+		return nil, errors.New("no macaroon supplied")
+	}
+
+	m, err := decodeMacaroon(req.macaroon)
+	if err != nil {
+		return nil, err
+	}
+	rootKey, err := req.rootKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := m.verify(rootKey); err != nil {
+		return nil, err
+	}
+	grant, err := checkMacaroonCaveats(m.caveats, req.now(), req.system)
+	if err != nil {
+		return nil, err
+	}
+
+	hostname, err := GetMachineName()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get hostname")
+	}
+	sys := &Sys{
+		Machinename: hostname,
+		User:        sysNameToPrincipalName(grant.user),
+		Pools:       grant.pools,
+	}
+	if grant.group != "" {
+		sys.Group = sysNameToPrincipalName(grant.group)
+	}
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("macaroon %q: successfully signed credential for %s (pools: %v, expires: %s)",
+		m.id, sys.User, sys.Pools, grant.expires)
+	return credential, nil
+}
+
+func (req *AuthMacaroonCredentialRequest) GetKey() string {
+	return tokenCacheKey(req.GetAuthFlavor(), string(req.macaroon))
+}
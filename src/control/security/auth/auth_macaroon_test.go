@@ -0,0 +1,210 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestAuth_checkMacaroonCaveats(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	expiry := "time-before 2025-06-01T13:00:00Z"
+
+	for name, tc := range map[string]struct {
+		caveats  []string
+		expGrant *macaroonGrant
+		expErr   error
+	}{
+		"user and expiry": {
+			caveats: []string{"daos-user jdoe", expiry},
+			expGrant: &macaroonGrant{
+				user:    "jdoe",
+				expires: time.Date(2025, 6, 1, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		"earliest expiry wins": {
+			caveats: []string{"daos-user jdoe", expiry, "time-before 2025-06-01T12:30:00Z"},
+			expGrant: &macaroonGrant{
+				user:    "jdoe",
+				expires: time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC),
+			},
+		},
+		"pools narrowed": {
+			caveats: []string{"daos-user jdoe", "daos-group hpc", expiry, "daos-pools tank,scratch,home", "daos-pools scratch,home,other"},
+			expGrant: &macaroonGrant{
+				user:    "jdoe",
+				group:   "hpc",
+				pools:   []string{"scratch", "home"},
+				expires: time.Date(2025, 6, 1, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		"disjoint pools": {
+			caveats: []string{"daos-user jdoe", expiry, "daos-pools tank", "daos-pools scratch"},
+			expErr:  errors.New("permit no pools"),
+		},
+		"this system": {
+			caveats: []string{"daos-user jdoe", expiry, "daos-system daos_server"},
+			expGrant: &macaroonGrant{
+				user:    "jdoe",
+				expires: time.Date(2025, 6, 1, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		"other system": {
+			caveats: []string{"daos-user jdoe", expiry, "daos-system other"},
+			expErr:  errors.New("restricted to system \"other\""),
+		},
+		"expired": {
+			caveats: []string{"daos-user jdoe", "time-before 2025-06-01T11:00:00Z"},
+			expErr:  errors.New("expired"),
+		},
+		"no expiry": {
+			caveats: []string{"daos-user jdoe"},
+			expErr:  errors.New("no time-before caveat"),
+		},
+		"no user": {
+			caveats: []string{expiry},
+			expErr:  errors.New("no daos-user caveat"),
+		},
+		"user changed": {
+			caveats: []string{"daos-user jdoe", expiry, "daos-user root"},
+			expErr:  errors.New("conflicting daos-user caveats"),
+		},
+		"unknown caveat": {
+			caveats: []string{"daos-user jdoe", expiry, "ip-address 10.0.0.1"},
+			expErr:  errors.New("unsatisfied macaroon caveat"),
+		},
+		"no argument": {
+			caveats: []string{"daos-user"},
+			expErr:  errors.New("has no argument"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var caveats []macaroonCaveat
+			for _, c := range tc.caveats {
+				caveats = append(caveats, macaroonCaveat{id: []byte(c)})
+			}
+
+			grant, err := checkMacaroonCaveats(caveats, now, "daos_server")
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.expGrant.user, grant.user, "unexpected user")
+			test.AssertEqual(t, tc.expGrant.group, grant.group, "unexpected group")
+			test.CmpAny(t, "pools", tc.expGrant.pools, grant.pools)
+			test.AssertTrue(t, tc.expGrant.expires.Equal(grant.expires), "unexpected expiry")
+		})
+	}
+}
+
+func TestAuth_AuthMacaroonCredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootKey := []byte("0123456789abcdef0123456789abcdef")
+	keyDir := t.TempDir()
+	keyFile := filepath.Join(keyDir, "root_key")
+	if err := os.WriteFile(keyFile, append(rootKey, '\n'), 0600); err != nil {
+		t.Fatal(err)
+	}
+	shortKeyFile := filepath.Join(keyDir, "short_key")
+	if err := os.WriteFile(shortKeyFile, []byte("secret"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	expiry := "time-before " + time.Now().Add(time.Hour).Format(time.RFC3339)
+
+	for name, tc := range map[string]struct {
+		macaroon []byte
+		keyFile  string
+		expUser  string
+		expGroup string
+		expPools []string
+		expErr   error
+	}{
+		"no macaroon": {
+			expErr: errors.New("no macaroon"),
+		},
+		"short root key": {
+			macaroon: testMintMacaroon(rootKey, "id", "daos-user jdoe", expiry),
+			keyFile:  shortKeyFile,
+			expErr:   errors.New("at least 16 bytes"),
+		},
+		"minted by another authority": {
+			macaroon: testMintMacaroon([]byte("fedcba9876543210fedcba9876543210"), "id", "daos-user jdoe", expiry),
+			expErr:   errors.New("invalid macaroon signature"),
+		},
+		"unsatisfied caveat": {
+			macaroon: testMintMacaroon(rootKey, "id", "daos-user jdoe", expiry, "daos-system other"),
+			expErr:   errors.New("restricted to system"),
+		},
+		"success": {
+			macaroon: testMintMacaroon(rootKey, "id", "daos-user jdoe", "daos-group hpc", expiry, "daos-system daos_server"),
+			expUser:  "jdoe@",
+			expGroup: "hpc@",
+		},
+		"restricted to pools": {
+			macaroon: testMintMacaroon(rootKey, "id", "daos-user jdoe", expiry, "daos-pools tank,scratch"),
+			expUser:  "jdoe@",
+			expPools: []string{"tank", "scratch"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthMacaroonCredentialRequest{
+				macaroon:    tc.macaroon,
+				signingKey:  agentKey,
+				rootKeyFile: keyFile,
+				system:      "daos_server",
+				now:         time.Now,
+			}
+			if tc.keyFile != "" {
+				req.rootKeyFile = tc.keyFile
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_MACAROON, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, sys.Group, "unexpected group")
+			test.CmpAny(t, "pools", tc.expPools, sys.Pools)
+
+			// The server rejects the credential for pools the macaroon
+			// does not allow.
+			if len(tc.expPools) > 0 {
+				test.CmpErr(t, nil, ValidateTokenPool(cred.Token, "", tc.expPools[0]))
+				test.CmpErr(t, errors.New("may not be used with pool"), ValidateTokenPool(cred.Token, "", "home"))
+			}
+		})
+	}
+}
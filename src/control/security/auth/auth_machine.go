@@ -0,0 +1,169 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// MachinePrincipalPrefix distinguishes the principal of a node from those of
+// users and groups, e.g. "host/node1.example.com@". As "/" may not appear in
+// user names, such a principal can only be asserted by a machine credential.
+const MachinePrincipalPrefix = "host/"
+
+type (
+	// AuthMachineCredentialFactory is a factory interface for AuthMachineCredentialRequests.
+	AuthMachineCredentialFactory struct {
+	}
+
+	// AuthMachineCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_MACHINE flavor.
+	AuthMachineCredentialRequest struct {
+		uid        uint32
+		signingKey crypto.PrivateKey
+		certPath   string
+		group      string
+	}
+)
+
+func (fac *AuthMachineCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthMachineCredentialRequest{}
+
+	if secCfg == nil || !secCfg.MachineConfig.Enabled {
+		return req, drpc.NewFailureWithMessage("agent is not configured to issue machine credentials")
+	}
+
+	info, err := sessionDomainInfo(log, session)
+	if err != nil {
+		return req, err
+	}
+
+	return fac.InitForUser(log, secCfg, info, key)
+}
+
+// InitForUser initializes a request for the machine credential on behalf of
+// the user, who must be permitted to request it.
+func (fac *AuthMachineCredentialFactory) InitForUser(log logging.Logger, secCfg *security.CredentialConfig, info *security.DomainInfo, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthMachineCredentialRequest{}
+
+	if secCfg == nil || !secCfg.MachineConfig.Enabled {
+		return req, drpc.NewFailureWithMessage("agent is not configured to issue machine credentials")
+	}
+	if info == nil {
+		return req, errors.New("nil domain info")
+	}
+	if info.Uid() != 0 && !slices.Contains(secCfg.MachineConfig.AllowedUIDs, info.Uid()) {
+		return req, drpc.NewFailureWithMessage(fmt.Sprintf("uid %d is not permitted to request machine credentials", info.Uid()))
+	}
+
+	req.uid = info.Uid()
+	req.signingKey = key
+	req.certPath = secCfg.MachineConfig.Cert
+	req.group = secCfg.MachineConfig.Group
+
+	return req, nil
+}
+
+func GetMachineFlavor() Flavor {
+	return Flavor_AUTH_MACHINE
+}
+
+func (fac AuthMachineCredentialFactory) GetAuthFlavor() Flavor {
+	return GetMachineFlavor()
+}
+
+func (req *AuthMachineCredentialRequest) GetAuthFlavor() Flavor {
+	return GetMachineFlavor()
+}
+
+// nodeName returns the name identifying the node, from its certificate if
+// one is configured.
+func (req *AuthMachineCredentialRequest) nodeName() (string, error) {
+	if req.certPath == "" {
+		return GetMachineName()
+	}
+
+	cert, err := security.LoadCertificate(req.certPath)
+	if err != nil {
+		return "", errors.Wrap(err, "loading node certificate")
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0], nil
+	}
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName, nil
+	}
+	return "", errors.Errorf("node certificate %q has no DNS name or common name", req.certPath)
+}
+
+// GetSignedCredential returns a credential for the node, marked as a machine
+// credential so that it can only be granted node-scoped permissions.
+func (req *AuthMachineCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	hostname, err := GetMachineName()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get hostname")
+	}
+	name, err := req.nodeName()
+	if err != nil {
+		return nil, err
+	}
+
+	sys := &Sys{
+		Machinename: hostname,
+		User:        sysNameToPrincipalName(MachinePrincipalPrefix + strings.ToLower(name)),
+		Machine:     true,
+	}
+	if req.group != "" {
+		sys.Group = sysNameToPrincipalName(req.group)
+	}
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("uid %d: successfully signed machine credential for %s", req.uid, sys.User)
+	return credential, nil
+}
+
+// GetKey returns a cache key for the request. All machine credentials are for
+// the same identity.
+func (req *AuthMachineCredentialRequest) GetKey() string {
+	return tokenCacheKey(req.GetAuthFlavor(), "")
+}
+
+// ValidateMachineToken checks that a verified token only asserts a machine
+// identity if it is an AUTH_MACHINE token, and that AUTH_MACHINE tokens assert
+// nothing else, so that node-scoped permissions cannot be gained by a user and
+// user permissions cannot be gained by a node.
+func ValidateMachineToken(token *Token) error {
+	sys, err := sysFromToken(token)
+	if err != nil {
+		return err
+	}
+	if token.GetFlavor() != Flavor_AUTH_MACHINE {
+		if sys.Machine || strings.HasPrefix(sys.User, MachinePrincipalPrefix) {
+			return errors.Errorf("%s credential asserts a machine identity", token.GetFlavor())
+		}
+		return nil
+	}
+
+	if !sys.Machine || !strings.HasPrefix(sys.User, MachinePrincipalPrefix) {
+		return errors.New("machine credential does not assert a machine identity")
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_AuthMachineCredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostname, err := GetMachineName()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca := newTestCA(t, "site-ca")
+
+	for name, tc := range map[string]struct {
+		certTmpl *x509.Certificate
+		certPath string
+		group    string
+		expUser  string
+		expGroup string
+		expErr   error
+	}{
+		"hostname": {
+			group:    "daos_nodes",
+			expUser:  sysNameToPrincipalName(MachinePrincipalPrefix + strings.ToLower(hostname)),
+			expGroup: "daos_nodes@",
+		},
+		"cert DNS name": {
+			certTmpl: &x509.Certificate{DNSNames: []string{"Node1.Example.com", "node1"}},
+			expUser:  "host/node1.example.com@",
+		},
+		"cert common name": {
+			certTmpl: &x509.Certificate{},
+			expUser:  "host/node@",
+		},
+		"missing cert": {
+			certPath: "/missing/node.crt",
+			expErr:   errors.New("loading node certificate"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthMachineCredentialRequest{
+				signingKey: agentKey,
+				certPath:   tc.certPath,
+				group:      tc.group,
+			}
+			if tc.certTmpl != nil {
+				req.certPath, _ = writeTestKeyPair(t, ca, t.TempDir(), "node", tc.certTmpl)
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_MACHINE, cred.Token.Flavor, "unexpected flavor")
+			test.AssertEqual(t, CredentialClass_CLASS_MACHINE, cred.Token.Class, "unexpected class")
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, sys.Group, "unexpected group")
+			test.AssertTrue(t, sys.Machine, "credential not marked as a machine credential")
+			test.AssertTrue(t, ValidateMachineToken(cred.Token) == nil, "machine credential not valid")
+		})
+	}
+}
+
+func TestAuth_ValidateMachineToken(t *testing.T) {
+	token := func(t *testing.T, flavor Flavor, sys *Sys) *Token {
+		cred, err := newSignedCredential(flavor, nil, sys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cred.Token
+	}
+
+	for name, tc := range map[string]struct {
+		flavor Flavor
+		sys    *Sys
+		expErr error
+	}{
+		"machine": {
+			flavor: Flavor_AUTH_MACHINE,
+			sys:    &Sys{User: "host/node1@", Machine: true},
+		},
+		"machine without marker": {
+			flavor: Flavor_AUTH_MACHINE,
+			sys:    &Sys{User: "host/node1@"},
+			expErr: errors.New("does not assert a machine identity"),
+		},
+		"machine for user": {
+			flavor: Flavor_AUTH_MACHINE,
+			sys:    &Sys{User: "root@", Machine: true},
+			expErr: errors.New("does not assert a machine identity"),
+		},
+		"user": {
+			flavor: Flavor_AUTH_SYS,
+			sys:    &Sys{User: "jdoe@"},
+		},
+		"user with machine principal": {
+			flavor: Flavor_AUTH_EXEC,
+			sys:    &Sys{User: "host/node1@"},
+			expErr: errors.New("AUTH_EXEC credential asserts a machine identity"),
+		},
+		"user marked as machine": {
+			flavor: Flavor_AUTH_SYS,
+			sys:    &Sys{User: "jdoe@", Machine: true},
+			expErr: errors.New("asserts a machine identity"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, ValidateMachineToken(token(t, tc.flavor, tc.sys)))
+		})
+	}
+}
+
+func TestAuth_AuthMachineCredentialFactory_InitForUser(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg    *security.CredentialConfig
+		uid    uint32
+		expErr error
+	}{
+		"not enabled": {
+			cfg:    &security.CredentialConfig{},
+			expErr: errors.New("not configured to issue machine credentials"),
+		},
+		"root": {
+			cfg: &security.CredentialConfig{
+				MachineConfig: security.MachineConfig{Enabled: true, Group: "daos_nodes"},
+			},
+		},
+		"allowed uid": {
+			cfg: &security.CredentialConfig{
+				MachineConfig: security.MachineConfig{Enabled: true, AllowedUIDs: []uint32{1001}},
+			},
+			uid: 1001,
+		},
+		"uid not allowed": {
+			cfg: &security.CredentialConfig{
+				MachineConfig: security.MachineConfig{Enabled: true, AllowedUIDs: []uint32{1001}},
+			},
+			uid:    1002,
+			expErr: errors.New("uid 1002 is not permitted"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			info := security.InitDomainInfo(&syscall.Ucred{Uid: tc.uid, Gid: tc.uid}, "")
+			req, err := (&AuthMachineCredentialFactory{}).InitForUser(log, tc.cfg, info, nil)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			machineReq, ok := req.(*AuthMachineCredentialRequest)
+			if !ok {
+				t.Fatalf("unexpected request type %T", req)
+			}
+			test.AssertEqual(t, tc.uid, machineReq.uid, "unexpected uid")
+			test.AssertEqual(t, tc.cfg.MachineConfig.Group, machineReq.group, "unexpected group")
+		})
+	}
+}
@@ -0,0 +1,258 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	oauth2Realm = "oauth2"
+
+	oauth2JWTBearerAssertion = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	maxOAuth2ResponseSize    = 1 << 20
+)
+
+type (
+	// AuthOAuth2CredentialFactory is a factory interface for AuthOAuth2CredentialRequests.
+	AuthOAuth2CredentialFactory struct {
+	}
+
+	// OAuth2ClientRequest is the body of an AUTH_OAUTH2 credential request.
+	// A client presents either its client credentials (a secret or a signed
+	// client assertion) or an access token previously issued to it.
+	OAuth2ClientRequest struct {
+		ClientID        string `json:"client_id,omitempty"`
+		ClientSecret    string `json:"client_secret,omitempty"`
+		ClientAssertion string `json:"client_assertion,omitempty"`
+		AccessToken     string `json:"access_token,omitempty"`
+	}
+
+	// AuthOAuth2CredentialRequest defines the request parameters for GetSignedCredential for the AUTH_OAUTH2 flavor.
+	AuthOAuth2CredentialRequest struct {
+		reqBody          []byte
+		client           OAuth2ClientRequest
+		signingKey       crypto.PrivateKey
+		tokenURL         string
+		introspectionURL string
+		agentClientID    string
+		agentSecretFile  string
+		scopes           []string
+		identityMap      security.ExternalIdentityMap
+		caseFold         security.CaseFoldPolicy
+		httpClient       *http.Client
+	}
+
+	oauth2TokenResponse struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+	}
+
+	oauth2IntrospectionResponse struct {
+		Active   bool   `json:"active"`
+		ClientID string `json:"client_id"`
+		Subject  string `json:"sub"`
+		Scope    string `json:"scope"`
+	}
+)
+
+func (fac *AuthOAuth2CredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthOAuth2CredentialRequest{}
+
+	if secCfg == nil || (secCfg.OAuth2Config.TokenURL == "" && secCfg.OAuth2Config.IntrospectionURL == "") {
+		return req, drpc.NewFailureWithMessage("agent is not configured for OAuth2 authentication")
+	}
+
+	if len(reqBody) > 0 {
+		if err := json.Unmarshal(reqBody, &req.client); err != nil {
+			return req, drpc.UnmarshalingPayloadFailure()
+		}
+	}
+
+	oauth2Cfg := &secCfg.OAuth2Config
+	req.reqBody = reqBody
+	req.signingKey = key
+	req.tokenURL = oauth2Cfg.TokenURL
+	req.introspectionURL = oauth2Cfg.IntrospectionURL
+	req.agentClientID = oauth2Cfg.ClientID
+	req.agentSecretFile = oauth2Cfg.ClientSecretFile
+	req.scopes = oauth2Cfg.Scopes
+	req.identityMap = oauth2Cfg.IdentityMap
+	req.caseFold = secCfg.PrincipalCaseFold
+	req.httpClient = http.DefaultClient
+
+	return req, nil
+}
+
+func GetOAuth2Flavor() Flavor {
+	return Flavor_AUTH_OAUTH2
+}
+
+func (fac AuthOAuth2CredentialFactory) GetAuthFlavor() Flavor {
+	return GetOAuth2Flavor()
+}
+
+func (req *AuthOAuth2CredentialRequest) GetAuthFlavor() Flavor {
+	return GetOAuth2Flavor()
+}
+
+// postForm sends a form-encoded request to an OAuth2 endpoint and decodes the
+// JSON response into out.
+func (req *AuthOAuth2CredentialRequest) postForm(ctx context.Context, endpoint string, form url.Values, clientID, secret string, out interface{}) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return errors.Wrapf(err, "creating request for %q", endpoint)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("Accept", "application/json")
+	if secret != "" {
+		// RFC 6749 section 2.3.1 requires the credentials to be form-encoded
+		// before they are used for basic authentication.
+		httpReq.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(secret))
+	}
+
+	resp, err := req.httpClient.Do(httpReq)
+	if err != nil {
+		return errors.Wrapf(err, "sending request to %q", endpoint)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("request to %q: unexpected status code %d", endpoint, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxOAuth2ResponseSize))
+	if err != nil {
+		return errors.Wrapf(err, "reading response from %q", endpoint)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return errors.Wrapf(err, "parsing response from %q", endpoint)
+	}
+
+	return nil
+}
+
+// exchangeClientCredentials authenticates the client by performing the OAuth2
+// client credentials grant on its behalf. A successful exchange proves that
+// the client holds valid credentials for its client ID.
+func (req *AuthOAuth2CredentialRequest) exchangeClientCredentials(ctx context.Context) (string, error) {
+	if req.tokenURL == "" {
+		return "", errors.New("agent is not configured with an OAuth2 token endpoint")
+	}
+	if req.client.ClientID == "" {
+		return "", errors.New("no OAuth2 client ID supplied")
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(req.scopes) > 0 {
+		form.Set("scope", strings.Join(req.scopes, " "))
+	}
+
+	var secret string
+	switch {
+	case req.client.ClientAssertion != "":
+		form.Set("client_id", req.client.ClientID)
+		form.Set("client_assertion_type", oauth2JWTBearerAssertion)
+		form.Set("client_assertion", req.client.ClientAssertion)
+	case req.client.ClientSecret != "":
+		secret = req.client.ClientSecret
+	default:
+		return "", errors.New("no OAuth2 client secret or assertion supplied")
+	}
+
+	tokenResp := &oauth2TokenResponse{}
+	if err := req.postForm(ctx, req.tokenURL, form, req.client.ClientID, secret, tokenResp); err != nil {
+		return "", errors.Wrap(err, "OAuth2 client credentials exchange failed")
+	}
+	if tokenResp.AccessToken == "" {
+		return "", errors.New("OAuth2 token endpoint did not issue an access token")
+	}
+
+	return req.client.ClientID, nil
+}
+
+// introspectAccessToken checks the client's access token at the introspection
+// endpoint and returns the client ID and subject it was issued to.
+func (req *AuthOAuth2CredentialRequest) introspectAccessToken(ctx context.Context) (string, string, error) {
+	if req.introspectionURL == "" {
+		return "", "", errors.New("agent is not configured with an OAuth2 introspection endpoint")
+	}
+
+	secret, err := os.ReadFile(req.agentSecretFile)
+	if err != nil {
+		return "", "", errors.Wrap(err, "reading OAuth2 client secret")
+	}
+
+	form := url.Values{
+		"token":           {req.client.AccessToken},
+		"token_type_hint": {"access_token"},
+	}
+	introspection := &oauth2IntrospectionResponse{}
+	if err := req.postForm(ctx, req.introspectionURL, form, req.agentClientID, strings.TrimSpace(string(secret)), introspection); err != nil {
+		return "", "", errors.Wrap(err, "OAuth2 token introspection failed")
+	}
+	if !introspection.Active {
+		return "", "", errors.New("OAuth2 access token is not active")
+	}
+
+	granted := strings.Fields(introspection.Scope)
+	for _, scope := range req.scopes {
+		if !slices.Contains(granted, scope) {
+			return "", "", errors.Errorf("OAuth2 access token was not granted scope %q", scope)
+		}
+	}
+
+	return introspection.ClientID, introspection.Subject, nil
+}
+
+// GetSignedCredential authenticates the service account and returns a
+// credential for the identity it is mapped to.
+func (req *AuthOAuth2CredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	var clientID, subject string
+	var err error
+	if req.client.AccessToken != "" {
+		clientID, subject, err = req.introspectAccessToken(ctx)
+	} else {
+		clientID, err = req.exchangeClientCredentials(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sys, err := externalIdentitySys(oauth2Realm, req.caseFold, req.identityMap, clientID, nil, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("OAuth2 client %q: successfully signed credential for %s", clientID, sys.User)
+	return credential, nil
+}
+
+// GetKey returns a cache key derived from the whole request, so that a cached
+// credential is only returned to a client presenting the same secret.
+func (req *AuthOAuth2CredentialRequest) GetKey() string {
+	return tokenCacheKey(req.GetAuthFlavor(), string(req.reqBody))
+}
@@ -0,0 +1,206 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_AuthOAuth2CredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretFile, []byte("agent-secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	writeJSON := func(w http.ResponseWriter, v interface{}) {
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			t.Error(err)
+		}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		id, secret, ok := r.BasicAuth()
+		id, _ = url.QueryUnescape(id)
+		secret, _ = url.QueryUnescape(secret)
+		switch {
+		case ok && id == "pipeline" && secret == "s3cr%t":
+		case r.PostForm.Get("client_id") == "pipeline" &&
+			r.PostForm.Get("client_assertion_type") == oauth2JWTBearerAssertion &&
+			r.PostForm.Get("client_assertion") == "signed-assertion":
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, &oauth2TokenResponse{AccessToken: "at", TokenType: "Bearer"})
+	})
+	mux.HandleFunc("/introspect", func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, ok := r.BasicAuth(); !ok || id != "daos-agent" || secret != "agent-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.PostForm.Get("token") {
+		case "good":
+			writeJSON(w, &oauth2IntrospectionResponse{Active: true, ClientID: "pipeline", Subject: "svc-1", Scope: "daos other"})
+		case "narrow":
+			writeJSON(w, &oauth2IntrospectionResponse{Active: true, ClientID: "pipeline", Scope: "other"})
+		default:
+			writeJSON(w, &oauth2IntrospectionResponse{})
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	for name, tc := range map[string]struct {
+		client      OAuth2ClientRequest
+		secretFile  string
+		identityMap security.ExternalIdentityMap
+		expUser     string
+		expGroup    string
+		expErr      error
+	}{
+		"no client ID": {
+			client: OAuth2ClientRequest{ClientSecret: "s3cr%t"},
+			expErr: errors.New("no OAuth2 client ID"),
+		},
+		"no secret": {
+			client: OAuth2ClientRequest{ClientID: "pipeline"},
+			expErr: errors.New("no OAuth2 client secret or assertion"),
+		},
+		"bad secret": {
+			client: OAuth2ClientRequest{ClientID: "pipeline", ClientSecret: "wrong"},
+			expErr: errors.New("status code 401"),
+		},
+		"client secret": {
+			client:  OAuth2ClientRequest{ClientID: "pipeline", ClientSecret: "s3cr%t"},
+			expUser: "pipeline@oauth2",
+		},
+		"client assertion": {
+			client:  OAuth2ClientRequest{ClientID: "pipeline", ClientAssertion: "signed-assertion"},
+			expUser: "pipeline@oauth2",
+		},
+		"mapped client": {
+			client: OAuth2ClientRequest{ClientID: "pipeline", ClientSecret: "s3cr%t"},
+			identityMap: security.ExternalIdentityMap{
+				"pipeline": {User: "svc", Group: "daos"},
+			},
+			expUser:  "svc@",
+			expGroup: "daos@",
+		},
+		"introspected token": {
+			client:     OAuth2ClientRequest{AccessToken: "good"},
+			secretFile: secretFile,
+			expUser:    "pipeline@oauth2",
+		},
+		"introspected token mapped by subject": {
+			client:     OAuth2ClientRequest{AccessToken: "good"},
+			secretFile: secretFile,
+			identityMap: security.ExternalIdentityMap{
+				"svc-1": {User: "svc"},
+			},
+			expUser: "svc@",
+		},
+		"inactive token": {
+			client:     OAuth2ClientRequest{AccessToken: "revoked"},
+			secretFile: secretFile,
+			expErr:     errors.New("not active"),
+		},
+		"token missing scope": {
+			client:     OAuth2ClientRequest{AccessToken: "narrow"},
+			secretFile: secretFile,
+			expErr:     errors.New("not granted scope"),
+		},
+		"missing agent secret": {
+			client:     OAuth2ClientRequest{AccessToken: "good"},
+			secretFile: filepath.Join(t.TempDir(), "missing"),
+			expErr:     errors.New("reading OAuth2 client secret"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthOAuth2CredentialRequest{
+				client:           tc.client,
+				signingKey:       agentKey,
+				tokenURL:         srv.URL + "/token",
+				introspectionURL: srv.URL + "/introspect",
+				agentClientID:    "daos-agent",
+				agentSecretFile:  tc.secretFile,
+				scopes:           []string{"daos"},
+				identityMap:      tc.identityMap,
+				httpClient:       srv.Client(),
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_OAUTH2, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, sys.Group, "unexpected group")
+		})
+	}
+}
+
+func TestAuth_AuthOAuth2CredentialRequest_GetKey(t *testing.T) {
+	secCfg := &security.CredentialConfig{
+		OAuth2Config: security.OAuth2Config{TokenURL: "https://idp.example.com/token"},
+	}
+	fac := &AuthOAuth2CredentialFactory{}
+
+	getKey := func(body string) string {
+		t.Helper()
+		req, err := fac.Init(nil, secCfg, nil, []byte(body), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return req.GetKey()
+	}
+
+	good := getKey(`{"client_id":"pipeline","client_secret":"good"}`)
+	bad := getKey(`{"client_id":"pipeline","client_secret":"bad"}`)
+	test.AssertFalse(t, good == bad, "cache key must depend on the client secret")
+
+	_, err := fac.Init(nil, secCfg, nil, []byte("not json"), nil)
+	test.CmpErr(t, errors.New("unmarshal"), err)
+}
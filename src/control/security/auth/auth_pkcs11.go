@@ -0,0 +1,323 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	pkcs11Realm = "pkcs11"
+
+	// pkcs11SignedContext is prepended to the challenge before it is signed,
+	// so that a card signature obtained for DAOS cannot be used elsewhere.
+	pkcs11SignedContext = "DAOS AUTH_PKCS11 challenge\x00"
+)
+
+var (
+	oidSubjectAltName = asn1.ObjectIdentifier{2, 5, 29, 17}
+	// Microsoft User Principal Name, carried by PIV authentication
+	// certificates as a subject alternative name.
+	oidUserPrincipalName = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2, 3}
+	// Microsoft Smart Card Logon extended key usage.
+	oidSmartcardLogon = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2, 2}
+)
+
+type (
+	// AuthPKCS11CredentialFactory is a factory interface for AuthPKCS11CredentialRequests.
+	AuthPKCS11CredentialFactory struct {
+	}
+
+	// AuthPKCS11CredentialRequest defines the request parameters for GetSignedCredential for the AUTH_PKCS11 flavor.
+	AuthPKCS11CredentialRequest struct {
+		req         *PKCS11AuthReq
+		uid         uint32
+		signingKey  crypto.PrivateKey
+		caCertDir   string
+		identityMap security.ExternalIdentityMap
+		caseFold    security.CaseFoldPolicy
+		challenges  *Challenges
+		now         func() time.Time
+	}
+)
+
+func (fac *AuthPKCS11CredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthPKCS11CredentialRequest{}
+
+	if secCfg == nil || secCfg.PKCS11Config.CACertDir == "" {
+		return req, drpc.NewFailureWithMessage("agent is not configured for smart card authentication")
+	}
+
+	req.req = new(PKCS11AuthReq)
+	if err := proto.Unmarshal(reqBody, req.req); err != nil {
+		return req, drpc.UnmarshalingPayloadFailure()
+	}
+
+	uid, err := sessionUID(log, session)
+	if err != nil {
+		return req, err
+	}
+
+	req.uid = uid
+	req.signingKey = key
+	req.caCertDir = secCfg.PKCS11Config.CACertDir
+	req.identityMap = secCfg.PKCS11Config.IdentityMap
+	req.caseFold = secCfg.PrincipalCaseFold
+	req.challenges = defaultChallenges
+	req.now = time.Now
+
+	return req, nil
+}
+
+func GetPKCS11Flavor() Flavor {
+	return Flavor_AUTH_PKCS11
+}
+
+func (fac AuthPKCS11CredentialFactory) GetAuthFlavor() Flavor {
+	return GetPKCS11Flavor()
+}
+
+func (req *AuthPKCS11CredentialRequest) GetAuthFlavor() Flavor {
+	return GetPKCS11Flavor()
+}
+
+// pkcs11SignedData returns the data a client signs with its card for the
+// given challenge.
+func pkcs11SignedData(challenge []byte) []byte {
+	return append([]byte(pkcs11SignedContext), challenge...)
+}
+
+// loadCACertDir returns a pool of the PEM certificates in the directory.
+func loadCACertDir(dir string) (*x509.CertPool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading smart card CA directory")
+	}
+
+	pool := x509.NewCertPool()
+	found := false
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, errors.Wrap(err, "reading smart card CA certificate")
+		}
+		if pool.AppendCertsFromPEM(data) {
+			found = true
+		}
+	}
+	if !found {
+		return nil, errors.Errorf("no CA certificates found in %q", dir)
+	}
+	return pool, nil
+}
+
+// verifyCardCertificate checks that the certificate was issued by one of the
+// trusted CAs for client or smart card logon use.
+func (req *AuthPKCS11CredentialRequest) verifyCardCertificate(cert *x509.Certificate) error {
+	roots, err := loadCACertDir(req.caCertDir)
+	if err != nil {
+		return err
+	}
+
+	// Smart card logon is not an extended key usage known to the verifier,
+	// so usage is checked separately.
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:       roots,
+		CurrentTime: req.now(),
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return errors.Wrap(err, "smart card certificate is not trusted")
+	}
+
+	if cert.KeyUsage != 0 && cert.KeyUsage&x509.KeyUsageDigitalSignature == 0 {
+		return errors.New("smart card certificate is not valid for digital signatures")
+	}
+	if !slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageClientAuth) &&
+		!slices.ContainsFunc(cert.UnknownExtKeyUsage, oidSmartcardLogon.Equal) {
+		return errors.New("smart card certificate is not valid for client authentication")
+	}
+	return nil
+}
+
+// pkcs11VerifySignature checks a signature made by a card over the SHA-256
+// digest of the data. ECDSA signatures may be in the raw r||s form produced
+// by the CKM_ECDSA mechanism, or ASN.1 encoded. RSA signatures may use
+// PKCS #1 v1.5 or PSS padding.
+func pkcs11VerifySignature(key crypto.PublicKey, data, sig []byte) error {
+	digest := sha256.Sum256(data)
+
+	switch pub := key.(type) {
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) == 2*size {
+			r := new(big.Int).SetBytes(sig[:size])
+			s := new(big.Int).SetBytes(sig[size:])
+			if ecdsa.Verify(pub, digest[:], r, s) {
+				return nil
+			}
+		} else if ecdsa.VerifyASN1(pub, digest[:], sig) {
+			return nil
+		}
+		return errors.New("invalid ECDSA signature")
+	case *rsa.PublicKey:
+		if rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig) == nil {
+			return nil
+		}
+		if err := rsa.VerifyPSS(pub, crypto.SHA256, digest[:], sig, nil); err != nil {
+			return errors.Wrap(err, "invalid RSA signature")
+		}
+		return nil
+	case ed25519.PublicKey:
+		if !ed25519.Verify(pub, data, sig) {
+			return errors.New("invalid ed25519 signature")
+		}
+		return nil
+	default:
+		return errors.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// certUserPrincipalName returns the User Principal Name from the subject
+// alternative names of the certificate, if it has one.
+func certUserPrincipalName(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidSubjectAltName) {
+			continue
+		}
+		var names asn1.RawValue
+		if _, err := asn1.Unmarshal(ext.Value, &names); err != nil {
+			return ""
+		}
+		for rest := names.Bytes; len(rest) > 0; {
+			var name asn1.RawValue
+			var err error
+			if rest, err = asn1.Unmarshal(rest, &name); err != nil {
+				return ""
+			}
+			// otherName [0] { type-id OID, value [0] EXPLICIT ANY }
+			if name.Class != asn1.ClassContextSpecific || name.Tag != 0 {
+				continue
+			}
+			var other struct {
+				TypeID asn1.ObjectIdentifier
+				Value  asn1.RawValue
+			}
+			if _, err := asn1.UnmarshalWithParams(name.FullBytes, &other, "tag:0"); err != nil {
+				continue
+			}
+			if !other.TypeID.Equal(oidUserPrincipalName) ||
+				other.Value.Class != asn1.ClassContextSpecific || other.Value.Tag != 0 {
+				continue
+			}
+			var upn string
+			if _, err := asn1.UnmarshalWithParams(other.Value.Bytes, &upn, "utf8"); err == nil {
+				return upn
+			}
+		}
+	}
+	return ""
+}
+
+// certPrincipal returns the name of the certificate holder: its User
+// Principal Name, email address or common name, in order of preference.
+func certPrincipal(cert *x509.Certificate) string {
+	if upn := certUserPrincipalName(cert); upn != "" {
+		return upn
+	}
+	if len(cert.EmailAddresses) > 0 {
+		return cert.EmailAddresses[0]
+	}
+	return cert.Subject.CommonName
+}
+
+// certFingerprint returns the SHA-256 fingerprint of the certificate, by
+// which an individual card may be mapped.
+func certFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// GetSignedCredential checks the card certificate and its signature of the
+// challenge, and returns a credential for the certificate holder.
+func (req *AuthPKCS11CredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	if len(req.req.GetCertificate()) == 0 || len(req.req.GetSignature()) == 0 {
+		return nil, errors.New("no smart card certificate or signature supplied")
+	}
+
+	cert, err := x509.ParseCertificate(req.req.GetCertificate())
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing smart card certificate")
+	}
+	if err := req.verifyCardCertificate(cert); err != nil {
+		return nil, err
+	}
+
+	// Check the signature before consuming the challenge, so that an
+	// invalid request cannot burn a legitimate client's challenge.
+	if err := pkcs11VerifySignature(cert.PublicKey, pkcs11SignedData(req.req.GetChallenge()), req.req.GetSignature()); err != nil {
+		return nil, err
+	}
+	if err := req.challenges.Redeem(req.req.GetChallenge(), req.uid); err != nil {
+		return nil, err
+	}
+
+	sys, err := externalIdentitySys(pkcs11Realm, req.caseFold, req.identityMap, certPrincipal(cert), nil,
+		certFingerprint(cert), cert.Subject.String())
+	if err != nil {
+		return nil, err
+	}
+	if upn := certUserPrincipalName(cert); upn != "" {
+		addIdentity(sys, IdentityKerberos, upn)
+	}
+	for _, uri := range cert.URIs {
+		if uri.Scheme == "spiffe" {
+			addIdentity(sys, IdentitySPIFFE, uri.Host+uri.Path)
+		}
+	}
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("uid %d: successfully signed credential for %s with smart card certificate %q",
+		req.uid, sys.User, cert.Subject)
+	return credential, nil
+}
+
+// GetKey returns a cache key for the request. As the cache is consulted before
+// the challenge is redeemed, the key is bound to the requesting uid.
+func (req *AuthPKCS11CredentialRequest) GetKey() string {
+	data := binary.BigEndian.AppendUint32(nil, req.uid)
+	data = append(data, req.req.GetChallenge()...)
+	data = append(data, req.req.GetSignature()...)
+	return tokenCacheKey(req.GetAuthFlavor(), string(data))
+}
@@ -0,0 +1,280 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// testUPNExtension returns a subject alternative name extension carrying a
+// User Principal Name, as found in PIV authentication certificates.
+func testUPNExtension(t *testing.T, upn string) pkix.Extension {
+	t.Helper()
+	value, err := asn1.MarshalWithParams(upn, "utf8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := asn1.MarshalWithParams(struct {
+		TypeID asn1.ObjectIdentifier
+		Value  asn1.RawValue
+	}{oidUserPrincipalName, asn1.RawValue{Class: asn1.ClassContextSpecific, IsCompound: true, Bytes: value}}, "tag:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	san, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSequence, IsCompound: true, Bytes: other})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkix.Extension{Id: oidSubjectAltName, Value: san}
+}
+
+type testCA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key}
+}
+
+// issue returns a DER certificate for the key, issued by the CA.
+func (ca *testCA) issue(t *testing.T, key crypto.PublicKey, tmpl *x509.Certificate) []byte {
+	t.Helper()
+	tmpl.SerialNumber = big.NewInt(2)
+	if tmpl.NotBefore.IsZero() {
+		tmpl.NotBefore = time.Now().Add(-time.Minute)
+		tmpl.NotAfter = time.Now().Add(time.Hour)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, key, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestAuth_AuthPKCS11CredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca := newTestCA(t, "Card CA")
+	otherCA := newTestCA(t, "Other CA")
+
+	caDir := t.TempDir()
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+	if err := os.WriteFile(filepath.Join(caDir, "card-ca.pem"), caPEM, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pivCert := ca.issue(t, ecKey.Public(), &x509.Certificate{
+		Subject:         pkix.Name{CommonName: "DOE.JOHN.1234567890"},
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		ExtraExtensions: []pkix.Extension{testUPNExtension(t, "1234567890@mil")},
+	})
+	logonCert := ca.issue(t, rsaKey.Public(), &x509.Certificate{
+		Subject:            pkix.Name{CommonName: "jdoe"},
+		EmailAddresses:     []string{"jdoe@example.com"},
+		KeyUsage:           x509.KeyUsageDigitalSignature,
+		UnknownExtKeyUsage: []asn1.ObjectIdentifier{oidSmartcardLogon},
+	})
+	serverCert := ca.issue(t, ecKey.Public(), &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "server"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	untrustedCert := otherCA.issue(t, ecKey.Public(), &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "jdoe"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	expiredCert := ca.issue(t, ecKey.Public(), &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "jdoe"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		NotBefore:   time.Now().Add(-2 * time.Hour),
+		NotAfter:    time.Now().Add(-time.Hour),
+	})
+
+	const uid = 1000
+	challenges := NewChallenges(ChallengeLifetime)
+	newChallenge := func() []byte {
+		challenge, err := challenges.Issue(uid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return challenge
+	}
+	// signRaw signs as the CKM_ECDSA mechanism does, returning r||s.
+	signRaw := func(challenge []byte) []byte {
+		digest := sha256.Sum256(pkcs11SignedData(challenge))
+		r, s, err := ecdsa.Sign(rand.Reader, ecKey, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	signRSA := func(challenge []byte) []byte {
+		digest := sha256.Sum256(pkcs11SignedData(challenge))
+		sig, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+	newReq := func(cert []byte, sign func([]byte) []byte) func() *PKCS11AuthReq {
+		return func() *PKCS11AuthReq {
+			challenge := newChallenge()
+			return &PKCS11AuthReq{Certificate: cert, Challenge: challenge, Signature: sign(challenge)}
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		req         func() *PKCS11AuthReq
+		identityMap security.ExternalIdentityMap
+		expUser     string
+		expGroup    string
+		expErr      error
+	}{
+		"no signature": {
+			req: func() *PKCS11AuthReq {
+				return &PKCS11AuthReq{Certificate: pivCert, Challenge: newChallenge()}
+			},
+			expErr: errors.New("no smart card certificate or signature"),
+		},
+		"untrusted issuer": {
+			req:    newReq(untrustedCert, signRaw),
+			expErr: errors.New("not trusted"),
+		},
+		"expired certificate": {
+			req:    newReq(expiredCert, signRaw),
+			expErr: errors.New("not trusted"),
+		},
+		"not for client authentication": {
+			req:    newReq(serverCert, signRaw),
+			expErr: errors.New("not valid for client authentication"),
+		},
+		"signed with another key": {
+			req:    newReq(pivCert, signRSA),
+			expErr: errors.New("invalid ECDSA signature"),
+		},
+		"challenge not issued by agent": {
+			req: func() *PKCS11AuthReq {
+				challenge := make([]byte, challengeLen)
+				return &PKCS11AuthReq{Certificate: pivCert, Challenge: challenge, Signature: signRaw(challenge)}
+			},
+			expErr: errors.New("not issued by this agent"),
+		},
+		"PIV certificate with UPN": {
+			req:     newReq(pivCert, signRaw),
+			expUser: "1234567890@mil",
+		},
+		"smart card logon certificate with email": {
+			req:     newReq(logonCert, signRSA),
+			expUser: "jdoe@example.com",
+		},
+		"mapped UPN": {
+			req: newReq(pivCert, signRaw),
+			identityMap: security.ExternalIdentityMap{
+				"1234567890@mil": {User: "jdoe", Group: "hpc"},
+			},
+			expUser:  "jdoe@",
+			expGroup: "hpc@",
+		},
+		"mapped subject": {
+			req: newReq(logonCert, signRSA),
+			identityMap: security.ExternalIdentityMap{
+				"CN=jdoe": {User: "jdoe"},
+			},
+			expUser: "jdoe@",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthPKCS11CredentialRequest{
+				req:         tc.req(),
+				uid:         uid,
+				signingKey:  agentKey,
+				caCertDir:   caDir,
+				identityMap: tc.identityMap,
+				challenges:  challenges,
+				now:         time.Now,
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_PKCS11, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, sys.Group, "unexpected group")
+
+			// The challenge cannot be used to obtain another credential.
+			_, err = req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, errors.New("already been used"), err)
+		})
+	}
+}
@@ -0,0 +1,217 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth/credprov"
+)
+
+// DefaultProviderTimeout is the time allowed for the credential provider to
+// respond if no timeout is configured.
+const DefaultProviderTimeout = 10 * time.Second
+
+type (
+	// AuthProviderCredentialFactory is a factory interface for AuthProviderCredentialRequests.
+	AuthProviderCredentialFactory struct {
+	}
+
+	// AuthProviderCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_PROVIDER flavor.
+	AuthProviderCredentialRequest struct {
+		body       []byte
+		uid        uint32
+		gid        uint32
+		pid        int32
+		signingKey crypto.PrivateKey
+		address    string
+		system     string
+		timeout    time.Duration
+		caseFold   security.CaseFoldPolicy
+		client     credprov.CredentialProviderClient
+	}
+)
+
+var (
+	providerConnsMutex sync.Mutex
+	providerConns      = make(map[security.ProviderConfig]*grpc.ClientConn)
+)
+
+// getProviderClient returns the shared client for the configured credential
+// provider, so that the certificates are only loaded once and the connection
+// is reused between requests.
+func getProviderClient(cfg security.ProviderConfig) (credprov.CredentialProviderClient, error) {
+	conn, err := getProviderConn(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return credprov.NewCredentialProviderClient(conn), nil
+}
+
+func getProviderConn(cfg security.ProviderConfig) (*grpc.ClientConn, error) {
+	providerConnsMutex.Lock()
+	defer providerConnsMutex.Unlock()
+
+	if conn, found := providerConns[cfg]; found {
+		return conn, nil
+	}
+
+	tlsCfg, err := cfg.TLSConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "loading credential provider certificates")
+	}
+	conn, err := grpc.NewClient(cfg.Address, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "credential provider %q", cfg.Address)
+	}
+	providerConns[cfg] = conn
+
+	return conn, nil
+}
+
+// ProbeBackend checks that a connection can be established to the credential
+// provider.
+func (fac *AuthProviderCredentialFactory) ProbeBackend(ctx context.Context, secCfg *security.CredentialConfig) error {
+	conn, err := getProviderConn(secCfg.ProviderConfig)
+	if err != nil {
+		return err
+	}
+
+	timeout := secCfg.ProviderConfig.Timeout
+	if timeout == 0 {
+		timeout = DefaultProviderTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn.Connect()
+	for state := conn.GetState(); state != connectivity.Ready; state = conn.GetState() {
+		if state == connectivity.TransientFailure {
+			return errors.Errorf("credential provider %q is unreachable", secCfg.ProviderConfig.Address)
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return errors.Wrapf(ctx.Err(), "credential provider %q", secCfg.ProviderConfig.Address)
+		}
+	}
+
+	return nil
+}
+
+func (fac *AuthProviderCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthProviderCredentialRequest{}
+
+	if secCfg == nil || secCfg.ProviderConfig.Address == "" {
+		return req, drpc.NewFailureWithMessage("agent is not configured with a credential provider")
+	}
+
+	info, err := sessionDomainInfo(log, session)
+	if err != nil {
+		return req, err
+	}
+
+	client, err := getProviderClient(secCfg.ProviderConfig)
+	if err != nil {
+		return req, err
+	}
+
+	req.body = reqBody
+	req.uid = info.Uid()
+	req.gid = info.Gid()
+	req.pid = info.Pid()
+	req.signingKey = key
+	req.address = secCfg.ProviderConfig.Address
+	req.system = secCfg.ProviderConfig.SystemName
+	req.timeout = secCfg.ProviderConfig.Timeout
+	req.caseFold = secCfg.PrincipalCaseFold
+	req.client = client
+
+	return req, nil
+}
+
+func GetProviderFlavor() Flavor {
+	return Flavor_AUTH_PROVIDER
+}
+
+func (fac AuthProviderCredentialFactory) GetAuthFlavor() Flavor {
+	return GetProviderFlavor()
+}
+
+func (req *AuthProviderCredentialRequest) GetAuthFlavor() Flavor {
+	return GetProviderFlavor()
+}
+
+// GetSignedCredential forwards the request body to the credential provider
+// and returns a credential for the identity it asserts.
+func (req *AuthProviderCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	hostname, err := GetMachineName()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get hostname")
+	}
+
+	timeout := req.timeout
+	if timeout == 0 {
+		timeout = DefaultProviderTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resp, err := req.client.GetIdentity(ctx, &credprov.GetIdentityReq{
+		Data: req.body,
+		Client: &credprov.Client{
+			Uid:         req.uid,
+			Gid:         req.gid,
+			Pid:         req.pid,
+			Machinename: hostname,
+		},
+		System: req.system,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "credential provider %q", req.address)
+	}
+	id := resp.GetIdentity()
+	if id.GetUser() == "" {
+		return nil, errors.Errorf("credential provider %q returned no user", req.address)
+	}
+
+	sys, err := assertedIdentitySys(req.caseFold, id.GetUser(), id.GetGroup(), id.GetGroups())
+	if err != nil {
+		return nil, errors.Wrapf(err, "credential provider %q", req.address)
+	}
+	sys.GrantedScopes = id.GetGrantedScopes()
+	sys.Pools = id.GetPools()
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("uid %d: successfully signed credential for %s asserted by credential provider",
+		req.uid, sys.User)
+	return credential, nil
+}
+
+// GetKey returns a cache key for the request. As the provider may identify
+// the client by its credentials, the key is bound to the requesting uid and gid.
+func (req *AuthProviderCredentialRequest) GetKey() string {
+	data := binary.BigEndian.AppendUint32(nil, req.uid)
+	data = binary.BigEndian.AppendUint32(data, req.gid)
+	data = append(data, req.body...)
+	return tokenCacheKey(req.GetAuthFlavor(), string(data))
+}
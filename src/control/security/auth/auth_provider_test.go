@@ -0,0 +1,274 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth/credprov"
+)
+
+type testCredProvider struct {
+	credprov.UnimplementedCredentialProviderServer
+	getIdentity func(*credprov.GetIdentityReq) (*credprov.GetIdentityResp, error)
+}
+
+func (p *testCredProvider) GetIdentity(_ context.Context, req *credprov.GetIdentityReq) (*credprov.GetIdentityResp, error) {
+	return p.getIdentity(req)
+}
+
+// writeTestKeyPair issues a certificate from the CA and writes it and its key
+// as PEM files in dir.
+func writeTestKeyPair(t *testing.T, ca *testCA, dir, name string, tmpl *x509.Certificate) (certPath, keyPath string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl.Subject = pkix.Name{CommonName: name}
+	certDER := ca.issue(t, key.Public(), tmpl)
+
+	certPath = filepath.Join(dir, name+".crt")
+	keyPath = filepath.Join(dir, name+".key")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0400); err != nil {
+		t.Fatal(err)
+	}
+	return certPath, keyPath
+}
+
+func writeTestCACert(t *testing.T, ca *testCA, dir string) string {
+	t.Helper()
+	path := filepath.Join(dir, ca.cert.Subject.CommonName+".crt")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// startTestCredProvider serves the provider on a local port, using a
+// certificate issued by the CA and only accepting clients with a certificate
+// issued by the same CA.
+func startTestCredProvider(t *testing.T, ca *testCA, provider *testCredProvider) string {
+	t.Helper()
+	dir := t.TempDir()
+	certPath, keyPath := writeTestKeyPair(t, ca, dir, "provider", &x509.Certificate{
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})))
+	credprov.RegisterCredentialProviderServer(srv, provider)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestAuth_AuthProviderCredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	siteCA := newTestCA(t, "site-ca")
+	otherCA := newTestCA(t, "other-ca")
+
+	for name, tc := range map[string]struct {
+		clientCA    *testCA
+		getIdentity func(*credprov.GetIdentityReq) (*credprov.GetIdentityResp, error)
+		expUser     string
+		expGroup    string
+		expGroups   []string
+		expScopes   []string
+		expPools    []string
+		expErr      error
+	}{
+		"agent certificate not trusted": {
+			clientCA: otherCA,
+			getIdentity: func(*credprov.GetIdentityReq) (*credprov.GetIdentityResp, error) {
+				return &credprov.GetIdentityResp{Identity: &credprov.Identity{User: "jdoe"}}, nil
+			},
+			expErr: errors.New("credential provider"),
+		},
+		"provider denies": {
+			getIdentity: func(*credprov.GetIdentityReq) (*credprov.GetIdentityResp, error) {
+				return nil, status.Error(codes.Unauthenticated, "unknown ticket")
+			},
+			expErr: errors.New("unknown ticket"),
+		},
+		"no user": {
+			getIdentity: func(*credprov.GetIdentityReq) (*credprov.GetIdentityResp, error) {
+				return &credprov.GetIdentityResp{}, nil
+			},
+			expErr: errors.New("returned no user"),
+		},
+		"identity from request": {
+			getIdentity: func(req *credprov.GetIdentityReq) (*credprov.GetIdentityResp, error) {
+				if req.GetSystem() != "daos_server" || req.GetClient().GetUid() != 1000 {
+					return nil, status.Errorf(codes.InvalidArgument, "unexpected request %+v", req)
+				}
+				return &credprov.GetIdentityResp{Identity: &credprov.Identity{
+					User:   string(req.GetData()),
+					Group:  "hpc",
+					Groups: []string{"users"},
+				}}, nil
+			},
+			expUser:   "jdoe@",
+			expGroup:  "hpc@",
+			expGroups: []string{"users@"},
+		},
+		"domain principals and grants": {
+			getIdentity: func(*credprov.GetIdentityReq) (*credprov.GetIdentityResp, error) {
+				return &credprov.GetIdentityResp{Identity: &credprov.Identity{
+					User:          "jdoe@EXAMPLE.COM",
+					GrantedScopes: []string{"pool:read"},
+					Pools:         []string{"tank"},
+				}}, nil
+			},
+			expUser:   "jdoe@example.com",
+			expScopes: []string{"pool:read"},
+			expPools:  []string{"tank"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			clientCA := tc.clientCA
+			if clientCA == nil {
+				clientCA = siteCA
+			}
+			addr := startTestCredProvider(t, siteCA, &testCredProvider{getIdentity: tc.getIdentity})
+
+			dir := t.TempDir()
+			certPath, keyPath := writeTestKeyPair(t, clientCA, dir, "agent", &x509.Certificate{
+				ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			})
+			cfg := security.ProviderConfig{
+				Address: addr,
+				CACert:  writeTestCACert(t, siteCA, dir),
+				Cert:    certPath,
+				Key:     keyPath,
+			}
+			client, err := getProviderClient(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := &AuthProviderCredentialRequest{
+				body:       []byte("jdoe"),
+				uid:        1000,
+				gid:        1000,
+				signingKey: agentKey,
+				address:    addr,
+				system:     "daos_server",
+				client:     client,
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_PROVIDER, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, sys.Group, "unexpected group")
+			test.CmpAny(t, "groups", tc.expGroups, sys.Groups)
+			test.CmpAny(t, "granted scopes", tc.expScopes, sys.GrantedScopes)
+			test.CmpAny(t, "pools", tc.expPools, sys.Pools)
+			if len(tc.expPools) > 0 {
+				test.CmpErr(t, nil, ValidateTokenPool(cred.Token, "", tc.expPools[0]))
+				test.CmpErr(t, errors.New("may not be used with pool"), ValidateTokenPool(cred.Token, "", "home"))
+			}
+		})
+	}
+}
+
+func TestAuth_getProviderClient(t *testing.T) {
+	_, err := getProviderClient(security.ProviderConfig{
+		Address: "127.0.0.1:7443",
+		CACert:  filepath.Join(t.TempDir(), "missing.crt"),
+		Cert:    "agent.crt",
+		Key:     "agent.key",
+	})
+	test.CmpErr(t, errors.New("loading credential provider certificates"), err)
+}
+
+func TestAuth_AuthProviderCredentialFactory_ProbeBackend(t *testing.T) {
+	siteCA := newTestCA(t, "site-ca")
+	address := startTestCredProvider(t, siteCA, &testCredProvider{})
+
+	dir := t.TempDir()
+	certPath, keyPath := writeTestKeyPair(t, siteCA, dir, "agent", &x509.Certificate{
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	cfg := &security.CredentialConfig{
+		ProviderConfig: security.ProviderConfig{
+			Address: address,
+			CACert:  writeTestCACert(t, siteCA, dir),
+			Cert:    certPath,
+			Key:     keyPath,
+		},
+	}
+	fac := &AuthProviderCredentialFactory{}
+
+	if err := fac.ProbeBackend(test.Context(t), cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.ProviderConfig.Address = "127.0.0.1:1"
+	test.CmpErr(t, errors.New("credential provider \"127.0.0.1:1\""), fac.ProbeBackend(test.Context(t), cfg))
+}
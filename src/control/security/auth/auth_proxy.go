@@ -0,0 +1,146 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+type (
+	// AuthProxyCredentialFactory is a factory interface for AuthProxyCredentialRequests.
+	AuthProxyCredentialFactory struct {
+	}
+
+	// AuthProxyCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_PROXY flavor.
+	AuthProxyCredentialRequest struct {
+		req        *ProxyReq
+		uid        uint32
+		signingKey crypto.PrivateKey
+		allowRoot  bool
+		caseFold   security.CaseFoldPolicy
+	}
+)
+
+func (fac *AuthProxyCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthProxyCredentialRequest{}
+
+	if secCfg == nil || len(secCfg.ProxyConfig.AllowedUIDs) == 0 {
+		return req, drpc.NewFailureWithMessage("agent is not configured to issue proxy credentials")
+	}
+
+	req.req = new(ProxyReq)
+	if err := proto.Unmarshal(reqBody, req.req); err != nil {
+		return req, drpc.UnmarshalingPayloadFailure()
+	}
+
+	uid, err := sessionUID(log, session)
+	if err != nil {
+		return req, err
+	}
+	if !slices.Contains(secCfg.ProxyConfig.AllowedUIDs, uid) {
+		return req, drpc.NewFailureWithMessage(fmt.Sprintf("uid %d is not permitted to request proxy credentials", uid))
+	}
+
+	req.uid = uid
+	req.signingKey = key
+	req.allowRoot = secCfg.ProxyConfig.AllowRoot
+	req.caseFold = secCfg.PrincipalCaseFold
+
+	return req, nil
+}
+
+func GetProxyFlavor() Flavor {
+	return Flavor_AUTH_PROXY
+}
+
+func (fac AuthProxyCredentialFactory) GetAuthFlavor() Flavor {
+	return GetProxyFlavor()
+}
+
+func (req *AuthProxyCredentialRequest) GetAuthFlavor() Flavor {
+	return GetProxyFlavor()
+}
+
+// GetSignedCredential returns a credential for the remote user asserted by
+// the gateway, recording the gateway host so that the server can check that
+// it is permitted to proxy.
+func (req *AuthProxyCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	sys, err := assertedIdentitySys(req.caseFold, req.req.GetUser(), req.req.GetGroup(), req.req.GetGroups())
+	if err != nil {
+		return nil, errors.Wrap(err, "proxied user")
+	}
+	if !req.allowRoot && sys.User == sysNameToPrincipalName("root") {
+		return nil, errors.New("proxy credentials may not be issued for root")
+	}
+	sys.Proxy = &Proxy{
+		Host:       sys.Machinename,
+		ClientHost: req.req.GetClientHost(),
+	}
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Noticef("audit: uid %d: issued proxy credential for %s from %q", req.uid, sys.User, sys.Proxy.ClientHost)
+	return credential, nil
+}
+
+// GetKey returns a cache key for the request, which is bound to the
+// requesting uid as well as the proxied identity.
+func (req *AuthProxyCredentialRequest) GetKey() string {
+	body, _ := proto.Marshal(req.req)
+	return tokenCacheKey(req.GetAuthFlavor(), string(append(binary.BigEndian.AppendUint32(nil, req.uid), body...)))
+}
+
+// ValidateProxyToken checks that an AUTH_PROXY token was requested by one of
+// the hosts permitted to proxy, and that other flavors do not claim to have
+// been proxied. As the token names its gateway itself, the gateway must also
+// be the host whose key signed the credential, as established by the server.
+func ValidateProxyToken(token *Token, proxyHosts []string, signingHost string) error {
+	sys, err := sysFromToken(token)
+	if err != nil {
+		return err
+	}
+	if token.GetFlavor() != Flavor_AUTH_PROXY {
+		if sys.Proxy != nil {
+			return errors.Errorf("%s credential claims to be proxied", token.GetFlavor())
+		}
+		return nil
+	}
+
+	proxy := sys.GetProxy()
+	if proxy == nil || proxy.GetHost() == "" {
+		return errors.New("proxy credential does not name its gateway")
+	}
+	if proxy.GetHost() != sys.Machinename {
+		return errors.Errorf("proxy credential gateway %q is not the issuing host %q", proxy.GetHost(), sys.Machinename)
+	}
+	if !slices.Contains(proxyHosts, proxy.GetHost()) {
+		return errors.Errorf("host %q is not permitted to proxy", proxy.GetHost())
+	}
+	if signingHost == "" {
+		return errors.Errorf("proxy credential gateway %q is not known by its signing key", proxy.GetHost())
+	}
+	if !strings.EqualFold(proxy.GetHost(), signingHost) {
+		return errors.Errorf("proxy credential gateway %q was signed by the key of host %q", proxy.GetHost(), signingHost)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestAuth_AuthProxyCredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostname, err := GetMachineName()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		req       *ProxyReq
+		allowRoot bool
+		expSys    *Sys
+		expErr    error
+	}{
+		"no user": {
+			req:    &ProxyReq{ClientHost: "ws1"},
+			expErr: errors.New("no user asserted"),
+		},
+		"root": {
+			req:    &ProxyReq{User: "root", ClientHost: "ws1"},
+			expErr: errors.New("may not be issued for root"),
+		},
+		"root allowed": {
+			req:       &ProxyReq{User: "root", ClientHost: "ws1"},
+			allowRoot: true,
+			expSys: &Sys{
+				Machinename: hostname,
+				User:        "root@",
+				Proxy:       &Proxy{Host: hostname, ClientHost: "ws1"},
+			},
+		},
+		"success": {
+			req: &ProxyReq{
+				User:       "jdoe",
+				Group:      "users",
+				Groups:     []string{"hpc@example.com"},
+				ClientHost: "ws1.example.com",
+			},
+			expSys: &Sys{
+				Machinename: hostname,
+				User:        "jdoe@",
+				Group:       "users@",
+				Groups:      []string{"hpc@example.com"},
+				Proxy:       &Proxy{Host: hostname, ClientHost: "ws1.example.com"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthProxyCredentialRequest{
+				req:        tc.req,
+				uid:        992,
+				signingKey: agentKey,
+				allowRoot:  tc.allowRoot,
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_PROXY, cred.Token.Flavor, "unexpected flavor")
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.CmpAny(t, "sys", tc.expSys, sys, test.DefaultCmpOpts()...)
+		})
+	}
+}
+
+func TestAuth_AuthProxyCredentialRequest_GetKey(t *testing.T) {
+	req := func(uid uint32, user string) *AuthProxyCredentialRequest {
+		return &AuthProxyCredentialRequest{uid: uid, req: &ProxyReq{User: user}}
+	}
+
+	test.AssertEqual(t, req(992, "jdoe").GetKey(), req(992, "jdoe").GetKey(), "same request, different keys")
+	test.AssertTrue(t, req(992, "jdoe").GetKey() != req(992, "asmith").GetKey(), "key not bound to user")
+	test.AssertTrue(t, req(992, "jdoe").GetKey() != req(993, "jdoe").GetKey(), "key not bound to uid")
+}
+
+func TestAuth_ValidateProxyToken(t *testing.T) {
+	token := func(flavor Flavor, sys *Sys) *Token {
+		data, err := proto.Marshal(sys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &Token{Flavor: flavor, Data: data}
+	}
+	proxyHosts := []string{"gw1", "gw2"}
+
+	for name, tc := range map[string]struct {
+		token       *Token
+		signingHost string
+		expErr      error
+	}{
+		"not proxied": {
+			token: token(Flavor_AUTH_SYS, &Sys{Machinename: "node1", User: "jdoe@"}),
+		},
+		"sys claiming proxy": {
+			token:  token(Flavor_AUTH_SYS, &Sys{Machinename: "gw1", User: "jdoe@", Proxy: &Proxy{Host: "gw1"}}),
+			expErr: errors.New("AUTH_SYS credential claims to be proxied"),
+		},
+		"no gateway": {
+			token:  token(Flavor_AUTH_PROXY, &Sys{Machinename: "gw1", User: "jdoe@"}),
+			expErr: errors.New("does not name its gateway"),
+		},
+		"gateway not issuer": {
+			token:  token(Flavor_AUTH_PROXY, &Sys{Machinename: "node1", User: "jdoe@", Proxy: &Proxy{Host: "gw1"}}),
+			expErr: errors.New("is not the issuing host"),
+		},
+		"host not permitted": {
+			token:  token(Flavor_AUTH_PROXY, &Sys{Machinename: "node1", User: "jdoe@", Proxy: &Proxy{Host: "node1"}}),
+			expErr: errors.New(`host "node1" is not permitted to proxy`),
+		},
+		"signed by another host": {
+			token:       token(Flavor_AUTH_PROXY, &Sys{Machinename: "gw2", User: "jdoe@", Proxy: &Proxy{Host: "gw2", ClientHost: "ws1"}}),
+			signingHost: "node1",
+			expErr:      errors.New(`gateway "gw2" was signed by the key of host "node1"`),
+		},
+		"signing host unknown": {
+			token:  token(Flavor_AUTH_PROXY, &Sys{Machinename: "gw2", User: "jdoe@", Proxy: &Proxy{Host: "gw2", ClientHost: "ws1"}}),
+			expErr: errors.New("is not known by its signing key"),
+		},
+		"permitted": {
+			token:       token(Flavor_AUTH_PROXY, &Sys{Machinename: "gw2", User: "jdoe@", Proxy: &Proxy{Host: "gw2", ClientHost: "ws1"}}),
+			signingHost: "GW2",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, ValidateProxyToken(tc.token, proxyHosts, tc.signingHost))
+		})
+	}
+}
@@ -0,0 +1,207 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// Token profiles accepted for the AUTH_SCITOKENS flavor.
+var (
+	sciTokenVersions  = []string{"scitoken:2.0", "scitokens:2.0"}
+	wlcgTokenVersions = []string{"1.0"}
+)
+
+type (
+	// AuthSciTokensCredentialFactory is a factory interface for AuthSciTokensCredentialRequests.
+	AuthSciTokensCredentialFactory struct {
+	}
+
+	// AuthSciTokensCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_SCITOKENS flavor.
+	AuthSciTokensCredentialRequest struct {
+		token      string
+		signingKey crypto.PrivateKey
+		config     *security.SciTokensConfig
+		caseFold   security.CaseFoldPolicy
+		keySource  func(*security.SciTokenIssuer) jwkSource
+	}
+
+	// sciTokenScope is an authorization scope of the form "<authz>[:<path>]",
+	// e.g. "storage.read:/home/jdoe".
+	sciTokenScope struct {
+		authz string
+		path  string
+	}
+)
+
+func (fac *AuthSciTokensCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthSciTokensCredentialRequest{}
+
+	if secCfg == nil || len(secCfg.SciTokensConfig.Issuers) == 0 {
+		return req, drpc.NewFailureWithMessage("agent is not configured for SciTokens authentication")
+	}
+
+	req.token = strings.TrimSpace(string(reqBody))
+	req.signingKey = key
+	req.config = &secCfg.SciTokensConfig
+	req.caseFold = secCfg.PrincipalCaseFold
+	req.keySource = sciTokenIssuerKeys
+
+	return req, nil
+}
+
+func GetSciTokensFlavor() Flavor {
+	return Flavor_AUTH_SCITOKENS
+}
+
+func (fac AuthSciTokensCredentialFactory) GetAuthFlavor() Flavor {
+	return GetSciTokensFlavor()
+}
+
+func (req *AuthSciTokensCredentialRequest) GetAuthFlavor() Flavor {
+	return GetSciTokensFlavor()
+}
+
+// sciTokenIssuerKeys returns the signing keys of a trusted issuer, from its
+// configured JWKS URL or else by discovery.
+func sciTokenIssuerKeys(iss *security.SciTokenIssuer) jwkSource {
+	if iss.JWKSURL != "" {
+		return getRemoteJWKS(iss.JWKSURL)
+	}
+	return getDiscoveredJWKS(iss.Issuer)
+}
+
+func parseSciTokenScope(s string) sciTokenScope {
+	authz, p, _ := strings.Cut(s, ":")
+	return sciTokenScope{authz: authz, path: p}
+}
+
+// covers returns true if the scope grants at least the access of the other.
+// Path-based scopes grant access to the path and everything beneath it.
+func (s sciTokenScope) covers(other sciTokenScope) bool {
+	if s.authz != other.authz {
+		return false
+	}
+	if other.path == "" {
+		return true
+	}
+
+	granted, wanted := path.Clean("/"+s.path), path.Clean("/"+other.path)
+	return granted == "/" || granted == wanted || strings.HasPrefix(wanted, granted+"/")
+}
+
+// checkSciTokenProfile checks that the token follows a supported version of
+// the SciTokens or WLCG token profile.
+func checkSciTokenProfile(claims jwtClaims) error {
+	if ver := claims.stringClaim("ver"); ver != "" {
+		if !slices.Contains(sciTokenVersions, ver) {
+			return errors.Errorf("unsupported SciToken version %q", ver)
+		}
+		return nil
+	}
+	if ver := claims.stringClaim("wlcg.ver"); ver != "" {
+		if !slices.Contains(wlcgTokenVersions, ver) {
+			return errors.Errorf("unsupported WLCG token version %q", ver)
+		}
+		return nil
+	}
+	return errors.New("token is not a SciToken or WLCG token")
+}
+
+// verifiedClaims checks the token's signature with the keys of its issuer,
+// which must be trusted, and returns its claims.
+func (req *AuthSciTokensCredentialRequest) verifiedClaims(ctx context.Context) (jwtClaims, error) {
+	_, unverified, _, _, err := parseJWT(req.token)
+	if err != nil {
+		return nil, err
+	}
+	iss := req.config.Issuer(unverified.stringClaim("iss"))
+	if iss == nil {
+		return nil, errors.Errorf("token issuer %q is not trusted", unverified.stringClaim("iss"))
+	}
+
+	verifier := &jwtVerifier{
+		issuers:   []string{iss.Issuer},
+		audiences: req.config.Audiences,
+		keys:      req.keySource(iss),
+	}
+	return verifier.Verify(ctx, req.token)
+}
+
+// grantedScopes returns the scopes granted by the token, after checking that
+// they include all of the required scopes.
+func (req *AuthSciTokensCredentialRequest) grantedScopes(claims jwtClaims) ([]string, error) {
+	granted := strings.Fields(claims.stringClaim("scope"))
+
+	for _, r := range req.config.RequiredScopes {
+		wanted := parseSciTokenScope(r)
+		if !slices.ContainsFunc(granted, func(g string) bool {
+			return parseSciTokenScope(g).covers(wanted)
+		}) {
+			return nil, errors.Errorf("token does not grant required scope %q", r)
+		}
+	}
+
+	return granted, nil
+}
+
+// GetSignedCredential validates the SciToken and returns a credential for its
+// subject carrying the scopes it grants.
+func (req *AuthSciTokensCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	if req.token == "" {
+		return nil, errors.New("no SciToken supplied")
+	}
+
+	claims, err := req.verifiedClaims(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid SciToken")
+	}
+	if err := checkSciTokenProfile(claims); err != nil {
+		return nil, err
+	}
+	scopes, err := req.grantedScopes(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	// Subjects are only unique per issuer, so unmapped identities are
+	// qualified by the issuer's host name.
+	issURL, err := url.Parse(claims.stringClaim("iss"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid SciToken issuer")
+	}
+	sys, err := externalIdentitySys(issURL.Hostname(), req.caseFold, req.config.IdentityMap,
+		claims.stringClaim("sub"), claims.stringsClaim("wlcg.groups"))
+	if err != nil {
+		return nil, err
+	}
+	sys.GrantedScopes = scopes
+	addIdentity(sys, IdentityOIDC, claims.stringClaim("sub")+"@"+issURL.Hostname())
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("%s: successfully signed credential for %s with scopes %v", claims, sys.User, scopes)
+	return credential, nil
+}
+
+func (req *AuthSciTokensCredentialRequest) GetKey() string {
+	return tokenCacheKey(req.GetAuthFlavor(), req.token)
+}
@@ -0,0 +1,200 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_sciTokenScope_covers(t *testing.T) {
+	for name, tc := range map[string]struct {
+		granted string
+		wanted  string
+		expOK   bool
+	}{
+		"same scope": {
+			granted: "storage.read:/data",
+			wanted:  "storage.read:/data",
+			expOK:   true,
+		},
+		"any path": {
+			granted: "storage.read:/data",
+			wanted:  "storage.read",
+			expOK:   true,
+		},
+		"subdirectory": {
+			granted: "storage.read:/data",
+			wanted:  "storage.read:/data/run1",
+			expOK:   true,
+		},
+		"root": {
+			granted: "storage.read:/",
+			wanted:  "storage.read:/data",
+			expOK:   true,
+		},
+		"sibling with common prefix": {
+			granted: "storage.read:/data",
+			wanted:  "storage.read:/database",
+		},
+		"parent": {
+			granted: "storage.read:/data/run1",
+			wanted:  "storage.read:/data",
+		},
+		"escape with dot-dot": {
+			granted: "storage.read:/data",
+			wanted:  "storage.read:/data/../etc",
+		},
+		"different authz": {
+			granted: "storage.read:/data",
+			wanted:  "storage.modify:/data",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ok := parseSciTokenScope(tc.granted).covers(parseSciTokenScope(tc.wanted))
+			test.AssertEqual(t, tc.expOK, ok, "unexpected result")
+		})
+	}
+}
+
+func TestAuth_AuthSciTokensCredentialRequest_GetSignedCredential(t *testing.T) {
+	tokenKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const issuer = "https://scitokens.example.org"
+	sciClaims := func(extra map[string]interface{}) map[string]interface{} {
+		claims := map[string]interface{}{
+			"iss":   issuer,
+			"aud":   "https://daos.example.org",
+			"sub":   "jdoe",
+			"ver":   "scitoken:2.0",
+			"scope": "storage.read:/home/jdoe compute.create",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		}
+		for k, v := range extra {
+			if v == nil {
+				delete(claims, k)
+				continue
+			}
+			claims[k] = v
+		}
+		return claims
+	}
+
+	for name, tc := range map[string]struct {
+		token          string
+		requiredScopes []string
+		identityMap    security.ExternalIdentityMap
+		expUser        string
+		expGroups      []string
+		expScopes      []string
+		expErr         error
+	}{
+		"no token": {
+			expErr: errors.New("no SciToken"),
+		},
+		"untrusted issuer": {
+			token:  testSignJWT(t, "RS256", "k", tokenKey, sciClaims(map[string]interface{}{"iss": "https://evil.example"})),
+			expErr: errors.New("not trusted"),
+		},
+		"wrong audience": {
+			token:  testSignJWT(t, "RS256", "k", tokenKey, sciClaims(map[string]interface{}{"aud": "https://other.example.org"})),
+			expErr: errors.New("audience"),
+		},
+		"not a SciToken": {
+			token:  testSignJWT(t, "RS256", "k", tokenKey, sciClaims(map[string]interface{}{"ver": nil})),
+			expErr: errors.New("not a SciToken or WLCG token"),
+		},
+		"unsupported version": {
+			token:  testSignJWT(t, "RS256", "k", tokenKey, sciClaims(map[string]interface{}{"ver": "scitoken:1.0"})),
+			expErr: errors.New("unsupported SciToken version"),
+		},
+		"missing required scope": {
+			token:          testSignJWT(t, "RS256", "k", tokenKey, sciClaims(nil)),
+			requiredScopes: []string{"storage.modify"},
+			expErr:         errors.New("does not grant required scope \"storage.modify\""),
+		},
+		"SciToken": {
+			token:          testSignJWT(t, "RS256", "k", tokenKey, sciClaims(nil)),
+			requiredScopes: []string{"storage.read:/home/jdoe/data"},
+			expUser:        "jdoe@scitokens.example.org",
+			expScopes:      []string{"storage.read:/home/jdoe", "compute.create"},
+		},
+		"WLCG token with groups": {
+			token: testSignJWT(t, "RS256", "k", tokenKey, sciClaims(map[string]interface{}{
+				"ver":         nil,
+				"wlcg.ver":    "1.0",
+				"wlcg.groups": []string{"/cms", "/cms/production"},
+				"scope":       "storage.read:/",
+			})),
+			expUser:   "jdoe@scitokens.example.org",
+			expGroups: []string{"/cms@scitokens.example.org", "/cms/production@scitokens.example.org"},
+			expScopes: []string{"storage.read:/"},
+		},
+		"mapped identity": {
+			token: testSignJWT(t, "RS256", "k", tokenKey, sciClaims(nil)),
+			identityMap: security.ExternalIdentityMap{
+				"jdoe": {User: "jdoe", Group: "hpc"},
+			},
+			expUser:   "jdoe@",
+			expScopes: []string{"storage.read:/home/jdoe", "compute.create"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthSciTokensCredentialRequest{
+				token:      tc.token,
+				signingKey: agentKey,
+				config: &security.SciTokensConfig{
+					Issuers:        []*security.SciTokenIssuer{{Issuer: issuer}},
+					Audiences:      []string{"https://daos.example.org"},
+					RequiredScopes: tc.requiredScopes,
+					IdentityMap:    tc.identityMap,
+				},
+				keySource: func(*security.SciTokenIssuer) jwkSource {
+					return staticJWKS{"k": &tokenKey.PublicKey}
+				},
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_SCITOKENS, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.CmpAny(t, "groups", tc.expGroups, sys.Groups)
+			test.CmpAny(t, "scopes", tc.expScopes, sys.GrantedScopes)
+			test.CmpAny(t, "identities", []*Identity{{Type: IdentityOIDC, Name: "jdoe@scitokens.example.org"}},
+				sys.Identities, test.DefaultCmpOpts()...)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	// slurmUserClaim is the claim in which slurmctld places the user name.
+	slurmUserClaim = "sun"
+	// slurmJobClaim is an optional claim restricting a token to a job.
+	slurmJobClaim = "job_id"
+	// slurmMinKeyLen is the minimum length of the shared JWT key.
+	slurmMinKeyLen = 32
+)
+
+// slurmJobCgroup matches the job component of the cgroup path of a process
+// in a Slurm job, e.g. "/slurm/uid_1000/job_42/step_0" (cgroup v1) or
+// "/system.slice/slurmstepd.scope/job_42/step_0/user/task_0" (cgroup v2).
+var slurmJobCgroup = regexp.MustCompile(`(?:^|/)job_([0-9]+)(?:/|$)`)
+
+type (
+	// AuthSlurmCredentialFactory is a factory interface for AuthSlurmCredentialRequests.
+	AuthSlurmCredentialFactory struct {
+	}
+
+	// AuthSlurmCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_SLURM flavor.
+	AuthSlurmCredentialRequest struct {
+		token      string
+		jobID      string
+		uid        uint32
+		signingKey crypto.PrivateKey
+		keyFile    string
+		caseFold   security.CaseFoldPolicy
+	}
+)
+
+// slurmJobID returns the ID of the Slurm job the process belongs to, as
+// recorded in its cgroup by slurmstepd.
+func slurmJobID(pid int32) (string, error) {
+	jobID, err := cgroupJobID(pid, slurmJobCgroup)
+	if err != nil {
+		return "", err
+	}
+	if jobID == "" {
+		return "", errors.Errorf("pid %d is not part of a Slurm job", pid)
+	}
+	return jobID, nil
+}
+
+func (fac *AuthSlurmCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthSlurmCredentialRequest{}
+
+	if secCfg == nil || secCfg.SlurmConfig.JWTKeyFile == "" {
+		return req, drpc.NewFailureWithMessage("agent is not configured for Slurm authentication")
+	}
+
+	info, err := sessionDomainInfo(log, session)
+	if err != nil {
+		return req, err
+	}
+	// The job is found here rather than when the credential is signed, as it
+	// is part of the cache key.
+	jobID, err := slurmJobID(info.Pid())
+	if err != nil {
+		return req, drpc.NewFailureWithMessage(err.Error())
+	}
+
+	req.token = strings.TrimSpace(string(reqBody))
+	req.jobID = jobID
+	req.uid = info.Uid()
+	req.signingKey = key
+	req.keyFile = secCfg.SlurmConfig.JWTKeyFile
+	req.caseFold = secCfg.PrincipalCaseFold
+
+	return req, nil
+}
+
+func GetSlurmFlavor() Flavor {
+	return Flavor_AUTH_SLURM
+}
+
+func (fac AuthSlurmCredentialFactory) GetAuthFlavor() Flavor {
+	return GetSlurmFlavor()
+}
+
+func (req *AuthSlurmCredentialRequest) GetAuthFlavor() Flavor {
+	return GetSlurmFlavor()
+}
+
+// jwtKey reads the key shared with slurmctld. The key is used as is, as it
+// is by slurmctld.
+func (req *AuthSlurmCredentialRequest) jwtKey() (sharedJWTKey, error) {
+	key, err := os.ReadFile(req.keyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading Slurm JWT key")
+	}
+	if len(key) < slurmMinKeyLen {
+		return nil, errors.Errorf("Slurm JWT key must be at least %d bytes", slurmMinKeyLen)
+	}
+	return sharedJWTKey(key), nil
+}
+
+// GetSignedCredential validates the Slurm JWT and returns a credential for
+// the user it names, restricted to the client's job.
+func (req *AuthSlurmCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	if req.token == "" {
+		return nil, errors.New("no Slurm JWT supplied")
+	}
+
+	key, err := req.jwtKey()
+	if err != nil {
+		return nil, err
+	}
+	claims, err := (&jwtVerifier{keys: key}).Verify(ctx, req.token)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid Slurm JWT")
+	}
+	if job, found := claims[slurmJobClaim]; found && fmt.Sprint(job) != req.jobID {
+		return nil, errors.Errorf("Slurm JWT is for job %v, not job %s", job, req.jobID)
+	}
+
+	sys, err := assertedIdentitySys(req.caseFold, claims.stringClaim(slurmUserClaim), "", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Slurm JWT")
+	}
+	sys.JobId = req.jobID
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("uid %d: successfully signed credential for %s in Slurm job %s",
+		req.uid, sys.User, sys.JobId)
+	return credential, nil
+}
+
+// GetKey returns a cache key for the request, which is bound to the job as
+// well as the token.
+func (req *AuthSlurmCredentialRequest) GetKey() string {
+	return tokenCacheKey(req.GetAuthFlavor(), req.jobID+":"+req.token)
+}
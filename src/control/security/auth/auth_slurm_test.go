@@ -0,0 +1,171 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// setTestProcCgroup writes the cgroup file of a fake process under a
+// temporary procRoot.
+func setTestProcCgroup(t *testing.T, pid int32, cgroup string) {
+	t.Helper()
+	orig := procRoot
+	t.Cleanup(func() { procRoot = orig })
+	procRoot = t.TempDir()
+
+	dir := filepath.Join(procRoot, fmt.Sprint(pid))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "cgroup"), []byte(cgroup), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAuth_slurmJobID(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cgroup string
+		expJob string
+		expErr error
+	}{
+		"cgroup v1": {
+			cgroup: "12:freezer:/slurm/uid_1000/job_1234/step_0\n11:memory:/slurm/uid_1000/job_1234/step_0/task_0\n",
+			expJob: "1234",
+		},
+		"cgroup v2": {
+			cgroup: "0::/system.slice/node1_slurmstepd.scope/job_77/step_batch/user/task_0\n",
+			expJob: "77",
+		},
+		"not in a job": {
+			cgroup: "0::/user.slice/user-1000.slice/session-3.scope\n",
+			expErr: errors.New("not part of a Slurm job"),
+		},
+		"job-like name": {
+			cgroup: "0::/user.slice/my_job_7.scope\n",
+			expErr: errors.New("not part of a Slurm job"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setTestProcCgroup(t, 42, tc.cgroup)
+
+			job, err := slurmJobID(42)
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expJob, job, "unexpected job")
+		})
+	}
+}
+
+func TestAuth_AuthSlurmCredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwtKey := []byte("0123456789abcdef0123456789abcdef")
+	keyFile := filepath.Join(t.TempDir(), "jwt_hs256.key")
+	if err := os.WriteFile(keyFile, jwtKey, 0600); err != nil {
+		t.Fatal(err)
+	}
+	shortKeyFile := filepath.Join(t.TempDir(), "short.key")
+	if err := os.WriteFile(shortKeyFile, []byte("secret"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := time.Now().Add(time.Hour).Unix()
+	for name, tc := range map[string]struct {
+		token   string
+		keyFile string
+		expUser string
+		expErr  error
+	}{
+		"no token": {
+			expErr: errors.New("no Slurm JWT"),
+		},
+		"short key": {
+			token:   testSignHMACJWT(t, "", jwtKey, map[string]interface{}{"sun": "jdoe", "exp": exp}),
+			keyFile: shortKeyFile,
+			expErr:  errors.New("at least 32 bytes"),
+		},
+		"wrong key": {
+			token:  testSignHMACJWT(t, "", []byte("not the slurmctld key"), map[string]interface{}{"sun": "jdoe", "exp": exp}),
+			expErr: errors.New("HMAC verification failed"),
+		},
+		"expired": {
+			token:  testSignHMACJWT(t, "", jwtKey, map[string]interface{}{"sun": "jdoe", "exp": time.Now().Add(-time.Hour).Unix()}),
+			expErr: errors.New("expired"),
+		},
+		"no user": {
+			token:  testSignHMACJWT(t, "", jwtKey, map[string]interface{}{"exp": exp}),
+			expErr: errors.New("no user"),
+		},
+		"other job": {
+			token:  testSignHMACJWT(t, "", jwtKey, map[string]interface{}{"sun": "jdoe", "exp": exp, "job_id": 99}),
+			expErr: errors.New("not job 1234"),
+		},
+		"success": {
+			token:   testSignHMACJWT(t, "", jwtKey, map[string]interface{}{"sun": "jdoe", "exp": exp}),
+			expUser: "jdoe@",
+		},
+		"success with job claim": {
+			token:   testSignHMACJWT(t, "", jwtKey, map[string]interface{}{"sun": "jdoe", "exp": exp, "job_id": 1234}),
+			expUser: "jdoe@",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthSlurmCredentialRequest{
+				token:      tc.token,
+				jobID:      "1234",
+				uid:        1000,
+				signingKey: agentKey,
+				keyFile:    keyFile,
+			}
+			if tc.keyFile != "" {
+				req.keyFile = tc.keyFile
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_SLURM, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, "1234", sys.JobId, "unexpected job")
+		})
+	}
+}
+
+func TestAuth_AuthSlurmCredentialRequest_GetKey(t *testing.T) {
+	req := func(job, token string) *AuthSlurmCredentialRequest {
+		return &AuthSlurmCredentialRequest{jobID: job, token: token}
+	}
+
+	test.AssertEqual(t, req("1", "tok").GetKey(), req("1", "tok").GetKey(), "same request, different keys")
+	test.AssertTrue(t, req("1", "tok").GetKey() != req("2", "tok").GetKey(), "key not bound to job")
+}
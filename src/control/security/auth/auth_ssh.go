@@ -0,0 +1,148 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/binary"
+	"os"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+type (
+	// AuthSSHCredentialFactory is a factory interface for AuthSSHCredentialRequests.
+	AuthSSHCredentialFactory struct {
+	}
+
+	// AuthSSHCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_SSH flavor.
+	AuthSSHCredentialRequest struct {
+		req                *SSHAuthReq
+		uid                uint32
+		signingKey         crypto.PrivateKey
+		authorizedKeysFile string
+		challenges         *Challenges
+	}
+)
+
+func (fac *AuthSSHCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthSSHCredentialRequest{}
+
+	if secCfg == nil || secCfg.SSHConfig.AuthorizedKeysFile == "" {
+		return req, drpc.NewFailureWithMessage("agent is not configured for SSH authentication")
+	}
+
+	req.req = new(SSHAuthReq)
+	if err := proto.Unmarshal(reqBody, req.req); err != nil {
+		return req, drpc.UnmarshalingPayloadFailure()
+	}
+
+	uid, err := sessionUID(log, session)
+	if err != nil {
+		return req, err
+	}
+
+	req.uid = uid
+	req.signingKey = key
+	req.authorizedKeysFile = secCfg.SSHConfig.AuthorizedKeysFile
+	req.challenges = defaultChallenges
+
+	return req, nil
+}
+
+func GetSSHFlavor() Flavor {
+	return Flavor_AUTH_SSH
+}
+
+func (fac AuthSSHCredentialFactory) GetAuthFlavor() Flavor {
+	return GetSSHFlavor()
+}
+
+func (req *AuthSSHCredentialRequest) GetAuthFlavor() Flavor {
+	return GetSSHFlavor()
+}
+
+// authorizedKey returns the entry in the authorized keys file for the key.
+func (req *AuthSSHCredentialRequest) authorizedKey(key *sshPublicKey) (*sshAuthorizedKey, error) {
+	data, err := os.ReadFile(req.authorizedKeysFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading SSH authorized keys")
+	}
+	keys, err := parseSSHAuthorizedKeys(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", req.authorizedKeysFile)
+	}
+
+	for _, ak := range keys {
+		if bytes.Equal(ak.key.blob, key.blob) {
+			return ak, nil
+		}
+	}
+	return nil, errors.New("SSH key is not authorized")
+}
+
+// GetSignedCredential checks the client's signature of the challenge and
+// returns a credential for the user the key is authorized for.
+func (req *AuthSSHCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	if len(req.req.GetPublicKey()) == 0 || len(req.req.GetSignature()) == 0 {
+		return nil, errors.New("no SSH public key or signature supplied")
+	}
+
+	key, err := parseSSHPublicKey(req.req.GetPublicKey())
+	if err != nil {
+		return nil, err
+	}
+	ak, err := req.authorizedKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the signature before consuming the challenge, so that an
+	// invalid request cannot burn a legitimate client's challenge.
+	if err := key.verify(sshSignedData(req.req.GetChallenge()), req.req.GetSignature()); err != nil {
+		return nil, err
+	}
+	if err := req.challenges.Redeem(req.req.GetChallenge(), req.uid); err != nil {
+		return nil, err
+	}
+
+	hostname, err := GetMachineName()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get hostname")
+	}
+	sys := &Sys{
+		Machinename: hostname,
+		User:        sysNameToPrincipalName(ak.user),
+	}
+	if ak.group != "" {
+		sys.Group = sysNameToPrincipalName(ak.group)
+	}
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("uid %d: successfully signed credential for %s with %s key", req.uid, sys.User, key.keyType)
+	return credential, nil
+}
+
+// GetKey returns a cache key for the request. As the cache is consulted before
+// the challenge is redeemed, the key is bound to the requesting uid.
+func (req *AuthSSHCredentialRequest) GetKey() string {
+	data := binary.BigEndian.AppendUint32(nil, req.uid)
+	data = append(data, req.req.GetChallenge()...)
+	data = append(data, req.req.GetSignature()...)
+	return tokenCacheKey(req.GetAuthFlavor(), string(data))
+}
@@ -0,0 +1,153 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestAuth_AuthSSHCredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := testSSHKeys(t)
+	edKey, ecKey := keys[sshKeyEd25519], keys[sshKeyECDSA256]
+	edBlob := testSSHPublicKey(t, edKey.Public())
+
+	akFile := filepath.Join(t.TempDir(), "authorized_keys")
+	akData := fmt.Sprintf("daos-user=\"jdoe\",daos-group=\"hpc\" ssh-ed25519 %s jdoe@laptop\n",
+		base64.StdEncoding.EncodeToString(edBlob))
+	if err := os.WriteFile(akFile, []byte(akData), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	const uid = 1000
+	challenges := NewChallenges(ChallengeLifetime)
+	newChallenge := func() []byte {
+		challenge, err := challenges.Issue(uid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return challenge
+	}
+
+	for name, tc := range map[string]struct {
+		req      func() *SSHAuthReq
+		expUser  string
+		expGroup string
+		expErr   error
+	}{
+		"no signature": {
+			req: func() *SSHAuthReq {
+				return &SSHAuthReq{PublicKey: edBlob, Challenge: newChallenge()}
+			},
+			expErr: errors.New("no SSH public key or signature"),
+		},
+		"unauthorized key": {
+			req: func() *SSHAuthReq {
+				challenge := newChallenge()
+				return &SSHAuthReq{
+					PublicKey: testSSHPublicKey(t, ecKey.Public()),
+					Challenge: challenge,
+					Signature: testSSHSign(t, ecKey, sshSignedData(challenge)),
+				}
+			},
+			expErr: errors.New("not authorized"),
+		},
+		"signature by another key": {
+			req: func() *SSHAuthReq {
+				challenge := newChallenge()
+				return &SSHAuthReq{
+					PublicKey: edBlob,
+					Challenge: challenge,
+					Signature: testSSHSign(t, ecKey, sshSignedData(challenge)),
+				}
+			},
+			expErr: errors.New("does not match key type"),
+		},
+		"signature over raw challenge": {
+			req: func() *SSHAuthReq {
+				challenge := newChallenge()
+				return &SSHAuthReq{
+					PublicKey: edBlob,
+					Challenge: challenge,
+					Signature: testSSHSign(t, edKey, challenge),
+				}
+			},
+			expErr: errors.New("invalid ed25519 signature"),
+		},
+		"forged challenge": {
+			req: func() *SSHAuthReq {
+				challenge := make([]byte, challengeLen)
+				return &SSHAuthReq{
+					PublicKey: edBlob,
+					Challenge: challenge,
+					Signature: testSSHSign(t, edKey, sshSignedData(challenge)),
+				}
+			},
+			expErr: errors.New("not issued by this agent"),
+		},
+		"success": {
+			req: func() *SSHAuthReq {
+				challenge := newChallenge()
+				return &SSHAuthReq{
+					PublicKey: edBlob,
+					Challenge: challenge,
+					Signature: testSSHSign(t, edKey, sshSignedData(challenge)),
+				}
+			},
+			expUser:  "jdoe@",
+			expGroup: "hpc@",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthSSHCredentialRequest{
+				req:                tc.req(),
+				uid:                uid,
+				signingKey:         agentKey,
+				authorizedKeysFile: akFile,
+				challenges:         challenges,
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_SSH, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, sys.Group, "unexpected group")
+
+			// The challenge cannot be used to obtain another credential.
+			_, err = req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, errors.New("already been used"), err)
+		})
+	}
+}
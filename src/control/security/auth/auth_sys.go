@@ -16,6 +16,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"syscall"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
@@ -67,6 +68,8 @@ type (
 		getGroupIds                 getGroupIdsFn
 		getGroupNames               getGroupNamesFn
 		clientMap                   *security.ClientUserMap
+		container                   *processContainer
+		process                     *processBinding
 		GetSignedCredentialInternal GetSignedCredentialInternalFn
 	}
 )
@@ -211,18 +214,55 @@ func (fact *AuthSysCredentialFactory) Init(log logging.Logger, secCfg *security.
 		log.Errorf("Unable to get credentials for client socket: %s", err)
 		return req, daos.MiscError
 	}
+	if secCfg.MapUserNamespaces {
+		uid, gid, err := userNamespaceIDs(info.Pid(), info.Uid(), info.Gid())
+		if err != nil {
+			log.Errorf("Unable to map user namespace IDs of client: %s", err)
+			return req, daos.MiscError
+		}
+		if uid != info.Uid() || gid != info.Gid() {
+			log.Debugf("%s: in user namespace, identified as uid %d gid %d", info, uid, gid)
+			info = security.InitDomainInfo(&syscall.Ucred{Pid: info.Pid(), Uid: uid, Gid: gid}, info.Ctx())
+		}
+	}
+	container, err := clientContainer(&secCfg.ContainerConfig, info.Pid())
+	if err != nil {
+		log.Errorf("Unable to determine container of client: %s", err)
+		return req, daos.MiscError
+	}
+	process, err := clientProcess(secCfg.ProcessBinding, info.Pid())
+	if err != nil {
+		log.Errorf("Unable to bind credential to client process: %s", err)
+		return req, daos.MiscError
+	}
 
-	req.DomainInfo = info
-	req.signingKey = key
-	req.getHostname = GetMachineName
-	req.getUser = user.LookupId
-	req.getGroup = user.LookupGroupId
-	req.getGroupIds = getGroupIds
-	req.getGroupNames = getGroupNames
-	req.clientMap = &secCfg.ClientUserMap
-	req.GetSignedCredentialInternal = GetSignedCredentialInternalImpl
+	sysReq := newAuthSysRequest(secCfg, info, container, key)
+	sysReq.process = process
+	return sysReq, nil
+}
+
+// InitForUser initializes a request for the credential of the user, as if it
+// had been requested by one of the user's processes outside of any container.
+func (fact *AuthSysCredentialFactory) InitForUser(log logging.Logger, secCfg *security.CredentialConfig, info *security.DomainInfo, key crypto.PrivateKey) (CredentialRequest, error) {
+	if info == nil {
+		return &AuthSysCredentialRequest{}, errors.New("nil domain info")
+	}
+	return newAuthSysRequest(secCfg, info, nil, key), nil
+}
 
-	return req, nil
+func newAuthSysRequest(secCfg *security.CredentialConfig, info *security.DomainInfo, container *processContainer, key crypto.PrivateKey) *AuthSysCredentialRequest {
+	return &AuthSysCredentialRequest{
+		DomainInfo:                  info,
+		signingKey:                  key,
+		getHostname:                 GetMachineName,
+		getUser:                     user.LookupId,
+		getGroup:                    user.LookupGroupId,
+		getGroupIds:                 getGroupIds,
+		getGroupNames:               getGroupNames,
+		clientMap:                   &secCfg.ClientUserMap,
+		container:                   container,
+		GetSignedCredentialInternal: GetSignedCredentialInternalImpl,
+	}
 }
 
 // GetSignedCredential returns a credential based on the provided domain info and
@@ -264,6 +304,8 @@ func GetSignedCredentialInternalImpl(ctx context.Context, req *AuthSysCredential
 		Group:       groupPrinc,
 		Groups:      groupPrincs,
 		Secctx:      req.DomainInfo.Ctx()}
+	req.container.apply(&sys)
+	req.process.apply(&sys)
 
 	// Marshal our AuthSys token into a byte array
 	tokenBytes, err := proto.Marshal(&sys)
@@ -274,22 +316,13 @@ func GetSignedCredentialInternalImpl(ctx context.Context, req *AuthSysCredential
 		Flavor: req.GetAuthFlavor(),
 		Data:   tokenBytes}
 
-	verifier, err := VerifierFromToken(req.signingKey, &token)
+	credential, err := newCredential(req.signingKey, &token)
 	if err != nil {
-		return nil, errors.WithMessage(err, "Unable to generate verifier")
+		return nil, err
 	}
 
-	verifierToken := Token{
-		Flavor: req.GetAuthFlavor(),
-		Data:   verifier}
-
-	credential := Credential{
-		Token:    &token,
-		Verifier: &verifierToken,
-		Origin:   "agent"}
-
 	logging.FromContext(ctx).Tracef("%s: successfully signed credential", req.DomainInfo)
-	return &credential, nil
+	return credential, nil
 }
 
 // To satisfy the unit tests, https://stackoverflow.com/a/76595928
@@ -348,7 +381,7 @@ func (req *AuthSysCredentialRequest) GetSignedCredential(log logging.Logger, ctx
 }
 
 func (req *AuthSysCredentialRequest) GetKey() string {
-	return fmt.Sprintf("%d:%d:%s", req.DomainInfo.Uid(), req.DomainInfo.Gid(), req.DomainInfo.Ctx())
+	return fmt.Sprintf("%d:%d:%s%s%s", req.DomainInfo.Uid(), req.DomainInfo.Gid(), req.DomainInfo.Ctx(), req.container.key(), req.process.key())
 }
 
 func GetSysFlavor() Flavor {
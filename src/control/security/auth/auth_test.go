@@ -7,6 +7,9 @@
 package auth
 
 import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
 	"errors"
 	"os/user"
 	"syscall"
@@ -294,3 +297,273 @@ func TestAuth_CredentialRequestOverrides(t *testing.T) {
 
 	verifyCredential(t, cred, "test-host", "test-user@", "test-group@", "test-secondary@")
 }
+
+func TestAuth_AuthSysCredentialFactory_InitForUser(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	factory := &AuthSysCredentialFactory{}
+	if _, err := factory.InitForUser(log, &security.CredentialConfig{}, nil, nil); err == nil {
+		t.Fatal("expected error for nil domain info")
+	}
+
+	info := getTestCreds(1, 2)
+	req, err := factory.InitForUser(log, &security.CredentialConfig{}, info, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The credential is cached under the same key as one requested by a
+	// process of the user.
+	test.AssertEqual(t, NewCredentialRequest(info, nil).GetKey(), req.GetKey(), "unexpected key")
+}
+
+func TestAuth_VerifierHash(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := SetVerifierHash(crypto.SHA512); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	for name, tc := range map[string]struct {
+		hash   crypto.Hash
+		key    crypto.PrivateKey
+		expAlg HashAlgorithm
+		expErr error
+	}{
+		"default": {
+			hash:   crypto.SHA512,
+			key:    agentKey,
+			expAlg: HashAlgorithm_HASH_SHA512,
+		},
+		"sha256": {
+			hash:   crypto.SHA256,
+			key:    agentKey,
+			expAlg: HashAlgorithm_HASH_SHA256,
+		},
+		"sha384 unsigned": {
+			hash:   crypto.SHA384,
+			expAlg: HashAlgorithm_HASH_SHA384,
+		},
+		"unsupported": {
+			hash:   crypto.MD5,
+			expErr: errors.New("may not be used for verifiers"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := SetVerifierHash(tc.hash)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			cred, err := newSignedCredential(Flavor_AUTH_SYS, tc.key, &Sys{User: "user@"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expAlg, cred.GetVerifier().GetHash(), "hash not recorded in verifier")
+			test.AssertEqual(t, HashAlgorithm(0), cred.GetToken().GetHash(), "hash recorded in token")
+
+			var pub crypto.PublicKey
+			if tc.key != nil {
+				pub = &agentKey.PublicKey
+			}
+			if err := VerifyCredential(pub, cred); err != nil {
+				t.Fatalf("expected credential to verify: %s", err)
+			}
+
+			// Verifiers computed with SHA-512 before the hash was
+			// recorded verify as before.
+			err = VerifyToken(pub, cred.GetToken(), cred.GetVerifier().GetData())
+			if tc.expAlg == HashAlgorithm_HASH_SHA512 && err != nil {
+				t.Fatalf("expected SHA-512 verifier to verify: %s", err)
+			}
+			if tc.expAlg != HashAlgorithm_HASH_SHA512 && err == nil {
+				t.Fatal("expected verifier not to verify with SHA-512")
+			}
+		})
+	}
+}
+
+func TestAuth_SetSigningPool(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := security.NewSigningPool(2)
+	SetSigningPool(pool)
+	defer SetSigningPool(nil)
+
+	cred, err := newSignedCredential(Flavor_AUTH_SYS, agentKey, &Sys{User: "user@"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyCredential(&agentKey.PublicKey, cred); err != nil {
+		t.Fatalf("expected credential signed by the pool to verify: %s", err)
+	}
+
+	// Credentials are not signed once the pool is closed.
+	pool.Close()
+	_, err = newSignedCredential(Flavor_AUTH_SYS, agentKey, &Sys{User: "user@"})
+	test.CmpErr(t, security.ErrSigningPoolClosed, err)
+}
+
+func TestAuth_SetTokenSignerBackend(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := SetTokenSignerBackend(security.TokenSignerSoftware); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	for name, tc := range map[string]struct {
+		backend  security.TokenSignerBackend
+		expErr   error
+		expSigns bool
+	}{
+		"software": {
+			backend:  security.TokenSignerSoftware,
+			expSigns: true,
+		},
+		"pkcs11 refuses a key in memory": {
+			backend: security.TokenSignerPKCS11,
+			expErr:  errors.New("held in memory rather than by a PKCS#11 token"),
+		},
+		"null": {
+			backend: security.TokenSignerNull,
+		},
+		"unknown": {
+			backend: "magic",
+			expErr:  errors.New(`unknown token signer "magic"`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cred, err := func() (*Credential, error) {
+				if err := SetTokenSignerBackend(tc.backend); err != nil {
+					return nil, err
+				}
+				return newSignedCredential(Flavor_AUTH_SYS, agentKey, &Sys{User: "user@"})
+			}()
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			if err := VerifyCredential(&agentKey.PublicKey, cred); err != nil {
+				t.Fatalf("expected credential to verify: %s", err)
+			}
+
+			// Only a signed credential verifies with the software signer.
+			if err := SetTokenSignerBackend(security.TokenSignerSoftware); err != nil {
+				t.Fatal(err)
+			}
+			err = VerifyCredential(&agentKey.PublicKey, cred)
+			test.AssertEqual(t, tc.expSigns, err == nil, "unexpected verification result")
+		})
+	}
+}
+
+func TestAuth_SetSignatureObserver(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expKeyID, err := security.PublicKeyID(&agentKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotKeyIDs []string
+	var gotFlavors []Flavor
+	SetSignatureObserver(func(keyID string, flavor Flavor) {
+		gotKeyIDs = append(gotKeyIDs, keyID)
+		gotFlavors = append(gotFlavors, flavor)
+	})
+	defer SetSignatureObserver(nil)
+
+	if _, err := newSignedCredential(Flavor_AUTH_MACHINE, agentKey, &Sys{User: "user@"}); err != nil {
+		t.Fatal(err)
+	}
+	// Unsigned credentials are not observed.
+	if _, err := newSignedCredential(Flavor_AUTH_SYS, nil, &Sys{User: "user@"}); err != nil {
+		t.Fatal(err)
+	}
+
+	test.AssertEqual(t, []string{expKeyID}, gotKeyIDs, "unexpected key IDs observed")
+	test.AssertEqual(t, []Flavor{Flavor_AUTH_MACHINE}, gotFlavors, "unexpected flavors observed")
+}
+
+func TestAuth_newSignedCredential_KeyID(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expKeyID, err := security.PublicKeyID(&agentKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		key      crypto.PrivateKey
+		expKeyID string
+	}{
+		"signed": {
+			key:      agentKey,
+			expKeyID: expKeyID,
+		},
+		"unsigned": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cred, err := newSignedCredential(Flavor_AUTH_SYS, tc.key, &Sys{User: "user@"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expKeyID, cred.GetKeyId(), "unexpected key ID")
+		})
+	}
+}
+
+func TestAuth_externalIdentitySys(t *testing.T) {
+	for name, tc := range map[string]struct {
+		id        string
+		groups    []string
+		expUser   string
+		expGroups []string
+	}{
+		"unqualified": {
+			id:        "jdoe",
+			groups:    []string{"hpc"},
+			expUser:   "jdoe@vault",
+			expGroups: []string{"hpc@vault"},
+		},
+		"domain-qualified": {
+			id:        "jdoe@example.com",
+			groups:    []string{"hpc@example.com"},
+			expUser:   "jdoe@example.com",
+			expGroups: []string{"hpc@example.com"},
+		},
+		"empty domain is not local": {
+			id:        "root@",
+			groups:    []string{"root@"},
+			expUser:   "root@vault",
+			expGroups: []string{"root@vault"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sys, err := externalIdentitySys("vault", security.CaseFoldNone, nil, tc.id, tc.groups)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.GetUser(), "unexpected user")
+			test.CmpAny(t, "groups", tc.expGroups, sys.GetGroups())
+		})
+	}
+}
@@ -0,0 +1,374 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	// DefaultTOTPDigits is the length of codes if none is configured.
+	DefaultTOTPDigits = 6
+	// DefaultTOTPPeriod is the time for which each code is valid if no
+	// period is configured.
+	DefaultTOTPPeriod = 30 * time.Second
+
+	// totpMinSecretLen is the minimum length of an enrolled secret, as
+	// required by RFC 4226.
+	totpMinSecretLen = 16
+	// totpMaxFailures is the number of consecutive incorrect codes after which
+	// a user is locked out for totpLockout.
+	totpMaxFailures = 5
+	totpLockout     = 5 * time.Minute
+)
+
+type (
+	// AuthTOTPCredentialFactory is a factory interface for AuthTOTPCredentialRequests.
+	AuthTOTPCredentialFactory struct {
+	}
+
+	// AuthTOTPCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_TOTP flavor.
+	AuthTOTPCredentialRequest struct {
+		code       string
+		uid        uint32
+		gid        uint32
+		secctx     string
+		signingKey crypto.PrivateKey
+		config     *security.TOTPConfig
+		state      *totpState
+		caseFold   security.CaseFoldPolicy
+		now        func() time.Time
+		container  *processContainer
+		process    *processBinding
+	}
+
+	// totpUserState records the use of codes by a user.
+	totpUserState struct {
+		lastStep    uint64
+		failures    int
+		lastFailure time.Time
+	}
+
+	// totpState records the last time step used by each user, so that each
+	// code can only be used once, and recent failures, so that codes cannot be
+	// guessed.
+	totpState struct {
+		sync.Mutex
+		users map[string]*totpUserState
+	}
+)
+
+var defaultTOTPState = newTOTPState()
+
+func newTOTPState() *totpState {
+	return &totpState{users: make(map[string]*totpUserState)}
+}
+
+func (ts *totpState) get(user string) *totpUserState {
+	us, found := ts.users[user]
+	if !found {
+		us = &totpUserState{}
+		ts.users[user] = us
+	}
+	return us
+}
+
+func (fac *AuthTOTPCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthTOTPCredentialRequest{}
+
+	if secCfg == nil || secCfg.TOTPConfig.SecretsFile == "" {
+		return req, drpc.NewFailureWithMessage("agent is not configured for TOTP authentication")
+	}
+
+	info, err := sessionDomainInfo(log, session)
+	if err != nil {
+		return req, err
+	}
+	container, err := clientContainer(&secCfg.ContainerConfig, info.Pid())
+	if err != nil {
+		return req, err
+	}
+	process, err := clientProcess(secCfg.ProcessBinding, info.Pid())
+	if err != nil {
+		return req, err
+	}
+
+	req.code = strings.TrimSpace(string(reqBody))
+	req.uid = info.Uid()
+	req.gid = info.Gid()
+	req.secctx = info.Ctx()
+	req.signingKey = key
+	req.config = &secCfg.TOTPConfig
+	req.state = defaultTOTPState
+	req.caseFold = secCfg.PrincipalCaseFold
+	req.now = time.Now
+	req.container = container
+	req.process = process
+
+	return req, nil
+}
+
+func GetTOTPFlavor() Flavor {
+	return Flavor_AUTH_TOTP
+}
+
+func (fac AuthTOTPCredentialFactory) GetAuthFlavor() Flavor {
+	return GetTOTPFlavor()
+}
+
+func (req *AuthTOTPCredentialRequest) GetAuthFlavor() Flavor {
+	return GetTOTPFlavor()
+}
+
+// localIdentitySys builds a Sys token for the local user and the group with
+// the given ID.
+func localIdentitySys(fold security.CaseFoldPolicy, u *user.User, gid uint32) (*Sys, error) {
+	g, err := user.LookupGroupId(strconv.FormatUint(uint64(gid), 10))
+	if err != nil {
+		return nil, err
+	}
+	gids, err := u.GroupIds()
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up groups of %q", u.Username)
+	}
+	var groups []string
+	for _, id := range gids {
+		sg, err := user.LookupGroupId(id)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, sg.Name)
+	}
+
+	return assertedIdentitySys(fold, u.Username, g.Name, groups)
+}
+
+// parseTOTPSecrets parses a secrets file. Each line holds a user name and
+// the base32 secret enrolled for them, as shown by authenticator apps.
+//
+//	jdoe JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+func parseTOTPSecrets(data []byte) (map[string][]byte, error) {
+	secrets := make(map[string][]byte)
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, errors.Errorf("line %d: expected user and secret", lineNum)
+		}
+		secret, err := encoding.DecodeString(strings.TrimRight(strings.ToUpper(fields[1]), "="))
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d: secret", lineNum)
+		}
+		if len(secret) < totpMinSecretLen {
+			return nil, errors.Errorf("line %d: secret must be at least %d bytes", lineNum, totpMinSecretLen)
+		}
+		if _, found := secrets[fields[0]]; found {
+			return nil, errors.Errorf("line %d: duplicate secret for user %q", lineNum, fields[0])
+		}
+		secrets[fields[0]] = secret
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+// userSecret returns the secret enrolled for the user. Anyone able to read the
+// secrets can generate codes, so the file must only be readable by the agent.
+func (req *AuthTOTPCredentialRequest) userSecret(name string) ([]byte, error) {
+	fi, err := os.Stat(req.config.SecretsFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "TOTP secrets")
+	}
+	if fi.Mode().Perm()&0077 != 0 {
+		return nil, errors.Errorf("TOTP secrets file %q is accessible by group or others", req.config.SecretsFile)
+	}
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok && st.Uid != 0 && int(st.Uid) != os.Geteuid() {
+		return nil, errors.Errorf("TOTP secrets file %q is not owned by root or the agent user", req.config.SecretsFile)
+	}
+
+	data, err := os.ReadFile(req.config.SecretsFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading TOTP secrets")
+	}
+	secrets, err := parseTOTPSecrets(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", req.config.SecretsFile)
+	}
+
+	secret, found := secrets[name]
+	if !found {
+		return nil, errors.Errorf("no TOTP secret is enrolled for user %q", name)
+	}
+	return secret, nil
+}
+
+// totpCode returns the code for the time step (RFC 4226, section 5.3).
+func totpCode(secret []byte, step uint64, digits int) string {
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(binary.BigEndian.AppendUint64(nil, step))
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0xf
+	value := binary.BigEndian.Uint32(sum[offset:]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
+
+// verifyCode checks the code against those for the time steps around now,
+// and records its use so that it cannot be used again.
+func (req *AuthTOTPCredentialRequest) verifyCode(name string, secret []byte) error {
+	digits := req.config.Digits
+	if digits == 0 {
+		digits = DefaultTOTPDigits
+	}
+	period := req.config.Period
+	if period == 0 {
+		period = DefaultTOTPPeriod
+	}
+	if len(req.code) != digits {
+		return errors.Errorf("TOTP code must be %d digits", digits)
+	}
+
+	req.state.Lock()
+	defer req.state.Unlock()
+
+	now := req.now()
+	us := req.state.get(name)
+	if us.failures >= totpMaxFailures && now.Sub(us.lastFailure) < totpLockout {
+		return errors.Errorf("too many incorrect TOTP codes for user %q; try again later", name)
+	}
+
+	current := uint64(now.Unix()) / uint64(period/time.Second)
+	skew := uint64(req.config.Skew)
+	for step := current - min(skew, current); step <= current+skew; step++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(secret, step, digits)), []byte(req.code)) != 1 {
+			continue
+		}
+		if step <= us.lastStep {
+			return errors.New("TOTP code has already been used")
+		}
+		us.lastStep = step
+		us.failures = 0
+		return nil
+	}
+
+	us.failures++
+	us.lastFailure = now
+	return errors.New("incorrect TOTP code")
+}
+
+// GetSignedCredential confirms the identity of the client's user with the
+// TOTP code and returns a credential for them marked as having been
+// confirmed with a second factor.
+func (req *AuthTOTPCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	if req.code == "" {
+		return nil, errors.New("no TOTP code supplied")
+	}
+
+	u, err := user.LookupId(strconv.FormatUint(uint64(req.uid), 10))
+	if err != nil {
+		return nil, err
+	}
+	sys, err := localIdentitySys(req.caseFold, u, req.gid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up uid %d", req.uid)
+	}
+
+	secret, err := req.userSecret(u.Username)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.verifyCode(u.Username, secret); err != nil {
+		log.Noticef("audit: uid %d: TOTP verification failed: %s", req.uid, err)
+		return nil, err
+	}
+	sys.SecondFactor = true
+	sys.Secctx = req.secctx
+	req.container.apply(sys)
+	req.process.apply(sys)
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("uid %d: successfully signed credential for %s confirmed by TOTP",
+		req.uid, sys.User)
+	return credential, nil
+}
+
+// GetKey returns a cache key for the request, which is bound to the
+// requesting uid, gid and MAC label, and cgroup and process if recorded, as
+// well as the code.
+func (req *AuthTOTPCredentialRequest) GetKey() string {
+	data := binary.BigEndian.AppendUint32(nil, req.uid)
+	data = binary.BigEndian.AppendUint32(data, req.gid)
+	data = append(data, req.secctx...)
+	data = append(data, 0)
+	data = append(data, req.code...)
+	data = append(data, req.container.key()...)
+	data = append(data, req.process.key()...)
+	return tokenCacheKey(req.GetAuthFlavor(), string(data))
+}
+
+// ValidateTOTPToken checks that only AUTH_TOTP tokens claim to have been
+// confirmed with a second factor, so that access requiring one cannot be
+// gained with another flavor.
+func ValidateTOTPToken(token *Token) error {
+	sys, err := sysFromToken(token)
+	if err != nil {
+		return err
+	}
+	if token.GetFlavor() != Flavor_AUTH_TOTP {
+		if sys.SecondFactor {
+			return errors.Errorf("%s credential claims a second factor", token.GetFlavor())
+		}
+		return nil
+	}
+
+	if !sys.SecondFactor {
+		return errors.New("TOTP credential is not marked as confirmed with a second factor")
+	}
+
+	return nil
+}
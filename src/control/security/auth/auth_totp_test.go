@@ -0,0 +1,239 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base32"
+	"os"
+	"os/user"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// rfc6238Secret is the SHA1 secret used by the test vectors of RFC 6238.
+var rfc6238Secret = []byte("12345678901234567890")
+
+func TestAuth_totpCode(t *testing.T) {
+	for unix, expCode := range map[int64]string{
+		59:          "94287082",
+		1111111109:  "07081804",
+		1111111111:  "14050471",
+		1234567890:  "89005924",
+		2000000000:  "69279037",
+		20000000000: "65353130",
+	} {
+		test.AssertEqual(t, expCode, totpCode(rfc6238Secret, uint64(unix/30), 8), "unexpected code")
+	}
+	test.AssertEqual(t, "287082", totpCode(rfc6238Secret, 1, 6), "unexpected 6-digit code")
+}
+
+func TestAuth_parseTOTPSecrets(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString(rfc6238Secret)
+
+	for name, tc := range map[string]struct {
+		data       string
+		expSecrets map[string][]byte
+		expErr     error
+	}{
+		"comments and padding": {
+			data:       "# enrolled users\n\njdoe " + secret + "\n",
+			expSecrets: map[string][]byte{"jdoe": rfc6238Secret},
+		},
+		"lowercase": {
+			data:       "jdoe " + "gezdgnbvgy3tqojqgezdgnbvgy3tqojq",
+			expSecrets: map[string][]byte{"jdoe": rfc6238Secret},
+		},
+		"missing secret": {
+			data:   "jdoe\n",
+			expErr: errors.New("line 1: expected user and secret"),
+		},
+		"invalid secret": {
+			data:   "jdoe not-base32!\n",
+			expErr: errors.New("line 1: secret"),
+		},
+		"short secret": {
+			data:   "jdoe JBSWY3DPEHPK3PXP\n",
+			expErr: errors.New("at least 16 bytes"),
+		},
+		"duplicate": {
+			data:   "jdoe " + secret + "\njdoe " + secret + "\n",
+			expErr: errors.New("line 2: duplicate"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			secrets, err := parseTOTPSecrets([]byte(tc.data))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.CmpAny(t, "secrets", tc.expSecrets, secrets)
+		})
+	}
+}
+
+func TestAuth_AuthTOTPCredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cur, err := user.Current()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	writeSecrets := func(name, user string, perm os.FileMode) string {
+		path := filepath.Join(dir, name)
+		data := user + " " + base32.StdEncoding.EncodeToString(rfc6238Secret) + "\n"
+		if err := os.WriteFile(path, []byte(data), perm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chmod(path, perm); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	secretsFile := writeSecrets("secrets", cur.Username, 0600)
+	readableFile := writeSecrets("readable", cur.Username, 0640)
+	otherFile := writeSecrets("other", "someone-else", 0600)
+
+	now := time.Unix(1111111111, 0)
+	step := uint64(now.Unix() / 30)
+
+	for name, tc := range map[string]struct {
+		code        string
+		secretsFile string
+		state       *totpUserState
+		expErr      error
+	}{
+		"no code": {
+			expErr: errors.New("no TOTP code"),
+		},
+		"secrets readable by group": {
+			code:        totpCode(rfc6238Secret, step, 6),
+			secretsFile: readableFile,
+			expErr:      errors.New("accessible by group or others"),
+		},
+		"not enrolled": {
+			code:        totpCode(rfc6238Secret, step, 6),
+			secretsFile: otherFile,
+			expErr:      errors.New("no TOTP secret is enrolled"),
+		},
+		"wrong length": {
+			code:   "1234",
+			expErr: errors.New("must be 6 digits"),
+		},
+		"incorrect code": {
+			code:   totpCode(rfc6238Secret, step+5, 6),
+			expErr: errors.New("incorrect TOTP code"),
+		},
+		"already used": {
+			code:   totpCode(rfc6238Secret, step, 6),
+			state:  &totpUserState{lastStep: step},
+			expErr: errors.New("already been used"),
+		},
+		"locked out": {
+			code:   totpCode(rfc6238Secret, step, 6),
+			state:  &totpUserState{failures: totpMaxFailures, lastFailure: now.Add(-time.Minute)},
+			expErr: errors.New("too many incorrect"),
+		},
+		"lockout expired": {
+			code:  totpCode(rfc6238Secret, step, 6),
+			state: &totpUserState{failures: totpMaxFailures, lastFailure: now.Add(-totpLockout)},
+		},
+		"previous step": {
+			code: totpCode(rfc6238Secret, step-1, 6),
+		},
+		"success": {
+			code: totpCode(rfc6238Secret, step, 6),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			state := newTOTPState()
+			if tc.state != nil {
+				state.users[cur.Username] = tc.state
+			}
+			cfg := &security.TOTPConfig{SecretsFile: secretsFile, Skew: 1}
+			if tc.secretsFile != "" {
+				cfg.SecretsFile = tc.secretsFile
+			}
+			req := &AuthTOTPCredentialRequest{
+				code:       tc.code,
+				uid:        uint32(os.Getuid()),
+				gid:        uint32(os.Getgid()),
+				signingKey: agentKey,
+				config:     cfg,
+				state:      state,
+				now:        func() time.Time { return now },
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_TOTP, cred.Token.Flavor, "unexpected flavor")
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, cur.Username+"@", sys.User, "unexpected user")
+			test.AssertTrue(t, sys.SecondFactor, "credential not marked with second factor")
+
+			_, err = req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, errors.New("already been used"), err)
+		})
+	}
+}
+
+func TestAuth_ValidateTOTPToken(t *testing.T) {
+	token := func(flavor Flavor, sys *Sys) *Token {
+		data, err := proto.Marshal(sys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &Token{Flavor: flavor, Data: data}
+	}
+
+	for name, tc := range map[string]struct {
+		token  *Token
+		expErr error
+	}{
+		"sys": {
+			token: token(Flavor_AUTH_SYS, &Sys{User: "jdoe@"}),
+		},
+		"sys claiming second factor": {
+			token:  token(Flavor_AUTH_SYS, &Sys{User: "jdoe@", SecondFactor: true}),
+			expErr: errors.New("AUTH_SYS credential claims a second factor"),
+		},
+		"totp without second factor": {
+			token:  token(Flavor_AUTH_TOTP, &Sys{User: "jdoe@"}),
+			expErr: errors.New("not marked"),
+		},
+		"totp": {
+			token: token(Flavor_AUTH_TOTP, &Sys{User: "jdoe@", SecondFactor: true}),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, ValidateTOTPToken(tc.token))
+		})
+	}
+}
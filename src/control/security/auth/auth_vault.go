@@ -0,0 +1,243 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	vaultRealm = "vault"
+
+	vaultLookupSelfPath  = "/v1/auth/token/lookup-self"
+	maxVaultResponseSize = 1 << 20
+
+	// vaultDefaultPolicy is attached to most Vault tokens and conveys no
+	// useful group membership.
+	vaultDefaultPolicy = "default"
+)
+
+type (
+	// AuthVaultCredentialFactory is a factory interface for AuthVaultCredentialRequests.
+	AuthVaultCredentialFactory struct {
+	}
+
+	// AuthVaultCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_VAULT flavor.
+	AuthVaultCredentialRequest struct {
+		vaultToken  string
+		signingKey  crypto.PrivateKey
+		address     string
+		namespace   string
+		userMetaKey string
+		identityMap security.ExternalIdentityMap
+		caseFold    security.CaseFoldPolicy
+		client      *http.Client
+	}
+
+	// vaultTokenInfo is the subset of a Vault token lookup response used to
+	// determine the identity of the token holder.
+	vaultTokenInfo struct {
+		DisplayName      string            `json:"display_name"`
+		EntityID         string            `json:"entity_id"`
+		Meta             map[string]string `json:"meta"`
+		Policies         []string          `json:"policies"`
+		IdentityPolicies []string          `json:"identity_policies"`
+	}
+)
+
+var (
+	caClientsMutex sync.Mutex
+	caClients      = make(map[string]*http.Client)
+)
+
+// getCAClient returns the shared HTTP client for talking to a service whose
+// certificate is issued by the given CA, so that the CA certificate is only
+// loaded once.
+func getCAClient(caCert string) (*http.Client, error) {
+	if caCert == "" {
+		return http.DefaultClient, nil
+	}
+
+	caClientsMutex.Lock()
+	defer caClientsMutex.Unlock()
+
+	if client, found := caClients[caCert]; found {
+		return client, nil
+	}
+
+	pemData, err := os.ReadFile(caCert)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading CA certificate %q", caCert)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemData) {
+		return nil, errors.Errorf("no certificates found in %q", caCert)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	client := &http.Client{Transport: transport}
+	caClients[caCert] = client
+
+	return client, nil
+}
+
+func (fac *AuthVaultCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthVaultCredentialRequest{}
+
+	if secCfg == nil || secCfg.VaultConfig.Address == "" {
+		return req, drpc.NewFailureWithMessage("agent is not configured for Vault authentication")
+	}
+
+	vaultCfg := &secCfg.VaultConfig
+	client, err := getCAClient(vaultCfg.CACert)
+	if err != nil {
+		return req, err
+	}
+
+	req.vaultToken = strings.TrimSpace(string(reqBody))
+	req.signingKey = key
+	req.address = strings.TrimSuffix(vaultCfg.Address, "/")
+	req.namespace = vaultCfg.Namespace
+	req.userMetaKey = vaultCfg.UserMetadataKey
+	req.identityMap = vaultCfg.IdentityMap
+	req.caseFold = secCfg.PrincipalCaseFold
+	req.client = client
+
+	return req, nil
+}
+
+func GetVaultFlavor() Flavor {
+	return Flavor_AUTH_VAULT
+}
+
+func (fac AuthVaultCredentialFactory) GetAuthFlavor() Flavor {
+	return GetVaultFlavor()
+}
+
+func (req *AuthVaultCredentialRequest) GetAuthFlavor() Flavor {
+	return GetVaultFlavor()
+}
+
+// lookupToken asks Vault to introspect the client's token. The lookup is
+// performed with the client's own token, so the agent needs no Vault
+// privileges of its own, and a token which is invalid, expired or revoked is
+// rejected by Vault.
+func (req *AuthVaultCredentialRequest) lookupToken(ctx context.Context) (*vaultTokenInfo, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, req.address+vaultLookupSelfPath, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Vault token lookup request")
+	}
+	httpReq.Header.Set("X-Vault-Token", req.vaultToken)
+	if req.namespace != "" {
+		httpReq.Header.Set("X-Vault-Namespace", req.namespace)
+	}
+
+	resp, err := req.client.Do(httpReq)
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up Vault token at %q", req.address)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("looking up Vault token at %q: unexpected status code %d", req.address, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxVaultResponseSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading Vault token lookup response")
+	}
+
+	var lookup struct {
+		Data *vaultTokenInfo `json:"data"`
+	}
+	if err := json.Unmarshal(body, &lookup); err != nil {
+		return nil, errors.Wrap(err, "parsing Vault token lookup response")
+	}
+	if lookup.Data == nil {
+		return nil, errors.New("Vault token lookup response contained no data")
+	}
+
+	return lookup.Data, nil
+}
+
+// principal returns the name of the token holder. The configured metadata key
+// takes precedence over the token's display name, which Vault derives from the
+// auth method used to obtain the token (e.g. "ldap-jdoe").
+func (req *AuthVaultCredentialRequest) principal(info *vaultTokenInfo) string {
+	if req.userMetaKey != "" {
+		if name := info.Meta[req.userMetaKey]; name != "" {
+			return name
+		}
+	}
+	return info.DisplayName
+}
+
+// groups returns the token's policies, excluding the default policy.
+func (info *vaultTokenInfo) groups() []string {
+	var groups []string
+	seen := make(map[string]struct{})
+	for _, policy := range append(info.Policies, info.IdentityPolicies...) {
+		if policy == "" || policy == vaultDefaultPolicy {
+			continue
+		}
+		if _, found := seen[policy]; found {
+			continue
+		}
+		seen[policy] = struct{}{}
+		groups = append(groups, policy)
+	}
+	return groups
+}
+
+// GetSignedCredential looks up the Vault token and returns a credential for
+// the identity it belongs to.
+func (req *AuthVaultCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	if req.vaultToken == "" {
+		return nil, errors.New("no Vault token supplied")
+	}
+
+	info, err := req.lookupToken(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid Vault token")
+	}
+
+	sys, err := externalIdentitySys(vaultRealm, req.caseFold, req.identityMap, req.principal(info), info.groups(), info.EntityID)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("Vault entity %q: successfully signed credential for %s", info.EntityID, sys.User)
+	return credential, nil
+}
+
+func (req *AuthVaultCredentialRequest) GetKey() string {
+	return tokenCacheKey(req.GetAuthFlavor(), req.vaultToken)
+}
@@ -0,0 +1,143 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_AuthVaultCredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokens := map[string]*vaultTokenInfo{
+		"s.ldap": {
+			DisplayName:      "ldap-jdoe",
+			EntityID:         "entity-1",
+			Meta:             map[string]string{"username": "jdoe"},
+			Policies:         []string{"default", "hpc"},
+			IdentityPolicies: []string{"hpc", "admins"},
+		},
+		"s.approle": {
+			DisplayName: "approle",
+			EntityID:    "entity-2",
+			Policies:    []string{"default"},
+		},
+		"s.anonymous": {},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != vaultLookupSelfPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("X-Vault-Namespace") != "hpc" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		info, found := tokens[r.Header.Get("X-Vault-Token")]
+		if !found {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{"data": info}); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+
+	for name, tc := range map[string]struct {
+		token       string
+		userMetaKey string
+		identityMap security.ExternalIdentityMap
+		expUser     string
+		expGroup    string
+		expGroups   []string
+		expErr      error
+	}{
+		"no token": {
+			expErr: errors.New("no Vault token"),
+		},
+		"rejected token": {
+			token:  "s.revoked",
+			expErr: errors.New("status code 403"),
+		},
+		"display name": {
+			token:     "s.ldap",
+			expUser:   "ldap-jdoe@vault",
+			expGroups: []string{"hpc@vault", "admins@vault"},
+		},
+		"metadata username": {
+			token:       "s.ldap",
+			userMetaKey: "username",
+			expUser:     "jdoe@vault",
+			expGroups:   []string{"hpc@vault", "admins@vault"},
+		},
+		"missing metadata key falls back to display name": {
+			token:       "s.approle",
+			userMetaKey: "username",
+			expUser:     "approle@vault",
+		},
+		"mapped entity": {
+			token: "s.approle",
+			identityMap: security.ExternalIdentityMap{
+				"entity-2": {User: "svc", Group: "daos"},
+			},
+			expUser:  "svc@",
+			expGroup: "daos@",
+		},
+		"no identity": {
+			token:  "s.anonymous",
+			expErr: errors.New("no vault identity"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthVaultCredentialRequest{
+				vaultToken:  tc.token,
+				signingKey:  agentKey,
+				address:     srv.URL,
+				namespace:   "hpc",
+				userMetaKey: tc.userMetaKey,
+				identityMap: tc.identityMap,
+				client:      srv.Client(),
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_VAULT, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, sys.Group, "unexpected group")
+			test.CmpAny(t, "groups", tc.expGroups, sys.Groups)
+		})
+	}
+}
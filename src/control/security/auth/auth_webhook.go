@@ -0,0 +1,332 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	// DefaultWebhookTimeout is the time allowed for the webhook to respond if
+	// no timeout is configured.
+	DefaultWebhookTimeout = 10 * time.Second
+
+	webhookSignatureHeader = "X-DAOS-Signature"
+	webhookTimestampHeader = "X-DAOS-Timestamp"
+	webhookMinKeyLen       = 32
+	maxWebhookResponseSize = 1 << 20
+)
+
+type (
+	// AuthWebhookCredentialFactory is a factory interface for AuthWebhookCredentialRequests.
+	AuthWebhookCredentialFactory struct {
+	}
+
+	// AuthWebhookCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_WEBHOOK flavor.
+	AuthWebhookCredentialRequest struct {
+		body       []byte
+		uid        uint32
+		gid        uint32
+		pid        int32
+		signingKey crypto.PrivateKey
+		url        string
+		keyFile    string
+		system     string
+		timeout    time.Duration
+		caseFold   security.CaseFoldPolicy
+		client     *http.Client
+	}
+
+	// webhookClient describes the process requesting the credential, as
+	// seen by the agent.
+	webhookClient struct {
+		UID         uint32 `json:"uid"`
+		GID         uint32 `json:"gid"`
+		PID         int32  `json:"pid"`
+		Machinename string `json:"machinename"`
+	}
+
+	// webhookRequest is the payload POSTed to the webhook.
+	webhookRequest struct {
+		Data   []byte        `json:"data"`
+		Client webhookClient `json:"client"`
+		System string        `json:"system"`
+	}
+)
+
+var (
+	webhookClientsMutex sync.Mutex
+	webhookClients      = make(map[security.WebhookConfig]*http.Client)
+)
+
+// getWebhookClient returns the shared HTTP client for the configured webhook,
+// so that the certificates are only loaded once and connections are reused
+// between requests.
+func getWebhookClient(cfg security.WebhookConfig) (*http.Client, error) {
+	webhookClientsMutex.Lock()
+	defer webhookClientsMutex.Unlock()
+
+	if client, found := webhookClients[cfg]; found {
+		return client, nil
+	}
+
+	tlsCfg, err := cfg.TLSConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "loading webhook certificates")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+	client := &http.Client{
+		Transport: transport,
+		// A redirect would send the signed payload somewhere unexpected.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	webhookClients[cfg] = client
+
+	return client, nil
+}
+
+func (fac *AuthWebhookCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthWebhookCredentialRequest{}
+
+	if secCfg == nil || secCfg.WebhookConfig.URL == "" {
+		return req, drpc.NewFailureWithMessage("agent is not configured with an identity webhook")
+	}
+
+	info, err := sessionDomainInfo(log, session)
+	if err != nil {
+		return req, err
+	}
+
+	client, err := getWebhookClient(secCfg.WebhookConfig)
+	if err != nil {
+		return req, err
+	}
+
+	req.body = reqBody
+	req.uid = info.Uid()
+	req.gid = info.Gid()
+	req.pid = info.Pid()
+	req.signingKey = key
+	req.url = secCfg.WebhookConfig.URL
+	req.keyFile = secCfg.WebhookConfig.HMACKeyFile
+	req.system = secCfg.WebhookConfig.SystemName
+	req.timeout = secCfg.WebhookConfig.Timeout
+	req.caseFold = secCfg.PrincipalCaseFold
+	req.client = client
+
+	return req, nil
+}
+
+// ProbeBackend checks that the HMAC key is usable and that the webhook is
+// reachable. The webhook is sent an unsigned HEAD request, so any response
+// short of a server error shows that it is up.
+func (fac *AuthWebhookCredentialFactory) ProbeBackend(ctx context.Context, secCfg *security.CredentialConfig) error {
+	cfg := secCfg.WebhookConfig
+	req := &AuthWebhookCredentialRequest{keyFile: cfg.HMACKeyFile}
+	if _, err := req.hmacKey(); err != nil {
+		return err
+	}
+	client, err := getWebhookClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodHead, cfg.URL, http.NoBody)
+	if err != nil {
+		return errors.Wrap(err, "creating webhook request")
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return errors.Wrapf(err, "identity webhook %q", cfg.URL)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return errors.Errorf("identity webhook %q: unexpected status code %d", cfg.URL, resp.StatusCode)
+	}
+
+	return nil
+}
+
+func GetWebhookFlavor() Flavor {
+	return Flavor_AUTH_WEBHOOK
+}
+
+func (fac AuthWebhookCredentialFactory) GetAuthFlavor() Flavor {
+	return GetWebhookFlavor()
+}
+
+func (req *AuthWebhookCredentialRequest) GetAuthFlavor() Flavor {
+	return GetWebhookFlavor()
+}
+
+// hmacKey reads the key shared with the webhook. Anyone able to read the key
+// can sign requests, so the file must only be readable by the agent. The key
+// is read for each request so that it may be rotated without a restart.
+func (req *AuthWebhookCredentialRequest) hmacKey() ([]byte, error) {
+	fi, err := os.Stat(req.keyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "webhook HMAC key")
+	}
+	if fi.Mode().Perm()&0077 != 0 {
+		return nil, errors.Errorf("webhook HMAC key file %q is accessible by group or others", req.keyFile)
+	}
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok && st.Uid != 0 && int(st.Uid) != os.Geteuid() {
+		return nil, errors.Errorf("webhook HMAC key file %q is not owned by root or the agent user", req.keyFile)
+	}
+
+	key, err := os.ReadFile(req.keyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading webhook HMAC key")
+	}
+	if len(key) < webhookMinKeyLen {
+		return nil, errors.Errorf("webhook HMAC key must be at least %d bytes", webhookMinKeyLen)
+	}
+	return key, nil
+}
+
+// webhookSignature returns the value of the signature header for a payload
+// sent at the given time.
+func webhookSignature(key []byte, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// callWebhook POSTs the signed request to the webhook and returns the identity
+// it responds with.
+func (req *AuthWebhookCredentialRequest) callWebhook(ctx context.Context) (*execIdentity, error) {
+	key, err := req.hmacKey()
+	if err != nil {
+		return nil, err
+	}
+	hostname, err := GetMachineName()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get hostname")
+	}
+
+	payload, err := json.Marshal(&webhookRequest{
+		Data: req.body,
+		Client: webhookClient{
+			UID:         req.uid,
+			GID:         req.gid,
+			PID:         req.pid,
+			Machinename: hostname,
+		},
+		System: req.system,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding webhook request")
+	}
+
+	timeout := req.timeout
+	if timeout == 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating webhook request")
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(webhookTimestampHeader, timestamp)
+	httpReq.Header.Set(webhookSignatureHeader, webhookSignature(key, timestamp, payload))
+
+	resp, err := req.client.Do(httpReq)
+	if err != nil {
+		return nil, errors.Wrapf(err, "identity webhook %q", req.url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("identity webhook %q: unexpected status code %d", req.url, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading webhook response")
+	}
+	id := &execIdentity{}
+	if err := json.Unmarshal(body, id); err != nil {
+		return nil, errors.Wrapf(err, "parsing response of identity webhook %q", req.url)
+	}
+	if id.User == "" {
+		return nil, errors.Errorf("identity webhook %q returned no user", req.url)
+	}
+	return id, nil
+}
+
+// GetSignedCredential forwards the request body to the webhook and returns a
+// credential for the identity it asserts.
+func (req *AuthWebhookCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	id, err := req.callWebhook(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sys, err := assertedIdentitySys(req.caseFold, id.User, id.Group, id.Groups)
+	if err != nil {
+		return nil, errors.Wrapf(err, "identity webhook %q", req.url)
+	}
+	sys.GrantedScopes = id.GrantedScopes
+	sys.Pools = id.Pools
+	if sys.Claims, err = NewClaims(id.Claims); err != nil {
+		return nil, errors.Wrapf(err, "identity webhook %q", req.url)
+	}
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("uid %d: successfully signed credential for %s asserted by identity webhook",
+		req.uid, sys.User)
+	return credential, nil
+}
+
+// GetKey returns a cache key for the request. As the webhook may identify the
+// client by its credentials, the key is bound to the requesting uid and gid.
+func (req *AuthWebhookCredentialRequest) GetKey() string {
+	data := binary.BigEndian.AppendUint32(nil, req.uid)
+	data = binary.BigEndian.AppendUint32(data, req.gid)
+	data = append(data, req.body...)
+	return tokenCacheKey(req.GetAuthFlavor(), string(data))
+}
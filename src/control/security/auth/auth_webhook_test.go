@@ -0,0 +1,290 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+var testWebhookKey = []byte("0123456789abcdef0123456789abcdef")
+
+// startTestWebhook serves the handler over HTTPS, using a certificate issued
+// by the CA and only accepting clients with a certificate issued by the same
+// CA. Requests without a valid signature are rejected.
+func startTestWebhook(t *testing.T, ca *testCA, handler func(*webhookRequest) (int, interface{})) string {
+	t.Helper()
+	dir := t.TempDir()
+	certPath, keyPath := writeTestKeyPair(t, ca, dir, "webhook", &x509.Certificate{
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		expSig := webhookSignature(testWebhookKey, r.Header.Get(webhookTimestampHeader), payload)
+		if r.Method != http.MethodPost || !hmac.Equal([]byte(expSig), []byte(r.Header.Get(webhookSignatureHeader))) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		req := &webhookRequest{}
+		if err := json.Unmarshal(payload, req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		status, body := handler(req)
+		w.WriteHeader(status)
+		if body != nil {
+			json.NewEncoder(w).Encode(body)
+		}
+	}))
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	return srv.URL + "/identity"
+}
+
+func writeTestWebhookKey(t *testing.T, dir string, key []byte, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(dir, "webhook.key")
+	if err := os.WriteFile(path, key, mode); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAuth_AuthWebhookCredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	siteCA := newTestCA(t, "site-ca")
+	otherCA := newTestCA(t, "other-ca")
+
+	jdoe := func(*webhookRequest) (int, interface{}) {
+		return http.StatusOK, &execIdentity{User: "jdoe"}
+	}
+
+	for name, tc := range map[string]struct {
+		clientCA  *testCA
+		hmacKey   []byte
+		keyMode   os.FileMode
+		handler   func(*webhookRequest) (int, interface{})
+		expUser   string
+		expGroup  string
+		expGroups []string
+		expScopes []string
+		expPools  []string
+		expErr    error
+	}{
+		"agent certificate not trusted": {
+			clientCA: otherCA,
+			handler:  jdoe,
+			expErr:   errors.New("identity webhook"),
+		},
+		"key readable by others": {
+			keyMode: 0644,
+			handler: jdoe,
+			expErr:  errors.New("accessible by group or others"),
+		},
+		"key too short": {
+			hmacKey: []byte("secret"),
+			handler: jdoe,
+			expErr:  errors.New("must be at least 32 bytes"),
+		},
+		"wrong key": {
+			hmacKey: []byte("fedcba9876543210fedcba9876543210"),
+			handler: jdoe,
+			expErr:  errors.New("unexpected status code 401"),
+		},
+		"webhook denies": {
+			handler: func(*webhookRequest) (int, interface{}) {
+				return http.StatusForbidden, nil
+			},
+			expErr: errors.New("unexpected status code 403"),
+		},
+		"no user": {
+			handler: func(*webhookRequest) (int, interface{}) {
+				return http.StatusOK, &execIdentity{Group: "users"}
+			},
+			expErr: errors.New("returned no user"),
+		},
+		"identity from request": {
+			handler: func(req *webhookRequest) (int, interface{}) {
+				if req.System != "daos_server" || req.Client.UID != 1000 || req.Client.Machinename == "" {
+					return http.StatusBadRequest, nil
+				}
+				return http.StatusOK, &execIdentity{
+					User:   string(req.Data),
+					Group:  "hpc",
+					Groups: []string{"users"},
+				}
+			},
+			expUser:   "jdoe@",
+			expGroup:  "hpc@",
+			expGroups: []string{"users@"},
+		},
+		"domain principals and grants": {
+			handler: func(*webhookRequest) (int, interface{}) {
+				return http.StatusOK, &execIdentity{
+					User:          "jdoe@EXAMPLE.COM",
+					GrantedScopes: []string{"pool:read"},
+					Pools:         []string{"tank"},
+				}
+			},
+			expUser:   "jdoe@example.com",
+			expScopes: []string{"pool:read"},
+			expPools:  []string{"tank"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			clientCA := tc.clientCA
+			if clientCA == nil {
+				clientCA = siteCA
+			}
+			hmacKey := tc.hmacKey
+			if hmacKey == nil {
+				hmacKey = testWebhookKey
+			}
+			keyMode := tc.keyMode
+			if keyMode == 0 {
+				keyMode = 0600
+			}
+			url := startTestWebhook(t, siteCA, tc.handler)
+
+			dir := t.TempDir()
+			certPath, keyPath := writeTestKeyPair(t, clientCA, dir, "agent", &x509.Certificate{
+				ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			})
+			cfg := security.WebhookConfig{
+				URL:         url,
+				CACert:      writeTestCACert(t, siteCA, dir),
+				Cert:        certPath,
+				Key:         keyPath,
+				HMACKeyFile: writeTestWebhookKey(t, dir, hmacKey, keyMode),
+			}
+			client, err := getWebhookClient(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := &AuthWebhookCredentialRequest{
+				body:       []byte("jdoe"),
+				uid:        1000,
+				gid:        1000,
+				signingKey: agentKey,
+				url:        url,
+				keyFile:    cfg.HMACKeyFile,
+				system:     "daos_server",
+				client:     client,
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_WEBHOOK, cred.Token.Flavor, "unexpected flavor")
+			if err := VerifyToken(&agentKey.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, sys.Group, "unexpected group")
+			test.CmpAny(t, "groups", tc.expGroups, sys.Groups)
+			test.CmpAny(t, "granted scopes", tc.expScopes, sys.GrantedScopes)
+			test.CmpAny(t, "pools", tc.expPools, sys.Pools)
+			if len(tc.expPools) > 0 {
+				test.CmpErr(t, nil, ValidateTokenPool(cred.Token, "", tc.expPools[0]))
+				test.CmpErr(t, errors.New("may not be used with pool"), ValidateTokenPool(cred.Token, "", "home"))
+			}
+		})
+	}
+}
+
+func TestAuth_getWebhookClient(t *testing.T) {
+	_, err := getWebhookClient(security.WebhookConfig{
+		URL:    "https://127.0.0.1:7443/identity",
+		CACert: filepath.Join(t.TempDir(), "missing.crt"),
+		Cert:   "agent.crt",
+		Key:    "agent.key",
+	})
+	test.CmpErr(t, errors.New("loading webhook certificates"), err)
+}
+
+func TestAuth_AuthWebhookCredentialFactory_ProbeBackend(t *testing.T) {
+	siteCA := newTestCA(t, "site-ca")
+	url := startTestWebhook(t, siteCA, func(*webhookRequest) (int, interface{}) {
+		return http.StatusOK, &execIdentity{User: "jdoe"}
+	})
+
+	dir := t.TempDir()
+	certPath, keyPath := writeTestKeyPair(t, siteCA, dir, "agent", &x509.Certificate{
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	cfg := &security.CredentialConfig{
+		WebhookConfig: security.WebhookConfig{
+			URL:         url,
+			CACert:      writeTestCACert(t, siteCA, dir),
+			Cert:        certPath,
+			Key:         keyPath,
+			HMACKeyFile: writeTestWebhookKey(t, dir, testWebhookKey, 0600),
+		},
+	}
+	fac := &AuthWebhookCredentialFactory{}
+
+	// The unsigned probe is rejected, but shows that the webhook is up.
+	if err := fac.ProbeBackend(test.Context(t), cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.WebhookConfig.URL = "https://127.0.0.1:1/identity"
+	test.CmpErr(t, errors.New("identity webhook"), fac.ProbeBackend(test.Context(t), cfg))
+
+	cfg.WebhookConfig.HMACKeyFile = writeTestWebhookKey(t, dir, []byte("secret"), 0600)
+	test.CmpErr(t, errors.New("must be at least 32 bytes"), fac.ProbeBackend(test.Context(t), cfg))
+}
@@ -0,0 +1,184 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	wlmJobClaim   = "job_id"
+	wlmHostsClaim = "hosts"
+)
+
+type (
+	// AuthWLMCredentialFactory is a factory interface for AuthWLMCredentialRequests.
+	AuthWLMCredentialFactory struct {
+	}
+
+	// AuthWLMCredentialRequest defines the request parameters for GetSignedCredential for the AUTH_WLM flavor.
+	AuthWLMCredentialRequest struct {
+		token      string
+		jobID      string
+		uid        uint32
+		signingKey crypto.PrivateKey
+		keyFile    string
+		issuer     string
+		caseFold   security.CaseFoldPolicy
+	}
+)
+
+func (fac *AuthWLMCredentialFactory) Init(log logging.Logger, secCfg *security.CredentialConfig, session *drpc.Session, reqBody []byte, key crypto.PrivateKey) (CredentialRequest, error) {
+	req := &AuthWLMCredentialRequest{}
+
+	if secCfg == nil || secCfg.WLMConfig.PublicKey == "" {
+		return req, drpc.NewFailureWithMessage("agent is not configured for workload manager job tokens")
+	}
+
+	info, err := sessionDomainInfo(log, session)
+	if err != nil {
+		return req, err
+	}
+	pattern, err := secCfg.WLMConfig.JobCgroup()
+	if err != nil {
+		return req, err
+	}
+	// The job is found here rather than when the credential is signed, as it
+	// is part of the cache key.
+	jobID, err := cgroupJobID(info.Pid(), pattern)
+	if err != nil {
+		return req, err
+	}
+	if jobID == "" {
+		return req, drpc.NewFailureWithMessage(fmt.Sprintf("pid %d was not launched by the workload manager", info.Pid()))
+	}
+
+	req.token = strings.TrimSpace(string(reqBody))
+	req.jobID = jobID
+	req.uid = info.Uid()
+	req.signingKey = key
+	req.keyFile = secCfg.WLMConfig.PublicKey
+	req.issuer = secCfg.WLMConfig.Issuer
+	req.caseFold = secCfg.PrincipalCaseFold
+
+	return req, nil
+}
+
+func GetWLMFlavor() Flavor {
+	return Flavor_AUTH_WLM
+}
+
+func (fac AuthWLMCredentialFactory) GetAuthFlavor() Flavor {
+	return GetWLMFlavor()
+}
+
+func (req *AuthWLMCredentialRequest) GetAuthFlavor() Flavor {
+	return GetWLMFlavor()
+}
+
+// publicKey reads the workload manager's PEM-encoded public key.
+func (req *AuthWLMCredentialRequest) publicKey() (fixedJWTKey, error) {
+	data, err := os.ReadFile(req.keyFile)
+	if err != nil {
+		return fixedJWTKey{}, errors.Wrap(err, "reading workload manager public key")
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return fixedJWTKey{}, errors.Errorf("no PEM public key found in %q", req.keyFile)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fixedJWTKey{}, errors.Wrapf(err, "parsing public key in %q", req.keyFile)
+	}
+	return fixedJWTKey{key: key}, nil
+}
+
+// checkWLMHosts checks that the node is one of the hosts allocated to the job,
+// if the token lists them.
+func checkWLMHosts(claims jwtClaims) error {
+	if _, found := claims[wlmHostsClaim]; !found {
+		return nil
+	}
+
+	hostname, err := GetMachineName()
+	if err != nil {
+		return errors.Wrap(err, "failed to get hostname")
+	}
+	short, _, _ := strings.Cut(hostname, ".")
+	hosts := claims.stringsClaim(wlmHostsClaim)
+	if !slices.Contains(hosts, hostname) && !slices.Contains(hosts, short) {
+		return errors.Errorf("job is not allocated host %q", hostname)
+	}
+	return nil
+}
+
+// GetSignedCredential validates the job token and returns a credential for
+// the user it names, restricted to the client's job.
+func (req *AuthWLMCredentialRequest) GetSignedCredential(log logging.Logger, ctx context.Context) (*Credential, error) {
+	if req.token == "" {
+		return nil, errors.New("no job token supplied")
+	}
+
+	key, err := req.publicKey()
+	if err != nil {
+		return nil, err
+	}
+	verifier := &jwtVerifier{keys: key}
+	if req.issuer != "" {
+		verifier.issuers = []string{req.issuer}
+	}
+	claims, err := verifier.Verify(ctx, req.token)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid job token")
+	}
+
+	job, found := claims[wlmJobClaim]
+	if !found {
+		return nil, errors.Errorf("job token has no %s claim", wlmJobClaim)
+	}
+	if fmt.Sprint(job) != req.jobID {
+		return nil, errors.Errorf("job token is for job %v, not job %s", job, req.jobID)
+	}
+	if err := checkWLMHosts(claims); err != nil {
+		return nil, err
+	}
+
+	sys, err := assertedIdentitySys(req.caseFold, claims.stringClaim("sub"), "", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "job token")
+	}
+	sys.JobId = req.jobID
+
+	credential, err := newSignedCredential(req.GetAuthFlavor(), req.signingKey, sys)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Tracef("uid %d: successfully signed credential for %s in job %s",
+		req.uid, sys.User, sys.JobId)
+	return credential, nil
+}
+
+// GetKey returns a cache key for the request, which is bound to the job as
+// well as the token. Job IDs are taken from cgroup paths, so cannot contain a
+// NUL.
+func (req *AuthWLMCredentialRequest) GetKey() string {
+	return tokenCacheKey(req.GetAuthFlavor(), req.jobID+"\x00"+req.token)
+}
@@ -0,0 +1,160 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestAuth_cgroupJobID(t *testing.T) {
+	fluxShell := regexp.MustCompile(`/shell-(f[0-9A-Za-z]+)\.service(?:/|$)`)
+
+	for name, tc := range map[string]struct {
+		cgroup string
+		expJob string
+	}{
+		"in job": {
+			cgroup: "0::/system.slice/flux.service/shell-f2Ww8bG5Xq.service\n",
+			expJob: "f2Ww8bG5Xq",
+		},
+		"not in job": {
+			cgroup: "0::/user.slice/user-1000.slice/session-3.scope\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setTestProcCgroup(t, 42, tc.cgroup)
+
+			job, err := cgroupJobID(42, fluxShell)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expJob, job, "unexpected job")
+		})
+	}
+}
+
+func TestAuth_AuthWLMCredentialRequest_GetSignedCredential(t *testing.T) {
+	agentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wlmKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(wlmKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "job-token.pub")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	hostname, err := GetMachineName()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	goodClaims := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss":    "flux",
+			"sub":    "jdoe",
+			"job_id": "f2Ww8bG5Xq",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+		}
+	}
+	withClaim := func(name string, value interface{}) map[string]interface{} {
+		c := goodClaims()
+		if value == nil {
+			delete(c, name)
+		} else {
+			c[name] = value
+		}
+		return c
+	}
+
+	for name, tc := range map[string]struct {
+		token   string
+		expUser string
+		expErr  error
+	}{
+		"no token": {
+			expErr: errors.New("no job token"),
+		},
+		"wrong signer": {
+			token:  testSignJWT(t, "ES256", "", otherKey, goodClaims()),
+			expErr: errors.New("signature verification failed"),
+		},
+		"wrong issuer": {
+			token:  testSignJWT(t, "ES256", "", wlmKey, withClaim("iss", "slurm")),
+			expErr: errors.New("not trusted"),
+		},
+		"no job": {
+			token:  testSignJWT(t, "ES256", "", wlmKey, withClaim("job_id", nil)),
+			expErr: errors.New("no job_id claim"),
+		},
+		"other job": {
+			token:  testSignJWT(t, "ES256", "", wlmKey, withClaim("job_id", "f3abc")),
+			expErr: errors.New("not job f2Ww8bG5Xq"),
+		},
+		"host not allocated": {
+			token:  testSignJWT(t, "ES256", "", wlmKey, withClaim("hosts", []string{"elsewhere"})),
+			expErr: errors.New("not allocated host"),
+		},
+		"success": {
+			token:   testSignJWT(t, "ES256", "", wlmKey, withClaim("hosts", []string{"elsewhere", hostname})),
+			expUser: "jdoe@",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &AuthWLMCredentialRequest{
+				token:      tc.token,
+				jobID:      "f2Ww8bG5Xq",
+				uid:        1000,
+				signingKey: agentKey,
+				keyFile:    keyFile,
+				issuer:     "flux",
+			}
+
+			cred, err := req.GetSignedCredential(log, test.Context(t))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, Flavor_AUTH_WLM, cred.Token.Flavor, "unexpected flavor")
+			sys, err := sysFromToken(cred.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.User, "unexpected user")
+			test.AssertEqual(t, "f2Ww8bG5Xq", sys.JobId, "unexpected job")
+		})
+	}
+}
@@ -0,0 +1,128 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package biscuit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Authorizer evaluates the facts, rules and checks of a token together with
+// facts supplied by the party verifying it, such as the current time or the
+// resource being accessed.
+type Authorizer struct {
+	token *Token
+	facts []predicate
+	world *world
+	err   error
+}
+
+// Authorizer returns a new authorizer for the token.
+func (t *Token) Authorizer() *Authorizer {
+	return &Authorizer{token: t}
+}
+
+// AddFact adds a fact to the authorizer. Facts may not be added once the
+// authorizer has been evaluated.
+func (a *Authorizer) AddFact(name string, terms ...Term) {
+	a.facts = append(a.facts, predicate{name: name, terms: terms})
+}
+
+// evaluate generates all of the facts derivable from the token and the
+// authorizer.
+func (a *Authorizer) evaluate() error {
+	if a.world != nil || a.err != nil {
+		return a.err
+	}
+
+	w := newWorld()
+	for _, f := range a.facts {
+		w.add(fact{pred: f, origin: authorizerOrigin})
+	}
+	var rules []*rule
+	for _, b := range a.token.blocks {
+		for _, f := range b.facts {
+			w.add(fact{pred: f, origin: blockOrigin(b.index)})
+		}
+		rules = append(rules, b.rules...)
+	}
+
+	if a.err = w.run(rules); a.err == nil {
+		a.world = w
+	}
+	return a.err
+}
+
+// Authorize evaluates the token and returns an error describing the checks
+// which failed, if any.
+func (a *Authorizer) Authorize() error {
+	if err := a.evaluate(); err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, b := range a.token.blocks {
+		for i, c := range b.checks {
+			ok, err := c.passes(a.world.facts)
+			if err != nil {
+				return errors.Wrapf(err, "biscuit block %d check %d", b.index, i)
+			}
+			if !ok {
+				failed = append(failed, c.String())
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("biscuit checks failed: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// Query returns the terms of each fact with the given name that was stated
+// or derived by the authority block or the authorizer. Facts added by
+// attenuation blocks are not returned, as anyone holding the token may add
+// them.
+func (a *Authorizer) Query(name string) ([][]Term, error) {
+	if err := a.evaluate(); err != nil {
+		return nil, err
+	}
+
+	var results [][]Term
+	for _, f := range a.world.facts {
+		if f.pred.name == name && f.origin&^(blockOrigin(0)|authorizerOrigin) == 0 {
+			results = append(results, f.pred.terms)
+		}
+	}
+	return results, nil
+}
+
+func (c *check) String() string {
+	var kind string
+	switch c.kind {
+	case CheckV2_All:
+		kind = "check all"
+	case CheckV2_Reject:
+		kind = "reject if"
+	default:
+		kind = "check if"
+	}
+
+	queries := make([]string, 0, len(c.queries))
+	for _, q := range c.queries {
+		body := make([]string, 0, len(q.body))
+		for _, p := range q.body {
+			body = append(body, p.String())
+		}
+		if len(q.exprs) > 0 {
+			body = append(body, "<expressions>")
+		}
+		queries = append(queries, strings.Join(body, ", "))
+	}
+	return fmt.Sprintf("block %d: %s %s", c.block, kind, strings.Join(queries, " or "))
+}
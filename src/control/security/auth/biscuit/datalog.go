@@ -0,0 +1,591 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package biscuit
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxFacts is the largest number of facts a token and its authorizer may
+	// generate before evaluation is abandoned.
+	maxFacts = 1000
+	// maxIterations is the largest number of rounds of rule application
+	// before evaluation is abandoned.
+	maxIterations = 100
+
+	// authorizerOrigin identifies facts supplied by the authorizer.
+	authorizerOrigin origin = 1 << 63
+)
+
+type (
+	termKind int
+
+	// Term is a Datalog value appearing in facts, rules and expressions.
+	Term struct {
+		kind  termKind
+		str   string // string value, or variable name
+		num   int64  // integer value, or date in seconds since the Unix epoch
+		bytes []byte
+		b     bool
+		set   []Term
+	}
+
+	predicate struct {
+		name  string
+		terms []Term
+	}
+
+	// origin is the set of blocks a fact was derived from, one bit per block.
+	origin uint64
+
+	fact struct {
+		pred   predicate
+		origin origin
+	}
+
+	opKind int
+
+	op struct {
+		kind   opKind
+		value  Term
+		unary  OpUnary_Kind
+		binary OpBinary_Kind
+	}
+
+	// expression is a sequence of operations on a stack of terms.
+	expression []op
+
+	rule struct {
+		head   predicate
+		body   []predicate
+		exprs  []expression
+		trust  origin // origins of the facts the rule may match
+		origin origin // origin of the block defining the rule
+	}
+
+	check struct {
+		kind    CheckV2_Kind
+		queries []*rule
+		block   int
+	}
+
+	// world is the set of facts known during evaluation.
+	world struct {
+		facts []fact
+		seen  map[string]struct{}
+	}
+
+	bindings map[string]Term
+)
+
+const (
+	termVariable termKind = iota
+	termInteger
+	termString
+	termDate
+	termBytes
+	termBool
+	termSet
+)
+
+const (
+	opValue opKind = iota
+	opUnary
+	opBinary
+)
+
+func blockOrigin(index int) origin {
+	return 1 << index
+}
+
+// StringTerm returns a string term.
+func StringTerm(s string) Term {
+	return Term{kind: termString, str: s}
+}
+
+// IntegerTerm returns an integer term.
+func IntegerTerm(i int64) Term {
+	return Term{kind: termInteger, num: i}
+}
+
+// DateTerm returns a date term, with a resolution of one second.
+func DateTerm(t time.Time) Term {
+	return Term{kind: termDate, num: t.Unix()}
+}
+
+// AsString returns the value of a string term.
+func (t Term) AsString() (string, bool) {
+	return t.str, t.kind == termString
+}
+
+func (t Term) String() string {
+	switch t.kind {
+	case termVariable:
+		return "$" + t.str
+	case termInteger:
+		return strconv.FormatInt(t.num, 10)
+	case termString:
+		return strconv.Quote(t.str)
+	case termDate:
+		return time.Unix(t.num, 0).UTC().Format(time.RFC3339)
+	case termBytes:
+		return "hex:" + hex.EncodeToString(t.bytes)
+	case termBool:
+		return strconv.FormatBool(t.b)
+	case termSet:
+		elems := make([]string, 0, len(t.set))
+		for _, e := range t.set {
+			elems = append(elems, e.String())
+		}
+		slices.Sort(elems)
+		elems = slices.Compact(elems)
+		return "[" + strings.Join(elems, ", ") + "]"
+	default:
+		return "<invalid>"
+	}
+}
+
+// equal compares terms of the same type. Sets are compared without regard to
+// order or repeated elements.
+func (t Term) equal(o Term) bool {
+	return t.kind == o.kind && t.String() == o.String()
+}
+
+func (t Term) setContains(e Term) bool {
+	return slices.ContainsFunc(t.set, e.equal)
+}
+
+func (p predicate) String() string {
+	terms := make([]string, 0, len(p.terms))
+	for _, t := range p.terms {
+		terms = append(terms, t.String())
+	}
+	return p.name + "(" + strings.Join(terms, ", ") + ")"
+}
+
+func (p predicate) hasVariables() bool {
+	return slices.ContainsFunc(p.terms, func(t Term) bool { return t.kind == termVariable })
+}
+
+func (p predicate) variables() []string {
+	var vars []string
+	for _, t := range p.terms {
+		if t.kind == termVariable {
+			vars = append(vars, t.str)
+		}
+	}
+	return vars
+}
+
+// bind substitutes the bound value of each variable in the predicate.
+func (p predicate) bind(b bindings) predicate {
+	bound := predicate{name: p.name, terms: make([]Term, len(p.terms))}
+	for i, t := range p.terms {
+		if t.kind == termVariable {
+			t = b[t.str]
+		}
+		bound.terms[i] = t
+	}
+	return bound
+}
+
+// unify extends the bindings so that the predicate matches the fact, returning
+// false if it cannot.
+func (p predicate) unify(f predicate, b bindings) (bindings, bool) {
+	if p.name != f.name || len(p.terms) != len(f.terms) {
+		return nil, false
+	}
+	out := make(bindings, len(b)+len(p.terms))
+	for k, v := range b {
+		out[k] = v
+	}
+	for i, t := range p.terms {
+		if t.kind != termVariable {
+			if !t.equal(f.terms[i]) {
+				return nil, false
+			}
+			continue
+		}
+		if v, found := out[t.str]; found {
+			if !v.equal(f.terms[i]) {
+				return nil, false
+			}
+			continue
+		}
+		out[t.str] = f.terms[i]
+	}
+	return out, true
+}
+
+func newWorld() *world {
+	return &world{seen: make(map[string]struct{})}
+}
+
+// add adds a fact if it is not already known, returning true if it was added.
+func (w *world) add(f fact) bool {
+	key := fmt.Sprintf("%x/%s", uint64(f.origin), f.pred)
+	if _, found := w.seen[key]; found {
+		return false
+	}
+	w.seen[key] = struct{}{}
+	w.facts = append(w.facts, f)
+	return true
+}
+
+// validate checks that every variable in the head and expressions of a rule
+// is bound by its body.
+func (r *rule) validate() error {
+	bound := make(map[string]bool)
+	for _, p := range r.body {
+		for _, v := range p.variables() {
+			bound[v] = true
+		}
+	}
+	for _, v := range r.head.variables() {
+		if !bound[v] {
+			return errors.Errorf("rule head variable $%s is not bound by its body", v)
+		}
+	}
+	for _, e := range r.exprs {
+		for _, o := range e {
+			if o.kind == opValue && o.value.kind == termVariable && !bound[o.value.str] {
+				return errors.Errorf("rule expression variable $%s is not bound by its body", o.value.str)
+			}
+		}
+	}
+	return nil
+}
+
+// eachMatch calls fn with the bindings and origin of each combination of
+// trusted facts matching the body of the rule, until fn returns false.
+func (r *rule) eachMatch(facts []fact, fn func(bindings, origin) (bool, error)) error {
+	var match func(i int, b bindings, o origin) (bool, error)
+	match = func(i int, b bindings, o origin) (bool, error) {
+		if i == len(r.body) {
+			return fn(b, o)
+		}
+		for _, f := range facts {
+			if f.origin&^r.trust != 0 {
+				continue
+			}
+			nb, ok := r.body[i].unify(f.pred, b)
+			if !ok {
+				continue
+			}
+			more, err := match(i+1, nb, o|f.origin)
+			if err != nil || !more {
+				return more, err
+			}
+		}
+		return true, nil
+	}
+
+	_, err := match(0, bindings{}, 0)
+	return err
+}
+
+// exprsHold returns true if all of the rule's expressions evaluate to true.
+func (r *rule) exprsHold(b bindings) (bool, error) {
+	for _, e := range r.exprs {
+		res, err := e.evaluate(b)
+		if err != nil {
+			return false, err
+		}
+		if res.kind != termBool {
+			return false, errors.Errorf("expression evaluated to %s, not a boolean", res)
+		}
+		if !res.b {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matches returns true if any combination of facts satisfies the rule.
+func (r *rule) matches(facts []fact) (bool, error) {
+	found := false
+	err := r.eachMatch(facts, func(b bindings, _ origin) (bool, error) {
+		ok, err := r.exprsHold(b)
+		if ok {
+			found = true
+		}
+		return !found, err
+	})
+	return found, err
+}
+
+// allMatch returns true if the body of the rule matches at least one
+// combination of facts, and every combination satisfies its expressions.
+func (r *rule) allMatch(facts []fact) (bool, error) {
+	found, all := false, true
+	err := r.eachMatch(facts, func(b bindings, _ origin) (bool, error) {
+		found = true
+		ok, err := r.exprsHold(b)
+		if !ok {
+			all = false
+		}
+		return all, err
+	})
+	return found && all, err
+}
+
+// passes evaluates a check against the known facts.
+func (c *check) passes(facts []fact) (bool, error) {
+	for _, q := range c.queries {
+		var ok bool
+		var err error
+
+		switch c.kind {
+		case CheckV2_One:
+			ok, err = q.matches(facts)
+		case CheckV2_All:
+			ok, err = q.allMatch(facts)
+		case CheckV2_Reject:
+			var rejected bool
+			rejected, err = q.matches(facts)
+			if rejected {
+				return false, err
+			}
+		default:
+			return false, errors.Errorf("unknown check kind %d", c.kind)
+		}
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return c.kind == CheckV2_Reject, nil
+}
+
+// run applies the rules to the facts until no new facts are generated.
+func (w *world) run(rules []*rule) error {
+	for iter := 0; ; iter++ {
+		if iter >= maxIterations {
+			return errors.Errorf("biscuit evaluation did not complete in %d iterations", maxIterations)
+		}
+
+		var derived []fact
+		for _, r := range rules {
+			err := r.eachMatch(w.facts, func(b bindings, o origin) (bool, error) {
+				ok, err := r.exprsHold(b)
+				if ok {
+					derived = append(derived, fact{pred: r.head.bind(b), origin: o | r.origin})
+				}
+				return len(derived) <= maxFacts, err
+			})
+			if err != nil {
+				return errors.Wrapf(err, "evaluating rule for %s", r.head)
+			}
+		}
+
+		added := 0
+		for _, f := range derived {
+			if w.add(f) {
+				added++
+			}
+		}
+		if len(w.facts) > maxFacts || len(derived) > maxFacts {
+			return errors.Errorf("biscuit evaluation generated more than %d facts", maxFacts)
+		}
+		if added == 0 {
+			return nil
+		}
+	}
+}
+
+// evaluate runs the expression with the given variable bindings, returning
+// the single term left on the stack.
+func (e expression) evaluate(b bindings) (Term, error) {
+	var stack []Term
+	pop := func() Term {
+		t := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		return t
+	}
+
+	for _, o := range e {
+		switch o.kind {
+		case opValue:
+			t := o.value
+			if t.kind == termVariable {
+				v, found := b[t.str]
+				if !found {
+					return Term{}, errors.Errorf("unbound variable $%s", t.str)
+				}
+				t = v
+			}
+			stack = append(stack, t)
+		case opUnary:
+			if len(stack) < 1 {
+				return Term{}, errors.New("expression stack underflow")
+			}
+			t, err := evalUnary(o.unary, pop())
+			if err != nil {
+				return Term{}, err
+			}
+			stack = append(stack, t)
+		case opBinary:
+			if len(stack) < 2 {
+				return Term{}, errors.New("expression stack underflow")
+			}
+			right := pop()
+			t, err := evalBinary(o.binary, pop(), right)
+			if err != nil {
+				return Term{}, err
+			}
+			stack = append(stack, t)
+		}
+	}
+
+	if len(stack) != 1 {
+		return Term{}, errors.New("invalid expression")
+	}
+	return stack[0], nil
+}
+
+func boolTerm(b bool) Term {
+	return Term{kind: termBool, b: b}
+}
+
+func evalUnary(kind OpUnary_Kind, t Term) (Term, error) {
+	switch {
+	case kind == OpUnary_Parens:
+		return t, nil
+	case kind == OpUnary_Negate && t.kind == termBool:
+		return boolTerm(!t.b), nil
+	case kind == OpUnary_Length && t.kind == termString:
+		return IntegerTerm(int64(len(t.str))), nil
+	case kind == OpUnary_Length && t.kind == termBytes:
+		return IntegerTerm(int64(len(t.bytes))), nil
+	case kind == OpUnary_Length && t.kind == termSet:
+		return IntegerTerm(int64(len(t.set))), nil
+	}
+	return Term{}, errors.Errorf("unsupported operation %s on %s", kind, t)
+}
+
+// checkedArith performs integer arithmetic, failing on overflow.
+func checkedArith(kind OpBinary_Kind, a, b int64) (int64, error) {
+	overflow := false
+	switch kind {
+	case OpBinary_Add:
+		overflow = (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b)
+		if !overflow {
+			return a + b, nil
+		}
+	case OpBinary_Sub:
+		overflow = (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b)
+		if !overflow {
+			return a - b, nil
+		}
+	case OpBinary_Mul:
+		if a == 0 || b == 0 {
+			return 0, nil
+		}
+		r := a * b
+		overflow = r/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64)
+		if !overflow {
+			return r, nil
+		}
+	case OpBinary_Div:
+		if b == 0 {
+			return 0, errors.New("division by zero")
+		}
+		overflow = a == math.MinInt64 && b == -1
+		if !overflow {
+			return a / b, nil
+		}
+	}
+	return 0, errors.Errorf("integer overflow in %s", kind)
+}
+
+func evalBinary(kind OpBinary_Kind, l, r Term) (Term, error) {
+	ordered := l.kind == r.kind && (l.kind == termInteger || l.kind == termDate)
+
+	switch {
+	case kind == OpBinary_LessThan && ordered:
+		return boolTerm(l.num < r.num), nil
+	case kind == OpBinary_GreaterThan && ordered:
+		return boolTerm(l.num > r.num), nil
+	case kind == OpBinary_LessOrEqual && ordered:
+		return boolTerm(l.num <= r.num), nil
+	case kind == OpBinary_GreaterOrEqual && ordered:
+		return boolTerm(l.num >= r.num), nil
+	case kind == OpBinary_Equal && l.kind == r.kind:
+		return boolTerm(l.equal(r)), nil
+	case kind == OpBinary_NotEqual && l.kind == r.kind:
+		return boolTerm(!l.equal(r)), nil
+	case kind == OpBinary_Contains && l.kind == termSet && r.kind == termSet:
+		for _, e := range r.set {
+			if !l.setContains(e) {
+				return boolTerm(false), nil
+			}
+		}
+		return boolTerm(true), nil
+	case kind == OpBinary_Contains && l.kind == termSet:
+		return boolTerm(l.setContains(r)), nil
+	case kind == OpBinary_Contains && l.kind == termString && r.kind == termString:
+		return boolTerm(strings.Contains(l.str, r.str)), nil
+	case kind == OpBinary_Prefix && l.kind == termString && r.kind == termString:
+		return boolTerm(strings.HasPrefix(l.str, r.str)), nil
+	case kind == OpBinary_Suffix && l.kind == termString && r.kind == termString:
+		return boolTerm(strings.HasSuffix(l.str, r.str)), nil
+	case kind == OpBinary_Regex && l.kind == termString && r.kind == termString:
+		re, err := regexp.Compile(r.str)
+		if err != nil {
+			return Term{}, errors.Wrap(err, "invalid regular expression")
+		}
+		return boolTerm(re.MatchString(l.str)), nil
+	case kind == OpBinary_Add && l.kind == termString && r.kind == termString:
+		return StringTerm(l.str + r.str), nil
+	case (kind == OpBinary_Add || kind == OpBinary_Sub || kind == OpBinary_Mul || kind == OpBinary_Div) &&
+		l.kind == termInteger && r.kind == termInteger:
+		n, err := checkedArith(kind, l.num, r.num)
+		if err != nil {
+			return Term{}, err
+		}
+		return IntegerTerm(n), nil
+	case kind == OpBinary_And && l.kind == termBool && r.kind == termBool:
+		return boolTerm(l.b && r.b), nil
+	case kind == OpBinary_Or && l.kind == termBool && r.kind == termBool:
+		return boolTerm(l.b || r.b), nil
+	case kind == OpBinary_Intersection && l.kind == termSet && r.kind == termSet:
+		set := Term{kind: termSet}
+		for _, e := range l.set {
+			if r.setContains(e) {
+				set.set = append(set.set, e)
+			}
+		}
+		return set, nil
+	case kind == OpBinary_Union && l.kind == termSet && r.kind == termSet:
+		set := Term{kind: termSet, set: slices.Clone(l.set)}
+		for _, e := range r.set {
+			if !l.setContains(e) {
+				set.set = append(set.set, e)
+			}
+		}
+		return set, nil
+	case kind == OpBinary_BitwiseAnd && l.kind == termInteger && r.kind == termInteger:
+		return IntegerTerm(l.num & r.num), nil
+	case kind == OpBinary_BitwiseOr && l.kind == termInteger && r.kind == termInteger:
+		return IntegerTerm(l.num | r.num), nil
+	case kind == OpBinary_BitwiseXor && l.kind == termInteger && r.kind == termInteger:
+		return IntegerTerm(l.num ^ r.num), nil
+	}
+	return Term{}, errors.Errorf("unsupported operation %s on %s and %s", kind, l, r)
+}
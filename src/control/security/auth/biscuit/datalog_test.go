@@ -0,0 +1,250 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package biscuit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestBiscuit_Authorizer(t *testing.T) {
+	rootPub, rootPriv := testGenerateKey(t)
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	authority := func(tb *testBuilder) {
+		tb.fact("user", StringTerm("jdoe"))
+		tb.fact("role", StringTerm("jdoe"), StringTerm("admin"))
+		tb.fact("role", StringTerm("jdoe"), StringTerm("ops"))
+		// right($r) <- user($u), role($u, $r)
+		tb.block.RulesV2 = append(tb.block.RulesV2, tb.rule(
+			tb.pred("right", testVar("r")),
+			[]*PredicateV2{tb.pred("user", testVar("u")), tb.pred("role", testVar("u"), testVar("r"))}))
+	}
+	// check if time($t), $t < expiry
+	expiring := func(expiry time.Time) func(*testBuilder) {
+		return func(tb *testBuilder) {
+			tb.checkIf([]*PredicateV2{tb.pred("time", testVar("t"))},
+				tb.expr(testVar("t"), DateTerm(expiry), OpBinary_LessThan))
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		blocks    []func(*testBuilder)
+		facts     map[string]Term
+		query     string
+		expResult []string
+		expErr    error
+	}{
+		"derived facts": {
+			blocks:    []func(*testBuilder){authority},
+			query:     "right",
+			expResult: []string{`"admin"`, `"ops"`},
+		},
+		"unexpired": {
+			blocks: []func(*testBuilder){authority, expiring(now.Add(time.Hour))},
+			facts:  map[string]Term{"time": DateTerm(now)},
+		},
+		"expired": {
+			blocks: []func(*testBuilder){authority, expiring(now.Add(-time.Hour))},
+			facts:  map[string]Term{"time": DateTerm(now)},
+			expErr: errors.New("block 1: check if time($t)"),
+		},
+		"no time supplied": {
+			blocks: []func(*testBuilder){authority, expiring(now.Add(time.Hour))},
+			expErr: errors.New("checks failed"),
+		},
+		"resource in set": {
+			blocks: []func(*testBuilder){authority, func(tb *testBuilder) {
+				tb.checkIf([]*PredicateV2{tb.pred("resource", testVar("r"))},
+					tb.expr(Term{kind: termSet, set: []Term{StringTerm("tank"), StringTerm("home")}},
+						testVar("r"), OpBinary_Contains))
+			}},
+			facts: map[string]Term{"resource": StringTerm("tank")},
+		},
+		"resource not in set": {
+			blocks: []func(*testBuilder){authority, func(tb *testBuilder) {
+				tb.checkIf([]*PredicateV2{tb.pred("resource", testVar("r"))},
+					tb.expr(Term{kind: termSet, set: []Term{StringTerm("home")}},
+						testVar("r"), OpBinary_Contains))
+			}},
+			facts:  map[string]Term{"resource": StringTerm("tank")},
+			expErr: errors.New("checks failed"),
+		},
+		"reject if": {
+			blocks: []func(*testBuilder){authority, func(tb *testBuilder) {
+				tb.check(CheckV2_Reject, tb.rule(tb.pred("query"),
+					[]*PredicateV2{tb.pred("right", StringTerm("admin"))}))
+			}},
+			expErr: errors.New("reject if right(\"admin\")"),
+		},
+		"check all": {
+			blocks: []func(*testBuilder){authority, func(tb *testBuilder) {
+				// check all role($u, $r), $r.starts_with("o")
+				tb.check(CheckV2_All, tb.rule(tb.pred("query"),
+					[]*PredicateV2{tb.pred("role", testVar("u"), testVar("r"))},
+					tb.expr(testVar("r"), StringTerm("o"), OpBinary_Prefix)))
+			}},
+			expErr: errors.New("check all"),
+		},
+		"attenuation facts not trusted by authority": {
+			blocks: []func(*testBuilder){
+				func(tb *testBuilder) {
+					tb.checkIf([]*PredicateV2{tb.pred("right", StringTerm("write"))})
+				},
+				func(tb *testBuilder) {
+					tb.fact("right", StringTerm("write"))
+				},
+			},
+			expErr: errors.New("block 0: check if right(\"write\")"),
+		},
+		"attenuation facts not returned by query": {
+			blocks: []func(*testBuilder){authority, func(tb *testBuilder) {
+				tb.fact("right", StringTerm("root"))
+			}},
+			query:     "right",
+			expResult: []string{`"admin"`, `"ops"`},
+		},
+		"previous scope": {
+			blocks: []func(*testBuilder){
+				authority,
+				func(tb *testBuilder) {
+					tb.fact("resource", StringTerm("tank"))
+				},
+				func(tb *testBuilder) {
+					tb.block.Scope = []*Scope{{Content: &Scope_ScopeType_{ScopeType: Scope_Previous}}}
+					tb.checkIf([]*PredicateV2{tb.pred("resource", StringTerm("tank"))})
+				},
+			},
+		},
+		"too many facts": {
+			blocks: []func(*testBuilder){func(tb *testBuilder) {
+				for n := int64(0); n < 40; n++ {
+					tb.fact("n", IntegerTerm(n))
+				}
+				// pair($x, $y) <- n($x), n($y)
+				tb.block.RulesV2 = append(tb.block.RulesV2, tb.rule(
+					tb.pred("pair", testVar("x"), testVar("y")),
+					[]*PredicateV2{tb.pred("n", testVar("x")), tb.pred("n", testVar("y"))}))
+			}},
+			expErr: errors.New("more than 1000 facts"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tok, err := Parse(testMint(t, rootPriv, tc.blocks...), rootPub)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			a := tok.Authorizer()
+			for name, term := range tc.facts {
+				a.AddFact(name, term)
+			}
+
+			err = a.Authorize()
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil || tc.query == "" {
+				return
+			}
+
+			results, err := a.Query(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, r := range results {
+				got = append(got, r[0].String())
+			}
+			test.CmpAny(t, "query results", tc.expResult, got)
+		})
+	}
+}
+
+func TestBiscuit_expression_evaluate(t *testing.T) {
+	i := IntegerTerm
+	s := StringTerm
+	set := func(terms ...Term) Term { return Term{kind: termSet, set: terms} }
+
+	for name, tc := range map[string]struct {
+		expr   []any
+		expRes Term
+		expErr error
+	}{
+		"arithmetic": {
+			expr:   []any{i(2), i(3), OpBinary_Add, i(4), OpBinary_Mul},
+			expRes: i(20),
+		},
+		"overflow": {
+			expr:   []any{i(1 << 62), i(2), OpBinary_Mul},
+			expErr: errors.New("integer overflow"),
+		},
+		"division by zero": {
+			expr:   []any{i(1), i(0), OpBinary_Div},
+			expErr: errors.New("division by zero"),
+		},
+		"string concatenation": {
+			expr:   []any{s("ta"), s("nk"), OpBinary_Add, s("tank"), OpBinary_Equal},
+			expRes: boolTerm(true),
+		},
+		"regex": {
+			expr:   []any{s("pool-42"), s(`^pool-\d+$`), OpBinary_Regex},
+			expRes: boolTerm(true),
+		},
+		"negate": {
+			expr:   []any{s("tank"), s("home"), OpBinary_Equal, OpUnary_Negate},
+			expRes: boolTerm(true),
+		},
+		"length": {
+			expr:   []any{set(s("a"), s("b")), OpUnary_Length},
+			expRes: i(2),
+		},
+		"set equality ignores order": {
+			expr:   []any{set(s("a"), s("b")), set(s("b"), s("a")), OpBinary_Equal},
+			expRes: boolTerm(true),
+		},
+		"intersection": {
+			expr:   []any{set(s("a"), s("b")), set(s("b"), s("c")), OpBinary_Intersection},
+			expRes: set(s("b")),
+		},
+		"type mismatch": {
+			expr:   []any{s("1"), i(1), OpBinary_Equal},
+			expErr: errors.New("unsupported operation"),
+		},
+		"stack underflow": {
+			expr:   []any{i(1), OpBinary_Add},
+			expErr: errors.New("underflow"),
+		},
+		"unbound variable": {
+			expr:   []any{testVar("x")},
+			expErr: errors.New("unbound variable"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var expr expression
+			for _, item := range tc.expr {
+				switch v := item.(type) {
+				case Term:
+					expr = append(expr, op{kind: opValue, value: v})
+				case OpUnary_Kind:
+					expr = append(expr, op{kind: opUnary, unary: v})
+				case OpBinary_Kind:
+					expr = append(expr, op{kind: opBinary, binary: v})
+				}
+			}
+
+			res, err := expr.evaluate(bindings{})
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertTrue(t, tc.expRes.equal(res), "unexpected result "+res.String())
+		})
+	}
+}
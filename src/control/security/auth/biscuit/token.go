@@ -0,0 +1,403 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Package biscuit verifies Biscuit authorization tokens and evaluates the
+// Datalog facts, rules and checks they carry. Only first-party blocks signed
+// with Ed25519 keys are supported.
+package biscuit
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// MaxTokenSize is the largest serialized token accepted.
+	MaxTokenSize = 64 << 10
+
+	// maxBlocks is the largest number of blocks, including the authority
+	// block, accepted in a token. Each block is identified by a bit in a
+	// fact's origin.
+	maxBlocks = 32
+
+	// Range of block format versions understood.
+	minBlockVersion = 3
+	maxBlockVersion = 6
+
+	// symbolOffset is the index of the first symbol defined by a token.
+	symbolOffset = 1024
+)
+
+// defaultSymbols are the symbols predefined by the Biscuit specification,
+// which are not serialized in tokens.
+var defaultSymbols = []string{
+	"read", "write", "resource", "operation", "right", "time", "role",
+	"owner", "tenant", "namespace", "user", "team", "service", "admin",
+	"email", "group", "member", "ip_address", "client", "client_ip",
+	"domain", "path", "version", "cluster", "node", "hostname", "nonce",
+	"query",
+}
+
+type (
+	// Token is a Biscuit whose signatures have been verified.
+	Token struct {
+		blocks []*block
+	}
+
+	block struct {
+		index   int
+		context string
+		facts   []predicate
+		rules   []*rule
+		checks  []*check
+	}
+
+	// symbolTable is the table of symbols defined by a token's blocks.
+	symbolTable []string
+)
+
+// decodeTokenData returns the binary form of a token, which may be supplied
+// as raw protobuf or base64url text with or without padding.
+func decodeTokenData(data []byte) []byte {
+	text := bytes.TrimRight(bytes.TrimSpace(data), "=")
+	if decoded, err := base64.RawURLEncoding.DecodeString(string(text)); err == nil {
+		return decoded
+	}
+	return data
+}
+
+// blockPublicKey returns the Ed25519 key from a serialized public key.
+func blockPublicKey(pk *PublicKey) (ed25519.PublicKey, error) {
+	if pk == nil {
+		return nil, errors.New("missing public key")
+	}
+	if pk.Algorithm != PublicKey_Ed25519 {
+		return nil, errors.Errorf("unsupported public key algorithm %s", pk.Algorithm)
+	}
+	if len(pk.Key) != ed25519.PublicKeySize {
+		return nil, errors.Errorf("invalid Ed25519 public key length %d", len(pk.Key))
+	}
+	return ed25519.PublicKey(pk.Key), nil
+}
+
+// signedPayload returns the data covered by a block's signature: the block
+// followed by the algorithm and bytes of the key which signs the next block.
+func signedPayload(sb *SignedBlock) []byte {
+	buf := append([]byte{}, sb.Block...)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(sb.NextKey.Algorithm))
+	return append(buf, sb.NextKey.Key...)
+}
+
+// verifyBlock verifies the signature of a block with the key of the previous
+// block, and returns the key for the next one.
+func verifyBlock(sb *SignedBlock, key ed25519.PublicKey) (ed25519.PublicKey, error) {
+	if sb == nil {
+		return nil, errors.New("missing block")
+	}
+	if sb.ExternalSignature != nil {
+		return nil, errors.New("third-party blocks are not supported")
+	}
+	if sb.GetVersion() != 0 {
+		return nil, errors.Errorf("unsupported signature version %d", sb.GetVersion())
+	}
+	next, err := blockPublicKey(sb.NextKey)
+	if err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(key, signedPayload(sb), sb.Signature) {
+		return nil, errors.New("invalid signature")
+	}
+	return next, nil
+}
+
+// verifyProof checks that the holder of the token possessed the private key
+// for the last block, or that the token was sealed with it.
+func verifyProof(proof *Proof, last *SignedBlock, key ed25519.PublicKey) error {
+	switch p := proof.GetContent().(type) {
+	case *Proof_NextSecret:
+		if len(p.NextSecret) != ed25519.SeedSize {
+			return errors.New("invalid biscuit proof key length")
+		}
+		pub := ed25519.NewKeyFromSeed(p.NextSecret).Public().(ed25519.PublicKey)
+		if !pub.Equal(key) {
+			return errors.New("biscuit proof does not match the last block")
+		}
+	case *Proof_FinalSignature:
+		payload := append(signedPayload(last), last.Signature...)
+		if !ed25519.Verify(key, payload, p.FinalSignature) {
+			return errors.New("invalid biscuit seal signature")
+		}
+	default:
+		return errors.New("biscuit has no proof")
+	}
+	return nil
+}
+
+// Parse verifies the signature chain of a serialized token from the root key
+// and decodes its blocks.
+func Parse(data []byte, root ed25519.PublicKey) (*Token, error) {
+	if len(data) > MaxTokenSize {
+		return nil, errors.Errorf("biscuit exceeds %d bytes", MaxTokenSize)
+	}
+
+	pb := new(Biscuit)
+	if err := proto.Unmarshal(decodeTokenData(data), pb); err != nil {
+		return nil, errors.Wrap(err, "decoding biscuit")
+	}
+	if pb.Authority == nil {
+		return nil, errors.New("biscuit has no authority block")
+	}
+	signed := append([]*SignedBlock{pb.Authority}, pb.Blocks...)
+	if len(signed) > maxBlocks {
+		return nil, errors.Errorf("biscuit has more than %d blocks", maxBlocks)
+	}
+
+	key := root
+	for i, sb := range signed {
+		next, err := verifyBlock(sb, key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "biscuit block %d", i)
+		}
+		key = next
+	}
+	if err := verifyProof(pb.Proof, signed[len(signed)-1], key); err != nil {
+		return nil, err
+	}
+
+	tok := &Token{}
+	var syms symbolTable
+	for i, sb := range signed {
+		pbBlock := new(Block)
+		if err := proto.Unmarshal(sb.Block, pbBlock); err != nil {
+			return nil, errors.Wrapf(err, "decoding biscuit block %d", i)
+		}
+		b, err := decodeBlock(pbBlock, i, &syms)
+		if err != nil {
+			return nil, errors.Wrapf(err, "biscuit block %d", i)
+		}
+		tok.blocks = append(tok.blocks, b)
+	}
+
+	return tok, nil
+}
+
+// Blocks returns the number of blocks in the token, including the authority
+// block.
+func (t *Token) Blocks() int {
+	return len(t.blocks)
+}
+
+func (s symbolTable) lookup(i uint64) (string, error) {
+	if i < uint64(len(defaultSymbols)) {
+		return defaultSymbols[i], nil
+	}
+	if i >= symbolOffset && i-symbolOffset < uint64(len(s)) {
+		return s[i-symbolOffset], nil
+	}
+	return "", errors.Errorf("unknown symbol %d", i)
+}
+
+// blockTrust returns the origins of the facts visible to the rules and checks
+// of a block with the given scopes. By default only facts from the authority
+// block, the block itself and the authorizer are trusted.
+func blockTrust(scopes []*Scope, index int, fallback origin) (origin, error) {
+	if len(scopes) == 0 {
+		return fallback, nil
+	}
+
+	trust := blockOrigin(index) | authorizerOrigin
+	for _, sc := range scopes {
+		switch s := sc.GetContent().(type) {
+		case *Scope_ScopeType_:
+			switch s.ScopeType {
+			case Scope_Authority:
+				trust |= blockOrigin(0)
+			case Scope_Previous:
+				trust |= blockOrigin(index+1) - 1
+			default:
+				return 0, errors.Errorf("unknown scope type %d", s.ScopeType)
+			}
+		default:
+			return 0, errors.New("public key scopes are not supported")
+		}
+	}
+	return trust, nil
+}
+
+type decoder struct {
+	syms symbolTable
+}
+
+func (d *decoder) term(pb *TermV2, inSet bool) (Term, error) {
+	switch c := pb.GetContent().(type) {
+	case *TermV2_Variable:
+		if inSet {
+			return Term{}, errors.New("variable in set")
+		}
+		name, err := d.syms.lookup(uint64(c.Variable))
+		if err != nil {
+			return Term{}, err
+		}
+		return Term{kind: termVariable, str: name}, nil
+	case *TermV2_Integer:
+		return IntegerTerm(c.Integer), nil
+	case *TermV2_String_:
+		s, err := d.syms.lookup(c.String_)
+		if err != nil {
+			return Term{}, err
+		}
+		return StringTerm(s), nil
+	case *TermV2_Date:
+		if c.Date > 1<<62 {
+			return Term{}, errors.Errorf("date %d out of range", c.Date)
+		}
+		return Term{kind: termDate, num: int64(c.Date)}, nil
+	case *TermV2_Bytes:
+		return Term{kind: termBytes, bytes: c.Bytes}, nil
+	case *TermV2_Bool:
+		return Term{kind: termBool, b: c.Bool}, nil
+	case *TermV2_Set:
+		if inSet {
+			return Term{}, errors.New("nested set")
+		}
+		set := Term{kind: termSet}
+		for _, e := range c.Set.GetSet() {
+			t, err := d.term(e, true)
+			if err != nil {
+				return Term{}, err
+			}
+			set.set = append(set.set, t)
+		}
+		return set, nil
+	default:
+		return Term{}, errors.New("unsupported term type")
+	}
+}
+
+func (d *decoder) predicate(pb *PredicateV2) (predicate, error) {
+	name, err := d.syms.lookup(pb.GetName())
+	if err != nil {
+		return predicate{}, err
+	}
+	p := predicate{name: name}
+	for _, t := range pb.GetTerms() {
+		term, err := d.term(t, false)
+		if err != nil {
+			return predicate{}, errors.Wrapf(err, "predicate %s", name)
+		}
+		p.terms = append(p.terms, term)
+	}
+	return p, nil
+}
+
+func (d *decoder) expression(pb *ExpressionV2) (expression, error) {
+	var expr expression
+	for _, o := range pb.GetOps() {
+		switch c := o.GetContent().(type) {
+		case *Op_Value:
+			t, err := d.term(c.Value, false)
+			if err != nil {
+				return nil, err
+			}
+			expr = append(expr, op{kind: opValue, value: t})
+		case *Op_Unary:
+			expr = append(expr, op{kind: opUnary, unary: c.Unary.GetKind()})
+		case *Op_Binary:
+			expr = append(expr, op{kind: opBinary, binary: c.Binary.GetKind()})
+		default:
+			return nil, errors.New("unsupported expression operation")
+		}
+	}
+	return expr, nil
+}
+
+func (d *decoder) rule(pb *RuleV2, index int, fallback origin) (*rule, error) {
+	head, err := d.predicate(pb.GetHead())
+	if err != nil {
+		return nil, err
+	}
+	r := &rule{head: head, origin: blockOrigin(index)}
+	for _, b := range pb.GetBody() {
+		p, err := d.predicate(b)
+		if err != nil {
+			return nil, err
+		}
+		r.body = append(r.body, p)
+	}
+	for _, e := range pb.GetExpressions() {
+		expr, err := d.expression(e)
+		if err != nil {
+			return nil, err
+		}
+		r.exprs = append(r.exprs, expr)
+	}
+	if r.trust, err = blockTrust(pb.GetScope(), index, fallback); err != nil {
+		return nil, err
+	}
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// decodeBlock decodes a block, after adding the symbols it defines to the
+// token's symbol table.
+func decodeBlock(pb *Block, index int, syms *symbolTable) (*block, error) {
+	if v := pb.GetVersion(); v < minBlockVersion || v > maxBlockVersion {
+		return nil, errors.Errorf("unsupported block version %d", v)
+	}
+	if len(pb.PublicKeys) > 0 {
+		return nil, errors.New("public key scopes are not supported")
+	}
+
+	*syms = append(*syms, pb.Symbols...)
+	d := &decoder{syms: *syms}
+	b := &block{index: index, context: pb.GetContext()}
+
+	trust, err := blockTrust(pb.GetScope(), index, blockOrigin(0)|blockOrigin(index)|authorizerOrigin)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range pb.GetFactsV2() {
+		p, err := d.predicate(f.GetPredicate())
+		if err != nil {
+			return nil, err
+		}
+		if p.hasVariables() {
+			return nil, errors.Errorf("fact %s contains variables", p)
+		}
+		b.facts = append(b.facts, p)
+	}
+	for _, r := range pb.GetRulesV2() {
+		rule, err := d.rule(r, index, trust)
+		if err != nil {
+			return nil, err
+		}
+		b.rules = append(b.rules, rule)
+	}
+	for _, c := range pb.GetChecksV2() {
+		chk := &check{kind: c.GetKind(), block: index}
+		if len(c.GetQueries()) == 0 {
+			return nil, errors.New("check has no queries")
+		}
+		for _, q := range c.GetQueries() {
+			rule, err := d.rule(q, index, trust)
+			if err != nil {
+				return nil, err
+			}
+			chk.queries = append(chk.queries, rule)
+		}
+		b.checks = append(b.checks, chk)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,317 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package biscuit
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"slices"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+// testBuilder builds the blocks of a token, maintaining its symbol table as
+// a Biscuit library would.
+type testBuilder struct {
+	syms  []string
+	block *Block
+}
+
+func testVar(name string) Term {
+	return Term{kind: termVariable, str: name}
+}
+
+func (tb *testBuilder) sym(s string) uint64 {
+	if i := slices.Index(defaultSymbols, s); i >= 0 {
+		return uint64(i)
+	}
+	if i := slices.Index(tb.syms, s); i >= 0 {
+		return symbolOffset + uint64(i)
+	}
+	tb.syms = append(tb.syms, s)
+	tb.block.Symbols = append(tb.block.Symbols, s)
+	return symbolOffset + uint64(len(tb.syms)-1)
+}
+
+func (tb *testBuilder) term(t Term) *TermV2 {
+	switch t.kind {
+	case termVariable:
+		return &TermV2{Content: &TermV2_Variable{Variable: uint32(tb.sym(t.str))}}
+	case termString:
+		return &TermV2{Content: &TermV2_String_{String_: tb.sym(t.str)}}
+	case termDate:
+		return &TermV2{Content: &TermV2_Date{Date: uint64(t.num)}}
+	case termBool:
+		return &TermV2{Content: &TermV2_Bool{Bool: t.b}}
+	case termSet:
+		set := &TermSet{}
+		for _, e := range t.set {
+			set.Set = append(set.Set, tb.term(e))
+		}
+		return &TermV2{Content: &TermV2_Set{Set: set}}
+	default:
+		return &TermV2{Content: &TermV2_Integer{Integer: t.num}}
+	}
+}
+
+func (tb *testBuilder) pred(name string, terms ...Term) *PredicateV2 {
+	p := &PredicateV2{Name: tb.sym(name)}
+	for _, t := range terms {
+		p.Terms = append(p.Terms, tb.term(t))
+	}
+	return p
+}
+
+// expr builds an expression from terms and operation kinds in postfix order.
+func (tb *testBuilder) expr(items ...any) *ExpressionV2 {
+	e := &ExpressionV2{}
+	for _, item := range items {
+		switch i := item.(type) {
+		case Term:
+			e.Ops = append(e.Ops, &Op{Content: &Op_Value{Value: tb.term(i)}})
+		case OpUnary_Kind:
+			e.Ops = append(e.Ops, &Op{Content: &Op_Unary{Unary: &OpUnary{Kind: i}}})
+		case OpBinary_Kind:
+			e.Ops = append(e.Ops, &Op{Content: &Op_Binary{Binary: &OpBinary{Kind: i}}})
+		}
+	}
+	return e
+}
+
+func (tb *testBuilder) fact(name string, terms ...Term) {
+	tb.block.FactsV2 = append(tb.block.FactsV2, &FactV2{Predicate: tb.pred(name, terms...)})
+}
+
+func (tb *testBuilder) rule(head *PredicateV2, body []*PredicateV2, exprs ...*ExpressionV2) *RuleV2 {
+	return &RuleV2{Head: head, Body: body, Expressions: exprs}
+}
+
+func (tb *testBuilder) check(kind CheckV2_Kind, queries ...*RuleV2) {
+	tb.block.ChecksV2 = append(tb.block.ChecksV2, &CheckV2{Queries: queries, Kind: &kind})
+}
+
+// checkIf adds a check with a single query.
+func (tb *testBuilder) checkIf(body []*PredicateV2, exprs ...*ExpressionV2) {
+	tb.check(CheckV2_One, tb.rule(tb.pred("query"), body, exprs...))
+}
+
+func testGenerateKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pub, priv
+}
+
+// testSignBlock appends a block signed with the given key to the token, and
+// returns the secret key for the next block.
+func testSignBlock(t *testing.T, pb *Biscuit, block *Block, key ed25519.PrivateKey) ed25519.PrivateKey {
+	t.Helper()
+	if block.Version == nil {
+		block.Version = proto.Uint32(minBlockVersion)
+	}
+	data, err := proto.Marshal(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nextPub, nextPriv := testGenerateKey(t)
+	sb := &SignedBlock{Block: data, NextKey: &PublicKey{Key: nextPub}}
+	sb.Signature = ed25519.Sign(key, signedPayload(sb))
+
+	if pb.Authority == nil {
+		pb.Authority = sb
+	} else {
+		pb.Blocks = append(pb.Blocks, sb)
+	}
+	pb.Proof = &Proof{Content: &Proof_NextSecret{NextSecret: nextPriv.Seed()}}
+	return nextPriv
+}
+
+// testMint mints a token with the given blocks, the first of which is the
+// authority block signed by the root key.
+func testMint(t *testing.T, root ed25519.PrivateKey, blocks ...func(*testBuilder)) []byte {
+	t.Helper()
+	pb := &Biscuit{}
+	tb := &testBuilder{}
+	key := root
+	for _, fn := range blocks {
+		tb.block = &Block{}
+		fn(tb)
+		key = testSignBlock(t, pb, tb.block, key)
+	}
+	data, err := proto.Marshal(pb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// testAttenuate appends a block to a token using only the token itself, as
+// any holder of the token may.
+func testAttenuate(t *testing.T, data []byte, fn func(*testBuilder)) []byte {
+	t.Helper()
+	pb := &Biscuit{}
+	if err := proto.Unmarshal(data, pb); err != nil {
+		t.Fatal(err)
+	}
+	tb := &testBuilder{}
+	for _, sb := range append([]*SignedBlock{pb.Authority}, pb.Blocks...) {
+		b := &Block{}
+		if err := proto.Unmarshal(sb.Block, b); err != nil {
+			t.Fatal(err)
+		}
+		tb.syms = append(tb.syms, b.Symbols...)
+	}
+	tb.block = &Block{}
+	fn(tb)
+	testSignBlock(t, pb, tb.block, ed25519.NewKeyFromSeed(pb.Proof.GetNextSecret()))
+	out, err := proto.Marshal(pb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func testModify(t *testing.T, data []byte, fn func(*Biscuit)) []byte {
+	t.Helper()
+	pb := &Biscuit{}
+	if err := proto.Unmarshal(data, pb); err != nil {
+		t.Fatal(err)
+	}
+	fn(pb)
+	out, err := proto.Marshal(pb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestBiscuit_Parse(t *testing.T) {
+	rootPub, rootPriv := testGenerateKey(t)
+	otherPub, _ := testGenerateKey(t)
+
+	authority := func(tb *testBuilder) {
+		tb.fact("user", StringTerm("jdoe"))
+	}
+	minted := testMint(t, rootPriv, authority)
+	attenuated := testAttenuate(t, minted, func(tb *testBuilder) {
+		tb.checkIf([]*PredicateV2{tb.pred("resource", StringTerm("tank"))})
+	})
+
+	for name, tc := range map[string]struct {
+		data      []byte
+		root      ed25519.PublicKey
+		expBlocks int
+		expErr    error
+	}{
+		"authority only": {
+			data:      minted,
+			expBlocks: 1,
+		},
+		"base64url": {
+			data:      []byte(base64.URLEncoding.EncodeToString(attenuated) + "\n"),
+			expBlocks: 2,
+		},
+		"attenuated": {
+			data:      attenuated,
+			expBlocks: 2,
+		},
+		"other root key": {
+			data:   minted,
+			root:   otherPub,
+			expErr: errors.New("block 0: invalid signature"),
+		},
+		"tampered authority": {
+			data: testModify(t, minted, func(pb *Biscuit) {
+				pb.Authority.Block = append(pb.Authority.Block, 0)
+			}),
+			expErr: errors.New("block 0: invalid signature"),
+		},
+		"block removed": {
+			data: testModify(t, attenuated, func(pb *Biscuit) {
+				pb.Blocks = nil
+			}),
+			expErr: errors.New("proof does not match"),
+		},
+		"sealed": {
+			data: testModify(t, attenuated, func(pb *Biscuit) {
+				last := pb.Blocks[0]
+				secret := ed25519.NewKeyFromSeed(pb.Proof.GetNextSecret())
+				sig := ed25519.Sign(secret, append(signedPayload(last), last.Signature...))
+				pb.Proof = &Proof{Content: &Proof_FinalSignature{FinalSignature: sig}}
+			}),
+			expBlocks: 2,
+		},
+		"invalid seal": {
+			data: testModify(t, attenuated, func(pb *Biscuit) {
+				pb.Proof = &Proof{Content: &Proof_FinalSignature{FinalSignature: make([]byte, ed25519.SignatureSize)}}
+			}),
+			expErr: errors.New("invalid biscuit seal signature"),
+		},
+		"no proof": {
+			data: testModify(t, minted, func(pb *Biscuit) {
+				pb.Proof = nil
+			}),
+			expErr: errors.New("no proof"),
+		},
+		"third-party block": {
+			data: testModify(t, attenuated, func(pb *Biscuit) {
+				pb.Blocks[0].ExternalSignature = &ExternalSignature{}
+			}),
+			expErr: errors.New("third-party blocks are not supported"),
+		},
+		"unsupported block version": {
+			data: testMint(t, rootPriv, func(tb *testBuilder) {
+				tb.block.Version = proto.Uint32(maxBlockVersion + 1)
+			}),
+			expErr: errors.New("unsupported block version"),
+		},
+		"unknown symbol": {
+			data: testMint(t, rootPriv, func(tb *testBuilder) {
+				tb.block.FactsV2 = append(tb.block.FactsV2, &FactV2{Predicate: &PredicateV2{Name: symbolOffset}})
+			}),
+			expErr: errors.New("unknown symbol"),
+		},
+		"fact with variable": {
+			data: testMint(t, rootPriv, func(tb *testBuilder) {
+				tb.fact("user", testVar("u"))
+			}),
+			expErr: errors.New("contains variables"),
+		},
+		"unbound head variable": {
+			data: testMint(t, rootPriv, func(tb *testBuilder) {
+				tb.block.RulesV2 = append(tb.block.RulesV2,
+					tb.rule(tb.pred("right", testVar("r")), []*PredicateV2{tb.pred("user", testVar("u"))}))
+			}),
+			expErr: errors.New("not bound by its body"),
+		},
+		"too large": {
+			data:   make([]byte, MaxTokenSize+1),
+			expErr: errors.New("exceeds"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			root := tc.root
+			if root == nil {
+				root = rootPub
+			}
+
+			tok, err := Parse(tc.data, root)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertEqual(t, tc.expBlocks, tok.Blocks(), "unexpected number of blocks")
+		})
+	}
+}
@@ -0,0 +1,116 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// procRoot is where process information is read from. It may be changed by
+// tests.
+var procRoot = "/proc"
+
+// processCgroups returns the paths of the cgroups the process belongs to, one
+// per hierarchy.
+func processCgroups(pid int32) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(procRoot, fmt.Sprint(pid), "cgroup"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading cgroups of pid %d", pid)
+	}
+
+	var paths []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		// hierarchy-ID:controller-list:cgroup-path
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		paths = append(paths, fields[2])
+	}
+	return paths, scanner.Err()
+}
+
+// cgroupJobID returns the first submatch of the pattern in the paths of the
+// process's cgroups, which is the ID of the job the process was launched in
+// by a workload manager that places jobs in their own cgroups. An empty ID is
+// returned if the process is not in a matching cgroup.
+func cgroupJobID(pid int32, pattern *regexp.Regexp) (string, error) {
+	cgroups, err := processCgroups(pid)
+	if err != nil {
+		return "", err
+	}
+	for _, cg := range cgroups {
+		if m := pattern.FindStringSubmatch(cg); m != nil && m[1] != "" {
+			return m[1], nil
+		}
+	}
+	return "", nil
+}
+
+// processContainer records the cgroup of a client process, and the container
+// it runs in, in credentials issued by the Unix-based flavors.
+type processContainer struct {
+	cgroup      string
+	containerID string
+}
+
+// clientContainer returns the cgroup and container of the process, or nil if
+// the agent is not configured to record them. The cgroup recorded is the one
+// listed last, which is in the unified hierarchy if the process has one.
+func clientContainer(cfg *security.ContainerConfig, pid int32) (*processContainer, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	pattern, err := cfg.ContainerID()
+	if err != nil {
+		return nil, err
+	}
+
+	cgroups, err := processCgroups(pid)
+	if err != nil {
+		return nil, err
+	}
+	pc := &processContainer{}
+	if len(cgroups) > 0 {
+		pc.cgroup = cgroups[len(cgroups)-1]
+	}
+	for _, cg := range cgroups {
+		if m := pattern.FindStringSubmatch(cg); m != nil && m[1] != "" {
+			pc.containerID = m[1]
+			break
+		}
+	}
+	return pc, nil
+}
+
+// apply records the cgroup and container in the token.
+func (pc *processContainer) apply(sys *Sys) {
+	if pc == nil {
+		return
+	}
+	sys.Cgroup = pc.cgroup
+	sys.ContainerId = pc.containerID
+}
+
+// key returns the part of a cache key that binds a credential to the cgroup.
+func (pc *processContainer) key() string {
+	if pc == nil {
+		return ""
+	}
+	return "\x00" + pc.cgroup
+}
@@ -0,0 +1,88 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_clientContainer(t *testing.T) {
+	containerID := strings.Repeat("0123456789abcdef", 4)
+
+	for name, tc := range map[string]struct {
+		cfg    security.ContainerConfig
+		cgroup string
+		expSys *Sys
+		expErr error
+	}{
+		"not enabled": {
+			cgroup: "0::/system.slice/docker-" + containerID + ".scope\n",
+			expSys: &Sys{},
+		},
+		"docker": {
+			cfg:    security.ContainerConfig{Enabled: true},
+			cgroup: "0::/system.slice/docker-" + containerID + ".scope\n",
+			expSys: &Sys{
+				Cgroup:      "/system.slice/docker-" + containerID + ".scope",
+				ContainerId: containerID,
+			},
+		},
+		"cgroup v1": {
+			cfg:    security.ContainerConfig{Enabled: true},
+			cgroup: "12:memory:/docker/" + containerID + "\n1:name=systemd:/docker/" + containerID + "\n",
+			expSys: &Sys{
+				Cgroup:      "/docker/" + containerID,
+				ContainerId: containerID,
+			},
+		},
+		"not in container": {
+			cfg:    security.ContainerConfig{Enabled: true},
+			cgroup: "0::/user.slice/user-1000.slice/session-3.scope\n",
+			expSys: &Sys{
+				Cgroup: "/user.slice/user-1000.slice/session-3.scope",
+			},
+		},
+		"custom pattern": {
+			cfg: security.ContainerConfig{
+				Enabled:            true,
+				ContainerIDPattern: `/apptainer-([0-9]+)\.scope$`,
+			},
+			cgroup: "0::/user.slice/user-1000.slice/apptainer-4242.scope\n",
+			expSys: &Sys{
+				Cgroup:      "/user.slice/user-1000.slice/apptainer-4242.scope",
+				ContainerId: "4242",
+			},
+		},
+		"bad pattern": {
+			cfg: security.ContainerConfig{
+				Enabled:            true,
+				ContainerIDPattern: `/apptainer-[0-9]+\.scope$`,
+			},
+			cgroup: "0::/user.slice/user-1000.slice/apptainer-4242.scope\n",
+			expErr: errors.New("exactly one subexpression"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setTestProcCgroup(t, 42, tc.cgroup)
+
+			pc, err := clientContainer(&tc.cfg, 42)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			sys := &Sys{}
+			pc.apply(sys)
+			test.CmpAny(t, "sys", tc.expSys, sys, test.DefaultCmpOpts()...)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	// ChallengeLifetime is the period during which a challenge issued by the
+	// agent may be used to obtain a credential.
+	ChallengeLifetime = time.Minute
+
+	challengeRandLen = 16
+	// A challenge is the expiry time, the requesting uid, random bytes and
+	// an HMAC over all of these.
+	challengeDataLen = 8 + 4 + challengeRandLen
+	challengeLen     = challengeDataLen + sha256.Size
+)
+
+// Challenges issues and checks the challenges signed by clients of
+// proof-of-possession flavors (e.g. AUTH_SSH). Challenges are authenticated
+// with a per-process secret so that they need not be stored until they are
+// used, and are bound to the uid of the process which requested them.
+type Challenges struct {
+	sync.Mutex
+	secret   []byte
+	lifetime time.Duration
+	now      func() time.Time
+	used     map[string]time.Time
+}
+
+var defaultChallenges = NewChallenges(ChallengeLifetime)
+
+// NewChallenges returns a Challenges with a new random secret.
+func NewChallenges(lifetime time.Duration) *Challenges {
+	secret := make([]byte, sha256.Size)
+	if _, err := rand.Read(secret); err != nil {
+		panic(errors.Wrap(err, "failed to generate challenge secret"))
+	}
+
+	return &Challenges{
+		secret:   secret,
+		lifetime: lifetime,
+		now:      time.Now,
+		used:     make(map[string]time.Time),
+	}
+}
+
+func (c *Challenges) mac(data []byte) []byte {
+	h := hmac.New(sha256.New, c.secret)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// Issue returns a new challenge for the given uid.
+func (c *Challenges) Issue(uid uint32) ([]byte, error) {
+	data := binary.BigEndian.AppendUint64(nil, uint64(c.now().Add(c.lifetime).Unix()))
+	data = binary.BigEndian.AppendUint32(data, uid)
+	nonce := make([]byte, challengeRandLen)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate challenge")
+	}
+	data = append(data, nonce...)
+
+	return append(data, c.mac(data)...), nil
+}
+
+// Redeem checks that the challenge was issued by this agent to the given uid
+// and has not expired or already been redeemed.
+func (c *Challenges) Redeem(challenge []byte, uid uint32) error {
+	if len(challenge) != challengeLen {
+		return errors.New("malformed challenge")
+	}
+	data, mac := challenge[:challengeDataLen], challenge[challengeDataLen:]
+	if !hmac.Equal(mac, c.mac(data)) {
+		return errors.New("challenge was not issued by this agent")
+	}
+	if binary.BigEndian.Uint32(data[8:12]) != uid {
+		return errors.New("challenge was issued to a different user")
+	}
+
+	now := c.now()
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(data[:8])), 0)
+	if now.After(expiresAt) {
+		return errors.New("challenge has expired")
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	for key, exp := range c.used {
+		if now.After(exp) {
+			delete(c.used, key)
+		}
+	}
+	key := string(mac)
+	if _, found := c.used[key]; found {
+		return errors.New("challenge has already been used")
+	}
+	c.used[key] = expiresAt
+
+	return nil
+}
+
+// IssueChallenge returns a new challenge for the client on the other end of
+// the session.
+func IssueChallenge(log logging.Logger, session *drpc.Session) ([]byte, error) {
+	uid, err := sessionUID(log, session)
+	if err != nil {
+		return nil, err
+	}
+	return defaultChallenges.Issue(uid)
+}
+
+func sessionUID(log logging.Logger, session *drpc.Session) (uint32, error) {
+	info, err := sessionDomainInfo(log, session)
+	if err != nil {
+		return 0, err
+	}
+	return info.Uid(), nil
+}
+
+// sessionDomainInfo returns the credentials of the client process connected to
+// the session.
+func sessionDomainInfo(log logging.Logger, session *drpc.Session) (*security.DomainInfo, error) {
+	if session == nil {
+		return nil, errors.New("nil session")
+	}
+	uConn, ok := session.Conn.(*net.UnixConn)
+	if !ok {
+		return nil, errors.New("connection is not a unix socket")
+	}
+	info, err := security.DomainInfoFromUnixConn(log, uConn)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get credentials for client socket")
+	}
+	return info, nil
+}
@@ -0,0 +1,74 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestAuth_Challenges_Redeem(t *testing.T) {
+	for name, tc := range map[string]struct {
+		issueUID  uint32
+		redeemUID uint32
+		elapsed   time.Duration
+		other     bool
+		tamper    bool
+		twice     bool
+		expErr    error
+	}{
+		"success": {},
+		"wrong uid": {
+			issueUID:  1000,
+			redeemUID: 1001,
+			expErr:    errors.New("different user"),
+		},
+		"expired": {
+			elapsed: ChallengeLifetime + time.Second,
+			expErr:  errors.New("expired"),
+		},
+		"issued by another agent": {
+			other:  true,
+			expErr: errors.New("not issued by this agent"),
+		},
+		"tampered": {
+			tamper: true,
+			expErr: errors.New("not issued by this agent"),
+		},
+		"replayed": {
+			twice:  true,
+			expErr: errors.New("already been used"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sc := NewChallenges(ChallengeLifetime)
+			issuer := sc
+			if tc.other {
+				issuer = NewChallenges(ChallengeLifetime)
+			}
+
+			challenge, err := issuer.Issue(tc.issueUID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.tamper {
+				challenge[0] ^= 0xff
+			}
+			sc.now = func() time.Time { return time.Now().Add(tc.elapsed) }
+
+			err = sc.Redeem(challenge, tc.redeemUID)
+			if tc.twice && err == nil {
+				err = sc.Redeem(challenge, tc.redeemUID)
+			}
+			test.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
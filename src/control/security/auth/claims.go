@@ -0,0 +1,154 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/lib/daos"
+)
+
+const (
+	// ClaimDomain is the domain of the ACL principals which stand for the
+	// claims of an identity, e.g. "job=1234@claim".
+	ClaimDomain = "claim"
+
+	// ClaimScope is the key of the claims for the scopes granted by the
+	// token issuer.
+	ClaimScope = "scope"
+	// ClaimJob is the key of the claim for the workload manager job.
+	ClaimJob = "job"
+	// ClaimContainer is the key of the claim for the container of the
+	// requesting process.
+	ClaimContainer = "container"
+	// ClaimLabel is the key of the claims for the names of the MAC label of
+	// the requesting process.
+	ClaimLabel = "label"
+	// ClaimClass is the key of the claim for the class of the identity,
+	// e.g. "class=machine@claim" for node identities.
+	ClaimClass = "class"
+)
+
+var claimKeyPattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// ClaimPrincipal returns the ACL principal which stands for the claim, so that
+// pool and container ACL entries for the group "<key>=<value>@claim" apply to
+// identities with the claim.
+func ClaimPrincipal(claim *Claim) string {
+	return claim.GetKey() + "=" + claim.GetValue() + "@" + ClaimDomain
+}
+
+// validateClaim checks that the claim can be expressed as an ACL principal.
+func validateClaim(claim *Claim) error {
+	if !claimKeyPattern.MatchString(claim.GetKey()) {
+		return errors.Errorf("invalid claim key %q", claim.GetKey())
+	}
+	if claim.GetValue() == "" || strings.ContainsAny(claim.GetValue(), ":@ \t\n") {
+		return errors.Errorf("claim %q has invalid value %q", claim.GetKey(), claim.GetValue())
+	}
+	if len(ClaimPrincipal(claim)) > daos.ACLPrincipalMaxLen {
+		return errors.Errorf("claim %q is too long", claim.GetKey())
+	}
+	return nil
+}
+
+// NewClaims validates the claims and returns them, ordered by key, for the
+// token.
+func NewClaims(claims map[string]string) ([]*Claim, error) {
+	keys := make([]string, 0, len(claims))
+	for key := range claims {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var out []*Claim
+	for _, key := range keys {
+		claim := &Claim{Key: key, Value: claims[key]}
+		if err := validateClaim(claim); err != nil {
+			return nil, err
+		}
+		out = append(out, claim)
+	}
+	return out, nil
+}
+
+// TokenClaims returns the claims about the identity: those recorded in the
+// token by its flavor, and those derived from the scopes, job and container
+// recorded in it. Claims which cannot be expressed as ACL principals are
+// omitted, as are label claims, which are only given by the server's MAC label
+// policy, and class claims, which are only given by the class recorded in the
+// token.
+func TokenClaims(sys *Sys) []*Claim {
+	claims := slices.Clone(sys.GetClaims())
+	for _, scope := range sys.GetGrantedScopes() {
+		claims = append(claims, &Claim{Key: ClaimScope, Value: scope})
+	}
+	if sys.GetJobId() != "" {
+		claims = append(claims, &Claim{Key: ClaimJob, Value: sys.GetJobId()})
+	}
+	if sys.GetContainerId() != "" {
+		claims = append(claims, &Claim{Key: ClaimContainer, Value: sys.GetContainerId()})
+	}
+
+	return slices.DeleteFunc(claims, func(claim *Claim) bool {
+		return claim.GetKey() == ClaimLabel || claim.GetKey() == ClaimClass || validateClaim(claim) != nil
+	})
+}
+
+// WithClaimPrincipals returns the token presented to the pool and container
+// access checks, with the principals of the claims of its identity and its
+// class, of the names the policy gives its MAC label, and of its identities of
+// types other than Unix and the groups they are mapped to, added to its
+// groups, so that ACLs may be written against them. Groups in the claim and
+// identity domains which are not backed by a claim or identity are removed, so
+// that they cannot be asserted as groups. A token with no such groups is
+// returned as it is.
+func WithClaimPrincipals(token *Token, labels *MACLabelPolicy, idGroups *IdentityGroupMap) (*Token, error) {
+	sys, err := sysFromToken(token)
+	if err != nil {
+		return nil, err
+	}
+	var principals []string
+	for _, claim := range append(TokenClaims(sys), labels.Claims(sys.GetSecctx())...) {
+		principals = append(principals, ClaimPrincipal(claim))
+	}
+	if claim := tokenClassClaim(token); claim != nil {
+		principals = append(principals, ClaimPrincipal(claim))
+	}
+	ids := tokenIdentities(sys)
+	for _, id := range ids {
+		principals = append(principals, IdentityPrincipal(id))
+	}
+	principals = append(principals, idGroups.Groups(ids)...)
+	groups := slices.DeleteFunc(slices.Clone(sys.GetGroups()), func(group string) bool {
+		return strings.HasSuffix(group, "@"+ClaimDomain) || strings.HasSuffix(group, "@"+IdentityDomain)
+	})
+	if len(principals) == 0 && len(groups) == len(sys.GetGroups()) {
+		return token, nil
+	}
+
+	for _, princ := range principals {
+		if !slices.Contains(groups, princ) {
+			groups = append(groups, princ)
+		}
+	}
+	sys.Groups = groups
+	data, err := proto.Marshal(sys)
+	if err != nil {
+		return nil, errors.Wrapf(err, "marshaling %s", token.GetFlavor())
+	}
+
+	withClaims := proto.Clone(token).(*Token)
+	withClaims.Data = data
+	return withClaims, nil
+}
@@ -0,0 +1,239 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_NewClaims(t *testing.T) {
+	for name, tc := range map[string]struct {
+		claims    map[string]string
+		expClaims []string
+		expErr    error
+	}{
+		"none": {},
+		"ordered by key": {
+			claims:    map[string]string{"project": "climate", "job": "1234"},
+			expClaims: []string{"job=1234@claim", "project=climate@claim"},
+		},
+		"invalid key": {
+			claims: map[string]string{"a=b": "c"},
+			expErr: errors.New(`invalid claim key "a=b"`),
+		},
+		"empty value": {
+			claims: map[string]string{"project": ""},
+			expErr: errors.New(`claim "project" has invalid value ""`),
+		},
+		"value with domain": {
+			claims: map[string]string{"project": "climate@example.com"},
+			expErr: errors.New("has invalid value"),
+		},
+		"too long": {
+			claims: map[string]string{"project": strings.Repeat("x", 256)},
+			expErr: errors.New(`claim "project" is too long`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			claims, err := NewClaims(tc.claims)
+			test.CmpErr(t, tc.expErr, err)
+
+			var principals []string
+			for _, claim := range claims {
+				principals = append(principals, ClaimPrincipal(claim))
+			}
+			test.CmpAny(t, "claims", tc.expClaims, principals)
+		})
+	}
+}
+
+func TestAuth_WithClaimPrincipals(t *testing.T) {
+	labels, err := NewMACLabelPolicy([]*security.MACLabelConfig{
+		{Name: "secret", Pattern: `[^:]+:[^:]+:[^:]+:s3(:.*)?`},
+		{Name: "staff", Pattern: `staff_u:.*`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	idGroups, err := NewIdentityGroupMap(&security.IdentityGroupConfig{
+		Mappings: security.IdentityGroupMappings{
+			"oidc:*@idp.example": {"hpc"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		sys       *Sys
+		class     CredentialClass
+		labels    *MACLabelPolicy
+		idGroups  *IdentityGroupMap
+		unchanged bool
+		expGroups []string
+		expErr    error
+	}{
+		"malformed token": {
+			expErr: errors.New("nil token"),
+		},
+		"no claims": {
+			sys:       &Sys{User: "jdoe@", Groups: []string{"users@"}},
+			unchanged: true,
+		},
+		"claims": {
+			sys: &Sys{
+				User:   "jdoe@",
+				Groups: []string{"users@"},
+				Claims: []*Claim{{Key: "project", Value: "climate"}},
+			},
+			expGroups: []string{"users@", "project=climate@claim"},
+		},
+		"derived claims": {
+			sys: &Sys{
+				User:          "jdoe@",
+				GrantedScopes: []string{"pool.read", "pool:write"},
+				JobId:         "1234",
+				ContainerId:   "abcd",
+			},
+			expGroups: []string{"scope=pool.read@claim", "job=1234@claim", "container=abcd@claim"},
+		},
+		"MAC label": {
+			sys: &Sys{
+				User:   "jdoe@",
+				Secctx: "staff_u:staff_r:staff_t:s3:c0.c255",
+			},
+			labels:    labels,
+			expGroups: []string{"label=secret@claim", "label=staff@claim"},
+		},
+		"MAC label not named": {
+			sys: &Sys{
+				User:   "jdoe@",
+				Secctx: "user_u:user_r:user_t:s0",
+			},
+			labels:    labels,
+			unchanged: true,
+		},
+		"MAC label without policy": {
+			sys: &Sys{
+				User:   "jdoe@",
+				Secctx: "staff_u:staff_r:staff_t:s3",
+			},
+			unchanged: true,
+		},
+		"asserted label claim ignored": {
+			sys: &Sys{
+				User:   "jdoe@",
+				Claims: []*Claim{{Key: ClaimLabel, Value: "secret"}},
+			},
+			labels:    labels,
+			unchanged: true,
+		},
+		"asserted claim groups removed": {
+			sys: &Sys{
+				User:   "jdoe@",
+				Groups: []string{"users@", "project=secret@claim"},
+			},
+			expGroups: []string{"users@"},
+		},
+		"identities": {
+			sys: &Sys{
+				User: "jdoe@",
+				Identities: []*Identity{
+					{Type: IdentityOIDC, Name: "alice@idp.example"},
+					{Type: IdentitySPIFFE, Name: "example.org/ns/prod/sa/app"},
+					{Type: "unknown", Name: "alice"},
+				},
+			},
+			expGroups: []string{
+				"oidc%3Aalice%40idp.example@identity",
+				"spiffe%3Aexample.org/ns/prod/sa/app@identity",
+			},
+		},
+		"identity mapped to groups": {
+			sys: &Sys{
+				User:   "jdoe@",
+				Groups: []string{"users@", "hpc@"},
+				Identities: []*Identity{
+					{Type: IdentityOIDC, Name: "alice@idp.example"},
+				},
+			},
+			idGroups: idGroups,
+			expGroups: []string{
+				"users@",
+				"hpc@",
+				"oidc%3Aalice%40idp.example@identity",
+			},
+		},
+		"unmapped identity": {
+			sys: &Sys{
+				User: "jdoe@",
+				Identities: []*Identity{
+					{Type: IdentityOIDC, Name: "alice@other.example"},
+				},
+			},
+			idGroups:  idGroups,
+			expGroups: []string{"oidc%3Aalice%40other.example@identity"},
+		},
+		"class": {
+			sys: &Sys{
+				User:   "node@",
+				Groups: []string{"class=user@claim"},
+			},
+			class:     CredentialClass_CLASS_MACHINE,
+			expGroups: []string{"class=machine@claim"},
+		},
+		"asserted class claim ignored": {
+			sys: &Sys{
+				User:   "jdoe@",
+				Claims: []*Claim{{Key: ClaimClass, Value: "machine"}},
+			},
+			unchanged: true,
+		},
+		"asserted identity groups removed": {
+			sys: &Sys{
+				User:   "jdoe@",
+				Groups: []string{"users@", "oidc%3Aalice%40idp.example@identity"},
+			},
+			expGroups: []string{"users@"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var token *Token
+			if tc.sys != nil {
+				data, err := proto.Marshal(tc.sys)
+				if err != nil {
+					t.Fatal(err)
+				}
+				token = &Token{Flavor: Flavor_AUTH_SYS, Data: data, Class: tc.class}
+			}
+
+			withClaims, err := WithClaimPrincipals(token, tc.labels, tc.idGroups)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			if tc.unchanged {
+				test.AssertTrue(t, withClaims == token, "token was changed")
+				return
+			}
+
+			sys, err := sysFromToken(withClaims)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.sys.User, sys.User, "unexpected user")
+			test.CmpAny(t, "groups", tc.expGroups, sys.Groups)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ClassName returns the name of the credential class, as it appears in the
+// class claim, e.g. "machine".
+func ClassName(class CredentialClass) string {
+	return strings.ToLower(strings.TrimPrefix(class.String(), "CLASS_"))
+}
+
+// flavorClass returns the class of the identities asserted by credentials of
+// the flavor: node identities for AUTH_MACHINE, services acting for users for
+// AUTH_DELEGATION, and users for all others.
+func flavorClass(flavor Flavor) CredentialClass {
+	switch flavor {
+	case Flavor_AUTH_MACHINE:
+		return CredentialClass_CLASS_MACHINE
+	case Flavor_AUTH_DELEGATION:
+		return CredentialClass_CLASS_DELEGATED
+	default:
+		return CredentialClass_CLASS_USER
+	}
+}
+
+// stampClass records the class of the identity in the token, unless it is
+// already recorded.
+func stampClass(token *Token) {
+	if token.GetClass() == CredentialClass_CLASS_UNSPECIFIED {
+		token.Class = flavorClass(token.GetFlavor())
+	}
+}
+
+// ValidateTokenClass checks that the class recorded in the token is that of
+// the identities asserted by its flavor. A token which records no class, as
+// signed by agents which don't record it, is accepted. The class must have
+// been checked to be authentic, by verifying the credential, beforehand.
+func ValidateTokenClass(token *Token) error {
+	class := token.GetClass()
+	if class == CredentialClass_CLASS_UNSPECIFIED {
+		return nil
+	}
+	if _, found := CredentialClass_name[int32(class)]; !found {
+		return errors.Errorf("%s credential has unknown class %d", token.GetFlavor(), class)
+	}
+	if expClass := flavorClass(token.GetFlavor()); class != expClass {
+		return errors.Errorf("%s credential asserts class %s, not %s", token.GetFlavor(),
+			ClassName(class), ClassName(expClass))
+	}
+	return nil
+}
+
+// tokenClassClaim returns the claim for the class recorded in the token, or
+// nil if it records none.
+func tokenClassClaim(token *Token) *Claim {
+	if token.GetClass() == CredentialClass_CLASS_UNSPECIFIED {
+		return nil
+	}
+	return &Claim{Key: ClaimClass, Value: ClassName(token.GetClass())}
+}
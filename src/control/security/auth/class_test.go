@@ -0,0 +1,84 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestAuth_stampClass(t *testing.T) {
+	for name, tc := range map[string]struct {
+		token    *Token
+		expClass CredentialClass
+	}{
+		"user": {
+			token:    &Token{Flavor: Flavor_AUTH_SYS},
+			expClass: CredentialClass_CLASS_USER,
+		},
+		"plugin flavor": {
+			token:    &Token{Flavor: MinPluginFlavor},
+			expClass: CredentialClass_CLASS_USER,
+		},
+		"machine": {
+			token:    &Token{Flavor: Flavor_AUTH_MACHINE},
+			expClass: CredentialClass_CLASS_MACHINE,
+		},
+		"delegated": {
+			token:    &Token{Flavor: Flavor_AUTH_DELEGATION},
+			expClass: CredentialClass_CLASS_DELEGATED,
+		},
+		"already recorded": {
+			token:    &Token{Flavor: Flavor_AUTH_SYS, Class: CredentialClass_CLASS_MACHINE},
+			expClass: CredentialClass_CLASS_MACHINE,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			stampClass(tc.token)
+			test.AssertEqual(t, tc.expClass, tc.token.Class, "unexpected class")
+		})
+	}
+}
+
+func TestAuth_ValidateTokenClass(t *testing.T) {
+	for name, tc := range map[string]struct {
+		token  *Token
+		expErr error
+	}{
+		"not recorded": {
+			token: &Token{Flavor: Flavor_AUTH_MACHINE},
+		},
+		"user": {
+			token: &Token{Flavor: Flavor_AUTH_SYS, Class: CredentialClass_CLASS_USER},
+		},
+		"machine": {
+			token: &Token{Flavor: Flavor_AUTH_MACHINE, Class: CredentialClass_CLASS_MACHINE},
+		},
+		"delegated": {
+			token: &Token{Flavor: Flavor_AUTH_DELEGATION, Class: CredentialClass_CLASS_DELEGATED},
+		},
+		"user asserts machine": {
+			token:  &Token{Flavor: Flavor_AUTH_SYS, Class: CredentialClass_CLASS_MACHINE},
+			expErr: errors.New("AUTH_SYS credential asserts class machine, not user"),
+		},
+		"delegation asserts user": {
+			token:  &Token{Flavor: Flavor_AUTH_DELEGATION, Class: CredentialClass_CLASS_USER},
+			expErr: errors.New("asserts class user, not delegated"),
+		},
+		"unknown class": {
+			token:  &Token{Flavor: Flavor_AUTH_SYS, Class: CredentialClass(42)},
+			expErr: errors.New("unknown class 42"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, ValidateTokenClass(tc.token))
+		})
+	}
+}
@@ -0,0 +1,281 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	// DefaultCosignTimeout is the time allowed for the co-signing service to
+	// respond if no timeout is configured.
+	DefaultCosignTimeout = 10 * time.Second
+
+	maxCosignResponseSize = 1 << 20
+)
+
+type (
+	// cosignRequest is the payload POSTed to the co-signing service. The
+	// service verifies the agent's signature on the credential, and decides
+	// whether to co-sign it.
+	cosignRequest struct {
+		Credential []byte `json:"credential"`
+		System     string `json:"system"`
+	}
+
+	// cosignResponse is the co-signature returned by the co-signing service,
+	// as made by NewCosignature.
+	cosignResponse struct {
+		Cosignature []byte `json:"cosignature"`
+		KeyID       string `json:"key_id"`
+	}
+)
+
+// cosignedPrincipal qualifies the name of a user or group as a local
+// principal, unless it is already domain-qualified.
+func cosignedPrincipal(name string) string {
+	if strings.Contains(name, "@") {
+		return name
+	}
+	return sysNameToPrincipalName(name)
+}
+
+// cosignatureRequired returns true if the identity asserted by the token is
+// one of the privileged identities whose credentials must be co-signed.
+func cosignatureRequired(ids *security.CosignedIdentities, token *Token) (bool, error) {
+	if !ids.IsSet() {
+		return false, nil
+	}
+	sys, err := sysFromToken(token)
+	if err != nil {
+		return false, err
+	}
+
+	if ids.Machine && sys.GetMachine() {
+		return true, nil
+	}
+	for _, user := range ids.Users {
+		if cosignedPrincipal(user) == sys.GetUser() {
+			return true, nil
+		}
+	}
+	for _, group := range ids.Groups {
+		princ := cosignedPrincipal(group)
+		if princ == sys.GetGroup() || slices.Contains(sys.GetGroups(), princ) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// NewCosignature signs the token of the credential with a co-signing key, as
+// a co-signing service does once it has decided to co-sign the credential. The
+// co-signature and the ID of the key are returned.
+func NewCosignature(key crypto.PrivateKey, cred *Credential) (*Token, string, error) {
+	keyID, err := security.PrivateKeyID(key)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "unable to identify co-signing key")
+	}
+	cosig, err := newVerifier(key, cred.GetToken())
+	if err != nil {
+		return nil, "", err
+	}
+	return cosig, keyID, nil
+}
+
+// Cosigner asks the co-signing service to co-sign privileged credentials.
+type Cosigner struct {
+	url     string
+	system  string
+	timeout time.Duration
+	ids     security.CosignedIdentities
+	client  *http.Client
+}
+
+// NewCosigner creates a Cosigner for the configured co-signing service, for
+// the credentials of the system. If no credentials are to be co-signed, nil is
+// returned, which co-signs nothing.
+func NewCosigner(cfg security.CosigningConfig, system string) (*Cosigner, error) {
+	if !cfg.IsSet() {
+		return nil, nil
+	}
+
+	tlsCfg, err := cfg.TLSConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "loading co-signing service certificates")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultCosignTimeout
+	}
+	return &Cosigner{
+		url:     cfg.URL,
+		system:  system,
+		timeout: timeout,
+		ids:     cfg.CosignedIdentities,
+		client: &http.Client{
+			Transport: transport,
+			// A redirect would send the credential somewhere unexpected.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}, nil
+}
+
+// Cosign has the co-signing service co-sign the credential, if it is
+// privileged. Other credentials are left as they are.
+func (c *Cosigner) Cosign(ctx context.Context, cred *Credential) error {
+	if c == nil {
+		return nil
+	}
+	required, err := cosignatureRequired(&c.ids, cred.GetToken())
+	if err != nil || !required {
+		return err
+	}
+
+	credBytes, err := proto.Marshal(cred)
+	if err != nil {
+		return errors.Wrap(err, "marshaling credential")
+	}
+	payload, err := json.Marshal(&cosignRequest{Credential: credBytes, System: c.system})
+	if err != nil {
+		return errors.Wrap(err, "encoding co-signing request")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "creating co-signing request")
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return errors.Wrapf(err, "co-signing service %q", c.url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("co-signing service %q: unexpected status code %d", c.url, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCosignResponseSize))
+	if err != nil {
+		return errors.Wrap(err, "reading co-signing response")
+	}
+	cosignResp := &cosignResponse{}
+	if err := json.Unmarshal(body, cosignResp); err != nil {
+		return errors.Wrapf(err, "parsing response of co-signing service %q", c.url)
+	}
+	cosig := &Token{}
+	if err := proto.Unmarshal(cosignResp.Cosignature, cosig); err != nil {
+		return errors.Wrapf(err, "co-signing service %q returned a malformed co-signature", c.url)
+	}
+	keyID, err := ParseKeyID(cosignResp.KeyID)
+	if err != nil {
+		return errors.Wrapf(err, "co-signing service %q", c.url)
+	}
+
+	cred.Cosignature = cosig
+	cred.CosignerKeyId = keyID
+	return nil
+}
+
+// CosignatureVerifier checks that privileged credentials have been co-signed
+// by one of the co-signing keys.
+type CosignatureVerifier struct {
+	ids  security.CosignedIdentities
+	keys map[string]crypto.PublicKey
+}
+
+// NewCosignatureVerifier loads the certificates of the co-signing keys. If no
+// credentials must be co-signed, nil is returned, which requires nothing.
+func NewCosignatureVerifier(cfg *security.CosignerConfig) (*CosignatureVerifier, error) {
+	if cfg == nil || !cfg.IsSet() {
+		return nil, nil
+	}
+
+	cv := &CosignatureVerifier{
+		ids:  cfg.CosignedIdentities,
+		keys: make(map[string]crypto.PublicKey),
+	}
+	for _, path := range cfg.CosignerCerts {
+		cert, err := security.LoadCertificate(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "loading co-signer certificate %s", path)
+		}
+		keyID, err := security.PublicKeyID(cert.PublicKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to identify key in %s", path)
+		}
+		cv.keys[keyID] = cert.PublicKey
+	}
+	return cv, nil
+}
+
+// KeyIDs returns the IDs of the co-signing keys.
+func (cv *CosignatureVerifier) KeyIDs() []string {
+	if cv == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(cv.keys))
+	for id := range cv.keys {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// Verify checks that the credential has been co-signed by one of the
+// co-signing keys, if it is privileged. The co-signing key must not be the key
+// which signed the credential, so that two independent keys have signed it.
+func (cv *CosignatureVerifier) Verify(cred *Credential) error {
+	if cv == nil {
+		return nil
+	}
+	required, err := cosignatureRequired(&cv.ids, cred.GetToken())
+	if err != nil || !required {
+		return err
+	}
+
+	if cred.GetCosignature() == nil {
+		return errors.New("privileged credential has not been co-signed")
+	}
+	keyID := cred.GetCosignerKeyId()
+	if keyID == cred.GetKeyId() {
+		return errors.Errorf("privileged credential was co-signed by its own signing key %s", keyID)
+	}
+	key, found := cv.keys[keyID]
+	if !found {
+		return errors.Errorf("privileged credential was co-signed by unknown key %q", keyID)
+	}
+
+	cosig := cred.GetCosignature()
+	if err := verifyToken(key, cred.GetToken(), cosig.GetData(), cosig.GetHash()); err != nil {
+		return errors.Wrap(err, "co-signature verification failed")
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func testCosignKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID, err := security.PrivateKeyID(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, keyID
+}
+
+func TestAuth_cosignatureRequired(t *testing.T) {
+	ids := &security.CosignedIdentities{
+		Users:  []string{"root", "admin@azure"},
+		Groups: []string{"wheel"},
+	}
+
+	for name, tc := range map[string]struct {
+		ids    *security.CosignedIdentities
+		sys    *Sys
+		expReq bool
+	}{
+		"nothing co-signed": {
+			ids: &security.CosignedIdentities{},
+			sys: &Sys{User: "root@"},
+		},
+		"unprivileged user": {
+			ids: ids,
+			sys: &Sys{User: "user@", Group: "users@"},
+		},
+		"local user": {
+			ids:    ids,
+			sys:    &Sys{User: "root@"},
+			expReq: true,
+		},
+		"qualified user": {
+			ids:    ids,
+			sys:    &Sys{User: "admin@azure"},
+			expReq: true,
+		},
+		"primary group": {
+			ids:    ids,
+			sys:    &Sys{User: "user@", Group: "wheel@"},
+			expReq: true,
+		},
+		"secondary group": {
+			ids:    ids,
+			sys:    &Sys{User: "user@", Group: "users@", Groups: []string{"wheel@"}},
+			expReq: true,
+		},
+		"machine not co-signed": {
+			ids: ids,
+			sys: &Sys{User: "machine:node1@", Machine: true},
+		},
+		"machine": {
+			ids:    &security.CosignedIdentities{Machine: true},
+			sys:    &Sys{User: "machine:node1@", Machine: true},
+			expReq: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := proto.Marshal(tc.sys)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			required, err := cosignatureRequired(tc.ids, &Token{Flavor: Flavor_AUTH_SYS, Data: data})
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expReq, required, "unexpected result")
+		})
+	}
+}
+
+func TestAuth_Cosign(t *testing.T) {
+	agentKey, agentKeyID := testCosignKey(t)
+	officerKey, officerKeyID := testCosignKey(t)
+	otherKey, otherKeyID := testCosignKey(t)
+	ids := security.CosignedIdentities{Users: []string{"root"}}
+
+	for name, tc := range map[string]struct {
+		user         string
+		status       int
+		cosignKey    crypto.PrivateKey
+		expErr       error
+		expCosigned  bool
+		expVerifyErr error
+	}{
+		"unprivileged": {
+			user: "user",
+		},
+		"co-signed": {
+			user:        "root",
+			cosignKey:   officerKey,
+			expCosigned: true,
+		},
+		"refused": {
+			user:   "root",
+			status: http.StatusForbidden,
+			expErr: errors.New("unexpected status code 403"),
+		},
+		"co-signed by unknown key": {
+			user:         "root",
+			cosignKey:    otherKey,
+			expCosigned:  true,
+			expVerifyErr: errors.Errorf("co-signed by unknown key %q", otherKeyID),
+		},
+		"co-signed by agent key": {
+			user:         "root",
+			cosignKey:    agentKey,
+			expCosigned:  true,
+			expVerifyErr: errors.New("co-signed by its own signing key"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.status != 0 {
+					w.WriteHeader(tc.status)
+					return
+				}
+				req := &cosignRequest{}
+				if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+					t.Error(err)
+					return
+				}
+				test.AssertEqual(t, "daos_server", req.System, "unexpected system")
+				cred := &Credential{}
+				if err := proto.Unmarshal(req.Credential, cred); err != nil {
+					t.Error(err)
+					return
+				}
+				cosig, keyID, err := NewCosignature(tc.cosignKey, cred)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				cosigBytes, err := proto.Marshal(cosig)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if err := json.NewEncoder(w).Encode(&cosignResponse{Cosignature: cosigBytes, KeyID: keyID}); err != nil {
+					t.Error(err)
+					return
+				}
+			}))
+			defer srv.Close()
+
+			cosigner := &Cosigner{
+				url:     srv.URL,
+				system:  "daos_server",
+				timeout: time.Second,
+				ids:     ids,
+				client:  srv.Client(),
+			}
+			cred, err := newSignedCredential(Flavor_AUTH_SYS, agentKey, &Sys{User: tc.user + "@"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, agentKeyID, cred.KeyId, "unexpected key ID")
+
+			err = cosigner.Cosign(context.Background(), cred)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertEqual(t, tc.expCosigned, cred.Cosignature != nil, "unexpected co-signature")
+
+			verifier := &CosignatureVerifier{
+				ids:  ids,
+				keys: map[string]crypto.PublicKey{officerKeyID: officerKey.Public(), agentKeyID: agentKey.Public()},
+			}
+			test.CmpErr(t, tc.expVerifyErr, verifier.Verify(cred))
+		})
+	}
+}
+
+func TestAuth_CosignatureVerifier_Verify(t *testing.T) {
+	agentKey, _ := testCosignKey(t)
+	officerKey, officerKeyID := testCosignKey(t)
+	verifier := &CosignatureVerifier{
+		ids:  security.CosignedIdentities{Users: []string{"root"}},
+		keys: map[string]crypto.PublicKey{officerKeyID: officerKey.Public()},
+	}
+
+	for name, tc := range map[string]struct {
+		modify func(*Credential)
+		expErr error
+	}{
+		"not co-signed": {
+			modify: func(cred *Credential) {
+				cred.Cosignature = nil
+				cred.CosignerKeyId = ""
+			},
+			expErr: errors.New("has not been co-signed"),
+		},
+		"co-signed": {
+			modify: func(*Credential) {},
+		},
+		"token modified after co-signing": {
+			modify: func(cred *Credential) {
+				data, err := proto.Marshal(&Sys{User: "root@", Groups: []string{"wheel@"}})
+				if err != nil {
+					t.Fatal(err)
+				}
+				cred.Token.Data = data
+			},
+			expErr: errors.New("co-signature verification failed"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cred, err := newSignedCredential(Flavor_AUTH_SYS, agentKey, &Sys{User: "root@"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cred.Cosignature, cred.CosignerKeyId, err = NewCosignature(officerKey, cred); err != nil {
+				t.Fatal(err)
+			}
+			tc.modify(cred)
+
+			test.CmpErr(t, tc.expErr, verifier.Verify(cred))
+		})
+	}
+
+	var nilVerifier *CosignatureVerifier
+	test.CmpErr(t, nil, nilVerifier.Verify(&Credential{}))
+}
@@ -0,0 +1,698 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// This file implements the small subset of the D-Bus wire protocol needed to
+// call methods on system services: authentication as the connecting user,
+// method calls and their replies. Values are represented as follows:
+//
+//	y, b, n, q, i, u, x, t, d: uint8, bool, int16, uint16, int32, uint32, int64, uint64, float64
+//	s, o, g:                   string
+//	a{..}:                     map[interface{}]interface{}
+//	a.., (..):                 []interface{}
+//	v:                         dbusVariant
+
+// DefaultSystemBusAddress is the path of the system bus socket.
+const DefaultSystemBusAddress = "/var/run/dbus/system_bus_socket"
+
+const (
+	dbusMethodCall   = 1
+	dbusMethodReturn = 2
+	dbusError        = 3
+
+	dbusFieldPath        = 1
+	dbusFieldInterface   = 2
+	dbusFieldMember      = 3
+	dbusFieldErrorName   = 4
+	dbusFieldReplySerial = 5
+	dbusFieldDestination = 6
+	dbusFieldSignature   = 8
+
+	// maxDBusMessageSize is the maximum message size allowed by the specification.
+	maxDBusMessageSize = 1 << 27
+)
+
+type (
+	// dbusVariant is a value along with the signature of its type.
+	dbusVariant struct {
+		Sig   string
+		Value interface{}
+	}
+
+	// dbusMessage is a D-Bus message and its header fields.
+	dbusMessage struct {
+		Type        byte
+		Serial      uint32
+		Path        string
+		Interface   string
+		Member      string
+		ErrorName   string
+		ReplySerial uint32
+		Destination string
+		Signature   string
+		Body        []interface{}
+	}
+
+	// dbusConn is a connection to a message bus.
+	dbusConn struct {
+		conn   net.Conn
+		reader *bufio.Reader
+		serial uint32
+	}
+
+	dbusEncoder struct {
+		buf bytes.Buffer
+	}
+
+	dbusDecoder struct {
+		data []byte
+		pos  int
+	}
+)
+
+// nextDBusType splits the first complete type from the signature.
+func nextDBusType(sig string) (string, string, error) {
+	if sig == "" {
+		return "", "", errors.New("empty D-Bus signature")
+	}
+	switch sig[0] {
+	case 'y', 'b', 'n', 'q', 'i', 'u', 'x', 't', 'd', 's', 'o', 'g', 'v':
+		return sig[:1], sig[1:], nil
+	case 'a':
+		elem, rest, err := nextDBusType(sig[1:])
+		if err != nil {
+			return "", "", err
+		}
+		return "a" + elem, rest, nil
+	case '(', '{':
+		closer := byte(')')
+		if sig[0] == '{' {
+			closer = '}'
+		}
+		rest := sig[1:]
+		for {
+			if rest == "" {
+				return "", "", errors.Errorf("unterminated D-Bus signature %q", sig)
+			}
+			if rest[0] == closer {
+				n := len(sig) - len(rest) + 1
+				return sig[:n], sig[n:], nil
+			}
+			var err error
+			if _, rest, err = nextDBusType(rest); err != nil {
+				return "", "", err
+			}
+		}
+	default:
+		return "", "", errors.Errorf("unsupported D-Bus type %q", sig[0])
+	}
+}
+
+// splitDBusSignature splits a signature into its complete types.
+func splitDBusSignature(sig string) ([]string, error) {
+	var types []string
+	for sig != "" {
+		t, rest, err := nextDBusType(sig)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+		sig = rest
+	}
+	return types, nil
+}
+
+func dbusAlignment(sig string) int {
+	switch sig[0] {
+	case 'y', 'g', 'v':
+		return 1
+	case 'n', 'q':
+		return 2
+	case 'x', 't', 'd', '(', '{':
+		return 8
+	default:
+		return 4
+	}
+}
+
+func (e *dbusEncoder) align(n int) {
+	for e.buf.Len()%n != 0 {
+		e.buf.WriteByte(0)
+	}
+}
+
+func (e *dbusEncoder) uint32(v uint32) {
+	e.align(4)
+	e.buf.Write(binary.LittleEndian.AppendUint32(nil, v))
+}
+
+func (e *dbusEncoder) encode(sig string, value interface{}) error {
+	e.align(dbusAlignment(sig))
+
+	mismatch := errors.Errorf("cannot encode %T as D-Bus type %q", value, sig)
+	switch sig[0] {
+	case 'y':
+		v, ok := value.(uint8)
+		if !ok {
+			return mismatch
+		}
+		e.buf.WriteByte(v)
+	case 'b':
+		v, ok := value.(bool)
+		if !ok {
+			return mismatch
+		}
+		var b uint32
+		if v {
+			b = 1
+		}
+		e.uint32(b)
+	case 'n', 'q':
+		var v uint16
+		switch n := value.(type) {
+		case int16:
+			v = uint16(n)
+		case uint16:
+			v = n
+		default:
+			return mismatch
+		}
+		e.buf.Write(binary.LittleEndian.AppendUint16(nil, v))
+	case 'i', 'u':
+		var v uint32
+		switch n := value.(type) {
+		case int32:
+			v = uint32(n)
+		case uint32:
+			v = n
+		default:
+			return mismatch
+		}
+		e.uint32(v)
+	case 'x', 't', 'd':
+		var v uint64
+		switch n := value.(type) {
+		case int64:
+			v = uint64(n)
+		case uint64:
+			v = n
+		case float64:
+			v = math.Float64bits(n)
+		default:
+			return mismatch
+		}
+		e.buf.Write(binary.LittleEndian.AppendUint64(nil, v))
+	case 's', 'o':
+		v, ok := value.(string)
+		if !ok {
+			return mismatch
+		}
+		e.uint32(uint32(len(v)))
+		e.buf.WriteString(v)
+		e.buf.WriteByte(0)
+	case 'g':
+		v, ok := value.(string)
+		if !ok || len(v) > 255 {
+			return mismatch
+		}
+		e.buf.WriteByte(byte(len(v)))
+		e.buf.WriteString(v)
+		e.buf.WriteByte(0)
+	case 'v':
+		v, ok := value.(dbusVariant)
+		if !ok {
+			return mismatch
+		}
+		if err := e.encode("g", v.Sig); err != nil {
+			return err
+		}
+		return e.encode(v.Sig, v.Value)
+	case 'a':
+		return e.encodeArray(sig[1:], value)
+	case '(', '{':
+		fields, err := splitDBusSignature(sig[1 : len(sig)-1])
+		if err != nil {
+			return err
+		}
+		v, ok := value.([]interface{})
+		if !ok || len(v) != len(fields) {
+			return mismatch
+		}
+		for i, field := range fields {
+			if err := e.encode(field, v[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *dbusEncoder) encodeArray(elem string, value interface{}) error {
+	e.uint32(0)
+	lenPos := e.buf.Len() - 4
+	e.align(dbusAlignment(elem))
+	start := e.buf.Len()
+
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if err := e.encode(elem, item); err != nil {
+				return err
+			}
+		}
+	case []string:
+		for _, item := range v {
+			if err := e.encode(elem, item); err != nil {
+				return err
+			}
+		}
+	case map[interface{}]interface{}:
+		if elem[0] != '{' {
+			return errors.Errorf("cannot encode map as D-Bus type a%s", elem)
+		}
+		for k, item := range v {
+			if err := e.encode(elem, []interface{}{k, item}); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.Errorf("cannot encode %T as D-Bus type a%s", value, elem)
+	}
+
+	binary.LittleEndian.PutUint32(e.buf.Bytes()[lenPos:], uint32(e.buf.Len()-start))
+	return nil
+}
+
+func (d *dbusDecoder) align(n int) error {
+	for d.pos%n != 0 {
+		if d.pos >= len(d.data) {
+			return io.ErrUnexpectedEOF
+		}
+		d.pos++
+	}
+	return nil
+}
+
+func (d *dbusDecoder) take(n int) ([]byte, error) {
+	if n < 0 || d.pos+n > len(d.data) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *dbusDecoder) uint32() (uint32, error) {
+	if err := d.align(4); err != nil {
+		return 0, err
+	}
+	b, err := d.take(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func (d *dbusDecoder) decode(sig string) (interface{}, error) {
+	if err := d.align(dbusAlignment(sig)); err != nil {
+		return nil, err
+	}
+
+	switch sig[0] {
+	case 'y':
+		b, err := d.take(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0], nil
+	case 'b':
+		v, err := d.uint32()
+		return v != 0, err
+	case 'n', 'q':
+		b, err := d.take(2)
+		if err != nil {
+			return nil, err
+		}
+		v := binary.LittleEndian.Uint16(b)
+		if sig[0] == 'n' {
+			return int16(v), nil
+		}
+		return v, nil
+	case 'i':
+		v, err := d.uint32()
+		return int32(v), err
+	case 'u':
+		return d.uint32()
+	case 'x', 't', 'd':
+		b, err := d.take(8)
+		if err != nil {
+			return nil, err
+		}
+		v := binary.LittleEndian.Uint64(b)
+		switch sig[0] {
+		case 'x':
+			return int64(v), nil
+		case 'd':
+			return math.Float64frombits(v), nil
+		}
+		return v, nil
+	case 's', 'o':
+		n, err := d.uint32()
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.take(int(n) + 1)
+		if err != nil {
+			return nil, err
+		}
+		return string(b[:n]), nil
+	case 'g':
+		n, err := d.take(1)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.take(int(n[0]) + 1)
+		if err != nil {
+			return nil, err
+		}
+		return string(b[:n[0]]), nil
+	case 'v':
+		vsig, err := d.decode("g")
+		if err != nil {
+			return nil, err
+		}
+		if t, rest, err := nextDBusType(vsig.(string)); err != nil || rest != "" || t == "" {
+			return nil, errors.Errorf("invalid D-Bus variant signature %q", vsig)
+		}
+		value, err := d.decode(vsig.(string))
+		return dbusVariant{Sig: vsig.(string), Value: value}, err
+	case 'a':
+		return d.decodeArray(sig[1:])
+	case '(', '{':
+		fields, err := splitDBusSignature(sig[1 : len(sig)-1])
+		if err != nil {
+			return nil, err
+		}
+		out := make([]interface{}, 0, len(fields))
+		for _, field := range fields {
+			v, err := d.decode(field)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	}
+	return nil, errors.Errorf("unsupported D-Bus type %q", sig)
+}
+
+func (d *dbusDecoder) decodeArray(elem string) (interface{}, error) {
+	n, err := d.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if err := d.align(dbusAlignment(elem)); err != nil {
+		return nil, err
+	}
+	end := d.pos + int(n)
+	if end > len(d.data) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	if elem[0] == '{' {
+		out := make(map[interface{}]interface{})
+		for d.pos < end {
+			entry, err := d.decode(elem)
+			if err != nil {
+				return nil, err
+			}
+			kv := entry.([]interface{})
+			out[kv[0]] = kv[1]
+		}
+		return out, nil
+	}
+
+	out := []interface{}{}
+	for d.pos < end {
+		v, err := d.decode(elem)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// marshal encodes the message, which must have its Signature set to that of
+// its body.
+func (m *dbusMessage) marshal() ([]byte, error) {
+	body := &dbusEncoder{}
+	types, err := splitDBusSignature(m.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if len(types) != len(m.Body) {
+		return nil, errors.Errorf("D-Bus signature %q does not match %d values", m.Signature, len(m.Body))
+	}
+	for i, t := range types {
+		if err := body.encode(t, m.Body[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	var fields []interface{}
+	addField := func(code byte, sig string, value interface{}) {
+		fields = append(fields, []interface{}{code, dbusVariant{Sig: sig, Value: value}})
+	}
+	if m.Path != "" {
+		addField(dbusFieldPath, "o", m.Path)
+	}
+	if m.Interface != "" {
+		addField(dbusFieldInterface, "s", m.Interface)
+	}
+	if m.Member != "" {
+		addField(dbusFieldMember, "s", m.Member)
+	}
+	if m.ErrorName != "" {
+		addField(dbusFieldErrorName, "s", m.ErrorName)
+	}
+	if m.ReplySerial != 0 {
+		addField(dbusFieldReplySerial, "u", m.ReplySerial)
+	}
+	if m.Destination != "" {
+		addField(dbusFieldDestination, "s", m.Destination)
+	}
+	if m.Signature != "" {
+		addField(dbusFieldSignature, "g", m.Signature)
+	}
+
+	msg := &dbusEncoder{}
+	msg.buf.Write([]byte{'l', m.Type, 0, 1})
+	msg.uint32(uint32(body.buf.Len()))
+	msg.uint32(m.Serial)
+	if err := msg.encode("a(yv)", fields); err != nil {
+		return nil, err
+	}
+	msg.align(8)
+	msg.buf.Write(body.buf.Bytes())
+
+	return msg.buf.Bytes(), nil
+}
+
+// readDBusMessage reads and decodes a message.
+func readDBusMessage(r io.Reader) (*dbusMessage, error) {
+	fixed := make([]byte, 16)
+	if _, err := io.ReadFull(r, fixed); err != nil {
+		return nil, err
+	}
+	if fixed[0] != 'l' {
+		return nil, errors.New("big-endian D-Bus messages are not supported")
+	}
+	bodyLen := binary.LittleEndian.Uint32(fixed[4:])
+	fieldsLen := binary.LittleEndian.Uint32(fixed[12:])
+	headerLen := 16 + int(fieldsLen)
+	headerLen += (8 - headerLen%8) % 8
+	if uint64(headerLen)+uint64(bodyLen) > maxDBusMessageSize {
+		return nil, errors.New("D-Bus message is too large")
+	}
+
+	data := make([]byte, headerLen+int(bodyLen))
+	copy(data, fixed)
+	if _, err := io.ReadFull(r, data[16:]); err != nil {
+		return nil, err
+	}
+
+	dec := &dbusDecoder{data: data[:headerLen], pos: 12}
+	rawFields, err := dec.decode("a(yv)")
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding D-Bus message header")
+	}
+	m := &dbusMessage{
+		Type:   fixed[1],
+		Serial: binary.LittleEndian.Uint32(fixed[8:]),
+	}
+	for _, f := range rawFields.([]interface{}) {
+		field := f.([]interface{})
+		value := field[1].(dbusVariant).Value
+		var ok bool
+		switch field[0].(uint8) {
+		case dbusFieldPath:
+			m.Path, ok = value.(string)
+		case dbusFieldInterface:
+			m.Interface, ok = value.(string)
+		case dbusFieldMember:
+			m.Member, ok = value.(string)
+		case dbusFieldErrorName:
+			m.ErrorName, ok = value.(string)
+		case dbusFieldReplySerial:
+			m.ReplySerial, ok = value.(uint32)
+		case dbusFieldDestination:
+			m.Destination, ok = value.(string)
+		case dbusFieldSignature:
+			m.Signature, ok = value.(string)
+		default:
+			ok = true
+		}
+		if !ok {
+			return nil, errors.Errorf("D-Bus header field %d has unexpected type %T", field[0], value)
+		}
+	}
+
+	types, err := splitDBusSignature(m.Signature)
+	if err != nil {
+		return nil, err
+	}
+	dec = &dbusDecoder{data: data[headerLen:]}
+	for _, t := range types {
+		v, err := dec.decode(t)
+		if err != nil {
+			return nil, errors.Wrap(err, "decoding D-Bus message body")
+		}
+		m.Body = append(m.Body, v)
+	}
+
+	return m, nil
+}
+
+// dialDBus connects to the bus at the socket path and authenticates as the
+// agent's user.
+func dialDBus(ctx context.Context, path string) (*dbusConn, error) {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "unix", path)
+	if err != nil {
+		return nil, errors.Wrap(err, "connecting to D-Bus")
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	c := &dbusConn{conn: conn, reader: bufio.NewReader(conn)}
+	if err := c.authenticate(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if _, err := c.Call(ctx, "org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "Hello", ""); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// authenticate uses the EXTERNAL mechanism, which authenticates the agent by
+// the credentials of its connection.
+func (c *dbusConn) authenticate() error {
+	uid := hex.EncodeToString([]byte(fmt.Sprint(os.Geteuid())))
+	if _, err := fmt.Fprintf(c.conn, "\x00AUTH EXTERNAL %s\r\n", uid); err != nil {
+		return errors.Wrap(err, "D-Bus authentication")
+	}
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return errors.Wrap(err, "D-Bus authentication")
+	}
+	if !strings.HasPrefix(line, "OK ") {
+		return errors.Errorf("D-Bus authentication rejected: %s", strings.TrimSpace(line))
+	}
+	if _, err := io.WriteString(c.conn, "BEGIN\r\n"); err != nil {
+		return errors.Wrap(err, "D-Bus authentication")
+	}
+	return nil
+}
+
+// Call calls a method and returns the values it returns.
+func (c *dbusConn) Call(ctx context.Context, dest, path, iface, member, sig string, args ...interface{}) ([]interface{}, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := c.conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	} else if err := c.conn.SetDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+
+	c.serial++
+	call := &dbusMessage{
+		Type:        dbusMethodCall,
+		Serial:      c.serial,
+		Path:        path,
+		Interface:   iface,
+		Member:      member,
+		Destination: dest,
+		Signature:   sig,
+		Body:        args,
+	}
+	data, err := call.marshal()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.conn.Write(data); err != nil {
+		return nil, errors.Wrapf(err, "calling %s.%s", iface, member)
+	}
+
+	for {
+		reply, err := readDBusMessage(c.reader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading reply to %s.%s", iface, member)
+		}
+		if reply.ReplySerial != call.Serial {
+			// Signals and other traffic are not of interest.
+			continue
+		}
+		switch reply.Type {
+		case dbusMethodReturn:
+			return reply.Body, nil
+		case dbusError:
+			msg := reply.ErrorName
+			if len(reply.Body) > 0 {
+				msg = fmt.Sprintf("%s: %v", reply.ErrorName, reply.Body[0])
+			}
+			return nil, errors.Errorf("%s.%s failed: %s", iface, member, msg)
+		}
+	}
+}
+
+// Close closes the connection.
+func (c *dbusConn) Close() error {
+	return c.conn.Close()
+}
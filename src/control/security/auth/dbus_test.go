@@ -0,0 +1,238 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+// fakeDBusReply is the reply of a fake bus to a method call. If ErrName is
+// set, an error is returned with Body as its arguments.
+type fakeDBusReply struct {
+	Sig     string
+	Body    []interface{}
+	ErrName string
+}
+
+// startFakeDBus starts a bus on a socket in a temporary directory, which
+// replies to method calls other than Hello using the handler. Each reply is
+// preceded by an unrelated signal.
+func startFakeDBus(t *testing.T, handler func(call *dbusMessage) fakeDBusReply) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "bus")
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeDBus(conn, handler)
+		}
+	}()
+
+	return path
+}
+
+func serveFakeDBus(conn net.Conn, handler func(call *dbusMessage) fakeDBusReply) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	if b, err := r.ReadByte(); err != nil || b != 0 {
+		return
+	}
+	line, err := r.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "AUTH EXTERNAL ") {
+		io.WriteString(conn, "REJECTED EXTERNAL\r\n")
+		return
+	}
+	io.WriteString(conn, "OK 0123456789abcdef\r\n")
+	if line, err := r.ReadString('\n'); err != nil || line != "BEGIN\r\n" {
+		return
+	}
+
+	var serial uint32
+	send := func(m *dbusMessage) bool {
+		serial++
+		m.Serial = serial
+		data, err := m.marshal()
+		if err != nil {
+			return false
+		}
+		_, err = conn.Write(data)
+		return err == nil
+	}
+
+	for {
+		call, err := readDBusMessage(r)
+		if err != nil {
+			return
+		}
+
+		reply := fakeDBusReply{Sig: "s", Body: []interface{}{":1.1"}}
+		if call.Member != "Hello" {
+			reply = handler(call)
+		}
+
+		signal := &dbusMessage{
+			Type:      4,
+			Path:      "/org/freedesktop/DBus",
+			Interface: "org.freedesktop.DBus",
+			Member:    "NameAcquired",
+			Signature: "s",
+			Body:      []interface{}{":1.1"},
+		}
+		msg := &dbusMessage{
+			Type:        dbusMethodReturn,
+			ReplySerial: call.Serial,
+			Signature:   reply.Sig,
+			Body:        reply.Body,
+		}
+		if reply.ErrName != "" {
+			msg.Type = dbusError
+			msg.ErrorName = reply.ErrName
+		}
+		if !send(signal) || !send(msg) {
+			return
+		}
+	}
+}
+
+func TestAuth_dbusMessage_RoundTrip(t *testing.T) {
+	msg := &dbusMessage{
+		Type:        dbusMethodCall,
+		Serial:      7,
+		Path:        "/org/example/Object",
+		Interface:   "org.example.Iface",
+		Member:      "Method",
+		Destination: "org.example",
+		Signature:   "ya{sv}as(bnqix)tdgo",
+		Body: []interface{}{
+			uint8(3),
+			map[interface{}]interface{}{
+				"name":  dbusVariant{Sig: "s", Value: "jdoe"},
+				"gid":   dbusVariant{Sig: "u", Value: uint32(5000)},
+				"names": dbusVariant{Sig: "as", Value: []interface{}{"a", "b"}},
+			},
+			[]string{"x", "", "yz"},
+			[]interface{}{true, int16(-2), uint16(2), int32(-3), int64(-4)},
+			uint64(1 << 40),
+			float64(1.5),
+			"sas",
+			"/",
+		},
+	}
+
+	data, err := msg.marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := readDBusMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// String arrays are decoded generically.
+	msg.Body[2] = []interface{}{"x", "", "yz"}
+	test.CmpAny(t, "message", msg, got)
+}
+
+func TestAuth_readDBusMessage_Invalid(t *testing.T) {
+	msg := &dbusMessage{
+		Type:      dbusMethodReturn,
+		Serial:    1,
+		Signature: "s",
+		Body:      []interface{}{"hello"},
+	}
+	data, err := msg.marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		data   []byte
+		expErr error
+	}{
+		"truncated": {
+			data:   data[:len(data)-3],
+			expErr: io.ErrUnexpectedEOF,
+		},
+		"big-endian": {
+			data:   append([]byte{'B'}, data[1:]...),
+			expErr: errors.New("big-endian"),
+		},
+		"too large": {
+			data:   append(append([]byte{}, data[:4]...), append([]byte{0xff, 0xff, 0xff, 0xff}, data[8:]...)...),
+			expErr: errors.New("too large"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := readDBusMessage(bytes.NewReader(tc.data))
+			test.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestAuth_dbusConn_Call(t *testing.T) {
+	path := startFakeDBus(t, func(call *dbusMessage) fakeDBusReply {
+		if call.Member == "Echo" {
+			return fakeDBusReply{Sig: call.Signature, Body: call.Body}
+		}
+		return fakeDBusReply{
+			Sig:     "s",
+			Body:    []interface{}{"no such method"},
+			ErrName: "org.freedesktop.DBus.Error.UnknownMethod",
+		}
+	})
+
+	for name, tc := range map[string]struct {
+		member  string
+		expBody []interface{}
+		expErr  error
+	}{
+		"success": {
+			member:  "Echo",
+			expBody: []interface{}{"hello", uint32(42)},
+		},
+		"error reply": {
+			member: "Missing",
+			expErr: errors.New("UnknownMethod: no such method"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := test.Context(t)
+			conn, err := dialDBus(ctx, path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			body, err := conn.Call(ctx, "org.example", "/org/example", "org.example.Iface", tc.member, "su", "hello", uint32(42))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.CmpAny(t, "reply", tc.expBody, body)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"maps"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// AgentEnrollmentList is the list of agent hosts enrolled throughout the
+// system, each with the ID of the key it signs credentials with. It is stored
+// by the management service, and each server checks that the credentials
+// presented by clients on an enrolled host were signed by its key, rather
+// than by any key trusted by the server, against the latest version it has
+// fetched.
+type AgentEnrollmentList struct {
+	Version uint64            `json:"version"`
+	KeyIDs  map[string]string `json:"key_ids,omitempty"`
+}
+
+// Enroll enrolls the agent host with the key, replacing any key it was
+// enrolled with. It returns true, and increments the version of the list, if
+// the list was changed.
+func (el *AgentEnrollmentList) Enroll(host, keyID string) (bool, error) {
+	if el == nil {
+		return false, errors.New("nil agent enrollment list")
+	}
+
+	host, err := parseAgentHost(host)
+	if err != nil {
+		return false, err
+	}
+	keyID, err = ParseKeyID(keyID)
+	if err != nil {
+		return false, err
+	}
+
+	if el.KeyIDs[host] == keyID {
+		return false, nil
+	}
+	if el.KeyIDs == nil {
+		el.KeyIDs = make(map[string]string)
+	}
+	el.KeyIDs[host] = keyID
+	el.Version++
+	return true, nil
+}
+
+// Unenroll removes the agent host from the list. It returns true, and
+// increments the version of the list, if the host was enrolled.
+func (el *AgentEnrollmentList) Unenroll(host string) (bool, error) {
+	if el == nil {
+		return false, errors.New("nil agent enrollment list")
+	}
+
+	host, err := parseAgentHost(host)
+	if err != nil {
+		return false, err
+	}
+
+	if _, found := el.KeyIDs[host]; !found {
+		return false, nil
+	}
+	delete(el.KeyIDs, host)
+	el.Version++
+	return true, nil
+}
+
+// AgentEnrollments is the list of enrolled agent hosts which a server checks
+// the credentials presented by clients against.
+type AgentEnrollments struct {
+	sync.RWMutex
+	version  uint64
+	keys     map[string]string
+	required bool
+}
+
+// NewAgentEnrollments returns an empty set of enrollments. If required is set,
+// credentials from hosts which are not enrolled are rejected.
+func NewAgentEnrollments(required bool) *AgentEnrollments {
+	return &AgentEnrollments{required: required}
+}
+
+// Update replaces the enrollments with the list, if it is newer. It returns
+// true if they were replaced.
+func (ae *AgentEnrollments) Update(list *AgentEnrollmentList) bool {
+	if ae == nil || list == nil {
+		return false
+	}
+
+	ae.Lock()
+	defer ae.Unlock()
+
+	if list.Version <= ae.version {
+		return false
+	}
+	ae.version = list.Version
+	ae.keys = maps.Clone(list.KeyIDs)
+	return true
+}
+
+// Version returns the version of the enrollments last installed.
+func (ae *AgentEnrollments) Version() uint64 {
+	if ae == nil {
+		return 0
+	}
+
+	ae.RLock()
+	defer ae.RUnlock()
+
+	return ae.version
+}
+
+// Host returns the agent host enrolled with the key, or an empty string if no
+// host is.
+func (ae *AgentEnrollments) Host(keyID string) string {
+	if ae == nil || keyID == "" {
+		return ""
+	}
+
+	ae.RLock()
+	defer ae.RUnlock()
+
+	keyID = strings.ToLower(keyID)
+	for host, enrolledKey := range ae.keys {
+		if enrolledKey == keyID {
+			return host
+		}
+	}
+	return ""
+}
+
+// Check returns an error if the credential was signed by a key other than
+// the one its agent host is enrolled with. A credential signed without a key,
+// by an agent which doesn't sign credentials, is not checked.
+func (ae *AgentEnrollments) Check(cred *Credential, keyID string) error {
+	if ae == nil || keyID == "" {
+		return nil
+	}
+
+	ae.RLock()
+	defer ae.RUnlock()
+
+	if len(ae.keys) == 0 && !ae.required {
+		return nil
+	}
+
+	// The host of the agent is recorded in the tokens of all flavors.
+	sys, err := sysFromToken(cred.GetToken())
+	if err != nil {
+		return errors.Wrap(err, "unable to determine the agent host of the credential")
+	}
+	host := sys.GetMachinename()
+	enrolledKey, found := ae.keys[strings.ToLower(host)]
+	if !found {
+		if ae.required {
+			return errors.Errorf("agent host %q is not enrolled", host)
+		}
+		return nil
+	}
+	if enrolledKey != strings.ToLower(keyID) {
+		return errors.Errorf("credential from agent host %q was signed by key %s, not its enrolled key %s",
+			host, keyID, enrolledKey)
+	}
+	return nil
+}
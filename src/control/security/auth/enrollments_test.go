@@ -0,0 +1,229 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestAuth_AgentEnrollmentList(t *testing.T) {
+	key1 := strings.Repeat("ab", keyIDLen/2)
+	key2 := strings.Repeat("cd", keyIDLen/2)
+
+	for name, tc := range map[string]struct {
+		list       *AgentEnrollmentList
+		host       string
+		keyID      string
+		unenroll   bool
+		expChanged bool
+		expList    *AgentEnrollmentList
+		expErr     error
+	}{
+		"nil list": {
+			host:   "host1",
+			keyID:  key1,
+			expErr: errors.New("nil agent enrollment list"),
+		},
+		"enrolled": {
+			list:       &AgentEnrollmentList{},
+			host:       " Host1",
+			keyID:      strings.ToUpper(key1),
+			expChanged: true,
+			expList: &AgentEnrollmentList{
+				Version: 1,
+				KeyIDs:  map[string]string{"host1": key1},
+			},
+		},
+		"already enrolled": {
+			list: &AgentEnrollmentList{
+				Version: 2,
+				KeyIDs:  map[string]string{"host1": key1},
+			},
+			host:  "host1",
+			keyID: key1,
+			expList: &AgentEnrollmentList{
+				Version: 2,
+				KeyIDs:  map[string]string{"host1": key1},
+			},
+		},
+		"new key": {
+			list: &AgentEnrollmentList{
+				Version: 2,
+				KeyIDs:  map[string]string{"host1": key1},
+			},
+			host:       "host1",
+			keyID:      key2,
+			expChanged: true,
+			expList: &AgentEnrollmentList{
+				Version: 3,
+				KeyIDs:  map[string]string{"host1": key2},
+			},
+		},
+		"invalid key ID": {
+			list:    &AgentEnrollmentList{},
+			host:    "host1",
+			keyID:   "bad",
+			expErr:  errors.New("must be 64 hex characters"),
+			expList: &AgentEnrollmentList{},
+		},
+		"empty host": {
+			list:    &AgentEnrollmentList{},
+			keyID:   key1,
+			expErr:  errors.New("must not be empty"),
+			expList: &AgentEnrollmentList{},
+		},
+		"unenrolled": {
+			list: &AgentEnrollmentList{
+				Version: 2,
+				KeyIDs:  map[string]string{"host1": key1, "host2": key2},
+			},
+			host:       "HOST1",
+			unenroll:   true,
+			expChanged: true,
+			expList: &AgentEnrollmentList{
+				Version: 3,
+				KeyIDs:  map[string]string{"host2": key2},
+			},
+		},
+		"not enrolled": {
+			list: &AgentEnrollmentList{
+				Version: 2,
+				KeyIDs:  map[string]string{"host2": key2},
+			},
+			host:     "host1",
+			unenroll: true,
+			expList: &AgentEnrollmentList{
+				Version: 2,
+				KeyIDs:  map[string]string{"host2": key2},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var changed bool
+			var err error
+			if tc.unenroll {
+				changed, err = tc.list.Unenroll(tc.host)
+			} else {
+				changed, err = tc.list.Enroll(tc.host, tc.keyID)
+			}
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expChanged, changed, "unexpected change")
+			if diff := cmp.Diff(tc.expList, tc.list); diff != "" {
+				t.Fatalf("unexpected list (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestAuth_AgentEnrollments_Check(t *testing.T) {
+	key1 := strings.Repeat("ab", keyIDLen/2)
+	key2 := strings.Repeat("cd", keyIDLen/2)
+	newCred := func(host string) *Credential {
+		sys, err := proto.Marshal(&Sys{Machinename: host})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &Credential{
+			Token:    &Token{Flavor: Flavor_AUTH_SYS, Data: sys},
+			Verifier: &Token{Data: []byte("verifier")},
+			Origin:   "agent",
+		}
+	}
+	enrolled := &AgentEnrollmentList{
+		Version: 1,
+		KeyIDs:  map[string]string{"host1": key1},
+	}
+
+	var nilEnrollments *AgentEnrollments
+	test.CmpErr(t, nil, nilEnrollments.Check(newCred("host1"), key2))
+	test.AssertFalse(t, nilEnrollments.Update(enrolled), "nil enrollments updated")
+
+	for name, tc := range map[string]struct {
+		list     *AgentEnrollmentList
+		required bool
+		cred     *Credential
+		keyID    string
+		expErr   error
+	}{
+		"nothing enrolled": {
+			cred:  newCred("host1"),
+			keyID: key2,
+		},
+		"nothing enrolled; required": {
+			required: true,
+			cred:     newCred("host1"),
+			keyID:    key1,
+			expErr:   errors.New(`agent host "host1" is not enrolled`),
+		},
+		"enrolled key": {
+			list:     enrolled,
+			required: true,
+			cred:     newCred("Host1"),
+			keyID:    strings.ToUpper(key1),
+		},
+		"other key": {
+			list:   enrolled,
+			cred:   newCred("host1"),
+			keyID:  key2,
+			expErr: errors.New("was signed by key " + key2 + ", not its enrolled key " + key1),
+		},
+		"host not enrolled": {
+			list:  enrolled,
+			cred:  newCred("host2"),
+			keyID: key2,
+		},
+		"host not enrolled; required": {
+			list:     enrolled,
+			required: true,
+			cred:     newCred("host2"),
+			keyID:    key2,
+			expErr:   errors.New(`agent host "host2" is not enrolled`),
+		},
+		"insecure": {
+			list:     enrolled,
+			required: true,
+			cred:     newCred("host1"),
+		},
+		"malformed token": {
+			list: enrolled,
+			cred: &Credential{
+				Token: &Token{Flavor: Flavor_AUTH_SYS, Data: []byte("garbage")},
+			},
+			keyID:  key1,
+			expErr: errors.New("unable to determine the agent host"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			enrollments := NewAgentEnrollments(tc.required)
+			if tc.list != nil {
+				test.AssertTrue(t, enrollments.Update(tc.list), "enrollments not updated")
+			}
+
+			test.CmpErr(t, tc.expErr, enrollments.Check(tc.cred, tc.keyID))
+		})
+	}
+
+	enrollments := NewAgentEnrollments(false)
+	enrollments.Update(&AgentEnrollmentList{Version: 2, KeyIDs: map[string]string{"host1": key1}})
+	test.AssertFalse(t, enrollments.Update(&AgentEnrollmentList{Version: 1}), "older list installed")
+	test.AssertEqual(t, uint64(2), enrollments.Version(), "unexpected version")
+
+	test.AssertEqual(t, "host1", enrollments.Host(strings.ToUpper(key1)), "unexpected host of enrolled key")
+	test.AssertEqual(t, "", enrollments.Host(key2), "unexpected host of key not enrolled")
+	test.AssertEqual(t, "", nilEnrollments.Host(key1), "unexpected host from nil enrollments")
+	test.CmpErr(t, errors.New("not its enrolled key"), enrollments.Check(newCred("host1"), key2))
+
+	test.AssertTrue(t, enrollments.Update(&AgentEnrollmentList{Version: 3}), "newer list not installed")
+	test.CmpErr(t, nil, enrollments.Check(newCred("host1"), key2))
+}
@@ -0,0 +1,139 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// credentialLifetimes are how long new credentials are valid, by flavor.
+type credentialLifetimes struct {
+	def     time.Duration
+	flavors map[Flavor]time.Duration
+}
+
+// lifetimes, if set, are recorded in the tokens of new credentials.
+var lifetimes atomic.Pointer[credentialLifetimes]
+
+// SetCredentialLifetimes sets how long new credentials are valid: those of the
+// flavors given by name for the lifetime given, and those of other flavors for
+// the default lifetime. The validity period is recorded in each credential's
+// token, so that it is covered by the verifier and enforced by the servers. A
+// lifetime of zero records none, and the credential doesn't expire.
+func SetCredentialLifetimes(def time.Duration, flavors map[string]time.Duration) error {
+	cl := &credentialLifetimes{
+		def:     def,
+		flavors: make(map[Flavor]time.Duration, len(flavors)),
+	}
+	for name, lifetime := range flavors {
+		parsed, err := ParseValidAuthFlavors([]string{name})
+		if err != nil {
+			return errors.Wrap(err, "flavor_lifetimes")
+		}
+		cl.flavors[parsed[0]] = lifetime
+	}
+	lifetimes.Store(cl)
+	return nil
+}
+
+// credentialLifetime returns how long new credentials of the flavor are valid,
+// or zero if they don't expire.
+func credentialLifetime(flavor Flavor) time.Duration {
+	cl := lifetimes.Load()
+	if cl == nil {
+		return 0
+	}
+	if lifetime, found := cl.flavors[flavor]; found {
+		return lifetime
+	}
+	return cl.def
+}
+
+// stampValidity records in the token that it is valid from now until its
+// flavor's lifetime has passed, unless the flavor has no lifetime.
+func stampValidity(token *Token, now time.Time) {
+	lifetime := credentialLifetime(token.GetFlavor())
+	if lifetime <= 0 {
+		return
+	}
+	token.NotBefore = uint64(now.Unix())
+	token.Expires = uint64(now.Add(lifetime).Unix())
+}
+
+// ValidateTokenExpiry checks that the token is within the validity period
+// recorded in it at the given time, allowing for the clock skew between the
+// agent which signed it and the server: that configured for credential expiry,
+// if any, or otherwise the skew given. A token which records no expiry is
+// accepted unless expiry is required. The validity period must have been
+// checked to be authentic, by verifying the credential, beforehand.
+func ValidateTokenExpiry(token *Token, now time.Time, skew time.Duration, cfg *security.CredentialExpiryConfig) error {
+	skew = cfg.Skew(skew)
+	notBefore := time.Unix(int64(token.GetNotBefore()), 0)
+	expires := time.Unix(int64(token.GetExpires()), 0)
+
+	if token.GetNotBefore() != 0 && now.Add(skew).Before(notBefore) {
+		return errors.Wrapf(daos.CredentialExpired, "credential is not valid until %s",
+			notBefore.UTC().Format(time.RFC3339))
+	}
+	if token.GetExpires() == 0 {
+		if cfg != nil && cfg.Required {
+			return errors.New("credential has no expiry")
+		}
+		return nil
+	}
+	if !now.Add(-skew).Before(expires) {
+		return errors.Wrapf(daos.CredentialExpired, "credential expired at %s", expires.UTC().Format(time.RFC3339))
+	}
+
+	if cfg != nil && cfg.MaxLifetime > 0 {
+		start := now
+		if token.GetNotBefore() != 0 {
+			start = notBefore
+		}
+		if lifetime := expires.Sub(start); lifetime > cfg.MaxLifetime {
+			return errors.Errorf("credential is valid for %s, longer than the maximum of %s",
+				lifetime, cfg.MaxLifetime)
+		}
+	}
+	return nil
+}
+
+// skewCorrection returns how far the time is outside the period from start
+// until end, which is only accepted because of the clock skew allowed, or zero
+// if it is within the period. A zero start or end leaves the period open at
+// that end.
+func skewCorrection(now, start, end time.Time) time.Duration {
+	if !start.IsZero() && now.Before(start) {
+		return start.Sub(now)
+	}
+	if !end.IsZero() && !now.Before(end) {
+		return now.Sub(end)
+	}
+	return 0
+}
+
+// TokenSkewCorrection returns how far the time is outside the validity period
+// recorded in the token, or zero if it is within it. A token accepted by
+// ValidateTokenExpiry with a correction was only accepted because of the clock
+// skew allowed, and suggests that the clocks of the agent and the server
+// disagree.
+func TokenSkewCorrection(token *Token, now time.Time) time.Duration {
+	var start, end time.Time
+	if token.GetNotBefore() != 0 {
+		start = time.Unix(int64(token.GetNotBefore()), 0)
+	}
+	if token.GetExpires() != 0 {
+		end = time.Unix(int64(token.GetExpires()), 0)
+	}
+	return skewCorrection(now, start, end)
+}
@@ -0,0 +1,202 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_SetCredentialLifetimes(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lifetimes.Store(nil)
+
+	for name, tc := range map[string]struct {
+		def         time.Duration
+		flavors     map[string]time.Duration
+		flavor      Flavor
+		expErr      error
+		expLifetime time.Duration
+	}{
+		"no lifetime": {
+			flavor: Flavor_AUTH_SYS,
+		},
+		"default lifetime": {
+			def:         time.Hour,
+			flavor:      Flavor_AUTH_SYS,
+			expLifetime: time.Hour,
+		},
+		"flavor lifetime": {
+			def:         time.Hour,
+			flavors:     map[string]time.Duration{"sys": 10 * time.Minute},
+			flavor:      Flavor_AUTH_SYS,
+			expLifetime: 10 * time.Minute,
+		},
+		"other flavor": {
+			def:         time.Hour,
+			flavors:     map[string]time.Duration{"AUTH_MACHINE": 10 * time.Minute},
+			flavor:      Flavor_AUTH_SYS,
+			expLifetime: time.Hour,
+		},
+		"flavor without lifetime": {
+			def:     time.Hour,
+			flavors: map[string]time.Duration{"AUTH_SYS": 0},
+			flavor:  Flavor_AUTH_SYS,
+		},
+		"unknown flavor": {
+			flavors: map[string]time.Duration{"AUTH_BOGUS": time.Hour},
+			expErr:  errors.New("flavor_lifetimes: auth string AUTH_BOGUS is not recognized"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			lifetimes.Store(nil)
+
+			err := SetCredentialLifetimes(tc.def, tc.flavors)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			before := time.Now().Unix()
+			cred, err := newSignedCredential(tc.flavor, key, &Sys{User: "user@"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			token := cred.GetToken()
+			if tc.expLifetime == 0 {
+				test.AssertEqual(t, uint64(0), token.GetNotBefore(), "unexpected not-before")
+				test.AssertEqual(t, uint64(0), token.GetExpires(), "unexpected expiry")
+				return
+			}
+			test.AssertTrue(t, token.GetNotBefore() >= uint64(before), "not-before not stamped")
+			test.AssertEqual(t, token.GetNotBefore()+uint64(tc.expLifetime.Seconds()), token.GetExpires(),
+				"unexpected expiry")
+
+			// The validity period is covered by the verifier.
+			if err := VerifyCredential(key.Public(), cred); err != nil {
+				t.Fatal(err)
+			}
+			token.Expires += 3600
+			if err := VerifyCredential(key.Public(), cred); err == nil {
+				t.Fatal("verification succeeded after the expiry was extended")
+			}
+		})
+	}
+}
+
+func TestAuth_ValidateTokenExpiry(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	unix := func(d time.Duration) uint64 {
+		return uint64(now.Add(d).Unix())
+	}
+
+	for name, tc := range map[string]struct {
+		token  *Token
+		cfg    *security.CredentialExpiryConfig
+		expErr error
+	}{
+		"no expiry": {
+			token: &Token{},
+		},
+		"no expiry required": {
+			token:  &Token{},
+			cfg:    &security.CredentialExpiryConfig{Required: true},
+			expErr: errors.New("credential has no expiry"),
+		},
+		"valid": {
+			token: &Token{NotBefore: unix(-time.Minute), Expires: unix(time.Hour)},
+			cfg:   &security.CredentialExpiryConfig{Required: true},
+		},
+		"expired": {
+			token:  &Token{NotBefore: unix(-2 * time.Hour), Expires: unix(-time.Hour)},
+			expErr: errors.New("credential expired at 2023-11-14T21:13:20Z"),
+		},
+		"expired within skew": {
+			token: &Token{NotBefore: unix(-2 * time.Hour), Expires: unix(-30 * time.Second)},
+		},
+		"expired beyond custom skew": {
+			token:  &Token{NotBefore: unix(-2 * time.Hour), Expires: unix(-30 * time.Second)},
+			cfg:    &security.CredentialExpiryConfig{ClockSkew: 10 * time.Second},
+			expErr: errors.New("credential expired"),
+		},
+		"not yet valid": {
+			token:  &Token{NotBefore: unix(time.Hour), Expires: unix(2 * time.Hour)},
+			expErr: errors.New("credential is not valid until 2023-11-14T23:13:20Z"),
+		},
+		"not yet valid within skew": {
+			token: &Token{NotBefore: unix(30 * time.Second), Expires: unix(time.Hour)},
+		},
+		"lifetime too long": {
+			token:  &Token{NotBefore: unix(-time.Minute), Expires: unix(24 * time.Hour)},
+			cfg:    &security.CredentialExpiryConfig{MaxLifetime: time.Hour},
+			expErr: errors.New("longer than the maximum of 1h0m0s"),
+		},
+		"remaining lifetime too long": {
+			token:  &Token{Expires: unix(24 * time.Hour)},
+			cfg:    &security.CredentialExpiryConfig{MaxLifetime: time.Hour},
+			expErr: errors.New("longer than the maximum of 1h0m0s"),
+		},
+		"lifetime within maximum": {
+			token: &Token{NotBefore: unix(-time.Minute), Expires: unix(59 * time.Minute)},
+			cfg:   &security.CredentialExpiryConfig{MaxLifetime: time.Hour},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, ValidateTokenExpiry(tc.token, now, security.DefaultCredentialClockSkew, tc.cfg))
+		})
+	}
+}
+
+func TestAuth_TokenSkewCorrection(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	unix := func(d time.Duration) uint64 {
+		return uint64(now.Add(d).Unix())
+	}
+
+	for name, tc := range map[string]struct {
+		token   *Token
+		skew    time.Duration
+		expCorr time.Duration
+	}{
+		"no expiry": {
+			token: &Token{},
+		},
+		"valid": {
+			token: &Token{NotBefore: unix(-time.Minute), Expires: unix(time.Hour)},
+		},
+		"expired within skew": {
+			token:   &Token{NotBefore: unix(-2 * time.Hour), Expires: unix(-30 * time.Second)},
+			expCorr: 30 * time.Second,
+		},
+		"not yet valid within skew": {
+			token:   &Token{NotBefore: unix(20 * time.Second), Expires: unix(time.Hour)},
+			expCorr: 20 * time.Second,
+		},
+		"expired within configured skew": {
+			token:   &Token{Expires: unix(-90 * time.Second)},
+			skew:    2 * time.Minute,
+			expCorr: 90 * time.Second,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			skew := security.CredentialClockSkew(tc.skew)
+			test.CmpErr(t, nil, ValidateTokenExpiry(tc.token, now, skew, nil))
+			test.AssertEqual(t, tc.expCorr, TokenSkewCorrection(tc.token, now), "unexpected correction")
+		})
+	}
+}
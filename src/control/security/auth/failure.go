@@ -0,0 +1,34 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"net"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// ClassifyFailure returns the status of a failed credential request: whether
+// it timed out, failed because a backend was unavailable, or was rejected.
+// Failures which cannot be attributed to a backend are treated as rejections.
+func ClassifyFailure(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded):
+		return security.CredentialFailureTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return security.CredentialFailureTimeout
+	case netErr != nil, isRetryableAMError(err):
+		return security.CredentialFailureUnavailable
+	default:
+		return security.CredentialFailureRejected
+	}
+}
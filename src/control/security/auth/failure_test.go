@@ -0,0 +1,78 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+type testNetError struct {
+	timeout bool
+}
+
+func (e *testNetError) Error() string   { return "network error" }
+func (e *testNetError) Timeout() bool   { return e.timeout }
+func (e *testNetError) Temporary() bool { return false }
+
+var _ net.Error = (*testNetError)(nil)
+
+func TestAuth_ClassifyFailure(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err       error
+		expStatus string
+	}{
+		"invalid token": {
+			err:       errors.New("token signature is invalid"),
+			expStatus: security.CredentialFailureRejected,
+		},
+		"access manager denied": {
+			err:       errors.Wrap(&amStatusError{code: http.StatusForbidden}, "validating"),
+			expStatus: security.CredentialFailureRejected,
+		},
+		"access manager throttled": {
+			err:       &amStatusError{code: http.StatusTooManyRequests},
+			expStatus: security.CredentialFailureUnavailable,
+		},
+		"access manager unavailable": {
+			err:       errors.Wrap(&amUnavailableError{failures: []string{"refused"}}, "validating"),
+			expStatus: security.CredentialFailureUnavailable,
+		},
+		"connection refused": {
+			err: &url.Error{
+				Op:  "Get",
+				URL: "https://idp.example.com/jwks",
+				Err: &testNetError{},
+			},
+			expStatus: security.CredentialFailureUnavailable,
+		},
+		"network timeout": {
+			err: errors.Wrap(&url.Error{
+				Op:  "Get",
+				URL: "https://idp.example.com/jwks",
+				Err: &testNetError{timeout: true},
+			}, "fetching keys"),
+			expStatus: security.CredentialFailureTimeout,
+		},
+		"deadline exceeded": {
+			err:       errors.Wrap(context.DeadlineExceeded, "validating"),
+			expStatus: security.CredentialFailureTimeout,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.AssertEqual(t, tc.expStatus, ClassifyFailure(tc.err), "unexpected status")
+		})
+	}
+}
@@ -0,0 +1,148 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/daos"
+)
+
+const (
+	// IdentityDomain is the domain of the ACL principals which stand for
+	// identities of types other than Unix, e.g. "oidc%3Aalice%40idp.example@identity"
+	// for the OIDC subject "alice" of the issuer "idp.example".
+	IdentityDomain = "identity"
+
+	// IdentityOIDC is the type of OIDC subjects, named "<subject>@<issuer host>".
+	IdentityOIDC = "oidc"
+	// IdentitySPIFFE is the type of SPIFFE IDs, named "<trust domain>/<path>".
+	IdentitySPIFFE = "spiffe"
+	// IdentityKerberos is the type of Kerberos principals, named
+	// "<name>@<REALM>".
+	IdentityKerberos = "krb5"
+	// IdentityKeystone is the type of OpenStack Keystone users, named
+	// "<user>@<domain>".
+	IdentityKeystone = "keystone"
+)
+
+var identityTypes = []string{IdentityOIDC, IdentitySPIFFE, IdentityKerberos, IdentityKeystone}
+
+// IsIdentityType returns true if ACL entries may be written against
+// identities of the type.
+func IsIdentityType(idType string) bool {
+	return slices.Contains(identityTypes, idType)
+}
+
+// escapeIdentity escapes the characters which may not appear in the name of
+// an ACL principal, or in an ACL entry.
+func escapeIdentity(str string) string {
+	var b strings.Builder
+	for i := 0; i < len(str); i++ {
+		ch := str[i]
+		if ch == '%' || ch == ':' || ch == '@' || ch <= ' ' || ch >= 0x7f {
+			fmt.Fprintf(&b, "%%%02X", ch)
+			continue
+		}
+		b.WriteByte(ch)
+	}
+	return b.String()
+}
+
+func unescapeIdentity(str string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(str); i++ {
+		if str[i] != '%' {
+			b.WriteByte(str[i])
+			continue
+		}
+		if i+2 >= len(str) {
+			return "", errors.Errorf("truncated escape in %q", str)
+		}
+		ch, err := strconv.ParseUint(str[i+1:i+3], 16, 8)
+		if err != nil {
+			return "", errors.Errorf("invalid escape in %q", str)
+		}
+		b.WriteByte(byte(ch))
+		i += 2
+	}
+	return b.String(), nil
+}
+
+// NewIdentity checks that the identity can be expressed as an ACL principal
+// and returns it.
+func NewIdentity(idType, name string) (*Identity, error) {
+	id := &Identity{Type: idType, Name: name}
+	if !IsIdentityType(idType) {
+		return nil, errors.Errorf("unknown identity type %q (one of %s)", idType,
+			strings.Join(identityTypes, ", "))
+	}
+	if name == "" || strings.ContainsAny(name, " \t\n") {
+		return nil, errors.Errorf("invalid %s identity %q", idType, name)
+	}
+	if len(IdentityPrincipal(id)) > daos.ACLPrincipalMaxLen {
+		return nil, errors.Errorf("%s identity %q is too long", idType, name)
+	}
+	return id, nil
+}
+
+// IdentityString returns the identity as written in ACL entries, e.g.
+// "oidc:alice@idp.example".
+func IdentityString(id *Identity) string {
+	return id.GetType() + ":" + id.GetName()
+}
+
+// IdentityPrincipal returns the ACL principal which stands for the identity,
+// so that pool and container ACL entries for the group "<type>:<name>",
+// escaped and in the identity domain, apply to users with the identity.
+func IdentityPrincipal(id *Identity) string {
+	return escapeIdentity(IdentityString(id)) + "@" + IdentityDomain
+}
+
+// ParseIdentityPrincipal returns the identity which the ACL principal stands
+// for, or nil if it is not in the identity domain.
+func ParseIdentityPrincipal(principal string) (*Identity, error) {
+	escaped, found := strings.CutSuffix(principal, "@"+IdentityDomain)
+	if !found {
+		return nil, nil
+	}
+
+	str, err := unescapeIdentity(escaped)
+	if err != nil {
+		return nil, err
+	}
+	idType, name, found := strings.Cut(str, ":")
+	if !found {
+		return nil, errors.Errorf("identity principal %q has no type", principal)
+	}
+	return NewIdentity(idType, name)
+}
+
+// addIdentity records the identity of the type which the flavor verified in
+// the token, if it can be expressed as an ACL principal.
+func addIdentity(sys *Sys, idType, name string) {
+	if id, err := NewIdentity(idType, name); err == nil {
+		sys.Identities = append(sys.Identities, id)
+	}
+}
+
+// tokenIdentities returns the identities recorded in the token by its flavor
+// which can be expressed as ACL principals.
+func tokenIdentities(sys *Sys) []*Identity {
+	var ids []*Identity
+	for _, id := range sys.GetIdentities() {
+		if _, err := NewIdentity(id.GetType(), id.GetName()); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
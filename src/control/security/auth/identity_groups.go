@@ -0,0 +1,144 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bytes"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+type identityGroupRule struct {
+	idType  string
+	pattern string
+	groups  []string
+}
+
+func newIdentityGroupRules(mappings security.IdentityGroupMappings) ([]identityGroupRule, error) {
+	if err := mappings.Validate(); err != nil {
+		return nil, err
+	}
+
+	var rules []identityGroupRule
+	for _, identity := range mappings.Keys() {
+		idType, pattern, _ := strings.Cut(identity, ":")
+		if !IsIdentityType(idType) {
+			return nil, errors.Errorf("identity %q: unknown identity type %q", identity, idType)
+		}
+		rule := identityGroupRule{idType: idType, pattern: pattern}
+		for _, group := range mappings[identity] {
+			rule.groups = append(rule.groups, sysNameToPrincipalName(group))
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// IdentityGroupMap resolves the identities verified by external identity
+// providers into the DAOS groups they are mapped to, so that ACL entries for
+// the groups apply to users with the identities.
+type IdentityGroupMap struct {
+	cfg       *security.IdentityGroupConfig
+	mutex     sync.RWMutex
+	fileData  []byte
+	cfgRules  []identityGroupRule
+	fileRules []identityGroupRule
+}
+
+// NewIdentityGroupMap loads the identity group configuration. If none is
+// configured, nil is returned, which maps no identities.
+func NewIdentityGroupMap(cfg *security.IdentityGroupConfig) (*IdentityGroupMap, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	cfgRules, err := newIdentityGroupRules(cfg.Mappings)
+	if err != nil {
+		return nil, err
+	}
+	m := &IdentityGroupMap{cfg: cfg, cfgRules: cfgRules}
+	if _, _, err := m.Reload(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Path returns the path of the mapping file, if one is configured.
+func (m *IdentityGroupMap) Path() string {
+	return m.cfg.MappingFile
+}
+
+// Reload loads the mapping file again, replacing its mappings if it has
+// changed. If it can't be loaded, its mappings are left unchanged. It returns
+// true, and the number of mappings in the file, if it changed.
+func (m *IdentityGroupMap) Reload() (bool, int, error) {
+	if m == nil || m.cfg.MappingFile == "" {
+		return false, 0, nil
+	}
+
+	data, err := m.cfg.ReadMappingFile()
+	if err != nil {
+		return false, 0, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.fileData != nil && bytes.Equal(data, m.fileData) {
+		return false, 0, nil
+	}
+	mappings, err := security.ParseIdentityGroupMappings(data)
+	if err != nil {
+		return false, 0, errors.Wrap(err, m.cfg.MappingFile)
+	}
+	rules, err := newIdentityGroupRules(mappings)
+	if err != nil {
+		return false, 0, errors.Wrap(err, m.cfg.MappingFile)
+	}
+
+	m.fileData = data
+	m.fileRules = rules
+	return true, len(rules), nil
+}
+
+// Groups returns the principals of the groups which the identities are
+// mapped to.
+func (m *IdentityGroupMap) Groups(ids []*Identity) []string {
+	if m == nil || len(ids) == 0 {
+		return nil
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var groups []string
+	for _, rule := range append(slices.Clone(m.cfgRules), m.fileRules...) {
+		for _, id := range ids {
+			if id.GetType() != rule.idType {
+				continue
+			}
+			if matched, _ := path.Match(rule.pattern, id.GetName()); !matched {
+				continue
+			}
+			for _, group := range rule.groups {
+				if !slices.Contains(groups, group) {
+					groups = append(groups, group)
+				}
+			}
+		}
+	}
+	return groups
+}
@@ -0,0 +1,119 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_IdentityGroupMap_Groups(t *testing.T) {
+	mappings := security.IdentityGroupMappings{
+		"oidc:*@idp.example":           {"hpc"},
+		"oidc:alice@idp.example":       {"admins", "hpc"},
+		"spiffe:example.org/ns/prod/*": {"prod"},
+	}
+
+	for name, tc := range map[string]struct {
+		cfg       *security.IdentityGroupConfig
+		ids       []*Identity
+		expGroups []string
+		expErr    error
+	}{
+		"not configured": {
+			ids: []*Identity{{Type: IdentityOIDC, Name: "alice@idp.example"}},
+		},
+		"unknown identity type": {
+			cfg: &security.IdentityGroupConfig{
+				Mappings: security.IdentityGroupMappings{"unix:alice": {"hpc"}},
+			},
+			expErr: errors.New(`unknown identity type "unix"`),
+		},
+		"no identities": {
+			cfg: &security.IdentityGroupConfig{Mappings: mappings},
+		},
+		"several mappings": {
+			cfg:       &security.IdentityGroupConfig{Mappings: mappings},
+			ids:       []*Identity{{Type: IdentityOIDC, Name: "alice@idp.example"}},
+			expGroups: []string{"hpc@", "admins@"},
+		},
+		"wildcard doesn't match path separator": {
+			cfg: &security.IdentityGroupConfig{Mappings: mappings},
+			ids: []*Identity{
+				{Type: IdentitySPIFFE, Name: "example.org/ns/prod/sa/app"},
+				{Type: IdentitySPIFFE, Name: "example.org/ns/prod/app"},
+			},
+			expGroups: []string{"prod@"},
+		},
+		"type must match": {
+			cfg: &security.IdentityGroupConfig{Mappings: mappings},
+			ids: []*Identity{{Type: IdentityKerberos, Name: "alice@idp.example"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			m, err := NewIdentityGroupMap(tc.cfg)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.CmpAny(t, "groups", tc.expGroups, m.Groups(tc.ids))
+		})
+	}
+}
+
+func TestAuth_IdentityGroupMap_Reload(t *testing.T) {
+	dir, cleanup := test.CreateTestDir(t)
+	defer cleanup()
+
+	path := filepath.Join(dir, "identity_groups.yml")
+	writeMappings := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	alice := []*Identity{{Type: IdentityOIDC, Name: "alice@idp.example"}}
+
+	writeMappings("\"oidc:*@idp.example\": [hpc]\n")
+	m, err := NewIdentityGroupMap(&security.IdentityGroupConfig{
+		MappingFile: path,
+		Mappings:    security.IdentityGroupMappings{"oidc:alice@idp.example": {"admins"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, path, m.Path(), "unexpected path")
+	test.CmpAny(t, "groups", []string{"admins@", "hpc@"}, m.Groups(alice))
+
+	changed, _, err := m.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertFalse(t, changed, "unchanged file reloaded")
+
+	writeMappings("\"oidc:*@idp.example\": [staff]\n\"krb5:*@EXAMPLE.COM\": [staff]\n")
+	changed, count, err := m.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, changed, "changed file not reloaded")
+	test.AssertEqual(t, 2, count, "unexpected number of mappings")
+	test.CmpAny(t, "groups", []string{"admins@", "staff@"}, m.Groups(alice))
+
+	// An invalid file is refused, and the mappings loaded from it before kept.
+	writeMappings("\"oidc:*@idp.example\": []\n")
+	_, _, err = m.Reload()
+	test.CmpErr(t, errors.New("no groups"), err)
+	test.CmpAny(t, "groups", []string{"admins@", "staff@"}, m.Groups(alice))
+}
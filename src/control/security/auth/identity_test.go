@@ -0,0 +1,114 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestAuth_NewIdentity(t *testing.T) {
+	for name, tc := range map[string]struct {
+		idType       string
+		idName       string
+		expPrincipal string
+		expErr       error
+	}{
+		"OIDC subject": {
+			idType:       IdentityOIDC,
+			idName:       "alice@idp.example",
+			expPrincipal: "oidc%3Aalice%40idp.example@identity",
+		},
+		"SPIFFE ID": {
+			idType:       IdentitySPIFFE,
+			idName:       "example.org/ns/prod/sa/app",
+			expPrincipal: "spiffe%3Aexample.org/ns/prod/sa/app@identity",
+		},
+		"Kerberos principal with percent": {
+			idType:       IdentityKerberos,
+			idName:       "100%admin@EXAMPLE.COM",
+			expPrincipal: "krb5%3A100%25admin%40EXAMPLE.COM@identity",
+		},
+		"unknown type": {
+			idType: "unix",
+			idName: "alice",
+			expErr: errors.New(`unknown identity type "unix"`),
+		},
+		"empty name": {
+			idType: IdentityOIDC,
+			expErr: errors.New(`invalid oidc identity ""`),
+		},
+		"name with whitespace": {
+			idType: IdentityOIDC,
+			idName: "alice smith@idp.example",
+			expErr: errors.New("invalid oidc identity"),
+		},
+		"too long": {
+			idType: IdentityOIDC,
+			idName: strings.Repeat("x", 256),
+			expErr: errors.New("is too long"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			id, err := NewIdentity(tc.idType, tc.idName)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			principal := IdentityPrincipal(id)
+			test.AssertEqual(t, tc.expPrincipal, principal, "unexpected principal")
+
+			parsed, err := ParseIdentityPrincipal(principal)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.CmpAny(t, "identity", id, parsed, test.DefaultCmpOpts()...)
+		})
+	}
+}
+
+func TestAuth_ParseIdentityPrincipal(t *testing.T) {
+	for name, tc := range map[string]struct {
+		principal string
+		expID     *Identity
+		expErr    error
+	}{
+		"user": {
+			principal: "alice@",
+		},
+		"claim": {
+			principal: "job=1234@claim",
+		},
+		"identity": {
+			principal: "oidc%3Aalice%40idp.example@identity",
+			expID:     &Identity{Type: IdentityOIDC, Name: "alice@idp.example"},
+		},
+		"no type": {
+			principal: "alice@identity",
+			expErr:    errors.New("has no type"),
+		},
+		"truncated escape": {
+			principal: "oidc%3Aalice%4@identity",
+			expErr:    errors.New("truncated escape"),
+		},
+		"invalid escape": {
+			principal: "oidc%3Aalice%zz@identity",
+			expErr:    errors.New("invalid escape"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			id, err := ParseIdentityPrincipal(tc.principal)
+			test.CmpErr(t, tc.expErr, err)
+			test.CmpAny(t, "identity", tc.expID, id, test.DefaultCmpOpts()...)
+		})
+	}
+}
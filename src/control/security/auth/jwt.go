@@ -0,0 +1,634 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256" // register SHA-256 for crypto.Hash
+	_ "crypto/sha512" // register SHA-384/512 for crypto.Hash
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultJWKSRefresh is how long a fetched JWKS is trusted before it is fetched again.
+	defaultJWKSRefresh = time.Hour
+	// defaultJWTLeeway is the clock skew allowed when checking time-based claims.
+	defaultJWTLeeway = time.Minute
+	// maxJWKSSize limits the size of a JWKS document we are willing to read.
+	maxJWKSSize = 1 << 20
+)
+
+type (
+	// jwtClaims is the decoded claim set of a JSON Web Token.
+	jwtClaims map[string]interface{}
+
+	jwtHeader struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+		Type      string `json:"typ"`
+	}
+
+	// jwkSource provides public keys for JWT signature verification.
+	jwkSource interface {
+		keyByID(ctx context.Context, kid string) (crypto.PublicKey, error)
+	}
+
+	// jwtVerifier validates JWTs issued by a trusted issuer.
+	jwtVerifier struct {
+		issuers   []string
+		audiences []string
+		keys      jwkSource
+		leeway    time.Duration
+		now       func() time.Time
+	}
+
+	// sharedJWTKey is the secret key used to sign and verify JWTs with an
+	// HMAC algorithm, such as those issued by slurmctld.
+	sharedJWTKey []byte
+
+	// fixedJWTKey is the only public key trusted to sign JWTs, whatever key
+	// ID they name.
+	fixedJWTKey struct {
+		key crypto.PublicKey
+	}
+
+	jsonWebKey struct {
+		KeyType string `json:"kty"`
+		KeyID   string `json:"kid"`
+		Use     string `json:"use"`
+		Curve   string `json:"crv"`
+		N       string `json:"n"`
+		E       string `json:"e"`
+		X       string `json:"x"`
+		Y       string `json:"y"`
+	}
+
+	jsonWebKeySet struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	// remoteJWKS fetches and caches a JSON Web Key Set published at a URL.
+	remoteJWKS struct {
+		sync.Mutex
+		url       string
+		client    *http.Client
+		refresh   time.Duration
+		keys      map[string]crypto.PublicKey
+		fetchedAt time.Time
+	}
+
+	// discoveredJWKS provides the keys of an issuer whose JWKS URL is
+	// published in its OpenID Connect or OAuth 2.0 authorization server
+	// metadata.
+	discoveredJWKS struct {
+		sync.Mutex
+		issuer string
+		client *http.Client
+		keys   jwkSource
+	}
+
+	issuerMetadata struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+)
+
+// issuerMetadataPaths are the well-known locations of issuer metadata, in the
+// order they are tried.
+var issuerMetadataPaths = []string{
+	"/.well-known/openid-configuration",
+	"/.well-known/oauth-authorization-server",
+}
+
+var (
+	jwksRegistryMutex sync.Mutex
+	jwksRegistry      = make(map[string]*remoteJWKS)
+	discoveryRegistry = make(map[string]*discoveredJWKS)
+)
+
+// getRemoteJWKS returns the shared key set for the given URL, so that all
+// requests using the same issuer benefit from the same cached keys.
+func getRemoteJWKS(url string) *remoteJWKS {
+	jwksRegistryMutex.Lock()
+	defer jwksRegistryMutex.Unlock()
+
+	if ks, found := jwksRegistry[url]; found {
+		return ks
+	}
+
+	ks := &remoteJWKS{
+		url:     url,
+		client:  http.DefaultClient,
+		refresh: defaultJWKSRefresh,
+	}
+	jwksRegistry[url] = ks
+	return ks
+}
+
+// getDiscoveredJWKS returns the shared key set for the given issuer.
+func getDiscoveredJWKS(issuer string) *discoveredJWKS {
+	jwksRegistryMutex.Lock()
+	defer jwksRegistryMutex.Unlock()
+
+	if ks, found := discoveryRegistry[issuer]; found {
+		return ks
+	}
+
+	ks := &discoveredJWKS{
+		issuer: issuer,
+		client: http.DefaultClient,
+	}
+	discoveryRegistry[issuer] = ks
+	return ks
+}
+
+func (ks *discoveredJWKS) fetchMetadata(ctx context.Context, url string) (*issuerMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating metadata request for %q", url)
+	}
+
+	resp, err := ks.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching metadata from %q", url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("fetching metadata from %q: unexpected status code %d", url, resp.StatusCode)
+	}
+
+	md := new(issuerMetadata)
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxJWKSSize)).Decode(md); err != nil {
+		return nil, errors.Wrapf(err, "parsing metadata from %q", url)
+	}
+	return md, nil
+}
+
+// discover finds the issuer's JWKS URL in its published metadata. The
+// metadata must name the issuer it was fetched for, so that one issuer
+// cannot direct the agent to another's keys.
+func (ks *discoveredJWKS) discover(ctx context.Context) (string, error) {
+	var errs []error
+	for _, path := range issuerMetadataPaths {
+		md, err := ks.fetchMetadata(ctx, strings.TrimSuffix(ks.issuer, "/")+path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if md.Issuer != ks.issuer {
+			return "", errors.Errorf("metadata for issuer %q names issuer %q", ks.issuer, md.Issuer)
+		}
+		if md.JWKSURI == "" {
+			return "", errors.Errorf("metadata for issuer %q has no jwks_uri", ks.issuer)
+		}
+		return md.JWKSURI, nil
+	}
+
+	return "", errors.Wrapf(errs[0], "discovering keys for issuer %q", ks.issuer)
+}
+
+func (ks *discoveredJWKS) keyByID(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	ks.Lock()
+	if ks.keys == nil {
+		jwksURL, err := ks.discover(ctx)
+		if err != nil {
+			ks.Unlock()
+			return nil, err
+		}
+		ks.keys = getRemoteJWKS(jwksURL)
+	}
+	keys := ks.keys
+	ks.Unlock()
+
+	return keys.keyByID(ctx, kid)
+}
+
+func (ks *remoteJWKS) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ks.url, http.NoBody)
+	if err != nil {
+		return errors.Wrapf(err, "creating JWKS request for %q", ks.url)
+	}
+
+	resp, err := ks.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "fetching JWKS from %q", ks.url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("fetching JWKS from %q: unexpected status code %d", ks.url, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+	if err != nil {
+		return errors.Wrapf(err, "reading JWKS from %q", ks.url)
+	}
+
+	keys, err := parseJWKS(body)
+	if err != nil {
+		return errors.Wrapf(err, "parsing JWKS from %q", ks.url)
+	}
+
+	ks.keys = keys
+	ks.fetchedAt = time.Now()
+	return nil
+}
+
+// keyByID returns the key with the given ID. If the key is not known, or the
+// cached key set is stale, the key set is re-fetched in order to pick up key
+// rotations by the issuer.
+func (ks *remoteJWKS) keyByID(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	ks.Lock()
+	defer ks.Unlock()
+
+	key, found := ks.keys[kid]
+	if found && time.Since(ks.fetchedAt) < ks.refresh {
+		return key, nil
+	}
+
+	if err := ks.fetch(ctx); err != nil {
+		if found {
+			// Better to use a stale key than to fail outright if the
+			// issuer is temporarily unreachable.
+			return key, nil
+		}
+		return nil, err
+	}
+
+	key, found = ks.keys[kid]
+	if !found {
+		return nil, errors.Errorf("no key with ID %q in JWKS from %q", kid, ks.url)
+	}
+	return key, nil
+}
+
+func (k sharedJWTKey) keyByID(context.Context, string) (crypto.PublicKey, error) {
+	return []byte(k), nil
+}
+
+func (k fixedJWTKey) keyByID(context.Context, string) (crypto.PublicKey, error) {
+	return k.key, nil
+}
+
+func decodeB64URLInt(in string) (*big.Int, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(in)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(buf), nil
+}
+
+func (jwk *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.KeyType {
+	case "RSA":
+		n, err := decodeB64URLInt(jwk.N)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid RSA modulus")
+		}
+		e, err := decodeB64URLInt(jwk.E)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid RSA exponent")
+		}
+		if !e.IsInt64() {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.Errorf("unsupported EC curve %q", jwk.Curve)
+		}
+		x, err := decodeB64URLInt(jwk.X)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid EC x coordinate")
+		}
+		y, err := decodeB64URLInt(jwk.Y)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid EC y coordinate")
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("EC point is not on curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if jwk.Curve != "Ed25519" {
+			return nil, errors.Errorf("unsupported OKP curve %q", jwk.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 public key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, errors.Errorf("unsupported key type %q", jwk.KeyType)
+	}
+}
+
+// parseJWKS parses a JSON Web Key Set into a map of key IDs to public keys.
+// Keys which are not intended for signatures, or are of unsupported types,
+// are skipped.
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set jsonWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no usable signing keys found")
+	}
+	return keys, nil
+}
+
+func verifyJWTSignature(alg string, key crypto.PublicKey, signed, sig []byte) error {
+	var hash crypto.Hash
+	if len(alg) == 5 {
+		switch alg[2:] {
+		case "256":
+			hash = crypto.SHA256
+		case "384":
+			hash = crypto.SHA384
+		case "512":
+			hash = crypto.SHA512
+		}
+	}
+	hashFor := func(h crypto.Hash) []byte {
+		hasher := h.New()
+		hasher.Write(signed)
+		return hasher.Sum(nil)
+	}
+
+	switch {
+	case strings.HasPrefix(alg, "RS") && hash != 0:
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.Errorf("key type %T does not match algorithm %s", key, alg)
+		}
+		return rsa.VerifyPKCS1v15(pub, hash, hashFor(hash), sig)
+	case strings.HasPrefix(alg, "HS") && hash != 0:
+		secret, ok := key.([]byte)
+		if !ok {
+			return errors.Errorf("key type %T does not match algorithm %s", key, alg)
+		}
+		mac := hmac.New(hash.New, secret)
+		mac.Write(signed)
+		if !hmac.Equal(mac.Sum(nil), sig) {
+			return errors.New("HMAC verification failed")
+		}
+		return nil
+	case strings.HasPrefix(alg, "PS") && hash != 0:
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.Errorf("key type %T does not match algorithm %s", key, alg)
+		}
+		return rsa.VerifyPSS(pub, hash, hashFor(hash), sig, nil)
+	case strings.HasPrefix(alg, "ES") && hash != 0:
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return errors.Errorf("key type %T does not match algorithm %s", key, alg)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return errors.New("invalid ECDSA signature length")
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, hashFor(hash), r, s) {
+			return errors.New("ECDSA verification failed")
+		}
+		return nil
+	case alg == "EdDSA":
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return errors.Errorf("key type %T does not match algorithm %s", key, alg)
+		}
+		if !ed25519.Verify(pub, signed, sig) {
+			return errors.New("Ed25519 verification failed")
+		}
+		return nil
+	default:
+		return errors.Errorf("unsupported JWT algorithm %q", alg)
+	}
+}
+
+func decodeJWTSegment(seg string, out interface{}) error {
+	buf, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	return dec.Decode(out)
+}
+
+// parseJWT splits and decodes a compact-serialized JWT without verifying it.
+func parseJWT(token string) (*jwtHeader, jwtClaims, []byte, []byte, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return nil, nil, nil, nil, errors.New("malformed JWT: expected 3 segments")
+	}
+
+	header := new(jwtHeader)
+	if err := decodeJWTSegment(parts[0], header); err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "malformed JWT header")
+	}
+
+	claims := make(jwtClaims)
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "malformed JWT claims")
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "malformed JWT signature")
+	}
+
+	return header, claims, []byte(parts[0] + "." + parts[1]), sig, nil
+}
+
+// Verify checks the signature and standard claims of the token, and returns
+// the claim set if the token is valid.
+func (v *jwtVerifier) Verify(ctx context.Context, token string) (jwtClaims, error) {
+	if v == nil || v.keys == nil {
+		return nil, errors.New("JWT verifier is not configured")
+	}
+
+	header, claims, signed, sig, err := parseJWT(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.Algorithm == "" || strings.EqualFold(header.Algorithm, "none") {
+		return nil, errors.New("unsigned JWTs are not accepted")
+	}
+
+	key, err := v.keys.keyByID(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyJWTSignature(header.Algorithm, key, signed, sig); err != nil {
+		return nil, errors.Wrap(err, "JWT signature verification failed")
+	}
+
+	if err := v.checkClaims(claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (v *jwtVerifier) checkClaims(claims jwtClaims) error {
+	now := time.Now()
+	if v.now != nil {
+		now = v.now()
+	}
+	leeway := v.leeway
+	if leeway == 0 {
+		leeway = defaultJWTLeeway
+	}
+
+	exp, found, err := claims.timeClaim("exp")
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.New("JWT has no expiration")
+	}
+	if now.After(exp.Add(leeway)) {
+		return errors.Errorf("JWT expired at %s", exp)
+	}
+
+	if nbf, found, err := claims.timeClaim("nbf"); err != nil {
+		return err
+	} else if found && now.Add(leeway).Before(nbf) {
+		return errors.Errorf("JWT is not valid before %s", nbf)
+	}
+
+	if len(v.issuers) > 0 {
+		iss := claims.stringClaim("iss")
+		if !slices.Contains(v.issuers, iss) {
+			return errors.Errorf("JWT issuer %q is not trusted", iss)
+		}
+	}
+
+	if len(v.audiences) > 0 {
+		matched := false
+		for _, aud := range claims.stringsClaim("aud") {
+			if slices.Contains(v.audiences, aud) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return errors.Errorf("JWT audience %v is not accepted", claims.stringsClaim("aud"))
+		}
+	}
+
+	return nil
+}
+
+// stringClaim returns the named claim if it is a string, or an empty string otherwise.
+func (c jwtClaims) stringClaim(name string) string {
+	s, _ := c[name].(string)
+	return s
+}
+
+// stringsClaim returns the named claim as a list of strings. Claims that are a
+// single string are returned as a list with one entry.
+func (c jwtClaims) stringsClaim(name string) []string {
+	switch v := c[name].(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// boolClaim returns the named claim as a boolean. Some issuers encode boolean
+// claims as strings, so "true" is also accepted.
+func (c jwtClaims) boolClaim(name string) bool {
+	switch v := c[name].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	default:
+		return false
+	}
+}
+
+// timeClaim returns the named claim interpreted as a NumericDate.
+func (c jwtClaims) timeClaim(name string) (time.Time, bool, error) {
+	raw, found := c[name]
+	if !found {
+		return time.Time{}, false, nil
+	}
+
+	num, ok := raw.(json.Number)
+	if !ok {
+		return time.Time{}, true, errors.Errorf("JWT claim %q is not a number", name)
+	}
+	secs, err := num.Float64()
+	if err != nil {
+		return time.Time{}, true, errors.Wrapf(err, "JWT claim %q", name)
+	}
+
+	return time.Unix(int64(secs), 0), true, nil
+}
+
+func (c jwtClaims) String() string {
+	return fmt.Sprintf("iss=%q sub=%q", c.stringClaim("iss"), c.stringClaim("sub"))
+}
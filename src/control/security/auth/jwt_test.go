@@ -0,0 +1,399 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func testRSAJWK(t *testing.T, kid string, key *rsa.PrivateKey) jsonWebKey {
+	t.Helper()
+	return jsonWebKey{
+		KeyType: "RSA",
+		KeyID:   kid,
+		Use:     "sig",
+		N:       b64(key.N.Bytes()),
+		E:       b64(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func testECJWK(t *testing.T, kid string, key *ecdsa.PrivateKey) jsonWebKey {
+	t.Helper()
+	return jsonWebKey{
+		KeyType: "EC",
+		KeyID:   kid,
+		Curve:   "P-256",
+		X:       b64(key.X.FillBytes(make([]byte, 32))),
+		Y:       b64(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func testSignJWT(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]interface{}) string {
+	t.Helper()
+
+	hdr, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := b64(hdr) + "." + b64(body)
+	digest := sha256.Sum256([]byte(signed))
+
+	var sig []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k, digest[:])
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed + "." + b64(sig)
+}
+
+func testSignHMACJWT(t *testing.T, kid string, secret []byte, claims map[string]interface{}) string {
+	t.Helper()
+
+	hdr, err := json.Marshal(map[string]string{"alg": "HS256", "kid": kid, "typ": "JWT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := b64(hdr) + "." + b64(body)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+
+	return signed + "." + b64(mac.Sum(nil))
+}
+
+type staticJWKS map[string]crypto.PublicKey
+
+func (s staticJWKS) keyByID(_ context.Context, kid string) (crypto.PublicKey, error) {
+	if key, found := s[kid]; found {
+		return key, nil
+	}
+	return nil, errors.Errorf("no key %q", kid)
+}
+
+func TestAuth_jwtVerifier_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	goodClaims := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss": "https://issuer.example",
+			"aud": []string{"daos", "other"},
+			"sub": "alice",
+			"exp": now.Add(time.Hour).Unix(),
+			"nbf": now.Add(-time.Minute).Unix(),
+		}
+	}
+	withClaim := func(name string, value interface{}) map[string]interface{} {
+		c := goodClaims()
+		if value == nil {
+			delete(c, name)
+		} else {
+			c[name] = value
+		}
+		return c
+	}
+
+	for name, tc := range map[string]struct {
+		token  string
+		expSub string
+		expErr error
+	}{
+		"malformed": {
+			token:  "not.a-jwt",
+			expErr: errors.New("malformed"),
+		},
+		"RS256": {
+			token:  testSignJWT(t, "RS256", "rsa", rsaKey, goodClaims()),
+			expSub: "alice",
+		},
+		"ES256": {
+			token:  testSignJWT(t, "ES256", "ec", ecKey, goodClaims()),
+			expSub: "alice",
+		},
+		"HS256": {
+			token:  testSignHMACJWT(t, "hs", []byte("shared secret"), goodClaims()),
+			expSub: "alice",
+		},
+		"HS256 wrong secret": {
+			token:  testSignHMACJWT(t, "hs", []byte("other secret"), goodClaims()),
+			expErr: errors.New("HMAC verification failed"),
+		},
+		"HS256 with public key": {
+			token:  testSignHMACJWT(t, "rsa", []byte("shared secret"), goodClaims()),
+			expErr: errors.New("does not match algorithm"),
+		},
+		"unknown key": {
+			token:  testSignJWT(t, "RS256", "missing", rsaKey, goodClaims()),
+			expErr: errors.New("no key"),
+		},
+		"wrong signer": {
+			token:  testSignJWT(t, "RS256", "rsa", otherKey, goodClaims()),
+			expErr: errors.New("signature verification failed"),
+		},
+		"algorithm/key mismatch": {
+			token:  testSignJWT(t, "ES256", "rsa", ecKey, goodClaims()),
+			expErr: errors.New("does not match algorithm"),
+		},
+		"expired": {
+			token:  testSignJWT(t, "RS256", "rsa", rsaKey, withClaim("exp", now.Add(-time.Hour).Unix())),
+			expErr: errors.New("expired"),
+		},
+		"no expiration": {
+			token:  testSignJWT(t, "RS256", "rsa", rsaKey, withClaim("exp", nil)),
+			expErr: errors.New("no expiration"),
+		},
+		"not yet valid": {
+			token:  testSignJWT(t, "RS256", "rsa", rsaKey, withClaim("nbf", now.Add(time.Hour).Unix())),
+			expErr: errors.New("not valid before"),
+		},
+		"untrusted issuer": {
+			token:  testSignJWT(t, "RS256", "rsa", rsaKey, withClaim("iss", "https://evil.example")),
+			expErr: errors.New("not trusted"),
+		},
+		"wrong audience": {
+			token:  testSignJWT(t, "RS256", "rsa", rsaKey, withClaim("aud", "other")),
+			expErr: errors.New("audience"),
+		},
+		"single audience string": {
+			token:  testSignJWT(t, "RS256", "rsa", rsaKey, withClaim("aud", "daos")),
+			expSub: "alice",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			v := &jwtVerifier{
+				issuers:   []string{"https://issuer.example"},
+				audiences: []string{"daos"},
+				keys: staticJWKS{
+					"rsa": &rsaKey.PublicKey,
+					"ec":  &ecKey.PublicKey,
+					"hs":  []byte("shared secret"),
+				},
+				now: func() time.Time { return now },
+			}
+
+			claims, err := v.Verify(test.Context(t), tc.token)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.expSub, claims.stringClaim("sub"), "unexpected subject")
+		})
+	}
+}
+
+func TestAuth_remoteJWKS_keyByID(t *testing.T) {
+	key1, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fetches atomic.Int32
+	keys := []jsonWebKey{testRSAJWK(t, "one", key1)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if err := json.NewEncoder(w).Encode(jsonWebKeySet{Keys: keys}); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+
+	ks := getRemoteJWKS(srv.URL)
+	if ks != getRemoteJWKS(srv.URL) {
+		t.Fatal("expected key sets for the same URL to be shared")
+	}
+
+	if _, err := ks.keyByID(test.Context(t), "one"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.keyByID(test.Context(t), "one"); err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, int32(1), fetches.Load(), "expected cached key set to be used")
+
+	// An unknown key ID triggers a re-fetch to pick up a rotated key.
+	keys = append(keys, testRSAJWK(t, "two", key2))
+	if _, err := ks.keyByID(test.Context(t), "two"); err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, int32(2), fetches.Load(), "expected key set to be re-fetched")
+
+	_, err = ks.keyByID(test.Context(t), "three")
+	test.CmpErr(t, errors.New("no key with ID"), err)
+}
+
+func TestAuth_discoveredJWKS_keyByID(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		metadataPath string
+		mdIssuer     func(srvURL string) string
+		expErr       error
+	}{
+		"openid configuration": {
+			metadataPath: "/.well-known/openid-configuration",
+		},
+		"authorization server metadata": {
+			metadataPath: "/.well-known/oauth-authorization-server",
+		},
+		"no metadata": {
+			metadataPath: "/.well-known/other",
+			expErr:       errors.New("unexpected status code 404"),
+		},
+		"metadata for another issuer": {
+			metadataPath: "/.well-known/openid-configuration",
+			mdIssuer:     func(string) string { return "https://evil.example" },
+			expErr:       errors.New("names issuer"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			mdIssuer := srv.URL
+			if tc.mdIssuer != nil {
+				mdIssuer = tc.mdIssuer(srv.URL)
+			}
+			mux.HandleFunc(tc.metadataPath, func(w http.ResponseWriter, r *http.Request) {
+				md := issuerMetadata{Issuer: mdIssuer, JWKSURI: srv.URL + "/jwks"}
+				if err := json.NewEncoder(w).Encode(md); err != nil {
+					t.Error(err)
+				}
+			})
+			mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+				keys := jsonWebKeySet{Keys: []jsonWebKey{testRSAJWK(t, "one", key)}}
+				if err := json.NewEncoder(w).Encode(keys); err != nil {
+					t.Error(err)
+				}
+			})
+
+			ks := getDiscoveredJWKS(srv.URL)
+			if ks != getDiscoveredJWKS(srv.URL) {
+				t.Fatal("expected key sets for the same issuer to be shared")
+			}
+
+			_, err := ks.keyByID(test.Context(t), "one")
+			test.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestAuth_parseJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	badCurve := testECJWK(t, "bad", ecKey)
+	badCurve.Curve = "P-192"
+	encKey := testRSAJWK(t, "enc", rsaKey)
+	encKey.Use = "enc"
+
+	for name, tc := range map[string]struct {
+		keys    []jsonWebKey
+		expKIDs []string
+		expErr  error
+	}{
+		"no keys": {
+			expErr: errors.New("no usable signing keys"),
+		},
+		"only unusable keys": {
+			keys:   []jsonWebKey{badCurve, encKey},
+			expErr: errors.New("no usable signing keys"),
+		},
+		"mixed keys": {
+			keys: []jsonWebKey{
+				testRSAJWK(t, "rsa", rsaKey),
+				testECJWK(t, "ec", ecKey),
+				badCurve,
+				encKey,
+			},
+			expKIDs: []string{"ec", "rsa"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(jsonWebKeySet{Keys: tc.keys})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			keys, err := parseJWKS(data)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, len(tc.expKIDs), len(keys), "unexpected number of keys")
+			for _, kid := range tc.expKIDs {
+				if _, found := keys[kid]; !found {
+					t.Fatalf("key %q not found", kid)
+				}
+			}
+		})
+	}
+}
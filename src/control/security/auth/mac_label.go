@@ -0,0 +1,63 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"regexp"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+type macLabelRule struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// MACLabelPolicy names the MAC labels recorded in credentials by the agents, so
+// that ACL entries for the group "label=<name>@claim" apply to the processes
+// with the labels matching each name's pattern.
+type MACLabelPolicy struct {
+	rules []macLabelRule
+}
+
+// NewMACLabelPolicy compiles the MAC label configuration. If no labels are
+// configured, nil is returned, which names none.
+func NewMACLabelPolicy(cfgs []*security.MACLabelConfig) (*MACLabelPolicy, error) {
+	if len(cfgs) == 0 {
+		return nil, nil
+	}
+
+	policy := &MACLabelPolicy{}
+	for _, cfg := range cfgs {
+		if err := cfg.Validate(); err != nil {
+			return nil, err
+		}
+		pattern, err := cfg.Regexp()
+		if err != nil {
+			return nil, errors.Wrapf(err, "label %q", cfg.Name)
+		}
+		policy.rules = append(policy.rules, macLabelRule{name: cfg.Name, pattern: pattern})
+	}
+	return policy, nil
+}
+
+// Claims returns a label claim for each name whose pattern matches the label.
+func (p *MACLabelPolicy) Claims(label string) []*Claim {
+	if p == nil || label == "" {
+		return nil
+	}
+
+	var claims []*Claim
+	for _, rule := range p.rules {
+		if rule.pattern.MatchString(label) {
+			claims = append(claims, &Claim{Key: ClaimLabel, Value: rule.name})
+		}
+	}
+	return claims
+}
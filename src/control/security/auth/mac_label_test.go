@@ -0,0 +1,59 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_MACLabelPolicy(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfgs      []*security.MACLabelConfig
+		label     string
+		expClaims []string
+		expErr    error
+	}{
+		"none configured": {
+			label: "staff_u:staff_r:staff_t:s3",
+		},
+		"invalid": {
+			cfgs:   []*security.MACLabelConfig{{Name: "secret", Pattern: "(s3"}},
+			expErr: errors.New(`label "secret": pattern`),
+		},
+		"no label": {
+			cfgs: []*security.MACLabelConfig{{Name: "any", Pattern: ".*"}},
+		},
+		"matching names": {
+			cfgs: []*security.MACLabelConfig{
+				{Name: "confined", Pattern: `/opt/.* \(enforce\)`},
+				{Name: "complain", Pattern: `.* \(complain\)`},
+				{Name: "sim", Pattern: `/opt/sim/.*`},
+			},
+			label:     "/opt/sim/bin/solver (enforce)",
+			expClaims: []string{"label=confined@claim", "label=sim@claim"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			policy, err := NewMACLabelPolicy(tc.cfgs)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			var principals []string
+			for _, claim := range policy.Claims(tc.label) {
+				principals = append(principals, ClaimPrincipal(claim))
+			}
+			test.CmpAny(t, "claims", tc.expClaims, principals)
+		})
+	}
+}
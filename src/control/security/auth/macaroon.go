@@ -0,0 +1,210 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// This file implements the subset of macaroons (as described in "Macaroons:
+// Cookies with Contextual Caveats for Decentralized Authorization in the
+// Cloud") needed to verify macaroons with first-party caveats, in the V2
+// binary format shared by libmacaroons and gopkg.in/macaroon.v2.
+
+const (
+	macaroonVersion2 = 2
+
+	// V2 field types.
+	macaroonFieldEOS        = 0
+	macaroonFieldLocation   = 1
+	macaroonFieldIdentifier = 2
+	macaroonFieldVID        = 4
+	macaroonFieldSignature  = 6
+
+	// macaroonKeyGenerator is used to derive the signing key from a root
+	// key, as in libmacaroons.
+	macaroonKeyGenerator = "macaroons-key-generator"
+)
+
+type (
+	// macaroon is a decoded macaroon.
+	macaroon struct {
+		location  string
+		id        []byte
+		caveats   []macaroonCaveat
+		signature []byte
+	}
+
+	// macaroonCaveat is a caveat of a macaroon. Only first-party caveats,
+	// which have no verification ID, are supported.
+	macaroonCaveat struct {
+		id  []byte
+		vid []byte
+	}
+
+	macaroonField struct {
+		fieldType byte
+		data      []byte
+	}
+
+	macaroonReader struct {
+		buf []byte
+	}
+)
+
+func (r *macaroonReader) field() (*macaroonField, error) {
+	if len(r.buf) == 0 {
+		return nil, errors.New("unexpected end of macaroon")
+	}
+	f := &macaroonField{fieldType: r.buf[0]}
+	r.buf = r.buf[1:]
+	if f.fieldType == macaroonFieldEOS {
+		return f, nil
+	}
+
+	n, used := binary.Uvarint(r.buf)
+	if used <= 0 || n > uint64(len(r.buf)-used) {
+		return nil, errors.New("malformed macaroon field length")
+	}
+	f.data = r.buf[used : used+int(n)]
+	r.buf = r.buf[used+int(n):]
+	return f, nil
+}
+
+// section reads the fields up to the next end-of-section marker. Field types
+// must be in ascending order, as required by the format.
+func (r *macaroonReader) section() ([]*macaroonField, error) {
+	var fields []*macaroonField
+	for {
+		f, err := r.field()
+		if err != nil {
+			return nil, err
+		}
+		if f.fieldType == macaroonFieldEOS {
+			return fields, nil
+		}
+		if len(fields) > 0 && f.fieldType <= fields[len(fields)-1].fieldType {
+			return nil, errors.Errorf("unexpected macaroon field type %d", f.fieldType)
+		}
+		fields = append(fields, f)
+	}
+}
+
+// decodeMacaroon decodes a macaroon in the V2 binary format, which may be
+// base64-encoded.
+func decodeMacaroon(data []byte) (*macaroon, error) {
+	if len(data) > 0 && data[0] != macaroonVersion2 {
+		text := strings.TrimRight(strings.TrimSpace(string(data)), "=")
+		var err error
+		if data, err = base64.RawURLEncoding.DecodeString(text); err != nil {
+			if data, err = base64.RawStdEncoding.DecodeString(text); err != nil {
+				return nil, errors.Wrap(err, "malformed macaroon encoding")
+			}
+		}
+	}
+	if len(data) == 0 || data[0] != macaroonVersion2 {
+		return nil, errors.New("unsupported macaroon format; only V2 binary macaroons are supported")
+	}
+
+	r := &macaroonReader{buf: data[1:]}
+	m := &macaroon{}
+
+	header, err := r.section()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range header {
+		switch f.fieldType {
+		case macaroonFieldLocation:
+			m.location = string(f.data)
+		case macaroonFieldIdentifier:
+			m.id = f.data
+		default:
+			return nil, errors.Errorf("unexpected macaroon field type %d", f.fieldType)
+		}
+	}
+	if m.id == nil {
+		return nil, errors.New("macaroon has no identifier")
+	}
+
+	for {
+		fields, err := r.section()
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			break
+		}
+
+		var c macaroonCaveat
+		for _, f := range fields {
+			switch f.fieldType {
+			case macaroonFieldLocation:
+			case macaroonFieldIdentifier:
+				c.id = f.data
+			case macaroonFieldVID:
+				c.vid = f.data
+			default:
+				return nil, errors.Errorf("unexpected macaroon caveat field type %d", f.fieldType)
+			}
+		}
+		if c.id == nil {
+			return nil, errors.New("macaroon caveat has no identifier")
+		}
+		m.caveats = append(m.caveats, c)
+	}
+
+	sig, err := r.field()
+	if err != nil {
+		return nil, err
+	}
+	if sig.fieldType != macaroonFieldSignature || len(sig.data) != sha256.Size {
+		return nil, errors.New("malformed macaroon signature")
+	}
+	m.signature = sig.data
+	if len(r.buf) != 0 {
+		return nil, errors.New("trailing data after macaroon")
+	}
+
+	return m, nil
+}
+
+func macaroonHMAC(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// macaroonSignature computes the signature of a macaroon with the given
+// identifier and caveats, minted with the root key.
+func macaroonSignature(rootKey, id []byte, caveats []macaroonCaveat) []byte {
+	sig := macaroonHMAC(macaroonHMAC([]byte(macaroonKeyGenerator), rootKey), id)
+	for _, c := range caveats {
+		sig = macaroonHMAC(sig, c.id)
+	}
+	return sig
+}
+
+// verify checks that the macaroon was minted with the root key. Third-party
+// caveats are not supported, as there is no way to present discharges.
+func (m *macaroon) verify(rootKey []byte) error {
+	for _, c := range m.caveats {
+		if c.vid != nil {
+			return errors.New("macaroons with third-party caveats are not supported")
+		}
+	}
+	if !hmac.Equal(m.signature, macaroonSignature(rootKey, m.id, m.caveats)) {
+		return errors.New("invalid macaroon signature")
+	}
+	return nil
+}
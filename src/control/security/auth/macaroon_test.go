@@ -0,0 +1,127 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func macaroonAppendField(buf []byte, fieldType byte, data []byte) []byte {
+	buf = append(buf, fieldType)
+	buf = binary.AppendUvarint(buf, uint64(len(data)))
+	return append(buf, data...)
+}
+
+// testMintMacaroon encodes a macaroon with first-party caveats in the V2
+// binary format, as a site authority using libmacaroons would.
+func testMintMacaroon(rootKey []byte, id string, caveats ...string) []byte {
+	var cavs []macaroonCaveat
+	for _, c := range caveats {
+		cavs = append(cavs, macaroonCaveat{id: []byte(c)})
+	}
+
+	buf := []byte{macaroonVersion2}
+	buf = macaroonAppendField(buf, macaroonFieldLocation, []byte("https://authority.example.com"))
+	buf = macaroonAppendField(buf, macaroonFieldIdentifier, []byte(id))
+	buf = append(buf, macaroonFieldEOS)
+	for _, c := range cavs {
+		buf = macaroonAppendField(buf, macaroonFieldIdentifier, c.id)
+		buf = append(buf, macaroonFieldEOS)
+	}
+	buf = append(buf, macaroonFieldEOS)
+	return macaroonAppendField(buf, macaroonFieldSignature, macaroonSignature(rootKey, []byte(id), cavs))
+}
+
+func TestAuth_decodeMacaroon(t *testing.T) {
+	rootKey := []byte("0123456789abcdef0123456789abcdef")
+	minted := testMintMacaroon(rootKey, "id-1", "daos-user jdoe", "time-before 2030-01-01T00:00:00Z")
+
+	thirdParty := []byte{macaroonVersion2}
+	thirdParty = macaroonAppendField(thirdParty, macaroonFieldIdentifier, []byte("id-1"))
+	thirdParty = append(thirdParty, macaroonFieldEOS)
+	thirdParty = macaroonAppendField(thirdParty, macaroonFieldLocation, []byte("https://third.example.com"))
+	thirdParty = macaroonAppendField(thirdParty, macaroonFieldIdentifier, []byte("tp"))
+	thirdParty = macaroonAppendField(thirdParty, macaroonFieldVID, []byte("vid"))
+	thirdParty = append(thirdParty, macaroonFieldEOS, macaroonFieldEOS)
+	thirdParty = macaroonAppendField(thirdParty, macaroonFieldSignature, make([]byte, 32))
+
+	for name, tc := range map[string]struct {
+		data       []byte
+		rootKey    []byte
+		expCaveats int
+		expErr     error
+	}{
+		"binary": {
+			data:       minted,
+			rootKey:    rootKey,
+			expCaveats: 2,
+		},
+		"base64url": {
+			data:       []byte(base64.RawURLEncoding.EncodeToString(minted)),
+			rootKey:    rootKey,
+			expCaveats: 2,
+		},
+		"padded base64": {
+			data:       []byte(base64.StdEncoding.EncodeToString(minted) + "\n"),
+			rootKey:    rootKey,
+			expCaveats: 2,
+		},
+		"wrong root key": {
+			data:       minted,
+			rootKey:    []byte("fedcba9876543210fedcba9876543210"),
+			expCaveats: 2,
+			expErr:     errors.New("invalid macaroon signature"),
+		},
+		"truncated": {
+			data:   minted[:len(minted)-1],
+			expErr: errors.New("malformed macaroon field length"),
+		},
+		"V1 format": {
+			data:   []byte("MDAxY2xvY2F0aW9uIGh0dHBzOi8vZXhhbXBsZS5jb20K"),
+			expErr: errors.New("only V2 binary macaroons"),
+		},
+		"third-party caveat": {
+			data:       thirdParty,
+			rootKey:    rootKey,
+			expCaveats: 1,
+			expErr:     errors.New("third-party caveats are not supported"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			m, err := decodeMacaroon(tc.data)
+			if err == nil {
+				test.AssertEqual(t, tc.expCaveats, len(m.caveats), "unexpected number of caveats")
+				err = m.verify(tc.rootKey)
+			}
+			test.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestAuth_macaroon_attenuation(t *testing.T) {
+	rootKey := []byte("0123456789abcdef0123456789abcdef")
+	m, err := decodeMacaroon(testMintMacaroon(rootKey, "id-1", "daos-user jdoe"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Anyone holding a macaroon can add a caveat by chaining its signature,
+	// without knowing the root key.
+	m.caveats = append(m.caveats, macaroonCaveat{id: []byte("daos-pools tank")})
+	m.signature = macaroonHMAC(m.signature, []byte("daos-pools tank"))
+	test.CmpErr(t, nil, m.verify(rootKey))
+
+	// But cannot remove one.
+	m.caveats = m.caveats[1:]
+	test.CmpErr(t, errors.New("invalid macaroon signature"), m.verify(rootKey))
+}
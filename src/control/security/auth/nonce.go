@@ -0,0 +1,249 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	// nonceMinSecretLen is the minimum length of the secret with which
+	// nonces are authenticated.
+	nonceMinSecretLen = 32
+	nonceRandomLen    = 16
+	nonceMACLen       = 16
+	// nonceLen is the length of a nonce: the time it was issued, random
+	// bytes and the MAC of both.
+	nonceLen = 8 + nonceRandomLen + nonceMACLen
+)
+
+// NewNonceCredential re-issues the credential with the nonce recorded in its
+// token, re-signing it with the supplied key. Each nonce issued by the servers
+// is accepted in only one credential, so a new one is recorded in each
+// credential the agent hands out.
+func NewNonceCredential(cred *Credential, key crypto.PrivateKey, nonce []byte) (*Credential, error) {
+	if cred == nil || cred.GetToken() == nil {
+		return nil, errors.New("nil credential")
+	}
+
+	token := proto.Clone(cred.GetToken()).(*Token)
+	token.Nonce = nonce
+	verifier, err := newVerifier(key, token)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceCred := proto.Clone(cred).(*Credential)
+	nonceCred.Token = token
+	nonceCred.Verifier = verifier
+	return nonceCred, nil
+}
+
+// NonceIssuer issues the nonces which agents record in credentials, and checks
+// the nonces recorded in the credentials presented to the server. A nonce is
+// authenticated with a secret shared by all of the servers, so that a nonce
+// issued by any of them is accepted by all, and expires once its lifetime has
+// passed. The nonces which have been used are remembered until they expire, so
+// that a credential presented to the server a second time is rejected. Each
+// server remembers the nonces presented to it, so a credential may still be
+// presented once to each server before its nonce expires.
+type NonceIssuer struct {
+	sync.Mutex
+	secret   []byte
+	lifetime time.Duration
+	skew     time.Duration
+	required bool
+	now      func() time.Time
+	accepted map[string]time.Time
+}
+
+// NewNonceIssuer loads the secret with which nonces are authenticated. Nonces
+// are checked allowing for the clock skew given between the servers. If nonces
+// are not configured, nil is returned, which requires none.
+func NewNonceIssuer(cfg *security.CredentialNonceConfig, skew time.Duration) (*NonceIssuer, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	fi, err := os.Stat(cfg.SecretFile)
+	if err != nil {
+		return nil, err
+	}
+	if fi.Mode().Perm()&0077 != 0 {
+		return nil, errors.Errorf("nonce secret file %q is accessible by group or others", cfg.SecretFile)
+	}
+	secret, err := os.ReadFile(cfg.SecretFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(secret) < nonceMinSecretLen {
+		return nil, errors.Errorf("nonce secret file %q must hold at least %d bytes", cfg.SecretFile, nonceMinSecretLen)
+	}
+
+	return newNonceIssuer(secret, cfg.NonceLifetime(), skew, cfg.Required), nil
+}
+
+func newNonceIssuer(secret []byte, lifetime, skew time.Duration, required bool) *NonceIssuer {
+	return &NonceIssuer{
+		secret:   secret,
+		lifetime: lifetime,
+		skew:     skew,
+		required: required,
+		now:      time.Now,
+		accepted: make(map[string]time.Time),
+	}
+}
+
+func (ni *NonceIssuer) mac(issued, random []byte) []byte {
+	mac := hmac.New(sha256.New, ni.secret)
+	mac.Write(issued)
+	mac.Write(random)
+	return mac.Sum(nil)[:nonceMACLen]
+}
+
+// Issue returns a new nonce, and the time after which it is no longer
+// accepted.
+func (ni *NonceIssuer) Issue() ([]byte, time.Time, error) {
+	nonces, expires, err := ni.IssueN(1)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return nonces[0], expires, nil
+}
+
+// IssueN returns count new nonces, all issued at the same time, and the time
+// after which they are no longer accepted.
+func (ni *NonceIssuer) IssueN(count int) ([][]byte, time.Time, error) {
+	if ni == nil {
+		return nil, time.Time{}, errors.New("credential nonces are not enabled")
+	}
+	if count < 1 {
+		return nil, time.Time{}, errors.Errorf("invalid nonce count %d", count)
+	}
+
+	now := ni.now()
+	issued := make([]byte, 8)
+	binary.BigEndian.PutUint64(issued, uint64(now.Unix()))
+	nonces := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		random := make([]byte, nonceRandomLen)
+		if _, err := rand.Read(random); err != nil {
+			return nil, time.Time{}, errors.Wrap(err, "failed to generate nonce")
+		}
+		nonce := make([]byte, 0, nonceLen)
+		nonce = append(nonce, issued...)
+		nonce = append(nonce, random...)
+		nonce = append(nonce, ni.mac(issued, random)...)
+		nonces = append(nonces, nonce)
+	}
+
+	return nonces, time.Unix(now.Unix(), 0).Add(ni.lifetime), nil
+}
+
+// prune forgets the accepted nonces which have expired.
+func (ni *NonceIssuer) prune(now time.Time) {
+	for nonce, expires := range ni.accepted {
+		if !now.Before(expires) {
+			delete(ni.accepted, nonce)
+		}
+	}
+}
+
+// Check checks that the nonce recorded in the token was issued by one of the
+// servers, has not expired and has not already been used. A token which
+// records no nonce is accepted unless nonces are required. The nonce must have
+// been checked to be authentic, by verifying the credential, beforehand.
+func (ni *NonceIssuer) Check(token *Token) error {
+	return ni.check(token, false)
+}
+
+// Use checks the nonce recorded in the token as Check does, and records it as
+// used, so that the nonce is rejected if it is presented again before it
+// expires.
+func (ni *NonceIssuer) Use(token *Token) error {
+	return ni.check(token, true)
+}
+
+func (ni *NonceIssuer) check(token *Token, use bool) error {
+	if ni == nil {
+		return nil
+	}
+	nonce := token.GetNonce()
+	if len(nonce) == 0 {
+		if ni.required {
+			return errors.New("credential was not signed with a nonce")
+		}
+		return nil
+	}
+
+	ni.Lock()
+	defer ni.Unlock()
+
+	now := ni.now()
+	ni.prune(now)
+	if _, found := ni.accepted[string(nonce)]; found {
+		return errors.New("credential has a nonce which has already been used")
+	}
+
+	if len(nonce) != nonceLen {
+		return errors.New("credential has a malformed nonce")
+	}
+	issuedBytes, random, mac := nonce[:8], nonce[8:8+nonceRandomLen], nonce[8+nonceRandomLen:]
+	if !hmac.Equal(mac, ni.mac(issuedBytes, random)) {
+		return errors.New("credential has a nonce which was not issued by the servers")
+	}
+
+	// Nonces are checked by servers other than the one which issued them,
+	// so allow for the skew between their clocks.
+	skew := ni.skew
+	issued := nonceIssued(nonce)
+	if issued.After(now.Add(skew)) {
+		return errors.Errorf("credential has a nonce issued in the future (%s)", issued.UTC().Format(time.RFC3339))
+	}
+	expires := issued.Add(ni.lifetime)
+	if !now.Add(-skew).Before(expires) {
+		return errors.Wrapf(daos.CredentialExpired, "credential has a nonce which expired at %s",
+			expires.UTC().Format(time.RFC3339))
+	}
+
+	// The nonce is remembered for as long as any server could accept it.
+	if use {
+		ni.accepted[string(nonce)] = expires.Add(skew)
+	}
+	return nil
+}
+
+// nonceIssued returns the time at which the well-formed nonce was issued.
+func nonceIssued(nonce []byte) time.Time {
+	return time.Unix(int64(binary.BigEndian.Uint64(nonce[:8])), 0)
+}
+
+// SkewCorrection returns how far the current time is outside the lifetime of
+// the nonce recorded in the token, or zero if it is within it or the token
+// records no nonce. A nonce accepted with a correction was only accepted
+// because of the clock skew allowed, and suggests that the clocks of the
+// servers disagree.
+func (ni *NonceIssuer) SkewCorrection(token *Token) time.Duration {
+	if ni == nil || len(token.GetNonce()) != nonceLen {
+		return 0
+	}
+	issued := nonceIssued(token.GetNonce())
+	return skewCorrection(ni.now(), issued, issued.Add(ni.lifetime))
+}
@@ -0,0 +1,277 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_NewNonceIssuer(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeSecret := func(name string, size int, mode os.FileMode) string {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, bytes.Repeat([]byte{'s'}, size), mode); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	for name, tc := range map[string]struct {
+		cfg       *security.CredentialNonceConfig
+		expNil    bool
+		expErr    error
+		expExpiry time.Duration
+	}{
+		"not configured": {
+			expNil: true,
+		},
+		"default lifetime": {
+			cfg:       &security.CredentialNonceConfig{SecretFile: writeSecret("good", 32, 0600)},
+			expExpiry: security.DefaultCredentialNonceLifetime,
+		},
+		"custom lifetime": {
+			cfg: &security.CredentialNonceConfig{
+				SecretFile: writeSecret("custom", 64, 0400),
+				Lifetime:   time.Minute,
+			},
+			expExpiry: time.Minute,
+		},
+		"missing secret": {
+			cfg:    &security.CredentialNonceConfig{SecretFile: filepath.Join(tmpDir, "missing")},
+			expErr: errors.New("no such file"),
+		},
+		"short secret": {
+			cfg:    &security.CredentialNonceConfig{SecretFile: writeSecret("short", 16, 0600)},
+			expErr: errors.New("must hold at least 32 bytes"),
+		},
+		"readable secret": {
+			cfg:    &security.CredentialNonceConfig{SecretFile: writeSecret("readable", 32, 0640)},
+			expErr: errors.New("accessible by group or others"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ni, err := NewNonceIssuer(tc.cfg, time.Minute)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertEqual(t, tc.expNil, ni == nil, "unexpected issuer")
+			if ni == nil {
+				return
+			}
+
+			nonce, expires, err := ni.Issue()
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, nonceLen, len(nonce), "unexpected nonce length")
+			test.AssertTrue(t, time.Until(expires) <= tc.expExpiry, "nonce expires too late")
+			test.AssertTrue(t, time.Until(expires) > tc.expExpiry-2*time.Second, "nonce expires too early")
+		})
+	}
+}
+
+func TestAuth_NonceIssuer_Check(t *testing.T) {
+	secret := bytes.Repeat([]byte{'s'}, nonceMinSecretLen)
+	now := time.Unix(1700000000, 0)
+
+	issue := func(t *testing.T, secret []byte, issued time.Time) []byte {
+		t.Helper()
+		ni := newNonceIssuer(secret, time.Hour, time.Minute, false)
+		ni.now = func() time.Time { return issued }
+		nonce, _, err := ni.Issue()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return nonce
+	}
+
+	for name, tc := range map[string]struct {
+		nonce    func(t *testing.T) []byte
+		required bool
+		expErr   error
+	}{
+		"no nonce": {
+			nonce: func(*testing.T) []byte { return nil },
+		},
+		"no nonce when required": {
+			nonce:    func(*testing.T) []byte { return nil },
+			required: true,
+			expErr:   errors.New("not signed with a nonce"),
+		},
+		"valid": {
+			nonce: func(t *testing.T) []byte {
+				return issue(t, secret, now.Add(-30*time.Minute))
+			},
+			required: true,
+		},
+		"expired": {
+			nonce: func(t *testing.T) []byte {
+				return issue(t, secret, now.Add(-2*time.Hour))
+			},
+			expErr: errors.New("nonce which expired at 2023-11-14T21:13:20Z"),
+		},
+		"issued in the future": {
+			nonce: func(t *testing.T) []byte {
+				return issue(t, secret, now.Add(time.Hour))
+			},
+			expErr: errors.New("nonce issued in the future"),
+		},
+		"other secret": {
+			nonce: func(t *testing.T) []byte {
+				return issue(t, bytes.Repeat([]byte{'o'}, nonceMinSecretLen), now)
+			},
+			expErr: errors.New("not issued by the servers"),
+		},
+		"modified issue time": {
+			nonce: func(t *testing.T) []byte {
+				nonce := issue(t, secret, now.Add(-2*time.Hour))
+				copy(nonce, issue(t, secret, now)[:8])
+				return nonce
+			},
+			expErr: errors.New("not issued by the servers"),
+		},
+		"malformed": {
+			nonce: func(*testing.T) []byte {
+				return []byte("nonce")
+			},
+			expErr: errors.New("malformed nonce"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ni := newNonceIssuer(secret, time.Hour, time.Minute, tc.required)
+			ni.now = func() time.Time { return now }
+
+			token := &Token{Nonce: tc.nonce(t)}
+			test.CmpErr(t, tc.expErr, ni.Check(token))
+			if tc.expErr != nil || token.Nonce == nil {
+				return
+			}
+
+			// Checking the nonce does not use it up.
+			test.AssertEqual(t, 0, len(ni.accepted), "checked nonce remembered")
+			test.CmpErr(t, nil, ni.Check(token))
+
+			// A used nonce is rejected if it is presented again, until
+			// it expires.
+			test.CmpErr(t, nil, ni.Use(token))
+			test.AssertEqual(t, 1, len(ni.accepted), "nonce not remembered")
+			test.CmpErr(t, errors.New("already been used"), ni.Check(token))
+			test.CmpErr(t, errors.New("already been used"), ni.Use(token))
+			ni.now = func() time.Time { return now.Add(2 * time.Hour) }
+			test.CmpErr(t, errors.New("expired"), ni.Use(token))
+			test.AssertEqual(t, 0, len(ni.accepted), "expired nonce not forgotten")
+		})
+	}
+
+	var nilIssuer *NonceIssuer
+	test.CmpErr(t, nil, nilIssuer.Check(&Token{}))
+	test.CmpErr(t, nil, nilIssuer.Use(&Token{}))
+}
+
+func TestAuth_NonceIssuer_IssueN(t *testing.T) {
+	secret := bytes.Repeat([]byte{'s'}, nonceMinSecretLen)
+	ni := newNonceIssuer(secret, time.Hour, time.Minute, true)
+
+	nonces, _, err := ni.IssueN(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 3, len(nonces), "unexpected number of nonces")
+
+	// Each nonce is accepted once.
+	for _, nonce := range nonces {
+		test.CmpErr(t, nil, ni.Use(&Token{Nonce: nonce}))
+	}
+	test.AssertEqual(t, 3, len(ni.accepted), "nonces not distinct")
+
+	_, _, err = ni.IssueN(0)
+	test.CmpErr(t, errors.New("invalid nonce count"), err)
+}
+
+func TestAuth_NonceIssuer_SkewCorrection(t *testing.T) {
+	secret := bytes.Repeat([]byte{'s'}, nonceMinSecretLen)
+	now := time.Unix(1700000000, 0)
+
+	for name, tc := range map[string]struct {
+		issued  time.Time
+		expCorr time.Duration
+	}{
+		"within lifetime": {
+			issued: now.Add(-30 * time.Minute),
+		},
+		"expired within skew": {
+			issued:  now.Add(-time.Hour - 20*time.Second),
+			expCorr: 20 * time.Second,
+		},
+		"issued in the future within skew": {
+			issued:  now.Add(40 * time.Second),
+			expCorr: 40 * time.Second,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ni := newNonceIssuer(secret, time.Hour, time.Minute, false)
+			ni.now = func() time.Time { return tc.issued }
+			nonce, _, err := ni.Issue()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ni.now = func() time.Time { return now }
+			token := &Token{Nonce: nonce}
+			test.CmpErr(t, nil, ni.Check(token))
+			test.AssertEqual(t, tc.expCorr, ni.SkewCorrection(token), "unexpected correction")
+		})
+	}
+
+	var nilIssuer *NonceIssuer
+	test.AssertEqual(t, time.Duration(0), nilIssuer.SkewCorrection(&Token{}), "unexpected correction")
+}
+
+func TestAuth_NewNonceCredential(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cred, err := newSignedCredential(Flavor_AUTH_SYS, key, &Sys{User: "user@"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 0, len(cred.GetToken().GetNonce()), "unexpected nonce")
+
+	nonce := []byte("issued-nonce")
+	nonceCred, err := NewNonceCredential(cred, key, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, nonce, nonceCred.GetToken().GetNonce(), "nonce not recorded")
+	test.AssertEqual(t, cred.GetKeyId(), nonceCred.GetKeyId(), "key ID not kept")
+	test.AssertEqual(t, 0, len(cred.GetToken().GetNonce()), "original credential modified")
+	test.CmpErr(t, nil, VerifyCredential(key.Public(), nonceCred))
+
+	// The nonce is covered by the verifier.
+	nonceCred.Token.Nonce = []byte("other-nonce")
+	if err := VerifyCredential(key.Public(), nonceCred); err == nil {
+		t.Fatal("verification succeeded after the nonce was replaced")
+	}
+
+	_, err = NewNonceCredential(nil, key, nonce)
+	test.CmpErr(t, errors.New("nil credential"), err)
+}
@@ -0,0 +1,150 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// OneTimeCredentialLifetime is the period after issuance during which a
+	// one-time credential will be accepted. The server remembers consumed
+	// credentials for this long, after which they are rejected as stale.
+	OneTimeCredentialLifetime = 2 * time.Minute
+
+	oneTimeNonceLen = 16
+)
+
+// sysFromToken unpacks the Sys structure carried by tokens of any flavor.
+func sysFromToken(token *Token) (*Sys, error) {
+	if token == nil {
+		return nil, errors.New("nil token")
+	}
+
+	sys := &Sys{}
+	if err := proto.Unmarshal(token.GetData(), sys); err != nil {
+		return nil, errors.Wrapf(err, "unmarshaling %s", token.GetFlavor())
+	}
+	return sys, nil
+}
+
+// NewOneTimeCredential re-issues the identity asserted by the supplied
+// credential as a single-use credential. The new token is stamped with its
+// issue time and a random nonce, so each one-time credential is distinct even
+// if the original credential was served from a cache.
+func NewOneTimeCredential(cred *Credential, key crypto.PrivateKey) (*Credential, error) {
+	if cred == nil {
+		return nil, errors.New("nil credential")
+	}
+
+	sys, err := sysFromToken(cred.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, oneTimeNonceLen)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+
+	sys.Scope = Scope_SCOPE_ONE_TIME
+	sys.Stamp = uint64(time.Now().Unix())
+	sys.Nonce = nonce
+
+	oneTime, err := newSignedCredential(cred.GetToken().GetFlavor(), key, sys)
+	if err != nil {
+		return nil, err
+	}
+	oneTime.Origin = cred.Origin
+
+	return oneTime, nil
+}
+
+// ConsumptionRecord remembers which one-time credentials have been presented
+// so that they cannot be replayed.
+//
+// The record is held in memory by each server, and shared by the engines it
+// runs, so a one-time credential is accepted once by each server rather than
+// once throughout the system. As the record is lost when the server restarts,
+// credentials issued before the record began are rejected.
+type ConsumptionRecord struct {
+	sync.Mutex
+	lifetime time.Duration
+	now      func() time.Time
+	since    time.Time
+	consumed map[string]time.Time
+}
+
+// NewConsumptionRecord returns a ConsumptionRecord that accepts one-time
+// credentials issued within the given lifetime.
+func NewConsumptionRecord(lifetime time.Duration) *ConsumptionRecord {
+	return &ConsumptionRecord{
+		lifetime: lifetime,
+		now:      time.Now,
+		since:    time.Now(),
+		consumed: make(map[string]time.Time),
+	}
+}
+
+// prune removes entries for credentials which are too old to be accepted
+// anyway. The caller must hold the lock.
+func (cr *ConsumptionRecord) prune(now time.Time) {
+	for key, expiresAt := range cr.consumed {
+		if now.After(expiresAt) {
+			delete(cr.consumed, key)
+		}
+	}
+}
+
+// Consume marks a verified one-time credential as used. An error is returned
+// if the credential has already been consumed or is no longer fresh.
+// Credentials that are not one-time are ignored.
+func (cr *ConsumptionRecord) Consume(cred *Credential) error {
+	if cr == nil {
+		return nil
+	}
+
+	sys, err := sysFromToken(cred.GetToken())
+	if err != nil {
+		return err
+	}
+	if sys.Scope != Scope_SCOPE_ONE_TIME {
+		return nil
+	}
+
+	now := cr.now()
+	issuedAt := time.Unix(int64(sys.Stamp), 0)
+	if issuedAt.Add(cr.lifetime).Before(now) || issuedAt.After(now.Add(cr.lifetime)) {
+		return errors.Errorf("one-time credential issued at %s is not fresh", issuedAt)
+	}
+	// Issue times are recorded to the second.
+	if issuedAt.Add(time.Second).Before(cr.since) {
+		return errors.Errorf("one-time credential issued at %s, before the server started", issuedAt)
+	}
+
+	sum := sha256.Sum256(cred.GetVerifier().GetData())
+	key := hex.EncodeToString(sum[:])
+
+	cr.Lock()
+	defer cr.Unlock()
+
+	cr.prune(now)
+	if _, found := cr.consumed[key]; found {
+		return errors.New("one-time credential has already been used")
+	}
+	cr.consumed[key] = issuedAt.Add(cr.lifetime)
+
+	return nil
+}
@@ -0,0 +1,146 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func testOneTimeCredential(t *testing.T, key *rsa.PrivateKey, base *Sys) *Credential {
+	t.Helper()
+
+	cred, err := newSignedCredential(Flavor_AUTH_SYS, key, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oneTime, err := NewOneTimeCredential(cred, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return oneTime
+}
+
+func TestAuth_NewOneTimeCredential(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewOneTimeCredential(nil, key)
+	test.CmpErr(t, errors.New("nil credential"), err)
+
+	base := &Sys{Machinename: "host", User: "user@", Group: "group@"}
+	cred1 := testOneTimeCredential(t, key, base)
+	cred2 := testOneTimeCredential(t, key, base)
+
+	for _, cred := range []*Credential{cred1, cred2} {
+		if err := VerifyToken(&key.PublicKey, cred.Token, cred.Verifier.Data); err != nil {
+			t.Fatal(err)
+		}
+		sys, err := sysFromToken(cred.Token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		test.AssertEqual(t, Scope_SCOPE_ONE_TIME, sys.Scope, "unexpected scope")
+		test.AssertEqual(t, base.User, sys.User, "unexpected user")
+		test.AssertEqual(t, oneTimeNonceLen, len(sys.Nonce), "unexpected nonce length")
+		test.AssertTrue(t, sys.Stamp > 0, "expected issue stamp to be set")
+	}
+
+	test.AssertFalse(t, bytes.Equal(cred1.Token.Data, cred2.Token.Data), "expected distinct one-time tokens")
+}
+
+func TestAuth_ConsumptionRecord_Consume(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := &Sys{Machinename: "host", User: "user@", Group: "group@"}
+
+	reusable, err := newSignedCredential(Flavor_AUTH_SYS, key, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		creds     []*Credential
+		elapsed   time.Duration
+		restarted bool
+		expErr    error
+	}{
+		"reusable credential presented twice": {
+			creds: []*Credential{reusable, reusable},
+		},
+		"one-time credential presented once": {
+			creds: []*Credential{testOneTimeCredential(t, key, base)},
+		},
+		"distinct one-time credentials": {
+			creds: []*Credential{
+				testOneTimeCredential(t, key, base),
+				testOneTimeCredential(t, key, base),
+			},
+		},
+		"one-time credential replayed": {
+			creds: func() []*Credential {
+				cred := testOneTimeCredential(t, key, base)
+				return []*Credential{cred, cred}
+			}(),
+			expErr: errors.New("already been used"),
+		},
+		"stale one-time credential": {
+			creds:   []*Credential{testOneTimeCredential(t, key, base)},
+			elapsed: OneTimeCredentialLifetime + time.Minute,
+			expErr:  errors.New("not fresh"),
+		},
+		"one-time credential issued before restart": {
+			creds:     []*Credential{testOneTimeCredential(t, key, base)},
+			restarted: true,
+			expErr:    errors.New("before the server started"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cr := NewConsumptionRecord(OneTimeCredentialLifetime)
+			cr.now = func() time.Time { return time.Now().Add(tc.elapsed) }
+			if tc.restarted {
+				cr.since = time.Now().Add(30 * time.Second)
+			}
+
+			var err error
+			for _, cred := range tc.creds {
+				if err = cr.Consume(cred); err != nil {
+					break
+				}
+			}
+			test.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestAuth_ConsumptionRecord_PerServer(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cred := testOneTimeCredential(t, key, &Sys{Machinename: "host", User: "user@", Group: "group@"})
+
+	// Each server keeps its own record, so a one-time credential is
+	// accepted once by each of them.
+	server1 := NewConsumptionRecord(OneTimeCredentialLifetime)
+	server2 := NewConsumptionRecord(OneTimeCredentialLifetime)
+	test.CmpErr(t, nil, server1.Consume(cred))
+	test.CmpErr(t, nil, server2.Consume(cred))
+	test.CmpErr(t, errors.New("already been used"), server1.Consume(cred))
+	test.CmpErr(t, errors.New("already been used"), server2.Consume(cred))
+}
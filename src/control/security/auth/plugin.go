@@ -0,0 +1,125 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"plugin"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const (
+	// PluginFactoriesSymbol is the name of the function a flavor plugin must
+	// export. It must be of type func() []auth.CredentialRequestFactory.
+	PluginFactoriesSymbol = "CredentialRequestFactories"
+
+	// MinPluginFlavor is the lowest flavor number that may be provided by a
+	// plugin. Lower numbers are reserved for the flavors built into DAOS.
+	// Plugin flavors carry a Sys token, as the built-in flavors do.
+	MinPluginFlavor Flavor = 1024
+)
+
+type (
+	// pluginSymbols looks up a symbol exported by a plugin.
+	pluginSymbols interface {
+		Lookup(symName string) (plugin.Symbol, error)
+	}
+
+	openPluginFn func(path string) (pluginSymbols, error)
+)
+
+var (
+	openPlugin openPluginFn = func(path string) (pluginSymbols, error) {
+		return plugin.Open(path)
+	}
+
+	pluginFlavors []Flavor
+)
+
+// loadPluginFactories opens the plugin at the path and returns the credential
+// factories it provides.
+func loadPluginFactories(path string) ([]CredentialRequestFactory, error) {
+	p, err := openPlugin(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening flavor plugin %q", path)
+	}
+
+	sym, err := p.Lookup(PluginFactoriesSymbol)
+	if err != nil {
+		return nil, errors.Wrapf(err, "flavor plugin %q", path)
+	}
+	getFactories, ok := sym.(func() []CredentialRequestFactory)
+	if !ok {
+		return nil, errors.Errorf("flavor plugin %q: %s is %T, not func() []auth.CredentialRequestFactory",
+			path, PluginFactoriesSymbol, sym)
+	}
+
+	factories := getFactories()
+	if len(factories) == 0 {
+		return nil, errors.Errorf("flavor plugin %q provides no credential factories", path)
+	}
+	for _, factory := range factories {
+		if factory == nil {
+			return nil, errors.Errorf("flavor plugin %q provides a nil credential factory", path)
+		}
+		if factory.GetAuthFlavor() < MinPluginFlavor {
+			return nil, errors.Errorf("flavor plugin %q: flavor %s is reserved for built-in flavors (plugin flavors must be at least %d)",
+				path, factory.GetAuthFlavor(), MinPluginFlavor)
+		}
+	}
+	return factories, nil
+}
+
+// LoadPlugins loads the credential factories provided by the Go plugins at the
+// paths, and makes their flavors available to the agent. Plugins must be built
+// with the same version of DAOS as the agent, and the credentials they sign
+// must carry a Sys token, as the servers check the identity asserted in it and
+// the engines check access against it. It must be called before any
+// credentials are requested.
+func LoadPlugins(log logging.Logger, paths []string) error {
+	var factories []CredentialRequestFactory
+	for _, path := range paths {
+		loaded, err := loadPluginFactories(path)
+		if err != nil {
+			return err
+		}
+		for _, factory := range loaded {
+			log.Noticef("loaded %s credential factory %T from plugin %q", factory.GetAuthFlavor(), factory, path)
+		}
+		factories = append(factories, loaded...)
+	}
+	if len(factories) == 0 {
+		return nil
+	}
+
+	authMap, err := generateAuthMap(FlavorToFactory, factories...)
+	if err != nil {
+		return errors.Wrap(err, "loading flavor plugins")
+	}
+	FlavorToFactory = authMap
+	for _, factory := range factories {
+		pluginFlavors = append(pluginFlavors, factory.GetAuthFlavor())
+	}
+
+	return nil
+}
+
+// PluginFlavors returns the flavors loaded from plugins.
+func PluginFlavors() []Flavor {
+	return pluginFlavors
+}
+
+// ValidateSysToken checks that the token carries a Sys token, as the tokens of
+// all flavors, including plugin flavors, must.
+func ValidateSysToken(token *Token) error {
+	if _, err := sysFromToken(token); err != nil {
+		return errors.Wrapf(err, "%s credential does not carry a Sys token", token.GetFlavor())
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto"
+	"plugin"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+type testPluginFactory struct {
+	flavor Flavor
+}
+
+func (f *testPluginFactory) Init(logging.Logger, *security.CredentialConfig, *drpc.Session, []byte, crypto.PrivateKey) (CredentialRequest, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *testPluginFactory) GetAuthFlavor() Flavor {
+	return f.flavor
+}
+
+type testPlugin map[string]plugin.Symbol
+
+func (p testPlugin) Lookup(name string) (plugin.Symbol, error) {
+	sym, found := p[name]
+	if !found {
+		return nil, errors.Errorf("symbol %s not found", name)
+	}
+	return sym, nil
+}
+
+func testPluginProviding(factories ...CredentialRequestFactory) testPlugin {
+	return testPlugin{
+		PluginFactoriesSymbol: func() []CredentialRequestFactory { return factories },
+	}
+}
+
+func TestAuth_generateAuthMap(t *testing.T) {
+	base := AuthMap{Flavor_AUTH_ANON: &AuthAnonCredentialFactory{}}
+
+	for name, tc := range map[string]struct {
+		factories  []CredentialRequestFactory
+		expFlavors []Flavor
+		expErr     error
+	}{
+		"no factories": {
+			expFlavors: []Flavor{Flavor_AUTH_ANON},
+		},
+		"new flavor": {
+			factories:  []CredentialRequestFactory{&testPluginFactory{flavor: 1024}},
+			expFlavors: []Flavor{Flavor_AUTH_ANON, 1024},
+		},
+		"collides with base": {
+			factories: []CredentialRequestFactory{&testPluginFactory{flavor: Flavor_AUTH_ANON}},
+			expErr:    errors.New("AUTH_ANON of *auth.testPluginFactory is already provided by *auth.AuthAnonCredentialFactory"),
+		},
+		"collides with another factory": {
+			factories: []CredentialRequestFactory{&testPluginFactory{flavor: 1024}, &testPluginFactory{flavor: 1024}},
+			expErr:    errors.New("already provided"),
+		},
+		"nil factory": {
+			factories: []CredentialRequestFactory{nil},
+			expErr:    errors.New("nil credential factory"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			authMap, err := generateAuthMap(base, tc.factories...)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, len(tc.expFlavors), len(authMap), "unexpected number of flavors")
+			for _, flavor := range tc.expFlavors {
+				if _, found := authMap[flavor]; !found {
+					t.Fatalf("flavor %s missing from map", flavor)
+				}
+			}
+			test.AssertEqual(t, 1, len(base), "base map was modified")
+		})
+	}
+}
+
+func TestAuth_LoadPlugins(t *testing.T) {
+	for name, tc := range map[string]struct {
+		plugins    map[string]testPlugin
+		paths      []string
+		expFlavors []Flavor
+		expErr     error
+	}{
+		"no plugins": {},
+		"open fails": {
+			paths:  []string{"/missing.so"},
+			expErr: errors.New("opening flavor plugin \"/missing.so\""),
+		},
+		"missing symbol": {
+			plugins: map[string]testPlugin{"/a.so": {}},
+			paths:   []string{"/a.so"},
+			expErr:  errors.New("symbol CredentialRequestFactories not found"),
+		},
+		"wrong symbol type": {
+			plugins: map[string]testPlugin{"/a.so": {PluginFactoriesSymbol: func() {}}},
+			paths:   []string{"/a.so"},
+			expErr:  errors.New("not func() []auth.CredentialRequestFactory"),
+		},
+		"no factories": {
+			plugins: map[string]testPlugin{"/a.so": testPluginProviding()},
+			paths:   []string{"/a.so"},
+			expErr:  errors.New("provides no credential factories"),
+		},
+		"reserved flavor": {
+			plugins: map[string]testPlugin{"/a.so": testPluginProviding(&testPluginFactory{flavor: 100})},
+			paths:   []string{"/a.so"},
+			expErr:  errors.New("reserved for built-in flavors"),
+		},
+		"plugins collide": {
+			plugins: map[string]testPlugin{
+				"/a.so": testPluginProviding(&testPluginFactory{flavor: 1024}),
+				"/b.so": testPluginProviding(&testPluginFactory{flavor: 1024}),
+			},
+			paths:  []string{"/a.so", "/b.so"},
+			expErr: errors.New("already provided"),
+		},
+		"success": {
+			plugins: map[string]testPlugin{
+				"/a.so": testPluginProviding(&testPluginFactory{flavor: 1024}, &testPluginFactory{flavor: 1025}),
+				"/b.so": testPluginProviding(&testPluginFactory{flavor: 2048}),
+			},
+			paths:      []string{"/a.so", "/b.so"},
+			expFlavors: []Flavor{1024, 1025, 2048},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			origMap, origOpen := FlavorToFactory, openPlugin
+			defer func() {
+				FlavorToFactory, openPlugin, pluginFlavors = origMap, origOpen, nil
+			}()
+			openPlugin = func(path string) (pluginSymbols, error) {
+				if p, found := tc.plugins[path]; found {
+					return p, nil
+				}
+				return nil, errors.New("no such file")
+			}
+
+			err := LoadPlugins(log, tc.paths)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				test.AssertEqual(t, len(origMap), len(FlavorToFactory), "flavors changed after failure")
+				return
+			}
+
+			test.CmpAny(t, "plugin flavors", tc.expFlavors, PluginFlavors())
+			test.AssertEqual(t, len(origMap)+len(tc.expFlavors), len(FlavorToFactory), "unexpected number of flavors")
+		})
+	}
+}
+
+func TestAuth_ParseValidAuthFlavors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in     []string
+		exp    []Flavor
+		expErr error
+	}{
+		"names": {
+			in:  []string{"sys", "AUTH_ANON"},
+			exp: []Flavor{Flavor_AUTH_SYS, Flavor_AUTH_ANON},
+		},
+		"plugin flavor": {
+			in:  []string{"AUTH_SYS", "1024"},
+			exp: []Flavor{Flavor_AUTH_SYS, 1024},
+		},
+		"built-in flavor by number": {
+			in:     []string{"1"},
+			expErr: errors.New("auth string 1 is not recognized"),
+		},
+		"unknown name": {
+			in:     []string{"kerberos"},
+			expErr: errors.New("not recognized"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			flavors, err := ParseValidAuthFlavors(tc.in)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.CmpAny(t, "flavors", tc.exp, flavors)
+		})
+	}
+}
+
+func TestAuth_ValidateSysToken(t *testing.T) {
+	sys, err := proto.Marshal(&Sys{User: "user@"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		token  *Token
+		expErr error
+	}{
+		"sys token": {
+			token: &Token{Flavor: Flavor_AUTH_SYS, Data: sys},
+		},
+		"plugin flavor with sys token": {
+			token: &Token{Flavor: MinPluginFlavor, Data: sys},
+		},
+		"plugin flavor without sys token": {
+			token:  &Token{Flavor: MinPluginFlavor, Data: []byte{0xff, 0xff}},
+			expErr: errors.New("credential does not carry a Sys token"),
+		},
+		"nil token": {
+			expErr: errors.New("nil token"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, ValidateSysToken(tc.token))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// poolMatches returns true if the pool named by a token, by UUID or label,
+// is the pool with the given UUID and label.
+func poolMatches(name, poolUUID, poolLabel string) bool {
+	if poolUUID != "" && strings.EqualFold(name, poolUUID) {
+		return true
+	}
+	return poolLabel != "" && name == poolLabel
+}
+
+// poolName returns the label of the pool, or its UUID if it has none.
+func poolName(poolUUID, poolLabel string) string {
+	if poolLabel != "" {
+		return poolLabel
+	}
+	return poolUUID
+}
+
+// ValidateTokenPool checks that a token restricted to a set of pools, such as
+// a delegation credential or one recorded by a provider, is presented to one
+// of them. A token which names no pools may be presented to any pool, and a
+// restricted token may not be presented outside of a pool at all.
+func ValidateTokenPool(token *Token, poolUUID, poolLabel string) error {
+	sys, err := sysFromToken(token)
+	if err != nil {
+		return err
+	}
+	if len(sys.Pools) == 0 {
+		return nil
+	}
+
+	if poolUUID == "" && poolLabel == "" {
+		return errors.Errorf("credential restricted to pools %v was not presented to a pool", sys.Pools)
+	}
+	if !slices.ContainsFunc(sys.Pools, func(name string) bool {
+		return poolMatches(name, poolUUID, poolLabel)
+	}) {
+		return errors.Errorf("credential restricted to pools %v may not be used with pool %q", sys.Pools,
+			poolName(poolUUID, poolLabel))
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestAuth_ValidateTokenPool(t *testing.T) {
+	const poolUUID = "d6e1bdf4-b3b5-4d18-a6d8-7a8a5c2e3c79"
+
+	for name, tc := range map[string]struct {
+		pools     []string
+		poolUUID  string
+		poolLabel string
+		expErr    error
+	}{
+		"unrestricted": {
+			poolUUID:  poolUUID,
+			poolLabel: "tank",
+		},
+		"unrestricted; no pool": {},
+		"restricted by label": {
+			pools:     []string{"scratch", "tank"},
+			poolUUID:  poolUUID,
+			poolLabel: "tank",
+		},
+		"restricted by UUID": {
+			pools:     []string{"D6E1BDF4-B3B5-4D18-A6D8-7A8A5C2E3C79"},
+			poolUUID:  poolUUID,
+			poolLabel: "tank",
+		},
+		"out of scope": {
+			pools:     []string{"scratch"},
+			poolUUID:  poolUUID,
+			poolLabel: "tank",
+			expErr:    errors.New(`may not be used with pool "tank"`),
+		},
+		"out of scope; unlabeled": {
+			pools:    []string{"scratch"},
+			poolUUID: poolUUID,
+			expErr:   errors.New(`may not be used with pool "` + poolUUID + `"`),
+		},
+		"restricted; no pool": {
+			pools:  []string{"tank"},
+			expErr: errors.New("was not presented to a pool"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := proto.Marshal(&Sys{User: "user@", Pools: tc.pools})
+			if err != nil {
+				t.Fatal(err)
+			}
+			token := &Token{Flavor: Flavor_AUTH_SYS, Data: data}
+
+			test.CmpErr(t, tc.expErr, ValidateTokenPool(token, tc.poolUUID, tc.poolLabel))
+		})
+	}
+}
@@ -0,0 +1,169 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// presignedBundleVersion is the version of the format of pre-signed
+// credential bundles.
+const presignedBundleVersion = 1
+
+// PresignIdentity is an identity for which a credential is pre-signed.
+type PresignIdentity struct {
+	User        string   `yaml:"user" json:"user"`
+	Group       string   `yaml:"group,omitempty" json:"group,omitempty"`
+	Groups      []string `yaml:"groups,omitempty" json:"groups,omitempty"`
+	MachineName string   `yaml:"machine_name,omitempty" json:"machine_name,omitempty"`
+}
+
+// PresignedCredentials is a bundle of AUTH_SYS credentials signed ahead of
+// time for a fixed set of identities, for use on air-gapped clusters or while
+// bootstrapping, when the agent can't sign credentials itself. The credentials
+// don't expire, so the bundle must be protected like a key.
+type PresignedCredentials struct {
+	Version  int               `json:"version"`
+	System   string            `json:"system"`
+	SignedAt time.Time         `json:"signed_at"`
+	KeyID    string            `json:"key_id"`
+	Creds    map[string][]byte `json:"creds"`
+}
+
+// PresignCredentials signs an AUTH_SYS credential for each of the identities
+// with the key, for use with the system, which is recorded in each credential.
+// An identity without a machine name is given that of the host on which it is
+// signed.
+func PresignCredentials(key crypto.PrivateKey, system string, ids []*PresignIdentity) (*PresignedCredentials, error) {
+	if key == nil {
+		return nil, errors.New("credentials can't be pre-signed without a signing key")
+	}
+	keyID, err := security.PrivateKeyID(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to identify signing key")
+	}
+
+	bundle := &PresignedCredentials{
+		Version:  presignedBundleVersion,
+		System:   system,
+		SignedAt: time.Now().UTC(),
+		KeyID:    keyID,
+		Creds:    make(map[string][]byte),
+	}
+	for _, id := range ids {
+		if id == nil || id.User == "" {
+			return nil, errors.New("pre-signed identity has no user")
+		}
+		userPrinc := sysNameToPrincipalName(id.User)
+		if _, found := bundle.Creds[userPrinc]; found {
+			return nil, errors.Errorf("user %q listed more than once", id.User)
+		}
+
+		machineName := id.MachineName
+		if machineName == "" {
+			if machineName, err = GetMachineName(); err != nil {
+				return nil, errors.Wrap(err, "Unable to get hostname")
+			}
+		}
+		sys := &Sys{
+			Machinename: machineName,
+			User:        userPrinc,
+		}
+		if id.Group != "" {
+			sys.Group = sysNameToPrincipalName(id.Group)
+		}
+		for _, group := range id.Groups {
+			sys.Groups = append(sys.Groups, sysNameToPrincipalName(group))
+		}
+
+		tokenBytes, err := proto.Marshal(sys)
+		if err != nil {
+			return nil, errors.Wrapf(err, "marshaling token of user %q", id.User)
+		}
+		cred, err := newCredential(key, &Token{
+			Flavor: Flavor_AUTH_SYS,
+			Data:   tokenBytes,
+			System: system,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "signing credential of user %q", id.User)
+		}
+		if bundle.Creds[userPrinc], err = proto.Marshal(cred); err != nil {
+			return nil, errors.Wrapf(err, "marshaling credential of user %q", id.User)
+		}
+	}
+	return bundle, nil
+}
+
+// Write writes the bundle to the file, readable only by its owner.
+func (pc *PresignedCredentials) Write(path string) error {
+	data, err := json.MarshalIndent(pc, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshaling pre-signed credentials")
+	}
+	return errors.Wrap(os.WriteFile(path, data, 0600), "writing pre-signed credentials")
+}
+
+// LoadPresignedCredentials loads a bundle of pre-signed credentials from the
+// file, which must not be accessible by anyone but its owner.
+func LoadPresignedCredentials(path string) (*PresignedCredentials, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading pre-signed credentials")
+	}
+	if fi.Mode().Perm()&0077 != 0 {
+		return nil, errors.Errorf("pre-signed credentials %s are accessible by other users (mode %#o)",
+			path, fi.Mode().Perm())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading pre-signed credentials")
+	}
+
+	pc := &PresignedCredentials{}
+	if err := json.Unmarshal(data, pc); err != nil {
+		return nil, errors.Wrapf(err, "parsing pre-signed credentials %s", path)
+	}
+	if pc.Version != presignedBundleVersion {
+		return nil, errors.Errorf("pre-signed credentials %s have unsupported version %d", path, pc.Version)
+	}
+	return pc, nil
+}
+
+// Lookup returns the pre-signed credential of the user, or nil if there is
+// none.
+func (pc *PresignedCredentials) Lookup(user string) (*Credential, error) {
+	if pc == nil {
+		return nil, nil
+	}
+	data, found := pc.Creds[sysNameToPrincipalName(user)]
+	if !found {
+		return nil, nil
+	}
+
+	cred := &Credential{}
+	if err := proto.Unmarshal(data, cred); err != nil {
+		return nil, errors.Wrapf(err, "unmarshaling pre-signed credential of user %q", user)
+	}
+	return cred, nil
+}
+
+// Users returns the number of users with a pre-signed credential.
+func (pc *PresignedCredentials) Users() int {
+	if pc == nil {
+		return 0
+	}
+	return len(pc.Creds)
+}
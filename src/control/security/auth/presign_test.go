@@ -0,0 +1,143 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestAuth_PresignCredentials(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		ids     []*PresignIdentity
+		expErr  error
+		expSys  map[string]*Sys
+		missing string
+	}{
+		"identities": {
+			ids: []*PresignIdentity{
+				{User: "alice", Group: "users", Groups: []string{"admin"}, MachineName: "mgmt"},
+				{User: "bob", MachineName: "mgmt"},
+			},
+			expSys: map[string]*Sys{
+				"alice": {Machinename: "mgmt", User: "alice@", Group: "users@", Groups: []string{"admin@"}},
+				"bob":   {Machinename: "mgmt", User: "bob@"},
+			},
+			missing: "carol",
+		},
+		"no user": {
+			ids:    []*PresignIdentity{{Group: "users"}},
+			expErr: errors.New("has no user"),
+		},
+		"repeated user": {
+			ids:    []*PresignIdentity{{User: "alice"}, {User: "alice"}},
+			expErr: errors.New(`user "alice" listed more than once`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			bundle, err := PresignCredentials(key, "daos_server", tc.ids)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			path := filepath.Join(t.TempDir(), "presigned.json")
+			if err := bundle.Write(path); err != nil {
+				t.Fatal(err)
+			}
+			loaded, err := LoadPresignedCredentials(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, "daos_server", loaded.System, "unexpected system")
+			test.AssertEqual(t, bundle.KeyID, loaded.KeyID, "unexpected key ID")
+			test.AssertEqual(t, len(tc.expSys), loaded.Users(), "unexpected number of users")
+
+			for user, expSys := range tc.expSys {
+				cred, err := loaded.Lookup(user)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if cred == nil {
+					t.Fatalf("no credential for %q", user)
+				}
+				test.AssertEqual(t, bundle.KeyID, cred.KeyId, "unexpected credential key ID")
+				test.AssertEqual(t, "daos_server", cred.GetToken().GetSystem(), "unexpected credential system")
+				if err := VerifyCredential(&key.PublicKey, cred); err != nil {
+					t.Fatalf("expected credential to verify: %s", err)
+				}
+
+				sys := &Sys{}
+				if err := proto.Unmarshal(cred.GetToken().GetData(), sys); err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(expSys, sys, cmp.Comparer(proto.Equal)); diff != "" {
+					t.Fatalf("unexpected token (-want, +got):\n%s\n", diff)
+				}
+			}
+
+			cred, err := loaded.Lookup(tc.missing)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertTrue(t, cred == nil, "unexpected credential for unknown user")
+		})
+	}
+}
+
+func TestAuth_LoadPresignedCredentials(t *testing.T) {
+	dir := t.TempDir()
+	for name, tc := range map[string]struct {
+		data   string
+		perm   os.FileMode
+		expErr error
+	}{
+		"world readable": {
+			data:   `{"version":1}`,
+			perm:   0644,
+			expErr: errors.New("accessible by other users"),
+		},
+		"unsupported version": {
+			data:   `{"version":2}`,
+			perm:   0600,
+			expErr: errors.New("unsupported version 2"),
+		},
+		"garbage": {
+			data:   "not json",
+			perm:   0600,
+			expErr: errors.New("parsing pre-signed credentials"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(tc.data), tc.perm); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chmod(path, tc.perm); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := LoadPresignedCredentials(path)
+			test.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// BackendProber is implemented by the factories of flavors which depend on an
+// external backend, such as the access manager, to check that the backend is
+// reachable before it is needed to issue a credential.
+type BackendProber interface {
+	ProbeBackend(ctx context.Context, cfg *security.CredentialConfig) error
+}
+
+// HasBackend reports whether the flavor depends on an external backend which
+// can be probed.
+func HasBackend(flavor Flavor) bool {
+	_, ok := FlavorToFactory[flavor].(BackendProber)
+	return ok
+}
+
+// ProbeFlavor checks that the backend of the flavor is reachable. Flavors
+// without a backend always pass.
+func ProbeFlavor(ctx context.Context, flavor Flavor, cfg *security.CredentialConfig) error {
+	fac, found := FlavorToFactory[flavor]
+	if !found {
+		return errors.Errorf("no credential factory for %s", flavor)
+	}
+	prober, ok := fac.(BackendProber)
+	if !ok {
+		return nil
+	}
+
+	return prober.ProbeBackend(ctx, cfg)
+}
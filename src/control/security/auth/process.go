@@ -0,0 +1,145 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// statStartTimeField is the index of the process start time among the fields
+// of /proc/<pid>/stat which follow the command name.
+const statStartTimeField = 22 - 3
+
+// processStartTime returns the time at which the process started, in clock
+// ticks after the node booted. Together with its PID, it identifies the
+// process even once the PID has been reused.
+func processStartTime(pid int32) (uint64, error) {
+	data, err := os.ReadFile(filepath.Join(procRoot, fmt.Sprint(pid), "stat"))
+	if err != nil {
+		return 0, errors.Wrapf(err, "reading stat of pid %d", pid)
+	}
+
+	// The command name is in parentheses, and may itself contain spaces and
+	// parentheses, so the fields are counted from the last parenthesis.
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return 0, errors.Errorf("malformed stat of pid %d", pid)
+	}
+	fields := bytes.Fields(data[end+1:])
+	if len(fields) <= statStartTimeField {
+		return 0, errors.Errorf("malformed stat of pid %d", pid)
+	}
+	start, err := strconv.ParseUint(string(fields[statStartTimeField]), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "malformed start time of pid %d", pid)
+	}
+	return start, nil
+}
+
+// processBinding binds credentials issued by the Unix-based flavors to the
+// client process which requested them, so that a credential taken from one
+// process can be told apart from those of others.
+type processBinding struct {
+	pid   int32
+	start uint64
+}
+
+// clientProcess returns the binding to the process, or nil if the agent is not
+// configured to bind credentials to processes.
+func clientProcess(enabled bool, pid int32) (*processBinding, error) {
+	if !enabled {
+		return nil, nil
+	}
+	if pid <= 0 {
+		return nil, errors.Errorf("invalid pid %d", pid)
+	}
+
+	start, err := processStartTime(pid)
+	if err != nil {
+		return nil, err
+	}
+	return &processBinding{pid: pid, start: start}, nil
+}
+
+// apply records the process in the token.
+func (pb *processBinding) apply(sys *Sys) {
+	if pb == nil {
+		return
+	}
+	sys.Pid = uint32(pb.pid)
+	sys.ProcessStart = pb.start
+}
+
+// key returns the part of a cache key that binds a credential to the process.
+func (pb *processBinding) key() string {
+	if pb == nil {
+		return ""
+	}
+	return fmt.Sprintf("\x00%d:%d", pb.pid, pb.start)
+}
+
+// ValidateProcessBinding checks that the token is bound to the process which
+// requested it, if binding is required for the pool it is presented to, which
+// it is if the pool is one of boundPools, named by label or UUID. A token which
+// is not bound to a process is accepted unless binding is required. The binding
+// must have been checked to be authentic, by verifying the credential,
+// beforehand.
+func ValidateProcessBinding(token *Token, boundPools []string, poolUUID, poolLabel string) error {
+	required := slices.ContainsFunc(boundPools, func(name string) bool {
+		return poolMatches(name, poolUUID, poolLabel)
+	})
+
+	sys, err := sysFromToken(token)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case sys.GetPid() == 0:
+		if required {
+			return errors.Errorf("credential is not bound to a process, as required for pool %q",
+				poolName(poolUUID, poolLabel))
+		}
+		return nil
+	case sys.GetProcessStart() == 0:
+		return errors.Errorf("credential is bound to pid %d with no start time", sys.GetPid())
+	}
+	return nil
+}
+
+// ValidateProcessPeer checks that a token bound to a process is bound to the
+// process with the given PID, the peer of the connection it is presented on,
+// and that the process has not been replaced by another with the same PID since
+// the token was bound. A token which is not bound to a process is accepted.
+func ValidateProcessPeer(token *Token, pid int32) error {
+	sys, err := sysFromToken(token)
+	if err != nil {
+		return err
+	}
+	if sys.GetPid() == 0 {
+		return nil
+	}
+
+	if int32(sys.GetPid()) != pid {
+		return errors.Errorf("credential bound to pid %d presented by pid %d", sys.GetPid(), pid)
+	}
+	start, err := processStartTime(pid)
+	if err != nil {
+		return err
+	}
+	if start != sys.GetProcessStart() {
+		return errors.Errorf("credential bound to pid %d presented by another process with that pid", pid)
+	}
+	return nil
+}
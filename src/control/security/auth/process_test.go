@@ -0,0 +1,199 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func setTestProcStat(t *testing.T, pid int32, stat string) {
+	t.Helper()
+	orig := procRoot
+	t.Cleanup(func() { procRoot = orig })
+	procRoot = t.TempDir()
+
+	dir := filepath.Join(procRoot, fmt.Sprint(pid))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAuth_clientProcess(t *testing.T) {
+	for name, tc := range map[string]struct {
+		disabled bool
+		pid      int32
+		stat     string
+		expSys   *Sys
+		expErr   error
+	}{
+		"not enabled": {
+			disabled: true,
+			pid:      42,
+			expSys:   &Sys{},
+		},
+		"bound": {
+			pid:    42,
+			stat:   "42 (ior) S 1 42 42 0 -1 4194560 100 0 0 0 1 2 0 0 20 0 1 0 123456 1000 10\n",
+			expSys: &Sys{Pid: 42, ProcessStart: 123456},
+		},
+		"command with spaces and parentheses": {
+			pid:    42,
+			stat:   "42 (my (app) x) R 1 42 42 0 -1 4194560 100 0 0 0 1 2 0 0 20 0 1 0 654321 1000 10\n",
+			expSys: &Sys{Pid: 42, ProcessStart: 654321},
+		},
+		"invalid pid": {
+			expErr: errors.New("invalid pid 0"),
+		},
+		"truncated stat": {
+			pid:    42,
+			stat:   "42 (ior) S 1 42\n",
+			expErr: errors.New("malformed stat of pid 42"),
+		},
+		"no such process": {
+			pid:    43,
+			expErr: errors.New("reading stat of pid 43"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setTestProcStat(t, 42, tc.stat)
+
+			pb, err := clientProcess(!tc.disabled, tc.pid)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			sys := &Sys{}
+			pb.apply(sys)
+			test.CmpAny(t, "sys", tc.expSys, sys, test.DefaultCmpOpts()...)
+		})
+	}
+
+	// Credentials bound to different processes are cached separately.
+	first := &processBinding{pid: 42, start: 100}
+	second := &processBinding{pid: 42, start: 200}
+	test.AssertTrue(t, first.key() != second.key(), "reused pid has the same cache key")
+	test.AssertEqual(t, "", (*processBinding)(nil).key(), "unbound key")
+}
+
+func TestAuth_ValidateProcessBinding(t *testing.T) {
+	const poolUUID = "d6e1bdf4-b3b5-4d18-a6d8-7a8a5c2e3c79"
+
+	token := func(sys *Sys) *Token {
+		data, err := proto.Marshal(sys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &Token{Flavor: Flavor_AUTH_SYS, Data: data}
+	}
+	pluginToken := &Token{Flavor: MinPluginFlavor, Data: []byte{0xff, 0xff}}
+
+	for name, tc := range map[string]struct {
+		token      *Token
+		boundPools []string
+		expErr     error
+	}{
+		"not bound": {
+			token: token(&Sys{User: "user@"}),
+		},
+		"not bound; required for another pool": {
+			token:      token(&Sys{User: "user@"}),
+			boundPools: []string{"scratch"},
+		},
+		"not bound; required": {
+			token:      token(&Sys{User: "user@"}),
+			boundPools: []string{"scratch", "tank"},
+			expErr:     errors.New(`not bound to a process, as required for pool "tank"`),
+		},
+		"not bound; required by UUID": {
+			token:      token(&Sys{User: "user@"}),
+			boundPools: []string{poolUUID},
+			expErr:     errors.New("not bound to a process"),
+		},
+		"bound": {
+			token:      token(&Sys{User: "user@", Pid: 42, ProcessStart: 123456}),
+			boundPools: []string{"tank"},
+		},
+		"no start time": {
+			token:  token(&Sys{User: "user@", Pid: 42}),
+			expErr: errors.New("bound to pid 42 with no start time"),
+		},
+		"plugin token": {
+			token:  pluginToken,
+			expErr: errors.New("unmarshaling"),
+		},
+		"plugin token; required": {
+			token:      pluginToken,
+			boundPools: []string{"tank"},
+			expErr:     errors.New("unmarshaling"),
+		},
+		"malformed token": {
+			expErr: errors.New("nil token"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, ValidateProcessBinding(tc.token, tc.boundPools, poolUUID, "tank"))
+		})
+	}
+}
+
+func TestAuth_ValidateProcessPeer(t *testing.T) {
+	token := func(sys *Sys) *Token {
+		data, err := proto.Marshal(sys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &Token{Flavor: Flavor_AUTH_SYS, Data: data}
+	}
+
+	for name, tc := range map[string]struct {
+		token  *Token
+		pid    int32
+		expErr error
+	}{
+		"not bound": {
+			token: token(&Sys{User: "user@"}),
+			pid:   43,
+		},
+		"bound to peer": {
+			token: token(&Sys{User: "user@", Pid: 42, ProcessStart: 123456}),
+			pid:   42,
+		},
+		"bound to another process": {
+			token:  token(&Sys{User: "user@", Pid: 42, ProcessStart: 123456}),
+			pid:    43,
+			expErr: errors.New("bound to pid 42 presented by pid 43"),
+		},
+		"pid reused": {
+			token:  token(&Sys{User: "user@", Pid: 42, ProcessStart: 100}),
+			pid:    42,
+			expErr: errors.New("presented by another process"),
+		},
+		"plugin token": {
+			token:  &Token{Flavor: MinPluginFlavor, Data: []byte{0xff, 0xff}},
+			pid:    43,
+			expErr: errors.New("unmarshaling"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setTestProcStat(t, 42, "42 (ior) S 1 42 42 0 -1 4194560 100 0 0 0 1 2 0 0 20 0 1 0 123456 1000 10\n")
+
+			test.CmpErr(t, tc.expErr, ValidateProcessPeer(tc.token, tc.pid))
+		})
+	}
+}
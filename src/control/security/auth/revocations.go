@@ -0,0 +1,240 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/daos"
+)
+
+// credHashLen is the length of a hex-encoded credential hash.
+const credHashLen = 2 * sha256.Size
+
+// CredentialHash returns the hash which identifies a credential for
+// revocation. It is the digest of the verifier, which is unique to each
+// credential and does not reveal the token it covers.
+func CredentialHash(cred *Credential) string {
+	digest := sha256.Sum256(cred.GetVerifier().GetData())
+	return hex.EncodeToString(digest[:])
+}
+
+// ParseCredentialHash validates a credential hash and returns it in canonical
+// form.
+func ParseCredentialHash(hash string) (string, error) {
+	hash = strings.ToLower(strings.TrimSpace(hash))
+	if len(hash) != credHashLen {
+		return "", errors.Errorf("credential hash %q must be %d hex characters", hash, credHashLen)
+	}
+	if _, err := hex.DecodeString(hash); err != nil {
+		return "", errors.Errorf("credential hash %q is not hex-encoded", hash)
+	}
+	return hash, nil
+}
+
+// parseAgentHost validates the host of an agent and returns it in canonical
+// form.
+func parseAgentHost(host string) (string, error) {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if host == "" {
+		return "", errors.New("agent host must not be empty")
+	}
+	return host, nil
+}
+
+// RevocationList is the list of credentials revoked throughout the system. It
+// is stored by the management service, and each server checks the
+// credentials presented by clients against the latest version it has fetched.
+type RevocationList struct {
+	Version    uint64   `json:"version"`
+	KeyIDs     []string `json:"key_ids,omitempty"`
+	CredHashes []string `json:"cred_hashes,omitempty"`
+	AgentHosts []string `json:"agent_hosts,omitempty"`
+}
+
+// addUnique adds the values not already in the list, returning the number
+// added.
+func addUnique(list *[]string, values []string, parse func(string) (string, error)) (int, error) {
+	var added int
+	for _, value := range values {
+		value, err := parse(value)
+		if err != nil {
+			return 0, err
+		}
+		if !slices.Contains(*list, value) {
+			*list = append(*list, value)
+			added++
+		}
+	}
+	return added, nil
+}
+
+// Add adds the revocations to the list, returning the number which were not
+// already in it. If any were added, the version of the list is incremented.
+// The list is unchanged if any of the revocations are invalid.
+func (rl *RevocationList) Add(keyIDs, credHashes, agentHosts []string) (int, error) {
+	if rl == nil {
+		return 0, errors.New("nil revocation list")
+	}
+
+	updated := &RevocationList{
+		Version:    rl.Version,
+		KeyIDs:     slices.Clone(rl.KeyIDs),
+		CredHashes: slices.Clone(rl.CredHashes),
+		AgentHosts: slices.Clone(rl.AgentHosts),
+	}
+	var total int
+	for _, set := range []struct {
+		list   *[]string
+		values []string
+		parse  func(string) (string, error)
+	}{
+		{&updated.KeyIDs, keyIDs, ParseKeyID},
+		{&updated.CredHashes, credHashes, ParseCredentialHash},
+		{&updated.AgentHosts, agentHosts, parseAgentHost},
+	} {
+		added, err := addUnique(set.list, set.values, set.parse)
+		if err != nil {
+			return 0, err
+		}
+		total += added
+	}
+
+	if total > 0 {
+		updated.Version++
+		*rl = *updated
+	}
+	return total, nil
+}
+
+// CredentialRevocations is the revocation list which a server checks the
+// credentials presented by clients against, together with the keys revoked by
+// the server's own configuration.
+type CredentialRevocations struct {
+	sync.RWMutex
+	version    uint64
+	configKeys []string
+	keys       map[string]struct{}
+	hashes     map[string]struct{}
+	hosts      map[string]struct{}
+}
+
+// NewCredentialRevocations returns an empty set of revocations.
+func NewCredentialRevocations() *CredentialRevocations {
+	return &CredentialRevocations{}
+}
+
+// RevokeKeys revokes the keys with the given IDs, whatever the revocation list
+// installed, such as those revoked by the server's configuration.
+func (r *CredentialRevocations) RevokeKeys(keyIDs ...string) error {
+	if r == nil {
+		return errors.New("nil revocations")
+	}
+
+	parsed := make([]string, 0, len(keyIDs))
+	for _, id := range keyIDs {
+		id, err := ParseKeyID(id)
+		if err != nil {
+			return err
+		}
+		parsed = append(parsed, id)
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	r.configKeys = append(r.configKeys, parsed...)
+	if r.keys == nil {
+		r.keys = make(map[string]struct{})
+	}
+	for _, id := range parsed {
+		r.keys[id] = struct{}{}
+	}
+	return nil
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[strings.ToLower(value)] = struct{}{}
+	}
+	return set
+}
+
+// Update replaces the revocations with the list, if it is newer. It returns
+// true if they were replaced.
+func (r *CredentialRevocations) Update(list *RevocationList) bool {
+	if r == nil || list == nil {
+		return false
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	if list.Version <= r.version {
+		return false
+	}
+	r.version = list.Version
+	r.keys = toSet(append(list.KeyIDs, r.configKeys...))
+	r.hashes = toSet(list.CredHashes)
+	r.hosts = toSet(list.AgentHosts)
+	return true
+}
+
+// Version returns the version of the revocation list last installed.
+func (r *CredentialRevocations) Version() uint64 {
+	if r == nil {
+		return 0
+	}
+
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.version
+}
+
+// Check returns an error if the credential, signed by the key with the given
+// ID, has been revoked, or was co-signed by a revoked key.
+func (r *CredentialRevocations) Check(cred *Credential, keyID string) error {
+	if r == nil {
+		return nil
+	}
+
+	r.RLock()
+	defer r.RUnlock()
+
+	if _, found := r.keys[strings.ToLower(keyID)]; found {
+		return errors.Wrapf(daos.CredentialRevoked, "credential signed by revoked key %s", keyID)
+	}
+	if cosigner := cred.GetCosignerKeyId(); cosigner != "" {
+		if _, found := r.keys[strings.ToLower(cosigner)]; found {
+			return errors.Wrapf(daos.CredentialRevoked, "credential co-signed by revoked key %s", cosigner)
+		}
+	}
+	if len(r.hosts) > 0 {
+		// The host of the agent is recorded in the tokens of all flavors.
+		if sys, err := sysFromToken(cred.GetToken()); err == nil {
+			if _, found := r.hosts[strings.ToLower(sys.GetMachinename())]; found {
+				return errors.Wrapf(daos.CredentialRevoked, "credentials from agent host %q are revoked",
+					sys.GetMachinename())
+			}
+		}
+	}
+	if len(r.hashes) > 0 {
+		hash := CredentialHash(cred)
+		if _, found := r.hashes[hash]; found {
+			return errors.Wrapf(daos.CredentialRevoked, "credential %s is revoked", hash)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestAuth_ParseCredentialHash(t *testing.T) {
+	validHash := strings.Repeat("cd", credHashLen/2)
+
+	for name, tc := range map[string]struct {
+		hash    string
+		expHash string
+		expErr  error
+	}{
+		"too short": {
+			hash:   "abcd",
+			expErr: errors.New("must be 64 hex characters"),
+		},
+		"not hex": {
+			hash:   strings.Repeat("zz", credHashLen/2),
+			expErr: errors.New("not hex-encoded"),
+		},
+		"canonicalized": {
+			hash:    " " + strings.ToUpper(validHash),
+			expHash: validHash,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			hash, err := ParseCredentialHash(tc.hash)
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expHash, hash, "unexpected hash")
+		})
+	}
+}
+
+func TestAuth_RevocationList_Add(t *testing.T) {
+	keyID := strings.Repeat("ab", keyIDLen/2)
+	credHash := strings.Repeat("cd", credHashLen/2)
+
+	for name, tc := range map[string]struct {
+		list       *RevocationList
+		keyIDs     []string
+		credHashes []string
+		hosts      []string
+		expAdded   int
+		expList    *RevocationList
+		expErr     error
+	}{
+		"nil list": {
+			expErr: errors.New("nil revocation list"),
+		},
+		"added": {
+			list:       &RevocationList{},
+			keyIDs:     []string{strings.ToUpper(keyID)},
+			credHashes: []string{credHash},
+			hosts:      []string{"Host1"},
+			expAdded:   3,
+			expList: &RevocationList{
+				Version:    1,
+				KeyIDs:     []string{keyID},
+				CredHashes: []string{credHash},
+				AgentHosts: []string{"host1"},
+			},
+		},
+		"already revoked": {
+			list: &RevocationList{
+				Version:    4,
+				AgentHosts: []string{"host1"},
+			},
+			hosts: []string{"host1", "host1"},
+			expList: &RevocationList{
+				Version:    4,
+				AgentHosts: []string{"host1"},
+			},
+		},
+		"some already revoked": {
+			list: &RevocationList{
+				Version:    4,
+				AgentHosts: []string{"host1"},
+			},
+			hosts:    []string{"host1", "host2"},
+			expAdded: 1,
+			expList: &RevocationList{
+				Version:    5,
+				AgentHosts: []string{"host1", "host2"},
+			},
+		},
+		"invalid revocation": {
+			list: &RevocationList{
+				Version:    4,
+				AgentHosts: []string{"host1"},
+			},
+			keyIDs: []string{"bad"},
+			hosts:  []string{"host2"},
+			expErr: errors.New("must be 64 hex characters"),
+			expList: &RevocationList{
+				Version:    4,
+				AgentHosts: []string{"host1"},
+			},
+		},
+		"empty host": {
+			list:    &RevocationList{},
+			hosts:   []string{" "},
+			expErr:  errors.New("must not be empty"),
+			expList: &RevocationList{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			added, err := tc.list.Add(tc.keyIDs, tc.credHashes, tc.hosts)
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expAdded, added, "unexpected number added")
+			if diff := cmp.Diff(tc.expList, tc.list); diff != "" {
+				t.Fatalf("unexpected list (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestAuth_CredentialRevocations(t *testing.T) {
+	keyID := strings.Repeat("ab", keyIDLen/2)
+	newCred := func(host, verifier string) *Credential {
+		sys, err := proto.Marshal(&Sys{Machinename: host})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &Credential{
+			Token:    &Token{Flavor: Flavor_AUTH_SYS, Data: sys},
+			Verifier: &Token{Data: []byte(verifier)},
+			Origin:   "agent",
+		}
+	}
+	cred := newCred("host1", "verifier")
+	other := newCred("host2", "other")
+
+	var nilRevs *CredentialRevocations
+	test.CmpErr(t, nil, nilRevs.Check(cred, keyID))
+	test.AssertFalse(t, nilRevs.Update(&RevocationList{Version: 1}), "nil revocations updated")
+
+	for name, tc := range map[string]struct {
+		list   *RevocationList
+		cred   *Credential
+		keyID  string
+		expErr error
+	}{
+		"nothing revoked": {
+			cred:  cred,
+			keyID: keyID,
+		},
+		"key revoked": {
+			list:   &RevocationList{Version: 1, KeyIDs: []string{keyID}},
+			cred:   cred,
+			keyID:  strings.ToUpper(keyID),
+			expErr: errors.New("signed by revoked key"),
+		},
+		"key revoked; insecure": {
+			list: &RevocationList{Version: 1, KeyIDs: []string{keyID}},
+			cred: cred,
+		},
+		"co-signing key revoked": {
+			list: &RevocationList{Version: 1, KeyIDs: []string{keyID}},
+			cred: func() *Credential {
+				c := newCred("host1", "verifier")
+				c.CosignerKeyId = keyID
+				return c
+			}(),
+			keyID:  strings.Repeat("cd", keyIDLen/2),
+			expErr: errors.New("co-signed by revoked key"),
+		},
+		"agent revoked": {
+			list:   &RevocationList{Version: 1, AgentHosts: []string{"host1"}},
+			cred:   cred,
+			keyID:  keyID,
+			expErr: errors.New(`credentials from agent host "host1" are revoked`),
+		},
+		"credential revoked": {
+			list:   &RevocationList{Version: 1, CredHashes: []string{CredentialHash(cred)}},
+			cred:   cred,
+			keyID:  keyID,
+			expErr: errors.New("credential " + CredentialHash(cred) + " is revoked"),
+		},
+		"other credential revoked": {
+			list: &RevocationList{
+				Version:    1,
+				CredHashes: []string{CredentialHash(other)},
+				AgentHosts: []string{"host2"},
+			},
+			cred:  cred,
+			keyID: keyID,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			revs := NewCredentialRevocations()
+			if tc.list != nil {
+				test.AssertTrue(t, revs.Update(tc.list), "revocations not updated")
+			}
+
+			test.CmpErr(t, tc.expErr, revs.Check(tc.cred, tc.keyID))
+		})
+	}
+
+	revs := NewCredentialRevocations()
+	revs.Update(&RevocationList{Version: 2, AgentHosts: []string{"host1"}})
+	test.AssertFalse(t, revs.Update(&RevocationList{Version: 1}), "older list installed")
+	test.AssertFalse(t, revs.Update(&RevocationList{Version: 2}), "same list installed")
+	test.AssertEqual(t, uint64(2), revs.Version(), "unexpected version")
+	test.CmpErr(t, errors.New("are revoked"), revs.Check(cred, ""))
+
+	test.AssertTrue(t, revs.Update(&RevocationList{Version: 3}), "newer list not installed")
+	test.CmpErr(t, nil, revs.Check(cred, ""))
+
+	// Keys revoked outside of the list stay revoked whichever list is
+	// installed.
+	test.CmpErr(t, errors.New("must be 64 hex characters"), revs.RevokeKeys("bogus"))
+	test.CmpErr(t, nil, revs.RevokeKeys(strings.ToUpper(keyID)))
+	test.CmpErr(t, errors.New("signed by revoked key"), revs.Check(cred, keyID))
+	test.AssertTrue(t, revs.Update(&RevocationList{Version: 4}), "newer list not installed")
+	test.CmpErr(t, errors.New("signed by revoked key"), revs.Check(cred, keyID))
+	test.CmpErr(t, errors.New("nil revocations"), nilRevs.RevokeKeys(keyID))
+}
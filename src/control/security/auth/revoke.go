@@ -0,0 +1,181 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+)
+
+// keyIDLen is the length of a hex-encoded key ID (see security.PublicKeyID).
+const keyIDLen = 64
+
+// ParseKeyID validates a key ID and returns it in canonical form.
+func ParseKeyID(id string) (string, error) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	if len(id) != keyIDLen {
+		return "", errors.Errorf("key ID %q must be %d hex characters", id, keyIDLen)
+	}
+	if _, err := hex.DecodeString(id); err != nil {
+		return "", errors.Errorf("key ID %q is not hex-encoded", id)
+	}
+	return id, nil
+}
+
+// IssuerKeyRevocations tracks the agent signing keys which have been revoked.
+// The agent does not issue credentials signed by a revoked key.
+type IssuerKeyRevocations struct {
+	sync.RWMutex
+	keys map[string]string
+}
+
+// NewIssuerKeyRevocations returns an empty set of revocations.
+func NewIssuerKeyRevocations() *IssuerKeyRevocations {
+	return &IssuerKeyRevocations{
+		keys: make(map[string]string),
+	}
+}
+
+// Revoke adds the key ID to the set of revoked keys. It returns true if the
+// key had not already been revoked.
+func (r *IssuerKeyRevocations) Revoke(id, reason string) (bool, error) {
+	if r == nil {
+		return false, errors.New("nil revocations")
+	}
+
+	id, err := ParseKeyID(id)
+	if err != nil {
+		return false, err
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	if _, found := r.keys[id]; found {
+		return false, nil
+	}
+	r.keys[id] = reason
+	return true, nil
+}
+
+// IsRevoked returns true if the key ID has been revoked.
+func (r *IssuerKeyRevocations) IsRevoked(id string) bool {
+	if r == nil {
+		return false
+	}
+
+	r.RLock()
+	defer r.RUnlock()
+
+	_, found := r.keys[strings.ToLower(id)]
+	return found
+}
+
+// Len returns the number of revoked keys.
+func (r *IssuerKeyRevocations) Len() int {
+	if r == nil {
+		return 0
+	}
+
+	r.RLock()
+	defer r.RUnlock()
+
+	return len(r.keys)
+}
+
+// RevokeIssuerKey sends a request to revoke an issuer key to the agent
+// listening on the given dRPC socket.
+func RevokeIssuerKey(ctx context.Context, sockPath string, method drpc.Method, req *RevokeIssuerKeyReq) (*RevokeIssuerKeyResp, error) {
+	resp := new(RevokeIssuerKeyResp)
+	if err := callDaemon(ctx, sockPath, method, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListCredentialCache requests the list of credentials cached by the agent
+// listening on the given dRPC socket.
+func ListCredentialCache(ctx context.Context, sockPath string, method drpc.Method) (*ListCredCacheResp, error) {
+	resp := new(ListCredCacheResp)
+	if err := callDaemon(ctx, sockPath, method, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SnapshotCredentialCache requests an encrypted snapshot of the credentials
+// cached by the agent listening on the given dRPC socket.
+func SnapshotCredentialCache(ctx context.Context, sockPath string, method drpc.Method) (*SnapshotCredCacheResp, error) {
+	resp := new(SnapshotCredCacheResp)
+	if err := callDaemon(ctx, sockPath, method, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RestoreCredentialCache requests that the agent listening on the given dRPC
+// socket restore a snapshot of a credential cache.
+func RestoreCredentialCache(ctx context.Context, sockPath string, method drpc.Method, req *RestoreCredCacheReq) (*RestoreCredCacheResp, error) {
+	resp := new(RestoreCredCacheResp)
+	if err := callDaemon(ctx, sockPath, method, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CredentialCacheEvents requests the credential cache events which followed
+// the last event seen from the agent listening on the given dRPC socket.
+func CredentialCacheEvents(ctx context.Context, sockPath string, method drpc.Method, req *CredCacheEventsReq) (*CredCacheEventsResp, error) {
+	resp := new(CredCacheEventsResp)
+	if err := callDaemon(ctx, sockPath, method, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// callDaemon sends the request to the daemon listening on the given dRPC
+// socket, and unmarshals its response. A nil request is sent with an empty
+// body.
+func callDaemon(ctx context.Context, sockPath string, method drpc.Method, req, resp proto.Message) error {
+	var body []byte
+	if req != nil {
+		var err error
+		if body, err = proto.Marshal(req); err != nil {
+			return drpc.MarshalingFailure()
+		}
+	}
+
+	client := drpc.NewClientConnection(sockPath)
+	if err := client.Connect(ctx); err != nil {
+		return errors.Wrapf(err, "connecting to %s", sockPath)
+	}
+	defer client.Close()
+
+	drpcResp, err := client.SendMsg(ctx, &drpc.Call{
+		Module: method.Module(),
+		Method: method.ID(),
+		Body:   body,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "sending %s request", method)
+	}
+	if drpcResp.Status != drpc.Status_SUCCESS {
+		return errors.Errorf("%s request failed: %s", method, drpcResp.Status)
+	}
+
+	if err := proto.Unmarshal(drpcResp.Body, resp); err != nil {
+		return drpc.UnmarshalingPayloadFailure()
+	}
+	return nil
+}
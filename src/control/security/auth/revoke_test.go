@@ -0,0 +1,82 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestAuth_ParseKeyID(t *testing.T) {
+	validID := strings.Repeat("ab", keyIDLen/2)
+
+	for name, tc := range map[string]struct {
+		id     string
+		expID  string
+		expErr error
+	}{
+		"empty": {
+			expErr: errors.New("must be 64 hex characters"),
+		},
+		"too short": {
+			id:     "abcd",
+			expErr: errors.New("must be 64 hex characters"),
+		},
+		"not hex": {
+			id:     strings.Repeat("zz", keyIDLen/2),
+			expErr: errors.New("not hex-encoded"),
+		},
+		"valid": {
+			id:    validID,
+			expID: validID,
+		},
+		"canonicalized": {
+			id:    " " + strings.ToUpper(validID) + "\n",
+			expID: validID,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			id, err := ParseKeyID(tc.id)
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expID, id, "unexpected key ID")
+		})
+	}
+}
+
+func TestAuth_IssuerKeyRevocations(t *testing.T) {
+	var nilRevs *IssuerKeyRevocations
+	_, err := nilRevs.Revoke("", "")
+	test.CmpErr(t, errors.New("nil revocations"), err)
+	test.AssertFalse(t, nilRevs.IsRevoked("anything"), "nil revocations should revoke nothing")
+	test.AssertEqual(t, 0, nilRevs.Len(), "unexpected length")
+
+	keyID := strings.Repeat("0f", keyIDLen/2)
+	revs := NewIssuerKeyRevocations()
+
+	_, err = revs.Revoke("bad", "")
+	test.CmpErr(t, errors.New("must be 64 hex characters"), err)
+	test.AssertFalse(t, revs.IsRevoked(keyID), "key should not be revoked yet")
+
+	added, err := revs.Revoke(strings.ToUpper(keyID), "compromised")
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, added, "expected key to be added")
+	test.AssertTrue(t, revs.IsRevoked(keyID), "expected key to be revoked")
+	test.AssertTrue(t, revs.IsRevoked(strings.ToUpper(keyID)), "expected lookup to be case-insensitive")
+
+	added, err = revs.Revoke(keyID, "again")
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertFalse(t, added, "expected duplicate revocation to be ignored")
+	test.AssertEqual(t, 1, revs.Len(), "unexpected length")
+}
@@ -0,0 +1,52 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bytes"
+	"crypto"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const selfTestPrincipal = "daos-self-test@"
+
+// SelfTestFlavor signs a synthetic credential of the given flavor with the key
+// and checks that it verifies with the public key, and that a tampered copy of
+// it does not. A nil key and public key test the unsigned (insecure) mode.
+func SelfTestFlavor(flavor Flavor, key crypto.PrivateKey, pub crypto.PublicKey) error {
+	if _, found := FlavorToFactory[flavor]; !found {
+		return errors.Errorf("no credential factory for %s", flavor)
+	}
+
+	sys := &Sys{
+		Stamp:       uint64(time.Now().Unix()),
+		Machinename: "self-test",
+		User:        selfTestPrincipal,
+		Group:       selfTestPrincipal,
+	}
+	cred, err := newSignedCredential(flavor, key, sys)
+	if err != nil {
+		return errors.Wrapf(err, "signing %s self-test credential", flavor)
+	}
+
+	if err := VerifyCredential(pub, cred); err != nil {
+		return errors.Wrapf(err, "verifying %s self-test credential", flavor)
+	}
+
+	tampered := &Token{
+		Flavor: cred.GetToken().GetFlavor(),
+		Data:   bytes.Clone(cred.GetToken().GetData()),
+	}
+	tampered.Data[len(tampered.Data)-1] ^= 0xff
+	if err := VerifyCredential(pub, &Credential{Token: tampered, Verifier: cred.GetVerifier()}); err == nil {
+		return errors.Errorf("tampered %s self-test credential was verified", flavor)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestAuth_SelfTestFlavor(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		flavor Flavor
+		key    crypto.PrivateKey
+		pub    crypto.PublicKey
+		expErr error
+	}{
+		"unknown flavor": {
+			flavor: Flavor(-1),
+			expErr: errors.New("no credential factory"),
+		},
+		"insecure": {
+			flavor: Flavor_AUTH_SYS,
+		},
+		"signed": {
+			flavor: Flavor_AUTH_SYS,
+			key:    key,
+			pub:    &key.PublicKey,
+		},
+		"mismatched public key": {
+			flavor: Flavor_AUTH_SYS,
+			key:    key,
+			pub:    &otherKey.PublicKey,
+			expErr: errors.New("verifying AUTH_SYS self-test"),
+		},
+		"ed25519": {
+			flavor: Flavor_AUTH_SYS,
+			key:    edKey,
+			pub:    edPub,
+		},
+		"ed25519 verified with rsa key": {
+			flavor: Flavor_AUTH_SYS,
+			key:    edKey,
+			pub:    &key.PublicKey,
+			expErr: errors.New("verifying AUTH_SYS self-test"),
+		},
+		"ecdsa": {
+			flavor: Flavor_AUTH_SYS,
+			key:    ecKey,
+			pub:    &ecKey.PublicKey,
+		},
+		"ecdsa verified with ed25519 key": {
+			flavor: Flavor_AUTH_SYS,
+			key:    ecKey,
+			pub:    edPub,
+			expErr: errors.New("verifying AUTH_SYS self-test"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, SelfTestFlavor(tc.flavor, tc.key, tc.pub))
+		})
+	}
+}
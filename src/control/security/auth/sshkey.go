@@ -0,0 +1,393 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// This file implements the subset of the SSH wire formats (RFC 4251, RFC 4253,
+// RFC 5656, RFC 8332, RFC 8709) needed to verify signatures made by keys held
+// in ssh-agent, and to parse authorized_keys files.
+
+const (
+	sshKeyEd25519   = "ssh-ed25519"
+	sshKeyRSA       = "ssh-rsa"
+	sshKeyECDSA256  = "ecdsa-sha2-nistp256"
+	sshKeyECDSA384  = "ecdsa-sha2-nistp384"
+	sshKeyECDSA521  = "ecdsa-sha2-nistp521"
+	sshSigRSASHA256 = "rsa-sha2-256"
+	sshSigRSASHA512 = "rsa-sha2-512"
+
+	// sshSigMagic and sshSigNamespace form the preamble of the data signed by
+	// clients (see PROTOCOL.sshsig in OpenSSH), which prevents a signature
+	// made for DAOS from being valid in any other protocol.
+	sshSigMagic     = "SSHSIG"
+	sshSigNamespace = "daos"
+	sshSigHash      = "sha512"
+
+	// sshMinRSABits is the smallest RSA modulus accepted.
+	sshMinRSABits = 2048
+)
+
+// sshReader consumes SSH wire-format values from a buffer.
+type sshReader struct {
+	buf []byte
+	err error
+}
+
+func (r *sshReader) uint32() uint32 {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.buf) < 4 {
+		r.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	v := binary.BigEndian.Uint32(r.buf)
+	r.buf = r.buf[4:]
+	return v
+}
+
+func (r *sshReader) bytes() []byte {
+	n := r.uint32()
+	if r.err != nil {
+		return nil
+	}
+	if uint32(len(r.buf)) < n {
+		r.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	v := r.buf[:n]
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *sshReader) string() string {
+	return string(r.bytes())
+}
+
+func (r *sshReader) mpint() *big.Int {
+	b := r.bytes()
+	if r.err != nil {
+		return nil
+	}
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		r.err = errors.New("negative mpint")
+		return nil
+	}
+	return new(big.Int).SetBytes(b)
+}
+
+// finish returns any error encountered, or an error if data remains unread.
+func (r *sshReader) finish() error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.buf) != 0 {
+		return errors.New("trailing data")
+	}
+	return nil
+}
+
+func sshAppendBytes(buf, b []byte) []byte {
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(b)))
+	return append(buf, b...)
+}
+
+func sshAppendString(buf []byte, s string) []byte {
+	return sshAppendBytes(buf, []byte(s))
+}
+
+// sshPublicKey is a public key decoded from the SSH wire format.
+type sshPublicKey struct {
+	keyType string
+	key     crypto.PublicKey
+	blob    []byte
+}
+
+func sshCurve(keyType string) (elliptic.Curve, string) {
+	switch keyType {
+	case sshKeyECDSA256:
+		return elliptic.P256(), "nistp256"
+	case sshKeyECDSA384:
+		return elliptic.P384(), "nistp384"
+	case sshKeyECDSA521:
+		return elliptic.P521(), "nistp521"
+	default:
+		return nil, ""
+	}
+}
+
+// parseSSHPublicKey decodes a public key blob in the SSH wire format.
+func parseSSHPublicKey(blob []byte) (*sshPublicKey, error) {
+	r := &sshReader{buf: blob}
+	pk := &sshPublicKey{
+		keyType: r.string(),
+		blob:    blob,
+	}
+
+	switch pk.keyType {
+	case sshKeyEd25519:
+		key := r.bytes()
+		if r.err == nil && len(key) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid ed25519 public key length")
+		}
+		pk.key = ed25519.PublicKey(key)
+	case sshKeyRSA:
+		e := r.mpint()
+		n := r.mpint()
+		if r.err != nil {
+			break
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA public exponent")
+		}
+		if n.BitLen() < sshMinRSABits {
+			return nil, errors.Errorf("RSA keys must be at least %d bits", sshMinRSABits)
+		}
+		pk.key = &rsa.PublicKey{N: n, E: int(e.Int64())}
+	case sshKeyECDSA256, sshKeyECDSA384, sshKeyECDSA521:
+		curve, curveName := sshCurve(pk.keyType)
+		if name := r.string(); r.err == nil && name != curveName {
+			return nil, errors.Errorf("curve %q does not match key type %q", name, pk.keyType)
+		}
+		point := r.bytes()
+		if r.err != nil {
+			break
+		}
+		x, y := elliptic.Unmarshal(curve, point)
+		if x == nil {
+			return nil, errors.New("invalid ECDSA public key")
+		}
+		pk.key = &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+	default:
+		if r.err == nil {
+			return nil, errors.Errorf("unsupported SSH key type %q", pk.keyType)
+		}
+	}
+
+	if err := r.finish(); err != nil {
+		return nil, errors.Wrap(err, "malformed SSH public key")
+	}
+	return pk, nil
+}
+
+// sshSignedData returns the data a client signs to prove possession of its key
+// for the given challenge, in the format produced by "ssh-keygen -Y sign".
+func sshSignedData(challenge []byte) []byte {
+	digest := sha512.Sum512(challenge)
+
+	buf := []byte(sshSigMagic)
+	buf = sshAppendString(buf, sshSigNamespace)
+	buf = sshAppendString(buf, "")
+	buf = sshAppendString(buf, sshSigHash)
+	return sshAppendBytes(buf, digest[:])
+}
+
+// verify checks an SSH wire-format signature blob over the data.
+func (pk *sshPublicKey) verify(data, sigBlob []byte) error {
+	r := &sshReader{buf: sigBlob}
+	format := r.string()
+	sig := r.bytes()
+	if err := r.finish(); err != nil {
+		return errors.Wrap(err, "malformed SSH signature")
+	}
+
+	switch pk.keyType {
+	case sshKeyEd25519:
+		if format != sshKeyEd25519 {
+			break
+		}
+		if !ed25519.Verify(pk.key.(ed25519.PublicKey), data, sig) {
+			return errors.New("invalid ed25519 signature")
+		}
+		return nil
+	case sshKeyRSA:
+		// The SHA-1 based "ssh-rsa" signature format is not accepted.
+		var hash crypto.Hash
+		var digest []byte
+		switch format {
+		case sshSigRSASHA256:
+			sum := sha256.Sum256(data)
+			hash, digest = crypto.SHA256, sum[:]
+		case sshSigRSASHA512:
+			sum := sha512.Sum512(data)
+			hash, digest = crypto.SHA512, sum[:]
+		default:
+			return errors.Errorf("unsupported RSA signature format %q", format)
+		}
+		return errors.Wrap(rsa.VerifyPKCS1v15(pk.key.(*rsa.PublicKey), hash, digest, sig), "invalid RSA signature")
+	case sshKeyECDSA256, sshKeyECDSA384, sshKeyECDSA521:
+		if format != pk.keyType {
+			break
+		}
+		sr := &sshReader{buf: sig}
+		rInt, sInt := sr.mpint(), sr.mpint()
+		if err := sr.finish(); err != nil {
+			return errors.Wrap(err, "malformed ECDSA signature")
+		}
+		var digest []byte
+		switch pk.keyType {
+		case sshKeyECDSA256:
+			sum := sha256.Sum256(data)
+			digest = sum[:]
+		case sshKeyECDSA384:
+			sum := sha512.Sum384(data)
+			digest = sum[:]
+		default:
+			sum := sha512.Sum512(data)
+			digest = sum[:]
+		}
+		if !ecdsa.Verify(pk.key.(*ecdsa.PublicKey), digest, rInt, sInt) {
+			return errors.New("invalid ECDSA signature")
+		}
+		return nil
+	}
+
+	return errors.Errorf("signature format %q does not match key type %q", format, pk.keyType)
+}
+
+// sshAuthorizedKey is a key from an authorized_keys-style file, along with
+// the DAOS identity it maps to.
+type sshAuthorizedKey struct {
+	key   *sshPublicKey
+	user  string
+	group string
+}
+
+func isSSHKeyType(s string) bool {
+	switch s {
+	case sshKeyEd25519, sshKeyRSA, sshKeyECDSA256, sshKeyECDSA384, sshKeyECDSA521:
+		return true
+	default:
+		return false
+	}
+}
+
+// splitSSHOptions splits an authorized_keys options field on commas which are
+// not within double quotes.
+func splitSSHOptions(opts string) []string {
+	var out []string
+	var quoted bool
+	start := 0
+	for i, c := range opts {
+		switch c {
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				out = append(out, opts[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(out, opts[start:])
+}
+
+// splitSSHKeyLine splits an authorized_keys line into fields on whitespace
+// which is not within double quotes.
+func splitSSHKeyLine(line string) []string {
+	var fields []string
+	var quoted bool
+	start := -1
+	for i, c := range line {
+		switch {
+		case c == '"':
+			quoted = !quoted
+			if start < 0 {
+				start = i
+			}
+		case (c == ' ' || c == '\t') && !quoted:
+			if start >= 0 {
+				fields = append(fields, line[start:i])
+				start = -1
+			}
+		default:
+			if start < 0 {
+				start = i
+			}
+		}
+	}
+	if start >= 0 {
+		fields = append(fields, line[start:])
+	}
+	return fields
+}
+
+// parseSSHAuthorizedKeys parses an authorized_keys-style file. Each key must
+// carry a daos-user="<user>" option naming the local user it authenticates,
+// and may carry a daos-group="<group>" option. Other options are ignored.
+//
+//	daos-user="jdoe",daos-group="hpc" ssh-ed25519 AAAAC3Nza... jdoe@laptop
+func parseSSHAuthorizedKeys(data []byte) ([]*sshAuthorizedKey, error) {
+	var keys []*sshAuthorizedKey
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := splitSSHKeyLine(line)
+		ak := &sshAuthorizedKey{}
+		if len(fields) > 0 && !isSSHKeyType(fields[0]) {
+			for _, opt := range splitSSHOptions(fields[0]) {
+				name, value, _ := strings.Cut(opt, "=")
+				value = strings.Trim(value, `"`)
+				switch strings.ToLower(name) {
+				case "daos-user":
+					ak.user = value
+				case "daos-group":
+					ak.group = value
+				}
+			}
+			fields = fields[1:]
+		}
+		if len(fields) < 2 {
+			return nil, errors.Errorf("line %d: missing key type or key", lineNum)
+		}
+		if ak.user == "" {
+			return nil, errors.Errorf("line %d: no daos-user option", lineNum)
+		}
+
+		blob, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineNum)
+		}
+		if ak.key, err = parseSSHPublicKey(blob); err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineNum)
+		}
+		if ak.key.keyType != fields[0] {
+			return nil, errors.Errorf("line %d: key type %q does not match key", lineNum, fields[0])
+		}
+		keys = append(keys, ak)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,224 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func sshAppendMPInt(buf []byte, n *big.Int) []byte {
+	b := n.Bytes()
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return sshAppendBytes(buf, b)
+}
+
+// testSSHPublicKey encodes a public key in the SSH wire format.
+func testSSHPublicKey(t *testing.T, key crypto.PublicKey) []byte {
+	t.Helper()
+
+	switch pub := key.(type) {
+	case ed25519.PublicKey:
+		return sshAppendBytes(sshAppendString(nil, sshKeyEd25519), pub)
+	case *rsa.PublicKey:
+		buf := sshAppendString(nil, sshKeyRSA)
+		buf = sshAppendMPInt(buf, big.NewInt(int64(pub.E)))
+		return sshAppendMPInt(buf, pub.N)
+	case *ecdsa.PublicKey:
+		buf := sshAppendString(nil, sshKeyECDSA256)
+		buf = sshAppendString(buf, "nistp256")
+		return sshAppendBytes(buf, elliptic.Marshal(pub.Curve, pub.X, pub.Y))
+	default:
+		t.Fatalf("unsupported key type %T", key)
+		return nil
+	}
+}
+
+// testSSHSign produces an SSH wire-format signature over the data, as
+// ssh-agent would.
+func testSSHSign(t *testing.T, key crypto.Signer, data []byte) []byte {
+	t.Helper()
+
+	var format string
+	var sig []byte
+	var err error
+	switch priv := key.(type) {
+	case ed25519.PrivateKey:
+		format, sig = sshKeyEd25519, ed25519.Sign(priv, data)
+	case *rsa.PrivateKey:
+		digest := sha512.Sum512(data)
+		format = sshSigRSASHA512
+		sig, err = rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA512, digest[:])
+	case *ecdsa.PrivateKey:
+		digest := sha256.Sum256(data)
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, priv, digest[:])
+		if err == nil {
+			format = sshKeyECDSA256
+			sig = sshAppendMPInt(sshAppendMPInt(nil, r), s)
+		}
+	default:
+		t.Fatalf("unsupported key type %T", key)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sshAppendBytes(sshAppendString(nil, format), sig)
+}
+
+func testSSHKeys(t *testing.T) map[string]crypto.Signer {
+	t.Helper()
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string]crypto.Signer{
+		sshKeyEd25519:  edKey,
+		sshKeyRSA:      rsaKey,
+		sshKeyECDSA256: ecKey,
+	}
+}
+
+func TestAuth_sshPublicKey_verify(t *testing.T) {
+	keys := testSSHKeys(t)
+	data := sshSignedData([]byte("challenge"))
+
+	for keyType, key := range keys {
+		t.Run(keyType, func(t *testing.T) {
+			pk, err := parseSSHPublicKey(testSSHPublicKey(t, key.Public()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, keyType, pk.keyType, "unexpected key type")
+
+			sig := testSSHSign(t, key, data)
+			test.CmpErr(t, nil, pk.verify(data, sig))
+
+			if err := pk.verify(sshSignedData([]byte("other")), sig); err == nil {
+				t.Fatal("expected signature over other data to fail")
+			}
+			for otherType, other := range keys {
+				if otherType == keyType {
+					continue
+				}
+				if err := pk.verify(data, testSSHSign(t, other, data)); err == nil {
+					t.Fatalf("expected signature by %s key to fail", otherType)
+				}
+			}
+		})
+	}
+}
+
+func TestAuth_parseSSHPublicKey(t *testing.T) {
+	smallRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		blob   []byte
+		expErr error
+	}{
+		"empty": {
+			expErr: errors.New("malformed"),
+		},
+		"unsupported type": {
+			blob:   sshAppendString(nil, "ssh-dss"),
+			expErr: errors.New("unsupported SSH key type"),
+		},
+		"truncated ed25519": {
+			blob:   sshAppendBytes(sshAppendString(nil, sshKeyEd25519), []byte{1, 2, 3}),
+			expErr: errors.New("length"),
+		},
+		"trailing data": {
+			blob:   append(testSSHPublicKey(t, testSSHKeys(t)[sshKeyEd25519].Public()), 0),
+			expErr: errors.New("trailing data"),
+		},
+		"small RSA key": {
+			blob:   testSSHPublicKey(t, &smallRSA.PublicKey),
+			expErr: errors.New("at least 2048 bits"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseSSHPublicKey(tc.blob)
+			test.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestAuth_parseSSHAuthorizedKeys(t *testing.T) {
+	edKey := testSSHKeys(t)[sshKeyEd25519]
+	blob := base64.StdEncoding.EncodeToString(testSSHPublicKey(t, edKey.Public()))
+
+	for name, tc := range map[string]struct {
+		data     string
+		expUser  string
+		expGroup string
+		expErr   error
+	}{
+		"user and group": {
+			data:     fmt.Sprintf("# comment\n\ndaos-user=\"jdoe\",daos-group=\"hpc\" ssh-ed25519 %s jdoe@laptop\n", blob),
+			expUser:  "jdoe",
+			expGroup: "hpc",
+		},
+		"other options ignored": {
+			data:    fmt.Sprintf("no-pty,from=\"10.0.0.1,10.0.0.2\",daos-user=\"jdoe\" ssh-ed25519 %s", blob),
+			expUser: "jdoe",
+		},
+		"no user": {
+			data:   fmt.Sprintf("ssh-ed25519 %s", blob),
+			expErr: errors.New("no daos-user option"),
+		},
+		"mismatched type": {
+			data:   fmt.Sprintf("daos-user=\"jdoe\" ssh-rsa %s", blob),
+			expErr: errors.New("does not match key"),
+		},
+		"bad base64": {
+			data:   "daos-user=\"jdoe\" ssh-ed25519 !!!",
+			expErr: errors.New("line 1"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			keys, err := parseSSHAuthorizedKeys([]byte(tc.data))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, 1, len(keys), "unexpected number of keys")
+			test.AssertEqual(t, tc.expUser, keys[0].user, "unexpected user")
+			test.AssertEqual(t, tc.expGroup, keys[0].group, "unexpected group")
+		})
+	}
+}
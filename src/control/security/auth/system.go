@@ -0,0 +1,56 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// credentialSystem, if set, is recorded in the tokens of new credentials.
+var credentialSystem atomic.Pointer[string]
+
+// SetCredentialSystem sets the name of the system which is recorded in the
+// token of each new credential, so that it is covered by the verifier and
+// servers of other systems which trust the same agent key reject it. If name
+// is empty, none is recorded.
+func SetCredentialSystem(name string) {
+	if name == "" {
+		credentialSystem.Store(nil)
+		return
+	}
+	credentialSystem.Store(&name)
+}
+
+// stampSystem records the name of the system, if any, in the token, unless it
+// already records one.
+func stampSystem(token *Token) {
+	if token.GetSystem() != "" {
+		return
+	}
+	if name := credentialSystem.Load(); name != nil {
+		token.System = *name
+	}
+}
+
+// ValidateTokenSystem checks that the token was signed for the named system. A
+// token which records no system is accepted unless binding is required. The
+// system must have been checked to be authentic, by verifying the credential,
+// beforehand.
+func ValidateTokenSystem(token *Token, system string, required bool) error {
+	switch tokenSys := token.GetSystem(); {
+	case tokenSys == "":
+		if required {
+			return errors.New("credential is not bound to a system")
+		}
+		return nil
+	case tokenSys != system:
+		return errors.Errorf("credential was signed for system %q, not %q", tokenSys, system)
+	}
+	return nil
+}
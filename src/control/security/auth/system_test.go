@@ -0,0 +1,85 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestAuth_SetCredentialSystem(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer SetCredentialSystem("")
+
+	for name, tc := range map[string]struct {
+		system    string
+		expSystem string
+	}{
+		"no system": {},
+		"system": {
+			system:    "daos_server",
+			expSystem: "daos_server",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			SetCredentialSystem(tc.system)
+
+			cred, err := newSignedCredential(Flavor_AUTH_SYS, key, &Sys{User: "user@"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expSystem, cred.GetToken().GetSystem(), "unexpected system")
+
+			// The system is covered by the verifier.
+			if err := VerifyCredential(key.Public(), cred); err != nil {
+				t.Fatal(err)
+			}
+			cred.Token.System = "other"
+			if err := VerifyCredential(key.Public(), cred); err == nil {
+				t.Fatal("verification succeeded after the system was changed")
+			}
+		})
+	}
+}
+
+func TestAuth_ValidateTokenSystem(t *testing.T) {
+	for name, tc := range map[string]struct {
+		token    *Token
+		required bool
+		expErr   error
+	}{
+		"not bound": {
+			token: &Token{},
+		},
+		"not bound; required": {
+			token:    &Token{},
+			required: true,
+			expErr:   errors.New("credential is not bound to a system"),
+		},
+		"same system": {
+			token:    &Token{System: "daos_server"},
+			required: true,
+		},
+		"other system": {
+			token:  &Token{System: "other"},
+			expErr: errors.New(`credential was signed for system "other", not "daos_server"`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, ValidateTokenSystem(tc.token, "daos_server", tc.required))
+		})
+	}
+}
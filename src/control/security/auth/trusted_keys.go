@@ -0,0 +1,107 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// TrustedKeys is a set of public keys trusted to sign credentials, such as the
+// current and previous keys of an agent during the window in which its key is
+// being rotated, and credentials signed with either are still in use. The keys
+// are kept in the order they were added, current first.
+type TrustedKeys struct {
+	ids  []string
+	keys map[string]crypto.PublicKey
+}
+
+// NewTrustedKeys returns a set of the trusted keys.
+func NewTrustedKeys(keys ...crypto.PublicKey) (*TrustedKeys, error) {
+	tk := &TrustedKeys{keys: make(map[string]crypto.PublicKey)}
+	for _, key := range keys {
+		if _, err := tk.Add(key); err != nil {
+			return nil, err
+		}
+	}
+	return tk, nil
+}
+
+// Add adds the key to the set, if it is not already in it, and returns its ID.
+func (tk *TrustedKeys) Add(key crypto.PublicKey) (string, error) {
+	keyID, err := security.PublicKeyID(key)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to identify trusted key")
+	}
+	if _, found := tk.keys[keyID]; !found {
+		tk.ids = append(tk.ids, keyID)
+		tk.keys[keyID] = key
+	}
+	return keyID, nil
+}
+
+// Key returns the trusted key with the ID, or nil if it is not trusted.
+func (tk *TrustedKeys) Key(keyID string) crypto.PublicKey {
+	if tk == nil {
+		return nil
+	}
+	return tk.keys[keyID]
+}
+
+// IDs returns the IDs of the trusted keys, in the order they were added.
+func (tk *TrustedKeys) IDs() []string {
+	if tk == nil {
+		return nil
+	}
+	return slices.Clone(tk.ids)
+}
+
+// VerifyCredentialWithKeys verifies the credential with one of the trusted keys
+// and returns the ID of the key which signed it. A credential which records the
+// ID of its signing key is only verified with the key with that ID. Otherwise,
+// as a credential signed by an older agent, it is verified with each key in
+// turn until one succeeds. If the set is nil, the credential's verifier is
+// checked as an unsigned hash, and no key ID is returned.
+func VerifyCredentialWithKeys(tk *TrustedKeys, cred *Credential) (string, error) {
+	if tk == nil {
+		return "", VerifyCredential(nil, cred)
+	}
+
+	if cred.GetKeyId() != "" {
+		keyID, err := ParseKeyID(cred.GetKeyId())
+		if err != nil {
+			return "", err
+		}
+		key, found := tk.keys[keyID]
+		if !found {
+			return "", errors.Wrapf(daos.UnknownKey, "credential was signed by key %s, which is not trusted", keyID)
+		}
+		return keyID, VerifyCredential(key, cred)
+	}
+
+	if len(tk.ids) == 0 {
+		return "", errors.Wrap(daos.UnknownKey, "no keys are trusted")
+	}
+	for _, keyID := range tk.ids {
+		err := VerifyCredential(tk.keys[keyID], cred)
+		if err == nil {
+			return keyID, nil
+		}
+		// Only a signature made with another key is tried against the
+		// next; a credential which can't be verified at all, such as one
+		// with an unknown verifier hash, is rejected as it is.
+		if !errors.Is(err, daos.BadSignature) {
+			return "", err
+		}
+	}
+	return "", errors.Wrapf(daos.BadSignature, "credential did not verify with any of the %d trusted keys", len(tk.ids))
+}
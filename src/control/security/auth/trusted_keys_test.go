@@ -0,0 +1,126 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_VerifyCredentialWithKeys(t *testing.T) {
+	newKey := func() (*ecdsa.PrivateKey, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyID, err := security.PublicKeyID(key.Public())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return key, keyID
+	}
+	current, currentID := newKey()
+	previous, previousID := newKey()
+	other, otherID := newKey()
+
+	// Credentials signed by older agents don't record their key ID.
+	newCred := func(key crypto.PrivateKey, withKeyID bool) *Credential {
+		cred, err := newSignedCredential(Flavor_AUTH_SYS, key, &Sys{User: "jdoe@"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !withKeyID {
+			cred.KeyId = ""
+		}
+		return cred
+	}
+
+	trusted, err := NewTrustedKeys(current.Public(), previous.Public(), current.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.CmpAny(t, "key IDs", []string{currentID, previousID}, trusted.IDs())
+
+	empty, err := NewTrustedKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		keys     *TrustedKeys
+		cred     *Credential
+		expKeyID string
+		expErr   error
+	}{
+		"selected by key ID": {
+			keys:     trusted,
+			cred:     newCred(previous, true),
+			expKeyID: previousID,
+		},
+		"untrusted key ID": {
+			keys:   trusted,
+			cred:   newCred(other, true),
+			expErr: errors.New("signed by key " + otherID + ", which is not trusted"),
+		},
+		"current key tried": {
+			keys:     trusted,
+			cred:     newCred(current, false),
+			expKeyID: currentID,
+		},
+		"previous key tried": {
+			keys:     trusted,
+			cred:     newCred(previous, false),
+			expKeyID: previousID,
+		},
+		"no key verifies": {
+			keys:   trusted,
+			cred:   newCred(other, false),
+			expErr: errors.New("did not verify with any of the 2 trusted keys"),
+		},
+		"unknown verifier hash": {
+			keys: trusted,
+			cred: func() *Credential {
+				cred := newCred(current, false)
+				cred.Verifier.Hash = HashAlgorithm(42)
+				return cred
+			}(),
+			expErr: errors.New("unknown verifier hash algorithm 42"),
+		},
+		"no keys": {
+			keys:   empty,
+			cred:   newCred(current, false),
+			expErr: errors.New("no keys are trusted"),
+		},
+		"unsigned": {
+			cred: newCred(nil, false),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			keyID, err := VerifyCredentialWithKeys(tc.keys, tc.cred)
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expKeyID, keyID, "unexpected key ID")
+		})
+	}
+
+	_, err = VerifyCredentialWithKeys(trusted, newCred(other, true))
+	test.AssertEqual(t, daos.UnknownKey, VerificationStatus(err), "unexpected status")
+	_, err = VerifyCredentialWithKeys(trusted, newCred(other, false))
+	test.AssertEqual(t, daos.BadSignature, VerificationStatus(err), "unexpected status")
+	unknownHash := newCred(current, false)
+	unknownHash.Verifier.Hash = HashAlgorithm(42)
+	_, err = VerifyCredentialWithKeys(trusted, unknownHash)
+	test.AssertEqual(t, daos.NoPermission, VerificationStatus(err), "unexpected status")
+}
@@ -0,0 +1,121 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// idMapping is a line of the uid_map or gid_map of a user namespace: count
+// IDs starting at inside in the namespace are mapped to those starting at
+// outside, as seen from the namespace of the reader.
+type idMapping struct {
+	inside  uint32
+	outside uint32
+	count   uint32
+}
+
+// inUserNamespace reports whether the process is in a different user namespace
+// than the agent.
+func inUserNamespace(pid int32) (bool, error) {
+	ns, err := os.Readlink(filepath.Join(procRoot, fmt.Sprint(pid), "ns", "user"))
+	if err != nil {
+		return false, errors.Wrapf(err, "reading user namespace of pid %d", pid)
+	}
+	self, err := os.Readlink(filepath.Join(procRoot, "self", "ns", "user"))
+	if err != nil {
+		return false, errors.Wrap(err, "reading user namespace of agent")
+	}
+	return ns != self, nil
+}
+
+// readIDMap reads the uid_map or gid_map of the process's user namespace.
+func readIDMap(pid int32, name string) ([]idMapping, error) {
+	data, err := os.ReadFile(filepath.Join(procRoot, fmt.Sprint(pid), name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s of pid %d", name, pid)
+	}
+
+	var mappings []idMapping
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, errors.Errorf("malformed %s line %q", name, scanner.Text())
+		}
+		var vals [3]uint32
+		for i, f := range fields {
+			v, err := strconv.ParseUint(f, 10, 32)
+			if err != nil {
+				return nil, errors.Wrapf(err, "malformed %s line %q", name, scanner.Text())
+			}
+			vals[i] = uint32(v)
+		}
+		mappings = append(mappings, idMapping{inside: vals[0], outside: vals[1], count: vals[2]})
+	}
+	return mappings, scanner.Err()
+}
+
+// namespaceOwnerID returns the ID outside the namespace to which ID 0 in the
+// namespace is mapped, or false if it is not mapped.
+func namespaceOwnerID(mappings []idMapping) (uint32, bool) {
+	for _, m := range mappings {
+		if m.inside == 0 && m.count > 0 {
+			return m.outside, true
+		}
+	}
+	return 0, false
+}
+
+// translateNamespaceID translates an ID of a process in the namespace to the
+// ID of the namespace owner. The IDs of processes in a rootless container,
+// other than its root, are subordinate IDs belonging to no user, so they act
+// as the unprivileged user to which the container's root is mapped. IDs are
+// not translated if the namespace's root is not mapped or is mapped to root,
+// in which case the namespace is not a rootless container.
+func translateNamespaceID(mappings []idMapping, id uint32) uint32 {
+	owner, found := namespaceOwnerID(mappings)
+	if !found || owner == 0 {
+		return id
+	}
+	for _, m := range mappings {
+		if id >= m.outside && id-m.outside < m.count {
+			return owner
+		}
+	}
+	return id
+}
+
+// userNamespaceIDs returns the uid and gid a process should be identified by.
+// The IDs of a process in the agent's own user namespace are returned as is.
+func userNamespaceIDs(pid int32, uid, gid uint32) (uint32, uint32, error) {
+	inNS, err := inUserNamespace(pid)
+	if err != nil || !inNS {
+		return uid, gid, err
+	}
+
+	uidMap, err := readIDMap(pid, "uid_map")
+	if err != nil {
+		return 0, 0, err
+	}
+	gidMap, err := readIDMap(pid, "gid_map")
+	if err != nil {
+		return 0, 0, err
+	}
+	return translateNamespaceID(uidMap, uid), translateNamespaceID(gidMap, gid), nil
+}
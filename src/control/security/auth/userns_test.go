@@ -0,0 +1,130 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+// setTestProcUserNS creates a process in a fake procfs, in the agent's user
+// namespace if uidMap is empty, or in its own otherwise.
+func setTestProcUserNS(t *testing.T, pid int32, uidMap, gidMap string) {
+	t.Helper()
+	orig := procRoot
+	t.Cleanup(func() { procRoot = orig })
+	procRoot = t.TempDir()
+
+	writeNS := func(proc, ns string) string {
+		dir := filepath.Join(procRoot, proc)
+		if err := os.MkdirAll(filepath.Join(dir, "ns"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(ns, filepath.Join(dir, "ns", "user")); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+	writeNS("self", "user:[4026531837]")
+	if uidMap == "" {
+		writeNS(fmt.Sprint(pid), "user:[4026531837]")
+		return
+	}
+
+	dir := writeNS(fmt.Sprint(pid), "user:[4026532451]")
+	for name, data := range map[string]string{"uid_map": uidMap, "gid_map": gidMap} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAuth_userNamespaceIDs(t *testing.T) {
+	rootless := "         0       1000          1\n         1     100000      65536\n"
+	rootlessGroups := "         0        100          1\n         1     100000      65536\n"
+
+	for name, tc := range map[string]struct {
+		uidMap string
+		gidMap string
+		uid    uint32
+		gid    uint32
+		expUID uint32
+		expGID uint32
+		expErr error
+	}{
+		"agent namespace": {
+			uid:    100032,
+			gid:    100032,
+			expUID: 100032,
+			expGID: 100032,
+		},
+		"rootless container root": {
+			uidMap: rootless,
+			gidMap: rootlessGroups,
+			uid:    1000,
+			gid:    100,
+			expUID: 1000,
+			expGID: 100,
+		},
+		"rootless container user": {
+			uidMap: rootless,
+			gidMap: rootlessGroups,
+			uid:    100032,
+			gid:    100032,
+			expUID: 1000,
+			expGID: 100,
+		},
+		"identity mapping": {
+			uidMap: "1000 1000 1\n",
+			gidMap: "100 100 1\n",
+			uid:    1000,
+			gid:    100,
+			expUID: 1000,
+			expGID: 100,
+		},
+		"root mapped to root": {
+			uidMap: "0 0 1\n1 100000 65536\n",
+			gidMap: "0 0 1\n1 100000 65536\n",
+			uid:    100032,
+			gid:    100032,
+			expUID: 100032,
+			expGID: 100032,
+		},
+		"malformed map": {
+			uidMap: "0 1000\n",
+			gidMap: rootlessGroups,
+			uid:    1000,
+			gid:    100,
+			expErr: errors.New("malformed uid_map"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setTestProcUserNS(t, 4242, tc.uidMap, tc.gidMap)
+
+			uid, gid, err := userNamespaceIDs(4242, tc.uid, tc.gid)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertEqual(t, tc.expUID, uid, "unexpected uid")
+			test.AssertEqual(t, tc.expGID, gid, "unexpected gid")
+		})
+	}
+}
+
+func TestAuth_userNamespaceIDs_NoProcess(t *testing.T) {
+	setTestProcUserNS(t, 4242, "", "")
+
+	_, _, err := userNamespaceIDs(4243, 1000, 1000)
+	test.CmpErr(t, errors.New("reading user namespace of pid 4243"), err)
+}
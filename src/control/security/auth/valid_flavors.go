@@ -0,0 +1,137 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ValidFlavorList is the list of flavors of credentials allowed throughout
+// the system once it has been changed at runtime. It is stored by the
+// management service, which returns it to agents in place of the flavors in
+// its configuration, and each server accepts credentials of the flavors in
+// the latest version it has fetched.
+type ValidFlavorList struct {
+	Version uint64   `json:"version"`
+	Flavors []Flavor `json:"flavors,omitempty"`
+}
+
+func checkFlavor(flavor Flavor) error {
+	if _, found := Flavor_name[int32(flavor)]; !found && flavor < MinPluginFlavor {
+		return errors.Errorf("unknown auth flavor %d", flavor)
+	}
+	return nil
+}
+
+// Update adds and removes the flavors, returning true, and incrementing the
+// version of the list, if it was changed. The list is unchanged if any of the
+// flavors are invalid, or if no flavors would remain.
+func (vl *ValidFlavorList) Update(add, remove []Flavor) (bool, error) {
+	if vl == nil {
+		return false, errors.New("nil valid flavor list")
+	}
+
+	flavors := slices.Clone(vl.Flavors)
+	for _, flavor := range add {
+		if err := checkFlavor(flavor); err != nil {
+			return false, err
+		}
+		if slices.Contains(remove, flavor) {
+			return false, errors.Errorf("auth flavor %s may not be both added and removed", flavor)
+		}
+		if !slices.Contains(flavors, flavor) {
+			flavors = append(flavors, flavor)
+		}
+	}
+	for _, flavor := range remove {
+		if err := checkFlavor(flavor); err != nil {
+			return false, err
+		}
+		flavors = slices.DeleteFunc(flavors, func(f Flavor) bool { return f == flavor })
+	}
+
+	if len(flavors) == 0 {
+		return false, errors.New("at least one auth flavor must remain valid")
+	}
+	if slices.Equal(flavors, vl.Flavors) {
+		return false, nil
+	}
+	vl.Flavors = flavors
+	vl.Version++
+	return true, nil
+}
+
+// ValidFlavors is the list of flavors of credentials which a server accepts.
+type ValidFlavors struct {
+	sync.RWMutex
+	version uint64
+	flavors []Flavor
+}
+
+// NewValidFlavors returns the flavors configured for the server, which are
+// accepted until the list is changed at runtime.
+func NewValidFlavors(flavors []Flavor) *ValidFlavors {
+	return &ValidFlavors{flavors: slices.Clone(flavors)}
+}
+
+// Update replaces the flavors with the list, if it is newer. It returns true
+// if they were replaced.
+func (vf *ValidFlavors) Update(list *ValidFlavorList) bool {
+	if vf == nil || list == nil {
+		return false
+	}
+
+	vf.Lock()
+	defer vf.Unlock()
+
+	if list.Version <= vf.version {
+		return false
+	}
+	vf.version = list.Version
+	vf.flavors = slices.Clone(list.Flavors)
+	return true
+}
+
+// Version returns the version of the list last installed, or zero if the
+// configured flavors are in use.
+func (vf *ValidFlavors) Version() uint64 {
+	if vf == nil {
+		return 0
+	}
+
+	vf.RLock()
+	defer vf.RUnlock()
+
+	return vf.version
+}
+
+// Flavors returns the flavors accepted.
+func (vf *ValidFlavors) Flavors() []Flavor {
+	if vf == nil {
+		return nil
+	}
+
+	vf.RLock()
+	defer vf.RUnlock()
+
+	return slices.Clone(vf.flavors)
+}
+
+// Contains returns true if credentials of the flavor are accepted.
+func (vf *ValidFlavors) Contains(flavor Flavor) bool {
+	if vf == nil {
+		return false
+	}
+
+	vf.RLock()
+	defer vf.RUnlock()
+
+	return slices.Contains(vf.flavors, flavor)
+}
@@ -0,0 +1,125 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestAuth_ValidFlavorList(t *testing.T) {
+	configured := func() *ValidFlavorList {
+		return &ValidFlavorList{Flavors: []Flavor{Flavor_AUTH_SYS, Flavor_AUTH_MACHINE}}
+	}
+
+	for name, tc := range map[string]struct {
+		list       *ValidFlavorList
+		add        []Flavor
+		remove     []Flavor
+		expChanged bool
+		expList    *ValidFlavorList
+		expErr     error
+	}{
+		"nil list": {
+			add:    []Flavor{Flavor_AUTH_SYS},
+			expErr: errors.New("nil valid flavor list"),
+		},
+		"added": {
+			list:       configured(),
+			add:        []Flavor{Flavor_AUTH_TOTP, Flavor_AUTH_SYS},
+			expChanged: true,
+			expList: &ValidFlavorList{
+				Version: 1,
+				Flavors: []Flavor{Flavor_AUTH_SYS, Flavor_AUTH_MACHINE, Flavor_AUTH_TOTP},
+			},
+		},
+		"removed": {
+			list:       &ValidFlavorList{Version: 2, Flavors: []Flavor{Flavor_AUTH_SYS, Flavor_AUTH_MACHINE}},
+			remove:     []Flavor{Flavor_AUTH_SYS},
+			expChanged: true,
+			expList:    &ValidFlavorList{Version: 3, Flavors: []Flavor{Flavor_AUTH_MACHINE}},
+		},
+		"added and removed": {
+			list:       configured(),
+			add:        []Flavor{Flavor_AUTH_TOTP},
+			remove:     []Flavor{Flavor_AUTH_SYS},
+			expChanged: true,
+			expList: &ValidFlavorList{
+				Version: 1,
+				Flavors: []Flavor{Flavor_AUTH_MACHINE, Flavor_AUTH_TOTP},
+			},
+		},
+		"plugin flavor": {
+			list:       configured(),
+			add:        []Flavor{MinPluginFlavor},
+			expChanged: true,
+			expList: &ValidFlavorList{
+				Version: 1,
+				Flavors: []Flavor{Flavor_AUTH_SYS, Flavor_AUTH_MACHINE, MinPluginFlavor},
+			},
+		},
+		"unchanged": {
+			list:    configured(),
+			add:     []Flavor{Flavor_AUTH_SYS},
+			remove:  []Flavor{Flavor_AUTH_TOTP},
+			expList: configured(),
+		},
+		"unknown flavor": {
+			list:    configured(),
+			add:     []Flavor{MinPluginFlavor - 1},
+			expErr:  errors.New("unknown auth flavor"),
+			expList: configured(),
+		},
+		"added and removed the same flavor": {
+			list:    configured(),
+			add:     []Flavor{Flavor_AUTH_TOTP},
+			remove:  []Flavor{Flavor_AUTH_TOTP},
+			expErr:  errors.New("both added and removed"),
+			expList: configured(),
+		},
+		"none left": {
+			list:    configured(),
+			remove:  []Flavor{Flavor_AUTH_SYS, Flavor_AUTH_MACHINE},
+			expErr:  errors.New("at least one auth flavor"),
+			expList: configured(),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			changed, err := tc.list.Update(tc.add, tc.remove)
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expChanged, changed, "unexpected change")
+			if diff := cmp.Diff(tc.expList, tc.list); diff != "" {
+				t.Fatalf("unexpected list (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestAuth_ValidFlavors(t *testing.T) {
+	var nilFlavors *ValidFlavors
+	test.AssertFalse(t, nilFlavors.Contains(Flavor_AUTH_SYS), "nil flavors contain AUTH_SYS")
+	test.AssertFalse(t, nilFlavors.Update(&ValidFlavorList{Version: 1}), "nil flavors updated")
+
+	vf := NewValidFlavors([]Flavor{Flavor_AUTH_SYS})
+	test.AssertTrue(t, vf.Contains(Flavor_AUTH_SYS), "configured flavor not valid")
+	test.AssertFalse(t, vf.Contains(Flavor_AUTH_TOTP), "unconfigured flavor valid")
+	test.AssertEqual(t, uint64(0), vf.Version(), "unexpected version")
+
+	test.AssertTrue(t, vf.Update(&ValidFlavorList{Version: 2, Flavors: []Flavor{Flavor_AUTH_TOTP}}),
+		"newer list not installed")
+	test.AssertFalse(t, vf.Contains(Flavor_AUTH_SYS), "removed flavor valid")
+	test.AssertTrue(t, vf.Contains(Flavor_AUTH_TOTP), "added flavor not valid")
+
+	test.AssertFalse(t, vf.Update(&ValidFlavorList{Version: 1, Flavors: []Flavor{Flavor_AUTH_SYS}}),
+		"older list installed")
+	test.AssertEqual(t, []Flavor{Flavor_AUTH_TOTP}, vf.Flavors(), "unexpected flavors")
+	test.AssertEqual(t, uint64(2), vf.Version(), "unexpected version")
+}
@@ -0,0 +1,161 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// Validator checks the credentials accepted by the server's own checks before
+// they are accepted, so that sites can add checks of their own, e.g.
+// cross-checking the job of the requesting process against the scheduler.
+type Validator interface {
+	// Validate returns an error if the credential must be rejected. The token
+	// has been verified, and sys is the identity it carries. A daos.Status
+	// error is reported to the client as it is, and any other error as
+	// NoPermission. It must return once the context is done.
+	Validate(ctx context.Context, token *Token, sys *Sys) error
+}
+
+// ValidatorFactory creates a validator with the options configured for it.
+type ValidatorFactory func(log logging.Logger, options map[string]string) (Validator, error)
+
+var (
+	validatorFactoriesMutex sync.RWMutex
+	validatorFactories      = make(map[string]ValidatorFactory)
+)
+
+// RegisterValidator makes the validator created by the factory available to
+// the server configuration under the name. It is meant to be called from the
+// init function of a package compiled into the server, and panics if the name
+// is already registered.
+func RegisterValidator(name string, factory ValidatorFactory) {
+	validatorFactoriesMutex.Lock()
+	defer validatorFactoriesMutex.Unlock()
+
+	if factory == nil {
+		panic("auth: RegisterValidator factory is nil")
+	}
+	if _, found := validatorFactories[name]; found {
+		panic("auth: RegisterValidator called twice for " + name)
+	}
+	validatorFactories[name] = factory
+}
+
+// RegisteredValidators returns the names of the validators available.
+func RegisteredValidators() []string {
+	validatorFactoriesMutex.RLock()
+	defer validatorFactoriesMutex.RUnlock()
+
+	names := make([]string, 0, len(validatorFactories))
+	for name := range validatorFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func getValidatorFactory(name string) (ValidatorFactory, bool) {
+	validatorFactoriesMutex.RLock()
+	defer validatorFactoriesMutex.RUnlock()
+
+	factory, found := validatorFactories[name]
+	return factory, found
+}
+
+type namedValidator struct {
+	Validator
+	name    string
+	timeout time.Duration
+}
+
+// Validators are the validators enabled on a server, which check each
+// credential in the order they are configured.
+type Validators struct {
+	validators []namedValidator
+}
+
+// NewValidators creates the validators configured. If none are configured,
+// nil is returned, which accepts all credentials.
+func NewValidators(log logging.Logger, cfgs []*security.CredentialValidatorConfig) (*Validators, error) {
+	if len(cfgs) == 0 {
+		return nil, nil
+	}
+
+	vs := &Validators{}
+	for _, cfg := range cfgs {
+		if err := cfg.Validate(); err != nil {
+			return nil, err
+		}
+		factory, found := getValidatorFactory(cfg.Name)
+		if !found {
+			return nil, errors.Errorf("unknown credential validator %q (registered: %v)",
+				cfg.Name, RegisteredValidators())
+		}
+		v, err := factory(log, cfg.Options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "creating credential validator %q", cfg.Name)
+		}
+		vs.validators = append(vs.validators, namedValidator{
+			Validator: v,
+			name:      cfg.Name,
+			timeout:   cfg.ValidatorTimeout(),
+		})
+	}
+	return vs, nil
+}
+
+// Names returns the names of the validators, in the order they are run.
+func (vs *Validators) Names() []string {
+	if vs == nil {
+		return nil
+	}
+
+	names := make([]string, len(vs.validators))
+	for i, v := range vs.validators {
+		names[i] = v.name
+	}
+	return names
+}
+
+// Validate runs the validators on the token of a verified credential, and
+// returns the error of the first which rejects it. A validator which doesn't
+// accept the credential within its timeout rejects it.
+func (vs *Validators) Validate(ctx context.Context, token *Token) error {
+	if vs == nil {
+		return nil
+	}
+
+	sys, err := sysFromToken(token)
+	if err != nil {
+		return err
+	}
+	for _, v := range vs.validators {
+		if err := vs.validate(ctx, v, token, sys); err != nil {
+			return errors.Wrapf(err, "credential validator %q", v.name)
+		}
+	}
+	return nil
+}
+
+func (vs *Validators) validate(parent context.Context, v namedValidator, token *Token, sys *Sys) error {
+	ctx, cancel := context.WithTimeout(parent, v.timeout)
+	defer cancel()
+
+	if err := v.Validate(ctx, token, sys); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,137 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+type testUserValidator struct {
+	rejected string
+}
+
+func (v *testUserValidator) Validate(_ context.Context, _ *Token, sys *Sys) error {
+	if sys.GetUser() == v.rejected {
+		return errors.Wrapf(daos.NoPermission, "user %s rejected", sys.GetUser())
+	}
+	return nil
+}
+
+type testSlowValidator struct{}
+
+func (v *testSlowValidator) Validate(ctx context.Context, _ *Token, _ *Sys) error {
+	<-ctx.Done()
+	return nil
+}
+
+func init() {
+	RegisterValidator("test_user", func(_ logging.Logger, options map[string]string) (Validator, error) {
+		if options["rejected"] == "" {
+			return nil, errors.New("rejected must be set")
+		}
+		return &testUserValidator{rejected: options["rejected"]}, nil
+	})
+	RegisterValidator("test_slow", func(logging.Logger, map[string]string) (Validator, error) {
+		return &testSlowValidator{}, nil
+	})
+}
+
+func TestAuth_RegisterValidator(t *testing.T) {
+	defer func() {
+		test.AssertTrue(t, recover() != nil, "registering a name twice didn't panic")
+	}()
+
+	test.AssertEqual(t, []string{"test_slow", "test_user"}, RegisteredValidators(),
+		"unexpected registered validators")
+	RegisterValidator("test_user", func(logging.Logger, map[string]string) (Validator, error) {
+		return &testSlowValidator{}, nil
+	})
+}
+
+func TestAuth_Validators(t *testing.T) {
+	rejectRoot := &security.CredentialValidatorConfig{
+		Name:    "test_user",
+		Options: map[string]string{"rejected": "root@"},
+	}
+
+	for name, tc := range map[string]struct {
+		cfgs         []*security.CredentialValidatorConfig
+		user         string
+		expNames     []string
+		expCreateErr error
+		expErr       error
+		expErrStatus daos.Status
+	}{
+		"none": {
+			user: "root@",
+		},
+		"unknown": {
+			cfgs:         []*security.CredentialValidatorConfig{{Name: "test_other"}},
+			expCreateErr: errors.New(`unknown credential validator "test_other"`),
+		},
+		"factory failed": {
+			cfgs:         []*security.CredentialValidatorConfig{{Name: "test_user"}},
+			expCreateErr: errors.New("rejected must be set"),
+		},
+		"accepted": {
+			cfgs:     []*security.CredentialValidatorConfig{rejectRoot},
+			user:     "jdoe@",
+			expNames: []string{"test_user"},
+		},
+		"rejected": {
+			cfgs:         []*security.CredentialValidatorConfig{rejectRoot},
+			user:         "root@",
+			expNames:     []string{"test_user"},
+			expErr:       errors.New(`credential validator "test_user": user root@ rejected`),
+			expErrStatus: daos.NoPermission,
+		},
+		"timed out": {
+			cfgs: []*security.CredentialValidatorConfig{
+				rejectRoot,
+				{Name: "test_slow", Timeout: time.Millisecond},
+			},
+			user:         "jdoe@",
+			expNames:     []string{"test_user", "test_slow"},
+			expErr:       errors.New(`credential validator "test_slow": context deadline exceeded`),
+			expErrStatus: daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			vs, err := NewValidators(log, tc.cfgs)
+			test.CmpErr(t, tc.expCreateErr, err)
+			if tc.expCreateErr != nil {
+				return
+			}
+			test.CmpAny(t, "names", tc.expNames, vs.Names())
+
+			data, err := proto.Marshal(&Sys{User: tc.user})
+			if err != nil {
+				t.Fatal(err)
+			}
+			token := &Token{Flavor: Flavor_AUTH_SYS, Data: data}
+
+			err = vs.Validate(test.Context(t), token)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				test.AssertEqual(t, tc.expErrStatus, VerificationStatus(err), "unexpected status")
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/daos"
+)
+
+// SignedToken is a token to be verified by VerifyTokens, with the signature
+// in its verifier, the hash algorithm the signature was computed with, and the
+// ID of the key which made it.
+type SignedToken struct {
+	Token *Token
+	Sig   []byte
+	Hash  HashAlgorithm
+	KeyID string
+}
+
+// SignedTokenFromCredential returns the token of the credential to be verified
+// against its verifier.
+func SignedTokenFromCredential(cred *Credential) SignedToken {
+	return SignedToken{
+		Token: cred.GetToken(),
+		Sig:   cred.GetVerifier().GetData(),
+		Hash:  cred.GetVerifier().GetHash(),
+		KeyID: cred.GetKeyId(),
+	}
+}
+
+// KeyLookup returns the public key with the ID, e.g. that of an agent trusted
+// to sign credentials.
+type KeyLookup func(keyID string) (crypto.PublicKey, error)
+
+type keyLookupResult struct {
+	key crypto.PublicKey
+	err error
+}
+
+// VerifyTokens verifies a batch of tokens, e.g. those presented by the many
+// clients which connect when a job starts, returning an error for each token
+// which fails to verify, or nil for each which verifies. Each key is looked up
+// once, however many of the tokens it signed, and the signatures are verified
+// in parallel. If lookup is nil, the tokens are verified as unsigned hashes,
+// as by VerifyToken with a nil key.
+func VerifyTokens(lookup KeyLookup, tokens []SignedToken) []error {
+	errs := make([]error, len(tokens))
+	if len(tokens) == 0 {
+		return errs
+	}
+
+	keys := make(map[string]keyLookupResult)
+	if lookup != nil {
+		for _, st := range tokens {
+			if _, found := keys[st.KeyID]; found {
+				continue
+			}
+			key, err := lookup(st.KeyID)
+			if err == nil && key == nil {
+				err = errors.New("key not found")
+			}
+			if err != nil {
+				err = errors.Wrapf(daos.UnknownKey, "unable to look up key %q (%s)", st.KeyID, err)
+			}
+			keys[st.KeyID] = keyLookupResult{key: key, err: err}
+		}
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	workers := min(runtime.GOMAXPROCS(0), len(tokens))
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(tokens) {
+					return
+				}
+				st := tokens[i]
+				found := keys[st.KeyID]
+				if found.err != nil {
+					errs[i] = found.err
+					continue
+				}
+				errs[i] = verifyToken(found.key, st.Token, st.Sig, st.Hash)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
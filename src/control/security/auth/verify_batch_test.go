@@ -0,0 +1,100 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestAuth_VerifyTokens(t *testing.T) {
+	key1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newToken := func(key crypto.PrivateKey, user string) SignedToken {
+		cred, err := newSignedCredential(Flavor_AUTH_SYS, key, &Sys{User: user})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return SignedTokenFromCredential(cred)
+	}
+
+	valid1 := newToken(key1, "user1@")
+	valid2 := newToken(key2, "user2@")
+	tampered := newToken(key1, "user3@")
+	tampered.Token.Data = append([]byte{}, valid2.Token.Data...)
+	unknown := newToken(key1, "user4@")
+	unknown.KeyID = "unknown"
+	unsigned := newToken(nil, "user5@")
+
+	for name, tc := range map[string]struct {
+		insecure bool
+		tokens   []SignedToken
+		expErrs  []error
+	}{
+		"no tokens": {
+			expErrs: []error{},
+		},
+		"all valid": {
+			tokens:  []SignedToken{valid1, valid2, valid1},
+			expErrs: []error{nil, nil, nil},
+		},
+		"some invalid": {
+			tokens: []SignedToken{valid1, tampered, unknown, valid2},
+			expErrs: []error{
+				nil,
+				errors.New("verification"),
+				errors.New(`unable to look up key "unknown"`),
+				nil,
+			},
+		},
+		"unsigned": {
+			insecure: true,
+			tokens:   []SignedToken{unsigned, valid1},
+			expErrs:  []error{nil, errors.New("unsigned hash failed to verify")},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			lookups := make(map[string]int)
+			lookup := func(keyID string) (crypto.PublicKey, error) {
+				lookups[keyID]++
+				switch keyID {
+				case valid1.KeyID:
+					return key1.Public(), nil
+				case valid2.KeyID:
+					return key2.Public(), nil
+				}
+				return nil, errors.New("unknown key")
+			}
+			if tc.insecure {
+				lookup = nil
+			}
+
+			errs := VerifyTokens(lookup, tc.tokens)
+			test.AssertEqual(t, len(tc.expErrs), len(errs), "unexpected number of results")
+			for i, expErr := range tc.expErrs {
+				test.CmpErr(t, expErr, errs[i])
+			}
+			for keyID, count := range lookups {
+				test.AssertEqual(t, 1, count, fmt.Sprintf("key %q looked up more than once", keyID))
+			}
+		})
+	}
+}
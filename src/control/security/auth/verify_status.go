@@ -0,0 +1,30 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/daos"
+)
+
+// VerificationStatus returns the status which a server reports to the client
+// for a credential rejected with the error. Errors for which the client can
+// act, e.g. by requesting a new credential if it has expired, are typed with
+// a status of their own: CredentialExpired, CredentialRevoked, BadAuthFlavor,
+// BadSignature or UnknownKey. Other errors are reported as NoPermission.
+func VerificationStatus(err error) daos.Status {
+	if err == nil {
+		return daos.Success
+	}
+
+	var status daos.Status
+	if errors.As(err, &status) {
+		return status
+	}
+	return daos.NoPermission
+}
@@ -0,0 +1,91 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestAuth_VerificationStatus(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cred, err := newSignedCredential(Flavor_AUTH_SYS, key, &Sys{User: "user@"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := &Credential{
+		Token:    &Token{Flavor: Flavor_AUTH_SYS, Data: []byte("tampered")},
+		Verifier: cred.GetVerifier(),
+	}
+	keyID := strings.Repeat("ab", keyIDLen/2)
+	revocations := NewCredentialRevocations()
+	revocations.Update(&RevocationList{Version: 1, KeyIDs: []string{keyID}})
+	now := time.Now()
+
+	for name, tc := range map[string]struct {
+		err       error
+		expStatus daos.Status
+	}{
+		"no error": {
+			expStatus: daos.Success,
+		},
+		"untyped error": {
+			err:       errors.New("rejected"),
+			expStatus: daos.NoPermission,
+		},
+		"bad signature": {
+			err:       VerifyCredential(key.Public(), tampered),
+			expStatus: daos.BadSignature,
+		},
+		"bad unsigned hash": {
+			err:       VerifyCredential(nil, tampered),
+			expStatus: daos.BadSignature,
+		},
+		"expired": {
+			err: ValidateTokenExpiry(&Token{
+				NotBefore: uint64(now.Add(-2 * time.Hour).Unix()),
+				Expires:   uint64(now.Add(-time.Hour).Unix()),
+			}, now, security.DefaultCredentialClockSkew, nil),
+			expStatus: daos.CredentialExpired,
+		},
+		"not yet valid": {
+			err: ValidateTokenExpiry(&Token{
+				NotBefore: uint64(now.Add(time.Hour).Unix()),
+				Expires:   uint64(now.Add(2 * time.Hour).Unix()),
+			}, now, security.DefaultCredentialClockSkew, nil),
+			expStatus: daos.CredentialExpired,
+		},
+		"revoked": {
+			err:       revocations.Check(cred, keyID),
+			expStatus: daos.CredentialRevoked,
+		},
+		"unknown key": {
+			err: VerifyTokens(func(string) (crypto.PublicKey, error) {
+				return nil, errors.New("not found")
+			}, []SignedToken{SignedTokenFromCredential(cred)})[0],
+			expStatus: daos.UnknownKey,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.AssertEqual(t, tc.expStatus, VerificationStatus(tc.err), "unexpected status")
+		})
+	}
+}
@@ -0,0 +1,126 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// CABundle holds the CA certificates trusted to verify the certificates of
+// peers, loaded from the ca_cert file of a transport config. It can be
+// reloaded while connections are being verified, so that a CA can be added to
+// or removed from the bundle, e.g. while migrating agents to a new CA, without
+// restarting the server.
+type CABundle struct {
+	path    string
+	leaf    *x509.Certificate
+	mutex   sync.Mutex
+	pemData []byte
+	pool    atomic.Pointer[x509.CertPool]
+}
+
+// NewCABundle loads the CA bundle of the transport config, and has the TLS
+// configs created from it verify peers with the current contents of the
+// bundle rather than those loaded at startup.
+func (tc *TransportConfig) NewCABundle() (*CABundle, error) {
+	if tc == nil {
+		return nil, errors.New("nil TransportConfig")
+	}
+	if tc.AllowInsecure {
+		return nil, errors.New("no CA bundle is used with allow_insecure")
+	}
+	if tc.tlsKeypair == nil || tc.caPool == nil {
+		if err := tc.PreLoadCertData(); err != nil {
+			return nil, err
+		}
+	}
+
+	b := &CABundle{
+		path: tc.CARootPath,
+		leaf: tc.tlsKeypair.Leaf,
+	}
+	if _, _, err := b.Reload(); err != nil {
+		return nil, err
+	}
+	tc.caBundle = b
+	return b, nil
+}
+
+// Path returns the path of the CA bundle.
+func (b *CABundle) Path() string {
+	return b.path
+}
+
+// Pool returns the CA certificates currently trusted.
+func (b *CABundle) Pool() *x509.CertPool {
+	return b.pool.Load()
+}
+
+// Reload loads the CA bundle again, replacing the trusted CA certificates if
+// it has changed. The bundle must still contain the CA of this component's own
+// certificate, as its peers verify it with the same bundle, so a bundle which
+// doesn't is refused and the trusted CA certificates are left unchanged. It
+// returns true, and the number of certificates in the bundle, if it changed.
+func (b *CABundle) Reload() (bool, int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	data, err := loadCertFile(b.path, MaxCertPerm, "caRoot")
+	if err != nil {
+		return false, 0, err
+	}
+	if b.pool.Load() != nil && bytes.Equal(data, b.pemData) {
+		return false, 0, nil
+	}
+
+	pool := x509.NewCertPool()
+	count := 0
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return false, 0, FaultInvalidCertFile(b.path, err)
+		}
+		pool.AddCert(cert)
+		count++
+	}
+	if count == 0 {
+		return false, 0, errors.Errorf("no CA certificates in %s", b.path)
+	}
+
+	if _, err := b.leaf.Verify(x509.VerifyOptions{
+		Roots:     pool,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return false, 0, errors.Wrapf(err, "CA bundle %s doesn't verify this component's certificate", b.path)
+	}
+
+	b.pemData = data
+	b.pool.Store(pool)
+	return true, count, nil
+}
+
+// trustedCAs returns the CA certificates with which to verify peers.
+func (tc *TransportConfig) trustedCAs() *x509.CertPool {
+	if tc.caBundle != nil {
+		return tc.caBundle.Pool()
+	}
+	return tc.caPool
+}
@@ -0,0 +1,157 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+// testCertificate issues a certificate for a new key, signed by the parent, or
+// self-signed as a CA if the parent is nil, returning it PEM-encoded.
+func testCertificate(t *testing.T, cn string, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, *ecdsa.PrivateKey, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func writeTestCABundle(t *testing.T, path string, bundles ...[]byte) {
+	t.Helper()
+
+	var data []byte
+	for _, b := range bundles {
+		data = append(data, b...)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, MaxCertPerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSecurity_CABundle_Reload(t *testing.T) {
+	dir := t.TempDir()
+	oldCA, oldCAKey, oldCAPEM := testCertificate(t, "old CA", nil, nil)
+	newCA, newCAKey, newCAPEM := testCertificate(t, "new CA", nil, nil)
+	_, key, certPEM := testCertificate(t, "server", oldCA, oldCAKey)
+	agentCert, _, _ := testCertificate(t, "agent", newCA, newCAKey)
+
+	tc := DefaultServerTransportConfig()
+	tc.ClientCertDir = ""
+	tc.CARootPath = filepath.Join(dir, "daosCA.crt")
+	tc.CertificatePath = filepath.Join(dir, "server.crt")
+	tc.PrivateKeyPath = filepath.Join(dir, "server.key")
+	writeTestCABundle(t, tc.CARootPath, oldCAPEM)
+	writeTestCABundle(t, tc.CertificatePath, certPEM)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(tc.PrivateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), MaxUserOnlyKeyPerm); err != nil {
+		t.Fatal(err)
+	}
+
+	bundle, err := tc.NewCABundle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	trusted := func() bool {
+		_, err := agentCert.Verify(x509.VerifyOptions{
+			Roots:     tc.trustedCAs(),
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		return err == nil
+	}
+	test.AssertFalse(t, trusted(), "agent of new CA trusted before it was added")
+
+	changed, _, err := bundle.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertFalse(t, changed, "unchanged bundle reloaded")
+
+	// Add the new CA, as when migrating agents to it.
+	writeTestCABundle(t, tc.CARootPath, oldCAPEM, newCAPEM)
+	changed, count, err := bundle.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, changed, "changed bundle not reloaded")
+	test.AssertEqual(t, 2, count, "unexpected number of CA certificates")
+	test.AssertTrue(t, trusted(), "agent of new CA not trusted after it was added")
+
+	// Handshakes are verified with the reloaded bundle.
+	tlsConfig, err := serverTLSConfig(tc).GetConfigForClient(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, tlsConfig.ClientCAs == bundle.Pool(), "handshake not verified with reloaded bundle")
+
+	for name, tc := range map[string]struct {
+		data   []byte
+		expErr error
+	}{
+		"without own CA": {
+			data:   newCAPEM,
+			expErr: errors.New("doesn't verify this component's certificate"),
+		},
+		"no certificates": {
+			data:   []byte("\n"),
+			expErr: errors.New("no CA certificates"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			writeTestCABundle(t, bundle.Path(), tc.data)
+			_, _, err := bundle.Reload()
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertTrue(t, trusted(), "bundle replaced after failed reload")
+		})
+	}
+}
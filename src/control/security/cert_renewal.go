@@ -0,0 +1,115 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto/x509"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// CertRenewalDAOSCA renews agent certificates with the CA of the
+	// control plane (auth_config.agent_ca in the server configuration).
+	CertRenewalDAOSCA = "daos_ca"
+	// CertRenewalACME renews agent certificates with an ACME server.
+	CertRenewalACME = "acme"
+
+	// DefaultCertRenewalCheckInterval is the interval at which the expiry
+	// of the certificate is checked, unless configured otherwise.
+	DefaultCertRenewalCheckInterval = time.Hour
+	// DefaultACMEHTTP01Listen is the address on which http-01 challenges
+	// are answered, unless configured otherwise.
+	DefaultACMEHTTP01Listen = ":80"
+)
+
+// ACMEConfig configures renewal of the agent certificate with an ACME server.
+// The server must issue certificates with the common name "agent", e.g. a
+// private ACME CA with a template for agent certificates.
+type ACMEConfig struct {
+	DirectoryURL string   `yaml:"directory_url"`
+	Email        string   `yaml:"email,omitempty"`
+	AccountKey   string   `yaml:"account_key"`
+	Domains      []string `yaml:"domains"`
+	HTTP01Listen string   `yaml:"http01_listen,omitempty"`
+	CACert       string   `yaml:"ca_cert,omitempty"`
+}
+
+// Validate checks the ACME configuration.
+func (cfg *ACMEConfig) Validate() error {
+	if cfg == nil {
+		return errors.New("acme is required for the acme method")
+	}
+	u, err := url.Parse(cfg.DirectoryURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return errors.Errorf("invalid ACME directory_url %q", cfg.DirectoryURL)
+	}
+	if !filepath.IsAbs(cfg.AccountKey) {
+		return errors.Errorf("ACME account_key %q must be an absolute path", cfg.AccountKey)
+	}
+	if len(cfg.Domains) == 0 {
+		return errors.New("ACME domains are required")
+	}
+	return nil
+}
+
+// CertRenewalConfig configures the agent to renew its certificate before it
+// expires, with a new key which replaces the old one in place. The new key and
+// certificate are loaded as if rotated by an administrator, so only a
+// certificate with a key file (key) can be renewed.
+type CertRenewalConfig struct {
+	Method        string        `yaml:"method"`
+	RenewBefore   time.Duration `yaml:"renew_before,omitempty"`
+	CheckInterval time.Duration `yaml:"check_interval,omitempty"`
+	ACME          *ACMEConfig   `yaml:"acme,omitempty"`
+}
+
+// Validate checks the certificate renewal configuration.
+func (cfg *CertRenewalConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+	switch cfg.Method {
+	case CertRenewalDAOSCA:
+		if cfg.ACME != nil {
+			return errors.Errorf("acme can't be set for the %s method", CertRenewalDAOSCA)
+		}
+	case CertRenewalACME:
+		if err := cfg.ACME.Validate(); err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("invalid method %q (must be %s or %s)", cfg.Method, CertRenewalDAOSCA,
+			CertRenewalACME)
+	}
+	if cfg.RenewBefore < 0 || cfg.CheckInterval < 0 {
+		return errors.New("renew_before and check_interval must not be negative")
+	}
+	return nil
+}
+
+// GetCheckInterval returns the interval at which the expiry of the certificate
+// is checked.
+func (cfg *CertRenewalConfig) GetCheckInterval() time.Duration {
+	if cfg.CheckInterval > 0 {
+		return cfg.CheckInterval
+	}
+	return DefaultCertRenewalCheckInterval
+}
+
+// RenewalTime returns the time at which the certificate is due for renewal,
+// which is renew_before ahead of its expiry, or once two thirds of its lifetime
+// have passed by default.
+func (cfg *CertRenewalConfig) RenewalTime(cert *x509.Certificate) time.Time {
+	if cfg.RenewBefore > 0 {
+		return cert.NotAfter.Add(-cfg.RenewBefore)
+	}
+	return cert.NotAfter.Add(-cert.NotAfter.Sub(cert.NotBefore) / 3)
+}
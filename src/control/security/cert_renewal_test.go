@@ -0,0 +1,98 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestSecurity_CertRenewalConfig_Validate(t *testing.T) {
+	acme := func() *ACMEConfig {
+		return &ACMEConfig{
+			DirectoryURL: "https://acme.example.com/directory",
+			AccountKey:   "/etc/daos/certs/acme-account.key",
+			Domains:      []string{"client-1.example.com"},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		cfg    *CertRenewalConfig
+		expErr error
+	}{
+		"nil": {},
+		"daos ca": {
+			cfg: &CertRenewalConfig{Method: CertRenewalDAOSCA},
+		},
+		"acme": {
+			cfg: &CertRenewalConfig{Method: CertRenewalACME, ACME: acme()},
+		},
+		"no method": {
+			cfg:    &CertRenewalConfig{},
+			expErr: errors.New(`invalid method ""`),
+		},
+		"acme with daos ca": {
+			cfg:    &CertRenewalConfig{Method: CertRenewalDAOSCA, ACME: acme()},
+			expErr: errors.New("acme can't be set for the daos_ca method"),
+		},
+		"acme without config": {
+			cfg:    &CertRenewalConfig{Method: CertRenewalACME},
+			expErr: errors.New("acme is required"),
+		},
+		"acme bad directory": {
+			cfg: &CertRenewalConfig{Method: CertRenewalACME, ACME: func() *ACMEConfig {
+				c := acme()
+				c.DirectoryURL = "acme.example.com"
+				return c
+			}()},
+			expErr: errors.New("invalid ACME directory_url"),
+		},
+		"acme relative account key": {
+			cfg: &CertRenewalConfig{Method: CertRenewalACME, ACME: func() *ACMEConfig {
+				c := acme()
+				c.AccountKey = "acme.key"
+				return c
+			}()},
+			expErr: errors.New("must be an absolute path"),
+		},
+		"acme no domains": {
+			cfg: &CertRenewalConfig{Method: CertRenewalACME, ACME: func() *ACMEConfig {
+				c := acme()
+				c.Domains = nil
+				return c
+			}()},
+			expErr: errors.New("ACME domains are required"),
+		},
+		"negative renew_before": {
+			cfg:    &CertRenewalConfig{Method: CertRenewalDAOSCA, RenewBefore: -time.Hour},
+			expErr: errors.New("must not be negative"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.cfg.Validate())
+		})
+	}
+}
+
+func TestSecurity_CertRenewalConfig_RenewalTime(t *testing.T) {
+	notBefore := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cert := &x509.Certificate{NotBefore: notBefore, NotAfter: notBefore.Add(30 * 24 * time.Hour)}
+
+	cfg := &CertRenewalConfig{Method: CertRenewalDAOSCA}
+	test.AssertEqual(t, notBefore.Add(20*24*time.Hour), cfg.RenewalTime(cert), "unexpected default renewal time")
+	test.AssertEqual(t, DefaultCertRenewalCheckInterval, cfg.GetCheckInterval(), "unexpected default check interval")
+
+	cfg.RenewBefore = 7 * 24 * time.Hour
+	cfg.CheckInterval = time.Minute
+	test.AssertEqual(t, notBefore.Add(23*24*time.Hour), cfg.RenewalTime(cert), "unexpected renewal time")
+	test.AssertEqual(t, time.Minute, cfg.GetCheckInterval(), "unexpected check interval")
+}
@@ -8,14 +8,22 @@ package security
 
 import (
 	"crypto"
+	"crypto/ed25519"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
 	"io/fs"
+	"net"
+	"net/url"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
 )
 
@@ -92,24 +100,1744 @@ func (cm ClientUserMap) Lookup(uid uint32) *MappedClientUser {
 }
 
 // CredentialConfig contains configuration details for managing user
-// credentials.
+// credentials. If CacheMaxEntries or CacheMaxMem is set, the least recently used
+// cached credentials are evicted once the cache holds that many, or that many
+// bytes, respectively. If CacheMaxPerUser is set, the oldest cached credentials
+// of a client user are evicted once the user holds that many. If CacheSpoolDir
+// is set, cached credentials are persisted to it, encrypted, so that they
+// survive a restart of the agent. If CacheRefreshAhead is set, cached
+// credentials which expire within it are refreshed in the background. If
+// CacheJitter is set, each cached credential expires up to that much earlier,
+// at random, so that credentials cached together do not all expire together.
+// If CacheReapInterval is set, expired cached credentials are removed at that
+// interval, rather than only once they are looked up again. If CacheEventLog is
+// set, the lifecycle events of cached credentials are logged. The cached credentials of CachePinnedUsers, given by name or UID, are never
+// evicted, and are refreshed before they expire even if they are not used.
+// The credentials listed in CacheWarmup are requested when the agent starts,
+// and again whenever the cache is flushed. If ValidFlavorsTTL is set, the
+// authentication flavors allowed by the server are fetched again at that
+// interval, rather than cached for as long as the agent runs.
 type CredentialConfig struct {
-	CacheExpiration  time.Duration       `yaml:"cache_expiration,omitempty"`
-	ClientUserMap    ClientUserMap       `yaml:"client_user_map,omitempty"`
-	ValidAuthMethods []string            `yaml:"valid_auth_methods,omitempty"`
-	AMConfig         AccessManagerConfig `yaml:"access_manager_config,omitempty"`
+	CacheExpiration   time.Duration         `yaml:"cache_expiration,omitempty"`
+	CacheJitter       time.Duration         `yaml:"cache_jitter,omitempty"`
+	CacheRefreshAhead time.Duration         `yaml:"cache_refresh_ahead,omitempty"`
+	CacheReapInterval time.Duration         `yaml:"cache_reap_interval,omitempty"`
+	CacheEventLog     bool                  `yaml:"cache_event_log,omitempty"`
+	CacheSpoolDir     string                `yaml:"cache_spool_dir,omitempty"`
+	CacheMaxEntries   int                   `yaml:"cache_max_entries,omitempty"`
+	CacheMaxPerUser   int                   `yaml:"cache_max_per_user,omitempty"`
+	CachePinnedUsers  []string              `yaml:"cache_pinned_users,omitempty"`
+	CacheWarmup       []CacheWarmupEntry    `yaml:"cache_warmup,omitempty"`
+	CacheMaxMem       ByteSize              `yaml:"cache_max_mem,omitempty"`
+	CacheEncryption   CacheEncryptionConfig `yaml:"cache_encryption,omitempty"`
+	NegativeCache     NegativeCacheConfig   `yaml:"negative_cache,omitempty"`
+	GroupWatch        GroupWatchConfig      `yaml:"group_watch,omitempty"`
+	ClientUserMap     ClientUserMap         `yaml:"client_user_map,omitempty"`
+	MapUserNamespaces bool                  `yaml:"map_user_namespaces,omitempty"`
+	ContainerConfig   ContainerConfig       `yaml:"container_config,omitempty"`
+	ProcessBinding    bool                  `yaml:"process_binding,omitempty"`
+	ValidAuthMethods  []string              `yaml:"valid_auth_methods,omitempty"`
+	ValidFlavorsTTL   time.Duration         `yaml:"valid_flavors_ttl,omitempty"`
+	AMConfig          AccessManagerConfig   `yaml:"access_manager_config,omitempty"`
+	AzureConfig       AzureConfig           `yaml:"azure_config,omitempty"`
+	GCPConfig         GCPConfig             `yaml:"gcp_config,omitempty"`
+	VaultConfig       VaultConfig           `yaml:"vault_config,omitempty"`
+	OAuth2Config      OAuth2Config          `yaml:"oauth2_config,omitempty"`
+	SSHConfig         SSHConfig             `yaml:"ssh_config,omitempty"`
+	FIDO2Config       FIDO2Config           `yaml:"fido2_config,omitempty"`
+	SciTokensConfig   SciTokensConfig       `yaml:"scitokens_config,omitempty"`
+	MacaroonConfig    MacaroonConfig        `yaml:"macaroon_config,omitempty"`
+	BiscuitConfig     BiscuitConfig         `yaml:"biscuit_config,omitempty"`
+	KeystoneConfig    KeystoneConfig        `yaml:"keystone_config,omitempty"`
+	PKCS11Config      PKCS11Config          `yaml:"pkcs11_config,omitempty"`
+	AnonConfig        AnonConfig            `yaml:"anon_config,omitempty"`
+	ExecConfig        ExecConfig            `yaml:"exec_config,omitempty"`
+	ProviderConfig    ProviderConfig        `yaml:"provider_config,omitempty"`
+	MachineConfig     MachineConfig         `yaml:"machine_config,omitempty"`
+	SlurmConfig       SlurmConfig           `yaml:"slurm_config,omitempty"`
+	WLMConfig         WLMConfig             `yaml:"wlm_config,omitempty"`
+	ADConfig          ADConfig              `yaml:"ad_config,omitempty"`
+	TOTPConfig        TOTPConfig            `yaml:"totp_config,omitempty"`
+	DelegationConfig  DelegationConfig      `yaml:"delegation_config,omitempty"`
+	ProxyConfig       ProxyConfig           `yaml:"proxy_config,omitempty"`
+	WebhookConfig     WebhookConfig         `yaml:"webhook_config,omitempty"`
+	FlavorPlugins     []string              `yaml:"flavor_plugins,omitempty"`
+	IssuanceSLOs      []*CredentialSLO      `yaml:"issuance_slos,omitempty"`
+	PrincipalCaseFold CaseFoldPolicy        `yaml:"principal_case_fold,omitempty"`
+	SelfTestPolicy    SelfTestPolicy        `yaml:"self_test_policy,omitempty"`
+	// BackendProbeInterval is how often the backends of the enabled
+	// flavors, such as the access manager, are checked to be reachable.
+	BackendProbeInterval time.Duration `yaml:"backend_probe_interval,omitempty"`
+	// SigningKeyReloadInterval is how often the agent's certificate and
+	// signing key are checked for changes, so that they are reloaded
+	// without restarting the agent. They are also reloaded on SIGHUP.
+	SigningKeyReloadInterval time.Duration `yaml:"signing_key_reload_interval,omitempty"`
+	// VerifierHash is the hash algorithm (sha256, sha384, sha512, sha3-256,
+	// sha3-384 or sha3-512) used to compute credential verifiers. It is
+	// recorded in each verifier, so servers verify credentials computed with
+	// any of them. The default is sha512.
+	VerifierHash string `yaml:"verifier_hash,omitempty"`
+	// SigningWorkers is the number of credentials which may be signed at
+	// once, so that signing many credentials at once uses several cores
+	// without starving the agent's other work. The default is half the
+	// number of CPUs.
+	SigningWorkers int `yaml:"signing_workers,omitempty"`
+	// KeyUsage configures the logging of the signatures made with each
+	// signing key.
+	KeyUsage KeyUsageConfig `yaml:"key_usage,omitempty"`
+	// TokenSigner is the backend with which credentials are signed:
+	// software (the default) signs with keys of any kind, pkcs11 and kms
+	// only with keys held on a PKCS#11 token or by a key management service
+	// respectively, and null, for testing, signs nothing.
+	TokenSigner TokenSignerBackend `yaml:"token_signer,omitempty"`
+	// PresignedCredentials is the path to a bundle of credentials signed
+	// ahead of time by daos_agent presign-credentials, which are served in
+	// place of those the agent would sign for their users.
+	PresignedCredentials string `yaml:"presigned_credentials,omitempty"`
+	// CosigningConfig configures the co-signing of privileged credentials
+	// by a security officer's key, as well as by the agent's key.
+	CosigningConfig CosigningConfig `yaml:"cosigning_config,omitempty"`
+	// CredentialNonces has the agent record a nonce issued by the servers
+	// in each credential it hands out, for servers which only accept
+	// credentials signed with a nonce they issued recently, and only once.
+	CredentialNonces bool `yaml:"credential_nonces,omitempty"`
+	// Lifetime is how long the credentials signed by the agent are valid.
+	// It is recorded in each credential and enforced by the servers, so
+	// that a captured credential can't be replayed indefinitely.
+	// FlavorLifetimes overrides it for the flavors given by name (e.g.
+	// AUTH_SYS). Credentials of flavors with no lifetime don't expire.
+	Lifetime        time.Duration            `yaml:"lifetime,omitempty"`
+	FlavorLifetimes map[string]time.Duration `yaml:"flavor_lifetimes,omitempty"`
+}
+
+const (
+	// CredentialFailureRejected is a credential request which was rejected,
+	// e.g. because its token was invalid or access was denied.
+	CredentialFailureRejected = "rejected"
+	// CredentialFailureUnavailable is a credential request which failed
+	// because a backend, such as the access manager, could not be reached.
+	CredentialFailureUnavailable = "unavailable"
+	// CredentialFailureTimeout is a credential request which timed out.
+	CredentialFailureTimeout = "timeout"
+
+	// DefaultNegativeCacheMaxEntries is the number of failures held by the
+	// negative cache if no limit is configured.
+	DefaultNegativeCacheMaxEntries = 4096
+
+	// DefaultGroupWatchPollInterval is the interval at which watched group
+	// databases are checked for changes if no interval is configured.
+	DefaultGroupWatchPollInterval = 10 * time.Second
+)
+
+// NegativeCacheConfig defines the caching of failed credential requests, so
+// that a client repeating a bad request does not reach the backends each time.
+// Failures are cached for TTL, unless StatusTTLs defines a different TTL for
+// their status (one of "rejected", "unavailable" or "timeout"). A TTL of zero
+// does not cache the failure.
+type NegativeCacheConfig struct {
+	TTL        time.Duration            `yaml:"ttl,omitempty"`
+	StatusTTLs map[string]time.Duration `yaml:"status_ttls,omitempty"`
+	MaxEntries int                      `yaml:"max_entries,omitempty"`
+}
+
+// Enabled returns true if any failures are to be cached.
+func (cfg *NegativeCacheConfig) Enabled() bool {
+	if cfg.TTL > 0 {
+		return true
+	}
+	for _, ttl := range cfg.StatusTTLs {
+		if ttl > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusTTL returns how long a failure with the status is cached.
+func (cfg *NegativeCacheConfig) StatusTTL(status string) time.Duration {
+	if ttl, found := cfg.StatusTTLs[status]; found {
+		return ttl
+	}
+	return cfg.TTL
+}
+
+// Validate checks the TTLs and their statuses.
+func (cfg *NegativeCacheConfig) Validate() error {
+	if cfg.TTL < 0 {
+		return errors.New("ttl must not be negative")
+	}
+	if cfg.MaxEntries < 0 {
+		return errors.New("max_entries must not be negative")
+	}
+	for status, ttl := range cfg.StatusTTLs {
+		switch status {
+		case CredentialFailureRejected, CredentialFailureUnavailable, CredentialFailureTimeout:
+		default:
+			return errors.Errorf("status_ttls: unknown status %q", status)
+		}
+		if ttl < 0 {
+			return errors.Errorf("status_ttls: ttl for %q must not be negative", status)
+		}
+	}
+	return nil
+}
+
+// CacheWarmupEntry identifies a credential which the agent requests on behalf
+// of a user, given by name or UID, before any of the user's processes asks for
+// it. The flavor defaults to AUTH_SYS.
+type CacheWarmupEntry struct {
+	User   string `yaml:"user"`
+	Flavor string `yaml:"flavor,omitempty"`
+}
+
+// GroupWatchConfig defines the group databases which are watched for changes,
+// so that cached AUTH_SYS credentials are invalidated once a user's group
+// membership changes rather than when they expire. Files in the format of
+// /etc/group invalidate only the credentials of the users whose membership
+// changed; any other file, such as an SSSD cache, invalidates all cached
+// AUTH_SYS credentials when it changes.
+type GroupWatchConfig struct {
+	Paths        []string      `yaml:"paths,omitempty"`
+	PollInterval time.Duration `yaml:"poll_interval,omitempty"`
+}
+
+// Enabled returns true if any group databases are watched.
+func (cfg *GroupWatchConfig) Enabled() bool {
+	return len(cfg.Paths) > 0
+}
+
+// Validate checks the watched paths and the poll interval.
+func (cfg *GroupWatchConfig) Validate() error {
+	if cfg.PollInterval < 0 {
+		return errors.New("poll_interval must not be negative")
+	}
+	for _, path := range cfg.Paths {
+		if !filepath.IsAbs(path) {
+			return errors.Errorf("paths: %q must be an absolute path", path)
+		}
+	}
+	return nil
+}
+
+const (
+	// DefaultKeyUsageChangeFactor is the factor by which the signatures made
+	// with a key in an interval must differ from its recent average to be
+	// reported, if no factor is configured.
+	DefaultKeyUsageChangeFactor = 10.0
+	// DefaultKeyUsageMinSignatures is the number of signatures below which
+	// changes in the usage of a key are not reported, if no minimum is
+	// configured.
+	DefaultKeyUsageMinSignatures = 100
+)
+
+// KeyUsageConfig defines the logging of the signatures made with each signing
+// key, by flavor, for capacity planning and to detect the abuse of a
+// compromised agent. If LogInterval is set, the signatures made with each key
+// are logged at that interval, and an interval in which a key made
+// ChangeFactor times more or fewer signatures than its recent average is
+// reported, unless both are fewer than MinSignatures.
+type KeyUsageConfig struct {
+	LogInterval   time.Duration `yaml:"log_interval,omitempty"`
+	ChangeFactor  float64       `yaml:"change_factor,omitempty"`
+	MinSignatures uint64        `yaml:"min_signatures,omitempty"`
+}
+
+// Validate checks the interval and change factor.
+func (cfg *KeyUsageConfig) Validate() error {
+	if cfg.LogInterval < 0 {
+		return errors.New("log_interval must not be negative")
+	}
+	if cfg.ChangeFactor != 0 && cfg.ChangeFactor <= 1 {
+		return errors.New("change_factor must be greater than 1")
+	}
+	return nil
+}
+
+// GetChangeFactor returns the factor by which the usage of a key must change
+// to be reported.
+func (cfg *KeyUsageConfig) GetChangeFactor() float64 {
+	if cfg.ChangeFactor > 0 {
+		return cfg.ChangeFactor
+	}
+	return DefaultKeyUsageChangeFactor
+}
+
+// GetMinSignatures returns the number of signatures below which changes in the
+// usage of a key are not reported.
+func (cfg *KeyUsageConfig) GetMinSignatures() uint64 {
+	if cfg.MinSignatures > 0 {
+		return cfg.MinSignatures
+	}
+	return DefaultKeyUsageMinSignatures
+}
+
+// ByteSize is a size in bytes, which may be given as a plain number of bytes
+// or with a unit, e.g. "64MiB".
+type ByteSize uint64
+
+// UnmarshalYAML parses the size with its optional unit.
+func (bs *ByteSize) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+
+	size, err := humanize.ParseBytes(str)
+	if err != nil {
+		return errors.Errorf("invalid size %q", str)
+	}
+	*bs = ByteSize(size)
+	return nil
+}
+
+// CacheKeySource identifies where the key used to encrypt cached credentials
+// comes from.
+type CacheKeySource string
+
+const (
+	// CacheKeySigningKey derives the key from the agent's signing key.
+	CacheKeySigningKey CacheKeySource = "signing_key"
+	// CacheKeyTPM unseals the key from a TPM.
+	CacheKeyTPM CacheKeySource = "tpm"
+)
+
+// CacheEncryptionConfig defines the encryption of cached credentials, which
+// are held encrypted in memory and only decrypted when used. Cached
+// credentials are always encrypted if the cache is persisted.
+type CacheEncryptionConfig struct {
+	Enabled   bool           `yaml:"enabled,omitempty"`
+	KeySource CacheKeySource `yaml:"key_source,omitempty"`
+	// TPMHandle is the persistent handle of the TPM object sealing the key.
+	TPMHandle string `yaml:"tpm_handle,omitempty"`
+}
+
+// Validate checks that the key source is known and fully configured.
+func (cfg *CacheEncryptionConfig) Validate() error {
+	switch cfg.KeySource {
+	case "", CacheKeySigningKey:
+		if cfg.TPMHandle != "" {
+			return errors.Errorf("tpm_handle requires key_source %q", CacheKeyTPM)
+		}
+	case CacheKeyTPM:
+		if cfg.TPMHandle == "" {
+			return errors.Errorf("tpm_handle is required for key_source %q", CacheKeyTPM)
+		}
+	default:
+		return errors.Errorf("unknown key_source %q", cfg.KeySource)
+	}
+	return nil
+}
+
+// EncryptCache returns true if cached credentials are to be encrypted.
+func (cc *CredentialConfig) EncryptCache() bool {
+	return cc.CacheSpoolDir != "" || cc.CacheEncryption.Enabled
+}
+
+// DefaultBackendProbeInterval is how often flavor backends are probed if no
+// interval is configured.
+const DefaultBackendProbeInterval = time.Minute
+
+// SelfTestPolicy defines how the agent reacts to a failure of the security
+// self-test run at startup.
+type SelfTestPolicy string
+
+const (
+	// SelfTestEnforce refuses to start if any part of the self-test fails.
+	SelfTestEnforce SelfTestPolicy = "enforce"
+	// SelfTestDegrade starts regardless, but refuses to issue credentials for
+	// flavors which failed the self-test.
+	SelfTestDegrade SelfTestPolicy = "degrade"
+	// SelfTestSkip does not run the self-test.
+	SelfTestSkip SelfTestPolicy = "skip"
+)
+
+// Validate checks that the policy is known.
+func (p SelfTestPolicy) Validate() error {
+	switch p {
+	case "", SelfTestEnforce, SelfTestDegrade, SelfTestSkip:
+		return nil
+	default:
+		return errors.Errorf("unknown self-test policy %q", p)
+	}
+}
+
+// validateLifetime checks a credential lifetime. A credential may be served
+// from the cache until cache_expiration after it was signed, so it must remain
+// valid for longer than that.
+func (cc *CredentialConfig) validateLifetime(name string, lifetime time.Duration) error {
+	if lifetime < 0 {
+		return errors.Errorf("%s must not be negative", name)
+	}
+	if lifetime > 0 && cc.CacheExpiration > 0 && lifetime <= cc.CacheExpiration {
+		return errors.Errorf("%s must be greater than cache_expiration", name)
+	}
+	return nil
+}
+
+// Validate performs basic validation of the credential configuration.
+func (cc *CredentialConfig) Validate() error {
+	if cc == nil {
+		return errors.New("credential config is nil")
+	}
+
+	if err := cc.PrincipalCaseFold.Validate(); err != nil {
+		return errors.Wrap(err, "principal_case_fold")
+	}
+	if err := cc.SelfTestPolicy.Validate(); err != nil {
+		return errors.Wrap(err, "self_test_policy")
+	}
+	if cc.BackendProbeInterval < 0 {
+		return errors.New("backend_probe_interval must not be negative")
+	}
+	if cc.SigningKeyReloadInterval < 0 {
+		return errors.New("signing_key_reload_interval must not be negative")
+	}
+	if _, err := ParseVerifierHash(cc.VerifierHash); err != nil {
+		return errors.Wrap(err, "verifier_hash")
+	}
+	if cc.SigningWorkers < 0 {
+		return errors.New("signing_workers must not be negative")
+	}
+	if err := cc.KeyUsage.Validate(); err != nil {
+		return errors.Wrap(err, "key_usage")
+	}
+	if err := cc.TokenSigner.Validate(); err != nil {
+		return errors.Wrap(err, "token_signer")
+	}
+	if err := cc.validateLifetime("lifetime", cc.Lifetime); err != nil {
+		return err
+	}
+	for flavor, lifetime := range cc.FlavorLifetimes {
+		if err := cc.validateLifetime(fmt.Sprintf("flavor_lifetimes: %s", flavor), lifetime); err != nil {
+			return err
+		}
+	}
+	if cc.PresignedCredentials != "" && !filepath.IsAbs(cc.PresignedCredentials) {
+		return errors.Errorf("presigned_credentials %q must be an absolute path", cc.PresignedCredentials)
+	}
+	if cc.CacheSpoolDir != "" {
+		if cc.CacheExpiration <= 0 {
+			return errors.New("cache_expiration must be set to use cache_spool_dir")
+		}
+		if !filepath.IsAbs(cc.CacheSpoolDir) {
+			return errors.Errorf("cache_spool_dir %q must be an absolute path", cc.CacheSpoolDir)
+		}
+	}
+	if cc.CacheJitter < 0 {
+		return errors.New("cache_jitter must not be negative")
+	}
+	if cc.CacheJitter > 0 && cc.CacheJitter >= cc.CacheExpiration {
+		return errors.New("cache_jitter must be less than cache_expiration")
+	}
+	if cc.CacheRefreshAhead < 0 {
+		return errors.New("cache_refresh_ahead must not be negative")
+	}
+	if cc.CacheRefreshAhead > 0 && cc.CacheRefreshAhead >= cc.CacheExpiration {
+		return errors.New("cache_refresh_ahead must be less than cache_expiration")
+	}
+	if cc.CacheReapInterval < 0 {
+		return errors.New("cache_reap_interval must not be negative")
+	}
+	if cc.CacheReapInterval > 0 && cc.CacheExpiration <= 0 {
+		return errors.New("cache_expiration must be set to use cache_reap_interval")
+	}
+	if cc.CacheEventLog && cc.CacheExpiration <= 0 {
+		return errors.New("cache_expiration must be set to use cache_event_log")
+	}
+	if cc.CacheMaxEntries < 0 {
+		return errors.New("cache_max_entries must not be negative")
+	}
+	if cc.CacheMaxEntries > 0 && cc.CacheExpiration <= 0 {
+		return errors.New("cache_expiration must be set to use cache_max_entries")
+	}
+	if cc.CacheMaxPerUser < 0 {
+		return errors.New("cache_max_per_user must not be negative")
+	}
+	if cc.CacheMaxPerUser > 0 && cc.CacheExpiration <= 0 {
+		return errors.New("cache_expiration must be set to use cache_max_per_user")
+	}
+	if cc.CacheMaxMem > 0 && cc.CacheExpiration <= 0 {
+		return errors.New("cache_expiration must be set to use cache_max_mem")
+	}
+	if len(cc.CachePinnedUsers) > 0 && cc.CacheExpiration <= 0 {
+		return errors.New("cache_expiration must be set to use cache_pinned_users")
+	}
+	for _, user := range cc.CachePinnedUsers {
+		if strings.TrimSpace(user) == "" {
+			return errors.New("cache_pinned_users must not contain empty names")
+		}
+	}
+	if cc.ValidFlavorsTTL < 0 {
+		return errors.New("valid_flavors_ttl must not be negative")
+	}
+	if len(cc.CacheWarmup) > 0 && cc.CacheExpiration <= 0 {
+		return errors.New("cache_expiration must be set to use cache_warmup")
+	}
+	for i, entry := range cc.CacheWarmup {
+		if strings.TrimSpace(entry.User) == "" {
+			return errors.Errorf("cache_warmup: entry %d has no user", i)
+		}
+	}
+	if cc.CacheEncryption.Enabled && cc.CacheExpiration <= 0 {
+		return errors.New("cache_expiration must be set to use cache_encryption")
+	}
+	if err := cc.CacheEncryption.Validate(); err != nil {
+		return errors.Wrap(err, "cache_encryption")
+	}
+	if err := cc.NegativeCache.Validate(); err != nil {
+		return errors.Wrap(err, "negative_cache")
+	}
+	if cc.GroupWatch.Enabled() && cc.CacheExpiration <= 0 {
+		return errors.New("cache_expiration must be set to use group_watch")
+	}
+	if err := cc.GroupWatch.Validate(); err != nil {
+		return errors.Wrap(err, "group_watch")
+	}
+
+	if err := cc.AMConfig.Validate(); err != nil {
+		return errors.Wrap(err, "access_manager_config")
+	}
+	if err := cc.AzureConfig.Validate(); err != nil {
+		return errors.Wrap(err, "azure_config")
+	}
+	if err := cc.GCPConfig.Validate(); err != nil {
+		return errors.Wrap(err, "gcp_config")
+	}
+	if err := cc.VaultConfig.Validate(); err != nil {
+		return errors.Wrap(err, "vault_config")
+	}
+	if err := cc.OAuth2Config.Validate(); err != nil {
+		return errors.Wrap(err, "oauth2_config")
+	}
+	if err := cc.FIDO2Config.Validate(); err != nil {
+		return errors.Wrap(err, "fido2_config")
+	}
+	if err := cc.SciTokensConfig.Validate(); err != nil {
+		return errors.Wrap(err, "scitokens_config")
+	}
+	if err := cc.BiscuitConfig.Validate(); err != nil {
+		return errors.Wrap(err, "biscuit_config")
+	}
+	if err := cc.KeystoneConfig.Validate(); err != nil {
+		return errors.Wrap(err, "keystone_config")
+	}
+	if err := cc.PKCS11Config.Validate(); err != nil {
+		return errors.Wrap(err, "pkcs11_config")
+	}
+	if err := cc.AnonConfig.Validate(); err != nil {
+		return errors.Wrap(err, "anon_config")
+	}
+	if err := cc.ExecConfig.Validate(); err != nil {
+		return errors.Wrap(err, "exec_config")
+	}
+	if err := cc.ProviderConfig.Validate(); err != nil {
+		return errors.Wrap(err, "provider_config")
+	}
+	if err := cc.MachineConfig.Validate(); err != nil {
+		return errors.Wrap(err, "machine_config")
+	}
+	if err := cc.SlurmConfig.Validate(); err != nil {
+		return errors.Wrap(err, "slurm_config")
+	}
+	if err := cc.WLMConfig.Validate(); err != nil {
+		return errors.Wrap(err, "wlm_config")
+	}
+	if err := cc.ADConfig.Validate(); err != nil {
+		return errors.Wrap(err, "ad_config")
+	}
+	if err := cc.TOTPConfig.Validate(); err != nil {
+		return errors.Wrap(err, "totp_config")
+	}
+	if err := cc.DelegationConfig.Validate(); err != nil {
+		return errors.Wrap(err, "delegation_config")
+	}
+	if err := cc.ProxyConfig.Validate(); err != nil {
+		return errors.Wrap(err, "proxy_config")
+	}
+	if err := cc.WebhookConfig.Validate(); err != nil {
+		return errors.Wrap(err, "webhook_config")
+	}
+	if err := cc.CosigningConfig.Validate(); err != nil {
+		return errors.Wrap(err, "cosigning_config")
+	}
+	if err := cc.ContainerConfig.Validate(); err != nil {
+		return errors.Wrap(err, "container_config")
+	}
+	for _, path := range cc.FlavorPlugins {
+		if !filepath.IsAbs(path) {
+			return errors.Errorf("flavor_plugins: %q is not an absolute path", path)
+		}
+	}
+
+	var err error
+	if cc.AzureConfig.IdentityMap, err = cc.AzureConfig.IdentityMap.Normalize(cc.PrincipalCaseFold); err != nil {
+		return errors.Wrap(err, "azure_config")
+	}
+	if cc.GCPConfig.IdentityMap, err = cc.GCPConfig.IdentityMap.Normalize(cc.PrincipalCaseFold); err != nil {
+		return errors.Wrap(err, "gcp_config")
+	}
+	if cc.VaultConfig.IdentityMap, err = cc.VaultConfig.IdentityMap.Normalize(cc.PrincipalCaseFold); err != nil {
+		return errors.Wrap(err, "vault_config")
+	}
+	if cc.OAuth2Config.IdentityMap, err = cc.OAuth2Config.IdentityMap.Normalize(cc.PrincipalCaseFold); err != nil {
+		return errors.Wrap(err, "oauth2_config")
+	}
+	if cc.SciTokensConfig.IdentityMap, err = cc.SciTokensConfig.IdentityMap.Normalize(cc.PrincipalCaseFold); err != nil {
+		return errors.Wrap(err, "scitokens_config")
+	}
+	if cc.KeystoneConfig.IdentityMap, err = cc.KeystoneConfig.IdentityMap.Normalize(cc.PrincipalCaseFold); err != nil {
+		return errors.Wrap(err, "keystone_config")
+	}
+	if cc.PKCS11Config.IdentityMap, err = cc.PKCS11Config.IdentityMap.Normalize(cc.PrincipalCaseFold); err != nil {
+		return errors.Wrap(err, "pkcs11_config")
+	}
+
+	sloNames := make(map[string]struct{})
+	for _, slo := range cc.IssuanceSLOs {
+		if err := slo.Validate(); err != nil {
+			return err
+		}
+		if _, found := sloNames[slo.Name]; found {
+			return errors.Errorf("duplicate issuance SLO name %q", slo.Name)
+		}
+		sloNames[slo.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+const (
+	// DefaultSLOWindow is the compliance window used for an issuance SLO
+	// if none is specified.
+	DefaultSLOWindow = time.Hour
+	// DefaultSLOBurnRateAlert is the burn rate above which an issuance SLO
+	// alert is raised if no threshold is specified.
+	DefaultSLOBurnRateAlert = 2.0
+)
+
+// CredentialSLO defines a service level objective for credential issuance.
+// If LatencyTarget is set, an issuance is only considered good if it succeeded
+// within the target latency. Otherwise, only the error rate is tracked.
+type CredentialSLO struct {
+	Name          string        `yaml:"name"`
+	Objective     float64       `yaml:"objective"`
+	LatencyTarget time.Duration `yaml:"latency_target,omitempty"`
+	Window        time.Duration `yaml:"window,omitempty"`
+	BurnRateAlert float64       `yaml:"burn_rate_alert,omitempty"`
+}
+
+// Validate checks the SLO definition and applies defaults for unset optional
+// fields.
+func (slo *CredentialSLO) Validate() error {
+	if slo == nil {
+		return errors.New("issuance SLO is nil")
+	}
+
+	if slo.Name == "" {
+		return errors.New("issuance SLO name must not be empty")
+	}
+
+	if slo.Objective <= 0 || slo.Objective >= 1 {
+		return errors.Errorf("issuance SLO %q: objective must be between 0 and 1 (exclusive)", slo.Name)
+	}
+
+	if slo.LatencyTarget < 0 {
+		return errors.Errorf("issuance SLO %q: latency_target must not be negative", slo.Name)
+	}
+
+	if slo.Window < 0 {
+		return errors.Errorf("issuance SLO %q: window must not be negative", slo.Name)
+	}
+	if slo.Window == 0 {
+		slo.Window = DefaultSLOWindow
+	}
+
+	if slo.BurnRateAlert < 0 {
+		return errors.Errorf("issuance SLO %q: burn_rate_alert must not be negative", slo.Name)
+	}
+	if slo.BurnRateAlert == 0 {
+		slo.BurnRateAlert = DefaultSLOBurnRateAlert
+	}
+
+	return nil
+}
+
+// ErrorBudget returns the fraction of issuances allowed to be bad.
+func (slo *CredentialSLO) ErrorBudget() float64 {
+	if slo == nil {
+		return 0
+	}
+	return 1 - slo.Objective
+}
+
+// AccessManagerConfig contains configuration details for managing access manager
+// requests. BaseURL and then each of FailoverURLs are tried in order of
+// priority. An endpoint which is unreachable, or responds with a server error,
+// is skipped until RetryInterval has passed, unless no other endpoint is
+// available. Connections to each endpoint are kept alive and reused between
+// requests, up to MaxConnections, until they have been idle for IdleTimeout.
+// If RevocationPollInterval is set, the access manager is polled at that
+// interval for subjects whose access has been revoked, so that their cached
+// responses can be invalidated. The access manager is reached using the
+// certificates in TLS, which are independent of those used to reach the DAOS
+// servers. A request which fails because no endpoint is available, or which
+// the access manager asks to be retried, is attempted up to MaxAttempts times,
+// with an exponential backoff from RetryBackoff up to RetryBackoffCap. Each
+// interaction with the access manager is recorded in the audit log, if one is
+// configured in Audit. Transport selects the protocol used to reach the
+// access manager, which determines the form of its endpoints.
+type AccessManagerConfig struct {
+	CallerID       string        `yaml:"caller_id,omitempty"`
+	Transport      string        `yaml:"transport,omitempty"`
+	BaseURL        string        `yaml:"base_url,omitempty"`
+	FailoverURLs   []string      `yaml:"failover_urls,omitempty"`
+	RetryInterval  time.Duration `yaml:"retry_interval,omitempty"`
+	MaxConnections int           `yaml:"max_connections,omitempty"`
+	IdleTimeout    time.Duration `yaml:"idle_timeout,omitempty"`
+	// RevocationPollInterval is how often to poll for revocations.
+	RevocationPollInterval time.Duration            `yaml:"revocation_poll_interval,omitempty"`
+	MaxAttempts            int                      `yaml:"max_attempts,omitempty"`
+	RetryBackoff           time.Duration            `yaml:"retry_backoff,omitempty"`
+	RetryBackoffCap        time.Duration            `yaml:"retry_backoff_cap,omitempty"`
+	TLS                    AccessManagerTLSConfig   `yaml:"tls_config,omitempty"`
+	Audit                  AccessManagerAuditConfig `yaml:"audit,omitempty"`
+}
+
+// Validate checks the access manager configuration if it has been set.
+func (ac *AccessManagerConfig) Validate() error {
+	if ac == nil || (ac.BaseURL == "" && len(ac.FailoverURLs) == 0) {
+		return nil
+	}
+
+	if ac.BaseURL == "" {
+		return errors.New("base_url must be set to use failover_urls")
+	}
+	for _, endpoint := range ac.Endpoints() {
+		if err := validateAMEndpoint(ac.Transport, endpoint); err != nil {
+			return err
+		}
+	}
+	if ac.RetryInterval < 0 {
+		return errors.New("retry_interval must not be negative")
+	}
+	if ac.MaxConnections < 0 {
+		return errors.New("max_connections must not be negative")
+	}
+	if ac.IdleTimeout < 0 {
+		return errors.New("idle_timeout must not be negative")
+	}
+	if ac.RevocationPollInterval < 0 {
+		return errors.New("revocation_poll_interval must not be negative")
+	}
+	if ac.MaxAttempts < 0 {
+		return errors.New("max_attempts must not be negative")
+	}
+	if ac.RetryBackoff < 0 || ac.RetryBackoffCap < 0 {
+		return errors.New("retry_backoff and retry_backoff_cap must not be negative")
+	}
+	if ac.RetryBackoff > 0 && ac.RetryBackoffCap > 0 && ac.RetryBackoffCap < ac.RetryBackoff {
+		return errors.New("retry_backoff_cap must not be less than retry_backoff")
+	}
+	if err := ac.TLS.Validate(); err != nil {
+		return errors.Wrap(err, "tls_config")
+	}
+	if err := ac.Audit.Validate(); err != nil {
+		return errors.Wrap(err, "audit")
+	}
+	if ac.TLS.IsSet() {
+		switch ac.Transport {
+		case AMTransportUnix:
+			return errors.Errorf("tls_config cannot be used with the %q transport", ac.Transport)
+		case AMTransportGRPC:
+		default:
+			for _, endpoint := range ac.Endpoints() {
+				if u, _ := url.Parse(endpoint); u.Scheme != "https" {
+					return errors.Errorf("endpoint %q must be an https URL to use tls_config", endpoint)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Transports used to reach the access manager.
+const (
+	// AMTransportREST reaches the access manager over HTTP(S), at endpoints
+	// such as "https://am.example.com". It is the default.
+	AMTransportREST = "rest"
+	// AMTransportGRPC reaches the access manager over gRPC with TLS, at
+	// endpoints such as "am.example.com:443".
+	AMTransportGRPC = "grpc"
+	// AMTransportUnix reaches a local access manager over HTTP on a Unix
+	// socket, at endpoints such as "unix:///var/run/am.sock".
+	AMTransportUnix = "unix"
+)
+
+// validateAMEndpoint checks that the endpoint has the form expected by the
+// transport.
+func validateAMEndpoint(transport, endpoint string) error {
+	switch transport {
+	case "", AMTransportREST:
+		if _, err := url.ParseRequestURI(endpoint); err != nil {
+			return errors.Wrapf(err, "invalid endpoint %q", endpoint)
+		}
+	case AMTransportGRPC:
+		if strings.Contains(endpoint, "://") {
+			return errors.Errorf("invalid endpoint %q: must be a host:port address", endpoint)
+		}
+		if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			return errors.Wrapf(err, "invalid endpoint %q", endpoint)
+		}
+	case AMTransportUnix:
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Scheme != "unix" || u.Host != "" || !filepath.IsAbs(u.Path) {
+			return errors.Errorf("invalid endpoint %q: must be a unix:// URL with an absolute path", endpoint)
+		}
+	default:
+		return errors.Errorf("unsupported transport %q (must be %q, %q or %q)",
+			transport, AMTransportREST, AMTransportGRPC, AMTransportUnix)
+	}
+
+	return nil
+}
+
+// Endpoints returns the access manager endpoints in order of priority.
+func (ac *AccessManagerConfig) Endpoints() []string {
+	if ac.BaseURL == "" {
+		return nil
+	}
+	return append([]string{ac.BaseURL}, ac.FailoverURLs...)
+}
+
+// AccessManagerTLSConfig contains the certificates used to reach the access
+// manager using mutual TLS. ServerName overrides the name expected in the
+// access manager's certificate, which is otherwise the host of the endpoint.
+// MinVersion is the minimum TLS version, "1.2" (the default) or "1.3".
+type AccessManagerTLSConfig struct {
+	CACert     string `yaml:"ca_cert,omitempty"`
+	Cert       string `yaml:"cert,omitempty"`
+	Key        string `yaml:"key,omitempty"`
+	ServerName string `yaml:"server_name,omitempty"`
+	MinVersion string `yaml:"min_version,omitempty"`
+}
+
+var tlsVersions = map[string]uint16{
+	"":    tls.VersionTLS12,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// IsSet reports whether any of the TLS configuration has been set.
+func (tc *AccessManagerTLSConfig) IsSet() bool {
+	return tc != nil && *tc != AccessManagerTLSConfig{}
+}
+
+// Validate checks the TLS configuration if it has been set.
+func (tc *AccessManagerTLSConfig) Validate() error {
+	if !tc.IsSet() {
+		return nil
+	}
+
+	if tc.CACert == "" || tc.Cert == "" || tc.Key == "" {
+		return errors.New("ca_cert, cert and key must be set")
+	}
+	if _, found := tlsVersions[tc.MinVersion]; !found {
+		return errors.Errorf("unsupported min_version %q", tc.MinVersion)
+	}
+
+	return nil
+}
+
+// TLSConfig loads the certificates and returns the TLS configuration used to
+// connect to the access manager.
+func (tc *AccessManagerTLSConfig) TLSConfig() (*tls.Config, error) {
+	certificate, certPool, err := loadCertWithCustomCA(tc.CACert, tc.Cert, tc.Key, MaxUserOnlyKeyPerm, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{*certificate},
+		RootCAs:      certPool,
+		ServerName:   tc.ServerName,
+		MinVersion:   tlsVersions[tc.MinVersion],
+	}, nil
+}
+
+// Redaction modes for identities recorded in the access manager audit log.
+const (
+	// AMAuditRedactHash records a fingerprint of each identity, so that the
+	// records for an identity can be correlated without revealing it.
+	AMAuditRedactHash = "hash"
+	// AMAuditRedactNone records identities as they are.
+	AMAuditRedactNone = "none"
+	// AMAuditRedactFull omits identities.
+	AMAuditRedactFull = "full"
+)
+
+// AccessManagerAuditConfig configures the audit log of access manager
+// interactions, which is written to LogFile separately from the agent log.
+// Redaction controls how identities are recorded, and defaults to
+// AMAuditRedactHash. Credentials and renewal tokens are only ever recorded as
+// fingerprints.
+type AccessManagerAuditConfig struct {
+	LogFile   string `yaml:"log_file,omitempty"`
+	Redaction string `yaml:"redaction,omitempty"`
+}
+
+// Validate checks the audit configuration.
+func (ac *AccessManagerAuditConfig) Validate() error {
+	switch ac.Redaction {
+	case "", AMAuditRedactHash, AMAuditRedactNone, AMAuditRedactFull:
+	default:
+		return errors.Errorf("unsupported redaction %q (must be %q, %q or %q)",
+			ac.Redaction, AMAuditRedactHash, AMAuditRedactNone, AMAuditRedactFull)
+	}
+	if ac.Redaction != "" && ac.LogFile == "" {
+		return errors.New("log_file must be set to use redaction")
+	}
+
+	return nil
+}
+
+// ExternalIdentityMap maps identities asserted by an external identity
+// provider (e.g. an object ID or a subject claim) to local users.
+type ExternalIdentityMap map[string]*MappedClientUser
+
+// Lookup returns the mapped user for the first of the supplied identities
+// found in the map, or nil if none of them are mapped.
+func (im ExternalIdentityMap) Lookup(ids ...string) *MappedClientUser {
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		if mu, found := im[id]; found {
+			return mu
+		}
+	}
+	return nil
+}
+
+// Normalize returns a copy of the map with each identity normalized with
+// NormalizePrincipal, so that lookups of normalized identities will match.
+// An error is returned if two identities normalize to the same value.
+func (im ExternalIdentityMap) Normalize(fold CaseFoldPolicy) (ExternalIdentityMap, error) {
+	if im == nil {
+		return nil, nil
+	}
+
+	out := make(ExternalIdentityMap, len(im))
+	for id, mu := range im {
+		normID, err := NormalizePrincipal(id, fold)
+		if err != nil {
+			return nil, errors.Wrap(err, "identity_map")
+		}
+		if _, found := out[normID]; found {
+			return nil, errors.Errorf("identity_map: %q is ambiguous after normalization", id)
+		}
+		out[normID] = mu
+	}
+
+	return out, nil
+}
+
+const (
+	azureLoginHost = "https://login.microsoftonline.com/"
+	azureSTSHost   = "https://sts.windows.net/"
+)
+
+// AzureConfig contains configuration details for validating Azure AD (Entra ID)
+// access tokens presented with the AUTH_AZURE flavor.
+type AzureConfig struct {
+	TenantID    string              `yaml:"tenant_id,omitempty"`
+	Audiences   []string            `yaml:"audiences,omitempty"`
+	JWKSURL     string              `yaml:"jwks_url,omitempty"`
+	IdentityMap ExternalIdentityMap `yaml:"identity_map,omitempty"`
+}
+
+// Validate checks the Azure configuration if it has been set.
+func (ac *AzureConfig) Validate() error {
+	if ac == nil || (ac.TenantID == "" && len(ac.Audiences) == 0 && ac.JWKSURL == "" && len(ac.IdentityMap) == 0) {
+		return nil
+	}
+
+	if ac.TenantID == "" {
+		return errors.New("tenant_id must be set")
+	}
+	if len(ac.Audiences) == 0 {
+		return errors.New("at least one audience must be set")
+	}
+
+	return nil
+}
+
+// KeySetURL returns the URL of the tenant's signing keys.
+func (ac *AzureConfig) KeySetURL() string {
+	if ac.JWKSURL != "" {
+		return ac.JWKSURL
+	}
+	return azureLoginHost + ac.TenantID + "/discovery/v2.0/keys"
+}
+
+// Issuers returns the token issuers trusted for the tenant. Both v1.0 and
+// v2.0 access token issuers are accepted.
+func (ac *AzureConfig) Issuers() []string {
+	return []string{
+		azureSTSHost + ac.TenantID + "/",
+		azureLoginHost + ac.TenantID + "/v2.0",
+	}
+}
+
+const (
+	gcpJWKSURL = "https://www.googleapis.com/oauth2/v3/certs"
+)
+
+// GCPConfig contains configuration details for validating Google-signed ID
+// tokens presented with the AUTH_GCP flavor.
+type GCPConfig struct {
+	Audiences      []string            `yaml:"audiences,omitempty"`
+	AllowedDomains []string            `yaml:"allowed_domains,omitempty"`
+	JWKSURL        string              `yaml:"jwks_url,omitempty"`
+	IdentityMap    ExternalIdentityMap `yaml:"identity_map,omitempty"`
+}
+
+// Validate checks the GCP configuration if it has been set.
+func (gc *GCPConfig) Validate() error {
+	if gc == nil || (len(gc.Audiences) == 0 && len(gc.AllowedDomains) == 0 && gc.JWKSURL == "" && len(gc.IdentityMap) == 0) {
+		return nil
+	}
+
+	if len(gc.Audiences) == 0 {
+		return errors.New("at least one audience must be set")
+	}
+
+	return nil
+}
+
+// KeySetURL returns the URL of Google's token signing keys.
+func (gc *GCPConfig) KeySetURL() string {
+	if gc.JWKSURL != "" {
+		return gc.JWKSURL
+	}
+	return gcpJWKSURL
+}
+
+// Issuers returns the token issuers trusted for Google-signed ID tokens.
+func (gc *GCPConfig) Issuers() []string {
+	return []string{"https://accounts.google.com", "accounts.google.com"}
+}
+
+// VaultConfig contains configuration details for looking up HashiCorp Vault
+// tokens presented with the AUTH_VAULT flavor.
+type VaultConfig struct {
+	Address         string              `yaml:"address,omitempty"`
+	Namespace       string              `yaml:"namespace,omitempty"`
+	CACert          string              `yaml:"ca_cert,omitempty"`
+	UserMetadataKey string              `yaml:"user_metadata_key,omitempty"`
+	IdentityMap     ExternalIdentityMap `yaml:"identity_map,omitempty"`
+}
+
+// Validate checks the Vault configuration if it has been set.
+func (vc *VaultConfig) Validate() error {
+	if vc == nil || (vc.Address == "" && vc.Namespace == "" && vc.CACert == "" && vc.UserMetadataKey == "" && len(vc.IdentityMap) == 0) {
+		return nil
+	}
+
+	if vc.Address == "" {
+		return errors.New("address must be set")
+	}
+	if err := validateHTTPURL(vc.Address); err != nil {
+		return errors.Wrap(err, "address")
+	}
+
+	return nil
+}
+
+// OAuth2Config contains configuration details for authenticating service
+// accounts with the AUTH_OAUTH2 flavor. Clients either present their client
+// credentials, which are exchanged at the token endpoint, or an access token,
+// which is checked at the introspection endpoint using the agent's own client
+// credentials.
+type OAuth2Config struct {
+	TokenURL         string              `yaml:"token_url,omitempty"`
+	IntrospectionURL string              `yaml:"introspection_url,omitempty"`
+	ClientID         string              `yaml:"client_id,omitempty"`
+	ClientSecretFile string              `yaml:"client_secret_file,omitempty"`
+	Scopes           []string            `yaml:"scopes,omitempty"`
+	IdentityMap      ExternalIdentityMap `yaml:"identity_map,omitempty"`
+}
+
+func validateHTTPURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return errors.Errorf("%q must be an http or https URL", rawURL)
+	}
+	return nil
+}
+
+// Validate checks the OAuth2 configuration if it has been set.
+func (oc *OAuth2Config) Validate() error {
+	if oc == nil || (oc.TokenURL == "" && oc.IntrospectionURL == "" && oc.ClientID == "" &&
+		oc.ClientSecretFile == "" && len(oc.Scopes) == 0 && len(oc.IdentityMap) == 0) {
+		return nil
+	}
+
+	if oc.TokenURL == "" && oc.IntrospectionURL == "" {
+		return errors.New("token_url or introspection_url must be set")
+	}
+	if oc.TokenURL != "" {
+		if err := validateHTTPURL(oc.TokenURL); err != nil {
+			return errors.Wrap(err, "token_url")
+		}
+	}
+	if oc.IntrospectionURL != "" {
+		if err := validateHTTPURL(oc.IntrospectionURL); err != nil {
+			return errors.Wrap(err, "introspection_url")
+		}
+		if oc.ClientID == "" || oc.ClientSecretFile == "" {
+			return errors.New("client_id and client_secret_file must be set to use introspection_url")
+		}
+	}
+
+	return nil
+}
+
+// SSHConfig contains configuration details for authenticating clients with
+// keys held in ssh-agent using the AUTH_SSH flavor.
+type SSHConfig struct {
+	AuthorizedKeysFile string `yaml:"authorized_keys_file,omitempty"`
+}
+
+// FIDO2Config contains configuration details for authenticating clients with
+// FIDO2 security keys using the AUTH_FIDO2 flavor. The agent acts as the
+// WebAuthn relying party identified by RPID, and accepts assertions made for
+// Origin by the credentials registered in CredentialsFile.
+type FIDO2Config struct {
+	RPID                    string `yaml:"rp_id,omitempty"`
+	Origin                  string `yaml:"origin,omitempty"`
+	CredentialsFile         string `yaml:"credentials_file,omitempty"`
+	RequireUserVerification bool   `yaml:"require_user_verification,omitempty"`
+}
+
+// Validate checks the FIDO2 configuration if it has been set.
+func (fc *FIDO2Config) Validate() error {
+	if fc == nil || (fc.RPID == "" && fc.Origin == "" && fc.CredentialsFile == "" && !fc.RequireUserVerification) {
+		return nil
+	}
+
+	if fc.RPID == "" || fc.Origin == "" || fc.CredentialsFile == "" {
+		return errors.New("rp_id, origin and credentials_file must be set")
+	}
+	u, err := url.Parse(fc.Origin)
+	if err != nil {
+		return errors.Wrap(err, "origin")
+	}
+	if u.Scheme != "https" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+		return errors.Errorf("origin %q must be an https origin", fc.Origin)
+	}
+	if host := u.Hostname(); host != fc.RPID && !strings.HasSuffix(host, "."+fc.RPID) {
+		return errors.Errorf("rp_id %q is not a registrable suffix of origin %q", fc.RPID, fc.Origin)
+	}
+
+	return nil
 }
 
-// AccessManagerConfig contains configuration details for managing access manager
-type AccessManagerConfig struct {
-	CallerID string `yaml:"caller_id,omitempty"`
-	BaseURL  string `yaml:"base_url,omitempty"`
+// SciTokenIssuer identifies an issuer of SciTokens or WLCG tokens trusted by
+// the agent. If JWKSURL is not set, the issuer's signing keys are discovered
+// from its OpenID Connect or OAuth 2.0 authorization server metadata.
+type SciTokenIssuer struct {
+	Issuer  string `yaml:"issuer"`
+	JWKSURL string `yaml:"jwks_url,omitempty"`
+}
+
+// SciTokensConfig contains configuration details for validating SciTokens
+// and WLCG tokens presented with the AUTH_SCITOKENS flavor. Tokens must be
+// issued for one of the Audiences, and must grant all of the RequiredScopes.
+type SciTokensConfig struct {
+	Issuers        []*SciTokenIssuer   `yaml:"issuers,omitempty"`
+	Audiences      []string            `yaml:"audiences,omitempty"`
+	RequiredScopes []string            `yaml:"required_scopes,omitempty"`
+	IdentityMap    ExternalIdentityMap `yaml:"identity_map,omitempty"`
+}
+
+// Validate checks the SciTokens configuration if it has been set.
+func (sc *SciTokensConfig) Validate() error {
+	if sc == nil || (len(sc.Issuers) == 0 && len(sc.Audiences) == 0 && len(sc.RequiredScopes) == 0 && len(sc.IdentityMap) == 0) {
+		return nil
+	}
+
+	if len(sc.Issuers) == 0 {
+		return errors.New("at least one issuer must be set")
+	}
+	if len(sc.Audiences) == 0 {
+		return errors.New("at least one audience must be set")
+	}
+
+	issuers := make(map[string]struct{})
+	for _, iss := range sc.Issuers {
+		if iss == nil || iss.Issuer == "" {
+			return errors.New("issuer must be set")
+		}
+		if u, err := url.Parse(iss.Issuer); err != nil || u.Scheme != "https" || u.Host == "" {
+			return errors.Errorf("issuer %q must be an https URL", iss.Issuer)
+		}
+		if _, found := issuers[iss.Issuer]; found {
+			return errors.Errorf("duplicate issuer %q", iss.Issuer)
+		}
+		issuers[iss.Issuer] = struct{}{}
+
+		if iss.JWKSURL != "" {
+			if err := validateHTTPURL(iss.JWKSURL); err != nil {
+				return errors.Wrapf(err, "jwks_url for issuer %q", iss.Issuer)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Issuer returns the configuration of the trusted issuer with the given
+// identifier, or nil if the issuer is not trusted.
+func (sc *SciTokensConfig) Issuer(iss string) *SciTokenIssuer {
+	for _, ti := range sc.Issuers {
+		if ti.Issuer == iss {
+			return ti
+		}
+	}
+	return nil
+}
+
+// MacaroonConfig contains configuration details for verifying macaroons
+// minted by a site authority and presented with the AUTH_MACAROON flavor.
+// RootKeyFile holds the secret shared with the authority. SystemName is set
+// by the agent, so that macaroons restricted to another system are rejected.
+type MacaroonConfig struct {
+	RootKeyFile string `yaml:"root_key_file,omitempty"`
+	SystemName  string `yaml:"-"`
+}
+
+// BiscuitConfig contains configuration details for verifying Biscuit tokens
+// presented with the AUTH_BISCUIT flavor. RootPublicKey is the hex-encoded
+// Ed25519 public key of the token issuer, optionally prefixed with
+// "ed25519/". SystemName is set by the agent, so that tokens may be
+// restricted to a system.
+type BiscuitConfig struct {
+	RootPublicKey string `yaml:"root_public_key,omitempty"`
+	SystemName    string `yaml:"-"`
+}
+
+// PublicKey decodes the configured root public key.
+func (bc *BiscuitConfig) PublicKey() (ed25519.PublicKey, error) {
+	key, err := hex.DecodeString(strings.TrimPrefix(bc.RootPublicKey, "ed25519/"))
+	if err != nil {
+		return nil, errors.Wrap(err, "root_public_key")
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, errors.Errorf("root_public_key must be a %d-byte Ed25519 key", ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// Validate checks the root public key, if set.
+func (bc *BiscuitConfig) Validate() error {
+	if bc == nil || bc.RootPublicKey == "" {
+		return nil
+	}
+	_, err := bc.PublicKey()
+	return err
+}
+
+// KeystoneConfig contains configuration details for validating OpenStack
+// Keystone tokens presented with the AUTH_KEYSTONE flavor. Users are
+// identified as "<user>@<domain>", or by user ID in the identity map.
+// ProjectGroups maps a token's project, as "<project>@<domain>" or project ID,
+// to the primary group of its users. If RequiredRoles is set, the token must
+// carry at least one of them.
+type KeystoneConfig struct {
+	AuthURL       string              `yaml:"auth_url,omitempty"`
+	CACert        string              `yaml:"ca_cert,omitempty"`
+	RequiredRoles []string            `yaml:"required_roles,omitempty"`
+	ProjectGroups map[string]string   `yaml:"project_groups,omitempty"`
+	IdentityMap   ExternalIdentityMap `yaml:"identity_map,omitempty"`
+}
+
+// Validate checks the Keystone configuration if it has been set.
+func (kc *KeystoneConfig) Validate() error {
+	if kc == nil || (kc.AuthURL == "" && kc.CACert == "" && len(kc.RequiredRoles) == 0 &&
+		len(kc.ProjectGroups) == 0 && len(kc.IdentityMap) == 0) {
+		return nil
+	}
+
+	if kc.AuthURL == "" {
+		return errors.New("auth_url must be set")
+	}
+	if err := validateHTTPURL(kc.AuthURL); err != nil {
+		return errors.Wrap(err, "auth_url")
+	}
+
+	return nil
+}
+
+// PKCS11Config contains configuration details for authenticating users who
+// sign a challenge with a smart card key using the AUTH_PKCS11 flavor.
+// CACertDir contains the PEM certificates of the CAs trusted to issue card
+// certificates. Users are identified by the User Principal Name, email
+// address or common name of their certificate, and may also be mapped by its
+// subject or SHA-256 fingerprint ("sha256:<hex>").
+type PKCS11Config struct {
+	CACertDir   string              `yaml:"ca_cert_dir,omitempty"`
+	IdentityMap ExternalIdentityMap `yaml:"identity_map,omitempty"`
+}
+
+// Validate checks the PKCS#11 configuration if it has been set.
+func (pc *PKCS11Config) Validate() error {
+	if pc == nil || (pc.CACertDir == "" && len(pc.IdentityMap) == 0) {
+		return nil
+	}
+
+	if pc.CACertDir == "" {
+		return errors.New("ca_cert_dir must be set")
+	}
+
+	return nil
+}
+
+// AnonConfig contains configuration details for issuing guest credentials
+// to unauthenticated clients using the AUTH_ANON flavor. Guest credentials
+// are issued for User and Group, which should be unprivileged accounts
+// granted no more than read access to public data.
+type AnonConfig struct {
+	User  string `yaml:"user,omitempty"`
+	Group string `yaml:"group,omitempty"`
+}
+
+// Validate checks the anonymous access configuration if it has been set.
+func (ac *AnonConfig) Validate() error {
+	if ac == nil || (ac.User == "" && ac.Group == "") {
+		return nil
+	}
+
+	if ac.User == "" {
+		return errors.New("user must be set")
+	}
+	if ac.User == "root" || ac.Group == "root" {
+		return errors.New("guest identity must not be root")
+	}
+
+	return nil
+}
+
+// ExecConfig contains configuration details for obtaining identities from a
+// site-provided helper executable using the AUTH_EXEC flavor. The helper is
+// run with the request body on stdin, the client's uid, gid and pid in the
+// DAOS_CLIENT_UID, DAOS_CLIENT_GID and DAOS_CLIENT_PID environment variables,
+// and any variables in Env ("NAME=value"). It must exit with status 0 and
+// write the identity to stdout as a JSON object with a "user" and optional
+// "group", "groups", "granted_scopes" and "pools".
+type ExecConfig struct {
+	Command string        `yaml:"command,omitempty"`
+	Args    []string      `yaml:"args,omitempty"`
+	Env     []string      `yaml:"env,omitempty"`
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// Validate checks the exec helper configuration if it has been set.
+func (ec *ExecConfig) Validate() error {
+	if ec == nil || (ec.Command == "" && len(ec.Args) == 0 && len(ec.Env) == 0) {
+		return nil
+	}
+
+	if !filepath.IsAbs(ec.Command) {
+		return errors.New("command must be an absolute path")
+	}
+	for _, env := range ec.Env {
+		if name, _, found := strings.Cut(env, "="); !found || name == "" {
+			return errors.Errorf("env entry %q is not of the form NAME=value", env)
+		}
+	}
+	if ec.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+
+	return nil
+}
+
+// ProviderConfig contains configuration details for obtaining identities from
+// a site-operated credential provider service using the AUTH_PROVIDER flavor.
+// The service implements the CredentialProvider gRPC service defined in
+// src/proto/security/credprov.proto, and is reached at Address (host:port)
+// using mutual TLS. SystemName is set by the agent and passed to the provider
+// with each request.
+type ProviderConfig struct {
+	Address    string        `yaml:"address,omitempty"`
+	CACert     string        `yaml:"ca_cert,omitempty"`
+	Cert       string        `yaml:"cert,omitempty"`
+	Key        string        `yaml:"key,omitempty"`
+	Timeout    time.Duration `yaml:"timeout,omitempty"`
+	SystemName string        `yaml:"-"`
+}
+
+// Validate checks the credential provider configuration if it has been set.
+func (pc *ProviderConfig) Validate() error {
+	if pc == nil || (pc.Address == "" && pc.CACert == "" && pc.Cert == "" && pc.Key == "") {
+		return nil
+	}
+
+	if pc.Address == "" {
+		return errors.New("address must be set")
+	}
+	if _, _, err := net.SplitHostPort(pc.Address); err != nil {
+		return errors.Wrap(err, "address")
+	}
+	if pc.CACert == "" || pc.Cert == "" || pc.Key == "" {
+		return errors.New("ca_cert, cert and key must be set")
+	}
+	if pc.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+
+	return nil
+}
+
+// TLSConfig loads the certificates and returns the TLS configuration used to
+// connect to the credential provider.
+func (pc *ProviderConfig) TLSConfig() (*tls.Config, error) {
+	certificate, certPool, err := loadCertWithCustomCA(pc.CACert, pc.Cert, pc.Key, MaxUserOnlyKeyPerm, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{*certificate},
+		RootCAs:      certPool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// MachineConfig contains configuration details for issuing credentials for
+// the node itself using the AUTH_MACHINE flavor. The node is identified by
+// the first DNS name or common name of Cert if set, or its hostname otherwise.
+// Machine credentials may only be requested by root or the users listed in
+// AllowedUIDs, such as the accounts of node-local daemons. If Group is set,
+// it is the primary group of the node.
+type MachineConfig struct {
+	Enabled     bool     `yaml:"enabled,omitempty"`
+	Cert        string   `yaml:"cert,omitempty"`
+	Group       string   `yaml:"group,omitempty"`
+	AllowedUIDs []uint32 `yaml:"allowed_uids,omitempty"`
+}
+
+// Validate checks the machine credential configuration if it has been set.
+func (mc *MachineConfig) Validate() error {
+	if mc == nil || (!mc.Enabled && mc.Cert == "" && mc.Group == "" && len(mc.AllowedUIDs) == 0) {
+		return nil
+	}
+
+	if !mc.Enabled {
+		return errors.New("enabled must be set to issue machine credentials")
+	}
+	if mc.Cert != "" && !filepath.IsAbs(mc.Cert) {
+		return errors.New("cert must be an absolute path")
+	}
+
+	return nil
+}
+
+// SlurmConfig contains configuration details for validating the Slurm JWTs
+// presented with the AUTH_SLURM flavor. JWTKeyFile is the HS256 key shared
+// with slurmctld (AuthAltParameters=jwt_key). Credentials are only issued to
+// processes of a Slurm job, for the user named by the token, and are
+// restricted to that job.
+type SlurmConfig struct {
+	JWTKeyFile string `yaml:"jwt_key_file,omitempty"`
+}
+
+// Validate checks the Slurm configuration if it has been set.
+func (sc *SlurmConfig) Validate() error {
+	if sc == nil || sc.JWTKeyFile == "" {
+		return nil
+	}
+
+	if !filepath.IsAbs(sc.JWTKeyFile) {
+		return errors.New("jwt_key_file must be an absolute path")
+	}
+
+	return nil
+}
+
+// WLMConfig contains configuration details for validating the job tokens
+// presented with the AUTH_WLM flavor. Job tokens are JWTs signed by a workload
+// manager, such as Flux, with the private key matching PublicKey (a PEM file),
+// and name the user in "sub" and the job in "job_id". Credentials are only
+// issued to processes in the cgroup of the token's job, as found by the first
+// submatch of JobCgroupPattern in the process's cgroup paths. If the token has
+// a "hosts" claim, the node must be one of the hosts allocated to the job.
+type WLMConfig struct {
+	PublicKey        string `yaml:"public_key,omitempty"`
+	Issuer           string `yaml:"issuer,omitempty"`
+	JobCgroupPattern string `yaml:"job_cgroup_pattern,omitempty"`
+}
+
+// Validate checks the workload manager configuration if it has been set.
+func (wc *WLMConfig) Validate() error {
+	if wc == nil || (wc.PublicKey == "" && wc.Issuer == "" && wc.JobCgroupPattern == "") {
+		return nil
+	}
+
+	if !filepath.IsAbs(wc.PublicKey) {
+		return errors.New("public_key must be an absolute path")
+	}
+	if wc.JobCgroupPattern == "" {
+		return errors.New("job_cgroup_pattern must be set")
+	}
+	if _, err := wc.JobCgroup(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// JobCgroup compiles the job cgroup pattern, which must have exactly one
+// subexpression, matching the job ID.
+func (wc *WLMConfig) JobCgroup() (*regexp.Regexp, error) {
+	re, err := regexp.Compile(wc.JobCgroupPattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "job_cgroup_pattern")
+	}
+	if re.NumSubexp() != 1 {
+		return nil, errors.New("job_cgroup_pattern must have exactly one subexpression matching the job ID")
+	}
+	return re, nil
+}
+
+// ADConfig contains configuration details for issuing credentials for Active
+// Directory users using the AUTH_AD flavor. The client's user and groups are
+// resolved through the SSSD InfoPipe service on the system bus at BusSocket,
+// rather than through NSS, and the user's SID is carried in the credential.
+type ADConfig struct {
+	Enabled   bool          `yaml:"enabled,omitempty"`
+	BusSocket string        `yaml:"bus_socket,omitempty"`
+	Timeout   time.Duration `yaml:"timeout,omitempty"`
+}
+
+// Validate checks the Active Directory configuration if it has been set.
+func (ac *ADConfig) Validate() error {
+	if ac == nil || (!ac.Enabled && ac.BusSocket == "" && ac.Timeout == 0) {
+		return nil
+	}
+
+	if !ac.Enabled {
+		return errors.New("enabled must be set to issue Active Directory credentials")
+	}
+	if ac.BusSocket != "" && !filepath.IsAbs(ac.BusSocket) {
+		return errors.New("bus_socket must be an absolute path")
+	}
+	if ac.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+
+	return nil
+}
+
+// TOTPConfig contains configuration details for confirming the identity of
+// the client's Unix user with a time-based one-time password (RFC 6238) using
+// the AUTH_TOTP flavor. SecretsFile lists the base32 secret enrolled for each
+// user, and must only be readable by the agent. Codes of Digits digits
+// (default 6) change every Period (default 30s), and codes from up to Skew
+// periods either side of the current one are accepted to allow for clock
+// drift.
+type TOTPConfig struct {
+	SecretsFile string        `yaml:"secrets_file,omitempty"`
+	Digits      int           `yaml:"digits,omitempty"`
+	Period      time.Duration `yaml:"period,omitempty"`
+	Skew        uint          `yaml:"skew,omitempty"`
+}
+
+// Validate checks the TOTP configuration if it has been set.
+func (tc *TOTPConfig) Validate() error {
+	if tc == nil || (tc.SecretsFile == "" && tc.Digits == 0 && tc.Period == 0 && tc.Skew == 0) {
+		return nil
+	}
+
+	if !filepath.IsAbs(tc.SecretsFile) {
+		return errors.New("secrets_file must be an absolute path")
+	}
+	if tc.Digits != 0 && (tc.Digits < 6 || tc.Digits > 8) {
+		return errors.New("digits must be between 6 and 8")
+	}
+	if tc.Period < 0 || tc.Period%time.Second != 0 {
+		return errors.New("period must be a whole number of seconds")
+	}
+
+	return nil
+}
+
+// DelegationConfig contains configuration details for issuing AUTH_DELEGATION
+// credentials, which the holder of a credential issued by the agent may pass
+// to a service acting on their behalf. Delegated credentials are restricted
+// to the requested pools and containers, and expire after the requested
+// lifetime, which may not exceed MaxLifetime (default 1h).
+type DelegationConfig struct {
+	Enabled     bool          `yaml:"enabled,omitempty"`
+	MaxLifetime time.Duration `yaml:"max_lifetime,omitempty"`
+}
+
+// Validate checks the delegation configuration if it has been set.
+func (dc *DelegationConfig) Validate() error {
+	if dc == nil || (!dc.Enabled && dc.MaxLifetime == 0) {
+		return nil
+	}
+
+	if !dc.Enabled {
+		return errors.New("enabled must be set to issue delegation credentials")
+	}
+	if dc.MaxLifetime < 0 || dc.MaxLifetime%time.Second != 0 {
+		return errors.New("max_lifetime must be a whole number of seconds")
+	}
+
+	return nil
+}
+
+// ProxyConfig contains configuration details for issuing AUTH_PROXY
+// credentials, with which gateway services such as NFS or dCache doors act
+// on behalf of remote users. Only the users listed in AllowedUIDs, such as the
+// accounts of gateway daemons, may request proxy credentials, and they may not
+// be requested for root unless AllowRoot is set. The server must also allow
+// the host to proxy.
+type ProxyConfig struct {
+	AllowedUIDs []uint32 `yaml:"allowed_uids,omitempty"`
+	AllowRoot   bool     `yaml:"allow_root,omitempty"`
+}
+
+// Validate checks the proxy configuration if it has been set.
+func (pc *ProxyConfig) Validate() error {
+	if pc == nil || (len(pc.AllowedUIDs) == 0 && !pc.AllowRoot) {
+		return nil
+	}
+
+	if len(pc.AllowedUIDs) == 0 {
+		return errors.New("allowed_uids must be set to issue proxy credentials")
+	}
+
+	return nil
+}
+
+// WebhookConfig contains configuration details for obtaining identities from
+// a site-operated HTTPS webhook using the AUTH_WEBHOOK flavor. The agent POSTs
+// a JSON object with the client's request body ("data", base64-encoded), its
+// "uid", "gid", "pid" and "machinename", and the "system", using mutual TLS.
+// The payload is signed with HMAC-SHA256 using the key in HMACKeyFile; the
+// X-DAOS-Signature header carries "sha256=" and the hex-encoded MAC of the
+// X-DAOS-Timestamp header value, a ".", and the payload. The webhook must
+// respond with status 200 and the identity as a JSON object as for ExecConfig.
+// SystemName is set by the agent.
+type WebhookConfig struct {
+	URL         string        `yaml:"url,omitempty"`
+	CACert      string        `yaml:"ca_cert,omitempty"`
+	Cert        string        `yaml:"cert,omitempty"`
+	Key         string        `yaml:"key,omitempty"`
+	HMACKeyFile string        `yaml:"hmac_key_file,omitempty"`
+	Timeout     time.Duration `yaml:"timeout,omitempty"`
+	SystemName  string        `yaml:"-"`
+}
+
+// Validate checks the webhook configuration if it has been set.
+func (wc *WebhookConfig) Validate() error {
+	if wc == nil || (wc.URL == "" && wc.CACert == "" && wc.Cert == "" && wc.Key == "" && wc.HMACKeyFile == "") {
+		return nil
+	}
+
+	u, err := url.Parse(wc.URL)
+	if err != nil {
+		return errors.Wrap(err, "url")
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return errors.New("url must be an https URL")
+	}
+	if wc.CACert == "" || wc.Cert == "" || wc.Key == "" {
+		return errors.New("ca_cert, cert and key must be set")
+	}
+	if !filepath.IsAbs(wc.HMACKeyFile) {
+		return errors.New("hmac_key_file must be an absolute path")
+	}
+	if wc.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+
+	return nil
+}
+
+// TLSConfig loads the certificates and returns the TLS configuration used to
+// connect to the webhook.
+func (wc *WebhookConfig) TLSConfig() (*tls.Config, error) {
+	certificate, certPool, err := loadCertWithCustomCA(wc.CACert, wc.Cert, wc.Key, MaxUserOnlyKeyPerm, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{*certificate},
+		RootCAs:      certPool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// DefaultContainerIDPattern matches the 64-digit container IDs in the cgroup
+// paths used by Docker, Podman, containerd and CRI-O.
+const DefaultContainerIDPattern = `(?:^|[/-])([0-9a-f]{64})(?:\.scope)?$`
+
+// ContainerConfig contains configuration details for recording the cgroup of
+// the requesting process, and the ID of the container it runs in, in the
+// credentials issued by the Unix-based flavors (AUTH_SYS, AUTH_AD and
+// AUTH_TOTP). The container ID is the first submatch of ContainerIDPattern,
+// or of DefaultContainerIDPattern if unset, in the process's cgroup paths.
+type ContainerConfig struct {
+	Enabled            bool   `yaml:"enabled,omitempty"`
+	ContainerIDPattern string `yaml:"container_id_pattern,omitempty"`
+}
+
+// Validate checks the container configuration if it has been set.
+func (cc *ContainerConfig) Validate() error {
+	if cc == nil || (!cc.Enabled && cc.ContainerIDPattern == "") {
+		return nil
+	}
+
+	if !cc.Enabled {
+		return errors.New("enabled must be set to record containers")
+	}
+	if _, err := cc.ContainerID(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContainerID compiles the container ID pattern, which must have exactly one
+// subexpression, matching the container ID.
+func (cc *ContainerConfig) ContainerID() (*regexp.Regexp, error) {
+	pattern := cc.ContainerIDPattern
+	if pattern == "" {
+		pattern = DefaultContainerIDPattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "container_id_pattern")
+	}
+	if re.NumSubexp() != 1 {
+		return nil, errors.New("container_id_pattern must have exactly one subexpression matching the container ID")
+	}
+	return re, nil
 }
 
 // TransportConfig contains all the information on whether or not to use
 // certificates and their location if their use is specified.
 type TransportConfig struct {
-	AllowInsecure     bool `yaml:"allow_insecure"`
+	AllowInsecure     bool          `yaml:"allow_insecure"`
+	WeakKeyPolicy     WeakKeyPolicy `yaml:"weak_key_policy,omitempty"`
 	CertificateConfig `yaml:",inline"`
 }
 
@@ -119,17 +1847,26 @@ func (tc *TransportConfig) String() string {
 
 // CertificateConfig contains the specific certificate information for the daos
 // component. ServerName is only needed if the config is being used as a
-// transport credential for a gRPC tls client.
+// transport credential for a gRPC tls client. If PKCS11Key, KMSKey or TPMKey
+// is set, the private key is held on a PKCS#11 token, by a key management
+// service or in the TPM respectively, rather than in the key file. If
+// KeyPassphrase is set, the key file is encrypted with a passphrase obtained as
+// it configures.
 type CertificateConfig struct {
-	ServerName      string           `yaml:"-"`
-	ClientCertDir   string           `yaml:"client_cert_dir,omitempty"`
-	CARootPath      string           `yaml:"ca_cert"`
-	CertificatePath string           `yaml:"cert"`
-	PrivateKeyPath  string           `yaml:"key"`
-	tlsKeypair      *tls.Certificate `yaml:"-"`
-	caPool          *x509.CertPool   `yaml:"-"`
-	maxKeyPerms     fs.FileMode      `yaml:"-"`
-	verifyTime      time.Time        `yaml:"-"` // for testing
+	ServerName      string               `yaml:"-"`
+	ClientCertDir   string               `yaml:"client_cert_dir,omitempty"`
+	CARootPath      string               `yaml:"ca_cert"`
+	CertificatePath string               `yaml:"cert"`
+	PrivateKeyPath  string               `yaml:"key"`
+	PKCS11Key       *PKCS11KeyConfig     `yaml:"pkcs11_key,omitempty"`
+	KMSKey          *KMSKeyConfig        `yaml:"kms_key,omitempty"`
+	TPMKey          *TPMKeyConfig        `yaml:"tpm_key,omitempty"`
+	KeyPassphrase   *KeyPassphraseConfig `yaml:"key_passphrase,omitempty"`
+	tlsKeypair      *tls.Certificate     `yaml:"-"`
+	caPool          *x509.CertPool       `yaml:"-"`
+	caBundle        *CABundle            `yaml:"-"`
+	maxKeyPerms     fs.FileMode          `yaml:"-"`
+	verifyTime      time.Time            `yaml:"-"` // for testing
 }
 
 // DefaultAgentTransportConfig provides a default transport config disabling
@@ -209,7 +1946,34 @@ func (tc *TransportConfig) PreLoadCertData() error {
 		}
 	}
 
-	certificate, certPool, err := loadCertWithCustomCA(tc.CARootPath, tc.CertificatePath, tc.PrivateKeyPath, tc.maxKeyPerms)
+	var certificate *tls.Certificate
+	var certPool *x509.CertPool
+	var err error
+	keySources := 0
+	for _, set := range []bool{tc.PKCS11Key != nil, tc.KMSKey != nil, tc.TPMKey != nil} {
+		if set {
+			keySources++
+		}
+	}
+
+	switch {
+	case keySources > 1:
+		return errors.New("only one of pkcs11_key, kms_key and tpm_key may be set")
+	case keySources > 0 && tc.KeyPassphrase != nil:
+		return errors.New("key_passphrase can't be used with pkcs11_key, kms_key or tpm_key")
+	case tc.PKCS11Key != nil:
+		certificate, certPool, err = loadCertWithPKCS11Key(tc.CARootPath, tc.CertificatePath, tc.PKCS11Key)
+	case tc.KMSKey != nil:
+		certificate, certPool, err = loadCertWithKMSKey(tc.CARootPath, tc.CertificatePath, tc.KMSKey)
+	case tc.TPMKey != nil:
+		certificate, certPool, err = loadCertWithTPMKey(tc.CARootPath, tc.CertificatePath, tc.TPMKey)
+	default:
+		if err := tc.KeyPassphrase.Validate(); err != nil {
+			return errors.Wrap(err, "key_passphrase")
+		}
+		certificate, certPool, err = loadCertWithCustomCA(tc.CARootPath, tc.CertificatePath, tc.PrivateKeyPath, tc.maxKeyPerms,
+			tc.KeyPassphrase)
+	}
 	if err != nil {
 		return err
 	}
@@ -244,6 +2008,22 @@ func (tc *TransportConfig) ReloadCertData() error {
 	return tc.PreLoadCertData()
 }
 
+// Reload returns a copy of the TransportConfig with its certificate data
+// reloaded, leaving the TransportConfig itself unchanged. It allows a
+// TransportConfig which is shared between goroutines to be replaced once the
+// new certificate data has been validated.
+func (tc *TransportConfig) Reload() (*TransportConfig, error) {
+	if tc == nil {
+		return nil, errors.New("nil TransportConfig")
+	}
+
+	reloaded := *tc
+	if err := reloaded.ReloadCertData(); err != nil {
+		return nil, err
+	}
+	return &reloaded, nil
+}
+
 // PrivateKey returns the private key stored in the certificates loaded into the TransportConfig
 func (tc *TransportConfig) PrivateKey() (crypto.PrivateKey, error) {
 	if tc.AllowInsecure {
@@ -273,3 +2053,18 @@ func (tc *TransportConfig) PublicKey() (crypto.PublicKey, error) {
 	}
 	return tc.tlsKeypair.Leaf.PublicKey, nil
 }
+
+// Certificate returns the leaf certificate loaded into the TransportConfig.
+func (tc *TransportConfig) Certificate() (*x509.Certificate, error) {
+	if tc.AllowInsecure {
+		return nil, nil
+	}
+	// If we don't have our keys loaded attempt to load them.
+	if tc.tlsKeypair == nil || tc.caPool == nil {
+		err := tc.ReloadCertData()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tc.tlsKeypair.Leaf, nil
+}
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
@@ -270,6 +271,40 @@ func TestReloadCertData(t *testing.T) {
 	}
 }
 
+func TestTransportConfig_Reload(t *testing.T) {
+	var nilTC *TransportConfig
+	_, err := nilTC.Reload()
+	test.CmpErr(t, errors.New("nil TransportConfig"), err)
+
+	serverTC := ServerTC()
+	agentTC := AgentTC()
+	testTC := serverTC
+
+	SetupTCFilePerms(t, serverTC)
+	SetupTCFilePerms(t, agentTC)
+
+	setValidVerifyTime(t, testTC)
+	if err := testTC.PreLoadCertData(); err != nil {
+		t.Fatal(err)
+	}
+	beforeCert := testTC.tlsKeypair.Certificate[0]
+
+	// A failed reload leaves the config unchanged.
+	testTC.PrivateKeyPath = agentTC.PrivateKeyPath
+	if _, err := testTC.Reload(); err == nil {
+		t.Fatal("expected reload with mismatched key to fail")
+	}
+	test.AssertTrue(t, bytes.Equal(beforeCert, testTC.tlsKeypair.Certificate[0]), "config changed by failed reload")
+
+	testTC.CertificatePath = agentTC.CertificatePath
+	reloaded, err := testTC.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, bytes.Equal(beforeCert, testTC.tlsKeypair.Certificate[0]), "config changed by reload")
+	test.AssertFalse(t, bytes.Equal(beforeCert, reloaded.tlsKeypair.Certificate[0]), "cert not reloaded")
+}
+
 func ValidateInsecurePrivateKey(t *testing.T, key crypto.PrivateKey, err error) {
 	if err != nil {
 		t.Fatalf("Unable to Load PrivateKey from TransportConfig: %s", err)
@@ -508,3 +543,1453 @@ default:
 		})
 	}
 }
+
+func TestSecurity_NegativeCacheConfig(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg        NegativeCacheConfig
+		expEnabled bool
+		expTTLs    map[string]time.Duration
+	}{
+		"disabled": {
+			expTTLs: map[string]time.Duration{
+				CredentialFailureRejected:    0,
+				CredentialFailureUnavailable: 0,
+			},
+		},
+		"default ttl": {
+			cfg:        NegativeCacheConfig{TTL: 5 * time.Second},
+			expEnabled: true,
+			expTTLs: map[string]time.Duration{
+				CredentialFailureRejected:    5 * time.Second,
+				CredentialFailureUnavailable: 5 * time.Second,
+			},
+		},
+		"status ttl overrides default": {
+			cfg: NegativeCacheConfig{
+				TTL: 5 * time.Second,
+				StatusTTLs: map[string]time.Duration{
+					CredentialFailureUnavailable: 0,
+				},
+			},
+			expEnabled: true,
+			expTTLs: map[string]time.Duration{
+				CredentialFailureRejected:    5 * time.Second,
+				CredentialFailureUnavailable: 0,
+			},
+		},
+		"status ttl only": {
+			cfg: NegativeCacheConfig{
+				StatusTTLs: map[string]time.Duration{
+					CredentialFailureRejected: time.Minute,
+				},
+			},
+			expEnabled: true,
+			expTTLs: map[string]time.Duration{
+				CredentialFailureRejected:    time.Minute,
+				CredentialFailureUnavailable: 0,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.AssertEqual(t, tc.expEnabled, tc.cfg.Enabled(), "unexpected enabled state")
+			for status, expTTL := range tc.expTTLs {
+				test.AssertEqual(t, expTTL, tc.cfg.StatusTTL(status), "unexpected ttl for "+status)
+			}
+		})
+	}
+}
+
+func TestSecurity_ByteSize(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfgYaml string
+		expSize ByteSize
+		expErr  error
+	}{
+		"bytes": {
+			cfgYaml: "4096",
+			expSize: 4096,
+		},
+		"binary unit": {
+			cfgYaml: "64MiB",
+			expSize: 64 << 20,
+		},
+		"decimal unit": {
+			cfgYaml: "1 GB",
+			expSize: 1000 * 1000 * 1000,
+		},
+		"invalid": {
+			cfgYaml: "lots",
+			expErr:  errors.New(`invalid size "lots"`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var result ByteSize
+			err := yaml.Unmarshal([]byte(tc.cfgYaml), &result)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertEqual(t, tc.expSize, result, "unexpected size")
+		})
+	}
+}
+
+func TestSecurity_CredentialConfig_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg    *CredentialConfig
+		expCfg *CredentialConfig
+		expErr error
+	}{
+		"nil": {
+			expErr: errors.New("nil"),
+		},
+		"empty": {
+			cfg:    &CredentialConfig{},
+			expCfg: &CredentialConfig{},
+		},
+		"SLO defaults applied": {
+			cfg: &CredentialConfig{
+				IssuanceSLOs: []*CredentialSLO{
+					{Name: "latency", Objective: 0.99, LatencyTarget: 200 * time.Millisecond},
+				},
+			},
+			expCfg: &CredentialConfig{
+				IssuanceSLOs: []*CredentialSLO{
+					{
+						Name:          "latency",
+						Objective:     0.99,
+						LatencyTarget: 200 * time.Millisecond,
+						Window:        DefaultSLOWindow,
+						BurnRateAlert: DefaultSLOBurnRateAlert,
+					},
+				},
+			},
+		},
+		"SLO without name": {
+			cfg: &CredentialConfig{
+				IssuanceSLOs: []*CredentialSLO{{Objective: 0.99}},
+			},
+			expErr: errors.New("name must not be empty"),
+		},
+		"SLO objective out of range": {
+			cfg: &CredentialConfig{
+				IssuanceSLOs: []*CredentialSLO{{Name: "errors", Objective: 1}},
+			},
+			expErr: errors.New("objective"),
+		},
+		"duplicate SLO names": {
+			cfg: &CredentialConfig{
+				IssuanceSLOs: []*CredentialSLO{
+					{Name: "errors", Objective: 0.999},
+					{Name: "errors", Objective: 0.99},
+				},
+			},
+			expErr: errors.New("duplicate"),
+		},
+		"Azure config valid": {
+			cfg: &CredentialConfig{
+				AzureConfig: AzureConfig{TenantID: "tenant", Audiences: []string{"api://daos"}},
+			},
+			expCfg: &CredentialConfig{
+				AzureConfig: AzureConfig{TenantID: "tenant", Audiences: []string{"api://daos"}},
+			},
+		},
+		"Azure config without tenant": {
+			cfg: &CredentialConfig{
+				AzureConfig: AzureConfig{Audiences: []string{"api://daos"}},
+			},
+			expErr: errors.New("tenant_id"),
+		},
+		"Azure config without audience": {
+			cfg: &CredentialConfig{
+				AzureConfig: AzureConfig{TenantID: "tenant"},
+			},
+			expErr: errors.New("audience"),
+		},
+		"GCP config valid": {
+			cfg: &CredentialConfig{
+				GCPConfig: GCPConfig{Audiences: []string{"daos"}},
+			},
+			expCfg: &CredentialConfig{
+				GCPConfig: GCPConfig{Audiences: []string{"daos"}},
+			},
+		},
+		"GCP config without audience": {
+			cfg: &CredentialConfig{
+				GCPConfig: GCPConfig{AllowedDomains: []string{"example.com"}},
+			},
+			expErr: errors.New("gcp_config: at least one audience"),
+		},
+		"Vault config valid": {
+			cfg: &CredentialConfig{
+				VaultConfig: VaultConfig{Address: "https://vault.example.com:8200"},
+			},
+			expCfg: &CredentialConfig{
+				VaultConfig: VaultConfig{Address: "https://vault.example.com:8200"},
+			},
+		},
+		"Vault config without address": {
+			cfg: &CredentialConfig{
+				VaultConfig: VaultConfig{UserMetadataKey: "username"},
+			},
+			expErr: errors.New("vault_config: address must be set"),
+		},
+		"Vault config with bad address scheme": {
+			cfg: &CredentialConfig{
+				VaultConfig: VaultConfig{Address: "vault.example.com:8200"},
+			},
+			expErr: errors.New("http or https"),
+		},
+		"OAuth2 token exchange config valid": {
+			cfg: &CredentialConfig{
+				OAuth2Config: OAuth2Config{TokenURL: "https://idp.example.com/token"},
+			},
+			expCfg: &CredentialConfig{
+				OAuth2Config: OAuth2Config{TokenURL: "https://idp.example.com/token"},
+			},
+		},
+		"OAuth2 config without endpoint": {
+			cfg: &CredentialConfig{
+				OAuth2Config: OAuth2Config{Scopes: []string{"daos"}},
+			},
+			expErr: errors.New("oauth2_config: token_url or introspection_url"),
+		},
+		"OAuth2 introspection without client credentials": {
+			cfg: &CredentialConfig{
+				OAuth2Config: OAuth2Config{IntrospectionURL: "https://idp.example.com/introspect"},
+			},
+			expErr: errors.New("client_id and client_secret_file"),
+		},
+		"OAuth2 config with bad token URL": {
+			cfg: &CredentialConfig{
+				OAuth2Config: OAuth2Config{TokenURL: "ftp://idp.example.com/token"},
+			},
+			expErr: errors.New("token_url"),
+		},
+		"FIDO2 config valid": {
+			cfg: &CredentialConfig{
+				FIDO2Config: FIDO2Config{
+					RPID:            "example.com",
+					Origin:          "https://daos.example.com",
+					CredentialsFile: "/etc/daos/fido2_credentials",
+				},
+			},
+			expCfg: &CredentialConfig{
+				FIDO2Config: FIDO2Config{
+					RPID:            "example.com",
+					Origin:          "https://daos.example.com",
+					CredentialsFile: "/etc/daos/fido2_credentials",
+				},
+			},
+		},
+		"FIDO2 config without credentials": {
+			cfg: &CredentialConfig{
+				FIDO2Config: FIDO2Config{
+					RPID:   "example.com",
+					Origin: "https://daos.example.com",
+				},
+			},
+			expErr: errors.New("fido2_config: rp_id, origin and credentials_file"),
+		},
+		"FIDO2 origin not https": {
+			cfg: &CredentialConfig{
+				FIDO2Config: FIDO2Config{
+					RPID:            "example.com",
+					Origin:          "http://daos.example.com",
+					CredentialsFile: "/etc/daos/fido2_credentials",
+				},
+			},
+			expErr: errors.New("must be an https origin"),
+		},
+		"FIDO2 origin outside RP ID": {
+			cfg: &CredentialConfig{
+				FIDO2Config: FIDO2Config{
+					RPID:            "example.com",
+					Origin:          "https://daos.example.org",
+					CredentialsFile: "/etc/daos/fido2_credentials",
+				},
+			},
+			expErr: errors.New("not a registrable suffix"),
+		},
+		"SciTokens config valid": {
+			cfg: &CredentialConfig{
+				SciTokensConfig: SciTokensConfig{
+					Issuers:   []*SciTokenIssuer{{Issuer: "https://scitokens.example.org"}},
+					Audiences: []string{"https://daos.example.org"},
+				},
+			},
+			expCfg: &CredentialConfig{
+				SciTokensConfig: SciTokensConfig{
+					Issuers:   []*SciTokenIssuer{{Issuer: "https://scitokens.example.org"}},
+					Audiences: []string{"https://daos.example.org"},
+				},
+			},
+		},
+		"SciTokens config without audience": {
+			cfg: &CredentialConfig{
+				SciTokensConfig: SciTokensConfig{
+					Issuers: []*SciTokenIssuer{{Issuer: "https://scitokens.example.org"}},
+				},
+			},
+			expErr: errors.New("scitokens_config: at least one audience"),
+		},
+		"SciTokens issuer not https": {
+			cfg: &CredentialConfig{
+				SciTokensConfig: SciTokensConfig{
+					Issuers:   []*SciTokenIssuer{{Issuer: "http://scitokens.example.org"}},
+					Audiences: []string{"https://daos.example.org"},
+				},
+			},
+			expErr: errors.New("must be an https URL"),
+		},
+		"SciTokens duplicate issuer": {
+			cfg: &CredentialConfig{
+				SciTokensConfig: SciTokensConfig{
+					Issuers: []*SciTokenIssuer{
+						{Issuer: "https://scitokens.example.org"},
+						{Issuer: "https://scitokens.example.org", JWKSURL: "https://scitokens.example.org/jwks"},
+					},
+					Audiences: []string{"https://daos.example.org"},
+				},
+			},
+			expErr: errors.New("duplicate issuer"),
+		},
+		"Biscuit root key valid": {
+			cfg: &CredentialConfig{
+				BiscuitConfig: BiscuitConfig{
+					RootPublicKey: "ed25519/" + strings.Repeat("ab", 32),
+				},
+			},
+			expCfg: &CredentialConfig{
+				BiscuitConfig: BiscuitConfig{
+					RootPublicKey: "ed25519/" + strings.Repeat("ab", 32),
+				},
+			},
+		},
+		"Biscuit root key not hex": {
+			cfg: &CredentialConfig{
+				BiscuitConfig: BiscuitConfig{
+					RootPublicKey: "secp256r1/" + strings.Repeat("ab", 32),
+				},
+			},
+			expErr: errors.New("biscuit_config: root_public_key"),
+		},
+		"Biscuit root key wrong length": {
+			cfg: &CredentialConfig{
+				BiscuitConfig: BiscuitConfig{
+					RootPublicKey: strings.Repeat("ab", 16),
+				},
+			},
+			expErr: errors.New("32-byte Ed25519 key"),
+		},
+		"Keystone config valid": {
+			cfg: &CredentialConfig{
+				KeystoneConfig: KeystoneConfig{
+					AuthURL:       "https://keystone.example.com:5000/v3",
+					ProjectGroups: map[string]string{"hpc@Default": "hpc"},
+				},
+			},
+			expCfg: &CredentialConfig{
+				KeystoneConfig: KeystoneConfig{
+					AuthURL:       "https://keystone.example.com:5000/v3",
+					ProjectGroups: map[string]string{"hpc@Default": "hpc"},
+				},
+			},
+		},
+		"Keystone config without auth URL": {
+			cfg: &CredentialConfig{
+				KeystoneConfig: KeystoneConfig{
+					RequiredRoles: []string{"member"},
+				},
+			},
+			expErr: errors.New("keystone_config: auth_url must be set"),
+		},
+		"PKCS11 identity map without CA directory": {
+			cfg: &CredentialConfig{
+				PKCS11Config: PKCS11Config{
+					IdentityMap: ExternalIdentityMap{
+						"jdoe@example.com": {User: "jdoe"},
+					},
+				},
+			},
+			expErr: errors.New("pkcs11_config: ca_cert_dir must be set"),
+		},
+		"anon config valid": {
+			cfg: &CredentialConfig{
+				AnonConfig: AnonConfig{User: "nobody", Group: "nobody"},
+			},
+			expCfg: &CredentialConfig{
+				AnonConfig: AnonConfig{User: "nobody", Group: "nobody"},
+			},
+		},
+		"anon group without user": {
+			cfg: &CredentialConfig{
+				AnonConfig: AnonConfig{Group: "nobody"},
+			},
+			expErr: errors.New("anon_config: user must be set"),
+		},
+		"anon root user": {
+			cfg: &CredentialConfig{
+				AnonConfig: AnonConfig{User: "root"},
+			},
+			expErr: errors.New("anon_config: guest identity must not be root"),
+		},
+		"exec config valid": {
+			cfg: &CredentialConfig{
+				ExecConfig: ExecConfig{
+					Command: "/usr/libexec/daos/identity-helper",
+					Env:     []string{"SITE=example"},
+				},
+			},
+			expCfg: &CredentialConfig{
+				ExecConfig: ExecConfig{
+					Command: "/usr/libexec/daos/identity-helper",
+					Env:     []string{"SITE=example"},
+				},
+			},
+		},
+		"exec relative command": {
+			cfg: &CredentialConfig{
+				ExecConfig: ExecConfig{Command: "identity-helper"},
+			},
+			expErr: errors.New("exec_config: command must be an absolute path"),
+		},
+		"exec bad env entry": {
+			cfg: &CredentialConfig{
+				ExecConfig: ExecConfig{
+					Command: "/usr/libexec/daos/identity-helper",
+					Env:     []string{"SITE"},
+				},
+			},
+			expErr: errors.New("not of the form NAME=value"),
+		},
+		"provider config valid": {
+			cfg: &CredentialConfig{
+				ProviderConfig: ProviderConfig{
+					Address: "idp.example.com:7443",
+					CACert:  "/etc/daos/certs/provider-ca.crt",
+					Cert:    "/etc/daos/certs/agent.crt",
+					Key:     "/etc/daos/certs/agent.key",
+				},
+			},
+			expCfg: &CredentialConfig{
+				ProviderConfig: ProviderConfig{
+					Address: "idp.example.com:7443",
+					CACert:  "/etc/daos/certs/provider-ca.crt",
+					Cert:    "/etc/daos/certs/agent.crt",
+					Key:     "/etc/daos/certs/agent.key",
+				},
+			},
+		},
+		"provider address without port": {
+			cfg: &CredentialConfig{
+				ProviderConfig: ProviderConfig{
+					Address: "idp.example.com",
+					CACert:  "/etc/daos/certs/provider-ca.crt",
+					Cert:    "/etc/daos/certs/agent.crt",
+					Key:     "/etc/daos/certs/agent.key",
+				},
+			},
+			expErr: errors.New("provider_config: address"),
+		},
+		"provider without client cert": {
+			cfg: &CredentialConfig{
+				ProviderConfig: ProviderConfig{
+					Address: "idp.example.com:7443",
+					CACert:  "/etc/daos/certs/provider-ca.crt",
+				},
+			},
+			expErr: errors.New("provider_config: ca_cert, cert and key must be set"),
+		},
+		"machine config valid": {
+			cfg: &CredentialConfig{
+				MachineConfig: MachineConfig{
+					Enabled:     true,
+					Cert:        "/etc/daos/certs/node.crt",
+					AllowedUIDs: []uint32{990},
+				},
+			},
+			expCfg: &CredentialConfig{
+				MachineConfig: MachineConfig{
+					Enabled:     true,
+					Cert:        "/etc/daos/certs/node.crt",
+					AllowedUIDs: []uint32{990},
+				},
+			},
+		},
+		"machine config not enabled": {
+			cfg: &CredentialConfig{
+				MachineConfig: MachineConfig{AllowedUIDs: []uint32{990}},
+			},
+			expErr: errors.New("machine_config: enabled must be set"),
+		},
+		"machine relative cert": {
+			cfg: &CredentialConfig{
+				MachineConfig: MachineConfig{Enabled: true, Cert: "node.crt"},
+			},
+			expErr: errors.New("machine_config: cert must be an absolute path"),
+		},
+		"slurm relative key file": {
+			cfg: &CredentialConfig{
+				SlurmConfig: SlurmConfig{JWTKeyFile: "jwt_hs256.key"},
+			},
+			expErr: errors.New("slurm_config: jwt_key_file must be an absolute path"),
+		},
+		"wlm config valid": {
+			cfg: &CredentialConfig{
+				WLMConfig: WLMConfig{
+					PublicKey:        "/etc/flux/job-token.pub",
+					JobCgroupPattern: `/shell-(f[0-9A-Za-z]+)\.service`,
+				},
+			},
+			expCfg: &CredentialConfig{
+				WLMConfig: WLMConfig{
+					PublicKey:        "/etc/flux/job-token.pub",
+					JobCgroupPattern: `/shell-(f[0-9A-Za-z]+)\.service`,
+				},
+			},
+		},
+		"wlm without cgroup pattern": {
+			cfg: &CredentialConfig{
+				WLMConfig: WLMConfig{PublicKey: "/etc/flux/job-token.pub"},
+			},
+			expErr: errors.New("wlm_config: job_cgroup_pattern must be set"),
+		},
+		"wlm cgroup pattern without job ID": {
+			cfg: &CredentialConfig{
+				WLMConfig: WLMConfig{
+					PublicKey:        "/etc/flux/job-token.pub",
+					JobCgroupPattern: `/shell-f[0-9A-Za-z]+\.service`,
+				},
+			},
+			expErr: errors.New("exactly one subexpression"),
+		},
+		"ad config valid": {
+			cfg: &CredentialConfig{
+				ADConfig: ADConfig{Enabled: true, BusSocket: "/run/dbus/system_bus_socket"},
+			},
+			expCfg: &CredentialConfig{
+				ADConfig: ADConfig{Enabled: true, BusSocket: "/run/dbus/system_bus_socket"},
+			},
+		},
+		"ad bus socket without enabled": {
+			cfg: &CredentialConfig{
+				ADConfig: ADConfig{BusSocket: "/run/dbus/system_bus_socket"},
+			},
+			expErr: errors.New("ad_config: enabled must be set"),
+		},
+		"ad relative bus socket": {
+			cfg: &CredentialConfig{
+				ADConfig: ADConfig{Enabled: true, BusSocket: "system_bus_socket"},
+			},
+			expErr: errors.New("bus_socket must be an absolute path"),
+		},
+		"totp config valid": {
+			cfg: &CredentialConfig{
+				TOTPConfig: TOTPConfig{SecretsFile: "/etc/daos/totp_secrets", Digits: 8, Skew: 1},
+			},
+			expCfg: &CredentialConfig{
+				TOTPConfig: TOTPConfig{SecretsFile: "/etc/daos/totp_secrets", Digits: 8, Skew: 1},
+			},
+		},
+		"totp without secrets file": {
+			cfg: &CredentialConfig{
+				TOTPConfig: TOTPConfig{Digits: 6},
+			},
+			expErr: errors.New("totp_config: secrets_file must be an absolute path"),
+		},
+		"totp bad digits": {
+			cfg: &CredentialConfig{
+				TOTPConfig: TOTPConfig{SecretsFile: "/etc/daos/totp_secrets", Digits: 4},
+			},
+			expErr: errors.New("digits must be between 6 and 8"),
+		},
+		"totp fractional period": {
+			cfg: &CredentialConfig{
+				TOTPConfig: TOTPConfig{SecretsFile: "/etc/daos/totp_secrets", Period: 1500 * time.Millisecond},
+			},
+			expErr: errors.New("whole number of seconds"),
+		},
+		"delegation config valid": {
+			cfg: &CredentialConfig{
+				DelegationConfig: DelegationConfig{Enabled: true, MaxLifetime: 15 * time.Minute},
+			},
+			expCfg: &CredentialConfig{
+				DelegationConfig: DelegationConfig{Enabled: true, MaxLifetime: 15 * time.Minute},
+			},
+		},
+		"delegation lifetime without enabled": {
+			cfg: &CredentialConfig{
+				DelegationConfig: DelegationConfig{MaxLifetime: time.Hour},
+			},
+			expErr: errors.New("delegation_config: enabled must be set"),
+		},
+		"delegation negative lifetime": {
+			cfg: &CredentialConfig{
+				DelegationConfig: DelegationConfig{Enabled: true, MaxLifetime: -time.Hour},
+			},
+			expErr: errors.New("max_lifetime must be a whole number of seconds"),
+		},
+		"proxy config valid": {
+			cfg: &CredentialConfig{
+				ProxyConfig: ProxyConfig{AllowedUIDs: []uint32{992}},
+			},
+			expCfg: &CredentialConfig{
+				ProxyConfig: ProxyConfig{AllowedUIDs: []uint32{992}},
+			},
+		},
+		"proxy allow root without uids": {
+			cfg: &CredentialConfig{
+				ProxyConfig: ProxyConfig{AllowRoot: true},
+			},
+			expErr: errors.New("proxy_config: allowed_uids must be set"),
+		},
+		"webhook config valid": {
+			cfg: &CredentialConfig{
+				WebhookConfig: WebhookConfig{
+					URL:         "https://idp.example.com/daos/identity",
+					CACert:      "/etc/daos/certs/webhook-ca.crt",
+					Cert:        "/etc/daos/certs/agent.crt",
+					Key:         "/etc/daos/certs/agent.key",
+					HMACKeyFile: "/etc/daos/webhook.key",
+				},
+			},
+			expCfg: &CredentialConfig{
+				WebhookConfig: WebhookConfig{
+					URL:         "https://idp.example.com/daos/identity",
+					CACert:      "/etc/daos/certs/webhook-ca.crt",
+					Cert:        "/etc/daos/certs/agent.crt",
+					Key:         "/etc/daos/certs/agent.key",
+					HMACKeyFile: "/etc/daos/webhook.key",
+				},
+			},
+		},
+		"webhook over plain http": {
+			cfg: &CredentialConfig{
+				WebhookConfig: WebhookConfig{
+					URL:         "http://idp.example.com/daos/identity",
+					CACert:      "/etc/daos/certs/webhook-ca.crt",
+					Cert:        "/etc/daos/certs/agent.crt",
+					Key:         "/etc/daos/certs/agent.key",
+					HMACKeyFile: "/etc/daos/webhook.key",
+				},
+			},
+			expErr: errors.New("webhook_config: url must be an https URL"),
+		},
+		"webhook without client cert": {
+			cfg: &CredentialConfig{
+				WebhookConfig: WebhookConfig{
+					URL:         "https://idp.example.com/daos/identity",
+					HMACKeyFile: "/etc/daos/webhook.key",
+				},
+			},
+			expErr: errors.New("webhook_config: ca_cert, cert and key must be set"),
+		},
+		"webhook without hmac key": {
+			cfg: &CredentialConfig{
+				WebhookConfig: WebhookConfig{
+					URL:    "https://idp.example.com/daos/identity",
+					CACert: "/etc/daos/certs/webhook-ca.crt",
+					Cert:   "/etc/daos/certs/agent.crt",
+					Key:    "/etc/daos/certs/agent.key",
+				},
+			},
+			expErr: errors.New("webhook_config: hmac_key_file must be an absolute path"),
+		},
+		"container config default pattern": {
+			cfg: &CredentialConfig{
+				ContainerConfig: ContainerConfig{Enabled: true},
+			},
+			expCfg: &CredentialConfig{
+				ContainerConfig: ContainerConfig{Enabled: true},
+			},
+		},
+		"container pattern without enabled": {
+			cfg: &CredentialConfig{
+				ContainerConfig: ContainerConfig{ContainerIDPattern: `/pod-([0-9a-f]+)$`},
+			},
+			expErr: errors.New("container_config: enabled must be set"),
+		},
+		"container pattern without subexpression": {
+			cfg: &CredentialConfig{
+				ContainerConfig: ContainerConfig{
+					Enabled:            true,
+					ContainerIDPattern: `/pod-[0-9a-f]+$`,
+				},
+			},
+			expErr: errors.New("container_config: container_id_pattern must have exactly one subexpression"),
+		},
+		"access manager failover": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					BaseURL:      "https://am1.example.com",
+					FailoverURLs: []string{"https://am2.example.com"},
+				},
+			},
+			expCfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					BaseURL:      "https://am1.example.com",
+					FailoverURLs: []string{"https://am2.example.com"},
+				},
+			},
+		},
+		"access manager failover without base url": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					FailoverURLs: []string{"https://am2.example.com"},
+				},
+			},
+			expErr: errors.New("access_manager_config: base_url must be set"),
+		},
+		"access manager negative max connections": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					BaseURL:        "https://am1.example.com",
+					MaxConnections: -1,
+				},
+			},
+			expErr: errors.New("access_manager_config: max_connections must not be negative"),
+		},
+		"access manager negative revocation poll interval": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					BaseURL:                "https://am1.example.com",
+					RevocationPollInterval: -time.Second,
+				},
+			},
+			expErr: errors.New("access_manager_config: revocation_poll_interval must not be negative"),
+		},
+		"access manager tls incomplete": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					BaseURL: "https://am1.example.com",
+					TLS: AccessManagerTLSConfig{
+						CACert: "/etc/daos/certs/am-ca.crt",
+					},
+				},
+			},
+			expErr: errors.New("access_manager_config: tls_config: ca_cert, cert and key must be set"),
+		},
+		"access manager tls unsupported min version": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					BaseURL: "https://am1.example.com",
+					TLS: AccessManagerTLSConfig{
+						CACert:     "/etc/daos/certs/am-ca.crt",
+						Cert:       "/etc/daos/certs/am-agent.crt",
+						Key:        "/etc/daos/certs/am-agent.key",
+						MinVersion: "1.1",
+					},
+				},
+			},
+			expErr: errors.New(`access_manager_config: tls_config: unsupported min_version "1.1"`),
+		},
+		"access manager tls with http endpoint": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					BaseURL:      "https://am1.example.com",
+					FailoverURLs: []string{"http://am2.example.com"},
+					TLS: AccessManagerTLSConfig{
+						CACert: "/etc/daos/certs/am-ca.crt",
+						Cert:   "/etc/daos/certs/am-agent.crt",
+						Key:    "/etc/daos/certs/am-agent.key",
+					},
+				},
+			},
+			expErr: errors.New(`access_manager_config: endpoint "http://am2.example.com" must be an https URL`),
+		},
+		"access manager tls": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					BaseURL: "https://am1.example.com",
+					TLS: AccessManagerTLSConfig{
+						CACert:     "/etc/daos/certs/am-ca.crt",
+						Cert:       "/etc/daos/certs/am-agent.crt",
+						Key:        "/etc/daos/certs/am-agent.key",
+						ServerName: "am.example.com",
+						MinVersion: "1.3",
+					},
+				},
+			},
+			expCfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					BaseURL: "https://am1.example.com",
+					TLS: AccessManagerTLSConfig{
+						CACert:     "/etc/daos/certs/am-ca.crt",
+						Cert:       "/etc/daos/certs/am-agent.crt",
+						Key:        "/etc/daos/certs/am-agent.key",
+						ServerName: "am.example.com",
+						MinVersion: "1.3",
+					},
+				},
+			},
+		},
+		"access manager negative max attempts": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					BaseURL:     "https://am1.example.com",
+					MaxAttempts: -1,
+				},
+			},
+			expErr: errors.New("access_manager_config: max_attempts must not be negative"),
+		},
+		"access manager backoff cap below backoff": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					BaseURL:         "https://am1.example.com",
+					RetryBackoff:    time.Second,
+					RetryBackoffCap: time.Millisecond,
+				},
+			},
+			expErr: errors.New("access_manager_config: retry_backoff_cap must not be less than retry_backoff"),
+		},
+		"access manager audit": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					BaseURL: "https://am1.example.com",
+					Audit: AccessManagerAuditConfig{
+						LogFile:   "/var/log/daos_agent_am_audit.log",
+						Redaction: AMAuditRedactNone,
+					},
+				},
+			},
+			expCfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					BaseURL: "https://am1.example.com",
+					Audit: AccessManagerAuditConfig{
+						LogFile:   "/var/log/daos_agent_am_audit.log",
+						Redaction: AMAuditRedactNone,
+					},
+				},
+			},
+		},
+		"access manager audit invalid redaction": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					BaseURL: "https://am1.example.com",
+					Audit: AccessManagerAuditConfig{
+						LogFile:   "/var/log/daos_agent_am_audit.log",
+						Redaction: "partial",
+					},
+				},
+			},
+			expErr: errors.New(`access_manager_config: audit: unsupported redaction "partial"`),
+		},
+		"access manager audit redaction without log file": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					BaseURL: "https://am1.example.com",
+					Audit:   AccessManagerAuditConfig{Redaction: AMAuditRedactFull},
+				},
+			},
+			expErr: errors.New("access_manager_config: audit: log_file must be set to use redaction"),
+		},
+		"access manager grpc transport": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					Transport:    AMTransportGRPC,
+					BaseURL:      "am1.example.com:443",
+					FailoverURLs: []string{"am2.example.com:443"},
+				},
+			},
+			expCfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					Transport:    AMTransportGRPC,
+					BaseURL:      "am1.example.com:443",
+					FailoverURLs: []string{"am2.example.com:443"},
+				},
+			},
+		},
+		"access manager grpc transport invalid endpoint": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					Transport: AMTransportGRPC,
+					BaseURL:   "https://am1.example.com",
+				},
+			},
+			expErr: errors.New(`access_manager_config: invalid endpoint "https://am1.example.com"`),
+		},
+		"access manager unix transport": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					Transport: AMTransportUnix,
+					BaseURL:   "unix:///var/run/am.sock",
+				},
+			},
+			expCfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					Transport: AMTransportUnix,
+					BaseURL:   "unix:///var/run/am.sock",
+				},
+			},
+		},
+		"access manager unix transport relative path": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					Transport: AMTransportUnix,
+					BaseURL:   "unix://am.sock",
+				},
+			},
+			expErr: errors.New(`access_manager_config: invalid endpoint "unix://am.sock"`),
+		},
+		"access manager unix transport with tls": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					Transport: AMTransportUnix,
+					BaseURL:   "unix:///var/run/am.sock",
+					TLS: AccessManagerTLSConfig{
+						CACert: "ca.crt",
+						Cert:   "agent.crt",
+						Key:    "agent.key",
+					},
+				},
+			},
+			expErr: errors.New(`access_manager_config: tls_config cannot be used with the "unix" transport`),
+		},
+		"access manager unsupported transport": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					Transport: "soap",
+					BaseURL:   "https://am1.example.com",
+				},
+			},
+			expErr: errors.New(`access_manager_config: unsupported transport "soap"`),
+		},
+		"cache spool": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheSpoolDir:   "/var/spool/daos_agent",
+			},
+			expCfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheSpoolDir:   "/var/spool/daos_agent",
+			},
+		},
+		"cache spool without cache": {
+			cfg: &CredentialConfig{
+				CacheSpoolDir: "/var/spool/daos_agent",
+			},
+			expErr: errors.New("cache_expiration must be set to use cache_spool_dir"),
+		},
+		"relative cache spool": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheSpoolDir:   "spool",
+			},
+			expErr: errors.New(`cache_spool_dir "spool" must be an absolute path`),
+		},
+		"credential lifetimes": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				Lifetime:        time.Hour,
+				FlavorLifetimes: map[string]time.Duration{"AUTH_SYS": 10 * time.Minute},
+			},
+			expCfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				Lifetime:        time.Hour,
+				FlavorLifetimes: map[string]time.Duration{"AUTH_SYS": 10 * time.Minute},
+			},
+		},
+		"negative lifetime": {
+			cfg: &CredentialConfig{
+				Lifetime: -time.Hour,
+			},
+			expErr: errors.New("lifetime must not be negative"),
+		},
+		"lifetime within cache expiration": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Hour,
+				Lifetime:        time.Hour,
+			},
+			expErr: errors.New("lifetime must be greater than cache_expiration"),
+		},
+		"flavor lifetime within cache expiration": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Hour,
+				FlavorLifetimes: map[string]time.Duration{"AUTH_SYS": time.Minute},
+			},
+			expErr: errors.New("flavor_lifetimes: AUTH_SYS must be greater than cache_expiration"),
+		},
+		"relative presigned credentials": {
+			cfg: &CredentialConfig{
+				PresignedCredentials: "presigned.json",
+			},
+			expErr: errors.New(`presigned_credentials "presigned.json" must be an absolute path`),
+		},
+		"cache refresh ahead": {
+			cfg: &CredentialConfig{
+				CacheExpiration:   time.Minute,
+				CacheRefreshAhead: 10 * time.Second,
+			},
+			expCfg: &CredentialConfig{
+				CacheExpiration:   time.Minute,
+				CacheRefreshAhead: 10 * time.Second,
+			},
+		},
+		"cache jitter": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheJitter:     10 * time.Second,
+			},
+			expCfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheJitter:     10 * time.Second,
+			},
+		},
+		"cache jitter exceeds expiration": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheJitter:     time.Minute,
+			},
+			expErr: errors.New("cache_jitter must be less than cache_expiration"),
+		},
+		"negative cache jitter": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheJitter:     -time.Second,
+			},
+			expErr: errors.New("cache_jitter must not be negative"),
+		},
+		"cache refresh ahead exceeds expiration": {
+			cfg: &CredentialConfig{
+				CacheExpiration:   time.Minute,
+				CacheRefreshAhead: time.Minute,
+			},
+			expErr: errors.New("cache_refresh_ahead must be less than cache_expiration"),
+		},
+		"negative cache refresh ahead": {
+			cfg: &CredentialConfig{
+				CacheExpiration:   time.Minute,
+				CacheRefreshAhead: -time.Second,
+			},
+			expErr: errors.New("cache_refresh_ahead must not be negative"),
+		},
+		"cache max entries": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheMaxEntries: 1000,
+			},
+			expCfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheMaxEntries: 1000,
+			},
+		},
+		"cache max entries without cache": {
+			cfg: &CredentialConfig{
+				CacheMaxEntries: 1000,
+			},
+			expErr: errors.New("cache_expiration must be set to use cache_max_entries"),
+		},
+		"negative cache max entries": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheMaxEntries: -1,
+			},
+			expErr: errors.New("cache_max_entries must not be negative"),
+		},
+		"cache max per user": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheMaxPerUser: 8,
+			},
+			expCfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheMaxPerUser: 8,
+			},
+		},
+		"cache max per user without cache": {
+			cfg: &CredentialConfig{
+				CacheMaxPerUser: 8,
+			},
+			expErr: errors.New("cache_expiration must be set to use cache_max_per_user"),
+		},
+		"negative cache max per user": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheMaxPerUser: -1,
+			},
+			expErr: errors.New("cache_max_per_user must not be negative"),
+		},
+		"cache max mem": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheMaxMem:     64 << 20,
+			},
+			expCfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheMaxMem:     64 << 20,
+			},
+		},
+		"cache max mem without cache": {
+			cfg: &CredentialConfig{
+				CacheMaxMem: 64 << 20,
+			},
+			expErr: errors.New("cache_expiration must be set to use cache_max_mem"),
+		},
+		"negative cache": {
+			cfg: &CredentialConfig{
+				NegativeCache: NegativeCacheConfig{
+					TTL: 5 * time.Second,
+					StatusTTLs: map[string]time.Duration{
+						CredentialFailureUnavailable: time.Second,
+						CredentialFailureTimeout:     0,
+					},
+				},
+			},
+			expCfg: &CredentialConfig{
+				NegativeCache: NegativeCacheConfig{
+					TTL: 5 * time.Second,
+					StatusTTLs: map[string]time.Duration{
+						CredentialFailureUnavailable: time.Second,
+						CredentialFailureTimeout:     0,
+					},
+				},
+			},
+		},
+		"negative cache negative ttl": {
+			cfg: &CredentialConfig{
+				NegativeCache: NegativeCacheConfig{TTL: -time.Second},
+			},
+			expErr: errors.New("negative_cache: ttl must not be negative"),
+		},
+		"negative cache unknown status": {
+			cfg: &CredentialConfig{
+				NegativeCache: NegativeCacheConfig{
+					StatusTTLs: map[string]time.Duration{"denied": time.Second},
+				},
+			},
+			expErr: errors.New(`negative_cache: status_ttls: unknown status "denied"`),
+		},
+		"negative cache negative status ttl": {
+			cfg: &CredentialConfig{
+				NegativeCache: NegativeCacheConfig{
+					StatusTTLs: map[string]time.Duration{CredentialFailureRejected: -time.Second},
+				},
+			},
+			expErr: errors.New(`negative_cache: status_ttls: ttl for "rejected" must not be negative`),
+		},
+		"cache pinned users": {
+			cfg: &CredentialConfig{
+				CacheExpiration:  time.Minute,
+				CachePinnedUsers: []string{"daos_mover", "1001"},
+			},
+			expCfg: &CredentialConfig{
+				CacheExpiration:  time.Minute,
+				CachePinnedUsers: []string{"daos_mover", "1001"},
+			},
+		},
+		"cache pinned users without cache": {
+			cfg: &CredentialConfig{
+				CachePinnedUsers: []string{"daos_mover"},
+			},
+			expErr: errors.New("cache_expiration must be set to use cache_pinned_users"),
+		},
+		"cache pinned users empty name": {
+			cfg: &CredentialConfig{
+				CacheExpiration:  time.Minute,
+				CachePinnedUsers: []string{"daos_mover", " "},
+			},
+			expErr: errors.New("cache_pinned_users must not contain empty names"),
+		},
+		"cache warmup": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheWarmup: []CacheWarmupEntry{
+					{User: "daos_mover"},
+					{User: "1001", Flavor: "machine"},
+				},
+			},
+			expCfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheWarmup: []CacheWarmupEntry{
+					{User: "daos_mover"},
+					{User: "1001", Flavor: "machine"},
+				},
+			},
+		},
+		"cache warmup without cache": {
+			cfg: &CredentialConfig{
+				CacheWarmup: []CacheWarmupEntry{{User: "daos_mover"}},
+			},
+			expErr: errors.New("cache_expiration must be set to use cache_warmup"),
+		},
+		"cache warmup without user": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheWarmup:     []CacheWarmupEntry{{User: "daos_mover"}, {Flavor: "sys"}},
+			},
+			expErr: errors.New("cache_warmup: entry 1 has no user"),
+		},
+		"valid flavors ttl": {
+			cfg: &CredentialConfig{
+				ValidFlavorsTTL: 5 * time.Minute,
+			},
+			expCfg: &CredentialConfig{
+				ValidFlavorsTTL: 5 * time.Minute,
+			},
+		},
+		"negative valid flavors ttl": {
+			cfg: &CredentialConfig{
+				ValidFlavorsTTL: -time.Minute,
+			},
+			expErr: errors.New("valid_flavors_ttl must not be negative"),
+		},
+		"cache reap interval": {
+			cfg: &CredentialConfig{
+				CacheExpiration:   time.Minute,
+				CacheReapInterval: 5 * time.Minute,
+			},
+			expCfg: &CredentialConfig{
+				CacheExpiration:   time.Minute,
+				CacheReapInterval: 5 * time.Minute,
+			},
+		},
+		"cache reap interval without cache": {
+			cfg: &CredentialConfig{
+				CacheReapInterval: 5 * time.Minute,
+			},
+			expErr: errors.New("cache_expiration must be set to use cache_reap_interval"),
+		},
+		"negative cache reap interval": {
+			cfg: &CredentialConfig{
+				CacheExpiration:   time.Minute,
+				CacheReapInterval: -time.Minute,
+			},
+			expErr: errors.New("cache_reap_interval must not be negative"),
+		},
+		"cache event log": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheEventLog:   true,
+			},
+			expCfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheEventLog:   true,
+			},
+		},
+		"cache event log without cache": {
+			cfg: &CredentialConfig{
+				CacheEventLog: true,
+			},
+			expErr: errors.New("cache_expiration must be set to use cache_event_log"),
+		},
+		"group watch": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				GroupWatch: GroupWatchConfig{
+					Paths:        []string{"/etc/group"},
+					PollInterval: 5 * time.Second,
+				},
+			},
+			expCfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				GroupWatch: GroupWatchConfig{
+					Paths:        []string{"/etc/group"},
+					PollInterval: 5 * time.Second,
+				},
+			},
+		},
+		"group watch without cache": {
+			cfg: &CredentialConfig{
+				GroupWatch: GroupWatchConfig{Paths: []string{"/etc/group"}},
+			},
+			expErr: errors.New("cache_expiration must be set to use group_watch"),
+		},
+		"group watch relative path": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				GroupWatch:      GroupWatchConfig{Paths: []string{"etc/group"}},
+			},
+			expErr: errors.New(`group_watch: paths: "etc/group" must be an absolute path`),
+		},
+		"group watch negative poll interval": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				GroupWatch: GroupWatchConfig{
+					Paths:        []string{"/etc/group"},
+					PollInterval: -time.Second,
+				},
+			},
+			expErr: errors.New("group_watch: poll_interval must not be negative"),
+		},
+		"cache encryption with tpm": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheEncryption: CacheEncryptionConfig{
+					Enabled:   true,
+					KeySource: CacheKeyTPM,
+					TPMHandle: "0x81000001",
+				},
+			},
+			expCfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheEncryption: CacheEncryptionConfig{
+					Enabled:   true,
+					KeySource: CacheKeyTPM,
+					TPMHandle: "0x81000001",
+				},
+			},
+		},
+		"cache encryption without cache": {
+			cfg: &CredentialConfig{
+				CacheEncryption: CacheEncryptionConfig{Enabled: true},
+			},
+			expErr: errors.New("cache_expiration must be set to use cache_encryption"),
+		},
+		"cache encryption tpm without handle": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheEncryption: CacheEncryptionConfig{
+					Enabled:   true,
+					KeySource: CacheKeyTPM,
+				},
+			},
+			expErr: errors.New(`cache_encryption: tpm_handle is required for key_source "tpm"`),
+		},
+		"cache encryption handle without tpm": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheEncryption: CacheEncryptionConfig{
+					Enabled:   true,
+					TPMHandle: "0x81000001",
+				},
+			},
+			expErr: errors.New(`cache_encryption: tpm_handle requires key_source "tpm"`),
+		},
+		"cache encryption unknown key source": {
+			cfg: &CredentialConfig{
+				CacheExpiration: time.Minute,
+				CacheEncryption: CacheEncryptionConfig{
+					Enabled:   true,
+					KeySource: "hsm",
+				},
+			},
+			expErr: errors.New(`cache_encryption: unknown key_source "hsm"`),
+		},
+		"access manager invalid failover url": {
+			cfg: &CredentialConfig{
+				AMConfig: AccessManagerConfig{
+					BaseURL:      "https://am1.example.com",
+					FailoverURLs: []string{"am2.example.com"},
+				},
+			},
+			expErr: errors.New(`access_manager_config: invalid endpoint "am2.example.com"`),
+		},
+		"relative flavor plugin path": {
+			cfg: &CredentialConfig{
+				FlavorPlugins: []string{"vendor_flavor.so"},
+			},
+			expErr: errors.New("flavor_plugins: \"vendor_flavor.so\" is not an absolute path"),
+		},
+		"unknown case fold policy": {
+			cfg: &CredentialConfig{
+				PrincipalCaseFold: "upper",
+			},
+			expErr: errors.New("principal_case_fold"),
+		},
+		"unknown self-test policy": {
+			cfg: &CredentialConfig{
+				SelfTestPolicy: "ignore",
+			},
+			expErr: errors.New("self_test_policy"),
+		},
+		"negative backend probe interval": {
+			cfg: &CredentialConfig{
+				BackendProbeInterval: -time.Minute,
+			},
+			expErr: errors.New("backend_probe_interval must not be negative"),
+		},
+		"signing key reload interval": {
+			cfg: &CredentialConfig{
+				SigningKeyReloadInterval: time.Minute,
+			},
+			expCfg: &CredentialConfig{
+				SigningKeyReloadInterval: time.Minute,
+			},
+		},
+		"negative signing key reload interval": {
+			cfg: &CredentialConfig{
+				SigningKeyReloadInterval: -time.Minute,
+			},
+			expErr: errors.New("signing_key_reload_interval must not be negative"),
+		},
+		"verifier hash": {
+			cfg: &CredentialConfig{
+				VerifierHash: "sha384",
+			},
+			expCfg: &CredentialConfig{
+				VerifierHash: "sha384",
+			},
+		},
+		"negative signing workers": {
+			cfg: &CredentialConfig{
+				SigningWorkers: -1,
+			},
+			expErr: errors.New("signing_workers must not be negative"),
+		},
+		"negative key usage log interval": {
+			cfg: &CredentialConfig{
+				KeyUsage: KeyUsageConfig{LogInterval: -time.Minute},
+			},
+			expErr: errors.New("key_usage: log_interval must not be negative"),
+		},
+		"key usage change factor too small": {
+			cfg: &CredentialConfig{
+				KeyUsage: KeyUsageConfig{LogInterval: time.Hour, ChangeFactor: 0.5},
+			},
+			expErr: errors.New("key_usage: change_factor must be greater than 1"),
+		},
+		"unknown verifier hash": {
+			cfg: &CredentialConfig{
+				VerifierHash: "md5",
+			},
+			expErr: errors.New("unknown hash algorithm"),
+		},
+		"identity map keys normalized": {
+			cfg: &CredentialConfig{
+				PrincipalCaseFold: CaseFoldLower,
+				GCPConfig: GCPConfig{
+					Audiences: []string{"daos"},
+					IdentityMap: ExternalIdentityMap{
+						"Svc@Example.com": {User: "svc"},
+					},
+				},
+			},
+			expCfg: &CredentialConfig{
+				PrincipalCaseFold: CaseFoldLower,
+				GCPConfig: GCPConfig{
+					Audiences: []string{"daos"},
+					IdentityMap: ExternalIdentityMap{
+						"svc@example.com": {User: "svc"},
+					},
+				},
+			},
+		},
+		"ambiguous identity map": {
+			cfg: &CredentialConfig{
+				PrincipalCaseFold: CaseFoldLower,
+				AzureConfig: AzureConfig{
+					TenantID:  "tenant",
+					Audiences: []string{"api://daos"},
+					IdentityMap: ExternalIdentityMap{
+						"ABC": {User: "one"},
+						"abc": {User: "two"},
+					},
+				},
+			},
+			expErr: errors.New("azure_config: identity_map"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expCfg, tc.cfg); diff != "" {
+				t.Fatalf("(want-, got+)\n %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto/tls"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CosignedIdentities selects the privileged credentials which must be signed
+// by a co-signing key as well as by the agent's key: those of the users, of
+// members of the groups, and, if Machine is set, machine credentials. Users
+// and groups are given by name, which is qualified as a local principal (e.g.
+// "<name>@") unless it is already domain-qualified.
+type CosignedIdentities struct {
+	Users   []string `yaml:"users,omitempty"`
+	Groups  []string `yaml:"groups,omitempty"`
+	Machine bool     `yaml:"machine,omitempty"`
+}
+
+// IsSet returns true if any credentials must be co-signed.
+func (ci *CosignedIdentities) IsSet() bool {
+	return ci != nil && (len(ci.Users) > 0 || len(ci.Groups) > 0 || ci.Machine)
+}
+
+// CosigningConfig configures the co-signing service, run by a security
+// officer, which the agent asks to co-sign privileged credentials. The service
+// is reached over HTTPS, authenticated by the agent's client certificate.
+type CosigningConfig struct {
+	URL                string        `yaml:"url,omitempty"`
+	CACert             string        `yaml:"ca_cert,omitempty"`
+	Cert               string        `yaml:"cert,omitempty"`
+	Key                string        `yaml:"key,omitempty"`
+	Timeout            time.Duration `yaml:"timeout,omitempty"`
+	CosignedIdentities `yaml:",inline"`
+}
+
+// Validate checks the co-signing configuration if it has been set.
+func (cc *CosigningConfig) Validate() error {
+	if cc == nil || (cc.URL == "" && !cc.IsSet()) {
+		return nil
+	}
+
+	if !cc.IsSet() {
+		return errors.New("users, groups or machine must be set")
+	}
+	u, err := url.Parse(cc.URL)
+	if err != nil {
+		return errors.Wrap(err, "url")
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return errors.New("url must be an https URL")
+	}
+	if cc.CACert == "" || cc.Cert == "" || cc.Key == "" {
+		return errors.New("ca_cert, cert and key must be set")
+	}
+	if cc.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	return nil
+}
+
+// TLSConfig loads the certificates and returns the TLS configuration used to
+// connect to the co-signing service.
+func (cc *CosigningConfig) TLSConfig() (*tls.Config, error) {
+	certificate, certPool, err := loadCertWithCustomCA(cc.CACert, cc.Cert, cc.Key, MaxUserOnlyKeyPerm, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{*certificate},
+		RootCAs:      certPool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// CosignerConfig configures the servers to require the signature of one of
+// the co-signing keys, whose certificates are given, on privileged credentials.
+type CosignerConfig struct {
+	CosignerCerts      []string `yaml:"cosigner_certs,omitempty"`
+	CosignedIdentities `yaml:",inline"`
+}
+
+// Validate checks the co-signer configuration.
+func (cc *CosignerConfig) Validate() error {
+	if cc == nil {
+		return nil
+	}
+	if !cc.IsSet() {
+		return errors.New("users, groups or machine must be set")
+	}
+	if len(cc.CosignerCerts) == 0 {
+		return errors.New("cosigner_certs must be set")
+	}
+	for _, path := range cc.CosignerCerts {
+		if !filepath.IsAbs(path) {
+			return errors.Errorf("cosigner_certs: %q must be an absolute path", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestSecurity_CosigningConfig_Validate(t *testing.T) {
+	validCfg := func() *CosigningConfig {
+		return &CosigningConfig{
+			URL:                "https://cosign.example.com/cosign",
+			CACert:             "/etc/daos/certs/cosignCA.crt",
+			Cert:               "/etc/daos/certs/agent.crt",
+			Key:                "/etc/daos/certs/agent.key",
+			CosignedIdentities: CosignedIdentities{Users: []string{"root"}},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		cfg    *CosigningConfig
+		expErr error
+	}{
+		"nil": {},
+		"unset": {
+			cfg: &CosigningConfig{},
+		},
+		"valid": {
+			cfg: validCfg(),
+		},
+		"nothing co-signed": {
+			cfg: func() *CosigningConfig {
+				cfg := validCfg()
+				cfg.Users = nil
+				return cfg
+			}(),
+			expErr: errors.New("users, groups or machine must be set"),
+		},
+		"http url": {
+			cfg: func() *CosigningConfig {
+				cfg := validCfg()
+				cfg.URL = "http://cosign.example.com/cosign"
+				return cfg
+			}(),
+			expErr: errors.New("url must be an https URL"),
+		},
+		"missing cert": {
+			cfg: func() *CosigningConfig {
+				cfg := validCfg()
+				cfg.Cert = ""
+				return cfg
+			}(),
+			expErr: errors.New("ca_cert, cert and key must be set"),
+		},
+		"negative timeout": {
+			cfg: func() *CosigningConfig {
+				cfg := validCfg()
+				cfg.Timeout = -1
+				return cfg
+			}(),
+			expErr: errors.New("timeout must not be negative"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.cfg.Validate())
+		})
+	}
+}
+
+func TestSecurity_CosignerConfig_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg    *CosignerConfig
+		expErr error
+	}{
+		"nil": {},
+		"valid": {
+			cfg: &CosignerConfig{
+				CosignerCerts:      []string{"/etc/daos/certs/officer.crt"},
+				CosignedIdentities: CosignedIdentities{Groups: []string{"admins"}, Machine: true},
+			},
+		},
+		"nothing co-signed": {
+			cfg: &CosignerConfig{
+				CosignerCerts: []string{"/etc/daos/certs/officer.crt"},
+			},
+			expErr: errors.New("users, groups or machine must be set"),
+		},
+		"no certs": {
+			cfg: &CosignerConfig{
+				CosignedIdentities: CosignedIdentities{Machine: true},
+			},
+			expErr: errors.New("cosigner_certs must be set"),
+		},
+		"relative cert": {
+			cfg: &CosignerConfig{
+				CosignerCerts:      []string{"officer.crt"},
+				CosignedIdentities: CosignedIdentities{Machine: true},
+			},
+			expErr: errors.New(`cosigner_certs: "officer.crt" must be an absolute path`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.cfg.Validate())
+		})
+	}
+}
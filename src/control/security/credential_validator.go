@@ -0,0 +1,50 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultCredentialValidatorTimeout is the time within which a credential
+// validator must accept a credential, unless another is configured.
+const DefaultCredentialValidatorTimeout = 5 * time.Second
+
+// CredentialValidatorConfig enables a credential validator compiled into the
+// server, which checks each credential the server's own checks accept before
+// it is accepted, e.g. cross-checking its job against the scheduler. The
+// options are passed to the validator as they are.
+type CredentialValidatorConfig struct {
+	Name    string            `yaml:"name"`
+	Timeout time.Duration     `yaml:"timeout,omitempty"`
+	Options map[string]string `yaml:"options,omitempty"`
+}
+
+// Validate checks the credential validator configuration.
+func (cvc *CredentialValidatorConfig) Validate() error {
+	if cvc == nil {
+		return errors.New("nil credential validator config")
+	}
+	if cvc.Name == "" {
+		return errors.New("name must be set")
+	}
+	if cvc.Timeout < 0 {
+		return errors.Errorf("validator %q: timeout must not be negative", cvc.Name)
+	}
+	return nil
+}
+
+// ValidatorTimeout returns the time within which the validator must accept a
+// credential.
+func (cvc *CredentialValidatorConfig) ValidatorTimeout() time.Duration {
+	if cvc.Timeout == 0 {
+		return DefaultCredentialValidatorTimeout
+	}
+	return cvc.Timeout
+}
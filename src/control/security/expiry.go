@@ -0,0 +1,64 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultCredentialClockSkew is the difference between the clocks of the
+// agents and the servers allowed when checking the validity period and the
+// nonce of a credential, if none is configured.
+const DefaultCredentialClockSkew = time.Minute
+
+// CredentialClockSkew returns the clock skew allowed when checking the
+// validity period and the nonce of a credential, given the skew configured, if
+// any.
+func CredentialClockSkew(skew time.Duration) time.Duration {
+	if skew == 0 {
+		return DefaultCredentialClockSkew
+	}
+	return skew
+}
+
+// CredentialExpiryConfig configures how the servers enforce the validity
+// period which agents record in credentials. Expired credentials, and those
+// which are not yet valid, are always rejected. If Required is set, so are
+// credentials which don't expire, e.g. those signed by agents which predate
+// credential expiry or which have no lifetime configured. If MaxLifetime is
+// set, so are credentials valid for longer than that.
+type CredentialExpiryConfig struct {
+	Required    bool          `yaml:"required,omitempty"`
+	ClockSkew   time.Duration `yaml:"clock_skew,omitempty"`
+	MaxLifetime time.Duration `yaml:"max_lifetime,omitempty"`
+}
+
+// Validate checks the credential expiry configuration.
+func (cec *CredentialExpiryConfig) Validate() error {
+	if cec == nil {
+		return nil
+	}
+	if cec.ClockSkew < 0 {
+		return errors.New("clock_skew must not be negative")
+	}
+	if cec.MaxLifetime < 0 {
+		return errors.New("max_lifetime must not be negative")
+	}
+	return nil
+}
+
+// Skew returns the clock skew allowed when checking the validity period of a
+// credential: that configured for it, if any, or otherwise the skew allowed
+// when checking any part of a credential.
+func (cec *CredentialExpiryConfig) Skew(skew time.Duration) time.Duration {
+	if cec == nil || cec.ClockSkew == 0 {
+		return skew
+	}
+	return cec.ClockSkew
+}
@@ -0,0 +1,58 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestSecurity_CredentialExpiryConfig(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg     *CredentialExpiryConfig
+		expErr  error
+		expSkew time.Duration
+	}{
+		"nil": {
+			expSkew: 2 * time.Minute,
+		},
+		"default skew": {
+			cfg:     &CredentialExpiryConfig{Required: true},
+			expSkew: 2 * time.Minute,
+		},
+		"custom skew": {
+			cfg:     &CredentialExpiryConfig{ClockSkew: 5 * time.Second, MaxLifetime: time.Hour},
+			expSkew: 5 * time.Second,
+		},
+		"negative skew": {
+			cfg:    &CredentialExpiryConfig{ClockSkew: -time.Second},
+			expErr: errors.New("clock_skew must not be negative"),
+		},
+		"negative max lifetime": {
+			cfg:    &CredentialExpiryConfig{MaxLifetime: -time.Hour},
+			expErr: errors.New("max_lifetime must not be negative"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertEqual(t, tc.expSkew, tc.cfg.Skew(2*time.Minute), "unexpected skew")
+		})
+	}
+}
+
+func TestSecurity_CredentialClockSkew(t *testing.T) {
+	test.AssertEqual(t, DefaultCredentialClockSkew, CredentialClockSkew(0), "unexpected default skew")
+	test.AssertEqual(t, 5*time.Second, CredentialClockSkew(5*time.Second), "unexpected skew")
+}
@@ -113,6 +113,14 @@ func TestSecurity_ComponentHasAccess(t *testing.T) {
 		"/mgmt.MgmtSvc/SystemGetAttr":            {ComponentAdmin},
 		"/mgmt.MgmtSvc/SystemSetProp":            {ComponentAdmin},
 		"/mgmt.MgmtSvc/SystemGetProp":            {ComponentAdmin},
+		"/mgmt.MgmtSvc/SignAgentCert":            {ComponentAgent},
+		"/mgmt.MgmtSvc/GetCredentialNonce":       {ComponentAgent},
+		"/mgmt.MgmtSvc/SystemRevokeCredentials":  {ComponentAdmin},
+		"/mgmt.MgmtSvc/GetCredentialRevocations": {ComponentAdmin, ComponentServer},
+		"/mgmt.MgmtSvc/SystemEnrollAgent":        {ComponentAdmin},
+		"/mgmt.MgmtSvc/GetAgentEnrollments":      {ComponentAdmin, ComponentServer},
+		"/mgmt.MgmtSvc/SystemUpdateAuthFlavors":  {ComponentAdmin},
+		"/mgmt.MgmtSvc/GetAuthFlavors":           {ComponentAdmin, ComponentServer},
 		"/RaftTransport/AppendEntries":           {ComponentServer},
 		"/RaftTransport/AppendEntriesPipeline":   {ComponentServer},
 		"/RaftTransport/RequestVote":             {ComponentServer},
@@ -29,10 +29,10 @@ import (
 // validate the certificate chain.
 
 func serverTLSConfig(cfg *TransportConfig) *tls.Config {
-	return &tls.Config{
+	tlsConfig := &tls.Config{
 		ClientAuth:               tls.RequireAndVerifyClientCert,
 		Certificates:             []tls.Certificate{*cfg.tlsKeypair},
-		ClientCAs:                cfg.caPool,
+		ClientCAs:                cfg.trustedCAs(),
 		MinVersion:               tls.VersionTLS12,
 		MaxVersion:               tls.VersionTLS12,
 		PreferServerCipherSuites: true,
@@ -41,7 +41,7 @@ func serverTLSConfig(cfg *TransportConfig) *tls.Config {
 		},
 		VerifyConnection: func(cs tls.ConnectionState) error {
 			opts := x509.VerifyOptions{
-				Roots:         cfg.caPool,
+				Roots:         cfg.trustedCAs(),
 				Intermediates: x509.NewCertPool(),
 				KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
 			}
@@ -52,6 +52,18 @@ func serverTLSConfig(cfg *TransportConfig) *tls.Config {
 			return err
 		},
 	}
+
+	// If the CA bundle can be reloaded, each handshake verifies the client
+	// with the CA certificates trusted at the time.
+	if cfg.caBundle != nil {
+		tlsConfig.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			clientConfig := tlsConfig.Clone()
+			clientConfig.GetConfigForClient = nil
+			clientConfig.ClientCAs = cfg.trustedCAs()
+			return clientConfig, nil
+		}
+	}
+	return tlsConfig
 }
 
 const ServerCommonName = "server"
@@ -59,7 +71,7 @@ const ServerCommonName = "server"
 func clientTLSConfig(cfg *TransportConfig) *tls.Config {
 	return &tls.Config{
 		Certificates:             []tls.Certificate{*cfg.tlsKeypair},
-		RootCAs:                  cfg.caPool,
+		RootCAs:                  cfg.trustedCAs(),
 		MinVersion:               tls.VersionTLS12,
 		MaxVersion:               tls.VersionTLS12,
 		PreferServerCipherSuites: true,
@@ -80,7 +92,7 @@ func clientTLSConfig(cfg *TransportConfig) *tls.Config {
 		// communicating with a DAOS server.
 		VerifyConnection: func(cs tls.ConnectionState) error {
 			opts := x509.VerifyOptions{
-				Roots:         cfg.caPool,
+				Roots:         cfg.trustedCAs(),
 				Intermediates: x509.NewCertPool(),
 			}
 			for _, cert := range cs.PeerCertificates[1:] {
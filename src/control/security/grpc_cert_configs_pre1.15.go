@@ -15,7 +15,7 @@ func serverTLSConfig(cfg *TransportConfig) *tls.Config {
 	return &tls.Config{
 		ClientAuth:               tls.RequireAndVerifyClientCert,
 		Certificates:             []tls.Certificate{*cfg.tlsKeypair},
-		ClientCAs:                cfg.caPool,
+		ClientCAs:                cfg.trustedCAs(),
 		MinVersion:               tls.VersionTLS12,
 		MaxVersion:               tls.VersionTLS12,
 		PreferServerCipherSuites: true,
@@ -29,7 +29,7 @@ func clientTLSConfig(cfg *TransportConfig) *tls.Config {
 	return &tls.Config{
 		ServerName:               cfg.ServerName,
 		Certificates:             []tls.Certificate{*cfg.tlsKeypair},
-		RootCAs:                  cfg.caPool,
+		RootCAs:                  cfg.trustedCAs(),
 		MinVersion:               tls.VersionTLS12,
 		MaxVersion:               tls.VersionTLS12,
 		PreferServerCipherSuites: true,
@@ -0,0 +1,12 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//go:build go1.24
+// +build go1.24
+
+package security
+
+// SHA-3 is only part of the standard library from Go 1.24. Builds with older
+// toolchains report the SHA-3 verifier hashes as unavailable.
+import _ "crypto/sha3"
@@ -0,0 +1,105 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// IdentityGroupMappings gives the DAOS groups of the users with the identities
+// matching each pattern. An identity is written as in ACL entries, e.g.
+// "oidc:*@idp.example" or "spiffe:example.org/ns/prod/*", and its name may
+// contain the wildcards of path.Match, so "*" doesn't match "/".
+type IdentityGroupMappings map[string][]string
+
+// Keys returns the identities in sorted order.
+func (igm IdentityGroupMappings) Keys() []string {
+	keys := make([]string, 0, len(igm))
+	for key := range igm {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// Validate checks the identity group mappings.
+func (igm IdentityGroupMappings) Validate() error {
+	for _, identity := range igm.Keys() {
+		groups := igm[identity]
+		idType, pattern, found := strings.Cut(identity, ":")
+		if !found || idType == "" || pattern == "" {
+			return errors.Errorf("invalid identity %q (must be <type>:<name>)", identity)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Wrapf(err, "identity %q", identity)
+		}
+		if len(groups) == 0 {
+			return errors.Errorf("identity %q: no groups", identity)
+		}
+		for _, group := range groups {
+			if group == "" || strings.ContainsAny(group, ":@ \t\n") {
+				return errors.Errorf("identity %q: invalid group %q", identity, group)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseIdentityGroupMappings parses and checks the contents of a mapping file,
+// which are in the format of the mappings in the configuration.
+func ParseIdentityGroupMappings(data []byte) (IdentityGroupMappings, error) {
+	var mappings IdentityGroupMappings
+	if err := yaml.UnmarshalStrict(data, &mappings); err != nil {
+		return nil, errors.Wrap(err, "parsing identity group mappings")
+	}
+	if err := mappings.Validate(); err != nil {
+		return nil, err
+	}
+	return mappings, nil
+}
+
+// IdentityGroupConfig maps the identities verified by external identity
+// providers to DAOS groups, so that ACL entries for groups apply to users
+// authenticated by those providers. The mappings in the file are used in
+// addition to those in the configuration, and the file may be changed while
+// the server is running.
+type IdentityGroupConfig struct {
+	MappingFile string                `yaml:"mapping_file,omitempty"`
+	Mappings    IdentityGroupMappings `yaml:"mappings,omitempty"`
+}
+
+// Validate checks the identity group configuration.
+func (igc *IdentityGroupConfig) Validate() error {
+	if igc == nil {
+		return nil
+	}
+	if igc.MappingFile == "" && len(igc.Mappings) == 0 {
+		return errors.New("mapping_file or mappings must be set")
+	}
+	return igc.Mappings.Validate()
+}
+
+// ReadMappingFile returns the contents of the mapping file, which must not be
+// writable by users other than its owner.
+func (igc *IdentityGroupConfig) ReadMappingFile() ([]byte, error) {
+	fi, err := os.Stat(igc.MappingFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "mapping_file")
+	}
+	if fi.Mode().Perm()&0o022 != 0 {
+		return nil, errors.Errorf("mapping_file %s must not be writable by group or others (mode %s)",
+			igc.MappingFile, fi.Mode().Perm())
+	}
+	return os.ReadFile(igc.MappingFile)
+}
@@ -0,0 +1,124 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestSecurity_IdentityGroupConfig_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg    *IdentityGroupConfig
+		expErr error
+	}{
+		"nil": {},
+		"empty": {
+			cfg:    &IdentityGroupConfig{},
+			expErr: errors.New("mapping_file or mappings must be set"),
+		},
+		"mapping file": {
+			cfg: &IdentityGroupConfig{MappingFile: "/etc/daos/identity_groups.yml"},
+		},
+		"mappings": {
+			cfg: &IdentityGroupConfig{
+				Mappings: IdentityGroupMappings{
+					"oidc:*@idp.example":           {"hpc"},
+					"spiffe:example.org/ns/prod/*": {"prod", "hpc"},
+				},
+			},
+		},
+		"no type": {
+			cfg: &IdentityGroupConfig{
+				Mappings: IdentityGroupMappings{"alice@idp.example": {"hpc"}},
+			},
+			expErr: errors.New("must be <type>:<name>"),
+		},
+		"bad pattern": {
+			cfg: &IdentityGroupConfig{
+				Mappings: IdentityGroupMappings{"oidc:[alice": {"hpc"}},
+			},
+			expErr: errors.New(`identity "oidc:[alice"`),
+		},
+		"no groups": {
+			cfg: &IdentityGroupConfig{
+				Mappings: IdentityGroupMappings{"oidc:alice@idp.example": {}},
+			},
+			expErr: errors.New("no groups"),
+		},
+		"group with domain": {
+			cfg: &IdentityGroupConfig{
+				Mappings: IdentityGroupMappings{"oidc:alice@idp.example": {"hpc@"}},
+			},
+			expErr: errors.New(`invalid group "hpc@"`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.cfg.Validate())
+		})
+	}
+}
+
+func TestSecurity_IdentityGroupConfig_ReadMappingFile(t *testing.T) {
+	dir, cleanup := test.CreateTestDir(t)
+	defer cleanup()
+
+	for name, tc := range map[string]struct {
+		content     string
+		mode        os.FileMode
+		expMappings IdentityGroupMappings
+		expErr      error
+	}{
+		"mappings": {
+			content: "\"oidc:*@idp.example\": [hpc]\n\"krb5:*@EXAMPLE.COM\": [staff, hpc]\n",
+			mode:    0o644,
+			expMappings: IdentityGroupMappings{
+				"oidc:*@idp.example": {"hpc"},
+				"krb5:*@EXAMPLE.COM": {"staff", "hpc"},
+			},
+		},
+		"writable by others": {
+			content: "\"oidc:*@idp.example\": [hpc]\n",
+			mode:    0o666,
+			expErr:  errors.New("must not be writable by group or others"),
+		},
+		"not a map": {
+			content: "- oidc:*@idp.example\n",
+			mode:    0o600,
+			expErr:  errors.New("parsing identity group mappings"),
+		},
+		"invalid mapping": {
+			content: "\"oidc:*@idp.example\": []\n",
+			mode:    0o600,
+			expErr:  errors.New("no groups"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(tc.content), tc.mode); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chmod(path, tc.mode); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := &IdentityGroupConfig{MappingFile: path}
+			data, err := cfg.ReadMappingFile()
+			var mappings IdentityGroupMappings
+			if err == nil {
+				mappings, err = ParseIdentityGroupMappings(data)
+			}
+			test.CmpErr(t, tc.expErr, err)
+			test.CmpAny(t, "mappings", tc.expMappings, mappings)
+		})
+	}
+}
@@ -0,0 +1,218 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/daos-stack/daos/src/control/lib/pkcs8"
+)
+
+const (
+	// credentialsDirEnv is set by systemd to the directory holding the
+	// credentials passed to the service with LoadCredential= or
+	// SetCredentialEncrypted=.
+	credentialsDirEnv = "CREDENTIALS_DIRECTORY"
+	// DefaultKeyPassphraseTimeout is the default time allowed for a key
+	// passphrase command to complete.
+	DefaultKeyPassphraseTimeout = 10 * time.Second
+	promptDevice                = "/dev/tty"
+)
+
+// promptedPassphrases caches the passphrases entered at the prompt, by key
+// file, so that reloading a key doesn't prompt again.
+var promptedPassphrases sync.Map
+
+// KeyPassphraseConfig configures how the passphrase of an encrypted key file
+// is obtained: from the systemd Credential of the service, from the output of
+// Command, or by prompting on the terminal. Exactly one must be set. The key
+// file must be a PKCS#8 key encrypted with PBES2, using scrypt or PBKDF2 and
+// AES-CBC, as written by "openssl pkcs8 -topk8 -scrypt".
+type KeyPassphraseConfig struct {
+	Credential string        `yaml:"credential,omitempty"`
+	Command    string        `yaml:"command,omitempty"`
+	Args       []string      `yaml:"args,omitempty"`
+	Timeout    time.Duration `yaml:"timeout,omitempty"`
+	Prompt     bool          `yaml:"prompt,omitempty"`
+}
+
+// Validate checks the key passphrase configuration.
+func (cfg *KeyPassphraseConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	sources := 0
+	for _, set := range []bool{cfg.Credential != "", cfg.Command != "", cfg.Prompt} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return errors.New("exactly one of credential, command and prompt must be set")
+	}
+	if cfg.Credential != "" && (strings.ContainsRune(cfg.Credential, '/') || cfg.Credential == "." || cfg.Credential == "..") {
+		return errors.Errorf("invalid credential name %q", cfg.Credential)
+	}
+	if cfg.Command != "" && !filepath.IsAbs(cfg.Command) {
+		return errors.New("command must be an absolute path")
+	}
+	if len(cfg.Args) > 0 && cfg.Command == "" {
+		return errors.New("args requires command")
+	}
+	if cfg.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	return nil
+}
+
+// Passphrase returns the passphrase of the key file.
+func (cfg *KeyPassphraseConfig) Passphrase(keyPath string) ([]byte, error) {
+	switch {
+	case cfg.Credential != "":
+		return readCredential(cfg.Credential)
+	case cfg.Command != "":
+		return cfg.runCommand()
+	case cfg.Prompt:
+		if cached, found := promptedPassphrases.Load(keyPath); found {
+			return cached.([]byte), nil
+		}
+		passphrase, err := promptPassphrase(fmt.Sprintf("Enter passphrase for %s: ", keyPath))
+		if err != nil {
+			return nil, err
+		}
+		promptedPassphrases.Store(keyPath, passphrase)
+		return passphrase, nil
+	default:
+		return nil, errors.New("no key passphrase source configured")
+	}
+}
+
+// readCredential reads the systemd credential of the service.
+func readCredential(name string) ([]byte, error) {
+	dir := os.Getenv(credentialsDirEnv)
+	if dir == "" {
+		return nil, errors.Errorf("%s is not set; the credential %q must be passed with LoadCredential= "+
+			"or SetCredentialEncrypted= in the service unit", credentialsDirEnv, name)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading credential %q", name)
+	}
+	return trimPassphrase(data)
+}
+
+func (cfg *KeyPassphraseConfig) runCommand() ([]byte, error) {
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultKeyPassphraseTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, cfg.Command, cfg.Args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.Wrapf(err, "running %s: %s", cfg.Command, msg)
+		}
+		return nil, errors.Wrapf(err, "running %s", cfg.Command)
+	}
+	return trimPassphrase(out)
+}
+
+// promptPassphrase prompts for the passphrase on the controlling terminal,
+// without echoing it.
+func promptPassphrase(prompt string) ([]byte, error) {
+	tty, err := os.OpenFile(promptDevice, os.O_RDWR, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening terminal to prompt for the key passphrase")
+	}
+	defer tty.Close()
+
+	fd := int(tty.Fd())
+	termios, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading terminal settings")
+	}
+	noEcho := *termios
+	noEcho.Lflag &^= unix.ECHO
+	noEcho.Lflag |= unix.ICANON | unix.ISIG
+	if err := unix.IoctlSetTermios(fd, unix.TCSETS, &noEcho); err != nil {
+		return nil, errors.Wrap(err, "disabling terminal echo")
+	}
+	defer func() {
+		_ = unix.IoctlSetTermios(fd, unix.TCSETS, termios)
+		fmt.Fprintln(tty)
+	}()
+
+	if _, err := fmt.Fprint(tty, prompt); err != nil {
+		return nil, err
+	}
+	line, err := bufio.NewReader(tty).ReadBytes('\n')
+	if err != nil {
+		return nil, errors.Wrap(err, "reading key passphrase")
+	}
+	return trimPassphrase(line)
+}
+
+// trimPassphrase removes the trailing newline of a passphrase read from a
+// file, command or terminal.
+func trimPassphrase(data []byte) ([]byte, error) {
+	data = bytes.TrimSuffix(data, []byte("\n"))
+	data = bytes.TrimSuffix(data, []byte("\r"))
+	if len(data) == 0 {
+		return nil, errors.New("empty key passphrase")
+	}
+	return data, nil
+}
+
+// decryptKeyPEM returns the key PEM decrypted with the configured passphrase,
+// if it is encrypted. An encrypted key requires a passphrase, and a passphrase
+// requires an encrypted key, so that a key left unencrypted by mistake isn't
+// silently accepted.
+func decryptKeyPEM(keyPath string, keyPEM []byte, cfg *KeyPassphraseConfig) ([]byte, error) {
+	block, _ := pem.Decode(keyPEM)
+	encrypted := block != nil && block.Type == pkcs8.PEMType
+	switch {
+	case !encrypted && cfg == nil:
+		return keyPEM, nil
+	case !encrypted:
+		return nil, errors.Errorf("key_passphrase is set, but %s is not an encrypted PKCS#8 key", keyPath)
+	case cfg == nil:
+		return nil, errors.Errorf("%s is encrypted, but no key_passphrase is configured for it", keyPath)
+	}
+
+	passphrase, err := cfg.Passphrase(keyPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "obtaining passphrase for %s", keyPath)
+	}
+	der, err := pkcs8.DecryptPrivateKey(block.Bytes, passphrase)
+	if err != nil {
+		if errors.Is(err, pkcs8.ErrIncorrectPassphrase) && cfg.Prompt {
+			promptedPassphrases.Delete(keyPath)
+		}
+		return nil, errors.Wrapf(err, "decrypting %s", keyPath)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
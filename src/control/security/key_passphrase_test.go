@@ -0,0 +1,208 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/pkcs8"
+)
+
+func TestSecurity_KeyPassphraseConfig_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg    *KeyPassphraseConfig
+		expErr error
+	}{
+		"nil": {},
+		"credential": {
+			cfg: &KeyPassphraseConfig{Credential: "daos_agent.key"},
+		},
+		"command": {
+			cfg: &KeyPassphraseConfig{Command: "/usr/bin/pass", Args: []string{"show", "daos"}},
+		},
+		"prompt": {
+			cfg: &KeyPassphraseConfig{Prompt: true},
+		},
+		"none": {
+			cfg:    &KeyPassphraseConfig{},
+			expErr: errors.New("exactly one of credential, command and prompt"),
+		},
+		"several": {
+			cfg:    &KeyPassphraseConfig{Credential: "daos_agent.key", Prompt: true},
+			expErr: errors.New("exactly one of credential, command and prompt"),
+		},
+		"credential path": {
+			cfg:    &KeyPassphraseConfig{Credential: "../daos_agent.key"},
+			expErr: errors.New("invalid credential name"),
+		},
+		"relative command": {
+			cfg:    &KeyPassphraseConfig{Command: "pass"},
+			expErr: errors.New("command must be an absolute path"),
+		},
+		"args without command": {
+			cfg:    &KeyPassphraseConfig{Prompt: true, Args: []string{"show"}},
+			expErr: errors.New("args requires command"),
+		},
+		"negative timeout": {
+			cfg:    &KeyPassphraseConfig{Command: "/usr/bin/pass", Timeout: -1},
+			expErr: errors.New("timeout must not be negative"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.cfg.Validate())
+		})
+	}
+}
+
+func TestSecurity_KeyPassphraseConfig_Passphrase(t *testing.T) {
+	credDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(credDir, "daos_agent.key"), []byte("from-systemd\n"), 0400); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		cfg           *KeyPassphraseConfig
+		credDir       string
+		expPassphrase string
+		expErr        error
+	}{
+		"credential": {
+			cfg:           &KeyPassphraseConfig{Credential: "daos_agent.key"},
+			credDir:       credDir,
+			expPassphrase: "from-systemd",
+		},
+		"credential outside of systemd": {
+			cfg:    &KeyPassphraseConfig{Credential: "daos_agent.key"},
+			expErr: errors.New("CREDENTIALS_DIRECTORY is not set"),
+		},
+		"missing credential": {
+			cfg:     &KeyPassphraseConfig{Credential: "other.key"},
+			credDir: credDir,
+			expErr:  errors.New(`reading credential "other.key"`),
+		},
+		"command": {
+			cfg:           &KeyPassphraseConfig{Command: "/bin/echo", Args: []string{"from-command"}},
+			expPassphrase: "from-command",
+		},
+		"command fails": {
+			cfg:    &KeyPassphraseConfig{Command: "/bin/sh", Args: []string{"-c", "echo vault sealed >&2; exit 1"}},
+			expErr: errors.New("running /bin/sh: vault sealed: exit status 1"),
+		},
+		"empty passphrase": {
+			cfg:    &KeyPassphraseConfig{Command: "/bin/echo"},
+			expErr: errors.New("empty key passphrase"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(credentialsDirEnv, tc.credDir)
+
+			passphrase, err := tc.cfg.Passphrase("agent.key")
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.expPassphrase, string(passphrase), "unexpected passphrase")
+		})
+	}
+}
+
+func TestSecurity_PreLoadCertData_encryptedKey(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey, caPEM := testCertificate(t, "CA", nil, nil)
+	_, key, certPEM := testCertificate(t, "agent", ca, caKey)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptedDER, err := pkcs8.EncryptPrivateKey(rand.Reader, keyDER, []byte("secret"), pkcs8.KDFScrypt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caPath := filepath.Join(dir, "daosCA.crt")
+	certPath := filepath.Join(dir, "agent.crt")
+	plainKeyPath := filepath.Join(dir, "plain.key")
+	encryptedKeyPath := filepath.Join(dir, "encrypted.key")
+	for path, data := range map[string][]byte{
+		caPath:           caPEM,
+		certPath:         certPEM,
+		plainKeyPath:     pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+		encryptedKeyPath: pem.EncodeToMemory(&pem.Block{Type: pkcs8.PEMType, Bytes: encryptedDER}),
+	} {
+		if err := os.WriteFile(path, data, MaxUserOnlyKeyPerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		keyPath    string
+		passphrase *KeyPassphraseConfig
+		tpmKey     *TPMKeyConfig
+		expErr     error
+	}{
+		"encrypted key": {
+			keyPath:    encryptedKeyPath,
+			passphrase: &KeyPassphraseConfig{Command: "/bin/echo", Args: []string{"secret"}},
+		},
+		"incorrect passphrase": {
+			keyPath:    encryptedKeyPath,
+			passphrase: &KeyPassphraseConfig{Command: "/bin/echo", Args: []string{"guess"}},
+			expErr:     pkcs8.ErrIncorrectPassphrase,
+		},
+		"encrypted key without passphrase": {
+			keyPath: encryptedKeyPath,
+			expErr:  errors.New("no key_passphrase is configured"),
+		},
+		"plaintext key with passphrase": {
+			keyPath:    plainKeyPath,
+			passphrase: &KeyPassphraseConfig{Command: "/bin/echo", Args: []string{"secret"}},
+			expErr:     errors.New("is not an encrypted PKCS#8 key"),
+		},
+		"plaintext key": {
+			keyPath: plainKeyPath,
+		},
+		"invalid passphrase config": {
+			keyPath:    encryptedKeyPath,
+			passphrase: &KeyPassphraseConfig{},
+			expErr:     errors.New("key_passphrase: exactly one of"),
+		},
+		"passphrase with tpm key": {
+			passphrase: &KeyPassphraseConfig{Prompt: true},
+			tpmKey:     &TPMKeyConfig{Handle: "0x81010002"},
+			expErr:     errors.New("key_passphrase can't be used with"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := &TransportConfig{
+				CertificateConfig: CertificateConfig{
+					CARootPath:      caPath,
+					CertificatePath: certPath,
+					PrivateKeyPath:  tc.keyPath,
+					KeyPassphrase:   tc.passphrase,
+					TPMKey:          tc.tpmKey,
+					maxKeyPerms:     MaxUserOnlyKeyPerm,
+				},
+			}
+			err := cfg.PreLoadCertData()
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertTrue(t, key.PublicKey.Equal(cfg.tlsKeypair.Leaf.PublicKey), "unexpected certificate")
+		})
+	}
+}
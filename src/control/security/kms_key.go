@@ -0,0 +1,134 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/kms"
+)
+
+// KMSBackend selects the key management service holding a KMS key.
+type KMSBackend string
+
+const (
+	// KMSVaultTransit signs with a key in the transit engine of HashiCorp
+	// Vault.
+	KMSVaultTransit KMSBackend = "vault-transit"
+	// KMSAWS signs with an asymmetric key in AWS KMS.
+	KMSAWS KMSBackend = "aws-kms"
+)
+
+// KMSKeyConfig identifies a private key held by an external key management
+// service, which performs the signature operations, to be used in place of the
+// key file. For the vault-transit backend, Address is the Vault server and
+// KeyName the transit key, and the Vault token is read from TokenFile (or
+// VAULT_TOKEN). For the aws-kms backend, KeyName is the key ID, ARN or alias
+// in Region, Address optionally overrides the regional endpoint, and the AWS
+// credentials are taken from the environment.
+type KMSKeyConfig struct {
+	Backend   KMSBackend    `yaml:"backend"`
+	Address   string        `yaml:"address,omitempty"`
+	KeyName   string        `yaml:"key_name"`
+	Mount     string        `yaml:"mount,omitempty"`
+	Namespace string        `yaml:"namespace,omitempty"`
+	TokenFile string        `yaml:"token_file,omitempty"`
+	Region    string        `yaml:"region,omitempty"`
+	CACert    string        `yaml:"ca_cert,omitempty"`
+	Timeout   time.Duration `yaml:"timeout,omitempty"`
+}
+
+// Validate checks the KMS key configuration.
+func (cfg *KMSKeyConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.KeyName == "" {
+		return errors.New("key_name is required")
+	}
+	if cfg.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+
+	switch cfg.Backend {
+	case KMSVaultTransit:
+		if cfg.Address == "" {
+			return errors.Errorf("address is required for backend %q", cfg.Backend)
+		}
+		if cfg.Region != "" {
+			return errors.Errorf("region is not used by backend %q", cfg.Backend)
+		}
+		if cfg.TokenFile != "" && !filepath.IsAbs(cfg.TokenFile) {
+			return errors.Errorf("token_file %q must be an absolute path", cfg.TokenFile)
+		}
+	case KMSAWS:
+		if cfg.Region == "" {
+			return errors.Errorf("region is required for backend %q", cfg.Backend)
+		}
+		if cfg.Mount != "" || cfg.Namespace != "" || cfg.TokenFile != "" {
+			return errors.Errorf("mount, namespace and token_file are not used by backend %q", cfg.Backend)
+		}
+	case "":
+		return errors.New("backend is required")
+	default:
+		return errors.Errorf("unknown backend %q (must be %q or %q)", cfg.Backend, KMSVaultTransit, KMSAWS)
+	}
+	return nil
+}
+
+// openKMSKey returns a signer for the configured KMS key, whose public half is
+// pub.
+func openKMSKey(cfg *KMSKeyConfig, pub crypto.PublicKey) (crypto.Signer, error) {
+	switch cfg.Backend {
+	case KMSVaultTransit:
+		if cfg.TokenFile != "" {
+			// The token file is read again for each signature, so
+			// that the token may be renewed, but its permissions are
+			// checked up front.
+			if _, err := LoadPEMData(cfg.TokenFile, MaxUserOnlyKeyPerm); err != nil {
+				return nil, errors.Wrap(err, "reading Vault token file")
+			}
+		}
+		return kms.NewVaultTransitSigner(kms.VaultTransitConfig{
+			Address:   cfg.Address,
+			Mount:     cfg.Mount,
+			KeyName:   cfg.KeyName,
+			Namespace: cfg.Namespace,
+			TokenFile: cfg.TokenFile,
+			CACert:    cfg.CACert,
+			Timeout:   cfg.Timeout,
+		}, pub)
+	case KMSAWS:
+		return kms.NewAWSKMSSigner(kms.AWSKMSConfig{
+			Region:   cfg.Region,
+			KeyID:    cfg.KeyName,
+			Endpoint: cfg.Address,
+			CACert:   cfg.CACert,
+			Timeout:  cfg.Timeout,
+		}, pub)
+	default:
+		return nil, errors.Errorf("unknown backend %q", cfg.Backend)
+	}
+}
+
+// loadCertWithKMSKey loads the certificate, with its private key held by a key
+// management service.
+func loadCertWithKMSKey(caRootPath, certPath string, cfg *KMSKeyConfig) (*tls.Certificate, *x509.CertPool, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, errors.Wrap(err, "kms_key")
+	}
+
+	return loadCertWithSigner(caRootPath, certPath, "KMS", func(pub crypto.PublicKey) (crypto.Signer, error) {
+		return openKMSKey(cfg, pub)
+	})
+}
@@ -0,0 +1,272 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestSecurity_KMSKeyConfig_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg    *KMSKeyConfig
+		expErr error
+	}{
+		"nil": {},
+		"vault transit": {
+			cfg: &KMSKeyConfig{
+				Backend:   KMSVaultTransit,
+				Address:   "https://vault.example.com:8200",
+				KeyName:   "daos-agent",
+				Mount:     "daos-transit",
+				TokenFile: "/etc/daos/certs/vault-token",
+			},
+		},
+		"aws kms": {
+			cfg: &KMSKeyConfig{
+				Backend: KMSAWS,
+				Region:  "us-east-1",
+				KeyName: "alias/daos-agent",
+				Timeout: 5 * time.Second,
+			},
+		},
+		"no backend": {
+			cfg: &KMSKeyConfig{
+				KeyName: "daos-agent",
+			},
+			expErr: errors.New("backend is required"),
+		},
+		"unknown backend": {
+			cfg: &KMSKeyConfig{
+				Backend: "gcp-kms",
+				KeyName: "daos-agent",
+			},
+			expErr: errors.New(`unknown backend "gcp-kms"`),
+		},
+		"no key name": {
+			cfg: &KMSKeyConfig{
+				Backend: KMSAWS,
+				Region:  "us-east-1",
+			},
+			expErr: errors.New("key_name is required"),
+		},
+		"negative timeout": {
+			cfg: &KMSKeyConfig{
+				Backend: KMSAWS,
+				Region:  "us-east-1",
+				KeyName: "alias/daos-agent",
+				Timeout: -time.Second,
+			},
+			expErr: errors.New("timeout must not be negative"),
+		},
+		"vault without address": {
+			cfg: &KMSKeyConfig{
+				Backend: KMSVaultTransit,
+				KeyName: "daos-agent",
+			},
+			expErr: errors.New("address is required"),
+		},
+		"vault with region": {
+			cfg: &KMSKeyConfig{
+				Backend: KMSVaultTransit,
+				Address: "https://vault.example.com:8200",
+				KeyName: "daos-agent",
+				Region:  "us-east-1",
+			},
+			expErr: errors.New("region is not used"),
+		},
+		"vault relative token file": {
+			cfg: &KMSKeyConfig{
+				Backend:   KMSVaultTransit,
+				Address:   "https://vault.example.com:8200",
+				KeyName:   "daos-agent",
+				TokenFile: "vault-token",
+			},
+			expErr: errors.New("must be an absolute path"),
+		},
+		"aws without region": {
+			cfg: &KMSKeyConfig{
+				Backend: KMSAWS,
+				KeyName: "alias/daos-agent",
+			},
+			expErr: errors.New("region is required"),
+		},
+		"aws with token file": {
+			cfg: &KMSKeyConfig{
+				Backend:   KMSAWS,
+				Region:    "us-east-1",
+				KeyName:   "alias/daos-agent",
+				TokenFile: "/etc/daos/certs/vault-token",
+			},
+			expErr: errors.New("not used by backend"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.cfg.Validate())
+		})
+	}
+}
+
+// testVaultTransit emulates the sign endpoint of the Vault transit engine for
+// RSA keys.
+func testVaultTransit(t *testing.T, keys map[string]crypto.Signer) *httptest.Server {
+	hashes := map[string]crypto.Hash{
+		"sha2-256": crypto.SHA256,
+		"sha2-384": crypto.SHA384,
+		"sha2-512": crypto.SHA512,
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+			return
+		}
+		var name string
+		if _, err := fmt.Sscanf(r.URL.Path, "/v1/transit/sign/%s", &name); err != nil || keys[name] == nil {
+			http.Error(w, `{"errors":["signing key not found"]}`, http.StatusBadRequest)
+			return
+		}
+
+		var req struct {
+			Input         []byte `json:"input"`
+			HashAlgorithm string `json:"hash_algorithm"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		hash := hashes[req.HashAlgorithm]
+		sig, err := keys[name].Sign(rand.Reader, req.Input, &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+			Hash:       hash,
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]string{
+				"signature": "vault:v1:" + base64.StdEncoding.EncodeToString(sig),
+			},
+		})
+	}))
+}
+
+func TestSecurity_loadCertWithKMSKey(t *testing.T) {
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	caPath := copyTestCertFile(t, tmpDir, "daosCA.crt", MaxCertPerm)
+	certPath := copyTestCertFile(t, tmpDir, "agent.crt", MaxCertPerm)
+	agentKey, err := LoadPrivateKey(copyTestCertFile(t, tmpDir, "agent.key", MaxUserOnlyKeyPerm))
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverKey, err := LoadPrivateKey(copyTestCertFile(t, tmpDir, "server.key", MaxUserOnlyKeyPerm))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenPath := writeTestSecretFile(t, tmpDir, "vault-token", "s.token\n")
+	badPermsTokenPath := writeTestSecretFile(t, tmpDir, "open-token", "s.token\n")
+	if err := os.Chmod(badPermsTokenPath, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := testVaultTransit(t, map[string]crypto.Signer{
+		"agent":  agentKey.(crypto.Signer),
+		"server": serverKey.(crypto.Signer),
+	})
+	defer srv.Close()
+
+	vaultCfg := func(keyName, tokenFile string) *KMSKeyConfig {
+		return &KMSKeyConfig{
+			Backend:   KMSVaultTransit,
+			Address:   srv.URL,
+			KeyName:   keyName,
+			TokenFile: tokenFile,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		cfg       *KMSKeyConfig
+		pkcs11Cfg *PKCS11KeyConfig
+		expErr    error
+	}{
+		"success": {
+			cfg: vaultCfg("agent", tokenPath),
+		},
+		"invalid config": {
+			cfg:    &KMSKeyConfig{Backend: KMSVaultTransit},
+			expErr: errors.New("kms_key: key_name is required"),
+		},
+		"both pkcs11 and kms": {
+			cfg:       vaultCfg("agent", tokenPath),
+			pkcs11Cfg: &PKCS11KeyConfig{},
+			expErr:    errors.New("only one of pkcs11_key, kms_key and tpm_key may be set"),
+		},
+		"token file readable by others": {
+			cfg:    vaultCfg("agent", badPermsTokenPath),
+			expErr: errors.New("reading Vault token file"),
+		},
+		"missing token file": {
+			cfg:    vaultCfg("agent", filepath.Join(tmpDir, "nope")),
+			expErr: errors.New("reading Vault token file"),
+		},
+		"unknown key": {
+			cfg:    vaultCfg("other", tokenPath),
+			expErr: errors.New("signing key not found"),
+		},
+		"key does not match cert": {
+			cfg:    vaultCfg("server", tokenPath),
+			expErr: errors.New("KMS key does not match"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := &TransportConfig{
+				CertificateConfig: CertificateConfig{
+					CARootPath:      caPath,
+					CertificatePath: certPath,
+					PKCS11Key:       tc.pkcs11Cfg,
+					KMSKey:          tc.cfg,
+				},
+			}
+			setValidVerifyTime(t, cfg)
+			err := cfg.PreLoadCertData()
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			key, err := cfg.PrivateKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			signer := DefaultTokenSigner()
+			sig, err := signer.Sign(key, []byte("message"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := signer.Verify(agentKey.(crypto.Signer).Public(), []byte("message"), sig); err != nil {
+				t.Fatalf("expected signature to verify: %s", err)
+			}
+		})
+	}
+}
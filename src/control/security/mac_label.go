@@ -0,0 +1,51 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+var macLabelNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// MACLabelConfig names the MAC labels of client processes (SELinux contexts or
+// AppArmor profiles, as recorded by the agent) which match the pattern, so that
+// pools and containers can be restricted to processes with those labels by
+// ACL entries for the group "label=<name>@claim". The pattern must match the
+// whole label.
+type MACLabelConfig struct {
+	Name    string `yaml:"name"`
+	Pattern string `yaml:"pattern"`
+}
+
+// Validate checks the MAC label configuration.
+func (mlc *MACLabelConfig) Validate() error {
+	if mlc == nil {
+		return errors.New("nil MAC label config")
+	}
+	if !macLabelNamePattern.MatchString(mlc.Name) {
+		return errors.Errorf("invalid name %q", mlc.Name)
+	}
+	if _, err := mlc.Regexp(); err != nil {
+		return errors.Wrapf(err, "label %q", mlc.Name)
+	}
+	return nil
+}
+
+// Regexp compiles the pattern, anchored to match whole labels.
+func (mlc *MACLabelConfig) Regexp() (*regexp.Regexp, error) {
+	if mlc.Pattern == "" {
+		return nil, errors.New("pattern must be set")
+	}
+	re, err := regexp.Compile(`^(?:` + mlc.Pattern + `)$`)
+	if err != nil {
+		return nil, errors.Wrap(err, "pattern")
+	}
+	return re, nil
+}
@@ -0,0 +1,72 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestSecurity_MACLabelConfig(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg      *MACLabelConfig
+		label    string
+		expMatch bool
+		expErr   error
+	}{
+		"nil": {
+			expErr: errors.New("nil MAC label config"),
+		},
+		"no name": {
+			cfg:    &MACLabelConfig{Pattern: ".*"},
+			expErr: errors.New(`invalid name ""`),
+		},
+		"name with domain": {
+			cfg:    &MACLabelConfig{Name: "secret@claim", Pattern: ".*"},
+			expErr: errors.New("invalid name"),
+		},
+		"no pattern": {
+			cfg:    &MACLabelConfig{Name: "secret"},
+			expErr: errors.New("pattern must be set"),
+		},
+		"bad pattern": {
+			cfg:    &MACLabelConfig{Name: "secret", Pattern: "(s0"},
+			expErr: errors.New(`label "secret": pattern`),
+		},
+		"selinux match": {
+			cfg:      &MACLabelConfig{Name: "secret", Pattern: `[^:]+:[^:]+:[^:]+:s3(:.*)?`},
+			label:    "staff_u:staff_r:staff_t:s3:c0.c255",
+			expMatch: true,
+		},
+		"selinux partial match": {
+			cfg:   &MACLabelConfig{Name: "secret", Pattern: `s3`},
+			label: "staff_u:staff_r:staff_t:s3:c0.c255",
+		},
+		"apparmor match": {
+			cfg:      &MACLabelConfig{Name: "sim", Pattern: `/opt/sim/bin/.* \(enforce\)`},
+			label:    "/opt/sim/bin/solver (enforce)",
+			expMatch: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			re, err := tc.cfg.Regexp()
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expMatch, re.MatchString(tc.label), "unexpected match")
+		})
+	}
+}
@@ -0,0 +1,56 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultCredentialNonceLifetime is how long the servers accept a nonce after
+// issuing it, if no lifetime is configured.
+const DefaultCredentialNonceLifetime = 10 * time.Minute
+
+// CredentialNonceConfig configures the nonces which the servers issue to
+// agents, and which agents record in the credentials they hand out, one in
+// each. A server accepts each nonce only once, and only until it expires,
+// whatever validity period the agent recorded in the credential. Nonces are
+// authenticated with the secret in SecretFile, which must be the same on every
+// server so that a nonce issued by one is accepted by all. If Required is set,
+// credentials signed without a nonce are rejected.
+type CredentialNonceConfig struct {
+	SecretFile string        `yaml:"secret_file,omitempty"`
+	Lifetime   time.Duration `yaml:"lifetime,omitempty"`
+	Required   bool          `yaml:"required,omitempty"`
+}
+
+// Validate checks the credential nonce configuration.
+func (cnc *CredentialNonceConfig) Validate() error {
+	if cnc == nil {
+		return nil
+	}
+	if cnc.SecretFile == "" {
+		return errors.New("secret_file must be set")
+	}
+	if !filepath.IsAbs(cnc.SecretFile) {
+		return errors.Errorf("secret_file %q must be an absolute path", cnc.SecretFile)
+	}
+	if cnc.Lifetime < 0 {
+		return errors.New("lifetime must not be negative")
+	}
+	return nil
+}
+
+// NonceLifetime returns how long the servers accept a nonce after issuing it.
+func (cnc *CredentialNonceConfig) NonceLifetime() time.Duration {
+	if cnc == nil || cnc.Lifetime == 0 {
+		return DefaultCredentialNonceLifetime
+	}
+	return cnc.Lifetime
+}
@@ -0,0 +1,57 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestSecurity_CredentialNonceConfig(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg         *CredentialNonceConfig
+		expErr      error
+		expLifetime time.Duration
+	}{
+		"nil": {
+			expLifetime: DefaultCredentialNonceLifetime,
+		},
+		"default lifetime": {
+			cfg:         &CredentialNonceConfig{SecretFile: "/etc/daos/nonce.key"},
+			expLifetime: DefaultCredentialNonceLifetime,
+		},
+		"custom lifetime": {
+			cfg:         &CredentialNonceConfig{SecretFile: "/etc/daos/nonce.key", Lifetime: time.Minute, Required: true},
+			expLifetime: time.Minute,
+		},
+		"no secret": {
+			cfg:    &CredentialNonceConfig{Required: true},
+			expErr: errors.New("secret_file must be set"),
+		},
+		"relative secret": {
+			cfg:    &CredentialNonceConfig{SecretFile: "nonce.key"},
+			expErr: errors.New(`secret_file "nonce.key" must be an absolute path`),
+		},
+		"negative lifetime": {
+			cfg:    &CredentialNonceConfig{SecretFile: "/etc/daos/nonce.key", Lifetime: -time.Minute},
+			expErr: errors.New("lifetime must not be negative"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertEqual(t, tc.expLifetime, tc.cfg.NonceLifetime(), "unexpected lifetime")
+		})
+	}
+}
@@ -8,6 +8,8 @@ package security
 
 import (
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
@@ -53,47 +55,45 @@ func isInvalidCert(err error) bool {
 	return isCertErr
 }
 
-func loadCertWithCustomCA(caRootPath, certPath, keyPath string, maxKeyPerm os.FileMode) (*tls.Certificate, *x509.CertPool, error) {
-	caPEM, err := LoadPEMData(caRootPath, MaxCertPerm)
+// loadCertFile loads the PEM file, returning a fault describing why it could
+// not be loaded.
+func loadCertFile(path string, perms os.FileMode, desc string) ([]byte, error) {
+	data, err := LoadPEMData(path, perms)
 	if err != nil {
 		switch {
 		case os.IsNotExist(err):
-			return nil, nil, FaultMissingCertFile(caRootPath)
+			return nil, FaultMissingCertFile(path)
 		case os.IsPermission(err):
-			return nil, nil, FaultUnreadableCertFile(caRootPath)
+			return nil, FaultUnreadableCertFile(path)
 		case isInvalidCert(err):
-			return nil, nil, FaultInvalidCertFile(caRootPath, err)
+			return nil, FaultInvalidCertFile(path, err)
 		default:
-			return nil, nil, errors.Wrapf(err, "could not load caRoot")
+			return nil, errors.Wrapf(err, "could not load %s", desc)
 		}
 	}
+	return data, nil
+}
 
-	certPEM, err := LoadPEMData(certPath, MaxCertPerm)
+// loadCertWithCustomCA loads the certificate and key files, decrypting the key
+// with the passphrase if it is configured.
+func loadCertWithCustomCA(caRootPath, certPath, keyPath string, maxKeyPerm os.FileMode, passphrase *KeyPassphraseConfig) (*tls.Certificate, *x509.CertPool, error) {
+	caPEM, err := loadCertFile(caRootPath, MaxCertPerm, "caRoot")
 	if err != nil {
-		switch {
-		case os.IsNotExist(err):
-			return nil, nil, FaultMissingCertFile(certPath)
-		case os.IsPermission(err):
-			return nil, nil, FaultUnreadableCertFile(certPath)
-		case isInvalidCert(err):
-			return nil, nil, FaultInvalidCertFile(certPath, err)
-		default:
-			return nil, nil, errors.Wrapf(err, "could not load cert")
-		}
+		return nil, nil, err
 	}
 
-	keyPEM, err := LoadPEMData(keyPath, maxKeyPerm)
+	certPEM, err := loadCertFile(certPath, MaxCertPerm, "cert")
 	if err != nil {
-		switch {
-		case os.IsNotExist(err):
-			return nil, nil, FaultMissingCertFile(keyPath)
-		case os.IsPermission(err):
-			return nil, nil, FaultUnreadableCertFile(keyPath)
-		case isInvalidCert(err):
-			return nil, nil, FaultInvalidCertFile(keyPath, err)
-		default:
-			return nil, nil, errors.Wrapf(err, "could not load key")
-		}
+		return nil, nil, err
+	}
+
+	keyPEM, err := loadCertFile(keyPath, maxKeyPerm, "key")
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err = decryptKeyPEM(keyPath, keyPEM, passphrase)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
@@ -158,6 +158,30 @@ func LoadCertificate(certPath string) (*x509.Certificate, error) {
 	return cert, err
 }
 
+// LoadCertificates loads all of the certificates in the file at the given
+// path, in order, such as the current and previous certificates of an agent
+// whose key is being rotated.
+func LoadCertificates(certPath string) ([]*x509.Certificate, error) {
+	pemData, err := LoadPEMData(certPath, MaxCertPerm)
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(pemData); block != nil; block, rest = pem.Decode(rest) {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("%s does not contain PEM data", certPath)
+	}
+	return certs, nil
+}
+
 // LoadPrivateKey loads the private key specified at the given path into an
 // crypto.PrivateKey interface compliant object.
 func LoadPrivateKey(keyPath string) (crypto.PrivateKey, error) {
@@ -193,14 +217,18 @@ func LoadPrivateKey(keyPath string) (crypto.PrivateKey, error) {
 	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
 	if err == nil {
 		switch key := key.(type) {
-		// TODO: Support key types other than RSA
-		case *rsa.PrivateKey:
+		case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
 			return key, nil
 		default:
 			return nil, fmt.Errorf("%s contains an unsupported private key type",
 				keyPath)
 		}
 	}
+
+	// SEC 1 packaging of EC keys
+	if ecKey, ecErr := x509.ParseECPrivateKey(block.Bytes); ecErr == nil {
+		return ecKey, nil
+	}
 	return nil, errors.Wrapf(err, "Invalid key data in PRIVATE KEY block")
 }
 
@@ -63,6 +63,9 @@ func TestLoadPEMData(t *testing.T) {
 
 func TestLoadPrivateKey(t *testing.T) {
 	goodKeyPath := "testdata/certs/daosCA.key"
+	ed25519KeyPath := "testdata/certs/ed25519.key"
+	ecKeyPath := "testdata/certs/ecdsa-p256.key"
+	pkcs8ECKeyPath := "testdata/certs/ecdsa-p384.key"
 	badKeyPerm := "testdata/certs/badperms.key"
 	malformed := "testdata/certs/bad.key"
 	toomany := "testdata/certs/toomanypem.key"
@@ -76,6 +79,11 @@ func TestLoadPrivateKey(t *testing.T) {
 	if err := os.Chmod(goodKeyPath, MaxUserOnlyKeyPerm); err != nil {
 		t.Fatal(err)
 	}
+	for _, path := range []string{ed25519KeyPath, ecKeyPath, pkcs8ECKeyPath} {
+		if err := os.Chmod(path, MaxUserOnlyKeyPerm); err != nil {
+			t.Fatal(err)
+		}
+	}
 	// Intentionallly safe cert perm as it should be incorrect
 	if err := os.Chmod(badKeyPerm, MaxCertPerm); err != nil {
 		t.Fatal(err)
@@ -96,6 +104,9 @@ func TestLoadPrivateKey(t *testing.T) {
 		expected string
 	}{
 		{goodKeyPath, "GoodKey", ""},
+		{ed25519KeyPath, "Ed25519Key", ""},
+		{ecKeyPath, "ECKey", ""},
+		{pkcs8ECKeyPath, "PKCS8ECKey", ""},
 		{badKeyPerm, "ImproperPermissions", badKeyPermError.Error()},
 		{malformed, "Malformed Key", malformedError},
 		{toomany, "InvalidPEMCount", toomanyError},
@@ -163,6 +174,40 @@ func TestLoadCertificate(t *testing.T) {
 		})
 	}
 }
+func TestSecurity_Pem_LoadCertificates(t *testing.T) {
+	malformed := "testdata/certs/bad.crt"
+	for _, path := range []string{"testdata/certs/daosCA.crt", malformed, "testdata/certs/toomanypem.crt"} {
+		if err := os.Chmod(path, MaxCertPerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		path     string
+		expCerts int
+		expErr   error
+	}{
+		"one": {
+			path:     "testdata/certs/daosCA.crt",
+			expCerts: 1,
+		},
+		"bundle": {
+			path:     "testdata/certs/toomanypem.crt",
+			expCerts: 3,
+		},
+		"malformed": {
+			path:   malformed,
+			expErr: errors.New("does not contain PEM data"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			certs, err := LoadCertificates(tc.path)
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expCerts, len(certs), "unexpected number of certificates")
+		})
+	}
+}
+
 func TestSecurity_Pem_ValidateCertDirectory(t *testing.T) {
 	for name, tc := range map[string]struct {
 		perms  fs.FileMode
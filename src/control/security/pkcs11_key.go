@@ -0,0 +1,170 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// PKCS11KeyConfig identifies a private key held on a PKCS#11 token, such as an
+// HSM or softHSM, to be used in place of the key file. The token is selected by
+// its label or its slot, and the key by its label and/or ID (hex-encoded). The
+// user PIN of the token is read from PINFile, which must not be readable by
+// other users.
+type PKCS11KeyConfig struct {
+	Module     string `yaml:"module"`
+	TokenLabel string `yaml:"token_label,omitempty"`
+	Slot       *uint  `yaml:"slot,omitempty"`
+	KeyLabel   string `yaml:"key_label,omitempty"`
+	KeyID      string `yaml:"key_id,omitempty"`
+	PINFile    string `yaml:"pin_file,omitempty"`
+}
+
+// Validate checks the PKCS#11 key configuration.
+func (cfg *PKCS11KeyConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.Module == "" {
+		return errors.New("module is required")
+	}
+	if cfg.TokenLabel == "" && cfg.Slot == nil {
+		return errors.New("token_label or slot is required")
+	}
+	if cfg.TokenLabel != "" && cfg.Slot != nil {
+		return errors.New("token_label and slot may not both be set")
+	}
+	if cfg.KeyLabel == "" && cfg.KeyID == "" {
+		return errors.New("key_label or key_id is required")
+	}
+	if _, err := hex.DecodeString(cfg.KeyID); err != nil {
+		return errors.Errorf("key_id %q is not hex-encoded", cfg.KeyID)
+	}
+	if cfg.PINFile != "" && !filepath.IsAbs(cfg.PINFile) {
+		return errors.Errorf("pin_file %q must be an absolute path", cfg.PINFile)
+	}
+	return nil
+}
+
+// PKCS11KeyOpener opens the configured private key on a PKCS#11 token, whose
+// public half is pub.
+type PKCS11KeyOpener func(cfg *PKCS11KeyConfig, pin string, pub crypto.PublicKey) (crypto.Signer, error)
+
+var (
+	pkcs11KeyOpenerMutex sync.RWMutex
+	pkcs11KeyOpener      PKCS11KeyOpener
+)
+
+// RegisterPKCS11KeyOpener sets the function used to open keys held on PKCS#11
+// tokens. Programs which support such keys register an opener at startup.
+func RegisterPKCS11KeyOpener(opener PKCS11KeyOpener) {
+	pkcs11KeyOpenerMutex.Lock()
+	defer pkcs11KeyOpenerMutex.Unlock()
+
+	pkcs11KeyOpener = opener
+}
+
+func openPKCS11Key(cfg *PKCS11KeyConfig, pin string, pub crypto.PublicKey) (crypto.Signer, error) {
+	pkcs11KeyOpenerMutex.RLock()
+	defer pkcs11KeyOpenerMutex.RUnlock()
+
+	if pkcs11KeyOpener == nil {
+		return nil, errors.New("keys held on PKCS#11 tokens are not supported by this program")
+	}
+	return pkcs11KeyOpener(cfg, pin, pub)
+}
+
+// loadCertWithPKCS11Key loads the certificate, with its private key held on a
+// PKCS#11 token.
+func loadCertWithPKCS11Key(caRootPath, certPath string, cfg *PKCS11KeyConfig) (*tls.Certificate, *x509.CertPool, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, errors.Wrap(err, "pkcs11_key")
+	}
+
+	var pin string
+	if cfg.PINFile != "" {
+		pinData, err := LoadPEMData(cfg.PINFile, MaxUserOnlyKeyPerm)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "reading PKCS#11 PIN file")
+		}
+		pin = strings.TrimSpace(string(pinData))
+	}
+
+	return loadCertWithSigner(caRootPath, certPath, "PKCS#11", func(pub crypto.PublicKey) (crypto.Signer, error) {
+		return openPKCS11Key(cfg, pin, pub)
+	})
+}
+
+// loadCertWithSigner loads the certificate, with its private key held
+// elsewhere and opened as a signer for the certificate's public key.
+func loadCertWithSigner(caRootPath, certPath, desc string, open func(crypto.PublicKey) (crypto.Signer, error)) (*tls.Certificate, *x509.CertPool, error) {
+	caPEM, err := loadCertFile(caRootPath, MaxCertPerm, "caRoot")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM, err := loadCertFile(certPath, MaxCertPerm, "cert")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certificate := &tls.Certificate{}
+	for block, rest := pem.Decode(certPEM); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type == "CERTIFICATE" {
+			certificate.Certificate = append(certificate.Certificate, block.Bytes)
+		}
+	}
+	if len(certificate.Certificate) == 0 {
+		return nil, nil, FaultInvalidCertFile(certPath, errors.New("no certificate found"))
+	}
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	if err != nil {
+		return nil, nil, FaultInvalidCertFile(certPath, err)
+	}
+
+	signer, err := open(leaf.PublicKey)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "opening %s key", desc)
+	}
+	if err := checkSignerMatches(signer, leaf.PublicKey); err != nil {
+		return nil, nil, errors.Wrapf(err, "%s key does not match %s", desc, certPath)
+	}
+	certificate.PrivateKey = signer
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caPEM) {
+		return nil, nil, errors.New("unable to append caRoot to cert pool")
+	}
+
+	return certificate, certPool, nil
+}
+
+// checkSignerMatches checks that signatures made by the signer verify with the
+// public key, as the signer's own idea of its public key can't be relied upon.
+func checkSignerMatches(signer crypto.Signer, pub crypto.PublicKey) error {
+	data := make([]byte, 32)
+	if _, err := rand.Read(data); err != nil {
+		return err
+	}
+
+	tokenSigner := DefaultTokenSigner()
+	sig, err := tokenSigner.Sign(signer, data)
+	if err != nil {
+		return errors.Wrap(err, "test signature failed")
+	}
+	return tokenSigner.Verify(pub, data, sig)
+}
@@ -0,0 +1,273 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+// tokenSigner stands in for a key held on a PKCS#11 token, which is only
+// available as a crypto.Signer.
+type tokenSigner struct {
+	crypto.Signer
+}
+
+// copyTestCertFile copies the file from testdata/certs to the directory, with
+// the permissions required of it.
+func copyTestCertFile(t *testing.T, dir, name string, perms os.FileMode) string {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata/certs", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, perms); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, perms); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// writeTestSecretFile writes a file readable only by the user.
+func writeTestSecretFile(t *testing.T, dir, name, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(contents), MaxUserOnlyKeyPerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, MaxUserOnlyKeyPerm); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSecurity_PKCS11KeyConfig_Validate(t *testing.T) {
+	slot := uint(1)
+
+	for name, tc := range map[string]struct {
+		cfg    *PKCS11KeyConfig
+		expErr error
+	}{
+		"nil": {},
+		"token label and key label": {
+			cfg: &PKCS11KeyConfig{
+				Module:     "/usr/lib64/pkcs11/libsofthsm2.so",
+				TokenLabel: "daos",
+				KeyLabel:   "agent",
+				PINFile:    "/etc/daos/certs/pin",
+			},
+		},
+		"slot and key ID": {
+			cfg: &PKCS11KeyConfig{
+				Module: "/usr/lib64/pkcs11/libsofthsm2.so",
+				Slot:   &slot,
+				KeyID:  "0a0b",
+			},
+		},
+		"no module": {
+			cfg: &PKCS11KeyConfig{
+				TokenLabel: "daos",
+				KeyLabel:   "agent",
+			},
+			expErr: errors.New("module is required"),
+		},
+		"no token": {
+			cfg: &PKCS11KeyConfig{
+				Module:   "/usr/lib64/pkcs11/libsofthsm2.so",
+				KeyLabel: "agent",
+			},
+			expErr: errors.New("token_label or slot is required"),
+		},
+		"token label and slot": {
+			cfg: &PKCS11KeyConfig{
+				Module:     "/usr/lib64/pkcs11/libsofthsm2.so",
+				TokenLabel: "daos",
+				Slot:       &slot,
+				KeyLabel:   "agent",
+			},
+			expErr: errors.New("may not both be set"),
+		},
+		"no key": {
+			cfg: &PKCS11KeyConfig{
+				Module:     "/usr/lib64/pkcs11/libsofthsm2.so",
+				TokenLabel: "daos",
+			},
+			expErr: errors.New("key_label or key_id is required"),
+		},
+		"bad key ID": {
+			cfg: &PKCS11KeyConfig{
+				Module:     "/usr/lib64/pkcs11/libsofthsm2.so",
+				TokenLabel: "daos",
+				KeyID:      "xyz",
+			},
+			expErr: errors.New("not hex-encoded"),
+		},
+		"relative PIN file": {
+			cfg: &PKCS11KeyConfig{
+				Module:     "/usr/lib64/pkcs11/libsofthsm2.so",
+				TokenLabel: "daos",
+				KeyLabel:   "agent",
+				PINFile:    "pin",
+			},
+			expErr: errors.New("must be an absolute path"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.cfg.Validate())
+		})
+	}
+}
+
+func TestSecurity_loadCertWithPKCS11Key(t *testing.T) {
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	caPath := copyTestCertFile(t, tmpDir, "daosCA.crt", MaxCertPerm)
+	certPath := copyTestCertFile(t, tmpDir, "agent.crt", MaxCertPerm)
+	agentKey, err := LoadPrivateKey(copyTestCertFile(t, tmpDir, "agent.key", MaxUserOnlyKeyPerm))
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverKey, err := LoadPrivateKey(copyTestCertFile(t, tmpDir, "server.key", MaxUserOnlyKeyPerm))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinPath := writeTestSecretFile(t, tmpDir, "pin", "1234\n")
+
+	p11Cfg := func(pinFile string) *PKCS11KeyConfig {
+		return &PKCS11KeyConfig{
+			Module:     "/usr/lib64/pkcs11/libsofthsm2.so",
+			TokenLabel: "daos",
+			KeyLabel:   "agent",
+			PINFile:    pinFile,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		cfg      *PKCS11KeyConfig
+		noOpener bool
+		key      crypto.PrivateKey
+		openErr  error
+		expPIN   string
+		expErr   error
+	}{
+		"success": {
+			cfg:    p11Cfg(pinPath),
+			key:    agentKey,
+			expPIN: "1234",
+		},
+		"no PIN file": {
+			cfg: p11Cfg(""),
+			key: agentKey,
+		},
+		"invalid config": {
+			cfg:    &PKCS11KeyConfig{},
+			expErr: errors.New("pkcs11_key: module is required"),
+		},
+		"missing PIN file": {
+			cfg:    p11Cfg(filepath.Join(tmpDir, "nope")),
+			expErr: errors.New("reading PKCS#11 PIN file"),
+		},
+		"not supported": {
+			cfg:      p11Cfg(pinPath),
+			noOpener: true,
+			expErr:   errors.New("not supported by this program"),
+		},
+		"open failed": {
+			cfg:     p11Cfg(pinPath),
+			openErr: errors.New("token not present"),
+			expErr:  errors.New("opening PKCS#11 key: token not present"),
+		},
+		"key does not match cert": {
+			cfg:    p11Cfg(pinPath),
+			key:    serverKey,
+			expErr: errors.New("PKCS#11 key does not match"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var gotPIN string
+			if tc.noOpener {
+				RegisterPKCS11KeyOpener(nil)
+			} else {
+				RegisterPKCS11KeyOpener(func(_ *PKCS11KeyConfig, pin string, _ crypto.PublicKey) (crypto.Signer, error) {
+					gotPIN = pin
+					if tc.openErr != nil {
+						return nil, tc.openErr
+					}
+					return tokenSigner{tc.key.(crypto.Signer)}, nil
+				})
+			}
+			defer RegisterPKCS11KeyOpener(nil)
+
+			cfg := &TransportConfig{
+				CertificateConfig: CertificateConfig{
+					CARootPath:      caPath,
+					CertificatePath: certPath,
+					PKCS11Key:       tc.cfg,
+				},
+			}
+			setValidVerifyTime(t, cfg)
+			err := cfg.PreLoadCertData()
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.expPIN, gotPIN, "unexpected PIN")
+			key, err := cfg.PrivateKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, isTokenSigner := key.(tokenSigner)
+			test.AssertTrue(t, isTokenSigner, "expected the key on the token")
+		})
+	}
+}
+
+func TestSecurity_TokenSigner_Signer(t *testing.T) {
+	rsaKey, _, source := SignTestSetup(t)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, key := range map[string]crypto.Signer{
+		"RSA":     rsaKey.(crypto.Signer),
+		"ECDSA":   ecdsaKey,
+		"Ed25519": ed25519Key,
+	} {
+		t.Run(name, func(t *testing.T) {
+			signer := DefaultTokenSigner()
+			sig, err := signer.Sign(tokenSigner{key}, source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := signer.Verify(key.Public(), source, sig); err != nil {
+				t.Fatalf("expected signature to verify: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/idna"
+	"golang.org/x/text/unicode/norm"
+)
+
+// CaseFoldPolicy defines whether the name portion of a principal asserted by
+// an external identity provider is case-folded during normalization.
+type CaseFoldPolicy string
+
+const (
+	// CaseFoldNone preserves the case of principal names.
+	CaseFoldNone CaseFoldPolicy = "none"
+	// CaseFoldLower folds principal names to lower case, for identity
+	// providers which treat names case-insensitively (e.g. Active Directory).
+	CaseFoldLower CaseFoldPolicy = "lower"
+)
+
+// Validate checks that the policy is known.
+func (p CaseFoldPolicy) Validate() error {
+	switch p {
+	case "", CaseFoldNone, CaseFoldLower:
+		return nil
+	default:
+		return errors.Errorf("unknown case fold policy %q", p)
+	}
+}
+
+// NormalizePrincipal returns the canonical form of a principal name of the
+// form "name[@domain]" so that visually identical identities always map to
+// the same DAOS principal. The whole name is converted to Unicode NFKC form,
+// the name portion is case-folded according to the policy, and the domain is
+// converted to its lower-case ASCII (punycode) form.
+func NormalizePrincipal(principal string, fold CaseFoldPolicy) (string, error) {
+	if !utf8.ValidString(principal) {
+		return "", errors.Errorf("principal %q is not valid UTF-8", principal)
+	}
+
+	principal = norm.NFKC.String(principal)
+	if strings.IndexFunc(principal, unicode.IsControl) >= 0 {
+		return "", errors.Errorf("principal %q contains control characters", principal)
+	}
+
+	name, domain, hasDomain := strings.Cut(principal, "@")
+	if name == "" {
+		return "", errors.New("principal name is empty")
+	}
+	if fold == CaseFoldLower {
+		name = strings.ToLower(name)
+	}
+	if !hasDomain {
+		return name, nil
+	}
+	if domain == "" {
+		return name + "@", nil
+	}
+
+	asciiDomain, err := idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid domain in principal %q", principal)
+	}
+
+	return name + "@" + asciiDomain, nil
+}
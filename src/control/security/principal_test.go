@@ -0,0 +1,125 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestSecurity_NormalizePrincipal(t *testing.T) {
+	for name, tc := range map[string]struct {
+		principal string
+		fold      CaseFoldPolicy
+		expResult string
+		expErr    error
+	}{
+		"empty": {
+			expErr: errors.New("empty"),
+		},
+		"invalid UTF-8": {
+			principal: "user\xff@example.com",
+			expErr:    errors.New("UTF-8"),
+		},
+		"control character": {
+			principal: "us\u0000er@example.com",
+			expErr:    errors.New("control characters"),
+		},
+		"name only": {
+			principal: "User",
+			expResult: "User",
+		},
+		"local principal": {
+			principal: "user@",
+			expResult: "user@",
+		},
+		"case preserved by default": {
+			principal: "User@Example.COM",
+			expResult: "User@example.com",
+		},
+		"case folded": {
+			principal: "User@Example.COM",
+			fold:      CaseFoldLower,
+			expResult: "user@example.com",
+		},
+		"compatibility characters": {
+			principal: "ｕｓｅｒ@example.com", // fullwidth "user"
+			expResult: "user@example.com",
+		},
+		"composed and decomposed forms": {
+			principal: "josé@example.com",
+			expResult: "josé@example.com",
+		},
+		"internationalized domain": {
+			principal: "user@bücher.example",
+			expResult: "user@xn--bcher-kva.example",
+		},
+		"invalid domain": {
+			principal: "user@exa mple.com",
+			expErr:    errors.New("invalid domain"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := NormalizePrincipal(tc.principal, tc.fold)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.expResult, result, "unexpected normalized principal")
+		})
+	}
+}
+
+func TestSecurity_ExternalIdentityMap_Normalize(t *testing.T) {
+	svc := &MappedClientUser{User: "svc"}
+
+	for name, tc := range map[string]struct {
+		idMap  ExternalIdentityMap
+		fold   CaseFoldPolicy
+		expMap ExternalIdentityMap
+		expErr error
+	}{
+		"nil": {},
+		"normalized keys": {
+			idMap:  ExternalIdentityMap{"svc@Bücher.example": svc},
+			expMap: ExternalIdentityMap{"svc@xn--bcher-kva.example": svc},
+		},
+		"distinct without folding": {
+			idMap: ExternalIdentityMap{"Svc@example.com": svc, "svc@example.com": svc},
+			expMap: ExternalIdentityMap{
+				"Svc@example.com": svc,
+				"svc@example.com": svc,
+			},
+		},
+		"ambiguous with folding": {
+			idMap:  ExternalIdentityMap{"Svc@example.com": svc, "svc@example.com": svc},
+			fold:   CaseFoldLower,
+			expErr: errors.New("ambiguous"),
+		},
+		"ambiguous compatibility forms": {
+			idMap:  ExternalIdentityMap{"ｓvc@example.com": svc, "svc@example.com": svc},
+			expErr: errors.New("ambiguous"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := tc.idMap.Normalize(tc.fold)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, len(tc.expMap), len(result), "unexpected map size")
+			for id, mu := range tc.expMap {
+				test.AssertEqual(t, mu, result[id], "unexpected mapping for "+id)
+			}
+		})
+	}
+}
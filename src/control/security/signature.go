@@ -8,10 +8,17 @@ package security
 
 import (
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
-	"crypto/sha512"
+	"crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
 	"io"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -25,61 +32,232 @@ func (err *UnsupportedKeyError) Error() string {
 	return "key contains an unsupported key type"
 }
 
-// TokenSigner serves to encapsulate the functionality needed
-// to sign and verify auth token signatures.
-type TokenSigner struct {
+var (
+	// errEd25519Verification is returned when an Ed25519 signature does not
+	// match.
+	errEd25519Verification = errors.New("ed25519: verification error")
+	// errECDSAVerification is returned when an ECDSA signature does not
+	// match.
+	errECDSAVerification = errors.New("ecdsa: verification error")
+)
+
+// DefaultVerifierHash is the hash algorithm used to compute verifiers unless
+// another is configured.
+const DefaultVerifierHash = crypto.SHA512
+
+// verifierHashes maps the names of the hash algorithms which may be used to
+// compute verifiers to their implementations.
+var verifierHashes = map[string]crypto.Hash{
+	"sha256":   crypto.SHA256,
+	"sha384":   crypto.SHA384,
+	"sha512":   crypto.SHA512,
+	"sha3-256": crypto.SHA3_256,
+	"sha3-384": crypto.SHA3_384,
+	"sha3-512": crypto.SHA3_512,
+}
+
+// ParseVerifierHash returns the hash algorithm with the given name. An empty
+// name selects the default, SHA-512. An error is returned if the algorithm is
+// unknown, or if it is not available in this build.
+func ParseVerifierHash(name string) (crypto.Hash, error) {
+	if name == "" {
+		return DefaultVerifierHash, nil
+	}
+
+	hash, found := verifierHashes[strings.ToLower(name)]
+	if !found {
+		return 0, errors.Errorf("unknown hash algorithm %q", name)
+	}
+	if !hash.Available() {
+		return 0, errors.Errorf("hash algorithm %q is not available in this build", name)
+	}
+	return hash, nil
+}
+
+// SoftwareTokenSigner is the TokenSigner which signs auth tokens with keys of
+// any kind, and verifies their signatures. The signature scheme is selected by
+// the type of the key: RSA keys sign the hash of the data with PSS, using the
+// signer's hash algorithm (SHA-512 by default), ECDSA keys sign the hash of the
+// data matching the strength of their curve (SHA-256 for P-256 and SHA-384 for
+// P-384), and Ed25519 keys sign the data itself, as Ed25519 hashes the data
+// internally. Keys which are only available as a crypto.Signer, such as keys
+// held on a PKCS#11 token, use the scheme of their public key.
+type SoftwareTokenSigner struct {
 	randPool io.Reader
+	hash     crypto.Hash
+}
+
+// DefaultTokenSigner creates a SoftwareTokenSigner with an instantiated entropy
+// pool.
+func DefaultTokenSigner() *SoftwareTokenSigner {
+	return &SoftwareTokenSigner{
+		randPool: rand.Reader,
+		hash:     DefaultVerifierHash,
+	}
 }
 
-// DefaultTokenSigner creates a TokenSigner with an instantiated entropy pool.
-func DefaultTokenSigner() *TokenSigner {
-	return &TokenSigner{
+// NewTokenSigner creates a SoftwareTokenSigner which hashes data with the given
+// algorithm.
+func NewTokenSigner(hash crypto.Hash) (*SoftwareTokenSigner, error) {
+	if !hash.Available() {
+		return nil, errors.Errorf("hash algorithm %s is not available", hash)
+	}
+	return &SoftwareTokenSigner{
 		randPool: rand.Reader,
+		hash:     hash,
+	}, nil
+}
+
+// HashAlgorithm returns the hash algorithm used by the signer.
+func (s *SoftwareTokenSigner) HashAlgorithm() crypto.Hash {
+	if s.hash == 0 {
+		return DefaultVerifierHash
 	}
+	return s.hash
 }
 
-// Hash returns the SHA-512 hash of the byte array passed in.
-func (s *TokenSigner) Hash(data []byte) ([]byte, error) {
-	hash := sha512.New()
+// Hash returns the hash of the byte array passed in, computed with the signer's
+// hash algorithm.
+func (s *SoftwareTokenSigner) Hash(data []byte) ([]byte, error) {
+	hash := s.HashAlgorithm().New()
 	if _, err := hash.Write(data); err != nil {
 		return nil, errors.New("hash failed to write")
 	}
 	return hash.Sum(nil), nil
 }
 
-// Sign takes an unhashed set of bytes and hashes and signs the result with the
-// key passed in.
-func (s *TokenSigner) Sign(key crypto.PrivateKey, data []byte) ([]byte, error) {
-	digest, err := s.Hash(data)
+// ecdsaHashFunc returns the hash algorithm used in ECDSA signatures with a key
+// on the curve.
+func ecdsaHashFunc(curve elliptic.Curve) (crypto.Hash, error) {
+	switch curve {
+	case elliptic.P256():
+		return crypto.SHA256, nil
+	case elliptic.P384():
+		return crypto.SHA384, nil
+	default:
+		return 0, &UnsupportedKeyError{}
+	}
+}
+
+// ecdsaHash returns the hash of the data used in ECDSA signatures with a key on
+// the curve.
+func ecdsaHash(curve elliptic.Curve, data []byte) ([]byte, error) {
+	hash, err := ecdsaHashFunc(curve)
 	if err != nil {
 		return nil, err
 	}
 
+	h := hash.New()
+	if _, err := h.Write(data); err != nil {
+		return nil, errors.New("hash failed to write")
+	}
+	return h.Sum(nil), nil
+}
+
+// Sign takes an unhashed set of bytes and signs them with the key passed in.
+func (s *SoftwareTokenSigner) Sign(key crypto.PrivateKey, data []byte) ([]byte, error) {
 	if s.randPool == nil {
 		s.randPool = rand.Reader
 	}
 	switch signingKey := key.(type) {
-	// TODO: Support key types other than RSA
 	case *rsa.PrivateKey:
-		return rsa.SignPSS(s.randPool, signingKey, crypto.SHA512, digest, nil)
+		digest, err := s.Hash(data)
+		if err != nil {
+			return nil, err
+		}
+		return rsa.SignPSS(s.randPool, signingKey, s.HashAlgorithm(), digest, nil)
+	case *ecdsa.PrivateKey:
+		digest, err := ecdsaHash(signingKey.Curve, data)
+		if err != nil {
+			return nil, err
+		}
+		return ecdsa.SignASN1(s.randPool, signingKey, digest)
+	case ed25519.PrivateKey:
+		return ed25519.Sign(signingKey, data), nil
+	case crypto.Signer:
+		return s.signWithSigner(signingKey, data)
 	default:
 		return nil, &UnsupportedKeyError{}
 	}
 }
 
-// Verify takes an unhashed set of bytes and hashes the data and verifies the
-// signature against the hash and the publickey passed in.
-func (s *TokenSigner) Verify(key crypto.PublicKey, data []byte, sig []byte) error {
-	digest, err := s.Hash(data)
-	if err != nil {
-		return err
+// signWithSigner signs the data with a key which is only available as a
+// crypto.Signer, such as a key held on a PKCS#11 token, using the same scheme
+// as for a key of the same type held in memory.
+func (s *SoftwareTokenSigner) signWithSigner(signer crypto.Signer, data []byte) ([]byte, error) {
+	switch pub := signer.Public().(type) {
+	case *rsa.PublicKey:
+		digest, err := s.Hash(data)
+		if err != nil {
+			return nil, err
+		}
+		return signer.Sign(s.randPool, digest, &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+			Hash:       s.HashAlgorithm(),
+		})
+	case *ecdsa.PublicKey:
+		hash, err := ecdsaHashFunc(pub.Curve)
+		if err != nil {
+			return nil, err
+		}
+		digest, err := ecdsaHash(pub.Curve, data)
+		if err != nil {
+			return nil, err
+		}
+		return signer.Sign(s.randPool, digest, hash)
+	case ed25519.PublicKey:
+		return signer.Sign(s.randPool, data, crypto.Hash(0))
+	default:
+		return nil, &UnsupportedKeyError{}
 	}
+}
 
+// Verify takes an unhashed set of bytes and verifies the signature of the data
+// against the publickey passed in.
+func (s *SoftwareTokenSigner) Verify(key crypto.PublicKey, data []byte, sig []byte) error {
 	switch signingKey := key.(type) {
-	// TODO: Support key types other than RSA
 	case *rsa.PublicKey:
-		return rsa.VerifyPSS(signingKey, crypto.SHA512, digest, sig, nil)
+		digest, err := s.Hash(data)
+		if err != nil {
+			return err
+		}
+		return rsa.VerifyPSS(signingKey, s.HashAlgorithm(), digest, sig, nil)
+	case *ecdsa.PublicKey:
+		digest, err := ecdsaHash(signingKey.Curve, data)
+		if err != nil {
+			return err
+		}
+		if !ecdsa.VerifyASN1(signingKey, digest, sig) {
+			return errECDSAVerification
+		}
+		return nil
+	case ed25519.PublicKey:
+		if !ed25519.Verify(signingKey, data, sig) {
+			return errEd25519Verification
+		}
+		return nil
 	default:
 		return &UnsupportedKeyError{}
 	}
 }
+
+// PublicKeyID returns an identifier for a signing key, derived from the
+// SHA-256 hash of its DER-encoded public key.
+func PublicKeyID(key crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to marshal public key")
+	}
+
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// PrivateKeyID returns the identifier for the public half of a signing key.
+func PrivateKeyID(key crypto.PrivateKey) (string, error) {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return "", &UnsupportedKeyError{}
+	}
+	return PublicKeyID(signer.Public())
+}
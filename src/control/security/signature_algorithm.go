@@ -0,0 +1,90 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SignatureAlgorithm is a scheme with which credentials may be signed, as
+// selected by the type of the signing key.
+type SignatureAlgorithm string
+
+const (
+	// SignatureRSAPSS is RSA-PSS, with an RSA key.
+	SignatureRSAPSS SignatureAlgorithm = "rsa-pss"
+	// SignatureECDSAP256 is ECDSA with SHA-256, with a P-256 key.
+	SignatureECDSAP256 SignatureAlgorithm = "ecdsa-p256"
+	// SignatureECDSAP384 is ECDSA with SHA-384, with a P-384 key.
+	SignatureECDSAP384 SignatureAlgorithm = "ecdsa-p384"
+	// SignatureEd25519 is Ed25519, with an Ed25519 key.
+	SignatureEd25519 SignatureAlgorithm = "ed25519"
+)
+
+// LegacySignatureAlgorithms are the signature algorithms accepted by servers
+// which don't advertise the algorithms they accept, as they predate any other.
+var LegacySignatureAlgorithms = []SignatureAlgorithm{SignatureRSAPSS}
+
+// SignatureAlgorithms returns all of the supported signature algorithms.
+func SignatureAlgorithms() []SignatureAlgorithm {
+	return []SignatureAlgorithm{SignatureEd25519, SignatureECDSAP384, SignatureECDSAP256, SignatureRSAPSS}
+}
+
+// ParseSignatureAlgorithms parses the names of signature algorithms. An
+// unknown or repeated name is an error.
+func ParseSignatureAlgorithms(names []string) ([]SignatureAlgorithm, error) {
+	var algs []SignatureAlgorithm
+	for _, name := range names {
+		alg := SignatureAlgorithm(strings.ToLower(name))
+		if !slices.Contains(SignatureAlgorithms(), alg) {
+			return nil, errors.Errorf("unknown signature algorithm %q", name)
+		}
+		if slices.Contains(algs, alg) {
+			return nil, errors.Errorf("signature algorithm %q listed more than once", name)
+		}
+		algs = append(algs, alg)
+	}
+	return algs, nil
+}
+
+// KeySignatureAlgorithm returns the algorithm of the signatures made with the
+// private half of the public key.
+func KeySignatureAlgorithm(key crypto.PublicKey) (SignatureAlgorithm, error) {
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		return SignatureRSAPSS, nil
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256():
+			return SignatureECDSAP256, nil
+		case elliptic.P384():
+			return SignatureECDSAP384, nil
+		}
+	case ed25519.PublicKey:
+		return SignatureEd25519, nil
+	}
+	return "", &UnsupportedKeyError{}
+}
+
+// SignatureAlgorithm returns the algorithm of the signatures made with the
+// key, or an empty algorithm if the transport is insecure, in which case
+// nothing is signed.
+func (tc *TransportConfig) SignatureAlgorithm() (SignatureAlgorithm, error) {
+	key, err := tc.PublicKey()
+	if err != nil || key == nil {
+		return "", err
+	}
+	return KeySignatureAlgorithm(key)
+}
@@ -0,0 +1,112 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestSecurity_ParseSignatureAlgorithms(t *testing.T) {
+	for name, tc := range map[string]struct {
+		names   []string
+		expAlgs []SignatureAlgorithm
+		expErr  error
+	}{
+		"none": {},
+		"all": {
+			names:   []string{"rsa-pss", "ECDSA-P256", "ecdsa-p384", "ed25519"},
+			expAlgs: []SignatureAlgorithm{SignatureRSAPSS, SignatureECDSAP256, SignatureECDSAP384, SignatureEd25519},
+		},
+		"unknown": {
+			names:  []string{"ed25519", "ml-dsa-65"},
+			expErr: errors.New(`unknown signature algorithm "ml-dsa-65"`),
+		},
+		"repeated": {
+			names:  []string{"ed25519", "Ed25519"},
+			expErr: errors.New(`signature algorithm "Ed25519" listed more than once`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			algs, err := ParseSignatureAlgorithms(tc.names)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expAlgs, algs); diff != "" {
+				t.Fatalf("unexpected algorithms (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestSecurity_KeySignatureAlgorithm(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p521Key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		key    crypto.PublicKey
+		expAlg SignatureAlgorithm
+		expErr error
+	}{
+		"rsa": {
+			key:    &rsaKey.PublicKey,
+			expAlg: SignatureRSAPSS,
+		},
+		"p-256": {
+			key:    &p256Key.PublicKey,
+			expAlg: SignatureECDSAP256,
+		},
+		"p-384": {
+			key:    &p384Key.PublicKey,
+			expAlg: SignatureECDSAP384,
+		},
+		"ed25519": {
+			key:    edKey,
+			expAlg: SignatureEd25519,
+		},
+		"p-521": {
+			key:    &p521Key.PublicKey,
+			expErr: &UnsupportedKeyError{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			alg, err := KeySignatureAlgorithm(tc.key)
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expAlg, alg, "unexpected signature algorithm")
+		})
+	}
+}
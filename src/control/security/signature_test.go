@@ -18,14 +18,18 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
 )
 
 var update = flag.Bool("update", false, "update .golden files")
 
-func SeededSigner() *TokenSigner {
+func SeededSigner() *SoftwareTokenSigner {
 	//This should ensure we get the same signature every time for testing purposes.
 	r := mrand.New(mrand.NewSource(1))
-	return &TokenSigner{
+	return &SoftwareTokenSigner{
 		randPool: r,
 	}
 }
@@ -39,7 +43,8 @@ func SignTestSetup(t *testing.T) (rsaKey, ecdsaKey crypto.PrivateKey, source []b
 	if err != nil {
 		t.Fatalf("Unable to load private key for %s: %s", keyPath, err.Error())
 	}
-	ecdsaKey, err = ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	// Only ECDSA keys on the P-256 and P-384 curves are supported.
+	ecdsaKey, err = ecdsa.GenerateKey(elliptic.P224(), crand.Reader)
 	if err != nil {
 		t.Fatal("Failed to generate ecdsa key for testing")
 	}
@@ -89,6 +94,126 @@ func TestSign(t *testing.T) {
 	}
 }
 
+func TestSecurity_Ed25519(t *testing.T) {
+	keyPath := "testdata/certs/ed25519.key"
+	if err := os.Chmod(keyPath, MaxUserOnlyKeyPerm); err != nil {
+		t.Fatal(err)
+	}
+	key, err := LoadPrivateKey(keyPath)
+	if err != nil {
+		t.Fatalf("Unable to load private key for %s: %s", keyPath, err.Error())
+	}
+	source, err := os.ReadFile("testdata/certs/source.txt")
+	if err != nil {
+		t.Fatal("Failed to read in source file for Sign test.")
+	}
+	tokenSigner := DefaultTokenSigner()
+
+	// Ed25519 signatures are deterministic, so can be checked against a
+	// golden file.
+	sig, err := tokenSigner.Sign(key, source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", "certs", "Ed25519.golden")
+	if *update {
+		if err := os.WriteFile(golden, sig, 0644); err != nil {
+			t.Errorf("failed to update golden file %s", golden)
+		}
+	}
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Errorf("unable to read golden file %s", golden)
+	}
+	if !bytes.Equal(sig, expected) {
+		t.Errorf("result %s; expected %s", hex.Dump(sig), hex.Dump(expected))
+	}
+
+	pub := key.(crypto.Signer).Public()
+	if err := tokenSigner.Verify(pub, source, sig); err != nil {
+		t.Fatalf("expected signature to verify: %s", err)
+	}
+
+	tampered := append([]byte{}, source...)
+	tampered[0] ^= 0xff
+	if err := tokenSigner.Verify(pub, tampered, sig); err == nil {
+		t.Fatal("expected signature of tampered data not to verify")
+	}
+
+	// A signature from one key type doesn't verify with another.
+	rsaPub, _, _ := VerifyTestSetup(t)
+	if err := tokenSigner.Verify(rsaPub, source, sig); err == nil {
+		t.Fatal("expected Ed25519 signature not to verify with an RSA key")
+	}
+}
+
+func TestSecurity_ECDSA(t *testing.T) {
+	source, err := os.ReadFile("testdata/certs/source.txt")
+	if err != nil {
+		t.Fatal("Failed to read in source file for Sign test.")
+	}
+	tokenSigner := DefaultTokenSigner()
+
+	keys := make(map[string]crypto.Signer)
+	for _, name := range []string{"p256", "p384"} {
+		keyPath := filepath.Join("testdata", "certs", "ecdsa-"+name+".key")
+		if err := os.Chmod(keyPath, MaxUserOnlyKeyPerm); err != nil {
+			t.Fatal(err)
+		}
+		key, err := LoadPrivateKey(keyPath)
+		if err != nil {
+			t.Fatalf("Unable to load private key for %s: %s", keyPath, err.Error())
+		}
+		keys[name] = key.(crypto.Signer)
+	}
+
+	for name, tc := range map[string]struct {
+		signKey   string
+		verifyKey string
+		tamper    bool
+		expErr    bool
+	}{
+		"P-256": {
+			signKey:   "p256",
+			verifyKey: "p256",
+		},
+		"P-384": {
+			signKey:   "p384",
+			verifyKey: "p384",
+		},
+		"tampered": {
+			signKey:   "p256",
+			verifyKey: "p256",
+			tamper:    true,
+			expErr:    true,
+		},
+		"different curve": {
+			signKey:   "p256",
+			verifyKey: "p384",
+			expErr:    true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sig, err := tokenSigner.Sign(keys[tc.signKey], source)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			data := append([]byte{}, source...)
+			if tc.tamper {
+				data[0] ^= 0xff
+			}
+			err = tokenSigner.Verify(keys[tc.verifyKey].Public(), data, sig)
+			if tc.expErr && err == nil {
+				t.Fatal("expected signature not to verify")
+			}
+			if !tc.expErr && err != nil {
+				t.Fatalf("expected signature to verify: %s", err)
+			}
+		})
+	}
+}
+
 func VerifyTestSetup(t *testing.T) (rsaKey, ecdsaKey crypto.PublicKey, source []byte) {
 	certPath := "testdata/certs/daosCA.crt"
 	if err := os.Chmod(certPath, MaxCertPerm); err != nil {
@@ -99,7 +224,7 @@ func VerifyTestSetup(t *testing.T) (rsaKey, ecdsaKey crypto.PublicKey, source []
 		t.Fatalf("Unable to load certificate for %s: %s", certPath, err.Error())
 	}
 	rsaKey = cert.PublicKey
-	gen, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	gen, err := ecdsa.GenerateKey(elliptic.P224(), crand.Reader)
 	if err != nil {
 		t.Fatal("Failed to generate ecdsa key for testing")
 	}
@@ -141,3 +266,118 @@ func TestVerify(t *testing.T) {
 		})
 	}
 }
+
+func TestSecurity_KeyID(t *testing.T) {
+	rsaKey, ecdsaKey, _ := SignTestSetup(t)
+
+	rsaID, err := PrivateKeyID(rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubID, err := PublicKeyID(rsaKey.(crypto.Signer).Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rsaID != pubID {
+		t.Fatalf("private key ID %s != public key ID %s", rsaID, pubID)
+	}
+	if len(rsaID) != hex.EncodedLen(32) {
+		t.Fatalf("unexpected key ID length %d", len(rsaID))
+	}
+
+	ecdsaID, err := PrivateKeyID(ecdsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ecdsaID == rsaID {
+		t.Fatal("expected different keys to have different IDs")
+	}
+
+	if _, err := PrivateKeyID("not a key"); err == nil {
+		t.Fatal("expected error for unsupported key")
+	}
+}
+
+func TestSecurity_ParseVerifierHash(t *testing.T) {
+	for name, tc := range map[string]struct {
+		name    string
+		expHash crypto.Hash
+		expErr  error
+	}{
+		"default": {
+			expHash: crypto.SHA512,
+		},
+		"sha256": {
+			name:    "sha256",
+			expHash: crypto.SHA256,
+		},
+		"sha384": {
+			name:    "SHA384",
+			expHash: crypto.SHA384,
+		},
+		"sha512": {
+			name:    "sha512",
+			expHash: crypto.SHA512,
+		},
+		"unknown": {
+			name:   "md5",
+			expErr: errors.New("unknown hash algorithm"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			hash, err := ParseVerifierHash(tc.name)
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expHash, hash, "unexpected hash")
+		})
+	}
+}
+
+func TestSecurity_TokenSigner_VerifierHash(t *testing.T) {
+	rsaKey, _, source := SignTestSetup(t)
+	rsaPub := rsaKey.(crypto.Signer).Public()
+
+	hashes := []crypto.Hash{crypto.SHA256, crypto.SHA384, crypto.SHA512}
+	for _, name := range []string{"sha3-256", "sha3-384", "sha3-512"} {
+		// SHA-3 is only available in builds with Go 1.24 or later.
+		if hash, err := ParseVerifierHash(name); err == nil {
+			hashes = append(hashes, hash)
+		}
+	}
+
+	for _, hash := range hashes {
+		t.Run(hash.String(), func(t *testing.T) {
+			signer, err := NewTokenSigner(hash)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, hash, signer.HashAlgorithm(), "unexpected hash algorithm")
+
+			digest, err := signer.Hash(source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, hash.Size(), len(digest), "unexpected digest size")
+
+			sig, err := signer.Sign(rsaKey, source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := signer.Verify(rsaPub, source, sig); err != nil {
+				t.Fatalf("expected signature to verify: %s", err)
+			}
+
+			// A signature only verifies with the hash it was made with.
+			other := DefaultTokenSigner()
+			if hash == crypto.SHA512 {
+				other, _ = NewTokenSigner(crypto.SHA256)
+			}
+			if err := other.Verify(rsaPub, source, sig); err == nil {
+				t.Fatal("expected signature not to verify with a different hash")
+			}
+		})
+	}
+
+	if _, err := NewTokenSigner(crypto.MD4); err == nil {
+		t.Fatal("expected error for unavailable hash")
+	}
+}
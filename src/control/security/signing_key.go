@@ -0,0 +1,126 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+// tenantLabelPattern matches the tenant labels which may select a signing key.
+var tenantLabelPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,62}$`)
+
+// SigningKeyConfig configures an additional credential signing key of the
+// agent, used in place of the transport key for credentials requested for the
+// System or Tenant, so that the tenants of a shared agent don't share a trust
+// anchor. If both are set, the key is only used for credentials requested for
+// the tenant on the system. If neither is set, the key is used by default in
+// place of the transport key, so that the agent's TLS identity and its
+// credential signing identity may be rotated and scoped independently. The
+// certificate and key are configured as for the transport key, and may be held
+// by any of the same key sources. As the tenant label is chosen by the client,
+// a tenant key must be bound to the users, by AllowedUIDs, or primary groups,
+// by AllowedGIDs, of the clients permitted to request credentials for it.
+type SigningKeyConfig struct {
+	System            string   `yaml:"system,omitempty"`
+	Tenant            string   `yaml:"tenant,omitempty"`
+	AllowedUIDs       []uint32 `yaml:"allowed_uids,omitempty"`
+	AllowedGIDs       []uint32 `yaml:"allowed_gids,omitempty"`
+	CertificateConfig `yaml:",inline"`
+}
+
+// ValidTenantLabel returns true if the label may select a signing key.
+func ValidTenantLabel(label string) bool {
+	return tenantLabelPattern.MatchString(label)
+}
+
+// Validate checks the signing key configuration.
+func (cfg *SigningKeyConfig) Validate() error {
+	if cfg == nil {
+		return errors.New("nil signing key config")
+	}
+	if cfg.System == "" && cfg.Tenant == "" {
+		return errors.New("one or both of system and tenant must be set")
+	}
+	if cfg.Tenant != "" && !ValidTenantLabel(cfg.Tenant) {
+		return errors.Errorf("invalid tenant label %q", cfg.Tenant)
+	}
+	if err := cfg.validateAllowed(); err != nil {
+		return err
+	}
+	return cfg.validateKey()
+}
+
+// validateAllowed checks that a tenant key, and only a tenant key, is bound to
+// the clients permitted to use it.
+func (cfg *SigningKeyConfig) validateAllowed() error {
+	bound := len(cfg.AllowedUIDs) > 0 || len(cfg.AllowedGIDs) > 0
+	switch {
+	case cfg.Tenant != "" && !bound:
+		return errors.Errorf("allowed_uids or allowed_gids must be set for signing key %s", cfg)
+	case cfg.Tenant == "" && bound:
+		return errors.New("allowed_uids and allowed_gids can only be set for tenant signing keys")
+	}
+	return nil
+}
+
+// Allows returns true if a client with the UID and primary GID may request
+// credentials signed with the key.
+func (cfg *SigningKeyConfig) Allows(uid, gid uint32) bool {
+	return slices.Contains(cfg.AllowedUIDs, uid) || slices.Contains(cfg.AllowedGIDs, gid)
+}
+
+// ValidateDefault checks the configuration of a signing key which is used by
+// default, in place of the transport key, and so is selected by no system or
+// tenant.
+func (cfg *SigningKeyConfig) ValidateDefault() error {
+	if cfg == nil {
+		return errors.New("nil signing key config")
+	}
+	if cfg.System != "" || cfg.Tenant != "" {
+		return errors.New("system and tenant can't be set on the default signing key")
+	}
+	if err := cfg.validateAllowed(); err != nil {
+		return err
+	}
+	return cfg.validateKey()
+}
+
+// validateKey checks that the certificate and key of the signing key are set.
+func (cfg *SigningKeyConfig) validateKey() error {
+	if cfg.CARootPath == "" || cfg.CertificatePath == "" {
+		return errors.Errorf("ca_cert and cert are required for signing key %s", cfg)
+	}
+	if cfg.PrivateKeyPath == "" && cfg.PKCS11Key == nil && cfg.KMSKey == nil && cfg.TPMKey == nil {
+		return errors.Errorf("no key configured for signing key %s", cfg)
+	}
+	return nil
+}
+
+// String describes the requests for which the signing key is used.
+func (cfg *SigningKeyConfig) String() string {
+	switch {
+	case cfg.System != "" && cfg.Tenant != "":
+		return "for tenant " + cfg.Tenant + " on system " + cfg.System
+	case cfg.Tenant != "":
+		return "for tenant " + cfg.Tenant
+	case cfg.System == "":
+		return "used by default"
+	default:
+		return "for system " + cfg.System
+	}
+}
+
+// TransportConfig returns a transport config with which to load the
+// certificate and key, whose key file must only be accessible by its owner.
+func (cfg *SigningKeyConfig) TransportConfig() *TransportConfig {
+	tc := &TransportConfig{CertificateConfig: cfg.CertificateConfig}
+	tc.maxKeyPerms = MaxUserOnlyKeyPerm
+	return tc
+}
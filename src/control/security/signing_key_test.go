@@ -0,0 +1,176 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestSecurity_SigningKeyConfig_Validate(t *testing.T) {
+	certs := CertificateConfig{
+		CARootPath:      "/etc/daos/certs/daosCA.crt",
+		CertificatePath: "/etc/daos/certs/tenant.crt",
+		PrivateKeyPath:  "/etc/daos/certs/tenant.key",
+	}
+
+	for name, tc := range map[string]struct {
+		cfg    *SigningKeyConfig
+		expErr error
+	}{
+		"nil": {
+			expErr: errors.New("nil signing key config"),
+		},
+		"tenant": {
+			cfg: &SigningKeyConfig{Tenant: "tenant-a", AllowedUIDs: []uint32{1000}, CertificateConfig: certs},
+		},
+		"tenant bound to groups": {
+			cfg: &SigningKeyConfig{Tenant: "tenant-a", AllowedGIDs: []uint32{1000}, CertificateConfig: certs},
+		},
+		"tenant not bound": {
+			cfg:    &SigningKeyConfig{Tenant: "tenant-a", CertificateConfig: certs},
+			expErr: errors.New("allowed_uids or allowed_gids must be set for signing key for tenant tenant-a"),
+		},
+		"system bound": {
+			cfg:    &SigningKeyConfig{System: "daos_server", AllowedUIDs: []uint32{1000}, CertificateConfig: certs},
+			expErr: errors.New("can only be set for tenant signing keys"),
+		},
+		"system": {
+			cfg: &SigningKeyConfig{System: "daos_server", CertificateConfig: certs},
+		},
+		"tenant on system": {
+			cfg: &SigningKeyConfig{System: "daos_server", Tenant: "tenant_a.1", AllowedUIDs: []uint32{1000}, CertificateConfig: certs},
+		},
+		"tpm key": {
+			cfg: &SigningKeyConfig{
+				Tenant:      "tenant-a",
+				AllowedUIDs: []uint32{1000},
+				CertificateConfig: CertificateConfig{
+					CARootPath:      certs.CARootPath,
+					CertificatePath: certs.CertificatePath,
+					TPMKey:          &TPMKeyConfig{Handle: "0x81010002"},
+				},
+			},
+		},
+		"neither system nor tenant": {
+			cfg:    &SigningKeyConfig{CertificateConfig: certs},
+			expErr: errors.New("one or both of system and tenant must be set"),
+		},
+		"invalid tenant": {
+			cfg:    &SigningKeyConfig{Tenant: "tenant/a", AllowedUIDs: []uint32{1000}, CertificateConfig: certs},
+			expErr: errors.New(`invalid tenant label "tenant/a"`),
+		},
+		"no cert": {
+			cfg: &SigningKeyConfig{
+				Tenant:      "tenant-a",
+				AllowedUIDs: []uint32{1000},
+				CertificateConfig: CertificateConfig{
+					CARootPath:     certs.CARootPath,
+					PrivateKeyPath: certs.PrivateKeyPath,
+				},
+			},
+			expErr: errors.New("ca_cert and cert are required for signing key for tenant tenant-a"),
+		},
+		"no key": {
+			cfg: &SigningKeyConfig{
+				System: "daos_server",
+				CertificateConfig: CertificateConfig{
+					CARootPath:      certs.CARootPath,
+					CertificatePath: certs.CertificatePath,
+				},
+			},
+			expErr: errors.New("no key configured for signing key for system daos_server"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.cfg.Validate())
+		})
+	}
+}
+
+func TestSecurity_SigningKeyConfig_ValidateDefault(t *testing.T) {
+	certs := CertificateConfig{
+		CARootPath:      "/etc/daos/certs/daosCA.crt",
+		CertificatePath: "/etc/daos/certs/signing.crt",
+		PrivateKeyPath:  "/etc/daos/certs/signing.key",
+	}
+
+	for name, tc := range map[string]struct {
+		cfg    *SigningKeyConfig
+		expErr error
+	}{
+		"nil": {
+			expErr: errors.New("nil signing key config"),
+		},
+		"default": {
+			cfg: &SigningKeyConfig{CertificateConfig: certs},
+		},
+		"tenant": {
+			cfg:    &SigningKeyConfig{Tenant: "tenant-a", CertificateConfig: certs},
+			expErr: errors.New("system and tenant can't be set on the default signing key"),
+		},
+		"bound": {
+			cfg:    &SigningKeyConfig{AllowedGIDs: []uint32{1000}, CertificateConfig: certs},
+			expErr: errors.New("can only be set for tenant signing keys"),
+		},
+		"no key": {
+			cfg: &SigningKeyConfig{
+				CertificateConfig: CertificateConfig{
+					CARootPath:      certs.CARootPath,
+					CertificatePath: certs.CertificatePath,
+				},
+			},
+			expErr: errors.New("no key configured for signing key used by default"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.cfg.ValidateDefault())
+		})
+	}
+}
+
+func TestSecurity_ValidTenantLabel(t *testing.T) {
+	for label, exp := range map[string]bool{
+		"":                      false,
+		"tenant-a":              true,
+		"Tenant_A.1":            true,
+		"-tenant":               false,
+		"tenant a":              false,
+		"tenant/a":              false,
+		"tenant:a":              false,
+		strings.Repeat("a", 63): true,
+		strings.Repeat("a", 64): false,
+	} {
+		test.AssertEqual(t, exp, ValidTenantLabel(label), "unexpected result for "+label)
+	}
+}
+
+func TestSecurity_SigningKeyConfig_Allows(t *testing.T) {
+	cfg := &SigningKeyConfig{
+		Tenant:      "tenant-a",
+		AllowedUIDs: []uint32{1000},
+		AllowedGIDs: []uint32{2000},
+	}
+
+	for name, tc := range map[string]struct {
+		uid uint32
+		gid uint32
+		exp bool
+	}{
+		"allowed user":  {uid: 1000, gid: 1, exp: true},
+		"allowed group": {uid: 1, gid: 2000, exp: true},
+		"neither":       {uid: 2000, gid: 1000},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.AssertEqual(t, tc.exp, cfg.Allows(tc.uid, tc.gid), "unexpected result")
+		})
+	}
+}
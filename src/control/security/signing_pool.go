@@ -0,0 +1,115 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrSigningPoolClosed is returned for signatures requested of a closed
+// signing pool.
+var ErrSigningPoolClosed = errors.New("signing pool closed")
+
+// DefaultSigningWorkers returns the number of signing workers used if none is
+// configured: half of the CPUs which may run Go code at once, but at least one,
+// so that signing many credentials at once uses several cores without
+// starving the agent's other work.
+func DefaultSigningWorkers() int {
+	if workers := runtime.GOMAXPROCS(0) / 2; workers > 1 {
+		return workers
+	}
+	return 1
+}
+
+type signingJob struct {
+	sign   func() ([]byte, error)
+	result chan signingResult
+}
+
+type signingResult struct {
+	sig []byte
+	err error
+}
+
+// SigningPool performs signatures on a fixed number of workers, so that
+// concurrent signatures, which are expensive with RSA keys, use several cores
+// but never more than the number of workers. Signatures requested while all of
+// the workers are busy wait for one to become free.
+type SigningPool struct {
+	workers   int
+	jobs      chan signingJob
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewSigningPool starts a signing pool with the number of workers, or with
+// DefaultSigningWorkers if it is not positive.
+func NewSigningPool(workers int) *SigningPool {
+	if workers <= 0 {
+		workers = DefaultSigningWorkers()
+	}
+
+	p := &SigningPool{
+		workers: workers,
+		jobs:    make(chan signingJob),
+		done:    make(chan struct{}),
+	}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+func (p *SigningPool) work() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case job := <-p.jobs:
+			sig, err := job.sign()
+			job.result <- signingResult{sig: sig, err: err}
+		}
+	}
+}
+
+// Workers returns the number of workers in the pool.
+func (p *SigningPool) Workers() int {
+	return p.workers
+}
+
+// Sign performs the signature on one of the pool's workers, waiting for one to
+// become free, and returns its result.
+func (p *SigningPool) Sign(sign func() ([]byte, error)) ([]byte, error) {
+	job := signingJob{
+		sign:   sign,
+		result: make(chan signingResult, 1),
+	}
+	select {
+	case <-p.done:
+		return nil, ErrSigningPoolClosed
+	case p.jobs <- job:
+	}
+
+	result := <-job.result
+	return result.sig, result.err
+}
+
+// Close stops the workers once they have finished their current signatures.
+// Signatures requested after the pool is closed fail.
+func (p *SigningPool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+	p.wg.Wait()
+}
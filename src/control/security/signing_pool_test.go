@@ -0,0 +1,87 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestSecurity_SigningPool(t *testing.T) {
+	for name, tc := range map[string]struct {
+		workers    int
+		expWorkers int
+	}{
+		"default": {
+			expWorkers: DefaultSigningWorkers(),
+		},
+		"one": {
+			workers:    1,
+			expWorkers: 1,
+		},
+		"several": {
+			workers:    3,
+			expWorkers: 3,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			pool := NewSigningPool(tc.workers)
+			defer pool.Close()
+			test.AssertEqual(t, tc.expWorkers, pool.Workers(), "unexpected number of workers")
+
+			// No more signatures than workers run at once.
+			var running, maxRunning atomic.Int32
+			var wg sync.WaitGroup
+			for i := 0; i < 4*tc.expWorkers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					sig, err := pool.Sign(func() ([]byte, error) {
+						n := running.Add(1)
+						defer running.Add(-1)
+						for {
+							prev := maxRunning.Load()
+							if n <= prev || maxRunning.CompareAndSwap(prev, n) {
+								break
+							}
+						}
+						time.Sleep(time.Millisecond)
+						return []byte("sig"), nil
+					})
+					if err != nil {
+						t.Error(err)
+					}
+					test.AssertEqual(t, "sig", string(sig), "unexpected signature")
+				}()
+			}
+			wg.Wait()
+			test.AssertTrue(t, int(maxRunning.Load()) <= tc.expWorkers, "too many signatures at once")
+		})
+	}
+}
+
+func TestSecurity_SigningPool_errors(t *testing.T) {
+	pool := NewSigningPool(1)
+
+	_, err := pool.Sign(func() ([]byte, error) {
+		return nil, errors.New("signing failed")
+	})
+	test.CmpErr(t, errors.New("signing failed"), err)
+
+	pool.Close()
+	_, err = pool.Sign(func() ([]byte, error) {
+		t.Fatal("signed after the pool was closed")
+		return nil, nil
+	})
+	test.CmpErr(t, ErrSigningPoolClosed, err)
+}
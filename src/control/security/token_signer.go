@@ -0,0 +1,114 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+
+	"github.com/pkg/errors"
+)
+
+// TokenSigner signs auth tokens with a key, and verifies their signatures. Data
+// is hashed with the signer's hash algorithm where the signature scheme calls
+// for it.
+type TokenSigner interface {
+	HashAlgorithm() crypto.Hash
+	Hash(data []byte) ([]byte, error)
+	Sign(key crypto.PrivateKey, data []byte) ([]byte, error)
+	Verify(key crypto.PublicKey, data []byte, sig []byte) error
+}
+
+// TokenSignerBackend selects the TokenSigner with which credentials are signed.
+type TokenSignerBackend string
+
+const (
+	// TokenSignerSoftware signs with keys of any kind.
+	TokenSignerSoftware TokenSignerBackend = "software"
+	// TokenSignerPKCS11 signs only with keys held on a PKCS#11 token.
+	TokenSignerPKCS11 TokenSignerBackend = "pkcs11"
+	// TokenSignerKMS signs only with keys held by a key management service.
+	TokenSignerKMS TokenSignerBackend = "kms"
+	// TokenSignerNull signs nothing, replacing each signature with the hash
+	// of the data. It is only meant for testing.
+	TokenSignerNull TokenSignerBackend = "null"
+)
+
+// Validate checks that the backend is known.
+func (b TokenSignerBackend) Validate() error {
+	switch b {
+	case "", TokenSignerSoftware, TokenSignerPKCS11, TokenSignerKMS, TokenSignerNull:
+		return nil
+	default:
+		return errors.Errorf("unknown token signer %q", b)
+	}
+}
+
+// NewBackendTokenSigner creates the TokenSigner of the backend, which hashes
+// data with the given algorithm. An empty backend selects the software signer.
+func NewBackendTokenSigner(backend TokenSignerBackend, hash crypto.Hash) (TokenSigner, error) {
+	software, err := NewTokenSigner(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	switch backend {
+	case "", TokenSignerSoftware:
+		return software, nil
+	case TokenSignerPKCS11:
+		return &heldKeyTokenSigner{SoftwareTokenSigner: software, desc: "a PKCS#11 token"}, nil
+	case TokenSignerKMS:
+		return &heldKeyTokenSigner{SoftwareTokenSigner: software, desc: "a key management service"}, nil
+	case TokenSignerNull:
+		return &nullTokenSigner{SoftwareTokenSigner: software}, nil
+	default:
+		return nil, errors.Errorf("unknown token signer %q", backend)
+	}
+}
+
+// heldKeyTokenSigner signs only with keys held outside of this process, so
+// that a key which was meant to be held on a token or by a key management
+// service is never used once it has been loaded into memory instead.
+type heldKeyTokenSigner struct {
+	*SoftwareTokenSigner
+	desc string
+}
+
+// Sign signs the data with the key, unless the key is held in memory.
+func (s *heldKeyTokenSigner) Sign(key crypto.PrivateKey, data []byte) ([]byte, error) {
+	switch key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+		return nil, errors.Errorf("refusing to sign with a key held in memory rather than by %s", s.desc)
+	}
+	return s.SoftwareTokenSigner.Sign(key, data)
+}
+
+// nullTokenSigner replaces each signature with the hash of the data, so that
+// tests can exercise the signing paths without performing any signatures.
+type nullTokenSigner struct {
+	*SoftwareTokenSigner
+}
+
+// Sign returns the hash of the data, ignoring the key.
+func (s *nullTokenSigner) Sign(_ crypto.PrivateKey, data []byte) ([]byte, error) {
+	return s.Hash(data)
+}
+
+// Verify checks that the signature is the hash of the data, ignoring the key.
+func (s *nullTokenSigner) Verify(_ crypto.PublicKey, data []byte, sig []byte) error {
+	digest, err := s.Hash(data)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(digest, sig) {
+		return errors.New("null signature does not match")
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestSecurity_NewBackendTokenSigner(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("token")
+
+	for name, tc := range map[string]struct {
+		backend     TokenSignerBackend
+		key         crypto.PrivateKey
+		expErr      error
+		expSignErr  error
+		expVerified bool
+	}{
+		"default": {
+			key:         key,
+			expVerified: true,
+		},
+		"software": {
+			backend:     TokenSignerSoftware,
+			key:         key,
+			expVerified: true,
+		},
+		"software with held key": {
+			backend:     TokenSignerSoftware,
+			key:         tokenSigner{key},
+			expVerified: true,
+		},
+		"pkcs11 with held key": {
+			backend:     TokenSignerPKCS11,
+			key:         tokenSigner{key},
+			expVerified: true,
+		},
+		"pkcs11 with key in memory": {
+			backend:    TokenSignerPKCS11,
+			key:        key,
+			expSignErr: errors.New("refusing to sign with a key held in memory rather than by a PKCS#11 token"),
+		},
+		"kms with held key": {
+			backend:     TokenSignerKMS,
+			key:         tokenSigner{key},
+			expVerified: true,
+		},
+		"kms with key in memory": {
+			backend:    TokenSignerKMS,
+			key:        key,
+			expSignErr: errors.New("rather than by a key management service"),
+		},
+		"null": {
+			backend: TokenSignerNull,
+			key:     key,
+		},
+		"unknown": {
+			backend: "magic",
+			expErr:  errors.New(`unknown token signer "magic"`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			signer, err := NewBackendTokenSigner(tc.backend, crypto.SHA512)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			sig, err := signer.Sign(tc.key, data)
+			test.CmpErr(t, tc.expSignErr, err)
+			if tc.expSignErr != nil {
+				return
+			}
+			if err := signer.Verify(&key.PublicKey, data, sig); err != nil {
+				t.Fatalf("expected signature to verify with its signer: %s", err)
+			}
+
+			// Only real signatures verify with the software signer.
+			err = DefaultTokenSigner().Verify(&key.PublicKey, data, sig)
+			test.AssertEqual(t, tc.expVerified, err == nil, "unexpected verification result")
+		})
+	}
+}
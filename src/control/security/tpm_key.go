@@ -0,0 +1,55 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/tpm"
+)
+
+// TPMKeyConfig identifies a private key generated in, and held by, the node's
+// TPM at a persistent handle, to be used in place of the key file. The key
+// can't be exported, so credentials can only be signed on this node.
+type TPMKeyConfig struct {
+	Handle  string        `yaml:"handle"`
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// Validate checks the TPM key configuration.
+func (cfg *TPMKeyConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.Handle == "" {
+		return errors.New("handle is required")
+	}
+	if _, err := tpm.ParseHandle(cfg.Handle); err != nil {
+		return err
+	}
+	if cfg.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	return nil
+}
+
+// loadCertWithTPMKey loads the certificate, with its private key held in the
+// TPM.
+func loadCertWithTPMKey(caRootPath, certPath string, cfg *TPMKeyConfig) (*tls.Certificate, *x509.CertPool, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, errors.Wrap(err, "tpm_key")
+	}
+
+	return loadCertWithSigner(caRootPath, certPath, "TPM", func(pub crypto.PublicKey) (crypto.Signer, error) {
+		return tpm.OpenKey(cfg.Handle, pub, cfg.Timeout)
+	})
+}
@@ -0,0 +1,95 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestSecurity_TPMKeyConfig_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg    *TPMKeyConfig
+		expErr error
+	}{
+		"nil": {},
+		"valid": {
+			cfg: &TPMKeyConfig{
+				Handle:  "0x81010002",
+				Timeout: 5 * time.Second,
+			},
+		},
+		"no handle": {
+			cfg:    &TPMKeyConfig{},
+			expErr: errors.New("handle is required"),
+		},
+		"invalid handle": {
+			cfg:    &TPMKeyConfig{Handle: "primary"},
+			expErr: errors.New("invalid TPM handle"),
+		},
+		"transient handle": {
+			cfg:    &TPMKeyConfig{Handle: "0x80000000"},
+			expErr: errors.New("not a persistent handle"),
+		},
+		"negative timeout": {
+			cfg: &TPMKeyConfig{
+				Handle:  "0x81010002",
+				Timeout: -time.Second,
+			},
+			expErr: errors.New("timeout must not be negative"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.cfg.Validate())
+		})
+	}
+}
+
+func TestSecurity_loadCertWithTPMKey(t *testing.T) {
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	caPath := copyTestCertFile(t, tmpDir, "daosCA.crt", MaxCertPerm)
+	certPath := copyTestCertFile(t, tmpDir, "agent.crt", MaxCertPerm)
+
+	for name, tc := range map[string]struct {
+		cfg    *TPMKeyConfig
+		kmsCfg *KMSKeyConfig
+		expErr error
+	}{
+		"invalid config": {
+			cfg:    &TPMKeyConfig{Handle: "0x1"},
+			expErr: errors.New("tpm_key: TPM handle"),
+		},
+		"multiple key sources": {
+			cfg:    &TPMKeyConfig{Handle: "0x81010002"},
+			kmsCfg: &KMSKeyConfig{},
+			expErr: errors.New("only one of pkcs11_key, kms_key and tpm_key may be set"),
+		},
+		// There is no key at the handle, if there is a TPM at all.
+		"no key": {
+			cfg:    &TPMKeyConfig{Handle: "0x81fffff0", Timeout: 5 * time.Second},
+			expErr: errors.New("TPM key does not match"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := &TransportConfig{
+				CertificateConfig: CertificateConfig{
+					CARootPath:      caPath,
+					CertificatePath: certPath,
+					KMSKey:          tc.kmsCfg,
+					TPMKey:          tc.cfg,
+				},
+			}
+			test.CmpErr(t, tc.expErr, cfg.PreLoadCertData())
+		})
+	}
+}
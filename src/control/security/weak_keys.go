@@ -0,0 +1,168 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto/dsa"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// MinRSAKeyBits is the size below which an RSA key is weak.
+const MinRSAKeyBits = 2048
+
+// WeakKeyPolicy defines how a component reacts to weak keys or certificate
+// signatures found in its certificates at startup.
+type WeakKeyPolicy string
+
+const (
+	// WeakKeyWarn logs each weak key or signature, and starts regardless.
+	WeakKeyWarn WeakKeyPolicy = "warn"
+	// WeakKeyFail refuses to start if any key or signature is weak.
+	WeakKeyFail WeakKeyPolicy = "fail"
+)
+
+// Validate checks that the policy is known.
+func (p WeakKeyPolicy) Validate() error {
+	switch p {
+	case "", WeakKeyWarn, WeakKeyFail:
+		return nil
+	default:
+		return errors.Errorf("unknown weak key policy %q", p)
+	}
+}
+
+// Check looks for weak keys and signatures in the certificates of the transport
+// configs. They are logged if the policy is to warn, and are an error if it is
+// to fail.
+func (p WeakKeyPolicy) Check(log logging.Logger, tcs ...*TransportConfig) error {
+	var found []string
+	for _, tc := range tcs {
+		weak, err := tc.WeakKeys()
+		if err != nil {
+			return err
+		}
+		found = append(found, weak...)
+	}
+	if len(found) == 0 {
+		return nil
+	}
+
+	if p == WeakKeyFail {
+		return errors.Errorf("weak keys or signatures in the certificates (weak_key_policy: %s): %s",
+			p, strings.Join(found, "; "))
+	}
+	for _, weak := range found {
+		log.Errorf("weak key or signature: %s", weak)
+	}
+	return nil
+}
+
+// WeakKeys returns a description of each weak key or signature in the
+// certificates of the transport config: the CA bundle, the certificate file
+// and, for servers, the agent certificates in the client certificate
+// directory. Agent certificates which can't be parsed are skipped, as they are
+// when the keys of agents are loaded.
+func (tc *TransportConfig) WeakKeys() ([]string, error) {
+	if tc == nil || tc.AllowInsecure {
+		return nil, nil
+	}
+
+	var found []string
+	for _, path := range []string{tc.CARootPath, tc.CertificatePath} {
+		data, err := loadCertFile(path, MaxCertPerm, "certificate")
+		if err != nil {
+			return nil, err
+		}
+		weak, err := weakPEMCertificates(path, data)
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, weak...)
+	}
+
+	if tc.ClientCertDir == "" {
+		return found, nil
+	}
+	entries, err := os.ReadDir(tc.ClientCertDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading client cert directory")
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".crt") {
+			continue
+		}
+		path := filepath.Join(tc.ClientCertDir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if weak, err := weakPEMCertificates(path, data); err == nil {
+			found = append(found, weak...)
+		}
+	}
+	return found, nil
+}
+
+// weakPEMCertificates returns a description of each weak key or signature of
+// the certificates in the PEM data.
+func weakPEMCertificates(path string, data []byte) ([]string, error) {
+	var found []string
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return found, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, FaultInvalidCertFile(path, err)
+		}
+		for _, reason := range WeakCertificate(cert) {
+			found = append(found, fmt.Sprintf("%s: certificate %q %s", path, cert.Subject.CommonName, reason))
+		}
+	}
+}
+
+// WeakCertificate returns the reasons the key or signature of the certificate
+// is weak: an RSA key smaller than MinRSAKeyBits, a DSA key or a key on the
+// P-224 curve, or a signature using MD5 or SHA-1.
+func WeakCertificate(cert *x509.Certificate) []string {
+	var reasons []string
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if bits := pub.N.BitLen(); bits < MinRSAKeyBits {
+			reasons = append(reasons, fmt.Sprintf("has a %d-bit RSA key (minimum %d)", bits, MinRSAKeyBits))
+		}
+	case *ecdsa.PublicKey:
+		if pub.Curve == elliptic.P224() {
+			reasons = append(reasons, "has a key on the deprecated curve P-224")
+		}
+	case *dsa.PublicKey:
+		reasons = append(reasons, "has a deprecated DSA key")
+	}
+
+	switch cert.SignatureAlgorithm {
+	case x509.MD2WithRSA, x509.MD5WithRSA, x509.SHA1WithRSA, x509.DSAWithSHA1, x509.ECDSAWithSHA1:
+		reasons = append(reasons, fmt.Sprintf("is signed with %s", cert.SignatureAlgorithm))
+	}
+	return reasons
+}
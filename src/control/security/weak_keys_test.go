@@ -0,0 +1,171 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestSecurity_WeakCertificate(t *testing.T) {
+	rsa1024, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsa2048, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		cert       *x509.Certificate
+		expReasons []string
+	}{
+		"rsa 2048": {
+			cert: &x509.Certificate{PublicKey: &rsa2048.PublicKey, SignatureAlgorithm: x509.SHA256WithRSA},
+		},
+		"p-256": {
+			cert: &x509.Certificate{PublicKey: &p256.PublicKey, SignatureAlgorithm: x509.ECDSAWithSHA256},
+		},
+		"ed25519": {
+			cert: &x509.Certificate{PublicKey: edKey, SignatureAlgorithm: x509.PureEd25519},
+		},
+		"rsa 1024": {
+			cert:       &x509.Certificate{PublicKey: &rsa1024.PublicKey, SignatureAlgorithm: x509.SHA256WithRSA},
+			expReasons: []string{"has a 1024-bit RSA key (minimum 2048)"},
+		},
+		"p-224": {
+			cert:       &x509.Certificate{PublicKey: &p224.PublicKey, SignatureAlgorithm: x509.ECDSAWithSHA256},
+			expReasons: []string{"has a key on the deprecated curve P-224"},
+		},
+		"sha-1 signature": {
+			cert:       &x509.Certificate{PublicKey: &rsa2048.PublicKey, SignatureAlgorithm: x509.SHA1WithRSA},
+			expReasons: []string{"is signed with SHA1-RSA"},
+		},
+		"md5 signature and small key": {
+			cert:       &x509.Certificate{PublicKey: &rsa1024.PublicKey, SignatureAlgorithm: x509.MD5WithRSA},
+			expReasons: []string{"has a 1024-bit RSA key (minimum 2048)", "is signed with MD5-RSA"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expReasons, WeakCertificate(tc.cert)); diff != "" {
+				t.Fatalf("unexpected reasons (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestSecurity_WeakKeyPolicy_Check(t *testing.T) {
+	clientDir := t.TempDir()
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "weak-agent"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, p224.Public(), p224)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range map[string][]byte{
+		"weak-agent.crt": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		"weak-agent.pem": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		"garbage.crt":    []byte("not a certificate"),
+	} {
+		if err := os.WriteFile(filepath.Join(clientDir, name), data, MaxCertPerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	weakAgent := filepath.Join(clientDir, "weak-agent.crt") +
+		`: certificate "weak-agent" has a key on the deprecated curve P-224`
+
+	for name, tc := range map[string]struct {
+		policy    WeakKeyPolicy
+		clientDir string
+		insecure  bool
+		expLog    string
+		expErr    error
+	}{
+		"no weak keys": {
+			policy: WeakKeyFail,
+		},
+		"insecure": {
+			policy:    WeakKeyFail,
+			clientDir: clientDir,
+			insecure:  true,
+		},
+		"warn": {
+			clientDir: clientDir,
+			expLog:    "weak key or signature: " + weakAgent,
+		},
+		"fail": {
+			policy:    WeakKeyFail,
+			clientDir: clientDir,
+			expErr:    errors.New("(weak_key_policy: fail): " + weakAgent),
+		},
+		"missing client cert dir": {
+			clientDir: filepath.Join(clientDir, "missing"),
+			expErr:    errors.New("reading client cert directory"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			cfg := ServerTC()
+			SetupTCFilePerms(t, cfg)
+			cfg.ClientCertDir = tc.clientDir
+			cfg.AllowInsecure = tc.insecure
+
+			err := tc.policy.Check(log, cfg)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			if tc.expLog == "" {
+				test.AssertFalse(t, strings.Contains(buf.String(), "weak key"), "unexpected weak key logged")
+				return
+			}
+			test.AssertTrue(t, strings.Contains(buf.String(), tc.expLog), "weak key not logged")
+		})
+	}
+}
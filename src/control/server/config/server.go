@@ -15,6 +15,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
@@ -56,7 +57,24 @@ type deprecatedParams struct {
 
 // AuthenticationConfig contains configuation details for valid authentication
 type AuthenticationConfig struct {
-	ValidAuth []string `yaml:"valid_auth"`
+	ValidAuth              []string                              `yaml:"valid_auth"`
+	RevokedIssuerKeys      []string                              `yaml:"revoked_issuer_keys,omitempty"`
+	ProxyHosts             []string                              `yaml:"proxy_hosts,omitempty"`
+	AgentCA                *security.AgentCAConfig               `yaml:"agent_ca,omitempty"`
+	CAReloadInterval       time.Duration                         `yaml:"ca_reload_interval,omitempty"`
+	RevocationPollInterval time.Duration                         `yaml:"revocation_poll_interval,omitempty"`
+	SignatureAlgorithms    []string                              `yaml:"signature_algorithms,omitempty"`
+	Cosigning              *security.CosignerConfig              `yaml:"cosigning,omitempty"`
+	CredentialClockSkew    time.Duration                         `yaml:"credential_clock_skew,omitempty"`
+	CredentialExpiry       *security.CredentialExpiryConfig      `yaml:"credential_expiry,omitempty"`
+	CredentialNonces       *security.CredentialNonceConfig       `yaml:"credential_nonces,omitempty"`
+	RequireSystemBinding   bool                                  `yaml:"require_system_binding,omitempty"`
+	ProcessBoundPools      []string                              `yaml:"process_bound_pools,omitempty"`
+	MACLabels              []*security.MACLabelConfig            `yaml:"mac_labels,omitempty"`
+	IdentityGroups         *security.IdentityGroupConfig         `yaml:"identity_groups,omitempty"`
+	CredentialValidators   []*security.CredentialValidatorConfig `yaml:"credential_validators,omitempty"`
+	RequireAgentEnrollment bool                                  `yaml:"require_agent_enrollment,omitempty"`
+	AccessManagerTrust     *security.AccessManagerTrustConfig    `yaml:"access_manager_trust,omitempty"`
 }
 
 func DefaultAuthenticationConfig() *AuthenticationConfig {
@@ -893,6 +911,71 @@ func (cfg *Server) Validate(log logging.Logger) (err error) {
 		}
 	}
 
+	if cfg.TransportConfig != nil {
+		if err := cfg.TransportConfig.WeakKeyPolicy.Validate(); err != nil {
+			return errors.Wrap(err, "transport_config.weak_key_policy")
+		}
+	}
+	if cfg.AuthenticationConfig != nil && cfg.AuthenticationConfig.CAReloadInterval < 0 {
+		return errors.New("auth_config.ca_reload_interval must not be negative")
+	}
+	if cfg.AuthenticationConfig != nil && cfg.AuthenticationConfig.RevocationPollInterval < 0 {
+		return errors.New("auth_config.revocation_poll_interval must not be negative")
+	}
+	if cfg.AuthenticationConfig != nil {
+		if _, err := security.ParseSignatureAlgorithms(cfg.AuthenticationConfig.SignatureAlgorithms); err != nil {
+			return errors.Wrap(err, "auth_config.signature_algorithms")
+		}
+	}
+	if cfg.AuthenticationConfig != nil && cfg.AuthenticationConfig.AgentCA != nil {
+		if cfg.TransportConfig != nil && cfg.TransportConfig.AllowInsecure {
+			return errors.New("auth_config.agent_ca can't be used with allow_insecure")
+		}
+		if err := cfg.AuthenticationConfig.AgentCA.Validate(); err != nil {
+			return errors.Wrap(err, "auth_config.agent_ca")
+		}
+	}
+	if cfg.AuthenticationConfig != nil && cfg.AuthenticationConfig.Cosigning != nil {
+		if cfg.TransportConfig != nil && cfg.TransportConfig.AllowInsecure {
+			return errors.New("auth_config.cosigning can't be used with allow_insecure")
+		}
+		if err := cfg.AuthenticationConfig.Cosigning.Validate(); err != nil {
+			return errors.Wrap(err, "auth_config.cosigning")
+		}
+	}
+	if cfg.AuthenticationConfig != nil {
+		if err := cfg.AuthenticationConfig.AccessManagerTrust.Validate(); err != nil {
+			return errors.Wrap(err, "auth_config.access_manager_trust")
+		}
+		if cfg.AuthenticationConfig.CredentialClockSkew < 0 {
+			return errors.New("auth_config.credential_clock_skew must not be negative")
+		}
+		for _, label := range cfg.AuthenticationConfig.MACLabels {
+			if err := label.Validate(); err != nil {
+				return errors.Wrap(err, "auth_config.mac_labels")
+			}
+		}
+		if err := cfg.AuthenticationConfig.IdentityGroups.Validate(); err != nil {
+			return errors.Wrap(err, "auth_config.identity_groups")
+		}
+		for _, validator := range cfg.AuthenticationConfig.CredentialValidators {
+			if err := validator.Validate(); err != nil {
+				return errors.Wrap(err, "auth_config.credential_validators")
+			}
+		}
+		if err := cfg.AuthenticationConfig.CredentialExpiry.Validate(); err != nil {
+			return errors.Wrap(err, "auth_config.credential_expiry")
+		}
+	}
+	if cfg.AuthenticationConfig != nil && cfg.AuthenticationConfig.CredentialNonces != nil {
+		if cfg.TransportConfig != nil && cfg.TransportConfig.AllowInsecure {
+			return errors.New("auth_config.credential_nonces can't be used with allow_insecure")
+		}
+		if err := cfg.AuthenticationConfig.CredentialNonces.Validate(); err != nil {
+			return errors.Wrap(err, "auth_config.credential_nonces")
+		}
+	}
+
 	return nil
 }
 
@@ -17,6 +17,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/google/go-cmp/cmp"
@@ -93,8 +94,11 @@ func uncommentServerConfig(t *testing.T, outFile string) {
 	defer out.Close()
 
 	// Keep track of keys we've already seen in order
-	// to avoid writing duplicate parameters.
+	// to avoid writing duplicate parameters. Nested keys
+	// are tracked per top-level section, so that sections
+	// may have parameters of the same name.
 	seenKeys := make(map[string]struct{})
+	section := ""
 
 	scn := bufio.NewScanner(in)
 	for scn.Scan() {
@@ -114,10 +118,16 @@ func uncommentServerConfig(t *testing.T, outFile string) {
 		if lineTmp == "-" {
 			seenKeys = make(map[string]struct{})
 		}
-		if _, seen := seenKeys[key]; seen && strings.HasSuffix(key, ":") {
+		seenKey := key
+		if strings.HasPrefix(line, " ") {
+			seenKey = section + key
+		} else if strings.HasSuffix(key, ":") {
+			section = key
+		}
+		if _, seen := seenKeys[seenKey]; seen && strings.HasSuffix(key, ":") {
 			continue
 		}
-		seenKeys[key] = struct{}{}
+		seenKeys[seenKey] = struct{}{}
 
 		line += "\n"
 		if _, err := out.WriteString(line); err != nil {
@@ -325,6 +335,57 @@ func TestServerConfig_Constructed(t *testing.T) {
 			WithStorageEnableHotplug(false).
 			WithStorageAutoFaultyCriteria(false, 0, 0),
 	}
+	constructed.TransportConfig.WeakKeyPolicy = security.WeakKeyWarn
+	constructed.AuthenticationConfig.CAReloadInterval = time.Minute
+	constructed.AuthenticationConfig.RevocationPollInterval = 30 * time.Second
+	constructed.AuthenticationConfig.SignatureAlgorithms = []string{"ed25519", "ecdsa-p256", "rsa-pss"}
+	constructed.AuthenticationConfig.Cosigning = &security.CosignerConfig{
+		CosignerCerts: []string{"/etc/daos/certs/officer.crt"},
+		CosignedIdentities: security.CosignedIdentities{
+			Users:   []string{"root"},
+			Groups:  []string{"daos_admins"},
+			Machine: true,
+		},
+	}
+	constructed.AuthenticationConfig.CredentialClockSkew = 2 * time.Minute
+	constructed.AuthenticationConfig.CredentialExpiry = &security.CredentialExpiryConfig{
+		Required:    true,
+		ClockSkew:   30 * time.Second,
+		MaxLifetime: 24 * time.Hour,
+	}
+	constructed.AuthenticationConfig.CredentialNonces = &security.CredentialNonceConfig{
+		SecretFile: "/etc/daos/credential_nonce.key",
+		Lifetime:   10 * time.Minute,
+	}
+	constructed.AuthenticationConfig.RequireSystemBinding = true
+	constructed.AuthenticationConfig.ProcessBoundPools = []string{"tank"}
+	constructed.AuthenticationConfig.MACLabels = []*security.MACLabelConfig{
+		{Name: "secret", Pattern: "[^:]+:[^:]+:[^:]+:s3(:.*)?"},
+	}
+	constructed.AuthenticationConfig.IdentityGroups = &security.IdentityGroupConfig{
+		MappingFile: "/etc/daos/identity_groups.yml",
+		Mappings: security.IdentityGroupMappings{
+			"oidc:*@idp.example":           {"hpc"},
+			"spiffe:example.org/ns/prod/*": {"prod", "hpc"},
+		},
+	}
+	constructed.AuthenticationConfig.CredentialValidators = []*security.CredentialValidatorConfig{
+		{
+			Name:    "job_check",
+			Timeout: 10 * time.Second,
+			Options: map[string]string{"scheduler": "slurm"},
+		},
+	}
+	constructed.AuthenticationConfig.RequireAgentEnrollment = true
+	constructed.AuthenticationConfig.AccessManagerTrust = &security.AccessManagerTrustConfig{
+		CACerts: []string{"/etc/daos/certs/am_ca.crt"},
+	}
+	constructed.AuthenticationConfig.AgentCA = &security.AgentCAConfig{
+		CACert:       "/etc/daos/certs/daosCA.crt",
+		CAKey:        "/etc/daos/certs/daosCA.key",
+		CertLifetime: 720 * time.Hour,
+		InstallDir:   "/etc/daos/certs/clients",
+	}
 	constructed.Path = testFile // just to avoid failing the cmp
 
 	for i := range constructed.Engines {
@@ -959,6 +1020,117 @@ func TestServerConfig_Validation(t *testing.T) {
 			},
 			expErr: storage.FaultBdevConfigRolesNoControlMetadata,
 		},
+		"agent CA with insecure transport": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithTransportConfig(&security.TransportConfig{AllowInsecure: true})
+			},
+			expErr: errors.New("agent_ca can't be used with allow_insecure"),
+		},
+		"unknown weak key policy": {
+			extraConfig: func(c *Server) *Server {
+				c.TransportConfig.WeakKeyPolicy = "ignore"
+				return c
+			},
+			expErr: errors.New(`transport_config.weak_key_policy: unknown weak key policy "ignore"`),
+		},
+		"negative CA reload interval": {
+			extraConfig: func(c *Server) *Server {
+				c.AuthenticationConfig.CAReloadInterval = -time.Minute
+				return c
+			},
+			expErr: errors.New("ca_reload_interval must not be negative"),
+		},
+		"negative revocation poll interval": {
+			extraConfig: func(c *Server) *Server {
+				c.AuthenticationConfig.RevocationPollInterval = -time.Minute
+				return c
+			},
+			expErr: errors.New("revocation_poll_interval must not be negative"),
+		},
+		"unknown signature algorithm": {
+			extraConfig: func(c *Server) *Server {
+				c.AuthenticationConfig.SignatureAlgorithms = []string{"ed25519", "dsa"}
+				return c
+			},
+			expErr: errors.New(`auth_config.signature_algorithms: unknown signature algorithm "dsa"`),
+		},
+		"cosigning without certs": {
+			extraConfig: func(c *Server) *Server {
+				c.AuthenticationConfig.Cosigning = &security.CosignerConfig{
+					CosignedIdentities: security.CosignedIdentities{Machine: true},
+				}
+				return c
+			},
+			expErr: errors.New("auth_config.cosigning: cosigner_certs must be set"),
+		},
+		"MAC label without pattern": {
+			extraConfig: func(c *Server) *Server {
+				c.AuthenticationConfig.MACLabels = []*security.MACLabelConfig{
+					{Name: "secret"},
+				}
+				return c
+			},
+			expErr: errors.New("auth_config.mac_labels: pattern must be set"),
+		},
+		"credential validator without name": {
+			extraConfig: func(c *Server) *Server {
+				c.AuthenticationConfig.CredentialValidators = []*security.CredentialValidatorConfig{
+					{Timeout: time.Second},
+				}
+				return c
+			},
+			expErr: errors.New("auth_config.credential_validators: name must be set"),
+		},
+		"identity group mapping with no groups": {
+			extraConfig: func(c *Server) *Server {
+				c.AuthenticationConfig.IdentityGroups = &security.IdentityGroupConfig{
+					Mappings: security.IdentityGroupMappings{
+						"oidc:alice@idp.example": nil,
+					},
+				}
+				return c
+			},
+			expErr: errors.New("auth_config.identity_groups: identity \"oidc:alice@idp.example\": no groups"),
+		},
+		"negative clock skew": {
+			extraConfig: func(c *Server) *Server {
+				c.AuthenticationConfig.CredentialClockSkew = -time.Second
+				return c
+			},
+			expErr: errors.New("auth_config.credential_clock_skew must not be negative"),
+		},
+		"credential expiry with negative clock skew": {
+			extraConfig: func(c *Server) *Server {
+				c.AuthenticationConfig.CredentialExpiry = &security.CredentialExpiryConfig{
+					ClockSkew: -time.Second,
+				}
+				return c
+			},
+			expErr: errors.New("auth_config.credential_expiry: clock_skew must not be negative"),
+		},
+		"credential nonces without secret": {
+			extraConfig: func(c *Server) *Server {
+				c.AuthenticationConfig.CredentialNonces = &security.CredentialNonceConfig{
+					Required: true,
+				}
+				return c
+			},
+			expErr: errors.New("auth_config.credential_nonces: secret_file must be set"),
+		},
+		"access manager trust without certs": {
+			extraConfig: func(c *Server) *Server {
+				c.AuthenticationConfig.AccessManagerTrust = &security.AccessManagerTrustConfig{}
+				return c
+			},
+			expErr: errors.New("auth_config.access_manager_trust: ca_certs must be set"),
+		},
+		"agent CA without key": {
+			extraConfig: func(c *Server) *Server {
+				c.AuthenticationConfig.AgentCA = &security.AgentCAConfig{CACert: "/etc/daos/certs/daosCA.crt"}
+				return c
+			},
+			expErr: errors.New("ca_cert and ca_key are required"),
+		},
 		"bdev_exclude addresses clash with bdev_list": {
 			extraConfig: func(c *Server) *Server {
 				c.BdevExclude = c.Engines[0].Storage.GetBdevs().Strings()
@@ -11,6 +11,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
@@ -67,13 +68,30 @@ func checkSocketDir(sockDir string) error {
 }
 
 type drpcServerSetupReq struct {
-	log     logging.Logger
-	sockDir string
-	engines []Engine
-	tc      *security.TransportConfig
-	vaf     []auth.Flavor
-	sysdb   *raft.Database
-	events  *events.PubSub
+	log          logging.Logger
+	sockDir      string
+	engines      []Engine
+	tc           *security.TransportConfig
+	validFlavors *auth.ValidFlavors
+	revocations  *auth.CredentialRevocations
+	enrollments  *auth.AgentEnrollments
+	keyring      *agentKeyring
+	proxyHosts   []string
+	sigAlgs      []security.SignatureAlgorithm
+	cosigners    *auth.CosignatureVerifier
+	amTrust      *auth.AMTrustVerifier
+	expiry       *security.CredentialExpiryConfig
+	nonces       *auth.NonceIssuer
+	clockSkew    time.Duration
+	metrics      *credentialMetrics
+	system       string
+	requireSys   bool
+	boundPools   []string
+	macLabels    *auth.MACLabelPolicy
+	idGroups     *auth.IdentityGroupMap
+	validators   *auth.Validators
+	sysdb        *raft.Database
+	events       *events.PubSub
 }
 
 // drpcServerSetup specifies socket path and starts drpc server.
@@ -92,7 +110,29 @@ func drpcServerSetup(ctx context.Context, req *drpcServerSetupReq) error {
 		return errors.Wrap(err, "unable to create socket server")
 	}
 
-	securityModule := NewSecurityModule(req.log, req.tc, req.vaf)
+	securityModule := NewSecurityModule(req.log, req.tc, nil)
+	if req.validFlavors != nil {
+		securityModule.validAuthFlavors = req.validFlavors
+	}
+	if req.keyring != nil {
+		securityModule.keyring = req.keyring
+	}
+	securityModule.proxyHosts = req.proxyHosts
+	securityModule.sigAlgs = req.sigAlgs
+	securityModule.cosigners = req.cosigners
+	securityModule.amTrust = req.amTrust
+	securityModule.expiry = req.expiry
+	securityModule.nonces = req.nonces
+	securityModule.clockSkew = req.clockSkew
+	securityModule.metrics = req.metrics
+	securityModule.revocations = req.revocations
+	securityModule.enrollments = req.enrollments
+	securityModule.system = req.system
+	securityModule.requireSystem = req.requireSys
+	securityModule.processBoundPools = req.boundPools
+	securityModule.macLabels = req.macLabels
+	securityModule.identityGroups = req.idGroups
+	securityModule.validators = req.validators
 
 	// Create and add our modules
 	drpcServer.RegisterRPCModule(securityModule)
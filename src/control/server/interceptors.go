@@ -8,6 +8,7 @@
 package server
 
 import (
+	"crypto/x509"
 	"fmt"
 	"strings"
 	"time"
@@ -29,7 +30,8 @@ import (
 	"github.com/daos-stack/daos/src/control/system"
 )
 
-func componentFromContext(ctx context.Context) (comp *security.Component, err error) {
+// peerCertFromContext returns the verified certificate of the peer.
+func peerCertFromContext(ctx context.Context) (*x509.Certificate, error) {
 	clientPeer, ok := peer.FromContext(ctx)
 	if !ok {
 		return nil, status.Error(codes.Unauthenticated, "no peer information found")
@@ -46,7 +48,14 @@ func componentFromContext(ctx context.Context) (comp *security.Component, err er
 		return nil, status.Error(codes.Unauthenticated, "unable to verify client certificates")
 	}
 
-	peerCert := certs[0][0]
+	return certs[0][0], nil
+}
+
+func componentFromContext(ctx context.Context) (comp *security.Component, err error) {
+	peerCert, err := peerCertFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	component := security.CommonNameToComponent(peerCert.Subject.CommonName)
 
 	return &component, nil
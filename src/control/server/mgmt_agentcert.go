@@ -0,0 +1,57 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/peer"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// SignAgentCert implements the method defined for the Management Service.
+//
+// Sign a renewal of the certificate the calling agent connected with, for the
+// key of the CSR in the request.
+func (svc *mgmtSvc) SignAgentCert(ctx context.Context, req *mgmtpb.SignAgentCertReq) (*mgmtpb.SignAgentCertResp, error) {
+	if err := svc.checkReplicaRequest(req); err != nil {
+		return nil, err
+	}
+	if svc.agentCA == nil {
+		return nil, errors.New("agent certificate renewal is not enabled (auth_config.agent_ca is not set)")
+	}
+
+	current, err := peerCertFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	from := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		from = p.Addr.String()
+	}
+
+	cert, err := svc.agentCA.Sign(req.GetCsr(), current)
+	if err != nil {
+		svc.log.Noticef("audit: refused to renew agent certificate for %s: %s", from, err)
+		return nil, err
+	}
+	keyID, err := security.PublicKeyID(cert.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	svc.log.Noticef("audit: renewed agent certificate for %s with key %s (expires %s)", from, keyID,
+		cert.NotAfter.Format(time.RFC3339))
+
+	return &mgmtpb.SignAgentCertResp{
+		Cert:   cert.Raw,
+		CaCert: svc.agentCA.Certificate().Raw,
+	}, nil
+}
@@ -0,0 +1,151 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// newTestAgentCA writes a CA certificate and key to a test directory and loads
+// them.
+func newTestAgentCA(t *testing.T) *security.AgentCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "DAOS CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	cfg := &security.AgentCAConfig{
+		CACert: filepath.Join(dir, "daosCA.crt"),
+		CAKey:  filepath.Join(dir, "daosCA.key"),
+	}
+	if err := os.WriteFile(cfg.CACert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), security.MaxCertPerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cfg.CAKey, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), security.MaxUserOnlyKeyPerm); err != nil {
+		t.Fatal(err)
+	}
+
+	ca, err := security.LoadAgentCA(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ca
+}
+
+func TestServer_MgmtSvc_SignAgentCert(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "agent"},
+	}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	agentCA := newTestAgentCA(t)
+
+	for name, tc := range map[string]struct {
+		noCA   bool
+		peerCN string
+		req    *mgmtpb.SignAgentCertReq
+		expErr error
+	}{
+		"not enabled": {
+			noCA:   true,
+			peerCN: "agent",
+			req:    &mgmtpb.SignAgentCertReq{Csr: csr},
+			expErr: errors.New("not enabled"),
+		},
+		"wrong system": {
+			peerCN: "agent",
+			req:    &mgmtpb.SignAgentCertReq{Sys: "bad", Csr: csr},
+			expErr: FaultWrongSystem("bad", build.DefaultSystemName),
+		},
+		"not an agent": {
+			peerCN: "admin",
+			req:    &mgmtpb.SignAgentCertReq{Csr: csr},
+			expErr: errors.New("not an agent certificate"),
+		},
+		"bad csr": {
+			peerCN: "agent",
+			req:    &mgmtpb.SignAgentCertReq{Csr: []byte("garbage")},
+			expErr: errors.New("certificate signing request"),
+		},
+		"success": {
+			peerCN: "agent",
+			req:    &mgmtpb.SignAgentCertReq{Csr: csr},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			if !tc.noCA {
+				svc.agentCA = agentCA
+			}
+			if tc.req.Sys == "" {
+				tc.req.Sys = build.DefaultSystemName
+			}
+
+			resp, err := svc.SignAgentCert(newTestAuthCtx(test.Context(t), tc.peerCN), tc.req)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			cert, err := x509.ParseCertificate(resp.Cert)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := cert.CheckSignatureFrom(agentCA.Certificate()); err != nil {
+				t.Fatal(err)
+			}
+			test.AssertTrue(t, key.PublicKey.Equal(cert.PublicKey), "certificate not issued for the CSR key")
+			test.AssertEqual(t, agentCA.Certificate().Raw, resp.CaCert, "unexpected CA certificate")
+		})
+	}
+}
@@ -0,0 +1,114 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/security/auth"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// agentEnrollmentsProp is the MS property holding the list of agent hosts
+// enrolled throughout the system.
+const agentEnrollmentsProp = "agent_enrollments"
+
+func (svc *mgmtSvc) getEnrollmentList() (*auth.AgentEnrollmentList, error) {
+	propStr, err := system.GetMgmtProperty(svc.sysdb, agentEnrollmentsProp)
+	if err != nil {
+		if system.IsErrSystemAttrNotFound(err) {
+			return new(auth.AgentEnrollmentList), nil
+		}
+		return nil, err
+	}
+
+	list := new(auth.AgentEnrollmentList)
+	if err := json.Unmarshal([]byte(propStr), list); err != nil {
+		return nil, errors.Wrap(err, "invalid agent enrollment list")
+	}
+	return list, nil
+}
+
+func (svc *mgmtSvc) setEnrollmentList(list *auth.AgentEnrollmentList) error {
+	propStr, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	return system.SetMgmtProperty(svc.sysdb, agentEnrollmentsProp, string(propStr))
+}
+
+// SystemEnrollAgent implements the method defined for the Management Service.
+//
+// Enroll an agent host with the key it signs credentials with, or unenroll it,
+// so that each server only accepts credentials from the host signed by that
+// key.
+func (svc *mgmtSvc) SystemEnrollAgent(ctx context.Context, req *mgmtpb.SystemEnrollAgentReq) (*mgmtpb.SystemEnrollAgentResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+
+	svc.enrollmentsLock.Lock()
+	defer svc.enrollmentsLock.Unlock()
+
+	list, err := svc.getEnrollmentList()
+	if err != nil {
+		return nil, err
+	}
+
+	var changed bool
+	if req.Unenroll {
+		changed, err = list.Unenroll(req.AgentHost)
+	} else {
+		changed, err = list.Enroll(req.AgentHost, req.KeyId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		if err := svc.setEnrollmentList(list); err != nil {
+			return nil, err
+		}
+		if req.Unenroll {
+			svc.log.Noticef("audit: unenrolled agent host %q; agent enrollments are at version %d",
+				req.AgentHost, list.Version)
+		} else {
+			svc.log.Noticef("audit: enrolled agent host %q with key %s; agent enrollments are at version %d",
+				req.AgentHost, req.KeyId, list.Version)
+		}
+
+		// Don't wait for the next refresh to apply the enrollment here.
+		svc.enrollments.Update(list)
+	}
+
+	return &mgmtpb.SystemEnrollAgentResp{
+		Version: list.Version,
+		Changed: changed,
+	}, nil
+}
+
+// GetAgentEnrollments implements the method defined for the Management Service.
+//
+// Return the agent hosts enrolled throughout the system.
+func (svc *mgmtSvc) GetAgentEnrollments(ctx context.Context, req *mgmtpb.GetAgentEnrollmentsReq) (*mgmtpb.GetAgentEnrollmentsResp, error) {
+	if err := svc.checkReplicaRequest(req); err != nil {
+		return nil, err
+	}
+
+	list, err := svc.getEnrollmentList()
+	if err != nil {
+		return nil, err
+	}
+
+	return &mgmtpb.GetAgentEnrollmentsResp{
+		Version: list.Version,
+		KeyIds:  list.KeyIDs,
+	}, nil
+}
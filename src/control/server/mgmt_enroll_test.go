@@ -0,0 +1,165 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/build"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_MgmtSvc_SystemEnrollAgent(t *testing.T) {
+	key1 := strings.Repeat("ab", 32)
+	key2 := strings.Repeat("cd", 32)
+
+	for name, tc := range map[string]struct {
+		nonReplica bool
+		propVal    string
+		req        *mgmtpb.SystemEnrollAgentReq
+		expResp    *mgmtpb.SystemEnrollAgentResp
+		expList    *mgmtpb.GetAgentEnrollmentsResp
+		expErr     error
+	}{
+		"not a replica": {
+			nonReplica: true,
+			req:        &mgmtpb.SystemEnrollAgentReq{AgentHost: "host1", KeyId: key1},
+			expErr:     errors.New("replica"),
+		},
+		"first enrollment": {
+			req:     &mgmtpb.SystemEnrollAgentReq{AgentHost: "host1", KeyId: key1},
+			expResp: &mgmtpb.SystemEnrollAgentResp{Version: 1, Changed: true},
+			expList: &mgmtpb.GetAgentEnrollmentsResp{
+				Version: 1,
+				KeyIds:  map[string]string{"host1": key1},
+			},
+		},
+		"new key": {
+			propVal: `{"version":2,"key_ids":{"host1":"` + key1 + `"}}`,
+			req:     &mgmtpb.SystemEnrollAgentReq{AgentHost: "host1", KeyId: key2},
+			expResp: &mgmtpb.SystemEnrollAgentResp{Version: 3, Changed: true},
+			expList: &mgmtpb.GetAgentEnrollmentsResp{
+				Version: 3,
+				KeyIds:  map[string]string{"host1": key2},
+			},
+		},
+		"already enrolled": {
+			propVal: `{"version":2,"key_ids":{"host1":"` + key1 + `"}}`,
+			req:     &mgmtpb.SystemEnrollAgentReq{AgentHost: "host1", KeyId: key1},
+			expResp: &mgmtpb.SystemEnrollAgentResp{Version: 2},
+			expList: &mgmtpb.GetAgentEnrollmentsResp{
+				Version: 2,
+				KeyIds:  map[string]string{"host1": key1},
+			},
+		},
+		"unenrolled": {
+			propVal: `{"version":2,"key_ids":{"host1":"` + key1 + `"}}`,
+			req:     &mgmtpb.SystemEnrollAgentReq{AgentHost: "host1", Unenroll: true},
+			expResp: &mgmtpb.SystemEnrollAgentResp{Version: 3, Changed: true},
+			expList: &mgmtpb.GetAgentEnrollmentsResp{Version: 3},
+		},
+		"invalid key ID": {
+			req:    &mgmtpb.SystemEnrollAgentReq{AgentHost: "host1", KeyId: "bad"},
+			expErr: errors.New("must be 64 hex characters"),
+		},
+		"corrupted list": {
+			propVal: "garbage",
+			req:     &mgmtpb.SystemEnrollAgentReq{AgentHost: "host1", KeyId: key1},
+			expErr:  errors.New("invalid agent enrollment list"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			if tc.nonReplica {
+				svc = newTestMgmtSvcNonReplica(t, log)
+			}
+			svc.enrollments = auth.NewAgentEnrollments(false)
+			if tc.propVal != "" {
+				if err := system.SetMgmtProperty(svc.sysdb, agentEnrollmentsProp, tc.propVal); err != nil {
+					t.Fatal(err)
+				}
+			}
+			tc.req.Sys = build.DefaultSystemName
+
+			resp, err := svc.SystemEnrollAgent(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.expResp, resp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+			if tc.expResp.Changed {
+				test.AssertEqual(t, tc.expResp.Version, svc.enrollments.Version(),
+					"enrollments not applied locally")
+			}
+
+			list, err := svc.GetAgentEnrollments(test.Context(t),
+				&mgmtpb.GetAgentEnrollmentsReq{Sys: build.DefaultSystemName})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expList, list, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected enrollments (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_MgmtSvc_GetAgentEnrollments(t *testing.T) {
+	for name, tc := range map[string]struct {
+		nonReplica bool
+		req        *mgmtpb.GetAgentEnrollmentsReq
+		expResp    *mgmtpb.GetAgentEnrollmentsResp
+		expErr     error
+	}{
+		"not a replica": {
+			nonReplica: true,
+			req:        &mgmtpb.GetAgentEnrollmentsReq{Sys: build.DefaultSystemName},
+			expErr:     errors.New("replica"),
+		},
+		"wrong system": {
+			req:    &mgmtpb.GetAgentEnrollmentsReq{Sys: "bad"},
+			expErr: FaultWrongSystem("bad", build.DefaultSystemName),
+		},
+		"nothing enrolled": {
+			req:     &mgmtpb.GetAgentEnrollmentsReq{Sys: build.DefaultSystemName},
+			expResp: &mgmtpb.GetAgentEnrollmentsResp{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			if tc.nonReplica {
+				svc = newTestMgmtSvcNonReplica(t, log)
+			}
+
+			resp, err := svc.GetAgentEnrollments(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.expResp, resp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/security/auth"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// validAuthFlavorsProp is the MS property holding the list of auth flavors
+// allowed throughout the system, once it has been changed at runtime.
+const validAuthFlavorsProp = "valid_auth_flavors"
+
+// getValidFlavorList returns the auth flavors allowed throughout the system.
+// Until they are changed at runtime, they are the flavors in the
+// configuration of this server, at version zero.
+func (svc *mgmtSvc) getValidFlavorList() (*auth.ValidFlavorList, error) {
+	propStr, err := system.GetMgmtProperty(svc.sysdb, validAuthFlavorsProp)
+	if err != nil {
+		if system.IsErrSystemAttrNotFound(err) {
+			return &auth.ValidFlavorList{Flavors: slices.Clone(svc.validAuthFlavors)}, nil
+		}
+		return nil, err
+	}
+
+	list := new(auth.ValidFlavorList)
+	if err := json.Unmarshal([]byte(propStr), list); err != nil {
+		return nil, errors.Wrap(err, "invalid auth flavor list")
+	}
+	return list, nil
+}
+
+func (svc *mgmtSvc) setValidFlavorList(list *auth.ValidFlavorList) error {
+	propStr, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	return system.SetMgmtProperty(svc.sysdb, validAuthFlavorsProp, string(propStr))
+}
+
+func flavorsToUint32(flavors []auth.Flavor) []uint32 {
+	nums := make([]uint32, len(flavors))
+	for i, flavor := range flavors {
+		nums[i] = uint32(flavor)
+	}
+	return nums
+}
+
+func flavorsFromUint32(nums []uint32) []auth.Flavor {
+	flavors := make([]auth.Flavor, len(nums))
+	for i, num := range nums {
+		flavors[i] = auth.Flavor(num)
+	}
+	return flavors
+}
+
+// SystemUpdateAuthFlavors implements the method defined for the Management Service.
+//
+// Add and remove auth flavors allowed throughout the system. The flavors are
+// returned to agents in place of those in the server configuration, and each
+// server accepts credentials of the flavors once it has fetched them.
+func (svc *mgmtSvc) SystemUpdateAuthFlavors(ctx context.Context, req *mgmtpb.SystemUpdateAuthFlavorsReq) (*mgmtpb.SystemUpdateAuthFlavorsResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+
+	svc.validFlavorsLock.Lock()
+	defer svc.validFlavorsLock.Unlock()
+
+	list, err := svc.getValidFlavorList()
+	if err != nil {
+		return nil, err
+	}
+	add, remove := flavorsFromUint32(req.Add), flavorsFromUint32(req.Remove)
+	changed, err := list.Update(add, remove)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		if err := svc.setValidFlavorList(list); err != nil {
+			return nil, err
+		}
+		svc.log.Noticef("audit: allowed auth flavors %v and disallowed %v; auth flavors %v are allowed at version %d",
+			add, remove, list.Flavors, list.Version)
+
+		// Don't wait for the next refresh to apply the flavors here.
+		svc.validFlavors.Update(list)
+	}
+
+	return &mgmtpb.SystemUpdateAuthFlavorsResp{
+		Version:          list.Version,
+		Changed:          changed,
+		ValidAuthFlavors: flavorsToUint32(list.Flavors),
+	}, nil
+}
+
+// GetAuthFlavors implements the method defined for the Management Service.
+//
+// Return the auth flavors allowed throughout the system.
+func (svc *mgmtSvc) GetAuthFlavors(ctx context.Context, req *mgmtpb.GetAuthFlavorsReq) (*mgmtpb.GetAuthFlavorsResp, error) {
+	if err := svc.checkReplicaRequest(req); err != nil {
+		return nil, err
+	}
+
+	list, err := svc.getValidFlavorList()
+	if err != nil {
+		return nil, err
+	}
+
+	return &mgmtpb.GetAuthFlavorsResp{
+		Version:          list.Version,
+		ValidAuthFlavors: flavorsToUint32(list.Flavors),
+	}, nil
+}
@@ -0,0 +1,179 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/build"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_MgmtSvc_SystemUpdateAuthFlavors(t *testing.T) {
+	sys := uint32(auth.Flavor_AUTH_SYS)
+	machine := uint32(auth.Flavor_AUTH_MACHINE)
+	totp := uint32(auth.Flavor_AUTH_TOTP)
+
+	for name, tc := range map[string]struct {
+		nonReplica bool
+		propVal    string
+		req        *mgmtpb.SystemUpdateAuthFlavorsReq
+		expResp    *mgmtpb.SystemUpdateAuthFlavorsResp
+		expList    *mgmtpb.GetAuthFlavorsResp
+		expErr     error
+	}{
+		"not a replica": {
+			nonReplica: true,
+			req:        &mgmtpb.SystemUpdateAuthFlavorsReq{Add: []uint32{totp}},
+			expErr:     errors.New("replica"),
+		},
+		"first update": {
+			req: &mgmtpb.SystemUpdateAuthFlavorsReq{Add: []uint32{totp}},
+			expResp: &mgmtpb.SystemUpdateAuthFlavorsResp{
+				Version:          1,
+				Changed:          true,
+				ValidAuthFlavors: []uint32{sys, machine, totp},
+			},
+			expList: &mgmtpb.GetAuthFlavorsResp{
+				Version:          1,
+				ValidAuthFlavors: []uint32{sys, machine, totp},
+			},
+		},
+		"later update": {
+			propVal: `{"version":2,"flavors":[1,21]}`,
+			req:     &mgmtpb.SystemUpdateAuthFlavorsReq{Remove: []uint32{sys}},
+			expResp: &mgmtpb.SystemUpdateAuthFlavorsResp{
+				Version:          3,
+				Changed:          true,
+				ValidAuthFlavors: []uint32{totp},
+			},
+			expList: &mgmtpb.GetAuthFlavorsResp{
+				Version:          3,
+				ValidAuthFlavors: []uint32{totp},
+			},
+		},
+		"unchanged": {
+			req: &mgmtpb.SystemUpdateAuthFlavorsReq{Add: []uint32{sys}},
+			expResp: &mgmtpb.SystemUpdateAuthFlavorsResp{
+				ValidAuthFlavors: []uint32{sys, machine},
+			},
+			expList: &mgmtpb.GetAuthFlavorsResp{
+				ValidAuthFlavors: []uint32{sys, machine},
+			},
+		},
+		"none left": {
+			req:    &mgmtpb.SystemUpdateAuthFlavorsReq{Remove: []uint32{sys, machine}},
+			expErr: errors.New("at least one auth flavor"),
+		},
+		"corrupted list": {
+			propVal: "garbage",
+			req:     &mgmtpb.SystemUpdateAuthFlavorsReq{Add: []uint32{totp}},
+			expErr:  errors.New("invalid auth flavor list"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			if tc.nonReplica {
+				svc = newTestMgmtSvcNonReplica(t, log)
+			}
+			svc.validAuthFlavors = []auth.Flavor{auth.Flavor_AUTH_SYS, auth.Flavor_AUTH_MACHINE}
+			svc.validFlavors = auth.NewValidFlavors(svc.validAuthFlavors)
+			if tc.propVal != "" {
+				if err := system.SetMgmtProperty(svc.sysdb, validAuthFlavorsProp, tc.propVal); err != nil {
+					t.Fatal(err)
+				}
+			}
+			tc.req.Sys = build.DefaultSystemName
+
+			resp, err := svc.SystemUpdateAuthFlavors(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.expResp, resp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+			if tc.expResp.Changed {
+				test.AssertEqual(t, tc.expResp.Version, svc.validFlavors.Version(),
+					"flavors not applied locally")
+			}
+
+			list, err := svc.GetAuthFlavors(test.Context(t),
+				&mgmtpb.GetAuthFlavorsReq{Sys: build.DefaultSystemName})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expList, list, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected flavors (-want, +got):\n%s\n", diff)
+			}
+
+			// The attach info returned to agents carries the stored list.
+			stored, err := svc.getValidFlavorList()
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expList.ValidAuthFlavors, flavorsToUint32(stored.Flavors),
+				"flavors not stored for agents")
+		})
+	}
+}
+
+func TestServer_MgmtSvc_GetAuthFlavors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		nonReplica bool
+		req        *mgmtpb.GetAuthFlavorsReq
+		expResp    *mgmtpb.GetAuthFlavorsResp
+		expErr     error
+	}{
+		"not a replica": {
+			nonReplica: true,
+			req:        &mgmtpb.GetAuthFlavorsReq{Sys: build.DefaultSystemName},
+			expErr:     errors.New("replica"),
+		},
+		"wrong system": {
+			req:    &mgmtpb.GetAuthFlavorsReq{Sys: "bad"},
+			expErr: FaultWrongSystem("bad", build.DefaultSystemName),
+		},
+		"as configured": {
+			req: &mgmtpb.GetAuthFlavorsReq{Sys: build.DefaultSystemName},
+			expResp: &mgmtpb.GetAuthFlavorsResp{
+				ValidAuthFlavors: []uint32{uint32(auth.Flavor_AUTH_SYS)},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			if tc.nonReplica {
+				svc = newTestMgmtSvcNonReplica(t, log)
+			}
+			svc.validAuthFlavors = []auth.Flavor{auth.Flavor_AUTH_SYS}
+
+			resp, err := svc.GetAuthFlavors(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.expResp, resp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
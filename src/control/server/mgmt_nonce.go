@@ -0,0 +1,48 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+)
+
+// maxCredentialNonces is the most nonces issued to an agent in one request.
+const maxCredentialNonces = 1024
+
+// GetCredentialNonce implements the method defined for the Management Service.
+//
+// Issue nonces for the calling agent to record in the credentials it signs,
+// one per credential. Any server accepts each nonce once, until it expires.
+func (svc *mgmtSvc) GetCredentialNonce(ctx context.Context, req *mgmtpb.GetCredentialNonceReq) (*mgmtpb.GetCredentialNonceResp, error) {
+	if err := svc.checkReplicaRequest(req); err != nil {
+		return nil, err
+	}
+	if svc.nonces == nil {
+		return nil, errors.New("credential nonces are not enabled (auth_config.credential_nonces is not set)")
+	}
+
+	count := int(req.GetCount())
+	if count == 0 {
+		count = 1
+	}
+	if count > maxCredentialNonces {
+		count = maxCredentialNonces
+	}
+	nonces, expires, err := svc.nonces.IssueN(count)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mgmtpb.GetCredentialNonceResp{
+		Nonces:  nonces,
+		Expires: uint64(expires.Unix()),
+	}, nil
+}
@@ -0,0 +1,102 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// newTestNonceIssuer returns a nonce issuer with a secret written to the
+// directory.
+func newTestNonceIssuer(t *testing.T, dir string, required bool) *auth.NonceIssuer {
+	t.Helper()
+
+	secretFile := filepath.Join(dir, "nonce.key")
+	if err := os.WriteFile(secretFile, bytes.Repeat([]byte{'s'}, 32), 0600); err != nil {
+		t.Fatal(err)
+	}
+	nonces, err := auth.NewNonceIssuer(&security.CredentialNonceConfig{
+		SecretFile: secretFile,
+		Lifetime:   time.Hour,
+		Required:   required,
+	}, security.DefaultCredentialClockSkew)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return nonces
+}
+
+func TestServer_MgmtSvc_GetCredentialNonce(t *testing.T) {
+	nonces := newTestNonceIssuer(t, t.TempDir(), false)
+
+	for name, tc := range map[string]struct {
+		disabled bool
+		req      *mgmtpb.GetCredentialNonceReq
+		expCount int
+		expErr   error
+	}{
+		"not enabled": {
+			disabled: true,
+			req:      &mgmtpb.GetCredentialNonceReq{},
+			expErr:   errors.New("not enabled"),
+		},
+		"wrong system": {
+			req:    &mgmtpb.GetCredentialNonceReq{Sys: "bad"},
+			expErr: FaultWrongSystem("bad", build.DefaultSystemName),
+		},
+		"success": {
+			req:      &mgmtpb.GetCredentialNonceReq{},
+			expCount: 1,
+		},
+		"several": {
+			req:      &mgmtpb.GetCredentialNonceReq{Count: 16},
+			expCount: 16,
+		},
+		"too many": {
+			req:      &mgmtpb.GetCredentialNonceReq{Count: maxCredentialNonces + 1},
+			expCount: maxCredentialNonces,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			if !tc.disabled {
+				svc.nonces = nonces
+			}
+			if tc.req.Sys == "" {
+				tc.req.Sys = build.DefaultSystemName
+			}
+
+			resp, err := svc.GetCredentialNonce(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.expCount, len(resp.Nonces), "unexpected number of nonces")
+			test.AssertTrue(t, resp.Expires > uint64(time.Now().Unix()), "nonces already expired")
+			for _, nonce := range resp.Nonces {
+				test.CmpErr(t, nil, nonces.Check(&auth.Token{Nonce: nonce}))
+			}
+		})
+	}
+}
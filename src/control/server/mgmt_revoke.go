@@ -0,0 +1,105 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/security/auth"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// credentialRevocationsProp is the MS property holding the list of
+// credentials revoked throughout the system.
+const credentialRevocationsProp = "credential_revocations"
+
+func (svc *mgmtSvc) getRevocationList() (*auth.RevocationList, error) {
+	propStr, err := system.GetMgmtProperty(svc.sysdb, credentialRevocationsProp)
+	if err != nil {
+		if system.IsErrSystemAttrNotFound(err) {
+			return new(auth.RevocationList), nil
+		}
+		return nil, err
+	}
+
+	list := new(auth.RevocationList)
+	if err := json.Unmarshal([]byte(propStr), list); err != nil {
+		return nil, errors.Wrap(err, "invalid credential revocation list")
+	}
+	return list, nil
+}
+
+func (svc *mgmtSvc) setRevocationList(list *auth.RevocationList) error {
+	propStr, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	return system.SetMgmtProperty(svc.sysdb, credentialRevocationsProp, string(propStr))
+}
+
+// SystemRevokeCredentials implements the method defined for the Management Service.
+//
+// Add credentials to the list of those revoked throughout the system, which
+// each server fetches and checks the credentials presented to it against.
+func (svc *mgmtSvc) SystemRevokeCredentials(ctx context.Context, req *mgmtpb.SystemRevokeCredentialsReq) (*mgmtpb.SystemRevokeCredentialsResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+
+	svc.revocationsLock.Lock()
+	defer svc.revocationsLock.Unlock()
+
+	list, err := svc.getRevocationList()
+	if err != nil {
+		return nil, err
+	}
+	added, err := list.Add(req.KeyIds, req.CredHashes, req.AgentHosts)
+	if err != nil {
+		return nil, err
+	}
+	if added > 0 {
+		if err := svc.setRevocationList(list); err != nil {
+			return nil, err
+		}
+		svc.log.Noticef("audit: revoked credentials signed by keys %v, credentials %v and credentials from agents %v "+
+			"(reason: %q); credential revocation list is at version %d",
+			req.KeyIds, req.CredHashes, req.AgentHosts, req.Reason, list.Version)
+
+		// Don't wait for the next refresh to reject the credentials here.
+		svc.revocations.Update(list)
+	}
+
+	return &mgmtpb.SystemRevokeCredentialsResp{
+		Version: list.Version,
+		Added:   uint32(added),
+	}, nil
+}
+
+// GetCredentialRevocations implements the method defined for the Management Service.
+//
+// Return the list of credentials revoked throughout the system.
+func (svc *mgmtSvc) GetCredentialRevocations(ctx context.Context, req *mgmtpb.GetCredentialRevocationsReq) (*mgmtpb.GetCredentialRevocationsResp, error) {
+	if err := svc.checkReplicaRequest(req); err != nil {
+		return nil, err
+	}
+
+	list, err := svc.getRevocationList()
+	if err != nil {
+		return nil, err
+	}
+
+	return &mgmtpb.GetCredentialRevocationsResp{
+		Version:    list.Version,
+		KeyIds:     list.KeyIDs,
+		CredHashes: list.CredHashes,
+		AgentHosts: list.AgentHosts,
+	}, nil
+}
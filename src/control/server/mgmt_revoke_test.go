@@ -0,0 +1,171 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/build"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_MgmtSvc_SystemRevokeCredentials(t *testing.T) {
+	keyID := strings.Repeat("ab", 32)
+
+	for name, tc := range map[string]struct {
+		nonReplica bool
+		propVal    string
+		req        *mgmtpb.SystemRevokeCredentialsReq
+		expResp    *mgmtpb.SystemRevokeCredentialsResp
+		expList    *mgmtpb.GetCredentialRevocationsResp
+		expErr     error
+	}{
+		"not a replica": {
+			nonReplica: true,
+			req:        &mgmtpb.SystemRevokeCredentialsReq{AgentHosts: []string{"host1"}},
+			expErr:     errors.New("replica"),
+		},
+		"first revocation": {
+			req: &mgmtpb.SystemRevokeCredentialsReq{
+				KeyIds:     []string{keyID},
+				AgentHosts: []string{"host1"},
+				Reason:     "compromised",
+			},
+			expResp: &mgmtpb.SystemRevokeCredentialsResp{Version: 1, Added: 2},
+			expList: &mgmtpb.GetCredentialRevocationsResp{
+				Version:    1,
+				KeyIds:     []string{keyID},
+				AgentHosts: []string{"host1"},
+			},
+		},
+		"added to list": {
+			propVal: `{"version":2,"agent_hosts":["host1"]}`,
+			req: &mgmtpb.SystemRevokeCredentialsReq{
+				AgentHosts: []string{"host1", "host2"},
+			},
+			expResp: &mgmtpb.SystemRevokeCredentialsResp{Version: 3, Added: 1},
+			expList: &mgmtpb.GetCredentialRevocationsResp{
+				Version:    3,
+				AgentHosts: []string{"host1", "host2"},
+			},
+		},
+		"already revoked": {
+			propVal: `{"version":2,"agent_hosts":["host1"]}`,
+			req: &mgmtpb.SystemRevokeCredentialsReq{
+				AgentHosts: []string{"host1"},
+			},
+			expResp: &mgmtpb.SystemRevokeCredentialsResp{Version: 2},
+			expList: &mgmtpb.GetCredentialRevocationsResp{
+				Version:    2,
+				AgentHosts: []string{"host1"},
+			},
+		},
+		"invalid key ID": {
+			req: &mgmtpb.SystemRevokeCredentialsReq{
+				KeyIds: []string{"bad"},
+			},
+			expErr: errors.New("must be 64 hex characters"),
+		},
+		"corrupted list": {
+			propVal: "garbage",
+			req: &mgmtpb.SystemRevokeCredentialsReq{
+				AgentHosts: []string{"host1"},
+			},
+			expErr: errors.New("invalid credential revocation list"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			if tc.nonReplica {
+				svc = newTestMgmtSvcNonReplica(t, log)
+			}
+			svc.revocations = auth.NewCredentialRevocations()
+			if tc.propVal != "" {
+				if err := system.SetMgmtProperty(svc.sysdb, credentialRevocationsProp, tc.propVal); err != nil {
+					t.Fatal(err)
+				}
+			}
+			tc.req.Sys = build.DefaultSystemName
+
+			resp, err := svc.SystemRevokeCredentials(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.expResp, resp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+			if tc.expResp.Added > 0 {
+				test.AssertEqual(t, tc.expResp.Version, svc.revocations.Version(),
+					"revocations not applied locally")
+			}
+
+			list, err := svc.GetCredentialRevocations(test.Context(t),
+				&mgmtpb.GetCredentialRevocationsReq{Sys: build.DefaultSystemName})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expList, list, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected revocation list (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_MgmtSvc_GetCredentialRevocations(t *testing.T) {
+	for name, tc := range map[string]struct {
+		nonReplica bool
+		req        *mgmtpb.GetCredentialRevocationsReq
+		expResp    *mgmtpb.GetCredentialRevocationsResp
+		expErr     error
+	}{
+		"not a replica": {
+			nonReplica: true,
+			req:        &mgmtpb.GetCredentialRevocationsReq{Sys: build.DefaultSystemName},
+			expErr:     errors.New("replica"),
+		},
+		"wrong system": {
+			req:    &mgmtpb.GetCredentialRevocationsReq{Sys: "bad"},
+			expErr: FaultWrongSystem("bad", build.DefaultSystemName),
+		},
+		"nothing revoked": {
+			req:     &mgmtpb.GetCredentialRevocationsReq{Sys: build.DefaultSystemName},
+			expResp: &mgmtpb.GetCredentialRevocationsResp{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			if tc.nonReplica {
+				svc = newTestMgmtSvcNonReplica(t, log)
+			}
+
+			resp, err := svc.GetCredentialRevocations(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.expResp, resp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"context"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -24,6 +25,7 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/daos"
 	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
 	"github.com/daos-stack/daos/src/control/security/auth"
 	"github.com/daos-stack/daos/src/control/system"
 	"github.com/daos-stack/daos/src/control/system/raft"
@@ -85,6 +87,15 @@ type mgmtSvc struct {
 	groupUpdateReqs   chan bool
 	lastMapVer        uint32
 	validAuthFlavors  []auth.Flavor
+	sigAlgs           []security.SignatureAlgorithm
+	agentCA           *security.AgentCA
+	nonces            *auth.NonceIssuer
+	revocations       *auth.CredentialRevocations
+	revocationsLock   sync.Mutex
+	enrollments       *auth.AgentEnrollments
+	enrollmentsLock   sync.Mutex
+	validFlavors      *auth.ValidFlavors
+	validFlavorsLock  sync.Mutex
 }
 
 func newMgmtSvc(h *EngineHarness, m *system.Membership, s *raft.Database, c control.UnaryInvoker, p *events.PubSub, a []auth.Flavor) *mgmtSvc {
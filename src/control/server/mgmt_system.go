@@ -129,13 +129,15 @@ func (svc *mgmtSvc) GetAttachInfo(ctx context.Context, req *mgmtpb.GetAttachInfo
 		}
 	}
 
-	vaf := make([]uint32, len(svc.validAuthFlavors))
-
-	for index, value := range svc.validAuthFlavors {
-		vaf[index] = uint32(value)
+	flavors, err := svc.getValidFlavorList()
+	if err != nil {
+		return nil, err
 	}
+	resp.ValidAuthFlavors = flavorsToUint32(flavors.Flavors)
 
-	resp.ValidAuthFlavors = vaf
+	for _, alg := range svc.sigAlgs {
+		resp.SignatureAlgorithms = append(resp.SignatureAlgorithms, string(alg))
+	}
 
 	return resp, nil
 }
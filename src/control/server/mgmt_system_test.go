@@ -39,6 +39,7 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/hardware"
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
 	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
 	"github.com/daos-stack/daos/src/control/server/storage"
 	"github.com/daos-stack/daos/src/control/system"
 	"github.com/daos-stack/daos/src/control/system/raft"
@@ -107,6 +108,7 @@ func TestServer_MgmtSvc_GetAttachInfo(t *testing.T) {
 	for name, tc := range map[string]struct {
 		svc               *mgmtSvc
 		clientNetworkHint *mgmtpb.ClientNetHint
+		sigAlgs           []security.SignatureAlgorithm
 		req               *mgmtpb.GetAttachInfoReq
 		expResp           *mgmtpb.GetAttachInfoResp
 	}{
@@ -203,6 +205,34 @@ func TestServer_MgmtSvc_GetAttachInfo(t *testing.T) {
 				Sys:         build.DefaultSystemName,
 			},
 		},
+		"signature algorithms advertised": {
+			clientNetworkHint: &mgmtpb.ClientNetHint{
+				Provider:    "ofi+tcp",
+				CrtTimeout:  5,
+				NetDevClass: uint32(hardware.Ether),
+			},
+			sigAlgs: []security.SignatureAlgorithm{security.SignatureEd25519, security.SignatureRSAPSS},
+			req: &mgmtpb.GetAttachInfoReq{
+				Sys: build.DefaultSystemName,
+			},
+			expResp: &mgmtpb.GetAttachInfoResp{
+				ClientNetHint: &mgmtpb.ClientNetHint{
+					Provider:    "ofi+tcp",
+					CrtTimeout:  5,
+					NetDevClass: uint32(hardware.Ether),
+				},
+				RankUris: []*mgmtpb.GetAttachInfoResp_RankUri{
+					{
+						Rank: msReplica.Rank.Uint32(),
+						Uri:  msReplica.PrimaryFabricURI,
+					},
+				},
+				MsRanks:             []uint32{0},
+				DataVersion:         2,
+				Sys:                 build.DefaultSystemName,
+				SignatureAlgorithms: []string{"ed25519", "rsa-pss"},
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -230,6 +260,7 @@ func TestServer_MgmtSvc_GetAttachInfo(t *testing.T) {
 				t.Fatal(err)
 			}
 			tc.svc.clientNetworkHint = []*mgmtpb.ClientNetHint{tc.clientNetworkHint}
+			tc.svc.sigAlgs = tc.sigAlgs
 			gotResp, gotErr := tc.svc.GetAttachInfo(test.Context(t), tc.req)
 			if gotErr != nil {
 				t.Fatalf("unexpected error: %+v\n", gotErr)
@@ -0,0 +1,69 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// enrollmentPoller keeps the list of agent hosts enrolled throughout the
+// system, which the server checks the credentials presented to its engines
+// against, current by fetching it from the management service.
+type enrollmentPoller struct {
+	log         logging.Logger
+	invoker     control.UnaryInvoker
+	system      string
+	replicas    []string
+	enrollments *auth.AgentEnrollments
+}
+
+// refresh fetches the enrollments and installs them if they are newer than
+// the current ones.
+func (ep *enrollmentPoller) refresh(ctx context.Context) error {
+	req := &control.GetAgentEnrollmentsReq{}
+	req.SetHostList(ep.replicas)
+	req.SetSystem(ep.system)
+
+	resp, err := control.GetAgentEnrollments(ctx, ep.invoker, req)
+	if err != nil {
+		return err
+	}
+
+	if ep.enrollments.Update(&auth.AgentEnrollmentList{
+		Version: resp.Version,
+		KeyIDs:  resp.KeyIDs,
+	}) {
+		ep.log.Noticef("audit: installed agent enrollments version %d (%d hosts)", resp.Version, len(resp.KeyIDs))
+	}
+	return nil
+}
+
+// run refreshes the enrollments at the interval until the context is
+// canceled. Until they can be fetched, the last enrollments installed are
+// used.
+func (ep *enrollmentPoller) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := ep.refresh(ctx); err != nil {
+			ep.log.Debugf("failed to fetch agent enrollments (version %d in use): %s",
+				ep.enrollments.Version(), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,96 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func TestServer_enrollmentPoller_refresh(t *testing.T) {
+	key1 := strings.Repeat("ab", 32)
+	key2 := strings.Repeat("cd", 32)
+	sys, err := proto.Marshal(&auth.Sys{Machinename: "host1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cred := &auth.Credential{
+		Token:    &auth.Token{Flavor: auth.Flavor_AUTH_SYS, Data: sys},
+		Verifier: &auth.Token{Data: []byte("verifier")},
+	}
+	enrolled := map[string]string{"host1": key1}
+
+	for name, tc := range map[string]struct {
+		curList     *auth.AgentEnrollmentList
+		resp        *mgmtpb.GetAgentEnrollmentsResp
+		respErr     error
+		expErr      error
+		expVersion  uint64
+		expRejected bool
+	}{
+		"fetch fails": {
+			curList:     &auth.AgentEnrollmentList{Version: 1, KeyIDs: enrolled},
+			respErr:     errors.New("no leader"),
+			expErr:      errors.New("no leader"),
+			expVersion:  1,
+			expRejected: true,
+		},
+		"nothing enrolled": {
+			resp: &mgmtpb.GetAgentEnrollmentsResp{},
+		},
+		"newer list": {
+			curList: &auth.AgentEnrollmentList{Version: 1},
+			resp: &mgmtpb.GetAgentEnrollmentsResp{
+				Version: 2,
+				KeyIds:  enrolled,
+			},
+			expVersion:  2,
+			expRejected: true,
+		},
+		"older list": {
+			curList: &auth.AgentEnrollmentList{Version: 3, KeyIDs: enrolled},
+			resp: &mgmtpb.GetAgentEnrollmentsResp{
+				Version: 2,
+			},
+			expVersion:  3,
+			expRejected: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			enrollments := auth.NewAgentEnrollments(false)
+			enrollments.Update(tc.curList)
+
+			ep := &enrollmentPoller{
+				log: log,
+				invoker: control.NewMockInvoker(log, &control.MockInvokerConfig{
+					UnaryResponseSet: []*control.UnaryResponse{
+						control.MockMSResponse("host1", tc.respErr, tc.resp),
+					},
+				}),
+				system:      "daos_server",
+				replicas:    []string{"host1"},
+				enrollments: enrollments,
+			}
+
+			test.CmpErr(t, tc.expErr, ep.refresh(test.Context(t)))
+			test.AssertEqual(t, tc.expVersion, enrollments.Version(), "unexpected version")
+			test.AssertEqual(t, tc.expRejected, enrollments.Check(cred, key2) != nil, "unexpected rejection")
+		})
+	}
+}
@@ -0,0 +1,68 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// validFlavorPoller keeps the auth flavors allowed throughout the system,
+// which the server accepts the credentials presented to its engines in,
+// current by fetching them from the management service.
+type validFlavorPoller struct {
+	log          logging.Logger
+	invoker      control.UnaryInvoker
+	system       string
+	replicas     []string
+	validFlavors *auth.ValidFlavors
+}
+
+// refresh fetches the flavors and installs them if they are newer than the
+// current ones.
+func (vp *validFlavorPoller) refresh(ctx context.Context) error {
+	req := &control.GetAuthFlavorsReq{}
+	req.SetHostList(vp.replicas)
+	req.SetSystem(vp.system)
+
+	resp, err := control.GetAuthFlavors(ctx, vp.invoker, req)
+	if err != nil {
+		return err
+	}
+
+	if vp.validFlavors.Update(&auth.ValidFlavorList{
+		Version: resp.Version,
+		Flavors: resp.ValidAuthFlavors,
+	}) {
+		vp.log.Noticef("audit: installed auth flavors version %d (%v)", resp.Version, resp.ValidAuthFlavors)
+	}
+	return nil
+}
+
+// run refreshes the flavors at the interval until the context is canceled.
+// Until they can be fetched, the last flavors installed are used.
+func (vp *validFlavorPoller) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := vp.refresh(ctx); err != nil {
+			vp.log.Debugf("failed to fetch auth flavors (version %d in use): %s",
+				vp.validFlavors.Version(), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,85 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func TestServer_validFlavorPoller_refresh(t *testing.T) {
+	for name, tc := range map[string]struct {
+		curList    *auth.ValidFlavorList
+		resp       *mgmtpb.GetAuthFlavorsResp
+		respErr    error
+		expErr     error
+		expVersion uint64
+		expFlavors []auth.Flavor
+	}{
+		"fetch fails": {
+			curList:    &auth.ValidFlavorList{Version: 1, Flavors: []auth.Flavor{auth.Flavor_AUTH_TOTP}},
+			respErr:    errors.New("no leader"),
+			expErr:     errors.New("no leader"),
+			expVersion: 1,
+			expFlavors: []auth.Flavor{auth.Flavor_AUTH_TOTP},
+		},
+		"unchanged since startup": {
+			resp: &mgmtpb.GetAuthFlavorsResp{
+				ValidAuthFlavors: []uint32{uint32(auth.Flavor_AUTH_SYS)},
+			},
+			expFlavors: []auth.Flavor{auth.Flavor_AUTH_SYS},
+		},
+		"newer list": {
+			resp: &mgmtpb.GetAuthFlavorsResp{
+				Version:          2,
+				ValidAuthFlavors: []uint32{uint32(auth.Flavor_AUTH_SYS), uint32(auth.Flavor_AUTH_TOTP)},
+			},
+			expVersion: 2,
+			expFlavors: []auth.Flavor{auth.Flavor_AUTH_SYS, auth.Flavor_AUTH_TOTP},
+		},
+		"older list": {
+			curList: &auth.ValidFlavorList{Version: 3, Flavors: []auth.Flavor{auth.Flavor_AUTH_TOTP}},
+			resp: &mgmtpb.GetAuthFlavorsResp{
+				Version:          2,
+				ValidAuthFlavors: []uint32{uint32(auth.Flavor_AUTH_SYS)},
+			},
+			expVersion: 3,
+			expFlavors: []auth.Flavor{auth.Flavor_AUTH_TOTP},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			validFlavors := auth.NewValidFlavors([]auth.Flavor{auth.Flavor_AUTH_SYS})
+			validFlavors.Update(tc.curList)
+
+			vp := &validFlavorPoller{
+				log: log,
+				invoker: control.NewMockInvoker(log, &control.MockInvokerConfig{
+					UnaryResponseSet: []*control.UnaryResponse{
+						control.MockMSResponse("host1", tc.respErr, tc.resp),
+					},
+				}),
+				system:       "daos_server",
+				replicas:     []string{"host1"},
+				validFlavors: validFlavors,
+			}
+
+			test.CmpErr(t, tc.expErr, vp.refresh(test.Context(t)))
+			test.AssertEqual(t, tc.expVersion, validFlavors.Version(), "unexpected version")
+			test.AssertEqual(t, tc.expFlavors, validFlavors.Flavors(), "unexpected flavors")
+		})
+	}
+}
@@ -0,0 +1,183 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"crypto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// agentKeyringRescanInterval limits how often the client certificate directory
+// is scanned again for a key ID which was not found in it, so that credentials
+// with made-up key IDs cannot keep the server busy scanning.
+const agentKeyringRescanInterval = 5 * time.Second
+
+// agentKey is the public key of an agent trusted to sign credentials.
+type agentKey struct {
+	key     crypto.PublicKey
+	path    string
+	modTime time.Time
+}
+
+// agentKeyring holds the public keys of the agents trusted to sign
+// credentials, loaded from the certificates in the client certificate
+// directory and indexed by key ID. A certificate file may hold several
+// certificates, such as the current and previous certificates of an agent whose
+// key is being rotated. The directory is scanned again when it, or
+// the certificate of a key looked up, changes, so that agent keys may be added
+// and removed without restarting the server.
+type agentKeyring struct {
+	sync.Mutex
+	log        logging.Logger
+	dir        string
+	dirModTime time.Time
+	scannedAt  time.Time
+	keys       map[string]*agentKey
+}
+
+func newAgentKeyring(log logging.Logger, dir string) *agentKeyring {
+	return &agentKeyring{
+		log: log,
+		dir: dir,
+	}
+}
+
+// scan loads the keys of all certificates in the directory. Certificates which
+// can't be loaded are logged and skipped.
+func (kr *agentKeyring) scan() error {
+	kr.scannedAt = time.Now()
+
+	fi, err := os.Stat(kr.dir)
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(kr.dir)
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]*agentKey)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".crt") {
+			continue
+		}
+		path := filepath.Join(kr.dir, entry.Name())
+		fileKeys, err := loadAgentKeys(path)
+		if err != nil {
+			kr.log.Errorf("skipping agent certificate %s: %v", path, err)
+			continue
+		}
+		for _, key := range fileKeys {
+			keyID, err := security.PublicKeyID(key.key)
+			if err != nil {
+				kr.log.Errorf("unable to identify key in %s: %v", path, err)
+				continue
+			}
+			if other, found := keys[keyID]; found {
+				kr.log.Debugf("agent key %s is in both %s and %s", keyID, other.path, path)
+				continue
+			}
+			keys[keyID] = key
+		}
+	}
+
+	kr.dirModTime = fi.ModTime()
+	kr.keys = keys
+	kr.log.Debugf("loaded %d agent keys from %s", len(keys), kr.dir)
+	return nil
+}
+
+func loadAgentKeys(path string) ([]*agentKey, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	certs, err := security.LoadCertificates(path)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*agentKey, len(certs))
+	for i, cert := range certs {
+		keys[i] = &agentKey{
+			key:     cert.PublicKey,
+			path:    path,
+			modTime: fi.ModTime(),
+		}
+	}
+	return keys, nil
+}
+
+// changed returns true if the directory, or the certificate of the key, has
+// changed since the directory was last scanned.
+func (kr *agentKeyring) changed(key *agentKey) bool {
+	if kr.keys == nil {
+		return true
+	}
+	if fi, err := os.Stat(kr.dir); err != nil || !fi.ModTime().Equal(kr.dirModTime) {
+		return true
+	}
+	if key == nil {
+		return false
+	}
+	fi, err := os.Stat(key.path)
+	return err != nil || !fi.ModTime().Equal(key.modTime)
+}
+
+// Lookup returns the public key with the ID.
+func (kr *agentKeyring) Lookup(keyID string) (crypto.PublicKey, error) {
+	kr.Lock()
+	defer kr.Unlock()
+
+	key := kr.keys[keyID]
+	if kr.changed(key) || (key == nil && time.Since(kr.scannedAt) >= agentKeyringRescanInterval) {
+		if err := kr.scan(); err != nil {
+			return nil, errors.Wrapf(err, "loading agent certificates from %s", kr.dir)
+		}
+		key = kr.keys[keyID]
+	}
+
+	if key == nil {
+		return nil, errors.Errorf("no agent certificate in %s has key ID %s", kr.dir, keyID)
+	}
+	return key.key, nil
+}
+
+// Host returns the agent host which the certificate holding the key with the
+// ID is named for, once the key has been looked up.
+func (kr *agentKeyring) Host(keyID string) string {
+	kr.Lock()
+	defer kr.Unlock()
+
+	key := kr.keys[keyID]
+	if key == nil {
+		return ""
+	}
+	return strings.TrimSuffix(filepath.Base(key.path), ".crt")
+}
+
+// Reload scans the directory again, so that changes to the agent certificates
+// take effect before the next lookup which would notice them. It returns the
+// number of agent keys loaded.
+func (kr *agentKeyring) Reload() (int, error) {
+	kr.Lock()
+	defer kr.Unlock()
+
+	if err := kr.scan(); err != nil {
+		return 0, errors.Wrapf(err, "loading agent certificates from %s", kr.dir)
+	}
+	return len(kr.keys), nil
+}
@@ -0,0 +1,126 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestSrv_agentKeyring_Lookup(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	newKey := func(name string) string {
+		t.Helper()
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeNamedTestCert(t, tmpDir, name, key)
+		keyID, err := security.PublicKeyID(key.Public())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return keyID
+	}
+	expFound := func(kr *agentKeyring, keyID string, exp bool) {
+		t.Helper()
+		_, err := kr.Lookup(keyID)
+		test.AssertEqual(t, exp, err == nil, "unexpected lookup result: "+keyID)
+	}
+
+	idA := newKey("agent-a")
+	idB := newKey("agent-b")
+	if err := os.WriteFile(filepath.Join(tmpDir, "notes.txt"), []byte("not a cert"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	kr := newAgentKeyring(log, tmpDir)
+	expFound(kr, idA, true)
+	expFound(kr, idB, true)
+	expFound(kr, strings.Repeat("0", 64), false)
+	test.AssertEqual(t, "agent-a", kr.Host(idA), "unexpected host of key")
+	test.AssertEqual(t, "", kr.Host(strings.Repeat("0", 64)), "unexpected host of unknown key")
+
+	// Unknown key IDs don't cause the directory to be scanned again
+	// straight away.
+	scannedAt := kr.scannedAt
+	expFound(kr, strings.Repeat("1", 64), false)
+	test.AssertEqual(t, scannedAt, kr.scannedAt, "directory scanned again")
+
+	// Keys added to the directory are found, and keys removed from it are
+	// no longer trusted.
+	idC := newKey("agent-c")
+	if err := os.Remove(filepath.Join(tmpDir, "agent-a.crt")); err != nil {
+		t.Fatal(err)
+	}
+	expFound(kr, idC, true)
+	expFound(kr, idA, false)
+	expFound(kr, idB, true)
+
+	// A certificate replaced in place is loaded again.
+	idD := newKey("agent-b")
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(filepath.Join(tmpDir, "agent-b.crt"), later, later); err != nil {
+		t.Fatal(err)
+	}
+	expFound(kr, idB, false)
+	expFound(kr, idD, true)
+}
+
+func TestSrv_agentKeyring_Reload(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	kr := newAgentKeyring(log, tmpDir)
+	count, err := kr.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 0, count, "unexpected number of keys")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeNamedTestCert(t, tmpDir, "agent", key)
+	keyID, err := security.PublicKeyID(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The new key is loaded straight away, rather than at the next lookup.
+	count, err = kr.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 1, count, "unexpected number of keys")
+	_, found := kr.keys[keyID]
+	test.AssertTrue(t, found, "new key not loaded")
+
+	kr = newAgentKeyring(log, filepath.Join(tmpDir, "missing"))
+	if _, err := kr.Reload(); err == nil {
+		t.Fatal("expected error for missing directory")
+	}
+}
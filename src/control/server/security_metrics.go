@@ -0,0 +1,63 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// skewCheck is a time-bound check of a credential which allows for clock skew.
+type skewCheck string
+
+const (
+	skewCheckExpiry skewCheck = "expiry"
+	skewCheckNonce  skewCheck = "nonce"
+)
+
+// credentialMetrics exports the server's validation of credentials.
+type credentialMetrics struct {
+	skewCorrections *prometheus.CounterVec
+}
+
+var _ prometheus.Collector = (*credentialMetrics)(nil)
+
+func newCredentialMetrics() *credentialMetrics {
+	return &credentialMetrics{
+		skewCorrections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "server_credential_clock_skew_corrections",
+			Help: "Credentials accepted only because of the clock skew allowed",
+		}, []string{"check"}),
+	}
+}
+
+// skewCorrected records that a credential was accepted by the check only
+// because of the clock skew allowed.
+func (m *credentialMetrics) skewCorrected(check skewCheck) {
+	if m == nil {
+		return
+	}
+
+	m.skewCorrections.WithLabelValues(string(check)).Inc()
+}
+
+// Describe implements the prometheus.Collector interface.
+func (m *credentialMetrics) Describe(ch chan<- *prometheus.Desc) {
+	if m == nil {
+		return
+	}
+
+	m.skewCorrections.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (m *credentialMetrics) Collect(ch chan<- prometheus.Metric) {
+	if m == nil {
+		return
+	}
+
+	m.skewCorrections.Collect(ch)
+}
@@ -0,0 +1,73 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// reloadTrust reloads the CA bundle with which the certificates of peers are
+// verified, and the agent certificates with which credentials are verified, so
+// that CAs and agents can be added or removed without restarting the server. A
+// CA bundle which can't be loaded is refused and the current one kept. The
+// identity group mapping file is reloaded in the same way.
+func (srv *server) reloadTrust() error {
+	var err error
+	if srv.caBundle != nil {
+		changed, count, bundleErr := srv.caBundle.Reload()
+		switch {
+		case bundleErr != nil:
+			err = errors.Wrapf(bundleErr, "reloading CA bundle %s", srv.caBundle.Path())
+		case changed:
+			srv.log.Noticef("audit: reloaded CA bundle %s with %d CA certificates", srv.caBundle.Path(), count)
+		}
+	}
+
+	tc := srv.cfg.TransportConfig
+	if srv.agentKeyring != nil && tc != nil && !tc.AllowInsecure && tc.ClientCertDir != "" {
+		if _, krErr := srv.agentKeyring.Reload(); krErr != nil && err == nil {
+			err = krErr
+		}
+	}
+
+	if srv.identityGroups != nil {
+		changed, count, mapErr := srv.identityGroups.Reload()
+		switch {
+		case mapErr != nil:
+			if err == nil {
+				err = errors.Wrap(mapErr, "reloading identity group mappings")
+			}
+		case changed:
+			srv.log.Noticef("audit: reloaded identity group mapping file %s with %d mappings",
+				srv.identityGroups.Path(), count)
+		}
+	}
+	return err
+}
+
+// runTrustReloader reloads the CA bundle, agent certificates and identity group
+// mappings at the interval until the context is canceled.
+func (srv *server) runTrustReloader(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := srv.reloadTrust(); err != nil {
+			srv.log.Errorf("CA bundle, agent certificates or identity group mappings changed, "+
+				"but could not be reloaded: %s", err)
+		}
+	}
+}
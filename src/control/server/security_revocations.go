@@ -0,0 +1,75 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// defaultRevocationPollInterval is how often the list of revoked credentials
+// is fetched if auth_config.revocation_poll_interval is not set.
+const defaultRevocationPollInterval = time.Minute
+
+// revocationPoller keeps the list of credentials revoked throughout the
+// system, which the server checks the credentials presented to its engines
+// against, current by fetching it from the management service.
+type revocationPoller struct {
+	log         logging.Logger
+	invoker     control.UnaryInvoker
+	system      string
+	replicas    []string
+	revocations *auth.CredentialRevocations
+}
+
+// refresh fetches the revocation list and installs it if it is newer than the
+// current one.
+func (rp *revocationPoller) refresh(ctx context.Context) error {
+	req := &control.GetCredentialRevocationsReq{}
+	req.SetHostList(rp.replicas)
+	req.SetSystem(rp.system)
+
+	resp, err := control.GetCredentialRevocations(ctx, rp.invoker, req)
+	if err != nil {
+		return err
+	}
+
+	if rp.revocations.Update(&auth.RevocationList{
+		Version:    resp.Version,
+		KeyIDs:     resp.KeyIDs,
+		CredHashes: resp.CredHashes,
+		AgentHosts: resp.AgentHosts,
+	}) {
+		rp.log.Noticef("audit: installed credential revocation list version %d (%d keys, %d credentials, %d agents)",
+			resp.Version, len(resp.KeyIDs), len(resp.CredHashes), len(resp.AgentHosts))
+	}
+	return nil
+}
+
+// run refreshes the revocation list at the interval until the context is
+// canceled. Until it can be fetched, the last list installed is used.
+func (rp *revocationPoller) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := rp.refresh(ctx); err != nil {
+			rp.log.Debugf("failed to fetch credential revocation list (version %d in use): %s",
+				rp.revocations.Version(), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,92 @@
+//
+// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+func TestServer_revocationPoller_refresh(t *testing.T) {
+	sys, err := proto.Marshal(&auth.Sys{Machinename: "host1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cred := &auth.Credential{
+		Token:    &auth.Token{Flavor: auth.Flavor_AUTH_SYS, Data: sys},
+		Verifier: &auth.Token{Data: []byte("verifier")},
+	}
+
+	for name, tc := range map[string]struct {
+		curList    *auth.RevocationList
+		resp       *mgmtpb.GetCredentialRevocationsResp
+		respErr    error
+		expErr     error
+		expVersion uint64
+		expRevoked bool
+	}{
+		"fetch fails": {
+			curList:    &auth.RevocationList{Version: 1, AgentHosts: []string{"host1"}},
+			respErr:    errors.New("no leader"),
+			expErr:     errors.New("no leader"),
+			expVersion: 1,
+			expRevoked: true,
+		},
+		"nothing revoked": {
+			resp: &mgmtpb.GetCredentialRevocationsResp{},
+		},
+		"newer list": {
+			curList: &auth.RevocationList{Version: 1},
+			resp: &mgmtpb.GetCredentialRevocationsResp{
+				Version:    2,
+				AgentHosts: []string{"host1"},
+			},
+			expVersion: 2,
+			expRevoked: true,
+		},
+		"older list": {
+			curList: &auth.RevocationList{Version: 3, AgentHosts: []string{"host1"}},
+			resp: &mgmtpb.GetCredentialRevocationsResp{
+				Version: 2,
+			},
+			expVersion: 3,
+			expRevoked: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			revocations := auth.NewCredentialRevocations()
+			revocations.Update(tc.curList)
+
+			rp := &revocationPoller{
+				log: log,
+				invoker: control.NewMockInvoker(log, &control.MockInvokerConfig{
+					UnaryResponseSet: []*control.UnaryResponse{
+						control.MockMSResponse("host1", tc.respErr, tc.resp),
+					},
+				}),
+				system:      "daos_server",
+				replicas:    []string{"host1"},
+				revocations: revocations,
+			}
+
+			test.CmpErr(t, tc.expErr, rp.refresh(test.Context(t)))
+			test.AssertEqual(t, tc.expVersion, revocations.Version(), "unexpected version")
+			test.AssertEqual(t, tc.expRevoked, revocations.Check(cred, "") != nil, "unexpected revocation")
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"slices"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
@@ -26,22 +27,127 @@ import (
 
 // SecurityModule is the security drpc module struct
 type SecurityModule struct {
-	log              logging.Logger
-	config           *security.TransportConfig
-	validAuthFlavors []auth.Flavor
+	log               logging.Logger
+	config            *security.TransportConfig
+	validAuthFlavors  *auth.ValidFlavors
+	consumed          *auth.ConsumptionRecord
+	keyring           *agentKeyring
+	proxyHosts        []string
+	sigAlgs           []security.SignatureAlgorithm
+	cosigners         *auth.CosignatureVerifier
+	amTrust           *auth.AMTrustVerifier
+	expiry            *security.CredentialExpiryConfig
+	nonces            *auth.NonceIssuer
+	clockSkew         time.Duration
+	metrics           *credentialMetrics
+	revocations       *auth.CredentialRevocations
+	enrollments       *auth.AgentEnrollments
+	system            string
+	requireSystem     bool
+	processBoundPools []string
+	macLabels         *auth.MACLabelPolicy
+	identityGroups    *auth.IdentityGroupMap
+	validators        *auth.Validators
 }
 
 // NewSecurityModule creates a new security module with a transport config
 func NewSecurityModule(log logging.Logger, tc *security.TransportConfig, vaf []auth.Flavor) *SecurityModule {
 
-	return &SecurityModule{
+	mod := &SecurityModule{
 		log:              log,
 		config:           tc,
-		validAuthFlavors: vaf,
+		validAuthFlavors: auth.NewValidFlavors(vaf),
+		consumed:         auth.NewConsumptionRecord(auth.OneTimeCredentialLifetime),
+		clockSkew:        security.DefaultCredentialClockSkew,
 	}
+	if tc != nil {
+		mod.keyring = newAgentKeyring(log, tc.ClientCertDir)
+	}
+	return mod
+}
+
+// agentKeys returns the public keys trusted to have signed the credential. A
+// credential which records the ID of its signing key is verified with the key
+// with that ID in any of the certificates in the client certificate directory.
+// Otherwise, it is verified with the keys in the certificate file named for its
+// origin, which may hold the certificates of both the current and the previous
+// key of the agent while its key is being rotated.
+func (m *SecurityModule) agentKeys(cred *auth.Credential) (*auth.TrustedKeys, daos.Status) {
+	if cred.GetKeyId() != "" {
+		keyID, err := auth.ParseKeyID(cred.GetKeyId())
+		if err != nil {
+			m.log.Errorf("malformed credential: %v", err)
+			return nil, daos.InvalidInput
+		}
+		key, err := m.keyring.Lookup(keyID)
+		if err != nil {
+			m.log.Errorf("loading key for credential from %q failed: %v", cred.Origin, err)
+			return nil, daos.UnknownKey
+		}
+		keys, err := auth.NewTrustedKeys(key)
+		if err != nil {
+			m.log.Errorf("key for credential from %q: %v", cred.Origin, err)
+			return nil, daos.BadCert
+		}
+		return keys, daos.Success
+	}
+
+	certName := fmt.Sprintf("%s.crt", cred.Origin)
+	certPath := filepath.Join(m.config.ClientCertDir, certName)
+	certs, err := security.LoadCertificates(certPath)
+	if err != nil {
+		m.log.Errorf("loading certificate %s failed: %v", certPath, err)
+		return nil, daos.NoCert
+	}
+
+	keys, err := auth.NewTrustedKeys()
+	if err != nil {
+		return nil, daos.BadCert
+	}
+	for _, cert := range certs {
+		if _, err := keys.Add(cert.PublicKey); err != nil {
+			m.log.Errorf("unable to identify key in %s: %v", certPath, err)
+			return nil, daos.BadCert
+		}
+	}
+	return keys, daos.Success
 }
 
-func (m *SecurityModule) processValidateCredentials(body []byte) ([]byte, error) {
+// signingHost returns the agent host whose key the credential was verified
+// with: the host enrolled with the key, or else the host which the certificate
+// holding the key is named for. It returns an empty string if the credential
+// was not verified with a key.
+func (m *SecurityModule) signingHost(cred *auth.Credential, keyID string) string {
+	if keyID == "" {
+		return ""
+	}
+	if host := m.enrollments.Host(keyID); host != "" {
+		return host
+	}
+	if cred.GetKeyId() != "" {
+		return m.keyring.Host(keyID)
+	}
+	return cred.Origin
+}
+
+// checkSignatureAlgorithm checks that signatures made with the agent's key use
+// one of the accepted algorithms, if they are restricted.
+func (m *SecurityModule) checkSignatureAlgorithm(key crypto.PublicKey) error {
+	if len(m.sigAlgs) == 0 {
+		return nil
+	}
+
+	alg, err := security.KeySignatureAlgorithm(key)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(m.sigAlgs, alg) {
+		return errors.Errorf("signature algorithm %s is not accepted", alg)
+	}
+	return nil
+}
+
+func (m *SecurityModule) processValidateCredentials(ctx context.Context, body []byte) ([]byte, error) {
 	req := &auth.ValidateCredReq{}
 	err := proto.Unmarshal(body, req)
 	if err != nil {
@@ -54,32 +160,159 @@ func (m *SecurityModule) processValidateCredentials(body []byte) ([]byte, error)
 		return m.validateRespWithStatus(daos.InvalidInput)
 	}
 
-	var key crypto.PublicKey
-	if m.config.AllowInsecure {
-		key = nil
-	} else {
-		certName := fmt.Sprintf("%s.crt", cred.Origin)
-		certPath := filepath.Join(m.config.ClientCertDir, certName)
-		cert, err := security.LoadCertificate(certPath)
-		if err != nil {
-			m.log.Errorf("loading certificate %s failed: %v", certPath, err)
-			return m.validateRespWithStatus(daos.NoCert)
+	var keys *auth.TrustedKeys
+	if !m.config.AllowInsecure {
+		var status daos.Status
+		keys, status = m.agentKeys(cred)
+		if status != daos.Success {
+			return m.validateRespWithStatus(status)
 		}
-		key = cert.PublicKey
 	}
 
-	if !slices.Contains(m.validAuthFlavors, cred.GetToken().Flavor) {
-		return nil, errors.Errorf("token has authentication flavor not supported by server.")
+	if !m.validAuthFlavors.Contains(cred.GetToken().Flavor) {
+		m.log.Errorf("cred rejected: credential from %q has authentication flavor %s, not supported by server",
+			cred.Origin, cred.GetToken().Flavor)
+		return m.validateRespWithStatus(daos.BadAuthFlavor)
 	}
 
 	// Check our verifier
-	err = auth.VerifyToken(key, cred.GetToken(), cred.GetVerifier().GetData())
+	keyID, err := auth.VerifyCredentialWithKeys(keys, cred)
 	if err != nil {
 		m.log.Errorf("cred verification failed: %v", err)
+		return m.validateRespWithStatus(auth.VerificationStatus(err))
+	}
+
+	// The checks below, and the engine, rely on the Sys token which the
+	// tokens of all flavors, including plugin flavors, carry.
+	if err := auth.ValidateSysToken(cred.GetToken()); err != nil {
+		m.log.Errorf("cred rejected: credential from %q: %v", cred.Origin, err)
+		return m.validateRespWithStatus(daos.NoPermission)
+	}
+
+	key := keys.Key(keyID)
+	if !m.config.AllowInsecure {
+		if err := m.checkSignatureAlgorithm(key); err != nil {
+			m.log.Errorf("cred rejected: credential from %q signed by key %s: %v", cred.Origin, keyID, err)
+			return m.validateRespWithStatus(daos.NoPermission)
+		}
+	}
+
+	if err := m.revocations.Check(cred, keyID); err != nil {
+		m.log.Noticef("audit: rejected credential from %q: %v", cred.Origin, err)
+		return m.validateRespWithStatus(auth.VerificationStatus(err))
+	}
+
+	if err := m.enrollments.Check(cred, keyID); err != nil {
+		m.log.Noticef("audit: rejected credential from %q: %v", cred.Origin, err)
+		return m.validateRespWithStatus(daos.NoPermission)
+	}
+
+	now := time.Now()
+	if err := auth.ValidateTokenExpiry(cred.GetToken(), now, m.clockSkew, m.expiry); err != nil {
+		m.log.Errorf("cred rejected: credential from %q: %v", cred.Origin, err)
+		return m.validateRespWithStatus(auth.VerificationStatus(err))
+	}
+	m.noteSkewCorrection(cred, skewCheckExpiry, auth.TokenSkewCorrection(cred.GetToken(), now))
+
+	if err := m.nonces.Check(cred.GetToken()); err != nil {
+		m.log.Errorf("cred rejected: credential from %q: %v", cred.Origin, err)
+		return m.validateRespWithStatus(auth.VerificationStatus(err))
+	}
+	m.noteSkewCorrection(cred, skewCheckNonce, m.nonces.SkewCorrection(cred.GetToken()))
+
+	if err := auth.ValidateTokenSystem(cred.GetToken(), m.system, m.requireSystem); err != nil {
+		m.log.Errorf("cred rejected: credential from %q: %v", cred.Origin, err)
+		return m.validateRespWithStatus(daos.NoPermission)
+	}
+
+	if err := auth.ValidateProcessBinding(cred.GetToken(), m.processBoundPools, req.GetPool(), req.GetPoolLabel()); err != nil {
+		m.log.Errorf("cred rejected: credential from %q: %v", cred.Origin, err)
+		return m.validateRespWithStatus(daos.NoPermission)
+	}
+
+	if !m.config.AllowInsecure {
+		if err := m.cosigners.Verify(cred); err != nil {
+			m.log.Errorf("cred rejected: credential from %q: %v", cred.Origin, err)
+			return m.validateRespWithStatus(daos.NoPermission)
+		}
+	}
+
+	if err := m.amTrust.Verify(cred, time.Now()); err != nil {
+		m.log.Errorf("cred rejected: credential from %q: %v", cred.Origin, err)
+		return m.validateRespWithStatus(auth.VerificationStatus(err))
+	}
+
+	if err := auth.ValidateAnonToken(cred.GetToken()); err != nil {
+		m.log.Errorf("cred rejected: %v", err)
+		return m.validateRespWithStatus(daos.NoPermission)
+	}
+
+	if err := auth.ValidateMachineToken(cred.GetToken()); err != nil {
+		m.log.Errorf("cred rejected: %v", err)
+		return m.validateRespWithStatus(daos.NoPermission)
+	}
+
+	if err := auth.ValidateTokenClass(cred.GetToken()); err != nil {
+		m.log.Errorf("cred rejected: %v", err)
+		return m.validateRespWithStatus(daos.NoPermission)
+	}
+
+	if err := auth.ValidateTOTPToken(cred.GetToken()); err != nil {
+		m.log.Errorf("cred rejected: %v", err)
+		return m.validateRespWithStatus(daos.NoPermission)
+	}
+
+	if err := auth.ValidateDelegationToken(cred.GetToken(), key, time.Now()); err != nil {
+		m.log.Errorf("cred rejected: %v", err)
 		return m.validateRespWithStatus(daos.NoPermission)
 	}
 
-	resp := &auth.ValidateCredResp{Token: cred.Token}
+	if err := auth.ValidateProxyToken(cred.GetToken(), m.proxyHosts, m.signingHost(cred, keyID)); err != nil {
+		m.log.Errorf("cred rejected: %v", err)
+		return m.validateRespWithStatus(daos.NoPermission)
+	}
+
+	// Credentials restricted to a set of pools, such as delegation credentials,
+	// are only accepted for the pool the engine is checking access to.
+	if err := auth.ValidateTokenPool(cred.GetToken(), req.GetPool(), req.GetPoolLabel()); err != nil {
+		m.log.Noticef("audit: rejected credential from %q: %v", cred.Origin, err)
+		return m.validateRespWithStatus(daos.NoPermission)
+	}
+
+	// The validators compiled into the server are the last to check the
+	// credential, before its nonce or one-time use is used up.
+	if err := m.validators.Validate(ctx, cred.GetToken()); err != nil {
+		m.log.Noticef("audit: rejected credential from %q: %v", cred.Origin, err)
+		return m.validateRespWithStatus(auth.VerificationStatus(err))
+	}
+
+	// An engine checking a credential against several pools at once, as when
+	// listing them, checks it without using it up.
+	if !req.GetCheckOnly() {
+		if err := m.nonces.Use(cred.GetToken()); err != nil {
+			m.log.Noticef("audit: rejected credential from %q: %v", cred.Origin, err)
+			return m.validateRespWithStatus(auth.VerificationStatus(err))
+		}
+		if err := m.consumed.Consume(cred); err != nil {
+			m.log.Errorf("cred rejected: %v", err)
+			return m.validateRespWithStatus(daos.NoPermission)
+		}
+	}
+
+	// The hash identifies the credential if it needs to be revoked.
+	m.log.Debugf("accepted credential %s from %q", auth.CredentialHash(cred), cred.Origin)
+
+	// The claims and external identities of the user, and the groups the
+	// identities are mapped to, are presented to the engine's pool and
+	// container access checks as principals, so that ACLs may be written
+	// against them.
+	token, err := auth.WithClaimPrincipals(cred.GetToken(), m.macLabels, m.identityGroups)
+	if err != nil {
+		m.log.Errorf("cred rejected: %v", err)
+		return m.validateRespWithStatus(daos.InvalidInput)
+	}
+
+	resp := &auth.ValidateCredResp{Token: token}
 	responseBytes, err := proto.Marshal(resp)
 	if err != nil {
 		return nil, drpc.MarshalingFailure()
@@ -87,17 +320,31 @@ func (m *SecurityModule) processValidateCredentials(body []byte) ([]byte, error)
 	return responseBytes, nil
 }
 
+// noteSkewCorrection warns that the credential passed the check only because of
+// the clock skew allowed, which suggests that the clocks of the nodes disagree
+// and may soon cause credentials to be rejected.
+func (m *SecurityModule) noteSkewCorrection(cred *auth.Credential, check skewCheck, correction time.Duration) {
+	if correction <= 0 {
+		return
+	}
+
+	m.log.Noticef("credential from %q passed the %s check only because of the clock skew allowed: off by %s",
+		cred.Origin, check, correction)
+	m.metrics.skewCorrected(check)
+}
+
 func (m *SecurityModule) validateRespWithStatus(status daos.Status) ([]byte, error) {
 	return drpc.Marshal(&auth.ValidateCredResp{Status: int32(status)})
 }
 
 // HandleCall is the handler for calls to the SecurityModule
-func (m *SecurityModule) HandleCall(_ context.Context, session *drpc.Session, method drpc.Method, body []byte) ([]byte, error) {
-	if method != daos.MethodValidateCredentials {
+func (m *SecurityModule) HandleCall(ctx context.Context, session *drpc.Session, method drpc.Method, body []byte) ([]byte, error) {
+	switch method {
+	case daos.MethodValidateCredentials:
+		return m.processValidateCredentials(ctx, body)
+	default:
 		return nil, drpc.UnknownMethodFailure()
 	}
-
-	return m.processValidateCredentials(body)
 }
 
 // ID will return Security module ID
@@ -8,7 +8,11 @@
 package server
 
 import (
+	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -16,11 +20,13 @@ import (
 	"math/big"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/daos-stack/daos/src/control/common/test"
@@ -257,7 +263,7 @@ func TestSrvSecurityModule_ValidateCred_Insecure_BadVerifier(t *testing.T) {
 	}
 
 	expectValidateResp(t, resp, &auth.ValidateCredResp{
-		Status: int32(daos.NoPermission),
+		Status: int32(daos.BadSignature),
 	})
 }
 
@@ -267,6 +273,17 @@ func generateTestCert(t *testing.T, dir string) crypto.PrivateKey {
 		t.Fatalf("Failed to generate private key: %v", err)
 	}
 
+	return writeTestCert(t, dir, key)
+}
+
+// writeTestCert writes a self-signed certificate for the key to the directory.
+func writeTestCert(t *testing.T, dir string, key crypto.Signer) crypto.PrivateKey {
+	return writeNamedTestCert(t, dir, "test", key)
+}
+
+// writeNamedTestCert writes a self-signed certificate for the key to the
+// directory, named for the agent.
+func writeNamedTestCert(t *testing.T, dir, name string, key crypto.Signer) crypto.PrivateKey {
 	cert := x509.Certificate{
 		SerialNumber:          big.NewInt(1),
 		IsCA:                  true,
@@ -274,12 +291,12 @@ func generateTestCert(t *testing.T, dir string) crypto.PrivateKey {
 		BasicConstraintsValid: true,
 	}
 
-	bytes, err := x509.CreateCertificate(rand.Reader, &cert, &cert, &key.PublicKey, key)
+	bytes, err := x509.CreateCertificate(rand.Reader, &cert, &cert, key.Public(), key)
 	if err != nil {
 		t.Fatalf("Failed to create certificate: %v", err)
 	}
 
-	path := filepath.Join(dir, "test.crt")
+	path := filepath.Join(dir, name+".crt")
 	f, err := os.Create(path)
 	if err != nil {
 		t.Fatalf("Failed to open cert file for writing: %v", err)
@@ -293,6 +310,309 @@ func generateTestCert(t *testing.T, dir string) crypto.PrivateKey {
 	return key
 }
 
+func TestSrvSecurityModule_ValidateCred_BadFlavor(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	mod := NewSecurityModule(log, insecureTransportConfig(), []auth.Flavor{auth.Flavor_AUTH_MACHINE})
+
+	token := getValidToken(t)
+	reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, nil))
+
+	resp, err := callValidateCreds(t, mod, reqBytes)
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	expectValidateResp(t, resp, &auth.ValidateCredResp{
+		Status: int32(daos.BadAuthFlavor),
+	})
+}
+
+func TestSrvSecurityModule_ValidateCred_FlavorsUpdated(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	mod := NewSecurityModule(log, insecureTransportConfig(), []auth.Flavor{auth.Flavor_AUTH_MACHINE})
+
+	token := getValidToken(t)
+	reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, nil))
+
+	// Flavors allowed at runtime replace those configured.
+	mod.validAuthFlavors.Update(&auth.ValidFlavorList{
+		Version: 1,
+		Flavors: []auth.Flavor{auth.Flavor_AUTH_SYS},
+	})
+
+	resp, err := callValidateCreds(t, mod, reqBytes)
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	expectValidateResp(t, resp, &auth.ValidateCredResp{
+		Token: token,
+	})
+}
+
+// testJobValidator rejects the credentials of the jobs in its options, as a
+// scheduler would those of jobs which are not running.
+type testJobValidator struct {
+	options map[string]string
+}
+
+func (v *testJobValidator) Validate(_ context.Context, _ *auth.Token, sys *auth.Sys) error {
+	switch v.options[sys.GetJobId()] {
+	case "ended":
+		return errors.Wrapf(daos.CredentialExpired, "job %s has ended", sys.GetJobId())
+	case "unknown":
+		return errors.Errorf("job %s is unknown", sys.GetJobId())
+	}
+	return nil
+}
+
+func init() {
+	auth.RegisterValidator("test_job_check", func(_ logging.Logger, options map[string]string) (auth.Validator, error) {
+		return &testJobValidator{options: options}, nil
+	})
+}
+
+func TestSrvSecurityModule_ValidateCred_Validators(t *testing.T) {
+	for name, tc := range map[string]struct {
+		jobID     string
+		expStatus daos.Status
+	}{
+		"accepted": {
+			jobID: "1234",
+		},
+		"rejected": {
+			jobID:     "5678",
+			expStatus: daos.NoPermission,
+		},
+		"rejected with status": {
+			jobID:     "9012",
+			expStatus: daos.CredentialExpired,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, insecureTransportConfig(), []auth.Flavor{auth.Flavor_AUTH_SYS})
+			validators, err := auth.NewValidators(log, []*security.CredentialValidatorConfig{
+				{
+					Name:    "test_job_check",
+					Options: map[string]string{"5678": "unknown", "9012": "ended"},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			mod.validators = validators
+
+			sys := &auth.Sys{
+				Stamp: uint64(time.Now().Unix()),
+				User:  "gooduser@",
+				Group: "goodgroup@",
+				JobId: tc.jobID,
+			}
+			token := &auth.Token{
+				Flavor: auth.Flavor_AUTH_SYS,
+				Data:   marshal(t, sys),
+			}
+			reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, nil))
+
+			resp, err := callValidateCreds(t, mod, reqBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expResp := &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			if tc.expStatus == daos.Success {
+				// The job is also presented to the ACL checks as a claim.
+				sys.Groups = []string{"job=" + tc.jobID + "@claim"}
+				expResp.Token = &auth.Token{
+					Flavor: auth.Flavor_AUTH_SYS,
+					Data:   marshal(t, sys),
+				}
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_Class(t *testing.T) {
+	for name, tc := range map[string]struct {
+		class     auth.CredentialClass
+		expGroups []string
+		expStatus daos.Status
+	}{
+		"not recorded": {},
+		"user": {
+			class:     auth.CredentialClass_CLASS_USER,
+			expGroups: []string{"class=user@claim"},
+		},
+		"machine asserted by user": {
+			class:     auth.CredentialClass_CLASS_MACHINE,
+			expStatus: daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, insecureTransportConfig(), []auth.Flavor{auth.Flavor_AUTH_SYS})
+
+			sys := &auth.Sys{
+				Stamp: uint64(time.Now().Unix()),
+				User:  "gooduser@",
+				Group: "goodgroup@",
+			}
+			token := &auth.Token{
+				Flavor: auth.Flavor_AUTH_SYS,
+				Data:   marshal(t, sys),
+				Class:  tc.class,
+			}
+			reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, nil))
+
+			resp, err := callValidateCreds(t, mod, reqBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expResp := &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			if tc.expStatus == daos.Success {
+				// The class is presented to the ACL checks as a claim.
+				sys.Groups = tc.expGroups
+				expResp.Token = &auth.Token{
+					Flavor: auth.Flavor_AUTH_SYS,
+					Data:   marshal(t, sys),
+					Class:  tc.class,
+				}
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_Claims(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	mod := NewSecurityModule(log, insecureTransportConfig(), []auth.Flavor{auth.Flavor_AUTH_SYS})
+
+	sys := &auth.Sys{
+		Stamp:  uint64(time.Now().Unix()),
+		User:   "gooduser@",
+		Group:  "goodgroup@",
+		JobId:  "1234",
+		Claims: []*auth.Claim{{Key: "project", Value: "climate"}},
+	}
+	token := &auth.Token{
+		Flavor: auth.Flavor_AUTH_SYS,
+		Data:   marshal(t, sys),
+	}
+	reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, nil))
+
+	resp, err := callValidateCreds(t, mod, reqBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sys.Groups = []string{"project=climate@claim", "job=1234@claim"}
+	expectValidateResp(t, resp, &auth.ValidateCredResp{
+		Token: &auth.Token{
+			Flavor: auth.Flavor_AUTH_SYS,
+			Data:   marshal(t, sys),
+		},
+	})
+}
+
+func TestSrvSecurityModule_ValidateCred_AMTrust(t *testing.T) {
+	amKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	amCert, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, amKey.Public(), amKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caPath := filepath.Join(t.TempDir(), "am_ca.crt")
+	if err := os.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: amCert}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	amTrust, err := auth.NewAMTrustVerifier(&security.AccessManagerTrustConfig{CACerts: []string{caPath}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := &auth.Token{
+		Flavor: auth.Flavor_AUTH_ACCMAN,
+		Data:   marshal(t, &auth.Sys{Machinename: "am://", User: "jdoe@am", Groups: []string{}}),
+	}
+	attest := func(statement string) *auth.Attestation {
+		sig, err := security.DefaultTokenSigner().Sign(amKey, []byte(statement))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &auth.Attestation{Statement: []byte(statement), Signature: sig, CertChain: [][]byte{amCert}}
+	}
+	forged := attest(`{"id":"am://root","roles":[]}`)
+	forged.Statement = []byte(`{"id":"am://jdoe","roles":[]}`)
+
+	for name, tc := range map[string]struct {
+		attestation *auth.Attestation
+		expResp     *auth.ValidateCredResp
+	}{
+		"attested": {
+			attestation: attest(`{"id":"am://jdoe","roles":[]}`),
+			expResp:     &auth.ValidateCredResp{Token: token},
+		},
+		"not attested": {
+			expResp: &auth.ValidateCredResp{Status: int32(daos.NoPermission)},
+		},
+		"attested for another identity": {
+			attestation: attest(`{"id":"am://root","roles":[]}`),
+			expResp:     &auth.ValidateCredResp{Status: int32(daos.NoPermission)},
+		},
+		"forged attestation": {
+			attestation: forged,
+			expResp:     &auth.ValidateCredResp{Status: int32(daos.BadSignature)},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, insecureTransportConfig(), []auth.Flavor{auth.Flavor_AUTH_ACCMAN})
+			mod.amTrust = amTrust
+
+			reqBytes := marshal(t, &auth.ValidateCredReq{
+				Cred: &auth.Credential{
+					Token:       token,
+					Verifier:    getVerifierForToken(t, token, nil),
+					Origin:      "test",
+					Attestation: tc.attestation,
+				},
+			})
+
+			resp, err := callValidateCreds(t, mod, reqBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expectValidateResp(t, resp, tc.expResp)
+		})
+	}
+}
+
 func TestSrvSecurityModule_ValidateCred_Secure_OK(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer test.ShowBufferOnFailure(t, buf)
@@ -318,6 +638,886 @@ func TestSrvSecurityModule_ValidateCred_Secure_OK(t *testing.T) {
 	})
 }
 
+func TestSrvSecurityModule_ValidateCred_Secure_Ed25519(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := writeTestCert(t, tmpDir, edKey)
+
+	mod := NewSecurityModule(log, secureTransportConfig(tmpDir), []auth.Flavor{auth.Flavor_AUTH_SYS})
+	token := getValidToken(t)
+
+	reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, key))
+
+	resp, err := callValidateCreds(t, mod, reqBytes)
+
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	expectValidateResp(t, resp, &auth.ValidateCredResp{
+		Token: token,
+	})
+}
+
+func TestSrvSecurityModule_ValidateCred_Secure_ECDSA(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := writeTestCert(t, tmpDir, ecKey)
+
+	mod := NewSecurityModule(log, secureTransportConfig(tmpDir), []auth.Flavor{auth.Flavor_AUTH_SYS})
+	token := getValidToken(t)
+
+	reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, key))
+
+	resp, err := callValidateCreds(t, mod, reqBytes)
+
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	expectValidateResp(t, resp, &auth.ValidateCredResp{
+		Token: token,
+	})
+}
+
+func TestSrvSecurityModule_ValidateCred_Secure_SignatureAlgorithm(t *testing.T) {
+	for name, tc := range map[string]struct {
+		sigAlgs   []security.SignatureAlgorithm
+		expStatus daos.Status
+	}{
+		"unrestricted": {},
+		"accepted": {
+			sigAlgs: []security.SignatureAlgorithm{security.SignatureEd25519, security.SignatureECDSAP256},
+		},
+		"not accepted": {
+			sigAlgs:   []security.SignatureAlgorithm{security.SignatureEd25519, security.SignatureRSAPSS},
+			expStatus: daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			tmpDir, tmpCleanup := test.CreateTestDir(t)
+			defer tmpCleanup()
+
+			ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			key := writeTestCert(t, tmpDir, ecKey)
+
+			mod := NewSecurityModule(log, secureTransportConfig(tmpDir), []auth.Flavor{auth.Flavor_AUTH_SYS})
+			mod.sigAlgs = tc.sigAlgs
+			token := getValidToken(t)
+
+			reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, key))
+
+			resp, err := callValidateCreds(t, mod, reqBytes)
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			expResp := &auth.ValidateCredResp{Token: token}
+			if tc.expStatus != daos.Success {
+				expResp = &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_Secure_Cosigned(t *testing.T) {
+	for name, tc := range map[string]struct {
+		users     []string
+		cosign    bool
+		revoke    bool
+		expStatus daos.Status
+	}{
+		"unprivileged": {
+			users: []string{"root"},
+		},
+		"privileged and co-signed": {
+			users:  []string{"gooduser"},
+			cosign: true,
+		},
+		"privileged and not co-signed": {
+			users:     []string{"gooduser"},
+			expStatus: daos.NoPermission,
+		},
+		"co-signed by revoked key": {
+			users:     []string{"gooduser"},
+			cosign:    true,
+			revoke:    true,
+			expStatus: daos.CredentialRevoked,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			tmpDir, tmpCleanup := test.CreateTestDir(t)
+			defer tmpCleanup()
+			officerDir := filepath.Join(tmpDir, "officer")
+			if err := os.Mkdir(officerDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			key := generateTestCert(t, tmpDir)
+			officerECKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			officerKey := writeNamedTestCert(t, officerDir, "officer", officerECKey)
+
+			cosigners, err := auth.NewCosignatureVerifier(&security.CosignerConfig{
+				CosignerCerts:      []string{filepath.Join(officerDir, "officer.crt")},
+				CosignedIdentities: security.CosignedIdentities{Users: tc.users},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			mod := NewSecurityModule(log, secureTransportConfig(tmpDir), []auth.Flavor{auth.Flavor_AUTH_SYS})
+			mod.cosigners = cosigners
+
+			token := getValidToken(t)
+			cred := &auth.Credential{
+				Token:    token,
+				Verifier: getVerifierForToken(t, token, key),
+				Origin:   "test",
+			}
+			if tc.cosign {
+				if cred.Cosignature, cred.CosignerKeyId, err = auth.NewCosignature(officerKey, cred); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.revoke {
+				mod.revocations = auth.NewCredentialRevocations()
+				if err := mod.revocations.RevokeKeys(cred.CosignerKeyId); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			resp, err := callValidateCreds(t, mod, marshal(t, &auth.ValidateCredReq{Cred: cred}))
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			expResp := &auth.ValidateCredResp{Token: token}
+			if tc.expStatus != daos.Success {
+				expResp = &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_Secure_Expiry(t *testing.T) {
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	key := generateTestCert(t, tmpDir)
+	now := time.Now()
+	unix := func(d time.Duration) uint64 {
+		return uint64(now.Add(d).Unix())
+	}
+
+	for name, tc := range map[string]struct {
+		notBefore      uint64
+		expires        uint64
+		clockSkew      time.Duration
+		expiry         *security.CredentialExpiryConfig
+		expStatus      daos.Status
+		expCorrections float64
+	}{
+		"no expiry": {},
+		"no expiry when required": {
+			expiry:    &security.CredentialExpiryConfig{Required: true},
+			expStatus: daos.NoPermission,
+		},
+		"valid": {
+			notBefore: unix(-time.Minute),
+			expires:   unix(time.Hour),
+			expiry:    &security.CredentialExpiryConfig{Required: true},
+		},
+		"expired": {
+			notBefore: unix(-2 * time.Hour),
+			expires:   unix(-time.Hour),
+			expStatus: daos.CredentialExpired,
+		},
+		"not yet valid": {
+			notBefore: unix(time.Hour),
+			expires:   unix(2 * time.Hour),
+			expStatus: daos.CredentialExpired,
+		},
+		"expired within skew": {
+			notBefore:      unix(-time.Hour),
+			expires:        unix(-30 * time.Second),
+			expCorrections: 1,
+		},
+		"not yet valid within configured skew": {
+			notBefore:      unix(3 * time.Minute),
+			expires:        unix(time.Hour),
+			clockSkew:      5 * time.Minute,
+			expCorrections: 1,
+		},
+		"expired beyond configured skew": {
+			notBefore: unix(-time.Hour),
+			expires:   unix(-30 * time.Second),
+			clockSkew: 10 * time.Second,
+			expStatus: daos.CredentialExpired,
+		},
+		"expiry skew overrides configured skew": {
+			notBefore: unix(-time.Hour),
+			expires:   unix(-30 * time.Second),
+			clockSkew: 5 * time.Minute,
+			expiry:    &security.CredentialExpiryConfig{ClockSkew: 10 * time.Second},
+			expStatus: daos.CredentialExpired,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, secureTransportConfig(tmpDir), []auth.Flavor{auth.Flavor_AUTH_SYS})
+			mod.expiry = tc.expiry
+			mod.clockSkew = security.CredentialClockSkew(tc.clockSkew)
+			mod.metrics = newCredentialMetrics()
+
+			token := getValidToken(t)
+			token.NotBefore = tc.notBefore
+			token.Expires = tc.expires
+			cred := &auth.Credential{
+				Token:    token,
+				Verifier: getVerifierForToken(t, token, key),
+				Origin:   "test",
+			}
+
+			resp, err := callValidateCreds(t, mod, marshal(t, &auth.ValidateCredReq{Cred: cred}))
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			expResp := &auth.ValidateCredResp{Token: token}
+			if tc.expStatus != daos.Success {
+				expResp = &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			}
+			expectValidateResp(t, resp, expResp)
+
+			counter, err := mod.metrics.skewCorrections.GetMetricWithLabelValues(string(skewCheckExpiry))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var m dto.Metric
+			if err := counter.Write(&m); err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expCorrections, m.GetCounter().GetValue(), "unexpected skew corrections")
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_Secure_Nonce(t *testing.T) {
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	key := generateTestCert(t, tmpDir)
+	nonces := newTestNonceIssuer(t, tmpDir, true)
+	issued, _, err := nonces.IssueN(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := append([]byte{}, issued[0]...)
+	forged[len(forged)-1] ^= 1
+
+	for name, tc := range map[string]struct {
+		nonces    *auth.NonceIssuer
+		nonce     []byte
+		checkOnly bool
+		replay    bool
+		expStatus daos.Status
+	}{
+		"nonces not enabled": {},
+		"issued nonce": {
+			nonces: nonces,
+			nonce:  issued[0],
+		},
+		"replayed nonce": {
+			nonces:    nonces,
+			nonce:     issued[1],
+			replay:    true,
+			expStatus: daos.NoPermission,
+		},
+		"checked nonce not used up": {
+			nonces:    nonces,
+			nonce:     issued[2],
+			checkOnly: true,
+			replay:    true,
+		},
+		"no nonce": {
+			nonces:    nonces,
+			expStatus: daos.NoPermission,
+		},
+		"forged nonce": {
+			nonces:    nonces,
+			nonce:     forged,
+			expStatus: daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, secureTransportConfig(tmpDir), []auth.Flavor{auth.Flavor_AUTH_SYS})
+			mod.nonces = tc.nonces
+
+			token := getValidToken(t)
+			token.Nonce = tc.nonce
+			cred := &auth.Credential{
+				Token:    token,
+				Verifier: getVerifierForToken(t, token, key),
+				Origin:   "test",
+			}
+
+			req := marshal(t, &auth.ValidateCredReq{Cred: cred, CheckOnly: tc.checkOnly})
+			if tc.replay {
+				if _, err := callValidateCreds(t, mod, req); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			resp, err := callValidateCreds(t, mod, req)
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			expResp := &auth.ValidateCredResp{Token: token}
+			if tc.expStatus != daos.Success {
+				expResp = &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_Secure_System(t *testing.T) {
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	key := generateTestCert(t, tmpDir)
+
+	for name, tc := range map[string]struct {
+		system    string
+		required  bool
+		expStatus daos.Status
+	}{
+		"not bound": {},
+		"not bound; required": {
+			required:  true,
+			expStatus: daos.NoPermission,
+		},
+		"same system": {
+			system:   "daos_server",
+			required: true,
+		},
+		"other system": {
+			system:    "other",
+			expStatus: daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, secureTransportConfig(tmpDir), []auth.Flavor{auth.Flavor_AUTH_SYS})
+			mod.system = "daos_server"
+			mod.requireSystem = tc.required
+
+			token := getValidToken(t)
+			token.System = tc.system
+			cred := &auth.Credential{
+				Token:    token,
+				Verifier: getVerifierForToken(t, token, key),
+				Origin:   "test",
+			}
+
+			resp, err := callValidateCreds(t, mod, marshal(t, &auth.ValidateCredReq{Cred: cred}))
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			expResp := &auth.ValidateCredResp{Token: token}
+			if tc.expStatus != daos.Success {
+				expResp = &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_Secure_ProcessBinding(t *testing.T) {
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	key := generateTestCert(t, tmpDir)
+
+	for name, tc := range map[string]struct {
+		pid        uint32
+		start      uint64
+		boundPools []string
+		expStatus  daos.Status
+	}{
+		"not bound": {},
+		"not bound; required for another pool": {
+			boundPools: []string{"scratch"},
+		},
+		"not bound; required": {
+			boundPools: []string{"tank"},
+			expStatus:  daos.NoPermission,
+		},
+		"bound": {
+			pid:        42,
+			start:      123456,
+			boundPools: []string{"tank"},
+		},
+		"bound without start time": {
+			pid:       42,
+			expStatus: daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, secureTransportConfig(tmpDir), []auth.Flavor{auth.Flavor_AUTH_SYS})
+			mod.processBoundPools = tc.boundPools
+
+			token := &auth.Token{
+				Flavor: auth.Flavor_AUTH_SYS,
+				Data: marshal(t, &auth.Sys{
+					Stamp:        uint64(time.Now().Unix()),
+					User:         "gooduser@",
+					Group:        "goodgroup@",
+					Pid:          tc.pid,
+					ProcessStart: tc.start,
+				}),
+			}
+			cred := &auth.Credential{
+				Token:    token,
+				Verifier: getVerifierForToken(t, token, key),
+				Origin:   "test",
+			}
+
+			resp, err := callValidateCreds(t, mod, marshal(t, &auth.ValidateCredReq{Cred: cred, PoolLabel: "tank"}))
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			expResp := &auth.ValidateCredResp{Token: token}
+			if tc.expStatus != daos.Success {
+				expResp = &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_Secure_Revoked(t *testing.T) {
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	key := generateTestCert(t, tmpDir)
+	keyID, err := security.PublicKeyID(key.(*rsa.PrivateKey).Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := &auth.Token{
+		Flavor: auth.Flavor_AUTH_SYS,
+		Data: marshal(t, &auth.Sys{
+			Stamp:       uint64(time.Now().Unix()),
+			Machinename: "host1",
+			User:        "gooduser@",
+			Group:       "goodgroup@",
+		}),
+	}
+	cred := &auth.Credential{
+		Token:    token,
+		Verifier: getVerifierForToken(t, token, key),
+		Origin:   "test",
+	}
+
+	for name, tc := range map[string]struct {
+		list      *auth.RevocationList
+		expStatus daos.Status
+	}{
+		"nothing revoked": {},
+		"other agent revoked": {
+			list: &auth.RevocationList{Version: 1, AgentHosts: []string{"host2"}},
+		},
+		"agent revoked": {
+			list:      &auth.RevocationList{Version: 1, AgentHosts: []string{"host1"}},
+			expStatus: daos.CredentialRevoked,
+		},
+		"key revoked": {
+			list:      &auth.RevocationList{Version: 1, KeyIDs: []string{keyID}},
+			expStatus: daos.CredentialRevoked,
+		},
+		"credential revoked": {
+			list:      &auth.RevocationList{Version: 1, CredHashes: []string{auth.CredentialHash(cred)}},
+			expStatus: daos.CredentialRevoked,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, secureTransportConfig(tmpDir), []auth.Flavor{auth.Flavor_AUTH_SYS})
+			mod.revocations = auth.NewCredentialRevocations()
+			if tc.list != nil {
+				mod.revocations.Update(tc.list)
+			}
+
+			resp, err := callValidateCreds(t, mod, marshal(t, &auth.ValidateCredReq{Cred: cred}))
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			expResp := &auth.ValidateCredResp{Token: token}
+			if tc.expStatus != daos.Success {
+				expResp = &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_Secure_Enrolled(t *testing.T) {
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	key := generateTestCert(t, tmpDir)
+	keyID, err := security.PublicKeyID(key.(*rsa.PrivateKey).Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKeyID := strings.Repeat("ab", 32)
+	token := &auth.Token{
+		Flavor: auth.Flavor_AUTH_SYS,
+		Data: marshal(t, &auth.Sys{
+			Stamp:       uint64(time.Now().Unix()),
+			Machinename: "host1",
+			User:        "gooduser@",
+			Group:       "goodgroup@",
+		}),
+	}
+	cred := &auth.Credential{
+		Token:    token,
+		Verifier: getVerifierForToken(t, token, key),
+		Origin:   "test",
+	}
+
+	for name, tc := range map[string]struct {
+		keyIDs    map[string]string
+		required  bool
+		expStatus daos.Status
+	}{
+		"nothing enrolled": {},
+		"nothing enrolled; required": {
+			required:  true,
+			expStatus: daos.NoPermission,
+		},
+		"enrolled with key": {
+			keyIDs:   map[string]string{"host1": keyID},
+			required: true,
+		},
+		"enrolled with other key": {
+			keyIDs:    map[string]string{"host1": otherKeyID},
+			expStatus: daos.NoPermission,
+		},
+		"other host enrolled": {
+			keyIDs: map[string]string{"host2": otherKeyID},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, secureTransportConfig(tmpDir), []auth.Flavor{auth.Flavor_AUTH_SYS})
+			mod.enrollments = auth.NewAgentEnrollments(tc.required)
+			mod.enrollments.Update(&auth.AgentEnrollmentList{Version: 1, KeyIDs: tc.keyIDs})
+
+			resp, err := callValidateCreds(t, mod, marshal(t, &auth.ValidateCredReq{Cred: cred}))
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			expResp := &auth.ValidateCredResp{Token: token}
+			if tc.expStatus != daos.Success {
+				expResp = &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_Secure_VerifierHash(t *testing.T) {
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	key := generateTestCert(t, tmpDir)
+
+	for name, tc := range map[string]struct {
+		hash      crypto.Hash
+		alg       auth.HashAlgorithm
+		expStatus daos.Status
+	}{
+		"sha512": {
+			hash: crypto.SHA512,
+			alg:  auth.HashAlgorithm_HASH_SHA512,
+		},
+		"sha256": {
+			hash: crypto.SHA256,
+			alg:  auth.HashAlgorithm_HASH_SHA256,
+		},
+		"sha384": {
+			hash: crypto.SHA384,
+			alg:  auth.HashAlgorithm_HASH_SHA384,
+		},
+		"recorded hash does not match": {
+			hash:      crypto.SHA256,
+			alg:       auth.HashAlgorithm_HASH_SHA384,
+			expStatus: daos.BadSignature,
+		},
+		"unknown hash": {
+			hash:      crypto.SHA256,
+			alg:       auth.HashAlgorithm(42),
+			expStatus: daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, secureTransportConfig(tmpDir), []auth.Flavor{auth.Flavor_AUTH_SYS})
+			token := getValidToken(t)
+
+			signer, err := security.NewTokenSigner(tc.hash)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig, err := signer.Sign(key, marshal(t, token))
+			if err != nil {
+				t.Fatal(err)
+			}
+			verifier := &auth.Token{
+				Flavor: auth.Flavor_AUTH_SYS,
+				Data:   sig,
+				Hash:   tc.alg,
+			}
+
+			resp, err := callValidateCreds(t, mod, getMarshaledValidateCredReq(t, token, verifier))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expResp := &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			if tc.expStatus == 0 {
+				expResp.Token = token
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_Secure_KeyID(t *testing.T) {
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	keys := make(map[string]crypto.PrivateKey)
+	keyIDs := make(map[string]string)
+	for _, name := range []string{"agent-a", "agent-b"} {
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[name] = writeNamedTestCert(t, tmpDir, name, rsaKey)
+		if keyIDs[name], err = security.PublicKeyID(rsaKey.Public()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		signer    string
+		keyID     string
+		expStatus daos.Status
+	}{
+		"first key": {
+			signer: "agent-a",
+			keyID:  keyIDs["agent-a"],
+		},
+		"second key": {
+			signer: "agent-b",
+			keyID:  keyIDs["agent-b"],
+		},
+		"signed by another key": {
+			signer:    "agent-b",
+			keyID:     keyIDs["agent-a"],
+			expStatus: daos.BadSignature,
+		},
+		"unknown key": {
+			signer:    "agent-a",
+			keyID:     strings.Repeat("0", 64),
+			expStatus: daos.UnknownKey,
+		},
+		"malformed key ID": {
+			signer:    "agent-a",
+			keyID:     "bad",
+			expStatus: daos.InvalidInput,
+		},
+		"no key ID and no certificate for origin": {
+			signer:    "agent-a",
+			expStatus: daos.NoCert,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, secureTransportConfig(tmpDir), []auth.Flavor{auth.Flavor_AUTH_SYS})
+			token := getValidToken(t)
+
+			reqBytes := marshal(t, &auth.ValidateCredReq{
+				Cred: &auth.Credential{
+					Token:    token,
+					Verifier: getVerifierForToken(t, token, keys[tc.signer]),
+					Origin:   "test",
+					KeyId:    tc.keyID,
+				},
+			})
+
+			resp, err := callValidateCreds(t, mod, reqBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expResp := &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			if tc.expStatus == daos.Success {
+				expResp.Token = token
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_Secure_RotatedKey(t *testing.T) {
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	// While the agent's key is being rotated, its certificate file holds
+	// the certificates of both its current and its previous key.
+	keys := make(map[string]crypto.PrivateKey)
+	keyIDs := make(map[string]string)
+	var bundle []byte
+	for _, name := range []string{"current", "previous", "other"} {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[name] = writeNamedTestCert(t, tmpDir, name, ecKey)
+		if keyIDs[name], err = security.PublicKeyID(ecKey.Public()); err != nil {
+			t.Fatal(err)
+		}
+		if name == "other" {
+			continue
+		}
+		certPath := filepath.Join(tmpDir, name+".crt")
+		data, err := os.ReadFile(certPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bundle = append(bundle, data...)
+		if err := os.Remove(certPath); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "test.crt"), bundle, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(tmpDir, "other.crt")); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		signer    string
+		withKeyID bool
+		expStatus daos.Status
+	}{
+		"current key": {
+			signer: "current",
+		},
+		"previous key": {
+			signer: "previous",
+		},
+		"previous key by ID": {
+			signer:    "previous",
+			withKeyID: true,
+		},
+		"untrusted key": {
+			signer:    "other",
+			expStatus: daos.BadSignature,
+		},
+		"untrusted key by ID": {
+			signer:    "other",
+			withKeyID: true,
+			expStatus: daos.UnknownKey,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, secureTransportConfig(tmpDir), []auth.Flavor{auth.Flavor_AUTH_SYS})
+			token := getValidToken(t)
+
+			cred := &auth.Credential{
+				Token:    token,
+				Verifier: getVerifierForToken(t, token, keys[tc.signer]),
+				Origin:   "test",
+			}
+			if tc.withKeyID {
+				cred.KeyId = keyIDs[tc.signer]
+			}
+
+			resp, err := callValidateCreds(t, mod, marshal(t, &auth.ValidateCredReq{Cred: cred}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expResp := &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			if tc.expStatus == daos.Success {
+				expResp.Token = token
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
 func TestSrvSecurityModule_ValidateCred_Secure_LoadingCertFailed(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer test.ShowBufferOnFailure(t, buf)
@@ -359,7 +1559,599 @@ func TestSrvSecurityModule_ValidateCred_Secure_BadVerifier(t *testing.T) {
 		t.Errorf("Expected no error, got: %v", err)
 	}
 
+	expectValidateResp(t, resp, &auth.ValidateCredResp{
+		Status: int32(daos.BadSignature),
+	})
+}
+
+func TestSrvSecurityModule_ValidateCred_OneTime_Replay(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	mod := NewSecurityModule(log, insecureTransportConfig(), []auth.Flavor{auth.Flavor_AUTH_SYS})
+
+	tokenData := &auth.Sys{
+		Stamp: uint64(time.Now().Unix()),
+		User:  "gooduser@",
+		Group: "goodgroup@",
+		Scope: auth.Scope_SCOPE_ONE_TIME,
+		Nonce: []byte("nonce"),
+	}
+	token := &auth.Token{
+		Flavor: auth.Flavor_AUTH_SYS,
+		Data:   marshal(t, tokenData),
+	}
+	reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, nil))
+
+	resp, err := callValidateCreds(t, mod, reqBytes)
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+	expectValidateResp(t, resp, &auth.ValidateCredResp{
+		Token: token,
+	})
+
+	// The same one-time credential must not be accepted again.
+	resp, err = callValidateCreds(t, mod, reqBytes)
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
 	expectValidateResp(t, resp, &auth.ValidateCredResp{
 		Status: int32(daos.NoPermission),
 	})
 }
+
+func TestSrvSecurityModule_ValidateCred_PluginFlavor(t *testing.T) {
+	pluginFlavor := auth.MinPluginFlavor
+
+	for name, tc := range map[string]struct {
+		data      []byte
+		expStatus daos.Status
+	}{
+		"sys token": {
+			data: marshal(t, &auth.Sys{
+				Stamp: uint64(time.Now().Unix()),
+				User:  "jdoe@",
+				Group: "users@",
+			}),
+		},
+		"not a sys token": {
+			data:      []byte{0xff, 0xff},
+			expStatus: daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, insecureTransportConfig(), []auth.Flavor{auth.Flavor_AUTH_SYS, pluginFlavor})
+
+			token := &auth.Token{
+				Flavor: pluginFlavor,
+				Data:   tc.data,
+			}
+			reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, nil))
+
+			resp, err := callValidateCreds(t, mod, reqBytes)
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			expResp := &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			if tc.expStatus == daos.Success {
+				expResp.Token = token
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_Anon(t *testing.T) {
+	for name, tc := range map[string]struct {
+		sys       *auth.Sys
+		expStatus daos.Status
+	}{
+		"one-time guest": {
+			sys: &auth.Sys{
+				Stamp: uint64(time.Now().Unix()),
+				User:  "nobody@",
+				Guest: true,
+				Scope: auth.Scope_SCOPE_ONE_TIME,
+				Nonce: []byte("nonce"),
+			},
+		},
+		"not one-time": {
+			sys: &auth.Sys{
+				Stamp: uint64(time.Now().Unix()),
+				User:  "nobody@",
+				Guest: true,
+			},
+			expStatus: daos.NoPermission,
+		},
+		"not a guest": {
+			sys: &auth.Sys{
+				Stamp: uint64(time.Now().Unix()),
+				User:  "root@",
+				Scope: auth.Scope_SCOPE_ONE_TIME,
+				Nonce: []byte("nonce"),
+			},
+			expStatus: daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, insecureTransportConfig(), []auth.Flavor{auth.Flavor_AUTH_ANON})
+
+			token := &auth.Token{
+				Flavor: auth.Flavor_AUTH_ANON,
+				Data:   marshal(t, tc.sys),
+			}
+			reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, nil))
+
+			resp, err := callValidateCreds(t, mod, reqBytes)
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			expResp := &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			if tc.expStatus == daos.Success {
+				expResp.Token = token
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_Machine(t *testing.T) {
+	for name, tc := range map[string]struct {
+		flavor    auth.Flavor
+		sys       *auth.Sys
+		expStatus daos.Status
+	}{
+		"machine credential": {
+			flavor: auth.Flavor_AUTH_MACHINE,
+			sys: &auth.Sys{
+				Stamp:   uint64(time.Now().Unix()),
+				User:    "host/node1.example.com@",
+				Machine: true,
+			},
+		},
+		"machine credential for a user": {
+			flavor: auth.Flavor_AUTH_MACHINE,
+			sys: &auth.Sys{
+				Stamp:   uint64(time.Now().Unix()),
+				User:    "root@",
+				Machine: true,
+			},
+			expStatus: daos.NoPermission,
+		},
+		"user credential for a machine": {
+			flavor: auth.Flavor_AUTH_SYS,
+			sys: &auth.Sys{
+				Stamp: uint64(time.Now().Unix()),
+				User:  "host/node1.example.com@",
+			},
+			expStatus: daos.NoPermission,
+		},
+		"user credential marked as machine": {
+			flavor: auth.Flavor_AUTH_SYS,
+			sys: &auth.Sys{
+				Stamp:   uint64(time.Now().Unix()),
+				User:    "root@",
+				Machine: true,
+			},
+			expStatus: daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, insecureTransportConfig(), []auth.Flavor{auth.Flavor_AUTH_SYS, auth.Flavor_AUTH_MACHINE})
+
+			token := &auth.Token{
+				Flavor: tc.flavor,
+				Data:   marshal(t, tc.sys),
+			}
+			reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, nil))
+
+			resp, err := callValidateCreds(t, mod, reqBytes)
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			expResp := &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			if tc.expStatus == daos.Success {
+				expResp.Token = token
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_TOTP(t *testing.T) {
+	for name, tc := range map[string]struct {
+		flavor    auth.Flavor
+		sys       *auth.Sys
+		expStatus daos.Status
+	}{
+		"totp credential": {
+			flavor: auth.Flavor_AUTH_TOTP,
+			sys: &auth.Sys{
+				Stamp:        uint64(time.Now().Unix()),
+				User:         "jdoe@",
+				SecondFactor: true,
+			},
+		},
+		"totp credential without second factor": {
+			flavor: auth.Flavor_AUTH_TOTP,
+			sys: &auth.Sys{
+				Stamp: uint64(time.Now().Unix()),
+				User:  "jdoe@",
+			},
+			expStatus: daos.NoPermission,
+		},
+		"sys credential claiming second factor": {
+			flavor: auth.Flavor_AUTH_SYS,
+			sys: &auth.Sys{
+				Stamp:        uint64(time.Now().Unix()),
+				User:         "jdoe@",
+				SecondFactor: true,
+			},
+			expStatus: daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, insecureTransportConfig(), []auth.Flavor{auth.Flavor_AUTH_SYS, auth.Flavor_AUTH_TOTP})
+
+			token := &auth.Token{
+				Flavor: tc.flavor,
+				Data:   marshal(t, tc.sys),
+			}
+			reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, nil))
+
+			resp, err := callValidateCreds(t, mod, reqBytes)
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			expResp := &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			if tc.expStatus == daos.Success {
+				expResp.Token = token
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_Delegation(t *testing.T) {
+	delegator := &auth.Sys{
+		Stamp: uint64(time.Now().Unix()),
+		User:  "jdoe@",
+		Group: "users@",
+		Pools: []string{"tank"},
+	}
+	parentToken := &auth.Token{
+		Flavor: auth.Flavor_AUTH_SYS,
+		Data:   marshal(t, delegator),
+	}
+	parent := &auth.Credential{
+		Token:    parentToken,
+		Verifier: getVerifierForToken(t, parentToken, nil),
+		Origin:   "test",
+	}
+	delegated := func(pools []string, expires time.Time) *auth.Sys {
+		sys := proto.Clone(delegator).(*auth.Sys)
+		sys.Pools = pools
+		sys.Delegation = &auth.Delegation{
+			Parent:  parent,
+			Expires: uint64(expires.Unix()),
+		}
+		return sys
+	}
+
+	for name, tc := range map[string]struct {
+		flavor    auth.Flavor
+		sys       *auth.Sys
+		pool      string
+		expStatus daos.Status
+	}{
+		"delegation credential": {
+			flavor: auth.Flavor_AUTH_DELEGATION,
+			sys:    delegated([]string{"tank"}, time.Now().Add(time.Hour)),
+			pool:   "tank",
+		},
+		"delegation credential; out-of-scope pool": {
+			flavor:    auth.Flavor_AUTH_DELEGATION,
+			sys:       delegated([]string{"tank"}, time.Now().Add(time.Hour)),
+			pool:      "home",
+			expStatus: daos.NoPermission,
+		},
+		"delegation credential; no pool": {
+			flavor:    auth.Flavor_AUTH_DELEGATION,
+			sys:       delegated([]string{"tank"}, time.Now().Add(time.Hour)),
+			expStatus: daos.NoPermission,
+		},
+		"expired delegation credential": {
+			flavor:    auth.Flavor_AUTH_DELEGATION,
+			sys:       delegated([]string{"tank"}, time.Now().Add(-time.Second)),
+			pool:      "tank",
+			expStatus: daos.NoPermission,
+		},
+		"delegation beyond delegator's pools": {
+			flavor:    auth.Flavor_AUTH_DELEGATION,
+			sys:       delegated([]string{"tank", "home"}, time.Now().Add(time.Hour)),
+			pool:      "tank",
+			expStatus: daos.NoPermission,
+		},
+		"sys credential claiming delegation": {
+			flavor:    auth.Flavor_AUTH_SYS,
+			sys:       delegated([]string{"tank"}, time.Now().Add(time.Hour)),
+			pool:      "tank",
+			expStatus: daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, insecureTransportConfig(), []auth.Flavor{auth.Flavor_AUTH_SYS, auth.Flavor_AUTH_DELEGATION})
+
+			token := &auth.Token{
+				Flavor: tc.flavor,
+				Data:   marshal(t, tc.sys),
+			}
+			req := &auth.ValidateCredReq{
+				Cred: &auth.Credential{
+					Token:    token,
+					Verifier: getVerifierForToken(t, token, nil),
+					Origin:   "test",
+				},
+				PoolLabel: tc.pool,
+			}
+
+			resp, err := callValidateCreds(t, mod, marshal(t, req))
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			expResp := &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			if tc.expStatus == daos.Success {
+				expResp.Token = token
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_PoolScope(t *testing.T) {
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	key := generateTestCert(t, tmpDir)
+
+	for name, tc := range map[string]struct {
+		flavor    auth.Flavor
+		pools     []string
+		pool      string
+		expStatus daos.Status
+	}{
+		"macaroon; unrestricted": {
+			flavor: auth.Flavor_AUTH_MACAROON,
+			pool:   "home",
+		},
+		"macaroon; in scope": {
+			flavor: auth.Flavor_AUTH_MACAROON,
+			pools:  []string{"tank", "scratch"},
+			pool:   "scratch",
+		},
+		"macaroon; out of scope": {
+			flavor:    auth.Flavor_AUTH_MACAROON,
+			pools:     []string{"tank", "scratch"},
+			pool:      "home",
+			expStatus: daos.NoPermission,
+		},
+		"biscuit; in scope": {
+			flavor: auth.Flavor_AUTH_BISCUIT,
+			pools:  []string{"scratch"},
+			pool:   "scratch",
+		},
+		"biscuit; out of scope": {
+			flavor:    auth.Flavor_AUTH_BISCUIT,
+			pools:     []string{"scratch"},
+			pool:      "home",
+			expStatus: daos.NoPermission,
+		},
+		"exec; in scope": {
+			flavor: auth.Flavor_AUTH_EXEC,
+			pools:  []string{"tank"},
+			pool:   "tank",
+		},
+		"exec; out of scope": {
+			flavor:    auth.Flavor_AUTH_EXEC,
+			pools:     []string{"tank"},
+			pool:      "home",
+			expStatus: daos.NoPermission,
+		},
+		"provider; in scope": {
+			flavor: auth.Flavor_AUTH_PROVIDER,
+			pools:  []string{"tank"},
+			pool:   "tank",
+		},
+		"provider; out of scope": {
+			flavor:    auth.Flavor_AUTH_PROVIDER,
+			pools:     []string{"tank"},
+			pool:      "home",
+			expStatus: daos.NoPermission,
+		},
+		"webhook; in scope": {
+			flavor: auth.Flavor_AUTH_WEBHOOK,
+			pools:  []string{"tank"},
+			pool:   "tank",
+		},
+		"webhook; out of scope": {
+			flavor:    auth.Flavor_AUTH_WEBHOOK,
+			pools:     []string{"tank"},
+			pool:      "home",
+			expStatus: daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := NewSecurityModule(log, secureTransportConfig(tmpDir), []auth.Flavor{tc.flavor})
+
+			token := &auth.Token{
+				Flavor: tc.flavor,
+				Data: marshal(t, &auth.Sys{
+					Stamp: uint64(time.Now().Unix()),
+					User:  "jdoe@",
+					Group: "hpc@",
+					Pools: tc.pools,
+				}),
+			}
+			req := &auth.ValidateCredReq{
+				Cred: &auth.Credential{
+					Token:    token,
+					Verifier: getVerifierForToken(t, token, key),
+					Origin:   "test",
+				},
+				PoolLabel: tc.pool,
+			}
+
+			resp, err := callValidateCreds(t, mod, marshal(t, req))
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			expResp := &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			if tc.expStatus == daos.Success {
+				expResp.Token = token
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
+
+func TestSrvSecurityModule_ValidateCred_Proxy(t *testing.T) {
+	gateway := &auth.Sys{
+		Machinename: "gw1",
+		User:        "jdoe@",
+		Proxy:       &auth.Proxy{Host: "gw1", ClientHost: "ws1"},
+	}
+
+	for name, tc := range map[string]struct {
+		proxyHosts []string
+		certName   string
+		enrolled   string
+		insecure   bool
+		sys        *auth.Sys
+		expStatus  daos.Status
+	}{
+		"permitted gateway": {
+			proxyHosts: []string{"gw1"},
+			certName:   "gw1",
+			sys:        gateway,
+		},
+		"permitted gateway; enrolled": {
+			proxyHosts: []string{"gw1"},
+			certName:   "agent",
+			enrolled:   "gw1",
+			sys:        gateway,
+		},
+		"no gateways permitted": {
+			certName:  "gw1",
+			sys:       gateway,
+			expStatus: daos.NoPermission,
+		},
+		"gateway not permitted": {
+			proxyHosts: []string{"gw1"},
+			certName:   "node1",
+			sys: &auth.Sys{
+				Machinename: "node1",
+				User:        "jdoe@",
+				Proxy:       &auth.Proxy{Host: "node1", ClientHost: "ws1"},
+			},
+			expStatus: daos.NoPermission,
+		},
+		"gateway signed by another host's key": {
+			proxyHosts: []string{"gw1"},
+			certName:   "node1",
+			sys:        gateway,
+			expStatus:  daos.NoPermission,
+		},
+		"gateway signed by another enrolled host's key": {
+			proxyHosts: []string{"gw1"},
+			certName:   "gw1",
+			enrolled:   "node1",
+			sys:        gateway,
+			expStatus:  daos.NoPermission,
+		},
+		"insecure": {
+			proxyHosts: []string{"gw1"},
+			insecure:   true,
+			sys:        gateway,
+			expStatus:  daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			tmpDir, tmpCleanup := test.CreateTestDir(t)
+			defer tmpCleanup()
+
+			sys := proto.Clone(tc.sys).(*auth.Sys)
+			sys.Stamp = uint64(time.Now().Unix())
+			token := &auth.Token{
+				Flavor: auth.Flavor_AUTH_PROXY,
+				Data:   marshal(t, sys),
+			}
+			cred := &auth.Credential{Token: token, Origin: "gw1"}
+
+			ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tcfg := insecureTransportConfig()
+			cred.Verifier = getVerifierForToken(t, token, nil)
+			if !tc.insecure {
+				key := writeNamedTestCert(t, tmpDir, tc.certName, ecKey)
+				cred.Verifier = getVerifierForToken(t, token, key)
+				cred.Origin = tc.certName
+				tcfg = secureTransportConfig(tmpDir)
+			}
+
+			mod := NewSecurityModule(log, tcfg, []auth.Flavor{auth.Flavor_AUTH_SYS, auth.Flavor_AUTH_PROXY})
+			mod.proxyHosts = tc.proxyHosts
+			if tc.enrolled != "" {
+				keyID, err := security.PublicKeyID(ecKey.Public())
+				if err != nil {
+					t.Fatal(err)
+				}
+				mod.enrollments = auth.NewAgentEnrollments(false)
+				mod.enrollments.Update(&auth.AgentEnrollmentList{
+					Version: 1,
+					KeyIDs:  map[string]string{tc.enrolled: keyID},
+				})
+			}
+
+			resp, err := callValidateCreds(t, mod, marshal(t, &auth.ValidateCredReq{Cred: cred}))
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			expResp := &auth.ValidateCredResp{Status: int32(tc.expStatus)}
+			if tc.expStatus == daos.Success {
+				expResp.Token = token
+			}
+			expectValidateResp(t, resp, expResp)
+		})
+	}
+}
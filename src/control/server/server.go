@@ -161,6 +161,20 @@ type server struct {
 	onShutdown       []func()
 
 	validAuthFlavors []auth.Flavor
+	validFlavors     *auth.ValidFlavors
+	sigAlgs          []security.SignatureAlgorithm
+	cosigners        *auth.CosignatureVerifier
+	amTrust          *auth.AMTrustVerifier
+	nonces           *auth.NonceIssuer
+	clockSkew        time.Duration
+	credMetrics      *credentialMetrics
+	macLabels        *auth.MACLabelPolicy
+	identityGroups   *auth.IdentityGroupMap
+	validators       *auth.Validators
+	revocations      *auth.CredentialRevocations
+	enrollments      *auth.AgentEnrollments
+	agentKeyring     *agentKeyring
+	caBundle         *security.CABundle
 }
 
 func newServer(log logging.Logger, cfg *config.Server, faultDomain *system.FaultDomain) (*server, error) {
@@ -181,6 +195,73 @@ func newServer(log logging.Logger, cfg *config.Server, faultDomain *system.Fault
 		return nil, errors.Wrap(err, "Failed to get valid authentication flavors")
 	}
 
+	sigAlgs, err := security.ParseSignatureAlgorithms(cfg.AuthenticationConfig.SignatureAlgorithms)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature algorithms")
+	}
+	if len(sigAlgs) == 0 {
+		sigAlgs = security.SignatureAlgorithms()
+	}
+
+	cosigners, err := auth.NewCosignatureVerifier(cfg.AuthenticationConfig.Cosigning)
+	if err != nil {
+		return nil, errors.Wrap(err, "auth_config.cosigning")
+	}
+	for _, keyID := range cosigners.KeyIDs() {
+		log.Noticef("audit: privileged credentials must be co-signed, accepting co-signing key %s", keyID)
+	}
+
+	amTrust, err := auth.NewAMTrustVerifier(cfg.AuthenticationConfig.AccessManagerTrust)
+	if err != nil {
+		return nil, errors.Wrap(err, "auth_config.access_manager_trust")
+	}
+	if amTrust != nil {
+		log.Noticef("audit: AUTH_ACCMAN credentials must be attested by an access manager certified by %s",
+			strings.Join(cfg.AuthenticationConfig.AccessManagerTrust.CACerts, ", "))
+	}
+
+	clockSkew := security.CredentialClockSkew(cfg.AuthenticationConfig.CredentialClockSkew)
+	nonces, err := auth.NewNonceIssuer(cfg.AuthenticationConfig.CredentialNonces, clockSkew)
+	if err != nil {
+		return nil, errors.Wrap(err, "auth_config.credential_nonces")
+	}
+	if nonces != nil {
+		log.Noticef("audit: issuing credential nonces valid for %s",
+			cfg.AuthenticationConfig.CredentialNonces.NonceLifetime())
+	}
+
+	macLabels, err := auth.NewMACLabelPolicy(cfg.AuthenticationConfig.MACLabels)
+	if err != nil {
+		return nil, errors.Wrap(err, "auth_config.mac_labels")
+	}
+
+	identityGroups, err := auth.NewIdentityGroupMap(cfg.AuthenticationConfig.IdentityGroups)
+	if err != nil {
+		return nil, errors.Wrap(err, "auth_config.identity_groups")
+	}
+
+	validators, err := auth.NewValidators(log, cfg.AuthenticationConfig.CredentialValidators)
+	if err != nil {
+		return nil, errors.Wrap(err, "auth_config.credential_validators")
+	}
+	if validators != nil {
+		log.Noticef("audit: credentials are checked by the validators %s",
+			strings.Join(validators.Names(), ", "))
+	}
+
+	revocations := auth.NewCredentialRevocations()
+	if err := revocations.RevokeKeys(cfg.AuthenticationConfig.RevokedIssuerKeys...); err != nil {
+		return nil, errors.Wrap(err, "invalid revoked issuer key")
+	}
+	for _, keyID := range cfg.AuthenticationConfig.RevokedIssuerKeys {
+		log.Noticef("audit: issuer key %s is revoked by server configuration", keyID)
+	}
+
+	var keyring *agentKeyring
+	if cfg.TransportConfig != nil {
+		keyring = newAgentKeyring(log, cfg.TransportConfig.ClientCertDir)
+	}
+
 	return &server{
 		log:              log,
 		cfg:              cfg,
@@ -189,6 +270,19 @@ func newServer(log logging.Logger, cfg *config.Server, faultDomain *system.Fault
 		faultDomain:      faultDomain,
 		harness:          harness,
 		validAuthFlavors: validAuthFlavors,
+		validFlavors:     auth.NewValidFlavors(validAuthFlavors),
+		sigAlgs:          sigAlgs,
+		cosigners:        cosigners,
+		amTrust:          amTrust,
+		nonces:           nonces,
+		clockSkew:        clockSkew,
+		credMetrics:      newCredentialMetrics(),
+		macLabels:        macLabels,
+		identityGroups:   identityGroups,
+		validators:       validators,
+		revocations:      revocations,
+		enrollments:      auth.NewAgentEnrollments(cfg.AuthenticationConfig.RequireAgentEnrollment),
+		agentKeyring:     keyring,
 	}, nil
 }
 
@@ -256,6 +350,19 @@ func (srv *server) createServices(ctx context.Context) (err error) {
 	srv.ctlSvc = NewControlService(srv.log, srv.harness, srv.cfg, srv.pubSub,
 		network.DefaultFabricScanner(srv.log))
 	srv.mgmtSvc = newMgmtSvc(srv.harness, srv.membership, srv.sysdb, rpcClient, srv.pubSub, srv.validAuthFlavors)
+	srv.mgmtSvc.nonces = srv.nonces
+	srv.mgmtSvc.revocations = srv.revocations
+	srv.mgmtSvc.enrollments = srv.enrollments
+	srv.mgmtSvc.validFlavors = srv.validFlavors
+	srv.mgmtSvc.sigAlgs = srv.sigAlgs
+	if caCfg := srv.cfg.AuthenticationConfig.AgentCA; caCfg != nil {
+		agentCA, err := security.LoadAgentCA(caCfg)
+		if err != nil {
+			return err
+		}
+		srv.mgmtSvc.agentCA = agentCA
+		srv.log.Noticef("audit: signing agent certificate renewals with CA %q", agentCA.Certificate().Subject)
+	}
 
 	if err := srv.mgmtSvc.systemProps.UpdateCompPropVal(daos.SystemPropertyDaosSystem, func() string {
 		return srv.cfg.SystemName
@@ -406,6 +513,17 @@ func (srv *server) addEngines(ctx context.Context, smi *common.SysMemInfo) error
 
 // setupGrpc creates a new grpc server and registers services.
 func (srv *server) setupGrpc() error {
+	if tc := srv.cfg.TransportConfig; tc != nil && !tc.AllowInsecure {
+		caBundle, err := tc.NewCABundle()
+		if err != nil {
+			return err
+		}
+		srv.caBundle = caBundle
+		if err := tc.WeakKeyPolicy.Check(srv.log, tc); err != nil {
+			return err
+		}
+	}
+
 	srvOpts, err := getGrpcOpts(srv.log, srv.cfg.TransportConfig, srv.sysdb.IsLeader)
 	if err != nil {
 		return err
@@ -512,13 +630,30 @@ func (srv *server) start(ctx context.Context) error {
 		build.DaosVersion, os.Getpid(), srv.ctlAddr)
 
 	drpcSetupReq := &drpcServerSetupReq{
-		log:     srv.log,
-		sockDir: srv.cfg.SocketDir,
-		engines: srv.harness.Instances(),
-		tc:      srv.cfg.TransportConfig,
-		vaf:     srv.validAuthFlavors,
-		sysdb:   srv.sysdb,
-		events:  srv.pubSub,
+		log:          srv.log,
+		sockDir:      srv.cfg.SocketDir,
+		engines:      srv.harness.Instances(),
+		tc:           srv.cfg.TransportConfig,
+		validFlavors: srv.validFlavors,
+		revocations:  srv.revocations,
+		enrollments:  srv.enrollments,
+		keyring:      srv.agentKeyring,
+		proxyHosts:   srv.cfg.AuthenticationConfig.ProxyHosts,
+		sigAlgs:      srv.sigAlgs,
+		cosigners:    srv.cosigners,
+		amTrust:      srv.amTrust,
+		expiry:       srv.cfg.AuthenticationConfig.CredentialExpiry,
+		nonces:       srv.nonces,
+		clockSkew:    srv.clockSkew,
+		metrics:      srv.credMetrics,
+		system:       srv.cfg.SystemName,
+		requireSys:   srv.cfg.AuthenticationConfig.RequireSystemBinding,
+		boundPools:   srv.cfg.AuthenticationConfig.ProcessBoundPools,
+		macLabels:    srv.macLabels,
+		idGroups:     srv.identityGroups,
+		validators:   srv.validators,
+		sysdb:        srv.sysdb,
+		events:       srv.pubSub,
 	}
 	// Single daos_server dRPC server to handle all engine requests
 	if err := drpcServerSetup(ctx, drpcSetupReq); err != nil {
@@ -652,12 +787,50 @@ func Start(log logging.Logger, cfg *config.Server) error {
 
 	srv.registerEvents()
 
+	if interval := srv.cfg.AuthenticationConfig.CAReloadInterval; interval > 0 {
+		go srv.runTrustReloader(ctx, interval)
+	}
+
+	revocationInterval := srv.cfg.AuthenticationConfig.RevocationPollInterval
+	if revocationInterval == 0 {
+		revocationInterval = defaultRevocationPollInterval
+	}
+	go (&revocationPoller{
+		log:         srv.log,
+		invoker:     srv.mgmtSvc.rpcClient,
+		system:      srv.cfg.SystemName,
+		replicas:    srv.cfg.MgmtSvcReplicas,
+		revocations: srv.revocations,
+	}).run(ctx, revocationInterval)
+	go (&enrollmentPoller{
+		log:         srv.log,
+		invoker:     srv.mgmtSvc.rpcClient,
+		system:      srv.cfg.SystemName,
+		replicas:    srv.cfg.MgmtSvcReplicas,
+		enrollments: srv.enrollments,
+	}).run(ctx, revocationInterval)
+	go (&validFlavorPoller{
+		log:          srv.log,
+		invoker:      srv.mgmtSvc.rpcClient,
+		system:       srv.cfg.SystemName,
+		replicas:     srv.cfg.MgmtSvcReplicas,
+		validFlavors: srv.validFlavors,
+	}).run(ctx, revocationInterval)
+
 	sigChan := make(chan os.Signal)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM, syscall.SIGHUP)
 	go func() {
-		sig := <-sigChan
-		srv.log.Debugf("Caught signal: %s", sig)
-		shutdown()
+		for sig := range sigChan {
+			srv.log.Debugf("Caught signal: %s", sig)
+			if sig == syscall.SIGHUP {
+				if err := srv.reloadTrust(); err != nil {
+					srv.log.Errorf("failed to reload CA bundle, agent certificates or identity group mappings: %s", err)
+				}
+				continue
+			}
+			shutdown()
+			return
+		}
 	}()
 
 	return srv.start(ctx)
@@ -13,6 +13,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
 	"github.com/daos-stack/daos/src/control/lib/telemetry/promexp"
 	"github.com/daos-stack/daos/src/control/logging"
@@ -69,11 +70,18 @@ func regPromEngineSources(ctx context.Context, log logging.Logger, engines []Eng
 	return nil
 }
 
-func startPrometheusExporter(ctx context.Context, log logging.Logger, port int, engines []Engine) (func(), error) {
+func startPrometheusExporter(ctx context.Context, log logging.Logger, port int, engines []Engine, srvCollectors ...prometheus.Collector) (func(), error) {
 	expCfg := &promexp.ExporterConfig{
 		Port:  port,
 		Title: "DAOS Engine Telemetry",
 		Register: func(ctx context.Context, log logging.Logger) error {
+			for _, sc := range srvCollectors {
+				if common.InterfaceIsNil(sc) {
+					continue
+				}
+				prometheus.MustRegister(sc)
+			}
+
 			return regPromEngineSources(ctx, log, engines)
 		},
 	}
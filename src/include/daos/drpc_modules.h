@@ -30,6 +30,9 @@ enum drpc_module {
 enum drpc_sec_agent_method {
 	DRPC_METHOD_SEC_AGENT_REQUEST_CREDS	= 101,
 	DRPC_METHOD_SEC_AGENT_REQUEST_AUTH_FLAVORS	= 102,
+	DRPC_METHOD_SEC_AGENT_REVOKE_ISSUER_KEY	= 103,
+	DRPC_METHOD_SEC_AGENT_REQUEST_CHALLENGE	= 104,
+	DRPC_METHOD_SEC_AGENT_INVALIDATE_CRED	= 105,
 	NUM_DRPC_SEC_AGENT_METHODS		/* Must be last */
 };
 
@@ -75,6 +78,10 @@ enum drpc_mgmt_method {
 	DRPC_METHOD_MGMT_CHK_PROP               = 245,
 	DRPC_METHOD_MGMT_CHK_ACT                = 246,
 	DRPC_METHOD_MGMT_SETUP_CLIENT_TELEM     = 247,
+	DRPC_METHOD_MGMT_LIST_CRED_CACHE        = 248,
+	DRPC_METHOD_MGMT_SNAPSHOT_CRED_CACHE    = 249,
+	DRPC_METHOD_MGMT_RESTORE_CRED_CACHE     = 250,
+	DRPC_METHOD_MGMT_CRED_CACHE_EVENTS      = 251,
 
 	NUM_DRPC_MGMT_METHODS /* Must be last */
 };
@@ -39,6 +39,20 @@ struct d_ownership {
  */
 int dc_sec_request_creds(d_iov_t *creds);
 
+/**
+ * Request single-use security credentials for the current user from the DAOS
+ * agent, for destructive operations such as destroying a pool.
+ *
+ * Each server accepts a single-use credential only once, shortly after it is
+ * issued. The servers do not share the record of the credentials used, so a
+ * single-use credential may be accepted once by each server.
+ *
+ * \param[out]	creds		Returned security credentials for current user.
+ *
+ * \return	Same as dc_sec_request_creds.
+ */
+int dc_sec_request_one_time_creds(d_iov_t *creds);
+
 /**
  * Request a user's permissions for a specific pool.
  *
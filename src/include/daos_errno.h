@@ -216,7 +216,17 @@ extern "C" {
 	/** Target is overload, retry RPC */							   \
 	ACTION(DER_OVERLOAD_RETRY, retry later because of overloaded service)			   \
 	ACTION(DER_NOT_RESUME, Cannot resume former DAOS check instance)			   \
-	ACTION(DER_CONT_NONEXIST, The specified container does not exist)
+	ACTION(DER_CONT_NONEXIST, The specified container does not exist)			   \
+	/** Credential is outside its validity period */					   \
+	ACTION(DER_CRED_EXPIRED, Credential has expired)					   \
+	/** Credential, or the key which signed it, has been revoked */				   \
+	ACTION(DER_CRED_REVOKED, Credential has been revoked)					   \
+	/** Credential's authentication flavor is not accepted */				   \
+	ACTION(DER_BAD_AUTH_FLAVOR, Authentication flavor not accepted)			   \
+	/** Credential's signature failed to verify */						   \
+	ACTION(DER_BAD_SIGNATURE, Credential signature is invalid)				   \
+	/** Credential was signed by a key which is not trusted */				   \
+	ACTION(DER_UNKNOWN_KEY, Credential signed by an unknown key)
 
 /* clang-format on */
 
@@ -36,11 +36,37 @@ ds_sec_alloc_default_daos_cont_acl(void);
 /**
  * Obtain the origin name specified by the security credential.
  *
+ * This function assumes the credential was previously validated with the
+ * control plane.
+ *
  * \param[in]	cred		User's security credential
  * \param[out]  machine		Hostname of the machine that generated the credential.
  *
  * \return	0		Success
  *		-DER_INVAL	Invalid input
+ *		-DER_NOMEM	Out of memory
+ *		-DER_PROTO	Unexpected or corrupt payload in the credential
+ */
+int
+ds_sec_cred_get_origin(d_iov_t *cred, char **machine);
+
+/**
+ * Derive the pool security capabilities for the given user credential, using
+ * the pool ownership information, pool ACL, and requested flags.
+ *
+ * A credential restricted to a set of pools is rejected by the control plane
+ * unless the pool is one of them.
+ *
+ * \param[in]	flags		Requested DAOS_PC flags
+ * \param[in]	cred		User's security credential
+ * \param[in]	pool_uuid	UUID of the pool
+ * \param[in]	pool_label	Label of the pool, or NULL if not labeled
+ * \param[in]	ownership	Pool ownership information
+ * \param[in]	acl		Pool ACL
+ * \param[out]	capas		Capability bits for this user
+ *
+ * \return	0		Success
+ *		-DER_INVAL	Invalid input
  *		-DER_BADPATH	Can't connect to the control plane socket at
  *				the expected path
  *		-DER_NOMEM	Out of memory
@@ -49,14 +75,23 @@ ds_sec_alloc_default_daos_cont_acl(void);
  *		-DER_PROTO	Unexpected or corrupt payload from control plane
  */
 int
-ds_sec_cred_get_origin(d_iov_t *cred, char **machine);
+ds_sec_pool_get_capabilities(uint64_t flags, d_iov_t *cred, uuid_t pool_uuid,
+			     const char *pool_label, struct d_ownership *ownership,
+			     struct daos_acl *acl, uint64_t *capas);
 
 /**
- * Derive the pool security capabilities for the given user credential, using
- * the pool ownership information, pool ACL, and requested flags.
+ * Derive the pool security capabilities for the given user credential, as
+ * ds_sec_pool_get_capabilities does, without using the credential up.
+ *
+ * A credential is accepted by the control plane only once if it is signed with
+ * a nonce or is a one-time credential. This checks such a credential against
+ * a pool without using it, so that it may be checked against several pools,
+ * as when listing the pools a user may connect to.
  *
  * \param[in]	flags		Requested DAOS_PC flags
  * \param[in]	cred		User's security credential
+ * \param[in]	pool_uuid	UUID of the pool
+ * \param[in]	pool_label	Label of the pool, or NULL if not labeled
  * \param[in]	ownership	Pool ownership information
  * \param[in]	acl		Pool ACL
  * \param[out]	capas		Capability bits for this user
@@ -71,9 +106,9 @@ ds_sec_cred_get_origin(d_iov_t *cred, char **machine);
  *		-DER_PROTO	Unexpected or corrupt payload from control plane
  */
 int
-ds_sec_pool_get_capabilities(uint64_t flags, d_iov_t *cred,
-			     struct d_ownership *ownership,
-			     struct daos_acl *acl, uint64_t *capas);
+ds_sec_pool_check_capabilities(uint64_t flags, d_iov_t *cred, uuid_t pool_uuid,
+			       const char *pool_label, struct d_ownership *ownership,
+			       struct daos_acl *acl, uint64_t *capas);
 
 /**
  * Derive the container security capabilities for the given user credential,
@@ -97,6 +132,30 @@ int
 ds_sec_cont_get_capabilities(uint64_t flags, d_iov_t *cred, struct d_ownership *ownership,
 			     struct daos_acl *acl, uint64_t *capas);
 
+/**
+ * Check that the given user credential may be used with the container. A
+ * delegation credential restricted to a set of containers may only be used
+ * with those containers, which it names by pool and container UUID or label.
+ *
+ * This function assumes the credential was acquired internally and was
+ * previously validated with the control plane.
+ *
+ * \param[in]	cred		User's security credential
+ * \param[in]	pool_uuid	UUID of the pool
+ * \param[in]	pool_label	Label of the pool, or NULL if not known
+ * \param[in]	cont_uuid	UUID of the container
+ * \param[in]	cont_label	Label of the container, or NULL if not labeled
+ *
+ * \return	0		Success
+ *		-DER_NO_PERM	Container is outside of the credential's scope
+ *		-DER_INVAL	Invalid input
+ *		-DER_NOMEM	Out of memory
+ *		-DER_PROTO	Unexpected or corrupt credential
+ */
+int
+ds_sec_cont_check_scope(d_iov_t *cred, uuid_t pool_uuid, const char *pool_label,
+			uuid_t cont_uuid, const char *cont_label);
+
 /**
  * Determine if the pool connection can be established based on the calculated
  * set of pool capabilities.
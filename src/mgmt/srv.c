@@ -448,7 +448,8 @@ void ds_mgmt_pool_find_hdlr(crt_rpc_t *rpc)
 }
 
 static int
-check_cred_pool_access(uuid_t pool_uuid, d_rank_list_t *svc_ranks, int flags, d_iov_t *cred)
+check_cred_pool_access(uuid_t pool_uuid, const char *pool_label, d_rank_list_t *svc_ranks,
+		       int flags, d_iov_t *cred)
 {
 	daos_prop_t            *props           = NULL;
 	struct daos_prop_entry *acl_entry       = NULL;
@@ -479,7 +480,9 @@ check_cred_pool_access(uuid_t pool_uuid, d_rank_list_t *svc_ranks, int flags, d_
 	owner.user  = owner_entry->dpe_str;
 	owner.group = owner_grp_entry->dpe_str;
 
-	rc = ds_sec_pool_get_capabilities(flags, cred, &owner, acl_entry->dpe_val_ptr, &sec_capas);
+	/* The credential is checked against each pool listed, so is not used up */
+	rc = ds_sec_pool_check_capabilities(flags, cred, pool_uuid, pool_label, &owner,
+					    acl_entry->dpe_val_ptr, &sec_capas);
 	if (rc != 0) {
 		DL_ERROR(rc, DF_UUID ": failed to read sec capabilities", DP_UUID(pool_uuid));
 		D_GOTO(out_props, rc);
@@ -544,8 +547,8 @@ ds_mgmt_pool_list_hdlr(crt_rpc_t *rpc)
 		daos_mgmt_pool_info_t      *mgmt_pool = &mgmt_pools[i];
 		struct mgmt_pool_list_pool *rpc_pool  = &rpc_pools[n_rpc];
 
-		chk_rc = check_cred_pool_access(mgmt_pool->mgpi_uuid, mgmt_pool->mgpi_svc,
-						DAOS_PC_RO, &in->pli_cred);
+		chk_rc = check_cred_pool_access(mgmt_pool->mgpi_uuid, mgmt_pool->mgpi_label,
+						mgmt_pool->mgpi_svc, DAOS_PC_RO, &in->pli_cred);
 		if (chk_rc != 0) {
 			if (chk_rc != -DER_NO_PERM)
 				DL_ERROR(chk_rc, DF_UUID ": failed to check pool access",
@@ -3994,6 +3994,7 @@ pool_connect_handler(crt_rpc_t *rpc, int handler_version)
 	struct daos_prop_entry	       *owner_entry, *global_ver_entry;
 	struct daos_prop_entry	       *owner_grp_entry;
 	struct daos_prop_entry	       *obj_ver_entry;
+	struct daos_prop_entry	       *label_entry;
 	uint64_t			sec_capas = 0;
 	struct pool_metrics	       *metrics;
 	char			       *machine = NULL;
@@ -4176,7 +4177,11 @@ pool_connect_handler(crt_rpc_t *rpc, int handler_version)
 	 * Security capabilities determine the access control policy on this
 	 * pool handle.
 	 */
-	rc = ds_sec_pool_get_capabilities(flags, credp, &owner, acl_entry->dpe_val_ptr, &sec_capas);
+	label_entry = daos_prop_entry_get(prop, DAOS_PROP_PO_LABEL);
+	D_ASSERT(label_entry != NULL);
+
+	rc = ds_sec_pool_get_capabilities(flags, credp, in->pci_op.pi_uuid, label_entry->dpe_str,
+					  &owner, acl_entry->dpe_val_ptr, &sec_capas);
 	if (rc != 0) {
 		DL_ERROR(rc, DF_UUID ": refusing connect attempt for " DF_X64,
 			 DP_UUID(in->pci_op.pi_uuid), flags);
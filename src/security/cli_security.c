@@ -23,15 +23,16 @@
 /* Prototypes for static helper functions */
 static int request_flavor_via_drpc(Drpc__Response **response);
 static int prepare_credential_request_accman(Auth__GetCredReq *args, Drpc__Call	*request);
-static int request_credentials_via_drpc(Drpc__Response **response, Auth__Flavor flavor);
+static int request_credentials_via_drpc(Drpc__Response **response, Auth__Flavor flavor,
+					Auth__Scope scope);
 static int process_flavor_response(Drpc__Response *response, Auth__Flavor *flavor);
 static int process_credential_response(Drpc__Response *response,
 				       d_iov_t *creds);
 static int get_flavor_from_response(Drpc__Response *response, Auth__Flavor *flavor);
 static int get_cred_from_response(Drpc__Response *response, d_iov_t *cred);
 
-int
-dc_sec_request_creds(d_iov_t *creds)
+static int
+request_creds(d_iov_t *creds, Auth__Scope scope)
 {
 	Drpc__Response	*response = NULL;
 	Auth__Flavor	flavor = AUTH__FLAVOR__AUTH_NONE;
@@ -53,7 +54,7 @@ dc_sec_request_creds(d_iov_t *creds)
 		return rc;
 	}
 
-	rc = request_credentials_via_drpc(&response, flavor);
+	rc = request_credentials_via_drpc(&response, flavor, scope);
 	if (rc != DER_SUCCESS) {
 		drpc_response_free(response);
 		return rc;
@@ -65,6 +66,18 @@ dc_sec_request_creds(d_iov_t *creds)
 	return rc;
 }
 
+int
+dc_sec_request_creds(d_iov_t *creds)
+{
+	return request_creds(creds, AUTH__SCOPE__SCOPE_DEFAULT);
+}
+
+int
+dc_sec_request_one_time_creds(d_iov_t *creds)
+{
+	return request_creds(creds, AUTH__SCOPE__SCOPE_ONE_TIME);
+}
+
 /** Constant that represents the upper bound size of a string encoded delegation credential token for the access manager. */
 #define MAX_DELEGATION_TOKEN_SIZE 10000
 
@@ -145,7 +158,20 @@ prepare_credential_request_accman(Auth__GetCredReq *args, Drpc__Call *request) {
 }
 
 static int
-request_credentials_via_drpc(Drpc__Response **response, Auth__Flavor flavor)
+pack_credential_request(Auth__GetCredReq *args, Drpc__Call *request)
+{
+	size_t len;
+
+	len = auth__get_cred_req__get_packed_size(args);
+	D_ALLOC(request->body.data, len);
+	if (request->body.data == NULL)
+		return -DER_NOMEM;
+	request->body.len = auth__get_cred_req__pack(args, request->body.data);
+	return -DER_SUCCESS;
+}
+
+static int
+request_credentials_via_drpc(Drpc__Response **response, Auth__Flavor flavor, Auth__Scope scope)
 {
 	Drpc__Call	*request;
 	struct drpc	*agent_socket;
@@ -173,6 +199,7 @@ request_credentials_via_drpc(Drpc__Response **response, Auth__Flavor flavor)
 
 	Auth__GetCredReq args = AUTH__GET_CRED_REQ__INIT;
 	args.flavor = flavor;
+	args.scope  = scope;
 
 	switch (flavor) {
 	case AUTH__FLAVOR__AUTH_NONE:
@@ -180,6 +207,15 @@ request_credentials_via_drpc(Drpc__Response **response, Auth__Flavor flavor)
 		return -1;
 	
 	case AUTH__FLAVOR__AUTH_SYS:
+		/* The agent takes an empty request for a default AUTH_SYS credential. */
+		if (scope == AUTH__SCOPE__SCOPE_DEFAULT)
+			break;
+		rc = pack_credential_request(&args, request);
+		if (rc != -DER_SUCCESS) {
+			drpc_close(agent_socket);
+			drpc_call_free(request);
+			return rc;
+		}
 		break;
 	
 	case AUTH__FLAVOR__AUTH_ACCMAN:
@@ -6,6 +6,7 @@
 
 #include <unistd.h>
 #include <string.h>
+#include <strings.h>
 #include <daos_errno.h>
 #include <daos/debug.h>
 #include <daos/drpc.h>
@@ -168,7 +169,8 @@ out:
 }
 
 static int
-new_validation_request(struct drpc *ctx, d_iov_t *creds, Drpc__Call **callp)
+new_validation_request(struct drpc *ctx, d_iov_t *creds, uuid_t pool_uuid, const char *pool_label,
+		       bool check_only, Drpc__Call **callp)
 {
 	struct drpc_alloc	alloc = PROTO_ALLOCATOR_INIT(alloc);
 	uint8_t			*body;
@@ -176,6 +178,7 @@ new_validation_request(struct drpc *ctx, d_iov_t *creds, Drpc__Call **callp)
 	Drpc__Call		*request;
 	Auth__ValidateCredReq	req = AUTH__VALIDATE_CRED_REQ__INIT;
 	Auth__Credential	*cred;
+	char			pool_str[DAOS_UUID_STR_SIZE];
 	int			rc;
 
 	*callp = NULL;
@@ -195,6 +198,20 @@ new_validation_request(struct drpc *ctx, d_iov_t *creds, Drpc__Call **callp)
 	}
 	req.cred = cred;
 
+	/*
+	 * Credentials restricted to a set of pools are only accepted for the
+	 * pool they are presented to.
+	 */
+	if (pool_uuid != NULL) {
+		uuid_unparse_lower(pool_uuid, pool_str);
+		req.pool = pool_str;
+	}
+	if (pool_label != NULL)
+		req.pool_label = (char *)pool_label;
+
+	/* Checking the credential doesn't use up its nonce or one-time use */
+	req.check_only = check_only;
+
 	len = auth__validate_cred_req__get_packed_size(&req);
 	D_ALLOC(body, len);
 	if (body == NULL) {
@@ -213,7 +230,8 @@ new_validation_request(struct drpc *ctx, d_iov_t *creds, Drpc__Call **callp)
 }
 
 static int
-validate_credentials_via_drpc(Drpc__Response **response, d_iov_t *creds)
+validate_credentials_via_drpc(Drpc__Response **response, d_iov_t *creds, uuid_t pool_uuid,
+			      const char *pool_label, bool check_only)
 {
 	struct drpc	*server_socket;
 	Drpc__Call	*request;
@@ -225,7 +243,8 @@ validate_credentials_via_drpc(Drpc__Response **response, d_iov_t *creds)
 		return rc;
 	}
 
-	rc  = new_validation_request(server_socket, creds, &request);
+	rc = new_validation_request(server_socket, creds, pool_uuid, pool_label, check_only,
+				    &request);
 	if (rc != DER_SUCCESS) {
 		drpc_close(server_socket);
 		return rc;
@@ -254,8 +273,9 @@ process_validation_response(Drpc__Response *response, Auth__Token **token)
 	return get_token_from_validation_response(response, token);
 }
 
-int
-ds_sec_validate_credentials(d_iov_t *creds, Auth__Token **token)
+static int
+validate_credentials(d_iov_t *creds, uuid_t pool_uuid, const char *pool_label, bool check_only,
+		     Auth__Token **token)
 {
 	Drpc__Response	*response = NULL;
 	int		rc;
@@ -268,7 +288,7 @@ ds_sec_validate_credentials(d_iov_t *creds, Auth__Token **token)
 		return -DER_INVAL;
 	}
 
-	rc = validate_credentials_via_drpc(&response, creds);
+	rc = validate_credentials_via_drpc(&response, creds, pool_uuid, pool_label, check_only);
 	if (rc != DER_SUCCESS) {
 		return rc;
 	}
@@ -279,6 +299,12 @@ ds_sec_validate_credentials(d_iov_t *creds, Auth__Token **token)
 	return rc;
 }
 
+int
+ds_sec_validate_credentials(d_iov_t *creds, Auth__Token **token)
+{
+	return validate_credentials(creds, NULL, NULL, false, token);
+}
+
 static uint64_t
 pool_capas_from_perms(uint64_t perms, bool is_owner)
 {
@@ -302,7 +328,11 @@ get_auth_sys_payload(Auth__Token *token, Auth__Sys **payload)
 {
 	struct drpc_alloc	alloc = PROTO_ALLOCATOR_INIT(alloc);
 
-	if (token->flavor != AUTH__FLAVOR__AUTH_SYS) {
+	/*
+	 * Every flavor but AUTH_NONE asserts the identity of the user with an
+	 * AuthSys payload, which the control plane has already verified.
+	 */
+	if (token->flavor == AUTH__FLAVOR__AUTH_NONE) {
 		D_ERROR("Credential auth flavor not supported\n");
 		return -DER_PROTO;
 	}
@@ -433,6 +463,33 @@ out:
 	return rc;
 }
 
+static int
+unpack_token_from_cred(d_iov_t *cred, Auth__Token **_token)
+{
+	struct drpc_alloc alloc = PROTO_ALLOCATOR_INIT(alloc);
+	Auth__Credential *unpacked;
+	Auth__Token      *token = NULL;
+
+	unpacked = auth__credential__unpack(&alloc.alloc, cred->iov_buf_len, cred->iov_buf);
+	if (alloc.oom)
+		return -DER_NOMEM;
+
+	if (unpacked == NULL) {
+		DL_ERROR(-DER_INVAL, "Couldn't unpack credential");
+		return -DER_INVAL;
+	}
+
+	if (unpacked->token != NULL) {
+		token = auth_token_dup(unpacked->token);
+		if (token == NULL)
+			return -DER_NOMEM;
+	}
+
+	auth__credential__free_unpacked(unpacked, &alloc.alloc);
+	*_token = token;
+	return 0;
+}
+
 int
 ds_sec_cred_get_origin(d_iov_t *cred, char **machine)
 {
@@ -445,11 +502,21 @@ ds_sec_cred_get_origin(d_iov_t *cred, char **machine)
 		return -DER_INVAL;
 	}
 
-	rc = ds_sec_validate_credentials(cred, &token);
-	if (rc != 0) {
-		D_ERROR("Failed to validate credentials, rc="DF_RC"\n",
-			DP_RC(rc));
+	if (cred->iov_buf == NULL || cred->iov_buf_len == 0) {
+		D_ERROR("Credential iov invalid\n");
+		return -DER_INVAL;
+	}
+
+	/*
+	 * The credential has already been validated, and validating it again
+	 * would be rejected as a replay of its nonce or one-time use.
+	 */
+	rc = unpack_token_from_cred(cred, &token);
+	if (rc != 0)
 		return rc;
+	if (token == NULL) {
+		D_ERROR("Credential has no token\n");
+		return -DER_INVAL;
 	}
 	rc = get_sec_origin_for_token(token, machine);
 
@@ -457,16 +524,16 @@ ds_sec_cred_get_origin(d_iov_t *cred, char **machine)
 	return rc;
 }
 
-int
-ds_sec_pool_get_capabilities(uint64_t flags, d_iov_t *cred,
-			     struct d_ownership *ownership,
-			     struct daos_acl *acl, uint64_t *capas)
+static int
+pool_get_capabilities(uint64_t flags, d_iov_t *cred, uuid_t pool_uuid, const char *pool_label,
+		      bool check_only, struct d_ownership *ownership, struct daos_acl *acl,
+		      uint64_t *capas)
 {
 	struct drpc_alloc	alloc = PROTO_ALLOCATOR_INIT(alloc);
 	int			rc;
 	Auth__Token		*token;
 
-	if (cred == NULL || ownership == NULL || acl == NULL ||
+	if (cred == NULL || pool_uuid == NULL || ownership == NULL || acl == NULL ||
 	    capas == NULL) {
 		D_ERROR("NULL input\n");
 		return -DER_INVAL;
@@ -491,7 +558,7 @@ ds_sec_pool_get_capabilities(uint64_t flags, d_iov_t *cred,
 		return rc;
 	}
 
-	rc = ds_sec_validate_credentials(cred, &token);
+	rc = validate_credentials(cred, pool_uuid, pool_label, check_only, &token);
 	if (rc != 0) {
 		DL_ERROR(rc, "Failed to validate credentials");
 		return rc;
@@ -506,6 +573,24 @@ ds_sec_pool_get_capabilities(uint64_t flags, d_iov_t *cred,
 	return rc;
 }
 
+int
+ds_sec_pool_get_capabilities(uint64_t flags, d_iov_t *cred, uuid_t pool_uuid,
+			     const char *pool_label, struct d_ownership *ownership,
+			     struct daos_acl *acl, uint64_t *capas)
+{
+	return pool_get_capabilities(flags, cred, pool_uuid, pool_label, false, ownership, acl,
+				     capas);
+}
+
+int
+ds_sec_pool_check_capabilities(uint64_t flags, d_iov_t *cred, uuid_t pool_uuid,
+			       const char *pool_label, struct d_ownership *ownership,
+			       struct daos_acl *acl, uint64_t *capas)
+{
+	return pool_get_capabilities(flags, cred, pool_uuid, pool_label, true, ownership, acl,
+				     capas);
+}
+
 static uint64_t
 cont_capas_from_perms(uint64_t perms, bool is_owner)
 {
@@ -556,33 +641,6 @@ filter_cont_capas_based_on_flags(uint64_t flags, uint64_t *capas)
 		*capas &= ~(uint64_t)CONT_CAPA_EVICT_ALL;
 }
 
-static int
-unpack_token_from_cred(d_iov_t *cred, Auth__Token **_token)
-{
-	struct drpc_alloc alloc = PROTO_ALLOCATOR_INIT(alloc);
-	Auth__Credential *unpacked;
-	Auth__Token      *token = NULL;
-
-	unpacked = auth__credential__unpack(&alloc.alloc, cred->iov_buf_len, cred->iov_buf);
-	if (alloc.oom)
-		return -DER_NOMEM;
-
-	if (unpacked == NULL) {
-		DL_ERROR(-DER_INVAL, "Couldn't unpack credential");
-		return -DER_INVAL;
-	}
-
-	if (unpacked->token != NULL) {
-		token = auth_token_dup(unpacked->token);
-		if (token == NULL)
-			return -DER_NOMEM;
-	}
-
-	auth__credential__free_unpacked(unpacked, &alloc.alloc);
-	*_token = token;
-	return 0;
-}
-
 int
 ds_sec_cont_get_capabilities(uint64_t flags, d_iov_t *cred, struct d_ownership *ownership,
 			     struct daos_acl *acl, uint64_t *capas)
@@ -635,6 +693,79 @@ ds_sec_cont_get_capabilities(uint64_t flags, d_iov_t *cred, struct d_ownership *
 	return rc;
 }
 
+/*
+ * Does a name in the scope of a credential, which may be either a UUID or a
+ * label, refer to the pool or container with the given UUID and label?
+ */
+static bool
+scope_name_matches(const char *name, size_t len, uuid_t uuid, const char *label)
+{
+	char uuid_str[DAOS_UUID_STR_SIZE];
+
+	uuid_unparse_lower(uuid, uuid_str);
+	if (len == strlen(uuid_str) && strncasecmp(name, uuid_str, len) == 0)
+		return true;
+
+	return label != NULL && strlen(label) == len && strncmp(name, label, len) == 0;
+}
+
+int
+ds_sec_cont_check_scope(d_iov_t *cred, uuid_t pool_uuid, const char *pool_label,
+			uuid_t cont_uuid, const char *cont_label)
+{
+	struct drpc_alloc alloc = PROTO_ALLOCATOR_INIT(alloc);
+	Auth__Token      *token;
+	Auth__Sys        *authsys;
+	Auth__Delegation *delegation;
+	int               rc;
+	size_t            i;
+
+	if (cred == NULL || cred->iov_buf == NULL || pool_uuid == NULL || cont_uuid == NULL) {
+		D_ERROR("NULL input\n");
+		return -DER_INVAL;
+	}
+
+	rc = unpack_token_from_cred(cred, &token);
+	if (rc != -DER_SUCCESS)
+		return rc;
+
+	/* The credential has already been validated at pool connect. */
+	if (token == NULL)
+		return -DER_INVAL;
+
+	rc = get_auth_sys_payload(token, &authsys);
+	if (rc != 0)
+		goto out_token;
+
+	delegation = authsys->delegation;
+	if (delegation == NULL || delegation->n_containers == 0)
+		goto out_authsys;
+
+	/* Containers are named "<pool>/<container>" by the delegator. */
+	rc = -DER_NO_PERM;
+	for (i = 0; i < delegation->n_containers; i++) {
+		const char *name = delegation->containers[i];
+		const char *sep  = strchr(name, '/');
+
+		if (sep == NULL)
+			continue;
+		if (scope_name_matches(name, sep - name, pool_uuid, pool_label) &&
+		    scope_name_matches(sep + 1, strlen(sep + 1), cont_uuid, cont_label)) {
+			rc = 0;
+			break;
+		}
+	}
+	if (rc != 0)
+		D_DEBUG(DB_SEC, DF_CONT ": outside of the containers delegated to %s\n",
+			DP_CONT(pool_uuid, cont_uuid), delegation->delegate);
+
+out_authsys:
+	auth__sys__free_unpacked(authsys, &alloc.alloc);
+out_token:
+	auth__token__free_unpacked(token, &alloc.alloc);
+	return rc;
+}
+
 bool
 ds_sec_pool_can_connect(uint64_t pool_capas)
 {
@@ -195,6 +195,29 @@ test_request_credentials_calls_drpc_call(void **state)
 	daos_iov_free(&creds);
 }
 
+static void
+test_request_one_time_credentials_sends_scope(void **state)
+{
+	d_iov_t		 creds;
+	Auth__GetCredReq *req;
+
+	memset(&creds, 0, sizeof(d_iov_t));
+
+	assert_rc_equal(dc_sec_request_one_time_creds(&creds), DER_SUCCESS);
+
+	assert_int_equal(drpc_call_msg_content.method,
+			DRPC_METHOD_SEC_AGENT_REQUEST_CREDS);
+
+	/* Check that the body requests a one-time credential */
+	req = auth__get_cred_req__unpack(NULL, drpc_call_msg_content.body.len,
+					 drpc_call_msg_content.body.data);
+	assert_non_null(req);
+	assert_int_equal(req->scope, AUTH__SCOPE__SCOPE_ONE_TIME);
+
+	auth__get_cred_req__free_unpacked(req, NULL);
+	daos_iov_free(&creds);
+}
+
 static void
 test_request_credentials_closes_socket_when_call_ok(void **state)
 {
@@ -735,6 +758,8 @@ main(void)
 			test_request_credentials_fails_if_drpc_call_fails),
 		SECURITY_UTEST(
 			test_request_credentials_calls_drpc_call),
+		SECURITY_UTEST(
+			test_request_one_time_credentials_sends_scope),
 		SECURITY_UTEST(
 			test_request_credentials_closes_socket_when_call_ok),
 		SECURITY_UTEST(
@@ -35,6 +35,12 @@ char *ds_sec_server_socket_path = "/fake/socket/path";
 #define TEST_GROUP	"mygroup@"
 #define TEST_HOST	"testhost"
 #define LONG_HOST	"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+#define TEST_POOL_LABEL	"tank"
+#define TEST_POOL_UUID	"d6e1bdf4-b3b5-4d18-a6d8-7a8a5c2e3c79"
+#define TEST_CONT_UUID	"8b4a5fbc-2b0f-4d5e-9e37-0f7c35b1e62a"
+
+static uuid_t test_pool_uuid;
+static uuid_t test_cont_uuid;
 
 /*
  * Test helper functions
@@ -124,6 +130,41 @@ init_default_cred(d_iov_t *cred)
 			TEST_HOST);
 }
 
+static void
+init_delegated_cred(d_iov_t *cred, char *containers[], size_t nr_containers)
+{
+	Auth__Credential	new_cred = AUTH__CREDENTIAL__INIT;
+	Auth__Token		token = AUTH__TOKEN__INIT;
+	Auth__Sys		authsys = AUTH__SYS__INIT;
+	Auth__Delegation	delegation = AUTH__DELEGATION__INIT;
+	uint8_t			*buf;
+	size_t			buf_len;
+
+	delegation.containers = containers;
+	delegation.n_containers = nr_containers;
+	delegation.delegate = "svc";
+
+	authsys.user = TEST_USER;
+	authsys.group = TEST_GROUP;
+	authsys.machinename = TEST_HOST;
+	authsys.delegation = &delegation;
+
+	token.flavor = AUTH__FLAVOR__AUTH_DELEGATION;
+	token.data.len = auth__sys__get_packed_size(&authsys);
+	D_ALLOC(token.data.data, token.data.len);
+	assert_non_null(token.data.data);
+	auth__sys__pack(&authsys, token.data.data);
+
+	new_cred.token = &token;
+	buf_len = auth__credential__get_packed_size(&new_cred);
+	D_ALLOC(buf, buf_len);
+	assert_non_null(buf);
+	auth__credential__pack(&new_cred, buf);
+	d_iov_set(cred, buf, buf_len);
+
+	D_FREE(token.data.data);
+}
+
 static void
 init_default_ownership(struct d_ownership *owner)
 {
@@ -527,7 +568,7 @@ expect_pool_get_capas_flags_invalid(uint64_t invalid_flags)
 
 	printf("Expecting flags %#lx invalid\n", invalid_flags);
 	assert_rc_equal(ds_sec_pool_get_capabilities(invalid_flags,
-						     &valid_cred,
+						     &valid_cred, test_pool_uuid, TEST_POOL_LABEL,
 						     &valid_owner, valid_acl,
 						     &result),
 			-DER_INVAL);
@@ -562,25 +603,31 @@ test_pool_get_capas_null_input(void **state)
 	assert_non_null(valid_acl);
 
 	assert_rc_equal(ds_sec_pool_get_capabilities(valid_flags,
-						     NULL,
+						     NULL, test_pool_uuid, TEST_POOL_LABEL,
 						     &valid_owner, valid_acl,
 						     &result),
 			-DER_INVAL);
 
 	assert_rc_equal(ds_sec_pool_get_capabilities(valid_flags,
-						     &valid_cred,
+						     &valid_cred, NULL, TEST_POOL_LABEL,
+						     &valid_owner, valid_acl,
+						     &result),
+			-DER_INVAL);
+
+	assert_rc_equal(ds_sec_pool_get_capabilities(valid_flags,
+						     &valid_cred, test_pool_uuid, TEST_POOL_LABEL,
 						     NULL, valid_acl,
 						     &result),
 			-DER_INVAL);
 
 	assert_rc_equal(ds_sec_pool_get_capabilities(valid_flags,
-						     &valid_cred,
+						     &valid_cred, test_pool_uuid, TEST_POOL_LABEL,
 						     &valid_owner, NULL,
 						     &result),
 			-DER_INVAL);
 
 	assert_rc_equal(ds_sec_pool_get_capabilities(valid_flags,
-						     &valid_cred,
+						     &valid_cred, test_pool_uuid, TEST_POOL_LABEL,
 						     &valid_owner, valid_acl,
 						     NULL),
 			-DER_INVAL);
@@ -606,7 +653,7 @@ expect_pool_get_capas_owner_invalid(char *user, char *group)
 	invalid_owner.user = user;
 	invalid_owner.group = group;
 	assert_rc_equal(ds_sec_pool_get_capabilities(valid_flags,
-						     &valid_cred,
+						     &valid_cred, test_pool_uuid, TEST_POOL_LABEL,
 						     &invalid_owner, valid_acl,
 						     &result),
 			-DER_INVAL);
@@ -641,6 +688,7 @@ test_pool_get_capas_bad_acl(void **state)
 	assert_non_null(bad_acl);
 
 	assert_rc_equal(ds_sec_pool_get_capabilities(DAOS_PC_RO, &cred,
+						     test_pool_uuid, TEST_POOL_LABEL,
 						     &ownership, bad_acl,
 						     &result),
 			-DER_INVAL);
@@ -666,6 +714,7 @@ test_pool_get_capas_validate_cred_failed(void **state)
 	drpc_call_resp_return_ptr = NULL;
 
 	assert_rc_equal(ds_sec_pool_get_capabilities(DAOS_PC_RO, &cred,
+						     test_pool_uuid, TEST_POOL_LABEL,
 						     &ownership, acl,
 						     &result),
 			drpc_call_return);
@@ -674,6 +723,71 @@ test_pool_get_capas_validate_cred_failed(void **state)
 	daos_iov_free(&cred);
 }
 
+static void
+test_pool_get_capas_sends_pool(void **state)
+{
+	struct daos_acl		*acl;
+	d_iov_t			cred;
+	struct d_ownership	ownership;
+	uint64_t		result;
+	Auth__ValidateCredReq	*req;
+
+	init_default_cred(&cred);
+	init_default_ownership(&ownership);
+	acl = daos_acl_create(NULL, 0);
+
+	assert_rc_equal(ds_sec_pool_get_capabilities(DAOS_PC_RO, &cred,
+						     test_pool_uuid, TEST_POOL_LABEL,
+						     &ownership, acl,
+						     &result),
+			0);
+
+	/* The control plane rejects credentials restricted to other pools */
+	req = auth__validate_cred_req__unpack(NULL,
+					      drpc_call_msg_content.body.len,
+					      drpc_call_msg_content.body.data);
+	assert_non_null(req);
+	assert_string_equal(req->pool, TEST_POOL_UUID);
+	assert_string_equal(req->pool_label, TEST_POOL_LABEL);
+	assert_false(req->check_only);
+
+	auth__validate_cred_req__free_unpacked(req, NULL);
+	daos_acl_free(acl);
+	daos_iov_free(&cred);
+}
+
+static void
+test_pool_check_capas_sends_check_only(void **state)
+{
+	struct daos_acl		*acl;
+	d_iov_t			cred;
+	struct d_ownership	ownership;
+	uint64_t		result;
+	Auth__ValidateCredReq	*req;
+
+	init_default_cred(&cred);
+	init_default_ownership(&ownership);
+	acl = daos_acl_create(NULL, 0);
+
+	assert_rc_equal(ds_sec_pool_check_capabilities(DAOS_PC_RO, &cred,
+						       test_pool_uuid, TEST_POOL_LABEL,
+						       &ownership, acl,
+						       &result),
+			0);
+
+	/* The control plane doesn't use up the credential's nonce */
+	req = auth__validate_cred_req__unpack(NULL,
+					      drpc_call_msg_content.body.len,
+					      drpc_call_msg_content.body.data);
+	assert_non_null(req);
+	assert_string_equal(req->pool, TEST_POOL_UUID);
+	assert_true(req->check_only);
+
+	auth__validate_cred_req__free_unpacked(req, NULL);
+	daos_acl_free(acl);
+	daos_iov_free(&cred);
+}
+
 static void
 expect_pool_get_capas_bad_authsys_payload(int auth_flavor)
 {
@@ -701,6 +815,7 @@ expect_pool_get_capas_bad_authsys_payload(int auth_flavor)
 	pack_validate_resp_in_drpc_call_resp_body(&resp);
 
 	assert_rc_equal(ds_sec_pool_get_capabilities(DAOS_PC_RO, &cred,
+						     test_pool_uuid, TEST_POOL_LABEL,
 						     &ownership, acl,
 						     &result),
 			-DER_PROTO);
@@ -731,8 +846,8 @@ expect_pool_capas_with_acl(struct daos_acl *acl, d_iov_t *cred,
 
 	init_default_ownership(&ownership);
 
-	assert_rc_equal(ds_sec_pool_get_capabilities(flags, cred, &ownership,
-						     acl, &result),
+	assert_rc_equal(ds_sec_pool_get_capabilities(flags, cred, test_pool_uuid, TEST_POOL_LABEL,
+						     &ownership, acl, &result),
 			0);
 
 	assert_int_equal(result, exp_capas);
@@ -2061,6 +2176,88 @@ test_get_admin_cont_capas(void **state)
 			 CONT_CAPAS_ALL);
 }
 
+static void
+test_cont_check_scope_null_input(void **state)
+{
+	d_iov_t cred;
+
+	init_default_cred(&cred);
+
+	assert_rc_equal(ds_sec_cont_check_scope(NULL, test_pool_uuid, TEST_POOL_LABEL,
+						test_cont_uuid, NULL),
+			-DER_INVAL);
+	assert_rc_equal(ds_sec_cont_check_scope(&cred, NULL, TEST_POOL_LABEL,
+						test_cont_uuid, NULL),
+			-DER_INVAL);
+	assert_rc_equal(ds_sec_cont_check_scope(&cred, test_pool_uuid, TEST_POOL_LABEL,
+						NULL, NULL),
+			-DER_INVAL);
+
+	daos_iov_free(&cred);
+}
+
+static void
+test_cont_check_scope_unrestricted(void **state)
+{
+	d_iov_t cred;
+
+	init_default_cred(&cred);
+
+	assert_rc_equal(ds_sec_cont_check_scope(&cred, test_pool_uuid, NULL,
+						test_cont_uuid, NULL),
+			0);
+
+	daos_iov_free(&cred);
+}
+
+static void
+test_cont_check_scope_delegated(void **state)
+{
+	d_iov_t	cred;
+	char	*containers[] = {TEST_POOL_LABEL "/results", "scratch/" TEST_CONT_UUID};
+
+	init_delegated_cred(&cred, containers, ARRAY_SIZE(containers));
+
+	/* named by labels */
+	assert_rc_equal(ds_sec_cont_check_scope(&cred, test_pool_uuid, TEST_POOL_LABEL,
+						test_cont_uuid, "results"),
+			0);
+	assert_rc_equal(ds_sec_cont_check_scope(&cred, test_pool_uuid, TEST_POOL_LABEL,
+						test_cont_uuid, "other"),
+			-DER_NO_PERM);
+	assert_rc_equal(ds_sec_cont_check_scope(&cred, test_pool_uuid, NULL,
+						test_cont_uuid, "results"),
+			-DER_NO_PERM);
+
+	/* container named by UUID, in another pool */
+	assert_rc_equal(ds_sec_cont_check_scope(&cred, test_pool_uuid, "scratch",
+						test_cont_uuid, NULL),
+			0);
+	assert_rc_equal(ds_sec_cont_check_scope(&cred, test_pool_uuid, TEST_POOL_LABEL,
+						test_cont_uuid, NULL),
+			-DER_NO_PERM);
+
+	daos_iov_free(&cred);
+}
+
+static void
+test_cont_check_scope_delegated_by_uuid(void **state)
+{
+	d_iov_t	cred;
+	char	*containers[] = {"D6E1BDF4-B3B5-4D18-A6D8-7A8A5C2E3C79/" TEST_CONT_UUID};
+
+	init_delegated_cred(&cred, containers, ARRAY_SIZE(containers));
+
+	assert_rc_equal(ds_sec_cont_check_scope(&cred, test_pool_uuid, NULL,
+						test_cont_uuid, NULL),
+			0);
+	assert_rc_equal(ds_sec_cont_check_scope(&cred, test_cont_uuid, NULL,
+						test_cont_uuid, NULL),
+			-DER_NO_PERM);
+
+	daos_iov_free(&cred);
+}
+
 static void
 test_origin_null_cred(void **state)
 {
@@ -2141,6 +2338,27 @@ test_origin_valid_origin(void **state)
 	daos_iov_free(&cred);
 }
 
+static void
+test_origin_not_revalidated(void **state)
+{
+	char *machine;
+	d_iov_t cred;
+
+	init_valid_cred(&cred, TEST_USER, TEST_GROUP, NULL, 0,
+			TEST_HOST);
+
+	/* Validating the credential again would use up its nonce */
+	drpc_call_return = -DER_MISC;
+
+	assert_rc_equal(ds_sec_cred_get_origin(&cred, &machine),
+			0);
+	assert_string_equal(machine, TEST_HOST);
+	assert_null(drpc_call_msg_ptr);
+
+	D_FREE(machine);
+	daos_iov_free(&cred);
+}
+
 static int
 teardown_tests(void **state)
 {
@@ -2175,6 +2393,8 @@ main(void)
 		ACL_UTEST(test_pool_get_capas_bad_owner),
 		ACL_UTEST(test_pool_get_capas_bad_acl),
 		ACL_UTEST(test_pool_get_capas_validate_cred_failed),
+		ACL_UTEST(test_pool_get_capas_sends_pool),
+		ACL_UTEST(test_pool_check_capas_sends_check_only),
 		ACL_UTEST(test_pool_get_capas_wrong_flavor),
 		ACL_UTEST(test_pool_get_capas_bad_payload),
 		ACL_UTEST(test_pool_get_capas_empty_acl),
@@ -2226,15 +2446,23 @@ main(void)
 		cmocka_unit_test(test_cont_can_evict_all),
 		cmocka_unit_test(test_get_rebuild_cont_capas),
 		cmocka_unit_test(test_get_admin_cont_capas),
+		ACL_UTEST(test_cont_check_scope_null_input),
+		ACL_UTEST(test_cont_check_scope_unrestricted),
+		ACL_UTEST(test_cont_check_scope_delegated),
+		ACL_UTEST(test_cont_check_scope_delegated_by_uuid),
 		ACL_UTEST(test_origin_null_cred),
 		ACL_UTEST(test_origin_null_machine_ptr),
 		ACL_UTEST(test_origin_empty_cred),
 		ACL_UTEST(test_origin_empty_origin),
 		ACL_UTEST(test_origin_long_origin),
 		ACL_UTEST(test_origin_valid_origin),
+		ACL_UTEST(test_origin_not_revalidated),
 
 	};
 
+	uuid_parse(TEST_POOL_UUID, test_pool_uuid);
+	uuid_parse(TEST_CONT_UUID, test_cont_uuid);
+
 	return cmocka_run_group_tests_name("security_srv_acl", tests, NULL,
 					   teardown_tests);
 }